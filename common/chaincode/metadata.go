@@ -12,11 +12,15 @@ import (
 	"github.com/hyperledger/fabric/protos/gossip"
 )
 
-// InstalledChaincode defines metadata about an installed chaincode
+// InstalledChaincode defines metadata about an installed chaincode.
+// Chaincodes installed through the lifecycle system chaincode are
+// identified by their package ID and label rather than name and version
 type InstalledChaincode struct {
-	Name    string
-	Version string
-	Id      []byte
+	Name      string
+	Version   string
+	Id        []byte
+	PackageID string
+	Label     string
 }
 
 // Metadata defines channel-scoped metadata of a chaincode
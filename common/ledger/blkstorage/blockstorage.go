@@ -32,18 +32,36 @@ type IndexConfig struct {
 	AttrsToIndex []IndexableAttr
 }
 
+// Contains returns true iff the supplied parameter is present in the IndexConfig.AttrsToIndex
+func (c *IndexConfig) Contains(indexableAttr IndexableAttr) bool {
+	for _, a := range c.AttrsToIndex {
+		if a == indexableAttr {
+			return true
+		}
+	}
+	return false
+}
+
 var (
 	// ErrNotFoundInIndex is used to indicate missing entry in the index
 	ErrNotFoundInIndex = l.NotFoundInIndexErr("")
 
 	// ErrAttrNotIndexed is used to indicate that an attribute is not indexed
 	ErrAttrNotIndexed = errors.New("attribute not indexed")
+
+	// ErrBlockArchived is used to indicate that the requested block (or transaction) is present in a block file
+	// that has been archived as per the configured retention window and hence cannot be served by the block store
+	ErrBlockArchived = errors.New("the requested block has been archived")
 )
 
 // BlockStoreProvider provides an handle to a BlockStore
 type BlockStoreProvider interface {
 	CreateBlockStore(ledgerid string) (BlockStore, error)
 	OpenBlockStore(ledgerid string) (BlockStore, error)
+	// BootstrapFromSnapshot creates a block store for a ledger from a snapshot. Only the lastBlock and
+	// the lastConfigBlock are available in such a block store. The txids of the ledger before the lastBlock
+	// are loaded from the snapshot so that these can be used for detecting duplicate transactions
+	BootstrapFromSnapshot(ledgerid string, snapshotDir string, lastBlock, lastConfigBlock *common.Block) (BlockStore, error)
 	Exists(ledgerid string) (bool, error)
 	List() ([]string, error)
 	Close()
@@ -62,5 +80,11 @@ type BlockStore interface {
 	RetrieveTxByBlockNumTranNum(blockNum uint64, tranNum uint64) (*common.Envelope, error)
 	RetrieveBlockByTxID(txID string) (*common.Block, error)
 	RetrieveTxValidationCodeByTxID(txID string) (peer.TxValidationCode, error)
+	// TxIDExists returns true if a transaction with the txID is present in the block store.
+	// This includes the txids imported from a snapshot, if the block store was bootstrapped from a snapshot
+	TxIDExists(txID string) (bool, error)
+	// ExportTxIds exports the txids present in the block store into a snapshot file in the given dir
+	// and returns a map that contains the name of the exported file and the hash of its contents
+	ExportTxIds(dir string) (map[string][]byte, error)
 	Shutdown()
 }
@@ -0,0 +1,255 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package fsblkstorage
+
+import (
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/common/ledger/util"
+	"github.com/hyperledger/fabric/protos/common"
+	putil "github.com/hyperledger/fabric/protos/utils"
+	"github.com/pkg/errors"
+)
+
+const archiveInfoFile = "archive.info"
+
+// archiveInfo captures the boundary below which the block files have been archived. The info is persisted
+// before the corresponding block files are moved (or deleted) so that a crash in between can be recovered
+// by archiving the left over files on the next start. The block index continues to hold the entries
+// for the archived blocks (e.g., the txids are still required for detecting the duplicate transactions)
+// and any lookup that resolves to an archived block file results in the error `blkstorage.ErrBlockArchived`
+type archiveInfo struct {
+	firstAvailableFileNum  int
+	firstAvailableBlockNum uint64
+}
+
+func (i *archiveInfo) marshal() ([]byte, error) {
+	buffer := proto.NewBuffer([]byte{})
+	if err := buffer.EncodeVarint(uint64(i.firstAvailableFileNum)); err != nil {
+		return nil, err
+	}
+	if err := buffer.EncodeVarint(i.firstAvailableBlockNum); err != nil {
+		return nil, err
+	}
+	return buffer.Bytes(), nil
+}
+
+func (i *archiveInfo) unmarshal(b []byte) error {
+	buffer := proto.NewBuffer(b)
+	fileNum, err := buffer.DecodeVarint()
+	if err != nil {
+		return err
+	}
+	i.firstAvailableFileNum = int(fileNum)
+	i.firstAvailableBlockNum, err = buffer.DecodeVarint()
+	return err
+}
+
+// HasArchivedBlocks returns true if any of the block files of the given ledger has been archived
+func HasArchivedBlocks(blockStorageDir, ledgerID string) (bool, error) {
+	info, err := loadArchiveInfo(NewConf(blockStorageDir, 0).getLedgerBlockDir(ledgerID))
+	if err != nil {
+		return false, err
+	}
+	return info.firstAvailableFileNum > 0, nil
+}
+
+func (mgr *blockfileMgr) getArchiveInfo() *archiveInfo {
+	return mgr.archiveInfo.Load().(*archiveInfo)
+}
+
+func (mgr *blockfileMgr) isBlockArchived(blockNum uint64) bool {
+	return blockNum < mgr.getArchiveInfo().firstAvailableBlockNum
+}
+
+func (mgr *blockfileMgr) isFileArchived(fileNum int) bool {
+	return fileNum < mgr.getArchiveInfo().firstAvailableFileNum
+}
+
+// archiveBlockFiles archives the block files that contain only the blocks below the retention window.
+// The block file that contains the last config block (as referred by the lastBlock) is never archived,
+// as the last config block is required for initializing the channel on the peer start
+func (mgr *blockfileMgr) archiveBlockFiles(lastBlock *common.Block) error {
+	if !mgr.conf.archivingEnabled() {
+		return nil
+	}
+	blocksToRetain := mgr.conf.archiveConf.BlocksToRetain
+	if lastBlock.Header.Number+1 <= blocksToRetain {
+		return nil
+	}
+	retainFrom := lastBlock.Header.Number + 1 - blocksToRetain
+	lastConfigBlockNum, err := putil.GetLastConfigIndexFromBlock(lastBlock)
+	if err != nil {
+		return errors.WithMessage(err, "error while retrieving the last config block number")
+	}
+	if lastConfigBlockNum < retainFrom {
+		retainFrom = lastConfigBlockNum
+	}
+
+	currentInfo := mgr.getArchiveInfo()
+	newInfo := &archiveInfo{
+		firstAvailableFileNum:  currentInfo.firstAvailableFileNum,
+		firstAvailableBlockNum: currentInfo.firstAvailableBlockNum,
+	}
+	latestFileNum := mgr.cpInfo.latestFileChunkSuffixNum
+	for fileNum := currentInfo.firstAvailableFileNum; fileNum < latestFileNum; fileNum++ {
+		nextFileFirstBlockNum, err := mgr.firstBlockNumFrom(fileNum+1, latestFileNum)
+		if err != nil {
+			return err
+		}
+		if nextFileFirstBlockNum > retainFrom {
+			break
+		}
+		newInfo.firstAvailableFileNum = fileNum + 1
+		newInfo.firstAvailableBlockNum = nextFileFirstBlockNum
+	}
+	if newInfo.firstAvailableFileNum == currentInfo.firstAvailableFileNum {
+		return nil
+	}
+
+	if err := writeArchiveInfo(mgr.rootDir, newInfo); err != nil {
+		return err
+	}
+	mgr.archiveInfo.Store(newInfo)
+	logger.Infof("Archiving the block files [%d] to [%d] that contain the blocks below the block number [%d]",
+		currentInfo.firstAvailableFileNum, newInfo.firstAvailableFileNum-1, newInfo.firstAvailableBlockNum)
+	return mgr.archiveFilesBelow(newInfo.firstAvailableFileNum)
+}
+
+// firstBlockNumFrom returns the number of the first block present in the block files starting from the
+// given fileNum. A block file can be empty if a block was larger than the configured max block file size
+func (mgr *blockfileMgr) firstBlockNumFrom(startFileNum, latestFileNum int) (uint64, error) {
+	for fileNum := startFileNum; fileNum <= latestFileNum; fileNum++ {
+		stream, err := newBlockfileStream(mgr.rootDir, fileNum, 0)
+		if err != nil {
+			return 0, err
+		}
+		blockBytes, err := stream.nextBlockBytes()
+		stream.close()
+		if err != nil {
+			return 0, err
+		}
+		if blockBytes == nil {
+			continue
+		}
+		info, err := extractSerializedBlockInfo(blockBytes)
+		if err != nil {
+			return 0, err
+		}
+		return info.blockHeader.Number, nil
+	}
+	return 0, errors.Errorf("no block found in the block files [%d] to [%d]", startFileNum, latestFileNum)
+}
+
+// archiveFilesBelow moves (or deletes) the block files below the given fileNum that are still present in
+// the block store dir. This is invoked on the peer start as well in order to complete the archiving that
+// may have been interrupted by a crash
+func (mgr *blockfileMgr) archiveFilesBelow(fileNum int) error {
+	moveToArchive := mgr.conf.archiveConf != nil && mgr.conf.archiveConf.ArchiveDir != ""
+	var archiveDir string
+	if moveToArchive {
+		archiveDir = mgr.conf.getLedgerArchiveDir(filepath.Base(mgr.rootDir))
+		if _, err := util.CreateDirIfMissing(archiveDir); err != nil {
+			return errors.Wrapf(err, "error while creating the archive dir [%s]", archiveDir)
+		}
+	}
+	for f := 0; f < fileNum; f++ {
+		filePath := deriveBlockfilePath(mgr.rootDir, f)
+		exists, _, err := util.FileExists(filePath)
+		if err != nil {
+			return err
+		}
+		if !exists {
+			continue
+		}
+		if !moveToArchive {
+			logger.Debugf("Deleting the block file [%s]", filePath)
+			if err := os.Remove(filePath); err != nil {
+				return errors.Wrapf(err, "error while deleting the block file [%s]", filePath)
+			}
+			continue
+		}
+		archivedFilePath := filepath.Join(archiveDir, filepath.Base(filePath))
+		logger.Debugf("Moving the block file [%s] to [%s]", filePath, archivedFilePath)
+		if err := moveFile(filePath, archivedFilePath); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// moveFile renames the file and falls back to copying and removing the file, as the archive dir
+// may be present on a different file system than the block store dir
+func moveFile(src, dest string) error {
+	if err := os.Rename(src, dest); err == nil {
+		return nil
+	}
+	srcFile, err := os.Open(src)
+	if err != nil {
+		return errors.Wrapf(err, "error while opening the file [%s]", src)
+	}
+	defer srcFile.Close()
+	destFile, err := os.Create(dest)
+	if err != nil {
+		return errors.Wrapf(err, "error while creating the file [%s]", dest)
+	}
+	if _, err := io.Copy(destFile, srcFile); err != nil {
+		destFile.Close()
+		return errors.Wrapf(err, "error while copying the file [%s] to [%s]", src, dest)
+	}
+	if err := destFile.Sync(); err != nil {
+		destFile.Close()
+		return errors.Wrapf(err, "error while syncing the file [%s]", dest)
+	}
+	if err := destFile.Close(); err != nil {
+		return errors.Wrapf(err, "error while closing the file [%s]", dest)
+	}
+	if err := os.Remove(src); err != nil {
+		return errors.Wrapf(err, "error while removing the file [%s]", src)
+	}
+	return nil
+}
+
+// writeArchiveInfo writes the info into a temporary file first and then renames
+// the temporary file so that a crash does not leave a partially written info file
+func writeArchiveInfo(ledgerDir string, info *archiveInfo) error {
+	b, err := info.marshal()
+	if err != nil {
+		return err
+	}
+	filePath := filepath.Join(ledgerDir, archiveInfoFile)
+	tmpFilePath := filePath + ".tmp"
+	if err := ioutil.WriteFile(tmpFilePath, b, 0644); err != nil {
+		return errors.Wrapf(err, "error while writing the file [%s]", tmpFilePath)
+	}
+	if err := os.Rename(tmpFilePath, filePath); err != nil {
+		return errors.Wrapf(err, "error while renaming the file [%s] to [%s]", tmpFilePath, filePath)
+	}
+	return nil
+}
+
+// loadArchiveInfo loads the archive info from the given ledger dir. An empty info is returned
+// if none of the block files has been archived
+func loadArchiveInfo(ledgerDir string) (*archiveInfo, error) {
+	filePath := filepath.Join(ledgerDir, archiveInfoFile)
+	b, err := ioutil.ReadFile(filePath)
+	if os.IsNotExist(err) {
+		return &archiveInfo{}, nil
+	}
+	if err != nil {
+		return nil, errors.Wrapf(err, "error while reading the file [%s]", filePath)
+	}
+	info := &archiveInfo{}
+	if err := info.unmarshal(b); err != nil {
+		return nil, errors.Wrapf(err, "error while unmarshaling the contents of the file [%s]", filePath)
+	}
+	return info, nil
+}
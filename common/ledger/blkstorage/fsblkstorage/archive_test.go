@@ -0,0 +1,199 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package fsblkstorage
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/common/ledger/blkstorage"
+	"github.com/hyperledger/fabric/common/ledger/testutil"
+	"github.com/hyperledger/fabric/common/util"
+	"github.com/hyperledger/fabric/protos/common"
+	putil "github.com/hyperledger/fabric/protos/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestArchiveBlockFiles(t *testing.T) {
+	for _, moveToArchive := range []bool{true, false} {
+		archiveDir, err := ioutil.TempDir("", "fsblkstorage-archive")
+		assert.NoError(t, err)
+		defer os.RemoveAll(archiveDir)
+		archiveConf := &ArchiveConf{BlocksToRetain: 3}
+		if moveToArchive {
+			archiveConf.ArchiveDir = archiveDir
+		}
+
+		blocks := constructTestBlocksWithLastConfig(t, 10, -1)
+		conf := NewConfWithArchiveConf(testPath(), maxSerializedBlockSize(t, blocks), archiveConf)
+		env := newTestEnv(t, conf)
+		defer env.Cleanup()
+		store, err := env.provider.OpenBlockStore("ledger1")
+		assert.NoError(t, err)
+		for _, b := range blocks {
+			assert.NoError(t, store.AddBlock(b))
+		}
+
+		// every block is stored in a separate block file and the files that contain
+		// the blocks [0-6] fall below the retention window of the last three blocks
+		verifyArchivedStore := func(store blkstorage.BlockStore) {
+			for blockNum := uint64(0); blockNum < 7; blockNum++ {
+				_, err := store.RetrieveBlockByNumber(blockNum)
+				assert.Equal(t, blkstorage.ErrBlockArchived, err)
+				_, err = store.RetrieveBlockByHash(blocks[blockNum].Header.Hash())
+				assert.Equal(t, blkstorage.ErrBlockArchived, err)
+				for _, txID := range txIDsOfBlock(t, blocks[blockNum]) {
+					exists, err := store.TxIDExists(txID)
+					assert.NoError(t, err)
+					assert.True(t, exists)
+					_, err = store.RetrieveTxByID(txID)
+					assert.Equal(t, blkstorage.ErrBlockArchived, err)
+				}
+			}
+			_, err := store.RetrieveBlocks(6)
+			assert.Equal(t, blkstorage.ErrBlockArchived, err)
+
+			for blockNum := uint64(7); blockNum < 10; blockNum++ {
+				block, err := store.RetrieveBlockByNumber(blockNum)
+				assert.NoError(t, err)
+				assert.True(t, proto.Equal(blocks[blockNum], block))
+			}
+			itr, err := store.RetrieveBlocks(7)
+			assert.NoError(t, err)
+			defer itr.Close()
+			for _, expectedBlock := range blocks[7:] {
+				block, err := itr.Next()
+				assert.NoError(t, err)
+				assert.True(t, proto.Equal(expectedBlock, block.(*common.Block)))
+			}
+
+			ledgerDir := conf.getLedgerBlockDir("ledger1")
+			for fileNum := 0; fileNum < 10; fileNum++ {
+				fileName := filepath.Base(deriveBlockfilePath(ledgerDir, fileNum))
+				_, err := os.Stat(filepath.Join(ledgerDir, fileName))
+				assert.Equal(t, fileNum < 7, os.IsNotExist(err))
+				_, err = os.Stat(filepath.Join(archiveDir, "ledger1", fileName))
+				assert.Equal(t, moveToArchive && fileNum < 7, err == nil)
+			}
+		}
+		verifyArchivedStore(store)
+
+		// the archived store survives a restart
+		store.Shutdown()
+		env.provider.Close()
+		env.provider = NewProvider(conf, env.provider.indexConfig).(*FsBlockstoreProvider)
+		store, err = env.provider.OpenBlockStore("ledger1")
+		assert.NoError(t, err)
+		verifyArchivedStore(store)
+		store.Shutdown()
+		env.provider.Close()
+
+		hasArchivedBlocks, err := HasArchivedBlocks(conf.blockStorageDir, "ledger1")
+		assert.NoError(t, err)
+		assert.True(t, hasArchivedBlocks)
+		assert.EqualError(t, ValidateRollbackParams(conf.blockStorageDir, "ledger1", 6),
+			"target block number [6] has been archived. First available block = [7]")
+		assert.NoError(t, ValidateRollbackParams(conf.blockStorageDir, "ledger1", 7))
+	}
+}
+
+func TestArchiveBlockFilesRetainsLastConfigBlock(t *testing.T) {
+	blocks := constructTestBlocksWithLastConfig(t, 10, 2)
+	conf := NewConfWithArchiveConf(testPath(), maxSerializedBlockSize(t, blocks), &ArchiveConf{BlocksToRetain: 1})
+	env := newTestEnv(t, conf)
+	defer env.Cleanup()
+	store, err := env.provider.OpenBlockStore("ledger1")
+	assert.NoError(t, err)
+	defer store.Shutdown()
+	for _, b := range blocks {
+		assert.NoError(t, store.AddBlock(b))
+	}
+
+	_, err = store.RetrieveBlockByNumber(1)
+	assert.Equal(t, blkstorage.ErrBlockArchived, err)
+	for _, blockNum := range []uint64{2, 5, 9} {
+		block, err := store.RetrieveBlockByNumber(blockNum)
+		assert.NoError(t, err)
+		assert.True(t, proto.Equal(blocks[blockNum], block))
+	}
+}
+
+func TestArchiveBlockFilesCompletedOnRestart(t *testing.T) {
+	blocks := constructTestBlocksWithLastConfig(t, 5, -1)
+	conf := NewConfWithArchiveConf(testPath(), maxSerializedBlockSize(t, blocks), &ArchiveConf{BlocksToRetain: 10})
+	env := newTestEnv(t, conf)
+	defer env.Cleanup()
+	store, err := env.provider.OpenBlockStore("ledger1")
+	assert.NoError(t, err)
+	for _, b := range blocks {
+		assert.NoError(t, store.AddBlock(b))
+	}
+	store.Shutdown()
+	hasArchivedBlocks, err := HasArchivedBlocks(conf.blockStorageDir, "ledger1")
+	assert.NoError(t, err)
+	assert.False(t, hasArchivedBlocks)
+
+	// simulate a crash after the archive info is persisted but before the block files are deleted
+	ledgerDir := conf.getLedgerBlockDir("ledger1")
+	assert.NoError(t, writeArchiveInfo(ledgerDir, &archiveInfo{firstAvailableFileNum: 2, firstAvailableBlockNum: 2}))
+	store, err = env.provider.OpenBlockStore("ledger1")
+	assert.NoError(t, err)
+	defer store.Shutdown()
+	for fileNum := 0; fileNum < 5; fileNum++ {
+		_, err := os.Stat(deriveBlockfilePath(ledgerDir, fileNum))
+		assert.Equal(t, fileNum < 2, os.IsNotExist(err))
+	}
+	_, err = store.RetrieveBlockByNumber(1)
+	assert.Equal(t, blkstorage.ErrBlockArchived, err)
+	_, err = store.RetrieveBlockByNumber(2)
+	assert.NoError(t, err)
+}
+
+func TestArchiveInfoMarshaling(t *testing.T) {
+	info := &archiveInfo{firstAvailableFileNum: 5, firstAvailableBlockNum: 100}
+	b, err := info.marshal()
+	assert.NoError(t, err)
+	unmarshaledInfo := &archiveInfo{}
+	assert.NoError(t, unmarshaledInfo.unmarshal(b))
+	assert.Equal(t, info, unmarshaledInfo)
+}
+
+// constructTestBlocksWithLastConfig constructs the test blocks of a similar size that refer to the given block as
+// the last config block. A negative lastConfigBlockNum causes each block to refer to itself as the last config block
+func constructTestBlocksWithLastConfig(t *testing.T, numBlocks int, lastConfigBlockNum int) []*common.Block {
+	bg, gb := testutil.NewBlockGenerator(t, util.GetTestChainID(), false)
+	blocks := []*common.Block{gb}
+	for len(blocks) < numBlocks {
+		blocks = append(blocks, bg.NextTestBlock(10, 1000))
+	}
+	for _, block := range blocks {
+		index := uint64(lastConfigBlockNum)
+		if lastConfigBlockNum < 0 || index > block.Header.Number {
+			index = block.Header.Number
+		}
+		block.Metadata.Metadata[common.BlockMetadataIndex_LAST_CONFIG] = putil.MarshalOrPanic(&common.Metadata{
+			Value: putil.MarshalOrPanic(&common.LastConfig{Index: index}),
+		})
+	}
+	return blocks
+}
+
+// maxSerializedBlockSize returns a block file size such that each of the given blocks is stored in a separate file
+func maxSerializedBlockSize(t *testing.T, blocks []*common.Block) int {
+	maxSize := 0
+	for _, block := range blocks {
+		blockBytes, _, err := serializeBlock(block)
+		assert.NoError(t, err)
+		if size := len(blockBytes) + len(proto.EncodeVarint(uint64(len(blockBytes)))); size > maxSize {
+			maxSize = size
+		}
+	}
+	return maxSize
+}
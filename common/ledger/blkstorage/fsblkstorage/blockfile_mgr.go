@@ -36,14 +36,17 @@ var (
 )
 
 type blockfileMgr struct {
-	rootDir           string
-	conf              *Conf
-	db                *leveldbhelper.DBHandle
-	index             index
-	cpInfo            *checkpointInfo
-	cpInfoCond        *sync.Cond
-	currentFileWriter *blockfileWriter
-	bcInfo            atomic.Value
+	rootDir                   string
+	conf                      *Conf
+	db                        *leveldbhelper.DBHandle
+	indexConfig               *blkstorage.IndexConfig
+	index                     index
+	bootstrappingSnapshotInfo *bootstrappingSnapshotInfo
+	archiveInfo               atomic.Value
+	cpInfo                    *checkpointInfo
+	cpInfoCond                *sync.Cond
+	currentFileWriter         *blockfileWriter
+	bcInfo                    atomic.Value
 }
 
 /*
@@ -96,7 +99,24 @@ func newBlockfileMgr(id string, conf *Conf, indexConfig *blkstorage.IndexConfig,
 		panic(fmt.Sprintf("Error creating block storage root dir [%s]: %s", rootDir, err))
 	}
 	// Instantiate the manager, i.e. blockFileMgr structure
-	mgr := &blockfileMgr{rootDir: rootDir, conf: conf, db: indexStore}
+	mgr := &blockfileMgr{rootDir: rootDir, conf: conf, db: indexStore, indexConfig: indexConfig}
+
+	// Load the information about the snapshot, if the block store was bootstrapped from a snapshot
+	if mgr.bootstrappingSnapshotInfo, err = loadBootstrappingSnapshotInfo(rootDir); err != nil {
+		panic(fmt.Sprintf("Could not load the bootstrapping snapshot info: %s", err))
+	}
+
+	// Load the information about the archived block files and complete the archiving, if it was interrupted by a crash
+	archiveInfo, err := loadArchiveInfo(rootDir)
+	if err != nil {
+		panic(fmt.Sprintf("Could not load the archive info: %s", err))
+	}
+	mgr.archiveInfo.Store(archiveInfo)
+	if archiveInfo.firstAvailableFileNum > 0 && conf.archiveConf != nil {
+		if err := mgr.archiveFilesBelow(archiveInfo.firstAvailableFileNum); err != nil {
+			panic(fmt.Sprintf("Could not archive the block files: %s", err))
+		}
+	}
 
 	// cp = checkpointInfo, retrieve from the database the file suffix or number of where blocks were stored.
 	// It also retrieves the current size of that file and the last block number that was written to that file.
@@ -150,6 +170,15 @@ func newBlockfileMgr(id string, conf *Conf, indexConfig *blkstorage.IndexConfig,
 		CurrentBlockHash:  nil,
 		PreviousBlockHash: nil}
 
+	if cpInfo.isChainEmpty && mgr.bootstrappingSnapshotInfo != nil {
+		// The block store is bootstrapped from a snapshot and the last block of the snapshot
+		// is yet to be added. Set the blockchain info such that the last block can be added
+		bcInfo = &common.BlockchainInfo{
+			Height:           mgr.bootstrappingSnapshotInfo.lastBlockNum,
+			CurrentBlockHash: mgr.bootstrappingSnapshotInfo.lastBlockPreviousHash,
+		}
+	}
+
 	if !cpInfo.isChainEmpty {
 		//If start up is a restart of an existing storage, sync the index from block storage and update BlockchainInfo for external API's
 		mgr.syncIndex()
@@ -189,7 +218,7 @@ func syncCPInfoFromFS(rootDir string, cpInfo *checkpointInfo) {
 		return
 	}
 	//Scan the file system to verify that the checkpoint info stored in db is correct
-	_, endOffsetLastBlock, numBlocks, err := scanForLastCompleteBlock(
+	lastBlockBytes, endOffsetLastBlock, numBlocks, err := scanForLastCompleteBlock(
 		rootDir, cpInfo.latestFileChunkSuffixNum, int64(cpInfo.latestFileChunksize))
 	if err != nil {
 		panic(fmt.Sprintf("Could not open current file for detecting last block in the file: %s", err))
@@ -200,7 +229,12 @@ func syncCPInfoFromFS(rootDir string, cpInfo *checkpointInfo) {
 	}
 	//Updates the checkpoint info for the actual last block number stored and it's end location
 	if cpInfo.isChainEmpty {
-		cpInfo.lastBlockNumber = uint64(numBlocks - 1)
+		// the first block may not be the genesis block, if the block store was bootstrapped from a snapshot
+		info, err := extractSerializedBlockInfo(lastBlockBytes)
+		if err != nil {
+			panic(fmt.Sprintf("Could not extract the info of the last block in the current file: %s", err))
+		}
+		cpInfo.lastBlockNumber = info.blockHeader.Number
 	} else {
 		cpInfo.lastBlockNumber += uint64(numBlocks)
 	}
@@ -271,9 +305,11 @@ func (mgr *blockfileMgr) addBlock(block *common.Block) error {
 
 	//Determine if we need to start a new file since the size of this block
 	//exceeds the amount of space left in the current file
+	movedToNextFile := false
 	if currentOffset+totalBytesToAppend > mgr.conf.maxBlockfileSize {
 		mgr.moveToNextFile()
 		currentOffset = 0
+		movedToNextFile = true
 	}
 	//append blockBytesEncodedLen to the file
 	err = mgr.currentFileWriter.append(blockBytesEncodedLen, false)
@@ -322,6 +358,15 @@ func (mgr *blockfileMgr) addBlock(block *common.Block) error {
 	//update the checkpoint info (for storage) and the blockchain info (for APIs) in the manager
 	mgr.updateCheckpoint(newCPInfo)
 	mgr.updateBlockchainInfo(blockHash, block)
+
+	// The block files are archived only when a new block file is started, as a block file becomes
+	// a candidate for archiving only after all the blocks in the file fall below the retention window.
+	// The block is already committed and hence, a failure in archiving is not returned as an error
+	if movedToNextFile {
+		if err := mgr.archiveBlockFiles(block); err != nil {
+			logger.Errorf("Error while archiving the block files: %s", err)
+		}
+	}
 	return nil
 }
 
@@ -362,6 +407,12 @@ func (mgr *blockfileMgr) syncIndex() error {
 		startingBlockNum = lastBlockIndexed + 1
 	} else {
 		logger.Debugf("No block indexed, Last block present in block files=[%d]", mgr.cpInfo.lastBlockNumber)
+		if archiveInfo := mgr.getArchiveInfo(); archiveInfo.firstAvailableFileNum > 0 {
+			logger.Warningf("The blocks below the block number [%d] have been archived and will not be indexed",
+				archiveInfo.firstAvailableBlockNum)
+			startFileNum = archiveInfo.firstAvailableFileNum
+			startingBlockNum = archiveInfo.firstAvailableBlockNum
+		}
 	}
 
 	logger.Infof("Start building index from block [%d] to last block [%d]", startingBlockNum, mgr.cpInfo.lastBlockNumber)
@@ -465,6 +516,13 @@ func (mgr *blockfileMgr) retrieveBlockByNumber(blockNum uint64) (*common.Block,
 	if blockNum == math.MaxUint64 {
 		blockNum = mgr.getBlockchainInfo().Height - 1
 	}
+	if info := mgr.bootstrappingSnapshotInfo; info != nil && info.lastConfigBlock != nil &&
+		blockNum == info.lastConfigBlock.Header.Number {
+		return info.lastConfigBlock, nil
+	}
+	if mgr.isBlockArchived(blockNum) {
+		return nil, blkstorage.ErrBlockArchived
+	}
 
 	loc, err := mgr.index.getBlockLocByBlockNum(blockNum)
 	if err != nil {
@@ -507,9 +565,22 @@ func (mgr *blockfileMgr) retrieveBlockHeaderByNumber(blockNum uint64) (*common.B
 }
 
 func (mgr *blockfileMgr) retrieveBlocks(startNum uint64) (*blocksItr, error) {
+	if info := mgr.bootstrappingSnapshotInfo; info != nil && startNum < info.lastBlockNum {
+		return nil, errors.Errorf(
+			"cannot serve block [%d]. The ledger is bootstrapped from a snapshot. First available block = [%d]",
+			startNum, info.lastBlockNum,
+		)
+	}
+	if mgr.isBlockArchived(startNum) {
+		return nil, blkstorage.ErrBlockArchived
+	}
 	return newBlockItr(mgr, startNum), nil
 }
 
+func (mgr *blockfileMgr) txIDExists(txID string) (bool, error) {
+	return mgr.index.txIDExists(txID)
+}
+
 func (mgr *blockfileMgr) retrieveTransactionByID(txID string) (*common.Envelope, error) {
 	logger.Debugf("retrieveTransactionByID() - txId = [%s]", txID)
 	loc, err := mgr.index.getTxLoc(txID)
@@ -552,8 +623,15 @@ func (mgr *blockfileMgr) fetchTransactionEnvelope(lp *fileLocPointer) (*common.E
 }
 
 func (mgr *blockfileMgr) fetchBlockBytes(lp *fileLocPointer) ([]byte, error) {
+	if mgr.isFileArchived(lp.fileSuffixNum) {
+		return nil, blkstorage.ErrBlockArchived
+	}
 	stream, err := newBlockfileStream(mgr.rootDir, lp.fileSuffixNum, int64(lp.offset))
 	if err != nil {
+		// the file may have been archived after the check above
+		if mgr.isFileArchived(lp.fileSuffixNum) {
+			return nil, blkstorage.ErrBlockArchived
+		}
 		return nil, err
 	}
 	defer stream.close()
@@ -565,9 +643,16 @@ func (mgr *blockfileMgr) fetchBlockBytes(lp *fileLocPointer) ([]byte, error) {
 }
 
 func (mgr *blockfileMgr) fetchRawBytes(lp *fileLocPointer) ([]byte, error) {
+	if mgr.isFileArchived(lp.fileSuffixNum) {
+		return nil, blkstorage.ErrBlockArchived
+	}
 	filePath := deriveBlockfilePath(mgr.rootDir, lp.fileSuffixNum)
 	reader, err := newBlockfileReader(filePath)
 	if err != nil {
+		// the file may have been archived after the check above
+		if mgr.isFileArchived(lp.fileSuffixNum) {
+			return nil, blkstorage.ErrBlockArchived
+		}
 		return nil, err
 	}
 	defer reader.close()
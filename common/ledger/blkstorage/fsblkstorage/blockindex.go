@@ -33,12 +33,18 @@ const (
 var indexCheckpointKey = []byte(indexCheckpointKeyStr)
 var errIndexEmpty = errors.New("NoBlockIndexed")
 
+// txIDFromSnapshotMarker is the value of the txid-index entry for a txid that is imported from a snapshot.
+// Such an entry only records the existence of the txid, as the corresponding block is not present in the
+// block files. Note that the value of a regular entry (a marshaled fileLocPointer) is at least three bytes long
+var txIDFromSnapshotMarker = []byte{0x00}
+
 type index interface {
 	getLastBlockIndexed() (uint64, error)
 	indexBlock(blockIdxInfo *blockIdxInfo) error
 	getBlockLocByHash(blockHash []byte) (*fileLocPointer, error)
 	getBlockLocByBlockNum(blockNum uint64) (*fileLocPointer, error)
 	getTxLoc(txID string) (*fileLocPointer, error)
+	txIDExists(txID string) (bool, error)
 	getTXLocByBlockNumTranNum(blockNum uint64, tranNum uint64) (*fileLocPointer, error)
 	getBlockLocByTxID(txID string) (*fileLocPointer, error)
 	getTxValidationCodeByTxID(txID string) (peer.TxValidationCode, error)
@@ -184,14 +190,14 @@ func (index *blockIndex) markDuplicateTxids(blockIdxInfo *blockIdxInfo) error {
 			continue
 		}
 
-		loc, err := index.getTxLoc(txid)
-		if loc != nil { // txid is duplicate of a previous tx in the index
+		exists, err := index.txIDExists(txid)
+		if err != nil {
+			return err
+		}
+		if exists { // txid is duplicate of a previous tx in the index
 			txIdxInfo.isDuplicate = true
 			continue
 		}
-		if err != blkstorage.ErrNotFoundInIndex {
-			return err
-		}
 		uniqueTxids[txid] = true
 	}
 	return nil
@@ -237,7 +243,7 @@ func (index *blockIndex) getTxLoc(txID string) (*fileLocPointer, error) {
 	if err != nil {
 		return nil, err
 	}
-	if b == nil {
+	if b == nil || bytes.Equal(b, txIDFromSnapshotMarker) {
 		return nil, blkstorage.ErrNotFoundInIndex
 	}
 	txFLP := &fileLocPointer{}
@@ -245,6 +251,17 @@ func (index *blockIndex) getTxLoc(txID string) (*fileLocPointer, error) {
 	return txFLP, nil
 }
 
+func (index *blockIndex) txIDExists(txID string) (bool, error) {
+	if _, ok := index.indexItemsMap[blkstorage.IndexableAttrTxID]; !ok {
+		return false, blkstorage.ErrAttrNotIndexed
+	}
+	b, err := index.db.Get(constructTxIDKey(txID))
+	if err != nil {
+		return false, err
+	}
+	return b != nil, nil
+}
+
 func (index *blockIndex) getBlockLocByTxID(txID string) (*fileLocPointer, error) {
 	if _, ok := index.indexItemsMap[blkstorage.IndexableAttrBlockTxID]; !ok {
 		return nil, blkstorage.ErrAttrNotIndexed
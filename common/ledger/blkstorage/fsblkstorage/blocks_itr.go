@@ -20,6 +20,7 @@ import (
 	"sync"
 
 	"github.com/hyperledger/fabric/common/ledger"
+	"github.com/hyperledger/fabric/common/ledger/blkstorage"
 )
 
 // blocksItr - an iterator for iterating over a sequence of blocks
@@ -53,6 +54,9 @@ func (itr *blocksItr) waitForBlock(blockNum uint64) uint64 {
 func (itr *blocksItr) initStream() error {
 	var lp *fileLocPointer
 	var err error
+	if itr.mgr.isBlockArchived(itr.blockNumToRetrieve) {
+		return blkstorage.ErrBlockArchived
+	}
 	if lp, err = itr.mgr.index.getBlockLocByBlockNum(itr.blockNumToRetrieve); err != nil {
 		return err
 	}
@@ -86,6 +90,10 @@ func (itr *blocksItr) Next() (ledger.QueryResult, error) {
 	}
 	nextBlockBytes, err := itr.stream.nextBlockBytes()
 	if err != nil {
+		// a slow iterator may fall behind the retention window and find the next block file archived
+		if itr.mgr.isBlockArchived(itr.blockNumToRetrieve) {
+			return nil, blkstorage.ErrBlockArchived
+		}
 		return nil, err
 	}
 	itr.blockNumToRetrieve++
@@ -30,15 +30,41 @@ const (
 type Conf struct {
 	blockStorageDir  string
 	maxBlockfileSize int
+	archiveConf      *ArchiveConf
+}
+
+// ArchiveConf encapsulates the configurations for archiving the block files that contain only
+// the blocks below the retention window
+type ArchiveConf struct {
+	// BlocksToRetain is the number of most recent blocks that are retained in the block files.
+	// A value of zero disables archiving
+	BlocksToRetain uint64
+	// ArchiveDir is the top level folder into which the archived block files are moved.
+	// An empty value causes the archived block files to be deleted
+	ArchiveDir string
 }
 
 // NewConf constructs new `Conf`.
 // blockStorageDir is the top level folder under which `FsBlockStore` manages its data
 func NewConf(blockStorageDir string, maxBlockfileSize int) *Conf {
+	return NewConfWithArchiveConf(blockStorageDir, maxBlockfileSize, nil)
+}
+
+// NewConfWithArchiveConf constructs new `Conf` that enables archiving of the block files as per the given archiveConf.
+// A nil archiveConf disables archiving
+func NewConfWithArchiveConf(blockStorageDir string, maxBlockfileSize int, archiveConf *ArchiveConf) *Conf {
 	if maxBlockfileSize <= 0 {
 		maxBlockfileSize = defaultMaxBlockfileSize
 	}
-	return &Conf{blockStorageDir, maxBlockfileSize}
+	return &Conf{blockStorageDir, maxBlockfileSize, archiveConf}
+}
+
+func (conf *Conf) archivingEnabled() bool {
+	return conf.archiveConf != nil && conf.archiveConf.BlocksToRetain > 0
+}
+
+func (conf *Conf) getLedgerArchiveDir(ledgerid string) string {
+	return filepath.Join(conf.archiveConf.ArchiveDir, ledgerid)
 }
 
 func (conf *Conf) getIndexDir() string {
@@ -0,0 +1,229 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package fsblkstorage
+
+import (
+	"os"
+
+	"github.com/hyperledger/fabric/common/ledger/blkstorage"
+	"github.com/hyperledger/fabric/common/ledger/util"
+	"github.com/hyperledger/fabric/common/ledger/util/leveldbhelper"
+	"github.com/pkg/errors"
+)
+
+type rollbackMgr struct {
+	ledgerID       string
+	ledgerDir      string
+	dbProvider     *leveldbhelper.Provider
+	indexStore     *blockIndex
+	targetBlockNum uint64
+}
+
+// Rollback reverts changes made to the block store beyond a given block number.
+// The rollback is performed in the following order so that an interrupted rollback
+// can be completed by invoking this function again with the same arguments
+// (1) the index entries of the blocks beyond the target block are removed
+// (2) the checkpoint info is updated to point to the end of the target block
+// (3) the block files are truncated such that the target block is the last block
+func Rollback(blockStorageDir, ledgerID string, targetBlockNum uint64, indexConfig *blkstorage.IndexConfig) error {
+	r, err := newRollbackMgr(blockStorageDir, ledgerID, indexConfig, targetBlockNum)
+	if err != nil {
+		return err
+	}
+	defer r.dbProvider.Close()
+
+	targetLoc, targetEndOffset, err := r.locateTargetBlock()
+	if err != nil {
+		return err
+	}
+	lastFileNum, err := retrieveLastFileSuffix(r.ledgerDir)
+	if err != nil {
+		return err
+	}
+
+	logger.Infof("Rolling back block index of ledger [%s] to block number [%d]", r.ledgerID, r.targetBlockNum)
+	if err := r.rollbackBlockIndex(targetLoc.fileSuffixNum, targetEndOffset, lastFileNum); err != nil {
+		return err
+	}
+
+	logger.Infof("Rolling back block files of ledger [%s] to block number [%d]", r.ledgerID, r.targetBlockNum)
+	if err := r.rollbackBlockFiles(targetLoc.fileSuffixNum, targetEndOffset, lastFileNum); err != nil {
+		return err
+	}
+	return nil
+}
+
+// ValidateRollbackParams performs necessary validation on the input given for
+// the rollback operation. The target block number is allowed to be equal to the
+// last block number in the store so that an interrupted rollback can be re-invoked
+func ValidateRollbackParams(blockStorageDir, ledgerID string, targetBlockNum uint64) error {
+	logger.Infof("Validating the rollback parameters: ledgerID [%s], block number [%d]",
+		ledgerID, targetBlockNum)
+	conf := NewConf(blockStorageDir, 0)
+	if err := validateLedgerID(conf.getLedgerBlockDir(ledgerID), ledgerID); err != nil {
+		return err
+	}
+
+	dbProvider := leveldbhelper.NewProvider(&leveldbhelper.Conf{DBPath: conf.getIndexDir()})
+	defer dbProvider.Close()
+	mgr := &blockfileMgr{db: dbProvider.GetDBHandle(ledgerID)}
+	cpInfo, err := mgr.loadCurrentInfo()
+	if err != nil {
+		return err
+	}
+	if cpInfo == nil || cpInfo.isChainEmpty {
+		return errors.Errorf("ledger [%s] does not contain any block", ledgerID)
+	}
+	if targetBlockNum > cpInfo.lastBlockNumber {
+		return errors.Errorf("target block number [%d] should be less than the biggest block number [%d]",
+			targetBlockNum, cpInfo.lastBlockNumber)
+	}
+	archiveInfo, err := loadArchiveInfo(conf.getLedgerBlockDir(ledgerID))
+	if err != nil {
+		return err
+	}
+	if targetBlockNum < archiveInfo.firstAvailableBlockNum {
+		return errors.Errorf("target block number [%d] has been archived. First available block = [%d]",
+			targetBlockNum, archiveInfo.firstAvailableBlockNum)
+	}
+	return nil
+}
+
+func validateLedgerID(ledgerDir, ledgerID string) error {
+	logger.Debugf("Validating the existence of ledgerID [%s]", ledgerID)
+	exists, _, err := util.FileExists(ledgerDir)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return errors.Errorf("ledgerID [%s] does not exist", ledgerID)
+	}
+	return nil
+}
+
+func newRollbackMgr(blockStorageDir, ledgerID string, indexConfig *blkstorage.IndexConfig, targetBlockNum uint64) (*rollbackMgr, error) {
+	r := &rollbackMgr{ledgerID: ledgerID, targetBlockNum: targetBlockNum}
+	conf := NewConf(blockStorageDir, 0)
+	r.ledgerDir = conf.getLedgerBlockDir(ledgerID)
+	r.dbProvider = leveldbhelper.NewProvider(&leveldbhelper.Conf{DBPath: conf.getIndexDir()})
+	indexStore, err := newBlockIndex(indexConfig, r.dbProvider.GetDBHandle(ledgerID))
+	if err != nil {
+		r.dbProvider.Close()
+		return nil, err
+	}
+	r.indexStore = indexStore
+	return r, nil
+}
+
+// locateTargetBlock returns the location of the target block along with the
+// offset in the block file at which the target block ends
+func (r *rollbackMgr) locateTargetBlock() (*fileLocPointer, int64, error) {
+	targetLoc, err := r.indexStore.getBlockLocByBlockNum(r.targetBlockNum)
+	if err != nil {
+		return nil, 0, errors.WithMessage(err, "error while locating the target block in the block index")
+	}
+	stream, err := newBlockfileStream(r.ledgerDir, targetLoc.fileSuffixNum, int64(targetLoc.offset))
+	if err != nil {
+		return nil, 0, err
+	}
+	defer stream.close()
+	blockBytes, err := stream.nextBlockBytes()
+	if err != nil {
+		return nil, 0, err
+	}
+	if blockBytes == nil {
+		return nil, 0, errors.Errorf("target block [%d] is not found in the block file [%d]",
+			r.targetBlockNum, targetLoc.fileSuffixNum)
+	}
+	return targetLoc, stream.currentOffset, nil
+}
+
+// rollbackBlockIndex removes the index entries of all the blocks that are present
+// in the block files beyond the target block
+func (r *rollbackMgr) rollbackBlockIndex(targetFileNum int, targetEndOffset int64, lastFileNum int) error {
+	if err := r.indexStore.db.Put(indexCheckpointKey, encodeBlockNum(r.targetBlockNum), true); err != nil {
+		return err
+	}
+
+	stream, err := newBlockStream(r.ledgerDir, targetFileNum, targetEndOffset, lastFileNum)
+	if err != nil {
+		return err
+	}
+	defer stream.close()
+
+	isRolledBack := func(loc *fileLocPointer) bool {
+		return loc.fileSuffixNum > targetFileNum ||
+			(loc.fileSuffixNum == targetFileNum && int64(loc.offset) >= targetEndOffset)
+	}
+
+	for {
+		blockBytes, _, err := stream.nextBlockBytesAndPlacementInfo()
+		if err != nil {
+			return err
+		}
+		if blockBytes == nil {
+			break
+		}
+		info, err := extractSerializedBlockInfo(blockBytes)
+		if err != nil {
+			return err
+		}
+		blockNum := info.blockHeader.Number
+		batch := leveldbhelper.NewUpdateBatch()
+		batch.Delete(constructBlockNumKey(blockNum))
+		batch.Delete(constructBlockHashKey(info.blockHeader.Hash()))
+		for txNum, txOffset := range info.txOffsets {
+			batch.Delete(constructBlockNumTranNumKey(blockNum, uint64(txNum)))
+			txLoc, err := r.indexStore.getTxLoc(txOffset.txID)
+			if err == blkstorage.ErrNotFoundInIndex || err == blkstorage.ErrAttrNotIndexed {
+				continue
+			}
+			if err != nil {
+				return err
+			}
+			// a txid that is a duplicate of a txid in a retained block is not removed - FAB-8557
+			if !isRolledBack(txLoc) {
+				continue
+			}
+			batch.Delete(constructTxIDKey(txOffset.txID))
+			batch.Delete(constructBlockTxIDKey(txOffset.txID))
+			batch.Delete(constructTxValidationCodeIDKey(txOffset.txID))
+		}
+		if err := r.indexStore.db.WriteBatch(batch, true); err != nil {
+			return err
+		}
+		logger.Debugf("Removed the index entries of block [%d]", blockNum)
+	}
+	return nil
+}
+
+// rollbackBlockFiles updates the checkpoint info to point to the target block and then
+// truncates the file containing the target block and removes all the subsequent files
+func (r *rollbackMgr) rollbackBlockFiles(targetFileNum int, targetEndOffset int64, lastFileNum int) error {
+	mgr := &blockfileMgr{db: r.indexStore.db}
+	cpInfo := &checkpointInfo{
+		latestFileChunkSuffixNum: targetFileNum,
+		latestFileChunksize:      int(targetEndOffset),
+		isChainEmpty:             false,
+		lastBlockNumber:          r.targetBlockNum,
+	}
+	if err := mgr.saveCurrentInfo(cpInfo, true); err != nil {
+		return err
+	}
+
+	for fileNum := lastFileNum; fileNum > targetFileNum; fileNum-- {
+		filePath := deriveBlockfilePath(r.ledgerDir, fileNum)
+		if err := os.Remove(filePath); err != nil && !os.IsNotExist(err) {
+			return errors.Wrapf(err, "error removing the block file [%s]", filePath)
+		}
+	}
+	filePath := deriveBlockfilePath(r.ledgerDir, targetFileNum)
+	if err := os.Truncate(filePath, targetEndOffset); err != nil {
+		return errors.Wrapf(err, "error truncating the block file [%s]", filePath)
+	}
+	return nil
+}
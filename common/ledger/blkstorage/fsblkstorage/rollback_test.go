@@ -0,0 +1,96 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package fsblkstorage
+
+import (
+	"testing"
+
+	"github.com/hyperledger/fabric/common/ledger/blkstorage"
+	"github.com/hyperledger/fabric/common/ledger/testutil"
+	"github.com/hyperledger/fabric/protos/common"
+	putil "github.com/hyperledger/fabric/protos/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRollback(t *testing.T) {
+	path := testPath()
+	ledgerID := "testLedger"
+	// use a small block file size so that the blocks span across multiple files
+	conf := NewConf(path, 2*1024)
+	env := newTestEnv(t, conf)
+	defer env.Cleanup()
+
+	blocks := testutil.ConstructTestBlocks(t, 50)
+	blkfileMgrWrapper := newTestBlockfileWrapper(env, ledgerID)
+	blkfileMgrWrapper.addBlocks(blocks)
+	assert.True(t, blkfileMgrWrapper.blockfileMgr.cpInfo.latestFileChunkSuffixNum > 2)
+	blkfileMgrWrapper.close()
+	env.provider.Close()
+
+	indexConfig := env.provider.indexConfig
+	assert.NoError(t, ValidateRollbackParams(path, ledgerID, 20))
+	assert.NoError(t, Rollback(path, ledgerID, 20, indexConfig))
+	// rollback should be re-invokable with the same parameters
+	assert.NoError(t, ValidateRollbackParams(path, ledgerID, 20))
+	assert.NoError(t, Rollback(path, ledgerID, 20, indexConfig))
+
+	env = newTestEnv(t, conf)
+	blkfileMgrWrapper = newTestBlockfileWrapper(env, ledgerID)
+	defer blkfileMgrWrapper.close()
+	mgr := blkfileMgrWrapper.blockfileMgr
+	assert.Equal(t, uint64(21), mgr.getBlockchainInfo().Height)
+	blkfileMgrWrapper.testGetBlockByHash(blocks[:21])
+	blkfileMgrWrapper.testGetBlockByNumber(blocks[:21], 0)
+
+	for _, block := range blocks[21:] {
+		_, err := mgr.retrieveBlockByNumber(block.Header.Number)
+		assert.Equal(t, blkstorage.ErrNotFoundInIndex, err)
+		_, err = mgr.retrieveBlockByHash(block.Header.Hash())
+		assert.Equal(t, blkstorage.ErrNotFoundInIndex, err)
+		txID := extractTxIDFromTestBlock(t, block)
+		_, err = mgr.retrieveTransactionByID(txID)
+		assert.Equal(t, blkstorage.ErrNotFoundInIndex, err)
+	}
+
+	// the rolled back blocks can be added again
+	blkfileMgrWrapper.addBlocks(blocks[21:])
+	blkfileMgrWrapper.testGetBlockByHash(blocks)
+	blkfileMgrWrapper.testGetBlockByNumber(blocks, 0)
+	for _, block := range blocks[21:] {
+		txID := extractTxIDFromTestBlock(t, block)
+		txEnv, err := mgr.retrieveTransactionByID(txID)
+		assert.NoError(t, err)
+		assert.Equal(t, block.Data.Data[0], putil.MarshalOrPanic(txEnv))
+	}
+}
+
+func TestValidateRollbackParams(t *testing.T) {
+	path := testPath()
+	env := newTestEnv(t, NewConf(path, 0))
+	defer env.Cleanup()
+
+	blkfileMgrWrapper := newTestBlockfileWrapper(env, "testLedger")
+	blkfileMgrWrapper.addBlocks(testutil.ConstructTestBlocks(t, 5))
+	blkfileMgrWrapper.close()
+	env.provider.Close()
+
+	err := ValidateRollbackParams(path, "nonExistingLedger", 2)
+	assert.EqualError(t, err, "ledgerID [nonExistingLedger] does not exist")
+
+	err = ValidateRollbackParams(path, "testLedger", 5)
+	assert.EqualError(t, err, "target block number [5] should be less than the biggest block number [4]")
+
+	assert.NoError(t, ValidateRollbackParams(path, "testLedger", 2))
+}
+
+func extractTxIDFromTestBlock(t *testing.T, block *common.Block) string {
+	txEnv, err := putil.GetEnvelopeFromBlock(block.Data.Data[0])
+	assert.NoError(t, err)
+	txID, err := extractTxID(putil.MarshalOrPanic(txEnv))
+	assert.NoError(t, err)
+	return txID
+}
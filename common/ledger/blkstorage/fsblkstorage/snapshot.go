@@ -0,0 +1,248 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package fsblkstorage
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/common/ledger/blkstorage"
+	"github.com/hyperledger/fabric/common/ledger/snapshot"
+	"github.com/hyperledger/fabric/common/ledger/util"
+	"github.com/hyperledger/fabric/common/ledger/util/leveldbhelper"
+	"github.com/hyperledger/fabric/protos/common"
+	"github.com/pkg/errors"
+)
+
+const (
+	// SnapshotDataFileName is the name of the snapshot file that contains the txids
+	SnapshotDataFileName = "txids.data"
+
+	snapshotFileFormat            = byte(1)
+	bootstrappingSnapshotInfoFile = "bootstrappingSnapshot.info"
+	maxTxIDsImportBatchSize       = 10000
+)
+
+// ExportTxIds exports all the txids present in the block store into a snapshot file in the given directory.
+// The txids are exported in the sorted order. This includes the txids that were imported into this block store
+// from a snapshot, if the block store was bootstrapped from a snapshot. The function returns a map that contains
+// the name of the exported file and the hash of its contents
+func (store *fsBlockStore) ExportTxIds(dir string) (map[string][]byte, error) {
+	return store.fileMgr.exportTxIds(dir)
+}
+
+func (mgr *blockfileMgr) exportTxIds(dir string) (map[string][]byte, error) {
+	if !mgr.indexConfig.Contains(blkstorage.IndexableAttrTxID) {
+		return nil, errors.New("exporting txids requires the txids to be indexed in the block store")
+	}
+	w, err := snapshot.CreateFile(filepath.Join(dir, SnapshotDataFileName), snapshotFileFormat)
+	if err != nil {
+		return nil, err
+	}
+	defer w.Close()
+
+	itr := mgr.db.GetIterator([]byte{txIDIdxKeyPrefix}, []byte{txIDIdxKeyPrefix + 1})
+	defer itr.Release()
+	for itr.Next() {
+		if err := w.EncodeString(string(itr.Key()[1:])); err != nil {
+			return nil, err
+		}
+	}
+	if err := itr.Error(); err != nil {
+		return nil, errors.Wrap(err, "error while iterating the txid-index")
+	}
+	hash, err := w.Done()
+	if err != nil {
+		return nil, err
+	}
+	return map[string][]byte{SnapshotDataFileName: hash}, nil
+}
+
+// BootstrapFromSnapshot creates a block store for the given ledger id from a snapshot. The txids exported in the
+// snapshot are loaded into the txid-index so that these can be used for detecting the duplicate transactions.
+// The lastBlock is added as the first block in the block store and the lastConfigBlock, if different from
+// the lastBlock, is persisted along with the bootstrapping information so that it can be served
+// via the function `RetrieveBlockByNumber`. None of the blocks before the lastBlock is available in such a block store
+func (p *FsBlockstoreProvider) BootstrapFromSnapshot(ledgerid string, snapshotDir string,
+	lastBlock, lastConfigBlock *common.Block) (blkstorage.BlockStore, error) {
+	exists, err := p.Exists(ledgerid)
+	if err != nil {
+		return nil, err
+	}
+	if exists {
+		return nil, errors.Errorf("the block store for the ledger [%s] already exists", ledgerid)
+	}
+	if lastConfigBlock.Header.Number > lastBlock.Header.Number {
+		return nil, errors.Errorf("the last config block [%d] cannot be beyond the last block [%d]",
+			lastConfigBlock.Header.Number, lastBlock.Header.Number)
+	}
+
+	indexStoreHandle := p.leveldbProvider.GetDBHandle(ledgerid)
+	if p.indexConfig.Contains(blkstorage.IndexableAttrTxID) {
+		if err := importTxIDs(snapshotDir, lastBlock, indexStoreHandle); err != nil {
+			return nil, err
+		}
+	}
+
+	info := &bootstrappingSnapshotInfo{
+		lastBlockNum:          lastBlock.Header.Number,
+		lastBlockPreviousHash: lastBlock.Header.PreviousHash,
+	}
+	if lastConfigBlock.Header.Number != lastBlock.Header.Number {
+		info.lastConfigBlock = lastConfigBlock
+	}
+	ledgerDir := p.conf.getLedgerBlockDir(ledgerid)
+	if _, err := util.CreateDirIfMissing(ledgerDir); err != nil {
+		return nil, errors.Wrapf(err, "error while creating the block store dir [%s]", ledgerDir)
+	}
+	if err := writeBootstrappingSnapshotInfo(ledgerDir, info); err != nil {
+		return nil, err
+	}
+
+	store := newFsBlockStore(ledgerid, p.conf, p.indexConfig, indexStoreHandle)
+	if err := store.AddBlock(lastBlock); err != nil {
+		store.Shutdown()
+		return nil, err
+	}
+	return store, nil
+}
+
+// IsBootstrappedFromSnapshot returns true if the block store for the given ledger was bootstrapped from a snapshot
+func IsBootstrappedFromSnapshot(blockStorageDir, ledgerID string) (bool, error) {
+	ledgerDir := NewConf(blockStorageDir, 0).getLedgerBlockDir(ledgerID)
+	exists, _, err := util.FileExists(filepath.Join(ledgerDir, bootstrappingSnapshotInfoFile))
+	return exists, err
+}
+
+func importTxIDs(snapshotDir string, lastBlock *common.Block, indexStoreHandle *leveldbhelper.DBHandle) error {
+	// the txids of the last block are indexed when the last block is added to the block store
+	_, lastBlockInfo, err := serializeBlock(lastBlock)
+	if err != nil {
+		return err
+	}
+	lastBlockTxIDs := map[string]struct{}{}
+	for _, txOffset := range lastBlockInfo.txOffsets {
+		lastBlockTxIDs[txOffset.txID] = struct{}{}
+	}
+
+	r, err := snapshot.OpenFile(filepath.Join(snapshotDir, SnapshotDataFileName), snapshotFileFormat)
+	if err != nil {
+		return err
+	}
+	defer r.Close()
+
+	batch := leveldbhelper.NewUpdateBatch()
+	for {
+		hasMore, err := r.HasMore()
+		if err != nil {
+			return err
+		}
+		if !hasMore {
+			break
+		}
+		txID, err := r.DecodeString()
+		if err != nil {
+			return err
+		}
+		if _, ok := lastBlockTxIDs[txID]; ok {
+			continue
+		}
+		batch.Put(constructTxIDKey(txID), txIDFromSnapshotMarker)
+		if batch.Len() < maxTxIDsImportBatchSize {
+			continue
+		}
+		if err := indexStoreHandle.WriteBatch(batch, true); err != nil {
+			return err
+		}
+		batch = leveldbhelper.NewUpdateBatch()
+	}
+	return indexStoreHandle.WriteBatch(batch, true)
+}
+
+// bootstrappingSnapshotInfo captures the information about the snapshot that a block store was bootstrapped from
+type bootstrappingSnapshotInfo struct {
+	lastBlockNum          uint64
+	lastBlockPreviousHash []byte
+	lastConfigBlock       *common.Block // nil, if the last block itself is the last config block
+}
+
+func (i *bootstrappingSnapshotInfo) marshal() ([]byte, error) {
+	buffer := proto.NewBuffer([]byte{})
+	if err := buffer.EncodeVarint(i.lastBlockNum); err != nil {
+		return nil, err
+	}
+	if err := buffer.EncodeRawBytes(i.lastBlockPreviousHash); err != nil {
+		return nil, err
+	}
+	var configBlockBytes []byte
+	if i.lastConfigBlock != nil {
+		var err error
+		if configBlockBytes, err = proto.Marshal(i.lastConfigBlock); err != nil {
+			return nil, err
+		}
+	}
+	if err := buffer.EncodeRawBytes(configBlockBytes); err != nil {
+		return nil, err
+	}
+	return buffer.Bytes(), nil
+}
+
+func (i *bootstrappingSnapshotInfo) unmarshal(b []byte) error {
+	buffer := proto.NewBuffer(b)
+	var err error
+	if i.lastBlockNum, err = buffer.DecodeVarint(); err != nil {
+		return err
+	}
+	if i.lastBlockPreviousHash, err = buffer.DecodeRawBytes(false); err != nil {
+		return err
+	}
+	configBlockBytes, err := buffer.DecodeRawBytes(false)
+	if err != nil {
+		return err
+	}
+	if len(configBlockBytes) == 0 {
+		return nil
+	}
+	i.lastConfigBlock = &common.Block{}
+	return proto.Unmarshal(configBlockBytes, i.lastConfigBlock)
+}
+
+// writeBootstrappingSnapshotInfo writes the info into a temporary file first and then renames
+// the temporary file so that a crash does not leave a partially written info file
+func writeBootstrappingSnapshotInfo(ledgerDir string, info *bootstrappingSnapshotInfo) error {
+	b, err := info.marshal()
+	if err != nil {
+		return err
+	}
+	filePath := filepath.Join(ledgerDir, bootstrappingSnapshotInfoFile)
+	tmpFilePath := filePath + ".tmp"
+	if err := ioutil.WriteFile(tmpFilePath, b, 0644); err != nil {
+		return errors.Wrapf(err, "error while writing the file [%s]", tmpFilePath)
+	}
+	if err := os.Rename(tmpFilePath, filePath); err != nil {
+		return errors.Wrapf(err, "error while renaming the file [%s] to [%s]", tmpFilePath, filePath)
+	}
+	return nil
+}
+
+func loadBootstrappingSnapshotInfo(ledgerDir string) (*bootstrappingSnapshotInfo, error) {
+	filePath := filepath.Join(ledgerDir, bootstrappingSnapshotInfoFile)
+	b, err := ioutil.ReadFile(filePath)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errors.Wrapf(err, "error while reading the file [%s]", filePath)
+	}
+	info := &bootstrappingSnapshotInfo{}
+	if err := info.unmarshal(b); err != nil {
+		return nil, errors.Wrapf(err, "error while unmarshaling the contents of the file [%s]", filePath)
+	}
+	return info, nil
+}
@@ -0,0 +1,149 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package fsblkstorage
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/common/ledger/blkstorage"
+	"github.com/hyperledger/fabric/common/ledger/snapshot"
+	"github.com/hyperledger/fabric/common/ledger/testutil"
+	"github.com/hyperledger/fabric/protos/common"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExportTxIdsAndBootstrapFromSnapshot(t *testing.T) {
+	env := newTestEnv(t, NewConf(testPath(), 0))
+	defer env.Cleanup()
+	snapshotDir, err := ioutil.TempDir("", "fsblkstorage-snapshot")
+	assert.NoError(t, err)
+	defer os.RemoveAll(snapshotDir)
+
+	blocks := testutil.ConstructTestBlocks(t, 11)
+	sourceStore, err := env.provider.OpenBlockStore("source-ledger")
+	assert.NoError(t, err)
+	defer sourceStore.Shutdown()
+	for _, b := range blocks[:10] {
+		assert.NoError(t, sourceStore.AddBlock(b))
+	}
+	fileHashes, err := sourceStore.ExportTxIds(snapshotDir)
+	assert.NoError(t, err)
+	computedHash, err := snapshot.ComputeFileHash(filepath.Join(snapshotDir, SnapshotDataFileName))
+	assert.NoError(t, err)
+	assert.Equal(t, map[string][]byte{SnapshotDataFileName: computedHash}, fileHashes)
+
+	store, err := env.provider.BootstrapFromSnapshot("bootstrapped-ledger", snapshotDir, blocks[9], blocks[5])
+	assert.NoError(t, err)
+	verifyBootstrappedStore := func(store blkstorage.BlockStore) {
+		bcInfo, err := store.GetBlockchainInfo()
+		assert.NoError(t, err)
+		assert.Equal(t, &common.BlockchainInfo{
+			Height:            10,
+			CurrentBlockHash:  blocks[9].Header.Hash(),
+			PreviousBlockHash: blocks[9].Header.PreviousHash,
+		}, bcInfo)
+
+		for _, blockNum := range []uint64{5, 9} {
+			block, err := store.RetrieveBlockByNumber(blockNum)
+			assert.NoError(t, err)
+			assert.True(t, proto.Equal(blocks[blockNum], block))
+		}
+		_, err = store.RetrieveBlockByNumber(3)
+		assert.Equal(t, blkstorage.ErrNotFoundInIndex, err)
+		_, err = store.RetrieveBlocks(3)
+		assert.EqualError(t, err, "cannot serve block [3]. The ledger is bootstrapped from a snapshot. First available block = [9]")
+
+		for _, txID := range txIDsOfBlock(t, blocks[2]) {
+			exists, err := store.TxIDExists(txID)
+			assert.NoError(t, err)
+			assert.True(t, exists)
+			_, err = store.RetrieveTxByID(txID)
+			assert.Equal(t, blkstorage.ErrNotFoundInIndex, err)
+		}
+		for _, txID := range txIDsOfBlock(t, blocks[9]) {
+			exists, err := store.TxIDExists(txID)
+			assert.NoError(t, err)
+			assert.True(t, exists)
+			_, err = store.RetrieveTxByID(txID)
+			assert.NoError(t, err)
+		}
+		exists, err := store.TxIDExists("non-existing-txid")
+		assert.NoError(t, err)
+		assert.False(t, exists)
+	}
+	verifyBootstrappedStore(store)
+
+	isBootstrapped, err := IsBootstrappedFromSnapshot(env.provider.conf.blockStorageDir, "bootstrapped-ledger")
+	assert.NoError(t, err)
+	assert.True(t, isBootstrapped)
+	isBootstrapped, err = IsBootstrappedFromSnapshot(env.provider.conf.blockStorageDir, "source-ledger")
+	assert.NoError(t, err)
+	assert.False(t, isBootstrapped)
+
+	// the txids exported from the bootstrapped store are same as those exported from the source store
+	reexportDir, err := ioutil.TempDir("", "fsblkstorage-snapshot")
+	assert.NoError(t, err)
+	defer os.RemoveAll(reexportDir)
+	reexportedFileHashes, err := store.ExportTxIds(reexportDir)
+	assert.NoError(t, err)
+	assert.Equal(t, fileHashes, reexportedFileHashes)
+
+	// the bootstrapped store survives a restart and accepts the next blocks
+	store.Shutdown()
+	env.provider.Close()
+	env.provider = NewProvider(env.provider.conf, env.provider.indexConfig).(*FsBlockstoreProvider)
+	store, err = env.provider.OpenBlockStore("bootstrapped-ledger")
+	assert.NoError(t, err)
+	defer store.Shutdown()
+	verifyBootstrappedStore(store)
+	assert.NoError(t, store.AddBlock(blocks[10]))
+	itr, err := store.RetrieveBlocks(9)
+	assert.NoError(t, err)
+	defer itr.Close()
+	for _, expectedBlock := range blocks[9:] {
+		block, err := itr.Next()
+		assert.NoError(t, err)
+		assert.True(t, proto.Equal(expectedBlock, block.(*common.Block)))
+	}
+
+	_, err = env.provider.BootstrapFromSnapshot("bootstrapped-ledger", snapshotDir, blocks[9], blocks[5])
+	assert.EqualError(t, err, "the block store for the ledger [bootstrapped-ledger] already exists")
+}
+
+func TestBootstrappingSnapshotInfoMarshaling(t *testing.T) {
+	blocks := testutil.ConstructTestBlocks(t, 3)
+	for _, info := range []*bootstrappingSnapshotInfo{
+		{lastBlockNum: 2, lastBlockPreviousHash: blocks[2].Header.PreviousHash, lastConfigBlock: blocks[1]},
+		{lastBlockNum: 2, lastBlockPreviousHash: blocks[2].Header.PreviousHash},
+	} {
+		b, err := info.marshal()
+		assert.NoError(t, err)
+		unmarshaledInfo := &bootstrappingSnapshotInfo{}
+		assert.NoError(t, unmarshaledInfo.unmarshal(b))
+		assert.Equal(t, info.lastBlockNum, unmarshaledInfo.lastBlockNum)
+		assert.Equal(t, info.lastBlockPreviousHash, unmarshaledInfo.lastBlockPreviousHash)
+		if info.lastConfigBlock == nil {
+			assert.Nil(t, unmarshaledInfo.lastConfigBlock)
+			continue
+		}
+		assert.True(t, proto.Equal(info.lastConfigBlock, unmarshaledInfo.lastConfigBlock))
+	}
+}
+
+func txIDsOfBlock(t *testing.T, block *common.Block) []string {
+	_, info, err := serializeBlock(block)
+	assert.NoError(t, err)
+	txIDs := []string{}
+	for _, txOffset := range info.txOffsets {
+		txIDs = append(txIDs, txOffset.txID)
+	}
+	return txIDs
+}
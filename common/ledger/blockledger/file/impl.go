@@ -9,9 +9,11 @@ package fileledger
 import (
 	"github.com/hyperledger/fabric/common/flogging"
 	"github.com/hyperledger/fabric/common/ledger"
+	"github.com/hyperledger/fabric/common/ledger/blkstorage"
 	"github.com/hyperledger/fabric/common/ledger/blockledger"
 	cb "github.com/hyperledger/fabric/protos/common"
 	ab "github.com/hyperledger/fabric/protos/orderer"
+	"github.com/pkg/errors"
 )
 
 var logger = flogging.MustGetLogger("common.ledger.blockledger.file")
@@ -43,8 +45,14 @@ type fileLedgerIterator struct {
 
 // Next blocks until there is a new block available, or until Close is called.
 // It returns an error if the next block is no longer retrievable.
+// The status NOT_FOUND is returned if the next block has been archived
+// so that the client can seek the block from another source
 func (i *fileLedgerIterator) Next() (*cb.Block, cb.Status) {
 	result, err := i.commonIterator.Next()
+	if errors.Cause(err) == blkstorage.ErrBlockArchived {
+		logger.Warningf("Block [%d] has been archived and cannot be served", i.blockNumber)
+		return nil, cb.Status_NOT_FOUND
+	}
 	if err != nil {
 		logger.Error(err)
 		return nil, cb.Status_SERVICE_UNAVAILABLE
@@ -53,6 +61,7 @@ func (i *fileLedgerIterator) Next() (*cb.Block, cb.Status) {
 	if result == nil {
 		return nil, cb.Status_SERVICE_UNAVAILABLE
 	}
+	i.blockNumber++
 	return result.(*cb.Block), cb.Status_SUCCESS
 }
 
@@ -86,6 +95,10 @@ func (fl *FileLedger) Iterator(startPosition *ab.SeekPosition) (blockledger.Iter
 	}
 
 	iterator, err := fl.blockStore.RetrieveBlocks(startingBlockNumber)
+	if errors.Cause(err) == blkstorage.ErrBlockArchived {
+		logger.Warningf("Block [%d] has been archived and cannot be served", startingBlockNumber)
+		return &blockledger.NotFoundErrorIterator{}, 0
+	}
 	if err != nil {
 		return &blockledger.NotFoundErrorIterator{}, 0
 	}
@@ -15,6 +15,7 @@ import (
 
 	"github.com/hyperledger/fabric/common/flogging"
 	cl "github.com/hyperledger/fabric/common/ledger"
+	"github.com/hyperledger/fabric/common/ledger/blkstorage"
 	"github.com/hyperledger/fabric/common/ledger/blockledger"
 	genesisconfig "github.com/hyperledger/fabric/common/tools/configtxgen/localconfig"
 	cb "github.com/hyperledger/fabric/protos/common"
@@ -107,6 +108,14 @@ func (mbs *mockBlockStore) RetrieveTxValidationCodeByTxID(txID string) (peer.TxV
 	return mbs.txValidationCode, mbs.defaultError
 }
 
+func (mbs *mockBlockStore) TxIDExists(txID string) (bool, error) {
+	return false, mbs.defaultError
+}
+
+func (mbs *mockBlockStore) ExportTxIds(dir string) (map[string][]byte, error) {
+	return nil, mbs.defaultError
+}
+
 func (*mockBlockStore) Shutdown() {
 }
 
@@ -295,3 +304,36 @@ func TestBlockstoreError(t *testing.T) {
 		assert.Equal(t, cb.Status_SERVICE_UNAVAILABLE, status, "Expected service unavailable error")
 	}
 }
+
+func TestArchivedBlocks(t *testing.T) {
+	{
+		fl := &FileLedger{
+			blockStore: &mockBlockStore{
+				blockchainInfo: &cb.BlockchainInfo{Height: uint64(10)},
+				defaultError:   blkstorage.ErrBlockArchived,
+			},
+			signal: make(chan struct{}),
+		}
+		it, _ := fl.Iterator(&ab.SeekPosition{Type: &ab.SeekPosition_Oldest{}})
+		defer it.Close()
+		_, status := it.Next()
+		assert.Equal(t, cb.Status_NOT_FOUND, status, "Expected not found status for an archived block")
+	}
+
+	{
+		resultsIterator := &mockBlockStoreIterator{}
+		resultsIterator.On("Next").Return(nil, blkstorage.ErrBlockArchived)
+		resultsIterator.On("Close").Return()
+		fl := &FileLedger{
+			blockStore: &mockBlockStore{
+				blockchainInfo:  &cb.BlockchainInfo{Height: uint64(10)},
+				resultsIterator: resultsIterator,
+			},
+			signal: make(chan struct{}),
+		}
+		it, _ := fl.Iterator(&ab.SeekPosition{Type: &ab.SeekPosition_Oldest{}})
+		defer it.Close()
+		_, status := it.Next()
+		assert.Equal(t, cb.Status_NOT_FOUND, status, "Expected not found status for an archived block")
+	}
+}
@@ -0,0 +1,193 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package snapshot
+
+import (
+	"bufio"
+	"crypto/sha256"
+	"encoding/binary"
+	"hash"
+	"io"
+	"os"
+
+	"github.com/pkg/errors"
+)
+
+// FileWriter creates and writes into a snapshot file. The first byte of a snapshot file
+// is the data format of the records present in the file. This is followed by the records,
+// where each record is written with a varint length prefix (for bytes and strings) or as a
+// varint (for numbers). A FileWriter computes the hash of the file contents while writing
+type FileWriter struct {
+	file      *os.File
+	hasher    hash.Hash
+	bufWriter *bufio.Writer
+	multiW    io.Writer
+	varintBuf []byte
+}
+
+// CreateFile creates a new snapshot file at the given path and writes the supplied
+// data format as the first byte. An existing file at the given path is overwritten
+func CreateFile(filePath string, dataFormat byte) (*FileWriter, error) {
+	file, err := os.OpenFile(filePath, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0644)
+	if err != nil {
+		return nil, errors.Wrapf(err, "error while creating the snapshot file [%s]", filePath)
+	}
+	bufWriter := bufio.NewWriter(file)
+	hasher := sha256.New()
+	w := &FileWriter{
+		file:      file,
+		hasher:    hasher,
+		bufWriter: bufWriter,
+		multiW:    io.MultiWriter(bufWriter, hasher),
+		varintBuf: make([]byte, binary.MaxVarintLen64),
+	}
+	if _, err := w.multiW.Write([]byte{dataFormat}); err != nil {
+		w.Close()
+		return nil, errors.Wrapf(err, "error while writing data format to the snapshot file [%s]", filePath)
+	}
+	return w, nil
+}
+
+// EncodeString encodes and appends the string to the file
+func (w *FileWriter) EncodeString(str string) error {
+	return w.EncodeBytes([]byte(str))
+}
+
+// EncodeBytes encodes and appends the bytes to the file
+func (w *FileWriter) EncodeBytes(b []byte) error {
+	if err := w.EncodeUVarint(uint64(len(b))); err != nil {
+		return err
+	}
+	if _, err := w.multiW.Write(b); err != nil {
+		return errors.Wrapf(err, "error while writing data to the snapshot file [%s]", w.file.Name())
+	}
+	return nil
+}
+
+// EncodeUVarint encodes and appends a number to the file
+func (w *FileWriter) EncodeUVarint(u uint64) error {
+	n := binary.PutUvarint(w.varintBuf, u)
+	if _, err := w.multiW.Write(w.varintBuf[:n]); err != nil {
+		return errors.Wrapf(err, "error while writing data to the snapshot file [%s]", w.file.Name())
+	}
+	return nil
+}
+
+// Done flushes the buffered data and syncs the file to the disk.
+// It returns the hash of the contents of the file
+func (w *FileWriter) Done() ([]byte, error) {
+	if err := w.bufWriter.Flush(); err != nil {
+		return nil, errors.Wrapf(err, "error while flushing to the snapshot file [%s]", w.file.Name())
+	}
+	if err := w.file.Sync(); err != nil {
+		return nil, errors.Wrapf(err, "error while syncing the snapshot file [%s]", w.file.Name())
+	}
+	return w.hasher.Sum(nil), nil
+}
+
+// Close closes the underlying file. Close is expected to be invoked after Done,
+// however, it is safe to invoke Close without Done, for instance, in the case of an error
+func (w *FileWriter) Close() error {
+	if w == nil {
+		return nil
+	}
+	return errors.Wrapf(w.file.Close(), "error while closing the snapshot file [%s]", w.file.Name())
+}
+
+// FileReader reads from a snapshot file that was created by a FileWriter
+type FileReader struct {
+	file      *os.File
+	bufReader *bufio.Reader
+}
+
+// OpenFile opens a snapshot file and verifies that the data format of the
+// file matches the expected data format
+func OpenFile(filePath string, expectedDataFormat byte) (*FileReader, error) {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return nil, errors.Wrapf(err, "error while opening the snapshot file [%s]", filePath)
+	}
+	r := &FileReader{
+		file:      file,
+		bufReader: bufio.NewReader(file),
+	}
+	dataFormat, err := r.bufReader.ReadByte()
+	if err != nil {
+		r.Close()
+		return nil, errors.Wrapf(err, "error while reading data format from the snapshot file [%s]", filePath)
+	}
+	if dataFormat != expectedDataFormat {
+		r.Close()
+		return nil, errors.Errorf("unexpected data format [%x] in the snapshot file [%s], expected [%x]",
+			dataFormat, filePath, expectedDataFormat)
+	}
+	return r, nil
+}
+
+// DecodeString reads and decodes a string
+func (r *FileReader) DecodeString() (string, error) {
+	b, err := r.DecodeBytes()
+	return string(b), err
+}
+
+// DecodeBytes reads and decodes bytes
+func (r *FileReader) DecodeBytes() ([]byte, error) {
+	size, err := r.DecodeUVarint()
+	if err != nil {
+		return nil, err
+	}
+	b := make([]byte, size)
+	if _, err := io.ReadFull(r.bufReader, b); err != nil {
+		return nil, errors.Wrapf(err, "error while reading data from the snapshot file [%s]", r.file.Name())
+	}
+	return b, nil
+}
+
+// DecodeUVarint reads and decodes a number
+func (r *FileReader) DecodeUVarint() (uint64, error) {
+	u, err := binary.ReadUvarint(r.bufReader)
+	if err != nil {
+		return 0, errors.Wrapf(err, "error while reading data from the snapshot file [%s]", r.file.Name())
+	}
+	return u, nil
+}
+
+// HasMore returns true if there is more data that can be read from the file.
+// This is used for reading the records till the end of the file
+func (r *FileReader) HasMore() (bool, error) {
+	_, err := r.bufReader.Peek(1)
+	if err == io.EOF {
+		return false, nil
+	}
+	if err != nil {
+		return false, errors.Wrapf(err, "error while reading data from the snapshot file [%s]", r.file.Name())
+	}
+	return true, nil
+}
+
+// Close closes the underlying file
+func (r *FileReader) Close() error {
+	if r == nil {
+		return nil
+	}
+	return errors.Wrapf(r.file.Close(), "error while closing the snapshot file [%s]", r.file.Name())
+}
+
+// ComputeFileHash computes the hash of the contents of the given file. This is used
+// for verifying the hashes of the files of a snapshot, before consuming the snapshot
+func ComputeFileHash(filePath string) ([]byte, error) {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return nil, errors.Wrapf(err, "error while opening the snapshot file [%s]", filePath)
+	}
+	defer file.Close()
+	hasher := sha256.New()
+	if _, err := io.Copy(hasher, file); err != nil {
+		return nil, errors.Wrapf(err, "error while reading the snapshot file [%s]", filePath)
+	}
+	return hasher.Sum(nil), nil
+}
@@ -0,0 +1,91 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package snapshot
+
+import (
+	"crypto/sha256"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFileCreateAndRead(t *testing.T) {
+	testDir, err := ioutil.TempDir("", "snapshot-file")
+	assert.NoError(t, err)
+	defer os.RemoveAll(testDir)
+	filePath := filepath.Join(testDir, "testfile")
+
+	w, err := CreateFile(filePath, byte(7))
+	assert.NoError(t, err)
+	assert.NoError(t, w.EncodeString("a-string"))
+	assert.NoError(t, w.EncodeBytes([]byte("some-bytes")))
+	assert.NoError(t, w.EncodeBytes(nil))
+	assert.NoError(t, w.EncodeUVarint(1234567))
+	hash, err := w.Done()
+	assert.NoError(t, err)
+	assert.NoError(t, w.Close())
+
+	fileContent, err := ioutil.ReadFile(filePath)
+	assert.NoError(t, err)
+	expectedHash := sha256.Sum256(fileContent)
+	assert.Equal(t, expectedHash[:], hash)
+	computedHash, err := ComputeFileHash(filePath)
+	assert.NoError(t, err)
+	assert.Equal(t, hash, computedHash)
+
+	r, err := OpenFile(filePath, byte(7))
+	assert.NoError(t, err)
+	defer r.Close()
+	hasMore, err := r.HasMore()
+	assert.NoError(t, err)
+	assert.True(t, hasMore)
+	str, err := r.DecodeString()
+	assert.NoError(t, err)
+	assert.Equal(t, "a-string", str)
+	b, err := r.DecodeBytes()
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("some-bytes"), b)
+	b, err = r.DecodeBytes()
+	assert.NoError(t, err)
+	assert.Len(t, b, 0)
+	u, err := r.DecodeUVarint()
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(1234567), u)
+	hasMore, err = r.HasMore()
+	assert.NoError(t, err)
+	assert.False(t, hasMore)
+	_, err = r.DecodeBytes()
+	assert.Error(t, err)
+}
+
+func TestFileErrors(t *testing.T) {
+	testDir, err := ioutil.TempDir("", "snapshot-file")
+	assert.NoError(t, err)
+	defer os.RemoveAll(testDir)
+	filePath := filepath.Join(testDir, "testfile")
+
+	_, err = CreateFile(filepath.Join(testDir, "non-existing-dir", "testfile"), byte(1))
+	assert.Contains(t, err.Error(), "error while creating the snapshot file")
+
+	_, err = OpenFile(filePath, byte(1))
+	assert.Contains(t, err.Error(), "error while opening the snapshot file")
+
+	w, err := CreateFile(filePath, byte(1))
+	assert.NoError(t, err)
+	_, err = w.Done()
+	assert.NoError(t, err)
+	assert.NoError(t, w.Close())
+
+	_, err = OpenFile(filePath, byte(2))
+	assert.EqualError(t, err, "unexpected data format [1] in the snapshot file ["+filePath+"], expected [2]")
+
+	_, err = ComputeFileHash(filepath.Join(testDir, "non-existing-file"))
+	assert.Contains(t, err.Error(), "error while opening the snapshot file")
+}
@@ -137,3 +137,26 @@ func TestCreateDBInNonEmptyDir(t *testing.T) {
 	}()
 	db.Open()
 }
+
+func TestFileLock(t *testing.T) {
+	fileLockPath := testDBPath + "/fileLock"
+	assert.NoError(t, os.RemoveAll(fileLockPath))
+	defer os.RemoveAll(fileLockPath)
+
+	// acquire the file lock
+	fileLock1 := NewFileLock(fileLockPath)
+	assert.NoError(t, fileLock1.Lock())
+
+	// a second lock on the same path should fail
+	fileLock2 := NewFileLock(fileLockPath)
+	err := fileLock2.Lock()
+	assert.EqualError(t, err, "lock is already acquired on file "+fileLockPath)
+
+	// release the first lock and retry the second one
+	fileLock1.Unlock()
+	assert.NoError(t, fileLock2.Lock())
+	fileLock2.Unlock()
+
+	// unlock can be called multiple times
+	fileLock2.Unlock()
+}
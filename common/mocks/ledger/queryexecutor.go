@@ -21,6 +21,7 @@ import (
 
 	commonledger "github.com/hyperledger/fabric/common/ledger"
 	"github.com/hyperledger/fabric/core/ledger"
+	"github.com/hyperledger/fabric/protos/ledger/rwset/kvrwset"
 )
 
 type MockQueryExecutor struct {
@@ -75,6 +76,10 @@ func (m *MockQueryExecutor) GetPrivateDataMetadataByHash(namespace, collection s
 	return nil, nil
 }
 
+func (m *MockQueryExecutor) GetPrivateDataHash(namespace, collection, key string) ([]byte, *kvrwset.Version, error) {
+	return nil, nil, nil
+}
+
 func (m *MockQueryExecutor) GetPrivateDataMultipleKeys(namespace, collection string, keys []string) ([][]byte, error) {
 	return nil, nil
 }
@@ -11,8 +11,12 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/golang/protobuf/ptypes"
 	"github.com/golang/protobuf/ptypes/empty"
 	"github.com/hyperledger/fabric/common/flogging"
+	"github.com/hyperledger/fabric/core/ledger"
+	"github.com/hyperledger/fabric/core/transientstore"
+	"github.com/hyperledger/fabric/gossip/privdata"
 	"github.com/hyperledger/fabric/protos/common"
 	pb "github.com/hyperledger/fabric/protos/peer"
 	"github.com/pkg/errors"
@@ -34,13 +38,30 @@ type AccessControlEvaluator interface {
 	Evaluate(signatureSet []*common.SignedData) error
 }
 
+// TransientStoreRetriever retrieves the transient store of a channel
+type TransientStoreRetriever interface {
+	// StoreForChannel returns the transient store of the given channel,
+	// or nil if the peer has not joined the channel
+	StoreForChannel(channel string) transientstore.Store
+}
+
+// ChaincodeIndexManagerRetriever retrieves the manager of the chaincode indexes of a channel
+type ChaincodeIndexManagerRetriever interface {
+	// ChaincodeIndexManager returns the manager of the chaincode indexes of the given channel
+	ChaincodeIndexManager(channel string) (ledger.ChaincodeIndexManager, error)
+}
+
 // NewAdminServer creates and returns a Admin service instance.
-func NewAdminServer(ace AccessControlEvaluator) *ServerAdmin {
+func NewAdminServer(ace AccessControlEvaluator, transientStores TransientStoreRetriever, reconciliation privdata.ReconciliationSupport,
+	indexManagers ChaincodeIndexManagerRetriever) *ServerAdmin {
 	s := &ServerAdmin{
 		v: &validator{
 			ace: ace,
 		},
-		specAtStartup: flogging.Global.Spec(),
+		specAtStartup:   flogging.Global.Spec(),
+		transientStores: transientStores,
+		reconciliation:  reconciliation,
+		indexManagers:   indexManagers,
 	}
 	return s
 }
@@ -49,7 +70,10 @@ func NewAdminServer(ace AccessControlEvaluator) *ServerAdmin {
 type ServerAdmin struct {
 	v requestValidator
 
-	specAtStartup string
+	specAtStartup   string
+	transientStores TransientStoreRetriever
+	reconciliation  privdata.ReconciliationSupport
+	indexManagers   ChaincodeIndexManagerRetriever
 }
 
 func (s *ServerAdmin) GetStatus(ctx context.Context, env *common.Envelope) (*pb.ServerStatus, error) {
@@ -140,3 +164,307 @@ func (s *ServerAdmin) SetLogSpec(ctx context.Context, env *common.Envelope) (*pb
 	}
 	return logResponse, nil
 }
+
+// GetTransientEntries describes the private write sets held in the transient store of a channel
+func (s *ServerAdmin) GetTransientEntries(ctx context.Context, env *common.Envelope) (*pb.TransientEntriesResponse, error) {
+	op, err := s.v.validate(ctx, env)
+	if err != nil {
+		return nil, err
+	}
+	request := op.GetTransientEntriesReq()
+	if request == nil {
+		return nil, errors.New("request is nil")
+	}
+	_, entries, err := s.transientEntries(request)
+	if err != nil {
+		return nil, err
+	}
+	return newTransientEntriesResponse(entries)
+}
+
+// PurgeTransientEntries removes private write sets from the transient store of a channel
+// and describes the private write sets that are removed
+func (s *ServerAdmin) PurgeTransientEntries(ctx context.Context, env *common.Envelope) (*pb.TransientEntriesResponse, error) {
+	op, err := s.v.validate(ctx, env)
+	if err != nil {
+		return nil, err
+	}
+	request := op.GetTransientEntriesReq()
+	if request == nil {
+		return nil, errors.New("request is nil")
+	}
+	store, entries, err := s.transientEntries(request)
+	if err != nil {
+		return nil, err
+	}
+
+	var txids []string
+	for _, entry := range entries {
+		if len(txids) == 0 || txids[len(txids)-1] != entry.Txid {
+			txids = append(txids, entry.Txid)
+		}
+	}
+	if err := store.PurgeByTxids(txids); err != nil {
+		return nil, errors.WithMessage(err, "failed purging the transient store")
+	}
+	logger.Infof("Purged [%d] private write sets of [%d] transactions from the transient store of channel [%s]",
+		len(entries), len(txids), request.ChannelId)
+	return newTransientEntriesResponse(entries)
+}
+
+// transientEntries returns the transient store of the channel of the request along with the
+// description of the private write sets selected by the request, ordered by txid
+func (s *ServerAdmin) transientEntries(request *pb.TransientEntriesRequest) (transientstore.Store, []*transientstore.EntryInfo, error) {
+	if request.ChannelId == "" {
+		return nil, nil, errors.New("channel ID must be provided")
+	}
+	var store transientstore.Store
+	if s.transientStores != nil {
+		store = s.transientStores.StoreForChannel(request.ChannelId)
+	}
+	if store == nil {
+		return nil, nil, errors.Errorf("no transient store found for channel %s", request.ChannelId)
+	}
+
+	entries, err := store.GetEntries()
+	if err != nil {
+		return nil, nil, errors.WithMessage(err, "failed retrieving the transient store entries")
+	}
+	if len(request.Txids) == 0 {
+		return store, entries, nil
+	}
+
+	selected := map[string]struct{}{}
+	for _, txid := range request.Txids {
+		selected[txid] = struct{}{}
+	}
+	var selectedEntries []*transientstore.EntryInfo
+	for _, entry := range entries {
+		if _, ok := selected[entry.Txid]; ok {
+			selectedEntries = append(selectedEntries, entry)
+		}
+	}
+	return store, selectedEntries, nil
+}
+
+func newTransientEntriesResponse(entries []*transientstore.EntryInfo) (*pb.TransientEntriesResponse, error) {
+	response := &pb.TransientEntriesResponse{}
+	for _, entry := range entries {
+		transientEntry := &pb.TransientEntry{
+			Txid:                  entry.Txid,
+			Uuid:                  entry.UUID,
+			ReceivedAtBlockHeight: entry.ReceivedAtBlockHeight,
+			Size:                  entry.Size,
+		}
+		if !entry.PersistedAt.IsZero() {
+			persistedAt, err := ptypes.TimestampProto(entry.PersistedAt)
+			if err != nil {
+				return nil, errors.WithStack(err)
+			}
+			transientEntry.PersistedAt = persistedAt
+		}
+		response.Entries = append(response.Entries, transientEntry)
+		response.TotalSize += entry.Size
+	}
+	return response, nil
+}
+
+// GetMissingPvtData lists the private data of eligible collections that is missing
+// in a range of blocks of a channel
+func (s *ServerAdmin) GetMissingPvtData(ctx context.Context, env *common.Envelope) (*pb.MissingPvtDataResponse, error) {
+	request, err := s.pvtDataReconciliationRequest(ctx, env)
+	if err != nil {
+		return nil, err
+	}
+	tracker, err := s.reconciliation.MissingPvtDataTracker(request.ChannelId)
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed retrieving the missing private data tracker")
+	}
+	missing, err := privdata.ListMissingPvtData(tracker, request.StartBlock, request.EndBlock)
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed retrieving the missing private data")
+	}
+
+	response := &pb.MissingPvtDataResponse{}
+	for _, m := range missing {
+		response.Missing = append(response.Missing, &pb.MissingPvtData{
+			BlockNum:   m.BlockNum,
+			TxNum:      m.TxNum,
+			Namespace:  m.Namespace,
+			Collection: m.Collection,
+		})
+	}
+	return response, nil
+}
+
+// ReconcilePvtData triggers an immediate reconciliation pass over the missing private data
+// in a range of blocks of a channel and returns the initial status of the pass
+func (s *ServerAdmin) ReconcilePvtData(ctx context.Context, env *common.Envelope) (*pb.PvtDataReconciliationStatus, error) {
+	request, err := s.pvtDataReconciliationRequest(ctx, env)
+	if err != nil {
+		return nil, err
+	}
+	reconciler, err := s.reconciliation.Reconciler(request.ChannelId)
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed retrieving the private data reconciler")
+	}
+	if err := reconciler.Reconcile(request.StartBlock, request.EndBlock); err != nil {
+		return nil, errors.WithMessage(err, "failed triggering the private data reconciliation")
+	}
+	logger.Infof("Triggered reconciliation of the missing private data of blocks range [%d - %d] of channel [%s]",
+		request.StartBlock, request.EndBlock, request.ChannelId)
+	return newPvtDataReconciliationStatus(reconciler.Status())
+}
+
+// GetPvtDataReconciliationStatus returns the progress of the most recent reconciliation pass
+// triggered on a channel
+func (s *ServerAdmin) GetPvtDataReconciliationStatus(ctx context.Context, env *common.Envelope) (*pb.PvtDataReconciliationStatus, error) {
+	request, err := s.pvtDataReconciliationRequest(ctx, env)
+	if err != nil {
+		return nil, err
+	}
+	reconciler, err := s.reconciliation.Reconciler(request.ChannelId)
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed retrieving the private data reconciler")
+	}
+	status := reconciler.Status()
+	if status == nil {
+		return nil, errors.Errorf("no private data reconciliation was triggered on channel %s", request.ChannelId)
+	}
+	return newPvtDataReconciliationStatus(status)
+}
+
+func (s *ServerAdmin) pvtDataReconciliationRequest(ctx context.Context, env *common.Envelope) (*pb.PvtDataReconciliationRequest, error) {
+	op, err := s.v.validate(ctx, env)
+	if err != nil {
+		return nil, err
+	}
+	request := op.GetPvtDataReconciliationReq()
+	if request == nil {
+		return nil, errors.New("request is nil")
+	}
+	if request.ChannelId == "" {
+		return nil, errors.New("channel ID must be provided")
+	}
+	if s.reconciliation == nil {
+		return nil, errors.New("private data reconciliation is not available")
+	}
+	return request, nil
+}
+
+func newPvtDataReconciliationStatus(status *privdata.ReconciliationStatus) (*pb.PvtDataReconciliationStatus, error) {
+	response := &pb.PvtDataReconciliationStatus{
+		StartBlock:         status.StartBlock,
+		EndBlock:           status.EndBlock,
+		InProgress:         status.InProgress,
+		ProcessedBlocks:    uint64(status.ProcessedBlocks),
+		LastProcessedBlock: status.LastProcessedBlock,
+		ReconciledItems:    uint64(status.ReconciledItems),
+		UnavailableItems:   uint64(status.UnavailableItems),
+		HashMismatches:     uint64(status.HashMismatches),
+		Error:              status.Error,
+	}
+	for endpoint, count := range status.FailedPeers {
+		if response.FailedPeers == nil {
+			response.FailedPeers = make(map[string]uint64)
+		}
+		response.FailedPeers[endpoint] = uint64(count)
+	}
+	var err error
+	if !status.StartedAt.IsZero() {
+		if response.StartedAt, err = ptypes.TimestampProto(status.StartedAt); err != nil {
+			return nil, errors.WithStack(err)
+		}
+	}
+	if !status.FinishedAt.IsZero() {
+		if response.FinishedAt, err = ptypes.TimestampProto(status.FinishedAt); err != nil {
+			return nil, errors.WithStack(err)
+		}
+	}
+	return response, nil
+}
+
+// ListChaincodeIndexes lists the indexes of the state database of a chaincode, for its public
+// state and for each of its collections
+func (s *ServerAdmin) ListChaincodeIndexes(ctx context.Context, env *common.Envelope) (*pb.ChaincodeIndexesResponse, error) {
+	request, indexManager, err := s.chaincodeIndexRequest(ctx, env)
+	if err != nil {
+		return nil, err
+	}
+	indexes, err := indexManager.ListIndexes(request.Chaincode)
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed listing the chaincode indexes")
+	}
+	response := &pb.ChaincodeIndexesResponse{}
+	for _, index := range indexes {
+		response.Indexes = append(response.Indexes, newChaincodeIndex(index))
+	}
+	return response, nil
+}
+
+// DropChaincodeIndex drops an index of the state database of a chaincode
+func (s *ServerAdmin) DropChaincodeIndex(ctx context.Context, env *common.Envelope) (*empty.Empty, error) {
+	request, indexManager, err := s.chaincodeIndexRequest(ctx, env)
+	if err != nil {
+		return nil, err
+	}
+	if request.DesignDoc == "" || request.IndexName == "" {
+		return nil, errors.New("design document and index name must be provided")
+	}
+	if err := indexManager.DropIndex(request.Chaincode, request.Collection, request.DesignDoc, request.IndexName); err != nil {
+		return nil, errors.WithMessage(err, "failed dropping the chaincode index")
+	}
+	return &empty.Empty{}, nil
+}
+
+// RebuildChaincodeIndex creates an index of the state database of a chaincode from its definition,
+// replacing the existing index of the same design document and name
+func (s *ServerAdmin) RebuildChaincodeIndex(ctx context.Context, env *common.Envelope) (*pb.ChaincodeIndex, error) {
+	request, indexManager, err := s.chaincodeIndexRequest(ctx, env)
+	if err != nil {
+		return nil, err
+	}
+	if len(request.IndexDefinition) == 0 {
+		return nil, errors.New("index definition must be provided")
+	}
+	index, err := indexManager.RebuildIndex(request.Chaincode, request.Collection, request.IndexDefinition)
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed rebuilding the chaincode index")
+	}
+	return newChaincodeIndex(index), nil
+}
+
+func (s *ServerAdmin) chaincodeIndexRequest(ctx context.Context, env *common.Envelope) (*pb.ChaincodeIndexRequest, ledger.ChaincodeIndexManager, error) {
+	op, err := s.v.validate(ctx, env)
+	if err != nil {
+		return nil, nil, err
+	}
+	request := op.GetChaincodeIndexReq()
+	if request == nil {
+		return nil, nil, errors.New("request is nil")
+	}
+	if request.ChannelId == "" {
+		return nil, nil, errors.New("channel ID must be provided")
+	}
+	if request.Chaincode == "" {
+		return nil, nil, errors.New("chaincode name must be provided")
+	}
+	if s.indexManagers == nil {
+		return nil, nil, errors.New("chaincode index management is not available")
+	}
+	indexManager, err := s.indexManagers.ChaincodeIndexManager(request.ChannelId)
+	if err != nil {
+		return nil, nil, errors.WithMessage(err, "failed retrieving the chaincode index manager")
+	}
+	return request, indexManager, nil
+}
+
+func newChaincodeIndex(index *ledger.ChaincodeIndex) *pb.ChaincodeIndex {
+	return &pb.ChaincodeIndex{
+		Chaincode:  index.Chaincode,
+		Collection: index.Collection,
+		DesignDoc:  index.DesignDoc,
+		Name:       index.Name,
+		Definition: index.Definition,
+	}
+}
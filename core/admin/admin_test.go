@@ -10,11 +10,17 @@ import (
 	"context"
 	"strings"
 	"testing"
+	"time"
 
+	"github.com/golang/protobuf/ptypes"
 	"github.com/hyperledger/fabric/common/flogging"
+	"github.com/hyperledger/fabric/core/ledger"
 	"github.com/hyperledger/fabric/core/testutil"
+	"github.com/hyperledger/fabric/core/transientstore"
+	"github.com/hyperledger/fabric/gossip/privdata"
 	"github.com/hyperledger/fabric/protos/common"
 	pb "github.com/hyperledger/fabric/protos/peer"
+	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 )
@@ -36,7 +42,7 @@ func (v *mockValidator) validate(ctx context.Context, env *common.Envelope) (*pb
 }
 
 func TestGetStatus(t *testing.T) {
-	adminServer := NewAdminServer(nil)
+	adminServer := NewAdminServer(nil, nil, nil, nil)
 	adminServer.v = &mockValidator{}
 	mv := adminServer.v.(*mockValidator)
 	mv.On("validate").Return(nil, nil).Once()
@@ -46,7 +52,7 @@ func TestGetStatus(t *testing.T) {
 }
 
 func TestStartServer(t *testing.T) {
-	adminServer := NewAdminServer(nil)
+	adminServer := NewAdminServer(nil, nil, nil, nil)
 	adminServer.v = &mockValidator{}
 	mv := adminServer.v.(*mockValidator)
 	mv.On("validate").Return(nil, nil).Once()
@@ -56,7 +62,7 @@ func TestStartServer(t *testing.T) {
 }
 
 func TestForbidden(t *testing.T) {
-	adminServer := NewAdminServer(nil)
+	adminServer := NewAdminServer(nil, nil, nil, nil)
 	adminServer.v = &mockValidator{}
 	mv := adminServer.v.(*mockValidator)
 	mv.On("validate").Return(nil, accessDenied).Times(7)
@@ -88,7 +94,7 @@ func TestForbidden(t *testing.T) {
 }
 
 func TestLoggingCalls(t *testing.T) {
-	adminServer := NewAdminServer(nil)
+	adminServer := NewAdminServer(nil, nil, nil, nil)
 	adminServer.v = &mockValidator{}
 	mv := adminServer.v.(*mockValidator)
 	flogging.MustGetLogger("test")
@@ -219,3 +225,437 @@ func TestLoggingCalls(t *testing.T) {
 		}
 	}
 }
+
+type fakeTransientStore struct {
+	transientstore.Store
+	entries    []*transientstore.EntryInfo
+	entriesErr error
+	purged     []string
+}
+
+func (s *fakeTransientStore) GetEntries() ([]*transientstore.EntryInfo, error) {
+	return s.entries, s.entriesErr
+}
+
+func (s *fakeTransientStore) PurgeByTxids(txids []string) error {
+	s.purged = append(s.purged, txids...)
+	return nil
+}
+
+type fakeTransientStoreRetriever map[string]transientstore.Store
+
+func (r fakeTransientStoreRetriever) StoreForChannel(channel string) transientstore.Store {
+	return r[channel]
+}
+
+func TestTransientEntries(t *testing.T) {
+	persistTime := time.Unix(1500000000, 0)
+	store := &fakeTransientStore{
+		entries: []*transientstore.EntryInfo{
+			{Txid: "txid-1", UUID: "uuid-1", ReceivedAtBlockHeight: 10, PersistedAt: persistTime, Size: 100},
+			{Txid: "txid-1", UUID: "uuid-2", ReceivedAtBlockHeight: 11, PersistedAt: persistTime, Size: 200},
+			{Txid: "txid-2", UUID: "uuid-3", ReceivedAtBlockHeight: 12, Size: 400},
+		},
+	}
+	adminServer := NewAdminServer(nil, fakeTransientStoreRetriever{"testchannel": store}, nil, nil)
+	adminServer.v = &mockValidator{}
+	mv := adminServer.v.(*mockValidator)
+
+	wrapTransientEntriesRequest := func(r *pb.TransientEntriesRequest) *pb.AdminOperation {
+		return &pb.AdminOperation{
+			Content: &pb.AdminOperation_TransientEntriesReq{
+				TransientEntriesReq: r,
+			},
+		}
+	}
+	persistedAt, err := ptypes.TimestampProto(persistTime)
+	assert.NoError(t, err)
+	txid1Entries := []*pb.TransientEntry{
+		{Txid: "txid-1", Uuid: "uuid-1", ReceivedAtBlockHeight: 10, PersistedAt: persistedAt, Size: 100},
+		{Txid: "txid-1", Uuid: "uuid-2", ReceivedAtBlockHeight: 11, PersistedAt: persistedAt, Size: 200},
+	}
+	txid2Entries := []*pb.TransientEntry{
+		{Txid: "txid-2", Uuid: "uuid-3", ReceivedAtBlockHeight: 12, Size: 400},
+	}
+
+	mv.On("validate").Return(wrapTransientEntriesRequest(&pb.TransientEntriesRequest{ChannelId: "testchannel"}), nil).Once()
+	response, err := adminServer.GetTransientEntries(context.Background(), nil)
+	assert.NoError(t, err)
+	assert.Equal(t, &pb.TransientEntriesResponse{Entries: append(txid1Entries, txid2Entries...), TotalSize: 700}, response)
+
+	mv.On("validate").Return(wrapTransientEntriesRequest(&pb.TransientEntriesRequest{ChannelId: "testchannel", Txids: []string{"txid-2", "txid-3"}}), nil).Once()
+	response, err = adminServer.GetTransientEntries(context.Background(), nil)
+	assert.NoError(t, err)
+	assert.Equal(t, &pb.TransientEntriesResponse{Entries: txid2Entries, TotalSize: 400}, response)
+	assert.Empty(t, store.purged)
+
+	mv.On("validate").Return(wrapTransientEntriesRequest(&pb.TransientEntriesRequest{ChannelId: "testchannel", Txids: []string{"txid-1"}}), nil).Once()
+	response, err = adminServer.PurgeTransientEntries(context.Background(), nil)
+	assert.NoError(t, err)
+	assert.Equal(t, &pb.TransientEntriesResponse{Entries: txid1Entries, TotalSize: 300}, response)
+	assert.Equal(t, []string{"txid-1"}, store.purged)
+
+	store.purged = nil
+	mv.On("validate").Return(wrapTransientEntriesRequest(&pb.TransientEntriesRequest{ChannelId: "testchannel"}), nil).Once()
+	_, err = adminServer.PurgeTransientEntries(context.Background(), nil)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"txid-1", "txid-2"}, store.purged)
+
+	errorTests := []struct {
+		op          *pb.AdminOperation
+		expectedErr string
+	}{
+		{op: &pb.AdminOperation{}, expectedErr: "request is nil"},
+		{op: wrapTransientEntriesRequest(&pb.TransientEntriesRequest{}), expectedErr: "channel ID must be provided"},
+		{op: wrapTransientEntriesRequest(&pb.TransientEntriesRequest{ChannelId: "nonexistent"}), expectedErr: "no transient store found for channel nonexistent"},
+	}
+	for _, tc := range errorTests {
+		mv.On("validate").Return(tc.op, nil).Once()
+		_, err = adminServer.GetTransientEntries(context.Background(), nil)
+		assert.EqualError(t, err, tc.expectedErr)
+		mv.On("validate").Return(tc.op, nil).Once()
+		_, err = adminServer.PurgeTransientEntries(context.Background(), nil)
+		assert.EqualError(t, err, tc.expectedErr)
+	}
+
+	store.entriesErr = errors.New("leveldb is unavailable")
+	mv.On("validate").Return(wrapTransientEntriesRequest(&pb.TransientEntriesRequest{ChannelId: "testchannel"}), nil).Once()
+	_, err = adminServer.GetTransientEntries(context.Background(), nil)
+	assert.EqualError(t, err, "failed retrieving the transient store entries: leveldb is unavailable")
+
+	mv.On("validate").Return(nil, accessDenied).Twice()
+	_, err = adminServer.GetTransientEntries(context.Background(), nil)
+	assert.Equal(t, accessDenied, err)
+	_, err = adminServer.PurgeTransientEntries(context.Background(), nil)
+	assert.Equal(t, accessDenied, err)
+}
+
+type fakeReconciler struct {
+	privdata.PvtDataReconciler
+	status       *privdata.ReconciliationStatus
+	reconcileErr error
+	reconciled   [][2]uint64
+}
+
+func (r *fakeReconciler) Reconcile(startBlock, endBlock uint64) error {
+	if r.reconcileErr != nil {
+		return r.reconcileErr
+	}
+	r.reconciled = append(r.reconciled, [2]uint64{startBlock, endBlock})
+	r.status = &privdata.ReconciliationStatus{StartBlock: startBlock, EndBlock: endBlock, InProgress: true}
+	return nil
+}
+
+func (r *fakeReconciler) Status() *privdata.ReconciliationStatus {
+	return r.status
+}
+
+type fakeMissingPvtDataTracker struct {
+	missing ledger.MissingPvtDataInfo
+	err     error
+}
+
+func (t *fakeMissingPvtDataTracker) GetMissingPvtDataInfoForMostRecentBlocks(maxBlocks int) (ledger.MissingPvtDataInfo, error) {
+	panic("not implemented")
+}
+
+func (t *fakeMissingPvtDataTracker) GetMissingPvtDataInfoForBlockRange(startBlock, endBlock uint64, maxBlocks int) (ledger.MissingPvtDataInfo, error) {
+	return t.missing, t.err
+}
+
+type fakeReconciliationSupport struct {
+	tracker    *fakeMissingPvtDataTracker
+	reconciler *fakeReconciler
+}
+
+func (s *fakeReconciliationSupport) MissingPvtDataTracker(channel string) (ledger.MissingPvtDataTracker, error) {
+	if channel != "testchannel" {
+		return nil, errors.Errorf("no such channel %s", channel)
+	}
+	return s.tracker, nil
+}
+
+func (s *fakeReconciliationSupport) Reconciler(channel string) (privdata.PvtDataReconciler, error) {
+	if channel != "testchannel" {
+		return nil, errors.Errorf("no such channel %s", channel)
+	}
+	return s.reconciler, nil
+}
+
+func TestPvtDataReconciliation(t *testing.T) {
+	missing := ledger.MissingPvtDataInfo{}
+	missing.Add(7, 1, "ns1", "col1")
+	missing.Add(5, 0, "ns2", "col2")
+	support := &fakeReconciliationSupport{
+		tracker:    &fakeMissingPvtDataTracker{missing: missing},
+		reconciler: &fakeReconciler{},
+	}
+	adminServer := NewAdminServer(nil, nil, support, nil)
+	adminServer.v = &mockValidator{}
+	mv := adminServer.v.(*mockValidator)
+
+	wrapPvtDataReconciliationRequest := func(r *pb.PvtDataReconciliationRequest) *pb.AdminOperation {
+		return &pb.AdminOperation{
+			Content: &pb.AdminOperation_PvtDataReconciliationReq{
+				PvtDataReconciliationReq: r,
+			},
+		}
+	}
+	request := wrapPvtDataReconciliationRequest(&pb.PvtDataReconciliationRequest{ChannelId: "testchannel", StartBlock: 2, EndBlock: 9})
+
+	mv.On("validate").Return(request, nil).Once()
+	missingResponse, err := adminServer.GetMissingPvtData(context.Background(), nil)
+	assert.NoError(t, err)
+	assert.Equal(t, &pb.MissingPvtDataResponse{
+		Missing: []*pb.MissingPvtData{
+			{BlockNum: 5, TxNum: 0, Namespace: "ns2", Collection: "col2"},
+			{BlockNum: 7, TxNum: 1, Namespace: "ns1", Collection: "col1"},
+		},
+	}, missingResponse)
+
+	mv.On("validate").Return(request, nil).Once()
+	_, err = adminServer.GetPvtDataReconciliationStatus(context.Background(), nil)
+	assert.EqualError(t, err, "no private data reconciliation was triggered on channel testchannel")
+
+	mv.On("validate").Return(request, nil).Once()
+	status, err := adminServer.ReconcilePvtData(context.Background(), nil)
+	assert.NoError(t, err)
+	assert.Equal(t, &pb.PvtDataReconciliationStatus{StartBlock: 2, EndBlock: 9, InProgress: true}, status)
+	assert.Equal(t, [][2]uint64{{2, 9}}, support.reconciler.reconciled)
+
+	startedAt := time.Unix(1500000000, 0)
+	finishedAt := startedAt.Add(time.Minute)
+	support.reconciler.status = &privdata.ReconciliationStatus{
+		StartBlock:         2,
+		EndBlock:           9,
+		ProcessedBlocks:    2,
+		LastProcessedBlock: 5,
+		ReconciledItems:    1,
+		UnavailableItems:   1,
+		HashMismatches:     1,
+		FailedPeers:        map[string]int{"peer1:7051": 1},
+		StartedAt:          startedAt,
+		FinishedAt:         finishedAt,
+	}
+	startedAtProto, err := ptypes.TimestampProto(startedAt)
+	assert.NoError(t, err)
+	finishedAtProto, err := ptypes.TimestampProto(finishedAt)
+	assert.NoError(t, err)
+	mv.On("validate").Return(request, nil).Once()
+	status, err = adminServer.GetPvtDataReconciliationStatus(context.Background(), nil)
+	assert.NoError(t, err)
+	assert.Equal(t, &pb.PvtDataReconciliationStatus{
+		StartBlock:         2,
+		EndBlock:           9,
+		ProcessedBlocks:    2,
+		LastProcessedBlock: 5,
+		ReconciledItems:    1,
+		UnavailableItems:   1,
+		HashMismatches:     1,
+		FailedPeers:        map[string]uint64{"peer1:7051": 1},
+		StartedAt:          startedAtProto,
+		FinishedAt:         finishedAtProto,
+	}, status)
+
+	support.reconciler.reconcileErr = errors.New("private data reconciliation is disabled")
+	mv.On("validate").Return(request, nil).Once()
+	_, err = adminServer.ReconcilePvtData(context.Background(), nil)
+	assert.EqualError(t, err, "failed triggering the private data reconciliation: private data reconciliation is disabled")
+
+	support.tracker.err = errors.New("leveldb is unavailable")
+	mv.On("validate").Return(request, nil).Once()
+	_, err = adminServer.GetMissingPvtData(context.Background(), nil)
+	assert.EqualError(t, err, "failed retrieving the missing private data: leveldb is unavailable")
+
+	errorTests := []struct {
+		op          *pb.AdminOperation
+		expectedErr string
+	}{
+		{op: &pb.AdminOperation{}, expectedErr: "request is nil"},
+		{op: wrapPvtDataReconciliationRequest(&pb.PvtDataReconciliationRequest{}), expectedErr: "channel ID must be provided"},
+	}
+	for _, tc := range errorTests {
+		mv.On("validate").Return(tc.op, nil).Once()
+		_, err = adminServer.GetMissingPvtData(context.Background(), nil)
+		assert.EqualError(t, err, tc.expectedErr)
+		mv.On("validate").Return(tc.op, nil).Once()
+		_, err = adminServer.ReconcilePvtData(context.Background(), nil)
+		assert.EqualError(t, err, tc.expectedErr)
+		mv.On("validate").Return(tc.op, nil).Once()
+		_, err = adminServer.GetPvtDataReconciliationStatus(context.Background(), nil)
+		assert.EqualError(t, err, tc.expectedErr)
+	}
+
+	nonexistent := wrapPvtDataReconciliationRequest(&pb.PvtDataReconciliationRequest{ChannelId: "nonexistent"})
+	mv.On("validate").Return(nonexistent, nil).Once()
+	_, err = adminServer.GetMissingPvtData(context.Background(), nil)
+	assert.EqualError(t, err, "failed retrieving the missing private data tracker: no such channel nonexistent")
+	mv.On("validate").Return(nonexistent, nil).Once()
+	_, err = adminServer.ReconcilePvtData(context.Background(), nil)
+	assert.EqualError(t, err, "failed retrieving the private data reconciler: no such channel nonexistent")
+
+	mv.On("validate").Return(nil, accessDenied).Times(3)
+	_, err = adminServer.GetMissingPvtData(context.Background(), nil)
+	assert.Equal(t, accessDenied, err)
+	_, err = adminServer.ReconcilePvtData(context.Background(), nil)
+	assert.Equal(t, accessDenied, err)
+	_, err = adminServer.GetPvtDataReconciliationStatus(context.Background(), nil)
+	assert.Equal(t, accessDenied, err)
+
+	adminServer = NewAdminServer(nil, nil, nil, nil)
+	adminServer.v = mv
+	mv.On("validate").Return(request, nil).Once()
+	_, err = adminServer.GetMissingPvtData(context.Background(), nil)
+	assert.EqualError(t, err, "private data reconciliation is not available")
+}
+
+type fakeChaincodeIndexManager struct {
+	indexes []*ledger.ChaincodeIndex
+	err     error
+}
+
+func (m *fakeChaincodeIndexManager) ListIndexes(chaincodeName string) ([]*ledger.ChaincodeIndex, error) {
+	return m.indexes, m.err
+}
+
+func (m *fakeChaincodeIndexManager) DropIndex(chaincodeName, collection, designDoc, indexName string) error {
+	if m.err != nil {
+		return m.err
+	}
+	var indexes []*ledger.ChaincodeIndex
+	for _, index := range m.indexes {
+		if index.Chaincode != chaincodeName || index.Collection != collection || index.DesignDoc != designDoc || index.Name != indexName {
+			indexes = append(indexes, index)
+		}
+	}
+	m.indexes = indexes
+	return nil
+}
+
+func (m *fakeChaincodeIndexManager) RebuildIndex(chaincodeName, collection string, indexDefinition []byte) (*ledger.ChaincodeIndex, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	index := &ledger.ChaincodeIndex{
+		Chaincode:  chaincodeName,
+		Collection: collection,
+		DesignDoc:  "indexOwnerDoc",
+		Name:       "indexOwner",
+		Definition: string(indexDefinition),
+	}
+	m.indexes = append(m.indexes, index)
+	return index, nil
+}
+
+type fakeChaincodeIndexManagerRetriever map[string]*fakeChaincodeIndexManager
+
+func (r fakeChaincodeIndexManagerRetriever) ChaincodeIndexManager(channel string) (ledger.ChaincodeIndexManager, error) {
+	indexManager, ok := r[channel]
+	if !ok {
+		return nil, errors.Errorf("no such channel %s", channel)
+	}
+	return indexManager, nil
+}
+
+func TestChaincodeIndexes(t *testing.T) {
+	indexManager := &fakeChaincodeIndexManager{}
+	adminServer := NewAdminServer(nil, nil, nil, fakeChaincodeIndexManagerRetriever{"testchannel": indexManager})
+	adminServer.v = &mockValidator{}
+	mv := adminServer.v.(*mockValidator)
+
+	wrapChaincodeIndexRequest := func(r *pb.ChaincodeIndexRequest) *pb.AdminOperation {
+		return &pb.AdminOperation{
+			Content: &pb.AdminOperation_ChaincodeIndexReq{
+				ChaincodeIndexReq: r,
+			},
+		}
+	}
+	definition := `{"index":{"fields":["owner"]},"ddoc":"indexOwnerDoc","name":"indexOwner","type":"json"}`
+
+	mv.On("validate").Return(wrapChaincodeIndexRequest(&pb.ChaincodeIndexRequest{
+		ChannelId:       "testchannel",
+		Chaincode:       "mycc",
+		Collection:      "coll1",
+		IndexDefinition: []byte(definition),
+	}), nil).Once()
+	index, err := adminServer.RebuildChaincodeIndex(context.Background(), nil)
+	assert.NoError(t, err)
+	expectedIndex := &pb.ChaincodeIndex{
+		Chaincode:  "mycc",
+		Collection: "coll1",
+		DesignDoc:  "indexOwnerDoc",
+		Name:       "indexOwner",
+		Definition: definition,
+	}
+	assert.Equal(t, expectedIndex, index)
+
+	listRequest := wrapChaincodeIndexRequest(&pb.ChaincodeIndexRequest{ChannelId: "testchannel", Chaincode: "mycc"})
+	mv.On("validate").Return(listRequest, nil).Once()
+	indexes, err := adminServer.ListChaincodeIndexes(context.Background(), nil)
+	assert.NoError(t, err)
+	assert.Equal(t, &pb.ChaincodeIndexesResponse{Indexes: []*pb.ChaincodeIndex{expectedIndex}}, indexes)
+
+	mv.On("validate").Return(wrapChaincodeIndexRequest(&pb.ChaincodeIndexRequest{
+		ChannelId:  "testchannel",
+		Chaincode:  "mycc",
+		Collection: "coll1",
+		DesignDoc:  "indexOwnerDoc",
+		IndexName:  "indexOwner",
+	}), nil).Once()
+	_, err = adminServer.DropChaincodeIndex(context.Background(), nil)
+	assert.NoError(t, err)
+	mv.On("validate").Return(listRequest, nil).Once()
+	indexes, err = adminServer.ListChaincodeIndexes(context.Background(), nil)
+	assert.NoError(t, err)
+	assert.Empty(t, indexes.Indexes)
+
+	indexManager.err = errors.New("chaincode [mycc] is not deployed on channel [testchannel]")
+	mv.On("validate").Return(listRequest, nil).Once()
+	_, err = adminServer.ListChaincodeIndexes(context.Background(), nil)
+	assert.EqualError(t, err, "failed listing the chaincode indexes: chaincode [mycc] is not deployed on channel [testchannel]")
+
+	errorTests := []struct {
+		op          *pb.AdminOperation
+		expectedErr string
+	}{
+		{op: &pb.AdminOperation{}, expectedErr: "request is nil"},
+		{op: wrapChaincodeIndexRequest(&pb.ChaincodeIndexRequest{}), expectedErr: "channel ID must be provided"},
+		{op: wrapChaincodeIndexRequest(&pb.ChaincodeIndexRequest{ChannelId: "testchannel"}), expectedErr: "chaincode name must be provided"},
+		{
+			op:          wrapChaincodeIndexRequest(&pb.ChaincodeIndexRequest{ChannelId: "nonexistent", Chaincode: "mycc"}),
+			expectedErr: "failed retrieving the chaincode index manager: no such channel nonexistent",
+		},
+	}
+	for _, tc := range errorTests {
+		mv.On("validate").Return(tc.op, nil).Once()
+		_, err = adminServer.ListChaincodeIndexes(context.Background(), nil)
+		assert.EqualError(t, err, tc.expectedErr)
+		mv.On("validate").Return(tc.op, nil).Once()
+		_, err = adminServer.DropChaincodeIndex(context.Background(), nil)
+		assert.EqualError(t, err, tc.expectedErr)
+		mv.On("validate").Return(tc.op, nil).Once()
+		_, err = adminServer.RebuildChaincodeIndex(context.Background(), nil)
+		assert.EqualError(t, err, tc.expectedErr)
+	}
+
+	mv.On("validate").Return(listRequest, nil).Once()
+	_, err = adminServer.DropChaincodeIndex(context.Background(), nil)
+	assert.EqualError(t, err, "design document and index name must be provided")
+	mv.On("validate").Return(listRequest, nil).Once()
+	_, err = adminServer.RebuildChaincodeIndex(context.Background(), nil)
+	assert.EqualError(t, err, "index definition must be provided")
+
+	mv.On("validate").Return(nil, accessDenied).Times(3)
+	_, err = adminServer.ListChaincodeIndexes(context.Background(), nil)
+	assert.Equal(t, accessDenied, err)
+	_, err = adminServer.DropChaincodeIndex(context.Background(), nil)
+	assert.Equal(t, accessDenied, err)
+	_, err = adminServer.RebuildChaincodeIndex(context.Background(), nil)
+	assert.Equal(t, accessDenied, err)
+
+	adminServer = NewAdminServer(nil, nil, nil, nil)
+	adminServer.v = mv
+	mv.On("validate").Return(listRequest, nil).Once()
+	_, err = adminServer.ListChaincodeIndexes(context.Background(), nil)
+	assert.EqualError(t, err, "chaincode index management is not available")
+}
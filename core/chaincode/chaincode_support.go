@@ -13,6 +13,7 @@ import (
 	"github.com/golang/protobuf/proto"
 	"github.com/hyperledger/fabric/common/metrics"
 	"github.com/hyperledger/fabric/common/util"
+	"github.com/hyperledger/fabric/core/chaincode/extcc"
 	"github.com/hyperledger/fabric/core/chaincode/platforms"
 	"github.com/hyperledger/fabric/core/common/ccprovider"
 	"github.com/hyperledger/fabric/core/common/sysccprovider"
@@ -57,6 +58,7 @@ type ChaincodeSupport struct {
 	appConfig        ApplicationConfigRetriever
 	HandlerMetrics   *HandlerMetrics
 	LaunchMetrics    *LaunchMetrics
+	QueryLimits      QueryLimitsProvider
 }
 
 // NewChaincodeSupport creates a new ChaincodeSupport instance.
@@ -87,6 +89,9 @@ func NewChaincodeSupport(
 		HandlerMetrics:   NewHandlerMetrics(metricsProvider),
 		LaunchMetrics:    NewLaunchMetrics(metricsProvider),
 	}
+	if config.QueryLimits != nil {
+		cs.QueryLimits = config.QueryLimits
+	}
 
 	// Keep TestQueries working
 	if !config.TLSEnabled {
@@ -107,11 +112,13 @@ func NewChaincodeSupport(
 	}
 
 	cs.Launcher = &RuntimeLauncher{
-		Runtime:         cs.Runtime,
-		Registry:        cs.HandlerRegistry,
-		PackageProvider: packageProvider,
-		StartupTimeout:  config.StartupTimeout,
-		Metrics:         cs.LaunchMetrics,
+		Runtime:           cs.Runtime,
+		Registry:          cs.HandlerRegistry,
+		PackageProvider:   packageProvider,
+		StartupTimeout:    config.StartupTimeout,
+		Metrics:           cs.LaunchMetrics,
+		ConnectionHandler: &extcc.ExternalChaincodeRuntime{},
+		StreamHandler:     cs,
 	}
 
 	return cs
@@ -185,6 +192,7 @@ func (cs *ChaincodeSupport) HandleChaincodeStream(stream ccintf.ChaincodeStream)
 		LedgerGetter:               peer.Default,
 		AppConfig:                  cs.appConfig,
 		Metrics:                    cs.HandlerMetrics,
+		QueryLimits:                cs.QueryLimits,
 	}
 
 	return handler.ProcessStream(stream)
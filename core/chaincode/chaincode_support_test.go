@@ -32,6 +32,7 @@ import (
 	"github.com/hyperledger/fabric/core/aclmgmt/resources"
 	"github.com/hyperledger/fabric/core/chaincode/accesscontrol"
 	"github.com/hyperledger/fabric/core/chaincode/mock"
+	"github.com/hyperledger/fabric/core/chaincode/persistence"
 	"github.com/hyperledger/fabric/core/chaincode/platforms"
 	"github.com/hyperledger/fabric/core/chaincode/platforms/golang"
 	"github.com/hyperledger/fabric/core/chaincode/shim"
@@ -181,7 +182,7 @@ func initMockPeer(chainIDs ...string) (*ChaincodeSupport, error) {
 		true,
 		ca.CertBytes(),
 		certGenerator,
-		&ccprovider.CCInfoFSImpl{},
+		&persistence.PackageProvider{LegacyPP: &ccprovider.CCInfoFSImpl{}},
 		lsccImpl,
 		mockAclProvider,
 		container.NewVMController(
@@ -7,11 +7,13 @@ SPDX-License-Identifier: Apache-2.0
 package chaincode
 
 import (
+	"path/filepath"
 	"strconv"
 	"strings"
 	"time"
 
 	"github.com/hyperledger/fabric/common/flogging"
+	"github.com/hyperledger/fabric/common/viperutil"
 	logging "github.com/op/go-logging"
 	"github.com/spf13/viper"
 )
@@ -29,6 +31,18 @@ type Config struct {
 	LogFormat      string
 	LogLevel       string
 	ShimLogLevel   string
+	QueryLimits    *QueryLimitsConfig
+
+	// ExternalBuilders are used, in order, to build and launch user chaincode
+	// in place of the docker containers
+	ExternalBuilders []ExternalBuilder
+}
+
+// ExternalBuilder is the configuration of an external chaincode builder.
+type ExternalBuilder struct {
+	Name                 string   `mapstructure:"name"`
+	Path                 string   `mapstructure:"path"`
+	EnvironmentWhitelist []string `mapstructure:"environmentWhitelist"`
 }
 
 func GlobalConfig() *Config {
@@ -58,6 +72,17 @@ func (c *Config) load() {
 	c.LogFormat = viper.GetString("chaincode.logging.format")
 	c.LogLevel = getLogLevelFromViper("chaincode.logging.level")
 	c.ShimLogLevel = getLogLevelFromViper("chaincode.logging.shim")
+
+	c.QueryLimits = &QueryLimitsConfig{
+		Default: QueryLimits{
+			MaxResults:  viper.GetInt("chaincode.queryLimits.maxResults"),
+			MaxBytes:    viper.GetInt("chaincode.queryLimits.maxBytes"),
+			MaxDuration: viper.GetDuration("chaincode.queryLimits.maxDuration"),
+		},
+		Overrides: getQueryLimitsOverridesFromViper("chaincode.queryLimits.overrides"),
+	}
+
+	c.ExternalBuilders = getExternalBuildersFromViper("chaincode.externalBuilders")
 }
 
 func toSeconds(s string, def int) time.Duration {
@@ -81,6 +106,66 @@ func getLogLevelFromViper(key string) string {
 	return levelString
 }
 
+// getQueryLimitsOverridesFromViper gets the overrides of the query limits from viper
+func getQueryLimitsOverridesFromViper(key string) []QueryLimitsOverride {
+	var config []struct {
+		Channel     string `mapstructure:"channel"`
+		Chaincode   string `mapstructure:"chaincode"`
+		MaxResults  int    `mapstructure:"maxResults"`
+		MaxBytes    int    `mapstructure:"maxBytes"`
+		MaxDuration string `mapstructure:"maxDuration"`
+	}
+	if err := viperutil.EnhancedExactUnmarshalKey(key, &config); err != nil {
+		chaincodeLogger.Warningf("%s has invalid query limits overrides, ignoring them: %s", key, err)
+		return nil
+	}
+
+	var overrides []QueryLimitsOverride
+	for _, c := range config {
+		var maxDuration time.Duration
+		if c.MaxDuration != "" {
+			var err error
+			if maxDuration, err = time.ParseDuration(c.MaxDuration); err != nil {
+				chaincodeLogger.Warningf("%s has invalid maximum duration %s for channel [%s] and chaincode [%s], ignoring the override",
+					key, c.MaxDuration, c.Channel, c.Chaincode)
+				continue
+			}
+		}
+		overrides = append(overrides, QueryLimitsOverride{
+			Channel:   c.Channel,
+			Chaincode: c.Chaincode,
+			QueryLimits: QueryLimits{
+				MaxResults:  c.MaxResults,
+				MaxBytes:    c.MaxBytes,
+				MaxDuration: maxDuration,
+			},
+		})
+	}
+	return overrides
+}
+
+// getExternalBuildersFromViper gets the external builders from viper
+func getExternalBuildersFromViper(key string) []ExternalBuilder {
+	var builders []ExternalBuilder
+	if err := viperutil.EnhancedExactUnmarshalKey(key, &builders); err != nil {
+		chaincodeLogger.Warningf("%s has invalid external builders, ignoring them: %s", key, err)
+		return nil
+	}
+
+	var valid []ExternalBuilder
+	for _, b := range builders {
+		if b.Path == "" {
+			chaincodeLogger.Warningf("%s has an external builder without path, ignoring it", key)
+			continue
+		}
+		if b.Name == "" {
+			b.Name = filepath.Base(b.Path)
+		}
+		valid = append(valid, b)
+	}
+	return valid
+}
+
 // DevModeUserRunsChaincode enables chaincode execution in a development
 // environment
 const DevModeUserRunsChaincode string = "dev"
@@ -79,6 +79,48 @@ var _ = Describe("Config", func() {
 			})
 		})
 
+		Context("when query limits are configured", func() {
+			BeforeEach(func() {
+				viper.Set("chaincode.queryLimits.maxResults", 100)
+				viper.Set("chaincode.queryLimits.maxBytes", 1000)
+				viper.Set("chaincode.queryLimits.maxDuration", "10s")
+				viper.Set("chaincode.queryLimits.overrides", []interface{}{
+					map[interface{}]interface{}{"channel": "mychannel", "chaincode": "mycc", "maxResults": 10, "maxDuration": "1s"},
+					map[interface{}]interface{}{"chaincode": "othercc", "maxDuration": "1 minute"},
+					map[interface{}]interface{}{"channel": "otherchannel"},
+				})
+			})
+
+			It("captures the limits and skips the invalid overrides", func() {
+				config := chaincode.GlobalConfig()
+				Expect(config.QueryLimits).To(Equal(&chaincode.QueryLimitsConfig{
+					Default: chaincode.QueryLimits{MaxResults: 100, MaxBytes: 1000, MaxDuration: 10 * time.Second},
+					Overrides: []chaincode.QueryLimitsOverride{
+						{Channel: "mychannel", Chaincode: "mycc", QueryLimits: chaincode.QueryLimits{MaxResults: 10, MaxDuration: time.Second}},
+						{Channel: "otherchannel"},
+					},
+				}))
+			})
+		})
+
+		Context("when external builders are configured", func() {
+			BeforeEach(func() {
+				viper.Set("chaincode.externalBuilders", []interface{}{
+					map[interface{}]interface{}{"name": "mybuilder", "path": "/opt/builders/mybuilder", "environmentWhitelist": []interface{}{"KUBECONFIG"}},
+					map[interface{}]interface{}{"path": "/opt/builders/unnamed"},
+					map[interface{}]interface{}{"name": "pathless"},
+				})
+			})
+
+			It("captures the builders and skips the ones without path", func() {
+				config := chaincode.GlobalConfig()
+				Expect(config.ExternalBuilders).To(Equal([]chaincode.ExternalBuilder{
+					{Name: "mybuilder", Path: "/opt/builders/mybuilder", EnvironmentWhitelist: []string{"KUBECONFIG"}},
+					{Name: "unnamed", Path: "/opt/builders/unnamed"},
+				}))
+			})
+		})
+
 		Context("when an invalid log level is configured", func() {
 			BeforeEach(func() {
 				viper.Set("chaincode.logging.level", "foo")
@@ -120,10 +162,20 @@ func capture() (restore func()) {
 		"chaincode.logging.level":  viper.GetString("chaincode.logging.level"),
 		"chaincode.logging.shim":   viper.GetString("chaincode.logging.shim"),
 	}
+	queryLimits := map[string]interface{}{
+		"chaincode.queryLimits.maxResults":  viper.Get("chaincode.queryLimits.maxResults"),
+		"chaincode.queryLimits.maxBytes":    viper.Get("chaincode.queryLimits.maxBytes"),
+		"chaincode.queryLimits.maxDuration": viper.Get("chaincode.queryLimits.maxDuration"),
+		"chaincode.queryLimits.overrides":   viper.Get("chaincode.queryLimits.overrides"),
+		"chaincode.externalBuilders":        viper.Get("chaincode.externalBuilders"),
+	}
 
 	return func() {
 		for k, val := range config {
 			viper.Set(k, val)
 		}
+		for k, val := range queryLimits {
+			viper.Set(k, val)
+		}
 	}
 }
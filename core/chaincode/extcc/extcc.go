@@ -0,0 +1,165 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package extcc
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"time"
+
+	"github.com/hyperledger/fabric/common/flogging"
+	"github.com/hyperledger/fabric/core/comm"
+	"github.com/hyperledger/fabric/core/container/ccintf"
+	pb "github.com/hyperledger/fabric/protos/peer"
+	"github.com/pkg/errors"
+)
+
+var extccLogger = flogging.MustGetLogger("extcc")
+
+// ConnectionFile is the name of the connection descriptor of a chaincode
+// running as a server, at the root of its code package
+const ConnectionFile = "connection.json"
+
+// DefaultDialTimeout is the time the peer waits for the connection to a
+// chaincode server when the connection descriptor does not specify it
+const DefaultDialTimeout = 3 * time.Second
+
+// Connection is the connection descriptor of a chaincode running as a server.
+// The keys and certificates are PEM encoded.
+type Connection struct {
+	Address            string `json:"address"`
+	DialTimeout        string `json:"dial_timeout"`
+	TLSRequired        bool   `json:"tls_required"`
+	ClientAuthRequired bool   `json:"client_auth_required"`
+	ClientKey          string `json:"client_key"`
+	ClientCert         string `json:"client_cert"`
+	RootCert           string `json:"root_cert"`
+}
+
+// StreamHandler handles the messages exchanged with a chaincode
+type StreamHandler interface {
+	HandleChaincodeStream(stream ccintf.ChaincodeStream) error
+}
+
+// ExternalChaincodeRuntime connects the peer to the chaincodes running as servers
+type ExternalChaincodeRuntime struct{}
+
+// Stream dials the chaincode server and hands the stream to the stream handler,
+// which handles the messages exchanged with the chaincode until the stream ends
+// or the context is cancelled.
+func (i *ExternalChaincodeRuntime) Stream(ctx context.Context, ccid string, ccinfo *ccintf.ChaincodeServerInfo, sHandler StreamHandler) error {
+	extccLogger.Debugf("Connecting to chaincode server %s at %s", ccid, ccinfo.Address)
+
+	client, err := comm.NewGRPCClient(ccinfo.ClientConfig)
+	if err != nil {
+		return errors.WithMessage(err, "error creating grpc client for chaincode "+ccid)
+	}
+	conn, err := client.NewConnection(ccinfo.Address, "")
+	if err != nil {
+		return errors.WithMessage(err, "error creating grpc connection to "+ccinfo.Address)
+	}
+	defer conn.Close()
+
+	stream, err := pb.NewChaincodeClient(conn).Connect(ctx)
+	if err != nil {
+		return errors.WithMessage(err, "error connecting to chaincode "+ccid)
+	}
+
+	// the peer initiated the stream, the chaincode registers and the rest of
+	// the protocol is unchanged
+	return sHandler.HandleChaincodeStream(stream)
+}
+
+// ChaincodeServerInfo returns the information to connect to the chaincode
+// server described by the connection descriptor of the code package. It
+// returns nil when the code package has no connection descriptor.
+func ChaincodeServerInfo(codePackage []byte) (*ccintf.ChaincodeServerInfo, error) {
+	connBytes, err := connectionFile(codePackage)
+	if err != nil {
+		return nil, err
+	}
+	if connBytes == nil {
+		return nil, nil
+	}
+
+	conn := &Connection{}
+	if err := json.Unmarshal(connBytes, conn); err != nil {
+		return nil, errors.Wrapf(err, "could not unmarshal %s", ConnectionFile)
+	}
+	if conn.Address == "" {
+		return nil, errors.Errorf("chaincode address not provided in %s", ConnectionFile)
+	}
+
+	clientConfig := comm.ClientConfig{
+		SecOpts: &comm.SecureOptions{},
+		KaOpts:  comm.DefaultKeepaliveOptions,
+		Timeout: DefaultDialTimeout,
+	}
+	if conn.DialTimeout != "" {
+		dialTimeout, err := time.ParseDuration(conn.DialTimeout)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid dial timeout in %s", ConnectionFile)
+		}
+		clientConfig.Timeout = dialTimeout
+	}
+
+	if conn.TLSRequired {
+		if conn.RootCert == "" {
+			return nil, errors.Errorf("root cert not provided in %s but TLS is required", ConnectionFile)
+		}
+		clientConfig.SecOpts.UseTLS = true
+		clientConfig.SecOpts.ServerRootCAs = [][]byte{[]byte(conn.RootCert)}
+
+		if conn.ClientAuthRequired {
+			if conn.ClientKey == "" || conn.ClientCert == "" {
+				return nil, errors.Errorf("client key and cert not provided in %s but client auth is required", ConnectionFile)
+			}
+			clientConfig.SecOpts.RequireClientCert = true
+			clientConfig.SecOpts.Key = []byte(conn.ClientKey)
+			clientConfig.SecOpts.Certificate = []byte(conn.ClientCert)
+		}
+	}
+
+	return &ccintf.ChaincodeServerInfo{
+		Address:      conn.Address,
+		ClientConfig: clientConfig,
+	}, nil
+}
+
+// connectionFile returns the content of the connection descriptor at the root
+// of the code package, or nil if the code package is not a .tar.gz holding one
+func connectionFile(codePackage []byte) ([]byte, error) {
+	gzr, err := gzip.NewReader(bytes.NewReader(codePackage))
+	if err != nil {
+		return nil, nil
+	}
+	tr := tar.NewReader(gzr)
+
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			return nil, nil
+		}
+		if err != nil {
+			extccLogger.Debugf("Could not read code package: %s", err)
+			return nil, nil
+		}
+		if header.Name != ConnectionFile {
+			continue
+		}
+		connBytes, err := ioutil.ReadAll(tr)
+		if err != nil {
+			return nil, errors.Wrapf(err, "could not read %s", ConnectionFile)
+		}
+		return connBytes, nil
+	}
+}
@@ -0,0 +1,223 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package extcc_test
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/common/crypto/tlsgen"
+	"github.com/hyperledger/fabric/core/chaincode/extcc"
+	"github.com/hyperledger/fabric/core/chaincode/shim"
+	"github.com/hyperledger/fabric/core/container/ccintf"
+	pb "github.com/hyperledger/fabric/protos/peer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func codePackage(t *testing.T, files map[string]string) []byte {
+	buf := &bytes.Buffer{}
+	gw := gzip.NewWriter(buf)
+	tw := tar.NewWriter(gw)
+	for name, content := range files {
+		err := tw.WriteHeader(&tar.Header{Name: name, Typeflag: tar.TypeReg, Mode: 0644, Size: int64(len(content))})
+		require.NoError(t, err)
+		_, err = tw.Write([]byte(content))
+		require.NoError(t, err)
+	}
+	require.NoError(t, tw.Close())
+	require.NoError(t, gw.Close())
+	return buf.Bytes()
+}
+
+func TestChaincodeServerInfo(t *testing.T) {
+	ccinfo, err := extcc.ChaincodeServerInfo([]byte("not a tar.gz"))
+	assert.NoError(t, err)
+	assert.Nil(t, ccinfo)
+
+	ccinfo, err = extcc.ChaincodeServerInfo(codePackage(t, map[string]string{"src/main.go": "package main"}))
+	assert.NoError(t, err)
+	assert.Nil(t, ccinfo)
+
+	ccinfo, err = extcc.ChaincodeServerInfo(codePackage(t, map[string]string{
+		"connection.json": `{"address":"ccserver:9999"}`,
+	}))
+	require.NoError(t, err)
+	assert.Equal(t, "ccserver:9999", ccinfo.Address)
+	assert.Equal(t, extcc.DefaultDialTimeout, ccinfo.ClientConfig.Timeout)
+	assert.False(t, ccinfo.ClientConfig.SecOpts.UseTLS)
+
+	ccinfo, err = extcc.ChaincodeServerInfo(codePackage(t, map[string]string{
+		"connection.json": `{"address":"ccserver:9999","dial_timeout":"1m","tls_required":true,"client_auth_required":true,"client_key":"key","client_cert":"cert","root_cert":"root"}`,
+	}))
+	require.NoError(t, err)
+	assert.Equal(t, time.Minute, ccinfo.ClientConfig.Timeout)
+	assert.True(t, ccinfo.ClientConfig.SecOpts.UseTLS)
+	assert.True(t, ccinfo.ClientConfig.SecOpts.RequireClientCert)
+	assert.Equal(t, []byte("key"), ccinfo.ClientConfig.SecOpts.Key)
+	assert.Equal(t, []byte("cert"), ccinfo.ClientConfig.SecOpts.Certificate)
+	assert.Equal(t, [][]byte{[]byte("root")}, ccinfo.ClientConfig.SecOpts.ServerRootCAs)
+
+	tests := []struct {
+		connection string
+		errMsg     string
+	}{
+		{`garbage`, "could not unmarshal connection.json: invalid character 'g' looking for beginning of value"},
+		{`{}`, "chaincode address not provided in connection.json"},
+		{`{"address":"ccserver:9999","dial_timeout":"soon"}`, "invalid dial timeout in connection.json: time: invalid duration"},
+		{`{"address":"ccserver:9999","tls_required":true}`, "root cert not provided in connection.json but TLS is required"},
+		{`{"address":"ccserver:9999","tls_required":true,"root_cert":"root","client_auth_required":true}`, "client key and cert not provided in connection.json but client auth is required"},
+	}
+	for _, tt := range tests {
+		_, err := extcc.ChaincodeServerInfo(codePackage(t, map[string]string{"connection.json": tt.connection}))
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), tt.errMsg)
+	}
+}
+
+type streamHandler struct {
+	registered        chan *pb.ChaincodeMessage
+	waitAfterRegister bool
+}
+
+func (s *streamHandler) HandleChaincodeStream(stream ccintf.ChaincodeStream) error {
+	msg, err := stream.Recv()
+	if err != nil {
+		return err
+	}
+	s.registered <- msg
+	if s.waitAfterRegister {
+		_, err = stream.Recv()
+		return err
+	}
+	return nil
+}
+
+func freeAddress(t *testing.T) string {
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer lis.Close()
+	return lis.Addr().String()
+}
+
+func TestStream(t *testing.T) {
+	ca, err := tlsgen.NewCA()
+	require.NoError(t, err)
+	serverPair, err := ca.NewServerCertKeyPair("127.0.0.1")
+	require.NoError(t, err)
+	clientPair, err := ca.NewClientCertKeyPair()
+	require.NoError(t, err)
+
+	address := freeAddress(t)
+	server := &shim.ChaincodeServer{
+		CCID:    "mycc:1.0",
+		Address: address,
+		CC:      &noopChaincode{},
+		TLSProps: shim.TLSProperties{
+			Key:           serverPair.Key,
+			Cert:          serverPair.Cert,
+			ClientCACerts: ca.CertBytes(),
+		},
+	}
+	go server.Start()
+
+	connection, err := json.Marshal(&extcc.Connection{
+		Address:            address,
+		TLSRequired:        true,
+		ClientAuthRequired: true,
+		ClientKey:          string(clientPair.Key),
+		ClientCert:         string(clientPair.Cert),
+		RootCert:           string(ca.CertBytes()),
+	})
+	require.NoError(t, err)
+	ccinfo, err := extcc.ChaincodeServerInfo(codePackage(t, map[string]string{"connection.json": string(connection)}))
+	require.NoError(t, err)
+
+	sHandler := &streamHandler{registered: make(chan *pb.ChaincodeMessage, 1)}
+	err = (&extcc.ExternalChaincodeRuntime{}).Stream(context.Background(), "mycc:1.0", ccinfo, sHandler)
+	require.NoError(t, err)
+
+	msg := <-sHandler.registered
+	assert.Equal(t, pb.ChaincodeMessage_REGISTER, msg.Type)
+	ccid := &pb.ChaincodeID{}
+	require.NoError(t, proto.Unmarshal(msg.Payload, ccid))
+	assert.Equal(t, "mycc:1.0", ccid.Name)
+
+	// the peer without a client certificate is rejected
+	ccinfo.ClientConfig.SecOpts.RequireClientCert = false
+	ccinfo.ClientConfig.SecOpts.Key = nil
+	ccinfo.ClientConfig.SecOpts.Certificate = nil
+	ccinfo.ClientConfig.Timeout = time.Second
+	err = (&extcc.ExternalChaincodeRuntime{}).Stream(context.Background(), "mycc:1.0", ccinfo, sHandler)
+	assert.Error(t, err)
+}
+
+func TestStreamConnectionRefused(t *testing.T) {
+	ccinfo, err := extcc.ChaincodeServerInfo(codePackage(t, map[string]string{
+		"connection.json": `{"address":"` + freeAddress(t) + `","dial_timeout":"100ms"}`,
+	}))
+	require.NoError(t, err)
+
+	err = (&extcc.ExternalChaincodeRuntime{}).Stream(context.Background(), "mycc:1.0", ccinfo, &streamHandler{})
+	assert.Contains(t, err.Error(), "error creating grpc connection to ")
+}
+
+func TestStreamCancelled(t *testing.T) {
+	address := freeAddress(t)
+	server := &shim.ChaincodeServer{
+		CCID:     "mycc:1.0",
+		Address:  address,
+		CC:       &noopChaincode{},
+		TLSProps: shim.TLSProperties{Disabled: true},
+	}
+	go server.Start()
+
+	ccinfo, err := extcc.ChaincodeServerInfo(codePackage(t, map[string]string{
+		"connection.json": `{"address":"` + address + `"}`,
+	}))
+	require.NoError(t, err)
+
+	// the stream handler waits for a message after the registration, which the
+	// chaincode never sends, until the stream is closed by cancelling the context
+	ctx, cancel := context.WithCancel(context.Background())
+	sHandler := &streamHandler{registered: make(chan *pb.ChaincodeMessage, 1), waitAfterRegister: true}
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- (&extcc.ExternalChaincodeRuntime{}).Stream(ctx, "mycc:1.0", ccinfo, sHandler)
+	}()
+
+	select {
+	case msg := <-sHandler.registered:
+		assert.Equal(t, pb.ChaincodeMessage_REGISTER, msg.Type)
+	case err := <-errCh:
+		t.Fatalf("stream failed before the chaincode registered: %s", err)
+	}
+	cancel()
+	select {
+	case err := <-errCh:
+		assert.Error(t, err)
+	case <-time.After(10 * time.Second):
+		t.Fatal("the stream was not closed when the context was cancelled")
+	}
+}
+
+type noopChaincode struct{}
+
+func (*noopChaincode) Init(stub shim.ChaincodeStubInterface) pb.Response {
+	return shim.Success(nil)
+}
+
+func (*noopChaincode) Invoke(stub shim.ChaincodeStubInterface) pb.Response {
+	return shim.Success(nil)
+}
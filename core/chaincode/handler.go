@@ -117,6 +117,11 @@ type ApplicationConfigRetriever interface {
 	GetApplicationConfig(cid string) (channelconfig.Application, bool)
 }
 
+// QueryLimitsProvider provides the limits of the queries of a chaincode on a channel.
+type QueryLimitsProvider interface {
+	QueryLimits(channelID, chaincodeName string) QueryLimits
+}
+
 // Handler implements the peer side of the chaincode stream.
 type Handler struct {
 	// Keepalive specifies the interval at which keep-alive messages are sent.
@@ -149,6 +154,8 @@ type Handler struct {
 	UUIDGenerator UUIDGenerator
 	// AppConfig is used to retrieve the application config for a channel
 	AppConfig ApplicationConfigRetriever
+	// QueryLimits is used to retrieve the limits of the queries of the chaincode
+	QueryLimits QueryLimitsProvider
 
 	// state holds the current handler state. It will be created, established, or
 	// ready.
@@ -207,6 +214,8 @@ func (h *Handler) handleMessageReadyState(msg *pb.ChaincodeMessage) error {
 		go h.HandleTransaction(msg, h.HandlePutState)
 	case pb.ChaincodeMessage_DEL_STATE:
 		go h.HandleTransaction(msg, h.HandleDelState)
+	case pb.ChaincodeMessage_PURGE_PRIVATE_DATA:
+		go h.HandleTransaction(msg, h.HandlePurgePrivateData)
 	case pb.ChaincodeMessage_INVOKE_CHAINCODE:
 		go h.HandleTransaction(msg, h.HandleInvokeChaincode)
 
@@ -218,6 +227,12 @@ func (h *Handler) handleMessageReadyState(msg *pb.ChaincodeMessage) error {
 		go h.HandleTransaction(msg, h.HandleGetQueryResult)
 	case pb.ChaincodeMessage_GET_HISTORY_FOR_KEY:
 		go h.HandleTransaction(msg, h.HandleGetHistoryForKey)
+	case pb.ChaincodeMessage_GET_STATE_AT_HEIGHT:
+		go h.HandleTransaction(msg, h.HandleGetStateAtHeight)
+	case pb.ChaincodeMessage_GET_STATE_BY_RANGE_AT_HEIGHT:
+		go h.HandleTransaction(msg, h.HandleGetStateByRangeAtHeight)
+	case pb.ChaincodeMessage_GET_PRIVATE_DATA_HASH:
+		go h.HandleTransaction(msg, h.HandleGetPrivateDataHash)
 	case pb.ChaincodeMessage_QUERY_STATE_NEXT:
 		go h.HandleTransaction(msg, h.HandleQueryStateNext)
 	case pb.ChaincodeMessage_QUERY_STATE_CLOSE:
@@ -647,6 +662,38 @@ func (h *Handler) HandleGetState(msg *pb.ChaincodeMessage, txContext *Transactio
 	return &pb.ChaincodeMessage{Type: pb.ChaincodeMessage_RESPONSE, Payload: res, Txid: msg.Txid, ChannelId: msg.ChannelId}, nil
 }
 
+// Handles query to ledger to get the hash of the value of a private data key. The hashes
+// are available on all the peers of the channel and hence, the read access to the collection
+// is not required
+func (h *Handler) HandleGetPrivateDataHash(msg *pb.ChaincodeMessage, txContext *TransactionContext) (*pb.ChaincodeMessage, error) {
+	getState := &pb.GetState{}
+	err := proto.Unmarshal(msg.Payload, getState)
+	if err != nil {
+		return nil, errors.Wrap(err, "unmarshal failed")
+	}
+
+	chaincodeName := h.ChaincodeName()
+	collection := getState.Collection
+	chaincodeLogger.Debugf("[%s] getting private data hash for chaincode %s, collection %s, key %s, channel %s",
+		shorttxid(msg.Txid), chaincodeName, collection, getState.Key, txContext.ChainID)
+
+	if !isCollectionSet(collection) {
+		return nil, errors.New("collection must not be empty")
+	}
+	if txContext.IsInitTransaction {
+		return nil, errors.New("private data APIs are not allowed in chaincode Init()")
+	}
+	res, _, err := txContext.TXSimulator.GetPrivateDataHash(chaincodeName, collection, getState.Key)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	if res == nil {
+		chaincodeLogger.Debugf("[%s] No private data hash associated with key: %s. Sending %s with an empty payload", shorttxid(msg.Txid), getState.Key, pb.ChaincodeMessage_RESPONSE)
+	}
+
+	return &pb.ChaincodeMessage{Type: pb.ChaincodeMessage_RESPONSE, Payload: res, Txid: msg.Txid, ChannelId: msg.ChannelId}, nil
+}
+
 // Handles query to ledger to get state metadata
 func (h *Handler) HandleGetStateMetadata(msg *pb.ChaincodeMessage, txContext *TransactionContext) (*pb.ChaincodeMessage, error) {
 	err := h.checkMetadataCap(msg)
@@ -715,6 +762,7 @@ func (h *Handler) HandleGetStateByRange(msg *pb.ChaincodeMessage, txContext *Tra
 
 	isPaginated := false
 
+	queryStartTime := time.Now()
 	chaincodeName := h.ChaincodeName()
 	collection := getStateByRange.Collection
 	if isCollectionSet(collection) {
@@ -748,6 +796,7 @@ func (h *Handler) HandleGetStateByRange(msg *pb.ChaincodeMessage, txContext *Tra
 	if err != nil {
 		return nil, errors.WithStack(err)
 	}
+	rangeIter = h.limitQuery(rangeIter, txContext, pb.ChaincodeMessage_GET_STATE_BY_RANGE, queryStartTime)
 	txContext.InitializeQueryContext(iterID, rangeIter)
 
 	payload, err := h.QueryResponseBuilder.BuildQueryResponse(txContext, rangeIter, iterID, isPaginated, totalReturnLimit)
@@ -839,6 +888,7 @@ func (h *Handler) HandleGetQueryResult(msg *pb.ChaincodeMessage, txContext *Tran
 	var executeIter commonledger.ResultsIterator
 	var paginationInfo map[string]interface{}
 
+	queryStartTime := time.Now()
 	chaincodeName := h.ChaincodeName()
 	collection := getQueryResult.Collection
 	if isCollectionSet(collection) {
@@ -865,6 +915,7 @@ func (h *Handler) HandleGetQueryResult(msg *pb.ChaincodeMessage, txContext *Tran
 		return nil, errors.WithStack(err)
 	}
 
+	executeIter = h.limitQuery(executeIter, txContext, pb.ChaincodeMessage_GET_QUERY_RESULT, queryStartTime)
 	txContext.InitializeQueryContext(iterID, executeIter)
 
 	payload, err := h.QueryResponseBuilder.BuildQueryResponse(txContext, executeIter, iterID, isPaginated, totalReturnLimit)
@@ -894,15 +945,125 @@ func (h *Handler) HandleGetHistoryForKey(msg *pb.ChaincodeMessage, txContext *Tr
 		return nil, errors.Wrap(err, "unmarshal failed")
 	}
 
-	historyIter, err := txContext.HistoryQueryExecutor.GetHistoryForKey(chaincodeName, getHistoryForKey.Key)
+	metadata, err := getQueryMetadataFromBytes(getHistoryForKey.Metadata)
+	if err != nil {
+		return nil, err
+	}
+
+	totalReturnLimit := calculateTotalReturnLimit(metadata)
+	isPaginated := false
+
+	var historyIter commonledger.ResultsIterator
+	var queryOptions map[string]interface{}
+
+	if isMetadataSetForPagination(metadata) {
+		queryOptions, err = createPaginationInfoFromMetadata(metadata, totalReturnLimit, pb.ChaincodeMessage_GET_HISTORY_FOR_KEY)
+		if err != nil {
+			return nil, err
+		}
+		isPaginated = true
+	}
+	if getHistoryForKey.StartBlock > 0 || getHistoryForKey.EndBlock > 0 || getHistoryForKey.Descending {
+		if queryOptions == nil {
+			queryOptions = make(map[string]interface{})
+		}
+		if getHistoryForKey.StartBlock > 0 {
+			queryOptions["startBlock"] = getHistoryForKey.StartBlock
+		}
+		if getHistoryForKey.EndBlock > 0 {
+			queryOptions["endBlock"] = getHistoryForKey.EndBlock
+		}
+		queryOptions["descending"] = getHistoryForKey.Descending
+	}
+
+	queryStartTime := time.Now()
+	collection := getHistoryForKey.Collection
+	if isCollectionSet(collection) {
+		if txContext.IsInitTransaction {
+			return nil, errors.New("private data APIs are not allowed in chaincode Init()")
+		}
+		if queryOptions != nil {
+			return nil, errors.New("block range, ordering and pagination are not supported for the history of private data")
+		}
+		if err := errorIfCreatorHasNoReadAccess(chaincodeName, collection, txContext); err != nil {
+			return nil, err
+		}
+		historyIter, err = txContext.HistoryQueryExecutor.GetPrivateDataHistoryForKey(chaincodeName, collection, getHistoryForKey.Key)
+	} else if queryOptions != nil {
+		historyIter, err = txContext.HistoryQueryExecutor.GetHistoryForKeyWithMetadata(chaincodeName, getHistoryForKey.Key, queryOptions)
+	} else {
+		historyIter, err = txContext.HistoryQueryExecutor.GetHistoryForKey(chaincodeName, getHistoryForKey.Key)
+	}
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	historyIter = h.limitQuery(historyIter, txContext, pb.ChaincodeMessage_GET_HISTORY_FOR_KEY, queryStartTime)
+	txContext.InitializeQueryContext(iterID, historyIter)
+	payload, err := h.QueryResponseBuilder.BuildQueryResponse(txContext, historyIter, iterID, isPaginated, totalReturnLimit)
+	if err != nil {
+		txContext.CleanupQueryContext(iterID)
+		return nil, errors.WithStack(err)
+	}
+
+	payloadBytes, err := proto.Marshal(payload)
+	if err != nil {
+		txContext.CleanupQueryContext(iterID)
+		return nil, errors.Wrap(err, "marshal failed")
+	}
+
+	chaincodeLogger.Debugf("Got keys and values. Sending %s", pb.ChaincodeMessage_RESPONSE)
+	return &pb.ChaincodeMessage{Type: pb.ChaincodeMessage_RESPONSE, Payload: payloadBytes, Txid: msg.Txid, ChannelId: msg.ChannelId}, nil
+}
+
+// Handles query to the state of a key at a given height
+func (h *Handler) HandleGetStateAtHeight(msg *pb.ChaincodeMessage, txContext *TransactionContext) (*pb.ChaincodeMessage, error) {
+	getStateAtHeight := &pb.GetStateAtHeight{}
+	err := proto.Unmarshal(msg.Payload, getStateAtHeight)
+	if err != nil {
+		return nil, errors.Wrap(err, "unmarshal failed")
+	}
+
+	chaincodeName := h.ChaincodeName()
+	chaincodeLogger.Debugf("[%s] getting state for chaincode %s, key %s, block number %d, channel %s",
+		shorttxid(msg.Txid), chaincodeName, getStateAtHeight.Key, getStateAtHeight.BlockNum, txContext.ChainID)
+
+	res, err := txContext.HistoryQueryExecutor.GetStateAtHeight(chaincodeName, getStateAtHeight.Key, getStateAtHeight.BlockNum)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	if res == nil {
+		chaincodeLogger.Debugf("[%s] No state associated with key: %s at block number %d. Sending %s with an empty payload",
+			shorttxid(msg.Txid), getStateAtHeight.Key, getStateAtHeight.BlockNum, pb.ChaincodeMessage_RESPONSE)
+	}
+
+	// Send response msg back to chaincode. GetState will not trigger event
+	return &pb.ChaincodeMessage{Type: pb.ChaincodeMessage_RESPONSE, Payload: res, Txid: msg.Txid, ChannelId: msg.ChannelId}, nil
+}
+
+// Handles range query to the state at a given height
+func (h *Handler) HandleGetStateByRangeAtHeight(msg *pb.ChaincodeMessage, txContext *TransactionContext) (*pb.ChaincodeMessage, error) {
+	iterID := h.UUIDGenerator.New()
+	chaincodeName := h.ChaincodeName()
+
+	getStateByRangeAtHeight := &pb.GetStateByRangeAtHeight{}
+	err := proto.Unmarshal(msg.Payload, getStateByRangeAtHeight)
+	if err != nil {
+		return nil, errors.Wrap(err, "unmarshal failed")
+	}
+
+	queryStartTime := time.Now()
+	rangeIter, err := txContext.HistoryQueryExecutor.GetStateRangeScanIteratorAtHeight(chaincodeName,
+		getStateByRangeAtHeight.StartKey, getStateByRangeAtHeight.EndKey, getStateByRangeAtHeight.BlockNum)
 	if err != nil {
 		return nil, errors.WithStack(err)
 	}
 
 	totalReturnLimit := calculateTotalReturnLimit(nil)
 
-	txContext.InitializeQueryContext(iterID, historyIter)
-	payload, err := h.QueryResponseBuilder.BuildQueryResponse(txContext, historyIter, iterID, false, totalReturnLimit)
+	rangeIter = h.limitQuery(rangeIter, txContext, pb.ChaincodeMessage_GET_STATE_BY_RANGE_AT_HEIGHT, queryStartTime)
+	txContext.InitializeQueryContext(iterID, rangeIter)
+	payload, err := h.QueryResponseBuilder.BuildQueryResponse(txContext, rangeIter, iterID, false, totalReturnLimit)
 	if err != nil {
 		txContext.CleanupQueryContext(iterID)
 		return nil, errors.WithStack(err)
@@ -950,12 +1111,12 @@ func createPaginationInfoFromMetadata(metadata *pb.QueryMetadata, totalReturnLim
 	paginationInfoMap := make(map[string]interface{})
 
 	switch queryType {
-	case pb.ChaincodeMessage_GET_QUERY_RESULT:
+	case pb.ChaincodeMessage_GET_QUERY_RESULT, pb.ChaincodeMessage_GET_HISTORY_FOR_KEY:
 		paginationInfoMap["bookmark"] = metadata.Bookmark
 	case pb.ChaincodeMessage_GET_STATE_BY_RANGE:
 		// this is a no-op for range query
 	default:
-		return nil, errors.New("query type must be either GetQueryResult or GetStateByRange")
+		return nil, errors.New("query type must be either GetQueryResult, GetStateByRange or GetHistoryForKey")
 	}
 
 	paginationInfoMap["limit"] = totalReturnLimit
@@ -1089,6 +1250,32 @@ func (h *Handler) HandleDelState(msg *pb.ChaincodeMessage, txContext *Transactio
 	return &pb.ChaincodeMessage{Type: pb.ChaincodeMessage_RESPONSE, Txid: msg.Txid, ChannelId: msg.ChannelId}, nil
 }
 
+// HandlePurgePrivateData records a purge of a private data key. Unlike a
+// delete, a purge removes the current and historical cleartext of the key
+// from every member peer once the transaction commits.
+func (h *Handler) HandlePurgePrivateData(msg *pb.ChaincodeMessage, txContext *TransactionContext) (*pb.ChaincodeMessage, error) {
+	delState := &pb.DelState{}
+	err := proto.Unmarshal(msg.Payload, delState)
+	if err != nil {
+		return nil, errors.Wrap(err, "unmarshal failed")
+	}
+
+	collection := delState.Collection
+	if !isCollectionSet(collection) {
+		return nil, errors.New("collection must not be empty")
+	}
+	if txContext.IsInitTransaction {
+		return nil, errors.New("private data APIs are not allowed in chaincode Init()")
+	}
+
+	err = txContext.TXSimulator.PurgePrivateData(h.ChaincodeName(), collection, delState.Key)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	return &pb.ChaincodeMessage{Type: pb.ChaincodeMessage_RESPONSE, Txid: msg.Txid, ChannelId: msg.ChannelId}, nil
+}
+
 // Handles requests that modify ledger state
 func (h *Handler) HandleInvokeChaincode(msg *pb.ChaincodeMessage, txContext *TransactionContext) (*pb.ChaincodeMessage, error) {
 	chaincodeLogger.Debugf("[%s] C-call-C", shorttxid(msg.Txid))
@@ -1167,9 +1354,12 @@ func (h *Handler) HandleInvokeChaincode(msg *pb.ChaincodeMessage, txContext *Tra
 
 		version = cd.CCVersion()
 
-		err = h.InstantiationPolicyChecker.CheckInstantiationPolicy(targetInstance.ChaincodeName, version, cd.(*ccprovider.ChaincodeData))
-		if err != nil {
-			return nil, errors.WithStack(err)
+		// only the chaincodes instantiated through LSCC have an instantiation policy
+		if ccData, ok := cd.(*ccprovider.ChaincodeData); ok {
+			err = h.InstantiationPolicyChecker.CheckInstantiationPolicy(targetInstance.ChaincodeName, version, ccData)
+			if err != nil {
+				return nil, errors.WithStack(err)
+			}
 		}
 	}
 
@@ -17,9 +17,12 @@ import (
 	"github.com/hyperledger/fabric/core/aclmgmt/resources"
 	"github.com/hyperledger/fabric/core/chaincode"
 	"github.com/hyperledger/fabric/core/chaincode/fake"
+	"github.com/hyperledger/fabric/core/chaincode/lifecycle"
 	"github.com/hyperledger/fabric/core/chaincode/mock"
 	"github.com/hyperledger/fabric/core/common/ccprovider"
 	"github.com/hyperledger/fabric/core/common/sysccprovider"
+	"github.com/hyperledger/fabric/protos/ledger/queryresult"
+	"github.com/hyperledger/fabric/protos/ledger/rwset/kvrwset"
 	pb "github.com/hyperledger/fabric/protos/peer"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/ginkgo/extensions/table"
@@ -48,6 +51,7 @@ var _ = Describe("Handler", func() {
 		fakeShimRequestsCompleted      *metricsfakes.Counter
 		fakeShimRequestDuration        *metricsfakes.Histogram
 		fakeExecuteTimeouts            *metricsfakes.Counter
+		fakeQueryLimitsExceeded        *metricsfakes.Counter
 
 		responseNotifier chan *pb.ChaincodeMessage
 		txContext        *chaincode.TransactionContext
@@ -102,12 +106,15 @@ var _ = Describe("Handler", func() {
 		fakeShimRequestDuration.WithReturns(fakeShimRequestDuration)
 		fakeExecuteTimeouts = &metricsfakes.Counter{}
 		fakeExecuteTimeouts.WithReturns(fakeExecuteTimeouts)
+		fakeQueryLimitsExceeded = &metricsfakes.Counter{}
+		fakeQueryLimitsExceeded.WithReturns(fakeQueryLimitsExceeded)
 
 		chaincodeMetrics := &chaincode.HandlerMetrics{
 			ShimRequestsReceived:  fakeShimRequestsReceived,
 			ShimRequestsCompleted: fakeShimRequestsCompleted,
 			ShimRequestDuration:   fakeShimRequestDuration,
 			ExecuteTimeouts:       fakeExecuteTimeouts,
+			QueryLimitsExceeded:   fakeQueryLimitsExceeded,
 		}
 
 		handler = &chaincode.Handler{
@@ -864,6 +871,91 @@ var _ = Describe("Handler", func() {
 		})
 	})
 
+	Describe("HandlePurgePrivateData", func() {
+		var incomingMessage *pb.ChaincodeMessage
+		var request *pb.DelState
+
+		BeforeEach(func() {
+			request = &pb.DelState{
+				Collection: "collection-name",
+				Key:        "purge-key",
+			}
+			payload, err := proto.Marshal(request)
+			Expect(err).NotTo(HaveOccurred())
+
+			incomingMessage = &pb.ChaincodeMessage{
+				Type:      pb.ChaincodeMessage_PURGE_PRIVATE_DATA,
+				Payload:   payload,
+				Txid:      "tx-id",
+				ChannelId: "channel-id",
+			}
+		})
+
+		It("calls PurgePrivateData on the transaction simulator", func() {
+			resp, err := handler.HandlePurgePrivateData(incomingMessage, txContext)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(resp).To(Equal(&pb.ChaincodeMessage{
+				Type:      pb.ChaincodeMessage_RESPONSE,
+				Txid:      "tx-id",
+				ChannelId: "channel-id",
+			}))
+
+			Expect(fakeTxSimulator.PurgePrivateDataCallCount()).To(Equal(1))
+			ccname, collection, key := fakeTxSimulator.PurgePrivateDataArgsForCall(0)
+			Expect(ccname).To(Equal("cc-instance-name"))
+			Expect(collection).To(Equal("collection-name"))
+			Expect(key).To(Equal("purge-key"))
+		})
+
+		Context("when unmarshalling the request fails", func() {
+			BeforeEach(func() {
+				incomingMessage.Payload = []byte("this-is-a-bogus-payload")
+			})
+
+			It("returns an error", func() {
+				_, err := handler.HandlePurgePrivateData(incomingMessage, txContext)
+				Expect(err).To(MatchError("unmarshal failed: proto: can't skip unknown wire type 4"))
+			})
+		})
+
+		Context("when collection is not set", func() {
+			BeforeEach(func() {
+				request.Collection = ""
+				payload, err := proto.Marshal(request)
+				Expect(err).NotTo(HaveOccurred())
+				incomingMessage.Payload = payload
+			})
+
+			It("returns an error", func() {
+				_, err := handler.HandlePurgePrivateData(incomingMessage, txContext)
+				Expect(err).To(MatchError("collection must not be empty"))
+				Expect(fakeTxSimulator.PurgePrivateDataCallCount()).To(Equal(0))
+			})
+		})
+
+		Context("when the transaction is an Init transaction", func() {
+			BeforeEach(func() {
+				txContext.IsInitTransaction = true
+			})
+
+			It("returns an error", func() {
+				_, err := handler.HandlePurgePrivateData(incomingMessage, txContext)
+				Expect(err).To(MatchError("private data APIs are not allowed in chaincode Init()"))
+			})
+		})
+
+		Context("when PurgePrivateData fails", func() {
+			BeforeEach(func() {
+				fakeTxSimulator.PurgePrivateDataReturns(errors.New("papaya"))
+			})
+
+			It("returns an error", func() {
+				_, err := handler.HandlePurgePrivateData(incomingMessage, txContext)
+				Expect(err).To(MatchError("papaya"))
+			})
+		})
+	})
+
 	Describe("HandleGetState", func() {
 		var (
 			incomingMessage  *pb.ChaincodeMessage
@@ -1043,6 +1135,96 @@ var _ = Describe("Handler", func() {
 		})
 	})
 
+	Describe("HandleGetPrivateDataHash", func() {
+		var incomingMessage *pb.ChaincodeMessage
+
+		BeforeEach(func() {
+			payload, err := proto.Marshal(&pb.GetState{
+				Key:        "get-state-key",
+				Collection: "collection-name",
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			incomingMessage = &pb.ChaincodeMessage{
+				Type:      pb.ChaincodeMessage_GET_PRIVATE_DATA_HASH,
+				Payload:   payload,
+				Txid:      "tx-id",
+				ChannelId: "channel-id",
+			}
+
+			fakeTxSimulator.GetPrivateDataHashReturns([]byte("private-data-hash"), &kvrwset.Version{BlockNum: 1, TxNum: 2}, nil)
+		})
+
+		It("calls GetPrivateDataHash on the transaction simulator without checking the read access", func() {
+			_, err := handler.HandleGetPrivateDataHash(incomingMessage, txContext)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(fakeCollectionStore.HasReadAccessCallCount()).To(Equal(0))
+			Expect(fakeTxSimulator.GetPrivateDataHashCallCount()).To(Equal(1))
+			ccname, collection, key := fakeTxSimulator.GetPrivateDataHashArgsForCall(0)
+			Expect(ccname).To(Equal("cc-instance-name"))
+			Expect(collection).To(Equal("collection-name"))
+			Expect(key).To(Equal("get-state-key"))
+		})
+
+		It("returns the response message with the hash", func() {
+			resp, err := handler.HandleGetPrivateDataHash(incomingMessage, txContext)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(resp).To(Equal(&pb.ChaincodeMessage{
+				Type:      pb.ChaincodeMessage_RESPONSE,
+				Payload:   []byte("private-data-hash"),
+				Txid:      "tx-id",
+				ChannelId: "channel-id",
+			}))
+		})
+
+		Context("when unmarshalling the request fails", func() {
+			BeforeEach(func() {
+				incomingMessage.Payload = []byte("this-is-a-bogus-payload")
+			})
+
+			It("returns an error", func() {
+				_, err := handler.HandleGetPrivateDataHash(incomingMessage, txContext)
+				Expect(err).To(MatchError("unmarshal failed: proto: can't skip unknown wire type 4"))
+			})
+		})
+
+		Context("when the collection is not set", func() {
+			BeforeEach(func() {
+				payload, err := proto.Marshal(&pb.GetState{Key: "get-state-key"})
+				Expect(err).NotTo(HaveOccurred())
+				incomingMessage.Payload = payload
+			})
+
+			It("returns an error", func() {
+				_, err := handler.HandleGetPrivateDataHash(incomingMessage, txContext)
+				Expect(err).To(MatchError("collection must not be empty"))
+			})
+		})
+
+		Context("when the transaction is an Init transaction", func() {
+			BeforeEach(func() {
+				txContext.IsInitTransaction = true
+			})
+
+			It("returns an error", func() {
+				_, err := handler.HandleGetPrivateDataHash(incomingMessage, txContext)
+				Expect(err).To(MatchError("private data APIs are not allowed in chaincode Init()"))
+			})
+		})
+
+		Context("when GetPrivateDataHash fails", func() {
+			BeforeEach(func() {
+				fakeTxSimulator.GetPrivateDataHashReturns(nil, nil, errors.New("pineapple"))
+			})
+
+			It("returns the error from GetPrivateDataHash", func() {
+				_, err := handler.HandleGetPrivateDataHash(incomingMessage, txContext)
+				Expect(err).To(MatchError("pineapple"))
+			})
+		})
+	})
+
 	Describe("HandleGetStateMetadata", func() {
 		var (
 			incomingMessage  *pb.ChaincodeMessage
@@ -1311,6 +1493,61 @@ var _ = Describe("Handler", func() {
 			Expect(resp).To(Equal(expectedResponse))
 		})
 
+		Context("when query limits are configured", func() {
+			var queryResult *queryresult.KV
+
+			BeforeEach(func() {
+				handler.QueryLimits = &chaincode.QueryLimitsConfig{
+					Overrides: []chaincode.QueryLimitsOverride{
+						{Chaincode: "cc-instance-name", QueryLimits: chaincode.QueryLimits{MaxResults: 1}},
+					},
+				}
+				queryResult = &queryresult.KV{Key: "key"}
+				fakeIterator.NextReturns(queryResult, nil)
+			})
+
+			It("fails the query when a limit is exceeded", func() {
+				_, err := handler.HandleGetStateByRange(incomingMessage, txContext)
+				Expect(err).NotTo(HaveOccurred())
+
+				iter := txContext.GetQueryIterator("generated-query-id")
+				Expect(iter).NotTo(Equal(fakeIterator))
+				result, err := iter.Next()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(result).To(BeIdenticalTo(queryResult))
+				_, err = iter.Next()
+				Expect(err).To(MatchError("GET_STATE_BY_RANGE query of chaincode cc-instance-name on channel channel-id exceeded the limit on the results [1]"))
+				Expect(err).To(BeAssignableToTypeOf(&chaincode.QueryLimitExceededError{}))
+
+				Expect(fakeQueryLimitsExceeded.WithCallCount()).To(Equal(1))
+				Expect(fakeQueryLimitsExceeded.WithArgsForCall(0)).To(Equal([]string{
+					"type", "GET_STATE_BY_RANGE",
+					"channel", "channel-id",
+					"chaincode", "cc-instance-name",
+					"limit", "results",
+				}))
+				Expect(fakeQueryLimitsExceeded.AddCallCount()).To(Equal(1))
+				Expect(fakeQueryLimitsExceeded.AddArgsForCall(0)).To(BeNumerically("~", 1.0))
+			})
+
+			Context("when the limits of the chaincode are disabled", func() {
+				BeforeEach(func() {
+					handler.QueryLimits = &chaincode.QueryLimitsConfig{
+						Default: chaincode.QueryLimits{MaxResults: 1},
+						Overrides: []chaincode.QueryLimitsOverride{
+							{Chaincode: "cc-instance-name"},
+						},
+					}
+				})
+
+				It("does not limit the query", func() {
+					_, err := handler.HandleGetStateByRange(incomingMessage, txContext)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(txContext.GetQueryIterator("generated-query-id")).To(Equal(fakeIterator))
+				})
+			})
+		})
+
 		Context("when collection is not set", func() {
 			It("calls GetStateRangeScanIterator on the transaction simulator", func() {
 				_, err := handler.HandleGetStateByRange(incomingMessage, txContext)
@@ -1923,6 +2160,193 @@ var _ = Describe("Handler", func() {
 			Expect(iterID).To(Equal("generated-query-id"))
 		})
 
+		Context("when a block range and descending order are requested", func() {
+			BeforeEach(func() {
+				request.StartBlock = 5
+				request.EndBlock = 10
+				request.Descending = true
+				payload, err := proto.Marshal(request)
+				Expect(err).NotTo(HaveOccurred())
+				incomingMessage.Payload = payload
+
+				fakeHistoryQueryExecutor.GetHistoryForKeyWithMetadataReturns(fakeIterator, nil)
+			})
+
+			It("calls GetHistoryForKeyWithMetadata on the history query executor", func() {
+				_, err := handler.HandleGetHistoryForKey(incomingMessage, txContext)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(fakeHistoryQueryExecutor.GetHistoryForKeyCallCount()).To(Equal(0))
+				Expect(fakeHistoryQueryExecutor.GetHistoryForKeyWithMetadataCallCount()).To(Equal(1))
+				ccname, key, metadata := fakeHistoryQueryExecutor.GetHistoryForKeyWithMetadataArgsForCall(0)
+				Expect(ccname).To(Equal("cc-instance-name"))
+				Expect(key).To(Equal("history-key"))
+				Expect(metadata).To(Equal(map[string]interface{}{
+					"startBlock": uint64(5),
+					"endBlock":   uint64(10),
+					"descending": true,
+				}))
+			})
+
+			It("builds a query response that is not paginated", func() {
+				_, err := handler.HandleGetHistoryForKey(incomingMessage, txContext)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(fakeQueryResponseBuilder.BuildQueryResponseCallCount()).To(Equal(1))
+				_, _, _, isPaginated, _ := fakeQueryResponseBuilder.BuildQueryResponseArgsForCall(0)
+				Expect(isPaginated).To(BeFalse())
+			})
+
+			Context("when the history query executor fails", func() {
+				BeforeEach(func() {
+					fakeHistoryQueryExecutor.GetHistoryForKeyWithMetadataReturns(nil, errors.New("anchovies"))
+				})
+
+				It("returns an error", func() {
+					_, err := handler.HandleGetHistoryForKey(incomingMessage, txContext)
+					Expect(err).To(MatchError("anchovies"))
+				})
+			})
+		})
+
+		Context("when the metadata requests pagination", func() {
+			BeforeEach(func() {
+				queryMetadata := &pb.QueryMetadata{
+					PageSize: 10,
+					Bookmark: "3:1",
+				}
+				requestMetadata, err := proto.Marshal(queryMetadata)
+				Expect(err).NotTo(HaveOccurred())
+				request.EndBlock = 20
+				request.Metadata = requestMetadata
+				payload, err := proto.Marshal(request)
+				Expect(err).NotTo(HaveOccurred())
+				incomingMessage.Payload = payload
+
+				fakeHistoryQueryExecutor.GetHistoryForKeyWithMetadataReturns(fakeIterator, nil)
+			})
+
+			It("passes the pagination info to the history query executor", func() {
+				_, err := handler.HandleGetHistoryForKey(incomingMessage, txContext)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(fakeHistoryQueryExecutor.GetHistoryForKeyWithMetadataCallCount()).To(Equal(1))
+				_, _, metadata := fakeHistoryQueryExecutor.GetHistoryForKeyWithMetadataArgsForCall(0)
+				Expect(metadata).To(Equal(map[string]interface{}{
+					"endBlock":   uint64(20),
+					"descending": false,
+					"limit":      int32(10),
+					"bookmark":   "3:1",
+				}))
+			})
+
+			It("builds a paginated query response", func() {
+				_, err := handler.HandleGetHistoryForKey(incomingMessage, txContext)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(fakeQueryResponseBuilder.BuildQueryResponseCallCount()).To(Equal(1))
+				_, _, _, isPaginated, totalReturnLimit := fakeQueryResponseBuilder.BuildQueryResponseArgsForCall(0)
+				Expect(isPaginated).To(BeTrue())
+				Expect(totalReturnLimit).To(Equal(int32(10)))
+			})
+		})
+
+		Context("when collection is set", func() {
+			BeforeEach(func() {
+				request.Collection = "collection-name"
+				payload, err := proto.Marshal(request)
+				Expect(err).NotTo(HaveOccurred())
+				incomingMessage.Payload = payload
+
+				fakeCollectionStore.HasReadAccessReturns(true, nil)
+				fakeHistoryQueryExecutor.GetPrivateDataHistoryForKeyReturns(fakeIterator, nil)
+			})
+
+			It("calls GetPrivateDataHistoryForKey on the history query executor", func() {
+				_, err := handler.HandleGetHistoryForKey(incomingMessage, txContext)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(fakeHistoryQueryExecutor.GetHistoryForKeyCallCount()).To(Equal(0))
+				Expect(fakeHistoryQueryExecutor.GetPrivateDataHistoryForKeyCallCount()).To(Equal(1))
+				ccname, collection, key := fakeHistoryQueryExecutor.GetPrivateDataHistoryForKeyArgsForCall(0)
+				Expect(ccname).To(Equal("cc-instance-name"))
+				Expect(collection).To(Equal("collection-name"))
+				Expect(key).To(Equal("history-key"))
+			})
+
+			It("initializes a query context", func() {
+				_, err := handler.HandleGetHistoryForKey(incomingMessage, txContext)
+				Expect(err).NotTo(HaveOccurred())
+
+				iter := txContext.GetQueryIterator("generated-query-id")
+				Expect(iter).To(Equal(fakeIterator))
+			})
+
+			Context("and the history query executor fails", func() {
+				BeforeEach(func() {
+					fakeHistoryQueryExecutor.GetPrivateDataHistoryForKeyReturns(nil, errors.New("olives"))
+				})
+
+				It("returns the error", func() {
+					_, err := handler.HandleGetHistoryForKey(incomingMessage, txContext)
+					Expect(err).To(MatchError("olives"))
+				})
+			})
+
+			Context("and the tx creator has no read access", func() {
+				BeforeEach(func() {
+					fakeCollectionStore.HasReadAccessReturns(false, nil)
+				})
+
+				It("returns the error", func() {
+					_, err := handler.HandleGetHistoryForKey(incomingMessage, txContext)
+					Expect(err).To(MatchError("tx creator does not have read access" +
+						" permission on privatedata in chaincodeName:cc-instance-name" +
+						" collectionName: collection-name"))
+					Expect(fakeHistoryQueryExecutor.GetPrivateDataHistoryForKeyCallCount()).To(Equal(0))
+				})
+			})
+
+			Context("and the block range is specified", func() {
+				BeforeEach(func() {
+					request.StartBlock = 2
+					payload, err := proto.Marshal(request)
+					Expect(err).NotTo(HaveOccurred())
+					incomingMessage.Payload = payload
+				})
+
+				It("returns an error", func() {
+					_, err := handler.HandleGetHistoryForKey(incomingMessage, txContext)
+					Expect(err).To(MatchError("block range, ordering and pagination are not supported for the history of private data"))
+				})
+			})
+
+			Context("and it is an Init transaction", func() {
+				BeforeEach(func() {
+					txContext.IsInitTransaction = true
+				})
+
+				It("returns the error", func() {
+					_, err := handler.HandleGetHistoryForKey(incomingMessage, txContext)
+					Expect(err).To(MatchError("private data APIs are not allowed in chaincode Init()"))
+				})
+			})
+		})
+
+		Context("when unmarshalling the query metadata fails", func() {
+			BeforeEach(func() {
+				request.Metadata = []byte("bogus-metadata")
+				payload, err := proto.Marshal(request)
+				Expect(err).NotTo(HaveOccurred())
+				incomingMessage.Payload = payload
+			})
+
+			It("returns an error", func() {
+				_, err := handler.HandleGetHistoryForKey(incomingMessage, txContext)
+				Expect(err).To(MatchError(ContainSubstring("unmarshal failed")))
+			})
+		})
+
 		Context("when unmarshalling the request fails", func() {
 			BeforeEach(func() {
 				incomingMessage.Payload = []byte("this-is-a-bogus-payload")
@@ -1990,6 +2414,188 @@ var _ = Describe("Handler", func() {
 		})
 	})
 
+	Describe("HandleGetStateAtHeight", func() {
+		var (
+			request         *pb.GetStateAtHeight
+			incomingMessage *pb.ChaincodeMessage
+		)
+
+		BeforeEach(func() {
+			request = &pb.GetStateAtHeight{
+				Key:      "get-state-key",
+				BlockNum: 5,
+			}
+			payload, err := proto.Marshal(request)
+			Expect(err).NotTo(HaveOccurred())
+
+			incomingMessage = &pb.ChaincodeMessage{
+				Type:      pb.ChaincodeMessage_GET_STATE_AT_HEIGHT,
+				Payload:   payload,
+				Txid:      "tx-id",
+				ChannelId: "channel-id",
+			}
+
+			fakeHistoryQueryExecutor.GetStateAtHeightReturns([]byte("get-state-at-height-response"), nil)
+		})
+
+		It("calls GetStateAtHeight on the history query executor", func() {
+			_, err := handler.HandleGetStateAtHeight(incomingMessage, txContext)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(fakeHistoryQueryExecutor.GetStateAtHeightCallCount()).To(Equal(1))
+			ccname, key, blockNum := fakeHistoryQueryExecutor.GetStateAtHeightArgsForCall(0)
+			Expect(ccname).To(Equal("cc-instance-name"))
+			Expect(key).To(Equal("get-state-key"))
+			Expect(blockNum).To(Equal(uint64(5)))
+		})
+
+		It("returns the response message from GetStateAtHeight", func() {
+			resp, err := handler.HandleGetStateAtHeight(incomingMessage, txContext)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(resp).To(Equal(&pb.ChaincodeMessage{
+				Type:      pb.ChaincodeMessage_RESPONSE,
+				Payload:   []byte("get-state-at-height-response"),
+				Txid:      "tx-id",
+				ChannelId: "channel-id",
+			}))
+		})
+
+		Context("when unmarshalling the request fails", func() {
+			BeforeEach(func() {
+				incomingMessage.Payload = []byte("this-is-a-bogus-payload")
+			})
+
+			It("returns an error", func() {
+				_, err := handler.HandleGetStateAtHeight(incomingMessage, txContext)
+				Expect(err).To(MatchError("unmarshal failed: proto: can't skip unknown wire type 4"))
+			})
+		})
+
+		Context("when the history query executor fails", func() {
+			BeforeEach(func() {
+				fakeHistoryQueryExecutor.GetStateAtHeightReturns(nil, errors.New("tomato"))
+			})
+
+			It("returns an error", func() {
+				_, err := handler.HandleGetStateAtHeight(incomingMessage, txContext)
+				Expect(err).To(MatchError("tomato"))
+			})
+		})
+	})
+
+	Describe("HandleGetStateByRangeAtHeight", func() {
+		var (
+			request               *pb.GetStateByRangeAtHeight
+			incomingMessage       *pb.ChaincodeMessage
+			expectedQueryResponse *pb.QueryResponse
+			fakeIterator          *mock.QueryResultsIterator
+		)
+
+		BeforeEach(func() {
+			request = &pb.GetStateByRangeAtHeight{
+				StartKey: "start-key",
+				EndKey:   "end-key",
+				BlockNum: 5,
+			}
+			payload, err := proto.Marshal(request)
+			Expect(err).NotTo(HaveOccurred())
+
+			incomingMessage = &pb.ChaincodeMessage{
+				Type:      pb.ChaincodeMessage_GET_STATE_BY_RANGE_AT_HEIGHT,
+				Payload:   payload,
+				Txid:      "tx-id",
+				ChannelId: "channel-id",
+			}
+
+			expectedQueryResponse = &pb.QueryResponse{
+				Id: "query-response-id",
+			}
+			fakeQueryResponseBuilder.BuildQueryResponseReturns(expectedQueryResponse, nil)
+
+			fakeIterator = &mock.QueryResultsIterator{}
+			fakeHistoryQueryExecutor.GetStateRangeScanIteratorAtHeightReturns(fakeIterator, nil)
+		})
+
+		It("calls GetStateRangeScanIteratorAtHeight on the history query executor", func() {
+			_, err := handler.HandleGetStateByRangeAtHeight(incomingMessage, txContext)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(fakeHistoryQueryExecutor.GetStateRangeScanIteratorAtHeightCallCount()).To(Equal(1))
+			ccname, startKey, endKey, blockNum := fakeHistoryQueryExecutor.GetStateRangeScanIteratorAtHeightArgsForCall(0)
+			Expect(ccname).To(Equal("cc-instance-name"))
+			Expect(startKey).To(Equal("start-key"))
+			Expect(endKey).To(Equal("end-key"))
+			Expect(blockNum).To(Equal(uint64(5)))
+		})
+
+		It("initializes a query context", func() {
+			_, err := handler.HandleGetStateByRangeAtHeight(incomingMessage, txContext)
+			Expect(err).NotTo(HaveOccurred())
+
+			pqr := txContext.GetPendingQueryResult("generated-query-id")
+			Expect(pqr).To(Equal(&chaincode.PendingQueryResult{}))
+			iter := txContext.GetQueryIterator("generated-query-id")
+			Expect(iter).To(Equal(fakeIterator))
+			retCount := txContext.GetTotalReturnCount("generated-query-id")
+			Expect(*retCount).To(Equal(int32(0)))
+		})
+
+		It("builds a query response", func() {
+			_, err := handler.HandleGetStateByRangeAtHeight(incomingMessage, txContext)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(fakeQueryResponseBuilder.BuildQueryResponseCallCount()).To(Equal(1))
+			tctx, iter, iterID, _, _ := fakeQueryResponseBuilder.BuildQueryResponseArgsForCall(0)
+			Expect(tctx).To(Equal(txContext))
+			Expect(iter).To(Equal(fakeIterator))
+			Expect(iterID).To(Equal("generated-query-id"))
+		})
+
+		Context("when unmarshalling the request fails", func() {
+			BeforeEach(func() {
+				incomingMessage.Payload = []byte("this-is-a-bogus-payload")
+			})
+
+			It("returns an error", func() {
+				_, err := handler.HandleGetStateByRangeAtHeight(incomingMessage, txContext)
+				Expect(err).To(MatchError("unmarshal failed: proto: can't skip unknown wire type 4"))
+			})
+		})
+
+		Context("when the history query executor fails", func() {
+			BeforeEach(func() {
+				fakeHistoryQueryExecutor.GetStateRangeScanIteratorAtHeightReturns(nil, errors.New("olives"))
+			})
+
+			It("returns an error", func() {
+				_, err := handler.HandleGetStateByRangeAtHeight(incomingMessage, txContext)
+				Expect(err).To(MatchError("olives"))
+			})
+		})
+
+		Context("when building the query response fails", func() {
+			BeforeEach(func() {
+				fakeQueryResponseBuilder.BuildQueryResponseReturns(nil, errors.New("mushrooms"))
+			})
+
+			It("returns an error", func() {
+				_, err := handler.HandleGetStateByRangeAtHeight(incomingMessage, txContext)
+				Expect(err).To(MatchError("mushrooms"))
+			})
+
+			It("cleans up the query context", func() {
+				handler.HandleGetStateByRangeAtHeight(incomingMessage, txContext)
+
+				pqr := txContext.GetPendingQueryResult("generated-query-id")
+				Expect(pqr).To(BeNil())
+				iter := txContext.GetQueryIterator("generated-query-id")
+				Expect(iter).To(BeNil())
+				retCount := txContext.GetTotalReturnCount("generated-query-id")
+				Expect(retCount).To(BeNil())
+			})
+		})
+	})
+
 	Describe("HandleInvokeChaincode", func() {
 		var (
 			expectedSignedProp      *pb.SignedProposal
@@ -2236,6 +2842,25 @@ var _ = Describe("Handler", func() {
 				Expect(cd).To(Equal(targetDefinition))
 			})
 
+			Context("when the chaincode definition is committed through the new lifecycle", func() {
+				BeforeEach(func() {
+					fakeDefinitionGetter.ChaincodeDefinitionReturns(&lifecycle.LegacyDefinition{
+						Name:    "target-chaincode-name",
+						Version: "target-chaincode-version",
+					}, nil)
+				})
+
+				It("does not check the instantiation policy", func() {
+					_, err := handler.HandleInvokeChaincode(incomingMessage, txContext)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(fakeInstantiationPolicyChecker.CheckInstantiationPolicyCallCount()).To(Equal(0))
+					Expect(fakeInvoker.InvokeCallCount()).To(Equal(1))
+					_, cccid, _ := fakeInvoker.InvokeArgsForCall(0)
+					Expect(cccid.Version).To(Equal("target-chaincode-version"))
+				})
+			})
+
 			Context("when getting the chaincode definition fails", func() {
 				BeforeEach(func() {
 					fakeDefinitionGetter.ChaincodeDefinitionReturns(nil, errors.New("blueberry-cobbler"))
@@ -0,0 +1,225 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package lifecycle
+
+import (
+	"sort"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/common/cauthdsl"
+	"github.com/hyperledger/fabric/common/channelconfig"
+	"github.com/hyperledger/fabric/common/configtx"
+	cb "github.com/hyperledger/fabric/protos/common"
+	mspprotos "github.com/hyperledger/fabric/protos/msp"
+	"github.com/hyperledger/fabric/protos/utils"
+	"github.com/pkg/errors"
+)
+
+// LifecycleEndorsementPolicyName is the name of the policy of the application
+// group of the channel config which governs the commit of chaincode definitions
+const LifecycleEndorsementPolicyName = "LifecycleEndorsement"
+
+// ApprovalPolicy decides whether the approvals of a chaincode definition by
+// the organizations of a channel allow the definition to be committed.
+type ApprovalPolicy interface {
+	// Orgs returns the MSP IDs of the organizations of the channel
+	Orgs() []string
+
+	// Satisfied returns true if the approvals, indexed by MSP ID, satisfy the policy
+	Satisfied(approvals map[string]bool) bool
+}
+
+// ChannelConfig is the part of the config of a channel from which the
+// LifecycleEndorsement policy is read
+type ChannelConfig interface {
+	ApplicationConfig() (channelconfig.Application, bool)
+	ConfigtxValidator() configtx.Validator
+}
+
+// LifecycleEndorsementPolicy evaluates the LifecycleEndorsement policy of a channel
+// against the approvals of its organizations. The approval of an organization stands
+// for the signature of any of its members. When the channel config does not define
+// the policy, the approval of a majority of the organizations is required.
+type LifecycleEndorsementPolicy struct {
+	orgs   []string
+	policy *cb.Policy
+}
+
+// NewLifecycleEndorsementPolicy returns the LifecycleEndorsement policy of the channel config.
+func NewLifecycleEndorsementPolicy(channelConfig ChannelConfig) (*LifecycleEndorsementPolicy, error) {
+	ac, ok := channelConfig.ApplicationConfig()
+	if !ok {
+		return nil, errors.New("could not get application config for channel")
+	}
+
+	lep := &LifecycleEndorsementPolicy{}
+	for _, org := range ac.Organizations() {
+		lep.orgs = append(lep.orgs, org.MSPID())
+	}
+	sort.Strings(lep.orgs)
+
+	config := channelConfig.ConfigtxValidator().ConfigProto()
+	if config == nil || config.ChannelGroup == nil {
+		return lep, nil
+	}
+	appGroup, ok := config.ChannelGroup.Groups[channelconfig.ApplicationGroupKey]
+	if !ok {
+		return lep, nil
+	}
+	if configPolicy, ok := appGroup.Policies[LifecycleEndorsementPolicyName]; ok && configPolicy.Policy != nil {
+		lep.policy = configPolicy.Policy
+	}
+
+	return lep, nil
+}
+
+// Orgs returns the MSP IDs of the application organizations of the channel.
+func (lep *LifecycleEndorsementPolicy) Orgs() []string {
+	return lep.orgs
+}
+
+// Satisfied returns true if the approvals satisfy the LifecycleEndorsement policy.
+func (lep *LifecycleEndorsementPolicy) Satisfied(approvals map[string]bool) bool {
+	if lep.policy == nil {
+		return lep.implicitMetaSatisfied(cb.ImplicitMetaPolicy_MAJORITY, approvals)
+	}
+
+	switch cb.Policy_PolicyType(lep.policy.Type) {
+	case cb.Policy_IMPLICIT_META:
+		imp := &cb.ImplicitMetaPolicy{}
+		if err := proto.Unmarshal(lep.policy.Value, imp); err != nil {
+			logger.Warningf("Could not unmarshal %s policy: %s", LifecycleEndorsementPolicyName, err)
+			return false
+		}
+		return lep.implicitMetaSatisfied(imp.Rule, approvals)
+	case cb.Policy_SIGNATURE:
+		spe := &cb.SignaturePolicyEnvelope{}
+		if err := proto.Unmarshal(lep.policy.Value, spe); err != nil {
+			logger.Warningf("Could not unmarshal %s policy: %s", LifecycleEndorsementPolicyName, err)
+			return false
+		}
+		return signaturePolicySatisfied(spe.Rule, spe.Identities, approvals)
+	default:
+		logger.Warningf("Unsupported type %d of %s policy", lep.policy.Type, LifecycleEndorsementPolicyName)
+		return false
+	}
+}
+
+// SignaturePolicyEnvelope returns the policy the endorsements of the commit of a
+// chaincode definition must satisfy, in which the approval of an organization is
+// the endorsement by any of its members.
+func (lep *LifecycleEndorsementPolicy) SignaturePolicyEnvelope() (*cb.SignaturePolicyEnvelope, error) {
+	if lep.policy == nil {
+		return lep.implicitMetaEnvelope(cb.ImplicitMetaPolicy_MAJORITY), nil
+	}
+
+	switch cb.Policy_PolicyType(lep.policy.Type) {
+	case cb.Policy_IMPLICIT_META:
+		imp := &cb.ImplicitMetaPolicy{}
+		if err := proto.Unmarshal(lep.policy.Value, imp); err != nil {
+			return nil, errors.Wrapf(err, "could not unmarshal %s policy", LifecycleEndorsementPolicyName)
+		}
+		return lep.implicitMetaEnvelope(imp.Rule), nil
+	case cb.Policy_SIGNATURE:
+		spe := &cb.SignaturePolicyEnvelope{}
+		if err := proto.Unmarshal(lep.policy.Value, spe); err != nil {
+			return nil, errors.Wrapf(err, "could not unmarshal %s policy", LifecycleEndorsementPolicyName)
+		}
+		identities := make([]*mspprotos.MSPPrincipal, len(spe.Identities))
+		for i, principal := range spe.Identities {
+			if principal.PrincipalClassification != mspprotos.MSPPrincipal_ROLE {
+				return nil, errors.Errorf("unsupported principal classification %s in %s policy", principal.PrincipalClassification, LifecycleEndorsementPolicyName)
+			}
+			role := &mspprotos.MSPRole{}
+			if err := proto.Unmarshal(principal.Principal, role); err != nil {
+				return nil, errors.Wrapf(err, "could not unmarshal principal of %s policy", LifecycleEndorsementPolicyName)
+			}
+			identities[i] = memberPrincipal(role.MspIdentifier)
+		}
+		return &cb.SignaturePolicyEnvelope{Version: spe.Version, Rule: spe.Rule, Identities: identities}, nil
+	default:
+		return nil, errors.Errorf("unsupported type %d of %s policy", lep.policy.Type, LifecycleEndorsementPolicyName)
+	}
+}
+
+func (lep *LifecycleEndorsementPolicy) implicitMetaEnvelope(rule cb.ImplicitMetaPolicy_Rule) *cb.SignaturePolicyEnvelope {
+	var n int
+	switch rule {
+	case cb.ImplicitMetaPolicy_ANY:
+		n = 1
+	case cb.ImplicitMetaPolicy_ALL:
+		n = len(lep.orgs)
+	case cb.ImplicitMetaPolicy_MAJORITY:
+		n = len(lep.orgs)/2 + 1
+	default:
+		// an unknown rule is never satisfied
+		n = len(lep.orgs) + 1
+	}
+
+	identities := make([]*mspprotos.MSPPrincipal, len(lep.orgs))
+	rules := make([]*cb.SignaturePolicy, len(lep.orgs))
+	for i, org := range lep.orgs {
+		identities[i] = memberPrincipal(org)
+		rules[i] = cauthdsl.SignedBy(int32(i))
+	}
+	return &cb.SignaturePolicyEnvelope{Rule: cauthdsl.NOutOf(int32(n), rules), Identities: identities}
+}
+
+func memberPrincipal(mspID string) *mspprotos.MSPPrincipal {
+	return &mspprotos.MSPPrincipal{
+		PrincipalClassification: mspprotos.MSPPrincipal_ROLE,
+		Principal:               utils.MarshalOrPanic(&mspprotos.MSPRole{Role: mspprotos.MSPRole_MEMBER, MspIdentifier: mspID}),
+	}
+}
+
+func (lep *LifecycleEndorsementPolicy) implicitMetaSatisfied(rule cb.ImplicitMetaPolicy_Rule, approvals map[string]bool) bool {
+	approved := 0
+	for _, org := range lep.orgs {
+		if approvals[org] {
+			approved++
+		}
+	}
+
+	switch rule {
+	case cb.ImplicitMetaPolicy_ANY:
+		return approved > 0
+	case cb.ImplicitMetaPolicy_ALL:
+		return approved == len(lep.orgs)
+	case cb.ImplicitMetaPolicy_MAJORITY:
+		return approved > len(lep.orgs)/2
+	default:
+		return false
+	}
+}
+
+func signaturePolicySatisfied(policy *cb.SignaturePolicy, identities []*mspprotos.MSPPrincipal, approvals map[string]bool) bool {
+	switch t := policy.GetType().(type) {
+	case *cb.SignaturePolicy_SignedBy:
+		if t.SignedBy < 0 || int(t.SignedBy) >= len(identities) {
+			return false
+		}
+		principal := identities[t.SignedBy]
+		if principal.PrincipalClassification != mspprotos.MSPPrincipal_ROLE {
+			return false
+		}
+		role := &mspprotos.MSPRole{}
+		if err := proto.Unmarshal(principal.Principal, role); err != nil {
+			return false
+		}
+		return approvals[role.MspIdentifier]
+	case *cb.SignaturePolicy_NOutOf_:
+		satisfied := int32(0)
+		for _, rule := range t.NOutOf.Rules {
+			if signaturePolicySatisfied(rule, identities, approvals) {
+				satisfied++
+			}
+		}
+		return satisfied >= t.NOutOf.N
+	default:
+		return false
+	}
+}
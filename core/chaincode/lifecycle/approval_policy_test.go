@@ -0,0 +1,170 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package lifecycle_test
+
+import (
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/common/cauthdsl"
+	"github.com/hyperledger/fabric/common/channelconfig"
+	"github.com/hyperledger/fabric/core/chaincode/lifecycle"
+	"github.com/hyperledger/fabric/core/chaincode/lifecycle/mock"
+	cb "github.com/hyperledger/fabric/protos/common"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("LifecycleEndorsementPolicy", func() {
+	var (
+		fakeChannelConfig *mock.ChannelConfig
+		fakeAppConfig     *mock.ApplicationConfig
+		fakeValidator     *mock.ConfigtxValidator
+		appGroup          *cb.ConfigGroup
+	)
+
+	BeforeEach(func() {
+		orgs := map[string]channelconfig.ApplicationOrg{}
+		for _, mspID := range []string{"org1", "org2", "org3"} {
+			fakeOrg := &mock.ApplicationOrgConfig{}
+			fakeOrg.MSPIDReturns(mspID)
+			orgs[mspID+"-name"] = fakeOrg
+		}
+		fakeAppConfig = &mock.ApplicationConfig{}
+		fakeAppConfig.OrganizationsReturns(orgs)
+
+		appGroup = &cb.ConfigGroup{Policies: map[string]*cb.ConfigPolicy{}}
+		fakeValidator = &mock.ConfigtxValidator{}
+		fakeValidator.ConfigProtoReturns(&cb.Config{
+			ChannelGroup: &cb.ConfigGroup{
+				Groups: map[string]*cb.ConfigGroup{
+					channelconfig.ApplicationGroupKey: appGroup,
+				},
+			},
+		})
+
+		fakeChannelConfig = &mock.ChannelConfig{}
+		fakeChannelConfig.ApplicationConfigReturns(fakeAppConfig, true)
+		fakeChannelConfig.ConfigtxValidatorReturns(fakeValidator)
+	})
+
+	setPolicy := func(policyType cb.Policy_PolicyType, value proto.Message) {
+		valueBytes, err := proto.Marshal(value)
+		Expect(err).NotTo(HaveOccurred())
+		appGroup.Policies[lifecycle.LifecycleEndorsementPolicyName] = &cb.ConfigPolicy{
+			Policy: &cb.Policy{Type: int32(policyType), Value: valueBytes},
+		}
+	}
+
+	It("returns the orgs of the channel", func() {
+		lep, err := lifecycle.NewLifecycleEndorsementPolicy(fakeChannelConfig)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(lep.Orgs()).To(ConsistOf("org1", "org2", "org3"))
+	})
+
+	Context("when the policy is not defined", func() {
+		It("requires the approval of a majority of the orgs", func() {
+			lep, err := lifecycle.NewLifecycleEndorsementPolicy(fakeChannelConfig)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(lep.Satisfied(map[string]bool{"org1": true, "org2": false})).To(BeFalse())
+			Expect(lep.Satisfied(map[string]bool{"org1": true, "org3": true})).To(BeTrue())
+			Expect(lep.Satisfied(map[string]bool{"org1": true, "other-org": true})).To(BeFalse())
+		})
+
+		It("requires the endorsements of the members of a majority of the orgs", func() {
+			lep, err := lifecycle.NewLifecycleEndorsementPolicy(fakeChannelConfig)
+			Expect(err).NotTo(HaveOccurred())
+			spe, err := lep.SignaturePolicyEnvelope()
+			Expect(err).NotTo(HaveOccurred())
+			expected, err := cauthdsl.FromString("OutOf(2, 'org1.member', 'org2.member', 'org3.member')")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(proto.Equal(spe, expected)).To(BeTrue())
+		})
+	})
+
+	Context("when the policy is an implicit meta policy", func() {
+		BeforeEach(func() {
+			setPolicy(cb.Policy_IMPLICIT_META, &cb.ImplicitMetaPolicy{
+				SubPolicy: "Endorsement",
+				Rule:      cb.ImplicitMetaPolicy_ALL,
+			})
+		})
+
+		It("evaluates the rule against the approvals", func() {
+			lep, err := lifecycle.NewLifecycleEndorsementPolicy(fakeChannelConfig)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(lep.Satisfied(map[string]bool{"org1": true, "org2": true})).To(BeFalse())
+			Expect(lep.Satisfied(map[string]bool{"org1": true, "org2": true, "org3": true})).To(BeTrue())
+		})
+
+		It("requires the endorsements of the members of the orgs as per the rule", func() {
+			lep, err := lifecycle.NewLifecycleEndorsementPolicy(fakeChannelConfig)
+			Expect(err).NotTo(HaveOccurred())
+			spe, err := lep.SignaturePolicyEnvelope()
+			Expect(err).NotTo(HaveOccurred())
+			expected, err := cauthdsl.FromString("OutOf(3, 'org1.member', 'org2.member', 'org3.member')")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(proto.Equal(spe, expected)).To(BeTrue())
+		})
+	})
+
+	Context("when the policy is a signature policy", func() {
+		BeforeEach(func() {
+			spe, err := cauthdsl.FromString("AND('org1.member', OR('org2.peer', 'org3.admin'))")
+			Expect(err).NotTo(HaveOccurred())
+			setPolicy(cb.Policy_SIGNATURE, spe)
+		})
+
+		It("evaluates the principals of the orgs against the approvals", func() {
+			lep, err := lifecycle.NewLifecycleEndorsementPolicy(fakeChannelConfig)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(lep.Satisfied(map[string]bool{"org2": true, "org3": true})).To(BeFalse())
+			Expect(lep.Satisfied(map[string]bool{"org1": true})).To(BeFalse())
+			Expect(lep.Satisfied(map[string]bool{"org1": true, "org3": true})).To(BeTrue())
+		})
+
+		It("requires the endorsements of the members of the orgs of the principals", func() {
+			lep, err := lifecycle.NewLifecycleEndorsementPolicy(fakeChannelConfig)
+			Expect(err).NotTo(HaveOccurred())
+			spe, err := lep.SignaturePolicyEnvelope()
+			Expect(err).NotTo(HaveOccurred())
+			expected, err := cauthdsl.FromString("AND('org1.member', OR('org2.member', 'org3.member'))")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(proto.Equal(spe, expected)).To(BeTrue())
+		})
+	})
+
+	Context("when the policy cannot be unmarshaled", func() {
+		BeforeEach(func() {
+			appGroup.Policies[lifecycle.LifecycleEndorsementPolicyName] = &cb.ConfigPolicy{
+				Policy: &cb.Policy{Type: int32(cb.Policy_SIGNATURE), Value: []byte("garbage")},
+			}
+		})
+
+		It("is never satisfied", func() {
+			lep, err := lifecycle.NewLifecycleEndorsementPolicy(fakeChannelConfig)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(lep.Satisfied(map[string]bool{"org1": true, "org2": true, "org3": true})).To(BeFalse())
+		})
+
+		It("returns an error for the endorsement policy", func() {
+			lep, err := lifecycle.NewLifecycleEndorsementPolicy(fakeChannelConfig)
+			Expect(err).NotTo(HaveOccurred())
+			_, err = lep.SignaturePolicyEnvelope()
+			Expect(err).To(MatchError(ContainSubstring("could not unmarshal LifecycleEndorsement policy")))
+		})
+	})
+
+	Context("when the channel has no application config", func() {
+		BeforeEach(func() {
+			fakeChannelConfig.ApplicationConfigReturns(nil, false)
+		})
+
+		It("returns an error", func() {
+			_, err := lifecycle.NewLifecycleEndorsementPolicy(fakeChannelConfig)
+			Expect(err).To(MatchError("could not get application config for channel"))
+		})
+	})
+})
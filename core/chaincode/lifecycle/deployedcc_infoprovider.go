@@ -0,0 +1,51 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package lifecycle
+
+import (
+	"github.com/hyperledger/fabric/core/common/privdata"
+	"github.com/hyperledger/fabric/core/ledger"
+	"github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/ledger/rwset/kvrwset"
+)
+
+// DeployedCCInfoProvider implements the interface ledger.DeployedChaincodeInfoProvider.
+// The approvals of the chaincode definitions are written to the implicit collections of
+// the orgs in the namespace of the lifecycle SCC, which is not deployed through LSCC, so
+// these collections are provided here. Anything else is provided by the legacy provider.
+type DeployedCCInfoProvider struct {
+	LegacyDeployedCCInfoProvider ledger.DeployedChaincodeInfoProvider
+}
+
+// Namespaces implements function in interface ledger.DeployedChaincodeInfoProvider
+func (p *DeployedCCInfoProvider) Namespaces() []string {
+	return p.LegacyDeployedCCInfoProvider.Namespaces()
+}
+
+// UpdatedChaincodes implements function in interface ledger.DeployedChaincodeInfoProvider
+func (p *DeployedCCInfoProvider) UpdatedChaincodes(stateUpdates map[string][]*kvrwset.KVWrite) ([]*ledger.ChaincodeLifecycleInfo, error) {
+	return p.LegacyDeployedCCInfoProvider.UpdatedChaincodes(stateUpdates)
+}
+
+// ChaincodeInfo implements function in interface ledger.DeployedChaincodeInfoProvider
+func (p *DeployedCCInfoProvider) ChaincodeInfo(chaincodeName string, qe ledger.SimpleQueryExecutor) (*ledger.DeployedChaincodeInfo, error) {
+	return p.LegacyDeployedCCInfoProvider.ChaincodeInfo(chaincodeName, qe)
+}
+
+// CollectionInfo implements function in interface ledger.DeployedChaincodeInfoProvider
+// The namespace of the lifecycle SCC has the implicit collections of the orgs and no other
+// collection. As for the chaincodes deployed through LSCC, the membership of the org of an
+// implicit collection in the channel is not checked here.
+func (p *DeployedCCInfoProvider) CollectionInfo(chaincodeName, collectionName string, qe ledger.SimpleQueryExecutor) (*common.StaticCollectionConfig, error) {
+	if chaincodeName != LifecycleNamespace {
+		return p.LegacyDeployedCCInfoProvider.CollectionInfo(chaincodeName, collectionName, qe)
+	}
+	if isImplicit, mspID := privdata.MSPIDIfImplicitCollection(collectionName); isImplicit {
+		return privdata.GenerateImplicitCollectionForOrg(mspID), nil
+	}
+	return nil, nil
+}
@@ -0,0 +1,99 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package lifecycle_test
+
+import (
+	"fmt"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/common/cauthdsl"
+	"github.com/hyperledger/fabric/core/chaincode/lifecycle"
+	"github.com/hyperledger/fabric/core/chaincode/lifecycle/mock"
+	"github.com/hyperledger/fabric/core/ledger"
+	ledgermock "github.com/hyperledger/fabric/core/ledger/mock"
+	cb "github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/ledger/rwset/kvrwset"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("DeployedCCInfoProvider", func() {
+	var (
+		fakeLegacyProvider *ledgermock.DeployedChaincodeInfoProvider
+		fakeQueryExecutor  *mock.QueryExecutor
+		provider           *lifecycle.DeployedCCInfoProvider
+	)
+
+	BeforeEach(func() {
+		fakeLegacyProvider = &ledgermock.DeployedChaincodeInfoProvider{}
+		fakeQueryExecutor = &mock.QueryExecutor{}
+		provider = &lifecycle.DeployedCCInfoProvider{
+			LegacyDeployedCCInfoProvider: fakeLegacyProvider,
+		}
+	})
+
+	Describe("CollectionInfo", func() {
+		It("returns the implicit collections of the orgs in the namespace of the lifecycle SCC", func() {
+			collection, err := provider.CollectionInfo("+lifecycle", "_implicit_org_org1", fakeQueryExecutor)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(proto.Equal(collection, &cb.StaticCollectionConfig{
+				Name: "_implicit_org_org1",
+				MemberOrgsPolicy: &cb.CollectionPolicyConfig{
+					Payload: &cb.CollectionPolicyConfig_SignaturePolicy{
+						SignaturePolicy: cauthdsl.SignedByMspMember("org1"),
+					},
+				},
+			})).To(BeTrue())
+			Expect(fakeLegacyProvider.CollectionInfoCallCount()).To(Equal(0))
+		})
+
+		It("returns nil for any other collection in the namespace of the lifecycle SCC", func() {
+			collection, err := provider.CollectionInfo("+lifecycle", "collection", fakeQueryExecutor)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(collection).To(BeNil())
+			Expect(fakeLegacyProvider.CollectionInfoCallCount()).To(Equal(0))
+		})
+
+		Context("when the namespace is not the namespace of the lifecycle SCC", func() {
+			BeforeEach(func() {
+				fakeLegacyProvider.CollectionInfoReturns(&cb.StaticCollectionConfig{Name: "legacy-collection"}, fmt.Errorf("legacy-error"))
+			})
+
+			It("returns the collection of the legacy provider", func() {
+				collection, err := provider.CollectionInfo("name", "_implicit_org_org1", fakeQueryExecutor)
+				Expect(err).To(MatchError("legacy-error"))
+				Expect(collection).To(Equal(&cb.StaticCollectionConfig{Name: "legacy-collection"}))
+
+				Expect(fakeLegacyProvider.CollectionInfoCallCount()).To(Equal(1))
+				name, collectionName, qe := fakeLegacyProvider.CollectionInfoArgsForCall(0)
+				Expect(name).To(Equal("name"))
+				Expect(collectionName).To(Equal("_implicit_org_org1"))
+				Expect(qe).To(Equal(fakeQueryExecutor))
+			})
+		})
+	})
+
+	It("delegates anything else to the legacy provider", func() {
+		fakeLegacyProvider.NamespacesReturns([]string{"lscc"})
+		Expect(provider.Namespaces()).To(Equal([]string{"lscc"}))
+
+		stateUpdates := map[string][]*kvrwset.KVWrite{"lscc": {{Key: "name"}}}
+		fakeLegacyProvider.UpdatedChaincodesReturns([]*ledger.ChaincodeLifecycleInfo{{Name: "name"}}, nil)
+		updated, err := provider.UpdatedChaincodes(stateUpdates)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(updated).To(Equal([]*ledger.ChaincodeLifecycleInfo{{Name: "name"}}))
+		Expect(fakeLegacyProvider.UpdatedChaincodesArgsForCall(0)).To(Equal(stateUpdates))
+
+		fakeLegacyProvider.ChaincodeInfoReturns(&ledger.DeployedChaincodeInfo{Name: "name"}, nil)
+		info, err := provider.ChaincodeInfo("name", fakeQueryExecutor)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(info).To(Equal(&ledger.DeployedChaincodeInfo{Name: "name"}))
+		name, qe := fakeLegacyProvider.ChaincodeInfoArgsForCall(0)
+		Expect(name).To(Equal("name"))
+		Expect(qe).To(Equal(fakeQueryExecutor))
+	})
+})
@@ -0,0 +1,70 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package lifecycle
+
+import (
+	"github.com/hyperledger/fabric/core/chaincode/shim"
+	"github.com/hyperledger/fabric/core/ledger"
+)
+
+// ChaincodePublicLedgerShim decorates the chaincode shim to support the state interfaces
+// required by the lifecycle operations on the public state of the channel
+type ChaincodePublicLedgerShim struct {
+	shim.ChaincodeStubInterface
+}
+
+// ChaincodePrivateLedgerShim wraps the chaincode shim to make access to the private data
+// of a collection look like the state interfaces required by the lifecycle operations
+type ChaincodePrivateLedgerShim struct {
+	Stub       shim.ChaincodeStubInterface
+	Collection string
+}
+
+// GetState returns the value for the key in the configured collection.
+func (cls *ChaincodePrivateLedgerShim) GetState(key string) ([]byte, error) {
+	return cls.Stub.GetPrivateData(cls.Collection, key)
+}
+
+// GetStateHash returns the hash of the value for the key in the configured collection.
+func (cls *ChaincodePrivateLedgerShim) GetStateHash(key string) ([]byte, error) {
+	return cls.Stub.GetPrivateDataHash(cls.Collection, key)
+}
+
+// PutState sets the value for the key in the configured collection.
+func (cls *ChaincodePrivateLedgerShim) PutState(key string, value []byte) error {
+	return cls.Stub.PutPrivateData(cls.Collection, key, value)
+}
+
+// DelState deletes the key in the configured collection.
+func (cls *ChaincodePrivateLedgerShim) DelState(key string) error {
+	return cls.Stub.DelPrivateData(cls.Collection, key)
+}
+
+// PublicQueryExecutorShim adapts a ledger query executor to the state interfaces
+// required by the lifecycle queries on the public state of a namespace
+type PublicQueryExecutorShim struct {
+	Namespace     string
+	QueryExecutor ledger.SimpleQueryExecutor
+}
+
+// GetState returns the value for the key in the configured namespace.
+func (pqes *PublicQueryExecutorShim) GetState(key string) ([]byte, error) {
+	return pqes.QueryExecutor.GetState(pqes.Namespace, key)
+}
+
+// PrivateQueryExecutorShim adapts a ledger query executor to the state interfaces
+// required by the lifecycle queries on the private data of a collection of a namespace
+type PrivateQueryExecutorShim struct {
+	Namespace     string
+	Collection    string
+	QueryExecutor ledger.QueryExecutor
+}
+
+// GetState returns the value for the key in the configured collection.
+func (pqes *PrivateQueryExecutorShim) GetState(key string) ([]byte, error) {
+	return pqes.QueryExecutor.GetPrivateData(pqes.Namespace, pqes.Collection, key)
+}
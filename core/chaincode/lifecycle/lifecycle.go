@@ -7,52 +7,417 @@ SPDX-License-Identifier: Apache-2.0
 package lifecycle
 
 import (
+	"bytes"
 	"fmt"
+	"strings"
 
+	"github.com/hyperledger/fabric/common/chaincode"
+	"github.com/hyperledger/fabric/common/flogging"
+	"github.com/hyperledger/fabric/common/util"
 	"github.com/hyperledger/fabric/core/chaincode/persistence"
+	"github.com/hyperledger/fabric/core/chaincode/shim"
+	"github.com/hyperledger/fabric/core/common/ccprovider"
+	"github.com/hyperledger/fabric/core/ledger"
+	pb "github.com/hyperledger/fabric/protos/peer"
+	lb "github.com/hyperledger/fabric/protos/peer/lifecycle"
 	"github.com/pkg/errors"
 )
 
+var logger = flogging.MustGetLogger("lifecycle")
+
+const (
+	// NamespacesName is the prefix of the keys of the chaincode definitions
+	// in the public state and of their approvals in the private state of the orgs
+	NamespacesName = "namespaces"
+
+	// ChaincodeSourcesName is the prefix of the keys of the package IDs of the
+	// installed chaincodes an org uses for the chaincode definitions it approves
+	ChaincodeSourcesName = "chaincode-sources"
+)
+
 // ChaincodeStore provides a way to persist chaincodes
 type ChaincodeStore interface {
-	Save(name, version string, ccInstallPkg []byte) (hash []byte, err error)
-	RetrieveHash(name, version string) (hash []byte, err error)
+	Save(label string, ccInstallPkg []byte) (packageID string, err error)
+	Load(packageID string) (ccInstallPkg []byte, err error)
+	ListInstalledChaincodes() ([]chaincode.InstalledChaincode, error)
 }
 
 type PackageParser interface {
 	Parse(data []byte) (*persistence.ChaincodePackage, error)
 }
 
+// ReadableState is the subset of the state of the ledger required to query
+// the chaincode definitions
+type ReadableState interface {
+	GetState(key string) (value []byte, err error)
+}
+
+// ReadWritableState is the subset of the state of the ledger required to
+// approve and commit the chaincode definitions
+type ReadWritableState interface {
+	ReadableState
+	PutState(key string, value []byte) error
+	DelState(key string) error
+}
+
+// OpaqueState is the private state of another org, of which only the hashes
+// of the values are available
+type OpaqueState interface {
+	GetStateHash(key string) (value []byte, err error)
+}
+
+// LegacyLifecycle is the lifecycle of the chaincodes instantiated through LSCC
+type LegacyLifecycle interface {
+	ChaincodeDefinition(chaincodeName string, qe ledger.QueryExecutor) (ccprovider.ChaincodeDefinition, error)
+	ChaincodeContainerInfo(chaincodeName string, qe ledger.QueryExecutor) (*ccprovider.ChaincodeContainerInfo, error)
+}
+
 // Lifecycle implements the lifecycle operations which are invoked
-// by the SCC as well as internally
+// by the SCC as well as internally. OrgMSPID is the MSP ID of the org
+// of the peer, whose approvals select the installed chaincode packages
+// the peer launches the chaincodes from.
 type Lifecycle struct {
 	ChaincodeStore ChaincodeStore
 	PackageParser  PackageParser
+	Protobuf       Protobuf
+	LegacyImpl     LegacyLifecycle
+	OrgMSPID       string
 }
 
 // InstallChaincode installs a given chaincode to the peer's chaincode store.
-// It returns the hash to reference the chaincode by or an error on failure.
-func (l *Lifecycle) InstallChaincode(name, version string, chaincodeInstallPackage []byte) ([]byte, error) {
+// It returns the package ID, made of the label of the package and its hash,
+// to reference the chaincode by or an error on failure.
+func (l *Lifecycle) InstallChaincode(chaincodeInstallPackage []byte) (*chaincode.InstalledChaincode, error) {
 	// Let's validate that the chaincodeInstallPackage is at least well formed before writing it
-	_, err := l.PackageParser.Parse(chaincodeInstallPackage)
+	ccPackage, err := l.PackageParser.Parse(chaincodeInstallPackage)
 	if err != nil {
 		return nil, errors.WithMessage(err, "could not parse as a chaincode install package")
 	}
 
-	hash, err := l.ChaincodeStore.Save(name, version, chaincodeInstallPackage)
+	packageID, err := l.ChaincodeStore.Save(ccPackage.Metadata.Label, chaincodeInstallPackage)
 	if err != nil {
 		return nil, errors.WithMessage(err, "could not save cc install package")
 	}
 
-	return hash, nil
+	return &chaincode.InstalledChaincode{
+		PackageID: packageID,
+		Label:     ccPackage.Metadata.Label,
+	}, nil
+}
+
+// QueryInstalledChaincode returns the package ID and label of the installed chaincode with the given package ID.
+func (l *Lifecycle) QueryInstalledChaincode(packageID string) (*chaincode.InstalledChaincode, error) {
+	installedChaincodes, err := l.QueryInstalledChaincodes()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, installedChaincode := range installedChaincodes {
+		if installedChaincode.PackageID == packageID {
+			return &installedChaincode, nil
+		}
+	}
+
+	return nil, &persistence.CodePackageNotFoundErr{PackageID: packageID}
+}
+
+// QueryInstalledChaincodes returns the package ID and label of the chaincodes installed on the peer.
+func (l *Lifecycle) QueryInstalledChaincodes() ([]chaincode.InstalledChaincode, error) {
+	installedChaincodes, err := l.ChaincodeStore.ListInstalledChaincodes()
+	if err != nil {
+		return nil, errors.WithMessage(err, "could not list installed chaincodes")
+	}
+
+	return installedChaincodes, nil
+}
+
+// GetInstalledChaincodePackage returns the install package of the installed chaincode with the given package ID.
+func (l *Lifecycle) GetInstalledChaincodePackage(packageID string) ([]byte, error) {
+	ccInstallPkg, err := l.ChaincodeStore.Load(packageID)
+	if err != nil {
+		return nil, errors.WithMessage(err, "could not load cc install package")
+	}
+
+	return ccInstallPkg, nil
+}
+
+// ApproveChaincodeDefinitionForOrg records in the private state of the org its
+// approval of the definition of the chaincode at the sequence of the definition.
+// The sequence must be the one following the sequence of the committed definition.
+// The package ID of the installed chaincode the org uses for the definition is
+// recorded apart from the approval, as the orgs may install the chaincode
+// under different labels.
+func (l *Lifecycle) ApproveChaincodeDefinitionForOrg(name string, cd *lb.ChaincodeDefinition, packageID string, publicState ReadableState, orgState ReadWritableState) error {
+	if err := l.checkNextSequence(name, cd, publicState); err != nil {
+		return err
+	}
+
+	cdBytes, err := l.Protobuf.Marshal(cd)
+	if err != nil {
+		return errors.WithMessage(err, "could not marshal chaincode definition")
+	}
+
+	err = orgState.PutState(approvalKey(name, cd.Sequence), cdBytes)
+	if err != nil {
+		return errors.WithMessage(err, fmt.Sprintf("could not write approval of chaincode definition for '%s'", name))
+	}
+
+	if packageID == "" {
+		err = orgState.DelState(sourceKey(name, cd.Sequence))
+	} else {
+		err = orgState.PutState(sourceKey(name, cd.Sequence), []byte(packageID))
+	}
+	if err != nil {
+		return errors.WithMessage(err, fmt.Sprintf("could not write package ID of chaincode definition for '%s'", name))
+	}
+
+	return nil
+}
+
+// CheckCommitReadiness returns the approvals by the orgs of the definition of the chaincode,
+// indexed by MSP ID, without committing the definition.
+func (l *Lifecycle) CheckCommitReadiness(name string, cd *lb.ChaincodeDefinition, publicState ReadableState, orgStates map[string]OpaqueState, policy ApprovalPolicy) (map[string]bool, error) {
+	if err := l.checkNextSequence(name, cd, publicState); err != nil {
+		return nil, err
+	}
+
+	cdBytes, err := l.Protobuf.Marshal(cd)
+	if err != nil {
+		return nil, errors.WithMessage(err, "could not marshal chaincode definition")
+	}
+
+	return orgApprovals(name, cd.Sequence, cdBytes, orgStates, policy)
+}
+
+// CommitChaincodeDefinition commits the definition of the chaincode to the public state
+// of the channel when the approvals of the orgs satisfy the approval policy of the channel.
+// It returns the approvals of the orgs, indexed by MSP ID.
+func (l *Lifecycle) CommitChaincodeDefinition(name string, cd *lb.ChaincodeDefinition, publicState ReadWritableState, orgStates map[string]OpaqueState, policy ApprovalPolicy) (map[string]bool, error) {
+	if err := l.checkNextSequence(name, cd, publicState); err != nil {
+		return nil, err
+	}
+
+	cdBytes, err := l.Protobuf.Marshal(cd)
+	if err != nil {
+		return nil, errors.WithMessage(err, "could not marshal chaincode definition")
+	}
+
+	approvals, err := orgApprovals(name, cd.Sequence, cdBytes, orgStates, policy)
+	if err != nil {
+		return nil, err
+	}
+
+	if !policy.Satisfied(approvals) {
+		return approvals, errors.Errorf("chaincode definition for '%s' at sequence %d is not approved by enough orgs to satisfy the %s policy, approvals: %v",
+			name, cd.Sequence, LifecycleEndorsementPolicyName, approvals)
+	}
+
+	err = publicState.PutState(definitionKey(name), cdBytes)
+	if err != nil {
+		return nil, errors.WithMessage(err, fmt.Sprintf("could not write chaincode definition for '%s'", name))
+	}
+
+	logger.Infof("Committed definition of chaincode '%s' at sequence %d with version '%s'", name, cd.Sequence, cd.Version)
+	return approvals, nil
 }
 
-// QueryInstalledChaincode returns the hash of an installed chaincode of a given name and version.
-func (l *Lifecycle) QueryInstalledChaincode(name, version string) ([]byte, error) {
-	hash, err := l.ChaincodeStore.RetrieveHash(name, version)
+// QueryChaincodeDefinition returns the committed definition of the chaincode.
+func (l *Lifecycle) QueryChaincodeDefinition(name string, publicState ReadableState) (*lb.ChaincodeDefinition, error) {
+	cd, err := l.committedDefinition(name, publicState)
 	if err != nil {
-		return nil, errors.WithMessage(err, fmt.Sprintf("could not retrieve hash for chaincode '%s:%s'", name, version))
+		return nil, err
+	}
+	if cd == nil {
+		return nil, errors.Errorf("chaincode definition for '%s' not found", name)
 	}
 
-	return hash, nil
+	return cd, nil
+}
+
+// QueryApprovedPackageID returns the package ID of the installed chaincode the
+// org approved for the definition of the chaincode at the given sequence. It
+// returns an empty package ID when the org did not approve a package.
+func (l *Lifecycle) QueryApprovedPackageID(name string, sequence int64, orgState ReadableState) (string, error) {
+	packageID, err := orgState.GetState(sourceKey(name, sequence))
+	if err != nil {
+		return "", errors.WithMessage(err, fmt.Sprintf("could not read package ID of chaincode definition for '%s'", name))
+	}
+
+	return string(packageID), nil
+}
+
+// LegacyDefinition is the definition of a chaincode committed to the channel in the
+// form the endorser and the chaincode support expect from the legacy lifecycle.
+type LegacyDefinition struct {
+	Name                string
+	Version             string
+	EndorsementPlugin   string
+	ValidationPlugin    string
+	ValidationParameter []byte
+}
+
+// CCName returns the name of the chaincode.
+func (ld *LegacyDefinition) CCName() string {
+	return ld.Name
+}
+
+// Hash returns nil, as the definition of the chaincode does not include the
+// hash of a chaincode package.
+func (ld *LegacyDefinition) Hash() []byte {
+	return nil
+}
+
+// CCVersion returns the version of the chaincode.
+func (ld *LegacyDefinition) CCVersion() string {
+	return ld.Version
+}
+
+// Validation returns the validation plugin of the chaincode and its parameter.
+func (ld *LegacyDefinition) Validation() (string, []byte) {
+	return ld.ValidationPlugin, ld.ValidationParameter
+}
+
+// Endorsement returns the endorsement plugin of the chaincode.
+func (ld *LegacyDefinition) Endorsement() string {
+	return ld.EndorsementPlugin
+}
+
+// ChaincodeDefinition returns the definition of the chaincode committed to the
+// channel, or the definition of the legacy lifecycle if none is committed.
+func (l *Lifecycle) ChaincodeDefinition(name string, qe ledger.QueryExecutor) (ccprovider.ChaincodeDefinition, error) {
+	cd, err := l.committedDefinition(name, &PublicQueryExecutorShim{Namespace: LifecycleNamespace, QueryExecutor: qe})
+	if err != nil {
+		return nil, err
+	}
+	if cd == nil {
+		return l.LegacyImpl.ChaincodeDefinition(name, qe)
+	}
+
+	return &LegacyDefinition{
+		Name:                name,
+		Version:             cd.Version,
+		EndorsementPlugin:   cd.EndorsementPlugin,
+		ValidationPlugin:    cd.ValidationPlugin,
+		ValidationParameter: cd.ValidationParameter,
+	}, nil
+}
+
+// ChaincodeContainerInfo returns the information necessary to launch the chaincode.
+// A chaincode whose definition is committed to the channel is launched from the
+// installed chaincode package the org of the peer approved for the definition, any
+// other chaincode is looked up in the legacy lifecycle.
+func (l *Lifecycle) ChaincodeContainerInfo(name string, qe ledger.QueryExecutor) (*ccprovider.ChaincodeContainerInfo, error) {
+	cd, err := l.committedDefinition(name, &PublicQueryExecutorShim{Namespace: LifecycleNamespace, QueryExecutor: qe})
+	if err != nil {
+		return nil, err
+	}
+	if cd == nil {
+		return l.LegacyImpl.ChaincodeContainerInfo(name, qe)
+	}
+
+	orgState := &PrivateQueryExecutorShim{
+		Namespace:     LifecycleNamespace,
+		Collection:    shim.ImplicitCollectionNameForOrg(l.OrgMSPID),
+		QueryExecutor: qe,
+	}
+	packageID, err := l.QueryApprovedPackageID(name, cd.Sequence, orgState)
+	if err != nil {
+		return nil, err
+	}
+	if packageID == "" {
+		return nil, errors.Errorf("org '%s' has not approved a chaincode package for the definition of '%s' at sequence %d", l.OrgMSPID, name, cd.Sequence)
+	}
+
+	ccInstallPkg, err := l.ChaincodeStore.Load(packageID)
+	if err != nil {
+		return nil, errors.WithMessage(err, fmt.Sprintf("could not load chaincode package of '%s'", name))
+	}
+	ccPackage, err := l.PackageParser.Parse(ccInstallPkg)
+	if err != nil {
+		return nil, errors.WithMessage(err, fmt.Sprintf("could not parse chaincode package of '%s'", name))
+	}
+
+	return &ccprovider.ChaincodeContainerInfo{
+		Name:          name,
+		Version:       cd.Version,
+		Path:          ccPackage.Metadata.Path,
+		Type:          strings.ToUpper(ccPackage.Metadata.Type),
+		PackageID:     packageID,
+		ContainerType: pb.ChaincodeDeploymentSpec_DOCKER.String(),
+	}, nil
+}
+
+func (l *Lifecycle) committedDefinition(name string, publicState ReadableState) (*lb.ChaincodeDefinition, error) {
+	cdBytes, err := publicState.GetState(definitionKey(name))
+	if err != nil {
+		return nil, errors.WithMessage(err, fmt.Sprintf("could not read chaincode definition for '%s'", name))
+	}
+	if cdBytes == nil {
+		return nil, nil
+	}
+
+	cd := &lb.ChaincodeDefinition{}
+	err = l.Protobuf.Unmarshal(cdBytes, cd)
+	if err != nil {
+		return nil, errors.WithMessage(err, fmt.Sprintf("could not unmarshal chaincode definition for '%s'", name))
+	}
+
+	return cd, nil
+}
+
+func (l *Lifecycle) checkNextSequence(name string, cd *lb.ChaincodeDefinition, publicState ReadableState) error {
+	if name == "" {
+		return errors.New("chaincode name must not be empty")
+	}
+	if cd == nil {
+		return errors.Errorf("chaincode definition for '%s' must not be empty", name)
+	}
+
+	committed, err := l.committedDefinition(name, publicState)
+	if err != nil {
+		return err
+	}
+	var currentSequence int64
+	if committed != nil {
+		currentSequence = committed.Sequence
+	}
+	if cd.Sequence != currentSequence+1 {
+		return errors.Errorf("requested sequence is %d, but new definition must be sequence %d", cd.Sequence, currentSequence+1)
+	}
+
+	return nil
+}
+
+// orgApprovals compares the approvals recorded in the private states of the orgs
+// with the marshaled chaincode definition
+func orgApprovals(name string, sequence int64, cdBytes []byte, orgStates map[string]OpaqueState, policy ApprovalPolicy) (map[string]bool, error) {
+	cdHash := util.ComputeSHA256(cdBytes)
+
+	approvals := map[string]bool{}
+	for _, org := range policy.Orgs() {
+		orgState, ok := orgStates[org]
+		if !ok {
+			approvals[org] = false
+			continue
+		}
+		approvalHash, err := orgState.GetStateHash(approvalKey(name, sequence))
+		if err != nil {
+			return nil, errors.WithMessage(err, fmt.Sprintf("could not read approval of chaincode definition for '%s' by org '%s'", name, org))
+		}
+		approvals[org] = bytes.Equal(approvalHash, cdHash)
+	}
+
+	return approvals, nil
+}
+
+func definitionKey(name string) string {
+	return fmt.Sprintf("%s/%s", NamespacesName, name)
+}
+
+func approvalKey(name string, sequence int64) string {
+	return fmt.Sprintf("%s/%s#%d", NamespacesName, name, sequence)
+}
+
+func sourceKey(name string, sequence int64) string {
+	return fmt.Sprintf("%s/%s#%d", ChaincodeSourcesName, name, sequence)
 }
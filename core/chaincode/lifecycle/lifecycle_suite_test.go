@@ -9,8 +9,11 @@ package lifecycle_test
 import (
 	"testing"
 
+	"github.com/hyperledger/fabric/common/channelconfig"
+	"github.com/hyperledger/fabric/common/configtx"
 	"github.com/hyperledger/fabric/core/chaincode/lifecycle"
 	"github.com/hyperledger/fabric/core/chaincode/shim"
+	"github.com/hyperledger/fabric/core/ledger"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 )
@@ -35,6 +38,56 @@ type sccFunctions interface {
 	lifecycle.SCCFunctions
 }
 
+//go:generate counterfeiter -o mock/legacy_lifecycle.go --fake-name LegacyLifecycle . legacyLifecycle
+type legacyLifecycle interface {
+	lifecycle.LegacyLifecycle
+}
+
+//go:generate counterfeiter -o mock/query_executor.go --fake-name QueryExecutor . queryExecutor
+type queryExecutor interface {
+	ledger.QueryExecutor
+}
+
+//go:generate counterfeiter -o mock/read_writable_state.go --fake-name ReadWritableState . readWritableState
+type readWritableState interface {
+	lifecycle.ReadWritableState
+}
+
+//go:generate counterfeiter -o mock/opaque_state.go --fake-name OpaqueState . opaqueState
+type opaqueState interface {
+	lifecycle.OpaqueState
+}
+
+//go:generate counterfeiter -o mock/approval_policy.go --fake-name ApprovalPolicy . approvalPolicy
+type approvalPolicy interface {
+	lifecycle.ApprovalPolicy
+}
+
+//go:generate counterfeiter -o mock/channel_config_source.go --fake-name ChannelConfigSource . channelConfigSource
+type channelConfigSource interface {
+	lifecycle.ChannelConfigSource
+}
+
+//go:generate counterfeiter -o mock/channel_config.go --fake-name ChannelConfig . channelConfig
+type channelConfig interface {
+	channelconfig.Resources
+}
+
+//go:generate counterfeiter -o mock/application_config.go --fake-name ApplicationConfig . applicationConfig
+type applicationConfig interface {
+	channelconfig.Application
+}
+
+//go:generate counterfeiter -o mock/application_org_config.go --fake-name ApplicationOrgConfig . applicationOrgConfig
+type applicationOrgConfig interface {
+	channelconfig.ApplicationOrg
+}
+
+//go:generate counterfeiter -o mock/configtx_validator.go --fake-name ConfigtxValidator . configtxValidator
+type configtxValidator interface {
+	configtx.Validator
+}
+
 func TestLifecycle(t *testing.T) {
 	RegisterFailHandler(Fail)
 	RunSpecs(t, "Lifecycle Suite")
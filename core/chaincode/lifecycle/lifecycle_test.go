@@ -9,8 +9,14 @@ package lifecycle_test
 import (
 	"fmt"
 
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/common/chaincode"
+	"github.com/hyperledger/fabric/common/util"
 	"github.com/hyperledger/fabric/core/chaincode/lifecycle"
 	"github.com/hyperledger/fabric/core/chaincode/lifecycle/mock"
+	"github.com/hyperledger/fabric/core/chaincode/persistence"
+	"github.com/hyperledger/fabric/core/common/ccprovider"
+	lb "github.com/hyperledger/fabric/protos/peer/lifecycle"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 )
@@ -29,37 +35,47 @@ var _ = Describe("Lifecycle", func() {
 		l = &lifecycle.Lifecycle{
 			PackageParser:  fakeParser,
 			ChaincodeStore: fakeCCStore,
+			Protobuf:       &lifecycle.ProtobufImpl{},
 		}
 	})
 
 	Describe("InstallChaincode", func() {
 		BeforeEach(func() {
-			fakeCCStore.SaveReturns([]byte("fake-hash"), nil)
+			fakeParser.ParseReturns(&persistence.ChaincodePackage{
+				Metadata: &persistence.ChaincodePackageMetadata{
+					Type:  "cc-type",
+					Path:  "cc-path",
+					Label: "cc-label",
+				},
+			}, nil)
+			fakeCCStore.SaveReturns("cc-label:fake-hash", nil)
 		})
 
-		It("saves the chaincode", func() {
-			hash, err := l.InstallChaincode("name", "version", []byte("cc-package"))
+		It("saves the chaincode under the label of the package", func() {
+			installedChaincode, err := l.InstallChaincode([]byte("cc-package"))
 			Expect(err).NotTo(HaveOccurred())
-			Expect(hash).To(Equal([]byte("fake-hash")))
+			Expect(installedChaincode).To(Equal(&chaincode.InstalledChaincode{
+				PackageID: "cc-label:fake-hash",
+				Label:     "cc-label",
+			}))
 
 			Expect(fakeParser.ParseCallCount()).To(Equal(1))
 			Expect(fakeParser.ParseArgsForCall(0)).To(Equal([]byte("cc-package")))
 
 			Expect(fakeCCStore.SaveCallCount()).To(Equal(1))
-			name, version, msg := fakeCCStore.SaveArgsForCall(0)
-			Expect(name).To(Equal("name"))
-			Expect(version).To(Equal("version"))
+			label, msg := fakeCCStore.SaveArgsForCall(0)
+			Expect(label).To(Equal("cc-label"))
 			Expect(msg).To(Equal([]byte("cc-package")))
 		})
 
 		Context("when saving the chaincode fails", func() {
 			BeforeEach(func() {
-				fakeCCStore.SaveReturns(nil, fmt.Errorf("fake-error"))
+				fakeCCStore.SaveReturns("", fmt.Errorf("fake-error"))
 			})
 
 			It("wraps and returns the error", func() {
-				hash, err := l.InstallChaincode("name", "version", []byte("cc-package"))
-				Expect(hash).To(BeNil())
+				installedChaincode, err := l.InstallChaincode([]byte("cc-package"))
+				Expect(installedChaincode).To(BeNil())
 				Expect(err).To(MatchError("could not save cc install package: fake-error"))
 			})
 		})
@@ -70,8 +86,8 @@ var _ = Describe("Lifecycle", func() {
 			})
 
 			It("wraps and returns the error", func() {
-				hash, err := l.InstallChaincode("name", "version", []byte("fake-package"))
-				Expect(hash).To(BeNil())
+				installedChaincode, err := l.InstallChaincode([]byte("fake-package"))
+				Expect(installedChaincode).To(BeNil())
 				Expect(err).To(MatchError("could not parse as a chaincode install package: parse-error"))
 			})
 		})
@@ -79,27 +95,588 @@ var _ = Describe("Lifecycle", func() {
 
 	Describe("QueryInstalledChaincode", func() {
 		BeforeEach(func() {
-			fakeCCStore.RetrieveHashReturns([]byte("fake-hash"), nil)
+			fakeCCStore.ListInstalledChaincodesReturns([]chaincode.InstalledChaincode{
+				{PackageID: "label1:hash1", Label: "label1"},
+				{PackageID: "label2:hash2", Label: "label2"},
+			}, nil)
+		})
+
+		It("returns the installed chaincode with the package ID", func() {
+			installedChaincode, err := l.QueryInstalledChaincode("label2:hash2")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(installedChaincode).To(Equal(&chaincode.InstalledChaincode{PackageID: "label2:hash2", Label: "label2"}))
+		})
+
+		Context("when the chaincode is not installed", func() {
+			It("returns an error", func() {
+				installedChaincode, err := l.QueryInstalledChaincode("label3:hash3")
+				Expect(installedChaincode).To(BeNil())
+				Expect(err).To(MatchError("chaincode install package 'label3:hash3' not found"))
+			})
+		})
+
+		Context("when the backing chaincode store fails to list the chaincodes", func() {
+			BeforeEach(func() {
+				fakeCCStore.ListInstalledChaincodesReturns(nil, fmt.Errorf("fake-error"))
+			})
+
+			It("wraps and returns the error", func() {
+				installedChaincode, err := l.QueryInstalledChaincode("label1:hash1")
+				Expect(installedChaincode).To(BeNil())
+				Expect(err).To(MatchError("could not list installed chaincodes: fake-error"))
+			})
+		})
+	})
+
+	Describe("QueryInstalledChaincodes", func() {
+		BeforeEach(func() {
+			fakeCCStore.ListInstalledChaincodesReturns([]chaincode.InstalledChaincode{
+				{PackageID: "label:fake-hash", Label: "label"},
+			}, nil)
 		})
 
 		It("passes through to the backing chaincode store", func() {
-			hash, err := l.QueryInstalledChaincode("name", "version")
+			installedChaincodes, err := l.QueryInstalledChaincodes()
 			Expect(err).NotTo(HaveOccurred())
-			Expect(hash).To(Equal([]byte("fake-hash")))
-			Expect(fakeCCStore.RetrieveHashCallCount()).To(Equal(1))
-			name, version := fakeCCStore.RetrieveHashArgsForCall(0)
-			Expect(name).To(Equal("name"))
-			Expect(version).To(Equal("version"))
+			Expect(installedChaincodes).To(Equal([]chaincode.InstalledChaincode{
+				{PackageID: "label:fake-hash", Label: "label"},
+			}))
 		})
 
-		Context("when the backing chaincode store fails to retrieve the hash", func() {
+		Context("when the backing chaincode store fails to list the chaincodes", func() {
 			BeforeEach(func() {
-				fakeCCStore.RetrieveHashReturns(nil, fmt.Errorf("fake-error"))
+				fakeCCStore.ListInstalledChaincodesReturns(nil, fmt.Errorf("fake-error"))
+			})
+
+			It("wraps and returns the error", func() {
+				_, err := l.QueryInstalledChaincodes()
+				Expect(err).To(MatchError("could not list installed chaincodes: fake-error"))
 			})
+		})
+	})
+
+	Describe("GetInstalledChaincodePackage", func() {
+		BeforeEach(func() {
+			fakeCCStore.LoadReturns([]byte("install-package"), nil)
+		})
+
+		It("loads the install package of the chaincode from the backing chaincode store", func() {
+			pkg, err := l.GetInstalledChaincodePackage("label:fake-hash")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(pkg).To(Equal([]byte("install-package")))
+			Expect(fakeCCStore.LoadCallCount()).To(Equal(1))
+			Expect(fakeCCStore.LoadArgsForCall(0)).To(Equal("label:fake-hash"))
+		})
+
+		Context("when loading the install package fails", func() {
+			BeforeEach(func() {
+				fakeCCStore.LoadReturns(nil, fmt.Errorf("fake-error"))
+			})
+
+			It("wraps and returns the error", func() {
+				_, err := l.GetInstalledChaincodePackage("label:fake-hash")
+				Expect(err).To(MatchError("could not load cc install package: fake-error"))
+			})
+		})
+	})
+
+	Describe("ChaincodeDefinition", func() {
+		var (
+			publicState  map[string][]byte
+			fakePublic   *mock.ReadWritableState
+			orgState     map[string][]byte
+			fakeOrgState *mock.ReadWritableState
+			definition   *lb.ChaincodeDefinition
+		)
+
+		newFakeState := func(state map[string][]byte) *mock.ReadWritableState {
+			fakeState := &mock.ReadWritableState{}
+			fakeState.GetStateStub = func(key string) ([]byte, error) {
+				return state[key], nil
+			}
+			fakeState.PutStateStub = func(key string, value []byte) error {
+				state[key] = value
+				return nil
+			}
+			fakeState.DelStateStub = func(key string) error {
+				delete(state, key)
+				return nil
+			}
+			return fakeState
+		}
+
+		BeforeEach(func() {
+			publicState = map[string][]byte{}
+			fakePublic = newFakeState(publicState)
+			orgState = map[string][]byte{}
+			fakeOrgState = newFakeState(orgState)
+
+			definition = &lb.ChaincodeDefinition{
+				Sequence:            1,
+				Version:             "version",
+				EndorsementPlugin:   "escc",
+				ValidationPlugin:    "vscc",
+				ValidationParameter: []byte("validation-parameter"),
+			}
+		})
+
+		Describe("ApproveChaincodeDefinitionForOrg", func() {
+			It("records the approval in the org state", func() {
+				err := l.ApproveChaincodeDefinitionForOrg("name", definition, "", fakePublic, fakeOrgState)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(fakePublic.PutStateCallCount()).To(Equal(0))
+				Expect(fakeOrgState.PutStateCallCount()).To(Equal(1))
+				key, value := fakeOrgState.PutStateArgsForCall(0)
+				Expect(key).To(Equal("namespaces/name#1"))
+				approved := &lb.ChaincodeDefinition{}
+				Expect(proto.Unmarshal(value, approved)).To(Succeed())
+				Expect(proto.Equal(approved, definition)).To(BeTrue())
+				Expect(orgState).NotTo(HaveKey("chaincode-sources/name#1"))
+			})
+
+			Context("when a package ID is provided", func() {
+				It("records the package ID apart from the approval", func() {
+					err := l.ApproveChaincodeDefinitionForOrg("name", definition, "label:hash", fakePublic, fakeOrgState)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(orgState).To(HaveKeyWithValue("chaincode-sources/name#1", []byte("label:hash")))
+
+					packageID, err := l.QueryApprovedPackageID("name", 1, fakeOrgState)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(packageID).To(Equal("label:hash"))
+				})
+
+				It("removes the package ID when the definition is approved again without one", func() {
+					Expect(l.ApproveChaincodeDefinitionForOrg("name", definition, "label:hash", fakePublic, fakeOrgState)).To(Succeed())
+					Expect(l.ApproveChaincodeDefinitionForOrg("name", definition, "", fakePublic, fakeOrgState)).To(Succeed())
+					Expect(orgState).NotTo(HaveKey("chaincode-sources/name#1"))
+					Expect(orgState).To(HaveKey("namespaces/name#1"))
+				})
+
+				It("does not change the approval", func() {
+					Expect(l.ApproveChaincodeDefinitionForOrg("name", definition, "label:hash", fakePublic, fakeOrgState)).To(Succeed())
+					withPackage := orgState["namespaces/name#1"]
+					Expect(l.ApproveChaincodeDefinitionForOrg("name", definition, "other-label:hash", fakePublic, fakeOrgState)).To(Succeed())
+					Expect(orgState["namespaces/name#1"]).To(Equal(withPackage))
+				})
+			})
+
+			Context("when a definition is already committed", func() {
+				BeforeEach(func() {
+					committed, err := proto.Marshal(&lb.ChaincodeDefinition{Sequence: 4})
+					Expect(err).NotTo(HaveOccurred())
+					publicState["namespaces/name"] = committed
+				})
+
+				It("requires the next sequence", func() {
+					err := l.ApproveChaincodeDefinitionForOrg("name", definition, "", fakePublic, fakeOrgState)
+					Expect(err).To(MatchError("requested sequence is 1, but new definition must be sequence 5"))
+
+					definition.Sequence = 5
+					err = l.ApproveChaincodeDefinitionForOrg("name", definition, "", fakePublic, fakeOrgState)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(orgState).To(HaveKey("namespaces/name#5"))
+				})
+			})
+
+			Context("when the name is empty", func() {
+				It("returns an error", func() {
+					err := l.ApproveChaincodeDefinitionForOrg("", definition, "", fakePublic, fakeOrgState)
+					Expect(err).To(MatchError("chaincode name must not be empty"))
+				})
+			})
+
+			Context("when reading the public state fails", func() {
+				BeforeEach(func() {
+					fakePublic.GetStateReturns(nil, fmt.Errorf("state-error"))
+				})
+
+				It("wraps and returns the error", func() {
+					err := l.ApproveChaincodeDefinitionForOrg("name", definition, "", fakePublic, fakeOrgState)
+					Expect(err).To(MatchError("could not read chaincode definition for 'name': state-error"))
+				})
+			})
+
+			Context("when writing the org state fails", func() {
+				BeforeEach(func() {
+					fakeOrgState.PutStateReturns(fmt.Errorf("state-error"))
+				})
+
+				It("wraps and returns the error", func() {
+					err := l.ApproveChaincodeDefinitionForOrg("name", definition, "", fakePublic, fakeOrgState)
+					Expect(err).To(MatchError("could not write approval of chaincode definition for 'name': state-error"))
+				})
+			})
+
+			Context("when writing the package ID fails", func() {
+				BeforeEach(func() {
+					fakeOrgState.PutStateReturnsOnCall(1, fmt.Errorf("state-error"))
+				})
+
+				It("wraps and returns the error", func() {
+					err := l.ApproveChaincodeDefinitionForOrg("name", definition, "label:hash", fakePublic, fakeOrgState)
+					Expect(err).To(MatchError("could not write package ID of chaincode definition for 'name': state-error"))
+				})
+			})
+		})
+
+		Describe("CommitChaincodeDefinition", func() {
+			var (
+				fakePolicy *mock.ApprovalPolicy
+				orgStates  map[string]lifecycle.OpaqueState
+				fakeOrg1   *mock.OpaqueState
+				fakeOrg2   *mock.OpaqueState
+			)
+
+			BeforeEach(func() {
+				Expect(l.ApproveChaincodeDefinitionForOrg("name", definition, "", fakePublic, fakeOrgState)).To(Succeed())
+				approvalHash := util.ComputeSHA256(orgState["namespaces/name#1"])
+
+				fakeOrg1 = &mock.OpaqueState{}
+				fakeOrg1.GetStateHashReturns(approvalHash, nil)
+				fakeOrg2 = &mock.OpaqueState{}
+				fakeOrg2.GetStateHashReturns([]byte("other-hash"), nil)
+				orgStates = map[string]lifecycle.OpaqueState{
+					"org1": fakeOrg1,
+					"org2": fakeOrg2,
+				}
+
+				fakePolicy = &mock.ApprovalPolicy{}
+				fakePolicy.OrgsReturns([]string{"org1", "org2", "org3"})
+				fakePolicy.SatisfiedReturns(true)
+			})
+
+			It("commits the definition approved by the orgs", func() {
+				approvals, err := l.CommitChaincodeDefinition("name", definition, fakePublic, orgStates, fakePolicy)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(approvals).To(Equal(map[string]bool{"org1": true, "org2": false, "org3": false}))
+
+				Expect(fakeOrg1.GetStateHashArgsForCall(0)).To(Equal("namespaces/name#1"))
+				Expect(fakeOrg2.GetStateHashArgsForCall(0)).To(Equal("namespaces/name#1"))
+				Expect(fakePolicy.SatisfiedCallCount()).To(Equal(1))
+				Expect(fakePolicy.SatisfiedArgsForCall(0)).To(Equal(approvals))
+
+				committed, err := l.QueryChaincodeDefinition("name", fakePublic)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(proto.Equal(committed, definition)).To(BeTrue())
+			})
+
+			Context("when the approvals do not satisfy the policy", func() {
+				BeforeEach(func() {
+					fakePolicy.SatisfiedReturns(false)
+				})
+
+				It("does not commit the definition", func() {
+					approvals, err := l.CommitChaincodeDefinition("name", definition, fakePublic, orgStates, fakePolicy)
+					Expect(err).To(MatchError("chaincode definition for 'name' at sequence 1 is not approved by enough orgs to satisfy the LifecycleEndorsement policy, approvals: map[org1:true org2:false org3:false]"))
+					Expect(approvals).To(Equal(map[string]bool{"org1": true, "org2": false, "org3": false}))
+					Expect(fakePublic.PutStateCallCount()).To(Equal(0))
+				})
+			})
+
+			Context("when the sequence is not the next one", func() {
+				BeforeEach(func() {
+					definition.Sequence = 2
+				})
+
+				It("returns an error", func() {
+					_, err := l.CommitChaincodeDefinition("name", definition, fakePublic, orgStates, fakePolicy)
+					Expect(err).To(MatchError("requested sequence is 2, but new definition must be sequence 1"))
+				})
+			})
+
+			Context("when reading the approval of an org fails", func() {
+				BeforeEach(func() {
+					fakeOrg2.GetStateHashReturns(nil, fmt.Errorf("state-error"))
+				})
+
+				It("wraps and returns the error", func() {
+					_, err := l.CommitChaincodeDefinition("name", definition, fakePublic, orgStates, fakePolicy)
+					Expect(err).To(MatchError("could not read approval of chaincode definition for 'name' by org 'org2': state-error"))
+				})
+			})
+
+			Context("when writing the public state fails", func() {
+				BeforeEach(func() {
+					fakePublic.PutStateReturns(fmt.Errorf("state-error"))
+				})
+
+				It("wraps and returns the error", func() {
+					_, err := l.CommitChaincodeDefinition("name", definition, fakePublic, orgStates, fakePolicy)
+					Expect(err).To(MatchError("could not write chaincode definition for 'name': state-error"))
+				})
+			})
+		})
+
+		Describe("CheckCommitReadiness", func() {
+			var (
+				fakePolicy *mock.ApprovalPolicy
+				orgStates  map[string]lifecycle.OpaqueState
+			)
+
+			BeforeEach(func() {
+				Expect(l.ApproveChaincodeDefinitionForOrg("name", definition, "", fakePublic, fakeOrgState)).To(Succeed())
+				fakeOrg1 := &mock.OpaqueState{}
+				fakeOrg1.GetStateHashReturns(util.ComputeSHA256(orgState["namespaces/name#1"]), nil)
+				orgStates = map[string]lifecycle.OpaqueState{"org1": fakeOrg1}
+
+				fakePolicy = &mock.ApprovalPolicy{}
+				fakePolicy.OrgsReturns([]string{"org1", "org2"})
+			})
+
+			It("returns the approvals of the orgs without committing the definition", func() {
+				approvals, err := l.CheckCommitReadiness("name", definition, fakePublic, orgStates, fakePolicy)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(approvals).To(Equal(map[string]bool{"org1": true, "org2": false}))
+				Expect(fakePublic.PutStateCallCount()).To(Equal(0))
+			})
+
+			Context("when the sequence is not the next one", func() {
+				BeforeEach(func() {
+					definition.Sequence = 3
+				})
+
+				It("returns an error", func() {
+					_, err := l.CheckCommitReadiness("name", definition, fakePublic, orgStates, fakePolicy)
+					Expect(err).To(MatchError("requested sequence is 3, but new definition must be sequence 1"))
+				})
+			})
+		})
+
+		Describe("QueryChaincodeDefinition", func() {
+			Context("when the definition is not committed", func() {
+				It("returns an error", func() {
+					_, err := l.QueryChaincodeDefinition("name", fakePublic)
+					Expect(err).To(MatchError("chaincode definition for 'name' not found"))
+				})
+			})
+
+			Context("when the committed definition is corrupt", func() {
+				BeforeEach(func() {
+					publicState["namespaces/name"] = []byte("garbage")
+				})
+
+				It("wraps and returns the error", func() {
+					_, err := l.QueryChaincodeDefinition("name", fakePublic)
+					Expect(err).To(HaveOccurred())
+					Expect(err.Error()).To(HavePrefix("could not unmarshal chaincode definition for 'name'"))
+				})
+			})
+		})
+
+		Describe("QueryApprovedPackageID", func() {
+			Context("when the org did not approve a package", func() {
+				It("returns an empty package ID", func() {
+					packageID, err := l.QueryApprovedPackageID("name", 1, fakeOrgState)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(packageID).To(BeEmpty())
+				})
+			})
+
+			Context("when reading the org state fails", func() {
+				BeforeEach(func() {
+					fakeOrgState.GetStateReturns(nil, fmt.Errorf("state-error"))
+				})
+
+				It("wraps and returns the error", func() {
+					_, err := l.QueryApprovedPackageID("name", 1, fakeOrgState)
+					Expect(err).To(MatchError("could not read package ID of chaincode definition for 'name': state-error"))
+				})
+			})
+		})
+	})
+
+	Describe("ChaincodeContainerInfo", func() {
+		var (
+			fakeLegacyImpl    *mock.LegacyLifecycle
+			fakeQueryExecutor *mock.QueryExecutor
+		)
+
+		BeforeEach(func() {
+			fakeLegacyImpl = &mock.LegacyLifecycle{}
+			fakeLegacyImpl.ChaincodeContainerInfoReturns(&ccprovider.ChaincodeContainerInfo{Name: "legacy-name"}, nil)
+			l.LegacyImpl = fakeLegacyImpl
+			l.OrgMSPID = "org1"
+
+			definition, err := proto.Marshal(&lb.ChaincodeDefinition{Sequence: 2, Version: "version"})
+			Expect(err).NotTo(HaveOccurred())
+			fakeQueryExecutor = &mock.QueryExecutor{}
+			fakeQueryExecutor.GetStateReturns(definition, nil)
+			fakeQueryExecutor.GetPrivateDataReturns([]byte("label:hash"), nil)
+
+			fakeCCStore.LoadReturns([]byte("cc-package"), nil)
+			fakeParser.ParseReturns(&persistence.ChaincodePackage{
+				Metadata: &persistence.ChaincodePackageMetadata{
+					Type:  "golang",
+					Path:  "cc-path",
+					Label: "label",
+				},
+			}, nil)
+		})
+
+		It("returns the container info of the package approved by the org of the peer", func() {
+			ccci, err := l.ChaincodeContainerInfo("name", fakeQueryExecutor)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(ccci).To(Equal(&ccprovider.ChaincodeContainerInfo{
+				Name:          "name",
+				Version:       "version",
+				Path:          "cc-path",
+				Type:          "GOLANG",
+				PackageID:     "label:hash",
+				ContainerType: "DOCKER",
+			}))
+
+			Expect(fakeQueryExecutor.GetStateCallCount()).To(Equal(1))
+			namespace, key := fakeQueryExecutor.GetStateArgsForCall(0)
+			Expect(namespace).To(Equal("+lifecycle"))
+			Expect(key).To(Equal("namespaces/name"))
+
+			Expect(fakeQueryExecutor.GetPrivateDataCallCount()).To(Equal(1))
+			namespace, collection, key := fakeQueryExecutor.GetPrivateDataArgsForCall(0)
+			Expect(namespace).To(Equal("+lifecycle"))
+			Expect(collection).To(Equal("_implicit_org_org1"))
+			Expect(key).To(Equal("chaincode-sources/name#2"))
+
+			Expect(fakeCCStore.LoadCallCount()).To(Equal(1))
+			Expect(fakeCCStore.LoadArgsForCall(0)).To(Equal("label:hash"))
+			Expect(fakeParser.ParseArgsForCall(0)).To(Equal([]byte("cc-package")))
+			Expect(fakeLegacyImpl.ChaincodeContainerInfoCallCount()).To(Equal(0))
+		})
+
+		Context("when the chaincode definition is not committed", func() {
+			BeforeEach(func() {
+				fakeQueryExecutor.GetStateReturns(nil, nil)
+			})
+
+			It("returns the container info of the legacy lifecycle", func() {
+				ccci, err := l.ChaincodeContainerInfo("name", fakeQueryExecutor)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(ccci).To(Equal(&ccprovider.ChaincodeContainerInfo{Name: "legacy-name"}))
+
+				Expect(fakeLegacyImpl.ChaincodeContainerInfoCallCount()).To(Equal(1))
+				name, qe := fakeLegacyImpl.ChaincodeContainerInfoArgsForCall(0)
+				Expect(name).To(Equal("name"))
+				Expect(qe).To(Equal(fakeQueryExecutor))
+				Expect(fakeCCStore.LoadCallCount()).To(Equal(0))
+			})
+		})
+
+		Context("when the org of the peer did not approve a package", func() {
+			BeforeEach(func() {
+				fakeQueryExecutor.GetPrivateDataReturns(nil, nil)
+			})
+
+			It("returns an error", func() {
+				_, err := l.ChaincodeContainerInfo("name", fakeQueryExecutor)
+				Expect(err).To(MatchError("org 'org1' has not approved a chaincode package for the definition of 'name' at sequence 2"))
+			})
+		})
+
+		Context("when reading the package ID fails", func() {
+			BeforeEach(func() {
+				fakeQueryExecutor.GetPrivateDataReturns(nil, fmt.Errorf("private-data-error"))
+			})
+
+			It("wraps and returns the error", func() {
+				_, err := l.ChaincodeContainerInfo("name", fakeQueryExecutor)
+				Expect(err).To(MatchError("could not read package ID of chaincode definition for 'name': private-data-error"))
+			})
+		})
+
+		Context("when the approved package is not installed", func() {
+			BeforeEach(func() {
+				fakeCCStore.LoadReturns(nil, &persistence.CodePackageNotFoundErr{PackageID: "label:hash"})
+			})
+
+			It("wraps and returns the error", func() {
+				_, err := l.ChaincodeContainerInfo("name", fakeQueryExecutor)
+				Expect(err).To(MatchError("could not load chaincode package of 'name': chaincode install package 'label:hash' not found"))
+			})
+		})
+
+		Context("when the approved package cannot be parsed", func() {
+			BeforeEach(func() {
+				fakeParser.ParseReturns(nil, fmt.Errorf("parse-error"))
+			})
+
+			It("wraps and returns the error", func() {
+				_, err := l.ChaincodeContainerInfo("name", fakeQueryExecutor)
+				Expect(err).To(MatchError("could not parse chaincode package of 'name': parse-error"))
+			})
+		})
+	})
+
+	Describe("ChaincodeDefinition of a chaincode", func() {
+		var (
+			fakeLegacyImpl    *mock.LegacyLifecycle
+			fakeQueryExecutor *mock.QueryExecutor
+		)
+
+		BeforeEach(func() {
+			fakeLegacyImpl = &mock.LegacyLifecycle{}
+			fakeLegacyImpl.ChaincodeDefinitionReturns(&ccprovider.ChaincodeData{Name: "name"}, nil)
+			l.LegacyImpl = fakeLegacyImpl
+
+			definition, err := proto.Marshal(&lb.ChaincodeDefinition{
+				Sequence:            2,
+				Version:             "version",
+				EndorsementPlugin:   "escc",
+				ValidationPlugin:    "vscc",
+				ValidationParameter: []byte("validation-parameter"),
+			})
+			Expect(err).NotTo(HaveOccurred())
+			fakeQueryExecutor = &mock.QueryExecutor{}
+			fakeQueryExecutor.GetStateReturns(definition, nil)
+		})
+
+		It("returns the definition committed to the channel", func() {
+			cd, err := l.ChaincodeDefinition("name", fakeQueryExecutor)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(cd).To(Equal(&lifecycle.LegacyDefinition{
+				Name:                "name",
+				Version:             "version",
+				EndorsementPlugin:   "escc",
+				ValidationPlugin:    "vscc",
+				ValidationParameter: []byte("validation-parameter"),
+			}))
+			Expect(cd.CCName()).To(Equal("name"))
+			Expect(cd.CCVersion()).To(Equal("version"))
+			Expect(cd.Endorsement()).To(Equal("escc"))
+			plugin, parameter := cd.Validation()
+			Expect(plugin).To(Equal("vscc"))
+			Expect(parameter).To(Equal([]byte("validation-parameter")))
+			Expect(cd.Hash()).To(BeNil())
+
+			namespace, key := fakeQueryExecutor.GetStateArgsForCall(0)
+			Expect(namespace).To(Equal("+lifecycle"))
+			Expect(key).To(Equal("namespaces/name"))
+			Expect(fakeLegacyImpl.ChaincodeDefinitionCallCount()).To(Equal(0))
+		})
+
+		Context("when the chaincode definition is not committed", func() {
+			BeforeEach(func() {
+				fakeQueryExecutor.GetStateReturns(nil, nil)
+			})
+
+			It("returns the definition of the legacy lifecycle", func() {
+				cd, err := l.ChaincodeDefinition("name", fakeQueryExecutor)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(cd).To(Equal(&ccprovider.ChaincodeData{Name: "name"}))
+				name, qe := fakeLegacyImpl.ChaincodeDefinitionArgsForCall(0)
+				Expect(name).To(Equal("name"))
+				Expect(qe).To(Equal(fakeQueryExecutor))
+			})
+		})
+
+		Context("when reading the chaincode definition fails", func() {
+			BeforeEach(func() {
+				fakeQueryExecutor.GetStateReturns(nil, fmt.Errorf("state-error"))
+			})
+
 			It("wraps and returns the error", func() {
-				hash, err := l.QueryInstalledChaincode("name", "version")
-				Expect(hash).To(BeNil())
-				Expect(err).To(MatchError("could not retrieve hash for chaincode 'name:version': fake-error"))
+				_, err := l.ChaincodeDefinition("name", fakeQueryExecutor)
+				Expect(err).To(MatchError("could not read chaincode definition for 'name': state-error"))
+				Expect(fakeLegacyImpl.ChaincodeDefinitionCallCount()).To(Equal(0))
 			})
 		})
 	})
@@ -3,59 +3,422 @@ package mock
 
 import (
 	sync "sync"
+
+	chaincode "github.com/hyperledger/fabric/common/chaincode"
+	lifecyclea "github.com/hyperledger/fabric/core/chaincode/lifecycle"
+	lifecycle "github.com/hyperledger/fabric/protos/peer/lifecycle"
 )
 
 type SCCFunctions struct {
-	InstallChaincodeStub        func(string, string, []byte) ([]byte, error)
+	ApproveChaincodeDefinitionForOrgStub        func(string, *lifecycle.ChaincodeDefinition, string, lifecyclea.ReadableState, lifecyclea.ReadWritableState) error
+	approveChaincodeDefinitionForOrgMutex       sync.RWMutex
+	approveChaincodeDefinitionForOrgArgsForCall []struct {
+		arg1 string
+		arg2 *lifecycle.ChaincodeDefinition
+		arg3 string
+		arg4 lifecyclea.ReadableState
+		arg5 lifecyclea.ReadWritableState
+	}
+	approveChaincodeDefinitionForOrgReturns struct {
+		result1 error
+	}
+	approveChaincodeDefinitionForOrgReturnsOnCall map[int]struct {
+		result1 error
+	}
+	CheckCommitReadinessStub        func(string, *lifecycle.ChaincodeDefinition, lifecyclea.ReadableState, map[string]lifecyclea.OpaqueState, lifecyclea.ApprovalPolicy) (map[string]bool, error)
+	checkCommitReadinessMutex       sync.RWMutex
+	checkCommitReadinessArgsForCall []struct {
+		arg1 string
+		arg2 *lifecycle.ChaincodeDefinition
+		arg3 lifecyclea.ReadableState
+		arg4 map[string]lifecyclea.OpaqueState
+		arg5 lifecyclea.ApprovalPolicy
+	}
+	checkCommitReadinessReturns struct {
+		result1 map[string]bool
+		result2 error
+	}
+	checkCommitReadinessReturnsOnCall map[int]struct {
+		result1 map[string]bool
+		result2 error
+	}
+	CommitChaincodeDefinitionStub        func(string, *lifecycle.ChaincodeDefinition, lifecyclea.ReadWritableState, map[string]lifecyclea.OpaqueState, lifecyclea.ApprovalPolicy) (map[string]bool, error)
+	commitChaincodeDefinitionMutex       sync.RWMutex
+	commitChaincodeDefinitionArgsForCall []struct {
+		arg1 string
+		arg2 *lifecycle.ChaincodeDefinition
+		arg3 lifecyclea.ReadWritableState
+		arg4 map[string]lifecyclea.OpaqueState
+		arg5 lifecyclea.ApprovalPolicy
+	}
+	commitChaincodeDefinitionReturns struct {
+		result1 map[string]bool
+		result2 error
+	}
+	commitChaincodeDefinitionReturnsOnCall map[int]struct {
+		result1 map[string]bool
+		result2 error
+	}
+	GetInstalledChaincodePackageStub        func(string) ([]byte, error)
+	getInstalledChaincodePackageMutex       sync.RWMutex
+	getInstalledChaincodePackageArgsForCall []struct {
+		arg1 string
+	}
+	getInstalledChaincodePackageReturns struct {
+		result1 []byte
+		result2 error
+	}
+	getInstalledChaincodePackageReturnsOnCall map[int]struct {
+		result1 []byte
+		result2 error
+	}
+	InstallChaincodeStub        func([]byte) (*chaincode.InstalledChaincode, error)
 	installChaincodeMutex       sync.RWMutex
 	installChaincodeArgsForCall []struct {
-		arg1 string
-		arg2 string
-		arg3 []byte
+		arg1 []byte
 	}
 	installChaincodeReturns struct {
-		result1 []byte
+		result1 *chaincode.InstalledChaincode
 		result2 error
 	}
 	installChaincodeReturnsOnCall map[int]struct {
-		result1 []byte
+		result1 *chaincode.InstalledChaincode
 		result2 error
 	}
-	QueryInstalledChaincodeStub        func(string, string) ([]byte, error)
+	QueryApprovedPackageIDStub        func(string, int64, lifecyclea.ReadableState) (string, error)
+	queryApprovedPackageIDMutex       sync.RWMutex
+	queryApprovedPackageIDArgsForCall []struct {
+		arg1 string
+		arg2 int64
+		arg3 lifecyclea.ReadableState
+	}
+	queryApprovedPackageIDReturns struct {
+		result1 string
+		result2 error
+	}
+	queryApprovedPackageIDReturnsOnCall map[int]struct {
+		result1 string
+		result2 error
+	}
+	QueryChaincodeDefinitionStub        func(string, lifecyclea.ReadableState) (*lifecycle.ChaincodeDefinition, error)
+	queryChaincodeDefinitionMutex       sync.RWMutex
+	queryChaincodeDefinitionArgsForCall []struct {
+		arg1 string
+		arg2 lifecyclea.ReadableState
+	}
+	queryChaincodeDefinitionReturns struct {
+		result1 *lifecycle.ChaincodeDefinition
+		result2 error
+	}
+	queryChaincodeDefinitionReturnsOnCall map[int]struct {
+		result1 *lifecycle.ChaincodeDefinition
+		result2 error
+	}
+	QueryInstalledChaincodeStub        func(string) (*chaincode.InstalledChaincode, error)
 	queryInstalledChaincodeMutex       sync.RWMutex
 	queryInstalledChaincodeArgsForCall []struct {
 		arg1 string
-		arg2 string
 	}
 	queryInstalledChaincodeReturns struct {
-		result1 []byte
+		result1 *chaincode.InstalledChaincode
 		result2 error
 	}
 	queryInstalledChaincodeReturnsOnCall map[int]struct {
-		result1 []byte
+		result1 *chaincode.InstalledChaincode
+		result2 error
+	}
+	QueryInstalledChaincodesStub        func() ([]chaincode.InstalledChaincode, error)
+	queryInstalledChaincodesMutex       sync.RWMutex
+	queryInstalledChaincodesArgsForCall []struct {
+	}
+	queryInstalledChaincodesReturns struct {
+		result1 []chaincode.InstalledChaincode
+		result2 error
+	}
+	queryInstalledChaincodesReturnsOnCall map[int]struct {
+		result1 []chaincode.InstalledChaincode
 		result2 error
 	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
 
-func (fake *SCCFunctions) InstallChaincode(arg1 string, arg2 string, arg3 []byte) ([]byte, error) {
-	var arg3Copy []byte
-	if arg3 != nil {
-		arg3Copy = make([]byte, len(arg3))
-		copy(arg3Copy, arg3)
+func (fake *SCCFunctions) ApproveChaincodeDefinitionForOrg(arg1 string, arg2 *lifecycle.ChaincodeDefinition, arg3 string, arg4 lifecyclea.ReadableState, arg5 lifecyclea.ReadWritableState) error {
+	fake.approveChaincodeDefinitionForOrgMutex.Lock()
+	ret, specificReturn := fake.approveChaincodeDefinitionForOrgReturnsOnCall[len(fake.approveChaincodeDefinitionForOrgArgsForCall)]
+	fake.approveChaincodeDefinitionForOrgArgsForCall = append(fake.approveChaincodeDefinitionForOrgArgsForCall, struct {
+		arg1 string
+		arg2 *lifecycle.ChaincodeDefinition
+		arg3 string
+		arg4 lifecyclea.ReadableState
+		arg5 lifecyclea.ReadWritableState
+	}{arg1, arg2, arg3, arg4, arg5})
+	fake.recordInvocation("ApproveChaincodeDefinitionForOrg", []interface{}{arg1, arg2, arg3, arg4, arg5})
+	fake.approveChaincodeDefinitionForOrgMutex.Unlock()
+	if fake.ApproveChaincodeDefinitionForOrgStub != nil {
+		return fake.ApproveChaincodeDefinitionForOrgStub(arg1, arg2, arg3, arg4, arg5)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.approveChaincodeDefinitionForOrgReturns
+	return fakeReturns.result1
+}
+
+func (fake *SCCFunctions) ApproveChaincodeDefinitionForOrgCallCount() int {
+	fake.approveChaincodeDefinitionForOrgMutex.RLock()
+	defer fake.approveChaincodeDefinitionForOrgMutex.RUnlock()
+	return len(fake.approveChaincodeDefinitionForOrgArgsForCall)
+}
+
+func (fake *SCCFunctions) ApproveChaincodeDefinitionForOrgCalls(stub func(string, *lifecycle.ChaincodeDefinition, string, lifecyclea.ReadableState, lifecyclea.ReadWritableState) error) {
+	fake.approveChaincodeDefinitionForOrgMutex.Lock()
+	defer fake.approveChaincodeDefinitionForOrgMutex.Unlock()
+	fake.ApproveChaincodeDefinitionForOrgStub = stub
+}
+
+func (fake *SCCFunctions) ApproveChaincodeDefinitionForOrgArgsForCall(i int) (string, *lifecycle.ChaincodeDefinition, string, lifecyclea.ReadableState, lifecyclea.ReadWritableState) {
+	fake.approveChaincodeDefinitionForOrgMutex.RLock()
+	defer fake.approveChaincodeDefinitionForOrgMutex.RUnlock()
+	argsForCall := fake.approveChaincodeDefinitionForOrgArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4, argsForCall.arg5
+}
+
+func (fake *SCCFunctions) ApproveChaincodeDefinitionForOrgReturns(result1 error) {
+	fake.approveChaincodeDefinitionForOrgMutex.Lock()
+	defer fake.approveChaincodeDefinitionForOrgMutex.Unlock()
+	fake.ApproveChaincodeDefinitionForOrgStub = nil
+	fake.approveChaincodeDefinitionForOrgReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *SCCFunctions) ApproveChaincodeDefinitionForOrgReturnsOnCall(i int, result1 error) {
+	fake.approveChaincodeDefinitionForOrgMutex.Lock()
+	defer fake.approveChaincodeDefinitionForOrgMutex.Unlock()
+	fake.ApproveChaincodeDefinitionForOrgStub = nil
+	if fake.approveChaincodeDefinitionForOrgReturnsOnCall == nil {
+		fake.approveChaincodeDefinitionForOrgReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.approveChaincodeDefinitionForOrgReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *SCCFunctions) CheckCommitReadiness(arg1 string, arg2 *lifecycle.ChaincodeDefinition, arg3 lifecyclea.ReadableState, arg4 map[string]lifecyclea.OpaqueState, arg5 lifecyclea.ApprovalPolicy) (map[string]bool, error) {
+	fake.checkCommitReadinessMutex.Lock()
+	ret, specificReturn := fake.checkCommitReadinessReturnsOnCall[len(fake.checkCommitReadinessArgsForCall)]
+	fake.checkCommitReadinessArgsForCall = append(fake.checkCommitReadinessArgsForCall, struct {
+		arg1 string
+		arg2 *lifecycle.ChaincodeDefinition
+		arg3 lifecyclea.ReadableState
+		arg4 map[string]lifecyclea.OpaqueState
+		arg5 lifecyclea.ApprovalPolicy
+	}{arg1, arg2, arg3, arg4, arg5})
+	fake.recordInvocation("CheckCommitReadiness", []interface{}{arg1, arg2, arg3, arg4, arg5})
+	fake.checkCommitReadinessMutex.Unlock()
+	if fake.CheckCommitReadinessStub != nil {
+		return fake.CheckCommitReadinessStub(arg1, arg2, arg3, arg4, arg5)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.checkCommitReadinessReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *SCCFunctions) CheckCommitReadinessCallCount() int {
+	fake.checkCommitReadinessMutex.RLock()
+	defer fake.checkCommitReadinessMutex.RUnlock()
+	return len(fake.checkCommitReadinessArgsForCall)
+}
+
+func (fake *SCCFunctions) CheckCommitReadinessCalls(stub func(string, *lifecycle.ChaincodeDefinition, lifecyclea.ReadableState, map[string]lifecyclea.OpaqueState, lifecyclea.ApprovalPolicy) (map[string]bool, error)) {
+	fake.checkCommitReadinessMutex.Lock()
+	defer fake.checkCommitReadinessMutex.Unlock()
+	fake.CheckCommitReadinessStub = stub
+}
+
+func (fake *SCCFunctions) CheckCommitReadinessArgsForCall(i int) (string, *lifecycle.ChaincodeDefinition, lifecyclea.ReadableState, map[string]lifecyclea.OpaqueState, lifecyclea.ApprovalPolicy) {
+	fake.checkCommitReadinessMutex.RLock()
+	defer fake.checkCommitReadinessMutex.RUnlock()
+	argsForCall := fake.checkCommitReadinessArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4, argsForCall.arg5
+}
+
+func (fake *SCCFunctions) CheckCommitReadinessReturns(result1 map[string]bool, result2 error) {
+	fake.checkCommitReadinessMutex.Lock()
+	defer fake.checkCommitReadinessMutex.Unlock()
+	fake.CheckCommitReadinessStub = nil
+	fake.checkCommitReadinessReturns = struct {
+		result1 map[string]bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *SCCFunctions) CheckCommitReadinessReturnsOnCall(i int, result1 map[string]bool, result2 error) {
+	fake.checkCommitReadinessMutex.Lock()
+	defer fake.checkCommitReadinessMutex.Unlock()
+	fake.CheckCommitReadinessStub = nil
+	if fake.checkCommitReadinessReturnsOnCall == nil {
+		fake.checkCommitReadinessReturnsOnCall = make(map[int]struct {
+			result1 map[string]bool
+			result2 error
+		})
+	}
+	fake.checkCommitReadinessReturnsOnCall[i] = struct {
+		result1 map[string]bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *SCCFunctions) CommitChaincodeDefinition(arg1 string, arg2 *lifecycle.ChaincodeDefinition, arg3 lifecyclea.ReadWritableState, arg4 map[string]lifecyclea.OpaqueState, arg5 lifecyclea.ApprovalPolicy) (map[string]bool, error) {
+	fake.commitChaincodeDefinitionMutex.Lock()
+	ret, specificReturn := fake.commitChaincodeDefinitionReturnsOnCall[len(fake.commitChaincodeDefinitionArgsForCall)]
+	fake.commitChaincodeDefinitionArgsForCall = append(fake.commitChaincodeDefinitionArgsForCall, struct {
+		arg1 string
+		arg2 *lifecycle.ChaincodeDefinition
+		arg3 lifecyclea.ReadWritableState
+		arg4 map[string]lifecyclea.OpaqueState
+		arg5 lifecyclea.ApprovalPolicy
+	}{arg1, arg2, arg3, arg4, arg5})
+	fake.recordInvocation("CommitChaincodeDefinition", []interface{}{arg1, arg2, arg3, arg4, arg5})
+	fake.commitChaincodeDefinitionMutex.Unlock()
+	if fake.CommitChaincodeDefinitionStub != nil {
+		return fake.CommitChaincodeDefinitionStub(arg1, arg2, arg3, arg4, arg5)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.commitChaincodeDefinitionReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *SCCFunctions) CommitChaincodeDefinitionCallCount() int {
+	fake.commitChaincodeDefinitionMutex.RLock()
+	defer fake.commitChaincodeDefinitionMutex.RUnlock()
+	return len(fake.commitChaincodeDefinitionArgsForCall)
+}
+
+func (fake *SCCFunctions) CommitChaincodeDefinitionCalls(stub func(string, *lifecycle.ChaincodeDefinition, lifecyclea.ReadWritableState, map[string]lifecyclea.OpaqueState, lifecyclea.ApprovalPolicy) (map[string]bool, error)) {
+	fake.commitChaincodeDefinitionMutex.Lock()
+	defer fake.commitChaincodeDefinitionMutex.Unlock()
+	fake.CommitChaincodeDefinitionStub = stub
+}
+
+func (fake *SCCFunctions) CommitChaincodeDefinitionArgsForCall(i int) (string, *lifecycle.ChaincodeDefinition, lifecyclea.ReadWritableState, map[string]lifecyclea.OpaqueState, lifecyclea.ApprovalPolicy) {
+	fake.commitChaincodeDefinitionMutex.RLock()
+	defer fake.commitChaincodeDefinitionMutex.RUnlock()
+	argsForCall := fake.commitChaincodeDefinitionArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4, argsForCall.arg5
+}
+
+func (fake *SCCFunctions) CommitChaincodeDefinitionReturns(result1 map[string]bool, result2 error) {
+	fake.commitChaincodeDefinitionMutex.Lock()
+	defer fake.commitChaincodeDefinitionMutex.Unlock()
+	fake.CommitChaincodeDefinitionStub = nil
+	fake.commitChaincodeDefinitionReturns = struct {
+		result1 map[string]bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *SCCFunctions) CommitChaincodeDefinitionReturnsOnCall(i int, result1 map[string]bool, result2 error) {
+	fake.commitChaincodeDefinitionMutex.Lock()
+	defer fake.commitChaincodeDefinitionMutex.Unlock()
+	fake.CommitChaincodeDefinitionStub = nil
+	if fake.commitChaincodeDefinitionReturnsOnCall == nil {
+		fake.commitChaincodeDefinitionReturnsOnCall = make(map[int]struct {
+			result1 map[string]bool
+			result2 error
+		})
+	}
+	fake.commitChaincodeDefinitionReturnsOnCall[i] = struct {
+		result1 map[string]bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *SCCFunctions) GetInstalledChaincodePackage(arg1 string) ([]byte, error) {
+	fake.getInstalledChaincodePackageMutex.Lock()
+	ret, specificReturn := fake.getInstalledChaincodePackageReturnsOnCall[len(fake.getInstalledChaincodePackageArgsForCall)]
+	fake.getInstalledChaincodePackageArgsForCall = append(fake.getInstalledChaincodePackageArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	fake.recordInvocation("GetInstalledChaincodePackage", []interface{}{arg1})
+	fake.getInstalledChaincodePackageMutex.Unlock()
+	if fake.GetInstalledChaincodePackageStub != nil {
+		return fake.GetInstalledChaincodePackageStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.getInstalledChaincodePackageReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *SCCFunctions) GetInstalledChaincodePackageCallCount() int {
+	fake.getInstalledChaincodePackageMutex.RLock()
+	defer fake.getInstalledChaincodePackageMutex.RUnlock()
+	return len(fake.getInstalledChaincodePackageArgsForCall)
+}
+
+func (fake *SCCFunctions) GetInstalledChaincodePackageCalls(stub func(string) ([]byte, error)) {
+	fake.getInstalledChaincodePackageMutex.Lock()
+	defer fake.getInstalledChaincodePackageMutex.Unlock()
+	fake.GetInstalledChaincodePackageStub = stub
+}
+
+func (fake *SCCFunctions) GetInstalledChaincodePackageArgsForCall(i int) string {
+	fake.getInstalledChaincodePackageMutex.RLock()
+	defer fake.getInstalledChaincodePackageMutex.RUnlock()
+	argsForCall := fake.getInstalledChaincodePackageArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *SCCFunctions) GetInstalledChaincodePackageReturns(result1 []byte, result2 error) {
+	fake.getInstalledChaincodePackageMutex.Lock()
+	defer fake.getInstalledChaincodePackageMutex.Unlock()
+	fake.GetInstalledChaincodePackageStub = nil
+	fake.getInstalledChaincodePackageReturns = struct {
+		result1 []byte
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *SCCFunctions) GetInstalledChaincodePackageReturnsOnCall(i int, result1 []byte, result2 error) {
+	fake.getInstalledChaincodePackageMutex.Lock()
+	defer fake.getInstalledChaincodePackageMutex.Unlock()
+	fake.GetInstalledChaincodePackageStub = nil
+	if fake.getInstalledChaincodePackageReturnsOnCall == nil {
+		fake.getInstalledChaincodePackageReturnsOnCall = make(map[int]struct {
+			result1 []byte
+			result2 error
+		})
+	}
+	fake.getInstalledChaincodePackageReturnsOnCall[i] = struct {
+		result1 []byte
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *SCCFunctions) InstallChaincode(arg1 []byte) (*chaincode.InstalledChaincode, error) {
+	var arg1Copy []byte
+	if arg1 != nil {
+		arg1Copy = make([]byte, len(arg1))
+		copy(arg1Copy, arg1)
 	}
 	fake.installChaincodeMutex.Lock()
 	ret, specificReturn := fake.installChaincodeReturnsOnCall[len(fake.installChaincodeArgsForCall)]
 	fake.installChaincodeArgsForCall = append(fake.installChaincodeArgsForCall, struct {
-		arg1 string
-		arg2 string
-		arg3 []byte
-	}{arg1, arg2, arg3Copy})
-	fake.recordInvocation("InstallChaincode", []interface{}{arg1, arg2, arg3Copy})
+		arg1 []byte
+	}{arg1Copy})
+	fake.recordInvocation("InstallChaincode", []interface{}{arg1Copy})
 	fake.installChaincodeMutex.Unlock()
 	if fake.InstallChaincodeStub != nil {
-		return fake.InstallChaincodeStub(arg1, arg2, arg3)
+		return fake.InstallChaincodeStub(arg1)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
@@ -70,56 +433,184 @@ func (fake *SCCFunctions) InstallChaincodeCallCount() int {
 	return len(fake.installChaincodeArgsForCall)
 }
 
-func (fake *SCCFunctions) InstallChaincodeCalls(stub func(string, string, []byte) ([]byte, error)) {
+func (fake *SCCFunctions) InstallChaincodeCalls(stub func([]byte) (*chaincode.InstalledChaincode, error)) {
 	fake.installChaincodeMutex.Lock()
 	defer fake.installChaincodeMutex.Unlock()
 	fake.InstallChaincodeStub = stub
 }
 
-func (fake *SCCFunctions) InstallChaincodeArgsForCall(i int) (string, string, []byte) {
+func (fake *SCCFunctions) InstallChaincodeArgsForCall(i int) []byte {
 	fake.installChaincodeMutex.RLock()
 	defer fake.installChaincodeMutex.RUnlock()
 	argsForCall := fake.installChaincodeArgsForCall[i]
-	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+	return argsForCall.arg1
 }
 
-func (fake *SCCFunctions) InstallChaincodeReturns(result1 []byte, result2 error) {
+func (fake *SCCFunctions) InstallChaincodeReturns(result1 *chaincode.InstalledChaincode, result2 error) {
 	fake.installChaincodeMutex.Lock()
 	defer fake.installChaincodeMutex.Unlock()
 	fake.InstallChaincodeStub = nil
 	fake.installChaincodeReturns = struct {
-		result1 []byte
+		result1 *chaincode.InstalledChaincode
 		result2 error
 	}{result1, result2}
 }
 
-func (fake *SCCFunctions) InstallChaincodeReturnsOnCall(i int, result1 []byte, result2 error) {
+func (fake *SCCFunctions) InstallChaincodeReturnsOnCall(i int, result1 *chaincode.InstalledChaincode, result2 error) {
 	fake.installChaincodeMutex.Lock()
 	defer fake.installChaincodeMutex.Unlock()
 	fake.InstallChaincodeStub = nil
 	if fake.installChaincodeReturnsOnCall == nil {
 		fake.installChaincodeReturnsOnCall = make(map[int]struct {
-			result1 []byte
+			result1 *chaincode.InstalledChaincode
 			result2 error
 		})
 	}
 	fake.installChaincodeReturnsOnCall[i] = struct {
-		result1 []byte
+		result1 *chaincode.InstalledChaincode
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *SCCFunctions) QueryApprovedPackageID(arg1 string, arg2 int64, arg3 lifecyclea.ReadableState) (string, error) {
+	fake.queryApprovedPackageIDMutex.Lock()
+	ret, specificReturn := fake.queryApprovedPackageIDReturnsOnCall[len(fake.queryApprovedPackageIDArgsForCall)]
+	fake.queryApprovedPackageIDArgsForCall = append(fake.queryApprovedPackageIDArgsForCall, struct {
+		arg1 string
+		arg2 int64
+		arg3 lifecyclea.ReadableState
+	}{arg1, arg2, arg3})
+	fake.recordInvocation("QueryApprovedPackageID", []interface{}{arg1, arg2, arg3})
+	fake.queryApprovedPackageIDMutex.Unlock()
+	if fake.QueryApprovedPackageIDStub != nil {
+		return fake.QueryApprovedPackageIDStub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.queryApprovedPackageIDReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *SCCFunctions) QueryApprovedPackageIDCallCount() int {
+	fake.queryApprovedPackageIDMutex.RLock()
+	defer fake.queryApprovedPackageIDMutex.RUnlock()
+	return len(fake.queryApprovedPackageIDArgsForCall)
+}
+
+func (fake *SCCFunctions) QueryApprovedPackageIDCalls(stub func(string, int64, lifecyclea.ReadableState) (string, error)) {
+	fake.queryApprovedPackageIDMutex.Lock()
+	defer fake.queryApprovedPackageIDMutex.Unlock()
+	fake.QueryApprovedPackageIDStub = stub
+}
+
+func (fake *SCCFunctions) QueryApprovedPackageIDArgsForCall(i int) (string, int64, lifecyclea.ReadableState) {
+	fake.queryApprovedPackageIDMutex.RLock()
+	defer fake.queryApprovedPackageIDMutex.RUnlock()
+	argsForCall := fake.queryApprovedPackageIDArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *SCCFunctions) QueryApprovedPackageIDReturns(result1 string, result2 error) {
+	fake.queryApprovedPackageIDMutex.Lock()
+	defer fake.queryApprovedPackageIDMutex.Unlock()
+	fake.QueryApprovedPackageIDStub = nil
+	fake.queryApprovedPackageIDReturns = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *SCCFunctions) QueryApprovedPackageIDReturnsOnCall(i int, result1 string, result2 error) {
+	fake.queryApprovedPackageIDMutex.Lock()
+	defer fake.queryApprovedPackageIDMutex.Unlock()
+	fake.QueryApprovedPackageIDStub = nil
+	if fake.queryApprovedPackageIDReturnsOnCall == nil {
+		fake.queryApprovedPackageIDReturnsOnCall = make(map[int]struct {
+			result1 string
+			result2 error
+		})
+	}
+	fake.queryApprovedPackageIDReturnsOnCall[i] = struct {
+		result1 string
 		result2 error
 	}{result1, result2}
 }
 
-func (fake *SCCFunctions) QueryInstalledChaincode(arg1 string, arg2 string) ([]byte, error) {
+func (fake *SCCFunctions) QueryChaincodeDefinition(arg1 string, arg2 lifecyclea.ReadableState) (*lifecycle.ChaincodeDefinition, error) {
+	fake.queryChaincodeDefinitionMutex.Lock()
+	ret, specificReturn := fake.queryChaincodeDefinitionReturnsOnCall[len(fake.queryChaincodeDefinitionArgsForCall)]
+	fake.queryChaincodeDefinitionArgsForCall = append(fake.queryChaincodeDefinitionArgsForCall, struct {
+		arg1 string
+		arg2 lifecyclea.ReadableState
+	}{arg1, arg2})
+	fake.recordInvocation("QueryChaincodeDefinition", []interface{}{arg1, arg2})
+	fake.queryChaincodeDefinitionMutex.Unlock()
+	if fake.QueryChaincodeDefinitionStub != nil {
+		return fake.QueryChaincodeDefinitionStub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.queryChaincodeDefinitionReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *SCCFunctions) QueryChaincodeDefinitionCallCount() int {
+	fake.queryChaincodeDefinitionMutex.RLock()
+	defer fake.queryChaincodeDefinitionMutex.RUnlock()
+	return len(fake.queryChaincodeDefinitionArgsForCall)
+}
+
+func (fake *SCCFunctions) QueryChaincodeDefinitionCalls(stub func(string, lifecyclea.ReadableState) (*lifecycle.ChaincodeDefinition, error)) {
+	fake.queryChaincodeDefinitionMutex.Lock()
+	defer fake.queryChaincodeDefinitionMutex.Unlock()
+	fake.QueryChaincodeDefinitionStub = stub
+}
+
+func (fake *SCCFunctions) QueryChaincodeDefinitionArgsForCall(i int) (string, lifecyclea.ReadableState) {
+	fake.queryChaincodeDefinitionMutex.RLock()
+	defer fake.queryChaincodeDefinitionMutex.RUnlock()
+	argsForCall := fake.queryChaincodeDefinitionArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *SCCFunctions) QueryChaincodeDefinitionReturns(result1 *lifecycle.ChaincodeDefinition, result2 error) {
+	fake.queryChaincodeDefinitionMutex.Lock()
+	defer fake.queryChaincodeDefinitionMutex.Unlock()
+	fake.QueryChaincodeDefinitionStub = nil
+	fake.queryChaincodeDefinitionReturns = struct {
+		result1 *lifecycle.ChaincodeDefinition
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *SCCFunctions) QueryChaincodeDefinitionReturnsOnCall(i int, result1 *lifecycle.ChaincodeDefinition, result2 error) {
+	fake.queryChaincodeDefinitionMutex.Lock()
+	defer fake.queryChaincodeDefinitionMutex.Unlock()
+	fake.QueryChaincodeDefinitionStub = nil
+	if fake.queryChaincodeDefinitionReturnsOnCall == nil {
+		fake.queryChaincodeDefinitionReturnsOnCall = make(map[int]struct {
+			result1 *lifecycle.ChaincodeDefinition
+			result2 error
+		})
+	}
+	fake.queryChaincodeDefinitionReturnsOnCall[i] = struct {
+		result1 *lifecycle.ChaincodeDefinition
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *SCCFunctions) QueryInstalledChaincode(arg1 string) (*chaincode.InstalledChaincode, error) {
 	fake.queryInstalledChaincodeMutex.Lock()
 	ret, specificReturn := fake.queryInstalledChaincodeReturnsOnCall[len(fake.queryInstalledChaincodeArgsForCall)]
 	fake.queryInstalledChaincodeArgsForCall = append(fake.queryInstalledChaincodeArgsForCall, struct {
 		arg1 string
-		arg2 string
-	}{arg1, arg2})
-	fake.recordInvocation("QueryInstalledChaincode", []interface{}{arg1, arg2})
+	}{arg1})
+	fake.recordInvocation("QueryInstalledChaincode", []interface{}{arg1})
 	fake.queryInstalledChaincodeMutex.Unlock()
 	if fake.QueryInstalledChaincodeStub != nil {
-		return fake.QueryInstalledChaincodeStub(arg1, arg2)
+		return fake.QueryInstalledChaincodeStub(arg1)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
@@ -134,41 +625,96 @@ func (fake *SCCFunctions) QueryInstalledChaincodeCallCount() int {
 	return len(fake.queryInstalledChaincodeArgsForCall)
 }
 
-func (fake *SCCFunctions) QueryInstalledChaincodeCalls(stub func(string, string) ([]byte, error)) {
+func (fake *SCCFunctions) QueryInstalledChaincodeCalls(stub func(string) (*chaincode.InstalledChaincode, error)) {
 	fake.queryInstalledChaincodeMutex.Lock()
 	defer fake.queryInstalledChaincodeMutex.Unlock()
 	fake.QueryInstalledChaincodeStub = stub
 }
 
-func (fake *SCCFunctions) QueryInstalledChaincodeArgsForCall(i int) (string, string) {
+func (fake *SCCFunctions) QueryInstalledChaincodeArgsForCall(i int) string {
 	fake.queryInstalledChaincodeMutex.RLock()
 	defer fake.queryInstalledChaincodeMutex.RUnlock()
 	argsForCall := fake.queryInstalledChaincodeArgsForCall[i]
-	return argsForCall.arg1, argsForCall.arg2
+	return argsForCall.arg1
 }
 
-func (fake *SCCFunctions) QueryInstalledChaincodeReturns(result1 []byte, result2 error) {
+func (fake *SCCFunctions) QueryInstalledChaincodeReturns(result1 *chaincode.InstalledChaincode, result2 error) {
 	fake.queryInstalledChaincodeMutex.Lock()
 	defer fake.queryInstalledChaincodeMutex.Unlock()
 	fake.QueryInstalledChaincodeStub = nil
 	fake.queryInstalledChaincodeReturns = struct {
-		result1 []byte
+		result1 *chaincode.InstalledChaincode
 		result2 error
 	}{result1, result2}
 }
 
-func (fake *SCCFunctions) QueryInstalledChaincodeReturnsOnCall(i int, result1 []byte, result2 error) {
+func (fake *SCCFunctions) QueryInstalledChaincodeReturnsOnCall(i int, result1 *chaincode.InstalledChaincode, result2 error) {
 	fake.queryInstalledChaincodeMutex.Lock()
 	defer fake.queryInstalledChaincodeMutex.Unlock()
 	fake.QueryInstalledChaincodeStub = nil
 	if fake.queryInstalledChaincodeReturnsOnCall == nil {
 		fake.queryInstalledChaincodeReturnsOnCall = make(map[int]struct {
-			result1 []byte
+			result1 *chaincode.InstalledChaincode
 			result2 error
 		})
 	}
 	fake.queryInstalledChaincodeReturnsOnCall[i] = struct {
-		result1 []byte
+		result1 *chaincode.InstalledChaincode
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *SCCFunctions) QueryInstalledChaincodes() ([]chaincode.InstalledChaincode, error) {
+	fake.queryInstalledChaincodesMutex.Lock()
+	ret, specificReturn := fake.queryInstalledChaincodesReturnsOnCall[len(fake.queryInstalledChaincodesArgsForCall)]
+	fake.queryInstalledChaincodesArgsForCall = append(fake.queryInstalledChaincodesArgsForCall, struct {
+	}{})
+	fake.recordInvocation("QueryInstalledChaincodes", []interface{}{})
+	fake.queryInstalledChaincodesMutex.Unlock()
+	if fake.QueryInstalledChaincodesStub != nil {
+		return fake.QueryInstalledChaincodesStub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.queryInstalledChaincodesReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *SCCFunctions) QueryInstalledChaincodesCallCount() int {
+	fake.queryInstalledChaincodesMutex.RLock()
+	defer fake.queryInstalledChaincodesMutex.RUnlock()
+	return len(fake.queryInstalledChaincodesArgsForCall)
+}
+
+func (fake *SCCFunctions) QueryInstalledChaincodesCalls(stub func() ([]chaincode.InstalledChaincode, error)) {
+	fake.queryInstalledChaincodesMutex.Lock()
+	defer fake.queryInstalledChaincodesMutex.Unlock()
+	fake.QueryInstalledChaincodesStub = stub
+}
+
+func (fake *SCCFunctions) QueryInstalledChaincodesReturns(result1 []chaincode.InstalledChaincode, result2 error) {
+	fake.queryInstalledChaincodesMutex.Lock()
+	defer fake.queryInstalledChaincodesMutex.Unlock()
+	fake.QueryInstalledChaincodesStub = nil
+	fake.queryInstalledChaincodesReturns = struct {
+		result1 []chaincode.InstalledChaincode
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *SCCFunctions) QueryInstalledChaincodesReturnsOnCall(i int, result1 []chaincode.InstalledChaincode, result2 error) {
+	fake.queryInstalledChaincodesMutex.Lock()
+	defer fake.queryInstalledChaincodesMutex.Unlock()
+	fake.QueryInstalledChaincodesStub = nil
+	if fake.queryInstalledChaincodesReturnsOnCall == nil {
+		fake.queryInstalledChaincodesReturnsOnCall = make(map[int]struct {
+			result1 []chaincode.InstalledChaincode
+			result2 error
+		})
+	}
+	fake.queryInstalledChaincodesReturnsOnCall[i] = struct {
+		result1 []chaincode.InstalledChaincode
 		result2 error
 	}{result1, result2}
 }
@@ -176,10 +722,24 @@ func (fake *SCCFunctions) QueryInstalledChaincodeReturnsOnCall(i int, result1 []
 func (fake *SCCFunctions) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
+	fake.approveChaincodeDefinitionForOrgMutex.RLock()
+	defer fake.approveChaincodeDefinitionForOrgMutex.RUnlock()
+	fake.checkCommitReadinessMutex.RLock()
+	defer fake.checkCommitReadinessMutex.RUnlock()
+	fake.commitChaincodeDefinitionMutex.RLock()
+	defer fake.commitChaincodeDefinitionMutex.RUnlock()
+	fake.getInstalledChaincodePackageMutex.RLock()
+	defer fake.getInstalledChaincodePackageMutex.RUnlock()
 	fake.installChaincodeMutex.RLock()
 	defer fake.installChaincodeMutex.RUnlock()
+	fake.queryApprovedPackageIDMutex.RLock()
+	defer fake.queryApprovedPackageIDMutex.RUnlock()
+	fake.queryChaincodeDefinitionMutex.RLock()
+	defer fake.queryChaincodeDefinitionMutex.RUnlock()
 	fake.queryInstalledChaincodeMutex.RLock()
 	defer fake.queryInstalledChaincodeMutex.RUnlock()
+	fake.queryInstalledChaincodesMutex.RLock()
+	defer fake.queryInstalledChaincodesMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
@@ -23,8 +23,6 @@ var _ = Describe("ProtobufImpl", func() {
 	BeforeEach(func() {
 		pi = &lifecycle.ProtobufImpl{}
 		sampleMsg = &lc.InstallChaincodeArgs{
-			Name:                    "name",
-			Version:                 "version",
 			ChaincodeInstallPackage: []byte("install-package"),
 		}
 	})
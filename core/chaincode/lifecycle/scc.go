@@ -8,41 +8,105 @@ package lifecycle
 
 import (
 	"fmt"
+	"sort"
 
+	"github.com/hyperledger/fabric/common/chaincode"
+	"github.com/hyperledger/fabric/common/channelconfig"
 	"github.com/hyperledger/fabric/core/chaincode/shim"
+	"github.com/hyperledger/fabric/protos/msp"
 	pb "github.com/hyperledger/fabric/protos/peer"
 	lb "github.com/hyperledger/fabric/protos/peer/lifecycle"
 	"github.com/pkg/errors"
 )
 
 const (
+	// LifecycleNamespace is the name of the lifecycle SCC and of its namespace in the ledger
+	LifecycleNamespace = "+lifecycle"
+
 	//InstalledChaincodeFuncName is the chaincode function name used to install a chaincode
 	InstallChaincodeFuncName = "InstallChaincode"
 
 	// QueryInstalledChaincodeFuncName is the chaincode function name used to query an installed chaincode
 	QueryInstalledChaincodeFuncName = "QueryInstalledChaincode"
+
+	// ApproveChaincodeDefinitionForMyOrgFuncName is the chaincode function name used to
+	// approve a chaincode definition for the org of the peer
+	ApproveChaincodeDefinitionForMyOrgFuncName = "ApproveChaincodeDefinitionForMyOrg"
+
+	// CommitChaincodeDefinitionFuncName is the chaincode function name used to commit
+	// a chaincode definition to the channel
+	CommitChaincodeDefinitionFuncName = "CommitChaincodeDefinition"
+
+	// QueryInstalledChaincodesFuncName is the chaincode function name used to query
+	// the chaincodes installed on the peer
+	QueryInstalledChaincodesFuncName = "QueryInstalledChaincodes"
+
+	// GetInstalledChaincodePackageFuncName is the chaincode function name used to get
+	// the install package of a chaincode installed on the peer
+	GetInstalledChaincodePackageFuncName = "GetInstalledChaincodePackage"
+
+	// CheckCommitReadinessFuncName is the chaincode function name used to check which
+	// orgs approved a chaincode definition
+	CheckCommitReadinessFuncName = "CheckCommitReadiness"
+
+	// QueryChaincodeDefinitionFuncName is the chaincode function name used to query
+	// the committed chaincode definition of a chaincode
+	QueryChaincodeDefinitionFuncName = "QueryChaincodeDefinition"
 )
 
 // SCCFunctions provides a backing implementation with concrete arguments
 // for each of the SCC functions
 type SCCFunctions interface {
 	// InstallChaincode persists a chaincode definition to disk
-	InstallChaincode(name, version string, chaincodePackage []byte) (hash []byte, err error)
+	InstallChaincode(chaincodePackage []byte) (*chaincode.InstalledChaincode, error)
+
+	// QueryInstalledChaincode returns the package ID and label for a given package ID of an installed chaincode
+	QueryInstalledChaincode(packageID string) (*chaincode.InstalledChaincode, error)
+
+	// QueryInstalledChaincodes returns the chaincodes installed on the peer
+	QueryInstalledChaincodes() ([]chaincode.InstalledChaincode, error)
+
+	// GetInstalledChaincodePackage returns the install package for a given package ID of an installed chaincode
+	GetInstalledChaincodePackage(packageID string) (ccInstallPkg []byte, err error)
 
-	// QueryInstalledChaincode returns the hash for a given name and version of an installed chaincode
-	QueryInstalledChaincode(name, version string) (hash []byte, err error)
+	// ApproveChaincodeDefinitionForOrg records the approval of a chaincode definition by an org
+	// and the package ID of the installed chaincode the org uses for it
+	ApproveChaincodeDefinitionForOrg(name string, cd *lb.ChaincodeDefinition, packageID string, publicState ReadableState, orgState ReadWritableState) error
+
+	// CheckCommitReadiness returns the approvals of a chaincode definition by the orgs
+	CheckCommitReadiness(name string, cd *lb.ChaincodeDefinition, publicState ReadableState, orgStates map[string]OpaqueState, policy ApprovalPolicy) (approvals map[string]bool, err error)
+
+	// CommitChaincodeDefinition commits a chaincode definition approved by enough orgs
+	CommitChaincodeDefinition(name string, cd *lb.ChaincodeDefinition, publicState ReadWritableState, orgStates map[string]OpaqueState, policy ApprovalPolicy) (approvals map[string]bool, err error)
+
+	// QueryChaincodeDefinition returns the committed chaincode definition of a chaincode
+	QueryChaincodeDefinition(name string, publicState ReadableState) (*lb.ChaincodeDefinition, error)
+
+	// QueryApprovedPackageID returns the package ID an org approved for a chaincode definition
+	QueryApprovedPackageID(name string, sequence int64, orgState ReadableState) (packageID string, err error)
+}
+
+// ChannelConfigSource provides the current config of a channel
+type ChannelConfigSource interface {
+	GetStableChannelConfig(channelID string) channelconfig.Resources
 }
 
 // SCC implements the required methods to satisfy the chaincode interface.
 // It routes the invocation calls to the backing implementations.
 type SCC struct {
+	// OrgMSPID is the MSP ID of the org of the peer, on whose behalf the
+	// chaincode definitions are approved
+	OrgMSPID string
+
+	ChannelConfigSource ChannelConfigSource
+
 	Protobuf  Protobuf
 	Functions SCCFunctions
 }
 
 // Name returns "+lifecycle"
 func (scc *SCC) Name() string {
-	return "+lifecycle"
+	return LifecycleNamespace
 }
 
 // Path returns "github.com/hyperledger/fabric/core/chaincode/lifecycle"
@@ -108,14 +172,15 @@ func (scc *SCC) Invoke(stub shim.ChaincodeStubInterface) pb.Response {
 			return shim.Error(err.Error())
 		}
 
-		hash, err := scc.Functions.InstallChaincode(input.Name, input.Version, input.ChaincodeInstallPackage)
+		installedChaincode, err := scc.Functions.InstallChaincode(input.ChaincodeInstallPackage)
 		if err != nil {
 			err = errors.WithMessage(err, "failed to invoke backing InstallChaincode")
 			return shim.Error(err.Error())
 		}
 
 		resultBytes, err := scc.Protobuf.Marshal(&lb.InstallChaincodeResult{
-			Hash: hash,
+			PackageId: installedChaincode.PackageID,
+			Label:     installedChaincode.Label,
 		})
 		if err != nil {
 			err = errors.WithMessage(err, "failed to marshal result")
@@ -131,14 +196,221 @@ func (scc *SCC) Invoke(stub shim.ChaincodeStubInterface) pb.Response {
 			return shim.Error(err.Error())
 		}
 
-		hash, err := scc.Functions.QueryInstalledChaincode(input.Name, input.Version)
+		installedChaincode, err := scc.Functions.QueryInstalledChaincode(input.PackageId)
 		if err != nil {
 			err = errors.WithMessage(err, "failed to invoke backing QueryInstalledChaincode")
 			return shim.Error(err.Error())
 		}
 
 		resultBytes, err := scc.Protobuf.Marshal(&lb.QueryInstalledChaincodeResult{
-			Hash: hash,
+			PackageId: installedChaincode.PackageID,
+			Label:     installedChaincode.Label,
+		})
+		if err != nil {
+			err = errors.WithMessage(err, "failed to marshal result")
+			return shim.Error(err.Error())
+		}
+
+		return shim.Success(resultBytes)
+	case QueryInstalledChaincodesFuncName:
+		input := &lb.QueryInstalledChaincodesArgs{}
+		err := scc.Protobuf.Unmarshal(inputBytes, input)
+		if err != nil {
+			err = errors.WithMessage(err, "failed to decode input arg to QueryInstalledChaincodes")
+			return shim.Error(err.Error())
+		}
+
+		installedChaincodes, err := scc.Functions.QueryInstalledChaincodes()
+		if err != nil {
+			err = errors.WithMessage(err, "failed to invoke backing QueryInstalledChaincodes")
+			return shim.Error(err.Error())
+		}
+
+		result := &lb.QueryInstalledChaincodesResult{}
+		for _, installedChaincode := range installedChaincodes {
+			result.InstalledChaincodes = append(result.InstalledChaincodes, &lb.QueryInstalledChaincodesResult_InstalledChaincode{
+				PackageId: installedChaincode.PackageID,
+				Label:     installedChaincode.Label,
+			})
+		}
+
+		resultBytes, err := scc.Protobuf.Marshal(result)
+		if err != nil {
+			err = errors.WithMessage(err, "failed to marshal result")
+			return shim.Error(err.Error())
+		}
+
+		return shim.Success(resultBytes)
+	case GetInstalledChaincodePackageFuncName:
+		input := &lb.GetInstalledChaincodePackageArgs{}
+		err := scc.Protobuf.Unmarshal(inputBytes, input)
+		if err != nil {
+			err = errors.WithMessage(err, "failed to decode input arg to GetInstalledChaincodePackage")
+			return shim.Error(err.Error())
+		}
+
+		ccInstallPkg, err := scc.Functions.GetInstalledChaincodePackage(input.PackageId)
+		if err != nil {
+			err = errors.WithMessage(err, "failed to invoke backing GetInstalledChaincodePackage")
+			return shim.Error(err.Error())
+		}
+
+		resultBytes, err := scc.Protobuf.Marshal(&lb.GetInstalledChaincodePackageResult{
+			ChaincodeInstallPackage: ccInstallPkg,
+		})
+		if err != nil {
+			err = errors.WithMessage(err, "failed to marshal result")
+			return shim.Error(err.Error())
+		}
+
+		return shim.Success(resultBytes)
+	case ApproveChaincodeDefinitionForMyOrgFuncName:
+		input := &lb.ApproveChaincodeDefinitionForMyOrgArgs{}
+		err := scc.Protobuf.Unmarshal(inputBytes, input)
+		if err != nil {
+			err = errors.WithMessage(err, "failed to decode input arg to ApproveChaincodeDefinitionForMyOrg")
+			return shim.Error(err.Error())
+		}
+
+		if stub.GetChannelID() == "" {
+			return shim.Error("ApproveChaincodeDefinitionForMyOrg must be invoked on a channel")
+		}
+
+		// the approval is recorded on behalf of the org of the peer, so only
+		// the members of this org may approve a chaincode definition
+		if err := scc.checkCreatorOrg(stub); err != nil {
+			return shim.Error(err.Error())
+		}
+
+		err = scc.Functions.ApproveChaincodeDefinitionForOrg(
+			input.Name,
+			input.Definition,
+			input.PackageId,
+			&ChaincodePublicLedgerShim{ChaincodeStubInterface: stub},
+			&ChaincodePrivateLedgerShim{Stub: stub, Collection: shim.ImplicitCollectionNameForOrg(scc.OrgMSPID)},
+		)
+		if err != nil {
+			err = errors.WithMessage(err, "failed to invoke backing ApproveChaincodeDefinitionForOrg")
+			return shim.Error(err.Error())
+		}
+
+		resultBytes, err := scc.Protobuf.Marshal(&lb.ApproveChaincodeDefinitionForMyOrgResult{})
+		if err != nil {
+			err = errors.WithMessage(err, "failed to marshal result")
+			return shim.Error(err.Error())
+		}
+
+		return shim.Success(resultBytes)
+	case CheckCommitReadinessFuncName:
+		input := &lb.CheckCommitReadinessArgs{}
+		err := scc.Protobuf.Unmarshal(inputBytes, input)
+		if err != nil {
+			err = errors.WithMessage(err, "failed to decode input arg to CheckCommitReadiness")
+			return shim.Error(err.Error())
+		}
+
+		if stub.GetChannelID() == "" {
+			return shim.Error("CheckCommitReadiness must be invoked on a channel")
+		}
+
+		orgStates, policy, err := scc.orgStatesAndPolicy(stub)
+		if err != nil {
+			return shim.Error(err.Error())
+		}
+
+		approvals, err := scc.Functions.CheckCommitReadiness(
+			input.Name,
+			input.Definition,
+			&ChaincodePublicLedgerShim{ChaincodeStubInterface: stub},
+			orgStates,
+			policy,
+		)
+		if err != nil {
+			err = errors.WithMessage(err, "failed to invoke backing CheckCommitReadiness")
+			return shim.Error(err.Error())
+		}
+
+		resultBytes, err := scc.Protobuf.Marshal(&lb.CheckCommitReadinessResult{
+			Approvals: sortedOrgApprovals(approvals),
+		})
+		if err != nil {
+			err = errors.WithMessage(err, "failed to marshal result")
+			return shim.Error(err.Error())
+		}
+
+		return shim.Success(resultBytes)
+	case CommitChaincodeDefinitionFuncName:
+		input := &lb.CommitChaincodeDefinitionArgs{}
+		err := scc.Protobuf.Unmarshal(inputBytes, input)
+		if err != nil {
+			err = errors.WithMessage(err, "failed to decode input arg to CommitChaincodeDefinition")
+			return shim.Error(err.Error())
+		}
+
+		if stub.GetChannelID() == "" {
+			return shim.Error("CommitChaincodeDefinition must be invoked on a channel")
+		}
+
+		orgStates, policy, err := scc.orgStatesAndPolicy(stub)
+		if err != nil {
+			return shim.Error(err.Error())
+		}
+
+		approvals, err := scc.Functions.CommitChaincodeDefinition(
+			input.Name,
+			input.Definition,
+			&ChaincodePublicLedgerShim{ChaincodeStubInterface: stub},
+			orgStates,
+			policy,
+		)
+		if err != nil {
+			err = errors.WithMessage(err, "failed to invoke backing CommitChaincodeDefinition")
+			return shim.Error(err.Error())
+		}
+
+		resultBytes, err := scc.Protobuf.Marshal(&lb.CommitChaincodeDefinitionResult{
+			Approvals: sortedOrgApprovals(approvals),
+		})
+		if err != nil {
+			err = errors.WithMessage(err, "failed to marshal result")
+			return shim.Error(err.Error())
+		}
+
+		return shim.Success(resultBytes)
+	case QueryChaincodeDefinitionFuncName:
+		input := &lb.QueryChaincodeDefinitionArgs{}
+		err := scc.Protobuf.Unmarshal(inputBytes, input)
+		if err != nil {
+			err = errors.WithMessage(err, "failed to decode input arg to QueryChaincodeDefinition")
+			return shim.Error(err.Error())
+		}
+
+		if stub.GetChannelID() == "" {
+			return shim.Error("QueryChaincodeDefinition must be invoked on a channel")
+		}
+
+		definition, err := scc.Functions.QueryChaincodeDefinition(
+			input.Name,
+			&ChaincodePublicLedgerShim{ChaincodeStubInterface: stub},
+		)
+		if err != nil {
+			err = errors.WithMessage(err, "failed to invoke backing QueryChaincodeDefinition")
+			return shim.Error(err.Error())
+		}
+
+		packageID, err := scc.Functions.QueryApprovedPackageID(
+			input.Name,
+			definition.Sequence,
+			&ChaincodePrivateLedgerShim{Stub: stub, Collection: shim.ImplicitCollectionNameForOrg(scc.OrgMSPID)},
+		)
+		if err != nil {
+			err = errors.WithMessage(err, "failed to invoke backing QueryApprovedPackageID")
+			return shim.Error(err.Error())
+		}
+
+		resultBytes, err := scc.Protobuf.Marshal(&lb.QueryChaincodeDefinitionResult{
+			Definition: definition,
+			PackageId:  packageID,
 		})
 		if err != nil {
 			err = errors.WithMessage(err, "failed to marshal result")
@@ -150,3 +422,58 @@ func (scc *SCC) Invoke(stub shim.ChaincodeStubInterface) pb.Response {
 		return shim.Error(fmt.Sprintf("unknown lifecycle function: %s", funcName))
 	}
 }
+
+// orgStatesAndPolicy returns the private states of the orgs of the channel of the
+// invocation and the policy their approvals of a chaincode definition must satisfy
+func (scc *SCC) orgStatesAndPolicy(stub shim.ChaincodeStubInterface) (map[string]OpaqueState, ApprovalPolicy, error) {
+	channelID := stub.GetChannelID()
+	channelConfig := scc.ChannelConfigSource.GetStableChannelConfig(channelID)
+	if channelConfig == nil {
+		return nil, nil, errors.Errorf("could not get channel config for channel '%s'", channelID)
+	}
+
+	policy, err := NewLifecycleEndorsementPolicy(channelConfig)
+	if err != nil {
+		return nil, nil, errors.WithMessage(err, fmt.Sprintf("could not get %s policy for channel '%s'", LifecycleEndorsementPolicyName, channelID))
+	}
+
+	orgStates := map[string]OpaqueState{}
+	for _, org := range policy.Orgs() {
+		orgStates[org] = &ChaincodePrivateLedgerShim{Stub: stub, Collection: shim.ImplicitCollectionNameForOrg(org)}
+	}
+
+	return orgStates, policy, nil
+}
+
+// checkCreatorOrg returns an error if the creator of the invocation is not a
+// member of the org of the peer
+func (scc *SCC) checkCreatorOrg(stub shim.ChaincodeStubInterface) error {
+	creator, err := stub.GetCreator()
+	if err != nil {
+		return errors.WithMessage(err, "could not get the creator of the invocation")
+	}
+
+	sid := &msp.SerializedIdentity{}
+	if err := scc.Protobuf.Unmarshal(creator, sid); err != nil {
+		return errors.WithMessage(err, "could not unmarshal the creator of the invocation")
+	}
+
+	if sid.Mspid != scc.OrgMSPID {
+		return errors.Errorf("the creator of the invocation belongs to org '%s', not to the org '%s' of the peer", sid.Mspid, scc.OrgMSPID)
+	}
+
+	return nil
+}
+
+// sortedOrgApprovals returns the approvals of the orgs sorted by MSP ID, so
+// that the results of the lifecycle functions marshal deterministically
+func sortedOrgApprovals(approvals map[string]bool) []*lb.OrgApproval {
+	orgApprovals := make([]*lb.OrgApproval, 0, len(approvals))
+	for mspID, approved := range approvals {
+		orgApprovals = append(orgApprovals, &lb.OrgApproval{MspId: mspID, Approved: approved})
+	}
+	sort.Slice(orgApprovals, func(i, j int) bool {
+		return orgApprovals[i].MspId < orgApprovals[j].MspId
+	})
+	return orgApprovals
+}
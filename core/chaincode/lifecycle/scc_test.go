@@ -10,9 +10,12 @@ import (
 	"fmt"
 
 	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/common/chaincode"
+	"github.com/hyperledger/fabric/common/channelconfig"
 	"github.com/hyperledger/fabric/core/chaincode/lifecycle"
 	"github.com/hyperledger/fabric/core/chaincode/lifecycle/mock"
 	"github.com/hyperledger/fabric/core/chaincode/shim"
+	"github.com/hyperledger/fabric/protos/msp"
 	lb "github.com/hyperledger/fabric/protos/peer/lifecycle"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
@@ -20,17 +23,21 @@ import (
 
 var _ = Describe("SCC", func() {
 	var (
-		scc          *lifecycle.SCC
-		fakeProto    *mock.Protobuf
-		fakeSCCFuncs *mock.SCCFunctions
+		scc                     *lifecycle.SCC
+		fakeProto               *mock.Protobuf
+		fakeSCCFuncs            *mock.SCCFunctions
+		fakeChannelConfigSource *mock.ChannelConfigSource
 	)
 
 	BeforeEach(func() {
 		fakeProto = &mock.Protobuf{}
 		fakeSCCFuncs = &mock.SCCFunctions{}
+		fakeChannelConfigSource = &mock.ChannelConfigSource{}
 		scc = &lifecycle.SCC{
-			Protobuf:  fakeProto,
-			Functions: fakeSCCFuncs,
+			OrgMSPID:            "org1",
+			ChannelConfigSource: fakeChannelConfigSource,
+			Protobuf:            fakeProto,
+			Functions:           fakeSCCFuncs,
 		}
 	})
 
@@ -125,8 +132,6 @@ var _ = Describe("SCC", func() {
 
 			BeforeEach(func() {
 				arg = &lb.InstallChaincodeArgs{
-					Name:                    "name",
-					Version:                 "version",
 					ChaincodeInstallPackage: []byte("chaincode-package"),
 				}
 
@@ -139,7 +144,10 @@ var _ = Describe("SCC", func() {
 				fakeProto.UnmarshalStub = proto.Unmarshal
 				fakeProto.MarshalStub = proto.Marshal
 
-				fakeSCCFuncs.InstallChaincodeReturns([]byte("fake-hash"), nil)
+				fakeSCCFuncs.InstallChaincodeReturns(&chaincode.InstalledChaincode{
+					PackageID: "label:fake-hash",
+					Label:     "label",
+				}, nil)
 			})
 
 			It("passes the arguments to and returns the results from the backing scc function implementation", func() {
@@ -148,12 +156,11 @@ var _ = Describe("SCC", func() {
 				payload := &lb.InstallChaincodeResult{}
 				err := proto.Unmarshal(res.Payload, payload)
 				Expect(err).NotTo(HaveOccurred())
-				Expect(payload.Hash).To(Equal([]byte("fake-hash")))
+				Expect(payload.PackageId).To(Equal("label:fake-hash"))
+				Expect(payload.Label).To(Equal("label"))
 
 				Expect(fakeSCCFuncs.InstallChaincodeCallCount()).To(Equal(1))
-				name, version, ccInstallPackage := fakeSCCFuncs.InstallChaincodeArgsForCall(0)
-				Expect(name).To(Equal("name"))
-				Expect(version).To(Equal("version"))
+				ccInstallPackage := fakeSCCFuncs.InstallChaincodeArgsForCall(0)
 				Expect(ccInstallPackage).To(Equal([]byte("chaincode-package")))
 			})
 
@@ -202,8 +209,7 @@ var _ = Describe("SCC", func() {
 
 			BeforeEach(func() {
 				arg = &lb.QueryInstalledChaincodeArgs{
-					Name:    "name",
-					Version: "version",
+					PackageId: "label:fake-hash",
 				}
 
 				var err error
@@ -215,7 +221,10 @@ var _ = Describe("SCC", func() {
 				fakeProto.UnmarshalStub = proto.Unmarshal
 				fakeProto.MarshalStub = proto.Marshal
 
-				fakeSCCFuncs.QueryInstalledChaincodeReturns([]byte("fake-hash"), nil)
+				fakeSCCFuncs.QueryInstalledChaincodeReturns(&chaincode.InstalledChaincode{
+					PackageID: "label:fake-hash",
+					Label:     "label",
+				}, nil)
 			})
 
 			It("passes the arguments to and returns the results from the backing scc function implementation", func() {
@@ -224,12 +233,11 @@ var _ = Describe("SCC", func() {
 				payload := &lb.QueryInstalledChaincodeResult{}
 				err := proto.Unmarshal(res.Payload, payload)
 				Expect(err).NotTo(HaveOccurred())
-				Expect(payload.Hash).To(Equal([]byte("fake-hash")))
+				Expect(payload.PackageId).To(Equal("label:fake-hash"))
+				Expect(payload.Label).To(Equal("label"))
 
 				Expect(fakeSCCFuncs.QueryInstalledChaincodeCallCount()).To(Equal(1))
-				name, version := fakeSCCFuncs.QueryInstalledChaincodeArgsForCall(0)
-				Expect(name).To(Equal("name"))
-				Expect(version).To(Equal("version"))
+				Expect(fakeSCCFuncs.QueryInstalledChaincodeArgsForCall(0)).To(Equal("label:fake-hash"))
 			})
 
 			Context("when the underlying function implementation fails", func() {
@@ -268,5 +276,502 @@ var _ = Describe("SCC", func() {
 				})
 			})
 		})
+
+		Describe("QueryInstalledChaincodes", func() {
+			BeforeEach(func() {
+				marshaledArg, err := proto.Marshal(&lb.QueryInstalledChaincodesArgs{})
+				Expect(err).NotTo(HaveOccurred())
+
+				fakeStub.GetArgsReturns([][]byte{[]byte("QueryInstalledChaincodes"), marshaledArg})
+
+				fakeProto.UnmarshalStub = proto.Unmarshal
+				fakeProto.MarshalStub = proto.Marshal
+
+				fakeSCCFuncs.QueryInstalledChaincodesReturns([]chaincode.InstalledChaincode{
+					{PackageID: "cc0-label:cc0-hash", Label: "cc0-label"},
+					{PackageID: "cc1-label:cc1-hash", Label: "cc1-label"},
+				}, nil)
+			})
+
+			It("returns the installed chaincodes from the backing scc function implementation", func() {
+				res := scc.Invoke(fakeStub)
+				Expect(res.Status).To(Equal(int32(200)))
+				payload := &lb.QueryInstalledChaincodesResult{}
+				err := proto.Unmarshal(res.Payload, payload)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(payload.InstalledChaincodes).To(HaveLen(2))
+				Expect(proto.Equal(payload.InstalledChaincodes[0], &lb.QueryInstalledChaincodesResult_InstalledChaincode{
+					PackageId: "cc0-label:cc0-hash",
+					Label:     "cc0-label",
+				})).To(BeTrue())
+				Expect(proto.Equal(payload.InstalledChaincodes[1], &lb.QueryInstalledChaincodesResult_InstalledChaincode{
+					PackageId: "cc1-label:cc1-hash",
+					Label:     "cc1-label",
+				})).To(BeTrue())
+
+				Expect(fakeSCCFuncs.QueryInstalledChaincodesCallCount()).To(Equal(1))
+			})
+
+			Context("when the underlying function implementation fails", func() {
+				BeforeEach(func() {
+					fakeSCCFuncs.QueryInstalledChaincodesReturns(nil, fmt.Errorf("underlying-error"))
+				})
+
+				It("wraps and returns the error", func() {
+					res := scc.Invoke(fakeStub)
+					Expect(res.Status).To(Equal(int32(500)))
+					Expect(res.Message).To(Equal("failed to invoke backing QueryInstalledChaincodes: underlying-error"))
+				})
+			})
+
+			Context("when unmarshaling the input fails", func() {
+				BeforeEach(func() {
+					fakeProto.UnmarshalReturns(fmt.Errorf("unmarshal-error"))
+				})
+
+				It("wraps and returns the error", func() {
+					res := scc.Invoke(fakeStub)
+					Expect(res.Status).To(Equal(int32(500)))
+					Expect(res.Message).To(Equal("failed to decode input arg to QueryInstalledChaincodes: unmarshal-error"))
+				})
+			})
+		})
+
+		Describe("GetInstalledChaincodePackage", func() {
+			BeforeEach(func() {
+				marshaledArg, err := proto.Marshal(&lb.GetInstalledChaincodePackageArgs{
+					PackageId: "label:fake-hash",
+				})
+				Expect(err).NotTo(HaveOccurred())
+
+				fakeStub.GetArgsReturns([][]byte{[]byte("GetInstalledChaincodePackage"), marshaledArg})
+
+				fakeProto.UnmarshalStub = proto.Unmarshal
+				fakeProto.MarshalStub = proto.Marshal
+
+				fakeSCCFuncs.GetInstalledChaincodePackageReturns([]byte("install-package"), nil)
+			})
+
+			It("passes the arguments to and returns the results from the backing scc function implementation", func() {
+				res := scc.Invoke(fakeStub)
+				Expect(res.Status).To(Equal(int32(200)))
+				payload := &lb.GetInstalledChaincodePackageResult{}
+				err := proto.Unmarshal(res.Payload, payload)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(payload.ChaincodeInstallPackage).To(Equal([]byte("install-package")))
+
+				Expect(fakeSCCFuncs.GetInstalledChaincodePackageCallCount()).To(Equal(1))
+				Expect(fakeSCCFuncs.GetInstalledChaincodePackageArgsForCall(0)).To(Equal("label:fake-hash"))
+			})
+
+			Context("when the underlying function implementation fails", func() {
+				BeforeEach(func() {
+					fakeSCCFuncs.GetInstalledChaincodePackageReturns(nil, fmt.Errorf("underlying-error"))
+				})
+
+				It("wraps and returns the error", func() {
+					res := scc.Invoke(fakeStub)
+					Expect(res.Status).To(Equal(int32(500)))
+					Expect(res.Message).To(Equal("failed to invoke backing GetInstalledChaincodePackage: underlying-error"))
+				})
+			})
+		})
+
+		Describe("ApproveChaincodeDefinitionForMyOrg", func() {
+			var (
+				arg          *lb.ApproveChaincodeDefinitionForMyOrgArgs
+				marshaledArg []byte
+			)
+
+			BeforeEach(func() {
+				arg = &lb.ApproveChaincodeDefinitionForMyOrgArgs{
+					Name: "name",
+					Definition: &lb.ChaincodeDefinition{
+						Sequence: 1,
+						Version:  "version",
+					},
+					PackageId: "label:fake-hash",
+				}
+
+				var err error
+				marshaledArg, err = proto.Marshal(arg)
+				Expect(err).NotTo(HaveOccurred())
+
+				fakeStub.GetArgsReturns([][]byte{[]byte("ApproveChaincodeDefinitionForMyOrg"), marshaledArg})
+				fakeStub.GetChannelIDReturns("test-channel")
+				creator, err := proto.Marshal(&msp.SerializedIdentity{Mspid: "org1"})
+				Expect(err).NotTo(HaveOccurred())
+				fakeStub.GetCreatorReturns(creator, nil)
+
+				fakeProto.UnmarshalStub = proto.Unmarshal
+				fakeProto.MarshalStub = proto.Marshal
+			})
+
+			It("passes the arguments and the states of the channel and of the org to the backing scc function implementation", func() {
+				res := scc.Invoke(fakeStub)
+				Expect(res.Status).To(Equal(int32(200)))
+				payload := &lb.ApproveChaincodeDefinitionForMyOrgResult{}
+				err := proto.Unmarshal(res.Payload, payload)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(fakeSCCFuncs.ApproveChaincodeDefinitionForOrgCallCount()).To(Equal(1))
+				name, cd, packageID, publicState, orgState := fakeSCCFuncs.ApproveChaincodeDefinitionForOrgArgsForCall(0)
+				Expect(name).To(Equal("name"))
+				Expect(proto.Equal(cd, arg.Definition)).To(BeTrue())
+				Expect(packageID).To(Equal("label:fake-hash"))
+				Expect(publicState).To(Equal(&lifecycle.ChaincodePublicLedgerShim{ChaincodeStubInterface: fakeStub}))
+				Expect(orgState).To(Equal(&lifecycle.ChaincodePrivateLedgerShim{Stub: fakeStub, Collection: "_implicit_org_org1"}))
+			})
+
+			Context("when the scc is not invoked on a channel", func() {
+				BeforeEach(func() {
+					fakeStub.GetChannelIDReturns("")
+				})
+
+				It("returns an error", func() {
+					res := scc.Invoke(fakeStub)
+					Expect(res.Status).To(Equal(int32(500)))
+					Expect(res.Message).To(Equal("ApproveChaincodeDefinitionForMyOrg must be invoked on a channel"))
+				})
+			})
+
+			Context("when the creator does not belong to the org of the peer", func() {
+				BeforeEach(func() {
+					creator, err := proto.Marshal(&msp.SerializedIdentity{Mspid: "org2"})
+					Expect(err).NotTo(HaveOccurred())
+					fakeStub.GetCreatorReturns(creator, nil)
+				})
+
+				It("returns an error", func() {
+					res := scc.Invoke(fakeStub)
+					Expect(res.Status).To(Equal(int32(500)))
+					Expect(res.Message).To(Equal("the creator of the invocation belongs to org 'org2', not to the org 'org1' of the peer"))
+					Expect(fakeSCCFuncs.ApproveChaincodeDefinitionForOrgCallCount()).To(Equal(0))
+				})
+			})
+
+			Context("when the creator cannot be retrieved", func() {
+				BeforeEach(func() {
+					fakeStub.GetCreatorReturns(nil, fmt.Errorf("creator-error"))
+				})
+
+				It("returns an error", func() {
+					res := scc.Invoke(fakeStub)
+					Expect(res.Status).To(Equal(int32(500)))
+					Expect(res.Message).To(Equal("could not get the creator of the invocation: creator-error"))
+				})
+			})
+
+			Context("when the underlying function implementation fails", func() {
+				BeforeEach(func() {
+					fakeSCCFuncs.ApproveChaincodeDefinitionForOrgReturns(fmt.Errorf("underlying-error"))
+				})
+
+				It("wraps and returns the error", func() {
+					res := scc.Invoke(fakeStub)
+					Expect(res.Status).To(Equal(int32(500)))
+					Expect(res.Message).To(Equal("failed to invoke backing ApproveChaincodeDefinitionForOrg: underlying-error"))
+				})
+			})
+
+			Context("when unmarshaling the input fails", func() {
+				BeforeEach(func() {
+					fakeProto.UnmarshalReturns(fmt.Errorf("unmarshal-error"))
+				})
+
+				It("wraps and returns the error", func() {
+					res := scc.Invoke(fakeStub)
+					Expect(res.Status).To(Equal(int32(500)))
+					Expect(res.Message).To(Equal("failed to decode input arg to ApproveChaincodeDefinitionForMyOrg: unmarshal-error"))
+				})
+			})
+		})
+
+		Describe("CommitChaincodeDefinition", func() {
+			var (
+				arg               *lb.CommitChaincodeDefinitionArgs
+				marshaledArg      []byte
+				fakeChannelConfig *mock.ChannelConfig
+			)
+
+			BeforeEach(func() {
+				arg = &lb.CommitChaincodeDefinitionArgs{
+					Name: "name",
+					Definition: &lb.ChaincodeDefinition{
+						Sequence: 1,
+						Version:  "version",
+					},
+				}
+
+				var err error
+				marshaledArg, err = proto.Marshal(arg)
+				Expect(err).NotTo(HaveOccurred())
+
+				fakeStub.GetArgsReturns([][]byte{[]byte("CommitChaincodeDefinition"), marshaledArg})
+				fakeStub.GetChannelIDReturns("test-channel")
+
+				fakeOrg1 := &mock.ApplicationOrgConfig{}
+				fakeOrg1.MSPIDReturns("org1")
+				fakeOrg2 := &mock.ApplicationOrgConfig{}
+				fakeOrg2.MSPIDReturns("org2")
+				fakeAppConfig := &mock.ApplicationConfig{}
+				fakeAppConfig.OrganizationsReturns(map[string]channelconfig.ApplicationOrg{
+					"org1-name": fakeOrg1,
+					"org2-name": fakeOrg2,
+				})
+				fakeChannelConfig = &mock.ChannelConfig{}
+				fakeChannelConfig.ApplicationConfigReturns(fakeAppConfig, true)
+				fakeChannelConfig.ConfigtxValidatorReturns(&mock.ConfigtxValidator{})
+				fakeChannelConfigSource.GetStableChannelConfigReturns(fakeChannelConfig)
+
+				fakeProto.UnmarshalStub = proto.Unmarshal
+				fakeProto.MarshalStub = proto.Marshal
+
+				fakeSCCFuncs.CommitChaincodeDefinitionReturns(map[string]bool{"org2": false, "org3": true, "org1": true}, nil)
+			})
+
+			It("passes the arguments, the states of the orgs and the policy of the channel to the backing scc function implementation", func() {
+				res := scc.Invoke(fakeStub)
+				Expect(res.Status).To(Equal(int32(200)))
+				payload := &lb.CommitChaincodeDefinitionResult{}
+				err := proto.Unmarshal(res.Payload, payload)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(payload.Approvals).To(Equal([]*lb.OrgApproval{
+					{MspId: "org1", Approved: true},
+					{MspId: "org2", Approved: false},
+					{MspId: "org3", Approved: true},
+				}))
+
+				Expect(fakeChannelConfigSource.GetStableChannelConfigArgsForCall(0)).To(Equal("test-channel"))
+				Expect(fakeSCCFuncs.CommitChaincodeDefinitionCallCount()).To(Equal(1))
+				name, cd, publicState, orgStates, policy := fakeSCCFuncs.CommitChaincodeDefinitionArgsForCall(0)
+				Expect(name).To(Equal("name"))
+				Expect(proto.Equal(cd, arg.Definition)).To(BeTrue())
+				Expect(publicState).To(Equal(&lifecycle.ChaincodePublicLedgerShim{ChaincodeStubInterface: fakeStub}))
+				Expect(orgStates).To(Equal(map[string]lifecycle.OpaqueState{
+					"org1": &lifecycle.ChaincodePrivateLedgerShim{Stub: fakeStub, Collection: "_implicit_org_org1"},
+					"org2": &lifecycle.ChaincodePrivateLedgerShim{Stub: fakeStub, Collection: "_implicit_org_org2"},
+				}))
+				Expect(policy.Orgs()).To(ConsistOf("org1", "org2"))
+			})
+
+			Context("when the channel config is not available", func() {
+				BeforeEach(func() {
+					fakeChannelConfigSource.GetStableChannelConfigReturns(nil)
+				})
+
+				It("returns an error", func() {
+					res := scc.Invoke(fakeStub)
+					Expect(res.Status).To(Equal(int32(500)))
+					Expect(res.Message).To(Equal("could not get channel config for channel 'test-channel'"))
+				})
+			})
+
+			Context("when the channel has no application config", func() {
+				BeforeEach(func() {
+					fakeChannelConfig.ApplicationConfigReturns(nil, false)
+				})
+
+				It("wraps and returns the error", func() {
+					res := scc.Invoke(fakeStub)
+					Expect(res.Status).To(Equal(int32(500)))
+					Expect(res.Message).To(Equal("could not get LifecycleEndorsement policy for channel 'test-channel': could not get application config for channel"))
+				})
+			})
+
+			Context("when the underlying function implementation fails", func() {
+				BeforeEach(func() {
+					fakeSCCFuncs.CommitChaincodeDefinitionReturns(nil, fmt.Errorf("underlying-error"))
+				})
+
+				It("wraps and returns the error", func() {
+					res := scc.Invoke(fakeStub)
+					Expect(res.Status).To(Equal(int32(500)))
+					Expect(res.Message).To(Equal("failed to invoke backing CommitChaincodeDefinition: underlying-error"))
+				})
+			})
+
+			Context("when unmarshaling the input fails", func() {
+				BeforeEach(func() {
+					fakeProto.UnmarshalReturns(fmt.Errorf("unmarshal-error"))
+				})
+
+				It("wraps and returns the error", func() {
+					res := scc.Invoke(fakeStub)
+					Expect(res.Status).To(Equal(int32(500)))
+					Expect(res.Message).To(Equal("failed to decode input arg to CommitChaincodeDefinition: unmarshal-error"))
+				})
+			})
+		})
+
+		Describe("CheckCommitReadiness", func() {
+			var (
+				arg          *lb.CheckCommitReadinessArgs
+				marshaledArg []byte
+			)
+
+			BeforeEach(func() {
+				arg = &lb.CheckCommitReadinessArgs{
+					Name: "name",
+					Definition: &lb.ChaincodeDefinition{
+						Sequence: 1,
+						Version:  "version",
+					},
+				}
+
+				var err error
+				marshaledArg, err = proto.Marshal(arg)
+				Expect(err).NotTo(HaveOccurred())
+
+				fakeStub.GetArgsReturns([][]byte{[]byte("CheckCommitReadiness"), marshaledArg})
+				fakeStub.GetChannelIDReturns("test-channel")
+
+				fakeOrg1 := &mock.ApplicationOrgConfig{}
+				fakeOrg1.MSPIDReturns("org1")
+				fakeAppConfig := &mock.ApplicationConfig{}
+				fakeAppConfig.OrganizationsReturns(map[string]channelconfig.ApplicationOrg{
+					"org1-name": fakeOrg1,
+				})
+				fakeChannelConfig := &mock.ChannelConfig{}
+				fakeChannelConfig.ApplicationConfigReturns(fakeAppConfig, true)
+				fakeChannelConfig.ConfigtxValidatorReturns(&mock.ConfigtxValidator{})
+				fakeChannelConfigSource.GetStableChannelConfigReturns(fakeChannelConfig)
+
+				fakeProto.UnmarshalStub = proto.Unmarshal
+				fakeProto.MarshalStub = proto.Marshal
+
+				fakeSCCFuncs.CheckCommitReadinessReturns(map[string]bool{"org1": true}, nil)
+			})
+
+			It("passes the arguments, the states of the orgs and the policy of the channel to the backing scc function implementation", func() {
+				res := scc.Invoke(fakeStub)
+				Expect(res.Status).To(Equal(int32(200)))
+				payload := &lb.CheckCommitReadinessResult{}
+				err := proto.Unmarshal(res.Payload, payload)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(payload.Approvals).To(Equal([]*lb.OrgApproval{{MspId: "org1", Approved: true}}))
+
+				Expect(fakeSCCFuncs.CheckCommitReadinessCallCount()).To(Equal(1))
+				name, cd, publicState, orgStates, policy := fakeSCCFuncs.CheckCommitReadinessArgsForCall(0)
+				Expect(name).To(Equal("name"))
+				Expect(proto.Equal(cd, arg.Definition)).To(BeTrue())
+				Expect(publicState).To(Equal(&lifecycle.ChaincodePublicLedgerShim{ChaincodeStubInterface: fakeStub}))
+				Expect(orgStates).To(Equal(map[string]lifecycle.OpaqueState{
+					"org1": &lifecycle.ChaincodePrivateLedgerShim{Stub: fakeStub, Collection: "_implicit_org_org1"},
+				}))
+				Expect(policy.Orgs()).To(ConsistOf("org1"))
+			})
+
+			Context("when the scc is not invoked on a channel", func() {
+				BeforeEach(func() {
+					fakeStub.GetChannelIDReturns("")
+				})
+
+				It("returns an error", func() {
+					res := scc.Invoke(fakeStub)
+					Expect(res.Status).To(Equal(int32(500)))
+					Expect(res.Message).To(Equal("CheckCommitReadiness must be invoked on a channel"))
+				})
+			})
+
+			Context("when the underlying function implementation fails", func() {
+				BeforeEach(func() {
+					fakeSCCFuncs.CheckCommitReadinessReturns(nil, fmt.Errorf("underlying-error"))
+				})
+
+				It("wraps and returns the error", func() {
+					res := scc.Invoke(fakeStub)
+					Expect(res.Status).To(Equal(int32(500)))
+					Expect(res.Message).To(Equal("failed to invoke backing CheckCommitReadiness: underlying-error"))
+				})
+			})
+		})
+
+		Describe("QueryChaincodeDefinition", func() {
+			var (
+				arg          *lb.QueryChaincodeDefinitionArgs
+				marshaledArg []byte
+			)
+
+			BeforeEach(func() {
+				arg = &lb.QueryChaincodeDefinitionArgs{
+					Name: "name",
+				}
+
+				var err error
+				marshaledArg, err = proto.Marshal(arg)
+				Expect(err).NotTo(HaveOccurred())
+
+				fakeStub.GetArgsReturns([][]byte{[]byte("QueryChaincodeDefinition"), marshaledArg})
+				fakeStub.GetChannelIDReturns("test-channel")
+
+				fakeProto.UnmarshalStub = proto.Unmarshal
+				fakeProto.MarshalStub = proto.Marshal
+
+				fakeSCCFuncs.QueryChaincodeDefinitionReturns(&lb.ChaincodeDefinition{
+					Sequence: 3,
+					Version:  "version",
+				}, nil)
+				fakeSCCFuncs.QueryApprovedPackageIDReturns("label:fake-hash", nil)
+			})
+
+			It("passes the arguments to and returns the results from the backing scc function implementation", func() {
+				res := scc.Invoke(fakeStub)
+				Expect(res.Status).To(Equal(int32(200)))
+				payload := &lb.QueryChaincodeDefinitionResult{}
+				err := proto.Unmarshal(res.Payload, payload)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(payload.Definition.Sequence).To(Equal(int64(3)))
+				Expect(payload.Definition.Version).To(Equal("version"))
+				Expect(payload.PackageId).To(Equal("label:fake-hash"))
+
+				Expect(fakeSCCFuncs.QueryChaincodeDefinitionCallCount()).To(Equal(1))
+				name, publicState := fakeSCCFuncs.QueryChaincodeDefinitionArgsForCall(0)
+				Expect(name).To(Equal("name"))
+				Expect(publicState).To(Equal(&lifecycle.ChaincodePublicLedgerShim{ChaincodeStubInterface: fakeStub}))
+
+				Expect(fakeSCCFuncs.QueryApprovedPackageIDCallCount()).To(Equal(1))
+				name, sequence, orgState := fakeSCCFuncs.QueryApprovedPackageIDArgsForCall(0)
+				Expect(name).To(Equal("name"))
+				Expect(sequence).To(Equal(int64(3)))
+				Expect(orgState).To(Equal(&lifecycle.ChaincodePrivateLedgerShim{Stub: fakeStub, Collection: "_implicit_org_org1"}))
+			})
+
+			Context("when the package ID cannot be read", func() {
+				BeforeEach(func() {
+					fakeSCCFuncs.QueryApprovedPackageIDReturns("", fmt.Errorf("underlying-error"))
+				})
+
+				It("wraps and returns the error", func() {
+					res := scc.Invoke(fakeStub)
+					Expect(res.Status).To(Equal(int32(500)))
+					Expect(res.Message).To(Equal("failed to invoke backing QueryApprovedPackageID: underlying-error"))
+				})
+			})
+
+			Context("when the underlying function implementation fails", func() {
+				BeforeEach(func() {
+					fakeSCCFuncs.QueryChaincodeDefinitionReturns(nil, fmt.Errorf("underlying-error"))
+				})
+
+				It("wraps and returns the error", func() {
+					res := scc.Invoke(fakeStub)
+					Expect(res.Status).To(Equal(int32(500)))
+					Expect(res.Message).To(Equal("failed to invoke backing QueryChaincodeDefinition: underlying-error"))
+				})
+			})
+
+			Context("when marshaling the output fails", func() {
+				BeforeEach(func() {
+					fakeProto.MarshalReturns(nil, fmt.Errorf("marshal-error"))
+				})
+
+				It("wraps and returns the error", func() {
+					res := scc.Invoke(fakeStub)
+					Expect(res.Status).To(Equal(int32(500)))
+					Expect(res.Message).To(Equal("failed to marshal result: marshal-error"))
+				})
+			})
+		})
 	})
 })
@@ -0,0 +1,60 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package lifecycle
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hyperledger/fabric/common/cauthdsl"
+	"github.com/hyperledger/fabric/core/chaincode/shim"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/rwsetutil"
+	cb "github.com/hyperledger/fabric/protos/common"
+	"github.com/pkg/errors"
+)
+
+// ValidationPolicy returns the policy the endorsements of a transaction must satisfy for
+// the writes of the transaction to the namespace of the lifecycle SCC. The commit of a
+// chaincode definition writes to the public state and must be endorsed as required by the
+// LifecycleEndorsement policy of the channel. The approval of a chaincode definition by an
+// org writes to the implicit collection of the org and must be endorsed by a member of the
+// org. Any other transaction must be endorsed by a member of any org of the channel.
+func ValidationPolicy(channelConfig ChannelConfig, nsRWSet *rwsetutil.NsRwSet) (*cb.SignaturePolicyEnvelope, error) {
+	lep, err := NewLifecycleEndorsementPolicy(channelConfig)
+	if err != nil {
+		return nil, errors.WithMessage(err, fmt.Sprintf("could not get %s policy", LifecycleEndorsementPolicyName))
+	}
+
+	var approvingOrgs []string
+	for _, collRWSet := range nsRWSet.CollHashedRwSets {
+		hashedRWSet := collRWSet.HashedRwSet
+		if hashedRWSet == nil || (len(hashedRWSet.HashedWrites) == 0 && len(hashedRWSet.MetadataWrites) == 0) {
+			continue
+		}
+		if !strings.HasPrefix(collRWSet.CollectionName, shim.ImplicitCollectionNamePrefix) {
+			return nil, errors.Errorf("transaction writes to collection '%s' of %s, which is not the implicit collection of an org",
+				collRWSet.CollectionName, LifecycleNamespace)
+		}
+		approvingOrgs = append(approvingOrgs, strings.TrimPrefix(collRWSet.CollectionName, shim.ImplicitCollectionNamePrefix))
+	}
+
+	kvRWSet := nsRWSet.KvRwSet
+	writesToPublicState := kvRWSet != nil && (len(kvRWSet.Writes) > 0 || len(kvRWSet.MetadataWrites) > 0)
+
+	switch {
+	case writesToPublicState && len(approvingOrgs) > 0:
+		return nil, errors.Errorf("transaction writes to both the public state and the implicit collections of %s", LifecycleNamespace)
+	case writesToPublicState:
+		return lep.SignaturePolicyEnvelope()
+	case len(approvingOrgs) > 1:
+		return nil, errors.Errorf("transaction writes to the implicit collections of more than one org in %s: %v", LifecycleNamespace, approvingOrgs)
+	case len(approvingOrgs) == 1:
+		return cauthdsl.SignedByMspMember(approvingOrgs[0]), nil
+	default:
+		return cauthdsl.SignedByAnyMember(lep.Orgs()), nil
+	}
+}
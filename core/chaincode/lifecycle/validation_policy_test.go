@@ -0,0 +1,139 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package lifecycle_test
+
+import (
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/common/cauthdsl"
+	"github.com/hyperledger/fabric/common/channelconfig"
+	"github.com/hyperledger/fabric/core/chaincode/lifecycle"
+	"github.com/hyperledger/fabric/core/chaincode/lifecycle/mock"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/rwsetutil"
+	cb "github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/ledger/rwset/kvrwset"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ValidationPolicy", func() {
+	var (
+		fakeChannelConfig *mock.ChannelConfig
+		nsRWSet           *rwsetutil.NsRwSet
+	)
+
+	BeforeEach(func() {
+		orgs := map[string]channelconfig.ApplicationOrg{}
+		for _, mspID := range []string{"org1", "org2", "org3"} {
+			fakeOrg := &mock.ApplicationOrgConfig{}
+			fakeOrg.MSPIDReturns(mspID)
+			orgs[mspID+"-name"] = fakeOrg
+		}
+		fakeAppConfig := &mock.ApplicationConfig{}
+		fakeAppConfig.OrganizationsReturns(orgs)
+
+		fakeChannelConfig = &mock.ChannelConfig{}
+		fakeChannelConfig.ApplicationConfigReturns(fakeAppConfig, true)
+		fakeChannelConfig.ConfigtxValidatorReturns(&mock.ConfigtxValidator{})
+
+		nsRWSet = &rwsetutil.NsRwSet{NameSpace: "+lifecycle"}
+	})
+
+	approve := func(collection string) *rwsetutil.CollHashedRwSet {
+		return &rwsetutil.CollHashedRwSet{
+			CollectionName: collection,
+			HashedRwSet: &kvrwset.HashedRWSet{
+				HashedWrites: []*kvrwset.KVWriteHash{{KeyHash: []byte("key-hash"), ValueHash: []byte("value-hash")}},
+			},
+		}
+	}
+
+	expectPolicy := func(expected *cb.SignaturePolicyEnvelope) {
+		spe, err := lifecycle.ValidationPolicy(fakeChannelConfig, nsRWSet)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(proto.Equal(spe, expected)).To(BeTrue())
+	}
+
+	Context("when the transaction commits a chaincode definition", func() {
+		BeforeEach(func() {
+			nsRWSet.KvRwSet = &kvrwset.KVRWSet{
+				Writes: []*kvrwset.KVWrite{{Key: "namespaces/name", Value: []byte("definition")}},
+			}
+		})
+
+		It("requires the endorsements of the LifecycleEndorsement policy", func() {
+			expected, err := cauthdsl.FromString("OutOf(2, 'org1.member', 'org2.member', 'org3.member')")
+			Expect(err).NotTo(HaveOccurred())
+			expectPolicy(expected)
+		})
+
+		Context("when the transaction approves a chaincode definition as well", func() {
+			BeforeEach(func() {
+				nsRWSet.CollHashedRwSets = []*rwsetutil.CollHashedRwSet{approve("_implicit_org_org1")}
+			})
+
+			It("returns an error", func() {
+				_, err := lifecycle.ValidationPolicy(fakeChannelConfig, nsRWSet)
+				Expect(err).To(MatchError("transaction writes to both the public state and the implicit collections of +lifecycle"))
+			})
+		})
+	})
+
+	Context("when the transaction approves a chaincode definition", func() {
+		BeforeEach(func() {
+			nsRWSet.KvRwSet = &kvrwset.KVRWSet{
+				Reads: []*kvrwset.KVRead{{Key: "namespaces/name"}},
+			}
+			nsRWSet.CollHashedRwSets = []*rwsetutil.CollHashedRwSet{
+				{CollectionName: "_implicit_org_org1", HashedRwSet: &kvrwset.HashedRWSet{}},
+				approve("_implicit_org_org2"),
+			}
+		})
+
+		It("requires the endorsement of a member of the approving org", func() {
+			expectPolicy(cauthdsl.SignedByMspMember("org2"))
+		})
+
+		Context("when the transaction approves for more than one org", func() {
+			BeforeEach(func() {
+				nsRWSet.CollHashedRwSets = append(nsRWSet.CollHashedRwSets, approve("_implicit_org_org3"))
+			})
+
+			It("returns an error", func() {
+				_, err := lifecycle.ValidationPolicy(fakeChannelConfig, nsRWSet)
+				Expect(err).To(MatchError("transaction writes to the implicit collections of more than one org in +lifecycle: [org2 org3]"))
+			})
+		})
+	})
+
+	Context("when the transaction writes to a collection which is not an implicit collection", func() {
+		BeforeEach(func() {
+			nsRWSet.CollHashedRwSets = []*rwsetutil.CollHashedRwSet{approve("collection")}
+		})
+
+		It("returns an error", func() {
+			_, err := lifecycle.ValidationPolicy(fakeChannelConfig, nsRWSet)
+			Expect(err).To(MatchError("transaction writes to collection 'collection' of +lifecycle, which is not the implicit collection of an org"))
+		})
+	})
+
+	Context("when the transaction does not write to the namespace", func() {
+		It("requires the endorsement of a member of any org", func() {
+			expectPolicy(cauthdsl.SignedByAnyMember([]string{"org1", "org2", "org3"}))
+		})
+	})
+
+	Context("when the channel has no application config", func() {
+		BeforeEach(func() {
+			fakeChannelConfig.ApplicationConfigReturns(nil, false)
+		})
+
+		It("returns an error", func() {
+			_, err := lifecycle.ValidationPolicy(fakeChannelConfig, nsRWSet)
+			Expect(err).To(MatchError("could not get LifecycleEndorsement policy: could not get application config for channel"))
+		})
+	})
+})
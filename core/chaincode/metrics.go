@@ -52,6 +52,13 @@ var (
 		LabelNames:   []string{"type", "channel", "chaincode", "success"},
 		StatsdFormat: "%{#fqname}.%{type}.%{channel}.%{chaincode}.%{success}",
 	}
+	queryLimitsExceeded = metrics.CounterOpts{
+		Namespace:    "chaincode",
+		Name:         "query_limits_exceeded",
+		Help:         "The number of chaincode queries that have failed for exceeding a query limit.",
+		LabelNames:   []string{"type", "channel", "chaincode", "limit"},
+		StatsdFormat: "%{#fqname}.%{type}.%{channel}.%{chaincode}.%{limit}",
+	}
 	executeTimeouts = metrics.CounterOpts{
 		Namespace:    "chaincode",
 		Name:         "execute_timeouts",
@@ -66,6 +73,7 @@ type HandlerMetrics struct {
 	ShimRequestsCompleted metrics.Counter
 	ShimRequestDuration   metrics.Histogram
 	ExecuteTimeouts       metrics.Counter
+	QueryLimitsExceeded   metrics.Counter
 }
 
 func NewHandlerMetrics(p metrics.Provider) *HandlerMetrics {
@@ -74,6 +82,7 @@ func NewHandlerMetrics(p metrics.Provider) *HandlerMetrics {
 		ShimRequestsCompleted: p.NewCounter(shimRequestsCompleted),
 		ShimRequestDuration:   p.NewHistogram(shimRequestDuration),
 		ExecuteTimeouts:       p.NewCounter(executeTimeouts),
+		QueryLimitsExceeded:   p.NewCounter(queryLimitsExceeded),
 	}
 }
 
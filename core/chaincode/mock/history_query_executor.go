@@ -22,6 +22,67 @@ type HistoryQueryExecutor struct {
 		result1 ledger.ResultsIterator
 		result2 error
 	}
+	GetHistoryForKeyWithMetadataStub        func(string, string, map[string]interface{}) (ledger.QueryResultsIterator, error)
+	getHistoryForKeyWithMetadataMutex       sync.RWMutex
+	getHistoryForKeyWithMetadataArgsForCall []struct {
+		arg1 string
+		arg2 string
+		arg3 map[string]interface{}
+	}
+	getHistoryForKeyWithMetadataReturns struct {
+		result1 ledger.QueryResultsIterator
+		result2 error
+	}
+	getHistoryForKeyWithMetadataReturnsOnCall map[int]struct {
+		result1 ledger.QueryResultsIterator
+		result2 error
+	}
+	GetPrivateDataHistoryForKeyStub        func(string, string, string) (ledger.ResultsIterator, error)
+	getPrivateDataHistoryForKeyMutex       sync.RWMutex
+	getPrivateDataHistoryForKeyArgsForCall []struct {
+		arg1 string
+		arg2 string
+		arg3 string
+	}
+	getPrivateDataHistoryForKeyReturns struct {
+		result1 ledger.ResultsIterator
+		result2 error
+	}
+	getPrivateDataHistoryForKeyReturnsOnCall map[int]struct {
+		result1 ledger.ResultsIterator
+		result2 error
+	}
+	GetStateAtHeightStub        func(string, string, uint64) ([]byte, error)
+	getStateAtHeightMutex       sync.RWMutex
+	getStateAtHeightArgsForCall []struct {
+		arg1 string
+		arg2 string
+		arg3 uint64
+	}
+	getStateAtHeightReturns struct {
+		result1 []byte
+		result2 error
+	}
+	getStateAtHeightReturnsOnCall map[int]struct {
+		result1 []byte
+		result2 error
+	}
+	GetStateRangeScanIteratorAtHeightStub        func(string, string, string, uint64) (ledger.ResultsIterator, error)
+	getStateRangeScanIteratorAtHeightMutex       sync.RWMutex
+	getStateRangeScanIteratorAtHeightArgsForCall []struct {
+		arg1 string
+		arg2 string
+		arg3 string
+		arg4 uint64
+	}
+	getStateRangeScanIteratorAtHeightReturns struct {
+		result1 ledger.ResultsIterator
+		result2 error
+	}
+	getStateRangeScanIteratorAtHeightReturnsOnCall map[int]struct {
+		result1 ledger.ResultsIterator
+		result2 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -90,11 +151,280 @@ func (fake *HistoryQueryExecutor) GetHistoryForKeyReturnsOnCall(i int, result1 l
 	}{result1, result2}
 }
 
+func (fake *HistoryQueryExecutor) GetHistoryForKeyWithMetadata(arg1 string, arg2 string, arg3 map[string]interface{}) (ledger.QueryResultsIterator, error) {
+	fake.getHistoryForKeyWithMetadataMutex.Lock()
+	ret, specificReturn := fake.getHistoryForKeyWithMetadataReturnsOnCall[len(fake.getHistoryForKeyWithMetadataArgsForCall)]
+	fake.getHistoryForKeyWithMetadataArgsForCall = append(fake.getHistoryForKeyWithMetadataArgsForCall, struct {
+		arg1 string
+		arg2 string
+		arg3 map[string]interface{}
+	}{arg1, arg2, arg3})
+	fake.recordInvocation("GetHistoryForKeyWithMetadata", []interface{}{arg1, arg2, arg3})
+	fake.getHistoryForKeyWithMetadataMutex.Unlock()
+	if fake.GetHistoryForKeyWithMetadataStub != nil {
+		return fake.GetHistoryForKeyWithMetadataStub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.getHistoryForKeyWithMetadataReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *HistoryQueryExecutor) GetHistoryForKeyWithMetadataCallCount() int {
+	fake.getHistoryForKeyWithMetadataMutex.RLock()
+	defer fake.getHistoryForKeyWithMetadataMutex.RUnlock()
+	return len(fake.getHistoryForKeyWithMetadataArgsForCall)
+}
+
+func (fake *HistoryQueryExecutor) GetHistoryForKeyWithMetadataCalls(stub func(string, string, map[string]interface{}) (ledger.QueryResultsIterator, error)) {
+	fake.getHistoryForKeyWithMetadataMutex.Lock()
+	defer fake.getHistoryForKeyWithMetadataMutex.Unlock()
+	fake.GetHistoryForKeyWithMetadataStub = stub
+}
+
+func (fake *HistoryQueryExecutor) GetHistoryForKeyWithMetadataArgsForCall(i int) (string, string, map[string]interface{}) {
+	fake.getHistoryForKeyWithMetadataMutex.RLock()
+	defer fake.getHistoryForKeyWithMetadataMutex.RUnlock()
+	argsForCall := fake.getHistoryForKeyWithMetadataArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *HistoryQueryExecutor) GetHistoryForKeyWithMetadataReturns(result1 ledger.QueryResultsIterator, result2 error) {
+	fake.getHistoryForKeyWithMetadataMutex.Lock()
+	defer fake.getHistoryForKeyWithMetadataMutex.Unlock()
+	fake.GetHistoryForKeyWithMetadataStub = nil
+	fake.getHistoryForKeyWithMetadataReturns = struct {
+		result1 ledger.QueryResultsIterator
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *HistoryQueryExecutor) GetHistoryForKeyWithMetadataReturnsOnCall(i int, result1 ledger.QueryResultsIterator, result2 error) {
+	fake.getHistoryForKeyWithMetadataMutex.Lock()
+	defer fake.getHistoryForKeyWithMetadataMutex.Unlock()
+	fake.GetHistoryForKeyWithMetadataStub = nil
+	if fake.getHistoryForKeyWithMetadataReturnsOnCall == nil {
+		fake.getHistoryForKeyWithMetadataReturnsOnCall = make(map[int]struct {
+			result1 ledger.QueryResultsIterator
+			result2 error
+		})
+	}
+	fake.getHistoryForKeyWithMetadataReturnsOnCall[i] = struct {
+		result1 ledger.QueryResultsIterator
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *HistoryQueryExecutor) GetPrivateDataHistoryForKey(arg1 string, arg2 string, arg3 string) (ledger.ResultsIterator, error) {
+	fake.getPrivateDataHistoryForKeyMutex.Lock()
+	ret, specificReturn := fake.getPrivateDataHistoryForKeyReturnsOnCall[len(fake.getPrivateDataHistoryForKeyArgsForCall)]
+	fake.getPrivateDataHistoryForKeyArgsForCall = append(fake.getPrivateDataHistoryForKeyArgsForCall, struct {
+		arg1 string
+		arg2 string
+		arg3 string
+	}{arg1, arg2, arg3})
+	fake.recordInvocation("GetPrivateDataHistoryForKey", []interface{}{arg1, arg2, arg3})
+	fake.getPrivateDataHistoryForKeyMutex.Unlock()
+	if fake.GetPrivateDataHistoryForKeyStub != nil {
+		return fake.GetPrivateDataHistoryForKeyStub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.getPrivateDataHistoryForKeyReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *HistoryQueryExecutor) GetPrivateDataHistoryForKeyCallCount() int {
+	fake.getPrivateDataHistoryForKeyMutex.RLock()
+	defer fake.getPrivateDataHistoryForKeyMutex.RUnlock()
+	return len(fake.getPrivateDataHistoryForKeyArgsForCall)
+}
+
+func (fake *HistoryQueryExecutor) GetPrivateDataHistoryForKeyCalls(stub func(string, string, string) (ledger.ResultsIterator, error)) {
+	fake.getPrivateDataHistoryForKeyMutex.Lock()
+	defer fake.getPrivateDataHistoryForKeyMutex.Unlock()
+	fake.GetPrivateDataHistoryForKeyStub = stub
+}
+
+func (fake *HistoryQueryExecutor) GetPrivateDataHistoryForKeyArgsForCall(i int) (string, string, string) {
+	fake.getPrivateDataHistoryForKeyMutex.RLock()
+	defer fake.getPrivateDataHistoryForKeyMutex.RUnlock()
+	argsForCall := fake.getPrivateDataHistoryForKeyArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *HistoryQueryExecutor) GetPrivateDataHistoryForKeyReturns(result1 ledger.ResultsIterator, result2 error) {
+	fake.getPrivateDataHistoryForKeyMutex.Lock()
+	defer fake.getPrivateDataHistoryForKeyMutex.Unlock()
+	fake.GetPrivateDataHistoryForKeyStub = nil
+	fake.getPrivateDataHistoryForKeyReturns = struct {
+		result1 ledger.ResultsIterator
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *HistoryQueryExecutor) GetPrivateDataHistoryForKeyReturnsOnCall(i int, result1 ledger.ResultsIterator, result2 error) {
+	fake.getPrivateDataHistoryForKeyMutex.Lock()
+	defer fake.getPrivateDataHistoryForKeyMutex.Unlock()
+	fake.GetPrivateDataHistoryForKeyStub = nil
+	if fake.getPrivateDataHistoryForKeyReturnsOnCall == nil {
+		fake.getPrivateDataHistoryForKeyReturnsOnCall = make(map[int]struct {
+			result1 ledger.ResultsIterator
+			result2 error
+		})
+	}
+	fake.getPrivateDataHistoryForKeyReturnsOnCall[i] = struct {
+		result1 ledger.ResultsIterator
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *HistoryQueryExecutor) GetStateAtHeight(arg1 string, arg2 string, arg3 uint64) ([]byte, error) {
+	fake.getStateAtHeightMutex.Lock()
+	ret, specificReturn := fake.getStateAtHeightReturnsOnCall[len(fake.getStateAtHeightArgsForCall)]
+	fake.getStateAtHeightArgsForCall = append(fake.getStateAtHeightArgsForCall, struct {
+		arg1 string
+		arg2 string
+		arg3 uint64
+	}{arg1, arg2, arg3})
+	fake.recordInvocation("GetStateAtHeight", []interface{}{arg1, arg2, arg3})
+	fake.getStateAtHeightMutex.Unlock()
+	if fake.GetStateAtHeightStub != nil {
+		return fake.GetStateAtHeightStub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.getStateAtHeightReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *HistoryQueryExecutor) GetStateAtHeightCallCount() int {
+	fake.getStateAtHeightMutex.RLock()
+	defer fake.getStateAtHeightMutex.RUnlock()
+	return len(fake.getStateAtHeightArgsForCall)
+}
+
+func (fake *HistoryQueryExecutor) GetStateAtHeightCalls(stub func(string, string, uint64) ([]byte, error)) {
+	fake.getStateAtHeightMutex.Lock()
+	defer fake.getStateAtHeightMutex.Unlock()
+	fake.GetStateAtHeightStub = stub
+}
+
+func (fake *HistoryQueryExecutor) GetStateAtHeightArgsForCall(i int) (string, string, uint64) {
+	fake.getStateAtHeightMutex.RLock()
+	defer fake.getStateAtHeightMutex.RUnlock()
+	argsForCall := fake.getStateAtHeightArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *HistoryQueryExecutor) GetStateAtHeightReturns(result1 []byte, result2 error) {
+	fake.getStateAtHeightMutex.Lock()
+	defer fake.getStateAtHeightMutex.Unlock()
+	fake.GetStateAtHeightStub = nil
+	fake.getStateAtHeightReturns = struct {
+		result1 []byte
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *HistoryQueryExecutor) GetStateAtHeightReturnsOnCall(i int, result1 []byte, result2 error) {
+	fake.getStateAtHeightMutex.Lock()
+	defer fake.getStateAtHeightMutex.Unlock()
+	fake.GetStateAtHeightStub = nil
+	if fake.getStateAtHeightReturnsOnCall == nil {
+		fake.getStateAtHeightReturnsOnCall = make(map[int]struct {
+			result1 []byte
+			result2 error
+		})
+	}
+	fake.getStateAtHeightReturnsOnCall[i] = struct {
+		result1 []byte
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *HistoryQueryExecutor) GetStateRangeScanIteratorAtHeight(arg1 string, arg2 string, arg3 string, arg4 uint64) (ledger.ResultsIterator, error) {
+	fake.getStateRangeScanIteratorAtHeightMutex.Lock()
+	ret, specificReturn := fake.getStateRangeScanIteratorAtHeightReturnsOnCall[len(fake.getStateRangeScanIteratorAtHeightArgsForCall)]
+	fake.getStateRangeScanIteratorAtHeightArgsForCall = append(fake.getStateRangeScanIteratorAtHeightArgsForCall, struct {
+		arg1 string
+		arg2 string
+		arg3 string
+		arg4 uint64
+	}{arg1, arg2, arg3, arg4})
+	fake.recordInvocation("GetStateRangeScanIteratorAtHeight", []interface{}{arg1, arg2, arg3, arg4})
+	fake.getStateRangeScanIteratorAtHeightMutex.Unlock()
+	if fake.GetStateRangeScanIteratorAtHeightStub != nil {
+		return fake.GetStateRangeScanIteratorAtHeightStub(arg1, arg2, arg3, arg4)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.getStateRangeScanIteratorAtHeightReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *HistoryQueryExecutor) GetStateRangeScanIteratorAtHeightCallCount() int {
+	fake.getStateRangeScanIteratorAtHeightMutex.RLock()
+	defer fake.getStateRangeScanIteratorAtHeightMutex.RUnlock()
+	return len(fake.getStateRangeScanIteratorAtHeightArgsForCall)
+}
+
+func (fake *HistoryQueryExecutor) GetStateRangeScanIteratorAtHeightCalls(stub func(string, string, string, uint64) (ledger.ResultsIterator, error)) {
+	fake.getStateRangeScanIteratorAtHeightMutex.Lock()
+	defer fake.getStateRangeScanIteratorAtHeightMutex.Unlock()
+	fake.GetStateRangeScanIteratorAtHeightStub = stub
+}
+
+func (fake *HistoryQueryExecutor) GetStateRangeScanIteratorAtHeightArgsForCall(i int) (string, string, string, uint64) {
+	fake.getStateRangeScanIteratorAtHeightMutex.RLock()
+	defer fake.getStateRangeScanIteratorAtHeightMutex.RUnlock()
+	argsForCall := fake.getStateRangeScanIteratorAtHeightArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
+}
+
+func (fake *HistoryQueryExecutor) GetStateRangeScanIteratorAtHeightReturns(result1 ledger.ResultsIterator, result2 error) {
+	fake.getStateRangeScanIteratorAtHeightMutex.Lock()
+	defer fake.getStateRangeScanIteratorAtHeightMutex.Unlock()
+	fake.GetStateRangeScanIteratorAtHeightStub = nil
+	fake.getStateRangeScanIteratorAtHeightReturns = struct {
+		result1 ledger.ResultsIterator
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *HistoryQueryExecutor) GetStateRangeScanIteratorAtHeightReturnsOnCall(i int, result1 ledger.ResultsIterator, result2 error) {
+	fake.getStateRangeScanIteratorAtHeightMutex.Lock()
+	defer fake.getStateRangeScanIteratorAtHeightMutex.Unlock()
+	fake.GetStateRangeScanIteratorAtHeightStub = nil
+	if fake.getStateRangeScanIteratorAtHeightReturnsOnCall == nil {
+		fake.getStateRangeScanIteratorAtHeightReturnsOnCall = make(map[int]struct {
+			result1 ledger.ResultsIterator
+			result2 error
+		})
+	}
+	fake.getStateRangeScanIteratorAtHeightReturnsOnCall[i] = struct {
+		result1 ledger.ResultsIterator
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *HistoryQueryExecutor) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
 	fake.getHistoryForKeyMutex.RLock()
 	defer fake.getHistoryForKeyMutex.RUnlock()
+	fake.getHistoryForKeyWithMetadataMutex.RLock()
+	defer fake.getHistoryForKeyWithMetadataMutex.RUnlock()
+	fake.getPrivateDataHistoryForKeyMutex.RLock()
+	defer fake.getPrivateDataHistoryForKeyMutex.RUnlock()
+	fake.getStateAtHeightMutex.RLock()
+	defer fake.getStateAtHeightMutex.RUnlock()
+	fake.getStateRangeScanIteratorAtHeightMutex.RLock()
+	defer fake.getStateRangeScanIteratorAtHeightMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
@@ -20,6 +20,19 @@ type PackageProvider struct {
 		result1 []byte
 		result2 error
 	}
+	GetChaincodeCodePackageByIDStub        func(string) ([]byte, error)
+	getChaincodeCodePackageByIDMutex       sync.RWMutex
+	getChaincodeCodePackageByIDArgsForCall []struct {
+		arg1 string
+	}
+	getChaincodeCodePackageByIDReturns struct {
+		result1 []byte
+		result2 error
+	}
+	getChaincodeCodePackageByIDReturnsOnCall map[int]struct {
+		result1 []byte
+		result2 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -88,11 +101,76 @@ func (fake *PackageProvider) GetChaincodeCodePackageReturnsOnCall(i int, result1
 	}{result1, result2}
 }
 
+func (fake *PackageProvider) GetChaincodeCodePackageByID(arg1 string) ([]byte, error) {
+	fake.getChaincodeCodePackageByIDMutex.Lock()
+	ret, specificReturn := fake.getChaincodeCodePackageByIDReturnsOnCall[len(fake.getChaincodeCodePackageByIDArgsForCall)]
+	fake.getChaincodeCodePackageByIDArgsForCall = append(fake.getChaincodeCodePackageByIDArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	fake.recordInvocation("GetChaincodeCodePackageByID", []interface{}{arg1})
+	fake.getChaincodeCodePackageByIDMutex.Unlock()
+	if fake.GetChaincodeCodePackageByIDStub != nil {
+		return fake.GetChaincodeCodePackageByIDStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.getChaincodeCodePackageByIDReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *PackageProvider) GetChaincodeCodePackageByIDCallCount() int {
+	fake.getChaincodeCodePackageByIDMutex.RLock()
+	defer fake.getChaincodeCodePackageByIDMutex.RUnlock()
+	return len(fake.getChaincodeCodePackageByIDArgsForCall)
+}
+
+func (fake *PackageProvider) GetChaincodeCodePackageByIDCalls(stub func(string) ([]byte, error)) {
+	fake.getChaincodeCodePackageByIDMutex.Lock()
+	defer fake.getChaincodeCodePackageByIDMutex.Unlock()
+	fake.GetChaincodeCodePackageByIDStub = stub
+}
+
+func (fake *PackageProvider) GetChaincodeCodePackageByIDArgsForCall(i int) string {
+	fake.getChaincodeCodePackageByIDMutex.RLock()
+	defer fake.getChaincodeCodePackageByIDMutex.RUnlock()
+	argsForCall := fake.getChaincodeCodePackageByIDArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *PackageProvider) GetChaincodeCodePackageByIDReturns(result1 []byte, result2 error) {
+	fake.getChaincodeCodePackageByIDMutex.Lock()
+	defer fake.getChaincodeCodePackageByIDMutex.Unlock()
+	fake.GetChaincodeCodePackageByIDStub = nil
+	fake.getChaincodeCodePackageByIDReturns = struct {
+		result1 []byte
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *PackageProvider) GetChaincodeCodePackageByIDReturnsOnCall(i int, result1 []byte, result2 error) {
+	fake.getChaincodeCodePackageByIDMutex.Lock()
+	defer fake.getChaincodeCodePackageByIDMutex.Unlock()
+	fake.GetChaincodeCodePackageByIDStub = nil
+	if fake.getChaincodeCodePackageByIDReturnsOnCall == nil {
+		fake.getChaincodeCodePackageByIDReturnsOnCall = make(map[int]struct {
+			result1 []byte
+			result2 error
+		})
+	}
+	fake.getChaincodeCodePackageByIDReturnsOnCall[i] = struct {
+		result1 []byte
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *PackageProvider) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
 	fake.getChaincodeCodePackageMutex.RLock()
 	defer fake.getChaincodeCodePackageMutex.RUnlock()
+	fake.getChaincodeCodePackageByIDMutex.RLock()
+	defer fake.getChaincodeCodePackageByIDMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
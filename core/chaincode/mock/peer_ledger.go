@@ -39,6 +39,18 @@ type PeerLedger struct {
 	commitWithPvtDataReturnsOnCall map[int]struct {
 		result1 error
 	}
+	GenerateSnapshotStub        func() (string, error)
+	generateSnapshotMutex       sync.RWMutex
+	generateSnapshotArgsForCall []struct {
+	}
+	generateSnapshotReturns struct {
+		result1 string
+		result2 error
+	}
+	generateSnapshotReturnsOnCall map[int]struct {
+		result1 string
+		result2 error
+	}
 	GetBlockByHashStub        func([]byte) (*common.Block, error)
 	getBlockByHashMutex       sync.RWMutex
 	getBlockByHashArgsForCall []struct {
@@ -103,6 +115,18 @@ type PeerLedger struct {
 		result1 ledger.ResultsIterator
 		result2 error
 	}
+	GetChaincodeIndexManagerStub        func() (ledgera.ChaincodeIndexManager, error)
+	getChaincodeIndexManagerMutex       sync.RWMutex
+	getChaincodeIndexManagerArgsForCall []struct {
+	}
+	getChaincodeIndexManagerReturns struct {
+		result1 ledgera.ChaincodeIndexManager
+		result2 error
+	}
+	getChaincodeIndexManagerReturnsOnCall map[int]struct {
+		result1 ledgera.ChaincodeIndexManager
+		result2 error
+	}
 	GetConfigHistoryRetrieverStub        func() (ledgera.ConfigHistoryRetriever, error)
 	getConfigHistoryRetrieverMutex       sync.RWMutex
 	getConfigHistoryRetrieverArgsForCall []struct {
@@ -252,6 +276,19 @@ type PeerLedger struct {
 	purgePrivateDataReturnsOnCall map[int]struct {
 		result1 error
 	}
+	TxIDExistsStub        func(string) (bool, error)
+	txIDExistsMutex       sync.RWMutex
+	txIDExistsArgsForCall []struct {
+		arg1 string
+	}
+	txIDExistsReturns struct {
+		result1 bool
+		result2 error
+	}
+	txIDExistsReturnsOnCall map[int]struct {
+		result1 bool
+		result2 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -407,6 +444,61 @@ func (fake *PeerLedger) CommitWithPvtDataReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
+func (fake *PeerLedger) GenerateSnapshot() (string, error) {
+	fake.generateSnapshotMutex.Lock()
+	ret, specificReturn := fake.generateSnapshotReturnsOnCall[len(fake.generateSnapshotArgsForCall)]
+	fake.generateSnapshotArgsForCall = append(fake.generateSnapshotArgsForCall, struct {
+	}{})
+	fake.recordInvocation("GenerateSnapshot", []interface{}{})
+	fake.generateSnapshotMutex.Unlock()
+	if fake.GenerateSnapshotStub != nil {
+		return fake.GenerateSnapshotStub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.generateSnapshotReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *PeerLedger) GenerateSnapshotCallCount() int {
+	fake.generateSnapshotMutex.RLock()
+	defer fake.generateSnapshotMutex.RUnlock()
+	return len(fake.generateSnapshotArgsForCall)
+}
+
+func (fake *PeerLedger) GenerateSnapshotCalls(stub func() (string, error)) {
+	fake.generateSnapshotMutex.Lock()
+	defer fake.generateSnapshotMutex.Unlock()
+	fake.GenerateSnapshotStub = stub
+}
+
+func (fake *PeerLedger) GenerateSnapshotReturns(result1 string, result2 error) {
+	fake.generateSnapshotMutex.Lock()
+	defer fake.generateSnapshotMutex.Unlock()
+	fake.GenerateSnapshotStub = nil
+	fake.generateSnapshotReturns = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *PeerLedger) GenerateSnapshotReturnsOnCall(i int, result1 string, result2 error) {
+	fake.generateSnapshotMutex.Lock()
+	defer fake.generateSnapshotMutex.Unlock()
+	fake.GenerateSnapshotStub = nil
+	if fake.generateSnapshotReturnsOnCall == nil {
+		fake.generateSnapshotReturnsOnCall = make(map[int]struct {
+			result1 string
+			result2 error
+		})
+	}
+	fake.generateSnapshotReturnsOnCall[i] = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *PeerLedger) GetBlockByHash(arg1 []byte) (*common.Block, error) {
 	var arg1Copy []byte
 	if arg1 != nil {
@@ -719,6 +811,61 @@ func (fake *PeerLedger) GetBlocksIteratorReturnsOnCall(i int, result1 ledger.Res
 	}{result1, result2}
 }
 
+func (fake *PeerLedger) GetChaincodeIndexManager() (ledgera.ChaincodeIndexManager, error) {
+	fake.getChaincodeIndexManagerMutex.Lock()
+	ret, specificReturn := fake.getChaincodeIndexManagerReturnsOnCall[len(fake.getChaincodeIndexManagerArgsForCall)]
+	fake.getChaincodeIndexManagerArgsForCall = append(fake.getChaincodeIndexManagerArgsForCall, struct {
+	}{})
+	fake.recordInvocation("GetChaincodeIndexManager", []interface{}{})
+	fake.getChaincodeIndexManagerMutex.Unlock()
+	if fake.GetChaincodeIndexManagerStub != nil {
+		return fake.GetChaincodeIndexManagerStub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.getChaincodeIndexManagerReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *PeerLedger) GetChaincodeIndexManagerCallCount() int {
+	fake.getChaincodeIndexManagerMutex.RLock()
+	defer fake.getChaincodeIndexManagerMutex.RUnlock()
+	return len(fake.getChaincodeIndexManagerArgsForCall)
+}
+
+func (fake *PeerLedger) GetChaincodeIndexManagerCalls(stub func() (ledgera.ChaincodeIndexManager, error)) {
+	fake.getChaincodeIndexManagerMutex.Lock()
+	defer fake.getChaincodeIndexManagerMutex.Unlock()
+	fake.GetChaincodeIndexManagerStub = stub
+}
+
+func (fake *PeerLedger) GetChaincodeIndexManagerReturns(result1 ledgera.ChaincodeIndexManager, result2 error) {
+	fake.getChaincodeIndexManagerMutex.Lock()
+	defer fake.getChaincodeIndexManagerMutex.Unlock()
+	fake.GetChaincodeIndexManagerStub = nil
+	fake.getChaincodeIndexManagerReturns = struct {
+		result1 ledgera.ChaincodeIndexManager
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *PeerLedger) GetChaincodeIndexManagerReturnsOnCall(i int, result1 ledgera.ChaincodeIndexManager, result2 error) {
+	fake.getChaincodeIndexManagerMutex.Lock()
+	defer fake.getChaincodeIndexManagerMutex.Unlock()
+	fake.GetChaincodeIndexManagerStub = nil
+	if fake.getChaincodeIndexManagerReturnsOnCall == nil {
+		fake.getChaincodeIndexManagerReturnsOnCall = make(map[int]struct {
+			result1 ledgera.ChaincodeIndexManager
+			result2 error
+		})
+	}
+	fake.getChaincodeIndexManagerReturnsOnCall[i] = struct {
+		result1 ledgera.ChaincodeIndexManager
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *PeerLedger) GetConfigHistoryRetriever() (ledgera.ConfigHistoryRetriever, error) {
 	fake.getConfigHistoryRetrieverMutex.Lock()
 	ret, specificReturn := fake.getConfigHistoryRetrieverReturnsOnCall[len(fake.getConfigHistoryRetrieverArgsForCall)]
@@ -1431,6 +1578,69 @@ func (fake *PeerLedger) PurgePrivateDataReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
+func (fake *PeerLedger) TxIDExists(arg1 string) (bool, error) {
+	fake.txIDExistsMutex.Lock()
+	ret, specificReturn := fake.txIDExistsReturnsOnCall[len(fake.txIDExistsArgsForCall)]
+	fake.txIDExistsArgsForCall = append(fake.txIDExistsArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	fake.recordInvocation("TxIDExists", []interface{}{arg1})
+	fake.txIDExistsMutex.Unlock()
+	if fake.TxIDExistsStub != nil {
+		return fake.TxIDExistsStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.txIDExistsReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *PeerLedger) TxIDExistsCallCount() int {
+	fake.txIDExistsMutex.RLock()
+	defer fake.txIDExistsMutex.RUnlock()
+	return len(fake.txIDExistsArgsForCall)
+}
+
+func (fake *PeerLedger) TxIDExistsCalls(stub func(string) (bool, error)) {
+	fake.txIDExistsMutex.Lock()
+	defer fake.txIDExistsMutex.Unlock()
+	fake.TxIDExistsStub = stub
+}
+
+func (fake *PeerLedger) TxIDExistsArgsForCall(i int) string {
+	fake.txIDExistsMutex.RLock()
+	defer fake.txIDExistsMutex.RUnlock()
+	argsForCall := fake.txIDExistsArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *PeerLedger) TxIDExistsReturns(result1 bool, result2 error) {
+	fake.txIDExistsMutex.Lock()
+	defer fake.txIDExistsMutex.Unlock()
+	fake.TxIDExistsStub = nil
+	fake.txIDExistsReturns = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *PeerLedger) TxIDExistsReturnsOnCall(i int, result1 bool, result2 error) {
+	fake.txIDExistsMutex.Lock()
+	defer fake.txIDExistsMutex.Unlock()
+	fake.TxIDExistsStub = nil
+	if fake.txIDExistsReturnsOnCall == nil {
+		fake.txIDExistsReturnsOnCall = make(map[int]struct {
+			result1 bool
+			result2 error
+		})
+	}
+	fake.txIDExistsReturnsOnCall[i] = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *PeerLedger) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
@@ -1440,6 +1650,8 @@ func (fake *PeerLedger) Invocations() map[string][][]interface{} {
 	defer fake.commitPvtDataOfOldBlocksMutex.RUnlock()
 	fake.commitWithPvtDataMutex.RLock()
 	defer fake.commitWithPvtDataMutex.RUnlock()
+	fake.generateSnapshotMutex.RLock()
+	defer fake.generateSnapshotMutex.RUnlock()
 	fake.getBlockByHashMutex.RLock()
 	defer fake.getBlockByHashMutex.RUnlock()
 	fake.getBlockByNumberMutex.RLock()
@@ -1450,6 +1662,8 @@ func (fake *PeerLedger) Invocations() map[string][][]interface{} {
 	defer fake.getBlockchainInfoMutex.RUnlock()
 	fake.getBlocksIteratorMutex.RLock()
 	defer fake.getBlocksIteratorMutex.RUnlock()
+	fake.getChaincodeIndexManagerMutex.RLock()
+	defer fake.getChaincodeIndexManagerMutex.RUnlock()
 	fake.getConfigHistoryRetrieverMutex.RLock()
 	defer fake.getConfigHistoryRetrieverMutex.RUnlock()
 	fake.getMissingPvtDataTrackerMutex.RLock()
@@ -1474,6 +1688,8 @@ func (fake *PeerLedger) Invocations() map[string][][]interface{} {
 	defer fake.pruneMutex.RUnlock()
 	fake.purgePrivateDataMutex.RLock()
 	defer fake.purgePrivateDataMutex.RUnlock()
+	fake.txIDExistsMutex.RLock()
+	defer fake.txIDExistsMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
@@ -19,7 +19,7 @@ import (
 
 // The chaincode package is simply a .tar.gz file.  For the time being, we
 // assume that the package contains a Chaincode-Package-Metadata.json file
-// which contains a 'Type', and optionally a 'Path'.  In the future, it would
+// which contains a 'Type', a 'Label', and optionally a 'Path'.  In the future, it would
 // be nice if we moved to a more buildpack type system, rather than the below
 // presented JAR+manifest type system, but for expediency and incremental changes,
 // moving to a tar format over the proto format for a user-inspectable artifact
@@ -36,10 +36,12 @@ type ChaincodePackage struct {
 }
 
 // ChaincodePackageMetadata contains the information necessary to understand
-// the embedded code package.
+// the embedded code package. The label identifies the package, together with
+// the hash of the package, once it is installed.
 type ChaincodePackageMetadata struct {
-	Type string `json:"Type"`
-	Path string `json:"Path"`
+	Type  string `json:"Type"`
+	Path  string `json:"Path"`
+	Label string `json:"Label"`
 }
 
 // ChaincodePackageParser provides the ability to parse chaincode packages
@@ -101,6 +103,10 @@ func (ccpp ChaincodePackageParser) Parse(source []byte) (*ChaincodePackage, erro
 		return nil, errors.Errorf("did not find any package metadata (missing %s)", ChaincodePackageMetadataFile)
 	}
 
+	if err := ValidateLabel(ccPackageMetadata.Label); err != nil {
+		return nil, err
+	}
+
 	return &ChaincodePackage{
 		Metadata:    ccPackageMetadata,
 		CodePackage: codePackage,
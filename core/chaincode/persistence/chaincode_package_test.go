@@ -27,11 +27,22 @@ var _ = Describe("ChaincodePackageParser", func() {
 			ccPackage, err := ccpp.Parse(data)
 			Expect(err).NotTo(HaveOccurred())
 			Expect(ccPackage.Metadata).To(Equal(&persistence.ChaincodePackageMetadata{
-				Type: "Fake-Type",
-				Path: "Fake-Path",
+				Type:  "Fake-Type",
+				Path:  "Fake-Path",
+				Label: "Fake-Label",
 			}))
 		})
 
+		Context("when the label is invalid", func() {
+			It("fails", func() {
+				data, err := ioutil.ReadFile("testdata/bad-label.tar.gz")
+				Expect(err).NotTo(HaveOccurred())
+
+				_, err = ccpp.Parse(data)
+				Expect(err).To(MatchError("invalid label 'Bad/Label'. Label must be non-empty, can only consist of alphanumerics, symbols from '.+-_', and can only begin with alphanumerics"))
+			})
+		})
+
 		Context("when the data is not gzipped", func() {
 			It("fails", func() {
 				_, err := ccpp.Parse([]byte("bad-data"))
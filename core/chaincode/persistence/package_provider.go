@@ -17,10 +17,7 @@ import (
 // StorePackageProvider is the interface needed to retrieve
 // the code package from a ChaincodeInstallPackage
 type StorePackageProvider interface {
-	GetChaincodeInstallPath() string
-	ListInstalledChaincodes() ([]chaincode.InstalledChaincode, error)
-	Load(hash []byte) (codePackage []byte, name, version string, err error)
-	RetrieveHash(name, version string) (hash []byte, err error)
+	Load(packageID string) (ccInstallPkg []byte, err error)
 }
 
 // LegacyPackageProvider is the interface needed to retrieve
@@ -36,29 +33,21 @@ type PackageParser interface {
 }
 
 // PackageProvider holds the necessary dependencies to obtain the code
-// package bytes for a chaincode
+// package bytes for a chaincode. LegacyPath is the directory where the
+// ChaincodeDeploymentSpecs are installed.
 type PackageProvider struct {
-	Store    StorePackageProvider
-	Parser   PackageParser
-	LegacyPP LegacyPackageProvider
+	Store      StorePackageProvider
+	Parser     PackageParser
+	LegacyPP   LegacyPackageProvider
+	LegacyPath string
 }
 
 // GetChaincodeCodePackage gets the code package bytes for a chaincode given
-// the name and version. It first searches through the persisted
-// ChaincodeInstallPackages and then falls back to searching for
-// ChaincodeDeploymentSpecs
+// the name and version. Chaincodes installed as ChaincodeInstallPackages are
+// identified by their package ID rather than by name and version, so only the
+// ChaincodeDeploymentSpecs are searched
 func (p *PackageProvider) GetChaincodeCodePackage(name, version string) ([]byte, error) {
-	codePackage, err := p.getCodePackageFromStore(name, version)
-	if err == nil {
-		return codePackage, nil
-	}
-	if _, ok := err.(*CodePackageNotFoundErr); !ok {
-		// return the error if the hash cannot be retrieved or the code package
-		// fails to load from the persistence store
-		return nil, err
-	}
-
-	codePackage, err = p.getCodePackageFromLegacyPP(name, version)
+	codePackage, err := p.getCodePackageFromLegacyPP(name, version)
 	if err != nil {
 		logger.Debug(err.Error())
 		err = errors.Errorf("code package not found for chaincode with name '%s', version '%s'", name, version)
@@ -67,18 +56,14 @@ func (p *PackageProvider) GetChaincodeCodePackage(name, version string) ([]byte,
 	return codePackage, nil
 }
 
-// GetCodePackageFromStore gets the code package bytes from the package
-// provider's Store, which persists ChaincodeInstallPackages
-func (p *PackageProvider) getCodePackageFromStore(name, version string) ([]byte, error) {
-	hash, err := p.Store.RetrieveHash(name, version)
+// GetChaincodeCodePackageByID gets the code package bytes of the
+// ChaincodeInstallPackage persisted in the package provider's Store with the
+// given package ID
+func (p *PackageProvider) GetChaincodeCodePackageByID(packageID string) ([]byte, error) {
+	fsBytes, err := p.Store.Load(packageID)
 	if _, ok := err.(*CodePackageNotFoundErr); ok {
 		return nil, err
 	}
-	if err != nil {
-		return nil, errors.WithMessage(err, "error retrieving hash")
-	}
-
-	fsBytes, _, _, err := p.Store.Load(hash)
 	if err != nil {
 		return nil, errors.WithMessage(err, "error loading code package from ChaincodeInstallPackage")
 	}
@@ -102,27 +87,15 @@ func (p *PackageProvider) getCodePackageFromLegacyPP(name, version string) ([]by
 }
 
 // ListInstalledChaincodes returns metadata (name, version, and ID) for
-// each chaincode installed on a peer
+// each chaincode installed on a peer as a ChaincodeDeploymentSpec. The
+// ChaincodeInstallPackages have no name and version and are listed by the
+// Store instead
 func (p *PackageProvider) ListInstalledChaincodes() ([]chaincode.InstalledChaincode, error) {
-	// first look through ChaincodeInstallPackages
-	installedChaincodes, err := p.Store.ListInstalledChaincodes()
-
-	if err != nil {
-		// log the error and continue
-		logger.Debugf("error getting installed chaincodes from persistence store: %s", err)
-	}
-
-	// then look through CDS/SCDS
-	installedChaincodesLegacy, err := p.LegacyPP.ListInstalledChaincodes(p.Store.GetChaincodeInstallPath(), ioutil.ReadDir, ccprovider.LoadPackage)
-
+	installedChaincodes, err := p.LegacyPP.ListInstalledChaincodes(p.LegacyPath, ioutil.ReadDir, ccprovider.LoadPackage)
 	if err != nil {
 		// log the error and continue
 		logger.Debugf("error getting installed chaincodes from ccprovider: %s", err)
 	}
 
-	for _, cc := range installedChaincodesLegacy {
-		installedChaincodes = append(installedChaincodes, cc)
-	}
-
 	return installedChaincodes, nil
 }
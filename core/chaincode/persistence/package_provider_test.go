@@ -20,133 +20,121 @@ import (
 var _ = Describe("PackageProvider", func() {
 	var _ = Describe("GetChaincodeCodePackage", func() {
 		var (
-			mockSPP         *mock.StorePackageProvider
 			mockLPP         *mock.LegacyPackageProvider
-			mockParser      *mock.PackageParser
 			packageProvider *persistence.PackageProvider
 		)
 
 		BeforeEach(func() {
-			mockSPP = &mock.StorePackageProvider{}
-			mockSPP.RetrieveHashReturns([]byte("testcchash"), nil)
-			mockSPP.LoadReturns([]byte("storeCode"), "testcc", "1.0", nil)
-
-			mockParser = &mock.PackageParser{}
-			mockParser.ParseReturns(&persistence.ChaincodePackage{
-				CodePackage: []byte("parsedCode"),
-			}, nil)
-
 			mockLPP = &mock.LegacyPackageProvider{}
 			mockLPP.GetChaincodeCodePackageReturns([]byte("legacyCode"), nil)
 
 			packageProvider = &persistence.PackageProvider{
-				Store:    mockSPP,
-				Parser:   mockParser,
 				LegacyPP: mockLPP,
 			}
 		})
 
-		It("gets the code package successfully", func() {
+		It("gets the code package successfully from the legacy package provider", func() {
 			pkgBytes, err := packageProvider.GetChaincodeCodePackage("testcc", "1.0")
 			Expect(err).NotTo(HaveOccurred())
+			Expect(pkgBytes).To(Equal([]byte("legacyCode")))
 
-			Expect(mockSPP.RetrieveHashCallCount()).To(Equal(1))
-			ccName, ccVersion := mockSPP.RetrieveHashArgsForCall(0)
+			Expect(mockLPP.GetChaincodeCodePackageCallCount()).To(Equal(1))
+			ccName, ccVersion := mockLPP.GetChaincodeCodePackageArgsForCall(0)
 			Expect(ccName).To(Equal("testcc"))
 			Expect(ccVersion).To(Equal("1.0"))
-
-			Expect(mockParser.ParseCallCount()).To(Equal(1))
-			Expect(mockParser.ParseArgsForCall(0)).To(Equal([]byte("storeCode")))
-
-			Expect(pkgBytes).To(Equal([]byte("parsedCode")))
 		})
 
-		Context("when parsing the code package fails", func() {
+		Context("when the code package is not available in the legacy package provider", func() {
 			BeforeEach(func() {
-				mockParser.ParseReturns(nil, fmt.Errorf("fake-error"))
+				mockLPP.GetChaincodeCodePackageReturns(nil, errors.New("latte"))
 			})
 
-			It("wraps and returns the error", func() {
-				_, err := packageProvider.GetChaincodeCodePackage("testcc", "1.0")
-				Expect(err).To(MatchError("error parsing chaincode package: fake-error"))
+			It("returns an error", func() {
+				pkgBytes, err := packageProvider.GetChaincodeCodePackage("testcc", "1.0")
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(Equal("code package not found for chaincode with name 'testcc', version '1.0'"))
+				Expect(len(pkgBytes)).To(Equal(0))
 			})
 		})
+	})
 
-		Context("when the code package is not available in the store package provider", func() {
-			BeforeEach(func() {
-				mockSPP.RetrieveHashReturns(nil, &persistence.CodePackageNotFoundErr{})
-			})
+	var _ = Describe("GetChaincodeCodePackageByID", func() {
+		var (
+			mockSPP         *mock.StorePackageProvider
+			mockParser      *mock.PackageParser
+			packageProvider *persistence.PackageProvider
+		)
 
-			It("gets the code package successfully from the legacy package provider", func() {
-				pkgBytes, err := packageProvider.GetChaincodeCodePackage("testcc", "1.0")
-				Expect(err).NotTo(HaveOccurred())
-				Expect(pkgBytes).To(Equal([]byte("legacyCode")))
-			})
+		BeforeEach(func() {
+			mockSPP = &mock.StorePackageProvider{}
+			mockSPP.LoadReturns([]byte("storeCode"), nil)
+
+			mockParser = &mock.PackageParser{}
+			mockParser.ParseReturns(&persistence.ChaincodePackage{
+				CodePackage: []byte("parsedCode"),
+			}, nil)
+
+			packageProvider = &persistence.PackageProvider{
+				Store:  mockSPP,
+				Parser: mockParser,
+			}
 		})
 
-		Context("when retrieving the hash from the store package provider fails", func() {
+		It("gets the code package successfully", func() {
+			pkgBytes, err := packageProvider.GetChaincodeCodePackageByID("testcc:hash")
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(mockSPP.LoadCallCount()).To(Equal(1))
+			Expect(mockSPP.LoadArgsForCall(0)).To(Equal("testcc:hash"))
+
+			Expect(mockParser.ParseCallCount()).To(Equal(1))
+			Expect(mockParser.ParseArgsForCall(0)).To(Equal([]byte("storeCode")))
+
+			Expect(pkgBytes).To(Equal([]byte("parsedCode")))
+		})
+
+		Context("when the code package is not available in the store package provider", func() {
 			BeforeEach(func() {
-				mockSPP.RetrieveHashReturns(nil, errors.New("chai"))
+				mockSPP.LoadReturns(nil, &persistence.CodePackageNotFoundErr{PackageID: "testcc:hash"})
 			})
 
-			It("returns an error", func() {
-				pkgBytes, err := packageProvider.GetChaincodeCodePackage("testcc", "1.0")
-				Expect(err).To(HaveOccurred())
-				Expect(err.Error()).To(Equal("error retrieving hash: chai"))
-				Expect(pkgBytes).To(BeNil())
+			It("returns the error", func() {
+				_, err := packageProvider.GetChaincodeCodePackageByID("testcc:hash")
+				Expect(err).To(MatchError("chaincode install package 'testcc:hash' not found"))
 			})
 		})
 
 		Context("when the code package fails to load from the store package provider", func() {
 			BeforeEach(func() {
-				mockSPP.LoadReturns(nil, "", "", errors.New("mocha"))
+				mockSPP.LoadReturns(nil, errors.New("mocha"))
 			})
 
-			It("returns an error", func() {
-				pkgBytes, err := packageProvider.GetChaincodeCodePackage("testcc", "1.0")
-				Expect(err).To(HaveOccurred())
+			It("wraps and returns the error", func() {
+				pkgBytes, err := packageProvider.GetChaincodeCodePackageByID("testcc:hash")
+				Expect(err).To(MatchError("error loading code package from ChaincodeInstallPackage: mocha"))
 				Expect(pkgBytes).To(BeNil())
 			})
 		})
 
-		Context("when the code package is not available in either package provider", func() {
+		Context("when parsing the code package fails", func() {
 			BeforeEach(func() {
-				mockSPP.RetrieveHashReturns(nil, &persistence.CodePackageNotFoundErr{})
-				mockLPP.GetChaincodeCodePackageReturns(nil, errors.New("latte"))
+				mockParser.ParseReturns(nil, fmt.Errorf("fake-error"))
 			})
 
-			It("returns an error", func() {
-				pkgBytes, err := packageProvider.GetChaincodeCodePackage("testcc", "1.0")
-				Expect(err).To(HaveOccurred())
-				Expect(err.Error()).To(Equal("code package not found for chaincode with name 'testcc', version '1.0'"))
-				Expect(len(pkgBytes)).To(Equal(0))
+			It("wraps and returns the error", func() {
+				_, err := packageProvider.GetChaincodeCodePackageByID("testcc:hash")
+				Expect(err).To(MatchError("error parsing chaincode package: fake-error"))
 			})
 		})
 	})
 
 	var _ = Describe("ListInstalledChaincodes", func() {
 		var (
-			mockSPP         *mock.StorePackageProvider
 			mockLPP         *mock.LegacyPackageProvider
 			packageProvider *persistence.PackageProvider
 		)
 
 		BeforeEach(func() {
-			mockSPP = &mock.StorePackageProvider{}
-			installedChaincodes := []chaincode.InstalledChaincode{
-				{
-					Name:    "test1",
-					Version: "1.0",
-					Id:      []byte("hash1"),
-				},
-				{
-					Name:    "cc1",
-					Version: "2.0",
-					Id:      []byte("hash2"),
-				},
-			}
-			mockSPP.ListInstalledChaincodesReturns(installedChaincodes, nil)
-
 			mockLPP = &mock.LegacyPackageProvider{}
 			installedChaincodesLegacy := []chaincode.InstalledChaincode{
 				{
@@ -158,27 +146,18 @@ var _ = Describe("PackageProvider", func() {
 			mockLPP.ListInstalledChaincodesReturns(installedChaincodesLegacy, nil)
 
 			packageProvider = &persistence.PackageProvider{
-				Store:    mockSPP,
-				LegacyPP: mockLPP,
+				LegacyPP:   mockLPP,
+				LegacyPath: "legacyPath",
 			}
 		})
 
-		It("lists the installed chaincodes successfully", func() {
+		It("lists the chaincodes installed as ChaincodeDeploymentSpecs", func() {
 			installedChaincodes, err := packageProvider.ListInstalledChaincodes()
 			Expect(err).NotTo(HaveOccurred())
-			Expect(len(installedChaincodes)).To(Equal(3))
-		})
+			Expect(len(installedChaincodes)).To(Equal(1))
 
-		Context("when listing the installed chaincodes from the persistence store fails", func() {
-			BeforeEach(func() {
-				mockSPP.ListInstalledChaincodesReturns(nil, errors.New("football"))
-			})
-
-			It("falls back to listing the chaincodes from the legacy package provider", func() {
-				installedChaincodes, err := packageProvider.ListInstalledChaincodes()
-				Expect(err).NotTo(HaveOccurred())
-				Expect(len(installedChaincodes)).To(Equal(1))
-			})
+			dir, _, _ := mockLPP.ListInstalledChaincodesArgsForCall(0)
+			Expect(dir).To(Equal("legacyPath"))
 		})
 
 		Context("when listing the installed chaincodes from the legacy package provider fails", func() {
@@ -186,10 +165,10 @@ var _ = Describe("PackageProvider", func() {
 				mockLPP.ListInstalledChaincodesReturns(nil, errors.New("football"))
 			})
 
-			It("lists the chaincodes from only the persistence store package provider ", func() {
+			It("logs the error and returns no chaincodes", func() {
 				installedChaincodes, err := packageProvider.ListInstalledChaincodes()
 				Expect(err).NotTo(HaveOccurred())
-				Expect(len(installedChaincodes)).To(Equal(2))
+				Expect(len(installedChaincodes)).To(Equal(0))
 			})
 		})
 	})
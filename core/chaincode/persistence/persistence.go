@@ -7,12 +7,14 @@ SPDX-License-Identifier: Apache-2.0
 package persistence
 
 import (
+	"crypto/sha256"
 	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
 	"os"
 	"path/filepath"
+	"regexp"
 	"strings"
 
 	"github.com/hyperledger/fabric/common/chaincode"
@@ -69,116 +71,114 @@ type Store struct {
 	ReadWriter IOReadWriter
 }
 
-// Save persists chaincode install package bytes with the given name
-// and version
-func (s *Store) Save(name, version string, ccInstallPkg []byte) ([]byte, error) {
-	metadataJSON, err := toJSON(name, version)
-	if err != nil {
-		return nil, err
+var (
+	// labelRegexp is the pattern the labels of chaincode install packages must match
+	labelRegexp = regexp.MustCompile(`^[[:alnum:]][[:alnum:]_.+-]*$`)
+
+	// packageFileRegexp matches the file names of the persisted chaincode install
+	// packages and captures their label and hash
+	packageFileRegexp = regexp.MustCompile(`^(.+)[.]([0-9a-f]{64})[.]tar[.]gz$`)
+
+	// legacyPackageFileRegexp matches the file names of the chaincode install packages
+	// persisted under their hash, before the packages were identified by package ID
+	legacyPackageFileRegexp = regexp.MustCompile(`^([0-9a-f]{64})[.]bin$`)
+)
+
+// ValidateLabel checks that a label of a chaincode install package starts with
+// an alphanumeric character and only contains alphanumerics and '_', '.', '+', '-'
+func ValidateLabel(label string) error {
+	if !labelRegexp.MatchString(label) {
+		return errors.Errorf("invalid label '%s'. Label must be non-empty, can only consist of alphanumerics, symbols from '.+-_', and can only begin with alphanumerics", label)
 	}
 
-	hash := util.ComputeSHA256(ccInstallPkg)
-	hashString := hex.EncodeToString(hash)
-	metadataPath := filepath.Join(s.Path, hashString+".json")
-	if _, err := s.ReadWriter.Stat(metadataPath); err == nil {
-		return nil, errors.Errorf("chaincode metadata already exists at %s", metadataPath)
+	return nil
+}
+
+// PackageID returns the ID of a chaincode install package, which is the label
+// of the package and the hex-encoded hash of the package bytes, separated by ':'
+func PackageID(label string, hash []byte) string {
+	return fmt.Sprintf("%s:%x", label, hash)
+}
+
+// parsePackageID returns the label and the hash of a package ID
+func parsePackageID(packageID string) (string, []byte, error) {
+	sep := strings.LastIndex(packageID, ":")
+	if sep == -1 {
+		return "", nil, errors.Errorf("invalid package ID '%s': missing ':' between label and hash", packageID)
 	}
 
-	ccInstallPkgPath := filepath.Join(s.Path, hashString+".bin")
-	if _, err := s.ReadWriter.Stat(ccInstallPkgPath); err == nil {
-		return nil, errors.Errorf("ChaincodeInstallPackage already exists at %s", ccInstallPkgPath)
+	label := packageID[:sep]
+	if err := ValidateLabel(label); err != nil {
+		return "", nil, errors.WithMessage(err, fmt.Sprintf("invalid package ID '%s'", packageID))
 	}
 
-	if err := s.ReadWriter.WriteFile(metadataPath, metadataJSON, 0600); err != nil {
-		return nil, errors.Wrapf(err, "error writing metadata file to %s", metadataPath)
+	hash, err := hex.DecodeString(packageID[sep+1:])
+	if err != nil || len(hash) != sha256.Size {
+		return "", nil, errors.Errorf("invalid package ID '%s': hash must be a hex-encoded SHA-256 hash", packageID)
 	}
 
-	if err := s.ReadWriter.WriteFile(ccInstallPkgPath, ccInstallPkg, 0600); err != nil {
-		err = errors.Wrapf(err, "error writing chaincode install package to %s", ccInstallPkgPath)
-		logger.Error(err.Error())
+	return label, hash, nil
+}
 
-		// need to roll back metadata write above on error
-		if err2 := s.ReadWriter.Remove(metadataPath); err2 != nil {
-			logger.Errorf("error removing metadata file at %s: %s", metadataPath, err2)
-		}
-		return nil, err
+// packageFileName returns the name of the file a chaincode install package is
+// persisted to, ':' is not allowed in file names on every platform
+func packageFileName(label string, hash []byte) string {
+	return fmt.Sprintf("%s.%x.tar.gz", label, hash)
+}
+
+// Save persists chaincode install package bytes with the given label and
+// returns the package ID of the chaincode install package
+func (s *Store) Save(label string, ccInstallPkg []byte) (string, error) {
+	if err := ValidateLabel(label); err != nil {
+		return "", err
 	}
 
-	return hash, nil
-}
+	hash := util.ComputeSHA256(ccInstallPkg)
+	packageID := PackageID(label, hash)
 
-// Load loads a persisted chaincode install package bytes with the given hash
-// and also returns the name and version
-func (s *Store) Load(hash []byte) (ccInstallPkg []byte, name, version string, err error) {
-	hashString := hex.EncodeToString(hash)
-	ccInstallPkgPath := filepath.Join(s.Path, hashString+".bin")
-	ccInstallPkg, err = s.ReadWriter.ReadFile(ccInstallPkgPath)
-	if err != nil {
-		err = errors.Wrapf(err, "error reading chaincode install package at %s", ccInstallPkgPath)
-		return nil, "", "", err
+	ccInstallPkgPath := filepath.Join(s.Path, packageFileName(label, hash))
+	if _, err := s.ReadWriter.Stat(ccInstallPkgPath); err == nil {
+		return "", errors.Errorf("ChaincodeInstallPackage already exists at %s", ccInstallPkgPath)
 	}
 
-	metadataPath := filepath.Join(s.Path, hashString+".json")
-	name, version, err = s.LoadMetadata(metadataPath)
-	if err != nil {
-		return nil, "", "", err
+	if err := s.ReadWriter.WriteFile(ccInstallPkgPath, ccInstallPkg, 0600); err != nil {
+		return "", errors.Wrapf(err, "error writing chaincode install package to %s", ccInstallPkgPath)
 	}
 
-	return ccInstallPkg, name, version, nil
+	return packageID, nil
 }
 
-// LoadMetadata loads the chaincode metadata stored at the specified path
-func (s *Store) LoadMetadata(path string) (name, version string, err error) {
-	metadataBytes, err := s.ReadWriter.ReadFile(path)
+// Load loads a persisted chaincode install package bytes with the given package ID
+func (s *Store) Load(packageID string) ([]byte, error) {
+	label, hash, err := parsePackageID(packageID)
 	if err != nil {
-		err = errors.Wrapf(err, "error reading metadata at %s", path)
-		return "", "", err
+		return nil, err
+	}
+
+	ccInstallPkgPath := filepath.Join(s.Path, packageFileName(label, hash))
+	ccInstallPkg, err := s.ReadWriter.ReadFile(ccInstallPkgPath)
+	if os.IsNotExist(err) {
+		return nil, &CodePackageNotFoundErr{PackageID: packageID}
 	}
-	ccMetadata := &ChaincodeMetadata{}
-	err = json.Unmarshal(metadataBytes, ccMetadata)
 	if err != nil {
-		err = errors.Wrapf(err, "error unmarshaling metadata at %s", path)
-		return "", "", err
+		return nil, errors.Wrapf(err, "error reading chaincode install package at %s", ccInstallPkgPath)
 	}
 
-	return ccMetadata.Name, ccMetadata.Version, nil
+	return ccInstallPkg, nil
 }
 
 // CodePackageNotFoundErr is the error returned when a code package cannot
 // be found in the persistence store
 type CodePackageNotFoundErr struct {
-	Name    string
-	Version string
+	PackageID string
 }
 
 func (e *CodePackageNotFoundErr) Error() string {
-	return fmt.Sprintf("chaincode install package not found with name '%s', version '%s'", e.Name, e.Version)
+	return fmt.Sprintf("chaincode install package '%s' not found", e.PackageID)
 }
 
-// RetrieveHash retrieves the hash of a chaincode install package given the
-// name and version of the chaincode
-func (s *Store) RetrieveHash(name string, version string) ([]byte, error) {
-	installedChaincodes, err := s.ListInstalledChaincodes()
-	if err != nil {
-		return nil, errors.WithMessage(err, "error getting installed chaincodes")
-	}
-
-	for _, installedChaincode := range installedChaincodes {
-		if installedChaincode.Name == name && installedChaincode.Version == version {
-			return installedChaincode.Id, nil
-		}
-	}
-
-	err = &CodePackageNotFoundErr{
-		Name:    name,
-		Version: version,
-	}
-
-	return nil, err
-}
-
-// ListInstalledChaincodes returns an array with information about the
-// chaincodes installed in the persistence store
+// ListInstalledChaincodes returns an array with the package IDs and labels
+// of the chaincodes installed in the persistence store
 func (s *Store) ListInstalledChaincodes() ([]chaincode.InstalledChaincode, error) {
 	files, err := s.ReadWriter.ReadDir(s.Path)
 	if err != nil {
@@ -187,27 +187,27 @@ func (s *Store) ListInstalledChaincodes() ([]chaincode.InstalledChaincode, error
 
 	installedChaincodes := []chaincode.InstalledChaincode{}
 	for _, file := range files {
-		if strings.HasSuffix(file.Name(), ".json") {
-			metadataPath := filepath.Join(s.Path, file.Name())
-			ccName, ccVersion, err := s.LoadMetadata(metadataPath)
-			if err != nil {
-				logger.Warning(err.Error())
-				continue
-			}
+		matches := packageFileRegexp.FindStringSubmatch(file.Name())
+		if matches == nil {
+			continue
+		}
 
-			// split the file name and get just the hash
-			hashString := strings.Split(file.Name(), ".")[0]
-			hash, err := hex.DecodeString(hashString)
-			if err != nil {
-				return nil, errors.Wrapf(err, "error decoding hash from hex string: %s", hashString)
-			}
-			installedChaincode := chaincode.InstalledChaincode{
-				Name:    ccName,
-				Version: ccVersion,
-				Id:      hash,
-			}
-			installedChaincodes = append(installedChaincodes, installedChaincode)
+		label := matches[1]
+		if err := ValidateLabel(label); err != nil {
+			logger.Warningf("Ignoring chaincode install package at %s: %s", filepath.Join(s.Path, file.Name()), err)
+			continue
 		}
+
+		hash, err := hex.DecodeString(matches[2])
+		if err != nil {
+			return nil, errors.Wrapf(err, "error decoding hash from hex string: %s", matches[2])
+		}
+
+		installedChaincodes = append(installedChaincodes, chaincode.InstalledChaincode{
+			PackageID: PackageID(label, hash),
+			Label:     label,
+			Id:        hash,
+		})
 	}
 	return installedChaincodes, nil
 }
@@ -218,22 +218,55 @@ func (s *Store) GetChaincodeInstallPath() string {
 	return s.Path
 }
 
-// ChaincodeMetadata holds the name and version of a chaincode
-type ChaincodeMetadata struct {
+// LegacyPackage is a chaincode install package persisted under its hash, along
+// with the name and version of the chaincode, before the packages were identified
+// by package ID
+type LegacyPackage struct {
+	Path    string
+	Name    string
+	Version string
+}
+
+// legacyPackageMetadata is the name and version of the chaincode persisted next
+// to a legacy package
+type legacyPackageMetadata struct {
 	Name    string `json:"Name"`
 	Version string `json:"Version"`
 }
 
-func toJSON(name, version string) ([]byte, error) {
-	metadata := &ChaincodeMetadata{
-		Name:    name,
-		Version: version,
+// ListLegacyPackages returns the chaincode install packages persisted in the
+// directory as <hash>.bin with their metadata in <hash>.json. These packages
+// have no label, so they cannot be referenced by a package ID and must be
+// packaged with a label and installed again.
+func ListLegacyPackages(dir string, rw IOReadWriter) ([]LegacyPackage, error) {
+	files, err := rw.ReadDir(dir)
+	if err != nil {
+		return nil, errors.Wrapf(err, "error reading chaincode directory at %s", dir)
 	}
 
-	metadataBytes, err := json.Marshal(metadata)
-	if err != nil {
-		return nil, errors.Wrap(err, "error marshaling name and version into JSON")
+	var legacyPackages []LegacyPackage
+	for _, file := range files {
+		matches := legacyPackageFileRegexp.FindStringSubmatch(file.Name())
+		if matches == nil {
+			continue
+		}
+
+		legacyPackage := LegacyPackage{Path: filepath.Join(dir, file.Name())}
+		metadataPath := filepath.Join(dir, matches[1]+".json")
+		metadataBytes, err := rw.ReadFile(metadataPath)
+		if err != nil {
+			logger.Debugf("error reading metadata of chaincode install package at %s: %s", metadataPath, err)
+		} else {
+			metadata := &legacyPackageMetadata{}
+			if err := json.Unmarshal(metadataBytes, metadata); err != nil {
+				logger.Debugf("error unmarshaling metadata of chaincode install package at %s: %s", metadataPath, err)
+			}
+			legacyPackage.Name = metadata.Name
+			legacyPackage.Version = metadata.Version
+		}
+
+		legacyPackages = append(legacyPackages, legacyPackage)
 	}
 
-	return metadataBytes, nil
+	return legacyPackages, nil
 }
@@ -12,6 +12,7 @@ import (
 	"os"
 	"path/filepath"
 
+	"github.com/hyperledger/fabric/common/chaincode"
 	"github.com/hyperledger/fabric/common/util"
 	"github.com/hyperledger/fabric/core/chaincode/persistence"
 	"github.com/hyperledger/fabric/core/chaincode/persistence/mock"
@@ -119,78 +120,46 @@ var _ = Describe("Persistence", func() {
 		})
 
 		It("saves successfully", func() {
-			hash, err := store.Save("testcc", "1.0", pkgBytes)
+			packageID, err := store.Save("testcc", pkgBytes)
 			Expect(err).NotTo(HaveOccurred())
-			Expect(hash).To(Equal(util.ComputeSHA256([]byte("testpkg"))))
-		})
+			Expect(packageID).To(Equal("testcc:" + hashString))
 
-		Context("when the metadata file already exists", func() {
-			BeforeEach(func() {
-				mockReadWriter.StatReturnsOnCall(0, nil, nil)
-			})
+			Expect(mockReadWriter.WriteFileCallCount()).To(Equal(1))
+			path, data, _ := mockReadWriter.WriteFileArgsForCall(0)
+			Expect(path).To(Equal("testcc." + hashString + ".tar.gz"))
+			Expect(data).To(Equal(pkgBytes))
+		})
 
+		Context("when the label is invalid", func() {
 			It("returns an error", func() {
-				hash, err := store.Save("testcc", "1.0", pkgBytes)
-				Expect(err).To(HaveOccurred())
-				Expect(hash).To(BeNil())
-				Expect(err.Error()).To(Equal("chaincode metadata already exists at " + hashString + ".json"))
+				packageID, err := store.Save("../testcc", pkgBytes)
+				Expect(err).To(MatchError("invalid label '../testcc'. Label must be non-empty, can only consist of alphanumerics, symbols from '.+-_', and can only begin with alphanumerics"))
+				Expect(packageID).To(BeEmpty())
+				Expect(mockReadWriter.WriteFileCallCount()).To(Equal(0))
 			})
 		})
 
 		Context("when the chaincode install package already exists", func() {
 			BeforeEach(func() {
-				mockReadWriter.StatReturnsOnCall(0, nil, errors.New("worldcup"))
-				mockReadWriter.StatReturnsOnCall(1, nil, nil)
+				mockReadWriter.StatReturns(nil, nil)
 			})
 
 			It("returns an error", func() {
-				hash, err := store.Save("testcc", "1.0", pkgBytes)
-				Expect(hash).To(BeNil())
-				Expect(err).To(HaveOccurred())
-				Expect(err.Error()).To(Equal("ChaincodeInstallPackage already exists at " + hashString + ".bin"))
+				packageID, err := store.Save("testcc", pkgBytes)
+				Expect(packageID).To(BeEmpty())
+				Expect(err).To(MatchError("ChaincodeInstallPackage already exists at testcc." + hashString + ".tar.gz"))
 			})
 		})
 
-		Context("when writing the metadata file fails", func() {
+		Context("when writing the chaincode install package file fails", func() {
 			BeforeEach(func() {
-				mockReadWriter.StatReturns(nil, errors.New("futbol"))
 				mockReadWriter.WriteFileReturns(errors.New("soccer"))
 			})
 
 			It("returns an error", func() {
-				hash, err := store.Save("testcc", "1.0", pkgBytes)
-				Expect(hash).To(BeNil())
-				Expect(err).To(HaveOccurred())
-				Expect(err.Error()).To(ContainSubstring("error writing metadata file"))
-			})
-		})
-
-		Context("when writing chaincode install package file fails and the metadata file is removed successfully", func() {
-			BeforeEach(func() {
-				mockReadWriter.StatReturns(nil, errors.New("futbol1"))
-				mockReadWriter.WriteFileReturnsOnCall(1, errors.New("soccer1"))
-			})
-
-			It("returns an error", func() {
-				hash, err := store.Save("testcc", "1.0", pkgBytes)
-				Expect(hash).To(BeNil())
-				Expect(err).To(HaveOccurred())
-				Expect(err.Error()).To(ContainSubstring("error writing chaincode install package"))
-			})
-		})
-
-		Context("when writing the chaincode install package file fails and the metadata remove also fails", func() {
-			BeforeEach(func() {
-				mockReadWriter.StatReturns(nil, errors.New("futbol2"))
-				mockReadWriter.WriteFileReturnsOnCall(1, errors.New("soccer2"))
-				mockReadWriter.RemoveReturns(errors.New("gooooool2"))
-			})
-
-			It("returns an error", func() {
-				hash, err := store.Save("testcc", "1.0", pkgBytes)
-				Expect(hash).To(BeNil())
-				Expect(err).To(HaveOccurred())
-				Expect(err.Error()).To(ContainSubstring("error writing chaincode install package"))
+				packageID, err := store.Save("testcc", pkgBytes)
+				Expect(packageID).To(BeEmpty())
+				Expect(err).To(MatchError("error writing chaincode install package to testcc." + hashString + ".tar.gz: soccer"))
 			})
 		})
 	})
@@ -199,95 +168,113 @@ var _ = Describe("Persistence", func() {
 		var (
 			mockReadWriter *mock.IOReadWriter
 			store          *persistence.Store
+			hashString     string
 		)
 
 		BeforeEach(func() {
 			mockReadWriter = &mock.IOReadWriter{}
-			mockReadWriter.ReadFileReturnsOnCall(0, []byte("cornerkick"), nil)
-			mockReadWriter.ReadFileReturnsOnCall(1, []byte(`{"Name":"vuvuzela","Version":"2.0"}`), nil)
+			mockReadWriter.ReadFileReturns([]byte("cornerkick"), nil)
 			store = &persistence.Store{
+				Path:       "testPath",
 				ReadWriter: mockReadWriter,
 			}
+			hashString = hex.EncodeToString(util.ComputeSHA256([]byte("cornerkick")))
 		})
 
 		It("loads successfully", func() {
-			ccInstallPkgBytes, name, version, err := store.Load([]byte("hash"))
+			ccInstallPkgBytes, err := store.Load("vuvuzela:" + hashString)
 			Expect(err).NotTo(HaveOccurred())
 			Expect(ccInstallPkgBytes).To(Equal([]byte("cornerkick")))
-			Expect(name).To(Equal("vuvuzela"))
-			Expect(version).To(Equal("2.0"))
+			Expect(mockReadWriter.ReadFileArgsForCall(0)).To(Equal(filepath.Join("testPath", "vuvuzela."+hashString+".tar.gz")))
 		})
 
-		Context("when reading the chaincode install package fails", func() {
+		Context("when the chaincode install package does not exist", func() {
 			BeforeEach(func() {
-				mockReadWriter.ReadFileReturnsOnCall(0, nil, errors.New("redcard"))
+				mockReadWriter.ReadFileReturns(nil, &os.PathError{Op: "open", Path: "missing", Err: os.ErrNotExist})
 			})
 
-			It("returns an error", func() {
-				ccInstallPkgBytes, name, version, err := store.Load([]byte("hash"))
-				Expect(err).To(HaveOccurred())
-				Expect(err.Error()).To(ContainSubstring("error reading chaincode install package"))
-				Expect(len(ccInstallPkgBytes)).To(Equal(0))
-				Expect(name).To(Equal(""))
-				Expect(version).To(Equal(""))
+			It("returns a CodePackageNotFoundErr", func() {
+				ccInstallPkgBytes, err := store.Load("vuvuzela:" + hashString)
+				Expect(err).To(Equal(&persistence.CodePackageNotFoundErr{PackageID: "vuvuzela:" + hashString}))
+				Expect(err).To(MatchError("chaincode install package 'vuvuzela:" + hashString + "' not found"))
+				Expect(ccInstallPkgBytes).To(BeNil())
 			})
 		})
 
-		Context("when reading the metadata fails", func() {
+		Context("when reading the chaincode install package fails", func() {
 			BeforeEach(func() {
-				mockReadWriter.ReadFileReturnsOnCall(1, nil, errors.New("yellowcard"))
+				mockReadWriter.ReadFileReturns(nil, errors.New("redcard"))
 			})
 
 			It("returns an error", func() {
-				ccInstallPkgBytes, name, version, err := store.Load([]byte("hash"))
+				ccInstallPkgBytes, err := store.Load("vuvuzela:" + hashString)
 				Expect(err).To(HaveOccurred())
-				Expect(err.Error()).To(ContainSubstring("error reading metadata"))
+				Expect(err.Error()).To(ContainSubstring("error reading chaincode install package"))
 				Expect(len(ccInstallPkgBytes)).To(Equal(0))
-				Expect(name).To(Equal(""))
-				Expect(version).To(Equal(""))
 			})
 		})
 
-		Context("when unmarshaling the metadata fails", func() {
-			BeforeEach(func() {
-				mockReadWriter.ReadFileReturnsOnCall(1, nil, nil)
-			})
+		Context("when the package ID is invalid", func() {
+			It("returns an error without reading the filesystem", func() {
+				_, err := store.Load("vuvuzela")
+				Expect(err).To(MatchError("invalid package ID 'vuvuzela': missing ':' between label and hash"))
 
-			It("returns an error", func() {
-				ccInstallPkgBytes, name, version, err := store.Load([]byte("hash"))
-				Expect(err).To(HaveOccurred())
-				Expect(err.Error()).To(ContainSubstring("error unmarshaling metadata"))
-				Expect(len(ccInstallPkgBytes)).To(Equal(0))
-				Expect(name).To(Equal(""))
-				Expect(version).To(Equal(""))
+				_, err = store.Load("../vuvuzela:" + hashString)
+				Expect(err).To(MatchError("invalid package ID '../vuvuzela:" + hashString + "': invalid label '../vuvuzela'. Label must be non-empty, can only consist of alphanumerics, symbols from '.+-_', and can only begin with alphanumerics"))
+
+				_, err = store.Load("vuvuzela:abcd")
+				Expect(err).To(MatchError("invalid package ID 'vuvuzela:abcd': hash must be a hex-encoded SHA-256 hash"))
+
+				Expect(mockReadWriter.ReadFileCallCount()).To(Equal(0))
 			})
 		})
 	})
 
-	Describe("RetrieveHash", func() {
+	Describe("ListInstalledChaincodes", func() {
 		var (
 			mockReadWriter *mock.IOReadWriter
 			store          *persistence.Store
+			hash1, hash2   []byte
 		)
 
 		BeforeEach(func() {
+			hash1 = util.ComputeSHA256([]byte("package1"))
+			hash2 = util.ComputeSHA256([]byte("package2"))
+
 			mockReadWriter = &mock.IOReadWriter{}
-			mockFileInfo := &mock.OSFileInfo{}
-			mockFileInfo.NameReturns(hex.EncodeToString([]byte("hash1")) + ".json")
-			mockFileInfo2 := &mock.OSFileInfo{}
-			mockFileInfo2.NameReturns(hex.EncodeToString([]byte("hash2")) + ".json")
-			mockReadWriter.ReadDirReturns([]os.FileInfo{mockFileInfo, mockFileInfo2}, nil)
-			mockReadWriter.ReadFileReturnsOnCall(0, []byte(`{"Name":"test1","Version":"1.0"}`), nil)
-			mockReadWriter.ReadFileReturnsOnCall(1, []byte(`{"Name":"test2","Version":"2.0"}`), nil)
+			var files []os.FileInfo
+			for _, name := range []string{
+				"label1." + hex.EncodeToString(hash1) + ".tar.gz",
+				"label.2." + hex.EncodeToString(hash2) + ".tar.gz",
+				"mycc.1.0",
+				hex.EncodeToString(hash1) + ".json",
+				"-badlabel." + hex.EncodeToString(hash1) + ".tar.gz",
+			} {
+				mockFileInfo := &mock.OSFileInfo{}
+				mockFileInfo.NameReturns(name)
+				files = append(files, mockFileInfo)
+			}
+			mockReadWriter.ReadDirReturns(files, nil)
 			store = &persistence.Store{
 				ReadWriter: mockReadWriter,
 			}
 		})
 
-		It("retrieves the hash successfully", func() {
-			hash, err := store.RetrieveHash("test2", "2.0")
+		It("returns the package IDs and labels of the installed chaincodes", func() {
+			installedChaincodes, err := store.ListInstalledChaincodes()
 			Expect(err).NotTo(HaveOccurred())
-			Expect(hash).To(Equal([]byte("hash2")))
+			Expect(installedChaincodes).To(Equal([]chaincode.InstalledChaincode{
+				{
+					PackageID: "label1:" + hex.EncodeToString(hash1),
+					Label:     "label1",
+					Id:        hash1,
+				},
+				{
+					PackageID: "label.2:" + hex.EncodeToString(hash2),
+					Label:     "label.2",
+					Id:        hash2,
+				},
+			}))
 		})
 
 		Context("when reading the directory fails", func() {
@@ -296,105 +283,81 @@ var _ = Describe("Persistence", func() {
 			})
 
 			It("returns an error", func() {
-				hash, err := store.RetrieveHash("test1", "1.0")
+				installedChaincodes, err := store.ListInstalledChaincodes()
 				Expect(err).To(HaveOccurred())
 				Expect(err.Error()).To(ContainSubstring("error reading chaincode directory"))
-				Expect(hash).To(BeNil())
-			})
-		})
-
-		Context("when reading the metadata fails", func() {
-			BeforeEach(func() {
-				mockReadWriter.ReadFileReturnsOnCall(0, nil, errors.New("handball"))
-			})
-
-			It("returns an error", func() {
-				hash, err := store.RetrieveHash("test1", "1.0")
-				Expect(err).To(HaveOccurred())
-				Expect(err.Error()).To(ContainSubstring("chaincode install package not found with name 'test1', version '1.0'"))
-				Expect(hash).To(BeNil())
-			})
-		})
-
-		Context("when decoding the hash string fails", func() {
-			BeforeEach(func() {
-				mockFileInfo := &mock.OSFileInfo{}
-				mockFileInfo.NameReturns("?.json")
-				mockReadWriter.ReadDirReturns([]os.FileInfo{mockFileInfo}, nil)
-			})
-
-			It("returns an error", func() {
-				hash, err := store.RetrieveHash("test1", "1.0")
-				Expect(err).To(HaveOccurred())
-				Expect(err.Error()).To(ContainSubstring("error decoding hash from hex string: ?"))
-				Expect(hash).To(BeNil())
-			})
-		})
-
-		Context("when reading a different metadata file fails but the desired chaincode metadata file exists", func() {
-			BeforeEach(func() {
-				mockReadWriter.ReadFileReturnsOnCall(0, nil, errors.New("penaltykick"))
-				mockReadWriter.ReadFileReturnsOnCall(1, []byte(`{"Name":"test2","Version":"2.0"}`), nil)
-			})
-
-			It("returns sucessfully", func() {
-				hash, err := store.RetrieveHash("test2", "2.0")
-				Expect(err).NotTo(HaveOccurred())
-				Expect(hash).To(Equal([]byte("hash2")))
-			})
-		})
-
-		Context("when no chaincode install package exists with the given name and version", func() {
-			It("returns an error", func() {
-				hash, err := store.RetrieveHash("test3", "1.0")
-				Expect(err).To(HaveOccurred())
-				Expect(err.Error()).To(Equal("chaincode install package not found with name 'test3', version '1.0'"))
-				Expect(hash).To(BeNil())
+				Expect(installedChaincodes).To(BeNil())
 			})
 		})
 	})
 
-	Describe("GetInstalledChaincodes", func() {
+	Describe("ListLegacyPackages", func() {
 		var (
 			mockReadWriter *mock.IOReadWriter
-			store          *persistence.Store
+			hash1, hash2   string
 		)
 
 		BeforeEach(func() {
+			hash1 = hex.EncodeToString(util.ComputeSHA256([]byte("package1")))
+			hash2 = hex.EncodeToString(util.ComputeSHA256([]byte("package2")))
+
 			mockReadWriter = &mock.IOReadWriter{}
-			mockFileInfo := &mock.OSFileInfo{}
-			mockFileInfo.NameReturns(hex.EncodeToString([]byte("hash1")) + ".json")
-			mockFileInfo2 := &mock.OSFileInfo{}
-			mockFileInfo2.NameReturns(hex.EncodeToString([]byte("hash2")) + ".json")
-			mockReadWriter.ReadDirReturns([]os.FileInfo{mockFileInfo, mockFileInfo2}, nil)
-			mockReadWriter.ReadFileReturnsOnCall(0, []byte(`{"Name":"test1","Version":"1.0"}`), nil)
-			mockReadWriter.ReadFileReturnsOnCall(1, []byte(`{"Name":"test2","Version":"2.0"}`), nil)
-			store = &persistence.Store{
-				ReadWriter: mockReadWriter,
+			var files []os.FileInfo
+			for _, name := range []string{
+				hash1 + ".bin",
+				hash1 + ".json",
+				hash2 + ".bin",
+				"mycc.1.0",
+				"label1." + hash1 + ".tar.gz",
+			} {
+				mockFileInfo := &mock.OSFileInfo{}
+				mockFileInfo.NameReturns(name)
+				files = append(files, mockFileInfo)
+			}
+			mockReadWriter.ReadDirReturns(files, nil)
+			mockReadWriter.ReadFileStub = func(name string) ([]byte, error) {
+				if name == filepath.Join("legacy-path", hash1+".json") {
+					return []byte(`{"Name":"mycc","Version":"1.0"}`), nil
+				}
+				return nil, os.ErrNotExist
 			}
 		})
 
-		It("returns the list of installed chaincodes", func() {
-			installedChaincodes, err := store.ListInstalledChaincodes()
+		It("returns the packages persisted under their hash", func() {
+			legacyPackages, err := persistence.ListLegacyPackages("legacy-path", mockReadWriter)
 			Expect(err).NotTo(HaveOccurred())
-			Expect(len(installedChaincodes)).To(Equal(2))
+			Expect(legacyPackages).To(Equal([]persistence.LegacyPackage{
+				{
+					Path:    filepath.Join("legacy-path", hash1+".bin"),
+					Name:    "mycc",
+					Version: "1.0",
+				},
+				{
+					Path: filepath.Join("legacy-path", hash2+".bin"),
+				},
+			}))
+			Expect(mockReadWriter.ReadDirArgsForCall(0)).To(Equal("legacy-path"))
 		})
 
-		Context("when the hash cannot be decoded from the filename", func() {
+		Context("when reading the directory fails", func() {
 			BeforeEach(func() {
-				mockFileInfo := &mock.OSFileInfo{}
-				mockFileInfo.NameReturns("?.json")
-				mockReadWriter.ReadDirReturns([]os.FileInfo{mockFileInfo}, nil)
+				mockReadWriter.ReadDirReturns(nil, errors.New("offsides"))
 			})
 
 			It("returns an error", func() {
-				installedChaincodes, err := store.ListInstalledChaincodes()
-				Expect(err).To(HaveOccurred())
-				Expect(len(installedChaincodes)).To(Equal(0))
+				legacyPackages, err := persistence.ListLegacyPackages("legacy-path", mockReadWriter)
+				Expect(err).To(MatchError("error reading chaincode directory at legacy-path: offsides"))
+				Expect(legacyPackages).To(BeNil())
 			})
 		})
 	})
 
+	Describe("PackageID", func() {
+		It("joins the label and the hex-encoded hash", func() {
+			Expect(persistence.PackageID("label", []byte{0x01, 0xab})).To(Equal("label:01ab"))
+		})
+	})
+
 	Describe("GetChaincodeInstallPath", func() {
 		var (
 			store *persistence.Store
@@ -0,0 +1,182 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package chaincode
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	commonledger "github.com/hyperledger/fabric/common/ledger"
+	pb "github.com/hyperledger/fabric/protos/peer"
+)
+
+// QueryLimits caps the results of a single query of a chaincode: the number of
+// results, the number of bytes of the results and the time elapsed since the
+// query started. A zero value disables the corresponding cap.
+type QueryLimits struct {
+	MaxResults  int
+	MaxBytes    int
+	MaxDuration time.Duration
+}
+
+// IsZero returns true if none of the caps is enabled.
+func (l QueryLimits) IsZero() bool {
+	return l.MaxResults <= 0 && l.MaxBytes <= 0 && l.MaxDuration <= 0
+}
+
+// QueryLimitsOverride replaces the default query limits for the chaincodes of
+// a channel, for a chaincode on all the channels or for a chaincode on a
+// channel. An empty Channel or Chaincode matches any channel or chaincode.
+type QueryLimitsOverride struct {
+	Channel   string
+	Chaincode string
+	QueryLimits
+}
+
+// QueryLimitsConfig holds the default query limits and their overrides.
+type QueryLimitsConfig struct {
+	Default   QueryLimits
+	Overrides []QueryLimitsOverride
+}
+
+// QueryLimits returns the limits that apply to the queries of the chaincode on
+// the channel. The most specific override matching the channel and the
+// chaincode wins, the chaincode taking precedence over the channel.
+func (c *QueryLimitsConfig) QueryLimits(channelID, chaincodeName string) QueryLimits {
+	limits := c.Default
+	bestScore := 0
+	for _, o := range c.Overrides {
+		if (o.Channel != "" && o.Channel != channelID) || (o.Chaincode != "" && o.Chaincode != chaincodeName) {
+			continue
+		}
+		score := 1
+		if o.Channel != "" {
+			score++
+		}
+		if o.Chaincode != "" {
+			score += 2
+		}
+		if score > bestScore {
+			limits = o.QueryLimits
+			bestScore = score
+		}
+	}
+	return limits
+}
+
+// QueryLimitExceededError is returned to the chaincode when a query exceeds
+// one of its limits.
+type QueryLimitExceededError struct {
+	ChannelID     string
+	ChaincodeName string
+	QueryType     pb.ChaincodeMessage_Type
+	Limit         string
+	Value         interface{}
+}
+
+func (e *QueryLimitExceededError) Error() string {
+	return fmt.Sprintf("%s query of chaincode %s on channel %s exceeded the limit on the %s [%v]",
+		e.QueryType, e.ChaincodeName, e.ChannelID, e.Limit, e.Value)
+}
+
+// Names of the query limits used in errors and metrics.
+const (
+	queryLimitResults  = "results"
+	queryLimitBytes    = "bytes"
+	queryLimitDuration = "duration"
+)
+
+// limitedResultsIterator accounts the cost of a query and fails the query when
+// the cost exceeds the limits. The duration of the query is the wall time since
+// the query started, which includes the execution of the query by the state
+// database before the first result is returned.
+type limitedResultsIterator struct {
+	commonledger.ResultsIterator
+	limits    QueryLimits
+	startTime time.Time
+	exceeded  func(limit string, value interface{}) error
+
+	results int
+	bytes   int
+}
+
+func (i *limitedResultsIterator) Next() (commonledger.QueryResult, error) {
+	queryResult, err := i.ResultsIterator.Next()
+	if err != nil {
+		return nil, err
+	}
+	if i.limits.MaxDuration > 0 && time.Since(i.startTime) > i.limits.MaxDuration {
+		return nil, i.exceeded(queryLimitDuration, i.limits.MaxDuration)
+	}
+	if queryResult == nil {
+		return nil, nil
+	}
+
+	i.results++
+	if i.limits.MaxResults > 0 && i.results > i.limits.MaxResults {
+		return nil, i.exceeded(queryLimitResults, i.limits.MaxResults)
+	}
+	if msg, ok := queryResult.(proto.Message); ok {
+		i.bytes += proto.Size(msg)
+	}
+	if i.limits.MaxBytes > 0 && i.bytes > i.limits.MaxBytes {
+		return nil, i.exceeded(queryLimitBytes, i.limits.MaxBytes)
+	}
+	return queryResult, nil
+}
+
+func (i *limitedResultsIterator) Close() {
+	chaincodeLogger.Debugf("Query closed after returning %d results of %d bytes in %s", i.results, i.bytes, time.Since(i.startTime))
+	i.ResultsIterator.Close()
+}
+
+// GetBookmarkAndClose preserves the bookmark of the queries with pagination.
+func (i *limitedResultsIterator) GetBookmarkAndClose() string {
+	if queryResultsIterator, ok := i.ResultsIterator.(commonledger.QueryResultsIterator); ok {
+		chaincodeLogger.Debugf("Query closed after returning %d results of %d bytes in %s", i.results, i.bytes, time.Since(i.startTime))
+		return queryResultsIterator.GetBookmarkAndClose()
+	}
+	i.Close()
+	return ""
+}
+
+// limitQuery wraps the iterator of a query of the chaincode to enforce the
+// query limits of the chaincode on the channel of the transaction. The start
+// time is taken before the query is handed to the ledger.
+func (h *Handler) limitQuery(iter commonledger.ResultsIterator, txContext *TransactionContext, queryType pb.ChaincodeMessage_Type, startTime time.Time) commonledger.ResultsIterator {
+	if h.QueryLimits == nil {
+		return iter
+	}
+	chaincodeName := h.ChaincodeName()
+	limits := h.QueryLimits.QueryLimits(txContext.ChainID, chaincodeName)
+	if limits.IsZero() {
+		return iter
+	}
+	return &limitedResultsIterator{
+		ResultsIterator: iter,
+		limits:          limits,
+		startTime:       startTime,
+		exceeded: func(limit string, value interface{}) error {
+			h.Metrics.QueryLimitsExceeded.With(
+				"type", queryType.String(),
+				"channel", txContext.ChainID,
+				"chaincode", chaincodeName,
+				"limit", limit,
+			).Add(1)
+			err := &QueryLimitExceededError{
+				ChannelID:     txContext.ChainID,
+				ChaincodeName: chaincodeName,
+				QueryType:     queryType,
+				Limit:         limit,
+				Value:         value,
+			}
+			chaincodeLogger.Warning(err.Error())
+			return err
+		},
+	}
+}
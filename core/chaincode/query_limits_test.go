@@ -0,0 +1,147 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package chaincode_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	commonledger "github.com/hyperledger/fabric/common/ledger"
+	"github.com/hyperledger/fabric/common/metrics/disabled"
+	"github.com/hyperledger/fabric/core/chaincode"
+	"github.com/hyperledger/fabric/core/chaincode/mock"
+	"github.com/hyperledger/fabric/core/common/sysccprovider"
+	"github.com/hyperledger/fabric/protos/ledger/queryresult"
+	pb "github.com/hyperledger/fabric/protos/peer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestQueryLimitsConfig(t *testing.T) {
+	config := &chaincode.QueryLimitsConfig{
+		Default: chaincode.QueryLimits{MaxResults: 1},
+		Overrides: []chaincode.QueryLimitsOverride{
+			{Channel: "ch1", Chaincode: "cc1", QueryLimits: chaincode.QueryLimits{MaxResults: 2}},
+			{Chaincode: "cc1", QueryLimits: chaincode.QueryLimits{MaxResults: 3}},
+			{Channel: "ch1", QueryLimits: chaincode.QueryLimits{MaxResults: 4}},
+			{Channel: "ch2", QueryLimits: chaincode.QueryLimits{MaxBytes: 5}},
+			{Chaincode: "cc3", QueryLimits: chaincode.QueryLimits{MaxDuration: time.Second}},
+		},
+	}
+
+	tests := []struct {
+		channel, chaincode string
+		expected           chaincode.QueryLimits
+	}{
+		{"ch1", "cc1", chaincode.QueryLimits{MaxResults: 2}},
+		{"ch2", "cc1", chaincode.QueryLimits{MaxResults: 3}},
+		{"ch1", "cc2", chaincode.QueryLimits{MaxResults: 4}},
+		{"ch2", "cc2", chaincode.QueryLimits{MaxBytes: 5}},
+		{"ch2", "cc3", chaincode.QueryLimits{MaxDuration: time.Second}},
+		{"ch3", "cc2", chaincode.QueryLimits{MaxResults: 1}},
+	}
+	for _, test := range tests {
+		assert.Equal(t, test.expected, config.QueryLimits(test.channel, test.chaincode), "channel %s chaincode %s", test.channel, test.chaincode)
+	}
+	assert.False(t, config.Default.IsZero())
+	assert.True(t, chaincode.QueryLimits{}.IsZero())
+}
+
+func newQueryLimitsTestHandler(limits chaincode.QueryLimits) *chaincode.Handler {
+	handler := &chaincode.Handler{
+		QueryResponseBuilder: &chaincode.QueryResponseGenerator{MaxResultLimit: 100},
+		UUIDGenerator:        chaincode.UUIDGeneratorFunc(func() string { return "query-id" }),
+		QueryLimits:          &chaincode.QueryLimitsConfig{Default: limits},
+		Metrics:              chaincode.NewHandlerMetrics(&disabled.Provider{}),
+	}
+	chaincode.SetHandlerCCInstance(handler, &sysccprovider.ChaincodeInstance{ChaincodeName: "mycc"})
+	return handler
+}
+
+func TestQueryLimitsBytes(t *testing.T) {
+	result := &queryresult.KV{Namespace: "mycc", Key: "key", Value: []byte("value")}
+	iter := &mock.QueryResultsIterator{}
+	iter.NextReturns(result, nil)
+	txSimulator := &mock.TxSimulator{}
+	txSimulator.ExecuteQueryReturns(iter, nil)
+	txContext := &chaincode.TransactionContext{ChainID: "mychannel", TXSimulator: txSimulator}
+
+	maxBytes := 3*proto.Size(result) - 1
+	handler := newQueryLimitsTestHandler(chaincode.QueryLimits{MaxBytes: maxBytes})
+	payload, err := proto.Marshal(&pb.GetQueryResult{Query: `{"selector":{}}`})
+	require.NoError(t, err)
+	_, err = handler.HandleGetQueryResult(&pb.ChaincodeMessage{Payload: payload}, txContext)
+	assert.EqualError(t, err, fmt.Sprintf("GET_QUERY_RESULT query of chaincode mycc on channel mychannel exceeded the limit on the bytes [%d]", maxBytes))
+	assert.Equal(t, 3, iter.NextCallCount())
+	assert.Equal(t, 1, iter.CloseCallCount())
+	assert.Nil(t, txContext.GetQueryIterator("query-id"))
+}
+
+func TestQueryLimitsDuration(t *testing.T) {
+	iter := &mock.QueryResultsIterator{}
+	iter.NextStub = func() (commonledger.QueryResult, error) {
+		time.Sleep(10 * time.Millisecond)
+		return &queryresult.KeyModification{TxId: "txid"}, nil
+	}
+	historyQueryExecutor := &mock.HistoryQueryExecutor{}
+	historyQueryExecutor.GetHistoryForKeyReturns(iter, nil)
+	txContext := &chaincode.TransactionContext{ChainID: "mychannel", HistoryQueryExecutor: historyQueryExecutor}
+
+	handler := newQueryLimitsTestHandler(chaincode.QueryLimits{MaxDuration: time.Millisecond})
+	payload, err := proto.Marshal(&pb.GetHistoryForKey{Key: "key"})
+	require.NoError(t, err)
+	_, err = handler.HandleGetHistoryForKey(&pb.ChaincodeMessage{Payload: payload}, txContext)
+	assert.EqualError(t, err, "GET_HISTORY_FOR_KEY query of chaincode mycc on channel mychannel exceeded the limit on the duration [1ms]")
+	assert.Equal(t, 1, iter.NextCallCount())
+}
+
+func TestQueryLimitsDurationIncludesQueryExecution(t *testing.T) {
+	iter := &mock.QueryResultsIterator{}
+	iter.NextReturns(&queryresult.KV{Key: "key"}, nil)
+	txSimulator := &mock.TxSimulator{}
+	txSimulator.ExecuteQueryStub = func(string, string) (commonledger.ResultsIterator, error) {
+		time.Sleep(10 * time.Millisecond)
+		return iter, nil
+	}
+	txContext := &chaincode.TransactionContext{ChainID: "mychannel", TXSimulator: txSimulator}
+
+	handler := newQueryLimitsTestHandler(chaincode.QueryLimits{MaxDuration: time.Millisecond})
+	payload, err := proto.Marshal(&pb.GetQueryResult{Query: `{"selector":{}}`})
+	require.NoError(t, err)
+	_, err = handler.HandleGetQueryResult(&pb.ChaincodeMessage{Payload: payload}, txContext)
+	assert.EqualError(t, err, "GET_QUERY_RESULT query of chaincode mycc on channel mychannel exceeded the limit on the duration [1ms]")
+	assert.Equal(t, 1, iter.NextCallCount())
+	assert.Equal(t, 1, iter.CloseCallCount())
+}
+
+func TestQueryLimitsPagination(t *testing.T) {
+	iter := &mock.QueryResultsIterator{}
+	iter.NextReturnsOnCall(0, &queryresult.KV{Key: "key1"}, nil)
+	iter.NextReturnsOnCall(1, &queryresult.KV{Key: "key2"}, nil)
+	iter.GetBookmarkAndCloseReturns("key3")
+	txSimulator := &mock.TxSimulator{}
+	txSimulator.GetStateRangeScanIteratorWithMetadataReturns(iter, nil)
+	txContext := &chaincode.TransactionContext{ChainID: "mychannel", TXSimulator: txSimulator}
+
+	handler := newQueryLimitsTestHandler(chaincode.QueryLimits{MaxResults: 2})
+	metadata, err := proto.Marshal(&pb.QueryMetadata{PageSize: 2})
+	require.NoError(t, err)
+	payload, err := proto.Marshal(&pb.GetStateByRange{StartKey: "key1", Metadata: metadata})
+	require.NoError(t, err)
+	resp, err := handler.HandleGetStateByRange(&pb.ChaincodeMessage{Payload: payload}, txContext)
+	require.NoError(t, err)
+
+	queryResponse := &pb.QueryResponse{}
+	require.NoError(t, proto.Unmarshal(resp.Payload, queryResponse))
+	assert.Len(t, queryResponse.Results, 2)
+	responseMetadata := &pb.QueryResponseMetadata{}
+	require.NoError(t, proto.Unmarshal(queryResponse.Metadata, responseMetadata))
+	assert.Equal(t, "key3", responseMetadata.Bookmark)
+	assert.Equal(t, 1, iter.GetBookmarkAndCloseCallCount())
+}
@@ -7,10 +7,13 @@ SPDX-License-Identifier: Apache-2.0
 package chaincode
 
 import (
+	"context"
 	"strconv"
 	"time"
 
+	"github.com/hyperledger/fabric/core/chaincode/extcc"
 	"github.com/hyperledger/fabric/core/common/ccprovider"
+	"github.com/hyperledger/fabric/core/container/ccintf"
 	"github.com/hyperledger/fabric/core/container/inproccontroller"
 	"github.com/pkg/errors"
 )
@@ -24,15 +27,26 @@ type LaunchRegistry interface {
 // PackageProvider gets chaincode packages from the filesystem.
 type PackageProvider interface {
 	GetChaincodeCodePackage(ccname string, ccversion string) ([]byte, error)
+	GetChaincodeCodePackageByID(packageID string) ([]byte, error)
 }
 
-// RuntimeLauncher is responsible for launching chaincode runtimes.
+// ConnectionHandler connects to the chaincodes running as servers. The
+// stream to the chaincode is closed when the context is cancelled.
+type ConnectionHandler interface {
+	Stream(ctx context.Context, ccid string, ccinfo *ccintf.ChaincodeServerInfo, sHandler extcc.StreamHandler) error
+}
+
+// RuntimeLauncher is responsible for launching chaincode runtimes. The
+// chaincode whose package holds a connection descriptor is not started,
+// the peer connects to it instead.
 type RuntimeLauncher struct {
-	Runtime         Runtime
-	Registry        LaunchRegistry
-	PackageProvider PackageProvider
-	StartupTimeout  time.Duration
-	Metrics         *LaunchMetrics
+	Runtime           Runtime
+	Registry          LaunchRegistry
+	PackageProvider   PackageProvider
+	StartupTimeout    time.Duration
+	Metrics           *LaunchMetrics
+	ConnectionHandler ConnectionHandler
+	StreamHandler     extcc.StreamHandler
 }
 
 func (r *RuntimeLauncher) Launch(ccci *ccprovider.ChaincodeContainerInfo) error {
@@ -42,6 +56,8 @@ func (r *RuntimeLauncher) Launch(ccci *ccprovider.ChaincodeContainerInfo) error
 	startTime := time.Now()
 	cname := ccci.Name + ":" + ccci.Version
 	launchState, started := r.Registry.Launching(cname)
+	var ccinfo *ccintf.ChaincodeServerInfo
+	var cancelStream context.CancelFunc
 	if !started {
 		startFailCh = make(chan error, 1)
 		timeoutCh = time.NewTimer(r.StartupTimeout).C
@@ -51,11 +67,31 @@ func (r *RuntimeLauncher) Launch(ccci *ccprovider.ChaincodeContainerInfo) error
 			return err
 		}
 
-		go func() {
-			if err := r.Runtime.Start(ccci, codePackage); err != nil {
-				startFailCh <- errors.WithMessage(err, "error starting container")
+		if codePackage != nil {
+			ccinfo, err = extcc.ChaincodeServerInfo(codePackage)
+			if err != nil {
+				return errors.WithMessage(err, "could not get chaincode server info")
 			}
-		}()
+		}
+
+		if ccinfo != nil {
+			// the stream lives as long as the chaincode once it is launched,
+			// it is only closed here if the launch fails
+			streamCtx, cancel := context.WithCancel(context.Background())
+			cancelStream = cancel
+			go func() {
+				chaincodeLogger.Debugf("connecting to chaincode server for %s", cname)
+				if err := r.ConnectionHandler.Stream(streamCtx, cname, ccinfo, r.StreamHandler); err != nil {
+					startFailCh <- errors.WithMessage(err, "connection to chaincode server failed")
+				}
+			}()
+		} else {
+			go func() {
+				if err := r.Runtime.Start(ccci, codePackage); err != nil {
+					startFailCh <- errors.WithMessage(err, "error starting container")
+				}
+			}()
+		}
 	}
 
 	var err error
@@ -80,7 +116,10 @@ func (r *RuntimeLauncher) Launch(ccci *ccprovider.ChaincodeContainerInfo) error
 		success = false
 		chaincodeLogger.Debugf("stopping due to error while launching: %+v", err)
 		defer r.Registry.Deregister(cname)
-		if err := r.Runtime.Stop(ccci); err != nil {
+		if cancelStream != nil {
+			// the peer does not start the chaincode server, it only closes the stream
+			cancelStream()
+		} else if err := r.Runtime.Stop(ccci); err != nil {
 			chaincodeLogger.Debugf("stop failed: %+v", err)
 		}
 	}
@@ -99,7 +138,13 @@ func (r *RuntimeLauncher) getCodePackage(ccci *ccprovider.ChaincodeContainerInfo
 		return nil, nil
 	}
 
-	codePackage, err := r.PackageProvider.GetChaincodeCodePackage(ccci.Name, ccci.Version)
+	var codePackage []byte
+	var err error
+	if ccci.PackageID != "" {
+		codePackage, err = r.PackageProvider.GetChaincodeCodePackageByID(ccci.PackageID)
+	} else {
+		codePackage, err = r.PackageProvider.GetChaincodeCodePackage(ccci.Name, ccci.Version)
+	}
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to get chaincode package")
 	}
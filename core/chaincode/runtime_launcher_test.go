@@ -7,13 +7,19 @@ SPDX-License-Identifier: Apache-2.0
 package chaincode_test
 
 import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
 	"time"
 
 	"github.com/hyperledger/fabric/common/metrics/metricsfakes"
 	"github.com/hyperledger/fabric/core/chaincode"
+	"github.com/hyperledger/fabric/core/chaincode/extcc"
 	"github.com/hyperledger/fabric/core/chaincode/fake"
 	"github.com/hyperledger/fabric/core/chaincode/mock"
 	"github.com/hyperledger/fabric/core/common/ccprovider"
+	"github.com/hyperledger/fabric/core/container/ccintf"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 	"github.com/pkg/errors"
@@ -94,6 +100,43 @@ var _ = Describe("RuntimeLauncher", func() {
 		ccciArg, codePackage := fakeRuntime.StartArgsForCall(0)
 		Expect(ccciArg).To(Equal(ccci))
 		Expect(codePackage).To(Equal([]byte("code-package")))
+
+		Expect(fakePackageProvider.GetChaincodeCodePackageCallCount()).To(Equal(1))
+		name, version := fakePackageProvider.GetChaincodeCodePackageArgsForCall(0)
+		Expect(name).To(Equal("chaincode-name"))
+		Expect(version).To(Equal("chaincode-version"))
+	})
+
+	Context("when the chaincode is launched from an installed chaincode package", func() {
+		BeforeEach(func() {
+			ccci.PackageID = "label:hash"
+			fakePackageProvider.GetChaincodeCodePackageByIDReturns([]byte("installed-code-package"), nil)
+		})
+
+		It("starts the runtime with the code package of the installed chaincode package", func() {
+			err := runtimeLauncher.Launch(ccci)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(fakePackageProvider.GetChaincodeCodePackageByIDCallCount()).To(Equal(1))
+			Expect(fakePackageProvider.GetChaincodeCodePackageByIDArgsForCall(0)).To(Equal("label:hash"))
+			Expect(fakePackageProvider.GetChaincodeCodePackageCallCount()).To(Equal(0))
+
+			Expect(fakeRuntime.StartCallCount()).To(Equal(1))
+			_, codePackage := fakeRuntime.StartArgsForCall(0)
+			Expect(codePackage).To(Equal([]byte("installed-code-package")))
+		})
+
+		Context("when the installed chaincode package cannot be loaded", func() {
+			BeforeEach(func() {
+				fakePackageProvider.GetChaincodeCodePackageByIDReturns(nil, errors.New("tiddlywinks"))
+			})
+
+			It("returns a wrapped error", func() {
+				err := runtimeLauncher.Launch(ccci)
+				Expect(err).To(MatchError("failed to get chaincode package: tiddlywinks"))
+				Expect(fakeRuntime.StartCallCount()).To(Equal(0))
+			})
+		})
 	})
 
 	It("waits for the launch to complete", func() {
@@ -303,4 +346,113 @@ var _ = Describe("RuntimeLauncher", func() {
 			Expect(fakeRuntime.StopCallCount()).To(Equal(1))
 		})
 	})
+
+	Context("when the code package holds a connection descriptor", func() {
+		var fakeConnectionHandler *mock.ConnectionHandler
+
+		BeforeEach(func() {
+			fakeConnectionHandler = &mock.ConnectionHandler{}
+			fakeConnectionHandler.StreamStub = func(context.Context, string, *ccintf.ChaincodeServerInfo, extcc.StreamHandler) error {
+				launchState.Notify(nil)
+				return nil
+			}
+			runtimeLauncher.ConnectionHandler = fakeConnectionHandler
+			runtimeLauncher.StreamHandler = &chaincode.ChaincodeSupport{}
+
+			fakePackageProvider.GetChaincodeCodePackageReturns(connectionPackage(`{"address":"ccserver:9999","dial_timeout":"10s"}`), nil)
+		})
+
+		It("connects to the chaincode server instead of starting the runtime", func() {
+			err := runtimeLauncher.Launch(ccci)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(fakeRuntime.StartCallCount()).To(Equal(0))
+			Expect(fakeConnectionHandler.StreamCallCount()).To(Equal(1))
+			ctx, ccid, ccinfo, sHandler := fakeConnectionHandler.StreamArgsForCall(0)
+			Expect(ctx.Err()).NotTo(HaveOccurred())
+			Expect(ccid).To(Equal("chaincode-name:chaincode-version"))
+			Expect(ccinfo.Address).To(Equal("ccserver:9999"))
+			Expect(ccinfo.ClientConfig.Timeout).To(Equal(10 * time.Second))
+			Expect(ccinfo.ClientConfig.SecOpts.UseTLS).To(BeFalse())
+			Expect(sHandler).To(Equal(runtimeLauncher.StreamHandler))
+		})
+
+		Context("when the connection descriptor is invalid", func() {
+			BeforeEach(func() {
+				fakePackageProvider.GetChaincodeCodePackageReturns(connectionPackage(`{"dial_timeout":"10s"}`), nil)
+			})
+
+			It("returns an error", func() {
+				err := runtimeLauncher.Launch(ccci)
+				Expect(err).To(MatchError("could not get chaincode server info: chaincode address not provided in connection.json"))
+				Expect(fakeConnectionHandler.StreamCallCount()).To(Equal(0))
+			})
+		})
+
+		Context("when the connection to the chaincode server fails", func() {
+			BeforeEach(func() {
+				fakeConnectionHandler.StreamStub = nil
+				fakeConnectionHandler.StreamReturns(errors.New("kiwi"))
+			})
+
+			It("returns a wrapped error", func() {
+				err := runtimeLauncher.Launch(ccci)
+				Expect(err).To(MatchError("connection to chaincode server failed: kiwi"))
+			})
+
+			It("does not stop the runtime", func() {
+				runtimeLauncher.Launch(ccci)
+				Expect(fakeRuntime.StopCallCount()).To(Equal(0))
+			})
+
+			It("deregisters the chaincode", func() {
+				runtimeLauncher.Launch(ccci)
+
+				Expect(fakeRegistry.DeregisterCallCount()).To(Equal(1))
+				cname := fakeRegistry.DeregisterArgsForCall(0)
+				Expect(cname).To(Equal("chaincode-name:chaincode-version"))
+			})
+
+			It("cancels the stream", func() {
+				runtimeLauncher.Launch(ccci)
+
+				ctx, _, _, _ := fakeConnectionHandler.StreamArgsForCall(0)
+				Expect(ctx.Err()).To(Equal(context.Canceled))
+			})
+		})
+
+		Context("when the chaincode server does not register in time", func() {
+			var streamClosed chan struct{}
+
+			BeforeEach(func() {
+				streamClosed = make(chan struct{})
+				fakeConnectionHandler.StreamStub = func(ctx context.Context, _ string, _ *ccintf.ChaincodeServerInfo, _ extcc.StreamHandler) error {
+					defer close(streamClosed)
+					<-ctx.Done()
+					return ctx.Err()
+				}
+				runtimeLauncher.StartupTimeout = 250 * time.Millisecond
+			})
+
+			It("closes the stream", func() {
+				err := runtimeLauncher.Launch(ccci)
+				Expect(err).To(MatchError("timeout expired while starting chaincode chaincode-name:chaincode-version for transaction"))
+				Eventually(streamClosed).Should(BeClosed())
+				Expect(fakeRuntime.StopCallCount()).To(Equal(0))
+			})
+		})
+	})
 })
+
+func connectionPackage(connection string) []byte {
+	buf := &bytes.Buffer{}
+	gw := gzip.NewWriter(buf)
+	tw := tar.NewWriter(gw)
+	err := tw.WriteHeader(&tar.Header{Name: extcc.ConnectionFile, Typeflag: tar.TypeReg, Mode: 0644, Size: int64(len(connection))})
+	Expect(err).NotTo(HaveOccurred())
+	_, err = tw.Write([]byte(connection))
+	Expect(err).NotTo(HaveOccurred())
+	Expect(tw.Close()).To(Succeed())
+	Expect(gw.Close()).To(Succeed())
+	return buf.Bytes()
+}
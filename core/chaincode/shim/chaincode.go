@@ -9,7 +9,9 @@ SPDX-License-Identifier: Apache-2.0
 package shim
 
 import (
+	"bytes"
 	"context"
+	"crypto/sha256"
 	"flag"
 	"fmt"
 	"io"
@@ -513,6 +515,17 @@ func (stub *ChaincodeStub) DelState(key string) error {
 
 //  ---------  private state functions  ---------
 
+// ImplicitCollectionNamePrefix is the prefix of the reserved names of the implicit
+// collections that the peer provides for every organization of the channel
+const ImplicitCollectionNamePrefix = "_implicit_org_"
+
+// ImplicitCollectionNameForOrg returns the name of the implicit collection of the
+// organization with the given MSP ID. The implicit collection can be passed to the
+// private data functions without being defined in the collections config of the chaincode
+func ImplicitCollectionNameForOrg(mspID string) string {
+	return ImplicitCollectionNamePrefix + mspID
+}
+
 // GetPrivateData documentation can be found in interfaces.go
 func (stub *ChaincodeStub) GetPrivateData(collection string, key string) ([]byte, error) {
 	if collection == "" {
@@ -521,6 +534,24 @@ func (stub *ChaincodeStub) GetPrivateData(collection string, key string) ([]byte
 	return stub.handler.handleGetState(collection, key, stub.ChannelId, stub.TxID)
 }
 
+// GetPrivateDataHash documentation can be found in interfaces.go
+func (stub *ChaincodeStub) GetPrivateDataHash(collection string, key string) ([]byte, error) {
+	if collection == "" {
+		return nil, fmt.Errorf("collection must not be an empty string")
+	}
+	return stub.handler.handleGetPrivateDataHash(collection, key, stub.ChannelId, stub.TxID)
+}
+
+// VerifyPrivateDataHash documentation can be found in interfaces.go
+func (stub *ChaincodeStub) VerifyPrivateDataHash(collection string, key string, value []byte) (bool, error) {
+	committedHash, err := stub.GetPrivateDataHash(collection, key)
+	if err != nil || committedHash == nil {
+		return false, err
+	}
+	valueHash := sha256.Sum256(value)
+	return bytes.Equal(committedHash, valueHash[:]), nil
+}
+
 // PutPrivateData documentation can be found in interfaces.go
 func (stub *ChaincodeStub) PutPrivateData(collection string, key string, value []byte) error {
 	if collection == "" {
@@ -540,6 +571,14 @@ func (stub *ChaincodeStub) DelPrivateData(collection string, key string) error {
 	return stub.handler.handleDelState(collection, key, stub.ChannelId, stub.TxID)
 }
 
+// PurgePrivateData documentation can be found in interfaces.go
+func (stub *ChaincodeStub) PurgePrivateData(collection string, key string) error {
+	if collection == "" {
+		return fmt.Errorf("collection must not be an empty string")
+	}
+	return stub.handler.handlePurgePrivateData(collection, key, stub.ChannelId, stub.TxID)
+}
+
 // GetPrivateDataByRange documentation can be found in interfaces.go
 func (stub *ChaincodeStub) GetPrivateDataByRange(collection, startKey, endKey string) (StateQueryIteratorInterface, error) {
 	if collection == "" {
@@ -595,6 +634,19 @@ func (stub *ChaincodeStub) GetPrivateDataQueryResult(collection, query string) (
 	return iterator, err
 }
 
+// GetPrivateDataHistoryForKey documentation can be found in interfaces.go
+func (stub *ChaincodeStub) GetPrivateDataHistoryForKey(collection, key string) (PrivateDataHistoryQueryIteratorInterface, error) {
+	if collection == "" {
+		return nil, fmt.Errorf("collection must not be an empty string")
+	}
+	request := &pb.GetHistoryForKey{Key: key, Collection: collection}
+	response, err := stub.handler.handleGetHistoryForKey(request, stub.ChannelId, stub.TxID)
+	if err != nil {
+		return nil, err
+	}
+	return &PrivateDataHistoryQueryIterator{CommonIterator: &CommonIterator{stub.handler, stub.ChannelId, stub.TxID, response, 0}}, nil
+}
+
 // GetPrivateDataValidationParameter documentation can be found in interfaces.go
 func (stub *ChaincodeStub) GetPrivateDataValidationParameter(collection, key string) ([]byte, error) {
 	md, err := stub.handler.handleGetStateMetadata(collection, key, stub.ChannelId, stub.TxID)
@@ -631,11 +683,17 @@ type HistoryQueryIterator struct {
 	*CommonIterator
 }
 
+// PrivateDataHistoryQueryIterator documentation can be found in interfaces.go
+type PrivateDataHistoryQueryIterator struct {
+	*CommonIterator
+}
+
 type resultType uint8
 
 const (
 	STATE_QUERY_RESULT resultType = iota + 1
 	HISTORY_QUERY_RESULT
+	PRIVATE_DATA_HISTORY_QUERY_RESULT
 )
 
 func createQueryResponseMetadata(metadataBytes []byte) (*pb.QueryResponseMetadata, error) {
@@ -700,13 +758,81 @@ func (stub *ChaincodeStub) GetStateByRange(startKey, endKey string) (StateQueryI
 
 // GetHistoryForKey documentation can be found in interfaces.go
 func (stub *ChaincodeStub) GetHistoryForKey(key string) (HistoryQueryIteratorInterface, error) {
-	response, err := stub.handler.handleGetHistoryForKey(key, stub.ChannelId, stub.TxID)
+	request := &pb.GetHistoryForKey{Key: key}
+	response, err := stub.handler.handleGetHistoryForKey(request, stub.ChannelId, stub.TxID)
 	if err != nil {
 		return nil, err
 	}
 	return &HistoryQueryIterator{CommonIterator: &CommonIterator{stub.handler, stub.ChannelId, stub.TxID, response, 0}}, nil
 }
 
+// GetHistoryForKeyByBlockRange documentation can be found in interfaces.go
+func (stub *ChaincodeStub) GetHistoryForKeyByBlockRange(key string, startBlock, endBlock uint64,
+	descending bool) (HistoryQueryIteratorInterface, error) {
+
+	if err := validateHistoryBlockRange(startBlock, endBlock); err != nil {
+		return nil, err
+	}
+	request := &pb.GetHistoryForKey{Key: key, StartBlock: startBlock, EndBlock: endBlock, Descending: descending}
+	response, err := stub.handler.handleGetHistoryForKey(request, stub.ChannelId, stub.TxID)
+	if err != nil {
+		return nil, err
+	}
+	return &HistoryQueryIterator{CommonIterator: &CommonIterator{stub.handler, stub.ChannelId, stub.TxID, response, 0}}, nil
+}
+
+// GetHistoryForKeyByBlockRangeWithPagination documentation can be found in interfaces.go
+func (stub *ChaincodeStub) GetHistoryForKeyByBlockRangeWithPagination(key string, startBlock, endBlock uint64,
+	descending bool, pageSize int32, bookmark string) (HistoryQueryIteratorInterface, *pb.QueryResponseMetadata, error) {
+
+	if err := validateHistoryBlockRange(startBlock, endBlock); err != nil {
+		return nil, nil, err
+	}
+	metadata, err := createQueryMetadata(pageSize, bookmark)
+	if err != nil {
+		return nil, nil, err
+	}
+	request := &pb.GetHistoryForKey{Key: key, StartBlock: startBlock, EndBlock: endBlock, Descending: descending, Metadata: metadata}
+	response, err := stub.handler.handleGetHistoryForKey(request, stub.ChannelId, stub.TxID)
+	if err != nil {
+		return nil, nil, err
+	}
+	responseMetadata, err := createQueryResponseMetadata(response.Metadata)
+	if err != nil {
+		return nil, nil, err
+	}
+	iterator := &HistoryQueryIterator{CommonIterator: &CommonIterator{stub.handler, stub.ChannelId, stub.TxID, response, 0}}
+	return iterator, responseMetadata, nil
+}
+
+// validateHistoryBlockRange checks that a non-zero endBlock is not below the startBlock
+func validateHistoryBlockRange(startBlock, endBlock uint64) error {
+	if endBlock != 0 && endBlock < startBlock {
+		return errors.Errorf("end block [%d] is less than the start block [%d]", endBlock, startBlock)
+	}
+	return nil
+}
+
+// GetStateAtHeight documentation can be found in interfaces.go
+func (stub *ChaincodeStub) GetStateAtHeight(key string, blockNum uint64) ([]byte, error) {
+	return stub.handler.handleGetStateAtHeight(key, blockNum, stub.ChannelId, stub.TxID)
+}
+
+// GetStateByRangeAtHeight documentation can be found in interfaces.go
+func (stub *ChaincodeStub) GetStateByRangeAtHeight(startKey, endKey string, blockNum uint64) (StateQueryIteratorInterface, error) {
+	if startKey == "" {
+		startKey = emptyKeySubstitute
+	}
+	if err := validateSimpleKeys(startKey, endKey); err != nil {
+		return nil, err
+	}
+	response, err := stub.handler.handleGetStateByRangeAtHeight(startKey, endKey, blockNum, stub.ChannelId, stub.TxID)
+	if err != nil {
+		return nil, err
+	}
+	return stub.createStateQueryIterator(response), nil
+}
+
 //CreateCompositeKey documentation can be found in interfaces.go
 func (stub *ChaincodeStub) CreateCompositeKey(objectType string, attributes []string) (string, error) {
 	return createCompositeKey(objectType, attributes)
@@ -862,6 +988,14 @@ func (iter *HistoryQueryIterator) Next() (*queryresult.KeyModification, error) {
 	}
 }
 
+func (iter *PrivateDataHistoryQueryIterator) Next() (*queryresult.PrivateDataKeyModification, error) {
+	result, err := iter.nextResult(PRIVATE_DATA_HISTORY_QUERY_RESULT)
+	if err != nil {
+		return nil, err
+	}
+	return result.(*queryresult.PrivateDataKeyModification), nil
+}
+
 // HasNext documentation can be found in interfaces.go
 func (iter *CommonIterator) HasNext() bool {
 	if iter.currentLoc < len(iter.response.Results) || iter.response.HasMore {
@@ -890,6 +1024,13 @@ func (iter *CommonIterator) getResultFromBytes(queryResultBytes *pb.QueryResultB
 			return nil, err
 		}
 		return historyQueryResult, nil
+
+	} else if rType == PRIVATE_DATA_HISTORY_QUERY_RESULT {
+		pvtDataHistoryQueryResult := &queryresult.PrivateDataKeyModification{}
+		if err := proto.Unmarshal(queryResultBytes.ResultBytes, pvtDataHistoryQueryResult); err != nil {
+			return nil, errors.Wrap(err, "error unmarshaling result from bytes")
+		}
+		return pvtDataHistoryQueryResult, nil
 	}
 	return nil, errors.New("wrong result type")
 }
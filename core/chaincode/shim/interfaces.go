@@ -232,6 +232,48 @@ type ChaincodeStubInterface interface {
 	// update ledger, and should limit use to read-only chaincode operations.
 	GetHistoryForKey(key string) (HistoryQueryIteratorInterface, error)
 
+	// GetHistoryForKeyByBlockRange returns a history of key values that were
+	// committed between the startBlock and the endBlock (both inclusive).
+	// An endBlock of zero refers to the last committed block. When descending
+	// is true, the history is returned newest first instead of in the commit
+	// order. Like GetHistoryForKey, this call requires the history database
+	// and should be limited to read-only chaincode operations.
+	GetHistoryForKeyByBlockRange(key string, startBlock, endBlock uint64,
+		descending bool) (HistoryQueryIteratorInterface, error)
+
+	// GetHistoryForKeyByBlockRangeWithPagination returns a history of key values
+	// in the same manner as GetHistoryForKeyByBlockRange, limited to `pageSize`
+	// history records. When an empty string is passed as a value to the bookmark
+	// argument, the returned iterator can be used to fetch the first `pageSize`
+	// history records. When the bookmark is a non-empty string, the iterator can
+	// be used to fetch the next `pageSize` history records starting from the
+	// bookmark. Note that only the bookmark present in a prior page of history
+	// records (ResponseMetadata) can be used as a value to the bookmark argument,
+	// along with the same block range and ordering. Otherwise, an empty string
+	// must be passed as bookmark.
+	// This call is only supported in a read only transaction.
+	GetHistoryForKeyByBlockRangeWithPagination(key string, startBlock, endBlock uint64,
+		descending bool, pageSize int32, bookmark string) (HistoryQueryIteratorInterface, *pb.QueryResponseMetadata, error)
+
+	// GetStateAtHeight returns the value of the specified `key` as it was
+	// after the block with the specified `blockNum` was committed to the
+	// ledger. If the key did not exist (or was deleted) at that height, a nil
+	// value is returned. Like GetHistoryForKey, GetStateAtHeight requires
+	// peer configuration core.ledger.history.enableHistoryDatabase to be true,
+	// and the query is NOT re-executed during validation phase. Applications
+	// should therefore limit use to read-only chaincode operations.
+	GetStateAtHeight(key string, blockNum uint64) ([]byte, error)
+
+	// GetStateByRangeAtHeight returns a range iterator over a set of keys in the
+	// ledger as they were after the block with the specified `blockNum` was
+	// committed. The iterator can be used to iterate over all keys between the
+	// startKey (inclusive) and endKey (exclusive) that existed at that height.
+	// An empty endKey refers to the last key of the chaincode. Call Close() on
+	// the returned StateQueryIteratorInterface object when done. Similar to
+	// GetStateAtHeight, this call requires the history database and is intended
+	// for read-only chaincode operations.
+	GetStateByRangeAtHeight(startKey, endKey string, blockNum uint64) (StateQueryIteratorInterface, error)
+
 	// GetPrivateData returns the value of the specified `key` from the specified
 	// `collection`. Note that GetPrivateData doesn't read data from the
 	// private writeset, which has not been committed to the `collection`. In
@@ -239,6 +281,20 @@ type ChaincodeStubInterface interface {
 	// that has not been committed.
 	GetPrivateData(collection, key string) ([]byte, error)
 
+	// GetPrivateDataHash returns the hash of the value of the specified `key` from
+	// the specified `collection`. The hashes of the private data are maintained by
+	// all the peers of the channel and hence, this function can be used even if the
+	// organization of the peer is not a member of the `collection`. A nil hash is
+	// returned if the `key` does not exist in the `collection`.
+	GetPrivateDataHash(collection, key string) ([]byte, error)
+
+	// VerifyPrivateDataHash returns true if the SHA256 hash of the specified `value`
+	// matches the committed hash of the value of the specified `key` in the specified
+	// `collection`. It allows a chaincode executing on a peer that is not a member
+	// of the `collection` to verify a value that is received out of band. False is
+	// returned if the `key` does not exist in the `collection`.
+	VerifyPrivateDataHash(collection, key string, value []byte) (bool, error)
+
 	// PutPrivateData puts the specified `key` and `value` into the transaction's
 	// private writeset. Note that only hash of the private writeset goes into the
 	// transaction proposal response (which is sent to the client who issued the
@@ -258,6 +314,14 @@ type ChaincodeStubInterface interface {
 	// when the transaction is validated and successfully committed.
 	DelPrivateData(collection, key string) error
 
+	// PurgePrivateData records the specified `key` to be purged in the private
+	// writeset of the transaction. Like DelPrivateData, the `key` is deleted
+	// from the collection when the transaction is validated and successfully
+	// committed. In addition, the current and historical values of the `key`
+	// are removed from the private data stores of every peer that is a member
+	// of the collection, leaving only their hashes behind.
+	PurgePrivateData(collection, key string) error
+
 	// SetPrivateDataValidationParameter sets the key-level endorsement policy
 	// for the private data specified by `key`.
 	SetPrivateDataValidationParameter(collection, key string, ep []byte) error
@@ -303,6 +367,17 @@ type ChaincodeStubInterface interface {
 	// ledger, and should limit use to read-only chaincode operations.
 	GetPrivateDataQueryResult(collection, query string) (StateQueryIteratorInterface, error)
 
+	// GetPrivateDataHistoryForKey returns a history of the modifications of the
+	// specified `key` in the specified `collection`. For each modification, the
+	// block and transaction numbers, transaction id, timestamp and the hash of the
+	// value are returned. The value itself is returned only if the private data is
+	// still available on the peer, i.e., it has been neither purged nor missed.
+	// Like GetHistoryForKey, GetPrivateDataHistoryForKey requires peer configuration
+	// core.ledger.history.enableHistoryDatabase to be true, and the query is NOT
+	// re-executed during validation phase. Applications should therefore limit
+	// use to read-only chaincode operations.
+	GetPrivateDataHistoryForKey(collection, key string) (PrivateDataHistoryQueryIteratorInterface, error)
+
 	// GetCreator returns `SignatureHeader.Creator` (e.g. an identity)
 	// of the `SignedProposal`. This is the identity of the agent (or user)
 	// submitting the transaction.
@@ -375,6 +450,16 @@ type HistoryQueryIteratorInterface interface {
 	Next() (*queryresult.KeyModification, error)
 }
 
+// PrivateDataHistoryQueryIteratorInterface allows a chaincode to iterate over
+// the modifications returned by a private data history query.
+type PrivateDataHistoryQueryIteratorInterface interface {
+	// Inherit HasNext() and Close()
+	CommonIteratorInterface
+
+	// Next returns the next modification in the private data history query iterator.
+	Next() (*queryresult.PrivateDataKeyModification, error)
+}
+
 // MockQueryIteratorInterface allows a chaincode to iterate over a set of
 // key/value pairs returned by range query.
 // TODO: Once the execute query and history query are implemented in MockStub,
@@ -9,7 +9,9 @@ SPDX-License-Identifier: Apache-2.0
 package shim
 
 import (
+	"bytes"
 	"container/list"
+	"crypto/sha256"
 	"fmt"
 	"strings"
 
@@ -165,6 +167,28 @@ func (stub *MockStub) GetPrivateData(collection string, key string) ([]byte, err
 	return m[key], nil
 }
 
+// GetPrivateDataHash returns the SHA256 hash of the value of the key in the
+// collection, or nil if the key does not exist in the collection.
+func (stub *MockStub) GetPrivateDataHash(collection, key string) ([]byte, error) {
+	value, err := stub.GetPrivateData(collection, key)
+	if err != nil || value == nil {
+		return nil, err
+	}
+	hash := sha256.Sum256(value)
+	return hash[:], nil
+}
+
+// VerifyPrivateDataHash verifies the value against the hash of the value of
+// the key in the collection.
+func (stub *MockStub) VerifyPrivateDataHash(collection, key string, value []byte) (bool, error) {
+	committedHash, err := stub.GetPrivateDataHash(collection, key)
+	if err != nil || committedHash == nil {
+		return false, err
+	}
+	hash := sha256.Sum256(value)
+	return bytes.Equal(committedHash, hash[:]), nil
+}
+
 func (stub *MockStub) PutPrivateData(collection string, key string, value []byte) error {
 	m, in := stub.PvtState[collection]
 	if !in {
@@ -181,6 +205,16 @@ func (stub *MockStub) DelPrivateData(collection string, key string) error {
 	return errors.New("Not Implemented")
 }
 
+// PurgePrivateData removes the specified `key` and its value from the
+// collection. The mock stub does not maintain history, so there is nothing
+// else to purge.
+func (stub *MockStub) PurgePrivateData(collection string, key string) error {
+	if m, in := stub.PvtState[collection]; in {
+		delete(m, key)
+	}
+	return nil
+}
+
 func (stub *MockStub) GetPrivateDataByRange(collection, startKey, endKey string) (StateQueryIteratorInterface, error) {
 	return nil, errors.New("Not Implemented")
 }
@@ -196,6 +230,11 @@ func (stub *MockStub) GetPrivateDataQueryResult(collection, query string) (State
 	return nil, errors.New("Not Implemented")
 }
 
+func (stub *MockStub) GetPrivateDataHistoryForKey(collection, key string) (PrivateDataHistoryQueryIteratorInterface, error) {
+	// Not implemented since the mock stub does not maintain the history
+	return nil, errors.New("Not Implemented")
+}
+
 // GetState retrieves the value for a given key from the ledger
 func (stub *MockStub) GetState(key string) ([]byte, error) {
 	value := stub.State[key]
@@ -292,6 +331,32 @@ func (stub *MockStub) GetHistoryForKey(key string) (HistoryQueryIteratorInterfac
 	return nil, errors.New("not implemented")
 }
 
+// GetHistoryForKeyByBlockRange function can be invoked by a chaincode to return a history
+// of key values within a block range. The mock stub does not maintain the history.
+func (stub *MockStub) GetHistoryForKeyByBlockRange(key string, startBlock, endBlock uint64,
+	descending bool) (HistoryQueryIteratorInterface, error) {
+	return nil, errors.New("not implemented")
+}
+
+// GetHistoryForKeyByBlockRangeWithPagination function can be invoked by a chaincode to return
+// a page of the history of key values within a block range. The mock stub does not maintain the history.
+func (stub *MockStub) GetHistoryForKeyByBlockRangeWithPagination(key string, startBlock, endBlock uint64,
+	descending bool, pageSize int32, bookmark string) (HistoryQueryIteratorInterface, *pb.QueryResponseMetadata, error) {
+	return nil, nil, errors.New("not implemented")
+}
+
+// GetStateAtHeight function can be invoked by a chaincode to return the value of
+// a key as of a given block height. The mock stub does not maintain the history.
+func (stub *MockStub) GetStateAtHeight(key string, blockNum uint64) ([]byte, error) {
+	return nil, errors.New("not implemented")
+}
+
+// GetStateByRangeAtHeight function can be invoked by a chaincode to query the state
+// of a range of keys as of a given block height. The mock stub does not maintain the history.
+func (stub *MockStub) GetStateByRangeAtHeight(startKey, endKey string, blockNum uint64) (StateQueryIteratorInterface, error) {
+	return nil, errors.New("not implemented")
+}
+
 //GetStateByPartialCompositeKey function can be invoked by a chaincode to query the
 //state based on a given partial composite key. This function returns an
 //iterator which can be used to iterate over all composite keys whose prefix
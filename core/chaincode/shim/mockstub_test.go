@@ -7,6 +7,7 @@ SPDX-License-Identifier: Apache-2.0
 package shim
 
 import (
+	"crypto/sha256"
 	"encoding/json"
 	"fmt"
 	"reflect"
@@ -284,6 +285,63 @@ func TestPutEmptyState(t *testing.T) {
 
 }
 
+// TestVerifyPrivateDataHash confirms that a value is verified against the hash of the private data in the mock state
+func TestVerifyPrivateDataHash(t *testing.T) {
+	stub := NewMockStub("verifypvt", nil)
+	stub.MockTransactionStart("1")
+	err := stub.PutPrivateData("coll1", "key1", []byte("value1"))
+	assert.NoError(t, err)
+	stub.MockTransactionEnd("1")
+
+	hash, err := stub.GetPrivateDataHash("coll1", "key1")
+	assert.NoError(t, err)
+	expectedHash := sha256.Sum256([]byte("value1"))
+	assert.Equal(t, expectedHash[:], hash)
+
+	verified, err := stub.VerifyPrivateDataHash("coll1", "key1", []byte("value1"))
+	assert.NoError(t, err)
+	assert.True(t, verified)
+	verified, err = stub.VerifyPrivateDataHash("coll1", "key1", []byte("value2"))
+	assert.NoError(t, err)
+	assert.False(t, verified)
+	verified, err = stub.VerifyPrivateDataHash("coll1", "key2", nil)
+	assert.NoError(t, err)
+	assert.False(t, verified)
+}
+
+// TestPurgePrivateData confirms that a purged key is removed from the private data in the mock state
+func TestPurgePrivateData(t *testing.T) {
+	stub := NewMockStub("purgepvt", nil)
+	stub.MockTransactionStart("1")
+	assert.NoError(t, stub.PutPrivateData("coll1", "key1", []byte("value1")))
+	assert.NoError(t, stub.PutPrivateData("coll1", "key2", []byte("value2")))
+	assert.NoError(t, stub.PurgePrivateData("coll1", "key1"))
+	assert.NoError(t, stub.PurgePrivateData("coll2", "key1"))
+	stub.MockTransactionEnd("1")
+
+	value, err := stub.GetPrivateData("coll1", "key1")
+	assert.NoError(t, err)
+	assert.Nil(t, value)
+	value, err = stub.GetPrivateData("coll1", "key2")
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("value2"), value)
+}
+
+func TestImplicitCollectionNameForOrg(t *testing.T) {
+	collection := ImplicitCollectionNameForOrg("Org1MSP")
+	assert.Equal(t, "_implicit_org_Org1MSP", collection)
+
+	stub := NewMockStub("implicitcoll", nil)
+	stub.MockTransactionStart("1")
+	err := stub.PutPrivateData(collection, "key1", []byte("value1"))
+	assert.NoError(t, err)
+	stub.MockTransactionEnd("1")
+
+	value, err := stub.GetPrivateData(collection, "key1")
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("value1"), value)
+}
+
 //TestMockMock clearly cheating for coverage... but not. Mock should
 //be tucked away under common/mocks package which is not
 //included for coverage. Moving mockstub to another package
@@ -314,6 +372,11 @@ func TestMockMock(t *testing.T) {
 	stub.GetArgsSlice()
 	stub.SetEvent("e", nil)
 	stub.GetHistoryForKey("k")
+	stub.GetHistoryForKeyByBlockRange("k", 1, 2, true)
+	stub.GetHistoryForKeyByBlockRangeWithPagination("k", 1, 2, true, 10, "")
+	stub.GetPrivateDataHistoryForKey("c", "k")
+	stub.GetStateAtHeight("k", 1)
+	stub.GetStateByRangeAtHeight("start", "end", 1)
 	iter := &MockStateRangeQueryIterator{}
 	iter.HasNext()
 	iter.Close()
@@ -0,0 +1,105 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package shim
+
+import (
+	"github.com/hyperledger/fabric/bccsp/factory"
+	"github.com/hyperledger/fabric/core/comm"
+	pb "github.com/hyperledger/fabric/protos/peer"
+	"github.com/pkg/errors"
+)
+
+// TLSProperties are the TLS settings of a ChaincodeServer
+type TLSProperties struct {
+	// Disabled turns TLS off; TLS is enabled by default
+	Disabled bool
+	// Key is the PEM encoded private key of the server
+	Key []byte
+	// Cert is the PEM encoded certificate of the server
+	Cert []byte
+	// ClientCACerts is the PEM encoded certificates of the CAs of the peers.
+	// When set, the peers connecting to the server must authenticate with a
+	// client certificate issued by one of these CAs
+	ClientCACerts []byte
+}
+
+// ChaincodeServer runs the chaincode as a gRPC server which the peer connects to,
+// rather than having the chaincode connect to the peer.
+type ChaincodeServer struct {
+	// CCID is the ID the chaincode registers with. It must match the name and
+	// version of the chaincode on the peer, in the form name:version
+	CCID string
+	// Address is the address the server listens on
+	Address string
+	// CC is the chaincode whose Init and Invoke handle the transactions
+	CC Chaincode
+	// TLSProps are the TLS settings of the server
+	TLSProps TLSProperties
+}
+
+// serverStream adapts the stream of a connection of the peer to the
+// PeerChaincodeStream of the chaincode handler
+type serverStream struct {
+	pb.Chaincode_ConnectServer
+}
+
+// CloseSend is a no-op as the stream is closed when Connect returns
+func (s *serverStream) CloseSend() error {
+	return nil
+}
+
+// Connect handles the stream of a peer connecting to the chaincode server.
+// The chaincode registers with the peer and then handles its messages, as
+// it does when it connects to the peer.
+func (cs *ChaincodeServer) Connect(stream pb.Chaincode_ConnectServer) error {
+	return chatWithPeer(cs.CCID, &serverStream{stream}, cs.CC)
+}
+
+// Start serves the chaincode until the server fails.
+func (cs *ChaincodeServer) Start() error {
+	if cs.CCID == "" {
+		return errors.New("ccid must be specified")
+	}
+	if cs.Address == "" {
+		return errors.New("address must be specified")
+	}
+	if cs.CC == nil {
+		return errors.New("chaincode must be specified")
+	}
+
+	// The chaincode server is a standalone chaincode, as the ones calling Start
+	SetupChaincodeLogging()
+
+	err := factory.InitFactories(factory.GetDefaultOpts())
+	if err != nil {
+		return errors.WithMessage(err, "internal error, BCCSP could not be initialized with default options")
+	}
+
+	secOpts := &comm.SecureOptions{UseTLS: !cs.TLSProps.Disabled}
+	if secOpts.UseTLS {
+		if cs.TLSProps.Key == nil || cs.TLSProps.Cert == nil {
+			return errors.New("key and cert must be specified when TLS is enabled")
+		}
+		secOpts.Key = cs.TLSProps.Key
+		secOpts.Certificate = cs.TLSProps.Cert
+		if cs.TLSProps.ClientCACerts != nil {
+			secOpts.RequireClientCert = true
+			secOpts.ClientRootCAs = [][]byte{cs.TLSProps.ClientCACerts}
+		}
+	}
+
+	server, err := comm.NewGRPCServer(cs.Address, comm.ServerConfig{
+		SecOpts: secOpts,
+		KaOpts:  comm.DefaultKeepaliveOptions,
+	})
+	if err != nil {
+		return errors.WithMessage(err, "failed to create chaincode server")
+	}
+	pb.RegisterChaincodeServer(server.Server(), cs)
+
+	return server.Start()
+}
@@ -0,0 +1,67 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package shim
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/core/comm"
+	pb "github.com/hyperledger/fabric/protos/peer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestChaincodeServerValidation(t *testing.T) {
+	tests := []struct {
+		server *ChaincodeServer
+		errMsg string
+	}{
+		{&ChaincodeServer{Address: "127.0.0.1:0", CC: &shimTestCC{}}, "ccid must be specified"},
+		{&ChaincodeServer{CCID: "mycc:1.0", CC: &shimTestCC{}}, "address must be specified"},
+		{&ChaincodeServer{CCID: "mycc:1.0", Address: "127.0.0.1:0"}, "chaincode must be specified"},
+		{&ChaincodeServer{CCID: "mycc:1.0", Address: "127.0.0.1:0", CC: &shimTestCC{}}, "key and cert must be specified when TLS is enabled"},
+		{&ChaincodeServer{CCID: "mycc:1.0", Address: "bad-address", CC: &shimTestCC{}, TLSProps: TLSProperties{Disabled: true}}, "failed to create chaincode server: listen tcp: address bad-address: missing port in address"},
+	}
+	for _, tt := range tests {
+		assert.EqualError(t, tt.server.Start(), tt.errMsg)
+	}
+}
+
+func TestChaincodeServerRegisters(t *testing.T) {
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	address := lis.Addr().String()
+	lis.Close()
+
+	server := &ChaincodeServer{
+		CCID:     "mycc:1.0",
+		Address:  address,
+		CC:       &shimTestCC{},
+		TLSProps: TLSProperties{Disabled: true},
+	}
+	go server.Start()
+
+	client, err := comm.NewGRPCClient(comm.ClientConfig{Timeout: 3 * time.Second})
+	require.NoError(t, err)
+	conn, err := client.NewConnection(address, "")
+	require.NoError(t, err)
+	defer conn.Close()
+
+	stream, err := pb.NewChaincodeClient(conn).Connect(context.Background())
+	require.NoError(t, err)
+	msg, err := stream.Recv()
+	require.NoError(t, err)
+
+	assert.Equal(t, pb.ChaincodeMessage_REGISTER, msg.Type)
+	ccid := &pb.ChaincodeID{}
+	require.NoError(t, proto.Unmarshal(msg.Payload, ccid))
+	assert.Equal(t, "mycc:1.0", ccid.Name)
+}
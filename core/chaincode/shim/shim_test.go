@@ -8,6 +8,7 @@ package shim
 
 import (
 	"bytes"
+	"crypto/sha256"
 	"os"
 	"strconv"
 	"strings"
@@ -81,6 +82,18 @@ func (t *shimTestCC) Invoke(stub ChaincodeStubInterface) pb.Response {
 		return t.rangeq(stub, args)
 	} else if function == "historyq" {
 		return t.historyq(stub, args)
+	} else if function == "pvthistoryq" {
+		return t.pvtHistoryq(stub, args)
+	} else if function == "verifypvt" {
+		return t.verifyPvt(stub, args)
+	} else if function == "purgepvt" {
+		return t.purgePvt(stub, args)
+	} else if function == "historyqpage" {
+		return t.historyqPage(stub, args)
+	} else if function == "queryatheight" {
+		return t.queryAtHeight(stub, args)
+	} else if function == "rangeqatheight" {
+		return t.rangeqAtHeight(stub, args)
 	} else if function == "richq" {
 		return t.richq(stub, args)
 	} else if function == "putep" {
@@ -245,6 +258,48 @@ func (t *shimTestCC) rangeq(stub ChaincodeStubInterface, args []string) pb.Respo
 	return Success(buffer.Bytes())
 }
 
+// queryAtHeight calls GetStateAtHeight
+func (t *shimTestCC) queryAtHeight(stub ChaincodeStubInterface, args []string) pb.Response {
+	if len(args) != 2 {
+		return Error("Incorrect number of arguments. Expecting key and block number")
+	}
+	blockNum, err := strconv.ParseUint(args[1], 10, 64)
+	if err != nil {
+		return Error(err.Error())
+	}
+	value, err := stub.GetStateAtHeight(args[0], blockNum)
+	if err != nil {
+		return Error(err.Error())
+	}
+	return Success(value)
+}
+
+// rangeqAtHeight calls GetStateByRangeAtHeight
+func (t *shimTestCC) rangeqAtHeight(stub ChaincodeStubInterface, args []string) pb.Response {
+	if len(args) != 3 {
+		return Error("Incorrect number of arguments. Expecting start key, end key and block number")
+	}
+	blockNum, err := strconv.ParseUint(args[2], 10, 64)
+	if err != nil {
+		return Error(err.Error())
+	}
+	resultsIterator, err := stub.GetStateByRangeAtHeight(args[0], args[1], blockNum)
+	if err != nil {
+		return Error(err.Error())
+	}
+	defer resultsIterator.Close()
+
+	var keys []string
+	for resultsIterator.HasNext() {
+		queryResponse, err := resultsIterator.Next()
+		if err != nil {
+			return Error(err.Error())
+		}
+		keys = append(keys, queryResponse.Key)
+	}
+	return Success([]byte(strings.Join(keys, ",")))
+}
+
 // richq calls tichq query
 func (t *shimTestCC) richq(stub ChaincodeStubInterface, args []string) pb.Response {
 	if len(args) != 1 {
@@ -342,6 +397,90 @@ func (t *shimTestCC) historyq(stub ChaincodeStubInterface, args []string) pb.Res
 	return Success(buffer.Bytes())
 }
 
+// pvtHistoryq calls GetPrivateDataHistoryForKey and returns the block and tx numbers of the modifications
+func (t *shimTestCC) pvtHistoryq(stub ChaincodeStubInterface, args []string) pb.Response {
+	if len(args) != 2 {
+		return Error("Incorrect number of arguments. Expecting collection and key")
+	}
+	resultsIterator, err := stub.GetPrivateDataHistoryForKey(args[0], args[1])
+	if err != nil {
+		return Error(err.Error())
+	}
+	defer resultsIterator.Close()
+
+	var buffer bytes.Buffer
+	for resultsIterator.HasNext() {
+		response, err := resultsIterator.Next()
+		if err != nil {
+			return Error(err.Error())
+		}
+		buffer.WriteString(strconv.FormatUint(response.BlockNum, 10) + ":" + strconv.FormatUint(response.TxNum, 10) + " ")
+	}
+	return Success(buffer.Bytes())
+}
+
+// verifyPvt calls VerifyPrivateDataHash and returns an error if the value does not match the committed hash
+func (t *shimTestCC) verifyPvt(stub ChaincodeStubInterface, args []string) pb.Response {
+	if len(args) != 3 {
+		return Error("Incorrect number of arguments. Expecting collection, key and value")
+	}
+	verified, err := stub.VerifyPrivateDataHash(args[0], args[1], []byte(args[2]))
+	if err != nil {
+		return Error(err.Error())
+	}
+	if !verified {
+		return Error("value does not match the committed hash")
+	}
+	return Success(nil)
+}
+
+// purgePvt calls PurgePrivateData for the given collection and key
+func (t *shimTestCC) purgePvt(stub ChaincodeStubInterface, args []string) pb.Response {
+	if len(args) != 2 {
+		return Error("Incorrect number of arguments. Expecting collection and key")
+	}
+	if err := stub.PurgePrivateData(args[0], args[1]); err != nil {
+		return Error(err.Error())
+	}
+	return Success(nil)
+}
+
+// historyqPage calls GetHistoryForKeyByBlockRangeWithPagination and returns the bookmark
+func (t *shimTestCC) historyqPage(stub ChaincodeStubInterface, args []string) pb.Response {
+	if len(args) != 6 {
+		return Error("Incorrect number of arguments. Expecting key, start block, end block, descending, page size and bookmark")
+	}
+	startBlock, err := strconv.ParseUint(args[1], 10, 64)
+	if err != nil {
+		return Error(err.Error())
+	}
+	endBlock, err := strconv.ParseUint(args[2], 10, 64)
+	if err != nil {
+		return Error(err.Error())
+	}
+	descending, err := strconv.ParseBool(args[3])
+	if err != nil {
+		return Error(err.Error())
+	}
+	pageSize, err := strconv.ParseInt(args[4], 10, 32)
+	if err != nil {
+		return Error(err.Error())
+	}
+	resultsIterator, metadata, err := stub.GetHistoryForKeyByBlockRangeWithPagination(args[0], startBlock, endBlock,
+		descending, int32(pageSize), args[5])
+	if err != nil {
+		return Error(err.Error())
+	}
+	defer resultsIterator.Close()
+
+	for resultsIterator.HasNext() {
+		if _, err := resultsIterator.Next(); err != nil {
+			return Error(err.Error())
+		}
+	}
+	return Success([]byte(metadata.Bookmark))
+}
+
 func (t *shimTestCC) putEP(stub ChaincodeStubInterface) pb.Response {
 	args := stub.GetArgs()
 	err := stub.SetStateValidationParameter(string(args[1]), args[2])
@@ -876,6 +1015,192 @@ func TestInvoke(t *testing.T) {
 	//wait for done
 	processDone(t, done, false)
 
+	//paginated history query over a block range
+
+	historyPageResp := &pb.QueryResponse{Results: []*pb.QueryResultBytes{
+		{ResultBytes: utils.MarshalOrPanic(&lproto.KeyModification{TxId: "6", Value: []byte("100")})}},
+		HasMore: false, Metadata: utils.MarshalOrPanic(&pb.QueryResponseMetadata{FetchedRecordsCount: 1, Bookmark: "4:0"})}
+
+	respSet = &mockpeer.MockResponseSet{
+		DoneFunc:  errorFunc,
+		ErrorFunc: errorFunc,
+		Responses: []*mockpeer.MockResponse{
+			{RecvMsg: &pb.ChaincodeMessage{Type: pb.ChaincodeMessage_GET_HISTORY_FOR_KEY, Txid: "7e", ChannelId: channelId}, RespMsg: &pb.ChaincodeMessage{Type: pb.ChaincodeMessage_RESPONSE, Payload: utils.MarshalOrPanic(historyPageResp), Txid: "7e", ChannelId: channelId}},
+			{RecvMsg: &pb.ChaincodeMessage{Type: pb.ChaincodeMessage_QUERY_STATE_CLOSE, Txid: "7e", ChannelId: channelId}, RespMsg: &pb.ChaincodeMessage{Type: pb.ChaincodeMessage_RESPONSE, Txid: "7e", ChannelId: channelId}},
+			{RecvMsg: &pb.ChaincodeMessage{Type: pb.ChaincodeMessage_COMPLETED, Txid: "7e", ChannelId: channelId}, RespMsg: nil},
+		},
+	}
+	peerSide.SetResponses(respSet)
+
+	ci = &pb.ChaincodeInput{Args: [][]byte{[]byte("historyqpage"), []byte("A"), []byte("2"), []byte("10"), []byte("true"), []byte("1"), []byte("")}, Decorations: nil}
+	payload = utils.MarshalOrPanic(ci)
+	peerSide.Send(&pb.ChaincodeMessage{Type: pb.ChaincodeMessage_TRANSACTION, Payload: payload, Txid: "7e", ChannelId: channelId})
+
+	//wait for done
+	processDone(t, done, false)
+
+	//error paginated history query with an invalid block range, the peer is never called
+
+	respSet = &mockpeer.MockResponseSet{
+		DoneFunc:  errorFunc,
+		ErrorFunc: errorFunc,
+		Responses: []*mockpeer.MockResponse{
+			{RecvMsg: &pb.ChaincodeMessage{Type: pb.ChaincodeMessage_COMPLETED, Txid: "7f", ChannelId: channelId}, RespMsg: nil},
+		},
+	}
+	peerSide.SetResponses(respSet)
+
+	ci = &pb.ChaincodeInput{Args: [][]byte{[]byte("historyqpage"), []byte("A"), []byte("10"), []byte("2"), []byte("false"), []byte("1"), []byte("")}, Decorations: nil}
+	payload = utils.MarshalOrPanic(ci)
+	peerSide.Send(&pb.ChaincodeMessage{Type: pb.ChaincodeMessage_TRANSACTION, Payload: payload, Txid: "7f", ChannelId: channelId})
+
+	//wait for done
+	processDone(t, done, false)
+
+	//private data history query
+
+	pvtHistoryResp := &pb.QueryResponse{Results: []*pb.QueryResultBytes{
+		{ResultBytes: utils.MarshalOrPanic(&lproto.PrivateDataKeyModification{BlockNum: 3, TxNum: 1, TxId: "6", Value: []byte("100")})}},
+		HasMore: false}
+
+	respSet = &mockpeer.MockResponseSet{
+		DoneFunc:  errorFunc,
+		ErrorFunc: errorFunc,
+		Responses: []*mockpeer.MockResponse{
+			{RecvMsg: &pb.ChaincodeMessage{Type: pb.ChaincodeMessage_GET_HISTORY_FOR_KEY, Txid: "7g", ChannelId: channelId}, RespMsg: &pb.ChaincodeMessage{Type: pb.ChaincodeMessage_RESPONSE, Payload: utils.MarshalOrPanic(pvtHistoryResp), Txid: "7g", ChannelId: channelId}},
+			{RecvMsg: &pb.ChaincodeMessage{Type: pb.ChaincodeMessage_QUERY_STATE_CLOSE, Txid: "7g", ChannelId: channelId}, RespMsg: &pb.ChaincodeMessage{Type: pb.ChaincodeMessage_RESPONSE, Txid: "7g", ChannelId: channelId}},
+			{RecvMsg: &pb.ChaincodeMessage{Type: pb.ChaincodeMessage_COMPLETED, Txid: "7g", ChannelId: channelId}, RespMsg: nil},
+		},
+	}
+	peerSide.SetResponses(respSet)
+
+	ci = &pb.ChaincodeInput{Args: [][]byte{[]byte("pvthistoryq"), []byte("coll1"), []byte("A")}, Decorations: nil}
+	payload = utils.MarshalOrPanic(ci)
+	peerSide.Send(&pb.ChaincodeMessage{Type: pb.ChaincodeMessage_TRANSACTION, Payload: payload, Txid: "7g", ChannelId: channelId})
+
+	//wait for done
+	processDone(t, done, false)
+
+	//error private data history query without a collection, the peer is never called
+
+	respSet = &mockpeer.MockResponseSet{
+		DoneFunc:  errorFunc,
+		ErrorFunc: errorFunc,
+		Responses: []*mockpeer.MockResponse{
+			{RecvMsg: &pb.ChaincodeMessage{Type: pb.ChaincodeMessage_COMPLETED, Txid: "7h", ChannelId: channelId}, RespMsg: nil},
+		},
+	}
+	peerSide.SetResponses(respSet)
+
+	ci = &pb.ChaincodeInput{Args: [][]byte{[]byte("pvthistoryq"), []byte(""), []byte("A")}, Decorations: nil}
+	payload = utils.MarshalOrPanic(ci)
+	peerSide.Send(&pb.ChaincodeMessage{Type: pb.ChaincodeMessage_TRANSACTION, Payload: payload, Txid: "7h", ChannelId: channelId})
+
+	//wait for done
+	processDone(t, done, false)
+
+	//verify private data hash
+
+	valueHash := sha256.Sum256([]byte("100"))
+	respSet = &mockpeer.MockResponseSet{
+		DoneFunc:  errorFunc,
+		ErrorFunc: errorFunc,
+		Responses: []*mockpeer.MockResponse{
+			{RecvMsg: &pb.ChaincodeMessage{Type: pb.ChaincodeMessage_GET_PRIVATE_DATA_HASH, Txid: "7i", ChannelId: channelId}, RespMsg: &pb.ChaincodeMessage{Type: pb.ChaincodeMessage_RESPONSE, Payload: valueHash[:], Txid: "7i", ChannelId: channelId}},
+			{RecvMsg: &pb.ChaincodeMessage{Type: pb.ChaincodeMessage_COMPLETED, Txid: "7i", ChannelId: channelId}, RespMsg: nil},
+		},
+	}
+	peerSide.SetResponses(respSet)
+
+	ci = &pb.ChaincodeInput{Args: [][]byte{[]byte("verifypvt"), []byte("coll1"), []byte("A"), []byte("100")}, Decorations: nil}
+	payload = utils.MarshalOrPanic(ci)
+	peerSide.Send(&pb.ChaincodeMessage{Type: pb.ChaincodeMessage_TRANSACTION, Payload: payload, Txid: "7i", ChannelId: channelId})
+
+	//wait for done
+	processDone(t, done, false)
+
+	//purge private data
+
+	respSet = &mockpeer.MockResponseSet{
+		DoneFunc:  errorFunc,
+		ErrorFunc: errorFunc,
+		Responses: []*mockpeer.MockResponse{
+			{RecvMsg: &pb.ChaincodeMessage{Type: pb.ChaincodeMessage_PURGE_PRIVATE_DATA, Txid: "7j", ChannelId: channelId}, RespMsg: &pb.ChaincodeMessage{Type: pb.ChaincodeMessage_RESPONSE, Txid: "7j", ChannelId: channelId}},
+			{RecvMsg: &pb.ChaincodeMessage{Type: pb.ChaincodeMessage_COMPLETED, Txid: "7j", ChannelId: channelId}, RespMsg: nil},
+		},
+	}
+	peerSide.SetResponses(respSet)
+
+	ci = &pb.ChaincodeInput{Args: [][]byte{[]byte("purgepvt"), []byte("coll1"), []byte("A")}, Decorations: nil}
+	payload = utils.MarshalOrPanic(ci)
+	peerSide.Send(&pb.ChaincodeMessage{Type: pb.ChaincodeMessage_TRANSACTION, Payload: payload, Txid: "7j", ChannelId: channelId})
+
+	//wait for done
+	processDone(t, done, false)
+
+	//query at height
+
+	respSet = &mockpeer.MockResponseSet{
+		DoneFunc:  errorFunc,
+		ErrorFunc: errorFunc,
+		Responses: []*mockpeer.MockResponse{
+			{RecvMsg: &pb.ChaincodeMessage{Type: pb.ChaincodeMessage_GET_STATE_AT_HEIGHT, Txid: "7b", ChannelId: channelId}, RespMsg: &pb.ChaincodeMessage{Type: pb.ChaincodeMessage_RESPONSE, Payload: []byte("100"), Txid: "7b", ChannelId: channelId}},
+			{RecvMsg: &pb.ChaincodeMessage{Type: pb.ChaincodeMessage_COMPLETED, Txid: "7b", ChannelId: channelId}, RespMsg: nil},
+		},
+	}
+	peerSide.SetResponses(respSet)
+
+	ci = &pb.ChaincodeInput{Args: [][]byte{[]byte("queryatheight"), []byte("A"), []byte("5")}, Decorations: nil}
+	payload = utils.MarshalOrPanic(ci)
+	peerSide.Send(&pb.ChaincodeMessage{Type: pb.ChaincodeMessage_TRANSACTION, Payload: payload, Txid: "7b", ChannelId: channelId})
+
+	//wait for done
+	processDone(t, done, false)
+
+	//range query at height
+
+	rangeQAtHeightResp := &pb.QueryResponse{Results: []*pb.QueryResultBytes{
+		{ResultBytes: utils.MarshalOrPanic(&lproto.KV{Namespace: "getputcc", Key: "A", Value: []byte("100")})},
+		{ResultBytes: utils.MarshalOrPanic(&lproto.KV{Namespace: "getputcc", Key: "B", Value: []byte("200")})}},
+		HasMore: false}
+
+	respSet = &mockpeer.MockResponseSet{
+		DoneFunc:  errorFunc,
+		ErrorFunc: errorFunc,
+		Responses: []*mockpeer.MockResponse{
+			{RecvMsg: &pb.ChaincodeMessage{Type: pb.ChaincodeMessage_GET_STATE_BY_RANGE_AT_HEIGHT, Txid: "7c", ChannelId: channelId}, RespMsg: &pb.ChaincodeMessage{Type: pb.ChaincodeMessage_RESPONSE, Payload: utils.MarshalOrPanic(rangeQAtHeightResp), Txid: "7c", ChannelId: channelId}},
+			{RecvMsg: &pb.ChaincodeMessage{Type: pb.ChaincodeMessage_QUERY_STATE_CLOSE, Txid: "7c", ChannelId: channelId}, RespMsg: &pb.ChaincodeMessage{Type: pb.ChaincodeMessage_RESPONSE, Txid: "7c", ChannelId: channelId}},
+			{RecvMsg: &pb.ChaincodeMessage{Type: pb.ChaincodeMessage_COMPLETED, Txid: "7c", ChannelId: channelId}, RespMsg: nil},
+		},
+	}
+	peerSide.SetResponses(respSet)
+
+	ci = &pb.ChaincodeInput{Args: [][]byte{[]byte("rangeqatheight"), []byte("A"), []byte("C"), []byte("5")}, Decorations: nil}
+	payload = utils.MarshalOrPanic(ci)
+	peerSide.Send(&pb.ChaincodeMessage{Type: pb.ChaincodeMessage_TRANSACTION, Payload: payload, Txid: "7c", ChannelId: channelId})
+
+	//wait for done
+	processDone(t, done, false)
+
+	//error range query at height
+
+	respSet = &mockpeer.MockResponseSet{
+		DoneFunc:  errorFunc,
+		ErrorFunc: errorFunc,
+		Responses: []*mockpeer.MockResponse{
+			{RecvMsg: &pb.ChaincodeMessage{Type: pb.ChaincodeMessage_GET_STATE_BY_RANGE_AT_HEIGHT, Txid: "7d", ChannelId: channelId}, RespMsg: &pb.ChaincodeMessage{Type: pb.ChaincodeMessage_ERROR, Payload: []byte("history database not enabled"), Txid: "7d", ChannelId: channelId}},
+			{RecvMsg: &pb.ChaincodeMessage{Type: pb.ChaincodeMessage_COMPLETED, Txid: "7d", ChannelId: channelId}, RespMsg: nil},
+		},
+	}
+	peerSide.SetResponses(respSet)
+
+	ci = &pb.ChaincodeInput{Args: [][]byte{[]byte("rangeqatheight"), []byte("A"), []byte("C"), []byte("5")}, Decorations: nil}
+	payload = utils.MarshalOrPanic(ci)
+	peerSide.Send(&pb.ChaincodeMessage{Type: pb.ChaincodeMessage_TRANSACTION, Payload: payload, Txid: "7d", ChannelId: channelId})
+
+	//wait for done
+	processDone(t, done, false)
+
 	//query result
 
 	//create the response
@@ -65,6 +65,11 @@ func (m *mockLedger) GetTransactionByID(txID string) (*peer.ProcessedTransaction
 	return args.Get(0).(*peer.ProcessedTransaction), args.Error(1)
 }
 
+func (m *mockLedger) TxIDExists(txID string) (bool, error) {
+	args := m.Called(txID)
+	return args.Bool(0), args.Error(1)
+}
+
 func (m *mockLedger) GetBlockByHash(blockHash []byte) (*common.Block, error) {
 	args := m.Called(blockHash)
 	return args.Get(0).(*common.Block), args.Error(1)
@@ -121,6 +126,14 @@ func (m *mockLedger) GetMissingPvtDataTracker() (ledger2.MissingPvtDataTracker,
 	panic("implement me")
 }
 
+func (m *mockLedger) GenerateSnapshot() (string, error) {
+	panic("implement me")
+}
+
+func (m *mockLedger) GetChaincodeIndexManager() (ledger2.ChaincodeIndexManager, error) {
+	panic("implement me")
+}
+
 func (m *mockLedger) PurgePrivateData(maxBlockNumToRetain uint64) error {
 	args := m.Called(maxBlockNumToRetain)
 	return args.Error(0)
@@ -53,6 +53,13 @@ type Support interface {
 
 	// Capabilities defines the capabilities for the application portion of this channel
 	Capabilities() channelconfig.ApplicationCapabilities
+
+	// ApplicationConfig returns the configuration of the application portion of this channel
+	ApplicationConfig() (channelconfig.Application, bool)
+
+	// ConfigtxValidator returns the validator of the config transactions of this channel,
+	// which holds the current config of the channel
+	ConfigtxValidator() configtx.Validator
 }
 
 //Validator interface which defines API to validate block transactions
@@ -479,22 +486,13 @@ func (v *TxValidator) checkTxIdDupsLedger(tIdx int, chdr *common.ChannelHeader,
 	// Retrieve the transaction identifier of the input header
 	txID := chdr.TxId
 
-	// Look for a transaction with the same identifier inside the ledger
-	_, err := ldgr.GetTransactionByID(txID)
-
-	// if returned error is nil, it means that there is already a tx in
-	// the ledger with the supplied id
-	if err == nil {
-		logger.Error("Duplicate transaction found, ", txID, ", skipping")
-		return &blockValidationResult{
-			tIdx:           tIdx,
-			validationCode: peer.TxValidationCode_DUPLICATE_TXID,
-		}
-	}
+	// Look for a transaction with the same identifier inside the ledger. This also covers the
+	// transactions committed before the snapshot, if the ledger was bootstrapped from a snapshot
+	exists, err := ldgr.TxIDExists(txID)
 
-	// if returned error is not of type blkstorage.NotFoundInIndexErr, it means
-	// we could not verify whether a tx with the supplied id is in the ledger
-	if _, isNotFoundInIndexErrType := err.(ledger.NotFoundInIndexErr); !isNotFoundInIndexErrType {
+	// if returned error is not nil, it means we could not verify
+	// whether a tx with the supplied id is in the ledger
+	if err != nil {
 		logger.Errorf("Ledger failure while attempting to detect duplicate status for "+
 			"txid %s, err '%s'. Aborting", txID, err)
 		return &blockValidationResult{
@@ -503,6 +501,16 @@ func (v *TxValidator) checkTxIdDupsLedger(tIdx int, chdr *common.ChannelHeader,
 		}
 	}
 
+	// if the txid exists, it means that there is already a tx in
+	// the ledger with the supplied id
+	if exists {
+		logger.Error("Duplicate transaction found, ", txID, ", skipping")
+		return &blockValidationResult{
+			tIdx:           tIdx,
+			validationCode: peer.TxValidationCode_DUPLICATE_TXID,
+		}
+	}
+
 	// it otherwise means that there is no transaction with the same identifier
 	// residing in the ledger
 	return nil
@@ -39,6 +39,7 @@ import (
 	"github.com/hyperledger/fabric/msp/mgmt"
 	"github.com/hyperledger/fabric/msp/mgmt/testtools"
 	"github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/ledger/rwset/kvrwset"
 	mb "github.com/hyperledger/fabric/protos/msp"
 	"github.com/hyperledger/fabric/protos/peer"
 	pb "github.com/hyperledger/fabric/protos/peer"
@@ -1500,7 +1501,7 @@ func TestTokenDuplicateTxId(t *testing.T) {
 	validator := txvalidator.NewTxValidator("", vcs, mp, pm)
 
 	tx := getTokenTx(t)
-	theLedger.On("GetTransactionByID", mock.Anything).Return(&peer.ProcessedTransaction{}, nil)
+	theLedger.On("TxIDExists", mock.Anything).Return(true, nil)
 
 	b := testutil.NewBlock([]*common.Envelope{tx}, 0, nil)
 
@@ -1530,6 +1531,12 @@ func (m *mockLedger) GetTransactionByID(txID string) (*peer.ProcessedTransaction
 	return args.Get(0).(*peer.ProcessedTransaction), args.Error(1)
 }
 
+// TxIDExists returns true if the txid exists in the ledger
+func (m *mockLedger) TxIDExists(txID string) (bool, error) {
+	args := m.Called(txID)
+	return args.Bool(0), args.Error(1)
+}
+
 // GetBlockByHash returns block using its hash value
 func (m *mockLedger) GetBlockByHash(blockHash []byte) (*common.Block, error) {
 	args := m.Called(blockHash)
@@ -1636,6 +1643,18 @@ func (m *mockLedger) GetMissingPvtDataTracker() (ledger.MissingPvtDataTracker, e
 	return args.Get(0).(ledger.MissingPvtDataTracker), nil
 }
 
+// GenerateSnapshot generates a snapshot of the ledger
+func (m *mockLedger) GenerateSnapshot() (string, error) {
+	args := m.Called()
+	return args.String(0), args.Error(1)
+}
+
+// GetChaincodeIndexManager returns the manager of the chaincode indexes
+func (m *mockLedger) GetChaincodeIndexManager() (ledger.ChaincodeIndexManager, error) {
+	args := m.Called()
+	return args.Get(0).(ledger.ChaincodeIndexManager), args.Error(1)
+}
+
 // mockQueryExecutor mock of the query executor,
 // needed to simulate inability to access state db, e.g.
 // the case where due to db failure it's not possible to
@@ -1687,6 +1706,11 @@ func (exec *mockQueryExecutor) GetPrivateDataMetadataByHash(namespace, collectio
 	return args.Get(0).(map[string][]byte), args.Error(1)
 }
 
+func (exec *mockQueryExecutor) GetPrivateDataHash(namespace, collection, key string) ([]byte, *kvrwset.Version, error) {
+	args := exec.Called(namespace, collection, key)
+	return args.Get(0).([]byte), args.Get(1).(*kvrwset.Version), args.Error(2)
+}
+
 func (exec *mockQueryExecutor) GetPrivateDataMultipleKeys(namespace, collection string, keys []string) ([][]byte, error) {
 	args := exec.Called(namespace, collection, keys)
 	return args.Get(0).([][]byte), args.Error(1)
@@ -1800,7 +1824,7 @@ func TestLedgerIsNoAvailable(t *testing.T) {
 	ccID := "mycc"
 	tx := getEnv(ccID, nil, createRWset(t, ccID), t)
 
-	theLedger.On("GetTransactionByID", mock.Anything).Return(&peer.ProcessedTransaction{}, ledger.NotFoundInIndexErr(""))
+	theLedger.On("TxIDExists", mock.Anything).Return(false, nil)
 
 	queryExecutor := new(mockQueryExecutor)
 	queryExecutor.On("GetState", mock.Anything, mock.Anything).Return([]byte{}, errors.New("Unable to connect to DB"))
@@ -1833,7 +1857,7 @@ func TestLedgerIsNotAvailableForCheckingTxidDuplicate(t *testing.T) {
 	ccID := "mycc"
 	tx := getEnv(ccID, nil, createRWset(t, ccID), t)
 
-	theLedger.On("GetTransactionByID", mock.Anything).Return(&peer.ProcessedTransaction{}, errors.New("Unable to connect to DB"))
+	theLedger.On("TxIDExists", mock.Anything).Return(false, errors.New("Unable to connect to DB"))
 
 	b := &common.Block{
 		Data:   &common.BlockData{Data: [][]byte{utils.MarshalOrPanic(tx)}},
@@ -1860,7 +1884,7 @@ func TestDuplicateTxId(t *testing.T) {
 	ccID := "mycc"
 	tx := getEnv(ccID, nil, createRWset(t, ccID), t)
 
-	theLedger.On("GetTransactionByID", mock.Anything).Return(&peer.ProcessedTransaction{}, nil)
+	theLedger.On("TxIDExists", mock.Anything).Return(true, nil)
 
 	b := &common.Block{
 		Data:   &common.BlockData{Data: [][]byte{utils.MarshalOrPanic(tx)}},
@@ -1898,7 +1922,7 @@ func TestValidationInvalidEndorsing(t *testing.T) {
 	ccID := "mycc"
 	tx := getEnv(ccID, nil, createRWset(t, ccID), t)
 
-	theLedger.On("GetTransactionByID", mock.Anything).Return(&peer.ProcessedTransaction{}, ledger.NotFoundInIndexErr(""))
+	theLedger.On("TxIDExists", mock.Anything).Return(false, nil)
 
 	cd := &ccp.ChaincodeData{
 		Name:    ccID,
@@ -1925,9 +1949,60 @@ func TestValidationInvalidEndorsing(t *testing.T) {
 	assertInvalid(b, t, peer.TxValidationCode_ENDORSEMENT_POLICY_FAILURE)
 }
 
+func TestValidationPolicyOfLifecycle(t *testing.T) {
+	theLedger := new(mockLedger)
+	theLedger.On("TxIDExists", mock.Anything).Return(false, nil)
+	vcs := struct {
+		*mocktxvalidator.Support
+		*semaphore.Weighted
+	}{&mocktxvalidator.Support{
+		LedgerVal:    theLedger,
+		ACVal:        &mockconfig.MockApplicationCapabilities{},
+		AppConfigVal: &mockconfig.MockApplication{},
+	}, semaphore.NewWeighted(10)}
+	mp := (&scc.MocksccProviderFactory{}).NewSystemChaincodeProvider()
+	mp.(*scc.MocksccProviderImpl).SysCCMap = map[string]bool{"+lifecycle": true}
+	pm := &mocks.PluginMapper{}
+	factory := &mocks.PluginFactory{}
+	plugin := &mocks.Plugin{}
+	factory.On("New").Return(plugin)
+	plugin.On("Init", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	var policy []byte
+	plugin.On("Validate", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		policy = args.Get(4).(txvalidator.SerializedPolicy).Bytes()
+	}).Return(nil)
+	pm.On("PluginFactoryByName", txvalidator.PluginName("vscc")).Return(factory)
+	validator := txvalidator.NewTxValidator("", vcs, mp, pm)
+
+	validate := func(collection string) *common.Block {
+		rwsetBuilder := rwsetutil.NewRWSetBuilder()
+		rwsetBuilder.AddToPvtAndHashedWriteSet("+lifecycle", collection, "namespaces/mycc#1", []byte("approval"))
+		rwset, err := rwsetBuilder.GetTxSimulationResults()
+		assert.NoError(t, err)
+		rwsetBytes, err := rwset.GetPubSimulationBytes()
+		assert.NoError(t, err)
+		tx := getEnv("+lifecycle", nil, rwsetBytes, t)
+		b := &common.Block{
+			Data:   &common.BlockData{Data: [][]byte{utils.MarshalOrPanic(tx)}},
+			Header: &common.BlockHeader{},
+		}
+		assert.NoError(t, validator.Validate(b))
+		return b
+	}
+
+	// the approval by an org must be endorsed by a member of the org
+	b := validate("_implicit_org_Org1MSP")
+	assertValid(b, t)
+	assert.Equal(t, utils.MarshalOrPanic(cauthdsl.SignedByMspMember("Org1MSP")), policy)
+
+	// the lifecycle namespace has no collection other than the implicit collections of the orgs
+	b = validate("mycollection")
+	assertInvalid(b, t, peer.TxValidationCode_INVALID_OTHER_REASON)
+}
+
 func createMockLedger(t *testing.T, ccID string) *mockLedger {
 	l := new(mockLedger)
-	l.On("GetTransactionByID", mock.Anything).Return(&peer.ProcessedTransaction{}, ledger.NotFoundInIndexErr(""))
+	l.On("TxIDExists", mock.Anything).Return(false, nil)
 	cd := &ccp.ChaincodeData{
 		Name:    ccID,
 		Version: ccVersion,
@@ -16,6 +16,7 @@ import (
 	"github.com/hyperledger/fabric/common/cauthdsl"
 	commonerrors "github.com/hyperledger/fabric/common/errors"
 	coreUtil "github.com/hyperledger/fabric/common/util"
+	"github.com/hyperledger/fabric/core/chaincode/lifecycle"
 	"github.com/hyperledger/fabric/core/common/ccprovider"
 	"github.com/hyperledger/fabric/core/common/sysccprovider"
 	"github.com/hyperledger/fabric/core/handlers/validation/api"
@@ -179,7 +180,7 @@ func (v *VsccValidatorImpl) VSCCValidateTx(seq int, payload *common.Payload, env
 		// validate *EACH* read write set according to its chaincode's endorsement policy
 		for _, ns := range wrNamespace {
 			// Get latest chaincode version, vscc and validate policy
-			txcc, vscc, policy, err := v.GetInfoForValidate(chdr, ns)
+			txcc, vscc, policy, err := v.getInfoForValidateTx(chdr, ns, txRWSet)
 			if err != nil {
 				logger.Errorf("GetInfoForValidate for txId = %s returned error: %+v", chdr.TxId, err)
 				return err, peer.TxValidationCode_INVALID_OTHER_REASON
@@ -225,7 +226,7 @@ func (v *VsccValidatorImpl) VSCCValidateTx(seq int, payload *common.Payload, env
 		}
 
 		// Get latest chaincode version, vscc and validate policy
-		_, vscc, policy, err := v.GetInfoForValidate(chdr, ccID)
+		_, vscc, policy, err := v.getInfoForValidateTx(chdr, ccID, txRWSet)
 		if err != nil {
 			logger.Errorf("GetInfoForValidate for txId = %s returned error: %+v", chdr.TxId, err)
 			return err, peer.TxValidationCode_INVALID_OTHER_REASON
@@ -356,6 +357,33 @@ func (v *VsccValidatorImpl) GetInfoForValidate(chdr *common.ChannelHeader, ccID
 	return cc, vscc, policy, nil
 }
 
+// getInfoForValidateTx returns the same as GetInfoForValidate, except for the namespace of
+// the lifecycle SCC whose policy depends on the writes of the transaction to the namespace
+func (v *VsccValidatorImpl) getInfoForValidateTx(chdr *common.ChannelHeader, ccID string, txRWSet *rwsetutil.TxRwSet) (*sysccprovider.ChaincodeInstance, *sysccprovider.ChaincodeInstance, []byte, error) {
+	cc, vscc, policy, err := v.GetInfoForValidate(chdr, ccID)
+	if err != nil || ccID != lifecycle.LifecycleNamespace {
+		return cc, vscc, policy, err
+	}
+
+	nsRWSet := &rwsetutil.NsRwSet{NameSpace: ccID}
+	for _, ns := range txRWSet.NsRwSets {
+		if ns.NameSpace == ccID {
+			nsRWSet = ns
+			break
+		}
+	}
+	p, err := lifecycle.ValidationPolicy(v.support, nsRWSet)
+	if err != nil {
+		return nil, nil, nil, errors.WithMessage(err, fmt.Sprintf("could not get the validation policy for txid %s", chdr.TxId))
+	}
+	policy, err = utils.Marshal(p)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	return cc, vscc, policy, nil
+}
+
 // txWritesToNamespace returns true if the supplied NsRwSet
 // performs a ledger write
 func (v *VsccValidatorImpl) txWritesToNamespace(ns *rwsetutil.NsRwSet) bool {
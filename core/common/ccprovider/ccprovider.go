@@ -497,6 +497,10 @@ type ChaincodeContainerInfo struct {
 	Type        string
 	CodePackage []byte
 
+	// PackageID is the ID of the installed chaincode package the peer launches
+	// the chaincode from, it is empty for the chaincodes instantiated through LSCC
+	PackageID string
+
 	// ContainerType is not a great name, but 'DOCKER' and 'SYSTEM' are the valid types
 	ContainerType string
 }
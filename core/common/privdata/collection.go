@@ -9,6 +9,7 @@ package privdata
 import (
 	"strings"
 
+	"github.com/hyperledger/fabric/common/cauthdsl"
 	"github.com/hyperledger/fabric/core/ledger"
 	"github.com/hyperledger/fabric/protos/common"
 	pb "github.com/hyperledger/fabric/protos/peer"
@@ -142,3 +143,37 @@ func GetCCNameFromCollectionConfigKey(key string) string {
 	splittedKey := strings.Split(key, collectionSeparator)
 	return splittedKey[0]
 }
+
+// ImplicitCollectionNamePrefix is the reserved prefix of the names of the implicit collections.
+// An implicit collection is available to every chaincode for each application organization of
+// the channel without being defined in the collection configuration of the chaincode
+const ImplicitCollectionNamePrefix = "_implicit_org_"
+
+// ImplicitCollectionNameForOrg returns the name of the implicit collection of the given organization
+func ImplicitCollectionNameForOrg(mspID string) string {
+	return ImplicitCollectionNamePrefix + mspID
+}
+
+// MSPIDIfImplicitCollection returns true and the MSP ID of the organization if the given
+// collection name is the name of an implicit collection
+func MSPIDIfImplicitCollection(collectionName string) (bool, string) {
+	if !strings.HasPrefix(collectionName, ImplicitCollectionNamePrefix) {
+		return false, ""
+	}
+	mspID := strings.TrimPrefix(collectionName, ImplicitCollectionNamePrefix)
+	return mspID != "", mspID
+}
+
+// GenerateImplicitCollectionForOrg returns the configuration of the implicit collection of the given
+// organization. The members of the organization are the members of the collection and the private data
+// of the collection never expires
+func GenerateImplicitCollectionForOrg(mspID string) *common.StaticCollectionConfig {
+	return &common.StaticCollectionConfig{
+		Name: ImplicitCollectionNameForOrg(mspID),
+		MemberOrgsPolicy: &common.CollectionPolicyConfig{
+			Payload: &common.CollectionPolicyConfig_SignaturePolicy{
+				SignaturePolicy: cauthdsl.SignedByMspMember(mspID),
+			},
+		},
+	}
+}
@@ -9,6 +9,9 @@ package privdata
 import (
 	"testing"
 
+	"github.com/hyperledger/fabric/common/cauthdsl"
+	"github.com/hyperledger/fabric/core/chaincode/shim"
+
 	"github.com/stretchr/testify/assert"
 )
 
@@ -26,3 +29,23 @@ func TestIsCollectionConfigKey(t *testing.T) {
 	isCollection = IsCollectionConfigKey("chaincodeKey~collection")
 	assert.True(t, isCollection, "key with tilda is a collection key and should have returned true")
 }
+
+func TestImplicitCollections(t *testing.T) {
+	collectionName := ImplicitCollectionNameForOrg("Org1MSP")
+	assert.Equal(t, "_implicit_org_Org1MSP", collectionName)
+	// the shim provides the same name to the chaincodes
+	assert.Equal(t, collectionName, shim.ImplicitCollectionNameForOrg("Org1MSP"))
+
+	isImplicit, mspID := MSPIDIfImplicitCollection(collectionName)
+	assert.True(t, isImplicit)
+	assert.Equal(t, "Org1MSP", mspID)
+	isImplicit, _ = MSPIDIfImplicitCollection("mycollection")
+	assert.False(t, isImplicit)
+	isImplicit, _ = MSPIDIfImplicitCollection(ImplicitCollectionNamePrefix)
+	assert.False(t, isImplicit)
+
+	collectionConfig := GenerateImplicitCollectionForOrg("Org1MSP")
+	assert.Equal(t, collectionName, collectionConfig.Name)
+	assert.Equal(t, uint64(0), collectionConfig.BlockToLive)
+	assert.Equal(t, cauthdsl.SignedByMspMember("Org1MSP"), collectionConfig.MemberOrgsPolicy.GetSignaturePolicy())
+}
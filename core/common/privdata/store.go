@@ -26,6 +26,9 @@ type Support interface {
 	// GetIdentityDeserializer returns an IdentityDeserializer
 	// instance for the specified chain
 	GetIdentityDeserializer(chainID string) msp.IdentityDeserializer
+
+	// GetMSPIDs returns the IDs of the application MSPs of the specified chain
+	GetMSPIDs(chainID string) []string
 }
 
 // StateGetter retrieves data from the state
@@ -93,6 +96,9 @@ func ParseCollectionConfig(colBytes []byte) (*common.CollectionConfigPackage, er
 }
 
 func (c *simpleCollectionStore) retrieveCollectionConfig(cc common.CollectionCriteria, qe ledger.QueryExecutor) (*common.StaticCollectionConfig, error) {
+	if isImplicit, mspID := MSPIDIfImplicitCollection(cc.Collection); isImplicit {
+		return c.retrieveImplicitCollectionConfig(cc, mspID)
+	}
 	collections, err := c.retrieveCollectionConfigPackage(cc, qe)
 	if err != nil {
 		return nil, err
@@ -113,6 +119,17 @@ func (c *simpleCollectionStore) retrieveCollectionConfig(cc common.CollectionCri
 	return nil, NoSuchCollectionError(cc)
 }
 
+// retrieveImplicitCollectionConfig returns the configuration of the implicit collection of the given
+// organization if the organization is an application organization of the channel
+func (c *simpleCollectionStore) retrieveImplicitCollectionConfig(cc common.CollectionCriteria, mspID string) (*common.StaticCollectionConfig, error) {
+	for _, channelMSPID := range c.s.GetMSPIDs(cc.Channel) {
+		if channelMSPID == mspID {
+			return GenerateImplicitCollectionForOrg(mspID), nil
+		}
+	}
+	return nil, NoSuchCollectionError(cc)
+}
+
 func (c *simpleCollectionStore) retrieveSimpleCollection(cc common.CollectionCriteria, qe ledger.QueryExecutor) (*SimpleCollection, error) {
 	staticCollectionConfig, err := c.retrieveCollectionConfig(cc, qe)
 	if err != nil {
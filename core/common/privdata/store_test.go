@@ -22,8 +22,9 @@ import (
 )
 
 type mockStoreSupport struct {
-	Qe   *lm.MockQueryExecutor
-	QErr error
+	Qe     *lm.MockQueryExecutor
+	QErr   error
+	MSPIDs []string
 }
 
 func (c *mockStoreSupport) GetQueryExecutorForLedger(cid string) (ledger.QueryExecutor, error) {
@@ -34,6 +35,10 @@ func (c *mockStoreSupport) GetIdentityDeserializer(chainID string) msp.IdentityD
 	return &mockDeserializer{}
 }
 
+func (c *mockStoreSupport) GetMSPIDs(chainID string) []string {
+	return c.MSPIDs
+}
+
 func TestCollectionStore(t *testing.T) {
 	wState := make(map[string]map[string][]byte)
 	support := &mockStoreSupport{Qe: &lm.MockQueryExecutor{State: wState}}
@@ -129,3 +134,32 @@ func TestCollectionStore(t *testing.T) {
 	assert.NoError(t, err)
 	assert.False(t, allowedAccess)
 }
+
+func TestCollectionStoreImplicitCollections(t *testing.T) {
+	support := &mockStoreSupport{
+		Qe:     &lm.MockQueryExecutor{State: map[string]map[string][]byte{}},
+		MSPIDs: []string{"Org1MSP", "Org2MSP"},
+	}
+	cs := NewSimpleCollectionStore(support)
+
+	// the implicit collections are available without a collection config for the chaincode
+	ccr := common.CollectionCriteria{Channel: "ch", Namespace: "cc", Collection: ImplicitCollectionNameForOrg("Org2MSP")}
+	c, err := cs.RetrieveCollection(ccr)
+	assert.NoError(t, err)
+	assert.Equal(t, "_implicit_org_Org2MSP", c.CollectionID())
+	assert.Equal(t, []string{"Org2MSP"}, c.MemberOrgs())
+
+	ca, err := cs.RetrieveCollectionAccessPolicy(ccr)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"Org2MSP"}, ca.MemberOrgs())
+	assert.False(t, ca.IsMemberOnlyRead())
+
+	pc, err := cs.RetrieveCollectionPersistenceConfigs(ccr)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(0), pc.BlockToLive())
+
+	// there is no implicit collection for an organization that is not a member of the channel
+	ccr.Collection = ImplicitCollectionNameForOrg("Org3MSP")
+	_, err = cs.RetrieveCollection(ccr)
+	assert.EqualError(t, err, "collection ch/cc/_implicit_org_Org3MSP could not be found")
+}
@@ -13,6 +13,7 @@ package ccintf
 import (
 	"fmt"
 
+	"github.com/hyperledger/fabric/core/comm"
 	pb "github.com/hyperledger/fabric/protos/peer"
 )
 
@@ -33,6 +34,13 @@ func GetCCHandlerKey() string {
 	return "CCHANDLER"
 }
 
+// ChaincodeServerInfo holds the information the peer needs to connect to a
+// chaincode running as a server
+type ChaincodeServerInfo struct {
+	Address      string
+	ClientConfig comm.ClientConfig
+}
+
 //CCID encapsulates chaincode ID
 type CCID struct {
 	Name    string
@@ -0,0 +1,303 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package externalbuilders
+
+import (
+	"archive/tar"
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/hyperledger/fabric/common/flogging"
+	"github.com/pkg/errors"
+)
+
+var logger = flogging.MustGetLogger("chaincode.externalbuilders")
+
+// DefaultEnvWhitelist enumerates the environment variables of the peer which
+// are always passed to the executables of the external builders.
+var DefaultEnvWhitelist = []string{"LD_LIBRARY_PATH", "LIBPATH", "PATH", "TMPDIR"}
+
+const (
+	// MetadataFile is the name of the file of the metadata directory which
+	// holds the BuildMetadata passed to the detect and build executables
+	MetadataFile = "metadata.json"
+
+	// ChaincodeFile is the name of the file of the run metadata directory
+	// which holds the RunMetadata passed to the run executable
+	ChaincodeFile = "chaincode.json"
+)
+
+// BuildMetadata describes the chaincode to detect and build.
+type BuildMetadata struct {
+	Type    string `json:"type"`
+	Path    string `json:"path"`
+	Name    string `json:"name"`
+	Version string `json:"version"`
+}
+
+// RunMetadata holds the information the chaincode needs to connect to the peer.
+// The TLS fields are PEM encoded and empty when TLS is disabled.
+type RunMetadata struct {
+	ChaincodeID string `json:"chaincode_id"`
+	PeerAddress string `json:"peer_address"`
+	ClientCert  string `json:"client_cert,omitempty"`
+	ClientKey   string `json:"client_key,omitempty"`
+	RootCert    string `json:"root_cert,omitempty"`
+}
+
+// Builder is an external builder: a directory holding the bin/detect,
+// bin/build, bin/release and bin/run executables. The release executable
+// is optional.
+type Builder struct {
+	Name                 string
+	Location             string
+	EnvironmentWhitelist []string
+}
+
+// BuildContext holds the directories of a chaincode built by an external
+// builder. The scratch directory holds all the others.
+type BuildContext struct {
+	ScratchDir  string
+	SourceDir   string
+	MetadataDir string
+	BldDir      string
+	ReleaseDir  string
+	RunDir      string
+}
+
+// NewBuildContext creates the directories of a build context, extracts the
+// code package into the source directory and writes the metadata into the
+// metadata directory.
+func NewBuildContext(md *BuildMetadata, codePackage []byte) (bc *BuildContext, err error) {
+	scratchDir, err := ioutil.TempDir("", "fabric-"+sanitize(md.Name+"-"+md.Version))
+	if err != nil {
+		return nil, errors.Wrap(err, "could not create temp dir")
+	}
+	defer func() {
+		if err != nil {
+			os.RemoveAll(scratchDir)
+		}
+	}()
+
+	bc = &BuildContext{
+		ScratchDir:  scratchDir,
+		SourceDir:   filepath.Join(scratchDir, "src"),
+		MetadataDir: filepath.Join(scratchDir, "metadata"),
+		BldDir:      filepath.Join(scratchDir, "bld"),
+		ReleaseDir:  filepath.Join(scratchDir, "release"),
+		RunDir:      filepath.Join(scratchDir, "run"),
+	}
+	for _, dir := range []string{bc.SourceDir, bc.MetadataDir, bc.BldDir, bc.ReleaseDir, bc.RunDir} {
+		if err := os.Mkdir(dir, 0700); err != nil {
+			return nil, errors.Wrapf(err, "could not create dir %s", dir)
+		}
+	}
+
+	if err := untar(codePackage, bc.SourceDir); err != nil {
+		return nil, errors.WithMessage(err, "could not extract code package")
+	}
+
+	if err := writeJSON(filepath.Join(bc.MetadataDir, MetadataFile), md); err != nil {
+		return nil, err
+	}
+
+	return bc, nil
+}
+
+// Cleanup removes the directories of the build context.
+func (bc *BuildContext) Cleanup() {
+	os.RemoveAll(bc.ScratchDir)
+}
+
+// Detect returns true if the builder can build the chaincode of the build
+// context, which is the case when its detect executable exits successfully.
+func (b *Builder) Detect(bc *BuildContext) bool {
+	detect := filepath.Join(b.Location, "bin", "detect")
+	cmd := b.newCommand(detect, bc.SourceDir, bc.MetadataDir)
+
+	if err := runCommand(logger.With("builder", b.Name), cmd); err != nil {
+		logger.Debugf("Detection for builder '%s' failed: %s", b.Name, err)
+		return false
+	}
+
+	return true
+}
+
+// Build invokes the build executable of the builder, which writes the build
+// output into the bld directory of the build context.
+func (b *Builder) Build(bc *BuildContext) error {
+	build := filepath.Join(b.Location, "bin", "build")
+	cmd := b.newCommand(build, bc.SourceDir, bc.MetadataDir, bc.BldDir)
+
+	if err := runCommand(logger.With("builder", b.Name), cmd); err != nil {
+		return errors.WithMessage(err, "builder '"+b.Name+"' failed")
+	}
+
+	return nil
+}
+
+// Release invokes the release executable of the builder, if any, which
+// writes the artifacts the peer consumes into the release directory of the
+// build context.
+func (b *Builder) Release(bc *BuildContext) error {
+	release := filepath.Join(b.Location, "bin", "release")
+	if _, err := os.Stat(release); os.IsNotExist(err) {
+		logger.Debugf("Builder '%s' does not provide a release executable", b.Name)
+		return nil
+	}
+	cmd := b.newCommand(release, bc.BldDir, bc.ReleaseDir)
+
+	if err := runCommand(logger.With("builder", b.Name), cmd); err != nil {
+		return errors.WithMessage(err, "builder '"+b.Name+"' release failed")
+	}
+
+	return nil
+}
+
+// Run writes the run metadata into the run directory of the build context
+// and starts the run executable of the builder, which launches the chaincode.
+// The returned command has been started and the output of the run executable
+// is mirrored to the logger of the chaincode.
+func (b *Builder) Run(bc *BuildContext, rmd *RunMetadata) (*exec.Cmd, error) {
+	if err := writeJSON(filepath.Join(bc.RunDir, ChaincodeFile), rmd); err != nil {
+		return nil, err
+	}
+
+	run := filepath.Join(b.Location, "bin", "run")
+	cmd := b.newCommand(run, bc.BldDir, bc.RunDir)
+
+	r, w, err := os.Pipe()
+	if err != nil {
+		return nil, errors.Wrap(err, "could not create pipe for output of run executable")
+	}
+	cmd.Stdout, cmd.Stderr = w, w
+	err = cmd.Start()
+	w.Close()
+	if err != nil {
+		r.Close()
+		return nil, errors.Wrapf(err, "builder '%s' could not start %s", b.Name, run)
+	}
+
+	go func() {
+		defer r.Close()
+		streamOutput(flogging.MustGetLogger("peer.chaincode."+sanitize(rmd.ChaincodeID)), r)
+	}()
+
+	return cmd, nil
+}
+
+// newCommand returns the command to invoke the executable with the
+// whitelisted environment variables of the peer.
+func (b *Builder) newCommand(name string, args ...string) *exec.Cmd {
+	cmd := exec.Command(name, args...)
+	whitelist := append(append([]string{}, DefaultEnvWhitelist...), b.EnvironmentWhitelist...)
+	for _, key := range whitelist {
+		if val, ok := os.LookupEnv(key); ok {
+			cmd.Env = append(cmd.Env, key+"="+val)
+		}
+	}
+	return cmd
+}
+
+// runCommand runs the command and logs its output.
+func runCommand(logger *flogging.FabricLogger, cmd *exec.Cmd) error {
+	output, err := cmd.CombinedOutput()
+	if len(output) != 0 {
+		streamOutput(logger, bytes.NewReader(output))
+	}
+	if err != nil {
+		return errors.Wrapf(err, "external builder failed running %s", cmd.Path)
+	}
+	return nil
+}
+
+// streamOutput mirrors each line of the output to the logger.
+func streamOutput(logger *flogging.FabricLogger, output io.Reader) {
+	scanner := bufio.NewScanner(output)
+	for scanner.Scan() {
+		logger.Info(scanner.Text())
+	}
+}
+
+func writeJSON(path string, v interface{}) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return errors.Wrapf(err, "could not marshal %s", filepath.Base(path))
+	}
+	if err := ioutil.WriteFile(path, data, 0600); err != nil {
+		return errors.Wrapf(err, "could not write %s", path)
+	}
+	return nil
+}
+
+// untar extracts the gzipped tar into the directory. The entries which would
+// be extracted outside of the directory are rejected.
+func untar(tgz []byte, dir string) error {
+	gzr, err := gzip.NewReader(bytes.NewReader(tgz))
+	if err != nil {
+		return errors.Wrap(err, "could not read code package")
+	}
+	tr := tar.NewReader(gzr)
+
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return errors.Wrap(err, "could not read code package")
+		}
+
+		target := filepath.Join(dir, header.Name)
+		if !strings.HasPrefix(target, filepath.Clean(dir)+string(os.PathSeparator)) {
+			return errors.Errorf("illegal file path %s in code package", header.Name)
+		}
+
+		switch header.Typeflag {
+		case tar.TypeDir:
+			if err := os.MkdirAll(target, 0700); err != nil {
+				return errors.Wrapf(err, "could not create dir %s", target)
+			}
+		case tar.TypeReg, tar.TypeRegA:
+			if err := os.MkdirAll(filepath.Dir(target), 0700); err != nil {
+				return errors.Wrapf(err, "could not create dir %s", filepath.Dir(target))
+			}
+			f, err := os.OpenFile(target, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, os.FileMode(header.Mode)&0755|0600)
+			if err != nil {
+				return errors.Wrapf(err, "could not create file %s", target)
+			}
+			_, err = io.Copy(f, tr)
+			f.Close()
+			if err != nil {
+				return errors.Wrapf(err, "could not write file %s", target)
+			}
+		default:
+			logger.Debugf("Skipping entry %s of type %c of code package", header.Name, header.Typeflag)
+		}
+	}
+}
+
+// sanitize replaces the characters of the chaincode name and version which
+// are not suitable for file and logger names.
+func sanitize(s string) string {
+	return strings.Map(func(r rune) rune {
+		switch {
+		case 'a' <= r && r <= 'z', 'A' <= r && r <= 'Z', '0' <= r && r <= '9', r == '-', r == '_', r == '.':
+			return r
+		default:
+			return '-'
+		}
+	}, s)
+}
@@ -0,0 +1,291 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package externalbuilders
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/hyperledger/fabric/core/container"
+	"github.com/hyperledger/fabric/core/container/ccintf"
+	"github.com/hyperledger/fabric/core/container/mock"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func codePackage(t *testing.T, files map[string]string) []byte {
+	buf := &bytes.Buffer{}
+	gw := gzip.NewWriter(buf)
+	tw := tar.NewWriter(gw)
+	for name, content := range files {
+		err := tw.WriteHeader(&tar.Header{Name: name, Typeflag: tar.TypeReg, Mode: 0644, Size: int64(len(content))})
+		require.NoError(t, err)
+		_, err = tw.Write([]byte(content))
+		require.NoError(t, err)
+	}
+	require.NoError(t, tw.Close())
+	require.NoError(t, gw.Close())
+	return buf.Bytes()
+}
+
+func TestBuildContext(t *testing.T) {
+	md := &BuildMetadata{Type: "GOLANG", Path: "github.com/mycc", Name: "mycc", Version: "1.0"}
+	bc, err := NewBuildContext(md, codePackage(t, map[string]string{"src/github.com/mycc/main.go": "package main"}))
+	require.NoError(t, err)
+
+	source, err := ioutil.ReadFile(filepath.Join(bc.SourceDir, "src/github.com/mycc/main.go"))
+	require.NoError(t, err)
+	assert.Equal(t, "package main", string(source))
+
+	metadata, err := ioutil.ReadFile(filepath.Join(bc.MetadataDir, MetadataFile))
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"type":"GOLANG","path":"github.com/mycc","name":"mycc","version":"1.0"}`, string(metadata))
+
+	bc.Cleanup()
+	_, err = os.Stat(bc.ScratchDir)
+	assert.True(t, os.IsNotExist(err))
+
+	_, err = NewBuildContext(md, []byte("garbage"))
+	assert.EqualError(t, err, "could not extract code package: could not read code package: unexpected EOF")
+
+	_, err = NewBuildContext(md, codePackage(t, map[string]string{"../escape": "escaped"}))
+	assert.EqualError(t, err, "could not extract code package: illegal file path ../escape in code package")
+}
+
+func TestBuilder(t *testing.T) {
+	builder := &Builder{Name: "good", Location: "testdata/goodbuilder"}
+
+	bc, err := NewBuildContext(&BuildMetadata{Type: "NODE"}, codePackage(t, nil))
+	require.NoError(t, err)
+	defer bc.Cleanup()
+	assert.False(t, builder.Detect(bc))
+
+	bc, err = NewBuildContext(&BuildMetadata{Type: "GOLANG"}, codePackage(t, map[string]string{"main.go": "package main"}))
+	require.NoError(t, err)
+	defer bc.Cleanup()
+	assert.True(t, builder.Detect(bc))
+
+	require.NoError(t, builder.Build(bc))
+	_, err = os.Stat(filepath.Join(bc.BldDir, "main.go"))
+	assert.NoError(t, err)
+
+	require.NoError(t, builder.Release(bc))
+	_, err = os.Stat(filepath.Join(bc.ReleaseDir, "released.json"))
+	assert.NoError(t, err)
+
+	failBuilder := &Builder{Name: "fail", Location: "testdata/failbuilder"}
+	assert.True(t, failBuilder.Detect(bc))
+	err = failBuilder.Build(bc)
+	assert.EqualError(t, err, "builder 'fail' failed: external builder failed running testdata/failbuilder/bin/build: exit status 1")
+	assert.NoError(t, failBuilder.Release(bc), "the release executable is optional")
+
+	missingBuilder := &Builder{Name: "missing", Location: "testdata/missing"}
+	assert.False(t, missingBuilder.Detect(bc))
+}
+
+func TestBuilderEnvironment(t *testing.T) {
+	os.Setenv("EXTERNAL_BUILDER_TEST_VAR", "value")
+	defer os.Unsetenv("EXTERNAL_BUILDER_TEST_VAR")
+
+	builder := &Builder{Name: "good", Location: "testdata/goodbuilder"}
+	assert.NotContains(t, builder.newCommand("true").Env, "EXTERNAL_BUILDER_TEST_VAR=value")
+
+	builder.EnvironmentWhitelist = []string{"EXTERNAL_BUILDER_TEST_VAR"}
+	assert.Contains(t, builder.newCommand("true").Env, "EXTERNAL_BUILDER_TEST_VAR=value")
+}
+
+func TestExternalVM(t *testing.T) {
+	fallbackVM := &mock.VM{}
+	fallbackProvider := &mock.VMProvider{}
+	fallbackProvider.NewVMReturns(fallbackVM)
+	provider := NewProvider([]*Builder{
+		{Name: "good", Location: "testdata/goodbuilder"},
+		{Name: "fail", Location: "testdata/failbuilder"},
+	}, "peer:7052", fallbackProvider)
+	vm := provider.NewVM()
+
+	ccid := ccintf.CCID{Name: "mycc", Version: "1.0"}
+	env := []string{
+		"CORE_CHAINCODE_ID_NAME=mycc:1.0",
+		"CORE_PEER_TLS_ENABLED=true",
+		"CORE_TLS_CLIENT_KEY_PATH=/etc/hyperledger/fabric/client.key",
+		"CORE_TLS_CLIENT_CERT_PATH=/etc/hyperledger/fabric/client.crt",
+		"CORE_PEER_TLS_ROOTCERT_FILE=/etc/hyperledger/fabric/peer.crt",
+	}
+	files := map[string][]byte{
+		"/etc/hyperledger/fabric/client.key": []byte("key"),
+		"/etc/hyperledger/fabric/client.crt": []byte("cert"),
+		"/etc/hyperledger/fabric/peer.crt":   []byte("root"),
+	}
+	builder := &container.PlatformBuilder{
+		Type:        "GOLANG",
+		Name:        "mycc",
+		Version:     "1.0",
+		CodePackage: codePackage(t, map[string]string{"main.go": "package main"}),
+	}
+
+	err := vm.Start(ccid, nil, env, files, builder)
+	require.NoError(t, err)
+	assert.Equal(t, 0, fallbackVM.StartCallCount())
+
+	instance := provider.instance("mycc-1.0")
+	require.NotNil(t, instance)
+	assert.Equal(t, "good", instance.Builder.Name)
+	var rmd RunMetadata
+	for i := 0; i < 1000; i++ {
+		data, err := ioutil.ReadFile(filepath.Join(instance.BuildContext.BldDir, ChaincodeFile))
+		if err == nil && json.Unmarshal(data, &rmd) == nil {
+			break
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	assert.Equal(t, RunMetadata{
+		ChaincodeID: "mycc:1.0",
+		PeerAddress: "peer:7052",
+		ClientCert:  "cert",
+		ClientKey:   "key",
+		RootCert:    "root",
+	}, rmd)
+
+	// starting the chaincode again stops the running instance
+	err = vm.Start(ccid, nil, env, files, builder)
+	require.NoError(t, err)
+	select {
+	case <-instance.done:
+	default:
+		t.Fatal("the running instance of the chaincode was not stopped")
+	}
+	_, err = os.Stat(instance.BuildContext.ScratchDir)
+	assert.True(t, os.IsNotExist(err))
+	restarted := provider.instance("mycc-1.0")
+	require.NotNil(t, restarted)
+	assert.NotEqual(t, instance, restarted)
+
+	err = vm.Stop(ccid, 0, false, false)
+	require.NoError(t, err)
+	assert.Nil(t, provider.instance("mycc-1.0"))
+	_, err = os.Stat(restarted.BuildContext.ScratchDir)
+	assert.True(t, os.IsNotExist(err))
+	assert.Equal(t, 0, fallbackVM.StopCallCount())
+
+	// the chaincode which no builder detects is started by the fallback vm
+	provider.Builders = provider.Builders[:1]
+	builder.Type = "NODE"
+	err = vm.Start(ccid, nil, env, files, builder)
+	require.NoError(t, err)
+	assert.Equal(t, 1, fallbackVM.StartCallCount())
+	assert.Nil(t, provider.instance("mycc-1.0"))
+
+	err = vm.Stop(ccid, 0, false, false)
+	require.NoError(t, err)
+	assert.Equal(t, 1, fallbackVM.StopCallCount())
+
+	// the first builder which detects the chaincode builds it
+	provider.Builders = []*Builder{{Name: "fail", Location: "testdata/failbuilder"}, {Name: "good", Location: "testdata/goodbuilder"}}
+	builder.Type = "GOLANG"
+	err = vm.Start(ccid, nil, env, files, builder)
+	assert.EqualError(t, err, "external builder failed to build chaincode mycc-1.0: builder 'fail' failed: external builder failed running testdata/failbuilder/bin/build: exit status 1")
+	assert.Nil(t, provider.instance("mycc-1.0"))
+	assert.Equal(t, 1, fallbackVM.StartCallCount())
+
+	// the health of the fallback vm is not reported
+	fallbackVM.HealthCheckReturns(errors.New("docker-unreachable"))
+	assert.NoError(t, vm.HealthCheck(context.Background()))
+	assert.Equal(t, 0, fallbackVM.HealthCheckCallCount())
+}
+
+// startInstance starts the script as the instance of a chaincode and returns
+// once the script has created the ready file passed as its first argument.
+func startInstance(t *testing.T, script, readyFile string) *Instance {
+	scratchDir, err := ioutil.TempDir("", "externalbuilders-scratch")
+	require.NoError(t, err)
+	cmd := exec.Command("sh", "-c", script, readyFile)
+	require.NoError(t, cmd.Start())
+	instance := &Instance{
+		BuildContext: &BuildContext{ScratchDir: scratchDir},
+		cmd:          cmd,
+		done:         make(chan struct{}),
+	}
+	go func() {
+		defer close(instance.done)
+		cmd.Wait()
+	}()
+
+	for i := 0; i < 1000; i++ {
+		if _, err := os.Stat(readyFile); err == nil {
+			return instance
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Fatalf("instance did not start: %s", script)
+	return nil
+}
+
+func TestExternalVMStop(t *testing.T) {
+	tempDir, err := ioutil.TempDir("", "externalbuilders")
+	require.NoError(t, err)
+	defer os.RemoveAll(tempDir)
+
+	provider := NewProvider(nil, "peer:7052", &mock.VMProvider{})
+	vm := &ExternalVM{provider: provider}
+	ccid := ccintf.CCID{Name: "mycc", Version: "1.0"}
+
+	t.Run("ExitedInstance", func(t *testing.T) {
+		instance := startInstance(t, `touch "$0"`, filepath.Join(tempDir, "exited"))
+		<-instance.done
+		provider.setInstance("mycc-1.0", instance)
+
+		err := vm.Stop(ccid, 0, false, true)
+		assert.NoError(t, err)
+		assert.Nil(t, provider.instance("mycc-1.0"))
+		_, err = os.Stat(instance.BuildContext.ScratchDir)
+		assert.True(t, os.IsNotExist(err))
+	})
+
+	t.Run("TerminatedInstance", func(t *testing.T) {
+		instance := startInstance(t, `touch "$0"; exec sleep 60`, filepath.Join(tempDir, "terminated"))
+		provider.setInstance("mycc-1.0", instance)
+
+		err := vm.Stop(ccid, 10, true, true)
+		assert.NoError(t, err)
+		assert.Nil(t, provider.instance("mycc-1.0"))
+		_, err = os.Stat(instance.BuildContext.ScratchDir)
+		assert.True(t, os.IsNotExist(err))
+		status := instance.cmd.ProcessState.Sys().(syscall.WaitStatus)
+		assert.Equal(t, syscall.SIGTERM, status.Signal())
+	})
+
+	t.Run("KilledInstance", func(t *testing.T) {
+		instance := startInstance(t, `trap "" TERM; touch "$0"; exec sleep 60`, filepath.Join(tempDir, "killed"))
+		provider.setInstance("mycc-1.0", instance)
+
+		err := vm.stopInstance(ccid, 100*time.Millisecond, true)
+		assert.EqualError(t, err, "chaincode mycc-1.0 did not exit within 100ms")
+		assert.Equal(t, instance, provider.instance("mycc-1.0"))
+		_, err = os.Stat(instance.BuildContext.ScratchDir)
+		assert.NoError(t, err)
+
+		err = vm.Stop(ccid, 0, false, true)
+		assert.NoError(t, err)
+		assert.Nil(t, provider.instance("mycc-1.0"))
+		_, err = os.Stat(instance.BuildContext.ScratchDir)
+		assert.True(t, os.IsNotExist(err))
+		status := instance.cmd.ProcessState.Sys().(syscall.WaitStatus)
+		assert.Equal(t, syscall.SIGKILL, status.Signal())
+	})
+}
@@ -0,0 +1,246 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package externalbuilders
+
+import (
+	"context"
+	"os/exec"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/hyperledger/fabric/core/container"
+	"github.com/hyperledger/fabric/core/container/ccintf"
+	"github.com/pkg/errors"
+)
+
+// The environment variables set by the chaincode support which point at the
+// TLS files uploaded to the chaincode runtime
+const (
+	tlsClientKeyPathEnv  = "CORE_TLS_CLIENT_KEY_PATH"
+	tlsClientCertPathEnv = "CORE_TLS_CLIENT_CERT_PATH"
+	tlsRootCertFileEnv   = "CORE_PEER_TLS_ROOTCERT_FILE"
+)
+
+// restartGracePeriod is the time the running instance of a chaincode is given
+// to exit on SIGTERM, before it is killed, when the chaincode is started again.
+const restartGracePeriod = time.Second
+
+// Instance is a chaincode launched by the run executable of an external builder.
+type Instance struct {
+	Builder      *Builder
+	BuildContext *BuildContext
+	cmd          *exec.Cmd
+	done         chan struct{}
+}
+
+// Provider implements container.VMProvider. The chaincode which none of the
+// external builders detects is handed to the VMs of the fallback provider.
+type Provider struct {
+	Builders    []*Builder
+	PeerAddress string
+	Fallback    container.VMProvider
+
+	mutex     sync.Mutex
+	instances map[string]*Instance
+}
+
+// NewProvider creates a new instance of Provider
+func NewProvider(builders []*Builder, peerAddress string, fallback container.VMProvider) *Provider {
+	return &Provider{
+		Builders:    builders,
+		PeerAddress: peerAddress,
+		Fallback:    fallback,
+		instances:   map[string]*Instance{},
+	}
+}
+
+// NewVM creates a new ExternalVM instance
+func (p *Provider) NewVM() container.VM {
+	return &ExternalVM{
+		provider: p,
+		fallback: p.Fallback.NewVM(),
+	}
+}
+
+func (p *Provider) instance(name string) *Instance {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	return p.instances[name]
+}
+
+func (p *Provider) setInstance(name string, instance *Instance) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	if instance == nil {
+		delete(p.instances, name)
+		return
+	}
+	p.instances[name] = instance
+}
+
+// ExternalVM is a vm which builds and launches chaincode with the first of the
+// external builders which detects it.
+type ExternalVM struct {
+	provider *Provider
+	fallback container.VM
+}
+
+// Start builds the chaincode with the first external builder which detects it
+// and launches it with the run executable of the builder. The chaincode is
+// started by the fallback vm when no builder detects it.
+func (vm *ExternalVM) Start(ccid ccintf.CCID, args, env []string, filesToUpload map[string][]byte, builder container.Builder) error {
+	pb, ok := builder.(*container.PlatformBuilder)
+	if !ok {
+		return vm.fallback.Start(ccid, args, env, filesToUpload, builder)
+	}
+
+	bc, err := NewBuildContext(&BuildMetadata{
+		Type:    pb.Type,
+		Path:    pb.Path,
+		Name:    pb.Name,
+		Version: pb.Version,
+	}, pb.CodePackage)
+	if err != nil {
+		return errors.WithMessage(err, "could not create build context")
+	}
+
+	var detected *Builder
+	for _, b := range vm.provider.Builders {
+		if b.Detect(bc) {
+			detected = b
+			break
+		}
+	}
+	if detected == nil {
+		bc.Cleanup()
+		logger.Debugf("No external builder detected chaincode %s, falling back", ccid.GetName())
+		return vm.fallback.Start(ccid, args, env, filesToUpload, builder)
+	}
+
+	if err := vm.stopInstance(ccid, restartGracePeriod, false); err != nil {
+		logger.Warningf("Could not stop the running instance of chaincode %s: %s", ccid.GetName(), err)
+	}
+
+	instance, err := vm.launch(detected, bc, ccid, env, filesToUpload)
+	if err != nil {
+		bc.Cleanup()
+		return err
+	}
+	vm.provider.setInstance(ccid.GetName(), instance)
+
+	logger.Infof("Started chaincode %s with external builder '%s'", ccid.GetName(), detected.Name)
+	return nil
+}
+
+func (vm *ExternalVM) launch(b *Builder, bc *BuildContext, ccid ccintf.CCID, env []string, filesToUpload map[string][]byte) (*Instance, error) {
+	if err := b.Build(bc); err != nil {
+		return nil, errors.WithMessage(err, "external builder failed to build chaincode "+ccid.GetName())
+	}
+	if err := b.Release(bc); err != nil {
+		return nil, errors.WithMessage(err, "external builder failed to release chaincode "+ccid.GetName())
+	}
+
+	rmd := &RunMetadata{
+		ChaincodeID: ccid.Name + ":" + ccid.Version,
+		PeerAddress: vm.provider.PeerAddress,
+	}
+	if lookupEnv(env, "CORE_PEER_TLS_ENABLED") == "true" {
+		rmd.ClientKey = string(filesToUpload[lookupEnv(env, tlsClientKeyPathEnv)])
+		rmd.ClientCert = string(filesToUpload[lookupEnv(env, tlsClientCertPathEnv)])
+		rmd.RootCert = string(filesToUpload[lookupEnv(env, tlsRootCertFileEnv)])
+	}
+
+	cmd, err := b.Run(bc, rmd)
+	if err != nil {
+		return nil, errors.WithMessage(err, "external builder failed to run chaincode "+ccid.GetName())
+	}
+
+	instance := &Instance{
+		Builder:      b,
+		BuildContext: bc,
+		cmd:          cmd,
+		done:         make(chan struct{}),
+	}
+	go func() {
+		defer close(instance.done)
+		err := cmd.Wait()
+		logger.Infof("Chaincode %s launched by external builder '%s' exited: %v", ccid.GetName(), b.Name, err)
+	}()
+
+	return instance, nil
+}
+
+// Stop terminates the chaincode launched by an external builder, waiting for
+// the timeout (in seconds) before killing it, and removes its build context.
+// The build context is removed even if dontremove is set, as nothing of it is
+// reused once the chaincode has exited. The chaincode is stopped by the
+// fallback vm when it was not launched by an external builder.
+func (vm *ExternalVM) Stop(ccid ccintf.CCID, timeout uint, dontkill, dontremove bool) error {
+	if vm.provider.instance(ccid.GetName()) == nil {
+		return vm.fallback.Stop(ccid, timeout, dontkill, dontremove)
+	}
+	return vm.stopInstance(ccid, time.Duration(timeout)*time.Second, dontkill)
+}
+
+func (vm *ExternalVM) stopInstance(ccid ccintf.CCID, timeout time.Duration, dontkill bool) error {
+	instance := vm.provider.instance(ccid.GetName())
+	if instance == nil {
+		return nil
+	}
+
+	select {
+	case <-instance.done:
+		// the chaincode has already exited
+	default:
+		err := instance.cmd.Process.Signal(syscall.SIGTERM)
+		if err != nil && !processFinished(err) {
+			logger.Debugf("Could not terminate chaincode %s: %s", ccid.GetName(), err)
+		}
+		select {
+		case <-instance.done:
+		case <-time.After(timeout):
+			if dontkill {
+				return errors.Errorf("chaincode %s did not exit within %s", ccid.GetName(), timeout)
+			}
+			err := instance.cmd.Process.Kill()
+			if err != nil && !processFinished(err) {
+				return errors.Wrapf(err, "could not kill chaincode %s", ccid.GetName())
+			}
+			<-instance.done
+		}
+	}
+
+	vm.provider.setInstance(ccid.GetName(), nil)
+	instance.BuildContext.Cleanup()
+
+	return nil
+}
+
+// processFinished returns true if the error is the one returned when signaling
+// a process which has already exited and been waited for.
+func processFinished(err error) bool {
+	return err.Error() == "os: process already finished"
+}
+
+// HealthCheck always succeeds, as the external builders have no long lived
+// dependency. The fallback vm is not checked, since it is only needed by the
+// chaincode which no external builder detects.
+func (vm *ExternalVM) HealthCheck(ctx context.Context) error {
+	return nil
+}
+
+// lookupEnv returns the value of the key in the environment.
+func lookupEnv(env []string, key string) string {
+	for _, kv := range env {
+		if strings.HasPrefix(kv, key+"=") {
+			return strings.TrimPrefix(kv, key+"=")
+		}
+	}
+	return ""
+}
@@ -12,6 +12,29 @@ type Store struct {
 	mock.Mock
 }
 
+// GetEntries provides a mock function with given fields:
+func (_m *Store) GetEntries() ([]*transientstore.EntryInfo, error) {
+	ret := _m.Called()
+
+	var r0 []*transientstore.EntryInfo
+	if rf, ok := ret.Get(0).(func() []*transientstore.EntryInfo); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*transientstore.EntryInfo)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetMinTransientBlkHt provides a mock function with given fields:
 func (_m *Store) GetMinTransientBlkHt() (uint64, error) {
 	ret := _m.Called()
@@ -98,6 +121,20 @@ func (_m *Store) PurgeByHeight(maxBlockNumToRetain uint64) error {
 	return r0
 }
 
+// PurgeByKeyHashes provides a mock function with given fields: purgeMarkers
+func (_m *Store) PurgeByKeyHashes(purgeMarkers []*ledger.PvtDataPurgeMarker) error {
+	ret := _m.Called(purgeMarkers)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func([]*ledger.PvtDataPurgeMarker) error); ok {
+		r0 = rf(purgeMarkers)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // PurgeByTxids provides a mock function with given fields: txids
 func (_m *Store) PurgeByTxids(txids []string) error {
 	ret := _m.Called(txids)
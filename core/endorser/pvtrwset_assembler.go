@@ -57,14 +57,24 @@ func (as *rwSetAssembler) AssemblePvtRWSet(privData *rwset.TxPvtReadWriteSet, tx
 			if err != nil {
 				return nil, errors.WithMessage(err, fmt.Sprintf("error while retrieving collection config for chaincode %#v", namespace))
 			}
+			colCP := &common.CollectionConfigPackage{}
 			if cb == nil {
-				return nil, errors.New(fmt.Sprintf("no collection config for chaincode %#v", namespace))
+				if !onlyImplicitCollections(pvtRwset) {
+					return nil, errors.New(fmt.Sprintf("no collection config for chaincode %#v", namespace))
+				}
+			} else if err = proto.Unmarshal(cb, colCP); err != nil {
+				return nil, errors.Wrapf(err, "invalid configuration for collection criteria %#v", namespace)
 			}
 
-			colCP := &common.CollectionConfigPackage{}
-			err = proto.Unmarshal(cb, colCP)
-			if err != nil {
-				return nil, errors.Wrapf(err, "invalid configuration for collection criteria %#v", namespace)
+			// implicit collections are not part of the collection config of the chaincode
+			for _, col := range pvtRwset.CollectionPvtRwset {
+				if isImplicit, mspID := privdata.MSPIDIfImplicitCollection(col.CollectionName); isImplicit {
+					colCP.Config = append(colCP.Config, &common.CollectionConfig{
+						Payload: &common.CollectionConfig_StaticCollectionConfig{
+							StaticCollectionConfig: privdata.GenerateImplicitCollectionForOrg(mspID),
+						},
+					})
+				}
 			}
 
 			txPvtRwSetWithConfig.CollectionConfigs[namespace] = colCP
@@ -99,3 +109,12 @@ func (as *rwSetAssembler) trimCollectionConfigs(pvtData *transientstore.TxPvtRea
 	}
 	pvtData.CollectionConfigs = filteredConfigs
 }
+
+func onlyImplicitCollections(nsPvtRwset *rwset.NsPvtReadWriteSet) bool {
+	for _, col := range nsPvtRwset.CollectionPvtRwset {
+		if isImplicit, _ := privdata.MSPIDIfImplicitCollection(col.CollectionName); !isImplicit {
+			return false
+		}
+	}
+	return true
+}
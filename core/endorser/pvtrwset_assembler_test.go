@@ -88,3 +88,75 @@ func TestAssemblePvtRWSet(t *testing.T) {
 	assert.Equal(t, 1, len(pvtReadWriteSetWithConfigInfo.PvtRwset.NsPvtRwset))
 
 }
+
+func TestAssemblePvtRWSetImplicitCollections(t *testing.T) {
+	collectionsConfigCC1 := &common.CollectionConfigPackage{
+		Config: []*common.CollectionConfig{
+			{
+				Payload: &common.CollectionConfig_StaticCollectionConfig{
+					StaticCollectionConfig: &common.StaticCollectionConfig{
+						Name: "mycollection-1",
+					},
+				},
+			},
+		},
+	}
+	colB, err := proto.Marshal(collectionsConfigCC1)
+	assert.NoError(t, err)
+
+	configRetriever := &mockCollectionConfigRetriever{}
+	configRetriever.On("GetState", "lscc", privdata.BuildCollectionKVSKey("myCC")).Return(colB, nil)
+	configRetriever.On("GetState", "lscc", privdata.BuildCollectionKVSKey("myCC2")).Return([]byte(nil), nil)
+
+	assembler := rwSetAssembler{}
+
+	privData := &rwset.TxPvtReadWriteSet{
+		DataModel: rwset.TxReadWriteSet_KV,
+		NsPvtRwset: []*rwset.NsPvtReadWriteSet{
+			{
+				Namespace: "myCC",
+				CollectionPvtRwset: []*rwset.CollectionPvtReadWriteSet{
+					{
+						CollectionName: "mycollection-1",
+						Rwset:          []byte{1, 2, 3, 4},
+					},
+					{
+						CollectionName: privdata.ImplicitCollectionNameForOrg("Org1MSP"),
+						Rwset:          []byte{5, 6, 7, 8},
+					},
+				},
+			},
+			{
+				Namespace: "myCC2",
+				CollectionPvtRwset: []*rwset.CollectionPvtReadWriteSet{
+					{
+						CollectionName: privdata.ImplicitCollectionNameForOrg("Org2MSP"),
+						Rwset:          []byte{1, 2, 3, 4},
+					},
+				},
+			},
+		},
+	}
+
+	pvtReadWriteSetWithConfigInfo, err := assembler.AssemblePvtRWSet(privData, configRetriever)
+	assert.NoError(t, err)
+	configPackages := pvtReadWriteSetWithConfigInfo.CollectionConfigs
+	assert.Len(t, configPackages, 2)
+
+	configs := configPackages["myCC"]
+	assert.Len(t, configs.Config, 2)
+	assert.Equal(t, "mycollection-1", configs.Config[0].GetStaticCollectionConfig().Name)
+	assert.True(t, proto.Equal(privdata.GenerateImplicitCollectionForOrg("Org1MSP"), configs.Config[1].GetStaticCollectionConfig()))
+
+	configs = configPackages["myCC2"]
+	assert.Len(t, configs.Config, 1)
+	assert.True(t, proto.Equal(privdata.GenerateImplicitCollectionForOrg("Org2MSP"), configs.Config[0].GetStaticCollectionConfig()))
+
+	// a chaincode without collection config can only use implicit collections
+	privData.NsPvtRwset[1].CollectionPvtRwset = append(privData.NsPvtRwset[1].CollectionPvtRwset, &rwset.CollectionPvtReadWriteSet{
+		CollectionName: "mycollection-1",
+		Rwset:          []byte{5, 6, 7, 8},
+	})
+	_, err = assembler.AssemblePvtRWSet(privData, configRetriever)
+	assert.EqualError(t, err, `no collection config for chaincode "myCC2"`)
+}
@@ -28,6 +28,8 @@ const (
 type Mgr interface {
 	ledger.StateListener
 	GetRetriever(ledgerID string, ledgerInfoRetriever LedgerInfoRetriever) ledger.ConfigHistoryRetriever
+	ExportConfigHistory(ledgerID string, lastBlockNum uint64, dir string) (map[string][]byte, error)
+	ImportConfigHistory(ledgerID, dir string) error
 	Close()
 }
 
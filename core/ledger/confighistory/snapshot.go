@@ -0,0 +1,101 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package confighistory
+
+import (
+	"path/filepath"
+
+	"github.com/hyperledger/fabric/common/ledger/snapshot"
+)
+
+const (
+	snapshotFileFormat = byte(1)
+	// SnapshotDataFileName is the name of the snapshot file that contains the config history
+	SnapshotDataFileName = "confighistory.data"
+)
+
+// ExportConfigHistory implements the function in the interface 'Mgr'. It exports the entries
+// of the config history of the given ledger, committed up to and including the lastBlockNum,
+// into a file in the given directory and returns the hash of the file. The entries beyond the
+// lastBlockNum are skipped as these may have been added while a later block is being processed.
+// Each record in the file contains the namespace, key, committing block number, and value of an entry
+func (m *mgr) ExportConfigHistory(ledgerID string, lastBlockNum uint64, dir string) (map[string][]byte, error) {
+	w, err := snapshot.CreateFile(filepath.Join(dir, SnapshotDataFileName), snapshotFileFormat)
+	if err != nil {
+		return nil, err
+	}
+	defer w.Close()
+
+	db := m.dbProvider.getDB(ledgerID)
+	itr := db.GetIterator([]byte(keyPrefix), nil)
+	defer itr.Release()
+	for itr.Next() {
+		k := decodeCompositeKey(itr.Key())
+		if k.blockNum > lastBlockNum {
+			continue
+		}
+		if err := w.EncodeString(k.ns); err != nil {
+			return nil, err
+		}
+		if err := w.EncodeString(k.key); err != nil {
+			return nil, err
+		}
+		if err := w.EncodeUVarint(k.blockNum); err != nil {
+			return nil, err
+		}
+		if err := w.EncodeBytes(itr.Value()); err != nil {
+			return nil, err
+		}
+	}
+	if err := itr.Error(); err != nil {
+		return nil, err
+	}
+	hash, err := w.Done()
+	if err != nil {
+		return nil, err
+	}
+	return map[string][]byte{SnapshotDataFileName: hash}, nil
+}
+
+// ImportConfigHistory implements the function in the interface 'Mgr'. It loads
+// the config history of the given ledger from the file in the snapshot directory
+func (m *mgr) ImportConfigHistory(ledgerID, dir string) error {
+	r, err := snapshot.OpenFile(filepath.Join(dir, SnapshotDataFileName), snapshotFileFormat)
+	if err != nil {
+		return err
+	}
+	defer r.Close()
+
+	batch := newBatch()
+	for {
+		hasMore, err := r.HasMore()
+		if err != nil {
+			return err
+		}
+		if !hasMore {
+			break
+		}
+		ns, err := r.DecodeString()
+		if err != nil {
+			return err
+		}
+		key, err := r.DecodeString()
+		if err != nil {
+			return err
+		}
+		blockNum, err := r.DecodeUVarint()
+		if err != nil {
+			return err
+		}
+		value, err := r.DecodeBytes()
+		if err != nil {
+			return err
+		}
+		batch.add(ns, key, blockNum, value)
+	}
+	return m.dbProvider.getDB(ledgerID).writeBatch(batch, true)
+}
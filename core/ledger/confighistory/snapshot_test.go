@@ -0,0 +1,75 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package confighistory
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hyperledger/fabric/common/ledger/snapshot"
+	"github.com/hyperledger/fabric/core/ledger"
+	"github.com/hyperledger/fabric/core/ledger/mock"
+	"github.com/hyperledger/fabric/protos/common"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExportAndImportConfigHistory(t *testing.T) {
+	dbPath := "/tmp/fabric/core/ledger/confighistory"
+	mockCCInfoProvider := &mock.DeployedChaincodeInfoProvider{}
+	env := newTestEnv(t, dbPath, mockCCInfoProvider)
+	mgr := env.mgr
+	defer env.cleanup()
+	snapshotDir, err := ioutil.TempDir("", "confighistory-snapshot")
+	assert.NoError(t, err)
+	defer os.RemoveAll(snapshotDir)
+
+	configCommittingBlockNums := []uint64{5, 10, 15}
+	for _, chaincodeName := range []string{"chaincode1", "chaincode2"} {
+		for _, committingBlockNum := range configCommittingBlockNums {
+			collConfigPackage := sampleCollectionConfigPackage(chaincodeName, committingBlockNum)
+			testutilEquipMockCCInfoProviderToReturnDesiredCollConfig(mockCCInfoProvider, chaincodeName, collConfigPackage)
+			assert.NoError(t, mgr.HandleStateUpdates(&ledger.StateUpdateTrigger{
+				LedgerID:           "sourceLedger",
+				CommittingBlockNum: committingBlockNum},
+			))
+		}
+	}
+
+	// the entries beyond the last block (i.e., block 15) are not exported
+	fileHashes, err := mgr.ExportConfigHistory("sourceLedger", 12, snapshotDir)
+	assert.NoError(t, err)
+	expectedHash, err := snapshot.ComputeFileHash(filepath.Join(snapshotDir, SnapshotDataFileName))
+	assert.NoError(t, err)
+	assert.Equal(t, map[string][]byte{SnapshotDataFileName: expectedHash}, fileHashes)
+
+	assert.NoError(t, mgr.ImportConfigHistory("importedLedger", snapshotDir))
+	dummyLedgerInfoRetriever := &dummyLedgerInfoRetriever{info: &common.BlockchainInfo{Height: 20}}
+	retriever := mgr.GetRetriever("importedLedger", dummyLedgerInfoRetriever)
+	for _, chaincodeName := range []string{"chaincode1", "chaincode2"} {
+		for _, committingBlockNum := range configCommittingBlockNums[:2] {
+			retrievedConfig, err := retriever.CollectionConfigAt(committingBlockNum, chaincodeName)
+			assert.NoError(t, err)
+			assert.Equal(t, sampleCollectionConfigPackage(chaincodeName, committingBlockNum), retrievedConfig.CollectionConfig)
+			assert.Equal(t, committingBlockNum, retrievedConfig.CommittingBlockNum)
+		}
+		retrievedConfig, err := retriever.MostRecentCollectionConfigBelow(20, chaincodeName)
+		assert.NoError(t, err)
+		assert.Equal(t, uint64(10), retrievedConfig.CommittingBlockNum)
+	}
+
+	// export of a ledger with no config history produces an empty data file
+	fileHashes, err = mgr.ExportConfigHistory("ledgerWithNoConfigHistory", 20, snapshotDir)
+	assert.NoError(t, err)
+	assert.Len(t, fileHashes, 1)
+	assert.NoError(t, mgr.ImportConfigHistory("anotherImportedLedger", snapshotDir))
+	retrievedConfig, err := mgr.GetRetriever("anotherImportedLedger", dummyLedgerInfoRetriever).
+		MostRecentCollectionConfigBelow(20, "chaincode1")
+	assert.NoError(t, err)
+	assert.Nil(t, retrievedConfig)
+}
@@ -0,0 +1,117 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package kvledger
+
+import (
+	"github.com/hyperledger/fabric/core/ledger"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/statedb"
+	"github.com/pkg/errors"
+)
+
+// GetChaincodeIndexManager returns the ChaincodeIndexManager for the state database of the ledger
+func (l *kvLedger) GetChaincodeIndexManager() (ledger.ChaincodeIndexManager, error) {
+	return &chaincodeIndexManager{l}, nil
+}
+
+// chaincodeIndexManager implements interface ledger.ChaincodeIndexManager. The indexes are
+// managed only for the chaincodes deployed on the channel and their defined collections
+type chaincodeIndexManager struct {
+	l *kvLedger
+}
+
+// ListIndexes implements the function in interface ledger.ChaincodeIndexManager
+func (m *chaincodeIndexManager) ListIndexes(chaincodeName string) ([]*ledger.ChaincodeIndex, error) {
+	collections, err := m.deployedCollections(chaincodeName)
+	if err != nil {
+		return nil, err
+	}
+	var indexes []*ledger.ChaincodeIndex
+	for _, collection := range append([]string{""}, collections...) {
+		indexInfos, err := m.l.vdb.ListIndexes(chaincodeName, collection)
+		if err != nil {
+			return nil, err
+		}
+		for _, indexInfo := range indexInfos {
+			indexes = append(indexes, newChaincodeIndex(chaincodeName, collection, indexInfo))
+		}
+	}
+	return indexes, nil
+}
+
+// DropIndex implements the function in interface ledger.ChaincodeIndexManager
+func (m *chaincodeIndexManager) DropIndex(chaincodeName, collection, designDoc, indexName string) error {
+	if err := m.checkCollection(chaincodeName, collection); err != nil {
+		return err
+	}
+	if err := m.l.vdb.DropIndex(chaincodeName, collection, designDoc, indexName); err != nil {
+		return err
+	}
+	logger.Infof("Channel [%s]: Dropped index [%s] of design document [%s] for chaincode [%s] and collection [%s]",
+		m.l.ledgerID, indexName, designDoc, chaincodeName, collection)
+	return nil
+}
+
+// RebuildIndex implements the function in interface ledger.ChaincodeIndexManager
+func (m *chaincodeIndexManager) RebuildIndex(chaincodeName, collection string, indexDefinition []byte) (*ledger.ChaincodeIndex, error) {
+	if err := m.checkCollection(chaincodeName, collection); err != nil {
+		return nil, err
+	}
+	indexInfo, err := m.l.vdb.RebuildIndex(chaincodeName, collection, indexDefinition)
+	if err != nil {
+		return nil, err
+	}
+	logger.Infof("Channel [%s]: Rebuilt index [%s] of design document [%s] for chaincode [%s] and collection [%s]",
+		m.l.ledgerID, indexInfo.Name, indexInfo.DesignDoc, chaincodeName, collection)
+	return newChaincodeIndex(chaincodeName, collection, indexInfo), nil
+}
+
+func (m *chaincodeIndexManager) checkCollection(chaincodeName, collection string) error {
+	collections, err := m.deployedCollections(chaincodeName)
+	if err != nil || collection == "" {
+		return err
+	}
+	for _, c := range collections {
+		if c == collection {
+			return nil
+		}
+	}
+	return errors.Errorf("collection [%s] is not defined for chaincode [%s]", collection, chaincodeName)
+}
+
+// deployedCollections returns the names of the collections defined for the chaincode or
+// an error if the chaincode is not deployed on the channel
+func (m *chaincodeIndexManager) deployedCollections(chaincodeName string) ([]string, error) {
+	qe, err := m.l.NewQueryExecutor()
+	if err != nil {
+		return nil, err
+	}
+	defer qe.Done()
+	info, err := m.l.ccInfoProvider.ChaincodeInfo(chaincodeName, qe)
+	if err != nil {
+		return nil, err
+	}
+	if info == nil {
+		return nil, errors.Errorf("chaincode [%s] is not deployed on channel [%s]", chaincodeName, m.l.ledgerID)
+	}
+	var collections []string
+	for _, config := range info.CollectionConfigPkg.GetConfig() {
+		if staticConfig := config.GetStaticCollectionConfig(); staticConfig != nil {
+			collections = append(collections, staticConfig.Name)
+		}
+	}
+	return collections, nil
+}
+
+func newChaincodeIndex(chaincodeName, collection string, indexInfo *statedb.IndexInfo) *ledger.ChaincodeIndex {
+	return &ledger.ChaincodeIndex{
+		Chaincode:  chaincodeName,
+		Collection: collection,
+		DesignDoc:  indexInfo.DesignDoc,
+		Name:       indexInfo.Name,
+		Definition: indexInfo.Definition,
+	}
+}
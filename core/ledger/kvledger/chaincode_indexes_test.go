@@ -0,0 +1,62 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package kvledger
+
+import (
+	"testing"
+
+	"github.com/hyperledger/fabric/common/ledger/testutil"
+	"github.com/hyperledger/fabric/common/util"
+	"github.com/hyperledger/fabric/core/ledger"
+	"github.com/hyperledger/fabric/core/ledger/cceventmgmt"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestChaincodeIndexManager(t *testing.T) {
+	env := newTestEnv(t)
+	defer env.cleanup()
+	viper.Set("ledger.state.stateDatabase", "JSONDB")
+	defer viper.Set("ledger.state.stateDatabase", "")
+	cceventmgmt.Initialize(nil)
+	provider := testutilNewProviderWithCollectionConfig(t, "cc1", map[string]uint64{"coll1": 0})
+	defer provider.Close()
+	_, gb := testutil.NewBlockGenerator(t, util.GetTestChainID(), false)
+	l, err := provider.Create(gb)
+	assert.NoError(t, err)
+	defer l.Close()
+
+	indexManager, err := l.GetChaincodeIndexManager()
+	assert.NoError(t, err)
+	_, err = indexManager.ListIndexes("cc2")
+	assert.EqualError(t, err, "chaincode [cc2] is not deployed on channel [testchainid]")
+	_, err = indexManager.RebuildIndex("cc1", "coll2", []byte(`{"index":{"fields":["owner"]}}`))
+	assert.EqualError(t, err, "collection [coll2] is not defined for chaincode [cc1]")
+	assert.EqualError(t, indexManager.DropIndex("cc1", "coll2", "indexOwnerDoc", "indexOwner"),
+		"collection [coll2] is not defined for chaincode [cc1]")
+
+	definition := `{"index":{"fields":["owner"]},"ddoc":"indexOwnerDoc","name":"indexOwner","type":"json"}`
+	publicIndex, err := indexManager.RebuildIndex("cc1", "", []byte(definition))
+	assert.NoError(t, err)
+	collectionIndex, err := indexManager.RebuildIndex("cc1", "coll1", []byte(definition))
+	assert.NoError(t, err)
+	assert.Equal(t, &ledger.ChaincodeIndex{
+		Chaincode:  "cc1",
+		Collection: "coll1",
+		DesignDoc:  "indexOwnerDoc",
+		Name:       "indexOwner",
+		Definition: collectionIndex.Definition,
+	}, collectionIndex)
+	indexes, err := indexManager.ListIndexes("cc1")
+	assert.NoError(t, err)
+	assert.Equal(t, []*ledger.ChaincodeIndex{publicIndex, collectionIndex}, indexes)
+
+	assert.NoError(t, indexManager.DropIndex("cc1", "", "indexOwnerDoc", "indexOwner"))
+	indexes, err = indexManager.ListIndexes("cc1")
+	assert.NoError(t, err)
+	assert.Equal(t, []*ledger.ChaincodeIndex{collectionIndex}, indexes)
+}
@@ -0,0 +1,96 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package kvledger
+
+import (
+	"os"
+
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/statedb/statecouchdb"
+	"github.com/hyperledger/fabric/core/ledger/ledgerconfig"
+	"github.com/hyperledger/fabric/core/ledger/ledgerstorage"
+	"github.com/pkg/errors"
+)
+
+// dropDBs drops the state, history, bookkeeping and config history databases of all the ledgers.
+// These databases are derived from the block store and are rebuilt on the next peer start
+func dropDBs() error {
+	// During block commits to stateDB, the transaction manager updates the bookkeeperDB and one of the
+	// state listener updates the config historyDB. As we drop the stateDB, we need to drop the
+	// configHistoryDB and bookkeeperDB too so that the lifecycle cache is rebuilt correctly
+	if err := dropStateDB(); err != nil {
+		return err
+	}
+	if err := dropConfigHistoryDB(); err != nil {
+		return err
+	}
+	if err := dropBookkeeperDB(); err != nil {
+		return err
+	}
+	return dropHistoryDB()
+}
+
+// checkAllLedgersHaveAllBlocks returns an error if any of the ledgers was bootstrapped from a snapshot or has
+// its block files archived. As the databases are dropped for all the ledgers together and the older blocks are
+// not present in the block store of such a ledger, dropping the databases would make such a ledger irrecoverable
+func checkAllLedgersHaveAllBlocks(idStore *idStore) error {
+	ledgerIDs, err := idStore.getAllLedgerIds()
+	if err != nil {
+		return err
+	}
+	for _, ledgerID := range ledgerIDs {
+		bootstrapped, err := ledgerstorage.IsBootstrappedFromSnapshot(ledgerID)
+		if err != nil {
+			return err
+		}
+		if bootstrapped {
+			return errors.Errorf("the ledger [%s] was bootstrapped from a snapshot, "+
+				"the databases cannot be rebuilt for a peer that has a ledger bootstrapped from a snapshot", ledgerID)
+		}
+		archived, err := ledgerstorage.HasArchivedBlocks(ledgerID)
+		if err != nil {
+			return err
+		}
+		if archived {
+			return errors.Errorf("the ledger [%s] has archived blocks, "+
+				"the databases cannot be rebuilt for a peer that has a ledger with archived blocks", ledgerID)
+		}
+	}
+	return nil
+}
+
+func dropStateDB() error {
+	if ledgerconfig.IsCouchDBEnabled() {
+		logger.Info("Dropping all the application databases of CouchDB")
+		if err := statecouchdb.DropApplicationDBs(); err != nil {
+			return err
+		}
+	}
+	if err := removeDir(ledgerconfig.GetStateJSONDBPath(), "stateJSONDB"); err != nil {
+		return err
+	}
+	return removeDir(ledgerconfig.GetStateLevelDBPath(), "stateLevelDB")
+}
+
+func dropConfigHistoryDB() error {
+	return removeDir(ledgerconfig.GetConfigHistoryPath(), "configHistoryDB")
+}
+
+func dropBookkeeperDB() error {
+	return removeDir(ledgerconfig.GetInternalBookkeeperPath(), "bookkeeperDB")
+}
+
+func dropHistoryDB() error {
+	return removeDir(ledgerconfig.GetHistoryLevelDBPath(), "historyDB")
+}
+
+func removeDir(path, dbName string) error {
+	logger.Infof("Dropping %s at path [%s]", dbName, path)
+	if err := os.RemoveAll(path); err != nil {
+		return errors.Wrapf(err, "error removing the %s at path [%s]", dbName, path)
+	}
+	return nil
+}
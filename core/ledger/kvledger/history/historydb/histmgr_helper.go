@@ -59,3 +59,30 @@ func SplitCompositeHistoryKey(bytesToSplit []byte, separator []byte) ([]byte, []
 	split := bytes.SplitN(bytesToSplit, separator, 2)
 	return split[0], split[1]
 }
+
+// PvtDataHashHistoryKeyPrefix is the prefix of the History Keys of the hashed private writes. As a namespace
+// never starts with this byte, these keys do not overlap with the History Keys of the public writes
+var PvtDataHashHistoryKeyPrefix = []byte{0x01}
+
+//ConstructCompositePvtDataHashHistoryKey builds the History Key of prefix~namespace~collection~keyhash~blocknum~trannum
+// for a hashed private write
+func ConstructCompositePvtDataHashHistoryKey(ns string, coll string, keyHash []byte, blocknum uint64, trannum uint64) []byte {
+	compositeKey := ConstructPartialCompositePvtDataHashHistoryKey(ns, coll, keyHash)
+	compositeKey = append(compositeKey, util.EncodeOrderPreservingVarUint64(blocknum)...)
+	compositeKey = append(compositeKey, util.EncodeOrderPreservingVarUint64(trannum)...)
+	return compositeKey
+}
+
+//ConstructPartialCompositePvtDataHashHistoryKey builds a partial History Key prefix~namespace~collection~keyhash~
+// for use in the history queries of the private data
+func ConstructPartialCompositePvtDataHashHistoryKey(ns string, coll string, keyHash []byte) []byte {
+	var compositeKey []byte
+	compositeKey = append(compositeKey, PvtDataHashHistoryKeyPrefix...)
+	compositeKey = append(compositeKey, []byte(ns)...)
+	compositeKey = append(compositeKey, CompositeKeySep...)
+	compositeKey = append(compositeKey, []byte(coll)...)
+	compositeKey = append(compositeKey, CompositeKeySep...)
+	compositeKey = append(compositeKey, keyHash...)
+	compositeKey = append(compositeKey, CompositeKeySep...)
+	return compositeKey
+}
@@ -46,3 +46,12 @@ func TestSplitCompositeKey(t *testing.T) {
 	// second position should hold the extra bytes that were split off
 	assert.Equal(t, []byte("extra bytes to split"), extraBytes)
 }
+
+func TestConstructPvtDataHashHistoryKey(t *testing.T) {
+	keyHash := []byte{0x00, 0x01, 0x02}
+	compositePartialKey := ConstructPartialCompositePvtDataHashHistoryKey("ns1", "coll1", keyHash)
+	assert.Equal(t, []byte(string([]byte{0x01})+"ns1"+strKeySep+"coll1"+strKeySep+string(keyHash)+strKeySep), compositePartialKey)
+
+	compositeKey := ConstructCompositePvtDataHashHistoryKey("ns1", "coll1", keyHash, 1, 2)
+	assert.Equal(t, append(compositePartialKey, []byte{0x01, 0x01, 0x01, 0x02}...), compositeKey)
+}
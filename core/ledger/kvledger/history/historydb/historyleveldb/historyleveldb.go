@@ -141,6 +141,17 @@ func (historyDB *historyDB) Commit(block *common.Block) error {
 					// No value is required, write an empty byte array (emptyValue) since Put() of nil is not allowed
 					dbBatch.Put(compositeHistoryKey, emptyValue)
 				}
+
+				// add a history record for each hashed private write so that the history of the private
+				// data can be queried even if the private data itself is not present on this peer
+				for _, collHashedRwSet := range nsRWSet.CollHashedRwSets {
+					coll := collHashedRwSet.CollectionName
+					for _, kvWriteHash := range collHashedRwSet.HashedRwSet.HashedWrites {
+						compositeHistoryKey := historydb.ConstructCompositePvtDataHashHistoryKey(ns, coll,
+							kvWriteHash.KeyHash, blockNo, tranNo)
+						dbBatch.Put(compositeHistoryKey, emptyValue)
+					}
+				}
 			}
 
 		} else {
@@ -8,13 +8,20 @@ package historyleveldb
 
 import (
 	"bytes"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
 
 	commonledger "github.com/hyperledger/fabric/common/ledger"
 	"github.com/hyperledger/fabric/common/ledger/blkstorage"
 	"github.com/hyperledger/fabric/common/ledger/util"
+	"github.com/hyperledger/fabric/core/ledger"
 	"github.com/hyperledger/fabric/core/ledger/kvledger/history/historydb"
 	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/rwsetutil"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/version"
 	"github.com/hyperledger/fabric/core/ledger/ledgerconfig"
+	ledgerutil "github.com/hyperledger/fabric/core/ledger/util"
 	"github.com/hyperledger/fabric/protos/common"
 	"github.com/hyperledger/fabric/protos/ledger/queryresult"
 	putils "github.com/hyperledger/fabric/protos/utils"
@@ -45,6 +52,270 @@ func (q *LevelHistoryDBQueryExecutor) GetHistoryForKey(namespace string, key str
 	return newHistoryScanner(compositeStartKey, namespace, key, dbItr, q.blockStore), nil
 }
 
+// GetHistoryForKeyWithMetadata implements method in interface `ledger.HistoryQueryExecutor`
+func (q *LevelHistoryDBQueryExecutor) GetHistoryForKeyWithMetadata(namespace string, key string,
+	metadata map[string]interface{}) (commonledger.QueryResultsIterator, error) {
+
+	if ledgerconfig.IsHistoryDBEnabled() == false {
+		return nil, errors.New("history database not enabled")
+	}
+	options, err := parseHistoryQueryMetadata(metadata)
+	if err != nil {
+		return nil, err
+	}
+
+	compositePartialKey := historydb.ConstructPartialCompositeHistoryKey(namespace, key, false)
+	compositeStartKey := compositePartialKey
+	compositeEndKey := historydb.ConstructPartialCompositeHistoryKey(namespace, key, true)
+	if options.startBlock > 0 {
+		compositeStartKey = historyKeyWithSuffix(compositePartialKey, util.EncodeOrderPreservingVarUint64(options.startBlock))
+	}
+	if options.hasEndBlock && options.endBlock < math.MaxUint64 {
+		compositeEndKey = historyKeyWithSuffix(compositePartialKey, util.EncodeOrderPreservingVarUint64(options.endBlock+1))
+	}
+	if options.bookmark != nil {
+		// the record referred by the bookmark is the first one to be returned
+		bookmarkKey := historyKeyWithSuffix(compositePartialKey,
+			util.EncodeOrderPreservingVarUint64(options.bookmark.BlockNum),
+			util.EncodeOrderPreservingVarUint64(options.bookmark.TxNum))
+		if !options.descending && bytes.Compare(bookmarkKey, compositeStartKey) > 0 {
+			compositeStartKey = bookmarkKey
+		}
+		if options.descending {
+			if bookmarkEndKey := append(bookmarkKey, 0x00); bytes.Compare(bookmarkEndKey, compositeEndKey) < 0 {
+				compositeEndKey = bookmarkEndKey
+			}
+		}
+	}
+
+	dbItr := q.historyDB.db.GetIterator(compositeStartKey, compositeEndKey)
+	scanner := newHistoryScanner(compositePartialKey, namespace, key, dbItr, q.blockStore)
+	scanner.descending = options.descending
+	scanner.limit = options.limit
+	return scanner, nil
+}
+
+// GetStateAtHeight implements method in interface `ledger.HistoryQueryExecutor`
+func (q *LevelHistoryDBQueryExecutor) GetStateAtHeight(namespace string, key string, blockNum uint64) ([]byte, error) {
+	if err := q.validateHeight(blockNum); err != nil {
+		return nil, err
+	}
+	return q.getValueAtHeight(namespace, key, blockNum)
+}
+
+// GetStateRangeScanIteratorAtHeight implements method in interface `ledger.HistoryQueryExecutor`
+func (q *LevelHistoryDBQueryExecutor) GetStateRangeScanIteratorAtHeight(namespace string, startKey string, endKey string,
+	blockNum uint64) (commonledger.ResultsIterator, error) {
+	if err := q.validateHeight(blockNum); err != nil {
+		return nil, err
+	}
+	nsPrefix := append([]byte(namespace), historydb.CompositeKeySep...)
+	compositeStartKey := append(append([]byte{}, nsPrefix...), []byte(startKey)...)
+	var compositeEndKey []byte
+	if endKey == "" {
+		// all the history keys of the namespace are lower than namespace~0x01
+		compositeEndKey = append([]byte(namespace), 0x01)
+	} else {
+		compositeEndKey = append(append([]byte{}, nsPrefix...), []byte(endKey)...)
+	}
+	dbItr := q.historyDB.db.GetIterator(compositeStartKey, compositeEndKey)
+	return &rangeScannerAtHeight{
+		queryExecutor: q,
+		nsPrefix:      nsPrefix,
+		namespace:     namespace,
+		startKey:      startKey,
+		endKey:        endKey,
+		blockNum:      blockNum,
+		dbItr:         dbItr,
+	}, nil
+}
+
+// GetPrivateDataHistoryForKey implements method in interface `ledger.HistoryQueryExecutor`
+func (q *LevelHistoryDBQueryExecutor) GetPrivateDataHistoryForKey(namespace string, collection string,
+	key string) (commonledger.ResultsIterator, error) {
+
+	if !ledgerconfig.IsHistoryDBEnabled() {
+		return nil, errors.New("history database not enabled")
+	}
+	keyHash := ledgerutil.ComputeStringHash(key)
+	compositePartialKey := historydb.ConstructPartialCompositePvtDataHashHistoryKey(namespace, collection, keyHash)
+	compositeEndKey := historyKeyWithSuffix(compositePartialKey, []byte{0xff})
+	dbItr := q.historyDB.db.GetIterator(compositePartialKey, compositeEndKey)
+	// the private data is available only if the block store is backed by the private data store as well
+	pvtdataRetriever, _ := q.blockStore.(pvtdataRetriever)
+	return &pvtDataHistoryScanner{
+		compositePartialKey: compositePartialKey,
+		namespace:           namespace,
+		collection:          collection,
+		key:                 key,
+		keyHash:             keyHash,
+		dbItr:               dbItr,
+		blockStore:          q.blockStore,
+		pvtdataRetriever:    pvtdataRetriever,
+	}, nil
+}
+
+// validateHeight checks that the history database is enabled and that the given block has been
+// committed to the history database
+func (q *LevelHistoryDBQueryExecutor) validateHeight(blockNum uint64) error {
+	if !ledgerconfig.IsHistoryDBEnabled() {
+		return errors.New("history database not enabled")
+	}
+	savepoint, err := q.historyDB.GetLastSavepoint()
+	if err != nil {
+		return err
+	}
+	if savepoint == nil {
+		return errors.Errorf("block number [%d] has not been committed to the history database", blockNum)
+	}
+	if blockNum > savepoint.BlockNum {
+		return errors.Errorf("block number [%d] is beyond the last block [%d] committed to the history database",
+			blockNum, savepoint.BlockNum)
+	}
+	return nil
+}
+
+// getValueAtHeight scans the history records of the key backwards, starting from the last record
+// that is not above the given block number, and returns the value written by the corresponding transaction
+func (q *LevelHistoryDBQueryExecutor) getValueAtHeight(namespace string, key string, blockNum uint64) ([]byte, error) {
+	compositePartialKey := historydb.ConstructPartialCompositeHistoryKey(namespace, key, false)
+	compositeEndKey := append(append([]byte{}, compositePartialKey...), util.EncodeOrderPreservingVarUint64(blockNum+1)...)
+	dbItr := q.historyDB.db.GetIterator(compositePartialKey, compositeEndKey)
+	defer dbItr.Release()
+
+	for ok := dbItr.Last(); ok; ok = dbItr.Prev() {
+		historyKey := dbItr.Key()
+		// the range may contain the records of other keys that carry the desired key followed by a nil byte
+		// as a prefix (FAB-11244). For such a record, the remaining bytes are not exactly a blocknum~trannum
+		recordBlockNum, tranNum, ok := decodeBlockNumTranNum(historyKey[len(compositePartialKey):])
+		if !ok {
+			logger.Debugf("Some other key [%#v] found in the range while looking up the value of key [%#v]. Skipping...",
+				historyKey, key)
+			continue
+		}
+		logger.Debugf("Found history record for namespace:%s key:%s at blockNumTranNum %v:%v as of block %d",
+			namespace, key, recordBlockNum, tranNum, blockNum)
+		tranEnvelope, err := q.blockStore.RetrieveTxByBlockNumTranNum(recordBlockNum, tranNum)
+		if err != nil {
+			return nil, err
+		}
+		queryResult, err := getKeyModificationFromTran(tranEnvelope, namespace, key)
+		if err != nil {
+			return nil, err
+		}
+		keyModification := queryResult.(*queryresult.KeyModification)
+		if keyModification.IsDelete {
+			return nil, nil
+		}
+		return keyModification.Value, nil
+	}
+	return nil, dbItr.Error()
+}
+
+// decodeBlockNumTranNum decodes the blocknum~trannum suffix of a history key. The returned bool
+// is false if the given bytes are not exactly an encoded blocknum followed by an encoded trannum
+func decodeBlockNumTranNum(b []byte) (uint64, uint64, bool) {
+	var nums [2]uint64
+	for i := range nums {
+		// the first byte is the number of bytes that encode the number
+		if len(b) == 0 || b[0] > 8 || len(b) < int(b[0])+1 {
+			return 0, 0, false
+		}
+		num, bytesConsumed := util.DecodeOrderPreservingVarUint64(b)
+		nums[i] = num
+		b = b[bytesConsumed:]
+	}
+	if len(b) != 0 {
+		return 0, 0, false
+	}
+	return nums[0], nums[1], true
+}
+
+// rangeScannerAtHeight implements ResultsIterator for iterating through the key-values of a
+// key range as of a given block number
+type rangeScannerAtHeight struct {
+	queryExecutor *LevelHistoryDBQueryExecutor
+	nsPrefix      []byte //nsPrefix includes namespace~
+	namespace     string
+	startKey      string
+	endKey        string
+	blockNum      uint64
+	dbItr         iterator.Iterator
+	// visitedKeys holds the keys already returned (or skipped) whose range of history records
+	// may still contain the records of the keys that follow. This is possible only for the keys
+	// that carry another key followed by a nil byte as a prefix (FAB-11244)
+	visitedKeys []string
+}
+
+func (scanner *rangeScannerAtHeight) Next() (commonledger.QueryResult, error) {
+	for scanner.dbItr.Next() {
+		historyKey := scanner.dbItr.Key()
+		key, ok := splitKeyFromHistoryKey(historyKey[len(scanner.nsPrefix):])
+		if !ok {
+			logger.Warningf("Unexpected history key [%#v] found in namespace [%s]. Skipping...", historyKey, scanner.namespace)
+			continue
+		}
+		if scanner.isVisited(historyKey, key) || !scanner.inRange(key) {
+			continue
+		}
+		scanner.visitedKeys = append(scanner.visitedKeys, key)
+		value, err := scanner.queryExecutor.getValueAtHeight(scanner.namespace, key, scanner.blockNum)
+		if err != nil {
+			return nil, err
+		}
+		if value == nil {
+			continue
+		}
+		return &queryresult.KV{Namespace: scanner.namespace, Key: key, Value: value}, nil
+	}
+	return nil, scanner.dbItr.Error()
+}
+
+// isVisited returns true if the key has already been visited. The history records of a key are
+// contiguous except for the records of the other keys that carry the key followed by a nil byte
+// as a prefix. Hence, a visited key needs to be remembered only until the scan moves past the range
+// namespace~key~ of the key
+func (scanner *rangeScannerAtHeight) isVisited(historyKey []byte, key string) bool {
+	for len(scanner.visitedKeys) > 0 {
+		lastVisitedKey := scanner.visitedKeys[len(scanner.visitedKeys)-1]
+		lastVisitedKeyPrefix := historydb.ConstructPartialCompositeHistoryKey(scanner.namespace, lastVisitedKey, false)
+		if bytes.HasPrefix(historyKey, lastVisitedKeyPrefix) {
+			break
+		}
+		scanner.visitedKeys = scanner.visitedKeys[:len(scanner.visitedKeys)-1]
+	}
+	for _, visitedKey := range scanner.visitedKeys {
+		if visitedKey == key {
+			return true
+		}
+	}
+	return false
+}
+
+// inRange filters the keys that fall in the scanned range of history keys only because the start key
+// carries the key followed by a nil byte as a prefix
+func (scanner *rangeScannerAtHeight) inRange(key string) bool {
+	return key >= scanner.startKey && (scanner.endKey == "" || key < scanner.endKey)
+}
+
+func (scanner *rangeScannerAtHeight) Close() {
+	scanner.dbItr.Release()
+}
+
+// splitKeyFromHistoryKey returns the key from the bytes key~blocknum~trannum. As the key may itself contain
+// nil bytes (FAB-11244), the first nil byte that is followed by exactly a blocknum~trannum separates the key
+func splitKeyFromHistoryKey(b []byte) (string, bool) {
+	for i := 0; i < len(b); i++ {
+		if b[i] != historydb.CompositeKeySep[0] {
+			continue
+		}
+		if _, _, ok := decodeBlockNumTranNum(b[i+1:]); ok {
+			return string(b[:i]), true
+		}
+	}
+	return "", false
+}
+
 //historyScanner implements ResultsIterator for iterating through history results
 type historyScanner struct {
 	compositePartialKey []byte //compositePartialKey includes namespace~key
@@ -52,18 +323,55 @@ type historyScanner struct {
 	key                 string
 	dbItr               iterator.Iterator
 	blockStore          blkstorage.BlockStore
+	descending          bool
+	limit               int32
+	numReturned         int32
+	started             bool
 }
 
 func newHistoryScanner(compositePartialKey []byte, namespace string, key string,
 	dbItr iterator.Iterator, blockStore blkstorage.BlockStore) *historyScanner {
-	return &historyScanner{compositePartialKey, namespace, key, dbItr, blockStore}
+	return &historyScanner{
+		compositePartialKey: compositePartialKey,
+		namespace:           namespace,
+		key:                 key,
+		dbItr:               dbItr,
+		blockStore:          blockStore,
+	}
 }
 
 func (scanner *historyScanner) Next() (commonledger.QueryResult, error) {
-	for {
-		if !scanner.dbItr.Next() {
-			return nil, nil
-		}
+	if scanner.limit > 0 && scanner.numReturned >= scanner.limit {
+		return nil, nil
+	}
+	blockNum, tranNum, ok := scanner.nextRecord()
+	if !ok {
+		return nil, nil
+	}
+	logger.Debugf("Found history record for namespace:%s key:%s at blockNumTranNum %v:%v\n",
+		scanner.namespace, scanner.key, blockNum, tranNum)
+
+	// Get the transaction from block storage that is associated with this history record
+	tranEnvelope, err := scanner.blockStore.RetrieveTxByBlockNumTranNum(blockNum, tranNum)
+	if err != nil {
+		return nil, err
+	}
+
+	// Get the txid, key write value, timestamp, and delete indicator associated with this transaction
+	queryResult, err := getKeyModificationFromTran(tranEnvelope, scanner.namespace, scanner.key)
+	if err != nil {
+		return nil, err
+	}
+	logger.Debugf("Found historic key value for namespace:%s key:%s from transaction %s\n",
+		scanner.namespace, scanner.key, queryResult.(*queryresult.KeyModification).TxId)
+	scanner.numReturned++
+	return queryResult, nil
+}
+
+// nextRecord moves the db iterator to the next history record of the key (in the scan order)
+// and returns the block number and the transaction number of the record
+func (scanner *historyScanner) nextRecord() (uint64, uint64, bool) {
+	for scanner.moveNext() {
 		historyKey := scanner.dbItr.Key() // history key is in the form namespace~key~blocknum~trannum
 
 		// SplitCompositeKey(namespace~key~blocknum~trannum, namespace~key~) will return the blocknum~trannum in second position
@@ -89,30 +397,38 @@ func (scanner *historyScanner) Next() (commonledger.QueryResult, error) {
 		}
 		blockNum, bytesConsumed := util.DecodeOrderPreservingVarUint64(blockNumTranNumBytes[0:])
 		tranNum, _ := util.DecodeOrderPreservingVarUint64(blockNumTranNumBytes[bytesConsumed:])
-		logger.Debugf("Found history record for namespace:%s key:%s at blockNumTranNum %v:%v\n",
-			scanner.namespace, scanner.key, blockNum, tranNum)
-
-		// Get the transaction from block storage that is associated with this history record
-		tranEnvelope, err := scanner.blockStore.RetrieveTxByBlockNumTranNum(blockNum, tranNum)
-		if err != nil {
-			return nil, err
-		}
+		return blockNum, tranNum, true
+	}
+	return 0, 0, false
+}
 
-		// Get the txid, key write value, timestamp, and delete indicator associated with this transaction
-		queryResult, err := getKeyModificationFromTran(tranEnvelope, scanner.namespace, scanner.key)
-		if err != nil {
-			return nil, err
-		}
-		logger.Debugf("Found historic key value for namespace:%s key:%s from transaction %s\n",
-			scanner.namespace, scanner.key, queryResult.(*queryresult.KeyModification).TxId)
-		return queryResult, nil
+// moveNext moves the db iterator forward, or backward if the newest records are to be returned first
+func (scanner *historyScanner) moveNext() bool {
+	if !scanner.descending {
+		return scanner.dbItr.Next()
+	}
+	if !scanner.started {
+		scanner.started = true
+		return scanner.dbItr.Last()
 	}
+	return scanner.dbItr.Prev()
 }
 
 func (scanner *historyScanner) Close() {
 	scanner.dbItr.Release()
 }
 
+// GetBookmarkAndClose returns the bookmark that refers to the next history record, if any,
+// and releases the db iterator
+func (scanner *historyScanner) GetBookmarkAndClose() string {
+	retval := ""
+	if blockNum, tranNum, ok := scanner.nextRecord(); ok {
+		retval = encodeHistoryBookmark(&version.Height{BlockNum: blockNum, TxNum: tranNum})
+	}
+	scanner.Close()
+	return retval
+}
+
 // getTxIDandKeyWriteValueFromTran inspects a transaction for writes to a given key
 func getKeyModificationFromTran(tranEnvelope *common.Envelope, namespace string, key string) (commonledger.QueryResult, error) {
 	logger.Debugf("Entering getKeyModificationFromTran()\n", namespace, key)
@@ -165,3 +481,226 @@ func getKeyModificationFromTran(tranEnvelope *common.Envelope, namespace string,
 	return nil, errors.New("namespace not found in transaction's ReadWriteSets")
 
 }
+
+// pvtdataRetriever retrieves the private data committed with a block
+type pvtdataRetriever interface {
+	GetPvtDataByNum(blockNum uint64, filter ledger.PvtNsCollFilter) ([]*ledger.TxPvtData, error)
+}
+
+// pvtDataHistoryScanner implements ResultsIterator for iterating through the history of a private data key
+type pvtDataHistoryScanner struct {
+	compositePartialKey []byte //compositePartialKey includes prefix~namespace~collection~keyhash
+	namespace           string
+	collection          string
+	key                 string
+	keyHash             []byte
+	dbItr               iterator.Iterator
+	blockStore          blkstorage.BlockStore
+	pvtdataRetriever    pvtdataRetriever
+}
+
+func (scanner *pvtDataHistoryScanner) Next() (commonledger.QueryResult, error) {
+	for scanner.dbItr.Next() {
+		historyKey := scanner.dbItr.Key() // history key is in the form prefix~namespace~collection~keyhash~blocknum~trannum
+		// the key hash is of fixed length, so the remaining bytes are always the blocknum~trannum
+		blockNum, tranNum, ok := decodeBlockNumTranNum(historyKey[len(scanner.compositePartialKey):])
+		if !ok {
+			logger.Warningf("Skipping the malformed history record [%#v] of the private data", historyKey)
+			continue
+		}
+		logger.Debugf("Found private data history record for namespace:%s collection:%s key:%s at blockNumTranNum %v:%v",
+			scanner.namespace, scanner.collection, scanner.key, blockNum, tranNum)
+
+		tranEnvelope, err := scanner.blockStore.RetrieveTxByBlockNumTranNum(blockNum, tranNum)
+		if err != nil {
+			return nil, err
+		}
+		keyModification, err := getPvtDataKeyModificationFromTran(tranEnvelope, scanner.namespace, scanner.collection, scanner.keyHash)
+		if err != nil {
+			return nil, err
+		}
+		keyModification.BlockNum = blockNum
+		keyModification.TxNum = tranNum
+		if !keyModification.IsDelete {
+			if keyModification.Value, err = scanner.retrieveValue(blockNum, tranNum); err != nil {
+				return nil, err
+			}
+		}
+		return keyModification, nil
+	}
+	return nil, nil
+}
+
+// retrieveValue returns the cleartext value of the key from the private data committed with the given
+// transaction. A nil value is returned if the private data is not available on the peer
+func (scanner *pvtDataHistoryScanner) retrieveValue(blockNum uint64, tranNum uint64) ([]byte, error) {
+	if scanner.pvtdataRetriever == nil {
+		return nil, nil
+	}
+	filter := ledger.NewPvtNsCollFilter()
+	filter.Add(scanner.namespace, scanner.collection)
+	txsPvtData, err := scanner.pvtdataRetriever.GetPvtDataByNum(blockNum, filter)
+	if err != nil {
+		return nil, err
+	}
+	for _, txPvtData := range txsPvtData {
+		if txPvtData.SeqInBlock != tranNum || txPvtData.WriteSet == nil {
+			continue
+		}
+		txPvtRWSet, err := rwsetutil.TxPvtRwSetFromProtoMsg(txPvtData.WriteSet)
+		if err != nil {
+			return nil, err
+		}
+		for _, nsPvtRWSet := range txPvtRWSet.NsPvtRwSet {
+			if nsPvtRWSet.NameSpace != scanner.namespace {
+				continue
+			}
+			for _, collPvtRWSet := range nsPvtRWSet.CollPvtRwSets {
+				if collPvtRWSet.CollectionName != scanner.collection {
+					continue
+				}
+				for _, kvWrite := range collPvtRWSet.KvRwSet.Writes {
+					if kvWrite.Key == scanner.key {
+						return kvWrite.Value, nil
+					}
+				}
+			}
+		}
+	}
+	return nil, nil
+}
+
+func (scanner *pvtDataHistoryScanner) Close() {
+	scanner.dbItr.Release()
+}
+
+// getPvtDataKeyModificationFromTran inspects a transaction for the hashed write to a given key of a collection
+func getPvtDataKeyModificationFromTran(tranEnvelope *common.Envelope, namespace string, collection string,
+	keyHash []byte) (*queryresult.PrivateDataKeyModification, error) {
+
+	payload, err := putils.GetPayload(tranEnvelope)
+	if err != nil {
+		return nil, err
+	}
+	tx, err := putils.GetTransaction(payload.Data)
+	if err != nil {
+		return nil, err
+	}
+	_, respPayload, err := putils.GetPayloads(tx.Actions[0])
+	if err != nil {
+		return nil, err
+	}
+	chdr, err := putils.UnmarshalChannelHeader(payload.Header.ChannelHeader)
+	if err != nil {
+		return nil, err
+	}
+	txRWSet := &rwsetutil.TxRwSet{}
+	if err = txRWSet.FromProtoBytes(respPayload.Results); err != nil {
+		return nil, err
+	}
+
+	for _, nsRWSet := range txRWSet.NsRwSets {
+		if nsRWSet.NameSpace != namespace {
+			continue
+		}
+		for _, collHashedRWSet := range nsRWSet.CollHashedRwSets {
+			if collHashedRWSet.CollectionName != collection {
+				continue
+			}
+			for _, kvWriteHash := range collHashedRWSet.HashedRwSet.HashedWrites {
+				if bytes.Equal(kvWriteHash.KeyHash, keyHash) {
+					return &queryresult.PrivateDataKeyModification{TxId: chdr.TxId, Timestamp: chdr.Timestamp,
+						ValueHash: kvWriteHash.ValueHash, IsDelete: kvWriteHash.IsDelete}, nil
+				}
+			}
+			return nil, errors.New("key hash not found in collection's hashed writeset")
+		}
+		return nil, errors.New("collection not found in namespace's hashed ReadWriteSets")
+	}
+	return nil, errors.New("namespace not found in transaction's ReadWriteSets")
+}
+
+const (
+	optionStartBlock = "startBlock"
+	optionEndBlock   = "endBlock"
+	optionDescending = "descending"
+	optionLimit      = "limit"
+	optionBookmark   = "bookmark"
+)
+
+// historyQueryOptions holds the options for a history query that are supplied as the query metadata
+type historyQueryOptions struct {
+	startBlock  uint64
+	endBlock    uint64
+	hasEndBlock bool
+	descending  bool
+	limit       int32
+	bookmark    *version.Height
+}
+
+func parseHistoryQueryMetadata(metadata map[string]interface{}) (*historyQueryOptions, error) {
+	options := &historyQueryOptions{}
+	for key, keyVal := range metadata {
+		var ok bool
+		switch key {
+		case optionStartBlock:
+			options.startBlock, ok = keyVal.(uint64)
+		case optionEndBlock:
+			options.endBlock, ok = keyVal.(uint64)
+			options.hasEndBlock = true
+		case optionDescending:
+			options.descending, ok = keyVal.(bool)
+		case optionLimit:
+			options.limit, ok = keyVal.(int32)
+		case optionBookmark:
+			var bookmark string
+			if bookmark, ok = keyVal.(string); ok && bookmark != "" {
+				var err error
+				if options.bookmark, err = decodeHistoryBookmark(bookmark); err != nil {
+					return nil, err
+				}
+			}
+		default:
+			return nil, errors.Errorf("invalid entry, option %s not recognized", key)
+		}
+		if !ok {
+			return nil, errors.Errorf("invalid entry, option %s has an unexpected type %T", key, keyVal)
+		}
+	}
+	if options.hasEndBlock && options.endBlock < options.startBlock {
+		return nil, errors.Errorf("end block [%d] is less than the start block [%d]", options.endBlock, options.startBlock)
+	}
+	if options.limit < 0 {
+		return nil, errors.Errorf("limit [%d] must not be negative", options.limit)
+	}
+	return options, nil
+}
+
+// encodeHistoryBookmark encodes the height of a history record as a bookmark of the form blocknum:trannum
+func encodeHistoryBookmark(height *version.Height) string {
+	return fmt.Sprintf("%d:%d", height.BlockNum, height.TxNum)
+}
+
+func decodeHistoryBookmark(bookmark string) (*version.Height, error) {
+	parts := strings.Split(bookmark, ":")
+	if len(parts) != 2 {
+		return nil, errors.Errorf("invalid bookmark [%s]", bookmark)
+	}
+	blockNum, err := strconv.ParseUint(parts[0], 10, 64)
+	if err != nil {
+		return nil, errors.Errorf("invalid bookmark [%s]", bookmark)
+	}
+	tranNum, err := strconv.ParseUint(parts[1], 10, 64)
+	if err != nil {
+		return nil, errors.Errorf("invalid bookmark [%s]", bookmark)
+	}
+	return version.NewHeight(blockNum, tranNum), nil
+}
+
+func historyKeyWithSuffix(compositePartialKey []byte, suffixes ...[]byte) []byte {
+	historyKey := append([]byte{}, compositePartialKey...)
+	for _, suffix := range suffixes {
+		historyKey = append(historyKey, suffix...)
+	}
+	return historyKey
+}
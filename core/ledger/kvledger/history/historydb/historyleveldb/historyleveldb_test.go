@@ -13,7 +13,9 @@ import (
 
 	configtxtest "github.com/hyperledger/fabric/common/configtx/test"
 	"github.com/hyperledger/fabric/common/flogging"
+	"github.com/hyperledger/fabric/common/ledger/blkstorage"
 	"github.com/hyperledger/fabric/common/ledger/testutil"
+	ledgerutil "github.com/hyperledger/fabric/common/ledger/util"
 	util2 "github.com/hyperledger/fabric/common/util"
 	"github.com/hyperledger/fabric/core/ledger"
 	"github.com/hyperledger/fabric/core/ledger/util"
@@ -337,3 +339,414 @@ func testutilVerifyResults(t *testing.T, hqe ledger.HistoryQueryExecutor, ns, ke
 	}
 	assert.Equal(t, expectedVals, retrievedVals)
 }
+
+func TestStateAtHeight(t *testing.T) {
+	env := newTestHistoryEnv(t)
+	defer env.cleanup()
+	provider := env.testBlockStorageEnv.provider
+	ledger1id := "ledger1"
+	store1, err := provider.OpenBlockStore(ledger1id)
+	assert.NoError(t, err, "Error upon provider.OpenBlockStore()")
+	defer store1.Shutdown()
+
+	bg, gb := testutil.NewBlockGenerator(t, ledger1id, false)
+	assert.NoError(t, store1.AddBlock(gb))
+	assert.NoError(t, env.testHistoryDB.Commit(gb))
+
+	commitBlock := func(simulate ...func(ledger.TxSimulator)) {
+		simulationResults := [][]byte{}
+		for _, s := range simulate {
+			simulator, _ := env.txmgr.NewTxSimulator(util2.GenerateUUID())
+			s(simulator)
+			simulator.Done()
+			simRes, _ := simulator.GetTxSimulationResults()
+			pubSimResBytes, _ := simRes.GetPubSimulationBytes()
+			simulationResults = append(simulationResults, pubSimResBytes)
+		}
+		block := bg.NextBlock(simulationResults)
+		assert.NoError(t, store1.AddBlock(block))
+		assert.NoError(t, env.testHistoryDB.Commit(block))
+	}
+
+	//block1
+	commitBlock(func(s ledger.TxSimulator) {
+		s.SetState("ns1", "key1", []byte("value1_1"))
+		s.SetState("ns1", "key2", []byte("value2_1"))
+		s.SetState("ns2", "key1", []byte("ns2_value1_1"))
+	})
+	//block2
+	commitBlock(
+		func(s ledger.TxSimulator) {
+			s.SetState("ns1", "key1", []byte("value1_2"))
+		},
+		func(s ledger.TxSimulator) {
+			s.SetState("ns1", "key1", []byte("value1_3"))
+			s.SetState("ns1", "key3", []byte("value3_1"))
+			// a key that carries another key followed by a nil byte as a prefix (FAB-11244)
+			s.SetState("ns1", "key1\x00\x01\x01", []byte("dummyVal1"))
+		},
+	)
+	//block3
+	commitBlock(func(s ledger.TxSimulator) {
+		s.DeleteState("ns1", "key1")
+		s.SetState("ns1", "key2", []byte("value2_2"))
+	})
+
+	qhistory, err := env.testHistoryDB.NewHistoryQueryExecutor(store1)
+	assert.NoError(t, err, "Error upon NewHistoryQueryExecutor")
+
+	testCases := []struct {
+		key           string
+		blockNum      uint64
+		expectedValue []byte
+	}{
+		{"key1", 0, nil},
+		{"key1", 1, []byte("value1_1")},
+		{"key1", 2, []byte("value1_3")},
+		{"key1", 3, nil},
+		{"key2", 2, []byte("value2_1")},
+		{"key2", 3, []byte("value2_2")},
+		{"key3", 1, nil},
+		{"key3", 3, []byte("value3_1")},
+		{"key1\x00\x01\x01", 1, nil},
+		{"key1\x00\x01\x01", 2, []byte("dummyVal1")},
+		{"non-existing-key", 3, nil},
+	}
+	for _, tc := range testCases {
+		value, err := qhistory.GetStateAtHeight("ns1", tc.key, tc.blockNum)
+		assert.NoError(t, err)
+		assert.Equal(t, tc.expectedValue, value, "key=%q blockNum=%d", tc.key, tc.blockNum)
+	}
+	value, err := qhistory.GetStateAtHeight("ns2", "key1", 3)
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("ns2_value1_1"), value)
+
+	_, err = qhistory.GetStateAtHeight("ns1", "key1", 4)
+	assert.EqualError(t, err, "block number [4] is beyond the last block [3] committed to the history database")
+
+	testutilVerifyRangeResultsAtHeight(t, qhistory, "ns1", "", "", 1,
+		[]string{"key1", "value1_1", "key2", "value2_1"})
+	testutilVerifyRangeResultsAtHeight(t, qhistory, "ns1", "", "", 2,
+		[]string{"key1", "value1_3", "key1\x00\x01\x01", "dummyVal1", "key2", "value2_1", "key3", "value3_1"})
+	testutilVerifyRangeResultsAtHeight(t, qhistory, "ns1", "", "", 3,
+		[]string{"key1\x00\x01\x01", "dummyVal1", "key2", "value2_2", "key3", "value3_1"})
+	testutilVerifyRangeResultsAtHeight(t, qhistory, "ns1", "key1\x00", "key3", 2,
+		[]string{"key1\x00\x01\x01", "dummyVal1", "key2", "value2_1"})
+	testutilVerifyRangeResultsAtHeight(t, qhistory, "ns2", "", "", 3,
+		[]string{"key1", "ns2_value1_1"})
+
+	_, err = qhistory.GetStateRangeScanIteratorAtHeight("ns1", "", "", 4)
+	assert.EqualError(t, err, "block number [4] is beyond the last block [3] committed to the history database")
+}
+
+func TestStateAtHeightHistoryDisabled(t *testing.T) {
+	env := newTestHistoryEnv(t)
+	defer env.cleanup()
+	viper.Set("ledger.history.enableHistoryDatabase", "false")
+	qhistory, err := env.testHistoryDB.NewHistoryQueryExecutor(nil)
+	assert.NoError(t, err, "Error upon NewHistoryQueryExecutor")
+	_, err = qhistory.GetStateAtHeight("ns1", "key7", 0)
+	assert.EqualError(t, err, "history database not enabled")
+	_, err = qhistory.GetStateRangeScanIteratorAtHeight("ns1", "", "", 0)
+	assert.EqualError(t, err, "history database not enabled")
+}
+
+func TestDecodeBlockNumTranNum(t *testing.T) {
+	blockNum, tranNum, ok := decodeBlockNumTranNum(append(ledgerutil.EncodeOrderPreservingVarUint64(300),
+		ledgerutil.EncodeOrderPreservingVarUint64(0)...))
+	assert.True(t, ok)
+	assert.Equal(t, uint64(300), blockNum)
+	assert.Equal(t, uint64(0), tranNum)
+
+	for _, b := range [][]byte{{}, {0x00}, {0x09, 0x00}, {0x02, 0x01}, {0x00, 0x00, 0x00}} {
+		_, _, ok := decodeBlockNumTranNum(b)
+		assert.False(t, ok, "bytes=%#v", b)
+	}
+}
+
+func testutilVerifyRangeResultsAtHeight(t *testing.T, hqe ledger.HistoryQueryExecutor, ns, startKey, endKey string,
+	blockNum uint64, expectedKVs []string) {
+	itr, err := hqe.GetStateRangeScanIteratorAtHeight(ns, startKey, endKey, blockNum)
+	assert.NoError(t, err, "Error upon GetStateRangeScanIteratorAtHeight()")
+	defer itr.Close()
+	retrievedKVs := []string{}
+	for {
+		kv, err := itr.Next()
+		assert.NoError(t, err)
+		if kv == nil {
+			break
+		}
+		assert.Equal(t, ns, kv.(*queryresult.KV).Namespace)
+		retrievedKVs = append(retrievedKVs, kv.(*queryresult.KV).Key, string(kv.(*queryresult.KV).Value))
+	}
+	assert.Equal(t, expectedKVs, retrievedKVs)
+}
+
+func TestHistoryWithMetadata(t *testing.T) {
+	env := newTestHistoryEnv(t)
+	defer env.cleanup()
+	provider := env.testBlockStorageEnv.provider
+	ledger1id := "ledger1"
+	store1, err := provider.OpenBlockStore(ledger1id)
+	assert.NoError(t, err, "Error upon provider.OpenBlockStore()")
+	defer store1.Shutdown()
+
+	bg, gb := testutil.NewBlockGenerator(t, ledger1id, false)
+	assert.NoError(t, store1.AddBlock(gb))
+	assert.NoError(t, env.testHistoryDB.Commit(gb))
+
+	commitBlock := func(values ...string) {
+		simulationResults := [][]byte{}
+		for _, value := range values {
+			simulator, _ := env.txmgr.NewTxSimulator(util2.GenerateUUID())
+			if value == "" {
+				simulator.DeleteState("ns1", "key7")
+			} else {
+				simulator.SetState("ns1", "key7", []byte(value))
+			}
+			simulator.Done()
+			simRes, _ := simulator.GetTxSimulationResults()
+			pubSimResBytes, _ := simRes.GetPubSimulationBytes()
+			simulationResults = append(simulationResults, pubSimResBytes)
+		}
+		block := bg.NextBlock(simulationResults)
+		assert.NoError(t, store1.AddBlock(block))
+		assert.NoError(t, env.testHistoryDB.Commit(block))
+	}
+	commitBlock("value1")           // block1
+	commitBlock("value2", "value3") // block2
+	commitBlock("value4")           // block3
+	commitBlock("")                 // block4 deletes the key
+
+	qhistory, err := env.testHistoryDB.NewHistoryQueryExecutor(store1)
+	assert.NoError(t, err, "Error upon NewHistoryQueryExecutor")
+
+	testCases := []struct {
+		name             string
+		metadata         map[string]interface{}
+		expectedVals     []string
+		expectedBookmark string
+	}{
+		{
+			name:         "no metadata",
+			metadata:     nil,
+			expectedVals: []string{"value1", "value2", "value3", "value4", "<deleted>"},
+		},
+		{
+			name:         "block range",
+			metadata:     map[string]interface{}{"startBlock": uint64(2), "endBlock": uint64(3)},
+			expectedVals: []string{"value2", "value3", "value4"},
+		},
+		{
+			name:         "start block only",
+			metadata:     map[string]interface{}{"startBlock": uint64(3)},
+			expectedVals: []string{"value4", "<deleted>"},
+		},
+		{
+			name:         "descending",
+			metadata:     map[string]interface{}{"descending": true},
+			expectedVals: []string{"<deleted>", "value4", "value3", "value2", "value1"},
+		},
+		{
+			name:         "descending with block range",
+			metadata:     map[string]interface{}{"descending": true, "startBlock": uint64(2), "endBlock": uint64(2)},
+			expectedVals: []string{"value3", "value2"},
+		},
+		{
+			name:             "first page",
+			metadata:         map[string]interface{}{"limit": int32(2)},
+			expectedVals:     []string{"value1", "value2"},
+			expectedBookmark: "2:1",
+		},
+		{
+			name:             "second page",
+			metadata:         map[string]interface{}{"limit": int32(2), "bookmark": "2:1"},
+			expectedVals:     []string{"value3", "value4"},
+			expectedBookmark: "4:0",
+		},
+		{
+			name:             "last page",
+			metadata:         map[string]interface{}{"limit": int32(2), "bookmark": "4:0"},
+			expectedVals:     []string{"<deleted>"},
+			expectedBookmark: "",
+		},
+		{
+			name:             "first page descending",
+			metadata:         map[string]interface{}{"limit": int32(2), "descending": true},
+			expectedVals:     []string{"<deleted>", "value4"},
+			expectedBookmark: "2:1",
+		},
+		{
+			name:             "second page descending",
+			metadata:         map[string]interface{}{"limit": int32(2), "descending": true, "bookmark": "2:1"},
+			expectedVals:     []string{"value3", "value2"},
+			expectedBookmark: "1:0",
+		},
+		{
+			name:             "page ending with the block range",
+			metadata:         map[string]interface{}{"limit": int32(3), "endBlock": uint64(2)},
+			expectedVals:     []string{"value1", "value2", "value3"},
+			expectedBookmark: "",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			itr, err := qhistory.GetHistoryForKeyWithMetadata("ns1", "key7", tc.metadata)
+			assert.NoError(t, err)
+			retrievedVals := []string{}
+			for {
+				kmod, err := itr.Next()
+				assert.NoError(t, err)
+				if kmod == nil {
+					break
+				}
+				if kmod.(*queryresult.KeyModification).IsDelete {
+					retrievedVals = append(retrievedVals, "<deleted>")
+					continue
+				}
+				retrievedVals = append(retrievedVals, string(kmod.(*queryresult.KeyModification).Value))
+			}
+			assert.Equal(t, tc.expectedVals, retrievedVals)
+			assert.Equal(t, tc.expectedBookmark, itr.GetBookmarkAndClose())
+		})
+	}
+
+	errorTestCases := []struct {
+		metadata      map[string]interface{}
+		expectedError string
+	}{
+		{map[string]interface{}{"sort": "asc"}, "invalid entry, option sort not recognized"},
+		{map[string]interface{}{"startBlock": 2}, "invalid entry, option startBlock has an unexpected type int"},
+		{map[string]interface{}{"limit": int64(2)}, "invalid entry, option limit has an unexpected type int64"},
+		{map[string]interface{}{"limit": int32(-1)}, "limit [-1] must not be negative"},
+		{map[string]interface{}{"startBlock": uint64(3), "endBlock": uint64(2)}, "end block [2] is less than the start block [3]"},
+		{map[string]interface{}{"bookmark": "key7"}, "invalid bookmark [key7]"},
+		{map[string]interface{}{"bookmark": "a:1"}, "invalid bookmark [a:1]"},
+	}
+	for _, tc := range errorTestCases {
+		_, err := qhistory.GetHistoryForKeyWithMetadata("ns1", "key7", tc.metadata)
+		assert.EqualError(t, err, tc.expectedError)
+	}
+}
+
+func TestPrivateDataHistory(t *testing.T) {
+	env := newTestHistoryEnv(t)
+	defer env.cleanup()
+	provider := env.testBlockStorageEnv.provider
+	ledger1id := "ledger1"
+	store1, err := provider.OpenBlockStore(ledger1id)
+	assert.NoError(t, err, "Error upon provider.OpenBlockStore()")
+	defer store1.Shutdown()
+	pvtdataStore := &testPvtdataBlockStore{BlockStore: store1, pvtdata: map[uint64][]*ledger.TxPvtData{}}
+
+	bg, gb := testutil.NewBlockGenerator(t, ledger1id, false)
+	assert.NoError(t, store1.AddBlock(gb))
+	assert.NoError(t, env.testHistoryDB.Commit(gb))
+
+	type pvtWrite struct {
+		coll, key, value string
+	}
+	commitBlock := func(retainPvtdata bool, txsWrites ...[]pvtWrite) {
+		simulationResults := [][]byte{}
+		txsPvtdata := []*ledger.TxPvtData{}
+		for i, txWrites := range txsWrites {
+			simulator, _ := env.txmgr.NewTxSimulator(util2.GenerateUUID())
+			for _, w := range txWrites {
+				if w.value == "" {
+					assert.NoError(t, simulator.DeletePrivateData("ns1", w.coll, w.key))
+				} else {
+					assert.NoError(t, simulator.SetPrivateData("ns1", w.coll, w.key, []byte(w.value)))
+				}
+			}
+			simulator.Done()
+			simRes, err := simulator.GetTxSimulationResults()
+			assert.NoError(t, err)
+			pubSimResBytes, _ := simRes.GetPubSimulationBytes()
+			simulationResults = append(simulationResults, pubSimResBytes)
+			txsPvtdata = append(txsPvtdata, &ledger.TxPvtData{SeqInBlock: uint64(i), WriteSet: simRes.PvtSimulationResults})
+		}
+		block := bg.NextBlock(simulationResults)
+		assert.NoError(t, store1.AddBlock(block))
+		assert.NoError(t, env.testHistoryDB.Commit(block))
+		if retainPvtdata {
+			pvtdataStore.pvtdata[block.Header.Number] = txsPvtdata
+		}
+	}
+	// block1
+	commitBlock(true, []pvtWrite{{"coll1", "key1", "value1"}})
+	// block2 - the private data is not available, e.g., purged or missing
+	commitBlock(false, []pvtWrite{{"coll2", "key1", "value1-coll2"}}, []pvtWrite{{"coll1", "key1", "value2"}})
+	// block3
+	commitBlock(true, []pvtWrite{{"coll1", "key1", ""}, {"coll1", "key2", "value1-key2"}})
+
+	expectedModifications := []*queryresult.PrivateDataKeyModification{
+		{BlockNum: 1, TxNum: 0, Value: []byte("value1"), ValueHash: util.ComputeStringHash("value1")},
+		{BlockNum: 2, TxNum: 1, ValueHash: util.ComputeStringHash("value2")},
+		{BlockNum: 3, TxNum: 0, IsDelete: true},
+	}
+	verifyModifications := func(qhistory ledger.HistoryQueryExecutor, coll, key string,
+		expectedModifications []*queryresult.PrivateDataKeyModification) {
+		itr, err := qhistory.GetPrivateDataHistoryForKey("ns1", coll, key)
+		assert.NoError(t, err)
+		defer itr.Close()
+		for _, expected := range expectedModifications {
+			res, err := itr.Next()
+			assert.NoError(t, err)
+			assert.NotNil(t, res)
+			kmod := res.(*queryresult.PrivateDataKeyModification)
+			assert.NotEmpty(t, kmod.TxId)
+			assert.NotNil(t, kmod.Timestamp)
+			assert.Equal(t, expected.BlockNum, kmod.BlockNum)
+			assert.Equal(t, expected.TxNum, kmod.TxNum)
+			assert.Equal(t, expected.Value, kmod.Value)
+			assert.Equal(t, expected.IsDelete, kmod.IsDelete)
+			if !expected.IsDelete {
+				assert.Equal(t, expected.ValueHash, kmod.ValueHash)
+			}
+		}
+		res, err := itr.Next()
+		assert.NoError(t, err)
+		assert.Nil(t, res)
+	}
+
+	qhistory, err := env.testHistoryDB.NewHistoryQueryExecutor(pvtdataStore)
+	assert.NoError(t, err)
+	verifyModifications(qhistory, "coll1", "key1", expectedModifications)
+	verifyModifications(qhistory, "coll2", "key1", []*queryresult.PrivateDataKeyModification{
+		{BlockNum: 2, TxNum: 0, ValueHash: util.ComputeStringHash("value1-coll2")},
+	})
+	verifyModifications(qhistory, "coll1", "key2", []*queryresult.PrivateDataKeyModification{
+		{BlockNum: 3, TxNum: 0, Value: []byte("value1-key2"), ValueHash: util.ComputeStringHash("value1-key2")},
+	})
+	verifyModifications(qhistory, "coll3", "key1", nil)
+
+	// the public history does not include the private writes
+	publicItr, err := qhistory.GetHistoryForKey("ns1", "key1")
+	assert.NoError(t, err)
+	res, err := publicItr.Next()
+	assert.NoError(t, err)
+	assert.Nil(t, res)
+	publicItr.Close()
+
+	// without the private data store, only the hashes are returned
+	qhistory, err = env.testHistoryDB.NewHistoryQueryExecutor(store1)
+	assert.NoError(t, err)
+	expectedModifications[0].Value = nil
+	verifyModifications(qhistory, "coll1", "key1", expectedModifications)
+
+	viper.Set("ledger.history.enableHistoryDatabase", "false")
+	defer viper.Set("ledger.history.enableHistoryDatabase", "true")
+	_, err = qhistory.GetPrivateDataHistoryForKey("ns1", "coll1", "key1")
+	assert.EqualError(t, err, "history database not enabled")
+}
+
+// testPvtdataBlockStore is a block store that is backed by the private data, similar to the ledger storage
+type testPvtdataBlockStore struct {
+	blkstorage.BlockStore
+	pvtdata map[uint64][]*ledger.TxPvtData
+}
+
+func (s *testPvtdataBlockStore) GetPvtDataByNum(blockNum uint64, filter ledger.PvtNsCollFilter) ([]*ledger.TxPvtData, error) {
+	return s.pvtdata[blockNum], nil
+}
@@ -37,10 +37,13 @@ type kvLedger struct {
 	ledgerID               string
 	blockStore             *ledgerstorage.Store
 	txtmgmt                txmgr.TxMgr
+	vdb                    privacyenabledstate.DB
 	historyDB              historydb.HistoryDB
+	configHistoryMgr       confighistory.Mgr
 	configHistoryRetriever ledger.ConfigHistoryRetriever
 	blockAPIsRWLock        *sync.RWMutex
 	stats                  *ledgerStats
+	ccInfoProvider         ledger.DeployedChaincodeInfoProvider
 }
 
 // NewKVLedger constructs new `KVLedger`
@@ -58,7 +61,15 @@ func newKVLedger(
 	logger.Debugf("Creating KVLedger ledgerID=%s: ", ledgerID)
 	// Create a kvLedger for this chain/ledger, which encasulates the underlying
 	// id store, blockstore, txmgr (state database), history database
-	l := &kvLedger{ledgerID: ledgerID, blockStore: blockStore, historyDB: historyDB, blockAPIsRWLock: &sync.RWMutex{}}
+	l := &kvLedger{
+		ledgerID:         ledgerID,
+		blockStore:       blockStore,
+		vdb:              versionedDB,
+		historyDB:        historyDB,
+		configHistoryMgr: configHistoryMgr,
+		blockAPIsRWLock:  &sync.RWMutex{},
+		ccInfoProvider:   ccInfoProvider,
+	}
 
 	// TODO Move the function `GetChaincodeEventListener` to ledger interface and
 	// this functionality of regiserting for events to ledgermgmt package so that this
@@ -73,6 +84,7 @@ func newKVLedger(
 		return nil, err
 	}
 	l.initBlockStore(btlPolicy)
+	l.stats = stats
 	//Recover both state DB and history DB if they are out of sync with block storage
 	if err := l.recoverDBs(); err != nil {
 		panic(errors.WithMessage(err, "error during state DB recovery"))
@@ -85,7 +97,6 @@ func newKVLedger(
 	}
 	// initialize stat with the current height
 	stats.updateBlockchainHeight(info.Height)
-	l.stats = stats
 	return l, nil
 }
 
@@ -179,12 +190,17 @@ func (l *kvLedger) syncStateDBWithPvtdatastore() error {
 	return nil
 }
 
+// recommitProgressLogInterval is the number of blocks after which the progress of recommitting
+// the lost blocks is logged. This is useful when the databases are being rebuilt from the genesis block
+const recommitProgressLogInterval = 1000
+
 //recommitLostBlocks retrieves blocks in specified range and commit the write set to either
 //state DB or history DB or both
 func (l *kvLedger) recommitLostBlocks(firstBlockNum uint64, lastBlockNum uint64, recoverables ...recoverable) error {
 	logger.Infof("Recommitting lost blocks - firstBlockNum=%d, lastBlockNum=%d, recoverables=%#v", firstBlockNum, lastBlockNum, recoverables)
 	var err error
 	var blockAndPvtdata *ledger.BlockAndPvtData
+	numBlocks := lastBlockNum - firstBlockNum + 1
 	for blockNumber := firstBlockNum; blockNumber <= lastBlockNum; blockNumber++ {
 		if blockAndPvtdata, err = l.GetPvtDataAndBlockByNum(blockNumber, nil); err != nil {
 			return err
@@ -194,6 +210,12 @@ func (l *kvLedger) recommitLostBlocks(firstBlockNum uint64, lastBlockNum uint64,
 				return err
 			}
 		}
+		numRecommitted := blockNumber - firstBlockNum + 1
+		l.stats.updateRecommitStats(numBlocks - numRecommitted)
+		if numRecommitted%recommitProgressLogInterval == 0 {
+			logger.Infof("Recommitting lost blocks for ledger [%s] - recommitted [%d] out of [%d] blocks (%d%%)",
+				l.ledgerID, numRecommitted, numBlocks, numRecommitted*100/numBlocks)
+		}
 	}
 	logger.Infof("Recommitted lost blocks - firstBlockNum=%d, lastBlockNum=%d, recoverables=%#v", firstBlockNum, lastBlockNum, recoverables)
 	return nil
@@ -215,6 +237,13 @@ func (l *kvLedger) GetTransactionByID(txID string) (*peer.ProcessedTransaction,
 	return processedTran, nil
 }
 
+// TxIDExists returns true if the txID is already present in one of the already committed blocks
+func (l *kvLedger) TxIDExists(txID string) (bool, error) {
+	l.blockAPIsRWLock.RLock()
+	defer l.blockAPIsRWLock.RUnlock()
+	return l.blockStore.TxIDExists(txID)
+}
+
 // GetBlockchainInfo returns basic info about blockchain
 func (l *kvLedger) GetBlockchainInfo() (*common.BlockchainInfo, error) {
 	bcInfo, err := l.blockStore.GetBlockchainInfo()
@@ -314,18 +343,33 @@ func (l *kvLedger) CommitWithPvtData(pvtdataAndBlock *ledger.BlockAndPvtData) er
 	}
 	elapsedCommitBlockStorage := time.Since(startCommitBlockStorage)
 
+	// The history database is written in parallel with the state database, as the history database is built
+	// from the block alone. Both the databases are recovered independently from the block store after a crash
+	var historyCommitDone chan error
+	if ledgerconfig.IsHistoryDBEnabled() {
+		logger.Debugf("[%s] Committing block [%d] transactions to history database", l.ledgerID, blockNo)
+		historyCommitDone = make(chan error, 1)
+		go func() {
+			historyCommitDone <- l.historyDB.Commit(block)
+		}()
+	}
+
+	// The updates of the block are applied to the state database in the background, so that the next block can be
+	// validated in the meantime. The state commit time covers the wait for the commit of the previous block
 	startCommitState := time.Now()
 	logger.Debugf("[%s] Committing block [%d] transactions to state database", l.ledgerID, blockNo)
-	if err = l.txtmgmt.Commit(); err != nil {
+	if err = l.txtmgmt.StartCommit(); err != nil {
 		panic(errors.WithMessage(err, "error during commit to txmgr"))
 	}
+	go func() {
+		if err := l.txtmgmt.WaitForCommit(); err != nil {
+			panic(errors.WithMessage(err, "error during commit to txmgr"))
+		}
+	}()
 	elapsedCommitState := time.Since(startCommitState)
 
-	// History database could be written in parallel with state and/or async as a future optimization,
-	// although it has not been a bottleneck...no need to clutter the log with elapsed duration.
-	if ledgerconfig.IsHistoryDBEnabled() {
-		logger.Debugf("[%s] Committing block [%d] transactions to history database", l.ledgerID, blockNo)
-		if err := l.historyDB.Commit(block); err != nil {
+	if historyCommitDone != nil {
+		if err := <-historyCommitDone; err != nil {
 			panic(errors.WithMessage(err, "Error during commit to history db"))
 		}
 	}
@@ -368,6 +412,13 @@ func (l *kvLedger) GetMissingPvtDataInfoForMostRecentBlocks(maxBlock int) (ledge
 	return l.blockStore.GetMissingPvtDataInfoForMostRecentBlocks(maxBlock)
 }
 
+// GetMissingPvtDataInfoForBlockRange returns the missing private data information for the most
+// recent `maxBlock` blocks within the range [startBlock, endBlock] which miss at least a private
+// data of a eligible collection.
+func (l *kvLedger) GetMissingPvtDataInfoForBlockRange(startBlock, endBlock uint64, maxBlock int) (ledger.MissingPvtDataInfo, error) {
+	return l.blockStore.GetMissingPvtDataInfoForBlockRange(startBlock, endBlock, maxBlock)
+}
+
 // GetPvtDataAndBlockByNum returns the block and the corresponding pvt data.
 // The pvt data is filtered by the list of 'collections' supplied
 func (l *kvLedger) GetPvtDataAndBlockByNum(blockNum uint64, filter ledger.PvtNsCollFilter) (*ledger.BlockAndPvtData, error) {
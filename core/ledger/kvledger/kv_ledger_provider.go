@@ -7,7 +7,6 @@ SPDX-License-Identifier: Apache-2.0
 package kvledger
 
 import (
-	"bytes"
 	"fmt"
 
 	"github.com/golang/protobuf/proto"
@@ -35,7 +34,10 @@ var (
 	ErrLedgerNotOpened = errors.New("ledger is not opened yet")
 
 	underConstructionLedgerKey = []byte("underConstructionLedgerKey")
+	rollbackInProgressKey      = []byte("rollbackInProgressKey")
+	resetInProgressKey         = []byte("resetInProgressKey")
 	ledgerKeyPrefix            = []byte("l")
+	ledgerKeyStop              = []byte("m")
 )
 
 // Provider implements interface ledger.PeerLedgerProvider
@@ -50,20 +52,37 @@ type Provider struct {
 	initializer         *ledger.Initializer
 	collElgNotifier     *collElgNotifier
 	stats               *stats
+	fileLock            *leveldbhelper.FileLock
 }
 
 // NewProvider instantiates a new Provider.
 // This is not thread-safe and assumed to be synchronized be the caller
 func NewProvider() (ledger.PeerLedgerProvider, error) {
 	logger.Info("Initializing ledger provider")
+	// Acquire the file lock so that the offline ledger maintenance commands (such as rollback)
+	// cannot operate on the ledgers while the peer is running
+	fileLock := leveldbhelper.NewFileLock(ledgerconfig.GetFileLockPath())
+	if err := fileLock.Lock(); err != nil {
+		return nil, errors.WithMessage(err, "as another peer node command is executing,"+
+			" wait for that command to complete its execution or terminate it before starting the peer")
+	}
 	// Initialize the ID store (inventory of chainIds/ledgerIds)
 	idStore := openIDStore(ledgerconfig.GetLedgerProviderPath())
+	if err := idStore.checkNoIncompleteRollbackOrReset(); err != nil {
+		idStore.close()
+		fileLock.Unlock()
+		return nil, err
+	}
 	ledgerStoreProvider := ledgerstorage.NewProvider()
 	// Initialize the history database (index for history of values by key)
 	historydbProvider := historyleveldb.NewHistoryDBProvider()
 	logger.Info("ledger provider Initialized")
-	provider := &Provider{idStore, ledgerStoreProvider,
-		nil, historydbProvider, nil, nil, nil, nil, nil, nil}
+	provider := &Provider{
+		idStore:             idStore,
+		ledgerStoreProvider: ledgerStoreProvider,
+		historydbProvider:   historydbProvider,
+		fileLock:            fileLock,
+	}
 	return provider, nil
 }
 
@@ -151,6 +170,10 @@ func (provider *Provider) openInternal(ledgerID string) (ledger.PeerLedger, erro
 	if err != nil {
 		return nil, err
 	}
+	return provider.openWithBlockStore(ledgerID, blockStore)
+}
+
+func (provider *Provider) openWithBlockStore(ledgerID string, blockStore *ledgerstorage.Store) (ledger.PeerLedger, error) {
 	provider.collElgNotifier.registerListener(ledgerID, blockStore)
 
 	// Get the versioned database (state database) for a chain/ledger
@@ -197,6 +220,7 @@ func (provider *Provider) Close() {
 	provider.historydbProvider.Close()
 	provider.bookkeepingProvider.Close()
 	provider.configHistoryMgr.Close()
+	provider.fileLock.Unlock()
 }
 
 // recoverUnderConstructionLedger checks whether the under construction flag is set - this would be the case
@@ -212,6 +236,12 @@ func (provider *Provider) recoverUnderConstructionLedger() {
 		return
 	}
 	logger.Infof("ledger [%s] found as under construction", ledgerID)
+	bootstrappedFromSnapshot, err := ledgerstorage.IsBootstrappedFromSnapshot(ledgerID)
+	panicOnErr(err, "Error while checking whether the under construction ledger [%s] is bootstrapped from a snapshot", ledgerID)
+	if bootstrappedFromSnapshot {
+		provider.recoverUnderConstructionLedgerFromSnapshot(ledgerID)
+		return
+	}
 	ledger, err := provider.openInternal(ledgerID)
 	panicOnErr(err, "Error while opening under construction ledger [%s]", ledgerID)
 	bcInfo, err := ledger.GetBlockchainInfo()
@@ -236,6 +266,30 @@ func (provider *Provider) recoverUnderConstructionLedger() {
 	return
 }
 
+// recoverUnderConstructionLedgerFromSnapshot completes the creation of a ledger that was being bootstrapped from
+// a snapshot. The last block of the snapshot is the last one to be persisted while bootstrapping a ledger, so its
+// presence in the block store indicates that all the other stores were populated from the snapshot. If the last block
+// is not present, the partially bootstrapped ledger cannot be cleaned up automatically and the peer refuses to start
+func (provider *Provider) recoverUnderConstructionLedgerFromSnapshot(ledgerID string) {
+	ledger, err := provider.openInternal(ledgerID)
+	panicOnErr(err, "Error while opening under construction ledger [%s]", ledgerID)
+	defer ledger.Close()
+	bcInfo, err := ledger.GetBlockchainInfo()
+	panicOnErr(err, "Error while getting blockchain info for the under construction ledger [%s]", ledgerID)
+	lastBlock, err := ledger.GetBlockByNumber(bcInfo.Height - 1)
+	if err != nil {
+		panic(errors.Errorf(
+			"the ledger [%s] was partially bootstrapped from a snapshot and cannot be recovered automatically."+
+				" Restore the ledger data of the peer from a backup before restarting the peer: %s", ledgerID, err))
+	}
+	logger.Infof("The last block of the snapshot was committed. Hence, marking the peer ledger [%s] as created", ledgerID)
+	lastConfigBlockNum, err := utils.GetLastConfigIndexFromBlock(lastBlock)
+	panicOnErr(err, "Error while retrieving the last config block number for ledger [%s]", ledgerID)
+	lastConfigBlock, err := ledger.GetBlockByNumber(lastConfigBlockNum)
+	panicOnErr(err, "Error while retrieving the last config block for ledger [%s]", ledgerID)
+	panicOnErr(provider.idStore.createLedgerID(ledgerID, lastConfigBlock), "Error while adding ledgerID [%s] to created list", ledgerID)
+}
+
 // runCleanup cleans up blockstorage, statedb, and historydb for what
 // may have got created during in-complete ledger creation
 func (provider *Provider) runCleanup(ledgerID string) error {
@@ -284,6 +338,60 @@ func (s *idStore) getUnderConstructionFlag() (string, error) {
 	return string(val), nil
 }
 
+func (s *idStore) setRollbackInProgressFlag(ledgerID string) error {
+	return s.db.Put(rollbackInProgressKey, []byte(ledgerID), true)
+}
+
+func (s *idStore) unsetRollbackInProgressFlag() error {
+	return s.db.Delete(rollbackInProgressKey, true)
+}
+
+func (s *idStore) getRollbackInProgressFlag() (string, error) {
+	val, err := s.db.Get(rollbackInProgressKey)
+	if err != nil {
+		return "", err
+	}
+	return string(val), nil
+}
+
+func (s *idStore) setResetInProgressFlag() error {
+	return s.db.Put(resetInProgressKey, []byte{}, true)
+}
+
+func (s *idStore) unsetResetInProgressFlag() error {
+	return s.db.Delete(resetInProgressKey, true)
+}
+
+func (s *idStore) getResetInProgressFlag() (bool, error) {
+	val, err := s.db.Get(resetInProgressKey)
+	if err != nil {
+		return false, err
+	}
+	return val != nil, nil
+}
+
+// checkNoIncompleteRollbackOrReset returns an error if a previously started rollback or reset
+// of the ledgers did not complete (e.g., because of a crash), as the ledger stores may be inconsistent
+func (s *idStore) checkNoIncompleteRollbackOrReset() error {
+	resetInProgress, err := s.getResetInProgressFlag()
+	if err != nil {
+		return err
+	}
+	if resetInProgress {
+		return errors.New("the reset of the ledgers did not complete, " +
+			"execute the reset command again before starting the peer")
+	}
+	ledgerID, err := s.getRollbackInProgressFlag()
+	if err != nil {
+		return err
+	}
+	if ledgerID != "" {
+		return errors.Errorf("the rollback of the ledger [%s] did not complete, "+
+			"execute the rollback command again before starting the peer", ledgerID)
+	}
+	return nil
+}
+
 func (s *idStore) createLedgerID(ledgerID string, gb *common.Block) error {
 	key := s.encodeLedgerKey(ledgerID)
 	var val []byte
@@ -315,16 +423,11 @@ func (s *idStore) ledgerIDExists(ledgerID string) (bool, error) {
 
 func (s *idStore) getAllLedgerIds() ([]string, error) {
 	var ids []string
-	itr := s.db.GetIterator(nil, nil)
+	itr := s.db.GetIterator(ledgerKeyPrefix, ledgerKeyStop)
 	defer itr.Release()
-	itr.First()
-	for itr.Valid() {
-		if bytes.Equal(itr.Key(), underConstructionLedgerKey) {
-			continue
-		}
+	for itr.Next() {
 		id := string(s.decodeLedgerID(itr.Key()))
 		ids = append(ids, id)
-		itr.Next()
 	}
 	return ids, nil
 }
@@ -130,6 +130,7 @@ func TestRecovery(t *testing.T) {
 
 	// construct a new provider to invoke recovery
 	provider = testutilNewProvider(t)
+	defer provider.Close()
 	assert.NoError(t, err, "Provider failed to recover an underConstructionLedger")
 	flag, err = provider.(*Provider).idStore.getUnderConstructionFlag()
 	assert.NoError(t, err, "Failed to read the underconstruction flag")
@@ -137,6 +138,44 @@ func TestRecovery(t *testing.T) {
 
 }
 
+func TestProviderFileLockAndInProgressFlags(t *testing.T) {
+	env := newTestEnv(t)
+	defer env.cleanup()
+	provider := testutilNewProvider(t)
+
+	// a second provider cannot be created while the first one holds the file lock
+	_, err := NewProvider()
+	assert.Contains(t, err.Error(), "as another peer node command is executing")
+
+	// assume a crash happens during the rollback of a ledger
+	assert.NoError(t, provider.(*Provider).idStore.setRollbackInProgressFlag(constructTestLedgerID(1)))
+	provider.Close()
+
+	_, err = NewProvider()
+	assert.EqualError(t, err, fmt.Sprintf("the rollback of the ledger [%s] did not complete, "+
+		"execute the rollback command again before starting the peer", constructTestLedgerID(1)))
+	assert.EqualError(t, RebuildDBs(), fmt.Sprintf("the rollback of the ledger [%s] did not complete, "+
+		"execute the rollback command for that ledger before rebuilding the databases", constructTestLedgerID(1)))
+
+	// assume a crash happens during the reset of the ledgers
+	idStore := openIDStore(ledgerconfig.GetLedgerProviderPath())
+	assert.NoError(t, idStore.unsetRollbackInProgressFlag())
+	assert.NoError(t, idStore.setResetInProgressFlag())
+	idStore.close()
+
+	_, err = NewProvider()
+	assert.EqualError(t, err, "the reset of the ledgers did not complete, "+
+		"execute the reset command again before starting the peer")
+	assert.EqualError(t, RebuildDBs(), "the reset of the ledgers did not complete, "+
+		"execute the reset command before rebuilding the databases")
+
+	idStore = openIDStore(ledgerconfig.GetLedgerProviderPath())
+	assert.NoError(t, idStore.unsetResetInProgressFlag())
+	idStore.close()
+	provider = testutilNewProvider(t)
+	provider.Close()
+}
+
 func TestMultipleLedgerBasicRW(t *testing.T) {
 	env := newTestEnv(t)
 	defer env.cleanup()
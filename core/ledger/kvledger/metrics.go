@@ -18,6 +18,8 @@ type stats struct {
 	blockstorageCommitTime metrics.Histogram
 	statedbCommitTime      metrics.Histogram
 	transactionsCount      metrics.Counter
+	recommittedBlocksCount metrics.Counter
+	recommitPendingBlocks  metrics.Gauge
 }
 
 func newStats(metricsProvider metrics.Provider) *stats {
@@ -27,6 +29,8 @@ func newStats(metricsProvider metrics.Provider) *stats {
 	stats.blockstorageCommitTime = metricsProvider.NewHistogram(blockstorageCommitTimeOpts)
 	stats.statedbCommitTime = metricsProvider.NewHistogram(statedbCommitTimeOpts)
 	stats.transactionsCount = metricsProvider.NewCounter(transactionCountOpts)
+	stats.recommittedBlocksCount = metricsProvider.NewCounter(recommittedBlocksCountOpts)
+	stats.recommitPendingBlocks = metricsProvider.NewGauge(recommitPendingBlocksOpts)
 	return stats
 }
 
@@ -59,6 +63,11 @@ func (s *ledgerStats) updateStatedbCommitTime(timeTaken time.Duration) {
 	s.stats.statedbCommitTime.With("channel", s.ledgerid).Observe(timeTaken.Seconds())
 }
 
+func (s *ledgerStats) updateRecommitStats(numPendingBlocks uint64) {
+	s.stats.recommittedBlocksCount.With("channel", s.ledgerid).Add(1)
+	s.stats.recommitPendingBlocks.With("channel", s.ledgerid).Set(float64(numPendingBlocks))
+}
+
 func (s *ledgerStats) updateTransactionsStats(
 	txstatsInfo []*txmgr.TxStatInfo,
 ) {
@@ -130,4 +139,22 @@ var (
 		LabelNames:   []string{"channel", "transaction_type", "chaincode", "validation_code"},
 		StatsdFormat: "%{#fqname}.%{channel}.%{transaction_type}.%{chaincode}.%{validation_code}",
 	}
+
+	recommittedBlocksCountOpts = metrics.CounterOpts{
+		Namespace:    "ledger",
+		Subsystem:    "",
+		Name:         "recommitted_blocks_count",
+		Help:         "Number of blocks recommitted from the block store to rebuild the state and history databases.",
+		LabelNames:   []string{"channel"},
+		StatsdFormat: "%{#fqname}.%{channel}",
+	}
+
+	recommitPendingBlocksOpts = metrics.GaugeOpts{
+		Namespace:    "ledger",
+		Subsystem:    "",
+		Name:         "recommit_pending_blocks",
+		Help:         "Number of blocks that remain to be recommitted to rebuild the state and history databases.",
+		LabelNames:   []string{"channel"},
+		StatsdFormat: "%{#fqname}.%{channel}",
+	}
 )
@@ -175,6 +175,44 @@ func TestStatsBlockCommit(t *testing.T) {
 	)
 }
 
+func TestStatsRecommitBlocks(t *testing.T) {
+	env := newTestEnv(t)
+	defer env.cleanup()
+	provider := testutilNewProvider(t)
+	ledgerid := "ledger1"
+	bg, gb := testutil.NewBlockGenerator(t, ledgerid, false)
+	l, err := provider.Create(gb)
+	assert.NoError(t, err)
+	for _, b := range bg.NextTestBlocks(4) {
+		assert.NoError(t, l.CommitWithPvtData(&lgr.BlockAndPvtData{Block: b}))
+	}
+	l.Close()
+	provider.Close()
+
+	// drop the state and history dbs so that all the blocks are recommitted on the next open
+	assert.NoError(t, dropDBs())
+	testMetricProvider := testutilConstructMetricProvider()
+	provider, err = NewProvider()
+	assert.NoError(t, err)
+	provider.Initialize(&lgr.Initializer{
+		DeployedChaincodeInfoProvider: &mock.DeployedChaincodeInfoProvider{},
+		MetricsProvider:               testMetricProvider.fakeProvider,
+	})
+	defer provider.Close()
+	l, err = provider.Open(ledgerid)
+	assert.NoError(t, err)
+	defer l.Close()
+
+	fakeRecommittedBlocksCount := testMetricProvider.fakeRecommittedBlocksCount
+	fakeRecommitPendingBlocksGauge := testMetricProvider.fakeRecommitPendingBlocksGauge
+	assert.Equal(t, 5, fakeRecommittedBlocksCount.AddCallCount())
+	assert.Equal(t, []string{"channel", ledgerid}, fakeRecommittedBlocksCount.WithArgsForCall(0))
+	for i := 0; i < 5; i++ {
+		assert.Equal(t, float64(1), fakeRecommittedBlocksCount.AddArgsForCall(i))
+		assert.Equal(t, float64(4-i), fakeRecommitPendingBlocksGauge.SetArgsForCall(i))
+	}
+}
+
 type testMetricProvider struct {
 	fakeProvider                   *metricsfakes.Provider
 	fakeBlockchainHeightGauge      *metricsfakes.Gauge
@@ -182,6 +220,8 @@ type testMetricProvider struct {
 	fakeBlockstorageCommitTimeHist *metricsfakes.Histogram
 	fakeStatedbCommitTimeHist      *metricsfakes.Histogram
 	fakeTransactionsCount          *metricsfakes.Counter
+	fakeRecommittedBlocksCount     *metricsfakes.Counter
+	fakeRecommitPendingBlocksGauge *metricsfakes.Gauge
 }
 
 func testutilConstructMetricProvider() *testMetricProvider {
@@ -191,10 +231,14 @@ func testutilConstructMetricProvider() *testMetricProvider {
 	fakeBlockstorageCommitTimeHist := testutilConstructHist()
 	fakeStatedbCommitTimeHist := testutilConstructHist()
 	fakeTransactionsCount := testutilConstructCounter()
+	fakeRecommittedBlocksCount := testutilConstructCounter()
+	fakeRecommitPendingBlocksGauge := testutilConstructGuage()
 	fakeProvider.NewGaugeStub = func(opts metrics.GaugeOpts) metrics.Gauge {
 		switch opts.Name {
 		case blockchainHeightOpts.Name:
 			return fakeBlockchainHeightGauge
+		case recommitPendingBlocksOpts.Name:
+			return fakeRecommitPendingBlocksGauge
 		}
 		return nil
 	}
@@ -214,6 +258,8 @@ func testutilConstructMetricProvider() *testMetricProvider {
 		switch opts.Name {
 		case transactionCountOpts.Name:
 			return fakeTransactionsCount
+		case recommittedBlocksCountOpts.Name:
+			return fakeRecommittedBlocksCount
 		}
 		return nil
 	}
@@ -224,6 +270,8 @@ func testutilConstructMetricProvider() *testMetricProvider {
 		fakeBlockstorageCommitTimeHist,
 		fakeStatedbCommitTimeHist,
 		fakeTransactionsCount,
+		fakeRecommittedBlocksCount,
+		fakeRecommitPendingBlocksGauge,
 	}
 }
 
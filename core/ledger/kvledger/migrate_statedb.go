@@ -0,0 +1,166 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package kvledger
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hyperledger/fabric/common/ledger/util/leveldbhelper"
+	"github.com/hyperledger/fabric/common/metrics/disabled"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/privacyenabledstate"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/statedb"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/statedb/statecouchdb"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/statedb/statejsondb"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/statedb/stateleveldb"
+	"github.com/hyperledger/fabric/core/ledger/ledgerconfig"
+	"github.com/pkg/errors"
+)
+
+// the values of "ledger.state.stateDatabase"
+const (
+	stateDBGoLevelDB = "goleveldb"
+	stateDBCouchDB   = "CouchDB"
+	stateDBJSONDB    = "JSONDB"
+)
+
+// MigrateStateDB copies the state database of each of the ledgers from the state database in use to the target state
+// database, which is one of "goleveldb", "CouchDB" or "JSONDB" (case insensitive). The versions and the metadata of the
+// keys are preserved and the savepoint is written only after all the keys of a ledger are copied. Once the state of a
+// ledger is copied, the state of each namespace is compared between the two databases. The state database in use is
+// left intact and the peer continues to use it until "ledger.state.stateDatabase" is changed to the target state
+// database. This function is expected to be invoked only when the peer is not running
+func MigrateStateDB(target string) error {
+	fileLock := leveldbhelper.NewFileLock(ledgerconfig.GetFileLockPath())
+	if err := fileLock.Lock(); err != nil {
+		return errors.WithMessage(err, "as another peer node command is executing,"+
+			" wait for that command to complete its execution or terminate it before retrying")
+	}
+	defer fileLock.Unlock()
+
+	source := stateDBInUse()
+	target, err := parseStateDBName(target)
+	if err != nil {
+		return err
+	}
+	if source == target {
+		return errors.Errorf("the state database in use is already %s", source)
+	}
+	if source == stateDBCouchDB {
+		return errors.New("migrating the state database is not supported when the state database in use is CouchDB")
+	}
+
+	idStore := openIDStore(ledgerconfig.GetLedgerProviderPath())
+	defer idStore.close()
+	if err := idStore.checkNoIncompleteRollbackOrReset(); err != nil {
+		return err
+	}
+	underConstructionLedgerID, err := idStore.getUnderConstructionFlag()
+	if err != nil {
+		return err
+	}
+	if underConstructionLedgerID != "" {
+		return errors.Errorf("the creation of the ledger [%s] did not complete, "+
+			"start the peer to complete it before migrating the state database", underConstructionLedgerID)
+	}
+	ledgerIDs, err := idStore.getAllLedgerIds()
+	if err != nil {
+		return err
+	}
+
+	sourceProvider, err := newVersionedDBProvider(source)
+	if err != nil {
+		return err
+	}
+	defer sourceProvider.Close()
+	targetProvider, err := newVersionedDBProvider(target)
+	if err != nil {
+		return err
+	}
+	defer targetProvider.Close()
+
+	logger.Infof("Migrating the state database of all the ledgers from %s to %s", source, target)
+	for _, ledgerID := range ledgerIDs {
+		if err := migrateLedgerState(ledgerID, sourceProvider, targetProvider); err != nil {
+			return errors.WithMessage(err, fmt.Sprintf("error while migrating the state database of the ledger [%s]", ledgerID))
+		}
+	}
+	logger.Infof("The state database of all the ledgers has been successfully migrated to %s, "+
+		"set ledger.state.stateDatabase to %s before starting the peer", target, target)
+	return nil
+}
+
+func migrateLedgerState(ledgerID string, sourceProvider, targetProvider statedb.VersionedDBProvider) error {
+	sourceDB, err := sourceProvider.GetDBHandle(ledgerID)
+	if err != nil {
+		return err
+	}
+	targetDB, err := targetProvider.GetDBHandle(ledgerID)
+	if err != nil {
+		return err
+	}
+	sourceSavepoint, err := sourceDB.GetLatestSavePoint()
+	if err != nil {
+		return err
+	}
+	targetSavepoint, err := targetDB.GetLatestSavePoint()
+	if err != nil {
+		return err
+	}
+	if targetSavepoint != nil {
+		// a target db without a savepoint may contain the keys copied by a migration that did not complete,
+		// which are overwritten, as the keys of the source db are not updated while the peer is not running
+		return errors.Errorf("the target state database already contains the state up to the block [%d]",
+			targetSavepoint.BlockNum)
+	}
+
+	logger.Infof("Copying the state of the ledger [%s]", ledgerID)
+	namespaces, err := privacyenabledstate.MigrateState(sourceDB, targetDB)
+	if err != nil {
+		return err
+	}
+	logger.Infof("Verifying the state of %d namespaces of the ledger [%s]", len(namespaces), ledgerID)
+	if err := privacyenabledstate.VerifyMigratedState(sourceDB, targetDB, namespaces); err != nil {
+		return err
+	}
+	if sourceSavepoint != nil {
+		logger.Infof("The state of the ledger [%s] has been migrated up to the block [%d]", ledgerID, sourceSavepoint.BlockNum)
+	}
+	return nil
+}
+
+func stateDBInUse() string {
+	switch {
+	case ledgerconfig.IsCouchDBEnabled():
+		return stateDBCouchDB
+	case ledgerconfig.IsJSONDBEnabled():
+		return stateDBJSONDB
+	default:
+		return stateDBGoLevelDB
+	}
+}
+
+func parseStateDBName(name string) (string, error) {
+	for _, stateDB := range []string{stateDBGoLevelDB, stateDBCouchDB, stateDBJSONDB} {
+		if strings.EqualFold(name, stateDB) {
+			return stateDB, nil
+		}
+	}
+	return "", errors.Errorf("invalid state database [%s], must be one of %s, %s or %s",
+		name, stateDBGoLevelDB, stateDBCouchDB, stateDBJSONDB)
+}
+
+func newVersionedDBProvider(stateDB string) (statedb.VersionedDBProvider, error) {
+	switch stateDB {
+	case stateDBCouchDB:
+		return statecouchdb.NewVersionedDBProvider(&disabled.Provider{}, statedb.NewCache(0, &disabled.Provider{}))
+	case stateDBJSONDB:
+		return statejsondb.NewVersionedDBProvider(), nil
+	default:
+		return stateleveldb.NewVersionedDBProvider(), nil
+	}
+}
@@ -0,0 +1,71 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package kvledger
+
+import (
+	"testing"
+
+	"github.com/hyperledger/fabric/common/ledger/testutil"
+	"github.com/hyperledger/fabric/common/util"
+	"github.com/hyperledger/fabric/core/ledger/cceventmgmt"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMigrateStateDB(t *testing.T) {
+	env := newTestEnv(t)
+	defer env.cleanup()
+	defer viper.Set("ledger.state.stateDatabase", "")
+	provider := testutilNewProvider(t)
+	ledgerID := util.GetTestChainID()
+	bg, gb := testutil.NewBlockGenerator(t, ledgerID, false)
+	l, err := provider.Create(gb)
+	assert.NoError(t, err)
+	commitBlockWithState(t, l, bg, "key1", "value1")
+	commitBlockWithState(t, l, bg, "key2", `{"asset_name": "marble1", "owner": "tom"}`)
+	l.Close()
+	provider.Close()
+
+	assert.EqualError(t, MigrateStateDB("unknown"), "invalid state database [unknown], must be one of goleveldb, CouchDB or JSONDB")
+	assert.EqualError(t, MigrateStateDB("GoLevelDB"), "the state database in use is already goleveldb")
+	viper.Set("ledger.state.stateDatabase", "CouchDB")
+	assert.EqualError(t, MigrateStateDB("goleveldb"), "migrating the state database is not supported when the state database in use is CouchDB")
+	viper.Set("ledger.state.stateDatabase", "")
+
+	assert.NoError(t, MigrateStateDB("jsondb"))
+	assert.EqualError(t, MigrateStateDB("JSONDB"), "error while migrating the state database of the ledger [testchainid]: "+
+		"the target state database already contains the state up to the block [2]")
+
+	// the peer uses the migrated state database and the state of the ledger continues to be updated
+	viper.Set("ledger.state.stateDatabase", "JSONDB")
+	// the state database registers for the chaincode lifecycle events to create the indexes
+	cceventmgmt.Initialize(nil)
+	provider = testutilNewProvider(t)
+	defer provider.Close()
+	l, err = provider.Open(ledgerID)
+	assert.NoError(t, err)
+	defer l.Close()
+	commitBlockWithState(t, l, bg, "key3", "value3")
+	qe, err := l.NewQueryExecutor()
+	assert.NoError(t, err)
+	defer qe.Done()
+	for key, value := range map[string]string{
+		"key1": "value1",
+		"key2": `{"asset_name": "marble1", "owner": "tom"}`,
+		"key3": "value3",
+	} {
+		v, err := qe.GetState("ns", key)
+		assert.NoError(t, err)
+		assert.Equal(t, value, string(v))
+	}
+	itr, err := qe.ExecuteQuery("ns", `{"selector":{"owner":"tom"}}`)
+	assert.NoError(t, err)
+	defer itr.Close()
+	res, err := itr.Next()
+	assert.NoError(t, err)
+	assert.NotNil(t, res)
+}
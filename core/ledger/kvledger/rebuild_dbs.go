@@ -0,0 +1,55 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package kvledger
+
+import (
+	"github.com/hyperledger/fabric/common/ledger/util/leveldbhelper"
+	"github.com/hyperledger/fabric/core/ledger/ledgerconfig"
+	"github.com/pkg/errors"
+)
+
+// RebuildDBs drops the state, history, bookkeeping and config history databases of all the ledgers.
+// On the next peer start, all the blocks present in the block store are recommitted to rebuild the
+// dropped databases. This function is expected to be invoked only when the peer is not running
+func RebuildDBs() error {
+	fileLock := leveldbhelper.NewFileLock(ledgerconfig.GetFileLockPath())
+	if err := fileLock.Lock(); err != nil {
+		return errors.WithMessage(err, "as another peer node command is executing,"+
+			" wait for that command to complete its execution or terminate it before retrying")
+	}
+	defer fileLock.Unlock()
+
+	idStore := openIDStore(ledgerconfig.GetLedgerProviderPath())
+	defer idStore.close()
+	// the databases are rebuilt from the block stores, which may be inconsistent
+	// until an interrupted reset or rollback is executed again
+	resetInProgress, err := idStore.getResetInProgressFlag()
+	if err != nil {
+		return err
+	}
+	if resetInProgress {
+		return errors.New("the reset of the ledgers did not complete, " +
+			"execute the reset command before rebuilding the databases")
+	}
+	inProgressLedgerID, err := idStore.getRollbackInProgressFlag()
+	if err != nil {
+		return err
+	}
+	if inProgressLedgerID != "" {
+		return errors.Errorf("the rollback of the ledger [%s] did not complete, "+
+			"execute the rollback command for that ledger before rebuilding the databases", inProgressLedgerID)
+	}
+
+	if err := checkAllLedgersHaveAllBlocks(idStore); err != nil {
+		return err
+	}
+	if err := dropDBs(); err != nil {
+		return err
+	}
+	logger.Info("The ledger databases have been dropped and will be rebuilt from the block store on the next peer start")
+	return nil
+}
@@ -0,0 +1,62 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package kvledger
+
+import (
+	"fmt"
+
+	"github.com/hyperledger/fabric/common/ledger/util/leveldbhelper"
+	"github.com/hyperledger/fabric/core/ledger/ledgerconfig"
+	"github.com/hyperledger/fabric/core/ledger/ledgerstorage"
+	"github.com/pkg/errors"
+)
+
+// ResetAllKVLedgers resets all the ledgers to the genesis block. The block store and the pvtdata
+// store of each ledger are reverted to the genesis block, while the state, history, bookkeeping and
+// config history databases are dropped and are rebuilt from the genesis block on the next peer start.
+// This function is expected to be invoked only when the peer is not running
+func ResetAllKVLedgers() error {
+	fileLock := leveldbhelper.NewFileLock(ledgerconfig.GetFileLockPath())
+	if err := fileLock.Lock(); err != nil {
+		return errors.WithMessage(err, "as another peer node command is executing,"+
+			" wait for that command to complete its execution or terminate it before retrying")
+	}
+	defer fileLock.Unlock()
+
+	idStore := openIDStore(ledgerconfig.GetLedgerProviderPath())
+	defer idStore.close()
+	ledgerIDs, err := idStore.getAllLedgerIds()
+	if err != nil {
+		return err
+	}
+	if err := checkAllLedgersHaveAllBlocks(idStore); err != nil {
+		return err
+	}
+
+	logger.Info("Resetting all the ledgers to the genesis block")
+	if err := idStore.setResetInProgressFlag(); err != nil {
+		return err
+	}
+	for _, ledgerID := range ledgerIDs {
+		logger.Infof("Resetting ledger [%s] to the genesis block", ledgerID)
+		if err := ledgerstorage.Rollback(ledgerID, 0); err != nil {
+			return errors.WithMessage(err, fmt.Sprintf("error while resetting ledger [%s]", ledgerID))
+		}
+	}
+	if err := dropDBs(); err != nil {
+		return err
+	}
+	// a reset supersedes a rollback that did not complete
+	if err := idStore.unsetRollbackInProgressFlag(); err != nil {
+		return err
+	}
+	if err := idStore.unsetResetInProgressFlag(); err != nil {
+		return err
+	}
+	logger.Info("All the ledgers have been successfully reset to the genesis block")
+	return nil
+}
@@ -0,0 +1,76 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package kvledger
+
+import (
+	"github.com/hyperledger/fabric/common/ledger/util/leveldbhelper"
+	"github.com/hyperledger/fabric/core/ledger/ledgerconfig"
+	"github.com/hyperledger/fabric/core/ledger/ledgerstorage"
+	"github.com/pkg/errors"
+)
+
+// RollbackKVLedger rollbacks a ledger to a specified block number. The block store and the pvtdata
+// store are reverted to the target block, while the state, history, bookkeeping and config history
+// databases of all the ledgers are dropped so that they are rebuilt from the block store on the next
+// peer start. This function is expected to be invoked only when the peer is not running
+func RollbackKVLedger(ledgerID string, blockNum uint64) error {
+	fileLock := leveldbhelper.NewFileLock(ledgerconfig.GetFileLockPath())
+	if err := fileLock.Lock(); err != nil {
+		return errors.WithMessage(err, "as another peer node command is executing,"+
+			" wait for that command to complete its execution or terminate it before retrying")
+	}
+	defer fileLock.Unlock()
+
+	idStore := openIDStore(ledgerconfig.GetLedgerProviderPath())
+	defer idStore.close()
+	exists, err := idStore.ledgerIDExists(ledgerID)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return errors.Errorf("ledgerID [%s] does not exist", ledgerID)
+	}
+	resetInProgress, err := idStore.getResetInProgressFlag()
+	if err != nil {
+		return err
+	}
+	if resetInProgress {
+		return errors.New("the reset of the ledgers did not complete, " +
+			"execute the reset command before rolling back a ledger")
+	}
+	inProgressLedgerID, err := idStore.getRollbackInProgressFlag()
+	if err != nil {
+		return err
+	}
+	if inProgressLedgerID != "" && inProgressLedgerID != ledgerID {
+		return errors.Errorf("the rollback of the ledger [%s] did not complete, "+
+			"execute the rollback command for that ledger before rolling back the ledger [%s]", inProgressLedgerID, ledgerID)
+	}
+
+	if err := checkAllLedgersHaveAllBlocks(idStore); err != nil {
+		return err
+	}
+	if err := ledgerstorage.ValidateRollbackParams(ledgerID, blockNum); err != nil {
+		return err
+	}
+
+	logger.Infof("Rolling back ledger [%s] to block number [%d]", ledgerID, blockNum)
+	if err := idStore.setRollbackInProgressFlag(ledgerID); err != nil {
+		return err
+	}
+	if err := ledgerstorage.Rollback(ledgerID, blockNum); err != nil {
+		return err
+	}
+	if err := dropDBs(); err != nil {
+		return err
+	}
+	if err := idStore.unsetRollbackInProgressFlag(); err != nil {
+		return err
+	}
+	logger.Infof("The channel [%s] has been successfully rolled back to the block number [%d]", ledgerID, blockNum)
+	return nil
+}
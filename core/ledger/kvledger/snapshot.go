@@ -0,0 +1,399 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package kvledger
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/common/ledger/snapshot"
+	"github.com/hyperledger/fabric/common/ledger/util"
+	"github.com/hyperledger/fabric/core/ledger"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/version"
+	"github.com/hyperledger/fabric/core/ledger/ledgerconfig"
+	"github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/utils"
+	"github.com/pkg/errors"
+)
+
+const (
+	snapshotSignableMetadataFileName   = "_snapshot_signable_metadata.json"
+	snapshotAdditionalMetadataFileName = "_snapshot_additional_metadata.json"
+	lastBlockFileName                  = "last_block.data"
+	lastConfigBlockFileName            = "last_config_block.data"
+
+	snapshotsCompletedDirName = "completed"
+	snapshotsTempDirName      = "temp"
+)
+
+// snapshotSignableMetadata is the metadata of a snapshot that is expected to be identical across the peers
+// of a channel for a snapshot generated at the same block. An admin can compare the hash of this metadata,
+// which is present in the additional metadata, across the peers before using a snapshot to bootstrap a peer
+type snapshotSignableMetadata struct {
+	ChannelName            string            `json:"channel_name"`
+	LastBlockNumber        uint64            `json:"last_block_number"`
+	LastBlockHashInHex     string            `json:"last_block_hash"`
+	PreviousBlockHashInHex string            `json:"previous_block_hash"`
+	StateDBType            string            `json:"state_db_type"`
+	FilesAndHashes         map[string]string `json:"snapshot_files_raw_hashes"`
+}
+
+// snapshotAdditionalMetadata is the metadata of a snapshot that is specific to the peer that generated the snapshot
+type snapshotAdditionalMetadata struct {
+	SnapshotHashInHex string `json:"snapshot_hash"`
+}
+
+// SnapshotDirForLedgerBlockNum returns the directory for the snapshot of the given ledger at the given block number
+func SnapshotDirForLedgerBlockNum(snapshotsRootDir, ledgerID string, blockNum uint64) string {
+	return filepath.Join(snapshotsRootDir, snapshotsCompletedDirName, ledgerID, strconv.FormatUint(blockNum, 10))
+}
+
+// GenerateSnapshot implements the corresponding function in the interface ledger.PeerLedger.
+// The snapshot is generated at the last committed block. The block commits are paused while
+// the snapshot is being generated. The files of the snapshot are first generated in a temporary
+// directory, which is renamed to the final snapshot directory once all the files are generated
+func (l *kvLedger) GenerateSnapshot() (string, error) {
+	l.blockAPIsRWLock.RLock()
+	defer l.blockAPIsRWLock.RUnlock()
+	// the state of the last block may still be committed in the background
+	if err := l.txtmgmt.WaitForCommit(); err != nil {
+		return "", err
+	}
+
+	bcInfo, err := l.blockStore.GetBlockchainInfo()
+	if err != nil {
+		return "", err
+	}
+	if bcInfo.Height == 0 {
+		return "", errors.Errorf("cannot generate a snapshot for the ledger [%s] as the ledger is empty", l.ledgerID)
+	}
+	lastBlockNum := bcInfo.Height - 1
+	savepoint, err := l.vdb.GetLatestSavePoint()
+	if err != nil {
+		return "", err
+	}
+	if savepoint == nil || savepoint.BlockNum != lastBlockNum {
+		return "", errors.Errorf("cannot generate a snapshot for the ledger [%s] as the state database is not in sync with the block store", l.ledgerID)
+	}
+
+	snapshotsRootDir := ledgerconfig.GetSnapshotsRootDir()
+	snapshotDir := SnapshotDirForLedgerBlockNum(snapshotsRootDir, l.ledgerID, lastBlockNum)
+	exists, _, err := util.FileExists(snapshotDir)
+	if err != nil {
+		return "", errors.WithMessage(err, "error while checking whether the snapshot directory exists")
+	}
+	if exists {
+		return "", errors.Errorf("the snapshot of the ledger [%s] at the block number [%d] already exists in the directory [%s]",
+			l.ledgerID, lastBlockNum, snapshotDir)
+	}
+	tempDir, err := createSnapshotTempDir(snapshotsRootDir, l.ledgerID)
+	if err != nil {
+		return "", err
+	}
+	defer os.RemoveAll(tempDir)
+
+	logger.Infof("Generating a snapshot of the ledger [%s] at the block number [%d]", l.ledgerID, lastBlockNum)
+	filesAndHashes := map[string][]byte{}
+	addFilesAndHashes := func(m map[string][]byte, err error) error {
+		if err != nil {
+			return err
+		}
+		for fileName, hash := range m {
+			filesAndHashes[fileName] = hash
+		}
+		return nil
+	}
+	if err := addFilesAndHashes(l.vdb.ExportPubStateAndPvtStateHashes(tempDir)); err != nil {
+		return "", err
+	}
+	if err := addFilesAndHashes(l.configHistoryMgr.ExportConfigHistory(l.ledgerID, lastBlockNum, tempDir)); err != nil {
+		return "", err
+	}
+	if err := addFilesAndHashes(l.blockStore.ExportTxIds(tempDir)); err != nil {
+		return "", err
+	}
+
+	lastBlock, err := l.blockStore.RetrieveBlockByNumber(lastBlockNum)
+	if err != nil {
+		return "", err
+	}
+	lastConfigBlockNum, err := utils.GetLastConfigIndexFromBlock(lastBlock)
+	if err != nil {
+		return "", err
+	}
+	lastConfigBlock, err := l.blockStore.RetrieveBlockByNumber(lastConfigBlockNum)
+	if err != nil {
+		return "", err
+	}
+	if err := addFilesAndHashes(writeBlockToSnapshotDir(tempDir, lastBlockFileName, lastBlock)); err != nil {
+		return "", err
+	}
+	if err := addFilesAndHashes(writeBlockToSnapshotDir(tempDir, lastConfigBlockFileName, lastConfigBlock)); err != nil {
+		return "", err
+	}
+
+	if err := writeSnapshotMetadata(tempDir, l.ledgerID, lastBlock, filesAndHashes); err != nil {
+		return "", err
+	}
+	if err := os.MkdirAll(filepath.Dir(snapshotDir), 0755); err != nil {
+		return "", errors.Wrapf(err, "error while creating the directory [%s]", filepath.Dir(snapshotDir))
+	}
+	if err := os.Rename(tempDir, snapshotDir); err != nil {
+		return "", errors.Wrapf(err, "error while renaming the directory [%s] to [%s]", tempDir, snapshotDir)
+	}
+	logger.Infof("Generated the snapshot of the ledger [%s] at the block number [%d] in the directory [%s]",
+		l.ledgerID, lastBlockNum, snapshotDir)
+	return snapshotDir, nil
+}
+
+// CreateFromSnapshot implements the corresponding function in the interface ledger.PeerLedgerProvider.
+// The snapshot files are verified against the hashes present in the snapshot metadata before these are
+// consumed. Same as the function `Create`, this function sets the under construction flag before creating
+// the ledger and atomically removes the flag while adding the ledger to the list of created ledgers.
+// The block store is created last so that the presence of the last block in the block store indicates
+// that all the other stores have been populated from the snapshot
+func (provider *Provider) CreateFromSnapshot(snapshotDir string) (ledger.PeerLedger, string, error) {
+	metadata, err := loadAndVerifySnapshotMetadata(snapshotDir)
+	if err != nil {
+		return nil, "", err
+	}
+	ledgerID := metadata.ChannelName
+	lastBlock, err := readBlockFromSnapshotDir(snapshotDir, lastBlockFileName)
+	if err != nil {
+		return nil, "", err
+	}
+	lastConfigBlock, err := readBlockFromSnapshotDir(snapshotDir, lastConfigBlockFileName)
+	if err != nil {
+		return nil, "", err
+	}
+	if err := verifyLastBlock(lastBlock, metadata); err != nil {
+		return nil, "", err
+	}
+
+	exists, err := provider.idStore.ledgerIDExists(ledgerID)
+	if err != nil {
+		return nil, "", err
+	}
+	if exists {
+		return nil, "", ErrLedgerIDExists
+	}
+	if err = provider.idStore.setUnderConstructionFlag(ledgerID); err != nil {
+		return nil, "", err
+	}
+	lgr, err := provider.createFromSnapshot(ledgerID, snapshotDir, lastBlock, lastConfigBlock)
+	if err != nil {
+		logger.Errorf("Error creating the ledger [%s] from the snapshot. Unsetting under construction flag. Error: %+v", ledgerID, err)
+		panicOnErr(provider.runCleanup(ledgerID), "Error running cleanup for ledger id [%s]", ledgerID)
+		panicOnErr(provider.idStore.unsetUnderConstructionFlag(), "Error while unsetting under construction flag")
+		return nil, "", err
+	}
+	panicOnErr(provider.idStore.createLedgerID(ledgerID, lastConfigBlock), "Error while marking ledger as created")
+	return lgr, ledgerID, nil
+}
+
+func (provider *Provider) createFromSnapshot(ledgerID, snapshotDir string, lastBlock, lastConfigBlock *common.Block) (ledger.PeerLedger, error) {
+	logger.Infof("Creating the ledger [%s] from the snapshot at the block number [%d]", ledgerID, lastBlock.Header.Number)
+	vdb, err := provider.vdbProvider.GetDBHandle(ledgerID)
+	if err != nil {
+		return nil, err
+	}
+	savepoint := version.NewHeight(lastBlock.Header.Number, uint64(len(lastBlock.Data.Data))-1)
+	if err := vdb.ImportPubStateAndPvtStateHashes(snapshotDir, savepoint); err != nil {
+		return nil, err
+	}
+	if err := provider.configHistoryMgr.ImportConfigHistory(ledgerID, snapshotDir); err != nil {
+		return nil, err
+	}
+	if ledgerconfig.IsHistoryDBEnabled() {
+		historyDB, err := provider.historydbProvider.GetDBHandle(ledgerID)
+		if err != nil {
+			return nil, err
+		}
+		if err := historyDB.Commit(lastBlock); err != nil {
+			return nil, err
+		}
+	}
+	blockStore, err := provider.ledgerStoreProvider.CreateFromSnapshot(ledgerID, snapshotDir, lastBlock, lastConfigBlock)
+	if err != nil {
+		return nil, err
+	}
+	return provider.openWithBlockStore(ledgerID, blockStore)
+}
+
+func createSnapshotTempDir(snapshotsRootDir, ledgerID string) (string, error) {
+	tempDirRoot := filepath.Join(snapshotsRootDir, snapshotsTempDirName)
+	if err := os.MkdirAll(tempDirRoot, 0755); err != nil {
+		return "", errors.Wrapf(err, "error while creating the directory [%s]", tempDirRoot)
+	}
+	tempDir, err := ioutil.TempDir(tempDirRoot, ledgerID+"-")
+	if err != nil {
+		return "", errors.Wrapf(err, "error while creating a temporary directory under [%s]", tempDirRoot)
+	}
+	return tempDir, nil
+}
+
+func writeBlockToSnapshotDir(dir, fileName string, block *common.Block) (map[string][]byte, error) {
+	blockBytes, err := proto.Marshal(block)
+	if err != nil {
+		return nil, errors.Wrap(err, "error while marshaling the block")
+	}
+	filePath := filepath.Join(dir, fileName)
+	if err := ioutil.WriteFile(filePath, blockBytes, 0644); err != nil {
+		return nil, errors.Wrapf(err, "error while writing the file [%s]", filePath)
+	}
+	hash, err := snapshot.ComputeFileHash(filePath)
+	if err != nil {
+		return nil, err
+	}
+	return map[string][]byte{fileName: hash}, nil
+}
+
+func readBlockFromSnapshotDir(dir, fileName string) (*common.Block, error) {
+	filePath := filepath.Join(dir, fileName)
+	blockBytes, err := ioutil.ReadFile(filePath)
+	if err != nil {
+		return nil, errors.Wrapf(err, "error while reading the file [%s]", filePath)
+	}
+	block := &common.Block{}
+	if err := proto.Unmarshal(blockBytes, block); err != nil {
+		return nil, errors.Wrapf(err, "error while unmarshaling the block from the file [%s]", filePath)
+	}
+	return block, nil
+}
+
+func writeSnapshotMetadata(dir, ledgerID string, lastBlock *common.Block, filesAndHashes map[string][]byte) error {
+	stateDBType := "goleveldb"
+	if ledgerconfig.IsCouchDBEnabled() {
+		stateDBType = "CouchDB"
+	} else if ledgerconfig.IsJSONDBEnabled() {
+		stateDBType = "JSONDB"
+	}
+	filesAndHashesInHex := map[string]string{}
+	for fileName, hash := range filesAndHashes {
+		filesAndHashesInHex[fileName] = hex.EncodeToString(hash)
+	}
+	signableMetadataBytes, err := json.MarshalIndent(
+		&snapshotSignableMetadata{
+			ChannelName:            ledgerID,
+			LastBlockNumber:        lastBlock.Header.Number,
+			LastBlockHashInHex:     hex.EncodeToString(lastBlock.Header.Hash()),
+			PreviousBlockHashInHex: hex.EncodeToString(lastBlock.Header.PreviousHash),
+			StateDBType:            stateDBType,
+			FilesAndHashes:         filesAndHashesInHex,
+		},
+		"", "    ",
+	)
+	if err != nil {
+		return errors.Wrap(err, "error while marshaling the snapshot metadata")
+	}
+	signableMetadataFilePath := filepath.Join(dir, snapshotSignableMetadataFileName)
+	if err := ioutil.WriteFile(signableMetadataFilePath, signableMetadataBytes, 0644); err != nil {
+		return errors.Wrapf(err, "error while writing the file [%s]", signableMetadataFilePath)
+	}
+	signableMetadataHash, err := snapshot.ComputeFileHash(signableMetadataFilePath)
+	if err != nil {
+		return err
+	}
+
+	additionalMetadataBytes, err := json.MarshalIndent(
+		&snapshotAdditionalMetadata{
+			SnapshotHashInHex: hex.EncodeToString(signableMetadataHash),
+		},
+		"", "    ",
+	)
+	if err != nil {
+		return errors.Wrap(err, "error while marshaling the snapshot additional metadata")
+	}
+	additionalMetadataFilePath := filepath.Join(dir, snapshotAdditionalMetadataFileName)
+	if err := ioutil.WriteFile(additionalMetadataFilePath, additionalMetadataBytes, 0644); err != nil {
+		return errors.Wrapf(err, "error while writing the file [%s]", additionalMetadataFilePath)
+	}
+	return nil
+}
+
+// loadAndVerifySnapshotMetadata loads the metadata of the snapshot and verifies the hash of the
+// signable metadata and the hashes of all the files listed in the signable metadata
+func loadAndVerifySnapshotMetadata(snapshotDir string) (*snapshotSignableMetadata, error) {
+	signableMetadataFilePath := filepath.Join(snapshotDir, snapshotSignableMetadataFileName)
+	signableMetadataBytes, err := ioutil.ReadFile(signableMetadataFilePath)
+	if err != nil {
+		return nil, errors.Wrapf(err, "error while reading the snapshot metadata file [%s]", signableMetadataFilePath)
+	}
+	additionalMetadataFilePath := filepath.Join(snapshotDir, snapshotAdditionalMetadataFileName)
+	additionalMetadataBytes, err := ioutil.ReadFile(additionalMetadataFilePath)
+	if err != nil {
+		return nil, errors.Wrapf(err, "error while reading the snapshot additional metadata file [%s]", additionalMetadataFilePath)
+	}
+	signableMetadata := &snapshotSignableMetadata{}
+	if err := json.Unmarshal(signableMetadataBytes, signableMetadata); err != nil {
+		return nil, errors.Wrapf(err, "error while unmarshaling the snapshot metadata file [%s]", signableMetadataFilePath)
+	}
+	additionalMetadata := &snapshotAdditionalMetadata{}
+	if err := json.Unmarshal(additionalMetadataBytes, additionalMetadata); err != nil {
+		return nil, errors.Wrapf(err, "error while unmarshaling the snapshot additional metadata file [%s]", additionalMetadataFilePath)
+	}
+
+	signableMetadataHash, err := snapshot.ComputeFileHash(signableMetadataFilePath)
+	if err != nil {
+		return nil, err
+	}
+	if hex.EncodeToString(signableMetadataHash) != additionalMetadata.SnapshotHashInHex {
+		return nil, errors.Errorf("hash mismatch for the snapshot metadata file [%s], expected hash = [%s], computed hash = [%x]",
+			signableMetadataFilePath, additionalMetadata.SnapshotHashInHex, signableMetadataHash)
+	}
+	if signableMetadata.StateDBType == "CouchDB" || ledgerconfig.IsCouchDBEnabled() {
+		return nil, errors.New("bootstrapping a ledger from a snapshot is not supported when the state database is CouchDB")
+	}
+	for _, fileName := range []string{lastBlockFileName, lastConfigBlockFileName} {
+		if _, ok := signableMetadata.FilesAndHashes[fileName]; !ok {
+			return nil, errors.Errorf("the snapshot metadata file [%s] does not contain the hash of the file [%s]",
+				signableMetadataFilePath, fileName)
+		}
+	}
+	for fileName, expectedHash := range signableMetadata.FilesAndHashes {
+		hash, err := snapshot.ComputeFileHash(filepath.Join(snapshotDir, fileName))
+		if err != nil {
+			return nil, err
+		}
+		if hex.EncodeToString(hash) != expectedHash {
+			return nil, errors.Errorf("hash mismatch for the snapshot file [%s], expected hash = [%s], computed hash = [%x]",
+				fileName, expectedHash, hash)
+		}
+	}
+	return signableMetadata, nil
+}
+
+func verifyLastBlock(lastBlock *common.Block, metadata *snapshotSignableMetadata) error {
+	if lastBlock.Header.Number != metadata.LastBlockNumber {
+		return errors.Errorf("the number of the last block in the snapshot [%d] does not match the snapshot metadata [%d]",
+			lastBlock.Header.Number, metadata.LastBlockNumber)
+	}
+	expectedHash, err := hex.DecodeString(metadata.LastBlockHashInHex)
+	if err != nil {
+		return errors.Wrap(err, "error while decoding the last block hash in the snapshot metadata")
+	}
+	if !bytes.Equal(lastBlock.Header.Hash(), expectedHash) {
+		return errors.Errorf("the hash of the last block in the snapshot [%x] does not match the snapshot metadata [%x]",
+			lastBlock.Header.Hash(), expectedHash)
+	}
+	channelName, err := utils.GetChainIDFromBlock(lastBlock)
+	if err != nil {
+		return errors.WithMessage(err, "error while extracting the channel name from the last block in the snapshot")
+	}
+	if channelName != metadata.ChannelName {
+		return errors.New(fmt.Sprintf("the channel name in the last block [%s] does not match the snapshot metadata [%s]",
+			channelName, metadata.ChannelName))
+	}
+	return nil
+}
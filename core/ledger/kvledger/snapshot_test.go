@@ -0,0 +1,161 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package kvledger
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/common/ledger/testutil"
+	"github.com/hyperledger/fabric/common/util"
+	lgr "github.com/hyperledger/fabric/core/ledger"
+	"github.com/hyperledger/fabric/core/ledger/ledgerconfig"
+	"github.com/hyperledger/fabric/protos/common"
+	putils "github.com/hyperledger/fabric/protos/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGenerateSnapshotAndCreateFromSnapshot(t *testing.T) {
+	env := newTestEnv(t)
+	defer env.cleanup()
+	provider := testutilNewProvider(t)
+	ledgerID := util.GetTestChainID()
+	bg, gb := testutil.NewBlockGenerator(t, ledgerID, false)
+	sourceLedger, err := provider.Create(gb)
+	assert.NoError(t, err)
+
+	blocks := []*common.Block{gb}
+	for i, value := range []string{"value1", "value2", "value3"} {
+		blocks = append(blocks, commitBlockWithState(t, sourceLedger, bg, "key", value))
+		if i == 0 {
+			blocks = append(blocks, commitBlockWithState(t, sourceLedger, bg, "another-key", value))
+		}
+	}
+	snapshotDir, err := sourceLedger.GenerateSnapshot()
+	assert.NoError(t, err)
+	assert.Equal(t, SnapshotDirForLedgerBlockNum(ledgerconfig.GetSnapshotsRootDir(), ledgerID, 4), snapshotDir)
+	_, err = sourceLedger.GenerateSnapshot()
+	assert.Contains(t, err.Error(), "the snapshot of the ledger [testchainid] at the block number [4] already exists")
+
+	// move the snapshot out of the peer file system path and bootstrap a new peer from it
+	movedSnapshotDir, err := ioutil.TempDir("", "kvledger-snapshot")
+	assert.NoError(t, err)
+	defer os.RemoveAll(movedSnapshotDir)
+	movedSnapshotDir = filepath.Join(movedSnapshotDir, "snapshot")
+	assert.NoError(t, os.Rename(snapshotDir, movedSnapshotDir))
+	sourceLedger.Close()
+	provider.Close()
+	env.cleanup()
+
+	env = newTestEnv(t)
+	defer env.cleanup()
+	provider = testutilNewProvider(t)
+	bootstrappedLedger, bootstrappedLedgerID, err := provider.CreateFromSnapshot(movedSnapshotDir)
+	assert.NoError(t, err)
+	assert.Equal(t, ledgerID, bootstrappedLedgerID)
+
+	bcInfo, err := bootstrappedLedger.GetBlockchainInfo()
+	assert.NoError(t, err)
+	assert.Equal(t, &common.BlockchainInfo{
+		Height:            5,
+		CurrentBlockHash:  blocks[4].Header.Hash(),
+		PreviousBlockHash: blocks[4].Header.PreviousHash,
+	}, bcInfo)
+	for _, blockNum := range []uint64{0, 4} {
+		block, err := bootstrappedLedger.GetBlockByNumber(blockNum)
+		assert.NoError(t, err)
+		assert.True(t, proto.Equal(blocks[blockNum], block))
+	}
+	_, err = bootstrappedLedger.GetBlockByNumber(2)
+	assert.Error(t, err)
+
+	qe, err := bootstrappedLedger.NewQueryExecutor()
+	assert.NoError(t, err)
+	val, err := qe.GetState("ns", "key")
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("value3"), val)
+	val, err = qe.GetState("ns", "another-key")
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("value1"), val)
+	qe.Done()
+
+	txID := txIDOfBlock(t, blocks[2])
+	exists, err := bootstrappedLedger.TxIDExists(txID)
+	assert.NoError(t, err)
+	assert.True(t, exists)
+	_, err = bootstrappedLedger.GetTransactionByID(txID)
+	assert.Error(t, err)
+	exists, err = bootstrappedLedger.TxIDExists("non-existing-txid")
+	assert.NoError(t, err)
+	assert.False(t, exists)
+
+	// the bootstrapped ledger accepts the next block
+	commitBlockWithState(t, bootstrappedLedger, bg, "key", "value4")
+	bcInfo, err = bootstrappedLedger.GetBlockchainInfo()
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(6), bcInfo.Height)
+
+	_, _, err = provider.CreateFromSnapshot(movedSnapshotDir)
+	assert.Equal(t, ErrLedgerIDExists, err)
+
+	// the offline maintenance commands refuse to operate on a peer with a ledger bootstrapped from a snapshot
+	bootstrappedLedger.Close()
+	provider.Close()
+	expectedErr := "the ledger [testchainid] was bootstrapped from a snapshot, the databases cannot be rebuilt for a peer that has a ledger bootstrapped from a snapshot"
+	assert.EqualError(t, RollbackKVLedger(ledgerID, 4), expectedErr)
+	assert.EqualError(t, ResetAllKVLedgers(), expectedErr)
+	assert.EqualError(t, RebuildDBs(), expectedErr)
+}
+
+func TestCreateFromSnapshotWithTamperedFiles(t *testing.T) {
+	env := newTestEnv(t)
+	defer env.cleanup()
+	provider := testutilNewProvider(t)
+	defer provider.Close()
+	bg, gb := testutil.NewBlockGenerator(t, util.GetTestChainID(), false)
+	sourceLedger, err := provider.Create(gb)
+	assert.NoError(t, err)
+	defer sourceLedger.Close()
+	commitBlockWithState(t, sourceLedger, bg, "key", "value")
+	snapshotDir, err := sourceLedger.GenerateSnapshot()
+	assert.NoError(t, err)
+
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(snapshotDir, lastBlockFileName), []byte("tampered"), 0644))
+	_, _, err = provider.CreateFromSnapshot(snapshotDir)
+	assert.Contains(t, err.Error(), "hash mismatch for the snapshot file [last_block.data]")
+
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(snapshotDir, snapshotSignableMetadataFileName), []byte("{}"), 0644))
+	_, _, err = provider.CreateFromSnapshot(snapshotDir)
+	assert.Contains(t, err.Error(), "hash mismatch for the snapshot metadata file")
+}
+
+func commitBlockWithState(t *testing.T, l lgr.PeerLedger, bg *testutil.BlockGenerator, key, value string) *common.Block {
+	simulator, err := l.NewTxSimulator(util.GenerateUUID())
+	assert.NoError(t, err)
+	assert.NoError(t, simulator.SetState("ns", key, []byte(value)))
+	simulator.Done()
+	simRes, err := simulator.GetTxSimulationResults()
+	assert.NoError(t, err)
+	pubSimBytes, err := simRes.GetPubSimulationBytes()
+	assert.NoError(t, err)
+	block := bg.NextBlock([][]byte{pubSimBytes})
+	assert.NoError(t, l.CommitWithPvtData(&lgr.BlockAndPvtData{Block: block}))
+	return block
+}
+
+func txIDOfBlock(t *testing.T, block *common.Block) string {
+	txEnv, err := putils.GetEnvelopeFromBlock(block.Data.Data[0])
+	assert.NoError(t, err)
+	payload, err := putils.GetPayload(txEnv)
+	assert.NoError(t, err)
+	chdr, err := putils.UnmarshalChannelHeader(payload.Header.ChannelHeader)
+	assert.NoError(t, err)
+	return chdr.TxId
+}
@@ -18,6 +18,7 @@ import (
 
 	"github.com/hyperledger/fabric/common/ledger/blkstorage/fsblkstorage"
 	"github.com/hyperledger/fabric/common/ledger/util"
+	"github.com/hyperledger/fabric/core/chaincode/lifecycle"
 	"github.com/hyperledger/fabric/core/common/privdata"
 	"github.com/hyperledger/fabric/core/ledger/ledgerconfig"
 	"github.com/hyperledger/fabric/core/ledger/ledgermgmt"
@@ -108,6 +109,9 @@ func (e *env) verifyRebuilableDoesNotExist(flags rebuildable) {
 	if flags&rebuildableConfigHistory == rebuildableConfigHistory {
 		e.verifyDirDoesNotExist(getConfigHistoryDBPath())
 	}
+	if flags&rebuildableHistoryDB == rebuildableHistoryDB {
+		e.verifyDirDoesNotExist(getHistoryDBPath())
+	}
 }
 
 func (e *env) verifyNonEmptyDirExists(path string) {
@@ -139,10 +143,14 @@ func initLedgerMgmt() {
 	}
 	membershipInfoProvider := privdata.NewMembershipInfoProvider(createSelfSignedData(), identityDeserializerFactory)
 
+	deployedCCInfoProvider := &lifecycle.DeployedCCInfoProvider{
+		LegacyDeployedCCInfoProvider: &lscc.DeployedCCInfoProvider{},
+	}
+
 	ledgermgmt.InitializeExistingTestEnvWithInitializer(
 		&ledgermgmt.Initializer{
 			CustomTxProcessors:            peer.ConfigTxProcessors,
-			DeployedChaincodeInfoProvider: &lscc.DeployedCCInfoProvider{},
+			DeployedChaincodeInfoProvider: deployedCCInfoProvider,
 			MembershipInfoProvider:        membershipInfoProvider,
 			MetricsProvider:               &disabled.Provider{},
 		},
@@ -186,3 +194,7 @@ func getBlockIndexDBPath() string {
 func getConfigHistoryDBPath() string {
 	return ledgerconfig.GetConfigHistoryPath()
 }
+
+func getHistoryDBPath() string {
+	return ledgerconfig.GetHistoryLevelDBPath()
+}
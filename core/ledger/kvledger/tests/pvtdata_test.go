@@ -152,3 +152,24 @@ func TestBTL(t *testing.T) {
 		r.pvtdataShouldNotContain("cc1", "coll2")                   // <cc1, coll2> shold have been purged from the pvtdata storage
 	})
 }
+
+func TestImplicitCollectionsOfLifecycleNamespace(t *testing.T) {
+	env := newEnv(defaultConfig, t)
+	defer env.cleanup()
+	h := newTestHelperCreateLgr("ledger1", t)
+
+	// the implicit collections of the orgs in the namespace of the lifecycle SCC
+	// do not need a collection config
+	h.simulateDataTx("", func(s *simulator) {
+		s.setPvtdata("+lifecycle", "_implicit_org_Org1MSP", "key1", "value1")
+	})
+	blk1 := h.cutBlockAndCommitWithPvtdata()
+	h.verifyPvtState("+lifecycle", "_implicit_org_Org1MSP", "key1", "value1")
+	h.verifyBlockAndPvtDataSameAs(1, blk1)
+
+	// the namespace of the lifecycle SCC has no other collection
+	h.simulateDataTx("", func(s *simulator) {
+		h.assertError(s.GetPrivateData("+lifecycle", "coll1", "key1"))
+		h.assertError(s.SetPrivateData("+lifecycle", "coll1", "key1", []byte("value1")))
+	})
+}
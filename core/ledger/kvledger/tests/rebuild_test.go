@@ -8,6 +8,9 @@ package tests
 
 import (
 	"testing"
+
+	"github.com/hyperledger/fabric/core/ledger/kvledger"
+	"github.com/stretchr/testify/assert"
 )
 
 func TestRebuildComponents(t *testing.T) {
@@ -50,3 +53,28 @@ func TestRebuildComponents(t *testing.T) {
 		},
 	)
 }
+
+func TestRebuildDBs(t *testing.T) {
+	env := newEnv(defaultConfig, t)
+	defer env.cleanup()
+
+	h1, h2 := newTestHelperCreateLgr("ledger1", t), newTestHelperCreateLgr("ledger2", t)
+	dataHelper := newSampleDataHelper(t)
+	dataHelper.populateLedger(h1)
+	dataHelper.populateLedger(h2)
+	dataHelper.verifyLedgerContent(h1)
+	dataHelper.verifyLedgerContent(h2)
+
+	// rebuild is not allowed while the ledgers are open
+	err := kvledger.RebuildDBs()
+	assert.Contains(t, err.Error(), "as another peer node command is executing")
+
+	closeLedgerMgmt()
+	assert.NoError(t, kvledger.RebuildDBs())
+	env.verifyRebuilableDoesNotExist(rebuildableStatedb + rebuildableConfigHistory + rebuildableHistoryDB)
+	initLedgerMgmt()
+
+	h1, h2 = newTestHelperOpenLgr("ledger1", t), newTestHelperOpenLgr("ledger2", t)
+	dataHelper.verifyLedgerContent(h1)
+	dataHelper.verifyLedgerContent(h2)
+}
@@ -0,0 +1,47 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package tests
+
+import (
+	"testing"
+
+	"github.com/hyperledger/fabric/core/ledger/kvledger"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResetAllLedgers(t *testing.T) {
+	env := newEnv(defaultConfig, t)
+	defer env.cleanup()
+
+	h1, h2 := newTestHelperCreateLgr("ledger1", t), newTestHelperCreateLgr("ledger2", t)
+	dataHelper := newSampleDataHelper(t)
+	dataHelper.populateLedger(h1)
+	dataHelper.populateLedger(h2)
+	dataHelper.verifyLedgerContent(h1)
+	dataHelper.verifyLedgerContent(h2)
+
+	// reset is not allowed while the ledgers are open
+	err := kvledger.ResetAllKVLedgers()
+	assert.Contains(t, err.Error(), "as another peer node command is executing")
+
+	closeLedgerMgmt()
+	assert.NoError(t, kvledger.ResetAllKVLedgers())
+	// reset should be re-invokable
+	assert.NoError(t, kvledger.ResetAllKVLedgers())
+	env.verifyRebuilableDoesNotExist(rebuildableStatedb + rebuildableConfigHistory + rebuildableHistoryDB)
+	initLedgerMgmt()
+
+	for _, h := range []*testhelper{newTestHelperOpenLgr("ledger1", t), newTestHelperOpenLgr("ledger2", t)} {
+		h.verifyLedgerHeight(1)
+		h.verifyPubState("cc1", "key1", "")
+		// commit the blocks again
+		for _, blk := range dataHelper.submittedData[h.lgrid].Blocks {
+			assert.NoError(t, h.lgr.CommitWithPvtData(blk))
+		}
+		dataHelper.verifyLedgerContent(h)
+	}
+}
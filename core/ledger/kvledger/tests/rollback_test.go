@@ -0,0 +1,51 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package tests
+
+import (
+	"testing"
+
+	"github.com/hyperledger/fabric/core/ledger/kvledger"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRollbackKVLedger(t *testing.T) {
+	env := newEnv(defaultConfig, t)
+	defer env.cleanup()
+
+	h1, h2 := newTestHelperCreateLgr("ledger1", t), newTestHelperCreateLgr("ledger2", t)
+	dataHelper := newSampleDataHelper(t)
+	dataHelper.populateLedger(h1)
+	dataHelper.populateLedger(h2)
+	dataHelper.verifyLedgerContent(h1)
+	dataHelper.verifyLedgerContent(h2)
+
+	// rollback is not allowed while the ledgers are open
+	err := kvledger.RollbackKVLedger("ledger1", 4)
+	assert.Contains(t, err.Error(), "as another peer node command is executing")
+
+	closeLedgerMgmt()
+	err = kvledger.RollbackKVLedger("nonExistingLedger", 4)
+	assert.EqualError(t, err, "ledgerID [nonExistingLedger] does not exist")
+	err = kvledger.RollbackKVLedger("ledger1", 10)
+	assert.EqualError(t, err, "target block number [10] should be less than the biggest block number [8]")
+	assert.NoError(t, kvledger.RollbackKVLedger("ledger1", 4))
+	env.verifyRebuilableDoesNotExist(rebuildableStatedb + rebuildableConfigHistory)
+	initLedgerMgmt()
+
+	h1, h2 = newTestHelperOpenLgr("ledger1", t), newTestHelperOpenLgr("ledger2", t)
+	h1.verifyLedgerHeight(5)
+	h1.verifyPubState("cc1", "key1", dataHelper.sampleVal("value01", "ledger1"))
+	h1.verifyPvtState("cc1", "coll1", "key3", dataHelper.sampleVal("value05", "ledger1"))
+	dataHelper.verifyLedgerContent(h2)
+
+	// commit the rolled back blocks again
+	for _, blk := range dataHelper.submittedData["ledger1"].Blocks[4:] {
+		assert.NoError(t, h1.lgr.CommitWithPvtData(blk))
+	}
+	dataHelper.verifyLedgerContent(h1)
+}
@@ -0,0 +1,27 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package privacyenabledstate
+
+import (
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/statedb"
+)
+
+// toCacheUpdates converts the updates applied to the db into the updates of the state cache.
+// This is used for the dbs that return the values in the form in which they are committed
+func toCacheUpdates(batch *statedb.UpdateBatch) statedb.CacheUpdates {
+	cacheUpdates := make(statedb.CacheUpdates)
+	for _, ns := range batch.GetUpdatedNamespaces() {
+		for key, vv := range batch.GetUpdates(ns) {
+			cv := &statedb.CacheValue{}
+			if !vv.IsDelete() {
+				cv.VersionedValue = vv
+			}
+			cacheUpdates.Add(ns, key, cv)
+		}
+	}
+	return cacheUpdates
+}
@@ -0,0 +1,79 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package privacyenabledstate
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/hyperledger/fabric/common/metrics/disabled"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/bookkeeping"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/statedb"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/statedb/mock"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/version"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCommonStorageDBWithCache(t *testing.T) {
+	bookkeepingTestEnv := bookkeeping.NewTestEnv(t)
+	defer bookkeepingTestEnv.Cleanup()
+	bookkeeper := bookkeepingTestEnv.TestProvider.GetDBHandle("ledger1", bookkeeping.MetadataPresenceIndicator)
+
+	mockVersionedDB := &mock.VersionedDB{}
+	mockVersionedDB.GetStateReturns(&statedb.VersionedValue{Value: []byte("value1"), Version: version.NewHeight(1, 1)}, nil)
+	mockVersionedDB.GetStateMultipleKeysReturns([]*statedb.VersionedValue{nil}, nil)
+	db := &CommonStorageDB{
+		VersionedDB:  mockVersionedDB,
+		ledgerID:     "ledger1",
+		metadataHint: newMetadataHint(bookkeeper),
+		cache:        statedb.NewCache(10, &disabled.Provider{}),
+	}
+
+	// the first read goes to the db and the subsequent reads are served from the cache
+	for i := 0; i < 2; i++ {
+		vv, err := db.GetState("ns1", "key1")
+		assert.NoError(t, err)
+		assert.Equal(t, []byte("value1"), vv.Value)
+		ver, err := db.GetVersion("ns1", "key1")
+		assert.NoError(t, err)
+		assert.Equal(t, version.NewHeight(1, 1), ver)
+	}
+	assert.Equal(t, 1, mockVersionedDB.GetStateCallCount())
+	assert.Equal(t, 0, mockVersionedDB.GetVersionCallCount())
+
+	// only the keys missing in the cache are loaded from the db
+	vals, err := db.GetStateMultipleKeys("ns1", []string{"key1", "key2"})
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("value1"), vals[0].Value)
+	assert.Nil(t, vals[1])
+	_, keys := mockVersionedDB.GetStateMultipleKeysArgsForCall(0)
+	assert.Equal(t, []string{"key2"}, keys)
+	ver, err := db.GetVersion("ns1", "key2")
+	assert.NoError(t, err)
+	assert.Nil(t, ver)
+	assert.Equal(t, 0, mockVersionedDB.GetVersionCallCount())
+
+	// the committed updates are visible without going to the db
+	updates := NewUpdateBatch()
+	updates.PubUpdates.Put("ns1", "key2", []byte("value2"), version.NewHeight(2, 1))
+	putPvtUpdates(t, updates, "ns1", "coll1", "key1", []byte("pvtvalue1"), version.NewHeight(2, 2))
+	assert.NoError(t, db.ApplyPrivacyAwareUpdates(updates, version.NewHeight(2, 2)))
+	vv, err := db.GetState("ns1", "key2")
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("value2"), vv.Value)
+	vv, err = db.GetPrivateData("ns1", "coll1", "key1")
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("pvtvalue1"), vv.Value)
+	assert.Equal(t, 1, mockVersionedDB.GetStateCallCount())
+
+	// the cache is cleared if the updates fail
+	mockVersionedDB.ApplyUpdatesReturns(errors.New("error applying updates"))
+	assert.EqualError(t, db.ApplyPrivacyAwareUpdates(NewUpdateBatch(), version.NewHeight(3, 1)), "error applying updates")
+	_, err = db.GetState("ns1", "key2")
+	assert.NoError(t, err)
+	assert.Equal(t, 2, mockVersionedDB.GetStateCallCount())
+}
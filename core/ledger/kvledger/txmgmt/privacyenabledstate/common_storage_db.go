@@ -17,6 +17,7 @@ import (
 	"github.com/hyperledger/fabric/core/ledger/kvledger/bookkeeping"
 	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/statedb"
 	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/statedb/statecouchdb"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/statedb/statejsondb"
 	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/statedb/stateleveldb"
 	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/version"
 	"github.com/hyperledger/fabric/core/ledger/ledgerconfig"
@@ -35,20 +36,24 @@ const (
 type CommonStorageDBProvider struct {
 	statedb.VersionedDBProvider
 	bookkeepingProvider bookkeeping.Provider
+	cache               *statedb.Cache
 }
 
 // NewCommonStorageDBProvider constructs an instance of DBProvider
 func NewCommonStorageDBProvider(bookkeeperProvider bookkeeping.Provider, metricsProvider metrics.Provider) (DBProvider, error) {
 	var vdbProvider statedb.VersionedDBProvider
 	var err error
+	cache := statedb.NewCache(ledgerconfig.GetStateCacheMaxKeysPerNamespace(), metricsProvider)
 	if ledgerconfig.IsCouchDBEnabled() {
-		if vdbProvider, err = statecouchdb.NewVersionedDBProvider(metricsProvider); err != nil {
+		if vdbProvider, err = statecouchdb.NewVersionedDBProvider(metricsProvider, cache); err != nil {
 			return nil, err
 		}
+	} else if ledgerconfig.IsJSONDBEnabled() {
+		vdbProvider = statejsondb.NewVersionedDBProvider()
 	} else {
 		vdbProvider = stateleveldb.NewVersionedDBProvider()
 	}
-	return &CommonStorageDBProvider{vdbProvider, bookkeeperProvider}, nil
+	return &CommonStorageDBProvider{vdbProvider, bookkeeperProvider, cache}, nil
 }
 
 // GetDBHandle implements function from interface DBProvider
@@ -59,7 +64,13 @@ func (p *CommonStorageDBProvider) GetDBHandle(id string) (DB, error) {
 	}
 	bookkeeper := p.bookkeepingProvider.GetDBHandle(id, bookkeeping.MetadataPresenceIndicator)
 	metadataHint := newMetadataHint(bookkeeper)
-	return NewCommonStorageDB(vdb, id, metadataHint)
+	// couchdb maintains the cache itself, as it does not preserve the formatting of the JSON values and the
+	// cache has to hold the values in the form in which couchdb returns them, along with the couchdb revisions
+	var cache *statedb.Cache
+	if p.cache.Enabled() && !ledgerconfig.IsCouchDBEnabled() {
+		cache = p.cache
+	}
+	return &CommonStorageDB{VersionedDB: vdb, ledgerID: id, metadataHint: metadataHint, cache: cache}, nil
 }
 
 // Close implements function from interface DBProvider
@@ -68,16 +79,19 @@ func (p *CommonStorageDBProvider) Close() {
 }
 
 // CommonStorageDB implements interface DB. This implementation uses a single database to maintain
-// both the public and private data
+// both the public and private data. If the state cache is enabled, the point reads of the keys are
+// served from the cache, which is updated when the updates are applied
 type CommonStorageDB struct {
 	statedb.VersionedDB
+	ledgerID     string
 	metadataHint *metadataHint
+	cache        *statedb.Cache
 }
 
 // NewCommonStorageDB wraps a VersionedDB instance. The public data is managed directly by the wrapped versionedDB.
 // For managing the hashed data and private data, this implementation creates separate namespaces in the wrapped db
 func NewCommonStorageDB(vdb statedb.VersionedDB, ledgerid string, metadataHint *metadataHint) (DB, error) {
-	return &CommonStorageDB{vdb, metadataHint}, nil
+	return &CommonStorageDB{VersionedDB: vdb, ledgerID: ledgerid, metadataHint: metadataHint}, nil
 }
 
 // IsBulkOptimizable implements corresponding function in interface DB
@@ -135,6 +149,74 @@ func (s *CommonStorageDB) GetChaincodeEventListener() cceventmgmt.ChaincodeLifec
 	return nil
 }
 
+// GetState overrides the function in statedb.VersionedDB to serve the key from the state cache
+func (s *CommonStorageDB) GetState(namespace, key string) (*statedb.VersionedValue, error) {
+	if s.cache == nil {
+		return s.VersionedDB.GetState(namespace, key)
+	}
+	cv, updateSequence := s.cache.GetState(s.ledgerID, namespace, key)
+	if cv != nil {
+		return cv.VersionedValue, nil
+	}
+	vv, err := s.VersionedDB.GetState(namespace, key)
+	if err != nil {
+		return nil, err
+	}
+	s.cache.PutState(updateSequence, s.ledgerID, namespace, key, &statedb.CacheValue{VersionedValue: vv})
+	return vv, nil
+}
+
+// GetVersion overrides the function in statedb.VersionedDB to serve the version of the key from the state cache
+func (s *CommonStorageDB) GetVersion(namespace, key string) (*version.Height, error) {
+	if s.cache == nil {
+		return s.VersionedDB.GetVersion(namespace, key)
+	}
+	cv, _ := s.cache.GetState(s.ledgerID, namespace, key)
+	if cv == nil {
+		return s.VersionedDB.GetVersion(namespace, key)
+	}
+	if cv.VersionedValue == nil {
+		return nil, nil
+	}
+	return cv.VersionedValue.Version, nil
+}
+
+// GetStateMultipleKeys overrides the function in statedb.VersionedDB to serve the keys from the state cache.
+// Only the keys that are not present in the cache are loaded from the db
+func (s *CommonStorageDB) GetStateMultipleKeys(namespace string, keys []string) ([]*statedb.VersionedValue, error) {
+	if s.cache == nil {
+		return s.VersionedDB.GetStateMultipleKeys(namespace, keys)
+	}
+	vals := make([]*statedb.VersionedValue, len(keys))
+	var missingKeys []string
+	var missingIndexes []int
+	var updateSequence uint64
+	for i, key := range keys {
+		cv, seq := s.cache.GetState(s.ledgerID, namespace, key)
+		if cv != nil {
+			vals[i] = cv.VersionedValue
+			continue
+		}
+		if missingKeys == nil {
+			updateSequence = seq
+		}
+		missingKeys = append(missingKeys, key)
+		missingIndexes = append(missingIndexes, i)
+	}
+	if len(missingKeys) == 0 {
+		return vals, nil
+	}
+	missingVals, err := s.VersionedDB.GetStateMultipleKeys(namespace, missingKeys)
+	if err != nil {
+		return nil, err
+	}
+	for i, vv := range missingVals {
+		vals[missingIndexes[i]] = vv
+		s.cache.PutState(updateSequence, s.ledgerID, namespace, missingKeys[i], &statedb.CacheValue{VersionedValue: vv})
+	}
+	return vals, nil
+}
+
 // GetPrivateData implements corresponding function in interface DB
 func (s *CommonStorageDB) GetPrivateData(namespace, collection, key string) (*statedb.VersionedValue, error) {
 	return s.GetState(derivePvtDataNs(namespace, collection), key)
@@ -200,7 +282,17 @@ func (s *CommonStorageDB) ApplyPrivacyAwareUpdates(updates *UpdateBatch, height
 	addPvtUpdates(combinedUpdates, updates.PvtUpdates)
 	addHashedUpdates(combinedUpdates, updates.HashUpdates, !s.BytesKeySupported())
 	s.metadataHint.setMetadataUsedFlag(updates)
-	return s.VersionedDB.ApplyUpdates(combinedUpdates.UpdateBatch, height)
+	if err := s.VersionedDB.ApplyUpdates(combinedUpdates.UpdateBatch, height); err != nil {
+		if s.cache != nil {
+			// the db may have applied a part of the updates
+			s.cache.Clear(s.ledgerID)
+		}
+		return err
+	}
+	if s.cache != nil {
+		s.cache.UpdateStates(s.ledgerID, toCacheUpdates(combinedUpdates.UpdateBatch))
+	}
+	return nil
 }
 
 // GetStateMetadata implements corresponding function in interface DB. This implementation provides
@@ -289,6 +381,49 @@ func (s *CommonStorageDB) HandleChaincodeDeploy(chaincodeDefinition *cceventmgmt
 	return nil
 }
 
+// ListIndexes implements corresponding function in interface DB
+func (s *CommonStorageDB) ListIndexes(namespace, collection string) ([]*statedb.IndexInfo, error) {
+	indexManageable, err := s.indexManageable()
+	if err != nil {
+		return nil, err
+	}
+	return indexManageable.ListIndexes(indexNs(namespace, collection))
+}
+
+// DropIndex implements corresponding function in interface DB
+func (s *CommonStorageDB) DropIndex(namespace, collection, designDoc, indexName string) error {
+	indexManageable, err := s.indexManageable()
+	if err != nil {
+		return err
+	}
+	return indexManageable.DropIndex(indexNs(namespace, collection), designDoc, indexName)
+}
+
+// RebuildIndex implements corresponding function in interface DB
+func (s *CommonStorageDB) RebuildIndex(namespace, collection string, indexDefinition []byte) (*statedb.IndexInfo, error) {
+	indexManageable, err := s.indexManageable()
+	if err != nil {
+		return nil, err
+	}
+	return indexManageable.RebuildIndex(indexNs(namespace, collection), indexDefinition)
+}
+
+func (s *CommonStorageDB) indexManageable() (statedb.IndexManageable, error) {
+	indexManageable, ok := s.VersionedDB.(statedb.IndexManageable)
+	if !ok {
+		return nil, errors.New("the state database does not support the management of indexes")
+	}
+	return indexManageable, nil
+}
+
+// indexNs returns the namespace that holds the data indexed for a namespace, or for one of its collections
+func indexNs(namespace, collection string) string {
+	if collection == "" {
+		return namespace
+	}
+	return derivePvtDataNs(namespace, collection)
+}
+
 // ChaincodeDeployDone is a noop for couchdb state impl
 func (s *CommonStorageDB) ChaincodeDeployDone(succeeded bool) {
 	// NOOP
@@ -39,6 +39,15 @@ type DB interface {
 	GetPrivateDataMetadataByHash(namespace, collection string, keyHash []byte) ([]byte, error)
 	ExecuteQueryOnPrivateData(namespace, collection, query string) (statedb.ResultsIterator, error)
 	ApplyPrivacyAwareUpdates(updates *UpdateBatch, height *version.Height) error
+	ExportPubStateAndPvtStateHashes(dir string) (map[string][]byte, error)
+	ImportPubStateAndPvtStateHashes(dir string, savepoint *version.Height) error
+	// ListIndexes returns the indexes on the data of a namespace, or on the private data of
+	// one of its collections if the collection is not empty
+	ListIndexes(namespace, collection string) ([]*statedb.IndexInfo, error)
+	// DropIndex removes an index on the data of a namespace, or on the private data of one of its collections
+	DropIndex(namespace, collection, designDoc, indexName string) error
+	// RebuildIndex creates or replaces an index on the data of a namespace, or on the private data of one of its collections
+	RebuildIndex(namespace, collection string, indexDefinition []byte) (*statedb.IndexInfo, error)
 }
 
 // PvtdataCompositeKey encloses Namespace, CollectionName and Key components
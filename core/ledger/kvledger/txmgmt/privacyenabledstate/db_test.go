@@ -233,19 +233,15 @@ func testGetStateRangeScanIterator(t *testing.T, env TestEnv) {
 	testItr(t, pvtItr4, []string{"key5", "key6"})
 }
 
-func TestQueryOnCouchDB(t *testing.T) {
+func TestQuery(t *testing.T) {
 	for _, env := range testEnvs {
-		_, ok := env.(*CouchDBCommonStorageTestEnv)
-		if !ok {
-			continue
-		}
 		t.Run(env.GetName(), func(t *testing.T) {
-			testQueryOnCouchDB(t, env)
+			testQuery(t, env)
 		})
 	}
 }
 
-func testQueryOnCouchDB(t *testing.T, env TestEnv) {
+func testQuery(t *testing.T, env TestEnv) {
 	env.Init(t)
 	defer env.Cleanup()
 	db := env.GetDBHandle("test-ledger-id")
@@ -423,6 +419,52 @@ func TestHandleChainCodeDeployOnCouchDB(t *testing.T) {
 	}
 }
 
+func TestIndexManagement(t *testing.T) {
+	for _, env := range testEnvs {
+		t.Run(env.GetName(), func(t *testing.T) {
+			testIndexManagement(t, env)
+		})
+	}
+}
+
+func testIndexManagement(t *testing.T, env TestEnv) {
+	env.Init(t)
+	defer env.Cleanup()
+	db := env.GetDBHandle("test-index-management")
+
+	updates := NewUpdateBatch()
+	updates.PubUpdates.Put("ns1", "key1", []byte(`{"owner":"tom"}`), version.NewHeight(1, 1))
+	putPvtUpdates(t, updates, "ns1", "coll1", "key1", []byte(`{"owner":"jerry"}`), version.NewHeight(1, 2))
+	assert.NoError(t, db.ApplyPrivacyAwareUpdates(updates, version.NewHeight(1, 2)))
+
+	if _, ok := env.(*LevelDBCommonStorageTestEnv); ok {
+		_, err := db.ListIndexes("ns1", "")
+		assert.EqualError(t, err, "the state database does not support the management of indexes")
+		assert.EqualError(t, db.DropIndex("ns1", "", "indexOwnerDoc", "indexOwner"), "the state database does not support the management of indexes")
+		_, err = db.RebuildIndex("ns1", "", []byte(`{"index":{"fields":["owner"]}}`))
+		assert.EqualError(t, err, "the state database does not support the management of indexes")
+		return
+	}
+
+	index, err := db.RebuildIndex("ns1", "coll1", []byte(`{"index":{"fields":["owner"]},"ddoc":"indexOwnerDoc","name":"indexOwner","type":"json"}`))
+	assert.NoError(t, err)
+	assert.Equal(t, "indexOwnerDoc", index.DesignDoc)
+	assert.Equal(t, "indexOwner", index.Name)
+
+	// the index is created on the private data of the collection only
+	indexes, err := db.ListIndexes("ns1", "coll1")
+	assert.NoError(t, err)
+	assert.Equal(t, []*statedb.IndexInfo{index}, indexes)
+	indexes, err = db.ListIndexes("ns1", "")
+	assert.NoError(t, err)
+	assert.Empty(t, indexes)
+
+	assert.NoError(t, db.DropIndex("ns1", "coll1", "indexOwnerDoc", "indexOwner"))
+	indexes, err = db.ListIndexes("ns1", "coll1")
+	assert.NoError(t, err)
+	assert.Empty(t, indexes)
+}
+
 func createCollectionConfig(collectionName string) *common.CollectionConfig {
 	return &common.CollectionConfig{
 		Payload: &common.CollectionConfig_StaticCollectionConfig{
@@ -0,0 +1,201 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package privacyenabledstate
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/statedb"
+	"github.com/pkg/errors"
+)
+
+const maxMigrationBatchSize = 10000
+
+// MigrateState copies all the keys of all the namespaces, which include the namespaces that hold the private data
+// and the hashes of the private data, from the source db to the target db along with their versions and metadata.
+// The keys are written to the target db in batches and the savepoint of the source db is recorded in the target db
+// only after the last batch so that an incomplete migration does not mark the target db as consistent. The keys of
+// the hashes of the private data are re-encoded if only one of the two dbs supports the keys of arbitrary bytes.
+// The function returns the namespaces that are copied to the target db
+func MigrateState(source, target statedb.VersionedDB) ([]string, error) {
+	fullScanIterable, ok := source.(statedb.FullScanIterable)
+	if !ok {
+		return nil, errors.New("migrating the state is not supported when the source state database is CouchDB")
+	}
+	savepoint, err := source.GetLatestSavePoint()
+	if err != nil {
+		return nil, err
+	}
+	itr, err := fullScanIterable.GetFullScanIterator(func(string) bool { return false })
+	if err != nil {
+		return nil, err
+	}
+	defer itr.Close()
+
+	var namespaces []string
+	batch := statedb.NewUpdateBatch()
+	batchSize := 0
+	for {
+		res, err := itr.Next()
+		if err != nil {
+			return nil, err
+		}
+		if res == nil {
+			break
+		}
+		kv := res.(*statedb.VersionedKV)
+		if len(namespaces) == 0 || namespaces[len(namespaces)-1] != kv.Namespace {
+			namespaces = append(namespaces, kv.Namespace)
+		}
+		key, err := convertKey(kv.Namespace, kv.Key, source, target)
+		if err != nil {
+			return nil, err
+		}
+		if err := target.ValidateKeyValue(key, kv.Value); err != nil {
+			return nil, errors.WithMessage(err, fmt.Sprintf("the key [%s] of the namespace [%s] cannot be stored in the target state database", key, kv.Namespace))
+		}
+		batch.PutValAndMetadata(kv.Namespace, key, kv.Value, kv.Metadata, kv.Version)
+		batchSize++
+		if batchSize < maxMigrationBatchSize {
+			continue
+		}
+		if err := target.ApplyUpdates(batch, nil); err != nil {
+			return nil, err
+		}
+		batch = statedb.NewUpdateBatch()
+		batchSize = 0
+	}
+	if err := target.ApplyUpdates(batch, nil); err != nil {
+		return nil, err
+	}
+	if err := target.ApplyUpdates(statedb.NewUpdateBatch(), savepoint); err != nil {
+		return nil, err
+	}
+	return namespaces, nil
+}
+
+// VerifyMigratedState computes a hash of the state of each of the given namespaces in both the dbs and returns an
+// error if the hashes of a namespace do not match. The JSON values are compared in a normalized form, as a db may
+// not preserve the formatting of these values
+func VerifyMigratedState(source, target statedb.VersionedDB, namespaces []string) error {
+	for _, ns := range namespaces {
+		sourceHash, err := computeNamespaceHash(source, ns)
+		if err != nil {
+			return err
+		}
+		targetHash, err := computeNamespaceHash(target, ns)
+		if err != nil {
+			return err
+		}
+		if !bytes.Equal(sourceHash, targetHash) {
+			return errors.Errorf("the state of the namespace [%s] in the target state database does not match "+
+				"the state in the source state database", ns)
+		}
+		logger.Debugf("The state of the namespace [%s] matches, hash = %x", ns, sourceHash)
+	}
+	return nil
+}
+
+// convertKey re-encodes the key of a hash of the private data from the encoding used by the source db
+// to the encoding used by the target db
+func convertKey(ns, key string, source, target statedb.VersionedDB) (string, error) {
+	if _, _, isHashedDataNs := decodeHashedDataNs(ns); !isHashedDataNs {
+		return key, nil
+	}
+	rawKey, err := decodeHashedKey(key, source)
+	if err != nil {
+		return "", err
+	}
+	if !target.BytesKeySupported() {
+		return base64.StdEncoding.EncodeToString([]byte(rawKey)), nil
+	}
+	return rawKey, nil
+}
+
+func decodeHashedKey(key string, db statedb.VersionedDB) (string, error) {
+	if db.BytesKeySupported() {
+		return key, nil
+	}
+	rawKey, err := base64.StdEncoding.DecodeString(key)
+	if err != nil {
+		return "", errors.Wrapf(err, "error decoding the key hash [%s]", key)
+	}
+	return string(rawKey), nil
+}
+
+// computeNamespaceHash computes a hash over the keys, versions, metadata and values of a namespace. The keys are
+// sorted before computing the hash, as the dbs do not necessarily return the keys in the same order
+func computeNamespaceHash(db statedb.VersionedDB, ns string) ([]byte, error) {
+	_, _, isHashedDataNs := decodeHashedDataNs(ns)
+	itr, err := db.GetStateRangeScanIterator(ns, "", "")
+	if err != nil {
+		return nil, err
+	}
+	defer itr.Close()
+
+	entries := map[string][]byte{}
+	for {
+		res, err := itr.Next()
+		if err != nil {
+			return nil, err
+		}
+		if res == nil {
+			break
+		}
+		kv := res.(*statedb.VersionedKV)
+		key := kv.Key
+		if isHashedDataNs {
+			if key, err = decodeHashedKey(key, db); err != nil {
+				return nil, err
+			}
+		}
+		var entry []byte
+		entry = appendLengthPrefixed(entry, kv.Version.ToBytes())
+		entry = appendLengthPrefixed(entry, kv.Metadata)
+		entry = appendLengthPrefixed(entry, normalizeValue(kv.Value))
+		entries[key] = entry
+	}
+
+	keys := make([]string, 0, len(entries))
+	for key := range entries {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	h := sha256.New()
+	for _, key := range keys {
+		h.Write(appendLengthPrefixed(nil, []byte(key)))
+		h.Write(entries[key])
+	}
+	return h.Sum(nil), nil
+}
+
+// normalizeValue returns a JSON object in the form in which it is encoded by encoding/json
+func normalizeValue(value []byte) []byte {
+	decoder := json.NewDecoder(bytes.NewReader(value))
+	decoder.UseNumber()
+	var jsonObject map[string]interface{}
+	if err := decoder.Decode(&jsonObject); err != nil || jsonObject == nil || decoder.More() {
+		return value
+	}
+	normalized, err := json.Marshal(jsonObject)
+	if err != nil {
+		return value
+	}
+	return normalized
+}
+
+func appendLengthPrefixed(b, data []byte) []byte {
+	var lenBytes [binary.MaxVarintLen64]byte
+	n := binary.PutUvarint(lenBytes[:], uint64(len(data)))
+	return append(append(b, lenBytes[:n]...), data...)
+}
@@ -0,0 +1,118 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package privacyenabledstate
+
+import (
+	"testing"
+
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/statedb"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/statedb/statejsondb"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/version"
+	"github.com/hyperledger/fabric/core/ledger/util"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMigrateState(t *testing.T) {
+	sourceEnv := &LevelDBCommonStorageTestEnv{}
+	sourceEnv.Init(t)
+	defer sourceEnv.Cleanup()
+	source := sourceEnv.GetDBHandle("testmigratestate").(*CommonStorageDB)
+
+	updates := NewUpdateBatch()
+	updates.PubUpdates.Put("ns1", "key1", []byte(`{"asset_name": "marble1", "owner": "tom"}`), version.NewHeight(1, 1))
+	updates.PubUpdates.PutValAndMetadata("ns1", "key2", []byte("value2"), []byte("metadata2"), version.NewHeight(1, 2))
+	updates.PubUpdates.Put("ns2", "key1", []byte("value3"), version.NewHeight(1, 3))
+	putPvtUpdatesWithMetadata(t, updates, "ns1", "coll1", "key1", []byte("pvtvalue1"), []byte("pvtmetadata1"), version.NewHeight(1, 4))
+	putPvtUpdates(t, updates, "ns1", "coll2", "key2", []byte("pvtvalue2"), version.NewHeight(1, 5))
+	assert.NoError(t, source.ApplyPrivacyAwareUpdates(updates, version.NewHeight(2, 5)))
+
+	// migrate from a db that supports the keys of arbitrary bytes to a db that does not
+	targetProvider := statejsondb.NewVersionedDBProvider()
+	defer targetProvider.Close()
+	targetVDB, err := targetProvider.GetDBHandle("testmigratestate")
+	assert.NoError(t, err)
+	namespaces, err := MigrateState(source.VersionedDB, targetVDB)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"ns1", "ns1$$hcoll1", "ns1$$hcoll2", "ns1$$pcoll1", "ns1$$pcoll2", "ns2"}, namespaces)
+	assert.NoError(t, VerifyMigratedState(source.VersionedDB, targetVDB, namespaces))
+	savepoint, err := targetVDB.GetLatestSavePoint()
+	assert.NoError(t, err)
+	assert.Equal(t, version.NewHeight(2, 5), savepoint)
+	target, err := NewCommonStorageDB(targetVDB, "testmigratestate", nil)
+	assert.NoError(t, err)
+	checkMigratedState(t, source, target)
+
+	// a mismatch in the state of a namespace is detected
+	batch := statedb.NewUpdateBatch()
+	batch.Put("ns2", "key1", []byte("value4"), version.NewHeight(1, 3))
+	assert.NoError(t, targetVDB.ApplyUpdates(batch, version.NewHeight(2, 5)))
+	assert.EqualError(t, VerifyMigratedState(source.VersionedDB, targetVDB, namespaces),
+		"the state of the namespace [ns2] in the target state database does not match the state in the source state database")
+	batch.Put("ns2", "key1", []byte("value3"), version.NewHeight(1, 3))
+	assert.NoError(t, targetVDB.ApplyUpdates(batch, version.NewHeight(2, 5)))
+
+	// migrate back to a db that supports the keys of arbitrary bytes
+	other := sourceEnv.GetDBHandle("testmigratestateback").(*CommonStorageDB)
+	namespaces, err = MigrateState(targetVDB, other.VersionedDB)
+	assert.NoError(t, err)
+	assert.Len(t, namespaces, 6)
+	assert.NoError(t, VerifyMigratedState(targetVDB, other.VersionedDB, namespaces))
+	checkMigratedState(t, source, other)
+}
+
+func TestMigrateStateInvalidKeyValue(t *testing.T) {
+	sourceEnv := &LevelDBCommonStorageTestEnv{}
+	sourceEnv.Init(t)
+	defer sourceEnv.Cleanup()
+	source := sourceEnv.GetDBHandle("testmigratestateinvalidkeyvalue").(*CommonStorageDB)
+
+	updates := NewUpdateBatch()
+	updates.PubUpdates.Put("ns1", "key1", []byte(`{"_id": "key2", "owner": "tom"}`), version.NewHeight(1, 1))
+	assert.NoError(t, source.ApplyPrivacyAwareUpdates(updates, version.NewHeight(1, 1)))
+
+	targetProvider := statejsondb.NewVersionedDBProvider()
+	defer targetProvider.Close()
+	targetVDB, err := targetProvider.GetDBHandle("testmigratestateinvalidkeyvalue")
+	assert.NoError(t, err)
+	_, err = MigrateState(source.VersionedDB, targetVDB)
+	assert.EqualError(t, err, "the key [key1] of the namespace [ns1] cannot be stored in the target state database: "+
+		"invalid field [_id], fields beginning with \"_\" are reserved")
+	savepoint, err := targetVDB.GetLatestSavePoint()
+	assert.NoError(t, err)
+	assert.Nil(t, savepoint)
+}
+
+func TestNormalizeValue(t *testing.T) {
+	assert.Equal(t, `{"a":2.50,"b":{"c":[1,"x"]}}`, string(normalizeValue([]byte(`{"b": {"c": [1, "x"]}, "a": 2.50}`))))
+	assert.Equal(t, `[1, 2]`, string(normalizeValue([]byte(`[1, 2]`))))
+	assert.Equal(t, `{"a":1} {"b":2}`, string(normalizeValue([]byte(`{"a":1} {"b":2}`))))
+	assert.Equal(t, `non-json`, string(normalizeValue([]byte(`non-json`))))
+}
+
+func checkMigratedState(t *testing.T, source, target DB) {
+	for _, key := range []struct{ ns, key string }{{"ns1", "key1"}, {"ns1", "key2"}, {"ns2", "key1"}} {
+		expected, err := source.GetState(key.ns, key.key)
+		assert.NoError(t, err)
+		actual, err := target.GetState(key.ns, key.key)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, actual)
+	}
+	for _, key := range []struct{ ns, coll, key string }{{"ns1", "coll1", "key1"}, {"ns1", "coll2", "key2"}} {
+		expected, err := source.GetPrivateData(key.ns, key.coll, key.key)
+		assert.NoError(t, err)
+		actual, err := target.GetPrivateData(key.ns, key.coll, key.key)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, actual)
+
+		expected, err = source.GetValueHash(key.ns, key.coll, util.ComputeStringHash(key.key))
+		assert.NoError(t, err)
+		assert.NotNil(t, expected)
+		actual, err = target.GetValueHash(key.ns, key.coll, util.ComputeStringHash(key.key))
+		assert.NoError(t, err)
+		assert.Equal(t, expected, actual)
+	}
+}
@@ -7,12 +7,15 @@ SPDX-License-Identifier: Apache-2.0
 package privacyenabledstate
 
 import (
+	"sync"
+
 	"github.com/hyperledger/fabric/common/ledger/util/leveldbhelper"
 )
 
 type metadataHint struct {
 	cache      map[string]bool
 	bookkeeper *leveldbhelper.DBHandle
+	lock       sync.RWMutex
 }
 
 func newMetadataHint(bookkeeper *leveldbhelper.DBHandle) *metadataHint {
@@ -23,14 +26,18 @@ func newMetadataHint(bookkeeper *leveldbhelper.DBHandle) *metadataHint {
 		namespace := string(itr.Key())
 		cache[namespace] = true
 	}
-	return &metadataHint{cache, bookkeeper}
+	return &metadataHint{cache: cache, bookkeeper: bookkeeper}
 }
 
 func (h *metadataHint) metadataEverUsedFor(namespace string) bool {
+	h.lock.RLock()
+	defer h.lock.RUnlock()
 	return h.cache[namespace]
 }
 
 func (h *metadataHint) setMetadataUsedFlag(updates *UpdateBatch) {
+	h.lock.Lock()
+	defer h.lock.Unlock()
 	batch := leveldbhelper.NewUpdateBatch()
 	for ns := range filterNamespacesThatHasMetadata(updates) {
 		if h.cache[ns] {
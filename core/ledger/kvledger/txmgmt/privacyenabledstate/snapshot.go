@@ -0,0 +1,204 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package privacyenabledstate
+
+import (
+	"path/filepath"
+	"strings"
+
+	"github.com/hyperledger/fabric/common/ledger/snapshot"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/statedb"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/version"
+	"github.com/pkg/errors"
+)
+
+const (
+	snapshotFileFormat = byte(1)
+	// PubStateDataFileName is the name of the snapshot file that contains the public state
+	PubStateDataFileName = "public_state.data"
+	// PvtStateHashesFileName is the name of the snapshot file that contains the hashes of the private state
+	PvtStateHashesFileName = "private_state_hashes.data"
+
+	maxImportBatchSize = 10000
+)
+
+// ExportPubStateAndPvtStateHashes implements the corresponding function in interface DB.
+// It writes the public state and the hashes of the private state into two separate files
+// in the given directory and returns the hashes of these files. The private data itself
+// is not exported. Each record in the public state file contains the namespace, key, version,
+// value, and metadata. Each record in the private state hashes file contains the namespace,
+// collection, key hash, version, value hash, and metadata
+func (s *CommonStorageDB) ExportPubStateAndPvtStateHashes(dir string) (map[string][]byte, error) {
+	fullScanIterable, ok := s.VersionedDB.(statedb.FullScanIterable)
+	if !ok {
+		return nil, errors.New("exporting the state to a snapshot is not supported for the state database in use")
+	}
+	itr, err := fullScanIterable.GetFullScanIterator(isPvtdataNs)
+	if err != nil {
+		return nil, err
+	}
+	defer itr.Close()
+
+	pubStateWriter, err := snapshot.CreateFile(filepath.Join(dir, PubStateDataFileName), snapshotFileFormat)
+	if err != nil {
+		return nil, err
+	}
+	defer pubStateWriter.Close()
+	pvtStateHashesWriter, err := snapshot.CreateFile(filepath.Join(dir, PvtStateHashesFileName), snapshotFileFormat)
+	if err != nil {
+		return nil, err
+	}
+	defer pvtStateHashesWriter.Close()
+
+	for {
+		res, err := itr.Next()
+		if err != nil {
+			return nil, err
+		}
+		if res == nil {
+			break
+		}
+		kv := res.(*statedb.VersionedKV)
+		ns, coll, isHashedDataNs := decodeHashedDataNs(kv.Namespace)
+		if isHashedDataNs {
+			if err := writeSnapshotRecord(pvtStateHashesWriter, kv, ns, coll); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		if err := writeSnapshotRecord(pubStateWriter, kv, kv.Namespace); err != nil {
+			return nil, err
+		}
+	}
+
+	pubStateHash, err := pubStateWriter.Done()
+	if err != nil {
+		return nil, err
+	}
+	pvtStateHashesHash, err := pvtStateHashesWriter.Done()
+	if err != nil {
+		return nil, err
+	}
+	return map[string][]byte{
+		PubStateDataFileName:   pubStateHash,
+		PvtStateHashesFileName: pvtStateHashesHash,
+	}, nil
+}
+
+// ImportPubStateAndPvtStateHashes implements the corresponding function in interface DB.
+// It loads the public state and the hashes of the private state from the files in the given
+// snapshot directory. The data is written to the db in batches and the savepoint is recorded
+// only along with the last batch so that an incomplete import does not mark the db as consistent
+func (s *CommonStorageDB) ImportPubStateAndPvtStateHashes(dir string, savepoint *version.Height) error {
+	pubStateReader, err := snapshot.OpenFile(filepath.Join(dir, PubStateDataFileName), snapshotFileFormat)
+	if err != nil {
+		return err
+	}
+	defer pubStateReader.Close()
+	pvtStateHashesReader, err := snapshot.OpenFile(filepath.Join(dir, PvtStateHashesFileName), snapshotFileFormat)
+	if err != nil {
+		return err
+	}
+	defer pvtStateHashesReader.Close()
+
+	batch := NewUpdateBatch()
+	batchSize := 0
+	for _, r := range []*snapshot.FileReader{pubStateReader, pvtStateHashesReader} {
+		isHashesFile := r == pvtStateHashesReader
+		for {
+			hasMore, err := r.HasMore()
+			if err != nil {
+				return err
+			}
+			if !hasMore {
+				break
+			}
+			if err := readSnapshotRecordIntoBatch(r, batch, isHashesFile); err != nil {
+				return err
+			}
+			batchSize++
+			if batchSize < maxImportBatchSize {
+				continue
+			}
+			if err := s.ApplyPrivacyAwareUpdates(batch, nil); err != nil {
+				return err
+			}
+			batch = NewUpdateBatch()
+			batchSize = 0
+		}
+	}
+	return s.ApplyPrivacyAwareUpdates(batch, savepoint)
+}
+
+func writeSnapshotRecord(w *snapshot.FileWriter, kv *statedb.VersionedKV, nsComponents ...string) error {
+	for _, c := range nsComponents {
+		if err := w.EncodeString(c); err != nil {
+			return err
+		}
+	}
+	if err := w.EncodeString(kv.Key); err != nil {
+		return err
+	}
+	if err := w.EncodeBytes(kv.Version.ToBytes()); err != nil {
+		return err
+	}
+	if err := w.EncodeBytes(kv.Value); err != nil {
+		return err
+	}
+	return w.EncodeBytes(kv.Metadata)
+}
+
+func readSnapshotRecordIntoBatch(r *snapshot.FileReader, batch *UpdateBatch, isHashesFile bool) error {
+	ns, err := r.DecodeString()
+	if err != nil {
+		return err
+	}
+	var coll string
+	if isHashesFile {
+		if coll, err = r.DecodeString(); err != nil {
+			return err
+		}
+	}
+	key, err := r.DecodeString()
+	if err != nil {
+		return err
+	}
+	versionBytes, err := r.DecodeBytes()
+	if err != nil {
+		return err
+	}
+	ver, _ := version.NewHeightFromBytes(versionBytes)
+	value, err := r.DecodeBytes()
+	if err != nil {
+		return err
+	}
+	metadata, err := r.DecodeBytes()
+	if err != nil {
+		return err
+	}
+	if len(metadata) == 0 {
+		metadata = nil
+	}
+	if isHashesFile {
+		batch.HashUpdates.PutValHashAndMetadata(ns, coll, []byte(key), value, metadata, ver)
+		return nil
+	}
+	batch.PubUpdates.PutValAndMetadata(ns, key, value, metadata, ver)
+	return nil
+}
+
+func isPvtdataNs(namespace string) bool {
+	return strings.Contains(namespace, nsJoiner+pvtDataPrefix)
+}
+
+func decodeHashedDataNs(namespace string) (string, string, bool) {
+	splits := strings.SplitN(namespace, nsJoiner+hashDataPrefix, 2)
+	if len(splits) != 2 {
+		return "", "", false
+	}
+	return splits[0], splits[1], true
+}
@@ -0,0 +1,119 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package privacyenabledstate
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hyperledger/fabric/common/ledger/snapshot"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/statedb"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/version"
+	"github.com/hyperledger/fabric/core/ledger/util"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSnapshotExportImport(t *testing.T) {
+	env := &LevelDBCommonStorageTestEnv{}
+	env.Init(t)
+	defer env.Cleanup()
+	snapshotDir, err := ioutil.TempDir("", "privacyenabledstate-snapshot")
+	assert.NoError(t, err)
+	defer os.RemoveAll(snapshotDir)
+
+	db := env.GetDBHandle("source-ledger")
+	updates := NewUpdateBatch()
+	updates.PubUpdates.Put("ns1", "key1", []byte("value1"), version.NewHeight(1, 1))
+	updates.PubUpdates.PutValAndMetadata("ns1", "key2", []byte("value2"), []byte("metadata2"), version.NewHeight(1, 2))
+	updates.PubUpdates.Put("ns2", "key3", []byte("value3"), version.NewHeight(1, 3))
+	putPvtUpdates(t, updates, "ns1", "coll1", "key1", []byte("pvt_value1"), version.NewHeight(1, 4))
+	putPvtUpdatesWithMetadata(t, updates, "ns1", "coll2", "key2", []byte("pvt_value2"), []byte("pvt_metadata2"), version.NewHeight(1, 5))
+	putPvtUpdates(t, updates, "ns2", "coll1", "key3", []byte("pvt_value3"), version.NewHeight(1, 6))
+	assert.NoError(t, db.ApplyPrivacyAwareUpdates(updates, version.NewHeight(1, 6)))
+
+	fileHashes, err := db.ExportPubStateAndPvtStateHashes(snapshotDir)
+	assert.NoError(t, err)
+	assert.Len(t, fileHashes, 2)
+	for fileName, hash := range fileHashes {
+		computedHash, err := snapshot.ComputeFileHash(filepath.Join(snapshotDir, fileName))
+		assert.NoError(t, err)
+		assert.Equal(t, computedHash, hash)
+	}
+
+	importedDB := env.GetDBHandle("imported-ledger")
+	assert.NoError(t, importedDB.ImportPubStateAndPvtStateHashes(snapshotDir, version.NewHeight(1, 6)))
+
+	savepoint, err := importedDB.GetLatestSavePoint()
+	assert.NoError(t, err)
+	assert.Equal(t, version.NewHeight(1, 6), savepoint)
+
+	for _, k := range []struct{ ns, key string }{{"ns1", "key1"}, {"ns1", "key2"}, {"ns2", "key3"}} {
+		expected, err := db.GetState(k.ns, k.key)
+		assert.NoError(t, err)
+		actual, err := importedDB.GetState(k.ns, k.key)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, actual)
+	}
+	for _, k := range []struct{ ns, coll, key string }{{"ns1", "coll1", "key1"}, {"ns1", "coll2", "key2"}, {"ns2", "coll1", "key3"}} {
+		expected, err := db.GetValueHash(k.ns, k.coll, util.ComputeStringHash(k.key))
+		assert.NoError(t, err)
+		actual, err := importedDB.GetValueHash(k.ns, k.coll, util.ComputeStringHash(k.key))
+		assert.NoError(t, err)
+		assert.Equal(t, expected, actual)
+
+		// private data is not exported
+		pvtData, err := importedDB.GetPrivateData(k.ns, k.coll, k.key)
+		assert.NoError(t, err)
+		assert.Nil(t, pvtData)
+	}
+
+	metadata, err := importedDB.GetStateMetadata("ns1", "key2")
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("metadata2"), metadata)
+	metadata, err = importedDB.GetPrivateDataMetadataByHash("ns1", "coll2", util.ComputeStringHash("key2"))
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("pvt_metadata2"), metadata)
+}
+
+func TestSnapshotImportInBatches(t *testing.T) {
+	env := &LevelDBCommonStorageTestEnv{}
+	env.Init(t)
+	defer env.Cleanup()
+	snapshotDir, err := ioutil.TempDir("", "privacyenabledstate-snapshot")
+	assert.NoError(t, err)
+	defer os.RemoveAll(snapshotDir)
+
+	db := env.GetDBHandle("source-ledger")
+	updates := NewUpdateBatch()
+	numKeys := maxImportBatchSize + 10
+	for i := 0; i < numKeys; i++ {
+		updates.PubUpdates.Put("ns", fmt.Sprintf("key-%06d", i), []byte("value"), version.NewHeight(1, 1))
+	}
+	assert.NoError(t, db.ApplyPrivacyAwareUpdates(updates, version.NewHeight(1, 1)))
+	_, err = db.ExportPubStateAndPvtStateHashes(snapshotDir)
+	assert.NoError(t, err)
+
+	importedDB := env.GetDBHandle("imported-ledger")
+	assert.NoError(t, importedDB.ImportPubStateAndPvtStateHashes(snapshotDir, version.NewHeight(1, 1)))
+	itr, err := importedDB.GetStateRangeScanIterator("ns", "", "")
+	assert.NoError(t, err)
+	defer itr.Close()
+	count := 0
+	for {
+		res, err := itr.Next()
+		assert.NoError(t, err)
+		if res == nil {
+			break
+		}
+		assert.Equal(t, []byte("value"), res.(*statedb.VersionedKV).Value)
+		count++
+	}
+	assert.Equal(t, numKeys, count)
+}
@@ -32,7 +32,7 @@ type TestEnv interface {
 // Tests will be run against each environment in this array
 // For example, to skip CouchDB tests, remove &couchDBLockBasedEnv{}
 //var testEnvs = []testEnv{&levelDBCommonStorageTestEnv{}, &couchDBCommonStorageTestEnv{}}
-var testEnvs = []TestEnv{&LevelDBCommonStorageTestEnv{}, &CouchDBCommonStorageTestEnv{}}
+var testEnvs = []TestEnv{&LevelDBCommonStorageTestEnv{}, &JSONDBCommonStorageTestEnv{}, &CouchDBCommonStorageTestEnv{}}
 
 ///////////// LevelDB Environment //////////////
 
@@ -73,6 +73,46 @@ func (env *LevelDBCommonStorageTestEnv) Cleanup() {
 	removeDBPath(env.t)
 }
 
+///////////// JSONDB Environment //////////////
+
+// JSONDBCommonStorageTestEnv implements TestEnv interface for the embedded JSON db based storage
+type JSONDBCommonStorageTestEnv struct {
+	t                 testing.TB
+	provider          DBProvider
+	bookkeeperTestEnv *bookkeeping.TestEnv
+}
+
+// Init implements corresponding function from interface TestEnv
+func (env *JSONDBCommonStorageTestEnv) Init(t testing.TB) {
+	viper.Set("ledger.state.stateDatabase", "JSONDB")
+	removeDBPath(t)
+	env.bookkeeperTestEnv = bookkeeping.NewTestEnv(t)
+	dbProvider, err := NewCommonStorageDBProvider(env.bookkeeperTestEnv.TestProvider, &disabled.Provider{})
+	assert.NoError(t, err)
+	env.t = t
+	env.provider = dbProvider
+}
+
+// GetDBHandle implements corresponding function from interface TestEnv
+func (env *JSONDBCommonStorageTestEnv) GetDBHandle(id string) DB {
+	db, err := env.provider.GetDBHandle(id)
+	assert.NoError(env.t, err)
+	return db
+}
+
+// GetName implements corresponding function from interface TestEnv
+func (env *JSONDBCommonStorageTestEnv) GetName() string {
+	return "jsonDBCommonStorageTestEnv"
+}
+
+// Cleanup implements corresponding function from interface TestEnv
+func (env *JSONDBCommonStorageTestEnv) Cleanup() {
+	env.provider.Close()
+	env.bookkeeperTestEnv.Cleanup()
+	removeDBPath(env.t)
+	viper.Set("ledger.state.stateDatabase", "")
+}
+
 ///////////// CouchDB Environment //////////////
 
 // CouchDBCommonStorageTestEnv implements TestEnv interface for couchdb based storage
@@ -142,9 +182,10 @@ func (env *CouchDBCommonStorageTestEnv) Cleanup() {
 }
 
 func removeDBPath(t testing.TB) {
-	dbPath := ledgerconfig.GetStateLevelDBPath()
-	if err := os.RemoveAll(dbPath); err != nil {
-		t.Fatalf("Err: %s", err)
-		t.FailNow()
+	for _, dbPath := range []string{ledgerconfig.GetStateLevelDBPath(), ledgerconfig.GetStateJSONDBPath()} {
+		if err := os.RemoveAll(dbPath); err != nil {
+			t.Fatalf("Err: %s", err)
+			t.FailNow()
+		}
 	}
 }
@@ -12,6 +12,7 @@ import (
 
 	"github.com/golang/protobuf/proto"
 	"github.com/hyperledger/fabric/common/flogging"
+	"github.com/hyperledger/fabric/core/ledger"
 	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/version"
 	"github.com/hyperledger/fabric/core/ledger/util"
 	"github.com/hyperledger/fabric/protos/ledger/rwset"
@@ -215,6 +216,58 @@ func TestTxSimulationResultWithPvtData(t *testing.T) {
 	assert.Equal(t, expectedPubRWSet, actualSimRes.PubSimulationResults)
 }
 
+func TestTxSimulationResultWithPvtDataPurge(t *testing.T) {
+	rwSetBuilder := NewRWSetBuilder()
+	rwSetBuilder.AddToPvtAndHashedWriteSet("ns1", "coll1", "key1", []byte("pvt-ns1-coll1-key1-value"))
+	rwSetBuilder.AddToPvtAndHashedWriteSetForPurge("ns1", "coll1", "key2")
+
+	actualSimRes, err := rwSetBuilder.GetTxSimulationResults()
+	assert.NoError(t, err)
+
+	// the purge is recorded as a delete in the pvt rwset
+	pvtNs1Coll1 := &kvrwset.KVRWSet{
+		Writes: []*kvrwset.KVWrite{
+			newKVWrite("key1", []byte("pvt-ns1-coll1-key1-value")),
+			{Key: "key2", IsDelete: true},
+		},
+	}
+	expectedPvtRWSet := &rwset.TxPvtReadWriteSet{
+		DataModel: rwset.TxReadWriteSet_KV,
+		NsPvtRwset: []*rwset.NsPvtReadWriteSet{
+			{
+				Namespace: "ns1",
+				CollectionPvtRwset: []*rwset.CollectionPvtReadWriteSet{
+					{
+						CollectionName: "coll1",
+						Rwset:          serializeTestProtoMsg(t, pvtNs1Coll1),
+					},
+				},
+			},
+		},
+	}
+	assert.Equal(t, expectedPvtRWSet, actualSimRes.PvtSimulationResults)
+
+	// the purge is recorded as a delete that is marked as purge in the hashed rwset
+	txRWSet, err := TxRwSetFromProtoMsg(actualSimRes.PubSimulationResults)
+	assert.NoError(t, err)
+	hashedWrites := txRWSet.NsRwSets[0].CollHashedRwSets[0].HashedRwSet.HashedWrites
+	assert.Equal(t,
+		[]*kvrwset.KVWriteHash{
+			{KeyHash: util.ComputeStringHash("key1"), ValueHash: util.ComputeHash([]byte("pvt-ns1-coll1-key1-value"))},
+			{KeyHash: util.ComputeStringHash("key2"), IsDelete: true, IsPurge: true},
+		},
+		hashedWrites,
+	)
+
+	// only the purged key yields a purge marker
+	assert.Equal(t,
+		[]*ledger.PvtDataPurgeMarker{
+			{Namespace: "ns1", Collection: "coll1", KeyHash: util.ComputeStringHash("key2"), BlockNum: 5, TxNum: 2},
+		},
+		txRWSet.GetPvtDataPurgeMarkers(5, 2),
+	)
+}
+
 func TestTxSimulationResultWithMetadata(t *testing.T) {
 	rwSetBuilder := NewRWSetBuilder()
 	// public rws ns1
@@ -18,6 +18,7 @@ package rwsetutil
 
 import (
 	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/core/ledger"
 	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/version"
 	"github.com/hyperledger/fabric/core/ledger/util"
 	"github.com/hyperledger/fabric/protos/ledger/rwset"
@@ -73,6 +74,29 @@ func (nsRwSet *NsRwSet) getPvtDataHash(coll string) []byte {
 	return nil
 }
 
+// GetPvtDataPurgeMarkers returns a purge marker for each private data key that is purged by the
+// transaction, i.e., for each hashed write that is marked as purge in the hashed write-sets
+func (txRwSet *TxRwSet) GetPvtDataPurgeMarkers(blockNum, txNum uint64) []*ledger.PvtDataPurgeMarker {
+	var purgeMarkers []*ledger.PvtDataPurgeMarker
+	for _, nsRwSet := range txRwSet.NsRwSets {
+		for _, collHashedRwSet := range nsRwSet.CollHashedRwSets {
+			for _, kvWriteHash := range collHashedRwSet.HashedRwSet.HashedWrites {
+				if !kvWriteHash.IsPurge {
+					continue
+				}
+				purgeMarkers = append(purgeMarkers, &ledger.PvtDataPurgeMarker{
+					Namespace:  nsRwSet.NameSpace,
+					Collection: collHashedRwSet.CollectionName,
+					KeyHash:    kvWriteHash.KeyHash,
+					BlockNum:   blockNum,
+					TxNum:      txNum,
+				})
+			}
+		}
+	}
+	return purgeMarkers
+}
+
 /////////////////////////////////////////////////////////////////
 // Messages related to PRIVATE read-write set
 /////////////////////////////////////////////////////////////////
@@ -0,0 +1,279 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package statedb
+
+import (
+	"container/list"
+	"sync"
+
+	"github.com/hyperledger/fabric/common/metrics"
+)
+
+// CacheValue is the cached state of a key
+type CacheValue struct {
+	// VersionedValue is nil if the key does not exist in the db
+	VersionedValue *VersionedValue
+	// VersionOnly is true if only the version of the key is known, in which case
+	// the Value and the Metadata of the VersionedValue are not populated
+	VersionOnly bool
+	// AdditionalInfo carries db specific information of the key, for instance, the couchdb revision of the document
+	AdditionalInfo []byte
+}
+
+// CacheUpdates contains the states of the keys updated by a block, organized by namespace. A nil state evicts
+// the key from the cache, so that the key is loaded from the db on the next read
+type CacheUpdates map[string]map[string]*CacheValue
+
+// Add adds the state of a key to the updates
+func (u CacheUpdates) Add(ns, key string, cv *CacheValue) {
+	nsUpdates, ok := u[ns]
+	if !ok {
+		nsUpdates = make(map[string]*CacheValue)
+		u[ns] = nsUpdates
+	}
+	nsUpdates[key] = cv
+}
+
+// Cache is a bounded cache of the committed state of the channels. For each channel, the cache maintains a separate
+// least recently used list for each namespace so that the keys of a busy namespace do not evict the keys of the other
+// namespaces. In addition, the states loaded for the validation of a block can be retained, outside of these lists,
+// until the block is committed. The cache is expected to be updated by the db with the states of the committed keys,
+// in the form in which the db returns them
+type Cache struct {
+	maxKeysPerNs int
+	stats        *cacheStats
+	mutex        sync.Mutex
+	channels     map[string]*channelCache
+}
+
+type channelCache struct {
+	namespaces     map[string]*nsCache
+	retained       CacheUpdates
+	updateSequence uint64
+}
+
+type nsCache struct {
+	entries map[string]*list.Element
+	lru     *list.List
+}
+
+type cacheEntry struct {
+	key string
+	cv  *CacheValue
+}
+
+// NewCache constructs a cache that keeps up to maxKeysPerNs keys of each namespace of a channel. If maxKeysPerNs
+// is not positive, the cache keeps only the retained states
+func NewCache(maxKeysPerNs int, metricsProvider metrics.Provider) *Cache {
+	if maxKeysPerNs < 0 {
+		maxKeysPerNs = 0
+	}
+	return &Cache{
+		maxKeysPerNs: maxKeysPerNs,
+		stats:        newCacheStats(metricsProvider),
+		channels:     make(map[string]*channelCache),
+	}
+}
+
+// Enabled returns true if the cache keeps the keys beyond the commit of a block
+func (c *Cache) Enabled() bool {
+	return c.maxKeysPerNs > 0
+}
+
+// GetState returns the cached state of the key, or nil if the key is not present in the cache. The hits and misses
+// are recorded only for the least recently used lists, not for the retained states. In addition,
+// it returns the update sequence of the channel that is expected to be passed to the functions 'PutState' and
+// 'RetainStates' if the caller loads the key from the db
+func (c *Cache) GetState(chainID, ns, key string) (*CacheValue, uint64) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	chCache := c.channel(chainID)
+	if nsCache, ok := chCache.namespaces[ns]; ok {
+		if element, ok := nsCache.entries[key]; ok {
+			nsCache.lru.MoveToFront(element)
+			c.stats.hit(chainID, ns)
+			return element.Value.(*cacheEntry).cv, chCache.updateSequence
+		}
+	}
+	if c.Enabled() {
+		c.stats.miss(chainID, ns)
+	}
+	if cv, ok := chCache.retained[ns][key]; ok {
+		return cv, chCache.updateSequence
+	}
+	return nil, chCache.updateSequence
+}
+
+// PeekState returns the cached state of the key, or nil if the key is not present in the cache, like the function
+// 'GetState'. Unlike 'GetState', it neither records a hit or a miss nor refreshes the key in the least recently
+// used list, as it is meant for the lookups of the db itself, for instance, of the revisions of the keys to commit
+func (c *Cache) PeekState(chainID, ns, key string) *CacheValue {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	chCache := c.channel(chainID)
+	if nsCache, ok := chCache.namespaces[ns]; ok {
+		if element, ok := nsCache.entries[key]; ok {
+			return element.Value.(*cacheEntry).cv
+		}
+	}
+	return chCache.retained[ns][key]
+}
+
+// PutState adds the state of a key loaded from the db to the cache. The state is dropped if the channel is updated
+// after the function 'GetState' returned the given update sequence, as the loaded state may precede the update
+func (c *Cache) PutState(updateSequence uint64, chainID, ns, key string, cv *CacheValue) {
+	if !c.Enabled() {
+		return
+	}
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	chCache := c.channel(chainID)
+	if updateSequence != chCache.updateSequence {
+		return
+	}
+	c.add(chCache, ns, key, cv)
+}
+
+// RetainStates adds the states of the keys loaded from the db for the validation of a block. These states are
+// not subject to eviction and are kept until the function 'ReleaseStates' is invoked after the block is committed.
+// Like for the function 'PutState', the states are dropped if the channel is updated after the given update sequence
+func (c *Cache) RetainStates(updateSequence uint64, chainID string, states CacheUpdates) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	chCache := c.channel(chainID)
+	if updateSequence != chCache.updateSequence {
+		return
+	}
+	for ns, nsStates := range states {
+		for key, cv := range nsStates {
+			chCache.retained.Add(ns, key, cv)
+		}
+	}
+}
+
+// ReleaseStates drops the retained states of a channel
+func (c *Cache) ReleaseStates(chainID string) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.channel(chainID).retained = make(CacheUpdates)
+}
+
+// UpdateStates applies the states of the keys committed to the db. The retained states of these keys are
+// updated as well, as the retained states are expected to be released only after the commit
+func (c *Cache) UpdateStates(chainID string, updates CacheUpdates) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	chCache := c.channel(chainID)
+	chCache.updateSequence++
+	for ns, nsUpdates := range updates {
+		for key, cv := range nsUpdates {
+			if cv == nil {
+				c.evict(chCache, ns, key)
+				delete(chCache.retained[ns], key)
+				continue
+			}
+			if c.Enabled() {
+				c.add(chCache, ns, key, cv)
+			}
+			if _, ok := chCache.retained[ns][key]; ok {
+				chCache.retained[ns][key] = cv
+			}
+		}
+	}
+}
+
+// Clear evicts all the keys of a channel, including the retained ones. This is used when the outcome of a commit is unknown
+func (c *Cache) Clear(chainID string) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	chCache := c.channel(chainID)
+	chCache.updateSequence++
+	chCache.namespaces = make(map[string]*nsCache)
+	chCache.retained = make(CacheUpdates)
+}
+
+func (c *Cache) channel(chainID string) *channelCache {
+	chCache, ok := c.channels[chainID]
+	if !ok {
+		chCache = &channelCache{
+			namespaces: make(map[string]*nsCache),
+			retained:   make(CacheUpdates),
+		}
+		c.channels[chainID] = chCache
+	}
+	return chCache
+}
+
+func (c *Cache) add(chCache *channelCache, ns, key string, cv *CacheValue) {
+	cache, ok := chCache.namespaces[ns]
+	if !ok {
+		cache = &nsCache{entries: make(map[string]*list.Element), lru: list.New()}
+		chCache.namespaces[ns] = cache
+	}
+	if element, ok := cache.entries[key]; ok {
+		element.Value.(*cacheEntry).cv = cv
+		cache.lru.MoveToFront(element)
+		return
+	}
+	cache.entries[key] = cache.lru.PushFront(&cacheEntry{key, cv})
+	if cache.lru.Len() > c.maxKeysPerNs {
+		oldest := cache.lru.Back()
+		cache.lru.Remove(oldest)
+		delete(cache.entries, oldest.Value.(*cacheEntry).key)
+	}
+}
+
+func (c *Cache) evict(chCache *channelCache, ns, key string) {
+	cache, ok := chCache.namespaces[ns]
+	if !ok {
+		return
+	}
+	if element, ok := cache.entries[key]; ok {
+		cache.lru.Remove(element)
+		delete(cache.entries, key)
+	}
+}
+
+type cacheStats struct {
+	hits   metrics.Counter
+	misses metrics.Counter
+}
+
+func newCacheStats(metricsProvider metrics.Provider) *cacheStats {
+	return &cacheStats{
+		hits:   metricsProvider.NewCounter(cacheHitsOpts),
+		misses: metricsProvider.NewCounter(cacheMissesOpts),
+	}
+}
+
+func (s *cacheStats) hit(chainID, ns string) {
+	s.hits.With("channel", chainID, "namespace", ns).Add(1)
+}
+
+func (s *cacheStats) miss(chainID, ns string) {
+	s.misses.With("channel", chainID, "namespace", ns).Add(1)
+}
+
+var (
+	cacheHitsOpts = metrics.CounterOpts{
+		Namespace:    "ledger",
+		Subsystem:    "",
+		Name:         "statedb_cache_hits",
+		Help:         "Number of state reads served from the state cache.",
+		LabelNames:   []string{"channel", "namespace"},
+		StatsdFormat: "%{#fqname}.%{channel}.%{namespace}",
+	}
+
+	cacheMissesOpts = metrics.CounterOpts{
+		Namespace:    "ledger",
+		Subsystem:    "",
+		Name:         "statedb_cache_misses",
+		Help:         "Number of state reads not found in the state cache.",
+		LabelNames:   []string{"channel", "namespace"},
+		StatsdFormat: "%{#fqname}.%{channel}.%{namespace}",
+	}
+)
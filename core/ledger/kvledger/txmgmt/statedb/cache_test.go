@@ -0,0 +1,153 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package statedb
+
+import (
+	"testing"
+
+	"github.com/hyperledger/fabric/common/metrics"
+	"github.com/hyperledger/fabric/common/metrics/disabled"
+	"github.com/hyperledger/fabric/common/metrics/metricsfakes"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/version"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCache(t *testing.T) {
+	cache := NewCache(2, &disabled.Provider{})
+	assert.True(t, cache.Enabled())
+	cv1 := &CacheValue{VersionedValue: &VersionedValue{Value: []byte("value1"), Version: version.NewHeight(1, 1)}}
+	cv2 := &CacheValue{VersionedValue: &VersionedValue{Value: []byte("value2"), Version: version.NewHeight(1, 2)}}
+	cv3 := &CacheValue{VersionedValue: &VersionedValue{Value: []byte("value3"), Version: version.NewHeight(1, 3)}}
+
+	cv, seq := cache.GetState("ledger1", "ns1", "key1")
+	assert.Nil(t, cv)
+	cache.PutState(seq, "ledger1", "ns1", "key1", cv1)
+	cache.PutState(seq, "ledger1", "ns1", "key2", cv2)
+	cache.PutState(seq, "ledger1", "ns1", "key3", &CacheValue{})
+	cache.PutState(seq, "ledger1", "ns2", "key1", cv3)
+
+	// key1 is the least recently used key of ns1 and is evicted, ns2 has a separate limit
+	cv, _ = cache.GetState("ledger1", "ns1", "key1")
+	assert.Nil(t, cv)
+	cv, _ = cache.GetState("ledger1", "ns1", "key2")
+	assert.Equal(t, cv2, cv)
+	cv, _ = cache.GetState("ledger1", "ns1", "key3")
+	assert.Equal(t, &CacheValue{}, cv)
+	cv, _ = cache.GetState("ledger1", "ns2", "key1")
+	assert.Equal(t, cv3, cv)
+	// the channels are separate
+	cv, _ = cache.GetState("ledger2", "ns2", "key1")
+	assert.Nil(t, cv)
+
+	// a value loaded before an update is not added to the cache
+	_, seq = cache.GetState("ledger1", "ns2", "key2")
+	updates := make(CacheUpdates)
+	updates.Add("ns1", "key3", cv1)
+	updates.Add("ns1", "key2", &CacheValue{})
+	cache.UpdateStates("ledger1", updates)
+	cache.PutState(seq, "ledger1", "ns2", "key2", cv2)
+	cv, _ = cache.GetState("ledger1", "ns2", "key2")
+	assert.Nil(t, cv)
+	cv, _ = cache.GetState("ledger1", "ns1", "key3")
+	assert.Equal(t, cv1, cv)
+	cv, _ = cache.GetState("ledger1", "ns1", "key2")
+	assert.Equal(t, &CacheValue{}, cv)
+
+	// a nil state evicts the key
+	updates = make(CacheUpdates)
+	updates.Add("ns1", "key3", nil)
+	cache.UpdateStates("ledger1", updates)
+	cv, _ = cache.GetState("ledger1", "ns1", "key3")
+	assert.Nil(t, cv)
+	cv, _ = cache.GetState("ledger1", "ns1", "key2")
+	assert.Equal(t, &CacheValue{}, cv)
+
+	cache.Clear("ledger1")
+	cv, _ = cache.GetState("ledger1", "ns2", "key1")
+	assert.Nil(t, cv)
+}
+
+func TestCacheRetainedStates(t *testing.T) {
+	for _, maxKeysPerNs := range []int{0, 1} {
+		cache := NewCache(maxKeysPerNs, &disabled.Provider{})
+		assert.Equal(t, maxKeysPerNs > 0, cache.Enabled())
+		cv1 := &CacheValue{VersionedValue: &VersionedValue{Version: version.NewHeight(1, 1)}, VersionOnly: true, AdditionalInfo: []byte("rev1")}
+		cv2 := &CacheValue{VersionedValue: &VersionedValue{Version: version.NewHeight(1, 2)}, VersionOnly: true, AdditionalInfo: []byte("rev2")}
+		cv3 := &CacheValue{VersionedValue: &VersionedValue{Value: []byte("value3"), Version: version.NewHeight(2, 1)}, AdditionalInfo: []byte("rev3")}
+
+		// the retained states are not subject to eviction
+		_, seq := cache.GetState("ledger1", "ns1", "key1")
+		retained := make(CacheUpdates)
+		retained.Add("ns1", "key1", cv1)
+		retained.Add("ns1", "key2", cv2)
+		cache.RetainStates(seq, "ledger1", retained)
+		cv, _ := cache.GetState("ledger1", "ns1", "key1")
+		assert.Equal(t, cv1, cv)
+		cv, _ = cache.GetState("ledger1", "ns1", "key2")
+		assert.Equal(t, cv2, cv)
+		assert.Equal(t, cv2, cache.PeekState("ledger1", "ns1", "key2"))
+
+		// the retained states are updated by the commit
+		updates := make(CacheUpdates)
+		updates.Add("ns1", "key1", cv3)
+		cache.UpdateStates("ledger1", updates)
+		cv, _ = cache.GetState("ledger1", "ns1", "key1")
+		assert.Equal(t, cv3, cv)
+
+		// a nil state evicts the retained state as well
+		cache.UpdateStates("ledger1", CacheUpdates{"ns1": {"key2": nil}})
+		cv, _ = cache.GetState("ledger1", "ns1", "key2")
+		assert.Nil(t, cv)
+
+		// the states loaded before the commit are not retained
+		cache.RetainStates(seq, "ledger1", CacheUpdates{"ns1": {"key3": cv1}})
+		cv, _ = cache.GetState("ledger1", "ns1", "key3")
+		assert.Nil(t, cv)
+
+		cache.ReleaseStates("ledger1")
+		cv, _ = cache.GetState("ledger1", "ns1", "key1")
+		if cache.Enabled() {
+			assert.Equal(t, cv3, cv)
+		} else {
+			assert.Nil(t, cv)
+		}
+	}
+}
+
+func TestCacheStats(t *testing.T) {
+	hits := &metricsfakes.Counter{}
+	hits.WithReturns(hits)
+	misses := &metricsfakes.Counter{}
+	misses.WithReturns(misses)
+	provider := &metricsfakes.Provider{}
+	provider.NewCounterStub = func(opts metrics.CounterOpts) metrics.Counter {
+		if opts.Name == "statedb_cache_hits" {
+			return hits
+		}
+		return misses
+	}
+	cache := NewCache(10, provider)
+
+	_, seq := cache.GetState("ledger1", "ns1", "key1")
+	cache.PutState(seq, "ledger1", "ns1", "key1", &CacheValue{})
+	cache.GetState("ledger1", "ns1", "key1")
+	assert.Equal(t, 1, misses.AddCallCount())
+	assert.Equal(t, []string{"channel", "ledger1", "namespace", "ns1"}, misses.WithArgsForCall(0))
+	assert.Equal(t, 1, hits.AddCallCount())
+	assert.Equal(t, []string{"channel", "ledger1", "namespace", "ns1"}, hits.WithArgsForCall(0))
+
+	// the peeks of the db are not recorded
+	assert.Equal(t, &CacheValue{}, cache.PeekState("ledger1", "ns1", "key1"))
+	assert.Nil(t, cache.PeekState("ledger1", "ns1", "key2"))
+	assert.Equal(t, 1, misses.AddCallCount())
+	assert.Equal(t, 1, hits.AddCallCount())
+
+	// the reads are not recorded if the cache keeps only the retained states
+	cache = NewCache(0, provider)
+	cache.GetState("ledger1", "ns1", "key1")
+	assert.Equal(t, 1, misses.AddCallCount())
+}
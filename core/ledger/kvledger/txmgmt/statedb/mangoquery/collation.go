@@ -0,0 +1,149 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package mangoquery
+
+import (
+	"bytes"
+	"encoding/json"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// the ranks of the JSON types in the collation order used by CouchDB, i.e.,
+// null < false < true < numbers < strings < arrays < objects
+const (
+	rankNull = iota
+	rankBool
+	rankNumber
+	rankString
+	rankArray
+	rankObject
+)
+
+// DecodeJSON decodes the given bytes into a generic JSON value. The numbers are decoded
+// as json.Number so that the values are returned without any loss of precision
+func DecodeJSON(b []byte) (interface{}, error) {
+	decoder := json.NewDecoder(bytes.NewReader(b))
+	decoder.UseNumber()
+	var v interface{}
+	if err := decoder.Decode(&v); err != nil {
+		return nil, err
+	}
+	if decoder.More() {
+		return nil, &json.SyntaxError{}
+	}
+	return v, nil
+}
+
+// CompareValues compares two JSON values (as decoded by function `DecodeJSON`) using the CouchDB collation
+// across the types. Within a type, the numbers are compared by their numeric value, the strings by their
+// UTF-8 bytes, the arrays element by element and the objects by their keys and values in the sorted order of the keys.
+// The returned value is negative, zero or positive if a is less than, equal to or greater than b respectively
+func CompareValues(a, b interface{}) int {
+	rankA, rankB := typeRank(a), typeRank(b)
+	if rankA != rankB {
+		return rankA - rankB
+	}
+	switch valA := a.(type) {
+	case bool:
+		valB := b.(bool)
+		switch {
+		case valA == valB:
+			return 0
+		case !valA:
+			return -1
+		default:
+			return 1
+		}
+	case json.Number, float64, int, int32, int64:
+		return compareNumbers(toFloat(a), toFloat(b))
+	case string:
+		return strings.Compare(valA, b.(string))
+	case []interface{}:
+		valB := b.([]interface{})
+		for i := 0; i < len(valA) && i < len(valB); i++ {
+			if c := CompareValues(valA[i], valB[i]); c != 0 {
+				return c
+			}
+		}
+		return len(valA) - len(valB)
+	case map[string]interface{}:
+		valB := b.(map[string]interface{})
+		keysA, keysB := sortedKeys(valA), sortedKeys(valB)
+		for i := 0; i < len(keysA) && i < len(keysB); i++ {
+			if c := strings.Compare(keysA[i], keysB[i]); c != 0 {
+				return c
+			}
+			if c := CompareValues(valA[keysA[i]], valB[keysB[i]]); c != 0 {
+				return c
+			}
+		}
+		return len(keysA) - len(keysB)
+	}
+	return 0
+}
+
+func typeRank(v interface{}) int {
+	switch v.(type) {
+	case nil:
+		return rankNull
+	case bool:
+		return rankBool
+	case json.Number, float64, int, int32, int64:
+		return rankNumber
+	case string:
+		return rankString
+	case []interface{}:
+		return rankArray
+	default:
+		return rankObject
+	}
+}
+
+// IsScalar returns true if the given JSON value is null, a boolean, a number or a string
+func IsScalar(v interface{}) bool {
+	return typeRank(v) < rankArray
+}
+
+func toFloat(v interface{}) float64 {
+	switch n := v.(type) {
+	case json.Number:
+		// a value that overflows a float64 is returned as the infinity of the right sign along with the error
+		f, _ := strconv.ParseFloat(string(n), 64)
+		return f
+	case float64:
+		return n
+	case int:
+		return float64(n)
+	case int32:
+		return float64(n)
+	case int64:
+		return float64(n)
+	}
+	return 0
+}
+
+func compareNumbers(a, b float64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	default:
+		return 0
+	}
+}
+
+func sortedKeys(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
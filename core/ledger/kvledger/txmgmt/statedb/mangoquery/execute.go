@@ -0,0 +1,211 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package mangoquery
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"sort"
+
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/statedb"
+	"github.com/pkg/errors"
+)
+
+const idField = "_id"
+
+type result struct {
+	kv           *statedb.VersionedKV
+	doc          map[string]interface{}
+	isJSONObject bool
+	orderValues  []interface{}
+}
+
+// Execute evaluates the query against the given candidates, which are expected to be the results of type
+// *statedb.VersionedKV for the keys of a single namespace, and returns the matching results. A JSON object
+// value is evaluated as a document that carries the key in the field "_id" and any other value is evaluated
+// as a document that contains only the field "_id", which is similar to how CouchDB stores the binary values.
+//
+// The results are sorted by the sort fields of the query or, if the query does not specify a sort, by the
+// given orderFields (ascending) and finally by the key. The orderFields are expected to be the fields of the
+// index from which the candidates are retrieved, if any, so that the results are ordered in the same way as
+// CouchDB orders the results of a query that uses an index. The bookmark, if not empty, is expected to be
+// the one returned by a previous execution of the same query and the results up to the bookmark are skipped.
+// A requestedLimit greater than zero limits the number of results returned. The "limit" of the query itself
+// is ignored, as statecouchdb overrides it with the page size of the query execution. The candidates
+// iterator is closed before this function returns
+func (q *Query) Execute(candidates statedb.ResultsIterator, orderFields []string, requestedLimit int32, bookmark string) (statedb.QueryResultsIterator, error) {
+	defer candidates.Close()
+	if len(q.Sort) > 0 {
+		orderFields = nil
+	}
+	var results []*result
+	for {
+		queryResult, err := candidates.Next()
+		if err != nil {
+			return nil, err
+		}
+		if queryResult == nil {
+			break
+		}
+		kv := queryResult.(*statedb.VersionedKV)
+		doc, isJSONObject := toDocument(kv)
+		if !q.Matches(doc) {
+			continue
+		}
+		r := &result{kv: kv, doc: doc, isJSONObject: isJSONObject}
+		if len(q.Sort) > 0 {
+			var ok bool
+			if r.orderValues, ok = q.SortValues(doc); !ok {
+				continue
+			}
+		} else if len(orderFields) > 0 {
+			var ok bool
+			if r.orderValues, ok = fieldValues(doc, orderFields); !ok {
+				continue
+			}
+		}
+		results = append(results, r)
+	}
+	sort.SliceStable(results, func(i, j int) bool {
+		return q.compare(results[i].orderValues, results[i].kv.Key, results[j].orderValues, results[j].kv.Key) < 0
+	})
+
+	if bookmark != "" {
+		bookmarkValues, bookmarkKey, err := decodeBookmark(bookmark)
+		if err != nil {
+			return nil, err
+		}
+		results = results[sort.Search(len(results), func(i int) bool {
+			return q.compare(results[i].orderValues, results[i].kv.Key, bookmarkValues, bookmarkKey) > 0
+		}):]
+	}
+	if int(q.Skip) >= len(results) {
+		results = nil
+	} else {
+		results = results[q.Skip:]
+	}
+	if requestedLimit > 0 && int(requestedLimit) < len(results) {
+		results = results[:requestedLimit]
+	}
+	return &resultsIterator{query: q, results: results, bookmark: bookmark}, nil
+}
+
+func (q *Query) compare(values1 []interface{}, key1 string, values2 []interface{}, key2 string) int {
+	if c := q.CompareSortValues(values1, values2); c != 0 {
+		return c
+	}
+	switch {
+	case key1 < key2:
+		return -1
+	case key1 > key2:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// toDocument returns the document to be evaluated for the given key-value. The second return
+// value is true if the value is a JSON object
+func toDocument(kv *statedb.VersionedKV) (map[string]interface{}, bool) {
+	doc, isJSONObject := map[string]interface{}(nil), false
+	if decoded, err := DecodeJSON(kv.Value); err == nil {
+		doc, isJSONObject = decoded.(map[string]interface{})
+	}
+	if !isJSONObject {
+		doc = map[string]interface{}{}
+	}
+	doc[idField] = kv.Key
+	return doc, isJSONObject
+}
+
+func fieldValues(doc map[string]interface{}, fields []string) ([]interface{}, bool) {
+	values := make([]interface{}, len(fields))
+	for i, field := range fields {
+		v, found := lookupField(doc, splitFieldName(field))
+		if !found {
+			return nil, false
+		}
+		values[i] = v
+	}
+	return values, true
+}
+
+// encodeBookmark encodes the position of a result as an opaque bookmark
+func encodeBookmark(orderValues []interface{}, key string) (string, error) {
+	b, err := json.Marshal(append([]interface{}{key}, orderValues...))
+	if err != nil {
+		return "", errors.Wrap(err, "error while encoding the bookmark")
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+func decodeBookmark(bookmark string) ([]interface{}, string, error) {
+	b, err := base64.RawURLEncoding.DecodeString(bookmark)
+	if err != nil {
+		return nil, "", errors.Errorf("invalid bookmark [%s]", bookmark)
+	}
+	decoded, err := DecodeJSON(b)
+	if err != nil {
+		return nil, "", errors.Errorf("invalid bookmark [%s]", bookmark)
+	}
+	position, ok := decoded.([]interface{})
+	if !ok || len(position) == 0 {
+		return nil, "", errors.Errorf("invalid bookmark [%s]", bookmark)
+	}
+	key, ok := position[0].(string)
+	if !ok {
+		return nil, "", errors.Errorf("invalid bookmark [%s]", bookmark)
+	}
+	return position[1:], key, nil
+}
+
+type resultsIterator struct {
+	query    *Query
+	results  []*result
+	next     int
+	bookmark string
+}
+
+// Next implements method in interface statedb.ResultsIterator
+func (itr *resultsIterator) Next() (statedb.QueryResult, error) {
+	if itr.next >= len(itr.results) {
+		return nil, nil
+	}
+	r := itr.results[itr.next]
+	itr.next++
+	bookmark, err := encodeBookmark(r.orderValues, r.kv.Key)
+	if err != nil {
+		return nil, err
+	}
+	itr.bookmark = bookmark
+	if len(itr.query.Fields) == 0 || !r.isJSONObject {
+		return r.kv, nil
+	}
+	projected := itr.query.Project(r.doc)
+	delete(projected, idField)
+	value, err := json.Marshal(projected)
+	if err != nil {
+		return nil, errors.Wrapf(err, "error while encoding the fields of the key [%s]", r.kv.Key)
+	}
+	return &statedb.VersionedKV{
+		CompositeKey:   r.kv.CompositeKey,
+		VersionedValue: statedb.VersionedValue{Value: value, Metadata: r.kv.Metadata, Version: r.kv.Version},
+	}, nil
+}
+
+// Close implements method in interface statedb.ResultsIterator
+func (itr *resultsIterator) Close() {
+	itr.results = nil
+}
+
+// GetBookmarkAndClose implements method in interface statedb.QueryResultsIterator. The returned bookmark
+// refers to the last result returned by the iterator or, if no result is returned, is the bookmark that
+// was supplied for the execution of the query
+func (itr *resultsIterator) GetBookmarkAndClose() string {
+	itr.Close()
+	return itr.bookmark
+}
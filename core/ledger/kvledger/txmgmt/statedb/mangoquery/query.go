@@ -0,0 +1,300 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package mangoquery parses and evaluates the subset of the CouchDB Mango query language that is supported
+// by the state databases that do not delegate the rich queries to CouchDB. A query is evaluated against the
+// JSON documents as decoded by the function `DecodeJSON`, where the key of the document is expected to be
+// present in the field "_id" as in CouchDB.
+package mangoquery
+
+import (
+	"encoding/json"
+	"math"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	fieldSelector = "selector"
+	fieldFields   = "fields"
+	fieldSort     = "sort"
+	fieldLimit    = "limit"
+	fieldSkip     = "skip"
+	fieldUseIndex = "use_index"
+	fieldBookmark = "bookmark"
+)
+
+// Query is a parsed Mango query
+type Query struct {
+	// Fields lists the fields to be returned in the results. An empty list means all the fields
+	Fields []string
+	// Sort lists the fields by which the results are to be sorted
+	Sort []SortField
+	// Limit is the maximum number of results specified in the query, zero if not specified
+	Limit int32
+	// Skip is the number of results to be skipped
+	Skip int32
+	// UseIndex contains the design document and optionally the name of the index specified in the query
+	UseIndex []string
+	// Bookmark is the bookmark specified in the query
+	Bookmark string
+
+	selector condition
+}
+
+// SortField is a field by which the results are to be sorted
+type SortField struct {
+	Field      string
+	Descending bool
+}
+
+// Range captures the bounds that a selector places on a field. A nil Start (or End) means that the
+// range is not bounded from below (or above)
+type Range struct {
+	Start          interface{}
+	End            interface{}
+	HasStart       bool
+	HasEnd         bool
+	StartInclusive bool
+	EndInclusive   bool
+}
+
+// IsEquality returns true if the range allows a single value only
+func (r *Range) IsEquality() bool {
+	return r.HasStart && r.HasEnd && r.StartInclusive && r.EndInclusive && CompareValues(r.Start, r.End) == 0
+}
+
+// Parse parses the given Mango query
+func Parse(query string) (*Query, error) {
+	decoded, err := DecodeJSON([]byte(query))
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid query [%s], the query must be a JSON object", query)
+	}
+	jsonQuery, ok := decoded.(map[string]interface{})
+	if !ok {
+		return nil, errors.Errorf("invalid query [%s], the query must be a JSON object", query)
+	}
+	selector, ok := jsonQuery[fieldSelector].(map[string]interface{})
+	if !ok {
+		return nil, errors.Errorf("invalid query [%s], the query must contain a \"%s\" object", query, fieldSelector)
+	}
+	q := &Query{}
+	if q.selector, err = compileSelector(selector, nil); err != nil {
+		return nil, errors.WithMessage(err, "invalid selector")
+	}
+
+	for name, value := range jsonQuery {
+		switch name {
+		case fieldSelector:
+		case fieldFields:
+			if q.Fields, err = parseStringArray(fieldFields, value); err != nil {
+				return nil, err
+			}
+		case fieldSort:
+			if q.Sort, err = parseSort(value); err != nil {
+				return nil, err
+			}
+		case fieldLimit:
+			if q.Limit, err = parseNonNegativeInt32(fieldLimit, value); err != nil {
+				return nil, err
+			}
+		case fieldSkip:
+			if q.Skip, err = parseNonNegativeInt32(fieldSkip, value); err != nil {
+				return nil, err
+			}
+		case fieldUseIndex:
+			if q.UseIndex, err = parseUseIndex(value); err != nil {
+				return nil, err
+			}
+		case fieldBookmark:
+			if q.Bookmark, ok = value.(string); !ok {
+				return nil, errors.Errorf("invalid entry, \"%s\" must be a string", fieldBookmark)
+			}
+		default:
+			return nil, errors.Errorf("invalid entry, option %s not supported", name)
+		}
+	}
+	return q, nil
+}
+
+// Matches returns true if the given document satisfies the selector of the query
+func (q *Query) Matches(doc interface{}) bool {
+	return q.selector.matches(doc)
+}
+
+// FieldRange returns the range of values that the selector allows for the given field. Only the conditions
+// that are combined with (an implicit or an explicit) $and at the top level of the selector and that compare the
+// field with a null, a boolean, a number or a string are considered. A document that satisfies the selector is
+// guaranteed to have a value for the field that falls within the returned range
+func (q *Query) FieldRange(field string) (*Range, bool) {
+	r := &Range{}
+	found := false
+	for _, c := range topLevelConditions(q.selector) {
+		fc, ok := c.(*fieldCondition)
+		if !ok || strings.Join(fc.path, ".") != field || !IsScalar(fc.operand) {
+			continue
+		}
+		switch fc.op {
+		case "$eq":
+			r.Start, r.HasStart, r.StartInclusive = fc.operand, true, true
+			r.End, r.HasEnd, r.EndInclusive = fc.operand, true, true
+			return r, true
+		case "$gt", "$gte":
+			r.Start, r.HasStart, r.StartInclusive = fc.operand, true, fc.op == "$gte"
+			found = true
+		case "$lt", "$lte":
+			r.End, r.HasEnd, r.EndInclusive = fc.operand, true, fc.op == "$lte"
+			found = true
+		}
+	}
+	return r, found
+}
+
+// Project returns a copy of the document that contains only the fields listed in the query. The document
+// itself is returned if no fields are listed
+func (q *Query) Project(doc map[string]interface{}) map[string]interface{} {
+	if len(q.Fields) == 0 {
+		return doc
+	}
+	projected := map[string]interface{}{}
+	for _, field := range q.Fields {
+		path := splitFieldName(field)
+		v, found := lookupField(doc, path)
+		if !found {
+			continue
+		}
+		target := projected
+		for _, name := range path[:len(path)-1] {
+			next, ok := target[name].(map[string]interface{})
+			if !ok {
+				next = map[string]interface{}{}
+				target[name] = next
+			}
+			target = next
+		}
+		target[path[len(path)-1]] = v
+	}
+	return projected
+}
+
+// SortValues returns the values of the sort fields of the query in the given document. The second return
+// value is false if the document lacks any of the sort fields, in which case the document
+// is excluded from the sorted results, as in CouchDB
+func (q *Query) SortValues(doc interface{}) ([]interface{}, bool) {
+	values := make([]interface{}, len(q.Sort))
+	for i, sortField := range q.Sort {
+		v, found := lookupField(doc, splitFieldName(sortField.Field))
+		if !found {
+			return nil, false
+		}
+		values[i] = v
+	}
+	return values, true
+}
+
+// CompareSortValues compares the values returned by the function `SortValues` for two documents
+// as per the directions of the sort fields
+func (q *Query) CompareSortValues(values1, values2 []interface{}) int {
+	for i := 0; i < len(values1) && i < len(values2); i++ {
+		c := CompareValues(values1[i], values2[i])
+		if i < len(q.Sort) && q.Sort[i].Descending {
+			c = -c
+		}
+		if c != 0 {
+			return c
+		}
+	}
+	return len(values1) - len(values2)
+}
+
+func topLevelConditions(c condition) []condition {
+	and, ok := c.(andCondition)
+	if !ok {
+		return []condition{c}
+	}
+	var conditions []condition
+	for _, sub := range and {
+		conditions = append(conditions, topLevelConditions(sub)...)
+	}
+	return conditions
+}
+
+func parseStringArray(name string, value interface{}) ([]string, error) {
+	arr, ok := value.([]interface{})
+	if !ok {
+		return nil, errors.Errorf("invalid entry, \"%s\" must be an array of strings", name)
+	}
+	strs := make([]string, len(arr))
+	for i, elem := range arr {
+		if strs[i], ok = elem.(string); !ok {
+			return nil, errors.Errorf("invalid entry, \"%s\" must be an array of strings", name)
+		}
+	}
+	return strs, nil
+}
+
+func parseNonNegativeInt32(name string, value interface{}) (int32, error) {
+	n, ok := value.(json.Number)
+	if !ok {
+		return 0, errors.Errorf("invalid entry, \"%s\" must be a non-negative integer", name)
+	}
+	i, err := n.Int64()
+	if err != nil || i < 0 || i > math.MaxInt32 {
+		return 0, errors.Errorf("invalid entry, \"%s\" must be a non-negative integer", name)
+	}
+	return int32(i), nil
+}
+
+// parseSort parses the sort syntax of CouchDB, i.e., an array of field names
+// or of single member objects in the form {"field": "asc|desc"}
+func parseSort(value interface{}) ([]SortField, error) {
+	arr, ok := value.([]interface{})
+	if !ok {
+		return nil, errors.Errorf("invalid entry, \"%s\" must be an array", fieldSort)
+	}
+	sortFields := make([]SortField, len(arr))
+	for i, elem := range arr {
+		sortField, ok := parseSortField(elem)
+		if !ok {
+			return nil, errors.Errorf("invalid entry, \"%s\" must contain either field names or objects in the form "+
+				"{\"<field name>\": \"asc|desc\"}", fieldSort)
+		}
+		sortFields[i] = sortField
+	}
+	return sortFields, nil
+}
+
+func parseSortField(elem interface{}) (SortField, bool) {
+	if field, ok := elem.(string); ok {
+		return SortField{Field: field}, true
+	}
+	obj, ok := elem.(map[string]interface{})
+	if !ok || len(obj) != 1 {
+		return SortField{}, false
+	}
+	for field, direction := range obj {
+		switch direction {
+		case "asc":
+			return SortField{Field: field}, true
+		case "desc":
+			return SortField{Field: field, Descending: true}, true
+		}
+	}
+	return SortField{}, false
+}
+
+func parseUseIndex(value interface{}) ([]string, error) {
+	if s, ok := value.(string); ok {
+		return []string{s}, nil
+	}
+	strs, err := parseStringArray(fieldUseIndex, value)
+	if err != nil || len(strs) == 0 || len(strs) > 2 {
+		return nil, errors.Errorf("invalid entry, \"%s\" must be either a design document name "+
+			"or an array of a design document name and an index name", fieldUseIndex)
+	}
+	return strs, nil
+}
@@ -0,0 +1,288 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package mangoquery
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/statedb"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/version"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCompareValues(t *testing.T) {
+	// the values in the CouchDB collation order
+	values := []string{
+		`null`, `false`, `true`, `-10.5`, `-1`, `0`, `1`, `2`, `10`,
+		`""`, `"A"`, `"a"`, `"ab"`, `"b"`, `[]`, `[1]`, `[1,2]`, `[2]`, `{}`, `{"a":1}`, `{"a":2}`, `{"b":1}`,
+	}
+	for i := range values {
+		for j := range values {
+			v1, err := DecodeJSON([]byte(values[i]))
+			assert.NoError(t, err)
+			v2, err := DecodeJSON([]byte(values[j]))
+			assert.NoError(t, err)
+			expected := 0
+			if i < j {
+				expected = -1
+			} else if i > j {
+				expected = 1
+			}
+			assert.Equal(t, expected, sign(CompareValues(v1, v2)), "comparing [%s] and [%s]", values[i], values[j])
+		}
+	}
+	one, _ := DecodeJSON([]byte(`1`))
+	oneFloat, _ := DecodeJSON([]byte(`1.0`))
+	assert.Equal(t, 0, CompareValues(one, oneFloat))
+}
+
+func TestMatches(t *testing.T) {
+	doc := `{"_id":"key1","owner":"tom","size":10,"color":"blue","sold":false,"price":null,
+		"tags":["small","round"],"scores":[3,5,8],"dims":{"h":2,"w":4},"parts":[{"name":"a","qty":1},{"name":"b","qty":5}]}`
+	testCases := []struct {
+		selector string
+		expected bool
+	}{
+		{`{}`, true},
+		{`{"owner":"tom"}`, true},
+		{`{"owner":"jerry"}`, false},
+		{`{"owner":"tom","size":10}`, true},
+		{`{"owner":"tom","size":11}`, false},
+		{`{"owner":{"$eq":"tom"}}`, true},
+		{`{"owner":{"$ne":"tom"}}`, false},
+		{`{"missing":{"$ne":"tom"}}`, false},
+		{`{"size":{"$gt":5,"$lt":20}}`, true},
+		{`{"size":{"$gte":10,"$lte":10}}`, true},
+		{`{"size":{"$gt":10}}`, false},
+		{`{"size":{"$lt":"a"}}`, true},
+		{`{"size":{"$in":[1,10]}}`, true},
+		{`{"size":{"$nin":[1,10]}}`, false},
+		{`{"tags":{"$in":["round"]}}`, true},
+		{`{"tags":{"$all":["round","small"]}}`, true},
+		{`{"tags":{"$all":["round","large"]}}`, false},
+		{`{"tags":{"$size":2}}`, true},
+		{`{"tags":{"$size":3}}`, false},
+		{`{"price":{"$type":"null"}}`, true},
+		{`{"sold":{"$type":"boolean"}}`, true},
+		{`{"dims":{"$type":"object"}}`, true},
+		{`{"tags":{"$type":"string"}}`, false},
+		{`{"size":{"$mod":[3,1]}}`, true},
+		{`{"size":{"$mod":[3,2]}}`, false},
+		{`{"color":{"$regex":"^bl"}}`, true},
+		{`{"color":{"$regex":"^re"}}`, false},
+		{`{"price":{"$exists":true}}`, true},
+		{`{"missing":{"$exists":false}}`, true},
+		{`{"missing":{"$exists":true}}`, false},
+		{`{"scores":{"$elemMatch":{"$gt":7}}}`, true},
+		{`{"scores":{"$allMatch":{"$gt":2}}}`, true},
+		{`{"scores":{"$allMatch":{"$gt":3}}}`, false},
+		{`{"parts":{"$elemMatch":{"name":"b","qty":{"$gt":2}}}}`, true},
+		{`{"parts":{"$elemMatch":{"name":"a","qty":{"$gt":2}}}}`, false},
+		{`{"dims.h":2}`, true},
+		{`{"dims":{"w":{"$gt":3}}}`, true},
+		{`{"dims":{"w":{"$gt":4}}}`, false},
+		{`{"owner":{"$not":{"$eq":"jerry"}}}`, true},
+		{`{"$and":[{"owner":"tom"},{"size":10}]}`, true},
+		{`{"$or":[{"owner":"jerry"},{"size":10}]}`, true},
+		{`{"$or":[{"owner":"jerry"},{"size":11}]}`, false},
+		{`{"$nor":[{"owner":"jerry"},{"size":11}]}`, true},
+		{`{"$not":{"owner":"tom"}}`, false},
+		{`{"_id":"key1"}`, true},
+	}
+	decodedDoc, err := DecodeJSON([]byte(doc))
+	assert.NoError(t, err)
+	for _, testCase := range testCases {
+		q, err := Parse(`{"selector":` + testCase.selector + `}`)
+		assert.NoError(t, err, "selector=%s", testCase.selector)
+		assert.Equal(t, testCase.expected, q.Matches(decodedDoc), "selector=%s", testCase.selector)
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	testCases := []struct {
+		query       string
+		expectedErr string
+	}{
+		{`["selector"]`, `invalid query [["selector"]], the query must be a JSON object`},
+		{`{"fields":["owner"]}`, `invalid query [{"fields":["owner"]}], the query must contain a "selector" object`},
+		{`{"selector":{"owner":{"$unknown":1}}}`, `invalid selector: invalid operator [$unknown]`},
+		{`{"selector":{"$or":{"owner":"tom"}}}`, `invalid selector: invalid operand for the operator [$or], must be a non-empty array of selectors`},
+		{`{"selector":{"owner":{"$in":"tom"}}}`, `invalid selector: invalid operand for the operator [$in], must be an array`},
+		{`{"selector":{"owner":{"$exists":1}}}`, `invalid selector: invalid operand for the operator [$exists], must be a boolean`},
+		{`{"selector":{"owner":{"$size":-1}}}`, `invalid selector: invalid operand for the operator [$size], must be a non-negative integer`},
+		{`{"selector":{"owner":{"$mod":[0,1]}}}`, `invalid selector: invalid operand for the operator [$mod], must be an array of a non-zero divisor and a remainder`},
+		{`{"selector":{"owner":{"$regex":1}}}`, `invalid selector: invalid operand for the operator [$regex], must be a string`},
+		{`{"selector":{},"fields":"owner"}`, `invalid entry, "fields" must be an array of strings`},
+		{`{"selector":{},"limit":-1}`, `invalid entry, "limit" must be a non-negative integer`},
+		{`{"selector":{},"skip":1.5}`, `invalid entry, "skip" must be a non-negative integer`},
+		{`{"selector":{},"sort":"owner"}`, `invalid entry, "sort" must be an array`},
+		{`{"selector":{},"bookmark":1}`, `invalid entry, "bookmark" must be a string`},
+		{`{"selector":{},"r":1}`, `invalid entry, option r not supported`},
+	}
+	for _, testCase := range testCases {
+		_, err := Parse(testCase.query)
+		assert.EqualError(t, err, testCase.expectedErr)
+	}
+
+	q, err := Parse(`{"selector":{"owner":"tom"},"fields":["owner"],"sort":["size",{"color":"desc"}],` +
+		`"limit":10,"skip":2,"use_index":"_design/indexOwnerDoc","bookmark":"bm"}`)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"owner"}, q.Fields)
+	assert.Equal(t, []SortField{{Field: "size"}, {Field: "color", Descending: true}}, q.Sort)
+	assert.Equal(t, int32(10), q.Limit)
+	assert.Equal(t, int32(2), q.Skip)
+	assert.Equal(t, []string{"_design/indexOwnerDoc"}, q.UseIndex)
+	assert.Equal(t, "bm", q.Bookmark)
+}
+
+func TestFieldRange(t *testing.T) {
+	q, err := Parse(`{"selector":{"owner":"tom","size":{"$gt":1,"$lte":5},"color":{"$in":["blue"]},"$or":[{"price":1},{"price":2}]}}`)
+	assert.NoError(t, err)
+
+	r, ok := q.FieldRange("owner")
+	assert.True(t, ok)
+	assert.True(t, r.IsEquality())
+	assert.Equal(t, "tom", r.Start)
+
+	r, ok = q.FieldRange("size")
+	assert.True(t, ok)
+	assert.False(t, r.IsEquality())
+	assert.True(t, r.HasStart && r.HasEnd)
+	assert.False(t, r.StartInclusive)
+	assert.True(t, r.EndInclusive)
+
+	_, ok = q.FieldRange("color")
+	assert.False(t, ok)
+	_, ok = q.FieldRange("price")
+	assert.False(t, ok)
+	_, ok = q.FieldRange("missing")
+	assert.False(t, ok)
+}
+
+func TestProject(t *testing.T) {
+	q, err := Parse(`{"selector":{},"fields":["owner","dims.h","missing"]}`)
+	assert.NoError(t, err)
+	doc, err := DecodeJSON([]byte(`{"owner":"tom","size":10,"dims":{"h":2,"w":4}}`))
+	assert.NoError(t, err)
+	projected := q.Project(doc.(map[string]interface{}))
+	assert.Equal(t, map[string]interface{}{"owner": "tom", "dims": map[string]interface{}{"h": doc.(map[string]interface{})["dims"].(map[string]interface{})["h"]}}, projected)
+}
+
+func TestExecute(t *testing.T) {
+	var candidates []*statedb.VersionedKV
+	for i := 1; i <= 10; i++ {
+		candidates = append(candidates, &statedb.VersionedKV{
+			CompositeKey:   statedb.CompositeKey{Namespace: "ns1", Key: fmt.Sprintf("key%02d", i)},
+			VersionedValue: statedb.VersionedValue{Value: []byte(fmt.Sprintf(`{"size":%d,"color":"%s"}`, i%4, []string{"blue", "red"}[i%2])), Version: version.NewHeight(1, uint64(i))},
+		})
+	}
+	candidates = append(candidates, &statedb.VersionedKV{
+		CompositeKey:   statedb.CompositeKey{Namespace: "ns1", Key: "key11"},
+		VersionedValue: statedb.VersionedValue{Value: []byte("non-json-value"), Version: version.NewHeight(1, 11)},
+	})
+
+	testCases := []struct {
+		name           string
+		query          string
+		orderFields    []string
+		requestedLimit int32
+		expectedKeys   []string
+	}{
+		{"all", `{"selector":{}}`, nil, 0,
+			[]string{"key01", "key02", "key03", "key04", "key05", "key06", "key07", "key08", "key09", "key10", "key11"}},
+		{"by-id", `{"selector":{"_id":{"$gt":"key09"}}}`, nil, 0, []string{"key10", "key11"}},
+		{"selector", `{"selector":{"color":"red"}}`, nil, 0, []string{"key01", "key03", "key05", "key07", "key09"}},
+		{"order-fields", `{"selector":{"color":"blue"}}`, []string{"size"}, 0, []string{"key04", "key08", "key02", "key06", "key10"}},
+		{"sort", `{"selector":{"color":"red"},"sort":[{"size":"desc"}]}`, []string{"color"}, 0, []string{"key03", "key07", "key01", "key05", "key09"}},
+		{"skip-and-limit", `{"selector":{"color":"red"},"skip":1,"limit":1}`, nil, 2, []string{"key03", "key05"}},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			q, err := Parse(testCase.query)
+			assert.NoError(t, err)
+			itr, err := q.Execute(newSliceIterator(candidates), testCase.orderFields, testCase.requestedLimit, "")
+			assert.NoError(t, err)
+			assert.Equal(t, testCase.expectedKeys, resultKeys(t, itr))
+		})
+	}
+
+	// pagination
+	q, err := Parse(`{"selector":{"color":"blue"},"sort":["size"]}`)
+	assert.NoError(t, err)
+	var keys []string
+	bookmark := ""
+	for {
+		itr, err := q.Execute(newSliceIterator(candidates), nil, 2, bookmark)
+		assert.NoError(t, err)
+		pageKeys := resultKeys(t, itr)
+		newBookmark := itr.GetBookmarkAndClose()
+		if len(pageKeys) == 0 {
+			assert.Equal(t, bookmark, newBookmark)
+			break
+		}
+		keys = append(keys, pageKeys...)
+		bookmark = newBookmark
+	}
+	assert.Equal(t, []string{"key04", "key08", "key02", "key06", "key10"}, keys)
+
+	_, err = q.Execute(newSliceIterator(candidates), nil, 2, "not-a-bookmark")
+	assert.EqualError(t, err, "invalid bookmark [not-a-bookmark]")
+
+	// fields
+	q, err = Parse(`{"selector":{"size":3},"fields":["size","_id"]}`)
+	assert.NoError(t, err)
+	itr, err := q.Execute(newSliceIterator(candidates), nil, 1, "")
+	assert.NoError(t, err)
+	res, err := itr.Next()
+	assert.NoError(t, err)
+	assert.Equal(t, "key03", res.(*statedb.VersionedKV).Key)
+	assert.Equal(t, `{"size":3}`, string(res.(*statedb.VersionedKV).Value))
+	assert.Equal(t, version.NewHeight(1, 3), res.(*statedb.VersionedKV).Version)
+}
+
+func sign(c int) int {
+	switch {
+	case c < 0:
+		return -1
+	case c > 0:
+		return 1
+	default:
+		return 0
+	}
+}
+
+type sliceIterator struct {
+	kvs []*statedb.VersionedKV
+}
+
+func newSliceIterator(kvs []*statedb.VersionedKV) *sliceIterator {
+	return &sliceIterator{kvs: kvs}
+}
+
+func (itr *sliceIterator) Next() (statedb.QueryResult, error) {
+	if len(itr.kvs) == 0 {
+		return nil, nil
+	}
+	kv := itr.kvs[0]
+	itr.kvs = itr.kvs[1:]
+	return kv, nil
+}
+
+func (itr *sliceIterator) Close() {}
+
+func resultKeys(t *testing.T, itr statedb.ResultsIterator) []string {
+	var keys []string
+	for {
+		res, err := itr.Next()
+		assert.NoError(t, err)
+		if res == nil {
+			return keys
+		}
+		keys = append(keys, res.(*statedb.VersionedKV).Key)
+	}
+}
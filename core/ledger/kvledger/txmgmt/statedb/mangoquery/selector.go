@@ -0,0 +1,352 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package mangoquery
+
+import (
+	"encoding/json"
+	"math"
+	"regexp"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// condition is a compiled part of a selector that is evaluated against a JSON value
+type condition interface {
+	matches(v interface{}) bool
+}
+
+type andCondition []condition
+
+func (c andCondition) matches(v interface{}) bool {
+	for _, sub := range c {
+		if !sub.matches(v) {
+			return false
+		}
+	}
+	return true
+}
+
+type orCondition []condition
+
+func (c orCondition) matches(v interface{}) bool {
+	for _, sub := range c {
+		if sub.matches(v) {
+			return true
+		}
+	}
+	return false
+}
+
+type notCondition struct {
+	sub condition
+}
+
+func (c *notCondition) matches(v interface{}) bool {
+	return !c.sub.matches(v)
+}
+
+// fieldCondition applies an operator to the value present at the path in the evaluated value
+type fieldCondition struct {
+	path    []string
+	op      string
+	operand interface{}
+	// sub is set for the operators that take a selector as the operand ($elemMatch, $allMatch and $not)
+	sub condition
+	// regex is set for the operator $regex
+	regex *regexp.Regexp
+}
+
+func (c *fieldCondition) matches(v interface{}) bool {
+	fieldVal, found := lookupField(v, c.path)
+	switch c.op {
+	case "$exists":
+		return found == c.operand.(bool)
+	case "$not":
+		// as in CouchDB, a missing field does not match a negated condition
+		return found && !c.sub.matches(fieldVal)
+	}
+	if !found {
+		return false
+	}
+	switch c.op {
+	case "$eq":
+		return CompareValues(fieldVal, c.operand) == 0
+	case "$ne":
+		return CompareValues(fieldVal, c.operand) != 0
+	case "$gt":
+		return CompareValues(fieldVal, c.operand) > 0
+	case "$gte":
+		return CompareValues(fieldVal, c.operand) >= 0
+	case "$lt":
+		return CompareValues(fieldVal, c.operand) < 0
+	case "$lte":
+		return CompareValues(fieldVal, c.operand) <= 0
+	case "$in":
+		return containsAny(c.operand.([]interface{}), fieldVal)
+	case "$nin":
+		return !containsAny(c.operand.([]interface{}), fieldVal)
+	case "$all":
+		arr, ok := fieldVal.([]interface{})
+		if !ok {
+			return false
+		}
+		for _, expected := range c.operand.([]interface{}) {
+			if !contains(arr, expected) {
+				return false
+			}
+		}
+		return true
+	case "$size":
+		arr, ok := fieldVal.([]interface{})
+		return ok && len(arr) == c.operand.(int)
+	case "$type":
+		return jsonTypeName(fieldVal) == c.operand.(string)
+	case "$mod":
+		n, ok := fieldVal.(json.Number)
+		if !ok {
+			return false
+		}
+		i, err := n.Int64()
+		if err != nil {
+			return false
+		}
+		divisorAndRemainder := c.operand.([2]int64)
+		return i%divisorAndRemainder[0] == divisorAndRemainder[1]
+	case "$regex":
+		s, ok := fieldVal.(string)
+		return ok && c.regex.MatchString(s)
+	case "$elemMatch":
+		arr, ok := fieldVal.([]interface{})
+		if !ok {
+			return false
+		}
+		for _, elem := range arr {
+			if c.sub.matches(elem) {
+				return true
+			}
+		}
+		return false
+	case "$allMatch":
+		arr, ok := fieldVal.([]interface{})
+		if !ok || len(arr) == 0 {
+			return false
+		}
+		for _, elem := range arr {
+			if !c.sub.matches(elem) {
+				return false
+			}
+		}
+		return true
+	}
+	return false
+}
+
+// containsAny returns true if the value is present in the list or, if the value is an array,
+// any of its elements is present in the list
+func containsAny(list []interface{}, v interface{}) bool {
+	if arr, ok := v.([]interface{}); ok {
+		for _, elem := range arr {
+			if contains(list, elem) {
+				return true
+			}
+		}
+	}
+	return contains(list, v)
+}
+
+func contains(list []interface{}, v interface{}) bool {
+	for _, elem := range list {
+		if CompareValues(elem, v) == 0 {
+			return true
+		}
+	}
+	return false
+}
+
+func jsonTypeName(v interface{}) string {
+	switch typeRank(v) {
+	case rankNull:
+		return "null"
+	case rankBool:
+		return "boolean"
+	case rankNumber:
+		return "number"
+	case rankString:
+		return "string"
+	case rankArray:
+		return "array"
+	default:
+		return "object"
+	}
+}
+
+// lookupField returns the value present at the given path. An empty path refers to the value itself
+func lookupField(v interface{}, path []string) (interface{}, bool) {
+	for _, name := range path {
+		obj, ok := v.(map[string]interface{})
+		if !ok {
+			return nil, false
+		}
+		if v, ok = obj[name]; !ok {
+			return nil, false
+		}
+	}
+	return v, true
+}
+
+// splitFieldName splits a field name that uses the dot notation for the nested fields
+func splitFieldName(field string) []string {
+	return strings.Split(field, ".")
+}
+
+// compileSelector compiles a selector that is applied to the value present at the given path.
+// The members of a selector are combined with an implicit $and. A member whose name is a field
+// name either specifies the value to match or, if the value is an object, a nested selector for the field
+func compileSelector(selector map[string]interface{}, path []string) (condition, error) {
+	conditions := andCondition{}
+	for _, name := range sortedKeys(selector) {
+		operand := selector[name]
+		if !strings.HasPrefix(name, "$") {
+			fieldPath := append(append([]string{}, path...), splitFieldName(name)...)
+			if nested, ok := operand.(map[string]interface{}); ok && len(nested) > 0 {
+				c, err := compileSelector(nested, fieldPath)
+				if err != nil {
+					return nil, err
+				}
+				conditions = append(conditions, c)
+				continue
+			}
+			conditions = append(conditions, &fieldCondition{path: fieldPath, op: "$eq", operand: operand})
+			continue
+		}
+		c, err := compileOperator(name, operand, path)
+		if err != nil {
+			return nil, err
+		}
+		conditions = append(conditions, c)
+	}
+	if len(conditions) == 1 {
+		return conditions[0], nil
+	}
+	return conditions, nil
+}
+
+func compileOperator(op string, operand interface{}, path []string) (condition, error) {
+	switch op {
+	case "$and", "$or", "$nor":
+		selectors, ok := operand.([]interface{})
+		if !ok || len(selectors) == 0 {
+			return nil, errors.Errorf("invalid operand for the operator [%s], must be a non-empty array of selectors", op)
+		}
+		conditions := make([]condition, len(selectors))
+		for i, s := range selectors {
+			selector, ok := s.(map[string]interface{})
+			if !ok {
+				return nil, errors.Errorf("invalid operand for the operator [%s], must be a non-empty array of selectors", op)
+			}
+			c, err := compileSelector(selector, path)
+			if err != nil {
+				return nil, err
+			}
+			conditions[i] = c
+		}
+		switch op {
+		case "$and":
+			return andCondition(conditions), nil
+		case "$or":
+			return orCondition(conditions), nil
+		default:
+			return &notCondition{orCondition(conditions)}, nil
+		}
+
+	case "$not", "$elemMatch", "$allMatch":
+		selector, ok := operand.(map[string]interface{})
+		if !ok {
+			return nil, errors.Errorf("invalid operand for the operator [%s], must be a selector", op)
+		}
+		if op == "$not" && len(path) == 0 {
+			c, err := compileSelector(selector, path)
+			if err != nil {
+				return nil, err
+			}
+			return &notCondition{c}, nil
+		}
+		c, err := compileSelector(selector, nil)
+		if err != nil {
+			return nil, err
+		}
+		return &fieldCondition{path: path, op: op, sub: c}, nil
+
+	case "$eq", "$ne", "$gt", "$gte", "$lt", "$lte":
+		return &fieldCondition{path: path, op: op, operand: operand}, nil
+
+	case "$in", "$nin", "$all":
+		if _, ok := operand.([]interface{}); !ok {
+			return nil, errors.Errorf("invalid operand for the operator [%s], must be an array", op)
+		}
+		return &fieldCondition{path: path, op: op, operand: operand}, nil
+
+	case "$exists":
+		if _, ok := operand.(bool); !ok {
+			return nil, errors.Errorf("invalid operand for the operator [%s], must be a boolean", op)
+		}
+		return &fieldCondition{path: path, op: op, operand: operand}, nil
+
+	case "$type":
+		typeName, ok := operand.(string)
+		switch typeName {
+		case "null", "boolean", "number", "string", "array", "object":
+		default:
+			ok = false
+		}
+		if !ok {
+			return nil, errors.Errorf("invalid operand for the operator [%s], must be one of "+
+				"\"null\", \"boolean\", \"number\", \"string\", \"array\" or \"object\"", op)
+		}
+		return &fieldCondition{path: path, op: op, operand: typeName}, nil
+
+	case "$size":
+		size, ok := toInt(operand)
+		if !ok || size < 0 || size > math.MaxInt32 {
+			return nil, errors.Errorf("invalid operand for the operator [%s], must be a non-negative integer", op)
+		}
+		return &fieldCondition{path: path, op: op, operand: int(size)}, nil
+
+	case "$mod":
+		arr, ok := operand.([]interface{})
+		if ok && len(arr) == 2 {
+			divisor, ok1 := toInt(arr[0])
+			remainder, ok2 := toInt(arr[1])
+			if ok1 && ok2 && divisor != 0 {
+				return &fieldCondition{path: path, op: op, operand: [2]int64{divisor, remainder}}, nil
+			}
+		}
+		return nil, errors.Errorf("invalid operand for the operator [%s], must be an array of a non-zero divisor and a remainder", op)
+
+	case "$regex":
+		pattern, ok := operand.(string)
+		if !ok {
+			return nil, errors.Errorf("invalid operand for the operator [%s], must be a string", op)
+		}
+		regex, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid regular expression [%s] for the operator [%s]", pattern, op)
+		}
+		return &fieldCondition{path: path, op: op, regex: regex}, nil
+	}
+	return nil, errors.Errorf("invalid operator [%s]", op)
+}
+
+func toInt(v interface{}) (int64, bool) {
+	n, ok := v.(json.Number)
+	if !ok {
+		return 0, false
+	}
+	i, err := n.Int64()
+	return i, err == nil
+}
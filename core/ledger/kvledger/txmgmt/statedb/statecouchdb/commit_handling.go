@@ -17,6 +17,7 @@ import (
 // nsCommittersBuilder implements `batch` interface. Each batch operates on a specific namespace in the updates and
 // builds one or more batches of type subNsCommitter.
 type nsCommittersBuilder struct {
+	ns              string
 	updates         map[string]*statedb.VersionedValue
 	db              *couchdb.CouchDatabase
 	revisions       map[string]string
@@ -25,8 +26,10 @@ type nsCommittersBuilder struct {
 
 // subNsCommitter implements `batch` interface. Each batch commits the portion of updates within a namespace assigned to it
 type subNsCommitter struct {
+	ns             string
 	db             *couchdb.CouchDatabase
 	batchUpdateMap map[string]*batchableDocument
+	revisions      map[string]string
 }
 
 // buildCommitters build the batches of type subNsCommitter. This functions processes different namespaces in parallel
@@ -39,13 +42,15 @@ func (vdb *VersionedDB) buildCommitters(updates *statedb.UpdateBatch) ([]batch,
 		if err != nil {
 			return nil, err
 		}
-		nsRevs := vdb.committedDataCache.revs[ns]
-		if nsRevs == nil {
-			nsRevs = make(nsRevisions)
-		}
 		// for each namespace, construct one builder with the corresponding couchdb handle and couch revisions
 		// that are already loaded into cache (during validation phase)
-		nsCommitterBuilder = append(nsCommitterBuilder, &nsCommittersBuilder{updates: nsUpdates, db: db, revisions: nsRevs})
+		nsRevs := make(map[string]string)
+		for key := range nsUpdates {
+			if cv := vdb.cache.PeekState(vdb.chainName, ns, key); cv != nil {
+				nsRevs[key] = string(cv.AdditionalInfo)
+			}
+		}
+		nsCommitterBuilder = append(nsCommitterBuilder, &nsCommittersBuilder{ns: ns, updates: nsUpdates, db: db, revisions: nsRevs})
 	}
 	if err := executeBatches(nsCommitterBuilder); err != nil {
 		return nil, err
@@ -73,23 +78,52 @@ func (builder *nsCommittersBuilder) execute() error {
 		}
 		batchUpdateMap[key] = &batchableDocument{CouchDoc: *couchDoc, Deleted: vv.Value == nil}
 		if len(batchUpdateMap) == maxBacthSize {
-			builder.subNsCommitters = append(builder.subNsCommitters, &subNsCommitter{builder.db, batchUpdateMap})
+			builder.subNsCommitters = append(builder.subNsCommitters, &subNsCommitter{ns: builder.ns, db: builder.db, batchUpdateMap: batchUpdateMap})
 			batchUpdateMap = make(map[string]*batchableDocument)
 		}
 	}
 	if len(batchUpdateMap) > 0 {
-		builder.subNsCommitters = append(builder.subNsCommitters, &subNsCommitter{builder.db, batchUpdateMap})
+		builder.subNsCommitters = append(builder.subNsCommitters, &subNsCommitter{ns: builder.ns, db: builder.db, batchUpdateMap: batchUpdateMap})
 	}
 	return nil
 }
 
 // execute implements the function in `batch` interface. This function commits the updates managed by a `subNsCommitter`
 func (committer *subNsCommitter) execute() error {
-	return commitUpdates(committer.db, committer.batchUpdateMap)
+	revisions, err := commitUpdates(committer.db, committer.batchUpdateMap)
+	committer.revisions = revisions
+	return err
 }
 
-// commitUpdates commits the given updates to couchdb
-func commitUpdates(db *couchdb.CouchDatabase, batchUpdateMap map[string]*batchableDocument) error {
+// committedStates returns the states of the keys committed by the given batches of type subNsCommitter, in the form
+// in which couchdb returns them. The values are normalized by a round trip through the couchdb document format
+func committedStates(committers []batch) statedb.CacheUpdates {
+	states := make(statedb.CacheUpdates)
+	for _, b := range committers {
+		committer := b.(*subNsCommitter)
+		for key, doc := range committer.batchUpdateMap {
+			if doc.Deleted {
+				states.Add(committer.ns, key, &statedb.CacheValue{})
+				continue
+			}
+			kv, err := couchDocToKeyValue(&doc.CouchDoc)
+			if err != nil {
+				// the key is evicted from the cache and is loaded from couchdb on the next read
+				logger.Warningf("Error converting the committed document of the key [%s] in namespace [%s]: %s", key, committer.ns, err)
+				states.Add(committer.ns, key, nil)
+				continue
+			}
+			states.Add(committer.ns, key, &statedb.CacheValue{
+				VersionedValue: kv.VersionedValue,
+				AdditionalInfo: []byte(committer.revisions[key]),
+			})
+		}
+	}
+	return states
+}
+
+// commitUpdates commits the given updates to couchdb and returns the new revisions of the documents
+func commitUpdates(db *couchdb.CouchDatabase, batchUpdateMap map[string]*batchableDocument) (map[string]string, error) {
 	//Add the documents to the batch update array
 	batchUpdateDocs := []*couchdb.CouchDoc{}
 	for _, updateDocument := range batchUpdateMap {
@@ -100,11 +134,13 @@ func commitUpdates(db *couchdb.CouchDatabase, batchUpdateMap map[string]*batchab
 	// Do the bulk update into couchdb. Note that this will do retries if the entire bulk update fails or times out
 	batchUpdateResp, err := db.BatchUpdateDocuments(batchUpdateDocs)
 	if err != nil {
-		return err
+		return nil, err
 	}
+	revisions := make(map[string]string)
 	// IF INDIVIDUAL DOCUMENTS IN THE BULK UPDATE DID NOT SUCCEED, TRY THEM INDIVIDUALLY
 	// iterate through the response from CouchDB by document
 	for _, respDoc := range batchUpdateResp {
+		revisions[respDoc.ID] = respDoc.Rev
 		// If the document returned an error, retry the individual document
 		if respDoc.Ok != true {
 			batchUpdateDocument := batchUpdateMap[respDoc.ID]
@@ -115,7 +151,7 @@ func commitUpdates(db *couchdb.CouchDatabase, batchUpdateMap map[string]*batchab
 			if batchUpdateDocument.CouchDoc.JSONValue != nil {
 				err = removeJSONRevision(&batchUpdateDocument.CouchDoc.JSONValue)
 				if err != nil {
-					return err
+					return nil, err
 				}
 			}
 			// Check to see if the document was added to the batch as a delete type document
@@ -129,7 +165,7 @@ func commitUpdates(db *couchdb.CouchDatabase, batchUpdateMap map[string]*batchab
 				logger.Warningf("CouchDB batch document update encountered an problem. Retrying update for document ID:%s", respDoc.ID)
 				// Save the individual document to couchdb
 				// Note that this will do retries as needed
-				_, err = db.SaveDoc(respDoc.ID, "", &batchUpdateDocument.CouchDoc)
+				revisions[respDoc.ID], err = db.SaveDoc(respDoc.ID, "", &batchUpdateDocument.CouchDoc)
 			}
 
 			// If the single document update or delete returns an error, then throw the error
@@ -138,11 +174,11 @@ func commitUpdates(db *couchdb.CouchDatabase, batchUpdateMap map[string]*batchab
 					respDoc.ID, respDoc.Error, respDoc.Reason)
 
 				logger.Errorf(errorString)
-				return errors.WithMessage(err, errorString)
+				return nil, errors.WithMessage(err, errorString)
 			}
 		}
 	}
-	return nil
+	return revisions, nil
 }
 
 // nsFlusher implements `batch` interface and a batch executes the function `couchdb.EnsureFullCommit()` for the given namespace
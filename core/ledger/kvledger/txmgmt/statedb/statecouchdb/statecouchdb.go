@@ -9,10 +9,12 @@ import (
 	"bytes"
 	"encoding/json"
 	"fmt"
+	"strings"
 	"sync"
 
 	"github.com/hyperledger/fabric/common/flogging"
 	"github.com/hyperledger/fabric/common/metrics"
+	"github.com/hyperledger/fabric/common/metrics/disabled"
 	"github.com/hyperledger/fabric/core/common/ccprovider"
 	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/statedb"
 	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/version"
@@ -36,10 +38,11 @@ type VersionedDBProvider struct {
 	databases     map[string]*VersionedDB
 	mux           sync.Mutex
 	openCounts    uint64
+	cache         *statedb.Cache
 }
 
-// NewVersionedDBProvider instantiates VersionedDBProvider
-func NewVersionedDBProvider(metricsProvider metrics.Provider) (*VersionedDBProvider, error) {
+// NewVersionedDBProvider instantiates VersionedDBProvider. The committed state of the keys is maintained in the given cache
+func NewVersionedDBProvider(metricsProvider metrics.Provider, cache *statedb.Cache) (*VersionedDBProvider, error) {
 	logger.Debugf("constructing CouchDB VersionedDBProvider")
 	couchDBDef := couchdb.GetCouchDBDefinition()
 	couchInstance, err := couchdb.CreateCouchInstance(couchDBDef.URL, couchDBDef.Username, couchDBDef.Password,
@@ -47,7 +50,7 @@ func NewVersionedDBProvider(metricsProvider metrics.Provider) (*VersionedDBProvi
 	if err != nil {
 		return nil, err
 	}
-	return &VersionedDBProvider{couchInstance, make(map[string]*VersionedDB), sync.Mutex{}, 0}, nil
+	return &VersionedDBProvider{couchInstance, make(map[string]*VersionedDB), sync.Mutex{}, 0, cache}, nil
 }
 
 // GetDBHandle gets the handle to a named database
@@ -57,7 +60,7 @@ func (provider *VersionedDBProvider) GetDBHandle(dbName string) (statedb.Version
 	vdb := provider.databases[dbName]
 	if vdb == nil {
 		var err error
-		vdb, err = newVersionedDB(provider.couchInstance, dbName)
+		vdb, err = newVersionedDB(provider.couchInstance, dbName, provider.cache)
 		if err != nil {
 			return nil, err
 		}
@@ -71,16 +74,41 @@ func (provider *VersionedDBProvider) Close() {
 	// No close needed on Couch
 }
 
+// DropApplicationDBs drops all the application databases (i.e., the databases maintained for all the channels)
+// in the CouchDB instance configured for the peer. This function is expected to be invoked only when the
+// peer is not running and the state of all the channels is to be rebuilt from the block store
+func DropApplicationDBs() error {
+	logger.Info("Dropping CouchDB application databases ...")
+	couchDBDef := couchdb.GetCouchDBDefinition()
+	couchInstance, err := couchdb.CreateCouchInstance(couchDBDef.URL, couchDBDef.Username, couchDBDef.Password,
+		couchDBDef.MaxRetries, couchDBDef.MaxRetriesOnStartup, couchDBDef.RequestTimeout, couchDBDef.CreateGlobalChangesDB,
+		&disabled.Provider{})
+	if err != nil {
+		return err
+	}
+	dbNames, err := couchInstance.RetrieveApplicationDBNames()
+	if err != nil {
+		return err
+	}
+	for _, dbName := range dbNames {
+		db := &couchdb.CouchDatabase{CouchInstance: couchInstance, DBName: dbName}
+		if _, err := db.DropDatabase(); err != nil {
+			return errors.WithMessage(err, fmt.Sprintf("error while dropping the CouchDB database [%s]", dbName))
+		}
+		logger.Infof("Dropped CouchDB database [%s]", dbName)
+	}
+	return nil
+}
+
 // VersionedDB implements VersionedDB interface
 type VersionedDB struct {
-	couchInstance      *couchdb.CouchInstance
-	metadataDB         *couchdb.CouchDatabase            // A database per channel to store metadata such as savepoint.
-	chainName          string                            // The name of the chain/channel.
-	namespaceDBs       map[string]*couchdb.CouchDatabase // One database per deployed chaincode.
-	committedDataCache *versionsCache                    // Used as a local cache during bulk processing of a block.
-	verCacheLock       sync.RWMutex
-	mux                sync.RWMutex
-	lsccStateCache     *lsccStateCache
+	couchInstance  *couchdb.CouchInstance
+	metadataDB     *couchdb.CouchDatabase            // A database per channel to store metadata such as savepoint.
+	chainName      string                            // The name of the chain/channel.
+	namespaceDBs   map[string]*couchdb.CouchDatabase // One database per deployed chaincode.
+	cache          *statedb.Cache                    // The committed values, versions and revisions of the keys.
+	mux            sync.RWMutex
+	lsccStateCache *lsccStateCache
 }
 
 type lsccStateCache struct {
@@ -133,7 +161,7 @@ func (l *lsccStateCache) evictARandomEntry() {
 }
 
 // newVersionedDB constructs an instance of VersionedDB
-func newVersionedDB(couchInstance *couchdb.CouchInstance, dbName string) (*VersionedDB, error) {
+func newVersionedDB(couchInstance *couchdb.CouchInstance, dbName string, cache *statedb.Cache) (*VersionedDB, error) {
 	// CreateCouchDatabase creates a CouchDB database object, as well as the underlying database if it does not exist
 	chainName := dbName
 	dbName = couchdb.ConstructMetadataDBName(dbName)
@@ -144,11 +172,11 @@ func newVersionedDB(couchInstance *couchdb.CouchInstance, dbName string) (*Versi
 	}
 	namespaceDBMap := make(map[string]*couchdb.CouchDatabase)
 	return &VersionedDB{
-		couchInstance:      couchInstance,
-		metadataDB:         metadataDB,
-		chainName:          chainName,
-		namespaceDBs:       namespaceDBMap,
-		committedDataCache: newVersionCache(),
+		couchInstance: couchInstance,
+		metadataDB:    metadataDB,
+		chainName:     chainName,
+		namespaceDBs:  namespaceDBMap,
+		cache:         cache,
 		lsccStateCache: &lsccStateCache{
 			cache: make(map[string]*statedb.VersionedValue),
 		},
@@ -201,16 +229,109 @@ func (vdb *VersionedDB) GetDBType() string {
 	return "couchdb"
 }
 
-// LoadCommittedVersions populates committedVersions and revisionNumbers into cache.
-// A bulk retrieve from couchdb is used to populate the cache.
-// committedVersions cache will be used for state validation of readsets
-// revisionNumbers cache will be used during commit phase for couchdb bulk updates
+// ListIndexes implements method in IndexManageable interface
+func (vdb *VersionedDB) ListIndexes(namespace string) ([]*statedb.IndexInfo, error) {
+	db, err := vdb.getNamespaceDBHandle(namespace)
+	if err != nil {
+		return nil, err
+	}
+	indexes, err := db.ListIndex()
+	if err != nil {
+		return nil, errors.WithMessage(err, fmt.Sprintf("error listing the indexes for namespace [%s]", namespace))
+	}
+	var indexInfos []*statedb.IndexInfo
+	for _, index := range indexes {
+		indexInfos = append(indexInfos, &statedb.IndexInfo{
+			DesignDoc:  index.DesignDocument,
+			Name:       index.Name,
+			Definition: index.Definition,
+		})
+	}
+	return indexInfos, nil
+}
+
+// DropIndex implements method in IndexManageable interface
+func (vdb *VersionedDB) DropIndex(namespace, designDoc, indexName string) error {
+	db, err := vdb.getNamespaceDBHandle(namespace)
+	if err != nil {
+		return err
+	}
+	if err := db.DeleteIndex(strings.TrimPrefix(designDoc, "_design/"), indexName); err != nil {
+		return errors.WithMessage(err, fmt.Sprintf(
+			"error dropping index [%s] of design document [%s] for namespace [%s]", indexName, designDoc, namespace))
+	}
+	logger.Infof("Dropped index [%s] of design document [%s] for namespace [%s]", indexName, designDoc, namespace)
+	return nil
+}
+
+// RebuildIndex implements method in IndexManageable interface. CouchDB leaves an index unchanged when it is
+// created again with the same definition, hence an existing index with the same design document and name is
+// dropped first. Once created, the index is warmed so that the subsequent queries do not wait for it to be built
+func (vdb *VersionedDB) RebuildIndex(namespace string, indexDefinition []byte) (*statedb.IndexInfo, error) {
+	def := &struct {
+		DesignDoc string `json:"ddoc"`
+		Name      string `json:"name"`
+	}{}
+	if err := json.Unmarshal(indexDefinition, def); err != nil {
+		return nil, errors.Wrap(err, "invalid index definition, the definition must be a JSON object")
+	}
+	db, err := vdb.getNamespaceDBHandle(namespace)
+	if err != nil {
+		return nil, err
+	}
+	existing, err := vdb.ListIndexes(namespace)
+	if err != nil {
+		return nil, err
+	}
+	designDoc := strings.TrimPrefix(def.DesignDoc, "_design/")
+	for _, index := range existing {
+		if designDoc != "" && index.DesignDoc == designDoc && index.Name == def.Name {
+			if err := vdb.DropIndex(namespace, index.DesignDoc, index.Name); err != nil {
+				return nil, err
+			}
+		}
+	}
+	resp, err := db.CreateIndex(string(indexDefinition))
+	if err != nil {
+		return nil, errors.WithMessage(err, fmt.Sprintf("error creating index for namespace [%s]", namespace))
+	}
+	designDoc = strings.TrimPrefix(resp.ID, "_design/")
+	if err := db.WarmIndex(designDoc, resp.Name); err != nil {
+		return nil, errors.WithMessage(err, fmt.Sprintf(
+			"error warming index [%s] of design document [%s] for namespace [%s]", resp.Name, designDoc, namespace))
+	}
+	indexes, err := vdb.ListIndexes(namespace)
+	if err != nil {
+		return nil, err
+	}
+	for _, index := range indexes {
+		if index.DesignDoc == designDoc && index.Name == resp.Name {
+			return index, nil
+		}
+	}
+	return &statedb.IndexInfo{DesignDoc: designDoc, Name: resp.Name}, nil
+}
+
+// LoadCommittedVersions retains the committed versions and revisions of the given keys in the cache until
+// the function 'ClearCachedVersions' is invoked. The keys that are not present in the cache are loaded with
+// a bulk retrieve from couchdb. The committed versions are used for the state validation of the readsets and
+// the revisions are used during the commit phase for the couchdb bulk updates
 func (vdb *VersionedDB) LoadCommittedVersions(keys []*statedb.CompositeKey) error {
+	retained := make(statedb.CacheUpdates)
 	nsKeysMap := map[string][]string{}
-	committedDataCache := newVersionCache()
-	for _, compositeKey := range keys {
+	var updateSequence uint64
+	for i, compositeKey := range keys {
 		ns, key := compositeKey.Namespace, compositeKey.Key
-		committedDataCache.setVerAndRev(ns, key, nil, "")
+		cv, seq := vdb.cache.GetState(vdb.chainName, ns, key)
+		if i == 0 {
+			updateSequence = seq
+		}
+		if cv != nil {
+			retained.Add(ns, key, cv)
+			continue
+		}
+		// the key is retained as absent unless the bulk retrieve returns it
+		retained.Add(ns, key, &statedb.CacheValue{})
 		logger.Debugf("Load into version cache: %s~%s", ns, key)
 		nsKeysMap[ns] = append(nsKeysMap[ns], key)
 	}
@@ -228,13 +349,15 @@ func (vdb *VersionedDB) LoadCommittedVersions(keys []*statedb.CompositeKey) erro
 				if err != nil {
 					return err
 				}
-				committedDataCache.setVerAndRev(ns, keyMetadata.ID, version, keyMetadata.Rev)
+				retained.Add(ns, keyMetadata.ID, &statedb.CacheValue{
+					VersionedValue: &statedb.VersionedValue{Version: version},
+					VersionOnly:    true,
+					AdditionalInfo: []byte(keyMetadata.Rev),
+				})
 			}
 		}
 	}
-	vdb.verCacheLock.Lock()
-	defer vdb.verCacheLock.Unlock()
-	vdb.committedDataCache = committedDataCache
+	vdb.cache.RetainStates(updateSequence, vdb.chainName, retained)
 	return nil
 }
 
@@ -242,8 +365,6 @@ func (vdb *VersionedDB) LoadCommittedVersions(keys []*statedb.CompositeKey) erro
 func (vdb *VersionedDB) GetVersion(namespace string, key string) (*version.Height, error) {
 	returnVersion, keyFound := vdb.GetCachedVersion(namespace, key)
 	if !keyFound {
-		// This if block get executed only during simulation because during commit
-		// we always call `LoadCommittedVersions` before calling `GetVersion`
 		vv, err := vdb.GetState(namespace, key)
 		if err != nil || vv == nil {
 			return nil, err
@@ -256,9 +377,14 @@ func (vdb *VersionedDB) GetVersion(namespace string, key string) (*version.Heigh
 // GetCachedVersion returns version from cache. `LoadCommittedVersions` function populates the cache
 func (vdb *VersionedDB) GetCachedVersion(namespace string, key string) (*version.Height, bool) {
 	logger.Debugf("Retrieving cached version: %s~%s", key, namespace)
-	vdb.verCacheLock.RLock()
-	defer vdb.verCacheLock.RUnlock()
-	return vdb.committedDataCache.getVersion(namespace, key)
+	cv, _ := vdb.cache.GetState(vdb.chainName, namespace, key)
+	if cv == nil {
+		return nil, false
+	}
+	if cv.VersionedValue == nil {
+		return nil, true
+	}
+	return cv.VersionedValue.Version, true
 }
 
 // ValidateKeyValue implements method in VersionedDB interface
@@ -284,26 +410,44 @@ func (vdb *VersionedDB) GetState(namespace string, key string) (*statedb.Version
 		}
 	}
 
+	vv, err := vdb.readState(namespace, key)
+	if err != nil || vv == nil {
+		return nil, err
+	}
+
+	if namespace == "lscc" {
+		vdb.lsccStateCache.setState(key, vv)
+	}
+
+	return vv, nil
+}
+
+// readState serves the state of the key from the cache or reads it from couchdb and adds it to the cache,
+// along with the revision of the document
+func (vdb *VersionedDB) readState(namespace string, key string) (*statedb.VersionedValue, error) {
+	cv, updateSequence := vdb.cache.GetState(vdb.chainName, namespace, key)
+	if cv != nil && !cv.VersionOnly {
+		return cv.VersionedValue, nil
+	}
+
 	db, err := vdb.getNamespaceDBHandle(namespace)
 	if err != nil {
 		return nil, err
 	}
-	couchDoc, _, err := db.ReadDoc(key)
+	couchDoc, rev, err := db.ReadDoc(key)
 	if err != nil {
 		return nil, err
 	}
 	if couchDoc == nil {
+		vdb.cache.PutState(updateSequence, vdb.chainName, namespace, key, &statedb.CacheValue{})
 		return nil, nil
 	}
 	kv, err := couchDocToKeyValue(couchDoc)
 	if err != nil {
 		return nil, err
 	}
-
-	if namespace == "lscc" {
-		vdb.lsccStateCache.setState(key, kv.VersionedValue)
-	}
-
+	vdb.cache.PutState(updateSequence, vdb.chainName, namespace, key,
+		&statedb.CacheValue{VersionedValue: kv.VersionedValue, AdditionalInfo: []byte(rev)})
 	return kv.VersionedValue, nil
 }
 
@@ -524,6 +668,8 @@ func (vdb *VersionedDB) ApplyUpdates(updates *statedb.UpdateBatch, height *versi
 	}
 	// stage 2 - ApplyUpdates push the changes to the DB
 	if err = executeBatches(updateBatches); err != nil {
+		// couchdb may have applied a part of the updates
+		vdb.cache.Clear(vdb.chainName)
 		return err
 	}
 
@@ -532,8 +678,10 @@ func (vdb *VersionedDB) ApplyUpdates(updates *statedb.UpdateBatch, height *versi
 	// Record a savepoint at a given height
 	if err = vdb.ensureFullCommitAndRecordSavepoint(height, namespaces); err != nil {
 		logger.Errorf("Error during recordSavepoint: %s", err.Error())
+		vdb.cache.Clear(vdb.chainName)
 		return err
 	}
+	vdb.cache.UpdateStates(vdb.chainName, committedStates(updateBatches))
 
 	lsccUpdates := updates.GetUpdates("lscc")
 	for key, value := range lsccUpdates {
@@ -543,12 +691,10 @@ func (vdb *VersionedDB) ApplyUpdates(updates *statedb.UpdateBatch, height *versi
 	return nil
 }
 
-// ClearCachedVersions clears committedVersions and revisionNumbers
+// ClearCachedVersions releases the versions and revisions retained by the function 'LoadCommittedVersions'
 func (vdb *VersionedDB) ClearCachedVersions() {
 	logger.Debugf("Clear Cache")
-	vdb.verCacheLock.Lock()
-	defer vdb.verCacheLock.Unlock()
-	vdb.committedDataCache = newVersionCache()
+	vdb.cache.ReleaseStates(vdb.chainName)
 }
 
 // Open implements method in VersionedDB interface
@@ -16,6 +16,7 @@ import (
 
 	"github.com/hyperledger/fabric/common/flogging"
 	"github.com/hyperledger/fabric/common/ledger/testutil"
+	"github.com/hyperledger/fabric/common/metrics/disabled"
 	"github.com/hyperledger/fabric/core/common/ccprovider"
 	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/statedb"
 	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/statedb/commontests"
@@ -402,6 +403,52 @@ func TestHandleChaincodeDeployErroneousIndexFile(t *testing.T) {
 	assert.NoError(t, err)
 }
 
+func TestIndexManagement(t *testing.T) {
+	env := NewTestVDBEnv(t)
+	defer env.Cleanup()
+	db, err := env.DBProvider.GetDBHandle("testindexmanagement")
+	assert.NoError(t, err)
+
+	batch := statedb.NewUpdateBatch()
+	batch.Put("ns1", "key1", []byte(`{"asset_name": "marble1","color": "blue","size": 1,"owner": "tom"}`), version.NewHeight(1, 1))
+	assert.NoError(t, db.ApplyUpdates(batch, version.NewHeight(1, 1)))
+
+	indexManageable, ok := db.(statedb.IndexManageable)
+	if !ok {
+		t.Fatalf("Couchdb state impl is expected to implement interface `statedb.IndexManageable`")
+	}
+
+	indexes, err := indexManageable.ListIndexes("ns1")
+	assert.NoError(t, err)
+	assert.Empty(t, indexes)
+
+	index, err := indexManageable.RebuildIndex("ns1", []byte(`{"index":{"fields":["owner"]},"ddoc":"indexOwnerDoc","name":"indexOwner","type":"json"}`))
+	assert.NoError(t, err)
+	assert.Equal(t, "indexOwnerDoc", index.DesignDoc)
+	assert.Equal(t, "indexOwner", index.Name)
+	assert.Contains(t, index.Definition, "owner")
+
+	// rebuilding the index with an updated definition replaces it
+	index, err = indexManageable.RebuildIndex("ns1", []byte(`{"index":{"fields":["color"]},"ddoc":"indexOwnerDoc","name":"indexOwner","type":"json"}`))
+	assert.NoError(t, err)
+	assert.Contains(t, index.Definition, "color")
+	indexes, err = indexManageable.ListIndexes("ns1")
+	assert.NoError(t, err)
+	assert.Len(t, indexes, 1)
+	assert.Equal(t, index, indexes[0])
+
+	_, err = indexManageable.RebuildIndex("ns1", []byte(`not a json`))
+	assert.Contains(t, err.Error(), "invalid index definition")
+
+	assert.NoError(t, indexManageable.DropIndex("ns1", "indexOwnerDoc", "indexOwner"))
+	indexes, err = indexManageable.ListIndexes("ns1")
+	assert.NoError(t, err)
+	assert.Empty(t, indexes)
+
+	err = indexManageable.DropIndex("ns1", "indexOwnerDoc", "indexOwner")
+	assert.Contains(t, err.Error(), "error dropping index [indexOwner] of design document [indexOwnerDoc] for namespace [ns1]")
+}
+
 func TestIsBulkOptimizable(t *testing.T) {
 	var db statedb.VersionedDB = &VersionedDB{}
 	_, ok := db.(statedb.BulkOptimizable)
@@ -837,3 +884,89 @@ func assertQueryResults(t *testing.T, results []*couchdb.QueryResult, expectedId
 	}
 	assert.Equal(t, expectedIds, actualIds)
 }
+
+func TestCacheWriteThrough(t *testing.T) {
+	env := NewTestVDBEnv(t)
+	defer env.Cleanup()
+	db, err := env.DBProvider.GetDBHandle("testcachewritethrough")
+	assert.NoError(t, err)
+	vdb := db.(*VersionedDB)
+	vdb.cache = statedb.NewCache(10, &disabled.Provider{})
+
+	batch := statedb.NewUpdateBatch()
+	batch.Put("ns1", "key1", []byte(`{"asset_name": "marble1", "size": 1.0}`), version.NewHeight(1, 1))
+	batch.Put("ns1", "key2", []byte("binary value"), version.NewHeight(1, 2))
+	assert.NoError(t, db.ApplyUpdates(batch, version.NewHeight(1, 2)))
+
+	// the committed values are cached in the form in which couchdb returns them, along with the revisions
+	cv1, _ := vdb.cache.GetState("testcachewritethrough", "ns1", "key1")
+	assert.NotNil(t, cv1)
+	assert.NotEmpty(t, cv1.AdditionalInfo)
+	cv2, _ := vdb.cache.GetState("testcachewritethrough", "ns1", "key2")
+	assert.NotNil(t, cv2)
+	vdb.cache.Clear("testcachewritethrough")
+	vv1, err := db.GetState("ns1", "key1")
+	assert.NoError(t, err)
+	assert.Equal(t, vv1, cv1.VersionedValue)
+	vv2, err := db.GetState("ns1", "key2")
+	assert.NoError(t, err)
+	assert.Equal(t, vv2, cv2.VersionedValue)
+	cv, _ := vdb.cache.GetState("testcachewritethrough", "ns1", "key1")
+	assert.Equal(t, cv1, cv)
+
+	// the versions loaded for the validation of a block are retained until released
+	vdb.cache.Clear("testcachewritethrough")
+	assert.NoError(t, vdb.LoadCommittedVersions([]*statedb.CompositeKey{
+		{Namespace: "ns1", Key: "key1"},
+		{Namespace: "ns1", Key: "key3"},
+	}))
+	ver, found := vdb.GetCachedVersion("ns1", "key1")
+	assert.True(t, found)
+	assert.Equal(t, version.NewHeight(1, 1), ver)
+	ver, found = vdb.GetCachedVersion("ns1", "key3")
+	assert.True(t, found)
+	assert.Nil(t, ver)
+
+	// the commit uses the retained revisions and updates the retained versions
+	batch = statedb.NewUpdateBatch()
+	batch.Put("ns1", "key1", []byte(`{"asset_name": "marble1", "size": 2}`), version.NewHeight(2, 1))
+	batch.Delete("ns1", "key2", version.NewHeight(2, 2))
+	assert.NoError(t, db.ApplyUpdates(batch, version.NewHeight(2, 2)))
+	ver, found = vdb.GetCachedVersion("ns1", "key1")
+	assert.True(t, found)
+	assert.Equal(t, version.NewHeight(2, 1), ver)
+	cv, _ = vdb.cache.GetState("testcachewritethrough", "ns1", "key2")
+	assert.Equal(t, &statedb.CacheValue{}, cv)
+
+	vdb.ClearCachedVersions()
+	vdb.cache.Clear("testcachewritethrough")
+	vv1, err = db.GetState("ns1", "key1")
+	assert.NoError(t, err)
+	assert.Equal(t, version.NewHeight(2, 1), vv1.Version)
+	vv2, err = db.GetState("ns1", "key2")
+	assert.NoError(t, err)
+	assert.Nil(t, vv2)
+}
+
+func TestCommittedStatesEvictsUnconvertibleDocuments(t *testing.T) {
+	cache := statedb.NewCache(10, &disabled.Provider{})
+	_, seq := cache.GetState("testcommittedstates", "ns1", "key1")
+	cache.PutState(seq, "testcommittedstates", "ns1", "key1", &statedb.CacheValue{
+		VersionedValue: &statedb.VersionedValue{Value: []byte("value1"), Version: version.NewHeight(1, 1)},
+		AdditionalInfo: []byte("1-rev"),
+	})
+
+	// the committed document has no version field and cannot be converted back to a versioned value
+	committer := &subNsCommitter{
+		ns: "ns1",
+		batchUpdateMap: map[string]*batchableDocument{
+			"key1": {CouchDoc: couchdb.CouchDoc{JSONValue: []byte(`{"_id":"key1","asset_name":"marble1"}`)}},
+		},
+		revisions: map[string]string{"key1": "2-rev"},
+	}
+	cache.UpdateStates("testcommittedstates", committedStates([]batch{committer}))
+
+	// the previous state of the key is not served from the cache anymore
+	cv, _ := cache.GetState("testcommittedstates", "ns1", "key1")
+	assert.Nil(t, cv)
+}
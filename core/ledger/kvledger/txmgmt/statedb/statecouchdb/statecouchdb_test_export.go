@@ -10,6 +10,7 @@ import (
 
 	"github.com/hyperledger/fabric/common/metrics/disabled"
 	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/statedb"
+	"github.com/hyperledger/fabric/core/ledger/ledgerconfig"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -23,7 +24,7 @@ type TestVDBEnv struct {
 func NewTestVDBEnv(t testing.TB) *TestVDBEnv {
 	t.Logf("Creating new TestVDBEnv")
 
-	dbProvider, _ := NewVersionedDBProvider(&disabled.Provider{})
+	dbProvider, _ := NewVersionedDBProvider(&disabled.Provider{}, statedb.NewCache(ledgerconfig.GetStateCacheMaxKeysPerNamespace(), &disabled.Provider{}))
 	testVDBEnv := &TestVDBEnv{t, dbProvider}
 	// No cleanup for new test environment.  Need to cleanup per test for each DB used in the test.
 	return testVDBEnv
@@ -88,6 +88,35 @@ type IndexCapable interface {
 	ProcessIndexesForChaincodeDeploy(namespace string, fileEntries []*ccprovider.TarFileEntry) error
 }
 
+// IndexManageable interface provides additional functions for databases
+// whose indexes can be managed outside of the deployment of the chaincodes
+type IndexManageable interface {
+	// ListIndexes returns the indexes of the namespace
+	ListIndexes(namespace string) ([]*IndexInfo, error)
+	// DropIndex removes the index of the design document from the namespace
+	DropIndex(namespace, designDoc, indexName string) error
+	// RebuildIndex creates the index from the given definition. An index with the same design
+	// document and name is replaced, so that the index is built again over the data of the namespace
+	RebuildIndex(namespace string, indexDefinition []byte) (*IndexInfo, error)
+}
+
+// IndexInfo describes an index of a namespace
+type IndexInfo struct {
+	DesignDoc  string
+	Name       string
+	Definition string
+}
+
+// FullScanIterable interface provides an additional function for databases
+// capable of iterating over all the keys across all the namespaces.
+// This is used for exporting the state to a snapshot
+type FullScanIterable interface {
+	// GetFullScanIterator returns an iterator over all the keys of all the namespaces, except the
+	// namespaces for which the function skipNamespace returns true. The returned ResultsIterator
+	// contains results of type *VersionedKV, sorted by the namespace and then by the key
+	GetFullScanIterator(skipNamespace func(namespace string) bool) (ResultsIterator, error)
+}
+
 // CompositeKey encloses Namespace and Key components
 type CompositeKey struct {
 	Namespace string
@@ -303,3 +332,31 @@ func ValidateRangeMetadata(metadata map[string]interface{}) error {
 	}
 	return nil
 }
+
+const optionBookmark = "bookmark"
+
+// ValidateQueryMetadata validates the JSON containing attributes for the rich query
+func ValidateQueryMetadata(metadata map[string]interface{}) error {
+	for key, keyVal := range metadata {
+		switch key {
+
+		case optionBookmark:
+			//Verify the bookmark is a string
+			if _, ok := keyVal.(string); ok {
+				continue
+			}
+			return fmt.Errorf("Invalid entry, \"bookmark\" must be a string")
+
+		case optionLimit:
+			//Verify the limit is an integer
+			if _, ok := keyVal.(int32); ok {
+				continue
+			}
+			return fmt.Errorf("Invalid entry, \"limit\" must be an int32")
+
+		default:
+			return fmt.Errorf("Invalid entry, option %s not recognized", key)
+		}
+	}
+	return nil
+}
@@ -163,3 +163,19 @@ func TestPaginatedRangeValidation(t *testing.T) {
 	assert.Error(t, err, "An should have been thrown for an invalid option")
 
 }
+
+// TestPaginatedQueryValidation tests the options of the rich queries with pagination
+func TestPaginatedQueryValidation(t *testing.T) {
+
+	queryOptions := map[string]interface{}{"limit": int32(10), "bookmark": "bookmark1"}
+	assert.NoError(t, ValidateQueryMetadata(queryOptions))
+
+	queryOptions = map[string]interface{}{"limit": "10"}
+	assert.EqualError(t, ValidateQueryMetadata(queryOptions), "Invalid entry, \"limit\" must be an int32")
+
+	queryOptions = map[string]interface{}{"bookmark": int32(10)}
+	assert.EqualError(t, ValidateQueryMetadata(queryOptions), "Invalid entry, \"bookmark\" must be a string")
+
+	queryOptions = map[string]interface{}{"limit1": int32(10)}
+	assert.EqualError(t, ValidateQueryMetadata(queryOptions), "Invalid entry, option limit1 not recognized")
+}
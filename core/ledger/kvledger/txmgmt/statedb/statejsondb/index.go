@@ -0,0 +1,590 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package statejsondb
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/hyperledger/fabric/common/ledger/util/leveldbhelper"
+	"github.com/hyperledger/fabric/core/common/ccprovider"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/statedb"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/statedb/mangoquery"
+	"github.com/pkg/errors"
+)
+
+// maxIndexBuildBatchSize is the number of index entries written in a single batch while building
+// an index over the existing data of a namespace
+var maxIndexBuildBatchSize = 1000
+
+// The type tags used in the encoding of the values of the indexed fields. The order of the tags follows the
+// CouchDB collation across the JSON types so that the index entries are sorted by the values of the first
+// indexed field (within a type, the order is preserved for the booleans, the numbers and the strings)
+const (
+	tagNull byte = iota + 1
+	tagFalse
+	tagTrue
+	tagNumber
+	tagString
+	tagArray
+	tagObject
+)
+
+// indexDefinition is the normalized form of a CouchDB index definition, e.g.,
+// {"index":{"fields":["docType","owner"]},"ddoc":"indexOwnerDoc","name":"indexOwner","type":"json"}
+type indexDefinition struct {
+	DesignDoc string   `json:"ddoc"`
+	Name      string   `json:"name"`
+	Fields    []string `json:"fields"`
+}
+
+// couchDBIndexDefinition is the format of the index definitions packaged
+// with a chaincode in the directory META-INF/statedb/couchdb/indexes
+type couchDBIndexDefinition struct {
+	Index *struct {
+		Fields []interface{} `json:"fields"`
+	} `json:"index"`
+	DesignDoc string `json:"ddoc"`
+	Name      string `json:"name"`
+	Type      string `json:"type"`
+}
+
+// parseIndexDefinition parses a CouchDB index definition. As in CouchDB, the direction of an indexed field is
+// accepted but it does not play any role, as the results of a query are sorted independently of the index.
+// If not specified, the name of the index is derived from the fields and the design document defaults to the name
+func parseIndexDefinition(indexData []byte) (*indexDefinition, error) {
+	couchDBDef := &couchDBIndexDefinition{}
+	if err := json.Unmarshal(indexData, couchDBDef); err != nil {
+		return nil, errors.Wrap(err, "invalid index definition, the definition must be a JSON object")
+	}
+	if couchDBDef.Index == nil || len(couchDBDef.Index.Fields) == 0 {
+		return nil, errors.New("invalid index definition, the definition must contain a non-empty \"index.fields\"")
+	}
+	if couchDBDef.Type != "" && couchDBDef.Type != "json" {
+		return nil, errors.Errorf("invalid index definition, unsupported index type [%s]", couchDBDef.Type)
+	}
+	def := &indexDefinition{
+		DesignDoc: trimDesignDocPrefix(couchDBDef.DesignDoc),
+		Name:      couchDBDef.Name,
+	}
+	for _, field := range couchDBDef.Index.Fields {
+		fieldName, ok := indexFieldName(field)
+		if !ok {
+			return nil, errors.New("invalid index definition, the fields must be either field names or " +
+				"objects in the form {\"<field name>\": \"asc|desc\"}")
+		}
+		def.Fields = append(def.Fields, fieldName)
+	}
+	if def.Name == "" {
+		hash := sha256.Sum256([]byte(strings.Join(def.Fields, "\x00")))
+		def.Name = hex.EncodeToString(hash[:16])
+	}
+	if def.DesignDoc == "" {
+		def.DesignDoc = def.Name
+	}
+	if strings.ContainsRune(def.Name, 0) || strings.ContainsRune(def.DesignDoc, 0) {
+		return nil, errors.New("invalid index definition, the name and the design document cannot contain a null character")
+	}
+	return def, nil
+}
+
+func indexFieldName(field interface{}) (string, bool) {
+	if fieldName, ok := field.(string); ok {
+		return fieldName, fieldName != ""
+	}
+	obj, ok := field.(map[string]interface{})
+	if !ok || len(obj) != 1 {
+		return "", false
+	}
+	for fieldName, direction := range obj {
+		if direction == "asc" || direction == "desc" {
+			return fieldName, fieldName != ""
+		}
+	}
+	return "", false
+}
+
+// trimDesignDocPrefix allows a design document to be referred as in CouchDB, with or without the prefix "_design/"
+func trimDesignDocPrefix(designDoc string) string {
+	return strings.TrimPrefix(designDoc, "_design/")
+}
+
+func (def *indexDefinition) sameAs(other *indexDefinition) bool {
+	if def.DesignDoc != other.DesignDoc || def.Name != other.Name || len(def.Fields) != len(other.Fields) {
+		return false
+	}
+	for i := range def.Fields {
+		if def.Fields[i] != other.Fields[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// defKey returns the key under which the index definition is persisted, i.e.,
+// <indexDefKeyPrefix><ns><sep><ddoc><sep><name>
+func (def *indexDefinition) defKey(ns string) []byte {
+	key := append(append([]byte{}, indexDefKeyPrefix...), []byte(ns)...)
+	key = append(append(key, compositeKeySep...), []byte(def.DesignDoc)...)
+	return append(append(key, compositeKeySep...), []byte(def.Name)...)
+}
+
+// entryKeyPrefix returns the prefix of the keys of the index entries, i.e.,
+// <indexKeyPrefix><ns><sep><ddoc><sep><name><sep>
+func (def *indexDefinition) entryKeyPrefix(ns string) []byte {
+	key := append(append([]byte{}, indexKeyPrefix...), []byte(ns)...)
+	key = append(append(key, compositeKeySep...), []byte(def.DesignDoc)...)
+	key = append(append(key, compositeKeySep...), []byte(def.Name)...)
+	return append(key, compositeKeySep...)
+}
+
+// entryKey returns the key of the index entry for the given value. The key of the entry is the prefix followed
+// by the encoded values of the indexed fields and the key of the state. The second return value is false if the
+// value is not a JSON object or lacks any of the indexed fields, in which case the value is not indexed, as in CouchDB
+func (def *indexDefinition) entryKey(ns, key string, doc map[string]interface{}) ([]byte, bool) {
+	entryKey := def.entryKeyPrefix(ns)
+	for _, field := range def.Fields {
+		v, found := lookupField(doc, field)
+		if !found {
+			return nil, false
+		}
+		entryKey = appendEncodedValue(entryKey, v)
+	}
+	return append(entryKey, []byte(key)...), true
+}
+
+// scanRange returns the start key (inclusive) and the end key (exclusive) of the index entries
+// whose first indexed field falls within the given range
+func (def *indexDefinition) scanRange(ns string, r *mangoquery.Range) ([]byte, []byte) {
+	prefix := def.entryKeyPrefix(ns)
+	startKey, endKey := prefix, successor(prefix)
+	if r.HasStart {
+		startKey = appendEncodedValue(append([]byte{}, prefix...), r.Start)
+		if !r.StartInclusive {
+			startKey = successor(startKey)
+		}
+	}
+	if r.HasEnd {
+		endKey = appendEncodedValue(append([]byte{}, prefix...), r.End)
+		if r.EndInclusive {
+			endKey = successor(endKey)
+		}
+	}
+	return startKey, endKey
+}
+
+// selectIndex selects the index for a query. An index is used if the query places a bound on the first field of the
+// index. If specified, the index in the "use_index" of the query is preferred, followed by the first index (by the
+// name of the design document and the index) that is bound to a single value and then by any other index
+func selectIndex(q *mangoquery.Query, indexes []*indexDefinition) (*indexDefinition, *mangoquery.Range) {
+	var selected *indexDefinition
+	var selectedRange *mangoquery.Range
+	for _, index := range indexes {
+		r, ok := q.FieldRange(index.Fields[0])
+		if !ok {
+			continue
+		}
+		if index.isNamedBy(q.UseIndex) {
+			return index, r
+		}
+		if selected == nil || (r.IsEquality() && !selectedRange.IsEquality()) {
+			selected, selectedRange = index, r
+		}
+	}
+	if len(q.UseIndex) > 0 {
+		logger.Warningf("The index %s specified in the query is either not present or not applicable to the query", q.UseIndex)
+	}
+	return selected, selectedRange
+}
+
+func (def *indexDefinition) isNamedBy(useIndex []string) bool {
+	if len(useIndex) == 0 || trimDesignDocPrefix(useIndex[0]) != def.DesignDoc {
+		return false
+	}
+	return len(useIndex) == 1 || useIndex[1] == def.Name
+}
+
+// GetDBType implements method in IndexCapable interface. The embedded JSON database consumes the
+// CouchDB index definitions so that the chaincodes packaged for CouchDB work unchanged
+func (vdb *versionedDB) GetDBType() string {
+	return "couchdb"
+}
+
+// ProcessIndexesForChaincodeDeploy implements method in IndexCapable interface. An index is built over the existing
+// data of the namespace when it is created. Processing an index that is already present with the same definition
+// is a no-op and an index whose fields are changed (by a chaincode upgrade) is rebuilt
+func (vdb *versionedDB) ProcessIndexesForChaincodeDeploy(namespace string, fileEntries []*ccprovider.TarFileEntry) error {
+	vdb.indexesLock.Lock()
+	defer vdb.indexesLock.Unlock()
+	for _, fileEntry := range fileEntries {
+		filename := fileEntry.FileHeader.Name
+		def, err := parseIndexDefinition(fileEntry.FileContent)
+		if err == nil {
+			err = vdb.createIndex(namespace, def)
+		}
+		if err != nil {
+			return errors.WithMessage(err, fmt.Sprintf(
+				"error creating index from file [%s] for channel [%s]", filename, namespace))
+		}
+	}
+	return nil
+}
+
+// ListIndexes implements method in IndexManageable interface
+func (vdb *versionedDB) ListIndexes(namespace string) ([]*statedb.IndexInfo, error) {
+	vdb.indexesLock.RLock()
+	defer vdb.indexesLock.RUnlock()
+	var indexInfos []*statedb.IndexInfo
+	for _, def := range vdb.indexes[namespace] {
+		indexInfos = append(indexInfos, def.info())
+	}
+	return indexInfos, nil
+}
+
+// DropIndex implements method in IndexManageable interface
+func (vdb *versionedDB) DropIndex(namespace, designDoc, indexName string) error {
+	vdb.indexesLock.Lock()
+	defer vdb.indexesLock.Unlock()
+	designDoc = trimDesignDocPrefix(designDoc)
+	for _, def := range vdb.indexes[namespace] {
+		if def.DesignDoc == designDoc && def.Name == indexName {
+			return vdb.dropIndex(namespace, def)
+		}
+	}
+	return errors.Errorf("index [%s] of design document [%s] not found for namespace [%s]", indexName, designDoc, namespace)
+}
+
+// RebuildIndex implements method in IndexManageable interface
+func (vdb *versionedDB) RebuildIndex(namespace string, indexDefinition []byte) (*statedb.IndexInfo, error) {
+	def, err := parseIndexDefinition(indexDefinition)
+	if err != nil {
+		return nil, err
+	}
+	vdb.indexesLock.Lock()
+	defer vdb.indexesLock.Unlock()
+	for _, index := range vdb.indexes[namespace] {
+		if index.DesignDoc == def.DesignDoc && index.Name == def.Name {
+			if err := vdb.dropIndex(namespace, index); err != nil {
+				return nil, err
+			}
+			break
+		}
+	}
+	if err := vdb.createIndex(namespace, def); err != nil {
+		return nil, err
+	}
+	return def.info(), nil
+}
+
+// info returns the description of the index, where the definition is rendered as in CouchDB
+func (def *indexDefinition) info() *statedb.IndexInfo {
+	fields := make([]string, 0, len(def.Fields))
+	for _, field := range def.Fields {
+		fieldJSON, _ := json.Marshal(field)
+		fields = append(fields, fmt.Sprintf(`{%s:"asc"}`, fieldJSON))
+	}
+	return &statedb.IndexInfo{
+		DesignDoc:  def.DesignDoc,
+		Name:       def.Name,
+		Definition: fmt.Sprintf(`{"fields":[%s]}`, strings.Join(fields, ",")),
+	}
+}
+
+func (vdb *versionedDB) dropIndex(ns string, def *indexDefinition) error {
+	if err := vdb.deleteAllIndexEntries(ns, def); err != nil {
+		return err
+	}
+	if err := vdb.db.Delete(def.defKey(ns), true); err != nil {
+		return err
+	}
+	var remaining []*indexDefinition
+	for _, index := range vdb.indexes[ns] {
+		if index != def {
+			remaining = append(remaining, index)
+		}
+	}
+	vdb.indexes[ns] = remaining
+	logger.Infof("Dropped the index [%s] of the design document [%s] for the namespace [%s]", def.Name, def.DesignDoc, ns)
+	return nil
+}
+
+func (vdb *versionedDB) createIndex(ns string, def *indexDefinition) error {
+	var existing []*indexDefinition
+	for _, index := range vdb.indexes[ns] {
+		if index.DesignDoc == def.DesignDoc && index.Name == def.Name {
+			if index.sameAs(def) {
+				logger.Debugf("Index [%s] of the design document [%s] is already present for the namespace [%s]", def.Name, def.DesignDoc, ns)
+				return nil
+			}
+			logger.Infof("Rebuilding the index [%s] of the design document [%s] for the namespace [%s] as the indexed fields have changed",
+				def.Name, def.DesignDoc, ns)
+			if err := vdb.deleteAllIndexEntries(ns, index); err != nil {
+				return err
+			}
+			continue
+		}
+		existing = append(existing, index)
+	}
+	logger.Infof("Building the index [%s] of the design document [%s] on the fields %s for the namespace [%s]",
+		def.Name, def.DesignDoc, def.Fields, ns)
+	if err := vdb.buildIndex(ns, def); err != nil {
+		return err
+	}
+	defBytes, err := json.Marshal(def)
+	if err != nil {
+		return errors.Wrap(err, "error while marshaling the index definition")
+	}
+	if err := vdb.db.Put(def.defKey(ns), defBytes, true); err != nil {
+		return err
+	}
+	indexes := append(existing, def)
+	sortIndexDefinitions(indexes)
+	vdb.indexes[ns] = indexes
+	return nil
+}
+
+// buildIndex adds the index entries for the existing data of the namespace
+func (vdb *versionedDB) buildIndex(ns string, def *indexDefinition) error {
+	dbItr := vdb.namespaceIterator(ns, "", "")
+	defer dbItr.Release()
+	dbBatch := leveldbhelper.NewUpdateBatch()
+	for dbItr.Next() {
+		kv, err := decodeKV(dbItr.Key(), dbItr.Value())
+		if err != nil {
+			return err
+		}
+		addIndexEntries(dbBatch, ns, kv.Key, kv.Value, []*indexDefinition{def})
+		if dbBatch.Len() >= maxIndexBuildBatchSize {
+			if err := vdb.db.WriteBatch(dbBatch, true); err != nil {
+				return err
+			}
+			dbBatch = leveldbhelper.NewUpdateBatch()
+		}
+	}
+	if err := dbItr.Error(); err != nil {
+		return errors.Wrapf(err, "error while building the index for the namespace [%s]", ns)
+	}
+	return vdb.db.WriteBatch(dbBatch, true)
+}
+
+func (vdb *versionedDB) deleteAllIndexEntries(ns string, def *indexDefinition) error {
+	prefix := def.entryKeyPrefix(ns)
+	dbItr := vdb.db.GetIterator(prefix, successor(prefix))
+	defer dbItr.Release()
+	dbBatch := leveldbhelper.NewUpdateBatch()
+	for dbItr.Next() {
+		dbBatch.Delete(append([]byte{}, dbItr.Key()...))
+		if dbBatch.Len() >= maxIndexBuildBatchSize {
+			if err := vdb.db.WriteBatch(dbBatch, true); err != nil {
+				return err
+			}
+			dbBatch = leveldbhelper.NewUpdateBatch()
+		}
+	}
+	if err := dbItr.Error(); err != nil {
+		return errors.Wrapf(err, "error while deleting the index entries for the namespace [%s]", ns)
+	}
+	return vdb.db.WriteBatch(dbBatch, true)
+}
+
+// deleteIndexEntries adds to the batch the deletion of the index entries for the committed value of the key
+func (vdb *versionedDB) deleteIndexEntries(dbBatch *leveldbhelper.UpdateBatch, ns, key string, indexes []*indexDefinition) error {
+	vv, err := vdb.GetState(ns, key)
+	if err != nil || vv == nil {
+		return err
+	}
+	doc, ok := toDocument(vv.Value)
+	if !ok {
+		return nil
+	}
+	for _, index := range indexes {
+		if entryKey, ok := index.entryKey(ns, key, doc); ok {
+			dbBatch.Delete(entryKey)
+		}
+	}
+	return nil
+}
+
+// addIndexEntries adds to the batch the index entries for the given value of the key
+func addIndexEntries(dbBatch *leveldbhelper.UpdateBatch, ns, key string, value []byte, indexes []*indexDefinition) {
+	if len(indexes) == 0 {
+		return
+	}
+	doc, ok := toDocument(value)
+	if !ok {
+		return
+	}
+	for _, index := range indexes {
+		if entryKey, ok := index.entryKey(ns, key, doc); ok {
+			dbBatch.Put(entryKey, []byte(key))
+		}
+	}
+}
+
+func loadIndexDefinitions(db *leveldbhelper.DBHandle) (map[string][]*indexDefinition, error) {
+	indexes := map[string][]*indexDefinition{}
+	dbItr := db.GetIterator(indexDefKeyPrefix, []byte{indexDefKeyPrefix[0] + 1})
+	defer dbItr.Release()
+	for dbItr.Next() {
+		ns := string(bytes.SplitN(dbItr.Key()[len(indexDefKeyPrefix):], compositeKeySep, 2)[0])
+		def := &indexDefinition{}
+		if err := json.Unmarshal(dbItr.Value(), def); err != nil {
+			return nil, errors.Wrapf(err, "error while unmarshaling an index definition for the namespace [%s]", ns)
+		}
+		indexes[ns] = append(indexes[ns], def)
+	}
+	if err := dbItr.Error(); err != nil {
+		return nil, errors.Wrap(err, "error while loading the index definitions")
+	}
+	for _, nsIndexes := range indexes {
+		sortIndexDefinitions(nsIndexes)
+	}
+	return indexes, nil
+}
+
+func sortIndexDefinitions(indexes []*indexDefinition) {
+	sort.Slice(indexes, func(i, j int) bool {
+		if indexes[i].DesignDoc != indexes[j].DesignDoc {
+			return indexes[i].DesignDoc < indexes[j].DesignDoc
+		}
+		return indexes[i].Name < indexes[j].Name
+	})
+}
+
+func toDocument(value []byte) (map[string]interface{}, bool) {
+	decoded, err := mangoquery.DecodeJSON(value)
+	if err != nil {
+		return nil, false
+	}
+	doc, ok := decoded.(map[string]interface{})
+	return doc, ok
+}
+
+// lookupField returns the value of a field that uses the dot notation for the nested fields
+func lookupField(doc map[string]interface{}, field string) (interface{}, bool) {
+	var v interface{} = doc
+	for _, name := range strings.Split(field, ".") {
+		obj, ok := v.(map[string]interface{})
+		if !ok {
+			return nil, false
+		}
+		if v, ok = obj[name]; !ok {
+			return nil, false
+		}
+	}
+	return v, true
+}
+
+// appendEncodedValue appends an order preserving and self delimiting encoding of the JSON value. The arrays and
+// the objects are encoded as their JSON text, which makes the encoding of a value unique but does not preserve
+// the CouchDB collation within these types. This is sufficient because an index is used only for the bounds on
+// the scalar values, while the selector is evaluated for each of the candidates retrieved from the index
+func appendEncodedValue(b []byte, v interface{}) []byte {
+	switch val := v.(type) {
+	case nil:
+		return append(b, tagNull)
+	case bool:
+		if val {
+			return append(b, tagTrue)
+		}
+		return append(b, tagFalse)
+	case json.Number:
+		return appendEncodedNumber(append(b, tagNumber), val)
+	case string:
+		return appendEscaped(append(b, tagString), []byte(val))
+	case []interface{}:
+		text, _ := json.Marshal(val)
+		return appendEscaped(append(b, tagArray), text)
+	default:
+		text, _ := json.Marshal(val)
+		return appendEscaped(append(b, tagObject), text)
+	}
+}
+
+// appendEncodedNumber encodes the number as the bits of the float64 in the big endian order, with the sign bit
+// flipped for the positive numbers and all the bits flipped for the negative numbers, so that the encoded bytes
+// sort in the numeric order
+func appendEncodedNumber(b []byte, n json.Number) []byte {
+	f, _ := strconv.ParseFloat(string(n), 64)
+	if f == 0 {
+		// encodes the negative zero as the positive zero
+		f = 0
+	}
+	bits := math.Float64bits(f)
+	if f < 0 {
+		bits = ^bits
+	} else {
+		bits ^= 1 << 63
+	}
+	for shift := uint(56); ; shift -= 8 {
+		b = append(b, byte(bits>>shift))
+		if shift == 0 {
+			return b
+		}
+	}
+}
+
+// appendEscaped appends the bytes with each null byte escaped as 0x00 0xFF and terminated by 0x00 0x01,
+// which preserves the order of the byte strings and makes the encoding self delimiting
+func appendEscaped(b []byte, s []byte) []byte {
+	for _, c := range s {
+		b = append(b, c)
+		if c == 0x00 {
+			b = append(b, 0xFF)
+		}
+	}
+	return append(b, 0x00, 0x01)
+}
+
+// successor returns the smallest key that is greater than all the keys that begin with the given prefix
+func successor(prefix []byte) []byte {
+	s := append([]byte{}, prefix...)
+	for i := len(s) - 1; i >= 0; i-- {
+		if s[i] < 0xFF {
+			s[i]++
+			return s[:i+1]
+		}
+	}
+	return nil
+}
+
+// indexScanner iterates over the index entries and returns the corresponding key-values
+type indexScanner struct {
+	vdb       *versionedDB
+	namespace string
+	dbItr     *leveldbhelper.Iterator
+}
+
+func (s *indexScanner) Next() (statedb.QueryResult, error) {
+	for s.dbItr.Next() {
+		key := string(s.dbItr.Value())
+		vv, err := s.vdb.GetState(s.namespace, key)
+		if err != nil {
+			return nil, err
+		}
+		if vv == nil {
+			// the key is deleted after the iterator was created
+			continue
+		}
+		return &statedb.VersionedKV{
+			CompositeKey:   statedb.CompositeKey{Namespace: s.namespace, Key: key},
+			VersionedValue: *vv,
+		}, nil
+	}
+	return nil, s.dbItr.Error()
+}
+
+func (s *indexScanner) Close() {
+	s.dbItr.Release()
+}
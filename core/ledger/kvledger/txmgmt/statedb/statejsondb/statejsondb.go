@@ -0,0 +1,432 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package statejsondb
+
+import (
+	"bytes"
+	"sync"
+	"unicode/utf8"
+
+	proto "github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/common/flogging"
+	"github.com/hyperledger/fabric/common/ledger/util/leveldbhelper"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/statedb"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/statedb/mangoquery"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/statedb/stateleveldb/msgs"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/version"
+	"github.com/hyperledger/fabric/core/ledger/ledgerconfig"
+	"github.com/pkg/errors"
+	"github.com/syndtr/goleveldb/leveldb/iterator"
+)
+
+var logger = flogging.MustGetLogger("statejsondb")
+
+// The keys in the underlying leveldb are prefixed by the type of the entry.
+// A data key is <dataKeyPrefix><ns><compositeKeySep><key>
+var (
+	dataKeyPrefix     = []byte{'d'}
+	indexKeyPrefix    = []byte{'i'}
+	indexDefKeyPrefix = []byte{'x'}
+	savePointKey      = []byte{'s'}
+	compositeKeySep   = []byte{0x00}
+	lastKeyIndicator  = byte(0x01)
+)
+
+// VersionedDBProvider implements interface VersionedDBProvider for the embedded JSON database. The database keeps
+// the state in a goleveldb instance, maintains the secondary indexes on the JSON fields as defined by the CouchDB
+// index definitions packaged with the chaincodes and evaluates the CouchDB (Mango) queries on the peer itself
+type VersionedDBProvider struct {
+	dbProvider *leveldbhelper.Provider
+}
+
+// NewVersionedDBProvider instantiates VersionedDBProvider
+func NewVersionedDBProvider() *VersionedDBProvider {
+	dbPath := ledgerconfig.GetStateJSONDBPath()
+	logger.Debugf("constructing VersionedDBProvider dbPath=%s", dbPath)
+	dbProvider := leveldbhelper.NewProvider(&leveldbhelper.Conf{DBPath: dbPath})
+	return &VersionedDBProvider{dbProvider}
+}
+
+// GetDBHandle gets the handle to a named database
+func (provider *VersionedDBProvider) GetDBHandle(dbName string) (statedb.VersionedDB, error) {
+	return newVersionedDB(provider.dbProvider.GetDBHandle(dbName), dbName)
+}
+
+// Close closes the underlying db
+func (provider *VersionedDBProvider) Close() {
+	provider.dbProvider.Close()
+}
+
+// versionedDB implements VersionedDB, BulkOptimizable, IndexCapable, IndexManageable and FullScanIterable interfaces
+type versionedDB struct {
+	db     *leveldbhelper.DBHandle
+	dbName string
+	// indexes maps a namespace to the definitions of the indexes on the namespace. The lock guards the
+	// indexes and serializes the commits with the creation of the indexes on the existing data
+	indexes       map[string][]*indexDefinition
+	indexesLock   sync.RWMutex
+	versionsCache *versionsCache
+}
+
+// newVersionedDB constructs an instance of VersionedDB
+func newVersionedDB(db *leveldbhelper.DBHandle, dbName string) (*versionedDB, error) {
+	indexes, err := loadIndexDefinitions(db)
+	if err != nil {
+		return nil, err
+	}
+	return &versionedDB{
+		db:            db,
+		dbName:        dbName,
+		indexes:       indexes,
+		versionsCache: newVersionsCache(),
+	}, nil
+}
+
+// Open implements method in VersionedDB interface
+func (vdb *versionedDB) Open() error {
+	// do nothing because shared db is used
+	return nil
+}
+
+// Close implements method in VersionedDB interface
+func (vdb *versionedDB) Close() {
+	// do nothing because shared db is used
+}
+
+// ValidateKeyValue implements method in VersionedDB interface. As the key is exposed to the queries via the field
+// "_id", the key is required to be a valid UTF-8 string and the top level fields of a JSON value are not allowed to
+// begin with an underscore, which is consistent with the restrictions that statecouchdb places on the keys and the values
+func (vdb *versionedDB) ValidateKeyValue(key string, value []byte) error {
+	if !utf8.ValidString(key) {
+		return errors.Errorf("invalid key [%x], must be a UTF-8 string", key)
+	}
+	decoded, err := mangoquery.DecodeJSON(value)
+	if err != nil {
+		return nil
+	}
+	doc, ok := decoded.(map[string]interface{})
+	if !ok {
+		return nil
+	}
+	for field := range doc {
+		if len(field) > 0 && field[0] == '_' {
+			return errors.Errorf("invalid field [%s], fields beginning with \"_\" are reserved", field)
+		}
+	}
+	return nil
+}
+
+// BytesKeySupported implements method in VersionedDB interface
+func (vdb *versionedDB) BytesKeySupported() bool {
+	return false
+}
+
+// GetState implements method in VersionedDB interface
+func (vdb *versionedDB) GetState(namespace string, key string) (*statedb.VersionedValue, error) {
+	logger.Debugf("GetState(). ns=%s, key=%s", namespace, key)
+	dbVal, err := vdb.db.Get(constructDataKey(namespace, key))
+	if err != nil {
+		return nil, err
+	}
+	if dbVal == nil {
+		return nil, nil
+	}
+	return decodeValue(dbVal)
+}
+
+// GetVersion implements method in VersionedDB interface
+func (vdb *versionedDB) GetVersion(namespace string, key string) (*version.Height, error) {
+	returnVersion, keyFound := vdb.GetCachedVersion(namespace, key)
+	if !keyFound {
+		// This if block get executed only during simulation because during commit
+		// we always call `LoadCommittedVersions` before calling `GetVersion`
+		vv, err := vdb.GetState(namespace, key)
+		if err != nil || vv == nil {
+			return nil, err
+		}
+		returnVersion = vv.Version
+	}
+	return returnVersion, nil
+}
+
+// GetStateMultipleKeys implements method in VersionedDB interface
+func (vdb *versionedDB) GetStateMultipleKeys(namespace string, keys []string) ([]*statedb.VersionedValue, error) {
+	vals := make([]*statedb.VersionedValue, len(keys))
+	for i, key := range keys {
+		val, err := vdb.GetState(namespace, key)
+		if err != nil {
+			return nil, err
+		}
+		vals[i] = val
+	}
+	return vals, nil
+}
+
+// GetStateRangeScanIterator implements method in VersionedDB interface
+// startKey is inclusive
+// endKey is exclusive
+func (vdb *versionedDB) GetStateRangeScanIterator(namespace string, startKey string, endKey string) (statedb.ResultsIterator, error) {
+	return vdb.GetStateRangeScanIteratorWithMetadata(namespace, startKey, endKey, nil)
+}
+
+const optionLimit = "limit"
+const optionBookmark = "bookmark"
+
+// GetStateRangeScanIteratorWithMetadata implements method in VersionedDB interface
+func (vdb *versionedDB) GetStateRangeScanIteratorWithMetadata(namespace string, startKey string, endKey string, metadata map[string]interface{}) (statedb.QueryResultsIterator, error) {
+	requestedLimit := int32(0)
+	// if metadata is provided, validate and apply options
+	if metadata != nil {
+		if err := statedb.ValidateRangeMetadata(metadata); err != nil {
+			return nil, err
+		}
+		if limitOption, ok := metadata[optionLimit]; ok {
+			requestedLimit = limitOption.(int32)
+		}
+	}
+	return newKVScanner(namespace, vdb.namespaceIterator(namespace, startKey, endKey), requestedLimit), nil
+}
+
+// ExecuteQuery implements method in VersionedDB interface
+func (vdb *versionedDB) ExecuteQuery(namespace, query string) (statedb.ResultsIterator, error) {
+	return vdb.ExecuteQueryWithMetadata(namespace, query, nil)
+}
+
+// ExecuteQueryWithMetadata implements method in VersionedDB interface. The candidate keys are retrieved
+// from an index on the namespace if the selector places a bound on the first field of the index, otherwise
+// all the keys of the namespace are evaluated
+func (vdb *versionedDB) ExecuteQueryWithMetadata(namespace, query string, metadata map[string]interface{}) (statedb.QueryResultsIterator, error) {
+	logger.Debugf("Entering ExecuteQueryWithMetadata namespace: %s, query: %s, metadata: %v", namespace, query, metadata)
+	requestedLimit := int32(0)
+	bookmark := ""
+	if metadata != nil {
+		if err := statedb.ValidateQueryMetadata(metadata); err != nil {
+			return nil, err
+		}
+		if limitOption, ok := metadata[optionLimit]; ok {
+			requestedLimit = limitOption.(int32)
+		}
+		if bookmarkOption, ok := metadata[optionBookmark]; ok {
+			bookmark = bookmarkOption.(string)
+		}
+	}
+	q, err := mangoquery.Parse(query)
+	if err != nil {
+		return nil, err
+	}
+	if bookmark == "" {
+		bookmark = q.Bookmark
+	}
+
+	vdb.indexesLock.RLock()
+	index, indexRange := selectIndex(q, vdb.indexes[namespace])
+	vdb.indexesLock.RUnlock()
+
+	if index == nil {
+		return q.Execute(newKVScanner(namespace, vdb.namespaceIterator(namespace, "", ""), 0), nil, requestedLimit, bookmark)
+	}
+	logger.Debugf("Using the index [%s] of the design document [%s] for the query on the namespace [%s]", index.Name, index.DesignDoc, namespace)
+	candidates := &indexScanner{
+		vdb:       vdb,
+		namespace: namespace,
+		dbItr:     vdb.db.GetIterator(index.scanRange(namespace, indexRange)),
+	}
+	return q.Execute(candidates, index.Fields, requestedLimit, bookmark)
+}
+
+// ApplyUpdates implements method in VersionedDB interface
+func (vdb *versionedDB) ApplyUpdates(batch *statedb.UpdateBatch, height *version.Height) error {
+	vdb.indexesLock.Lock()
+	defer vdb.indexesLock.Unlock()
+
+	dbBatch := leveldbhelper.NewUpdateBatch()
+	namespaces := batch.GetUpdatedNamespaces()
+	for _, ns := range namespaces {
+		indexes := vdb.indexes[ns]
+		updates := batch.GetUpdates(ns)
+		for k, vv := range updates {
+			dataKey := constructDataKey(ns, k)
+			logger.Debugf("Channel [%s]: Applying key(string)=[%s] key(bytes)=[%#v]", vdb.dbName, string(dataKey), dataKey)
+
+			// the index entries for the existing value are deleted before adding the
+			// entries for the new value, as the later operation in the batch prevails
+			if len(indexes) > 0 {
+				if err := vdb.deleteIndexEntries(dbBatch, ns, k, indexes); err != nil {
+					return err
+				}
+			}
+			if vv.Value == nil {
+				dbBatch.Delete(dataKey)
+				continue
+			}
+			encodedVal, err := encodeValue(vv)
+			if err != nil {
+				return err
+			}
+			dbBatch.Put(dataKey, encodedVal)
+			addIndexEntries(dbBatch, ns, k, vv.Value, indexes)
+		}
+	}
+	// Record a savepoint at a given height
+	// If a given height is nil, it denotes that we are committing pvt data of old blocks.
+	// In this case, we should not store a savepoint for recovery. The lastUpdatedOldBlockList
+	// in the pvtstore acts as a savepoint for pvt data.
+	if height != nil {
+		dbBatch.Put(savePointKey, height.ToBytes())
+	}
+	return vdb.db.WriteBatch(dbBatch, true)
+}
+
+// GetLatestSavePoint implements method in VersionedDB interface
+func (vdb *versionedDB) GetLatestSavePoint() (*version.Height, error) {
+	versionBytes, err := vdb.db.Get(savePointKey)
+	if err != nil {
+		return nil, err
+	}
+	if versionBytes == nil {
+		return nil, nil
+	}
+	version, _ := version.NewHeightFromBytes(versionBytes)
+	return version, nil
+}
+
+// GetFullScanIterator implements method in FullScanIterable interface
+func (vdb *versionedDB) GetFullScanIterator(skipNamespace func(string) bool) (statedb.ResultsIterator, error) {
+	return &fullDBScanner{
+		dbItr:         vdb.db.GetIterator(dataKeyPrefix, []byte{dataKeyPrefix[0] + 1}),
+		skipNamespace: skipNamespace,
+	}, nil
+}
+
+// namespaceIterator returns an iterator over the data keys of the namespace between the startKey (inclusive)
+// and the endKey (exclusive). An empty endKey represents a logical key after the last key of the namespace
+func (vdb *versionedDB) namespaceIterator(namespace, startKey, endKey string) iterator.Iterator {
+	dataStartKey := constructDataKey(namespace, startKey)
+	dataEndKey := constructDataKey(namespace, endKey)
+	if endKey == "" {
+		dataEndKey[len(dataEndKey)-1] = lastKeyIndicator
+	}
+	return vdb.db.GetIterator(dataStartKey, dataEndKey)
+}
+
+func constructDataKey(ns string, key string) []byte {
+	dataKey := append([]byte{}, dataKeyPrefix...)
+	dataKey = append(append(dataKey, []byte(ns)...), compositeKeySep...)
+	return append(dataKey, []byte(key)...)
+}
+
+func splitDataKey(dataKey []byte) (string, string) {
+	split := bytes.SplitN(dataKey[len(dataKeyPrefix):], compositeKeySep, 2)
+	return string(split[0]), string(split[1])
+}
+
+// encodeValue encodes the versioned value in the same format as the one used by stateleveldb
+func encodeValue(v *statedb.VersionedValue) ([]byte, error) {
+	vvMsg := &msgs.VersionedValueProto{
+		VersionBytes: v.Version.ToBytes(),
+		Value:        v.Value,
+		Metadata:     v.Metadata,
+	}
+	encodedValue, err := proto.Marshal(vvMsg)
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte{0}, encodedValue...), nil
+}
+
+func decodeValue(encodedValue []byte) (*statedb.VersionedValue, error) {
+	msg := &msgs.VersionedValueProto{}
+	if err := proto.Unmarshal(encodedValue[1:], msg); err != nil {
+		return nil, err
+	}
+	ver, _ := version.NewHeightFromBytes(msg.VersionBytes)
+	val := msg.Value
+	// protobuf always makes an empty byte array as nil
+	if val == nil {
+		val = []byte{}
+	}
+	return &statedb.VersionedValue{Version: ver, Value: val, Metadata: msg.Metadata}, nil
+}
+
+func decodeKV(dbKey, dbVal []byte) (*statedb.VersionedKV, error) {
+	dbValCopy := make([]byte, len(dbVal))
+	copy(dbValCopy, dbVal)
+	ns, key := splitDataKey(dbKey)
+	vv, err := decodeValue(dbValCopy)
+	if err != nil {
+		return nil, err
+	}
+	return &statedb.VersionedKV{
+		CompositeKey:   statedb.CompositeKey{Namespace: ns, Key: key},
+		VersionedValue: *vv,
+	}, nil
+}
+
+type kvScanner struct {
+	namespace            string
+	dbItr                iterator.Iterator
+	requestedLimit       int32
+	totalRecordsReturned int32
+}
+
+func newKVScanner(namespace string, dbItr iterator.Iterator, requestedLimit int32) *kvScanner {
+	return &kvScanner{namespace, dbItr, requestedLimit, 0}
+}
+
+func (scanner *kvScanner) Next() (statedb.QueryResult, error) {
+	if scanner.requestedLimit > 0 && scanner.totalRecordsReturned >= scanner.requestedLimit {
+		return nil, nil
+	}
+	if !scanner.dbItr.Next() {
+		return nil, nil
+	}
+	kv, err := decodeKV(scanner.dbItr.Key(), scanner.dbItr.Value())
+	if err != nil {
+		return nil, err
+	}
+	scanner.totalRecordsReturned++
+	return kv, nil
+}
+
+func (scanner *kvScanner) Close() {
+	scanner.dbItr.Release()
+}
+
+func (scanner *kvScanner) GetBookmarkAndClose() string {
+	retval := ""
+	if scanner.dbItr.Next() {
+		_, key := splitDataKey(scanner.dbItr.Key())
+		retval = key
+	}
+	scanner.Close()
+	return retval
+}
+
+type fullDBScanner struct {
+	dbItr         iterator.Iterator
+	skipNamespace func(string) bool
+	skippedNs     *string
+}
+
+func (s *fullDBScanner) Next() (statedb.QueryResult, error) {
+	for s.dbItr.Next() {
+		ns, _ := splitDataKey(s.dbItr.Key())
+		if s.skippedNs != nil && *s.skippedNs == ns {
+			continue
+		}
+		if s.skipNamespace != nil && s.skipNamespace(ns) {
+			s.skippedNs = &ns
+			continue
+		}
+		return decodeKV(s.dbItr.Key(), s.dbItr.Value())
+	}
+	return nil, nil
+}
+
+func (s *fullDBScanner) Close() {
+	s.dbItr.Release()
+}
@@ -0,0 +1,510 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package statejsondb
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hyperledger/fabric/common/ledger/testutil"
+	"github.com/hyperledger/fabric/core/common/ccprovider"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/statedb"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/statedb/commontests"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/statedb/mangoquery"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/version"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMain(m *testing.M) {
+	viper.Set("peer.fileSystemPath", "/tmp/fabric/ledgertests/kvledger/txmgmt/statedb/statejsondb")
+	os.Exit(m.Run())
+}
+
+func TestBasicRW(t *testing.T) {
+	env := NewTestVDBEnv(t)
+	defer env.Cleanup()
+	commontests.TestBasicRW(t, env.DBProvider)
+}
+
+func TestMultiDBBasicRW(t *testing.T) {
+	env := NewTestVDBEnv(t)
+	defer env.Cleanup()
+	commontests.TestMultiDBBasicRW(t, env.DBProvider)
+}
+
+func TestDeletes(t *testing.T) {
+	env := NewTestVDBEnv(t)
+	defer env.Cleanup()
+	commontests.TestDeletes(t, env.DBProvider)
+}
+
+func TestIterator(t *testing.T) {
+	env := NewTestVDBEnv(t)
+	defer env.Cleanup()
+	commontests.TestIterator(t, env.DBProvider)
+}
+
+func TestQuery(t *testing.T) {
+	env := NewTestVDBEnv(t)
+	defer env.Cleanup()
+	commontests.TestQuery(t, env.DBProvider)
+}
+
+func TestGetStateMultipleKeys(t *testing.T) {
+	env := NewTestVDBEnv(t)
+	defer env.Cleanup()
+	commontests.TestGetStateMultipleKeys(t, env.DBProvider)
+}
+
+func TestGetVersion(t *testing.T) {
+	env := NewTestVDBEnv(t)
+	defer env.Cleanup()
+	commontests.TestGetVersion(t, env.DBProvider)
+}
+
+func TestSmallBatchSize(t *testing.T) {
+	env := NewTestVDBEnv(t)
+	defer env.Cleanup()
+	commontests.TestSmallBatchSize(t, env.DBProvider)
+}
+
+func TestValueAndMetadataWrites(t *testing.T) {
+	env := NewTestVDBEnv(t)
+	defer env.Cleanup()
+	commontests.TestValueAndMetadataWrites(t, env.DBProvider)
+}
+
+func TestPaginatedRangeQuery(t *testing.T) {
+	env := NewTestVDBEnv(t)
+	defer env.Cleanup()
+	commontests.TestPaginatedRangeQuery(t, env.DBProvider)
+}
+
+func TestApplyUpdatesWithNilHeight(t *testing.T) {
+	env := NewTestVDBEnv(t)
+	defer env.Cleanup()
+	commontests.TestApplyUpdatesWithNilHeight(t, env.DBProvider)
+}
+
+func TestUtilityFunctions(t *testing.T) {
+	env := NewTestVDBEnv(t)
+	defer env.Cleanup()
+	db, err := env.DBProvider.GetDBHandle("testutilityfunctions")
+	assert.NoError(t, err)
+
+	_, ok := db.(statedb.BulkOptimizable)
+	assert.True(t, ok)
+	indexCapable, ok := db.(statedb.IndexCapable)
+	assert.True(t, ok)
+	assert.Equal(t, "couchdb", indexCapable.GetDBType())
+	_, ok = db.(statedb.FullScanIterable)
+	assert.True(t, ok)
+
+	assert.False(t, db.BytesKeySupported())
+	assert.NoError(t, db.ValidateKeyValue("testKey", []byte("testValue")))
+	assert.NoError(t, db.ValidateKeyValue("testKey", []byte(`{"owner":"tom"}`)))
+	assert.NoError(t, db.ValidateKeyValue("testKey", []byte(`["_id"]`)))
+	assert.EqualError(t, db.ValidateKeyValue(string([]byte{0xff, 0xfe}), []byte("testValue")),
+		"invalid key [fffe], must be a UTF-8 string")
+	assert.EqualError(t, db.ValidateKeyValue("testKey", []byte(`{"_id":"key1","owner":"tom"}`)),
+		"invalid field [_id], fields beginning with \"_\" are reserved")
+}
+
+func TestFullScanIterator(t *testing.T) {
+	env := NewTestVDBEnv(t)
+	defer env.Cleanup()
+	db, err := env.DBProvider.GetDBHandle("testfullscaniterator")
+	assert.NoError(t, err)
+	assert.NoError(t, db.(statedb.IndexCapable).ProcessIndexesForChaincodeDeploy("ns1",
+		indexFileEntries(t, `{"index":{"fields":["owner"]},"ddoc":"indexOwnerDoc","name":"indexOwner","type":"json"}`)))
+
+	batch := statedb.NewUpdateBatch()
+	batch.Put("ns1", "key1", []byte(`{"owner":"tom"}`), version.NewHeight(1, 1))
+	batch.PutValAndMetadata("ns1", "key2", []byte("value2"), []byte("metadata2"), version.NewHeight(1, 2))
+	batch.Put("ns2", "key3", []byte("value3"), version.NewHeight(1, 3))
+	batch.Put("ns3", "key4", []byte("value4"), version.NewHeight(1, 4))
+	assert.NoError(t, db.ApplyUpdates(batch, version.NewHeight(2, 5)))
+
+	itr, err := db.(statedb.FullScanIterable).GetFullScanIterator(
+		func(ns string) bool { return ns == "ns2" },
+	)
+	assert.NoError(t, err)
+	defer itr.Close()
+	var results []*statedb.VersionedKV
+	for {
+		res, err := itr.Next()
+		assert.NoError(t, err)
+		if res == nil {
+			break
+		}
+		results = append(results, res.(*statedb.VersionedKV))
+	}
+	assert.Equal(t,
+		[]*statedb.VersionedKV{
+			{
+				CompositeKey:   statedb.CompositeKey{Namespace: "ns1", Key: "key1"},
+				VersionedValue: statedb.VersionedValue{Value: []byte(`{"owner":"tom"}`), Version: version.NewHeight(1, 1)},
+			},
+			{
+				CompositeKey:   statedb.CompositeKey{Namespace: "ns1", Key: "key2"},
+				VersionedValue: statedb.VersionedValue{Value: []byte("value2"), Metadata: []byte("metadata2"), Version: version.NewHeight(1, 2)},
+			},
+			{
+				CompositeKey:   statedb.CompositeKey{Namespace: "ns3", Key: "key4"},
+				VersionedValue: statedb.VersionedValue{Value: []byte("value4"), Version: version.NewHeight(1, 4)},
+			},
+		},
+		results,
+	)
+}
+
+func TestIndexMaintenance(t *testing.T) {
+	env := NewTestVDBEnv(t)
+	defer env.Cleanup()
+	db, err := env.DBProvider.GetDBHandle("testindexmaintenance")
+	assert.NoError(t, err)
+
+	// the index is built over the existing data
+	batch := statedb.NewUpdateBatch()
+	batch.Put("ns1", "key1", []byte(`{"owner":"tom","size":1}`), version.NewHeight(1, 1))
+	batch.Put("ns1", "key2", []byte(`{"owner":"jerry","size":2}`), version.NewHeight(1, 2))
+	batch.Put("ns1", "key3", []byte(`{"size":3}`), version.NewHeight(1, 3))
+	batch.Put("ns1", "key4", []byte("non-json-value"), version.NewHeight(1, 4))
+	batch.Put("ns2", "key1", []byte(`{"owner":"tom","size":1}`), version.NewHeight(1, 5))
+	assert.NoError(t, db.ApplyUpdates(batch, version.NewHeight(1, 5)))
+
+	indexCapable := db.(statedb.IndexCapable)
+	ownerIndex := `{"index":{"fields":["owner"]},"ddoc":"indexOwnerDoc","name":"indexOwner","type":"json"}`
+	assert.NoError(t, indexCapable.ProcessIndexesForChaincodeDeploy("ns1", indexFileEntries(t, ownerIndex)))
+	ownerIndexDef := &indexDefinition{DesignDoc: "indexOwnerDoc", Name: "indexOwner", Fields: []string{"owner"}}
+	assert.Equal(t, []string{"key2", "key1"}, indexedKeys(t, db, "ns1", ownerIndexDef))
+	assert.Empty(t, indexedKeys(t, db, "ns2", ownerIndexDef))
+
+	// the index is maintained on the updates and the deletes
+	batch = statedb.NewUpdateBatch()
+	batch.Put("ns1", "key1", []byte(`{"owner":"fred","size":1}`), version.NewHeight(2, 1))
+	batch.Delete("ns1", "key2", version.NewHeight(2, 2))
+	batch.Put("ns1", "key3", []byte(`{"owner":"alice","size":3}`), version.NewHeight(2, 3))
+	batch.Put("ns1", "key5", []byte(`{"owner":"bob"}`), version.NewHeight(2, 4))
+	assert.NoError(t, db.ApplyUpdates(batch, version.NewHeight(2, 4)))
+	assert.Equal(t, []string{"key3", "key5", "key1"}, indexedKeys(t, db, "ns1", ownerIndexDef))
+
+	// processing the same index again is a no-op and the index definitions survive the restart
+	assert.NoError(t, indexCapable.ProcessIndexesForChaincodeDeploy("ns1", indexFileEntries(t, ownerIndex)))
+	env.DBProvider.Close()
+	env.DBProvider = NewVersionedDBProvider()
+	db, err = env.DBProvider.GetDBHandle("testindexmaintenance")
+	assert.NoError(t, err)
+	assert.Equal(t, []*indexDefinition{ownerIndexDef}, db.(*versionedDB).indexes["ns1"])
+	batch = statedb.NewUpdateBatch()
+	batch.Put("ns1", "key4", []byte(`{"owner":"carol"}`), version.NewHeight(3, 1))
+	assert.NoError(t, db.ApplyUpdates(batch, version.NewHeight(3, 1)))
+	assert.Equal(t, []string{"key3", "key5", "key4", "key1"}, indexedKeys(t, db, "ns1", ownerIndexDef))
+
+	// an index whose fields are changed is rebuilt
+	maxIndexBuildBatchSize = 2
+	defer func() { maxIndexBuildBatchSize = 1000 }()
+	sizeIndex := `{"index":{"fields":[{"size":"desc"}]},"ddoc":"indexOwnerDoc","name":"indexOwner","type":"json"}`
+	assert.NoError(t, db.(statedb.IndexCapable).ProcessIndexesForChaincodeDeploy("ns1", indexFileEntries(t, sizeIndex)))
+	sizeIndexDef := &indexDefinition{DesignDoc: "indexOwnerDoc", Name: "indexOwner", Fields: []string{"size"}}
+	assert.Equal(t, []*indexDefinition{sizeIndexDef}, db.(*versionedDB).indexes["ns1"])
+	assert.Equal(t, []string{"key1", "key3"}, indexedKeys(t, db, "ns1", sizeIndexDef))
+
+	// an erroneous index definition
+	err = db.(statedb.IndexCapable).ProcessIndexesForChaincodeDeploy("ns1", indexFileEntries(t, `{"index":{"fields": This is a bad json}`))
+	assert.Contains(t, err.Error(), "error creating index from file [META-INF/statedb/couchdb/indexes/index0.json] for channel [ns1]")
+	err = db.(statedb.IndexCapable).ProcessIndexesForChaincodeDeploy("ns1", indexFileEntries(t, `{"index":{"fields":["size"]},"type":"text"}`))
+	assert.Contains(t, err.Error(), "invalid index definition, unsupported index type [text]")
+}
+
+func TestIndexManagement(t *testing.T) {
+	env := NewTestVDBEnv(t)
+	defer env.Cleanup()
+	db, err := env.DBProvider.GetDBHandle("testindexmanagement")
+	assert.NoError(t, err)
+
+	batch := statedb.NewUpdateBatch()
+	batch.Put("ns1", "key1", []byte(`{"owner":"tom","size":1}`), version.NewHeight(1, 1))
+	batch.Put("ns1", "key2", []byte(`{"owner":"jerry","size":2}`), version.NewHeight(1, 2))
+	assert.NoError(t, db.ApplyUpdates(batch, version.NewHeight(1, 2)))
+
+	indexManageable := db.(statedb.IndexManageable)
+	indexes, err := indexManageable.ListIndexes("ns1")
+	assert.NoError(t, err)
+	assert.Empty(t, indexes)
+
+	index, err := indexManageable.RebuildIndex("ns1", []byte(`{"index":{"fields":["owner"]},"ddoc":"_design/indexOwnerDoc","name":"indexOwner"}`))
+	assert.NoError(t, err)
+	assert.Equal(t, &statedb.IndexInfo{DesignDoc: "indexOwnerDoc", Name: "indexOwner", Definition: `{"fields":[{"owner":"asc"}]}`}, index)
+	ownerIndexDef := &indexDefinition{DesignDoc: "indexOwnerDoc", Name: "indexOwner", Fields: []string{"owner"}}
+	assert.Equal(t, []string{"key2", "key1"}, indexedKeys(t, db, "ns1", ownerIndexDef))
+
+	// rebuilding the index with an updated definition replaces it
+	index, err = indexManageable.RebuildIndex("ns1", []byte(`{"index":{"fields":["size","owner"]},"ddoc":"indexOwnerDoc","name":"indexOwner"}`))
+	assert.NoError(t, err)
+	assert.Equal(t, `{"fields":[{"size":"asc"},{"owner":"asc"}]}`, index.Definition)
+	indexes, err = indexManageable.ListIndexes("ns1")
+	assert.NoError(t, err)
+	assert.Equal(t, []*statedb.IndexInfo{index}, indexes)
+	sizeIndexDef := &indexDefinition{DesignDoc: "indexOwnerDoc", Name: "indexOwner", Fields: []string{"size", "owner"}}
+	assert.Equal(t, []string{"key1", "key2"}, indexedKeys(t, db, "ns1", sizeIndexDef))
+
+	_, err = indexManageable.RebuildIndex("ns1", []byte(`{"index":{"fields":["size"]},"type":"text"}`))
+	assert.EqualError(t, err, "invalid index definition, unsupported index type [text]")
+
+	// the dropped index does not survive the restart
+	assert.NoError(t, indexManageable.DropIndex("ns1", "_design/indexOwnerDoc", "indexOwner"))
+	assert.Empty(t, indexedKeys(t, db, "ns1", sizeIndexDef))
+	env.DBProvider.Close()
+	env.DBProvider = NewVersionedDBProvider()
+	db, err = env.DBProvider.GetDBHandle("testindexmanagement")
+	assert.NoError(t, err)
+	indexes, err = db.(statedb.IndexManageable).ListIndexes("ns1")
+	assert.NoError(t, err)
+	assert.Empty(t, indexes)
+
+	err = db.(statedb.IndexManageable).DropIndex("ns1", "indexOwnerDoc", "indexOwner")
+	assert.EqualError(t, err, "index [indexOwner] of design document [indexOwnerDoc] not found for namespace [ns1]")
+}
+
+func TestParseIndexDefinition(t *testing.T) {
+	def, err := parseIndexDefinition([]byte(`{"index":{"fields":["docType",{"owner":"desc"}]},"ddoc":"_design/indexOwnerDoc","name":"indexOwner","type":"json"}`))
+	assert.NoError(t, err)
+	assert.Equal(t, &indexDefinition{DesignDoc: "indexOwnerDoc", Name: "indexOwner", Fields: []string{"docType", "owner"}}, def)
+
+	def, err = parseIndexDefinition([]byte(`{"index":{"fields":["owner"]}}`))
+	assert.NoError(t, err)
+	assert.Len(t, def.Name, 32)
+	assert.Equal(t, def.Name, def.DesignDoc)
+
+	testCases := []struct {
+		definition  string
+		expectedErr string
+	}{
+		{`{"index":{}}`, "invalid index definition, the definition must contain a non-empty \"index.fields\""},
+		{`{"index":{"fields":[{"owner":"up"}]}}`, "invalid index definition, the fields must be either field names or objects in the form {\"<field name>\": \"asc|desc\"}"},
+		{`{"index":{"fields":[1]}}`, "invalid index definition, the fields must be either field names or objects in the form {\"<field name>\": \"asc|desc\"}"},
+		{`{"index":{"fields":["owner"]},"name":"a\u0000b"}`, "invalid index definition, the name and the design document cannot contain a null character"},
+	}
+	for _, testCase := range testCases {
+		_, err := parseIndexDefinition([]byte(testCase.definition))
+		assert.EqualError(t, err, testCase.expectedErr)
+	}
+}
+
+func TestEncodedValueOrder(t *testing.T) {
+	// the values in the collation order, the arrays and the objects are ordered only by their type
+	values := []string{
+		`null`, `false`, `true`, `-1e300`, `-10.5`, `-1`, `0`, `0.5`, `1`, `2`, `10`, `1e300`,
+		`""`, `"\u0000"`, `"a"`, `"a\u0000"`, `"a\u0000b"`, `"ab"`, `"b"`, `[]`, `{}`,
+	}
+	var previous []byte
+	for _, value := range values {
+		decoded, err := mangoquery.DecodeJSON([]byte(value))
+		assert.NoError(t, err)
+		encoded := appendEncodedValue(nil, decoded)
+		if previous != nil {
+			assert.True(t, bytes.Compare(previous, encoded) < 0, "encoding of [%s] is expected to sort after the previous value", value)
+		}
+		previous = encoded
+	}
+
+	negativeZero, _ := mangoquery.DecodeJSON([]byte(`-0`))
+	zero, _ := mangoquery.DecodeJSON([]byte(`0`))
+	assert.Equal(t, appendEncodedValue(nil, zero), appendEncodedValue(nil, negativeZero))
+	assert.Equal(t, []byte{0x01, 0x02}, successor([]byte{0x01, 0x01, 0xff}))
+}
+
+func TestQueryWithIndexes(t *testing.T) {
+	env := NewTestVDBEnv(t)
+	defer env.Cleanup()
+	db, err := env.DBProvider.GetDBHandle("testquerywithindexes")
+	assert.NoError(t, err)
+	assert.NoError(t, db.(statedb.IndexCapable).ProcessIndexesForChaincodeDeploy("ns1", indexFileEntries(t,
+		`{"index":{"fields":["owner","size"]},"ddoc":"indexOwnerDoc","name":"indexOwner","type":"json"}`,
+		`{"index":{"fields":["size"]},"ddoc":"indexSizeDoc","name":"indexSize","type":"json"}`,
+	)))
+
+	batch := statedb.NewUpdateBatch()
+	owners := []string{"tom", "jerry", "fred", "martha", "fred", "elaine", "fred", "elaine", "fred", "mary"}
+	for i, owner := range owners {
+		value := fmt.Sprintf(`{"asset_name":"marble%d","color":"blue","size":%d,"owner":"%s"}`, i+1, i+1, owner)
+		batch.Put("ns1", fmt.Sprintf("key%d", i+1), []byte(value), version.NewHeight(1, uint64(i+1)))
+	}
+	batch.Put("ns1", "key11", []byte(`{"asset_name":"marble11","color":"cyan","size":"large","owner":"fred"}`), version.NewHeight(1, 11))
+	batch.Put("ns1", "key12", []byte(`{"asset_name":"marble12","color":"cyan","owner":"fred"}`), version.NewHeight(1, 12))
+	assert.NoError(t, db.ApplyUpdates(batch, version.NewHeight(1, 12)))
+
+	testCases := []struct {
+		name         string
+		query        string
+		expectedKeys []string
+	}{
+		{
+			name:  "equality-on-the-first-field-orders-by-the-index",
+			query: `{"selector":{"owner":"fred"}}`,
+			// the index on [owner, size] is used and key12 lacks the field size
+			expectedKeys: []string{"key3", "key5", "key7", "key9", "key11"},
+		},
+		{
+			name:         "range-on-the-first-field",
+			query:        `{"selector":{"size":{"$gt":3,"$lte":6}}}`,
+			expectedKeys: []string{"key4", "key5", "key6"},
+		},
+		{
+			name:  "range-across-the-types",
+			query: `{"selector":{"size":{"$gt":8}}}`,
+			// the strings are greater than the numbers in the collation order
+			expectedKeys: []string{"key9", "key10", "key11"},
+		},
+		{
+			name:         "use-index",
+			query:        `{"selector":{"owner":"fred","size":{"$lt":8}},"use_index":["_design/indexSizeDoc","indexSize"]}`,
+			expectedKeys: []string{"key3", "key5", "key7"},
+		},
+		{
+			name:         "no-applicable-index-orders-by-key",
+			query:        `{"selector":{"color":"cyan"}}`,
+			expectedKeys: []string{"key11", "key12"},
+		},
+		{
+			name:         "sort",
+			query:        `{"selector":{"owner":"fred"},"sort":[{"size":"desc"}]}`,
+			expectedKeys: []string{"key11", "key9", "key7", "key5", "key3"},
+		},
+		{
+			name:         "skip",
+			query:        `{"selector":{"owner":"fred"},"sort":["size"],"skip":3}`,
+			expectedKeys: []string{"key9", "key11"},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			itr, err := db.ExecuteQuery("ns1", testCase.query)
+			assert.NoError(t, err)
+			assert.Equal(t, testCase.expectedKeys, queryResultKeys(t, itr))
+		})
+	}
+
+	// fields
+	itr, err := db.ExecuteQuery("ns1", `{"selector":{"owner":"jerry"},"fields":["owner","size","_id"]}`)
+	assert.NoError(t, err)
+	res, err := itr.Next()
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"owner":"jerry","size":2}`, string(res.(*statedb.VersionedKV).Value))
+	assert.Equal(t, version.NewHeight(1, 2), res.(*statedb.VersionedKV).Version)
+
+	// invalid queries and options
+	_, err = db.ExecuteQuery("ns1", `{"selector":{"owner":{"$unknown":1}}}`)
+	assert.EqualError(t, err, "invalid selector: invalid operator [$unknown]")
+	_, err = db.ExecuteQueryWithMetadata("ns1", `{"selector":{"owner":"fred"}}`, map[string]interface{}{"limit": 10})
+	assert.EqualError(t, err, "Invalid entry, \"limit\" must be an int32")
+	_, err = db.ExecuteQueryWithMetadata("ns1", `{"selector":{"owner":"fred"}}`, map[string]interface{}{"bookmark": "bad-bookmark"})
+	assert.EqualError(t, err, "invalid bookmark [bad-bookmark]")
+}
+
+func TestPaginatedQuery(t *testing.T) {
+	env := NewTestVDBEnv(t)
+	defer env.Cleanup()
+	db, err := env.DBProvider.GetDBHandle("testpaginatedquery")
+	assert.NoError(t, err)
+	assert.NoError(t, db.(statedb.IndexCapable).ProcessIndexesForChaincodeDeploy("ns1", indexFileEntries(t,
+		`{"index":{"fields":["color"]},"ddoc":"indexColorDoc","name":"indexColor","type":"json"}`,
+	)))
+
+	batch := statedb.NewUpdateBatch()
+	colors := []string{"blue", "red", "red", "red", "blue", "red", "blue", "red", "green", "green", "red", "red"}
+	for i, color := range colors {
+		value := fmt.Sprintf(`{"asset_name":"marble%d","color":"%s","size":%d}`, i+1, color, (i*7)%12)
+		batch.Put("ns1", fmt.Sprintf("key%02d", i+1), []byte(value), version.NewHeight(1, uint64(i+1)))
+	}
+	assert.NoError(t, db.ApplyUpdates(batch, version.NewHeight(1, 12)))
+
+	for _, query := range []string{
+		`{"selector":{"color":"red"}}`,
+		`{"selector":{"color":{"$regex":"^r"}}}`,
+		`{"selector":{"color":"red"},"sort":[{"size":"desc"}]}`,
+	} {
+		itr, err := db.ExecuteQuery("ns1", query)
+		assert.NoError(t, err)
+		expectedKeys := queryResultKeys(t, itr)
+		assert.Len(t, expectedKeys, 7)
+
+		for _, pageSize := range []int32{1, 2, 3, 7, 10} {
+			var keys []string
+			bookmark := ""
+			for {
+				itr, err := db.ExecuteQueryWithMetadata("ns1", query, map[string]interface{}{"limit": pageSize, "bookmark": bookmark})
+				assert.NoError(t, err)
+				pageKeys := queryResultKeys(t, itr)
+				assert.True(t, len(pageKeys) <= int(pageSize))
+				newBookmark := itr.GetBookmarkAndClose()
+				if len(pageKeys) == 0 {
+					assert.Equal(t, bookmark, newBookmark)
+					break
+				}
+				keys = append(keys, pageKeys...)
+				bookmark = newBookmark
+			}
+			assert.Equal(t, expectedKeys, keys, "query=%s, pageSize=%d", query, pageSize)
+		}
+	}
+
+	// the bookmark can be supplied within the query as well
+	itr, err := db.ExecuteQueryWithMetadata("ns1", `{"selector":{"color":"red"}}`, map[string]interface{}{"limit": int32(2)})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"key02", "key03"}, queryResultKeys(t, itr))
+	bookmarkJSON, err := json.Marshal(itr.GetBookmarkAndClose())
+	assert.NoError(t, err)
+	itr, err = db.ExecuteQueryWithMetadata("ns1", `{"selector":{"color":"red"},"bookmark":`+string(bookmarkJSON)+`}`,
+		map[string]interface{}{"limit": int32(2)})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"key04", "key06"}, queryResultKeys(t, itr))
+}
+
+func indexFileEntries(t *testing.T, indexDefinitions ...string) []*ccprovider.TarFileEntry {
+	var tarEntries []*testutil.TarFileEntry
+	for i, indexDefinition := range indexDefinitions {
+		tarEntries = append(tarEntries, &testutil.TarFileEntry{
+			Name: fmt.Sprintf("META-INF/statedb/couchdb/indexes/index%d.json", i),
+			Body: indexDefinition,
+		})
+	}
+	fileEntries, err := ccprovider.ExtractFileEntries(testutil.CreateTarBytesForTest(tarEntries), "couchdb")
+	assert.NoError(t, err)
+	return fileEntries["META-INF/statedb/couchdb/indexes"]
+}
+
+// indexedKeys returns the keys in the order of the entries of the given index
+func indexedKeys(t *testing.T, db statedb.VersionedDB, ns string, def *indexDefinition) []string {
+	vdb := db.(*versionedDB)
+	prefix := def.entryKeyPrefix(ns)
+	dbItr := vdb.db.GetIterator(prefix, successor(prefix))
+	defer dbItr.Release()
+	var keys []string
+	for dbItr.Next() {
+		keys = append(keys, string(dbItr.Value()))
+	}
+	assert.NoError(t, dbItr.Error())
+	return keys
+}
+
+func queryResultKeys(t *testing.T, itr statedb.ResultsIterator) []string {
+	var keys []string
+	for {
+		res, err := itr.Next()
+		assert.NoError(t, err)
+		if res == nil {
+			return keys
+		}
+		keys = append(keys, res.(*statedb.VersionedKV).Key)
+	}
+}
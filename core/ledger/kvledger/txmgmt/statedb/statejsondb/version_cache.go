@@ -0,0 +1,73 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package statejsondb
+
+import (
+	"sync"
+
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/statedb"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/version"
+)
+
+// versionsCache contains the committed versions of the keys that are loaded in bulk
+// before the validation of a block and is used for the state validation of the readsets
+type versionsCache struct {
+	vers map[string]map[string]*version.Height
+	lock sync.RWMutex
+}
+
+func newVersionsCache() *versionsCache {
+	return &versionsCache{vers: map[string]map[string]*version.Height{}}
+}
+
+func (c *versionsCache) getVersion(ns, key string) (*version.Height, bool) {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+	ver, ok := c.vers[ns][key]
+	return ver, ok
+}
+
+func (c *versionsCache) reset(vers map[string]map[string]*version.Height) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.vers = vers
+}
+
+// LoadCommittedVersions implements method in BulkOptimizable interface. A nil version is
+// cached for a key that is not present in the db so that the db is not looked up again
+func (vdb *versionedDB) LoadCommittedVersions(keys []*statedb.CompositeKey) error {
+	vers := map[string]map[string]*version.Height{}
+	for _, compositeKey := range keys {
+		ns, key := compositeKey.Namespace, compositeKey.Key
+		vv, err := vdb.GetState(ns, key)
+		if err != nil {
+			return err
+		}
+		if vers[ns] == nil {
+			vers[ns] = map[string]*version.Height{}
+		}
+		if vv == nil {
+			vers[ns][key] = nil
+			continue
+		}
+		vers[ns][key] = vv.Version
+	}
+	vdb.versionsCache.reset(vers)
+	return nil
+}
+
+// GetCachedVersion implements method in BulkOptimizable interface
+func (vdb *versionedDB) GetCachedVersion(namespace, key string) (*version.Height, bool) {
+	logger.Debugf("Retrieving cached version: %s~%s", key, namespace)
+	return vdb.versionsCache.getVersion(namespace, key)
+}
+
+// ClearCachedVersions implements method in BulkOptimizable interface
+func (vdb *versionedDB) ClearCachedVersions() {
+	logger.Debugf("Clear Cache")
+	vdb.versionsCache.reset(map[string]map[string]*version.Height{})
+}
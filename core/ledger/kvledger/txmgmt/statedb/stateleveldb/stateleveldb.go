@@ -11,9 +11,9 @@ import (
 	"github.com/hyperledger/fabric/common/flogging"
 	"github.com/hyperledger/fabric/common/ledger/util/leveldbhelper"
 	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/statedb"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/statedb/mangoquery"
 	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/version"
 	"github.com/hyperledger/fabric/core/ledger/ledgerconfig"
-	"github.com/pkg/errors"
 	"github.com/syndtr/goleveldb/leveldb/iterator"
 )
 
@@ -154,14 +154,43 @@ func (vdb *versionedDB) GetStateRangeScanIteratorWithMetadata(namespace string,
 
 }
 
+const optionBookmark = "bookmark"
+
 // ExecuteQuery implements method in VersionedDB interface
 func (vdb *versionedDB) ExecuteQuery(namespace, query string) (statedb.ResultsIterator, error) {
-	return nil, errors.New("ExecuteQuery not supported for leveldb")
+	return vdb.ExecuteQueryWithMetadata(namespace, query, nil)
 }
 
-// ExecuteQueryWithMetadata implements method in VersionedDB interface
+// ExecuteQueryWithMetadata implements method in VersionedDB interface. As goleveldb does not maintain
+// any index on the values, the selector of the query is evaluated for all the keys of the namespace.
+// The results are ordered and paged in the same way as statecouchdb orders and pages the results
 func (vdb *versionedDB) ExecuteQueryWithMetadata(namespace, query string, metadata map[string]interface{}) (statedb.QueryResultsIterator, error) {
-	return nil, errors.New("ExecuteQueryWithMetadata not supported for leveldb")
+	logger.Debugf("Entering ExecuteQueryWithMetadata namespace: %s, query: %s, metadata: %v", namespace, query, metadata)
+	requestedLimit := int32(0)
+	bookmark := ""
+	if metadata != nil {
+		if err := statedb.ValidateQueryMetadata(metadata); err != nil {
+			return nil, err
+		}
+		if limitOption, ok := metadata[optionLimit]; ok {
+			requestedLimit = limitOption.(int32)
+		}
+		if bookmarkOption, ok := metadata[optionBookmark]; ok {
+			bookmark = bookmarkOption.(string)
+		}
+	}
+	q, err := mangoquery.Parse(query)
+	if err != nil {
+		return nil, err
+	}
+	if bookmark == "" {
+		bookmark = q.Bookmark
+	}
+	compositeStartKey := constructCompositeKey(namespace, "")
+	compositeEndKey := constructCompositeKey(namespace, "")
+	compositeEndKey[len(compositeEndKey)-1] = lastKeyIndicator
+	dbItr := vdb.db.GetIterator(compositeStartKey, compositeEndKey)
+	return q.Execute(newKVScanner(namespace, dbItr, 0), nil, requestedLimit, bookmark)
 }
 
 // ApplyUpdates implements method in VersionedDB interface
@@ -212,6 +241,14 @@ func (vdb *versionedDB) GetLatestSavePoint() (*version.Height, error) {
 	return version, nil
 }
 
+// GetFullScanIterator implements method in FullScanIterable interface
+func (vdb *versionedDB) GetFullScanIterator(skipNamespace func(string) bool) (statedb.ResultsIterator, error) {
+	return &fullDBScanner{
+		dbItr:         vdb.db.GetIterator(nil, nil),
+		skipNamespace: skipNamespace,
+	}, nil
+}
+
 func constructCompositeKey(ns string, key string) []byte {
 	return append(append([]byte(ns), compositeKeySep...), []byte(key)...)
 }
@@ -275,3 +312,42 @@ func (scanner *kvScanner) GetBookmarkAndClose() string {
 	scanner.Close()
 	return retval
 }
+
+type fullDBScanner struct {
+	dbItr         iterator.Iterator
+	skipNamespace func(string) bool
+	skippedNs     *string
+}
+
+func (s *fullDBScanner) Next() (statedb.QueryResult, error) {
+	for s.dbItr.Next() {
+		dbKey := s.dbItr.Key()
+		if bytes.Equal(dbKey, savePointKey) {
+			continue
+		}
+		ns, key := splitCompositeKey(dbKey)
+		if s.skippedNs != nil && *s.skippedNs == ns {
+			continue
+		}
+		if s.skipNamespace != nil && s.skipNamespace(ns) {
+			s.skippedNs = &ns
+			continue
+		}
+		dbVal := s.dbItr.Value()
+		dbValCopy := make([]byte, len(dbVal))
+		copy(dbValCopy, dbVal)
+		vv, err := decodeValue(dbValCopy)
+		if err != nil {
+			return nil, err
+		}
+		return &statedb.VersionedKV{
+			CompositeKey:   statedb.CompositeKey{Namespace: ns, Key: key},
+			VersionedValue: *vv,
+		}, nil
+	}
+	return nil, nil
+}
+
+func (s *fullDBScanner) Close() {
+	s.dbItr.Release()
+}
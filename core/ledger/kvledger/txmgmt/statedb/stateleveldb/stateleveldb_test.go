@@ -17,6 +17,7 @@ limitations under the License.
 package stateleveldb
 
 import (
+	"fmt"
 	"os"
 	"testing"
 
@@ -80,16 +81,126 @@ func TestQueryOnLevelDB(t *testing.T) {
 	batch := statedb.NewUpdateBatch()
 	jsonValue1 := `{"asset_name": "marble1","color": "blue","size": 1,"owner": "tom"}`
 	batch.Put("ns1", "key1", []byte(jsonValue1), version.NewHeight(1, 1))
+	jsonValue2 := `{"asset_name": "marble2","color": "blue","size": 2,"owner": "jerry"}`
+	batch.Put("ns1", "key2", []byte(jsonValue2), version.NewHeight(1, 2))
+	batch.Put("ns1", "key3", []byte("non-json-value"), version.NewHeight(1, 3))
+	batch.Put("ns2", "key1", []byte(jsonValue2), version.NewHeight(1, 4))
 
 	savePoint := version.NewHeight(2, 22)
 	db.ApplyUpdates(batch, savePoint)
 
 	// query for owner=jerry, use namespace "ns1"
-	// As queries are not supported in levelDB, call to ExecuteQuery()
-	// should return a error message
 	itr, err := db.ExecuteQuery("ns1", `{"selector":{"owner":"jerry"}}`)
-	assert.Error(t, err, "ExecuteQuery not supported for leveldb")
-	assert.Nil(t, itr)
+	assert.NoError(t, err)
+	commontests.TestItrWithoutClose(t, itr, []string{"key2"})
+
+	// the non-JSON values match only the selectors on the key
+	itr, err = db.ExecuteQuery("ns1", `{"selector":{"_id":{"$gt":"key1"}}}`)
+	assert.NoError(t, err)
+	commontests.TestItrWithoutClose(t, itr, []string{"key2", "key3"})
+
+	// query using bad query string
+	_, err = db.ExecuteQuery("ns1", "this is an invalid query string")
+	assert.Error(t, err, "Should have received an error for invalid query string")
+
+	// query using an unsupported operator
+	_, err = db.ExecuteQuery("ns1", `{"selector":{"owner":{"$unknown":"jerry"}}}`)
+	assert.EqualError(t, err, "invalid selector: invalid operator [$unknown]")
+
+	// query using invalid metadata
+	_, err = db.ExecuteQueryWithMetadata("ns1", `{"selector":{"owner":"jerry"}}`, map[string]interface{}{"limit1": int32(10)})
+	assert.EqualError(t, err, "Invalid entry, option limit1 not recognized")
+}
+
+func TestQuery(t *testing.T) {
+	env := NewTestVDBEnv(t)
+	defer env.Cleanup()
+	commontests.TestQuery(t, env.DBProvider)
+}
+
+// TestPaginatedQuery tests that the results are ordered and paged in the same way as in statecouchdb
+func TestPaginatedQuery(t *testing.T) {
+	env := NewTestVDBEnv(t)
+	defer env.Cleanup()
+	db, err := env.DBProvider.GetDBHandle("testpaginatedquery")
+	assert.NoError(t, err)
+
+	batch := statedb.NewUpdateBatch()
+	colors := []string{"blue", "red", "red", "red", "blue", "red", "blue", "red", "green", "green",
+		"cyan", "red", "red", "red", "red", "red", "red", "red", "red", "red",
+		"cyan", "red", "blue", "red", "red", "red", "green", "red", "red", "red"}
+	for i, color := range colors {
+		jsonValue := fmt.Sprintf(`{"asset_name": "marble%d","color": "%s","size": %d}`, i+1, color, i+1)
+		batch.Put("ns1", fmt.Sprintf("key%d", i+1), []byte(jsonValue), version.NewHeight(1, uint64(i+1)))
+	}
+	assert.NoError(t, db.ApplyUpdates(batch, version.NewHeight(2, 22)))
+
+	// without a sort, the results are ordered by the key
+	queryString := `{"selector":{"color":"red"}}`
+	returnKeys := []string{"key12", "key13", "key14", "key15", "key16", "key17", "key18", "key19", "key2", "key20"}
+	bookmark, err := executeQuery(t, db, "ns1", queryString, "", int32(10), returnKeys)
+	assert.NoError(t, err)
+	returnKeys = []string{"key22", "key24", "key25", "key26", "key28", "key29", "key3", "key30", "key4", "key6"}
+	bookmark, err = executeQuery(t, db, "ns1", queryString, bookmark, int32(10), returnKeys)
+	assert.NoError(t, err)
+	returnKeys = []string{"key8"}
+	bookmark, err = executeQuery(t, db, "ns1", queryString, bookmark, int32(10), returnKeys)
+	assert.NoError(t, err)
+	_, err = executeQuery(t, db, "ns1", queryString, bookmark, int32(10), []string{})
+	assert.NoError(t, err)
+
+	// with a sort
+	queryString = `{"selector":{"color":"red"}, "sort": [{"size": "desc"}]}`
+	returnKeys = []string{"key30", "key29", "key28", "key26", "key25", "key24", "key22", "key20", "key19", "key18"}
+	bookmark, err = executeQuery(t, db, "ns1", queryString, "", int32(10), returnKeys)
+	assert.NoError(t, err)
+	returnKeys = []string{"key17", "key16", "key15", "key14", "key13", "key12", "key8", "key6", "key4", "key3"}
+	bookmark, err = executeQuery(t, db, "ns1", queryString, bookmark, int32(10), returnKeys)
+	assert.NoError(t, err)
+	returnKeys = []string{"key2"}
+	_, err = executeQuery(t, db, "ns1", queryString, bookmark, int32(10), returnKeys)
+	assert.NoError(t, err)
+
+	// with $or, $in and without a limit
+	queryString = `{"selector":{"$or":[{"color":{"$in":["green","cyan"]}},{"size":{"$lte":1}}]}, "sort": ["size"]}`
+	returnKeys = []string{"key1", "key9", "key10", "key11", "key21", "key27"}
+	_, err = executeQuery(t, db, "ns1", queryString, "", int32(0), returnKeys)
+	assert.NoError(t, err)
+}
+
+func executeQuery(t *testing.T, db statedb.VersionedDB, namespace, query, bookmark string, limit int32, returnKeys []string) (string, error) {
+	var itr statedb.ResultsIterator
+	var err error
+
+	if limit == int32(0) && bookmark == "" {
+		itr, err = db.ExecuteQuery(namespace, query)
+		if err != nil {
+			return "", err
+		}
+	} else {
+		queryOptions := make(map[string]interface{})
+		if bookmark != "" {
+			queryOptions["bookmark"] = bookmark
+		}
+		if limit != 0 {
+			queryOptions["limit"] = limit
+		}
+
+		itr, err = db.ExecuteQueryWithMetadata(namespace, query, queryOptions)
+		if err != nil {
+			return "", err
+		}
+	}
+
+	// Verify the keys returned
+	commontests.TestItrWithoutClose(t, itr, returnKeys)
+
+	returnBookmark := ""
+	if queryResultItr, ok := itr.(statedb.QueryResultsIterator); ok {
+		returnBookmark = queryResultItr.GetBookmarkAndClose()
+	}
+
+	return returnBookmark, nil
 }
 
 func TestGetStateMultipleKeys(t *testing.T) {
@@ -136,3 +247,60 @@ func TestApplyUpdatesWithNilHeight(t *testing.T) {
 	defer env.Cleanup()
 	commontests.TestApplyUpdatesWithNilHeight(t, env.DBProvider)
 }
+
+func TestFullScanIterator(t *testing.T) {
+	env := NewTestVDBEnv(t)
+	defer env.Cleanup()
+	db, err := env.DBProvider.GetDBHandle("testfullscaniterator")
+	assert.NoError(t, err)
+
+	batch := statedb.NewUpdateBatch()
+	batch.Put("ns1", "key1", []byte("value1"), version.NewHeight(1, 1))
+	batch.PutValAndMetadata("ns1", "key2", []byte("value2"), []byte("metadata2"), version.NewHeight(1, 2))
+	batch.Put("ns2", "key3", []byte("value3"), version.NewHeight(1, 3))
+	batch.Put("ns3", "key4", []byte("value4"), version.NewHeight(1, 4))
+	batch.Put("ns3", "key5", []byte("value5"), version.NewHeight(1, 5))
+	assert.NoError(t, db.ApplyUpdates(batch, version.NewHeight(2, 5)))
+
+	otherDB, err := env.DBProvider.GetDBHandle("otherdb")
+	assert.NoError(t, err)
+	otherBatch := statedb.NewUpdateBatch()
+	otherBatch.Put("ns1", "other-key", []byte("other-value"), version.NewHeight(1, 1))
+	assert.NoError(t, otherDB.ApplyUpdates(otherBatch, version.NewHeight(1, 1)))
+
+	itr, err := db.(statedb.FullScanIterable).GetFullScanIterator(
+		func(ns string) bool { return ns == "ns2" },
+	)
+	assert.NoError(t, err)
+	defer itr.Close()
+	var results []*statedb.VersionedKV
+	for {
+		res, err := itr.Next()
+		assert.NoError(t, err)
+		if res == nil {
+			break
+		}
+		results = append(results, res.(*statedb.VersionedKV))
+	}
+	assert.Equal(t,
+		[]*statedb.VersionedKV{
+			{
+				CompositeKey:   statedb.CompositeKey{Namespace: "ns1", Key: "key1"},
+				VersionedValue: statedb.VersionedValue{Value: []byte("value1"), Version: version.NewHeight(1, 1)},
+			},
+			{
+				CompositeKey:   statedb.CompositeKey{Namespace: "ns1", Key: "key2"},
+				VersionedValue: statedb.VersionedValue{Value: []byte("value2"), Metadata: []byte("metadata2"), Version: version.NewHeight(1, 2)},
+			},
+			{
+				CompositeKey:   statedb.CompositeKey{Namespace: "ns3", Key: "key4"},
+				VersionedValue: statedb.VersionedValue{Value: []byte("value4"), Version: version.NewHeight(1, 4)},
+			},
+			{
+				CompositeKey:   statedb.CompositeKey{Namespace: "ns3", Key: "key5"},
+				VersionedValue: statedb.VersionedValue{Value: []byte("value5"), Version: version.NewHeight(1, 5)},
+			},
+		},
+		results,
+	)
+}
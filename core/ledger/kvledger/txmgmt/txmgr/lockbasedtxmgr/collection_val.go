@@ -23,19 +23,40 @@ func newCollNameValidator(ccInfoProvider ledger.DeployedChaincodeInfoProvider, q
 }
 
 func (v *collNameValidator) validateCollName(ns, coll string) error {
+	if v.cache.containsCollName(ns, coll) {
+		return nil
+	}
+	var collConfigNotDefinedErr error
 	if !v.cache.isPopulatedFor(ns) {
 		conf, err := v.retrieveCollConfigFromStateDB(ns)
-		if err != nil {
+		switch err.(type) {
+		case nil:
+			v.cache.populate(ns, conf)
+			if v.cache.containsCollName(ns, coll) {
+				return nil
+			}
+		case *ledger.CollConfigNotDefinedError:
+			collConfigNotDefinedErr = err
+		default:
 			return err
 		}
-		v.cache.populate(ns, conf)
 	}
-	if !v.cache.containsCollName(ns, coll) {
+	// a collection that is not present in the collection config package, such as the implicit
+	// collection of an organization, may still be provided by the ccInfoProvider
+	collConfig, err := v.ccInfoProvider.CollectionInfo(ns, coll, v.queryExecutor)
+	if err != nil {
+		return err
+	}
+	if collConfig == nil {
+		if collConfigNotDefinedErr != nil {
+			return collConfigNotDefinedErr
+		}
 		return &ledger.InvalidCollNameError{
 			Ns:   ns,
 			Coll: coll,
 		}
 	}
+	v.cache.add(ns, coll)
 	return nil
 }
 
@@ -72,6 +93,10 @@ func (c collConfigCache) populate(ns string, pkg *common.CollectionConfigPackage
 	}
 }
 
+func (c collConfigCache) add(ns, coll string) {
+	c[collConfigkey{ns, coll}] = true
+}
+
 func (c collConfigCache) isPopulatedFor(ns string) bool {
 	return c[collConfigkey{ns, ""}]
 }
@@ -10,6 +10,9 @@ import (
 
 	"github.com/hyperledger/fabric/core/ledger"
 	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/version"
+	"github.com/hyperledger/fabric/core/ledger/mock"
+	"github.com/hyperledger/fabric/protos/common"
+	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -51,6 +54,44 @@ func TestCollectionValidation(t *testing.T) {
 	assert.NoError(t, err)
 }
 
+func TestCollectionValidationCollNotInConfigPkg(t *testing.T) {
+	testEnv := testEnvsMap[levelDBtestEnvName]
+	testEnv.init(t, "testLedger", nil)
+	defer testEnv.cleanup()
+	txMgr := testEnv.getTxMgr().(*LockBasedTxMgr)
+	populateCollConfigForTest(t, txMgr,
+		[]collConfigkey{
+			{"ns1", "coll1"},
+		},
+		version.NewHeight(1, 1),
+	)
+	ccInfoProvider := txMgr.ccInfoProvider.(*mock.DeployedChaincodeInfoProvider)
+	ccInfoProvider.CollectionInfoStub = func(ccName, collName string, qe ledger.SimpleQueryExecutor) (*common.StaticCollectionConfig, error) {
+		if collName == "_implicit_org_Org1MSP" {
+			return &common.StaticCollectionConfig{Name: collName}, nil
+		}
+		return nil, nil
+	}
+
+	sim, err := txMgr.NewTxSimulator("tx-id1")
+	assert.NoError(t, err)
+
+	// the collection provided by the ccInfoProvider is valid for a namespace with or without a collection config package
+	assert.NoError(t, sim.SetPrivateData("ns1", "_implicit_org_Org1MSP", "key1", []byte("val1")))
+	assert.NoError(t, sim.SetPrivateData("ns2", "_implicit_org_Org1MSP", "key1", []byte("val1")))
+	assert.NoError(t, sim.SetPrivateData("ns1", "coll1", "key1", []byte("val1")))
+
+	err = sim.SetPrivateData("ns1", "coll2", "key1", []byte("val1"))
+	assert.IsType(t, &ledger.InvalidCollNameError{}, err)
+	err = sim.SetPrivateData("ns2", "coll1", "key1", []byte("val1"))
+	assert.IsType(t, &ledger.CollConfigNotDefinedError{}, err)
+
+	ccInfoProvider.CollectionInfoReturns(nil, errors.New("collection info error"))
+	err = sim.SetPrivateData("ns3", "_implicit_org_Org2MSP", "key1", []byte("val1"))
+	assert.EqualError(t, err, "collection info error")
+	sim.Done()
+}
+
 func TestPvtGetNoCollection(t *testing.T) {
 	testEnv := testEnvs[0]
 	testEnv.init(t, "test-pvtdata-get-no-collection", nil)
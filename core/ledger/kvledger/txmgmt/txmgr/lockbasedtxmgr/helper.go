@@ -174,6 +174,27 @@ func (h *queryHelper) getPrivateDataValueHash(ns, coll, key string) (valueHash,
 	return valHash, metadata, nil
 }
 
+func (h *queryHelper) getPrivateDataHash(ns, coll, key string) ([]byte, *kvrwset.Version, error) {
+	if err := h.validateCollName(ns, coll); err != nil {
+		return nil, nil, err
+	}
+	if err := h.checkDone(); err != nil {
+		return nil, nil, err
+	}
+	versionedValue, err := h.txmgr.db.GetValueHash(ns, coll, util.ComputeStringHash(key))
+	if err != nil {
+		return nil, nil, err
+	}
+	valHash, _, ver := decomposeVersionedValue(versionedValue)
+	if h.rwsetBuilder != nil {
+		h.rwsetBuilder.AddToHashedReadSet(ns, coll, key, ver)
+	}
+	if ver == nil {
+		return nil, nil, nil
+	}
+	return valHash, &kvrwset.Version{BlockNum: ver.BlockNum, TxNum: ver.TxNum}, nil
+}
+
 func (h *queryHelper) getPrivateDataMultipleKeys(ns, coll string, keys []string) ([][]byte, error) {
 	if err := h.validateCollName(ns, coll); err != nil {
 		return nil, err
@@ -8,6 +8,7 @@ package lockbasedtxmgr
 import (
 	commonledger "github.com/hyperledger/fabric/common/ledger"
 	"github.com/hyperledger/fabric/core/ledger"
+	"github.com/hyperledger/fabric/protos/ledger/rwset/kvrwset"
 )
 
 // LockBasedQueryExecutor is a query executor used in `LockBasedTxMgr`
@@ -80,6 +81,11 @@ func (q *lockBasedQueryExecutor) GetPrivateDataMetadataByHash(namespace, collect
 	return q.helper.getPrivateDataMetadataByHash(namespace, collection, keyhash)
 }
 
+// GetPrivateDataHash implements method in interface `ledger.QueryExecutor`
+func (q *lockBasedQueryExecutor) GetPrivateDataHash(namespace, collection, key string) ([]byte, *kvrwset.Version, error) {
+	return q.helper.getPrivateDataHash(namespace, collection, key)
+}
+
 // GetPrivateDataMultipleKeys implements method in interface `ledger.QueryExecutor`
 func (q *lockBasedQueryExecutor) GetPrivateDataMultipleKeys(namespace, collection string, keys []string) ([][]byte, error) {
 	return q.helper.getPrivateDataMultipleKeys(namespace, collection, keys)
@@ -25,6 +25,7 @@ import (
 	"github.com/hyperledger/fabric/protos/common"
 	"github.com/hyperledger/fabric/protos/ledger/rwset"
 	"github.com/hyperledger/fabric/protos/ledger/rwset/kvrwset"
+	"github.com/pkg/errors"
 )
 
 var logger = flogging.MustGetLogger("lockbasedtxmgr")
@@ -41,6 +42,7 @@ type LockBasedTxMgr struct {
 	commitRWLock    sync.RWMutex
 	oldBlockCommit  sync.Mutex
 	current         *current
+	pendingCommitDB *pendingCommitDB
 }
 
 type current struct {
@@ -72,7 +74,8 @@ func NewLockBasedTxMgr(ledgerid string, db privacyenabledstate.DB, stateListener
 		return nil, err
 	}
 	txmgr.pvtdataPurgeMgr = &pvtdataPurgeMgr{pvtstatePurgeMgr, false}
-	txmgr.validator = valimpl.NewStatebasedValidator(txmgr, db)
+	txmgr.pendingCommitDB = newPendingCommitDB(db)
+	txmgr.validator = valimpl.NewStatebasedValidator(txmgr, txmgr.pendingCommitDB)
 	return txmgr, nil
 }
 
@@ -104,22 +107,12 @@ func (txmgr *LockBasedTxMgr) NewTxSimulator(txid string) (ledger.TxSimulator, er
 func (txmgr *LockBasedTxMgr) ValidateAndPrepare(blockAndPvtdata *ledger.BlockAndPvtData, doMVCCValidation bool) (
 	[]*txmgr.TxStatInfo, error,
 ) {
-	// Among ValidateAndPrepare(), PrepareExpiringKeys(), and
-	// RemoveStaleAndCommitPvtDataOfOldBlocks(), we can allow only one
-	// function to execute at a time. The reason is that each function calls
-	// LoadCommittedVersions() which would clear the existing entries in the
-	// transient buffer and load new entries (such a transient buffer is not
-	// applicable for the golevelDB). As a result, these three functions can
-	// interleave and nullify the optimization provided by the bulk read API.
-	// Once the ledger cache (FAB-103) is introduced and existing
-	// LoadCommittedVersions() is refactored to return a map, we can allow
-	// these three functions to execute parallely.
-	logger.Debugf("Waiting for purge mgr to finish the background job of computing expirying keys for the block")
-	txmgr.pvtdataPurgeMgr.WaitForPrepareToFinish()
-	txmgr.oldBlockCommit.Lock()
-	defer txmgr.oldBlockCommit.Unlock()
-	logger.Debug("lock acquired on oldBlockCommit for validating read set version against the committed version")
-
+	// The validation does not wait for the commit of the previous block, which may still be in progress
+	// in the background (see the function StartCommit). The validator reads the state through the
+	// pendingCommitDB that serves the updates of the previous block as if they were already committed.
+	// The loading of the committed versions by this function, PrepareForExpiringKeys(), and
+	// RemoveStaleAndCommitPvtDataOfOldBlocks() can interleave, as the loaded versions are retained
+	// by the state cache until the commit of the block
 	block := blockAndPvtdata.Block
 	logger.Debugf("Validating new block with num trans = [%d]", len(block.Data.Data))
 	batch, txstatsInfo, err := txmgr.validator.ValidateAndPrepareBatch(blockAndPvtdata, doMVCCValidation)
@@ -138,7 +131,7 @@ func (txmgr *LockBasedTxMgr) ValidateAndPrepare(blockAndPvtdata *ledger.BlockAnd
 // RemoveStaleAndCommitPvtDataOfOldBlocks implements method in interface `txmgmt.TxMgr`
 // The following six operations are performed:
 // (1) contructs the unique pvt data from the passed blocksPvtData
-// (2) acquire a lock on oldBlockCommit
+// (2) acquire a lock on oldBlockCommit and wait for the purge mgr to prepare the expiring keys
 // (3) checks for stale pvtData by comparing [version, valueHash] and removes stale data
 // (4) creates update batch from the the non-stale pvtData
 // (5) update the BTL bookkeeping managed by the purge manager and update expiring keys.
@@ -156,22 +149,15 @@ func (txmgr *LockBasedTxMgr) ValidateAndPrepare(blockAndPvtdata *ledger.BlockAnd
 // function might receive pvtData of both valid and invalid tx. Such a scenario is explained
 // in FAB-12924 and is related to state fork and rebuilding ledger state.
 func (txmgr *LockBasedTxMgr) RemoveStaleAndCommitPvtDataOfOldBlocks(blocksPvtData map[uint64][]*ledger.TxPvtData) error {
-	// (0) Among ValidateAndPrepare(), PrepareExpiringKeys(), and
-	// RemoveStaleAndCommitPvtDataOfOldBlocks(), we can allow only one
-	// function to execute at a time. The reason is that each function calls
-	// LoadCommittedVersions() which would clear the existing entries in the
-	// transient buffer and load new entries (such a transient buffer is not
-	// applicable for the golevelDB). As a result, these three functions can
-	// interleave and nullify the optimization provided by the bulk read API.
-	// Once the ledger cache (FAB-103) is introduced and existing
-	// LoadCommittedVersions() is refactored to return a map, we can allow
-	// these three functions to execute parallely. However, we cannot remove
-	// the lock on oldBlockCommit as it is also used to avoid interleaving
-	// between Commit() and execution of this function for the correctness.
-	logger.Debug("Waiting for purge mgr to finish the background job of computing expirying keys for the block")
-	txmgr.pvtdataPurgeMgr.WaitForPrepareToFinish()
+	// (0) the lock on oldBlockCommit avoids interleaving between the commit of a
+	// block, which holds the lock until the updates are applied in the background,
+	// and the execution of this function for the correctness. The purge manager
+	// prepares the expiring keys for the next block once a block is committed,
+	// hence, we wait for the preparation to finish only after acquiring the lock
 	txmgr.oldBlockCommit.Lock()
 	defer txmgr.oldBlockCommit.Unlock()
+	logger.Debug("Waiting for purge mgr to finish the background job of computing expirying keys for the block")
+	txmgr.pvtdataPurgeMgr.WaitForPrepareToFinish()
 	logger.Debug("lock acquired on oldBlockCommit for committing pvtData of old blocks to state database")
 
 	// (1) as the blocksPvtData can contain multiple versions of pvtData for
@@ -373,6 +359,11 @@ func checkIfPvtWriteIsStale(hashedKey *privacyenabledstate.HashedCompositeKey,
 	if err != nil {
 		return true, err
 	}
+	// the hashedKey does not exist anymore (e.g., it was deleted or purged later), hence, the
+	// pvt write of an upsert is stale
+	if vv == nil {
+		return true, nil
+	}
 	if bytes.Equal(vv.Value, util.ComputeHash(kvWrite.Value)) {
 		// if hash of value matches, update version
 		// and return true
@@ -403,6 +394,11 @@ func (txmgr *LockBasedTxMgr) invokeNamespaceListeners() error {
 		if len(stateUpdatesForListener) == 0 {
 			continue
 		}
+		// the listeners expect the committed state to include the previous block and the function
+		// StateCommitDone to be invoked for the previous block before the updates of this block are handled
+		if err := txmgr.pendingCommitDB.wait(); err != nil {
+			return err
+		}
 		txmgr.current.listeners = append(txmgr.current.listeners, listener)
 
 		committedStateQueryExecuter := &queryutil.QECombiner{
@@ -432,6 +428,9 @@ func (txmgr *LockBasedTxMgr) invokeNamespaceListeners() error {
 
 // Shutdown implements method in interface `txmgmt.TxMgr`
 func (txmgr *LockBasedTxMgr) Shutdown() {
+	if err := txmgr.pendingCommitDB.wait(); err != nil {
+		logger.Errorf("Error while committing the last block to the state database: %s", err)
+	}
 	// wait for background go routine to finish else the timing issue causes a nil pointer inside goleveldb code
 	// see FAB-11974
 	txmgr.pvtdataPurgeMgr.WaitForPrepareToFinish()
@@ -440,6 +439,20 @@ func (txmgr *LockBasedTxMgr) Shutdown() {
 
 // Commit implements method in interface `txmgmt.TxMgr`
 func (txmgr *LockBasedTxMgr) Commit() error {
+	if err := txmgr.StartCommit(); err != nil {
+		return err
+	}
+	return txmgr.WaitForCommit()
+}
+
+// StartCommit implements method in interface `txmgmt.TxMgr`. It waits for the commit of the previous block and
+// acquires the write lock that prevents the transaction simulation, such that the simulations started after the
+// return of this function observe the updates of the block. The updates are then applied to the state database
+// in the background. This allows the validation of the next block to overlap with the commit of this block
+func (txmgr *LockBasedTxMgr) StartCommit() error {
+	if err := txmgr.pendingCommitDB.wait(); err != nil {
+		return errors.WithMessage(err, "the commit of the previous block failed")
+	}
 	// we need to acquire a lock on oldBlockCommit. The following are the two reasons:
 	// (1) the DeleteExpiredAndUpdateBookkeeping() would perform incorrect operation if
 	//        toPurgeList is updated by RemoveStaleAndCommitPvtDataOfOldBlocks().
@@ -447,37 +460,58 @@ func (txmgr *LockBasedTxMgr) Commit() error {
 	//     batch based on the current state and if we allow regular block commits at the
 	//     same time, the former may overwrite the newer versions of the data and we may
 	//     end up with an incorrect update batch.
+	// The lock is released by the background routine once the updates are applied
 	txmgr.oldBlockCommit.Lock()
-	defer txmgr.oldBlockCommit.Unlock()
 	logger.Debug("lock acquired on oldBlockCommit for committing regular updates to state database")
 
+	if txmgr.current == nil {
+		panic("validateAndPrepare() method should have been called before calling commit()")
+	}
+	current := txmgr.current
+	txmgr.reset()
+
 	// When using the purge manager for the first block commit after peer start, the asynchronous function
 	// 'PrepareForExpiringKeys' is invoked in-line. However, for the subsequent blocks commits, this function is invoked
 	// in advance for the next block
 	if !txmgr.pvtdataPurgeMgr.usedOnce {
-		txmgr.pvtdataPurgeMgr.PrepareForExpiringKeys(txmgr.current.blockNum())
+		txmgr.pvtdataPurgeMgr.PrepareForExpiringKeys(current.blockNum())
 		txmgr.pvtdataPurgeMgr.usedOnce = true
 	}
-	defer func() {
-		txmgr.pvtdataPurgeMgr.PrepareForExpiringKeys(txmgr.current.blockNum() + 1)
-		logger.Debugf("launched the background routine for preparing keys to purge with the next block")
-		txmgr.reset()
-	}()
 
 	logger.Debugf("Committing updates to state database")
-	if txmgr.current == nil {
-		panic("validateAndPrepare() method should have been called before calling commit()")
-	}
-
 	if err := txmgr.pvtdataPurgeMgr.DeleteExpiredAndUpdateBookkeeping(
-		txmgr.current.batch.PvtUpdates, txmgr.current.batch.HashUpdates); err != nil {
+		current.batch.PvtUpdates, current.batch.HashUpdates); err != nil {
+		txmgr.pvtdataPurgeMgr.PrepareForExpiringKeys(current.blockNum() + 1)
+		txmgr.oldBlockCommit.Unlock()
 		return err
 	}
 
-	commitHeight := version.NewHeight(txmgr.current.blockNum(), txmgr.current.maxTxNumber())
+	commit := txmgr.pendingCommitDB.start(current.batch)
 	txmgr.commitRWLock.Lock()
 	logger.Debugf("Write lock acquired for committing updates to state database")
-	if err := txmgr.db.ApplyPrivacyAwareUpdates(txmgr.current.batch, commitHeight); err != nil {
+	go func() {
+		err := txmgr.applyUpdates(current)
+		txmgr.pendingCommitDB.finish(commit, err)
+		txmgr.oldBlockCommit.Unlock()
+	}()
+	return nil
+}
+
+// WaitForCommit implements method in interface `txmgmt.TxMgr`
+func (txmgr *LockBasedTxMgr) WaitForCommit() error {
+	return txmgr.pendingCommitDB.wait()
+}
+
+// applyUpdates applies the updates of a block to the state database. It is invoked in the background by the
+// function StartCommit, while holding the locks acquired by that function
+func (txmgr *LockBasedTxMgr) applyUpdates(current *current) error {
+	defer func() {
+		txmgr.pvtdataPurgeMgr.PrepareForExpiringKeys(current.blockNum() + 1)
+		logger.Debugf("launched the background routine for preparing keys to purge with the next block")
+	}()
+
+	commitHeight := version.NewHeight(current.blockNum(), current.maxTxNumber())
+	if err := txmgr.db.ApplyPrivacyAwareUpdates(current.batch, commitHeight); err != nil {
 		txmgr.commitRWLock.Unlock()
 		return err
 	}
@@ -494,7 +528,7 @@ func (txmgr *LockBasedTxMgr) Commit() error {
 	}
 	// In the case of error state listeners will not recieve this call - instead a peer panic is caused by the ledger upon receiveing
 	// an error from this function
-	txmgr.updateStateListeners()
+	txmgr.updateStateListeners(current)
 	return nil
 }
 
@@ -555,8 +589,8 @@ func extractStateUpdates(batch *privacyenabledstate.UpdateBatch, namespaces []st
 	return stateupdates
 }
 
-func (txmgr *LockBasedTxMgr) updateStateListeners() {
-	for _, l := range txmgr.current.listeners {
+func (txmgr *LockBasedTxMgr) updateStateListeners(current *current) {
+	for _, l := range current.listeners {
 		l.StateCommitDone(txmgr.ledgerid)
 	}
 }
@@ -0,0 +1,181 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package lockbasedtxmgr
+
+import (
+	"sync"
+
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/privacyenabledstate"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/statedb"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/version"
+)
+
+// pendingCommitDB wraps the state db for validating a block while the updates of the previous block are
+// being applied to the state db in the background. The reads served by this wrapper return the state as if
+// the updates of the previous block were already committed. This allows the validation of a block to proceed
+// without waiting for the commit of the previous block, while producing the same results as a validation
+// that starts after the commit
+type pendingCommitDB struct {
+	privacyenabledstate.DB
+	lock    sync.RWMutex
+	updates *privacyenabledstate.UpdateBatch
+	commit  *pendingCommit
+}
+
+// pendingCommit tracks the completion of the background commit of a block
+type pendingCommit struct {
+	done chan struct{}
+	err  error
+}
+
+func newPendingCommitDB(db privacyenabledstate.DB) *pendingCommitDB {
+	return &pendingCommitDB{DB: db}
+}
+
+// start records the updates of a block whose commit is about to begin. The public updates are copied, as the state
+// db adds the private data and the hashes to the public updates while applying them
+func (p *pendingCommitDB) start(batch *privacyenabledstate.UpdateBatch) *pendingCommit {
+	pubUpdates := privacyenabledstate.NewPubUpdateBatch()
+	for _, ns := range batch.PubUpdates.GetUpdatedNamespaces() {
+		for key, vv := range batch.PubUpdates.GetUpdates(ns) {
+			pubUpdates.Update(ns, key, vv)
+		}
+	}
+	commit := &pendingCommit{done: make(chan struct{})}
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	p.updates = &privacyenabledstate.UpdateBatch{
+		PubUpdates:  pubUpdates,
+		HashUpdates: batch.HashUpdates,
+		PvtUpdates:  batch.PvtUpdates,
+	}
+	p.commit = commit
+	return commit
+}
+
+// finish records the outcome of the commit and releases the callers waiting for the commit
+func (p *pendingCommitDB) finish(commit *pendingCommit, err error) {
+	p.lock.Lock()
+	p.updates = nil
+	p.lock.Unlock()
+	commit.err = err
+	close(commit.done)
+}
+
+// wait waits for the completion of the last started commit and returns its error
+func (p *pendingCommitDB) wait() error {
+	p.lock.RLock()
+	commit := p.commit
+	p.lock.RUnlock()
+	if commit == nil {
+		return nil
+	}
+	<-commit.done
+	return commit.err
+}
+
+func (p *pendingCommitDB) pendingUpdates() *privacyenabledstate.UpdateBatch {
+	p.lock.RLock()
+	defer p.lock.RUnlock()
+	return p.updates
+}
+
+// GetState overrides the function in the interface privacyenabledstate.DB
+func (p *pendingCommitDB) GetState(namespace, key string) (*statedb.VersionedValue, error) {
+	if updates := p.pendingUpdates(); updates != nil {
+		if vv := updates.PubUpdates.Get(namespace, key); vv != nil {
+			return existingValue(vv), nil
+		}
+	}
+	return p.DB.GetState(namespace, key)
+}
+
+// GetVersion overrides the function in the interface privacyenabledstate.DB
+func (p *pendingCommitDB) GetVersion(namespace, key string) (*version.Height, error) {
+	if updates := p.pendingUpdates(); updates != nil {
+		if vv := updates.PubUpdates.Get(namespace, key); vv != nil {
+			return existingVersion(vv), nil
+		}
+	}
+	return p.DB.GetVersion(namespace, key)
+}
+
+// GetStateMetadata overrides the function in the interface privacyenabledstate.DB
+func (p *pendingCommitDB) GetStateMetadata(namespace, key string) ([]byte, error) {
+	if updates := p.pendingUpdates(); updates != nil {
+		if vv := updates.PubUpdates.Get(namespace, key); vv != nil {
+			return vv.Metadata, nil
+		}
+	}
+	return p.DB.GetStateMetadata(namespace, key)
+}
+
+// GetStateRangeScanIterator overrides the function in the interface privacyenabledstate.DB. If the block being
+// committed updates the namespace, the range scan is performed after the commit of the block
+func (p *pendingCommitDB) GetStateRangeScanIterator(namespace, startKey, endKey string) (statedb.ResultsIterator, error) {
+	if updates := p.pendingUpdates(); updates != nil && len(updates.PubUpdates.GetUpdates(namespace)) > 0 {
+		if err := p.wait(); err != nil {
+			return nil, err
+		}
+	}
+	return p.DB.GetStateRangeScanIterator(namespace, startKey, endKey)
+}
+
+// GetPrivateData overrides the function in the interface privacyenabledstate.DB
+func (p *pendingCommitDB) GetPrivateData(namespace, collection, key string) (*statedb.VersionedValue, error) {
+	if updates := p.pendingUpdates(); updates != nil {
+		if vv := updates.PvtUpdates.Get(namespace, collection, key); vv != nil {
+			return existingValue(vv), nil
+		}
+	}
+	return p.DB.GetPrivateData(namespace, collection, key)
+}
+
+// GetValueHash overrides the function in the interface privacyenabledstate.DB
+func (p *pendingCommitDB) GetValueHash(namespace, collection string, keyHash []byte) (*statedb.VersionedValue, error) {
+	if updates := p.pendingUpdates(); updates != nil {
+		if vv := updates.HashUpdates.Get(namespace, collection, string(keyHash)); vv != nil {
+			return existingValue(vv), nil
+		}
+	}
+	return p.DB.GetValueHash(namespace, collection, keyHash)
+}
+
+// GetKeyHashVersion overrides the function in the interface privacyenabledstate.DB
+func (p *pendingCommitDB) GetKeyHashVersion(namespace, collection string, keyHash []byte) (*version.Height, error) {
+	if updates := p.pendingUpdates(); updates != nil {
+		if vv := updates.HashUpdates.Get(namespace, collection, string(keyHash)); vv != nil {
+			return existingVersion(vv), nil
+		}
+	}
+	return p.DB.GetKeyHashVersion(namespace, collection, keyHash)
+}
+
+// GetPrivateDataMetadataByHash overrides the function in the interface privacyenabledstate.DB
+func (p *pendingCommitDB) GetPrivateDataMetadataByHash(namespace, collection string, keyHash []byte) ([]byte, error) {
+	if updates := p.pendingUpdates(); updates != nil {
+		if vv := updates.HashUpdates.Get(namespace, collection, string(keyHash)); vv != nil {
+			return vv.Metadata, nil
+		}
+	}
+	return p.DB.GetPrivateDataMetadataByHash(namespace, collection, keyHash)
+}
+
+// existingValue returns nil for a delete marker, as the db does for a deleted key
+func existingValue(vv *statedb.VersionedValue) *statedb.VersionedValue {
+	if vv.IsDelete() {
+		return nil
+	}
+	return vv
+}
+
+func existingVersion(vv *statedb.VersionedValue) *version.Height {
+	if vv.IsDelete() {
+		return nil
+	}
+	return vv.Version
+}
@@ -0,0 +1,174 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package lockbasedtxmgr
+
+import (
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/core/ledger"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/privacyenabledstate"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/statedb"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/version"
+	"github.com/hyperledger/fabric/core/ledger/util"
+	"github.com/hyperledger/fabric/protos/common"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPendingCommitDB(t *testing.T) {
+	testEnv := testEnvsMap[levelDBtestEnvName]
+	testEnv.init(t, "testpendingcommitdb", nil)
+	defer testEnv.cleanup()
+	db := testEnv.getVDB()
+
+	committed := privacyenabledstate.NewUpdateBatch()
+	committed.PubUpdates.Put("ns1", "key1", []byte("value1"), version.NewHeight(1, 0))
+	committed.PubUpdates.Put("ns1", "key2", []byte("value2"), version.NewHeight(1, 0))
+	committed.PubUpdates.Put("ns2", "key1", []byte("value1"), version.NewHeight(1, 0))
+	committed.HashUpdates.Put("ns1", "coll1", util.ComputeStringHash("key1"), util.ComputeStringHash("value1"), version.NewHeight(1, 0))
+	committed.PvtUpdates.Put("ns1", "coll1", "key1", []byte("value1"), version.NewHeight(1, 0))
+	require.NoError(t, db.ApplyPrivacyAwareUpdates(committed, version.NewHeight(1, 0)))
+
+	pendingDB := newPendingCommitDB(db)
+	assert.NoError(t, pendingDB.wait())
+
+	pending := privacyenabledstate.NewUpdateBatch()
+	pending.PubUpdates.PutValAndMetadata("ns1", "key1", []byte("value1_2"), []byte("metadata1_2"), version.NewHeight(2, 0))
+	pending.PubUpdates.Delete("ns1", "key2", version.NewHeight(2, 0))
+	pending.PubUpdates.Put("ns1", "key3", []byte("value3"), version.NewHeight(2, 0))
+	pending.HashUpdates.Put("ns1", "coll1", util.ComputeStringHash("key1"), util.ComputeStringHash("value1_2"), version.NewHeight(2, 0))
+	pending.PvtUpdates.Put("ns1", "coll1", "key1", []byte("value1_2"), version.NewHeight(2, 0))
+	commit := pendingDB.start(pending)
+
+	// the updates of the block being committed are served before the committed state
+	vv, err := pendingDB.GetState("ns1", "key1")
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("value1_2"), vv.Value)
+	ver, err := pendingDB.GetVersion("ns1", "key1")
+	assert.NoError(t, err)
+	assert.Equal(t, version.NewHeight(2, 0), ver)
+	metadata, err := pendingDB.GetStateMetadata("ns1", "key1")
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("metadata1_2"), metadata)
+	vv, err = pendingDB.GetState("ns1", "key2")
+	assert.NoError(t, err)
+	assert.Nil(t, vv)
+	ver, err = pendingDB.GetVersion("ns1", "key2")
+	assert.NoError(t, err)
+	assert.Nil(t, ver)
+	vv, err = pendingDB.GetPrivateData("ns1", "coll1", "key1")
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("value1_2"), vv.Value)
+	vv, err = pendingDB.GetValueHash("ns1", "coll1", util.ComputeStringHash("key1"))
+	assert.NoError(t, err)
+	assert.Equal(t, util.ComputeStringHash("value1_2"), vv.Value)
+	ver, err = pendingDB.GetKeyHashVersion("ns1", "coll1", util.ComputeStringHash("key1"))
+	assert.NoError(t, err)
+	assert.Equal(t, version.NewHeight(2, 0), ver)
+	vv, err = pendingDB.GetState("ns2", "key1")
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("value1"), vv.Value)
+
+	// the range scan on a namespace updated by the block being committed waits for the commit
+	itrReturned := make(chan statedb.ResultsIterator, 1)
+	go func() {
+		itr, err := pendingDB.GetStateRangeScanIterator("ns1", "", "")
+		assert.NoError(t, err)
+		itrReturned <- itr
+	}()
+	itr, err := pendingDB.GetStateRangeScanIterator("ns2", "", "")
+	assert.NoError(t, err)
+	itr.Close()
+	select {
+	case <-itrReturned:
+		t.Fatal("the range scan is expected to wait for the commit")
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	require.NoError(t, db.ApplyPrivacyAwareUpdates(pending, version.NewHeight(2, 0)))
+	pendingDB.finish(commit, nil)
+	assert.NoError(t, pendingDB.wait())
+	itr = <-itrReturned
+	defer itr.Close()
+	var keys []string
+	for {
+		res, err := itr.Next()
+		require.NoError(t, err)
+		if res == nil {
+			break
+		}
+		keys = append(keys, res.(*statedb.VersionedKV).Key)
+	}
+	assert.Equal(t, []string{"key1", "key3"}, keys)
+	assert.Nil(t, pendingDB.pendingUpdates())
+
+	// the error of the commit is returned to the callers waiting for the commit
+	commit = pendingDB.start(privacyenabledstate.NewUpdateBatch())
+	pendingDB.finish(commit, errors.New("commit failed"))
+	assert.EqualError(t, pendingDB.wait(), "commit failed")
+}
+
+func TestValidationDuringPendingCommit(t *testing.T) {
+	for _, testEnv := range testEnvs {
+		t.Logf("Running test for TestEnv = %s", testEnv.getName())
+		testEnv.init(t, "testvalidationduringpendingcommit", nil)
+		testValidationDuringPendingCommit(t, testEnv)
+		testEnv.cleanup()
+	}
+}
+
+func testValidationDuringPendingCommit(t *testing.T, env testEnv) {
+	txMgr := env.getTxMgr()
+	txMgrHelper := newTxMgrTestHelper(t, txMgr)
+	s1, _ := txMgr.NewTxSimulator("test_tx1")
+	s1.SetState("ns1", "key1", []byte("value1"))
+	s1.Done()
+	txRWSet1, _ := s1.GetTxSimulationResults()
+	txMgrHelper.validateAndCommitRWSet(txRWSet1.PubSimulationResults)
+
+	// tx2 and tx3 both read ns1:key1 before tx2 is committed
+	s2, _ := txMgr.NewTxSimulator("test_tx2")
+	s2.GetState("ns1", "key1")
+	s2.SetState("ns1", "key1", []byte("value1_2"))
+	s2.Done()
+	s3, _ := txMgr.NewTxSimulator("test_tx3")
+	s3.GetState("ns1", "key1")
+	s3.SetState("ns1", "key2", []byte("value2"))
+	s3.Done()
+	txRWSet2, _ := s2.GetTxSimulationResults()
+	txRWSet3, _ := s3.GetTxSimulationResults()
+
+	validate := func(txRWSet *ledger.TxSimulationResults) util.TxValidationFlags {
+		rwSetBytes, err := proto.Marshal(txRWSet.PubSimulationResults)
+		require.NoError(t, err)
+		block := txMgrHelper.bg.NextBlock([][]byte{rwSetBytes})
+		_, err = txMgr.ValidateAndPrepare(&ledger.BlockAndPvtData{Block: block}, true)
+		require.NoError(t, err)
+		return util.TxValidationFlags(block.Metadata.Metadata[common.BlockMetadataIndex_TRANSACTIONS_FILTER])
+	}
+
+	// the block of tx3 is validated without waiting for the commit of the block of tx2, and
+	// tx3 is invalidated by the pending update of ns1:key1
+	assert.True(t, validate(txRWSet2).IsValid(0))
+	require.NoError(t, txMgr.StartCommit())
+	assert.True(t, validate(txRWSet3).IsInvalid(0))
+	require.NoError(t, txMgr.StartCommit())
+	require.NoError(t, txMgr.WaitForCommit())
+
+	qe, err := txMgr.NewQueryExecutor("test_tx4")
+	require.NoError(t, err)
+	defer qe.Done()
+	value, err := qe.GetState("ns1", "key1")
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("value1_2"), value)
+	value, err = qe.GetState("ns1", "key2")
+	assert.NoError(t, err)
+	assert.Nil(t, value)
+}
@@ -14,6 +14,7 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/golang/protobuf/proto"
 	"github.com/hyperledger/fabric/common/flogging"
 	"github.com/hyperledger/fabric/common/ledger/testutil"
 	"github.com/hyperledger/fabric/core/ledger"
@@ -25,6 +26,7 @@ import (
 	ledgertestutil "github.com/hyperledger/fabric/core/ledger/testutil"
 	"github.com/hyperledger/fabric/core/ledger/util"
 	"github.com/hyperledger/fabric/protos/ledger/queryresult"
+	"github.com/hyperledger/fabric/protos/ledger/rwset/kvrwset"
 	"github.com/spf13/viper"
 	"github.com/stretchr/testify/assert"
 )
@@ -659,18 +661,14 @@ func createTestValue(i int) []byte {
 	return []byte(fmt.Sprintf("value_%03d", i))
 }
 
-//TestExecuteQueryQuery is only tested on the CouchDB testEnv
 func TestExecuteQuery(t *testing.T) {
 
 	for _, testEnv := range testEnvs {
-		// Query is only supported and tested on the CouchDB testEnv
-		if testEnv.getName() == couchDBtestEnvName {
-			t.Logf("Running test for TestEnv = %s", testEnv.getName())
-			testLedgerID := "testexecutequery"
-			testEnv.init(t, testLedgerID, nil)
-			testExecuteQuery(t, testEnv)
-			testEnv.cleanup()
-		}
+		t.Logf("Running test for TestEnv = %s", testEnv.getName())
+		testLedgerID := "testexecutequery"
+		testEnv.init(t, testLedgerID, nil)
+		testExecuteQuery(t, testEnv)
+		testEnv.cleanup()
 	}
 }
 
@@ -734,18 +732,14 @@ func testExecuteQuery(t *testing.T, env testEnv) {
 	assert.Equal(t, 3, counter)
 }
 
-// TestExecutePaginatedQuery is only tested on the CouchDB testEnv
 func TestExecutePaginatedQuery(t *testing.T) {
 
 	for _, testEnv := range testEnvs {
-		// Query is only supported and tested on the CouchDB testEnv
-		if testEnv.getName() == couchDBtestEnvName {
-			t.Logf("Running test for TestEnv = %s", testEnv.getName())
-			testLedgerID := "testexecutepaginatedquery"
-			testEnv.init(t, testLedgerID, nil)
-			testExecutePaginatedQuery(t, testEnv)
-			testEnv.cleanup()
-		}
+		t.Logf("Running test for TestEnv = %s", testEnv.getName())
+		testLedgerID := "testexecutepaginatedquery"
+		testEnv.init(t, testLedgerID, nil)
+		testExecutePaginatedQuery(t, testEnv)
+		testEnv.cleanup()
 	}
 }
 
@@ -1188,6 +1182,59 @@ func testValidationAndCommitOfOldPvtData(t *testing.T, env testEnv) {
 	assert.Equal(t, v4, vv.Value)
 }
 
+func TestTxSimulatorPurgePrivateData(t *testing.T) {
+	testEnv := testEnvs[0]
+	testEnv.init(t, "TestTxSimulatorPurgePrivateData", nil)
+	defer testEnv.cleanup()
+
+	txMgr := testEnv.getTxMgr()
+	populateCollConfigForTest(t, txMgr.(*LockBasedTxMgr),
+		[]collConfigkey{
+			{"ns1", "coll1"},
+		},
+		version.NewHeight(1, 1),
+	)
+
+	db := testEnv.getVDB()
+	updateBatch := privacyenabledstate.NewUpdateBatch()
+	updateBatch.HashUpdates.Put("ns1", "coll1", util.ComputeStringHash("key1"), util.ComputeStringHash("value1"), version.NewHeight(1, 1))
+	updateBatch.PvtUpdates.Put("ns1", "coll1", "key1", []byte("value1"), version.NewHeight(1, 1))
+	db.ApplyPrivacyAwareUpdates(updateBatch, version.NewHeight(1, 1))
+
+	sim, err := txMgr.NewTxSimulator("txid1")
+	assert.NoError(t, err)
+	assert.IsType(t, &ledger.InvalidCollNameError{}, sim.PurgePrivateData("ns1", "coll2", "key1"))
+	assert.NoError(t, sim.PurgePrivateData("ns1", "coll1", "key1"))
+	sim.Done()
+	simRes, err := sim.GetTxSimulationResults()
+	assert.NoError(t, err)
+
+	// the purge is recorded as a delete in the pvt write set and as a purge in the hashed write set
+	pvtKVRWSet := &kvrwset.KVRWSet{}
+	assert.NoError(t, proto.Unmarshal(simRes.PvtSimulationResults.NsPvtRwset[0].CollectionPvtRwset[0].Rwset, pvtKVRWSet))
+	assert.Equal(t, []*kvrwset.KVWrite{{Key: "key1", IsDelete: true}}, pvtKVRWSet.Writes)
+	txRWSet, err := rwsetutil.TxRwSetFromProtoMsg(simRes.PubSimulationResults)
+	assert.NoError(t, err)
+	assert.Equal(t,
+		[]*kvrwset.KVWriteHash{{KeyHash: util.ComputeStringHash("key1"), IsDelete: true, IsPurge: true}},
+		txRWSet.NsRwSets[0].CollHashedRwSets[0].HashedRwSet.HashedWrites,
+	)
+
+	// once the purge is committed, the pvt data of the key from an older block is stale
+	updateBatch = privacyenabledstate.NewUpdateBatch()
+	updateBatch.HashUpdates.Delete("ns1", "coll1", util.ComputeStringHash("key1"), version.NewHeight(2, 1))
+	updateBatch.PvtUpdates.Delete("ns1", "coll1", "key1", version.NewHeight(2, 1))
+	db.ApplyPrivacyAwareUpdates(updateBatch, version.NewHeight(2, 1))
+
+	blocksPvtData := map[uint64][]*ledger.TxPvtData{
+		1: {producePvtdata(t, 1, []string{"ns1:coll1"}, []string{"key1"}, [][]byte{[]byte("value1")})},
+	}
+	assert.NoError(t, txMgr.RemoveStaleAndCommitPvtDataOfOldBlocks(blocksPvtData))
+	vv, err := db.GetPrivateData("ns1", "coll1", "key1")
+	assert.NoError(t, err)
+	assert.Nil(t, vv)
+}
+
 func TestTxSimulatorMissingPvtdata(t *testing.T) {
 	testEnv := testEnvs[0]
 	testEnv.init(t, "TestTxSimulatorUnsupportedTxQueries", nil)
@@ -1228,6 +1275,62 @@ func TestTxSimulatorMissingPvtdata(t *testing.T) {
 	assert.True(t, testPvtValueEqual(t, txMgr, "ns1", "coll4", "key4", nil))
 }
 
+func TestGetPrivateDataHash(t *testing.T) {
+	testEnv := testEnvs[0]
+	testEnv.init(t, "TestGetPrivateDataHash", nil)
+	defer testEnv.cleanup()
+
+	txMgr := testEnv.getTxMgr()
+	populateCollConfigForTest(t, txMgr.(*LockBasedTxMgr),
+		[]collConfigkey{
+			{"ns1", "coll1"},
+			{"ns1", "coll2"},
+		},
+		version.NewHeight(1, 1),
+	)
+
+	// only the hash is present for key2, as the peer is not a member of coll2
+	db := testEnv.getVDB()
+	updateBatch := privacyenabledstate.NewUpdateBatch()
+	updateBatch.HashUpdates.Put("ns1", "coll1", util.ComputeStringHash("key1"), util.ComputeStringHash("value1"), version.NewHeight(1, 1))
+	updateBatch.PvtUpdates.Put("ns1", "coll1", "key1", []byte("value1"), version.NewHeight(1, 1))
+	updateBatch.HashUpdates.Put("ns1", "coll2", util.ComputeStringHash("key2"), util.ComputeStringHash("value2"), version.NewHeight(2, 3))
+	db.ApplyPrivacyAwareUpdates(updateBatch, version.NewHeight(2, 3))
+
+	qe, err := txMgr.NewQueryExecutor("test_tx1")
+	assert.NoError(t, err)
+	valueHash, ver, err := qe.GetPrivateDataHash("ns1", "coll1", "key1")
+	assert.NoError(t, err)
+	assert.Equal(t, util.ComputeStringHash("value1"), valueHash)
+	assert.Equal(t, &kvrwset.Version{BlockNum: 1, TxNum: 1}, ver)
+	valueHash, ver, err = qe.GetPrivateDataHash("ns1", "coll2", "key2")
+	assert.NoError(t, err)
+	assert.Equal(t, util.ComputeStringHash("value2"), valueHash)
+	assert.Equal(t, &kvrwset.Version{BlockNum: 2, TxNum: 3}, ver)
+	valueHash, ver, err = qe.GetPrivateDataHash("ns1", "coll2", "non-existing-key")
+	assert.NoError(t, err)
+	assert.Nil(t, valueHash)
+	assert.Nil(t, ver)
+	_, _, err = qe.GetPrivateDataHash("ns1", "non-existing-coll", "key1")
+	_, ok := err.(*ledger.InvalidCollNameError)
+	assert.True(t, ok)
+	qe.Done()
+
+	// the simulator records the read of the hashed key
+	sim, err := txMgr.NewTxSimulator("test_tx2")
+	assert.NoError(t, err)
+	_, _, err = sim.GetPrivateDataHash("ns1", "coll2", "key2")
+	assert.NoError(t, err)
+	sim.Done()
+	simRes, err := sim.GetTxSimulationResults()
+	assert.NoError(t, err)
+	txRWSet, err := rwsetutil.TxRwSetFromProtoMsg(simRes.PubSimulationResults)
+	assert.NoError(t, err)
+	assert.Equal(t, []*kvrwset.KVReadHash{
+		{KeyHash: util.ComputeStringHash("key2"), Version: &kvrwset.Version{BlockNum: 2, TxNum: 3}},
+	}, txRWSet.NsRwSets[0].CollHashedRwSets[0].HashedRwSet.HashedReads)
+}
+
 func TestRemoveStaleAndCommitPvtDataOfOldBlocksWithExpiry(t *testing.T) {
 	ledgerid := "TestTxSimulatorMissingPvtdataExpiry"
 	btlPolicy := btltestutil.SampleBTLPolicy(
@@ -33,6 +33,12 @@ type TxMgr interface {
 	ShouldRecover(lastAvailableBlock uint64) (bool, uint64, error)
 	CommitLostBlock(blockAndPvtdata *ledger.BlockAndPvtData) error
 	Commit() error
+	// StartCommit starts the commit of the block passed to the last call of ValidateAndPrepare. The simulators and
+	// query executors created after the function returns observe the state of the block, while the updates may still
+	// be applied in the background. The next block can be validated before the commit finishes
+	StartCommit() error
+	// WaitForCommit waits for the commit started by the last call of StartCommit and returns its error
+	WaitForCommit() error
 	Rollback()
 	Shutdown()
 }
@@ -6,12 +6,15 @@ SPDX-License-Identifier: Apache-2.0
 package statebasedval
 
 import (
+	"sync"
+
 	"github.com/hyperledger/fabric/common/flogging"
 	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/privacyenabledstate"
 	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/rwsetutil"
 	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/statedb"
 	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/validator/internal"
 	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/version"
+	"github.com/hyperledger/fabric/core/ledger/ledgerconfig"
 	"github.com/hyperledger/fabric/protos/ledger/rwset/kvrwset"
 	"github.com/hyperledger/fabric/protos/peer"
 )
@@ -19,14 +22,19 @@ import (
 var logger = flogging.MustGetLogger("statebasedval")
 
 // Validator validates a tx against the latest committed state
-// and preceding valid transactions with in the same block
+// and preceding valid transactions with in the same block.
+// If parallelism is more than one, the transactions are first validated concurrently against the
+// committed state and only the transactions that depend on a preceding valid transaction of the
+// same block are validated again, in the order of the transactions. This produces the same
+// results as validating all the transactions one after another
 type Validator struct {
-	db privacyenabledstate.DB
+	db          privacyenabledstate.DB
+	parallelism int
 }
 
 // NewValidator constructs StateValidator
 func NewValidator(db privacyenabledstate.DB) *Validator {
-	return &Validator{db}
+	return &Validator{db, ledgerconfig.GetMVCCValidationParallelism()}
 }
 
 // preLoadCommittedVersionOfRSet loads committed version of all keys in each
@@ -97,17 +105,33 @@ func (v *Validator) ValidateAndPrepareBatch(block *internal.Block, doMVCCValidat
 		}
 	}
 
+	var committedStateValidationCodes []peer.TxValidationCode
+	var deps txDependencies
+	if doMVCCValidation && v.parallelism > 1 && len(block.Txs) > 1 {
+		deps = computeTxDependencies(block.Txs)
+		var err error
+		if committedStateValidationCodes, err = v.validateTxsAgainstCommittedState(block.Txs); err != nil {
+			return nil, err
+		}
+	}
+
 	updates := internal.NewPubAndHashUpdates()
-	for _, tx := range block.Txs {
+	valid := make([]bool, len(block.Txs))
+	for i, tx := range block.Txs {
 		var validationCode peer.TxValidationCode
 		var err error
-		if validationCode, err = v.validateEndorserTX(tx.RWSet, doMVCCValidation, updates); err != nil {
+		if committedStateValidationCodes != nil && !deps.dependsOnValidTx(i, valid) {
+			// none of the preceding valid transactions writes a key that this transaction reads and hence,
+			// the outcome of the validation against the committed state holds
+			validationCode = committedStateValidationCodes[i]
+		} else if validationCode, err = v.validateEndorserTX(tx.RWSet, doMVCCValidation, updates); err != nil {
 			return nil, err
 		}
 
 		tx.ValidationCode = validationCode
 		if validationCode == peer.TxValidationCode_VALID {
 			logger.Debugf("Block [%d] Transaction index [%d] TxId [%s] marked as valid by state validator", block.Num, tx.IndexInBlock, tx.ID)
+			valid[i] = true
 			committingTxHeight := version.NewHeight(block.Num, uint64(tx.IndexInBlock))
 			updates.ApplyWriteSet(tx.RWSet, committingTxHeight, v.db)
 		} else {
@@ -118,6 +142,43 @@ func (v *Validator) ValidateAndPrepareBatch(block *internal.Block, doMVCCValidat
 	return updates, nil
 }
 
+// validateTxsAgainstCommittedState validates the given transactions concurrently against the committed state only,
+// i.e., ignoring the writes of the preceding transactions in the same block. If the validation of more than one
+// transaction fails with an error, the error of the transaction that appears first in the block is returned
+func (v *Validator) validateTxsAgainstCommittedState(txs []*internal.Transaction) ([]peer.TxValidationCode, error) {
+	validationCodes := make([]peer.TxValidationCode, len(txs))
+	errs := make([]error, len(txs))
+	noUpdates := internal.NewPubAndHashUpdates()
+	txIndexes := make(chan int, len(txs))
+	for i := range txs {
+		txIndexes <- i
+	}
+	close(txIndexes)
+
+	numWorkers := v.parallelism
+	if numWorkers > len(txs) {
+		numWorkers = len(txs)
+	}
+	var wg sync.WaitGroup
+	wg.Add(numWorkers)
+	for w := 0; w < numWorkers; w++ {
+		go func() {
+			defer wg.Done()
+			for i := range txIndexes {
+				validationCodes[i], errs[i] = v.validateTx(txs[i].RWSet, noUpdates)
+			}
+		}()
+	}
+	wg.Wait()
+
+	for _, err := range errs {
+		if err != nil {
+			return nil, err
+		}
+	}
+	return validationCodes, nil
+}
+
 // validateEndorserTX validates endorser transaction
 func (v *Validator) validateEndorserTX(
 	txRWSet *rwsetutil.TxRwSet,
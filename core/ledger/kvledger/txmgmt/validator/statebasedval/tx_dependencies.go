@@ -0,0 +1,117 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package statebasedval
+
+import (
+	"sort"
+
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/privacyenabledstate"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/statedb"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/validator/internal"
+)
+
+// txDependencies is the dependency graph of the transactions of a block. For each transaction, it contains
+// the indexes (in the slice of the transactions of the block) of the preceding transactions that write a key
+// that the transaction reads, or a key that falls in the range of a range query of the transaction. Only the
+// writes of a preceding transaction that turns out to be valid can change the outcome of the mvcc validation
+// of a transaction and hence, a transaction that does not depend on a valid transaction can be validated
+// against the committed state independently of the other transactions of the block
+type txDependencies [][]int
+
+type keyWriter struct {
+	key     string
+	txIndex int
+}
+
+// computeTxDependencies builds the dependency graph of the given transactions from their read-write sets
+func computeTxDependencies(txs []*internal.Transaction) txDependencies {
+	pubWriters := make(map[statedb.CompositeKey][]int)
+	hashedWriters := make(map[privacyenabledstate.HashedCompositeKey][]int)
+	nsWriters := make(map[string][]*keyWriter)
+
+	deps := make(txDependencies, len(txs))
+	for i, tx := range txs {
+		depsOfTx := make(map[int]struct{})
+		for _, nsRWSet := range tx.RWSet.NsRwSets {
+			ns := nsRWSet.NameSpace
+			for _, kvRead := range nsRWSet.KvRwSet.Reads {
+				for _, writer := range pubWriters[statedb.CompositeKey{Namespace: ns, Key: kvRead.Key}] {
+					depsOfTx[writer] = struct{}{}
+				}
+			}
+			for _, rqi := range nsRWSet.KvRwSet.RangeQueriesInfo {
+				for _, writer := range nsWriters[ns] {
+					// the end key is treated as inclusive, as it is included in the validation if the iterator
+					// was not exhausted during the simulation. An empty end key indicates an unbounded range
+					if writer.key >= rqi.StartKey && (rqi.EndKey == "" || writer.key <= rqi.EndKey) {
+						depsOfTx[writer.txIndex] = struct{}{}
+					}
+				}
+			}
+			for _, collHashedRWSet := range nsRWSet.CollHashedRwSets {
+				for _, kvReadHash := range collHashedRWSet.HashedRwSet.HashedReads {
+					hashedKey := privacyenabledstate.HashedCompositeKey{
+						Namespace:      ns,
+						CollectionName: collHashedRWSet.CollectionName,
+						KeyHash:        string(kvReadHash.KeyHash),
+					}
+					for _, writer := range hashedWriters[hashedKey] {
+						depsOfTx[writer] = struct{}{}
+					}
+				}
+			}
+		}
+		for dep := range depsOfTx {
+			deps[i] = append(deps[i], dep)
+		}
+		sort.Ints(deps[i])
+
+		for _, nsRWSet := range tx.RWSet.NsRwSets {
+			ns := nsRWSet.NameSpace
+			var writtenKeys []string
+			for _, kvWrite := range nsRWSet.KvRwSet.Writes {
+				writtenKeys = append(writtenKeys, kvWrite.Key)
+			}
+			for _, kvMetadataWrite := range nsRWSet.KvRwSet.MetadataWrites {
+				writtenKeys = append(writtenKeys, kvMetadataWrite.Key)
+			}
+			for _, key := range writtenKeys {
+				compositeKey := statedb.CompositeKey{Namespace: ns, Key: key}
+				pubWriters[compositeKey] = append(pubWriters[compositeKey], i)
+				nsWriters[ns] = append(nsWriters[ns], &keyWriter{key, i})
+			}
+			for _, collHashedRWSet := range nsRWSet.CollHashedRwSets {
+				var writtenKeyHashes [][]byte
+				for _, kvWriteHash := range collHashedRWSet.HashedRwSet.HashedWrites {
+					writtenKeyHashes = append(writtenKeyHashes, kvWriteHash.KeyHash)
+				}
+				for _, kvMetadataWriteHash := range collHashedRWSet.HashedRwSet.MetadataWrites {
+					writtenKeyHashes = append(writtenKeyHashes, kvMetadataWriteHash.KeyHash)
+				}
+				for _, keyHash := range writtenKeyHashes {
+					hashedKey := privacyenabledstate.HashedCompositeKey{
+						Namespace:      ns,
+						CollectionName: collHashedRWSet.CollectionName,
+						KeyHash:        string(keyHash),
+					}
+					hashedWriters[hashedKey] = append(hashedWriters[hashedKey], i)
+				}
+			}
+		}
+	}
+	return deps
+}
+
+// dependsOnValidTx returns true if the transaction at the given index depends on a transaction
+// that is marked as valid in the given slice
+func (deps txDependencies) dependsOnValidTx(txIndex int, valid []bool) bool {
+	for _, dep := range deps[txIndex] {
+		if valid[dep] {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,172 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package statebasedval
+
+import (
+	"fmt"
+	"math/rand"
+	"sort"
+	"testing"
+
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/privacyenabledstate"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/rwsetutil"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/validator/internal"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/version"
+	"github.com/hyperledger/fabric/core/ledger/util"
+	"github.com/hyperledger/fabric/protos/ledger/rwset/kvrwset"
+	"github.com/hyperledger/fabric/protos/peer"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestComputeTxDependencies(t *testing.T) {
+	// tx0 writes key1 and a private key
+	rwsetBuilder0 := rwsetutil.NewRWSetBuilder()
+	rwsetBuilder0.AddToWriteSet("ns1", "key1", []byte("value1"))
+	rwsetBuilder0.AddToPvtAndHashedWriteSet("ns1", "coll1", "pvtkey1", []byte("pvtvalue1"))
+
+	// tx1 reads key2 and updates the metadata of key5
+	rwsetBuilder1 := rwsetutil.NewRWSetBuilder()
+	rwsetBuilder1.AddToReadSet("ns1", "key2", version.NewHeight(1, 1))
+	rwsetBuilder1.AddToMetadataWriteSet("ns1", "key5", map[string][]byte{"metadata1": []byte("value")})
+
+	// tx2 reads key1 and the private key, depends on tx0
+	rwsetBuilder2 := rwsetutil.NewRWSetBuilder()
+	rwsetBuilder2.AddToReadSet("ns1", "key1", version.NewHeight(1, 0))
+	rwsetBuilder2.AddToHashedReadSet("ns1", "coll1", "pvtkey1", version.NewHeight(1, 0))
+
+	// tx3 performs a range query over [key3, key6] that includes key5, depends on tx1
+	rwsetBuilder3 := rwsetutil.NewRWSetBuilder()
+	rwsetBuilder3.AddToRangeQuerySet("ns1", &kvrwset.RangeQueryInfo{StartKey: "key3", EndKey: "key6", ItrExhausted: true})
+
+	// tx4 reads the same keys as tx2 but in a different namespace and collection, depends on none
+	rwsetBuilder4 := rwsetutil.NewRWSetBuilder()
+	rwsetBuilder4.AddToReadSet("ns2", "key1", nil)
+	rwsetBuilder4.AddToHashedReadSet("ns1", "coll2", "pvtkey1", nil)
+	rwsetBuilder4.AddToRangeQuerySet("ns1", &kvrwset.RangeQueryInfo{StartKey: "key6", EndKey: "key9", ItrExhausted: true})
+
+	// tx5 performs an unbounded range query, depends on tx0 and tx1
+	rwsetBuilder5 := rwsetutil.NewRWSetBuilder()
+	rwsetBuilder5.AddToRangeQuerySet("ns1", &kvrwset.RangeQueryInfo{StartKey: "key", EndKey: "", ItrExhausted: true})
+
+	txs := newTestTxs(getTestPubSimulationRWSet(t,
+		rwsetBuilder0, rwsetBuilder1, rwsetBuilder2, rwsetBuilder3, rwsetBuilder4, rwsetBuilder5))
+	deps := computeTxDependencies(txs)
+	assert.Equal(t, txDependencies{nil, nil, {0}, {1}, nil, {0, 1}}, deps)
+
+	assert.False(t, deps.dependsOnValidTx(5, []bool{false, false, true, true, true, true}))
+	assert.True(t, deps.dependsOnValidTx(5, []bool{false, true, false, false, false, false}))
+	assert.False(t, deps.dependsOnValidTx(4, []bool{true, true, true, true, true, true}))
+}
+
+func TestParallelValidationMatchesSequentialValidation(t *testing.T) {
+	testDBEnv := privacyenabledstate.LevelDBCommonStorageTestEnv{}
+	testDBEnv.Init(t)
+	defer testDBEnv.Cleanup()
+	db := testDBEnv.GetDBHandle("TestDB")
+
+	// populate db with initial data
+	committedVersions := map[string]*version.Height{}
+	batch := privacyenabledstate.NewUpdateBatch()
+	for i := 0; i < 8; i++ {
+		key := fmt.Sprintf("key%d", i)
+		committedVersions[key] = version.NewHeight(1, uint64(i))
+		batch.PubUpdates.Put("ns1", key, []byte("value"), committedVersions[key])
+	}
+	for i := 0; i < 3; i++ {
+		key := fmt.Sprintf("pvtkey%d", i)
+		committedVersions[key] = version.NewHeight(1, uint64(10+i))
+		batch.HashUpdates.Put("ns1", "coll1", util.ComputeStringHash(key), []byte("value"), committedVersions[key])
+	}
+	db.ApplyPrivacyAwareUpdates(batch, version.NewHeight(1, 12))
+
+	sequentialValidator := &Validator{db, 1}
+	parallelValidator := &Validator{db, 4}
+	rand := rand.New(rand.NewSource(1))
+	for blockNum := uint64(2); blockNum < 22; blockNum++ {
+		txRWSets := getTestPubSimulationRWSet(t, newRandomTestRWSetBuilders(rand, committedVersions, 30)...)
+
+		sequentialBlock := &internal.Block{Num: blockNum, Txs: newTestTxs(txRWSets)}
+		sequentialUpdates, err := sequentialValidator.ValidateAndPrepareBatch(sequentialBlock, true)
+		assert.NoError(t, err)
+		parallelBlock := &internal.Block{Num: blockNum, Txs: newTestTxs(txRWSets)}
+		parallelUpdates, err := parallelValidator.ValidateAndPrepareBatch(parallelBlock, true)
+		assert.NoError(t, err)
+
+		numValid := 0
+		for i := range sequentialBlock.Txs {
+			assert.Equal(t, sequentialBlock.Txs[i].ValidationCode, parallelBlock.Txs[i].ValidationCode)
+			if sequentialBlock.Txs[i].ValidationCode == peer.TxValidationCode_VALID {
+				numValid++
+			}
+		}
+		// the generated transactions are expected to produce a mix of valid and invalid transactions
+		assert.True(t, numValid > 0 && numValid < len(sequentialBlock.Txs))
+		assert.Equal(t, sequentialUpdates, parallelUpdates)
+	}
+}
+
+func newTestTxs(txRWSets []*rwsetutil.TxRwSet) []*internal.Transaction {
+	var txs []*internal.Transaction
+	for i, txRWSet := range txRWSets {
+		txs = append(txs, &internal.Transaction{
+			ID:             fmt.Sprintf("txid-%d", i),
+			IndexInBlock:   i,
+			ValidationCode: peer.TxValidationCode_VALID,
+			RWSet:          txRWSet,
+		})
+	}
+	return txs
+}
+
+// newRandomTestRWSetBuilders generates transactions that read the committed versions of the keys most of the time
+// and write to a small set of keys so that many of the transactions depend on each other
+func newRandomTestRWSetBuilders(rand *rand.Rand, committedVersions map[string]*version.Height, numTxs int) []*rwsetutil.RWSetBuilder {
+	readVersion := func(key string) *version.Height {
+		if rand.Intn(10) == 0 {
+			return version.NewHeight(0, 1)
+		}
+		return committedVersions[key]
+	}
+	var builders []*rwsetutil.RWSetBuilder
+	for i := 0; i < numTxs; i++ {
+		b := rwsetutil.NewRWSetBuilder()
+		for j := rand.Intn(3); j > 0; j-- {
+			key := fmt.Sprintf("key%d", rand.Intn(10))
+			b.AddToReadSet("ns1", key, readVersion(key))
+		}
+		if rand.Intn(4) == 0 {
+			startKey, endKey := fmt.Sprintf("key%d", rand.Intn(5)), fmt.Sprintf("key%d", 5+rand.Intn(5))
+			var keys []string
+			for key := range committedVersions {
+				if key >= startKey && key < endKey {
+					keys = append(keys, key)
+				}
+			}
+			sort.Strings(keys)
+			var kvReads []*kvrwset.KVRead
+			for _, key := range keys {
+				kvReads = append(kvReads, rwsetutil.NewKVRead(key, readVersion(key)))
+			}
+			rqi := &kvrwset.RangeQueryInfo{StartKey: startKey, EndKey: endKey, ItrExhausted: true}
+			rqi.SetRawReads(kvReads)
+			b.AddToRangeQuerySet("ns1", rqi)
+		}
+		if rand.Intn(3) == 0 {
+			key := fmt.Sprintf("pvtkey%d", rand.Intn(4))
+			b.AddToHashedReadSet("ns1", "coll1", key, readVersion(key))
+		}
+		switch rand.Intn(4) {
+		case 0:
+			b.AddToWriteSet("ns1", fmt.Sprintf("key%d", rand.Intn(10)), nil)
+		case 1:
+			b.AddToPvtAndHashedWriteSet("ns1", "coll1", fmt.Sprintf("pvtkey%d", rand.Intn(4)), []byte("newvalue"))
+		default:
+			b.AddToWriteSet("ns1", fmt.Sprintf("key%d", rand.Intn(10)), []byte("newvalue"))
+		}
+		builders = append(builders, b)
+	}
+	return builders
+}
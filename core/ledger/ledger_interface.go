@@ -33,6 +33,11 @@ type PeerLedgerProvider interface {
 	// This function guarantees that the creation of ledger and committing the genesis block would an atomic action
 	// The chain id retrieved from the genesis block is treated as a ledger id
 	Create(genesisBlock *common.Block) (PeerLedger, error)
+	// CreateFromSnapshot creates a new ledger from a snapshot generated by the function `PeerLedger.GenerateSnapshot`.
+	// The contents of the snapshot are verified against the hashes present in the metadata of the snapshot.
+	// The ledger created from a snapshot does not contain the blocks before the last block of the snapshot.
+	// This function returns the created ledger and the ledger id, which is the channel name recorded in the snapshot
+	CreateFromSnapshot(snapshotDir string) (PeerLedger, string, error)
 	// Open opens an already created ledger
 	Open(ledgerID string) (PeerLedger, error)
 	// Exists tells whether the ledger with given id exists
@@ -49,6 +54,10 @@ type PeerLedger interface {
 	commonledger.Ledger
 	// GetTransactionByID retrieves a transaction by id
 	GetTransactionByID(txID string) (*peer.ProcessedTransaction, error)
+	// TxIDExists returns true if the given txID has been committed to the ledger. Unlike the function
+	// `GetTransactionByID`, this function also considers the transactions that were committed before
+	// the last block of the snapshot, if the ledger was bootstrapped from a snapshot
+	TxIDExists(txID string) (bool, error)
 	// GetBlockByHash returns a block given it's hash
 	GetBlockByHash(blockHash []byte) (*common.Block, error)
 	// GetBlockByTxID returns a block which contains a transaction
@@ -94,6 +103,12 @@ type PeerLedger interface {
 	CommitPvtDataOfOldBlocks(blockPvtData []*BlockPvtData) ([]*PvtdataHashMismatch, error)
 	// GetMissingPvtDataTracker return the MissingPvtDataTracker
 	GetMissingPvtDataTracker() (MissingPvtDataTracker, error)
+	// GenerateSnapshot generates a snapshot of the ledger at the last committed block and returns the
+	// directory that contains the snapshot. The snapshot contains the public state, the hashes of the
+	// private state, the config history, the txids, and the last block and the last config block
+	GenerateSnapshot() (string, error)
+	// GetChaincodeIndexManager returns the ChaincodeIndexManager
+	GetChaincodeIndexManager() (ChaincodeIndexManager, error)
 }
 
 // ValidatedLedger represents the 'final ledger' after filtering out invalid transactions from PeerLedger.
@@ -149,6 +164,11 @@ type QueryExecutor interface {
 	GetPrivateDataMetadata(namespace, collection, key string) (map[string][]byte, error)
 	// GetPrivateDataMetadataByHash gets the metadata of a private data item identified by a tuple <namespace, collection, keyhash>
 	GetPrivateDataMetadataByHash(namespace, collection string, keyhash []byte) (map[string][]byte, error)
+	// GetPrivateDataHash gets the committed hash of the value of a private data item identified by a tuple <namespace, collection, key>
+	// and the version at which the hash was committed. Unlike GetPrivateData, this function does not require the peer to be
+	// a member of the collection, as the hashes of the private data are maintained by all the peers of the channel.
+	// A nil hash and version are returned if the key does not exist
+	GetPrivateDataHash(namespace, collection, key string) ([]byte, *kvrwset.Version, error)
 	// GetPrivateDataMultipleKeys gets the values for the multiple private data items in a single call
 	GetPrivateDataMultipleKeys(namespace, collection string, keys []string) ([][]byte, error)
 	// GetPrivateDataRangeScanIterator returns an iterator that contains all the key-values between given key ranges.
@@ -171,6 +191,26 @@ type HistoryQueryExecutor interface {
 	// GetHistoryForKey retrieves the history of values for a key.
 	// The returned ResultsIterator contains results of type *KeyModification which is defined in protos/ledger/queryresult.
 	GetHistoryForKey(namespace string, key string) (commonledger.ResultsIterator, error)
+	// GetHistoryForKeyWithMetadata retrieves the history of values for a key as per the given metadata.
+	// metadata is a map of additional query parameters - "startBlock" and "endBlock" (of type uint64) restrict
+	// the history to the modifications committed in the given block range (both inclusive), "descending"
+	// (of type bool) returns the newest modification first, and "limit" (of type int32) and "bookmark"
+	// (of type string) are used for paginating the results.
+	// The returned ResultsIterator contains results of type *KeyModification which is defined in protos/ledger/queryresult.
+	GetHistoryForKeyWithMetadata(namespace string, key string, metadata map[string]interface{}) (commonledger.QueryResultsIterator, error)
+	// GetStateAtHeight returns the value of the given namespace and key as it was after the block
+	// with the given block number was committed. A nil value is returned if the key did not exist
+	// (or was deleted) at that height.
+	GetStateAtHeight(namespace string, key string, blockNum uint64) ([]byte, error)
+	// GetStateRangeScanIteratorAtHeight returns an iterator that contains all the key-values between given key ranges
+	// as they were after the block with the given block number was committed. startKey is included in the results
+	// and endKey is excluded. An empty endKey refers to the last available key in the namespace.
+	// The returned ResultsIterator contains results of type *KV which is defined in protos/ledger/queryresult.
+	GetStateRangeScanIteratorAtHeight(namespace string, startKey string, endKey string, blockNum uint64) (commonledger.ResultsIterator, error)
+	// GetPrivateDataHistoryForKey retrieves the history of the hashed writes for a key in a private data collection.
+	// The cleartext value is included in a result only if the private data is still available on the peer.
+	// The returned ResultsIterator contains results of type *PrivateDataKeyModification which is defined in protos/ledger/queryresult.
+	GetPrivateDataHistoryForKey(namespace string, collection string, key string) (commonledger.ResultsIterator, error)
 }
 
 // TxSimulator simulates a transaction on a consistent snapshot of the 'as recent state as possible'
@@ -195,6 +235,10 @@ type TxSimulator interface {
 	SetPrivateDataMultipleKeys(namespace, collection string, kvs map[string][]byte) error
 	// DeletePrivateData deletes the given tuple <namespace, collection, key> from private data
 	DeletePrivateData(namespace, collection, key string) error
+	// PurgePrivateData deletes the given tuple <namespace, collection, key> from private data, similar to DeletePrivateData.
+	// In addition, once the transaction is committed, the historical private data of the key is removed from the peers
+	// so that only the hashes of the key and its values remain in the blocks
+	PurgePrivateData(namespace, collection, key string) error
 	// SetPrivateDataMetadata sets the metadata associated with an existing key-tuple <namespace, collection, key>
 	SetPrivateDataMetadata(namespace, collection, key string, metadata map[string][]byte) error
 	// DeletePrivateDataMetadata deletes the metadata associated with an existing key-tuple <namespace, collection, key>
@@ -250,6 +294,16 @@ type BlockAndPvtData struct {
 	MissingPvtData TxMissingPvtDataMap
 }
 
+// PvtDataPurgeMarker identifies a private data key that is purged by a valid transaction.
+// The key is identified by its hash and the transaction by its position in the chain
+type PvtDataPurgeMarker struct {
+	Namespace  string
+	Collection string
+	KeyHash    []byte
+	BlockNum   uint64
+	TxNum      uint64
+}
+
 // BlockPvtData contains the private data for a block
 type BlockPvtData struct {
 	BlockNum  uint64
@@ -369,9 +423,34 @@ type ConfigHistoryRetriever interface {
 	MostRecentCollectionConfigBelow(blockNum uint64, chaincodeName string) (*CollectionConfigInfo, error)
 }
 
+// ChaincodeIndexManager allows managing the indexes of the state database defined for the chaincodes
+// and their collections, outside of the deployment of the chaincodes
+type ChaincodeIndexManager interface {
+	// ListIndexes returns the indexes on the data of the chaincode and on the private data of its collections
+	ListIndexes(chaincodeName string) ([]*ChaincodeIndex, error)
+	// DropIndex removes an index on the data of the chaincode, or on the private data of
+	// one of its collections if the collection is not empty
+	DropIndex(chaincodeName, collection, designDoc, indexName string) error
+	// RebuildIndex creates an index from the given definition on the data of the chaincode, or on the private
+	// data of one of its collections if the collection is not empty. An index with the same design document
+	// and name is replaced, so that updated index definitions can be applied without redeploying the chaincode
+	RebuildIndex(chaincodeName, collection string, indexDefinition []byte) (*ChaincodeIndex, error)
+}
+
+// ChaincodeIndex describes an index of the state database on the data of a chaincode,
+// or on the private data of one of its collections
+type ChaincodeIndex struct {
+	Chaincode  string
+	Collection string
+	DesignDoc  string
+	Name       string
+	Definition string
+}
+
 // MissingPvtDataTracker allows getting information about the private data that is not missing on the peer
 type MissingPvtDataTracker interface {
 	GetMissingPvtDataInfoForMostRecentBlocks(maxBlocks int) (MissingPvtDataInfo, error)
+	GetMissingPvtDataInfoForBlockRange(startBlock, endBlock uint64, maxBlocks int) (MissingPvtDataInfo, error)
 }
 
 // MissingPvtDataInfo is a map of block number to MissingBlockPvtdataInfo
@@ -8,6 +8,7 @@ package ledgerconfig
 
 import (
 	"path/filepath"
+	"runtime"
 
 	"github.com/hyperledger/fabric/core/config"
 	"github.com/spf13/viper"
@@ -22,21 +23,34 @@ func IsCouchDBEnabled() bool {
 	return false
 }
 
+// IsJSONDBEnabled returns true if the state database is configured to be the embedded JSON database
+func IsJSONDBEnabled() bool {
+	return viper.GetString("ledger.state.stateDatabase") == "JSONDB"
+}
+
 const confPeerFileSystemPath = "peer.fileSystemPath"
 const confLedgersData = "ledgersData"
 const confLedgerProvider = "ledgerProvider"
 const confStateleveldb = "stateLeveldb"
+const confStateJSONdb = "stateJSONdb"
 const confHistoryLeveldb = "historyLeveldb"
 const confBookkeeper = "bookkeeper"
 const confConfigHistory = "configHistory"
 const confChains = "chains"
 const confPvtdataStore = "pvtdataStore"
+const confFileLock = "fileLock"
+const confSnapshots = "snapshots"
+const confSnapshotsRootDir = "ledger.snapshots.rootDir"
+const confBlocksToRetain = "ledger.blockchain.archive.blocksToRetain"
+const confBlockArchiveDir = "ledger.blockchain.archive.archiveDir"
 const confTotalQueryLimit = "ledger.state.totalQueryLimit"
 const confInternalQueryLimit = "ledger.state.couchDBConfig.internalQueryLimit"
 const confEnableHistoryDatabase = "ledger.history.enableHistoryDatabase"
 const confMaxBatchSize = "ledger.state.couchDBConfig.maxBatchUpdateSize"
 const confAutoWarmIndexes = "ledger.state.couchDBConfig.autoWarmIndexes"
 const confWarmIndexesAfterNBlocks = "ledger.state.couchDBConfig.warmIndexesAfterNBlocks"
+const confStateCacheMaxKeysPerNamespace = "ledger.state.cache.maxKeysPerNamespace"
+const confMVCCValidationParallelism = "ledger.state.mvccValidationParallelism"
 
 var confCollElgProcMaxDbBatchSize = &conf{"ledger.pvtdataStore.collElgProcMaxDbBatchSize", 5000}
 var confCollElgProcDbBatchesInterval = &conf{"ledger.pvtdataStore.collElgProcDbBatchesInterval", 1000}
@@ -58,6 +72,11 @@ func GetStateLevelDBPath() string {
 	return filepath.Join(GetRootPath(), confStateleveldb)
 }
 
+// GetStateJSONDBPath returns the filesystem path that is used to maintain the embedded JSON state db
+func GetStateJSONDBPath() string {
+	return filepath.Join(GetRootPath(), confStateJSONdb)
+}
+
 // GetHistoryLevelDBPath returns the filesystem path that is used to maintain the history level db
 func GetHistoryLevelDBPath() string {
 	return filepath.Join(GetRootPath(), confHistoryLeveldb)
@@ -83,6 +102,40 @@ func GetConfigHistoryPath() string {
 	return filepath.Join(GetRootPath(), confConfigHistory)
 }
 
+// GetFileLockPath returns the filesystem path that is used to create a file lock, which is used to
+// ensure that the peer process and the offline ledger maintenance commands do not operate on the ledgers concurrently
+func GetFileLockPath() string {
+	return filepath.Join(GetRootPath(), confFileLock)
+}
+
+// GetSnapshotsRootDir returns the filesystem path under which the snapshots of the ledgers are generated.
+// If not set in the config, this defaults to a directory under the ledgers root path
+func GetSnapshotsRootDir() string {
+	if viper.IsSet(confSnapshotsRootDir) && viper.GetString(confSnapshotsRootDir) != "" {
+		return config.GetPath(confSnapshotsRootDir)
+	}
+	return filepath.Join(GetRootPath(), confSnapshots)
+}
+
+// GetBlocksToRetain returns the number of most recent blocks that are retained in the block store of a ledger.
+// The block files that contain only the blocks older than these are archived. A value of zero disables archiving
+func GetBlocksToRetain() uint64 {
+	blocksToRetain := viper.GetInt(confBlocksToRetain)
+	if blocksToRetain <= 0 {
+		return 0
+	}
+	return uint64(blocksToRetain)
+}
+
+// GetBlockArchiveDir returns the filesystem path into which the archived block files are moved.
+// An empty path causes the archived block files to be deleted
+func GetBlockArchiveDir() string {
+	if viper.GetString(confBlockArchiveDir) == "" {
+		return ""
+	}
+	return config.GetPath(confBlockArchiveDir)
+}
+
 // GetMaxBlockfileSize returns maximum size of the block file
 func GetMaxBlockfileSize() int {
 	return 64 * 1024 * 1024
@@ -186,6 +239,26 @@ func GetWarmIndexesAfterNBlocks() int {
 	return warmAfterNBlocks
 }
 
+// GetStateCacheMaxKeysPerNamespace returns the maximum number of keys of a namespace that are cached
+// for a channel. A value of zero, which is the default, disables the state cache
+func GetStateCacheMaxKeysPerNamespace() int {
+	maxKeys := viper.GetInt(confStateCacheMaxKeysPerNamespace)
+	if maxKeys < 0 {
+		return 0
+	}
+	return maxKeys
+}
+
+// GetMVCCValidationParallelism returns the maximum number of the transactions of a block that are validated
+// concurrently against the state. If not set or not positive, it defaults to the number of CPUs
+func GetMVCCValidationParallelism() int {
+	parallelism := viper.GetInt(confMVCCValidationParallelism)
+	if parallelism <= 0 {
+		return runtime.NumCPU()
+	}
+	return parallelism
+}
+
 type conf struct {
 	Name       string
 	DefaultVal int
@@ -17,6 +17,7 @@ limitations under the License.
 package ledgerconfig
 
 import (
+	"runtime"
 	"testing"
 
 	ledgertestutil "github.com/hyperledger/fabric/core/ledger/testutil"
@@ -46,15 +47,26 @@ func TestIsCouchDBEnabled(t *testing.T) {
 	assert.True(t, updatedValue) //test config returns true
 }
 
+func TestIsJSONDBEnabled(t *testing.T) {
+	setUpCoreYAMLConfig()
+	defer ledgertestutil.ResetConfigToDefaultValues()
+	assert.False(t, IsJSONDBEnabled())
+	viper.Set("ledger.state.stateDatabase", "JSONDB")
+	assert.True(t, IsJSONDBEnabled())
+	assert.False(t, IsCouchDBEnabled())
+}
+
 func TestLedgerConfigPathDefault(t *testing.T) {
 	setUpCoreYAMLConfig()
 	assert.Equal(t, "/var/hyperledger/production/ledgersData", GetRootPath())
 	assert.Equal(t, "/var/hyperledger/production/ledgersData/ledgerProvider", GetLedgerProviderPath())
 	assert.Equal(t, "/var/hyperledger/production/ledgersData/stateLeveldb", GetStateLevelDBPath())
+	assert.Equal(t, "/var/hyperledger/production/ledgersData/stateJSONdb", GetStateJSONDBPath())
 	assert.Equal(t, "/var/hyperledger/production/ledgersData/historyLeveldb", GetHistoryLevelDBPath())
 	assert.Equal(t, "/var/hyperledger/production/ledgersData/chains", GetBlockStorePath())
 	assert.Equal(t, "/var/hyperledger/production/ledgersData/pvtdataStore", GetPvtdataStorePath())
 	assert.Equal(t, "/var/hyperledger/production/ledgersData/bookkeeper", GetInternalBookkeeperPath())
+	assert.Equal(t, "/var/hyperledger/production/ledgersData/fileLock", GetFileLockPath())
 }
 
 func TestLedgerConfigPath(t *testing.T) {
@@ -64,10 +76,28 @@ func TestLedgerConfigPath(t *testing.T) {
 	assert.Equal(t, "/tmp/hyperledger/production/ledgersData", GetRootPath())
 	assert.Equal(t, "/tmp/hyperledger/production/ledgersData/ledgerProvider", GetLedgerProviderPath())
 	assert.Equal(t, "/tmp/hyperledger/production/ledgersData/stateLeveldb", GetStateLevelDBPath())
+	assert.Equal(t, "/tmp/hyperledger/production/ledgersData/stateJSONdb", GetStateJSONDBPath())
 	assert.Equal(t, "/tmp/hyperledger/production/ledgersData/historyLeveldb", GetHistoryLevelDBPath())
 	assert.Equal(t, "/tmp/hyperledger/production/ledgersData/chains", GetBlockStorePath())
 	assert.Equal(t, "/tmp/hyperledger/production/ledgersData/pvtdataStore", GetPvtdataStorePath())
 	assert.Equal(t, "/tmp/hyperledger/production/ledgersData/bookkeeper", GetInternalBookkeeperPath())
+	assert.Equal(t, "/tmp/hyperledger/production/ledgersData/fileLock", GetFileLockPath())
+	assert.Equal(t, "/tmp/hyperledger/production/ledgersData/snapshots", GetSnapshotsRootDir())
+	viper.Set("ledger.snapshots.rootDir", "/tmp/hyperledger/snapshots")
+	assert.Equal(t, "/tmp/hyperledger/snapshots", GetSnapshotsRootDir())
+}
+
+func TestBlockArchiveConfig(t *testing.T) {
+	setUpCoreYAMLConfig()
+	defer ledgertestutil.ResetConfigToDefaultValues()
+	assert.Equal(t, uint64(0), GetBlocksToRetain())
+	assert.Equal(t, "", GetBlockArchiveDir())
+	viper.Set("ledger.blockchain.archive.blocksToRetain", 1000)
+	viper.Set("ledger.blockchain.archive.archiveDir", "/tmp/hyperledger/archive")
+	assert.Equal(t, uint64(1000), GetBlocksToRetain())
+	assert.Equal(t, "/tmp/hyperledger/archive", GetBlockArchiveDir())
+	viper.Set("ledger.blockchain.archive.blocksToRetain", -1)
+	assert.Equal(t, uint64(0), GetBlocksToRetain())
 }
 
 func TestGetTotalLimitDefault(t *testing.T) {
@@ -244,6 +274,28 @@ func TestGetWarmIndexesAfterNBlocks(t *testing.T) {
 	assert.Equal(t, 10, updatedValue)
 }
 
+func TestGetStateCacheMaxKeysPerNamespace(t *testing.T) {
+	viper.Reset()
+	assert.Equal(t, 0, GetStateCacheMaxKeysPerNamespace())
+	setUpCoreYAMLConfig()
+	defer ledgertestutil.ResetConfigToDefaultValues()
+	assert.Equal(t, 100, GetStateCacheMaxKeysPerNamespace())
+	viper.Set("ledger.state.cache.maxKeysPerNamespace", 500)
+	assert.Equal(t, 500, GetStateCacheMaxKeysPerNamespace())
+	viper.Set("ledger.state.cache.maxKeysPerNamespace", -1)
+	assert.Equal(t, 0, GetStateCacheMaxKeysPerNamespace())
+}
+
+func TestGetMVCCValidationParallelism(t *testing.T) {
+	viper.Reset()
+	assert.Equal(t, runtime.NumCPU(), GetMVCCValidationParallelism())
+	setUpCoreYAMLConfig()
+	defer ledgertestutil.ResetConfigToDefaultValues()
+	assert.Equal(t, runtime.NumCPU(), GetMVCCValidationParallelism())
+	viper.Set("ledger.state.mvccValidationParallelism", 1)
+	assert.Equal(t, 1, GetMVCCValidationParallelism())
+}
+
 func TestGetMaxBlockfileSize(t *testing.T) {
 	assert.Equal(t, 67108864, GetMaxBlockfileSize())
 }
@@ -13,11 +13,13 @@ import (
 	"github.com/hyperledger/fabric/common/ledger/blkstorage"
 	"github.com/hyperledger/fabric/common/ledger/blkstorage/fsblkstorage"
 	"github.com/hyperledger/fabric/core/ledger"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/rwsetutil"
 	"github.com/hyperledger/fabric/core/ledger/ledgerconfig"
 	"github.com/hyperledger/fabric/core/ledger/pvtdatapolicy"
 	"github.com/hyperledger/fabric/core/ledger/pvtdatastorage"
 	lutil "github.com/hyperledger/fabric/core/ledger/util"
 	"github.com/hyperledger/fabric/protos/common"
+	putils "github.com/hyperledger/fabric/protos/utils"
 	"github.com/pkg/errors"
 )
 
@@ -36,20 +38,25 @@ type Store struct {
 	rwlock       *sync.RWMutex
 }
 
+var attrsToIndex = []blkstorage.IndexableAttr{
+	blkstorage.IndexableAttrBlockHash,
+	blkstorage.IndexableAttrBlockNum,
+	blkstorage.IndexableAttrTxID,
+	blkstorage.IndexableAttrBlockNumTranNum,
+	blkstorage.IndexableAttrBlockTxID,
+	blkstorage.IndexableAttrTxValidationCode,
+}
+
 // NewProvider returns the handle to the provider
 func NewProvider() *Provider {
 	// Initialize the block storage
-	attrsToIndex := []blkstorage.IndexableAttr{
-		blkstorage.IndexableAttrBlockHash,
-		blkstorage.IndexableAttrBlockNum,
-		blkstorage.IndexableAttrTxID,
-		blkstorage.IndexableAttrBlockNumTranNum,
-		blkstorage.IndexableAttrBlockTxID,
-		blkstorage.IndexableAttrTxValidationCode,
-	}
 	indexConfig := &blkstorage.IndexConfig{AttrsToIndex: attrsToIndex}
+	archiveConf := &fsblkstorage.ArchiveConf{
+		BlocksToRetain: ledgerconfig.GetBlocksToRetain(),
+		ArchiveDir:     ledgerconfig.GetBlockArchiveDir(),
+	}
 	blockStoreProvider := fsblkstorage.NewProvider(
-		fsblkstorage.NewConf(ledgerconfig.GetBlockStorePath(), ledgerconfig.GetMaxBlockfileSize()),
+		fsblkstorage.NewConfWithArchiveConf(ledgerconfig.GetBlockStorePath(), ledgerconfig.GetMaxBlockfileSize(), archiveConf),
 		indexConfig)
 
 	pvtStoreProvider := pvtdatastorage.NewProvider()
@@ -75,12 +82,58 @@ func (p *Provider) Open(ledgerid string) (*Store, error) {
 	return store, nil
 }
 
+// CreateFromSnapshot creates the stores for a ledger from a snapshot. The block store is bootstrapped
+// with the txids present in the snapshot and the supplied last block and last config block. The pvtdata
+// store is initialized such that the last block is treated as the last committed block
+func (p *Provider) CreateFromSnapshot(ledgerid, snapshotDir string, lastBlock, lastConfigBlock *common.Block) (*Store, error) {
+	blockStore, err := p.blkStoreProvider.BootstrapFromSnapshot(ledgerid, snapshotDir, lastBlock, lastConfigBlock)
+	if err != nil {
+		return nil, err
+	}
+	pvtdataStore, err := p.pvtdataStoreProvider.OpenStore(ledgerid)
+	if err != nil {
+		return nil, err
+	}
+	store := &Store{blockStore, pvtdataStore, &sync.RWMutex{}}
+	if err := store.init(); err != nil {
+		return nil, err
+	}
+	return store, nil
+}
+
 // Close closes the provider
 func (p *Provider) Close() {
 	p.blkStoreProvider.Close()
 	p.pvtdataStoreProvider.Close()
 }
 
+// ValidateRollbackParams performs necessary validation on the input given for
+// the rollback operation
+func ValidateRollbackParams(ledgerID string, blockNum uint64) error {
+	return fsblkstorage.ValidateRollbackParams(ledgerconfig.GetBlockStorePath(), ledgerID, blockNum)
+}
+
+// Rollback reverts the block store and the pvtdata store of the given ledger to the given block number.
+// The block store is rolled back first as both the rollbacks can be safely re-invoked in case
+// of an interruption
+func Rollback(ledgerID string, blockNum uint64) error {
+	indexConfig := &blkstorage.IndexConfig{AttrsToIndex: attrsToIndex}
+	if err := fsblkstorage.Rollback(ledgerconfig.GetBlockStorePath(), ledgerID, blockNum, indexConfig); err != nil {
+		return err
+	}
+	return pvtdatastorage.RollbackToBlock(ledgerID, blockNum)
+}
+
+// IsBootstrappedFromSnapshot returns true if the block store of the given ledger was bootstrapped from a snapshot
+func IsBootstrappedFromSnapshot(ledgerID string) (bool, error) {
+	return fsblkstorage.IsBootstrappedFromSnapshot(ledgerconfig.GetBlockStorePath(), ledgerID)
+}
+
+// HasArchivedBlocks returns true if any of the block files of the given ledger has been archived
+func HasArchivedBlocks(ledgerID string) (bool, error) {
+	return fsblkstorage.HasArchivedBlocks(ledgerconfig.GetBlockStorePath(), ledgerID)
+}
+
 // Init initializes store with essential configurations
 func (s *Store) Init(btlPolicy pvtdatapolicy.BTLPolicy) {
 	s.pvtdataStore.Init(btlPolicy)
@@ -121,7 +174,11 @@ func (s *Store) CommitWithPvtData(blockAndPvtdata *ledger.BlockAndPvtData) error
 		// valid transactions' pvtdata. Hence, it is necessary to rebuild pvtdatastore
 		// along with the blockstore to keep only valid tx data in the pvtdatastore.
 		validTxPvtData, validTxMissingPvtData := constructValidTxPvtDataAndMissingData(blockAndPvtdata)
-		if err := s.pvtdataStore.Prepare(blockAndPvtdata.Block.Header.Number, validTxPvtData, validTxMissingPvtData); err != nil {
+		purgeMarkers, err := constructPvtDataPurgeMarkers(blockAndPvtdata)
+		if err != nil {
+			return err
+		}
+		if err := s.pvtdataStore.Prepare(blockAndPvtdata.Block.Header.Number, validTxPvtData, validTxMissingPvtData, purgeMarkers); err != nil {
 			return err
 		}
 		writtenToPvtStore = true
@@ -169,6 +226,56 @@ func constructValidTxPvtDataAndMissingData(blockAndPvtData *ledger.BlockAndPvtDa
 	return validTxPvtData, validTxMissingPvtData
 }
 
+// constructPvtDataPurgeMarkers returns the purge markers for the pvt data keys that are purged by the valid
+// transactions in the block. As a purge is recorded in the hashed rwset of a collection, only the transactions
+// that carry (or miss) pvt data are inspected
+func constructPvtDataPurgeMarkers(blockAndPvtData *ledger.BlockAndPvtData) ([]*ledger.PvtDataPurgeMarker, error) {
+	var purgeMarkers []*ledger.PvtDataPurgeMarker
+	block := blockAndPvtData.Block
+	txsFilter := lutil.TxValidationFlags(block.Metadata.Metadata[common.BlockMetadataIndex_TRANSACTIONS_FILTER])
+
+	for txNum, envBytes := range block.Data.Data {
+		if txsFilter.IsInvalid(txNum) {
+			continue
+		}
+		_, hasPvtData := blockAndPvtData.PvtData[uint64(txNum)]
+		_, hasMissingPvtData := blockAndPvtData.MissingPvtData[uint64(txNum)]
+		if !hasPvtData && !hasMissingPvtData {
+			continue
+		}
+
+		env, err := putils.GetEnvelopeFromBlock(envBytes)
+		if err != nil {
+			return nil, err
+		}
+		payload, err := putils.GetPayload(env)
+		if err != nil {
+			return nil, err
+		}
+		chdr, err := putils.UnmarshalChannelHeader(payload.Header.ChannelHeader)
+		if err != nil {
+			return nil, err
+		}
+		if common.HeaderType(chdr.Type) != common.HeaderType_ENDORSER_TRANSACTION {
+			continue
+		}
+		respPayload, err := putils.GetActionFromEnvelope(envBytes)
+		if err != nil {
+			return nil, err
+		}
+		txRWSet := &rwsetutil.TxRwSet{}
+		if err := txRWSet.FromProtoBytes(respPayload.Results); err != nil {
+			// a valid transaction is expected to carry a well-formed rwset, this is only
+			// guarding against a block that bypassed the validation
+			logger.Warningf("Skipping the purge markers of tx [%d] in block [%d] as the rwset could not be unmarshalled: %s",
+				txNum, block.Header.Number, err)
+			continue
+		}
+		purgeMarkers = append(purgeMarkers, txRWSet.GetPvtDataPurgeMarkers(block.Header.Number, uint64(txNum))...)
+	}
+	return purgeMarkers, nil
+}
+
 // CommitPvtDataOfOldBlocks commits the pvtData of old blocks
 func (s *Store) CommitPvtDataOfOldBlocks(blocksPvtData map[uint64][]*ledger.TxPvtData) error {
 	err := s.pvtdataStore.CommitPvtDataOfOldBlocks(blocksPvtData)
@@ -226,6 +333,11 @@ func (s *Store) GetMissingPvtDataInfoForMostRecentBlocks(maxBlock int) (ledger.M
 	return s.pvtdataStore.GetMissingPvtDataInfoForMostRecentBlocks(maxBlock)
 }
 
+// GetMissingPvtDataInfoForBlockRange invokes the function on underlying pvtdata store
+func (s *Store) GetMissingPvtDataInfoForBlockRange(startBlock, endBlock uint64, maxBlock int) (ledger.MissingPvtDataInfo, error) {
+	return s.pvtdataStore.GetMissingPvtDataInfoForBlockRange(startBlock, endBlock, maxBlock)
+}
+
 // ProcessCollsEligibilityEnabled invokes the function on underlying pvtdata store
 func (s *Store) ProcessCollsEligibilityEnabled(committingBlk uint64, nsCollMap map[string][]string) error {
 	return s.pvtdataStore.ProcessCollsEligibilityEnabled(committingBlk, nsCollMap)
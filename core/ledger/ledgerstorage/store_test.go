@@ -7,6 +7,7 @@ SPDX-License-Identifier: Apache-2.0
 package ledgerstorage
 
 import (
+	"io/ioutil"
 	"os"
 	"testing"
 
@@ -16,6 +17,7 @@ import (
 	"github.com/hyperledger/fabric/common/ledger/blkstorage/fsblkstorage"
 	"github.com/hyperledger/fabric/common/ledger/testutil"
 	"github.com/hyperledger/fabric/core/ledger"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/rwsetutil"
 	"github.com/hyperledger/fabric/core/ledger/ledgerconfig"
 	"github.com/hyperledger/fabric/core/ledger/pvtdatapolicy"
 	btltestutil "github.com/hyperledger/fabric/core/ledger/pvtdatapolicy/testutil"
@@ -160,6 +162,53 @@ func TestStoreWithExistingBlockchain(t *testing.T) {
 	assert.Equal(t, uint64(10), pvtdataBlockHt)
 }
 
+func TestCreateFromSnapshot(t *testing.T) {
+	testEnv := newTestEnv(t)
+	defer testEnv.cleanup()
+	snapshotDir, err := ioutil.TempDir("", "ledgerstorage-snapshot")
+	assert.NoError(t, err)
+	defer os.RemoveAll(snapshotDir)
+
+	provider := NewProvider()
+	defer provider.Close()
+	sourceStore, err := provider.Open("source-ledger")
+	assert.NoError(t, err)
+	sourceStore.Init(btlPolicyForSampleData())
+	defer sourceStore.Shutdown()
+	testBlocks := testutil.ConstructTestBlocks(t, 10)
+	for _, blk := range testBlocks[:9] {
+		assert.NoError(t, sourceStore.CommitWithPvtData(&ledger.BlockAndPvtData{Block: blk}))
+	}
+	_, err = sourceStore.ExportTxIds(snapshotDir)
+	assert.NoError(t, err)
+
+	store, err := provider.CreateFromSnapshot("bootstrapped-ledger", snapshotDir, testBlocks[8], testBlocks[0])
+	assert.NoError(t, err)
+	store.Init(btlPolicyForSampleData())
+	defer store.Shutdown()
+
+	isBootstrapped, err := IsBootstrappedFromSnapshot("bootstrapped-ledger")
+	assert.NoError(t, err)
+	assert.True(t, isBootstrapped)
+	isBootstrapped, err = IsBootstrappedFromSnapshot("source-ledger")
+	assert.NoError(t, err)
+	assert.False(t, isBootstrapped)
+
+	// the pvtdata store is initialized with the last block as the last committed block
+	pvtdataBlockHt, err := store.pvtdataStore.LastCommittedBlockHeight()
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(9), pvtdataBlockHt)
+
+	pvtdata := samplePvtData(t, []uint64{0})
+	assert.NoError(t, store.CommitWithPvtData(&ledger.BlockAndPvtData{Block: testBlocks[9], PvtData: pvtdata}))
+	pvtdataBlockHt, err = store.pvtdataStore.LastCommittedBlockHeight()
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(10), pvtdataBlockHt)
+	blockAndPvtdata, err := store.GetPvtDataAndBlockByNum(9, nil)
+	assert.NoError(t, err)
+	assert.True(t, proto.Equal(testBlocks[9], blockAndPvtdata.Block))
+}
+
 func TestCrashAfterPvtdataStorePreparation(t *testing.T) {
 	testEnv := newTestEnv(t)
 	defer testEnv.cleanup()
@@ -183,7 +232,7 @@ func TestCrashAfterPvtdataStorePreparation(t *testing.T) {
 		pvtdataAtCrash = append(pvtdataAtCrash, p)
 	}
 	// Only call Prepare on pvt data store and mimic a crash
-	store.pvtdataStore.Prepare(blokNumAtCrash, pvtdataAtCrash, nil)
+	store.pvtdataStore.Prepare(blokNumAtCrash, pvtdataAtCrash, nil, nil)
 	store.Shutdown()
 	provider.Close()
 	provider = NewProvider()
@@ -240,7 +289,7 @@ func TestCrashBeforePvtdataStoreCommit(t *testing.T) {
 
 	// Mimic a crash just short of calling the final commit on pvtdata store
 	// After starting the store again, the block and the pvtdata should be available
-	store.pvtdataStore.Prepare(blokNumAtCrash, pvtdataAtCrash, nil)
+	store.pvtdataStore.Prepare(blokNumAtCrash, pvtdataAtCrash, nil, nil)
 	store.BlockStore.AddBlock(dataAtCrash.Block)
 	store.Shutdown()
 	provider.Close()
@@ -324,6 +373,45 @@ func TestConstructPvtdataMap(t *testing.T) {
 	assert.Nil(t, constructPvtdataMap(nil))
 }
 
+func TestConstructPvtDataPurgeMarkers(t *testing.T) {
+	var simulationResults [][]byte
+	for _, key := range []string{"key0", "key1", "key2"} {
+		builder := rwsetutil.NewRWSetBuilder()
+		builder.AddToPvtAndHashedWriteSetForPurge("ns-1", "coll-1", key)
+		simRes, err := builder.GetTxSimulationResults()
+		assert.NoError(t, err)
+		pubSimResBytes, err := simRes.GetPubSimulationBytes()
+		assert.NoError(t, err)
+		simulationResults = append(simulationResults, pubSimResBytes)
+	}
+	bg, _ := testutil.NewBlockGenerator(t, "testLedger", false)
+	block := bg.NextBlock(simulationResults)
+	// tx1 is invalid and the pvt data of tx2 is missing
+	txFilter := lutil.NewTxValidationFlagsSetValue(3, pb.TxValidationCode_VALID)
+	txFilter.SetFlag(1, pb.TxValidationCode_MVCC_READ_CONFLICT)
+	block.Metadata.Metadata[common.BlockMetadataIndex_TRANSACTIONS_FILTER] = txFilter
+	missingData := make(ledger.TxMissingPvtDataMap)
+	missingData.Add(2, "ns-1", "coll-1", true)
+	blockAndPvtData := &ledger.BlockAndPvtData{
+		Block: block,
+		PvtData: ledger.TxPvtDataMap{
+			0: &ledger.TxPvtData{SeqInBlock: 0},
+			1: &ledger.TxPvtData{SeqInBlock: 1},
+		},
+		MissingPvtData: missingData,
+	}
+
+	purgeMarkers, err := constructPvtDataPurgeMarkers(blockAndPvtData)
+	assert.NoError(t, err)
+	assert.Equal(t,
+		[]*ledger.PvtDataPurgeMarker{
+			{Namespace: "ns-1", Collection: "coll-1", KeyHash: lutil.ComputeStringHash("key0"), BlockNum: 1, TxNum: 0},
+			{Namespace: "ns-1", Collection: "coll-1", KeyHash: lutil.ComputeStringHash("key2"), BlockNum: 1, TxNum: 2},
+		},
+		purgeMarkers,
+	)
+}
+
 func sampleDataWithPvtdataForSelectiveTx(t *testing.T) []*ledger.BlockAndPvtData {
 	var blockAndpvtdata []*ledger.BlockAndPvtData
 	blocks := testutil.ConstructTestBlocks(t, 10)
@@ -9,9 +9,13 @@ package pvtdatastorage
 import (
 	"math"
 
+	"github.com/golang/protobuf/proto"
 	"github.com/hyperledger/fabric/core/ledger"
 	"github.com/hyperledger/fabric/core/ledger/pvtdatapolicy"
+	"github.com/hyperledger/fabric/core/ledger/util"
 	"github.com/hyperledger/fabric/protos/ledger/rwset"
+	"github.com/hyperledger/fabric/protos/ledger/rwset/kvrwset"
+	"github.com/pkg/errors"
 	"github.com/willf/bitset"
 )
 
@@ -158,6 +162,85 @@ func deriveKeys(expiryEntry *expiryEntry) (dataKeys []*dataKey, missingDataKeys
 	return
 }
 
+// deriveHashedIndexKeys constructs a hashedIndexKey for each key that is written (or whose metadata is written)
+// in the pvt data of a dataEntry. The pvt data is only verified against the hash present in the block and hence,
+// a pvt rwset that cannot be unmarshalled is not indexed (instead of failing the commit)
+func deriveHashedIndexKeys(dataEntry *dataEntry) []*hashedIndexKey {
+	kvRWSet := &kvrwset.KVRWSet{}
+	if err := proto.Unmarshal(dataEntry.value.Rwset, kvRWSet); err != nil {
+		logger.Warningf("Skipping indexing of the keys in the pvt rwset [ns=%s, coll=%s, blkNum=%d, txNum=%d] as it could not be unmarshalled: %s",
+			dataEntry.key.ns, dataEntry.key.coll, dataEntry.key.blkNum, dataEntry.key.txNum, err)
+		return nil
+	}
+	var keys []string
+	for _, kvWrite := range kvRWSet.Writes {
+		keys = append(keys, kvWrite.Key)
+	}
+	for _, kvMetadataWrite := range kvRWSet.MetadataWrites {
+		keys = append(keys, kvMetadataWrite.Key)
+	}
+
+	var hashedIndexKeys []*hashedIndexKey
+	indexedKeys := make(map[string]bool)
+	for _, key := range keys {
+		if indexedKeys[key] {
+			continue
+		}
+		indexedKeys[key] = true
+		hashedIndexKeys = append(hashedIndexKeys, &hashedIndexKey{
+			ns:      dataEntry.key.ns,
+			coll:    dataEntry.key.coll,
+			keyHash: util.ComputeStringHash(key),
+			blkNum:  dataEntry.key.blkNum,
+			txNum:   dataEntry.key.txNum,
+		})
+	}
+	return hashedIndexKeys
+}
+
+// removePvtWrites returns a copy of the collection pvt rwset without the writes and the metadata writes
+// of the keys for which the function 'isRemoved' returns true. A pvt rwset that cannot be unmarshalled
+// is returned as is, same as it is not indexed by the function 'deriveHashedIndexKeys'
+func removePvtWrites(collPvtdata *rwset.CollectionPvtReadWriteSet,
+	isRemoved func(keyHash []byte) (bool, error)) (*rwset.CollectionPvtReadWriteSet, error) {
+	kvRWSet := &kvrwset.KVRWSet{}
+	if err := proto.Unmarshal(collPvtdata.Rwset, kvRWSet); err != nil {
+		return collPvtdata, nil
+	}
+
+	var writes []*kvrwset.KVWrite
+	for _, kvWrite := range kvRWSet.Writes {
+		removed, err := isRemoved(util.ComputeStringHash(kvWrite.Key))
+		if err != nil {
+			return nil, err
+		}
+		if !removed {
+			writes = append(writes, kvWrite)
+		}
+	}
+	var metadataWrites []*kvrwset.KVMetadataWrite
+	for _, kvMetadataWrite := range kvRWSet.MetadataWrites {
+		removed, err := isRemoved(util.ComputeStringHash(kvMetadataWrite.Key))
+		if err != nil {
+			return nil, err
+		}
+		if !removed {
+			metadataWrites = append(metadataWrites, kvMetadataWrite)
+		}
+	}
+	kvRWSet.Writes = writes
+	kvRWSet.MetadataWrites = metadataWrites
+
+	rwsetBytes, err := proto.Marshal(kvRWSet)
+	if err != nil {
+		return nil, errors.Wrap(err, "error while marshalling the pvt rwset")
+	}
+	return &rwset.CollectionPvtReadWriteSet{
+		CollectionName: collPvtdata.CollectionName,
+		Rwset:          rwsetBytes,
+	}, nil
+}
+
 func passesFilter(dataKey *dataKey, filter ledger.PvtNsCollFilter) bool {
 	return filter == nil || filter.Has(dataKey.ns, dataKey.coll)
 }
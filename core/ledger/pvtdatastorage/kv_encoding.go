@@ -27,6 +27,9 @@ var (
 	ineligibleMissingDataKeyPrefix = []byte{5}
 	collElgKeyPrefix               = []byte{6}
 	lastUpdatedOldBlocksKey        = []byte{7}
+	purgeMarkerKeyPrefix           = []byte{8}
+	hashedIndexKeyPrefix           = []byte{9}
+	pendingPurgeMarkerKeyPrefix    = []byte{10}
 
 	nilByte    = byte(0)
 	emptyValue = []byte{}
@@ -209,3 +212,70 @@ func eligibleMissingdatakeyRange(blkNum uint64) (startKey, endKey []byte) {
 	endKey = append(eligibleMissingDataKeyPrefix, util.EncodeReverseOrderVarUint64(blkNum-1)...)
 	return
 }
+
+func encodeHashedIndexKey(key *hashedIndexKey) []byte {
+	keyBytes := append(hashedIndexKeyPrefix, encodeHashedIndexKeyPrefix(key.ns, key.coll, key.keyHash)...)
+	return append(keyBytes, version.NewHeight(key.blkNum, key.txNum).ToBytes()...)
+}
+
+func encodeHashedIndexKeyPrefix(ns, coll string, keyHash []byte) []byte {
+	keyBytes := append([]byte(ns), nilByte)
+	keyBytes = append(keyBytes, []byte(coll)...)
+	keyBytes = append(keyBytes, nilByte)
+	keyBytes = append(keyBytes, proto.EncodeVarint(uint64(len(keyHash)))...)
+	return append(keyBytes, keyHash...)
+}
+
+func decodeHashedIndexKey(keyBytes []byte) *hashedIndexKey {
+	splittedKey := bytes.SplitN(keyBytes[1:], []byte{nilByte}, 3) // encoded bytes for keyHash may contain empty bytes
+	keyHashLen, n := proto.DecodeVarint(splittedKey[2])
+	keyHash := splittedKey[2][n : n+int(keyHashLen)]
+	height, _ := version.NewHeightFromBytes(splittedKey[2][n+int(keyHashLen):])
+	return &hashedIndexKey{
+		ns:      string(splittedKey[0]),
+		coll:    string(splittedKey[1]),
+		keyHash: append([]byte(nil), keyHash...),
+		blkNum:  height.BlockNum,
+		txNum:   height.TxNum,
+	}
+}
+
+// getHashedIndexKeysForRangeScan returns the range that covers the writes of the given key hash
+// that are committed before the given height
+func getHashedIndexKeysForRangeScan(key *purgeMarkerKey, height *version.Height) (startKey, endKey []byte) {
+	startKey = encodeHashedIndexKey(&hashedIndexKey{ns: key.ns, coll: key.coll, keyHash: key.keyHash})
+	endKey = encodeHashedIndexKey(&hashedIndexKey{ns: key.ns, coll: key.coll, keyHash: key.keyHash,
+		blkNum: height.BlockNum, txNum: height.TxNum})
+	return
+}
+
+func encodePurgeMarkerKey(key *purgeMarkerKey) []byte {
+	return append(purgeMarkerKeyPrefix, encodeHashedIndexKeyPrefix(key.ns, key.coll, key.keyHash)...)
+}
+
+func encodePendingPurgeMarkerKey(key *purgeMarkerKey) []byte {
+	return append(pendingPurgeMarkerKeyPrefix, encodeHashedIndexKeyPrefix(key.ns, key.coll, key.keyHash)...)
+}
+
+func decodePurgeMarkerKey(keyBytes []byte) *purgeMarkerKey {
+	splittedKey := bytes.SplitN(keyBytes[1:], []byte{nilByte}, 3) // encoded bytes for keyHash may contain empty bytes
+	keyHashLen, n := proto.DecodeVarint(splittedKey[2])
+	return &purgeMarkerKey{
+		ns:      string(splittedKey[0]),
+		coll:    string(splittedKey[1]),
+		keyHash: append([]byte(nil), splittedKey[2][n:n+int(keyHashLen)]...),
+	}
+}
+
+func encodePurgeMarkerValue(height *version.Height) []byte {
+	return height.ToBytes()
+}
+
+func decodePurgeMarkerValue(valueBytes []byte) *version.Height {
+	height, _ := version.NewHeightFromBytes(valueBytes)
+	return height
+}
+
+func getPendingPurgeMarkerKeysForRangeScan() (startKey, endKey []byte) {
+	return pendingPurgeMarkerKeyPrefix, []byte{pendingPurgeMarkerKeyPrefix[0] + 1}
+}
@@ -11,6 +11,7 @@ import (
 	math "math"
 	"testing"
 
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/version"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -20,6 +21,28 @@ func TestDataKeyEncoding(t *testing.T) {
 	assert.Equal(t, dataKey1, datakey2)
 }
 
+func TestHashedIndexKeyEncoding(t *testing.T) {
+	hashedIndexKey1 := &hashedIndexKey{ns: "ns1", coll: "coll1", keyHash: []byte{0, 1, 0, 2}, blkNum: 2, txNum: 5}
+	hashedIndexKey2 := decodeHashedIndexKey(encodeHashedIndexKey(hashedIndexKey1))
+	assert.Equal(t, hashedIndexKey1, hashedIndexKey2)
+
+	purgeMarkerKey1 := &purgeMarkerKey{ns: "ns1", coll: "coll1", keyHash: []byte{0, 1, 0, 2}}
+	assert.Equal(t, purgeMarkerKey1, decodePurgeMarkerKey(encodePurgeMarkerKey(purgeMarkerKey1)))
+	assert.Equal(t, purgeMarkerKey1, decodePurgeMarkerKey(encodePendingPurgeMarkerKey(purgeMarkerKey1)))
+}
+
+func TestHashedIndexKeyRange(t *testing.T) {
+	key := &purgeMarkerKey{ns: "ns", coll: "coll", keyHash: []byte("keyHash")}
+	startKey, endKey := getHashedIndexKeysForRangeScan(key, version.NewHeight(20, 5))
+	keyBeforePurge := encodeHashedIndexKey(&hashedIndexKey{ns: "ns", coll: "coll", keyHash: []byte("keyHash"), blkNum: 20, txNum: 4})
+	keyOfPurge := encodeHashedIndexKey(&hashedIndexKey{ns: "ns", coll: "coll", keyHash: []byte("keyHash"), blkNum: 20, txNum: 5})
+	keyOfOtherHash := encodeHashedIndexKey(&hashedIndexKey{ns: "ns", coll: "coll", keyHash: []byte("keyHash1"), blkNum: 1, txNum: 1})
+	assert.True(t, bytes.Compare(keyBeforePurge, startKey) > 0)
+	assert.True(t, bytes.Compare(keyBeforePurge, endKey) < 0)
+	assert.Equal(t, 0, bytes.Compare(keyOfPurge, endKey))
+	assert.True(t, bytes.Compare(keyOfOtherHash, endKey) > 0)
+}
+
 func TestDatakeyRange(t *testing.T) {
 	blockNum := uint64(20)
 	startKey, endKey := datakeyRange(blockNum)
@@ -0,0 +1,93 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package pvtdatastorage
+
+import (
+	"github.com/hyperledger/fabric/common/ledger/util/leveldbhelper"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/version"
+	"github.com/hyperledger/fabric/core/ledger/ledgerconfig"
+)
+
+// RollbackToBlock removes all the entries of the pvtdata store that belong to the blocks
+// beyond the given block number and sets the given block number as the last committed block.
+// This function is expected to be invoked only when the peer is not running (i.e., by the
+// offline rollback command). All the changes are written in a single batch and hence, an
+// interrupted invocation leaves the store as is
+func RollbackToBlock(ledgerID string, blockNum uint64) error {
+	dbProvider := leveldbhelper.NewProvider(&leveldbhelper.Conf{DBPath: ledgerconfig.GetPvtdataStorePath()})
+	defer dbProvider.Close()
+	s := &store{db: dbProvider.GetDBHandle(ledgerID), ledgerid: ledgerID}
+	if err := s.initState(); err != nil {
+		return err
+	}
+	return s.rollbackToBlock(blockNum)
+}
+
+func (s *store) rollbackToBlock(blockNum uint64) error {
+	if s.isEmpty {
+		logger.Infof("Pvtdata store for ledger [%s] is empty. Nothing to rollback", s.ledgerid)
+		return nil
+	}
+	batch := leveldbhelper.NewUpdateBatch()
+	batch.Delete(pendingCommitKey)
+	batch.Delete(lastUpdatedOldBlocksKey)
+	if s.lastCommittedBlock > blockNum {
+		batch.Put(lastCommittedBlkkey, encodeLastCommittedBlockVal(blockNum))
+	}
+
+	// data entries are sorted by the block number in ascending order
+	dataStartKey := append(pvtDataKeyPrefix, version.NewHeight(blockNum+1, 0).ToBytes()...)
+	s.deleteKeysInRange(batch, dataStartKey, expiryKeyPrefix, nil)
+
+	// expiry entries are sorted by the expiring block number
+	s.deleteKeysInRange(batch, expiryKeyPrefix, eligibleMissingDataKeyPrefix, func(k, v []byte) bool {
+		return decodeExpiryKey(k).committingBlk > blockNum
+	})
+
+	// eligible missing data entries and collection eligibility entries are sorted by the block number in descending order
+	eligibleMissingDataEndKey, _ := createRangeScanKeysForEligibleMissingDataEntries(blockNum)
+	s.deleteKeysInRange(batch, eligibleMissingDataKeyPrefix, eligibleMissingDataEndKey, nil)
+	s.deleteKeysInRange(batch, collElgKeyPrefix, encodeCollElgKey(blockNum), nil)
+
+	// ineligible missing data entries are sorted by the namespace and collection first
+	s.deleteKeysInRange(batch, ineligibleMissingDataKeyPrefix, collElgKeyPrefix, func(k, v []byte) bool {
+		return decodeMissingDataKey(k).blkNum > blockNum
+	})
+
+	// a purge marker keeps the height of the last purge of a key. The markers set by the purges in the
+	// rolled back blocks are removed and are set again when these blocks are committed again
+	s.deleteKeysInRange(batch, purgeMarkerKeyPrefix, hashedIndexKeyPrefix, func(k, v []byte) bool {
+		return decodePurgeMarkerValue(v).BlockNum > blockNum
+	})
+
+	// hashed index entries are sorted by the namespace, collection, and key hash first
+	s.deleteKeysInRange(batch, hashedIndexKeyPrefix, pendingPurgeMarkerKeyPrefix, func(k, v []byte) bool {
+		return decodeHashedIndexKey(k).blkNum > blockNum
+	})
+
+	// pending purge markers belong to the pending batch, which is discarded
+	pendingPurgeMarkerStartKey, pendingPurgeMarkerEndKey := getPendingPurgeMarkerKeysForRangeScan()
+	s.deleteKeysInRange(batch, pendingPurgeMarkerStartKey, pendingPurgeMarkerEndKey, nil)
+
+	logger.Infof("Rolling back pvtdata store for ledger [%s] to block number [%d]. Number of entries to update [%d]",
+		s.ledgerid, blockNum, batch.Len())
+	return s.db.WriteBatch(batch, true)
+}
+
+// deleteKeysInRange adds to the batch the deletes for all the keys in the given range
+// for which the filter returns true. A nil filter selects all the keys in the range
+func (s *store) deleteKeysInRange(batch *leveldbhelper.UpdateBatch, startKey, endKey []byte, filter func(k, v []byte) bool) {
+	itr := s.db.GetIterator(startKey, endKey)
+	defer itr.Release()
+	for itr.Next() {
+		k := itr.Key()
+		if filter != nil && !filter(k, itr.Value()) {
+			continue
+		}
+		batch.Delete(append([]byte{}, k...))
+	}
+}
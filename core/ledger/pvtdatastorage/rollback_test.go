@@ -0,0 +1,164 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package pvtdatastorage
+
+import (
+	"testing"
+
+	"github.com/hyperledger/fabric/core/ledger"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/version"
+	btltestutil "github.com/hyperledger/fabric/core/ledger/pvtdatapolicy/testutil"
+	"github.com/hyperledger/fabric/core/ledger/util"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRollbackToBlock(t *testing.T) {
+	btlPolicy := btltestutil.SampleBTLPolicy(
+		map[[2]string]uint64{
+			{"ns-1", "coll-1"}: 0,
+			{"ns-1", "coll-2"}: 100,
+		},
+	)
+	ledgerID := "TestRollbackToBlock"
+	env := NewTestStoreEnv(t, ledgerID, btlPolicy)
+	defer env.Cleanup()
+	assert := assert.New(t)
+	s := env.TestStore
+	assert.NoError(s.Prepare(0, nil, nil, nil))
+	assert.NoError(s.Commit())
+
+	pvtdata := []*ledger.TxPvtData{
+		produceSamplePvtdata(t, 0, []string{"ns-1:coll-1", "ns-1:coll-2"}),
+	}
+	missingData := make(ledger.TxMissingPvtDataMap)
+	missingData.Add(1, "ns-1", "coll-1", true)
+	missingData.Add(1, "ns-2", "coll-2", false)
+	for i := 1; i <= 9; i++ {
+		assert.NoError(s.Prepare(uint64(i), pvtdata, missingData, nil))
+		assert.NoError(s.Commit())
+	}
+	// leave a pending batch for block 10
+	assert.NoError(s.Prepare(10, pvtdata, missingData, nil))
+	env.TestStoreProvider.Close()
+
+	assert.NoError(RollbackToBlock(ledgerID, 5))
+	env.CloseAndReopen()
+	s = env.TestStore
+	testPendingBatch(false, assert, s)
+	testLastCommittedBlockHeight(6, assert, s)
+
+	dataKey := &dataKey{nsCollBlk: nsCollBlk{ns: "ns-1", coll: "coll-1"}, txNum: 0}
+	eligibleMissingDataKey := &missingDataKey{nsCollBlk: nsCollBlk{ns: "ns-1", coll: "coll-1"}, isEligible: true}
+	ineligibleMissingDataKey := &missingDataKey{nsCollBlk: nsCollBlk{ns: "ns-2", coll: "coll-2"}, isEligible: false}
+	for i := uint64(1); i <= 10; i++ {
+		dataKey.blkNum = i
+		eligibleMissingDataKey.blkNum = i
+		ineligibleMissingDataKey.blkNum = i
+		expiryKey := &expiryKey{expiringBlk: i + 101, committingBlk: i}
+		expectedToExist := i <= 5
+		assert.Equal(expectedToExist, testDataKeyExists(t, s, dataKey))
+		assert.Equal(expectedToExist, testMissingDataKeyExists(t, s, eligibleMissingDataKey))
+		assert.Equal(expectedToExist, testMissingDataKeyExists(t, s, ineligibleMissingDataKey))
+		expiryData, err := s.(*store).getExpiryDataOfExpiryKey(expiryKey)
+		assert.NoError(err)
+		assert.Equal(expectedToExist, expiryData != nil)
+	}
+
+	_, err := s.GetPvtDataByBlockNum(6, nil)
+	assert.IsType(&ErrOutOfRange{}, err)
+
+	// the rolled back blocks can be committed again
+	for i := 6; i <= 9; i++ {
+		assert.NoError(s.Prepare(uint64(i), pvtdata, missingData, nil))
+		assert.NoError(s.Commit())
+	}
+	testLastCommittedBlockHeight(10, assert, s)
+}
+
+func TestRollbackToBlockWithPurges(t *testing.T) {
+	btlPolicy := btltestutil.SampleBTLPolicy(
+		map[[2]string]uint64{
+			{"ns-1", "coll-1"}: 0,
+		},
+	)
+	ledgerID := "TestRollbackToBlockWithPurges"
+	env := NewTestStoreEnv(t, ledgerID, btlPolicy)
+	defer env.Cleanup()
+	assert := assert.New(t)
+	s := env.TestStore
+	key1Hash := util.ComputeStringHash("key1")
+	key2Hash := util.ComputeStringHash("key2")
+	key3Hash := util.ComputeStringHash("key3")
+
+	assert.NoError(s.Prepare(0, nil, nil, nil))
+	assert.NoError(s.Commit())
+
+	// block 1: tx1 writes key1 and key2
+	testDataForBlk1 := []*ledger.TxPvtData{
+		produceSamplePvtdataWithWrites(t, 1, "ns-1", "coll-1", map[string][]byte{"key1": []byte("value1"), "key2": []byte("value2")}),
+	}
+	assert.NoError(s.Prepare(1, testDataForBlk1, nil, nil))
+	assert.NoError(s.Commit())
+
+	// block 2: tx1 purges key1
+	purgeMarkersForBlk2 := []*ledger.PvtDataPurgeMarker{
+		{Namespace: "ns-1", Collection: "coll-1", KeyHash: key1Hash, BlockNum: 2, TxNum: 1},
+	}
+	assert.NoError(s.Prepare(2, nil, nil, purgeMarkersForBlk2))
+	assert.NoError(s.Commit())
+
+	// block 3: tx1 writes key3 and tx2 purges key2
+	testDataForBlk3 := []*ledger.TxPvtData{
+		produceSamplePvtdataWithWrites(t, 1, "ns-1", "coll-1", map[string][]byte{"key3": []byte("value3")}),
+	}
+	purgeMarkersForBlk3 := []*ledger.PvtDataPurgeMarker{
+		{Namespace: "ns-1", Collection: "coll-1", KeyHash: key2Hash, BlockNum: 3, TxNum: 2},
+	}
+	assert.NoError(s.Prepare(3, testDataForBlk3, nil, purgeMarkersForBlk3))
+	assert.NoError(s.Commit())
+
+	// leave a pending batch for block 4 that purges key3
+	purgeMarkersForBlk4 := []*ledger.PvtDataPurgeMarker{
+		{Namespace: "ns-1", Collection: "coll-1", KeyHash: key3Hash, BlockNum: 4, TxNum: 1},
+	}
+	assert.NoError(s.Prepare(4, nil, nil, purgeMarkersForBlk4))
+	env.TestStoreProvider.Close()
+
+	assert.NoError(RollbackToBlock(ledgerID, 2))
+	env.CloseAndReopen()
+	s = env.TestStore
+	testPendingBatch(false, assert, s)
+	testLastCommittedBlockHeight(3, assert, s)
+
+	// the purge marker of block 2 is retained while the ones of block 3 and the pending block 4 are removed
+	purgeHeight, err := s.(*store).getPurgeMarkerHeight(&purgeMarkerKey{"ns-1", "coll-1", key1Hash})
+	assert.NoError(err)
+	assert.Equal(version.NewHeight(2, 1), purgeHeight)
+	purgeHeight, err = s.(*store).getPurgeMarkerHeight(&purgeMarkerKey{"ns-1", "coll-1", key2Hash})
+	assert.NoError(err)
+	assert.Nil(purgeHeight)
+	pendingPurgeMarkers, err := s.(*store).retrievePendingPurgeMarkers()
+	assert.NoError(err)
+	assert.Empty(pendingPurgeMarkers)
+
+	// the hashed index entries of block 3 are removed
+	assert.False(testHashedIndexKeyExists(t, s, &hashedIndexKey{"ns-1", "coll-1", key3Hash, 3, 1}))
+
+	// the rolled back blocks can be committed again and purge as before
+	assert.NoError(s.Prepare(3, testDataForBlk3, nil, purgeMarkersForBlk3))
+	assert.NoError(s.Commit())
+	assert.NoError(s.Prepare(4, nil, nil, purgeMarkersForBlk4))
+	assert.NoError(s.Commit())
+	testLastCommittedBlockHeight(5, assert, s)
+	blk3PvtData, err := s.GetPvtDataByBlockNum(3, nil)
+	assert.NoError(err)
+	assert.Len(blk3PvtData, 1)
+	assert.Nil(testPvtWrites(t, blk3PvtData[0]))
+	purgeHeight, err = s.(*store).getPurgeMarkerHeight(&purgeMarkerKey{"ns-1", "coll-1", key3Hash})
+	assert.NoError(err)
+	assert.Equal(version.NewHeight(4, 1), purgeHeight)
+}
@@ -46,6 +46,10 @@ type Store interface {
 	// GetMissingPvtDataInfoForMostRecentBlocks returns the missing private data information for the
 	// most recent `maxBlock` blocks which miss at least a private data of a eligible collection.
 	GetMissingPvtDataInfoForMostRecentBlocks(maxBlock int) (ledger.MissingPvtDataInfo, error)
+	// GetMissingPvtDataInfoForBlockRange returns the missing private data information for the most
+	// recent `maxBlock` blocks within the range [startBlock, endBlock] which miss at least a private
+	// data of a eligible collection.
+	GetMissingPvtDataInfoForBlockRange(startBlock, endBlock uint64, maxBlock int) (ledger.MissingPvtDataInfo, error)
 	// Prepare prepares the Store for commiting the pvt data and storing both eligible and ineligible
 	// missing private data --- `eligible` denotes that the missing private data belongs to a collection
 	// for which this peer is a member; `ineligible` denotes that the missing private data belong to a
@@ -54,9 +58,13 @@ type Store interface {
 	// is expected to call either `Commit` or `Rollback` function. Return from this should ensure
 	// that enough preparation is done such that `Commit` function invoked afterwards can commit the
 	// data and the store is capable of surviving a crash between this function call and the next
-	// invoke to the `Commit`
-	Prepare(blockNum uint64, pvtData []*ledger.TxPvtData, missingPvtData ledger.TxMissingPvtDataMap) error
-	// Commit commits the pvt data passed in the previous invoke to the `Prepare` function
+	// invoke to the `Commit`. The parameter `purgeMarkers` refers to the pvt data keys that are purged
+	// by the valid transactions in the block
+	Prepare(blockNum uint64, pvtData []*ledger.TxPvtData, missingPvtData ledger.TxMissingPvtDataMap,
+		purgeMarkers []*ledger.PvtDataPurgeMarker) error
+	// Commit commits the pvt data passed in the previous invoke to the `Prepare` function. Along with
+	// this, the writes of the keys that are purged in the block are removed from the pvt data of the
+	// same and the previous blocks
 	Commit() error
 	// Rollback rolls back the pvt data passed in the previous invoke to the `Prepare` function
 	Rollback() error
@@ -7,7 +7,9 @@ SPDX-License-Identifier: Apache-2.0
 package pvtdatastorage
 
 import (
+	"bytes"
 	"fmt"
+	"math"
 	"sort"
 	"sync"
 	"sync/atomic"
@@ -17,6 +19,7 @@ import (
 	"github.com/hyperledger/fabric/common/flogging"
 	"github.com/hyperledger/fabric/common/ledger/util/leveldbhelper"
 	"github.com/hyperledger/fabric/core/ledger"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/version"
 	"github.com/hyperledger/fabric/core/ledger/ledgerconfig"
 	"github.com/hyperledger/fabric/core/ledger/pvtdatapolicy"
 	"github.com/hyperledger/fabric/protos/ledger/rwset"
@@ -83,6 +86,22 @@ type missingDataKey struct {
 	isEligible bool
 }
 
+type hashedIndexKey struct {
+	ns, coll      string
+	keyHash       []byte
+	blkNum, txNum uint64
+}
+
+type purgeMarkerKey struct {
+	ns, coll string
+	keyHash  []byte
+}
+
+type purgeMarkerEntry struct {
+	key    *purgeMarkerKey
+	height *version.Height
+}
+
 type storeEntries struct {
 	dataEntries        []*dataEntry
 	expiryEntries      []*expiryEntry
@@ -162,7 +181,8 @@ func (s *store) Init(btlPolicy pvtdatapolicy.BTLPolicy) {
 }
 
 // Prepare implements the function in the interface `Store`
-func (s *store) Prepare(blockNum uint64, pvtData []*ledger.TxPvtData, missingPvtData ledger.TxMissingPvtDataMap) error {
+func (s *store) Prepare(blockNum uint64, pvtData []*ledger.TxPvtData, missingPvtData ledger.TxMissingPvtDataMap,
+	purgeMarkers []*ledger.PvtDataPurgeMarker) error {
 	if s.batchPending {
 		return &ErrIllegalCall{`A pending batch exists as as result of last invoke to "Prepare" call.
 			 Invoke "Commit" or "Rollback" on the pending batch before invoking "Prepare" function`}
@@ -187,6 +207,7 @@ func (s *store) Prepare(blockNum uint64, pvtData []*ledger.TxPvtData, missingPvt
 			return err
 		}
 		batch.Put(keyBytes, valBytes)
+		addHashedIndexEntriesToUpdateBatch(batch, dataEntry)
 	}
 
 	for _, expiryEntry := range storeEntries.expiryEntries {
@@ -205,6 +226,14 @@ func (s *store) Prepare(blockNum uint64, pvtData []*ledger.TxPvtData, missingPvt
 		batch.Put(keyBytes, valBytes)
 	}
 
+	// the purge markers are kept aside till the commit, as the writes of the purged
+	// keys are removed only when the block is committed
+	for _, purgeMarker := range purgeMarkers {
+		key := &purgeMarkerKey{purgeMarker.Namespace, purgeMarker.Collection, purgeMarker.KeyHash}
+		height := version.NewHeight(purgeMarker.BlockNum, purgeMarker.TxNum)
+		batch.Put(encodePendingPurgeMarkerKey(key), encodePurgeMarkerValue(height))
+	}
+
 	batch.Put(pendingCommitKey, emptyValue)
 	if err := s.db.WriteBatch(batch, true); err != nil {
 		return err
@@ -222,6 +251,21 @@ func (s *store) Commit() error {
 	committingBlockNum := s.nextBlockNum()
 	logger.Debugf("Committing private data for block [%d]", committingBlockNum)
 	batch := leveldbhelper.NewUpdateBatch()
+	pendingPurgeMarkers, err := s.retrievePendingPurgeMarkers()
+	if err != nil {
+		return err
+	}
+	if len(pendingPurgeMarkers) > 0 {
+		// the purger lock ensures that the expiry based purger does not run
+		// concurrently on the pvt data from which the purged keys are removed
+		s.purgerLock.Lock()
+		defer s.purgerLock.Unlock()
+		if err := s.addPurgeUpdatesToBatch(batch, pendingPurgeMarkers); err != nil {
+			return err
+		}
+		logger.Debugf("Removing the writes of [%d] purged keys from private data for block [%d]",
+			len(pendingPurgeMarkers), committingBlockNum)
+	}
 	batch.Delete(pendingCommitKey)
 	batch.Put(lastCommittedBlkkey, encodeLastCommittedBlockVal(committingBlockNum))
 	if err := s.db.WriteBatch(batch, true); err != nil {
@@ -242,7 +286,7 @@ func (s *store) Commit() error {
 // existing expiry entires as is because, most likely they will also get overwritten
 // per new data entries. Even if some of the expiry entries does not get overwritten,
 // (beacuse of some data may be missing next time), the additional expiry entries are just
-// a Noop
+// a Noop. The hashed index entries of the data entries and the pending purge markers are deleted as well.
 func (s *store) Rollback() error {
 	if !s.batchPending {
 		return &ErrIllegalCall{"No pending batch to rollback"}
@@ -251,7 +295,12 @@ func (s *store) Rollback() error {
 	batch := leveldbhelper.NewUpdateBatch()
 	itr := s.db.GetIterator(datakeyRange(blkNum))
 	for itr.Next() {
-		batch.Delete(itr.Key())
+		dataKeyBytes := itr.Key()
+		if err := s.addHashedIndexDeletesToUpdateBatch(batch, dataKeyBytes, itr.Value()); err != nil {
+			itr.Release()
+			return err
+		}
+		batch.Delete(dataKeyBytes)
 	}
 	itr.Release()
 	itr = s.db.GetIterator(eligibleMissingdatakeyRange(blkNum))
@@ -259,6 +308,11 @@ func (s *store) Rollback() error {
 		batch.Delete(itr.Key())
 	}
 	itr.Release()
+	itr = s.db.GetIterator(getPendingPurgeMarkerKeysForRangeScan())
+	for itr.Next() {
+		batch.Delete(itr.Key())
+	}
+	itr.Release()
 	batch.Delete(pendingCommitKey)
 	if err := s.db.WriteBatch(batch, true); err != nil {
 		return err
@@ -329,6 +383,12 @@ func (s *store) constructUpdateEntriesFromDataEntries(dataEntries []*dataEntry)
 	// Second, update the expiryData and missingData as per the data entry. Finally, add
 	// the data entry along with the updated expiryData and missingData to the update entries
 	for _, dataEntry := range dataEntries {
+		// remove the writes of the keys that have been purged after this pvtData was committed
+		dataEntry, err := s.removePurgedWritesFromDataEntry(dataEntry)
+		if err != nil {
+			return nil, err
+		}
+
 		// get the expiryBlk number to construct the expiryKey
 		expiryKey, err := s.constructExpiryKeyFromDataEntry(dataEntry)
 		if err != nil {
@@ -471,6 +531,8 @@ func addNewDataEntriesToUpdateBatch(batch *leveldbhelper.UpdateBatch, entries *e
 			return err
 		}
 		batch.Put(keyBytes, valBytes)
+		dataKey := dataKey
+		addHashedIndexEntriesToUpdateBatch(batch, &dataEntry{key: &dataKey, value: pvtData})
 	}
 	return nil
 }
@@ -681,7 +743,12 @@ func (s *store) InitLastCommittedBlock(blockNum uint64) error {
 func (s *store) GetMissingPvtDataInfoForMostRecentBlocks(maxBlock int) (ledger.MissingPvtDataInfo, error) {
 	// we assume that this function would be called by the gossip only after processing the
 	// last retrieved missing pvtdata info and committing the same.
-	if maxBlock < 1 {
+	return s.GetMissingPvtDataInfoForBlockRange(0, math.MaxUint64, maxBlock)
+}
+
+// GetMissingPvtDataInfoForBlockRange implements the function in the interface `Store`
+func (s *store) GetMissingPvtDataInfoForBlockRange(startBlock, endBlock uint64, maxBlock int) (ledger.MissingPvtDataInfo, error) {
+	if maxBlock < 1 || startBlock > endBlock {
 		return nil, nil
 	}
 
@@ -693,8 +760,11 @@ func (s *store) GetMissingPvtDataInfoForMostRecentBlocks(maxBlock int) (ledger.M
 	// construct the MissingPvtDataInfo. As a result, lastCommittedBlock can get
 	// changed. To ensure consistency, we atomically load the lastCommittedBlock value
 	lastCommittedBlock := atomic.LoadUint64(&s.lastCommittedBlock)
+	if endBlock > lastCommittedBlock {
+		endBlock = lastCommittedBlock
+	}
 
-	startKey, endKey := createRangeScanKeysForEligibleMissingDataEntries(lastCommittedBlock)
+	startKey, endKey := createRangeScanKeysForEligibleMissingDataEntries(endBlock)
 	dbItr := s.db.GetIterator(startKey, endKey)
 	defer dbItr.Release()
 
@@ -702,6 +772,11 @@ func (s *store) GetMissingPvtDataInfoForMostRecentBlocks(maxBlock int) (ledger.M
 		missingDataKeyBytes := dbItr.Key()
 		missingDataKey := decodeMissingDataKey(missingDataKeyBytes)
 
+		if missingDataKey.blkNum < startBlock {
+			// entries are sorted in the decreasing order of block numbers
+			break
+		}
+
 		if isMaxBlockLimitReached && (missingDataKey.blkNum != lastProcessedBlock) {
 			// esnures that exactly maxBlock number
 			// of blocks' entries are processed
@@ -799,7 +874,17 @@ func (s *store) purgeExpiredData(minBlkNum, maxBlkNum uint64) error {
 		batch.Delete(encodeExpiryKey(expiryEntry.key))
 		dataKeys, missingDataKeys := deriveKeys(expiryEntry)
 		for _, dataKey := range dataKeys {
-			batch.Delete(encodeDataKey(dataKey))
+			dataKeyBytes := encodeDataKey(dataKey)
+			dataValueBytes, err := s.db.Get(dataKeyBytes)
+			if err != nil {
+				return err
+			}
+			if dataValueBytes != nil {
+				if err := s.addHashedIndexDeletesToUpdateBatch(batch, dataKeyBytes, dataValueBytes); err != nil {
+					return err
+				}
+			}
+			batch.Delete(dataKeyBytes)
 		}
 		for _, missingDataKey := range missingDataKeys {
 			batch.Delete(encodeMissingDataKey(missingDataKey))
@@ -810,6 +895,127 @@ func (s *store) purgeExpiredData(minBlkNum, maxBlkNum uint64) error {
 	return nil
 }
 
+func (s *store) retrievePendingPurgeMarkers() ([]*purgeMarkerEntry, error) {
+	itr := s.db.GetIterator(getPendingPurgeMarkerKeysForRangeScan())
+	defer itr.Release()
+
+	var purgeMarkerEntries []*purgeMarkerEntry
+	for itr.Next() {
+		purgeMarkerEntries = append(purgeMarkerEntries, &purgeMarkerEntry{
+			key:    decodePurgeMarkerKey(itr.Key()),
+			height: decodePurgeMarkerValue(itr.Value()),
+		})
+	}
+	return purgeMarkerEntries, nil
+}
+
+// addPurgeUpdatesToBatch adds to the batch the updates for removing the writes of the purged keys from
+// the pvt data committed before the purging transaction. The writes are located via the hashed index and
+// the purge markers are moved from pending to committed, keeping the highest purge height per key.
+// The write of the purging transaction itself (a delete) is retained so that it can still be served for
+// the reconciliation of the purging transaction's pvt data
+func (s *store) addPurgeUpdatesToBatch(batch *leveldbhelper.UpdateBatch, purgeMarkerEntries []*purgeMarkerEntry) error {
+	updatedDataEntries := make(map[dataKey]*rwset.CollectionPvtReadWriteSet)
+	for _, purgeMarkerEntry := range purgeMarkerEntries {
+		keyHash := purgeMarkerEntry.key.keyHash
+		isPurgedKey := func(writeKeyHash []byte) (bool, error) {
+			return bytes.Equal(writeKeyHash, keyHash), nil
+		}
+
+		itr := s.db.GetIterator(getHashedIndexKeysForRangeScan(purgeMarkerEntry.key, purgeMarkerEntry.height))
+		for itr.Next() {
+			indexKey := decodeHashedIndexKey(itr.Key())
+			key := dataKey{nsCollBlk{indexKey.ns, indexKey.coll, indexKey.blkNum}, indexKey.txNum}
+			batch.Delete(itr.Key())
+
+			collPvtdata, ok := updatedDataEntries[key]
+			if !ok {
+				dataValueBytes, err := s.db.Get(encodeDataKey(&key))
+				if err != nil {
+					itr.Release()
+					return err
+				}
+				if dataValueBytes == nil {
+					// stale index entry
+					continue
+				}
+				if collPvtdata, err = decodeDataValue(dataValueBytes); err != nil {
+					itr.Release()
+					return err
+				}
+			}
+			collPvtdata, err := removePvtWrites(collPvtdata, isPurgedKey)
+			if err != nil {
+				itr.Release()
+				return err
+			}
+			updatedDataEntries[key] = collPvtdata
+		}
+		itr.Release()
+
+		committedHeight, err := s.getPurgeMarkerHeight(purgeMarkerEntry.key)
+		if err != nil {
+			return err
+		}
+		if committedHeight == nil || committedHeight.Compare(purgeMarkerEntry.height) < 0 {
+			batch.Put(encodePurgeMarkerKey(purgeMarkerEntry.key), encodePurgeMarkerValue(purgeMarkerEntry.height))
+		}
+		batch.Delete(encodePendingPurgeMarkerKey(purgeMarkerEntry.key))
+	}
+
+	for key, collPvtdata := range updatedDataEntries {
+		valBytes, err := encodeDataValue(collPvtdata)
+		if err != nil {
+			return err
+		}
+		batch.Put(encodeDataKey(&key), valBytes)
+	}
+	return nil
+}
+
+// removePurgedWritesFromDataEntry returns a dataEntry without the writes of the keys that are purged
+// by a transaction committed after the dataEntry
+func (s *store) removePurgedWritesFromDataEntry(entry *dataEntry) (*dataEntry, error) {
+	height := version.NewHeight(entry.key.blkNum, entry.key.txNum)
+	isPurgedKey := func(keyHash []byte) (bool, error) {
+		purgeHeight, err := s.getPurgeMarkerHeight(&purgeMarkerKey{entry.key.ns, entry.key.coll, keyHash})
+		if err != nil {
+			return false, err
+		}
+		return purgeHeight != nil && purgeHeight.Compare(height) > 0, nil
+	}
+	collPvtdata, err := removePvtWrites(entry.value, isPurgedKey)
+	if err != nil {
+		return nil, err
+	}
+	return &dataEntry{key: entry.key, value: collPvtdata}, nil
+}
+
+func (s *store) getPurgeMarkerHeight(key *purgeMarkerKey) (*version.Height, error) {
+	v, err := s.db.Get(encodePurgeMarkerKey(key))
+	if err != nil || v == nil {
+		return nil, err
+	}
+	return decodePurgeMarkerValue(v), nil
+}
+
+func (s *store) addHashedIndexDeletesToUpdateBatch(batch *leveldbhelper.UpdateBatch, dataKeyBytes, dataValueBytes []byte) error {
+	dataValue, err := decodeDataValue(dataValueBytes)
+	if err != nil {
+		return err
+	}
+	for _, hashedIndexKey := range deriveHashedIndexKeys(&dataEntry{key: decodeDatakey(dataKeyBytes), value: dataValue}) {
+		batch.Delete(encodeHashedIndexKey(hashedIndexKey))
+	}
+	return nil
+}
+
+func addHashedIndexEntriesToUpdateBatch(batch *leveldbhelper.UpdateBatch, dataEntry *dataEntry) {
+	for _, hashedIndexKey := range deriveHashedIndexKeys(dataEntry) {
+		batch.Put(encodeHashedIndexKey(hashedIndexKey), emptyValue)
+	}
+}
+
 func (s *store) retrieveExpiryEntries(minBlkNum, maxBlkNum uint64) ([]*expiryEntry, error) {
 	startKey, endKey := getExpiryKeysForRangeScan(minBlkNum, maxBlkNum)
 	logger.Debugf("retrieveExpiryEntries(): startKey=%#v, endKey=%#v", startKey, endKey)
@@ -19,6 +19,8 @@ import (
 	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/rwsetutil"
 	"github.com/hyperledger/fabric/core/ledger/ledgerconfig"
 	btltestutil "github.com/hyperledger/fabric/core/ledger/pvtdatapolicy/testutil"
+	"github.com/hyperledger/fabric/core/ledger/util"
+	"github.com/hyperledger/fabric/protos/ledger/rwset/kvrwset"
 	"github.com/spf13/viper"
 	"github.com/stretchr/testify/assert"
 )
@@ -83,15 +85,15 @@ func TestStoreBasicCommitAndRetrieval(t *testing.T) {
 	blk2MissingData.Add(3, "ns-1", "coll-1", true)
 
 	// no pvt data with block 0
-	assert.NoError(store.Prepare(0, nil, nil))
+	assert.NoError(store.Prepare(0, nil, nil, nil))
 	assert.NoError(store.Commit())
 
 	// pvt data with block 1 - commit
-	assert.NoError(store.Prepare(1, testData, blk1MissingData))
+	assert.NoError(store.Prepare(1, testData, blk1MissingData, nil))
 	assert.NoError(store.Commit())
 
 	// pvt data with block 2 - rollback
-	assert.NoError(store.Prepare(2, testData, nil))
+	assert.NoError(store.Prepare(2, testData, nil, nil))
 	assert.NoError(store.Rollback())
 
 	// pvt data retrieval for block 0 should return nil
@@ -129,7 +131,7 @@ func TestStoreBasicCommitAndRetrieval(t *testing.T) {
 	assert.Nil(retrievedData)
 
 	// pvt data with block 2 - commit
-	assert.NoError(store.Prepare(2, testData, blk2MissingData))
+	assert.NoError(store.Prepare(2, testData, blk2MissingData, nil))
 	assert.NoError(store.Commit())
 
 	// retrieve the stored missing entries using GetMissingPvtDataInfoForMostRecentBlocks
@@ -162,6 +164,29 @@ func TestStoreBasicCommitAndRetrieval(t *testing.T) {
 	missingPvtDataInfo, err = store.GetMissingPvtDataInfoForMostRecentBlocks(10)
 	assert.NoError(err)
 	assert.Equal(expectedMissingPvtDataInfo, missingPvtDataInfo)
+
+	// retrieve the stored missing entries using GetMissingPvtDataInfoForBlockRange
+	missingPvtDataInfo, err = store.GetMissingPvtDataInfoForBlockRange(0, 100, 10)
+	assert.NoError(err)
+	assert.Equal(expectedMissingPvtDataInfo, missingPvtDataInfo)
+
+	expectedBlk1MissingPvtDataInfo := ledger.MissingPvtDataInfo{1: expectedMissingPvtDataInfo[1]}
+	missingPvtDataInfo, err = store.GetMissingPvtDataInfoForBlockRange(0, 1, 10)
+	assert.NoError(err)
+	assert.Equal(expectedBlk1MissingPvtDataInfo, missingPvtDataInfo)
+
+	expectedBlk2MissingPvtDataInfo := ledger.MissingPvtDataInfo{2: expectedMissingPvtDataInfo[2]}
+	missingPvtDataInfo, err = store.GetMissingPvtDataInfoForBlockRange(2, 2, 10)
+	assert.NoError(err)
+	assert.Equal(expectedBlk2MissingPvtDataInfo, missingPvtDataInfo)
+
+	missingPvtDataInfo, err = store.GetMissingPvtDataInfoForBlockRange(1, 2, 1)
+	assert.NoError(err)
+	assert.Equal(expectedBlk2MissingPvtDataInfo, missingPvtDataInfo)
+
+	missingPvtDataInfo, err = store.GetMissingPvtDataInfoForBlockRange(2, 1, 10)
+	assert.NoError(err)
+	assert.Len(missingPvtDataInfo, 0)
 }
 
 func TestCommitPvtDataOfOldBlocks(t *testing.T) {
@@ -211,15 +236,15 @@ func TestCommitPvtDataOfOldBlocks(t *testing.T) {
 	blk2MissingData.Add(3, "ns-1", "coll-1", true)
 
 	// COMMIT BLOCK 0 WITH NO DATA
-	assert.NoError(store.Prepare(0, nil, nil))
+	assert.NoError(store.Prepare(0, nil, nil, nil))
 	assert.NoError(store.Commit())
 
 	// COMMIT BLOCK 1 WITH PVTDATA AND MISSINGDATA
-	assert.NoError(store.Prepare(1, testData, blk1MissingData))
+	assert.NoError(store.Prepare(1, testData, blk1MissingData, nil))
 	assert.NoError(store.Commit())
 
 	// COMMIT BLOCK 2 WITH PVTDATA AND MISSINGDATA
-	assert.NoError(store.Prepare(2, nil, blk2MissingData))
+	assert.NoError(store.Prepare(2, nil, blk2MissingData, nil))
 	assert.NoError(store.Commit())
 
 	// CHECK MISSINGDATA ENTRIES ARE CORRECTLY STORED
@@ -322,7 +347,7 @@ func TestCommitPvtDataOfOldBlocks(t *testing.T) {
 	assert.Nil(blksPvtData)
 
 	// COMMIT BLOCK 3 WITH NO PVTDATA
-	assert.NoError(store.Prepare(3, nil, nil))
+	assert.NoError(store.Prepare(3, nil, nil, nil))
 	assert.NoError(store.Commit())
 
 	// IN BLOCK 1, NS-1:COLL-2 AND NS-2:COLL-2 SHOULD HAVE EXPIRED BUT NOT PURGED
@@ -354,7 +379,7 @@ func TestCommitPvtDataOfOldBlocks(t *testing.T) {
 	assert.NoError(err)
 
 	// COMMIT BLOCK 4 WITH NO PVTDATA
-	assert.NoError(store.Prepare(4, nil, nil))
+	assert.NoError(store.Prepare(4, nil, nil, nil))
 	assert.NoError(store.Commit())
 
 	testWaitForPurgerRoutineToFinish(store)
@@ -416,7 +441,7 @@ func TestExpiryDataNotIncluded(t *testing.T) {
 	blk2MissingData.Add(1, "ns-1", "coll-2", true)
 
 	// no pvt data with block 0
-	assert.NoError(store.Prepare(0, nil, nil))
+	assert.NoError(store.Prepare(0, nil, nil, nil))
 	assert.NoError(store.Commit())
 
 	// write pvt data for block 1
@@ -424,7 +449,7 @@ func TestExpiryDataNotIncluded(t *testing.T) {
 		produceSamplePvtdata(t, 2, []string{"ns-1:coll-1", "ns-1:coll-2", "ns-2:coll-1", "ns-2:coll-2"}),
 		produceSamplePvtdata(t, 4, []string{"ns-1:coll-1", "ns-1:coll-2", "ns-2:coll-1", "ns-2:coll-2"}),
 	}
-	assert.NoError(store.Prepare(1, testDataForBlk1, blk1MissingData))
+	assert.NoError(store.Prepare(1, testDataForBlk1, blk1MissingData, nil))
 	assert.NoError(store.Commit())
 
 	// write pvt data for block 2
@@ -432,7 +457,7 @@ func TestExpiryDataNotIncluded(t *testing.T) {
 		produceSamplePvtdata(t, 3, []string{"ns-1:coll-1", "ns-1:coll-2", "ns-2:coll-1", "ns-2:coll-2"}),
 		produceSamplePvtdata(t, 5, []string{"ns-1:coll-1", "ns-1:coll-2", "ns-2:coll-1", "ns-2:coll-2"}),
 	}
-	assert.NoError(store.Prepare(2, testDataForBlk2, blk2MissingData))
+	assert.NoError(store.Prepare(2, testDataForBlk2, blk2MissingData, nil))
 	assert.NoError(store.Commit())
 
 	retrievedData, _ := store.GetPvtDataByBlockNum(1, nil)
@@ -457,7 +482,7 @@ func TestExpiryDataNotIncluded(t *testing.T) {
 	assert.Equal(expectedMissingPvtDataInfo, missingPvtDataInfo)
 
 	// Commit block 3 with no pvtdata
-	assert.NoError(store.Prepare(3, nil, nil))
+	assert.NoError(store.Prepare(3, nil, nil, nil))
 	assert.NoError(store.Commit())
 
 	// After committing block 3, the data for "ns-1:coll1" of block 1 should have expired and should not be returned by the store
@@ -481,7 +506,7 @@ func TestExpiryDataNotIncluded(t *testing.T) {
 	assert.Equal(expectedMissingPvtDataInfo, missingPvtDataInfo)
 
 	// Commit block 4 with no pvtdata
-	assert.NoError(store.Prepare(4, nil, nil))
+	assert.NoError(store.Prepare(4, nil, nil, nil))
 	assert.NoError(store.Commit())
 
 	// After committing block 4, the data for "ns-2:coll2" of block 1 should also have expired and should not be returned by the store
@@ -533,7 +558,7 @@ func TestStorePurge(t *testing.T) {
 	s := env.TestStore
 
 	// no pvt data with block 0
-	assert.NoError(s.Prepare(0, nil, nil))
+	assert.NoError(s.Prepare(0, nil, nil, nil))
 	assert.NoError(s.Commit())
 
 	// construct missing data for block 1
@@ -550,11 +575,11 @@ func TestStorePurge(t *testing.T) {
 		produceSamplePvtdata(t, 2, []string{"ns-1:coll-1", "ns-1:coll-2", "ns-2:coll-1", "ns-2:coll-2"}),
 		produceSamplePvtdata(t, 4, []string{"ns-1:coll-1", "ns-1:coll-2", "ns-2:coll-1", "ns-2:coll-2"}),
 	}
-	assert.NoError(s.Prepare(1, testDataForBlk1, blk1MissingData))
+	assert.NoError(s.Prepare(1, testDataForBlk1, blk1MissingData, nil))
 	assert.NoError(s.Commit())
 
 	// write pvt data for block 2
-	assert.NoError(s.Prepare(2, nil, nil))
+	assert.NoError(s.Prepare(2, nil, nil, nil))
 	assert.NoError(s.Commit())
 	// data for ns-1:coll-1 and ns-2:coll-2 should exist in store
 	ns1Coll1 := &dataKey{nsCollBlk: nsCollBlk{ns: "ns-1", coll: "coll-1", blkNum: 1}, txNum: 2}
@@ -579,7 +604,7 @@ func TestStorePurge(t *testing.T) {
 	assert.True(testMissingDataKeyExists(t, s, ns3Coll2inelgMD))
 
 	// write pvt data for block 3
-	assert.NoError(s.Prepare(3, nil, nil))
+	assert.NoError(s.Prepare(3, nil, nil, nil))
 	assert.NoError(s.Commit())
 	// data for ns-1:coll-1 and ns-2:coll-2 should exist in store (because purger should not be launched at block 3)
 	testWaitForPurgerRoutineToFinish(s)
@@ -593,13 +618,16 @@ func TestStorePurge(t *testing.T) {
 	assert.True(testMissingDataKeyExists(t, s, ns3Coll2inelgMD))
 
 	// write pvt data for block 4
-	assert.NoError(s.Prepare(4, nil, nil))
+	assert.NoError(s.Prepare(4, nil, nil, nil))
 	assert.NoError(s.Commit())
 	// data for ns-1:coll-1 should not exist in store (because purger should be launched at block 4)
 	// but ns-2:coll-2 should exist because it expires at block 5
 	testWaitForPurgerRoutineToFinish(s)
 	assert.False(testDataKeyExists(t, s, ns1Coll1))
 	assert.True(testDataKeyExists(t, s, ns2Coll2))
+	// the hashed index entry of the expired data should have been purged as well
+	assert.False(testHashedIndexKeyExists(t, s, &hashedIndexKey{ns: "ns-1", coll: "coll-1",
+		keyHash: util.ComputeStringHash("key-ns-1-coll-1"), blkNum: 1, txNum: 2}))
 	// eligible missingData entries for ns-1:coll-1 should have expired and ns-1:coll-2 (neverExpires) should exist in store
 	assert.False(testMissingDataKeyExists(t, s, ns1Coll1elgMD))
 	assert.True(testMissingDataKeyExists(t, s, ns1Coll2elgMD))
@@ -608,7 +636,7 @@ func TestStorePurge(t *testing.T) {
 	assert.True(testMissingDataKeyExists(t, s, ns3Coll2inelgMD))
 
 	// write pvt data for block 5
-	assert.NoError(s.Prepare(5, nil, nil))
+	assert.NoError(s.Prepare(5, nil, nil, nil))
 	assert.NoError(s.Commit())
 	// ns-2:coll-2 should exist because though the data expires at block 5 but purger is launched every second block
 	testWaitForPurgerRoutineToFinish(s)
@@ -616,7 +644,7 @@ func TestStorePurge(t *testing.T) {
 	assert.True(testDataKeyExists(t, s, ns2Coll2))
 
 	// write pvt data for block 6
-	assert.NoError(s.Prepare(6, nil, nil))
+	assert.NoError(s.Prepare(6, nil, nil, nil))
 	assert.NoError(s.Commit())
 	// ns-2:coll-2 should not exists now (because purger should be launched at block 6)
 	testWaitForPurgerRoutineToFinish(s)
@@ -627,6 +655,100 @@ func TestStorePurge(t *testing.T) {
 	assert.True(testDataKeyExists(t, s, &dataKey{nsCollBlk: nsCollBlk{ns: "ns-1", coll: "coll-2", blkNum: 1}, txNum: 2}))
 }
 
+func TestStorePurgeOfPurgedKeys(t *testing.T) {
+	btlPolicy := btltestutil.SampleBTLPolicy(
+		map[[2]string]uint64{
+			{"ns-1", "coll-1"}: 0,
+		},
+	)
+	env := NewTestStoreEnv(t, "TestStorePurgeOfPurgedKeys", btlPolicy)
+	defer env.Cleanup()
+	assert := assert.New(t)
+	s := env.TestStore
+	key1Hash := util.ComputeStringHash("key1")
+
+	assert.NoError(s.Prepare(0, nil, nil, nil))
+	assert.NoError(s.Commit())
+
+	// block 1: tx1 writes key1 and key2 and the pvt data of tx2 is missing
+	blk1MissingData := make(ledger.TxMissingPvtDataMap)
+	blk1MissingData.Add(2, "ns-1", "coll-1", true)
+	testDataForBlk1 := []*ledger.TxPvtData{
+		produceSamplePvtdataWithWrites(t, 1, "ns-1", "coll-1", map[string][]byte{"key1": []byte("value1"), "key2": []byte("value2")}),
+	}
+	assert.NoError(s.Prepare(1, testDataForBlk1, blk1MissingData, nil))
+	assert.NoError(s.Commit())
+
+	// block 2: tx1 writes key1, tx3 purges key1, and tx5 writes key1 again
+	builder := rwsetutil.NewRWSetBuilder()
+	builder.AddToPvtAndHashedWriteSetForPurge("ns-1", "coll-1", "key1")
+	simRes, err := builder.GetTxSimulationResults()
+	assert.NoError(err)
+	testDataForBlk2 := []*ledger.TxPvtData{
+		produceSamplePvtdataWithWrites(t, 1, "ns-1", "coll-1", map[string][]byte{"key1": []byte("value1-tx1")}),
+		{SeqInBlock: 3, WriteSet: simRes.PvtSimulationResults},
+		produceSamplePvtdataWithWrites(t, 5, "ns-1", "coll-1", map[string][]byte{"key1": []byte("value1-tx5")}),
+	}
+	purgeMarkers := []*ledger.PvtDataPurgeMarker{
+		{Namespace: "ns-1", Collection: "coll-1", KeyHash: key1Hash, BlockNum: 2, TxNum: 3},
+	}
+	assert.NoError(s.Prepare(2, testDataForBlk2, nil, purgeMarkers))
+	assert.NoError(s.Commit())
+
+	// the writes of key1 before the purging transaction are removed
+	blk1PvtData, err := s.GetPvtDataByBlockNum(1, nil)
+	assert.NoError(err)
+	assert.Equal(
+		[]*kvrwset.KVWrite{{Key: "key2", Value: []byte("value2")}},
+		testPvtWrites(t, blk1PvtData[0]),
+	)
+	blk2PvtData, err := s.GetPvtDataByBlockNum(2, nil)
+	assert.NoError(err)
+	assert.Len(blk2PvtData, 3)
+	assert.Nil(testPvtWrites(t, blk2PvtData[0]))
+	assert.Equal([]*kvrwset.KVWrite{{Key: "key1", IsDelete: true}}, testPvtWrites(t, blk2PvtData[1]))
+	assert.Equal([]*kvrwset.KVWrite{{Key: "key1", Value: []byte("value1-tx5")}}, testPvtWrites(t, blk2PvtData[2]))
+
+	assert.False(testHashedIndexKeyExists(t, s, &hashedIndexKey{"ns-1", "coll-1", key1Hash, 1, 1}))
+	assert.False(testHashedIndexKeyExists(t, s, &hashedIndexKey{"ns-1", "coll-1", key1Hash, 2, 1}))
+	assert.True(testHashedIndexKeyExists(t, s, &hashedIndexKey{"ns-1", "coll-1", key1Hash, 2, 3}))
+	assert.True(testHashedIndexKeyExists(t, s, &hashedIndexKey{"ns-1", "coll-1", key1Hash, 2, 5}))
+	assert.True(testHashedIndexKeyExists(t, s, &hashedIndexKey{"ns-1", "coll-1", util.ComputeStringHash("key2"), 1, 1}))
+
+	// the missing pvt data of an old block does not bring back the purged key
+	oldBlocksPvtData := map[uint64][]*ledger.TxPvtData{
+		1: {
+			produceSamplePvtdataWithWrites(t, 2, "ns-1", "coll-1", map[string][]byte{"key1": []byte("value1-blk1-tx2"), "key3": []byte("value3")}),
+		},
+	}
+	assert.NoError(s.CommitPvtDataOfOldBlocks(oldBlocksPvtData))
+	assert.NoError(s.ResetLastUpdatedOldBlocksList())
+	blk1PvtData, err = s.GetPvtDataByBlockNum(1, nil)
+	assert.NoError(err)
+	assert.Len(blk1PvtData, 2)
+	assert.Equal([]*kvrwset.KVWrite{{Key: "key3", Value: []byte("value3")}}, testPvtWrites(t, blk1PvtData[1]))
+	assert.True(testHashedIndexKeyExists(t, s, &hashedIndexKey{"ns-1", "coll-1", util.ComputeStringHash("key3"), 1, 2}))
+
+	// a rolled back block neither purges nor leaves its index entries behind
+	testDataForBlk3 := []*ledger.TxPvtData{
+		produceSamplePvtdataWithWrites(t, 1, "ns-1", "coll-1", map[string][]byte{"key4": []byte("value4")}),
+	}
+	purgeMarkers = []*ledger.PvtDataPurgeMarker{
+		{Namespace: "ns-1", Collection: "coll-1", KeyHash: util.ComputeStringHash("key2"), BlockNum: 3, TxNum: 2},
+	}
+	assert.NoError(s.Prepare(3, testDataForBlk3, nil, purgeMarkers))
+	assert.NoError(s.Rollback())
+	assert.False(testHashedIndexKeyExists(t, s, &hashedIndexKey{"ns-1", "coll-1", util.ComputeStringHash("key4"), 3, 1}))
+	assert.NoError(s.Prepare(3, nil, nil, nil))
+	assert.NoError(s.Commit())
+	blk1PvtData, err = s.GetPvtDataByBlockNum(1, nil)
+	assert.NoError(err)
+	assert.Equal(
+		[]*kvrwset.KVWrite{{Key: "key2", Value: []byte("value2")}},
+		testPvtWrites(t, blk1PvtData[0]),
+	)
+}
+
 func TestStoreState(t *testing.T) {
 	btlPolicy := btltestutil.SampleBTLPolicy(
 		map[[2]string]uint64{
@@ -641,14 +763,14 @@ func TestStoreState(t *testing.T) {
 	testData := []*ledger.TxPvtData{
 		produceSamplePvtdata(t, 0, []string{"ns-1:coll-1", "ns-1:coll-2"}),
 	}
-	_, ok := store.Prepare(1, testData, nil).(*ErrIllegalArgs)
+	_, ok := store.Prepare(1, testData, nil, nil).(*ErrIllegalArgs)
 	assert.True(ok)
 
-	assert.Nil(store.Prepare(0, testData, nil))
+	assert.Nil(store.Prepare(0, testData, nil, nil))
 	assert.NoError(store.Commit())
 
-	assert.Nil(store.Prepare(1, testData, nil))
-	_, ok = store.Prepare(2, testData, nil).(*ErrIllegalCall)
+	assert.Nil(store.Prepare(1, testData, nil, nil))
+	_, ok = store.Prepare(2, testData, nil, nil).(*ErrIllegalCall)
 	assert.True(ok)
 }
 
@@ -705,7 +827,7 @@ func testCollElgEnabled(t *testing.T) {
 	// Initial state: eligible for {ns-1:coll-1 and ns-2:coll-1 }
 
 	// no pvt data with block 0
-	assert.NoError(store.Prepare(0, nil, nil))
+	assert.NoError(store.Prepare(0, nil, nil, nil))
 	assert.NoError(store.Commit())
 
 	// construct and commit block 1
@@ -717,7 +839,7 @@ func testCollElgEnabled(t *testing.T) {
 	testDataForBlk1 := []*ledger.TxPvtData{
 		produceSamplePvtdata(t, 2, []string{"ns-1:coll-1"}),
 	}
-	assert.NoError(store.Prepare(1, testDataForBlk1, blk1MissingData))
+	assert.NoError(store.Prepare(1, testDataForBlk1, blk1MissingData, nil))
 	assert.NoError(store.Commit())
 
 	// construct and commit block 2
@@ -728,7 +850,7 @@ func testCollElgEnabled(t *testing.T) {
 	testDataForBlk2 := []*ledger.TxPvtData{
 		produceSamplePvtdata(t, 3, []string{"ns-1:coll-1"}),
 	}
-	assert.NoError(store.Prepare(2, testDataForBlk2, blk2MissingData))
+	assert.NoError(store.Prepare(2, testDataForBlk2, blk2MissingData, nil))
 	assert.NoError(store.Commit())
 
 	// Retrieve and verify missing data reported
@@ -784,7 +906,7 @@ func TestRollBack(t *testing.T) {
 	defer env.Cleanup()
 	assert := assert.New(t)
 	store := env.TestStore
-	assert.NoError(store.Prepare(0, nil, nil))
+	assert.NoError(store.Prepare(0, nil, nil, nil))
 	assert.NoError(store.Commit())
 
 	pvtdata := []*ledger.TxPvtData{
@@ -797,7 +919,7 @@ func TestRollBack(t *testing.T) {
 	missingData.Add(5, "ns-2", "coll-2", false)
 
 	for i := 1; i <= 9; i++ {
-		assert.NoError(store.Prepare(uint64(i), pvtdata, missingData))
+		assert.NoError(store.Prepare(uint64(i), pvtdata, missingData, nil))
 		assert.NoError(store.Commit())
 	}
 
@@ -819,7 +941,7 @@ func TestRollBack(t *testing.T) {
 	testLastCommittedBlockHeight(10, assert, store)
 
 	// prepare for block 10 and test store for presence of datakeys and eligibile missingdatakeys
-	assert.NoError(store.Prepare(10, pvtdata, missingData))
+	assert.NoError(store.Prepare(10, pvtdata, missingData, nil))
 	testPendingBatch(true, assert, store)
 	testLastCommittedBlockHeight(10, assert, store)
 
@@ -883,6 +1005,12 @@ func testMissingDataKeyExists(t *testing.T, s Store, missingDataKey *missingData
 	return len(val) != 0
 }
 
+func testHashedIndexKeyExists(t *testing.T, s Store, hashedIndexKey *hashedIndexKey) bool {
+	val, err := s.(*store).db.Get(encodeHashedIndexKey(hashedIndexKey))
+	assert.NoError(t, err)
+	return val != nil
+}
+
 func testWaitForPurgerRoutineToFinish(s Store) {
 	time.Sleep(1 * time.Second)
 	s.(*store).purgerLock.Lock()
@@ -905,3 +1033,19 @@ func produceSamplePvtdata(t *testing.T, txNum uint64, nsColls []string) *ledger.
 	assert.NoError(t, err)
 	return &ledger.TxPvtData{SeqInBlock: txNum, WriteSet: simRes.PvtSimulationResults}
 }
+
+func produceSamplePvtdataWithWrites(t *testing.T, txNum uint64, ns, coll string, kvs map[string][]byte) *ledger.TxPvtData {
+	builder := rwsetutil.NewRWSetBuilder()
+	for key, value := range kvs {
+		builder.AddToPvtAndHashedWriteSet(ns, coll, key, value)
+	}
+	simRes, err := builder.GetTxSimulationResults()
+	assert.NoError(t, err)
+	return &ledger.TxPvtData{SeqInBlock: txNum, WriteSet: simRes.PvtSimulationResults}
+}
+
+func testPvtWrites(t *testing.T, txPvtData *ledger.TxPvtData) []*kvrwset.KVWrite {
+	kvRWSet := &kvrwset.KVRWSet{}
+	assert.NoError(t, proto.Unmarshal(txPvtData.WriteSet.NsPvtRwset[0].CollectionPvtRwset[0].Rwset, kvRWSet))
+	return kvRWSet.Writes
+}
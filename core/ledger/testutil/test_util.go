@@ -80,7 +80,11 @@ func ResetConfigToDefaultValues() {
 	viper.Set("ledger.history.enableHistoryDatabase", false)
 	viper.Set("ledger.state.couchDBConfig.autoWarmIndexes", true)
 	viper.Set("ledger.state.couchDBConfig.warmIndexesAfterNBlocks", 1)
+	viper.Set("ledger.state.cache.maxKeysPerNamespace", 100)
 	viper.Set("peer.fileSystemPath", "/var/hyperledger/production")
+	viper.Set("ledger.snapshots.rootDir", "")
+	viper.Set("ledger.blockchain.archive.blocksToRetain", 0)
+	viper.Set("ledger.blockchain.archive.archiveDir", "")
 }
 
 // ParseTestParams parses tests params
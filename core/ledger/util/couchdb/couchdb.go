@@ -120,6 +120,7 @@ type QueryResult struct {
 //CouchConnectionDef contains parameters
 type CouchConnectionDef struct {
 	URL                   string
+	URLs                  []string
 	Username              string
 	Password              string
 	MaxRetries            int
@@ -130,9 +131,10 @@ type CouchConnectionDef struct {
 
 //CouchInstance represents a CouchDB instance
 type CouchInstance struct {
-	conf   CouchConnectionDef //connection configuration
-	client *http.Client       // a client to connect to this instance
-	stats  *stats
+	conf      CouchConnectionDef //connection configuration
+	client    *http.Client       // a client to connect to this instance
+	stats     *stats
+	endpoints *endpointPool // the CouchDB nodes requests are sent to
 }
 
 //CouchDatabase represents a database within a CouchDB instance
@@ -232,30 +234,52 @@ func closeResponseBody(resp *http.Response) {
 	}
 }
 
-//CreateConnectionDefinition for a new client connection
+//CreateConnectionDefinition for a new client connection. The couchDBAddress may
+//contain a comma separated list of addresses of the CouchDB nodes of a local cluster,
+//the first of which initially receives the writes.
 func CreateConnectionDefinition(couchDBAddress, username, password string, maxRetries,
 	maxRetriesOnStartup int, requestTimeout time.Duration, createGlobalChangesDB bool) (*CouchConnectionDef, error) {
 
 	logger.Debugf("Entering CreateConnectionDefinition()")
 
-	connectURL := &url.URL{
-		Host:   couchDBAddress,
-		Scheme: "http",
-	}
+	var urls []string
+	for _, address := range strings.Split(couchDBAddress, ",") {
+		address = strings.TrimSpace(address)
+		if address == "" {
+			continue
+		}
 
-	//parse the constructed URL to verify no errors
-	finalURL, err := url.Parse(connectURL.String())
-	if err != nil {
-		logger.Errorf("URL parse error: %s", err)
-		return nil, errors.Wrapf(err, "error parsing connect URL: %s", connectURL)
+		connectURL := &url.URL{
+			Host:   address,
+			Scheme: "http",
+		}
+
+		//parse the constructed URL to verify no errors
+		finalURL, err := url.Parse(connectURL.String())
+		if err != nil {
+			logger.Errorf("URL parse error: %s", err)
+			return nil, errors.Wrapf(err, "error parsing connect URL: %s", connectURL)
+		}
+		urls = append(urls, finalURL.String())
+	}
+	if len(urls) == 0 {
+		return nil, errors.Errorf("no CouchDB address provided in [%s]", couchDBAddress)
 	}
 
-	logger.Debugf("Created database configuration  URL=[%s]", finalURL.String())
+	logger.Debugf("Created database configuration  URLs=%s", urls)
 	logger.Debugf("Exiting CreateConnectionDefinition()")
 
 	//return an object containing the connection information
-	return &CouchConnectionDef{finalURL.String(), username, password, maxRetries,
-		maxRetriesOnStartup, requestTimeout, createGlobalChangesDB}, nil
+	return &CouchConnectionDef{
+		URL:                   urls[0],
+		URLs:                  urls,
+		Username:              username,
+		Password:              password,
+		MaxRetries:            maxRetries,
+		MaxRetriesOnStartup:   maxRetriesOnStartup,
+		RequestTimeout:        requestTimeout,
+		CreateGlobalChangesDB: createGlobalChangesDB,
+	}, nil
 
 }
 
@@ -438,6 +462,44 @@ func (couchInstance *CouchInstance) VerifyCouchConfig() (*ConnectionInfo, *DBRet
 	return dbResponse, couchDBReturn, nil
 }
 
+// RetrieveApplicationDBNames returns all the application database names in the couch instance,
+// i.e., all the databases except the system databases (the ones that start with an underscore)
+func (couchInstance *CouchInstance) RetrieveApplicationDBNames() ([]string, error) {
+	logger.Debugf("Entering RetrieveApplicationDBNames()")
+	defer logger.Debugf("Exiting RetrieveApplicationDBNames()")
+
+	connectURL, err := url.Parse(couchInstance.conf.URL)
+	if err != nil {
+		logger.Errorf("URL parse error: %s", err)
+		return nil, errors.Wrapf(err, "error parsing couch instance URL: %s", couchInstance.conf.URL)
+	}
+	connectURL.Path = "/_all_dbs"
+
+	//get the number of retries
+	maxRetries := couchInstance.conf.MaxRetries
+
+	resp, _, err := couchInstance.handleRequest(http.MethodGet, "", "RetrieveApplicationDBNames", connectURL, nil,
+		couchInstance.conf.Username, couchInstance.conf.Password, maxRetries, true, nil)
+	if err != nil {
+		return nil, errors.WithMessage(err, "unable to retrieve the database names from CouchDB")
+	}
+	defer closeResponseBody(resp)
+
+	var dbNames []string
+	decodeErr := json.NewDecoder(resp.Body).Decode(&dbNames)
+	if decodeErr != nil {
+		return nil, errors.Wrap(decodeErr, "error decoding response body")
+	}
+
+	var applicationDBNames []string
+	for _, dbName := range dbNames {
+		if !strings.HasPrefix(dbName, "_") {
+			applicationDBNames = append(applicationDBNames, dbName)
+		}
+	}
+	return applicationDBNames, nil
+}
+
 //DropDatabase provides method to drop an existing database
 func (dbclient *CouchDatabase) DropDatabase() (*DBOperationResponse, error) {
 	dbName := dbclient.DBName
@@ -1644,6 +1706,8 @@ func (dbclient *CouchDatabase) handleRequest(method, functionName string, connec
 // If it returns an error, it ensures that the response body is closed, else it is the
 // callee's responsibility to close response correctly.
 // Any http error or CouchDB error (4XX or 500) will result in a golang error getting returned
+// Every attempt is made against each of the CouchDB endpoints that are available, read requests
+// starting with the next endpoint in turn and write requests starting with the primary endpoint.
 func (couchInstance *CouchInstance) handleRequest(method, dbName, functionName string, connectURL *url.URL, data []byte, rev string,
 	multipartBoundary string, maxRetries int, keepConnectionOpen bool, queryParms *url.Values, pathElements ...string) (*http.Response, *DBReturn, error) {
 
@@ -1652,8 +1716,11 @@ func (couchInstance *CouchInstance) handleRequest(method, dbName, functionName s
 	//create the return objects for couchDB
 	var resp *http.Response
 	var errResp error
+	var endpoint *couchEndpoint
 	couchDBReturn := &DBReturn{}
-	defer couchInstance.recordMetric(time.Now(), dbName, functionName, couchDBReturn)
+	defer func(startTime time.Time) {
+		couchInstance.recordMetric(startTime, dbName, functionName, endpoint, couchDBReturn)
+	}(time.Now())
 
 	//set initial wait duration for retries
 	waitDuration := retryWaitTime * time.Millisecond
@@ -1662,139 +1729,161 @@ func (couchInstance *CouchInstance) handleRequest(method, dbName, functionName s
 		return nil, nil, errors.New("number of retries must be zero or greater")
 	}
 
-	requestURL := constructCouchDBUrl(connectURL, dbName, pathElements...)
-
-	if queryParms != nil {
-		requestURL.RawQuery = queryParms.Encode()
-	}
-
-	logger.Debugf("Request URL: %s", requestURL)
+	read := isReadRequest(method, pathElements)
 
 	//attempt the http request for the max number of retries
 	// if maxRetries is 0, the database creation will be attempted once and will
 	//    return an error if unsuccessful
 	// if maxRetries is 3 (default), a maximum of 4 attempts (one attempt with 3 retries)
 	//    will be made with warning entries for unsuccessful attempts
+retryLoop:
 	for attempts := 0; attempts <= maxRetries; attempts++ {
 
-		//Set up a buffer for the payload data
-		payloadData := new(bytes.Buffer)
+		for _, endpoint = range couchInstance.endpoints.candidates(read) {
 
-		payloadData.ReadFrom(bytes.NewReader(data))
+			requestURL := constructCouchDBUrl(endpointURL(connectURL, endpoint), dbName, pathElements...)
 
-		//Create request based on URL for couchdb operation
-		req, err := http.NewRequest(method, requestURL.String(), payloadData)
-		if err != nil {
-			return nil, nil, errors.Wrap(err, "error creating http request")
-		}
+			if queryParms != nil {
+				requestURL.RawQuery = queryParms.Encode()
+			}
 
-		//set the request to close on completion if shared connections are not allowSharedConnection
-		//Current CouchDB has a problem with zero length attachments, do not allow the connection to be reused.
-		//Apache JIRA item for CouchDB   https://issues.apache.org/jira/browse/COUCHDB-3394
-		if !keepConnectionOpen {
-			req.Close = true
-		}
+			logger.Debugf("Request URL: %s", requestURL)
 
-		//add content header for PUT
-		if method == http.MethodPut || method == http.MethodPost || method == http.MethodDelete {
+			//Set up a buffer for the payload data
+			payloadData := new(bytes.Buffer)
 
-			//If the multipartBoundary is not set, then this is a JSON and content-type should be set
-			//to application/json.   Else, this is contains an attachment and needs to be multipart
-			if multipartBoundary == "" {
-				req.Header.Set("Content-Type", "application/json")
-			} else {
-				req.Header.Set("Content-Type", "multipart/related;boundary=\""+multipartBoundary+"\"")
+			payloadData.ReadFrom(bytes.NewReader(data))
+
+			//Create request based on URL for couchdb operation
+			req, err := http.NewRequest(method, requestURL.String(), payloadData)
+			if err != nil {
+				return nil, nil, errors.Wrap(err, "error creating http request")
 			}
 
-			//check to see if the revision is set,  if so, pass as a header
-			if rev != "" {
-				req.Header.Set("If-Match", rev)
+			//set the request to close on completion if shared connections are not allowSharedConnection
+			//Current CouchDB has a problem with zero length attachments, do not allow the connection to be reused.
+			//Apache JIRA item for CouchDB   https://issues.apache.org/jira/browse/COUCHDB-3394
+			if !keepConnectionOpen {
+				req.Close = true
 			}
-		}
 
-		//add content header for PUT
-		if method == http.MethodPut || method == http.MethodPost {
-			req.Header.Set("Accept", "application/json")
-		}
+			//add content header for PUT
+			if method == http.MethodPut || method == http.MethodPost || method == http.MethodDelete {
 
-		//add content header for GET
-		if method == http.MethodGet {
-			req.Header.Set("Accept", "multipart/related")
-		}
+				//If the multipartBoundary is not set, then this is a JSON and content-type should be set
+				//to application/json.   Else, this is contains an attachment and needs to be multipart
+				if multipartBoundary == "" {
+					req.Header.Set("Content-Type", "application/json")
+				} else {
+					req.Header.Set("Content-Type", "multipart/related;boundary=\""+multipartBoundary+"\"")
+				}
 
-		//If username and password are set the use basic auth
-		if couchInstance.conf.Username != "" && couchInstance.conf.Password != "" {
-			//req.Header.Set("Authorization", "Basic YWRtaW46YWRtaW5w")
-			req.SetBasicAuth(couchInstance.conf.Username, couchInstance.conf.Password)
-		}
+				//check to see if the revision is set,  if so, pass as a header
+				if rev != "" {
+					req.Header.Set("If-Match", rev)
+				}
+			}
 
-		if logger.IsEnabledFor(zapcore.DebugLevel) {
-			dump, _ := httputil.DumpRequestOut(req, false)
-			// compact debug log by replacing carriage return / line feed with dashes to separate http headers
-			logger.Debugf("HTTP Request: %s", bytes.Replace(dump, []byte{0x0d, 0x0a}, []byte{0x20, 0x7c, 0x20}, -1))
-		}
+			//add content header for PUT
+			if method == http.MethodPut || method == http.MethodPost {
+				req.Header.Set("Accept", "application/json")
+			}
 
-		//Execute http request
-		resp, errResp = couchInstance.client.Do(req)
+			//add content header for GET
+			if method == http.MethodGet {
+				req.Header.Set("Accept", "multipart/related")
+			}
 
-		//check to see if the return from CouchDB is valid
-		if invalidCouchDBReturn(resp, errResp) {
-			continue
-		}
+			//If username and password are set the use basic auth
+			if couchInstance.conf.Username != "" && couchInstance.conf.Password != "" {
+				//req.Header.Set("Authorization", "Basic YWRtaW46YWRtaW5w")
+				req.SetBasicAuth(couchInstance.conf.Username, couchInstance.conf.Password)
+			}
 
-		//if there is no golang http error and no CouchDB 500 error, then drop out of the retry
-		if errResp == nil && resp != nil && resp.StatusCode < 500 {
-			// if this is an error, then populate the couchDBReturn
-			if resp.StatusCode >= 400 {
-				//Read the response body and close it for next attempt
-				jsonError, err := ioutil.ReadAll(resp.Body)
-				if err != nil {
-					return nil, nil, errors.Wrap(err, "error reading response body")
-				}
-				defer closeResponseBody(resp)
+			if logger.IsEnabledFor(zapcore.DebugLevel) {
+				dump, _ := httputil.DumpRequestOut(req, false)
+				// compact debug log by replacing carriage return / line feed with dashes to separate http headers
+				logger.Debugf("HTTP Request: %s", bytes.Replace(dump, []byte{0x0d, 0x0a}, []byte{0x20, 0x7c, 0x20}, -1))
+			}
 
-				errorBytes := []byte(jsonError)
-				//Unmarshal the response
-				err = json.Unmarshal(errorBytes, &couchDBReturn)
-				if err != nil {
-					return nil, nil, errors.Wrap(err, "error unmarshalling json data")
-				}
+			//Execute http request
+			resp, errResp = couchInstance.client.Do(req)
+
+			//check to see if the return from CouchDB is valid
+			if invalidCouchDBReturn(resp, errResp) {
+				continue
 			}
 
-			break
-		}
+			//if there is no golang http error and no CouchDB 500 error, then drop out of the retry
+			if errResp == nil && resp != nil && resp.StatusCode < 500 {
+				couchInstance.endpoints.markSucceeded(endpoint, read)
+
+				// if this is an error, then populate the couchDBReturn
+				if resp.StatusCode >= 400 {
+					//Read the response body and close it for next attempt
+					jsonError, err := ioutil.ReadAll(resp.Body)
+					if err != nil {
+						return nil, nil, errors.Wrap(err, "error reading response body")
+					}
+					defer closeResponseBody(resp)
+
+					errorBytes := []byte(jsonError)
+					//Unmarshal the response
+					err = json.Unmarshal(errorBytes, &couchDBReturn)
+					if err != nil {
+						return nil, nil, errors.Wrap(err, "error unmarshalling json data")
+					}
+				}
+
+				break retryLoop
+			}
 
-		// If the maxRetries is greater than 0, then log the retry info
-		if maxRetries > 0 {
+			couchInstance.stats.addRequestFailure(endpoint.name())
 
-			//if this is an unexpected golang http error, log the error and retry
+			//the endpoint could not be reached, subsequent requests will avoid it until it recovers
 			if errResp != nil {
+				couchInstance.endpoints.markFailed(endpoint)
+			}
 
-				//Log the error with the retry count and continue
-				logger.Warningf("Retrying couchdb request in %s. Attempt:%v  Error:%v",
-					waitDuration.String(), attempts+1, errResp.Error())
+			// If the maxRetries is greater than 0, then log the retry info
+			if maxRetries > 0 {
 
-				//otherwise this is an unexpected 500 error from CouchDB. Log the error and retry.
-			} else {
-				//Read the response body and close it for next attempt
-				jsonError, err := ioutil.ReadAll(resp.Body)
-				defer closeResponseBody(resp)
-				if err != nil {
-					return nil, nil, errors.Wrap(err, "error reading response body")
-				}
+				//if this is an unexpected golang http error, log the error and retry
+				if errResp != nil {
 
-				errorBytes := []byte(jsonError)
-				//Unmarshal the response
-				err = json.Unmarshal(errorBytes, &couchDBReturn)
-				if err != nil {
-					return nil, nil, errors.Wrap(err, "error unmarshalling json data")
-				}
+					//Log the error with the retry count and continue
+					logger.Warningf("Retrying couchdb request. Endpoint:%s  Attempt:%v  Error:%v",
+						endpoint.name(), attempts+1, errResp.Error())
+
+					//otherwise this is an unexpected 500 error from CouchDB. Log the error and retry.
+				} else {
+					//Read the response body and close it for next attempt
+					jsonError, err := ioutil.ReadAll(resp.Body)
+					defer closeResponseBody(resp)
+					if err != nil {
+						return nil, nil, errors.Wrap(err, "error reading response body")
+					}
 
-				//Log the 500 error with the retry count and continue
-				logger.Warningf("Retrying couchdb request in %s. Attempt:%v  Couch DB Error:%s,  Status Code:%v  Reason:%v",
-					waitDuration.String(), attempts+1, couchDBReturn.Error, resp.Status, couchDBReturn.Reason)
+					errorBytes := []byte(jsonError)
+					//Unmarshal the response
+					err = json.Unmarshal(errorBytes, &couchDBReturn)
+					if err != nil {
+						return nil, nil, errors.Wrap(err, "error unmarshalling json data")
+					}
 
+					//Log the 500 error with the retry count and continue
+					logger.Warningf("Retrying couchdb request. Endpoint:%s  Attempt:%v  Couch DB Error:%s,  Status Code:%v  Reason:%v",
+						endpoint.name(), attempts+1, couchDBReturn.Error, resp.Status, couchDBReturn.Reason)
+
+				}
 			}
+		}
+
+		// If the maxRetries is greater than 0 and none of the endpoints succeeded, then back off before retrying
+		if maxRetries > 0 && attempts < maxRetries {
+
+			logger.Warningf("Retrying couchdb request in %s", waitDuration.String())
+
 			//sleep for specified sleep time, then retry
 			time.Sleep(waitDuration)
 
@@ -1841,8 +1930,29 @@ func (couchInstance *CouchInstance) handleRequest(method, dbName, functionName s
 	return resp, couchDBReturn, nil
 }
 
-func (ci *CouchInstance) recordMetric(startTime time.Time, dbName, api string, couchDBReturn *DBReturn) {
-	ci.stats.observeProcessingTime(startTime, dbName, api, strconv.Itoa(couchDBReturn.StatusCode))
+func (ci *CouchInstance) recordMetric(startTime time.Time, dbName, api string, endpoint *couchEndpoint, couchDBReturn *DBReturn) {
+	endpointName := ""
+	if endpoint != nil {
+		endpointName = endpoint.name()
+	}
+	ci.stats.observeProcessingTime(startTime, dbName, api, strconv.Itoa(couchDBReturn.StatusCode), endpointName)
+}
+
+// checkEndpointHealth returns whether the CouchDB node behind an endpoint responds
+func (ci *CouchInstance) checkEndpointHealth(endpoint *couchEndpoint) bool {
+	req, err := http.NewRequest(http.MethodGet, endpoint.url.String()+"/", nil)
+	if err != nil {
+		return false
+	}
+	if ci.conf.Username != "" && ci.conf.Password != "" {
+		req.SetBasicAuth(ci.conf.Username, ci.conf.Password)
+	}
+	resp, err := ci.client.Do(req)
+	if err != nil {
+		return false
+	}
+	defer closeResponseBody(resp)
+	return resp.StatusCode == http.StatusOK
 }
 
 //invalidCouchDBResponse checks to make sure either a valid response or error is returned
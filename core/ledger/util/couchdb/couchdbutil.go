@@ -32,7 +32,8 @@ var chainNameAllowedLength = 50
 var namespaceNameAllowedLength = 50
 var collectionNameAllowedLength = 50
 
-//CreateCouchInstance creates a CouchDB instance
+//CreateCouchInstance creates a CouchDB instance, couchDBConnectURL may contain a comma
+//separated list of the addresses of the CouchDB nodes of a local cluster
 func CreateCouchInstance(couchDBConnectURL, id, pw string, maxRetries,
 	maxRetriesOnStartup int, connectionTimeout time.Duration, createGlobalChangesDB bool, metricsProvider metrics.Provider) (*CouchInstance, error) {
 
@@ -55,6 +56,17 @@ func CreateCouchInstance(couchDBConnectURL, id, pw string, maxRetries,
 	//Create the CouchDB instance
 	couchInstance := &CouchInstance{conf: *couchConf, client: client}
 	couchInstance.stats = newStats(metricsProvider)
+
+	var endpointURLs []*url.URL
+	for _, couchDBURL := range couchConf.URLs {
+		endpointURL, err := url.Parse(couchDBURL)
+		if err != nil {
+			return nil, errors.Wrapf(err, "error parsing CouchDB URL: %s", couchDBURL)
+		}
+		endpointURLs = append(endpointURLs, endpointURL)
+	}
+	couchInstance.endpoints = newEndpointPool(endpointURLs, couchInstance.checkEndpointHealth, couchInstance.stats)
+
 	connectInfo, retVal, verifyErr := couchInstance.VerifyCouchConfig()
 	if verifyErr != nil {
 		return nil, verifyErr
@@ -0,0 +1,187 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package couchdb
+
+import (
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+)
+
+// endpointRecheckInterval is the time an unavailable CouchDB endpoint is left
+// alone before it is health checked again
+var endpointRecheckInterval = 10 * time.Second
+
+// couchEndpoint is a single CouchDB node of the local cluster
+type couchEndpoint struct {
+	url       *url.URL
+	available bool
+	checkedAt time.Time
+}
+
+// name returns the host of the endpoint, used in logs and metric labels
+func (e *couchEndpoint) name() string {
+	return e.url.Host
+}
+
+// endpointPool keeps track of the CouchDB nodes a CouchInstance talks to.
+// Reads are spread over the available nodes in a round robin fashion, while
+// writes are sent to a primary node. When the primary fails, writes fail
+// over to the next node that passes a health check.
+type endpointPool struct {
+	lock            sync.Mutex
+	endpoints       []*couchEndpoint
+	primary         int
+	next            int
+	recheckInterval time.Duration
+	healthCheck     func(*couchEndpoint) bool
+	stats           *stats
+}
+
+func newEndpointPool(urls []*url.URL, healthCheck func(*couchEndpoint) bool, stats *stats) *endpointPool {
+	pool := &endpointPool{
+		recheckInterval: endpointRecheckInterval,
+		healthCheck:     healthCheck,
+		stats:           stats,
+	}
+	for _, u := range urls {
+		endpoint := &couchEndpoint{url: u, available: true}
+		pool.endpoints = append(pool.endpoints, endpoint)
+		stats.setEndpointAvailable(endpoint.name(), true)
+	}
+	return pool
+}
+
+// candidates returns the endpoints a request should be attempted against,
+// in order of preference. Endpoints that are known to be unavailable are
+// left out, unless every endpoint is unavailable, in which case all of them
+// are returned so that the request is still attempted.
+func (p *endpointPool) candidates(read bool) []*couchEndpoint {
+	p.lock.Lock()
+	start := p.primary
+	if read {
+		start = p.next
+		p.next = (p.next + 1) % len(p.endpoints)
+	}
+	ordered := make([]*couchEndpoint, 0, len(p.endpoints))
+	for i := range p.endpoints {
+		ordered = append(ordered, p.endpoints[(start+i)%len(p.endpoints)])
+	}
+	p.lock.Unlock()
+
+	var usable []*couchEndpoint
+	for _, endpoint := range ordered {
+		if p.usable(endpoint) {
+			usable = append(usable, endpoint)
+		}
+	}
+	if len(usable) == 0 {
+		return ordered
+	}
+	return usable
+}
+
+// usable returns whether an endpoint is available. An unavailable endpoint
+// becomes usable again once it passes a health check, which is attempted at
+// most once per recheck interval.
+func (p *endpointPool) usable(endpoint *couchEndpoint) bool {
+	p.lock.Lock()
+	if endpoint.available {
+		p.lock.Unlock()
+		return true
+	}
+	if time.Since(endpoint.checkedAt) < p.recheckInterval {
+		p.lock.Unlock()
+		return false
+	}
+	// claim the health check so that concurrent requests do not probe the endpoint as well
+	endpoint.checkedAt = time.Now()
+	p.lock.Unlock()
+
+	if !p.healthCheck(endpoint) {
+		logger.Debugf("CouchDB endpoint [%s] is still unavailable", endpoint.name())
+		return false
+	}
+	logger.Infof("CouchDB endpoint [%s] is available again", endpoint.name())
+	p.lock.Lock()
+	endpoint.available = true
+	p.lock.Unlock()
+	p.stats.setEndpointAvailable(endpoint.name(), true)
+	return true
+}
+
+// markFailed records that an endpoint could not be reached
+func (p *endpointPool) markFailed(endpoint *couchEndpoint) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	if !endpoint.available {
+		return
+	}
+	logger.Warningf("CouchDB endpoint [%s] is unavailable", endpoint.name())
+	endpoint.available = false
+	endpoint.checkedAt = time.Now()
+	p.stats.setEndpointAvailable(endpoint.name(), false)
+}
+
+// markSucceeded records that a request to an endpoint succeeded. A successful
+// write to an endpoint other than the primary means that the primary failed
+// over, so the endpoint becomes the new primary.
+func (p *endpointPool) markSucceeded(endpoint *couchEndpoint, read bool) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	if !endpoint.available {
+		logger.Infof("CouchDB endpoint [%s] is available again", endpoint.name())
+		endpoint.available = true
+		p.stats.setEndpointAvailable(endpoint.name(), true)
+	}
+	if read {
+		return
+	}
+	current := p.endpoints[p.primary]
+	if current == endpoint {
+		return
+	}
+	for i, e := range p.endpoints {
+		if e == endpoint {
+			logger.Warningf("CouchDB primary endpoint failed over from [%s] to [%s]", current.name(), endpoint.name())
+			p.primary = i
+			return
+		}
+	}
+}
+
+// primaryEndpoint returns the endpoint writes are currently sent to
+func (p *endpointPool) primaryEndpoint() *couchEndpoint {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	return p.endpoints[p.primary]
+}
+
+// isReadRequest returns whether a request only reads data and can therefore
+// be served by any of the CouchDB endpoints
+func isReadRequest(method string, pathElements []string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead:
+		return true
+	case http.MethodPost:
+		if len(pathElements) == 0 {
+			return false
+		}
+		last := pathElements[len(pathElements)-1]
+		return last == "_find" || last == "_all_docs"
+	default:
+		return false
+	}
+}
+
+// endpointURL returns a copy of the request URL that points to the endpoint
+func endpointURL(connectURL *url.URL, endpoint *couchEndpoint) *url.URL {
+	u := *connectURL
+	u.Scheme = endpoint.url.Scheme
+	u.Host = endpoint.url.Host
+	return &u
+}
@@ -0,0 +1,192 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package couchdb
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/hyperledger/fabric/common/metrics/disabled"
+	"github.com/hyperledger/fabric/common/metrics/metricsfakes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeCouchCluster simulates the nodes of a CouchDB cluster sharing the same databases
+type fakeCouchCluster struct {
+	lock      sync.Mutex
+	databases map[string]bool
+	requests  map[string]map[string]int // node -> method -> count
+}
+
+func newFakeCouchCluster() *fakeCouchCluster {
+	return &fakeCouchCluster{
+		databases: map[string]bool{},
+		requests:  map[string]map[string]int{},
+	}
+}
+
+func (c *fakeCouchCluster) addNode(t *testing.T) *httptest.Server {
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		node := strings.TrimPrefix(server.URL, "http://")
+		c.lock.Lock()
+		defer c.lock.Unlock()
+		if c.requests[node] == nil {
+			c.requests[node] = map[string]int{}
+		}
+		c.requests[node][r.Method]++
+
+		w.Header().Set("Content-Type", "application/json")
+		dbName := strings.Trim(r.URL.Path, "/")
+		switch {
+		case dbName == "":
+			json.NewEncoder(w).Encode(&ConnectionInfo{Couchdb: "Welcome", Version: "2.2.0"})
+		case r.Method == http.MethodGet && c.databases[dbName]:
+			json.NewEncoder(w).Encode(&DBInfo{DbName: dbName})
+		case r.Method == http.MethodGet:
+			w.WriteHeader(http.StatusNotFound)
+			json.NewEncoder(w).Encode(&DBReturn{Error: "not_found", Reason: "Database does not exist."})
+		case r.Method == http.MethodPut:
+			c.databases[dbName] = true
+			w.WriteHeader(http.StatusCreated)
+			json.NewEncoder(w).Encode(&DBOperationResponse{Ok: true})
+		default:
+			w.WriteHeader(http.StatusMethodNotAllowed)
+		}
+	}))
+	return server
+}
+
+func (c *fakeCouchCluster) requestCount(server *httptest.Server, method string) int {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return c.requests[strings.TrimPrefix(server.URL, "http://")][method]
+}
+
+func TestCreateConnectionDefinitionMultipleAddresses(t *testing.T) {
+	connDef, err := CreateConnectionDefinition("couchdb0:5984, couchdb1:5984,", "", "", 3, 10, time.Second, false)
+	require.NoError(t, err)
+	assert.Equal(t, "http://couchdb0:5984", connDef.URL)
+	assert.Equal(t, []string{"http://couchdb0:5984", "http://couchdb1:5984"}, connDef.URLs)
+
+	_, err = CreateConnectionDefinition(" , ", "", "", 3, 10, time.Second, false)
+	assert.EqualError(t, err, "no CouchDB address provided in [ , ]")
+}
+
+func TestIsReadRequest(t *testing.T) {
+	assert.True(t, isReadRequest(http.MethodGet, nil))
+	assert.True(t, isReadRequest(http.MethodPost, []string{"_find"}))
+	assert.True(t, isReadRequest(http.MethodPost, []string{"_all_docs"}))
+	assert.False(t, isReadRequest(http.MethodPost, []string{"_bulk_docs"}))
+	assert.False(t, isReadRequest(http.MethodPost, nil))
+	assert.False(t, isReadRequest(http.MethodPut, nil))
+	assert.False(t, isReadRequest(http.MethodDelete, []string{"doc"}))
+}
+
+func TestEndpointsReadsAreBalanced(t *testing.T) {
+	cluster := newFakeCouchCluster()
+	node0, node1 := cluster.addNode(t), cluster.addNode(t)
+	defer node0.Close()
+	defer node1.Close()
+
+	couchInstance, err := CreateCouchInstance(node0.Listener.Addr().String()+","+node1.Listener.Addr().String(),
+		"", "", 3, 3, time.Second, false, &disabled.Provider{})
+	require.NoError(t, err)
+
+	db, err := CreateCouchDatabase(couchInstance, "testdb")
+	require.NoError(t, err)
+	reads0, reads1 := cluster.requestCount(node0, http.MethodGet), cluster.requestCount(node1, http.MethodGet)
+	for i := 0; i < 10; i++ {
+		_, _, err := db.GetDatabaseInfo()
+		require.NoError(t, err)
+	}
+	assert.Equal(t, 5, cluster.requestCount(node0, http.MethodGet)-reads0)
+	assert.Equal(t, 5, cluster.requestCount(node1, http.MethodGet)-reads1)
+
+	// writes only go to the primary
+	assert.NotZero(t, cluster.requestCount(node0, http.MethodPut))
+	assert.Zero(t, cluster.requestCount(node1, http.MethodPut))
+}
+
+func TestEndpointsWriteFailover(t *testing.T) {
+	cluster := newFakeCouchCluster()
+	node0, node1 := cluster.addNode(t), cluster.addNode(t)
+	defer node1.Close()
+	addr0, addr1 := node0.Listener.Addr().String(), node1.Listener.Addr().String()
+
+	couchInstance, err := CreateCouchInstance(addr0+","+addr1, "", "", 3, 3, time.Second, false, &disabled.Provider{})
+	require.NoError(t, err)
+	assert.Equal(t, addr0, couchInstance.endpoints.primaryEndpoint().name())
+
+	fakeCounter := &metricsfakes.Counter{}
+	fakeCounter.WithReturns(fakeCounter)
+	fakeGauge := &metricsfakes.Gauge{}
+	fakeGauge.WithReturns(fakeGauge)
+	couchInstance.stats.requestFailures = fakeCounter
+	couchInstance.stats.endpointAvailable = fakeGauge
+
+	node0.Close()
+	_, err = CreateCouchDatabase(couchInstance, "testdb")
+	require.NoError(t, err)
+	assert.Equal(t, addr1, couchInstance.endpoints.primaryEndpoint().name())
+	assert.Equal(t, 1, cluster.requestCount(node1, http.MethodPut))
+
+	require.Equal(t, 1, fakeCounter.AddCallCount())
+	assert.Equal(t, []string{"endpoint", addr0}, fakeCounter.WithArgsForCall(0))
+	require.Equal(t, 1, fakeGauge.SetCallCount())
+	assert.Equal(t, []string{"endpoint", addr0}, fakeGauge.WithArgsForCall(0))
+	assert.Equal(t, float64(0), fakeGauge.SetArgsForCall(0))
+
+	// the failed endpoint is no longer attempted
+	_, _, err = (&CouchDatabase{CouchInstance: couchInstance, DBName: "testdb"}).GetDatabaseInfo()
+	require.NoError(t, err)
+	assert.Equal(t, 1, fakeCounter.AddCallCount())
+}
+
+func TestEndpointsRecovery(t *testing.T) {
+	healthy := false
+	u0, err := url.Parse("http://couchdb0:5984")
+	require.NoError(t, err)
+	u1, err := url.Parse("http://couchdb1:5984")
+	require.NoError(t, err)
+	pool := newEndpointPool([]*url.URL{u0, u1}, func(*couchEndpoint) bool { return healthy }, newStats(&disabled.Provider{}))
+	endpoint0 := pool.endpoints[0]
+
+	pool.markFailed(endpoint0)
+	assert.Equal(t, []*couchEndpoint{pool.endpoints[1]}, pool.candidates(false))
+
+	// the endpoint is not health checked before the recheck interval elapses
+	pool.recheckInterval = time.Hour
+	healthy = true
+	assert.Equal(t, []*couchEndpoint{pool.endpoints[1]}, pool.candidates(false))
+
+	// the endpoint fails the health check
+	pool.recheckInterval = 0
+	healthy = false
+	assert.Equal(t, []*couchEndpoint{pool.endpoints[1]}, pool.candidates(false))
+
+	// the endpoint passes the health check
+	healthy = true
+	assert.Equal(t, pool.endpoints, pool.candidates(false))
+
+	// all endpoints are still attempted when none is available
+	healthy = false
+	pool.markFailed(pool.endpoints[0])
+	pool.markFailed(pool.endpoints[1])
+	assert.Equal(t, pool.endpoints, pool.candidates(false))
+
+	// a successful write promotes the endpoint to primary and makes it available
+	pool.markSucceeded(pool.endpoints[1], false)
+	assert.Equal(t, pool.endpoints[1], pool.primaryEndpoint())
+	assert.Equal(t, []*couchEndpoint{pool.endpoints[1]}, pool.candidates(true))
+}
@@ -17,25 +17,60 @@ var (
 		Subsystem:    "",
 		Name:         "processing_time",
 		Help:         "Time taken in seconds for the function to complete request to CouchDB",
-		LabelNames:   []string{"database", "function_name", "result"},
-		StatsdFormat: "%{#fqname}.%{database}.%{function_name}.%{result}",
+		LabelNames:   []string{"database", "function_name", "result", "endpoint"},
+		StatsdFormat: "%{#fqname}.%{database}.%{function_name}.%{result}.%{endpoint}",
+	}
+
+	requestFailuresOpts = metrics.CounterOpts{
+		Namespace:    "couchdb",
+		Subsystem:    "",
+		Name:         "request_failures",
+		Help:         "The number of failed attempts to complete a request against a CouchDB endpoint",
+		LabelNames:   []string{"endpoint"},
+		StatsdFormat: "%{#fqname}.%{endpoint}",
+	}
+
+	endpointAvailableOpts = metrics.GaugeOpts{
+		Namespace:    "couchdb",
+		Subsystem:    "",
+		Name:         "endpoint_available",
+		Help:         "Indicates whether a CouchDB endpoint is available (1) or not (0)",
+		LabelNames:   []string{"endpoint"},
+		StatsdFormat: "%{#fqname}.%{endpoint}",
 	}
 )
 
 type stats struct {
 	apiProcessingTime metrics.Histogram
+	requestFailures   metrics.Counter
+	endpointAvailable metrics.Gauge
 }
 
 func newStats(metricsProvider metrics.Provider) *stats {
 	return &stats{
 		apiProcessingTime: metricsProvider.NewHistogram(apiProcessingTimeOpts),
+		requestFailures:   metricsProvider.NewCounter(requestFailuresOpts),
+		endpointAvailable: metricsProvider.NewGauge(endpointAvailableOpts),
 	}
 }
 
-func (s *stats) observeProcessingTime(startTime time.Time, dbName, functionName, result string) {
+func (s *stats) observeProcessingTime(startTime time.Time, dbName, functionName, result, endpoint string) {
 	s.apiProcessingTime.With(
 		"database", dbName,
 		"function_name", functionName,
 		"result", result,
+		"endpoint", endpoint,
 	).Observe(time.Since(startTime).Seconds())
 }
+
+func (s *stats) addRequestFailure(endpoint string) {
+	s.requestFailures.With("endpoint", endpoint).Add(1)
+}
+
+func (s *stats) setEndpointAvailable(endpoint string, available bool) {
+	value := 0.0
+	if available {
+		value = 1
+	}
+	s.endpointAvailable.With("endpoint", endpoint).Set(value)
+}
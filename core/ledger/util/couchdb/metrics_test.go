@@ -35,17 +35,21 @@ func TestAPIProcessTimeMetric(t *testing.T) {
 
 	couchInstance.stats = &stats{
 		apiProcessingTime: fakeHistogram,
+		requestFailures:   &disabled.Counter{},
+		endpointAvailable: &disabled.Gauge{},
 	}
 
-	url, err := url.Parse("http://locahost:0")
+	connectURL, err := url.Parse("http://locahost:0")
 	gt.Expect(err).NotTo(HaveOccurred(), "Error when trying to parse URL")
+	couchInstance.endpoints = newEndpointPool([]*url.URL{connectURL}, couchInstance.checkEndpointHealth, couchInstance.stats)
 
-	couchInstance.handleRequest(http.MethodGet, "db_name", "function_name", url, nil, "", "", 0, true, nil)
+	couchInstance.handleRequest(http.MethodGet, "db_name", "function_name", connectURL, nil, "", "", 0, true, nil)
 	gt.Expect(fakeHistogram.ObserveCallCount()).To(Equal(1))
 	gt.Expect(fakeHistogram.ObserveArgsForCall(0)).NotTo(BeZero())
 	gt.Expect(fakeHistogram.WithArgsForCall(0)).To(Equal([]string{
 		"database", "db_name",
 		"function_name", "function_name",
 		"result", "0",
+		"endpoint", "locahost:0",
 	}))
 }
@@ -10,6 +10,7 @@ import (
 	commonledger "github.com/hyperledger/fabric/common/ledger"
 	"github.com/hyperledger/fabric/core/common/ccprovider"
 	"github.com/hyperledger/fabric/core/ledger"
+	"github.com/hyperledger/fabric/protos/ledger/rwset/kvrwset"
 	"github.com/hyperledger/fabric/protos/peer"
 )
 
@@ -89,6 +90,10 @@ func (m *MockTxSim) DeletePrivateData(namespace, collection, key string) error {
 	return nil
 }
 
+func (m *MockTxSim) PurgePrivateData(namespace, collection, key string) error {
+	return nil
+}
+
 func (m *MockTxSim) ExecuteQueryOnPrivateData(namespace, collection, query string) (commonledger.ResultsIterator, error) {
 	return nil, nil
 }
@@ -125,6 +130,10 @@ func (m *MockTxSim) GetPrivateDataMetadataByHash(namespace, collection string, k
 	return nil, nil
 }
 
+func (m *MockTxSim) GetPrivateDataHash(namespace, collection, key string) ([]byte, *kvrwset.Version, error) {
+	return nil, nil, nil
+}
+
 func (m *MockTxSim) SetStateMetadata(namespace, key string, metadata map[string][]byte) error {
 	return nil
 }
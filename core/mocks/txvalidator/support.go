@@ -20,6 +20,8 @@ import (
 	"sync"
 
 	"github.com/hyperledger/fabric/common/channelconfig"
+	"github.com/hyperledger/fabric/common/configtx"
+	mockconfigtx "github.com/hyperledger/fabric/common/mocks/configtx"
 	mockpolicies "github.com/hyperledger/fabric/common/mocks/policies"
 	"github.com/hyperledger/fabric/common/policies"
 	"github.com/hyperledger/fabric/core/ledger"
@@ -32,6 +34,8 @@ type Support struct {
 	MSPManagerVal msp.MSPManager
 	ApplyVal      error
 	ACVal         channelconfig.ApplicationCapabilities
+	AppConfigVal  channelconfig.Application
+	ConfigtxVal   configtx.Validator
 
 	sync.Mutex
 	capabilitiesInvokeCount int
@@ -71,6 +75,19 @@ func (ms *Support) GetMSPIDs(cid string) []string {
 	return []string{"SampleOrg"}
 }
 
+// ApplicationConfig returns AppConfigVal, and whether it is set
+func (ms *Support) ApplicationConfig() (channelconfig.Application, bool) {
+	return ms.AppConfigVal, ms.AppConfigVal != nil
+}
+
+// ConfigtxValidator returns ConfigtxVal, or an empty validator if it is not set
+func (ms *Support) ConfigtxValidator() configtx.Validator {
+	if ms.ConfigtxVal == nil {
+		return &mockconfigtx.Validator{}
+	}
+	return ms.ConfigtxVal
+}
+
 func (ms *Support) CapabilitiesInvokeCount() int {
 	ms.Lock()
 	defer ms.Unlock()
@@ -21,6 +21,7 @@ import (
 	"github.com/hyperledger/fabric/common/ledger/blockledger"
 	fileledger "github.com/hyperledger/fabric/common/ledger/blockledger/file"
 	"github.com/hyperledger/fabric/common/metrics"
+	"github.com/hyperledger/fabric/common/metrics/disabled"
 	"github.com/hyperledger/fabric/common/policies"
 	"github.com/hyperledger/fabric/core/chaincode/platforms"
 	"github.com/hyperledger/fabric/core/comm"
@@ -81,9 +82,16 @@ var TransientStoreFactory = &storeProvider{stores: make(map[string]transientstor
 type storeProvider struct {
 	stores map[string]transientstore.Store
 	transientstore.StoreProvider
+	metricsProvider metrics.Provider
 	sync.RWMutex
 }
 
+func (sp *storeProvider) setMetricsProvider(metricsProvider metrics.Provider) {
+	sp.Lock()
+	defer sp.Unlock()
+	sp.metricsProvider = metricsProvider
+}
+
 func (sp *storeProvider) StoreForChannel(channel string) transientstore.Store {
 	sp.RLock()
 	defer sp.RUnlock()
@@ -94,7 +102,11 @@ func (sp *storeProvider) OpenStore(ledgerID string) (transientstore.Store, error
 	sp.Lock()
 	defer sp.Unlock()
 	if sp.StoreProvider == nil {
-		sp.StoreProvider = transientstore.NewStoreProvider()
+		metricsProvider := sp.metricsProvider
+		if metricsProvider == nil {
+			metricsProvider = &disabled.Provider{}
+		}
+		sp.StoreProvider = transientstore.NewStoreProvider(metricsProvider)
 	}
 	store, err := sp.StoreProvider.OpenStore(ledgerID)
 	if err == nil {
@@ -213,6 +225,7 @@ func Initialize(init func(string), ccp ccprovider.ChaincodeProvider, sccp sysccp
 
 	pluginMapper = pm
 	chainInitializer = init
+	TransientStoreFactory.setMetricsProvider(metricsProvider)
 
 	var cb *common.Block
 	var ledger ledger.PeerLedger
@@ -440,6 +453,20 @@ func CreateChainFromBlock(cb *common.Block, ccp ccprovider.ChaincodeProvider, sc
 	return createChain(cid, l, cb, ccp, sccp, pluginMapper)
 }
 
+// CreateChainFromSnapshot creates a new chain from the ledger snapshot present in the given directory
+// and returns the chain ID recorded in the snapshot
+func CreateChainFromSnapshot(snapshotDir string, ccp ccprovider.ChaincodeProvider, sccp sysccprovider.SystemChaincodeProvider) (string, error) {
+	l, cid, err := ledgermgmt.CreateLedgerFromSnapshot(snapshotDir)
+	if err != nil {
+		return "", errors.WithMessage(err, "cannot create ledger from snapshot")
+	}
+	cb, err := getCurrConfigBlockFromLedger(l)
+	if err != nil {
+		return "", errors.WithMessage(err, "cannot retrieve the config block from the ledger created from snapshot")
+	}
+	return cid, createChain(cid, l, cb, ccp, sccp, pluginMapper)
+}
+
 // GetLedger returns the ledger of the chain with chain ID. Note that this
 // call returns nil if chain cid has not been created.
 func GetLedger(cid string) ledger.PeerLedger {
@@ -724,6 +751,10 @@ func (*CollectionSupport) GetIdentityDeserializer(chainID string) msp.IdentityDe
 	return mspmgmt.GetManagerForChain(chainID)
 }
 
+func (*CollectionSupport) GetMSPIDs(chainID string) []string {
+	return GetMSPIDs(chainID)
+}
+
 //
 //  Deliver service support structs for the peer
 //
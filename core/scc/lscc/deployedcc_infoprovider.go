@@ -80,7 +80,18 @@ func (p *DeployedCCInfoProvider) ChaincodeInfo(chaincodeName string, qe ledger.S
 }
 
 // CollectionInfo implements function in interface ledger.DeployedChaincodeInfoProvider
+// In addition to the collections defined in the collection config of the chaincode, this returns the
+// configuration of an implicit collection if the chaincode is deployed. As the ledger has no access to the
+// channel configuration, the membership of the organization of an implicit collection in the channel is not
+// checked here. The collection store only provides the implicit collections of the members of the channel
 func (p *DeployedCCInfoProvider) CollectionInfo(chaincodeName, collectionName string, qe ledger.SimpleQueryExecutor) (*common.StaticCollectionConfig, error) {
+	if isImplicit, mspID := privdata.MSPIDIfImplicitCollection(collectionName); isImplicit {
+		chaincodeDataBytes, err := qe.GetState(lsccNamespace, chaincodeName)
+		if err != nil || chaincodeDataBytes == nil {
+			return nil, err
+		}
+		return privdata.GenerateImplicitCollectionForOrg(mspID), nil
+	}
 	collConfigPkg, err := fetchCollConfigPkg(chaincodeName, qe)
 	if err != nil || collConfigPkg == nil {
 		return nil, err
@@ -85,6 +85,15 @@ func TestCollectionInfo(t *testing.T) {
 	collInfo3, err := ccInfoProvdier.CollectionInfo("cc2", "non-existing-coll-in-cc2", mockQE)
 	assert.NoError(t, err)
 	assert.Nil(t, collInfo3)
+
+	// implicit collections are available for the deployed chaincodes, regardless of their collection config
+	collInfo4, err := ccInfoProvdier.CollectionInfo("cc1", "_implicit_org_Org1MSP", mockQE)
+	assert.NoError(t, err)
+	assert.Equal(t, privdata.GenerateImplicitCollectionForOrg("Org1MSP"), collInfo4)
+
+	collInfo5, err := ccInfoProvdier.CollectionInfo("non-existing-cc", "_implicit_org_Org1MSP", mockQE)
+	assert.NoError(t, err)
+	assert.Nil(t, collInfo5)
 }
 
 func prepareMockQE(t *testing.T, deployedChaincodes []*ledger.DeployedChaincodeInfo) *mock.QueryExecutor {
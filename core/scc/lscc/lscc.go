@@ -9,6 +9,7 @@ package lscc
 import (
 	"fmt"
 	"regexp"
+	"strings"
 
 	"github.com/golang/protobuf/proto"
 	"github.com/hyperledger/fabric/common/cauthdsl"
@@ -320,6 +321,10 @@ func (lscc *LifeCycleSysCC) putChaincodeCollectionData(stub shim.ChaincodeStubIn
 		return fmt.Errorf("could not get MSP manager for channel %s", stub.GetChannelID())
 	}
 	for _, collectionConfig := range collections.Config {
+		collName := collectionConfig.GetStaticCollectionConfig().GetName()
+		if strings.HasPrefix(collName, privdata.ImplicitCollectionNamePrefix) {
+			return errors.Errorf("collection name %s is reserved for implicit collections", collName)
+		}
 		err = checkCollectionMemberPolicy(collectionConfig, mspmgr)
 		if err != nil {
 			return errors.Wrapf(err, "collection member policy check failed")
@@ -27,6 +27,7 @@ import (
 	"github.com/hyperledger/fabric/core/chaincode/platforms/golang"
 	"github.com/hyperledger/fabric/core/chaincode/shim"
 	"github.com/hyperledger/fabric/core/common/ccprovider"
+	"github.com/hyperledger/fabric/core/common/privdata"
 	cutil "github.com/hyperledger/fabric/core/container/util"
 	"github.com/hyperledger/fabric/core/ledger/ledgermgmt"
 	"github.com/hyperledger/fabric/core/mocks/scc/lscc"
@@ -878,6 +879,16 @@ func TestPutChaincodeCollectionData(t *testing.T) {
 	err = scc.putChaincodeCollectionData(stub, cd, ccpBytes)
 	assert.NoError(t, err)
 	stub.MockTransactionEnd("foo")
+
+	implicitColl := createCollectionConfig(privdata.ImplicitCollectionNameForOrg("Org1MSP"), policyEnvelope, 1, 2)
+	ccp = &common.CollectionConfigPackage{Config: []*common.CollectionConfig{implicitColl}}
+	ccpBytes, err = proto.Marshal(ccp)
+	assert.NoError(t, err)
+
+	stub.MockTransactionStart("foo")
+	err = scc.putChaincodeCollectionData(stub, cd, ccpBytes)
+	assert.EqualError(t, err, "collection name _implicit_org_Org1MSP is reserved for implicit collections")
+	stub.MockTransactionEnd("foo")
 }
 
 func TestGetChaincodeCollectionData(t *testing.T) {
@@ -138,6 +138,42 @@ type ChaincodeStub struct {
 		result1 shim.HistoryQueryIteratorInterface
 		result2 error
 	}
+	GetHistoryForKeyByBlockRangeStub        func(string, uint64, uint64, bool) (shim.HistoryQueryIteratorInterface, error)
+	getHistoryForKeyByBlockRangeMutex       sync.RWMutex
+	getHistoryForKeyByBlockRangeArgsForCall []struct {
+		arg1 string
+		arg2 uint64
+		arg3 uint64
+		arg4 bool
+	}
+	getHistoryForKeyByBlockRangeReturns struct {
+		result1 shim.HistoryQueryIteratorInterface
+		result2 error
+	}
+	getHistoryForKeyByBlockRangeReturnsOnCall map[int]struct {
+		result1 shim.HistoryQueryIteratorInterface
+		result2 error
+	}
+	GetHistoryForKeyByBlockRangeWithPaginationStub        func(string, uint64, uint64, bool, int32, string) (shim.HistoryQueryIteratorInterface, *peer.QueryResponseMetadata, error)
+	getHistoryForKeyByBlockRangeWithPaginationMutex       sync.RWMutex
+	getHistoryForKeyByBlockRangeWithPaginationArgsForCall []struct {
+		arg1 string
+		arg2 uint64
+		arg3 uint64
+		arg4 bool
+		arg5 int32
+		arg6 string
+	}
+	getHistoryForKeyByBlockRangeWithPaginationReturns struct {
+		result1 shim.HistoryQueryIteratorInterface
+		result2 *peer.QueryResponseMetadata
+		result3 error
+	}
+	getHistoryForKeyByBlockRangeWithPaginationReturnsOnCall map[int]struct {
+		result1 shim.HistoryQueryIteratorInterface
+		result2 *peer.QueryResponseMetadata
+		result3 error
+	}
 	GetPrivateDataStub        func(string, string) ([]byte, error)
 	getPrivateDataMutex       sync.RWMutex
 	getPrivateDataArgsForCall []struct {
@@ -182,6 +218,34 @@ type ChaincodeStub struct {
 		result1 shim.StateQueryIteratorInterface
 		result2 error
 	}
+	GetPrivateDataHashStub        func(string, string) ([]byte, error)
+	getPrivateDataHashMutex       sync.RWMutex
+	getPrivateDataHashArgsForCall []struct {
+		arg1 string
+		arg2 string
+	}
+	getPrivateDataHashReturns struct {
+		result1 []byte
+		result2 error
+	}
+	getPrivateDataHashReturnsOnCall map[int]struct {
+		result1 []byte
+		result2 error
+	}
+	GetPrivateDataHistoryForKeyStub        func(string, string) (shim.PrivateDataHistoryQueryIteratorInterface, error)
+	getPrivateDataHistoryForKeyMutex       sync.RWMutex
+	getPrivateDataHistoryForKeyArgsForCall []struct {
+		arg1 string
+		arg2 string
+	}
+	getPrivateDataHistoryForKeyReturns struct {
+		result1 shim.PrivateDataHistoryQueryIteratorInterface
+		result2 error
+	}
+	getPrivateDataHistoryForKeyReturnsOnCall map[int]struct {
+		result1 shim.PrivateDataHistoryQueryIteratorInterface
+		result2 error
+	}
 	GetPrivateDataQueryResultStub        func(string, string) (shim.StateQueryIteratorInterface, error)
 	getPrivateDataQueryResultMutex       sync.RWMutex
 	getPrivateDataQueryResultArgsForCall []struct {
@@ -265,6 +329,20 @@ type ChaincodeStub struct {
 		result1 []byte
 		result2 error
 	}
+	GetStateAtHeightStub        func(string, uint64) ([]byte, error)
+	getStateAtHeightMutex       sync.RWMutex
+	getStateAtHeightArgsForCall []struct {
+		arg1 string
+		arg2 uint64
+	}
+	getStateAtHeightReturns struct {
+		result1 []byte
+		result2 error
+	}
+	getStateAtHeightReturnsOnCall map[int]struct {
+		result1 []byte
+		result2 error
+	}
 	GetStateByPartialCompositeKeyStub        func(string, []string) (shim.StateQueryIteratorInterface, error)
 	getStateByPartialCompositeKeyMutex       sync.RWMutex
 	getStateByPartialCompositeKeyArgsForCall []struct {
@@ -311,6 +389,21 @@ type ChaincodeStub struct {
 		result1 shim.StateQueryIteratorInterface
 		result2 error
 	}
+	GetStateByRangeAtHeightStub        func(string, string, uint64) (shim.StateQueryIteratorInterface, error)
+	getStateByRangeAtHeightMutex       sync.RWMutex
+	getStateByRangeAtHeightArgsForCall []struct {
+		arg1 string
+		arg2 string
+		arg3 uint64
+	}
+	getStateByRangeAtHeightReturns struct {
+		result1 shim.StateQueryIteratorInterface
+		result2 error
+	}
+	getStateByRangeAtHeightReturnsOnCall map[int]struct {
+		result1 shim.StateQueryIteratorInterface
+		result2 error
+	}
 	GetStateByRangeWithPaginationStub        func(string, string, int32, string) (shim.StateQueryIteratorInterface, *peer.QueryResponseMetadata, error)
 	getStateByRangeWithPaginationMutex       sync.RWMutex
 	getStateByRangeWithPaginationArgsForCall []struct {
@@ -399,6 +492,18 @@ type ChaincodeStub struct {
 	invokeChaincodeReturnsOnCall map[int]struct {
 		result1 peer.Response
 	}
+	PurgePrivateDataStub        func(string, string) error
+	purgePrivateDataMutex       sync.RWMutex
+	purgePrivateDataArgsForCall []struct {
+		arg1 string
+		arg2 string
+	}
+	purgePrivateDataReturns struct {
+		result1 error
+	}
+	purgePrivateDataReturnsOnCall map[int]struct {
+		result1 error
+	}
 	PutPrivateDataStub        func(string, string, []byte) error
 	putPrivateDataMutex       sync.RWMutex
 	putPrivateDataArgsForCall []struct {
@@ -476,6 +581,21 @@ type ChaincodeStub struct {
 		result2 []string
 		result3 error
 	}
+	VerifyPrivateDataHashStub        func(string, string, []byte) (bool, error)
+	verifyPrivateDataHashMutex       sync.RWMutex
+	verifyPrivateDataHashArgsForCall []struct {
+		arg1 string
+		arg2 string
+		arg3 []byte
+	}
+	verifyPrivateDataHashReturns struct {
+		result1 bool
+		result2 error
+	}
+	verifyPrivateDataHashReturnsOnCall map[int]struct {
+		result1 bool
+		result2 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -1109,6 +1229,143 @@ func (fake *ChaincodeStub) GetHistoryForKeyReturnsOnCall(i int, result1 shim.His
 	}{result1, result2}
 }
 
+func (fake *ChaincodeStub) GetHistoryForKeyByBlockRange(arg1 string, arg2 uint64, arg3 uint64, arg4 bool) (shim.HistoryQueryIteratorInterface, error) {
+	fake.getHistoryForKeyByBlockRangeMutex.Lock()
+	ret, specificReturn := fake.getHistoryForKeyByBlockRangeReturnsOnCall[len(fake.getHistoryForKeyByBlockRangeArgsForCall)]
+	fake.getHistoryForKeyByBlockRangeArgsForCall = append(fake.getHistoryForKeyByBlockRangeArgsForCall, struct {
+		arg1 string
+		arg2 uint64
+		arg3 uint64
+		arg4 bool
+	}{arg1, arg2, arg3, arg4})
+	fake.recordInvocation("GetHistoryForKeyByBlockRange", []interface{}{arg1, arg2, arg3, arg4})
+	fake.getHistoryForKeyByBlockRangeMutex.Unlock()
+	if fake.GetHistoryForKeyByBlockRangeStub != nil {
+		return fake.GetHistoryForKeyByBlockRangeStub(arg1, arg2, arg3, arg4)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.getHistoryForKeyByBlockRangeReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *ChaincodeStub) GetHistoryForKeyByBlockRangeCallCount() int {
+	fake.getHistoryForKeyByBlockRangeMutex.RLock()
+	defer fake.getHistoryForKeyByBlockRangeMutex.RUnlock()
+	return len(fake.getHistoryForKeyByBlockRangeArgsForCall)
+}
+
+func (fake *ChaincodeStub) GetHistoryForKeyByBlockRangeCalls(stub func(string, uint64, uint64, bool) (shim.HistoryQueryIteratorInterface, error)) {
+	fake.getHistoryForKeyByBlockRangeMutex.Lock()
+	defer fake.getHistoryForKeyByBlockRangeMutex.Unlock()
+	fake.GetHistoryForKeyByBlockRangeStub = stub
+}
+
+func (fake *ChaincodeStub) GetHistoryForKeyByBlockRangeArgsForCall(i int) (string, uint64, uint64, bool) {
+	fake.getHistoryForKeyByBlockRangeMutex.RLock()
+	defer fake.getHistoryForKeyByBlockRangeMutex.RUnlock()
+	argsForCall := fake.getHistoryForKeyByBlockRangeArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
+}
+
+func (fake *ChaincodeStub) GetHistoryForKeyByBlockRangeReturns(result1 shim.HistoryQueryIteratorInterface, result2 error) {
+	fake.getHistoryForKeyByBlockRangeMutex.Lock()
+	defer fake.getHistoryForKeyByBlockRangeMutex.Unlock()
+	fake.GetHistoryForKeyByBlockRangeStub = nil
+	fake.getHistoryForKeyByBlockRangeReturns = struct {
+		result1 shim.HistoryQueryIteratorInterface
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *ChaincodeStub) GetHistoryForKeyByBlockRangeReturnsOnCall(i int, result1 shim.HistoryQueryIteratorInterface, result2 error) {
+	fake.getHistoryForKeyByBlockRangeMutex.Lock()
+	defer fake.getHistoryForKeyByBlockRangeMutex.Unlock()
+	fake.GetHistoryForKeyByBlockRangeStub = nil
+	if fake.getHistoryForKeyByBlockRangeReturnsOnCall == nil {
+		fake.getHistoryForKeyByBlockRangeReturnsOnCall = make(map[int]struct {
+			result1 shim.HistoryQueryIteratorInterface
+			result2 error
+		})
+	}
+	fake.getHistoryForKeyByBlockRangeReturnsOnCall[i] = struct {
+		result1 shim.HistoryQueryIteratorInterface
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *ChaincodeStub) GetHistoryForKeyByBlockRangeWithPagination(arg1 string, arg2 uint64, arg3 uint64, arg4 bool, arg5 int32, arg6 string) (shim.HistoryQueryIteratorInterface, *peer.QueryResponseMetadata, error) {
+	fake.getHistoryForKeyByBlockRangeWithPaginationMutex.Lock()
+	ret, specificReturn := fake.getHistoryForKeyByBlockRangeWithPaginationReturnsOnCall[len(fake.getHistoryForKeyByBlockRangeWithPaginationArgsForCall)]
+	fake.getHistoryForKeyByBlockRangeWithPaginationArgsForCall = append(fake.getHistoryForKeyByBlockRangeWithPaginationArgsForCall, struct {
+		arg1 string
+		arg2 uint64
+		arg3 uint64
+		arg4 bool
+		arg5 int32
+		arg6 string
+	}{arg1, arg2, arg3, arg4, arg5, arg6})
+	fake.recordInvocation("GetHistoryForKeyByBlockRangeWithPagination", []interface{}{arg1, arg2, arg3, arg4, arg5, arg6})
+	fake.getHistoryForKeyByBlockRangeWithPaginationMutex.Unlock()
+	if fake.GetHistoryForKeyByBlockRangeWithPaginationStub != nil {
+		return fake.GetHistoryForKeyByBlockRangeWithPaginationStub(arg1, arg2, arg3, arg4, arg5, arg6)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	fakeReturns := fake.getHistoryForKeyByBlockRangeWithPaginationReturns
+	return fakeReturns.result1, fakeReturns.result2, fakeReturns.result3
+}
+
+func (fake *ChaincodeStub) GetHistoryForKeyByBlockRangeWithPaginationCallCount() int {
+	fake.getHistoryForKeyByBlockRangeWithPaginationMutex.RLock()
+	defer fake.getHistoryForKeyByBlockRangeWithPaginationMutex.RUnlock()
+	return len(fake.getHistoryForKeyByBlockRangeWithPaginationArgsForCall)
+}
+
+func (fake *ChaincodeStub) GetHistoryForKeyByBlockRangeWithPaginationCalls(stub func(string, uint64, uint64, bool, int32, string) (shim.HistoryQueryIteratorInterface, *peer.QueryResponseMetadata, error)) {
+	fake.getHistoryForKeyByBlockRangeWithPaginationMutex.Lock()
+	defer fake.getHistoryForKeyByBlockRangeWithPaginationMutex.Unlock()
+	fake.GetHistoryForKeyByBlockRangeWithPaginationStub = stub
+}
+
+func (fake *ChaincodeStub) GetHistoryForKeyByBlockRangeWithPaginationArgsForCall(i int) (string, uint64, uint64, bool, int32, string) {
+	fake.getHistoryForKeyByBlockRangeWithPaginationMutex.RLock()
+	defer fake.getHistoryForKeyByBlockRangeWithPaginationMutex.RUnlock()
+	argsForCall := fake.getHistoryForKeyByBlockRangeWithPaginationArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4, argsForCall.arg5, argsForCall.arg6
+}
+
+func (fake *ChaincodeStub) GetHistoryForKeyByBlockRangeWithPaginationReturns(result1 shim.HistoryQueryIteratorInterface, result2 *peer.QueryResponseMetadata, result3 error) {
+	fake.getHistoryForKeyByBlockRangeWithPaginationMutex.Lock()
+	defer fake.getHistoryForKeyByBlockRangeWithPaginationMutex.Unlock()
+	fake.GetHistoryForKeyByBlockRangeWithPaginationStub = nil
+	fake.getHistoryForKeyByBlockRangeWithPaginationReturns = struct {
+		result1 shim.HistoryQueryIteratorInterface
+		result2 *peer.QueryResponseMetadata
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *ChaincodeStub) GetHistoryForKeyByBlockRangeWithPaginationReturnsOnCall(i int, result1 shim.HistoryQueryIteratorInterface, result2 *peer.QueryResponseMetadata, result3 error) {
+	fake.getHistoryForKeyByBlockRangeWithPaginationMutex.Lock()
+	defer fake.getHistoryForKeyByBlockRangeWithPaginationMutex.Unlock()
+	fake.GetHistoryForKeyByBlockRangeWithPaginationStub = nil
+	if fake.getHistoryForKeyByBlockRangeWithPaginationReturnsOnCall == nil {
+		fake.getHistoryForKeyByBlockRangeWithPaginationReturnsOnCall = make(map[int]struct {
+			result1 shim.HistoryQueryIteratorInterface
+			result2 *peer.QueryResponseMetadata
+			result3 error
+		})
+	}
+	fake.getHistoryForKeyByBlockRangeWithPaginationReturnsOnCall[i] = struct {
+		result1 shim.HistoryQueryIteratorInterface
+		result2 *peer.QueryResponseMetadata
+		result3 error
+	}{result1, result2, result3}
+}
+
 func (fake *ChaincodeStub) GetPrivateData(arg1 string, arg2 string) ([]byte, error) {
 	fake.getPrivateDataMutex.Lock()
 	ret, specificReturn := fake.getPrivateDataReturnsOnCall[len(fake.getPrivateDataArgsForCall)]
@@ -1308,6 +1565,134 @@ func (fake *ChaincodeStub) GetPrivateDataByRangeReturnsOnCall(i int, result1 shi
 	}{result1, result2}
 }
 
+func (fake *ChaincodeStub) GetPrivateDataHash(arg1 string, arg2 string) ([]byte, error) {
+	fake.getPrivateDataHashMutex.Lock()
+	ret, specificReturn := fake.getPrivateDataHashReturnsOnCall[len(fake.getPrivateDataHashArgsForCall)]
+	fake.getPrivateDataHashArgsForCall = append(fake.getPrivateDataHashArgsForCall, struct {
+		arg1 string
+		arg2 string
+	}{arg1, arg2})
+	fake.recordInvocation("GetPrivateDataHash", []interface{}{arg1, arg2})
+	fake.getPrivateDataHashMutex.Unlock()
+	if fake.GetPrivateDataHashStub != nil {
+		return fake.GetPrivateDataHashStub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.getPrivateDataHashReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *ChaincodeStub) GetPrivateDataHashCallCount() int {
+	fake.getPrivateDataHashMutex.RLock()
+	defer fake.getPrivateDataHashMutex.RUnlock()
+	return len(fake.getPrivateDataHashArgsForCall)
+}
+
+func (fake *ChaincodeStub) GetPrivateDataHashCalls(stub func(string, string) ([]byte, error)) {
+	fake.getPrivateDataHashMutex.Lock()
+	defer fake.getPrivateDataHashMutex.Unlock()
+	fake.GetPrivateDataHashStub = stub
+}
+
+func (fake *ChaincodeStub) GetPrivateDataHashArgsForCall(i int) (string, string) {
+	fake.getPrivateDataHashMutex.RLock()
+	defer fake.getPrivateDataHashMutex.RUnlock()
+	argsForCall := fake.getPrivateDataHashArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *ChaincodeStub) GetPrivateDataHashReturns(result1 []byte, result2 error) {
+	fake.getPrivateDataHashMutex.Lock()
+	defer fake.getPrivateDataHashMutex.Unlock()
+	fake.GetPrivateDataHashStub = nil
+	fake.getPrivateDataHashReturns = struct {
+		result1 []byte
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *ChaincodeStub) GetPrivateDataHashReturnsOnCall(i int, result1 []byte, result2 error) {
+	fake.getPrivateDataHashMutex.Lock()
+	defer fake.getPrivateDataHashMutex.Unlock()
+	fake.GetPrivateDataHashStub = nil
+	if fake.getPrivateDataHashReturnsOnCall == nil {
+		fake.getPrivateDataHashReturnsOnCall = make(map[int]struct {
+			result1 []byte
+			result2 error
+		})
+	}
+	fake.getPrivateDataHashReturnsOnCall[i] = struct {
+		result1 []byte
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *ChaincodeStub) GetPrivateDataHistoryForKey(arg1 string, arg2 string) (shim.PrivateDataHistoryQueryIteratorInterface, error) {
+	fake.getPrivateDataHistoryForKeyMutex.Lock()
+	ret, specificReturn := fake.getPrivateDataHistoryForKeyReturnsOnCall[len(fake.getPrivateDataHistoryForKeyArgsForCall)]
+	fake.getPrivateDataHistoryForKeyArgsForCall = append(fake.getPrivateDataHistoryForKeyArgsForCall, struct {
+		arg1 string
+		arg2 string
+	}{arg1, arg2})
+	fake.recordInvocation("GetPrivateDataHistoryForKey", []interface{}{arg1, arg2})
+	fake.getPrivateDataHistoryForKeyMutex.Unlock()
+	if fake.GetPrivateDataHistoryForKeyStub != nil {
+		return fake.GetPrivateDataHistoryForKeyStub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.getPrivateDataHistoryForKeyReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *ChaincodeStub) GetPrivateDataHistoryForKeyCallCount() int {
+	fake.getPrivateDataHistoryForKeyMutex.RLock()
+	defer fake.getPrivateDataHistoryForKeyMutex.RUnlock()
+	return len(fake.getPrivateDataHistoryForKeyArgsForCall)
+}
+
+func (fake *ChaincodeStub) GetPrivateDataHistoryForKeyCalls(stub func(string, string) (shim.PrivateDataHistoryQueryIteratorInterface, error)) {
+	fake.getPrivateDataHistoryForKeyMutex.Lock()
+	defer fake.getPrivateDataHistoryForKeyMutex.Unlock()
+	fake.GetPrivateDataHistoryForKeyStub = stub
+}
+
+func (fake *ChaincodeStub) GetPrivateDataHistoryForKeyArgsForCall(i int) (string, string) {
+	fake.getPrivateDataHistoryForKeyMutex.RLock()
+	defer fake.getPrivateDataHistoryForKeyMutex.RUnlock()
+	argsForCall := fake.getPrivateDataHistoryForKeyArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *ChaincodeStub) GetPrivateDataHistoryForKeyReturns(result1 shim.PrivateDataHistoryQueryIteratorInterface, result2 error) {
+	fake.getPrivateDataHistoryForKeyMutex.Lock()
+	defer fake.getPrivateDataHistoryForKeyMutex.Unlock()
+	fake.GetPrivateDataHistoryForKeyStub = nil
+	fake.getPrivateDataHistoryForKeyReturns = struct {
+		result1 shim.PrivateDataHistoryQueryIteratorInterface
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *ChaincodeStub) GetPrivateDataHistoryForKeyReturnsOnCall(i int, result1 shim.PrivateDataHistoryQueryIteratorInterface, result2 error) {
+	fake.getPrivateDataHistoryForKeyMutex.Lock()
+	defer fake.getPrivateDataHistoryForKeyMutex.Unlock()
+	fake.GetPrivateDataHistoryForKeyStub = nil
+	if fake.getPrivateDataHistoryForKeyReturnsOnCall == nil {
+		fake.getPrivateDataHistoryForKeyReturnsOnCall = make(map[int]struct {
+			result1 shim.PrivateDataHistoryQueryIteratorInterface
+			result2 error
+		})
+	}
+	fake.getPrivateDataHistoryForKeyReturnsOnCall[i] = struct {
+		result1 shim.PrivateDataHistoryQueryIteratorInterface
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *ChaincodeStub) GetPrivateDataQueryResult(arg1 string, arg2 string) (shim.StateQueryIteratorInterface, error) {
 	fake.getPrivateDataQueryResultMutex.Lock()
 	ret, specificReturn := fake.getPrivateDataQueryResultReturnsOnCall[len(fake.getPrivateDataQueryResultArgsForCall)]
@@ -1685,6 +2070,70 @@ func (fake *ChaincodeStub) GetStateReturnsOnCall(i int, result1 []byte, result2
 	}{result1, result2}
 }
 
+func (fake *ChaincodeStub) GetStateAtHeight(arg1 string, arg2 uint64) ([]byte, error) {
+	fake.getStateAtHeightMutex.Lock()
+	ret, specificReturn := fake.getStateAtHeightReturnsOnCall[len(fake.getStateAtHeightArgsForCall)]
+	fake.getStateAtHeightArgsForCall = append(fake.getStateAtHeightArgsForCall, struct {
+		arg1 string
+		arg2 uint64
+	}{arg1, arg2})
+	fake.recordInvocation("GetStateAtHeight", []interface{}{arg1, arg2})
+	fake.getStateAtHeightMutex.Unlock()
+	if fake.GetStateAtHeightStub != nil {
+		return fake.GetStateAtHeightStub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.getStateAtHeightReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *ChaincodeStub) GetStateAtHeightCallCount() int {
+	fake.getStateAtHeightMutex.RLock()
+	defer fake.getStateAtHeightMutex.RUnlock()
+	return len(fake.getStateAtHeightArgsForCall)
+}
+
+func (fake *ChaincodeStub) GetStateAtHeightCalls(stub func(string, uint64) ([]byte, error)) {
+	fake.getStateAtHeightMutex.Lock()
+	defer fake.getStateAtHeightMutex.Unlock()
+	fake.GetStateAtHeightStub = stub
+}
+
+func (fake *ChaincodeStub) GetStateAtHeightArgsForCall(i int) (string, uint64) {
+	fake.getStateAtHeightMutex.RLock()
+	defer fake.getStateAtHeightMutex.RUnlock()
+	argsForCall := fake.getStateAtHeightArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *ChaincodeStub) GetStateAtHeightReturns(result1 []byte, result2 error) {
+	fake.getStateAtHeightMutex.Lock()
+	defer fake.getStateAtHeightMutex.Unlock()
+	fake.GetStateAtHeightStub = nil
+	fake.getStateAtHeightReturns = struct {
+		result1 []byte
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *ChaincodeStub) GetStateAtHeightReturnsOnCall(i int, result1 []byte, result2 error) {
+	fake.getStateAtHeightMutex.Lock()
+	defer fake.getStateAtHeightMutex.Unlock()
+	fake.GetStateAtHeightStub = nil
+	if fake.getStateAtHeightReturnsOnCall == nil {
+		fake.getStateAtHeightReturnsOnCall = make(map[int]struct {
+			result1 []byte
+			result2 error
+		})
+	}
+	fake.getStateAtHeightReturnsOnCall[i] = struct {
+		result1 []byte
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *ChaincodeStub) GetStateByPartialCompositeKey(arg1 string, arg2 []string) (shim.StateQueryIteratorInterface, error) {
 	var arg2Copy []string
 	if arg2 != nil {
@@ -1892,6 +2341,71 @@ func (fake *ChaincodeStub) GetStateByRangeReturnsOnCall(i int, result1 shim.Stat
 	}{result1, result2}
 }
 
+func (fake *ChaincodeStub) GetStateByRangeAtHeight(arg1 string, arg2 string, arg3 uint64) (shim.StateQueryIteratorInterface, error) {
+	fake.getStateByRangeAtHeightMutex.Lock()
+	ret, specificReturn := fake.getStateByRangeAtHeightReturnsOnCall[len(fake.getStateByRangeAtHeightArgsForCall)]
+	fake.getStateByRangeAtHeightArgsForCall = append(fake.getStateByRangeAtHeightArgsForCall, struct {
+		arg1 string
+		arg2 string
+		arg3 uint64
+	}{arg1, arg2, arg3})
+	fake.recordInvocation("GetStateByRangeAtHeight", []interface{}{arg1, arg2, arg3})
+	fake.getStateByRangeAtHeightMutex.Unlock()
+	if fake.GetStateByRangeAtHeightStub != nil {
+		return fake.GetStateByRangeAtHeightStub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.getStateByRangeAtHeightReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *ChaincodeStub) GetStateByRangeAtHeightCallCount() int {
+	fake.getStateByRangeAtHeightMutex.RLock()
+	defer fake.getStateByRangeAtHeightMutex.RUnlock()
+	return len(fake.getStateByRangeAtHeightArgsForCall)
+}
+
+func (fake *ChaincodeStub) GetStateByRangeAtHeightCalls(stub func(string, string, uint64) (shim.StateQueryIteratorInterface, error)) {
+	fake.getStateByRangeAtHeightMutex.Lock()
+	defer fake.getStateByRangeAtHeightMutex.Unlock()
+	fake.GetStateByRangeAtHeightStub = stub
+}
+
+func (fake *ChaincodeStub) GetStateByRangeAtHeightArgsForCall(i int) (string, string, uint64) {
+	fake.getStateByRangeAtHeightMutex.RLock()
+	defer fake.getStateByRangeAtHeightMutex.RUnlock()
+	argsForCall := fake.getStateByRangeAtHeightArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *ChaincodeStub) GetStateByRangeAtHeightReturns(result1 shim.StateQueryIteratorInterface, result2 error) {
+	fake.getStateByRangeAtHeightMutex.Lock()
+	defer fake.getStateByRangeAtHeightMutex.Unlock()
+	fake.GetStateByRangeAtHeightStub = nil
+	fake.getStateByRangeAtHeightReturns = struct {
+		result1 shim.StateQueryIteratorInterface
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *ChaincodeStub) GetStateByRangeAtHeightReturnsOnCall(i int, result1 shim.StateQueryIteratorInterface, result2 error) {
+	fake.getStateByRangeAtHeightMutex.Lock()
+	defer fake.getStateByRangeAtHeightMutex.Unlock()
+	fake.GetStateByRangeAtHeightStub = nil
+	if fake.getStateByRangeAtHeightReturnsOnCall == nil {
+		fake.getStateByRangeAtHeightReturnsOnCall = make(map[int]struct {
+			result1 shim.StateQueryIteratorInterface
+			result2 error
+		})
+	}
+	fake.getStateByRangeAtHeightReturnsOnCall[i] = struct {
+		result1 shim.StateQueryIteratorInterface
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *ChaincodeStub) GetStateByRangeWithPagination(arg1 string, arg2 string, arg3 int32, arg4 string) (shim.StateQueryIteratorInterface, *peer.QueryResponseMetadata, error) {
 	fake.getStateByRangeWithPaginationMutex.Lock()
 	ret, specificReturn := fake.getStateByRangeWithPaginationReturnsOnCall[len(fake.getStateByRangeWithPaginationArgsForCall)]
@@ -2305,6 +2819,67 @@ func (fake *ChaincodeStub) InvokeChaincodeReturnsOnCall(i int, result1 peer.Resp
 	}{result1}
 }
 
+func (fake *ChaincodeStub) PurgePrivateData(arg1 string, arg2 string) error {
+	fake.purgePrivateDataMutex.Lock()
+	ret, specificReturn := fake.purgePrivateDataReturnsOnCall[len(fake.purgePrivateDataArgsForCall)]
+	fake.purgePrivateDataArgsForCall = append(fake.purgePrivateDataArgsForCall, struct {
+		arg1 string
+		arg2 string
+	}{arg1, arg2})
+	fake.recordInvocation("PurgePrivateData", []interface{}{arg1, arg2})
+	fake.purgePrivateDataMutex.Unlock()
+	if fake.PurgePrivateDataStub != nil {
+		return fake.PurgePrivateDataStub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.purgePrivateDataReturns
+	return fakeReturns.result1
+}
+
+func (fake *ChaincodeStub) PurgePrivateDataCallCount() int {
+	fake.purgePrivateDataMutex.RLock()
+	defer fake.purgePrivateDataMutex.RUnlock()
+	return len(fake.purgePrivateDataArgsForCall)
+}
+
+func (fake *ChaincodeStub) PurgePrivateDataCalls(stub func(string, string) error) {
+	fake.purgePrivateDataMutex.Lock()
+	defer fake.purgePrivateDataMutex.Unlock()
+	fake.PurgePrivateDataStub = stub
+}
+
+func (fake *ChaincodeStub) PurgePrivateDataArgsForCall(i int) (string, string) {
+	fake.purgePrivateDataMutex.RLock()
+	defer fake.purgePrivateDataMutex.RUnlock()
+	argsForCall := fake.purgePrivateDataArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *ChaincodeStub) PurgePrivateDataReturns(result1 error) {
+	fake.purgePrivateDataMutex.Lock()
+	defer fake.purgePrivateDataMutex.Unlock()
+	fake.PurgePrivateDataStub = nil
+	fake.purgePrivateDataReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *ChaincodeStub) PurgePrivateDataReturnsOnCall(i int, result1 error) {
+	fake.purgePrivateDataMutex.Lock()
+	defer fake.purgePrivateDataMutex.Unlock()
+	fake.PurgePrivateDataStub = nil
+	if fake.purgePrivateDataReturnsOnCall == nil {
+		fake.purgePrivateDataReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.purgePrivateDataReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *ChaincodeStub) PutPrivateData(arg1 string, arg2 string, arg3 []byte) error {
 	var arg3Copy []byte
 	if arg3 != nil {
@@ -2703,6 +3278,76 @@ func (fake *ChaincodeStub) SplitCompositeKeyReturnsOnCall(i int, result1 string,
 	}{result1, result2, result3}
 }
 
+func (fake *ChaincodeStub) VerifyPrivateDataHash(arg1 string, arg2 string, arg3 []byte) (bool, error) {
+	var arg3Copy []byte
+	if arg3 != nil {
+		arg3Copy = make([]byte, len(arg3))
+		copy(arg3Copy, arg3)
+	}
+	fake.verifyPrivateDataHashMutex.Lock()
+	ret, specificReturn := fake.verifyPrivateDataHashReturnsOnCall[len(fake.verifyPrivateDataHashArgsForCall)]
+	fake.verifyPrivateDataHashArgsForCall = append(fake.verifyPrivateDataHashArgsForCall, struct {
+		arg1 string
+		arg2 string
+		arg3 []byte
+	}{arg1, arg2, arg3Copy})
+	fake.recordInvocation("VerifyPrivateDataHash", []interface{}{arg1, arg2, arg3Copy})
+	fake.verifyPrivateDataHashMutex.Unlock()
+	if fake.VerifyPrivateDataHashStub != nil {
+		return fake.VerifyPrivateDataHashStub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.verifyPrivateDataHashReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *ChaincodeStub) VerifyPrivateDataHashCallCount() int {
+	fake.verifyPrivateDataHashMutex.RLock()
+	defer fake.verifyPrivateDataHashMutex.RUnlock()
+	return len(fake.verifyPrivateDataHashArgsForCall)
+}
+
+func (fake *ChaincodeStub) VerifyPrivateDataHashCalls(stub func(string, string, []byte) (bool, error)) {
+	fake.verifyPrivateDataHashMutex.Lock()
+	defer fake.verifyPrivateDataHashMutex.Unlock()
+	fake.VerifyPrivateDataHashStub = stub
+}
+
+func (fake *ChaincodeStub) VerifyPrivateDataHashArgsForCall(i int) (string, string, []byte) {
+	fake.verifyPrivateDataHashMutex.RLock()
+	defer fake.verifyPrivateDataHashMutex.RUnlock()
+	argsForCall := fake.verifyPrivateDataHashArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *ChaincodeStub) VerifyPrivateDataHashReturns(result1 bool, result2 error) {
+	fake.verifyPrivateDataHashMutex.Lock()
+	defer fake.verifyPrivateDataHashMutex.Unlock()
+	fake.VerifyPrivateDataHashStub = nil
+	fake.verifyPrivateDataHashReturns = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *ChaincodeStub) VerifyPrivateDataHashReturnsOnCall(i int, result1 bool, result2 error) {
+	fake.verifyPrivateDataHashMutex.Lock()
+	defer fake.verifyPrivateDataHashMutex.Unlock()
+	fake.VerifyPrivateDataHashStub = nil
+	if fake.verifyPrivateDataHashReturnsOnCall == nil {
+		fake.verifyPrivateDataHashReturnsOnCall = make(map[int]struct {
+			result1 bool
+			result2 error
+		})
+	}
+	fake.verifyPrivateDataHashReturnsOnCall[i] = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *ChaincodeStub) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
@@ -2728,12 +3373,20 @@ func (fake *ChaincodeStub) Invocations() map[string][][]interface{} {
 	defer fake.getFunctionAndParametersMutex.RUnlock()
 	fake.getHistoryForKeyMutex.RLock()
 	defer fake.getHistoryForKeyMutex.RUnlock()
+	fake.getHistoryForKeyByBlockRangeMutex.RLock()
+	defer fake.getHistoryForKeyByBlockRangeMutex.RUnlock()
+	fake.getHistoryForKeyByBlockRangeWithPaginationMutex.RLock()
+	defer fake.getHistoryForKeyByBlockRangeWithPaginationMutex.RUnlock()
 	fake.getPrivateDataMutex.RLock()
 	defer fake.getPrivateDataMutex.RUnlock()
 	fake.getPrivateDataByPartialCompositeKeyMutex.RLock()
 	defer fake.getPrivateDataByPartialCompositeKeyMutex.RUnlock()
 	fake.getPrivateDataByRangeMutex.RLock()
 	defer fake.getPrivateDataByRangeMutex.RUnlock()
+	fake.getPrivateDataHashMutex.RLock()
+	defer fake.getPrivateDataHashMutex.RUnlock()
+	fake.getPrivateDataHistoryForKeyMutex.RLock()
+	defer fake.getPrivateDataHistoryForKeyMutex.RUnlock()
 	fake.getPrivateDataQueryResultMutex.RLock()
 	defer fake.getPrivateDataQueryResultMutex.RUnlock()
 	fake.getPrivateDataValidationParameterMutex.RLock()
@@ -2746,12 +3399,16 @@ func (fake *ChaincodeStub) Invocations() map[string][][]interface{} {
 	defer fake.getSignedProposalMutex.RUnlock()
 	fake.getStateMutex.RLock()
 	defer fake.getStateMutex.RUnlock()
+	fake.getStateAtHeightMutex.RLock()
+	defer fake.getStateAtHeightMutex.RUnlock()
 	fake.getStateByPartialCompositeKeyMutex.RLock()
 	defer fake.getStateByPartialCompositeKeyMutex.RUnlock()
 	fake.getStateByPartialCompositeKeyWithPaginationMutex.RLock()
 	defer fake.getStateByPartialCompositeKeyWithPaginationMutex.RUnlock()
 	fake.getStateByRangeMutex.RLock()
 	defer fake.getStateByRangeMutex.RUnlock()
+	fake.getStateByRangeAtHeightMutex.RLock()
+	defer fake.getStateByRangeAtHeightMutex.RUnlock()
 	fake.getStateByRangeWithPaginationMutex.RLock()
 	defer fake.getStateByRangeWithPaginationMutex.RUnlock()
 	fake.getStateValidationParameterMutex.RLock()
@@ -2766,6 +3423,8 @@ func (fake *ChaincodeStub) Invocations() map[string][][]interface{} {
 	defer fake.getTxTimestampMutex.RUnlock()
 	fake.invokeChaincodeMutex.RLock()
 	defer fake.invokeChaincodeMutex.RUnlock()
+	fake.purgePrivateDataMutex.RLock()
+	defer fake.purgePrivateDataMutex.RUnlock()
 	fake.putPrivateDataMutex.RLock()
 	defer fake.putPrivateDataMutex.RUnlock()
 	fake.putStateMutex.RLock()
@@ -2778,6 +3437,8 @@ func (fake *ChaincodeStub) Invocations() map[string][][]interface{} {
 	defer fake.setStateValidationParameterMutex.RUnlock()
 	fake.splitCompositeKeyMutex.RLock()
 	defer fake.splitCompositeKeyMutex.RUnlock()
+	fake.verifyPrivateDataHashMutex.RLock()
+	defer fake.verifyPrivateDataHashMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
@@ -15,6 +15,7 @@ import (
 	"github.com/hyperledger/fabric/core/chaincode/shim"
 	"github.com/hyperledger/fabric/core/ledger"
 	"github.com/hyperledger/fabric/core/peer"
+	"github.com/hyperledger/fabric/protos/ledger/queryresult"
 	pb "github.com/hyperledger/fabric/protos/peer"
 	"github.com/hyperledger/fabric/protos/utils"
 )
@@ -40,6 +41,8 @@ func (e *LedgerQuerier) Enabled() bool             { return true }
 // - GetBlockByNumber returns a block
 // - GetBlockByHash returns a block
 // - GetTransactionByID returns a transaction
+// - GetStateAtHeight returns the value of a key as of a block height
+// - GetStateByRangeAtHeight returns the key-values of a key range as of a block height
 type LedgerQuerier struct {
 	aclProvider aclmgmt.ACLProvider
 }
@@ -53,6 +56,9 @@ const (
 	GetBlockByHash     string = "GetBlockByHash"
 	GetTransactionByID string = "GetTransactionByID"
 	GetBlockByTxID     string = "GetBlockByTxID"
+
+	GetStateAtHeight        string = "GetStateAtHeight"
+	GetStateByRangeAtHeight string = "GetStateByRangeAtHeight"
 )
 
 // Init is called once per chain when the chain is created.
@@ -71,6 +77,8 @@ func (e *LedgerQuerier) Init(stub shim.ChaincodeStubInterface) pb.Response {
 // # GetBlockByNumber: Return the block specified by block number in args[2]
 // # GetBlockByHash: Return the block specified by block hash in args[2]
 // # GetTransactionByID: Return the transaction specified by ID in args[2]
+// # GetStateAtHeight: Return the value of namespace args[2] and key args[3] as of block number args[4]
+// # GetStateByRangeAtHeight: Return the KVs of namespace args[2] in range [args[3], args[4]) as of block number args[5]
 func (e *LedgerQuerier) Invoke(stub shim.ChaincodeStubInterface) pb.Response {
 	args := stub.GetArgs()
 
@@ -115,6 +123,10 @@ func (e *LedgerQuerier) Invoke(stub shim.ChaincodeStubInterface) pb.Response {
 		return getChainInfo(targetLedger)
 	case GetBlockByTxID:
 		return getBlockByTxID(targetLedger, args[2])
+	case GetStateAtHeight:
+		return getStateAtHeight(targetLedger, args[2:])
+	case GetStateByRangeAtHeight:
+		return getStateByRangeAtHeight(targetLedger, args[2:])
 	}
 
 	return shim.Error(fmt.Sprintf("Requested function %s not found.", fname))
@@ -214,6 +226,70 @@ func getBlockByTxID(vledger ledger.PeerLedger, rawTxID []byte) pb.Response {
 	return shim.Success(bytes)
 }
 
+func getStateAtHeight(vledger ledger.PeerLedger, args [][]byte) pb.Response {
+	if len(args) != 3 {
+		return shim.Error(fmt.Sprintf("Incorrect number of arguments for %s, expecting namespace, key and block number", GetStateAtHeight))
+	}
+	namespace, key := string(args[0]), string(args[1])
+	bnum, err := strconv.ParseUint(string(args[2]), 10, 64)
+	if err != nil {
+		return shim.Error(fmt.Sprintf("Failed to parse block number with error %s", err))
+	}
+	hqe, err := vledger.NewHistoryQueryExecutor()
+	if err != nil {
+		return shim.Error(fmt.Sprintf("Failed to get history query executor with error %s", err))
+	}
+	value, err := hqe.GetStateAtHeight(namespace, key, bnum)
+	if err != nil {
+		return shim.Error(fmt.Sprintf("Failed to get state of key %s in namespace %s at block number %d, error %s", key, namespace, bnum, err))
+	}
+
+	return shim.Success(value)
+}
+
+func getStateByRangeAtHeight(vledger ledger.PeerLedger, args [][]byte) pb.Response {
+	if len(args) != 4 {
+		return shim.Error(fmt.Sprintf("Incorrect number of arguments for %s, expecting namespace, start key, end key and block number", GetStateByRangeAtHeight))
+	}
+	namespace, startKey, endKey := string(args[0]), string(args[1]), string(args[2])
+	bnum, err := strconv.ParseUint(string(args[3]), 10, 64)
+	if err != nil {
+		return shim.Error(fmt.Sprintf("Failed to parse block number with error %s", err))
+	}
+	hqe, err := vledger.NewHistoryQueryExecutor()
+	if err != nil {
+		return shim.Error(fmt.Sprintf("Failed to get history query executor with error %s", err))
+	}
+	itr, err := hqe.GetStateRangeScanIteratorAtHeight(namespace, startKey, endKey, bnum)
+	if err != nil {
+		return shim.Error(fmt.Sprintf("Failed to get state of range [%s, %s) in namespace %s at block number %d, error %s", startKey, endKey, namespace, bnum, err))
+	}
+	defer itr.Close()
+
+	queryResponse := &pb.QueryResponse{}
+	for {
+		res, err := itr.Next()
+		if err != nil {
+			return shim.Error(fmt.Sprintf("Failed to iterate over the state at block number %d, error %s", bnum, err))
+		}
+		if res == nil {
+			break
+		}
+		kvBytes, err := utils.Marshal(res.(*queryresult.KV))
+		if err != nil {
+			return shim.Error(err.Error())
+		}
+		queryResponse.Results = append(queryResponse.Results, &pb.QueryResultBytes{ResultBytes: kvBytes})
+	}
+
+	bytes, err := utils.Marshal(queryResponse)
+	if err != nil {
+		return shim.Error(err.Error())
+	}
+
+	return shim.Success(bytes)
+}
+
 func getACLResource(fname string) string {
 	return "qscc/" + fname
 }
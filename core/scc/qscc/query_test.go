@@ -21,6 +21,7 @@ import (
 	"os"
 	"testing"
 
+	"github.com/golang/protobuf/proto"
 	"github.com/hyperledger/fabric/common/ledger/testutil"
 	"github.com/hyperledger/fabric/common/util"
 	"github.com/hyperledger/fabric/core/aclmgmt/mocks"
@@ -29,6 +30,7 @@ import (
 	ledger2 "github.com/hyperledger/fabric/core/ledger"
 	"github.com/hyperledger/fabric/core/peer"
 	"github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/ledger/queryresult"
 	peer2 "github.com/hyperledger/fabric/protos/peer"
 	"github.com/hyperledger/fabric/protos/utils"
 	"github.com/pkg/errors"
@@ -252,6 +254,18 @@ func TestFailingAccessControl(t *testing.T) {
 	assert.Contains(t, res.Message, "Failed access control")
 	// assert that the expectations were met
 	mockAclProvider.AssertExpectations(t)
+
+	// GetStateAtHeight
+	args = [][]byte{[]byte(GetStateAtHeight), []byte(chainid), []byte("ns1"), []byte("key1"), []byte("0")}
+	sProp, _ = utils.MockSignedEndorserProposalOrPanic(chainid, &peer2.ChaincodeSpec{}, []byte("Alice"), []byte("msg1"))
+	sProp.Signature = sProp.ProposalBytes
+	// Set the ACLProvider to have a failure
+	resetProvider(resources.Qscc_GetStateAtHeight, chainid, sProp, errors.New("Failed access control"))
+	res = stub.MockInvokeWithSignedProposal("2", args, sProp)
+	assert.Equal(t, int32(shim.ERROR), res.Status, "GetStateAtHeight must fail: %s", res.Message)
+	assert.Contains(t, res.Message, "Failed access control")
+	// assert that the expectations were met
+	mockAclProvider.AssertExpectations(t)
 }
 
 func TestQueryNonexistentFunction(t *testing.T) {
@@ -328,6 +342,71 @@ func TestQueryGeneratedBlock(t *testing.T) {
 	}
 }
 
+func TestQueryStateAtHeight(t *testing.T) {
+	chainid := "mytestchainid9"
+	path := tempDir(t, "test9")
+	defer os.RemoveAll(path)
+
+	viper.Set("ledger.history.enableHistoryDatabase", true)
+	defer viper.Set("ledger.history.enableHistoryDatabase", false)
+	stub, err := setupTestLedger(chainid, path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	addBlockForTesting(t, chainid)
+
+	args := [][]byte{[]byte(GetStateAtHeight), []byte(chainid), []byte("ns1"), []byte("key1"), []byte("1")}
+	prop := resetProvider(resources.Qscc_GetStateAtHeight, chainid, &peer2.SignedProposal{}, nil)
+	res := stub.MockInvokeWithSignedProposal("1", args, prop)
+	assert.Equal(t, int32(shim.OK), res.Status, "GetStateAtHeight failed with err: %s", res.Message)
+	assert.Equal(t, []byte("value1"), res.Payload)
+
+	args = [][]byte{[]byte(GetStateAtHeight), []byte(chainid), []byte("ns1"), []byte("key1"), []byte("0")}
+	res = stub.MockInvokeWithSignedProposal("2", args, prop)
+	assert.Equal(t, int32(shim.OK), res.Status, "GetStateAtHeight failed with err: %s", res.Message)
+	assert.Nil(t, res.Payload)
+
+	args = [][]byte{[]byte(GetStateAtHeight), []byte(chainid), []byte("ns1"), []byte("key1"), []byte("2")}
+	res = stub.MockInvokeWithSignedProposal("3", args, prop)
+	assert.Equal(t, int32(shim.ERROR), res.Status, "GetStateAtHeight should have failed for a block number beyond the height")
+
+	args = [][]byte{[]byte(GetStateAtHeight), []byte(chainid), []byte("ns1"), []byte("key1"), []byte("abc")}
+	res = stub.MockInvokeWithSignedProposal("4", args, prop)
+	assert.Equal(t, int32(shim.ERROR), res.Status, "GetStateAtHeight should have failed for an invalid block number")
+
+	args = [][]byte{[]byte(GetStateAtHeight), []byte(chainid), []byte("ns1"), []byte("key1")}
+	res = stub.MockInvokeWithSignedProposal("5", args, prop)
+	assert.Equal(t, int32(shim.ERROR), res.Status, "GetStateAtHeight should have failed due to incorrect number of arguments")
+
+	args = [][]byte{[]byte(GetStateByRangeAtHeight), []byte(chainid), []byte("ns1"), []byte("key1"), []byte("key3"), []byte("1")}
+	prop = resetProvider(resources.Qscc_GetStateByRangeAtHeight, chainid, &peer2.SignedProposal{}, nil)
+	res = stub.MockInvokeWithSignedProposal("6", args, prop)
+	assert.Equal(t, int32(shim.OK), res.Status, "GetStateByRangeAtHeight failed with err: %s", res.Message)
+	queryResponse := &peer2.QueryResponse{}
+	assert.NoError(t, proto.Unmarshal(res.Payload, queryResponse))
+	var kvs []*queryresult.KV
+	for _, result := range queryResponse.Results {
+		kv := &queryresult.KV{}
+		assert.NoError(t, proto.Unmarshal(result.ResultBytes, kv))
+		kvs = append(kvs, kv)
+	}
+	assert.Equal(t, []*queryresult.KV{
+		{Namespace: "ns1", Key: "key1", Value: []byte("value1")},
+		{Namespace: "ns1", Key: "key2", Value: []byte("value2")},
+	}, kvs)
+
+	args = [][]byte{[]byte(GetStateByRangeAtHeight), []byte(chainid), []byte("ns1"), []byte(""), []byte(""), []byte("0")}
+	res = stub.MockInvokeWithSignedProposal("7", args, prop)
+	assert.Equal(t, int32(shim.OK), res.Status, "GetStateByRangeAtHeight failed with err: %s", res.Message)
+	queryResponse = &peer2.QueryResponse{}
+	assert.NoError(t, proto.Unmarshal(res.Payload, queryResponse))
+	assert.Len(t, queryResponse.Results, 0)
+
+	args = [][]byte{[]byte(GetStateByRangeAtHeight), []byte(chainid), []byte("ns1"), []byte(""), []byte("")}
+	res = stub.MockInvokeWithSignedProposal("8", args, prop)
+	assert.Equal(t, int32(shim.ERROR), res.Status, "GetStateByRangeAtHeight should have failed due to incorrect number of arguments")
+}
+
 func addBlockForTesting(t *testing.T, chainid string) *common.Block {
 	ledger := peer.GetLedger(chainid)
 	defer ledger.Close()
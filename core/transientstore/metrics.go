@@ -0,0 +1,64 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package transientstore
+
+import "github.com/hyperledger/fabric/common/metrics"
+
+var (
+	entriesOpts = metrics.GaugeOpts{
+		Namespace:    "transientstore",
+		Subsystem:    "",
+		Name:         "entries",
+		Help:         "Number of private write sets in the transient store.",
+		LabelNames:   []string{"channel"},
+		StatsdFormat: "%{#fqname}.%{channel}",
+	}
+
+	sizeOpts = metrics.GaugeOpts{
+		Namespace:    "transientstore",
+		Subsystem:    "",
+		Name:         "size_bytes",
+		Help:         "Total size in bytes of the private write sets in the transient store.",
+		LabelNames:   []string{"channel"},
+		StatsdFormat: "%{#fqname}.%{channel}",
+	}
+
+	evictedEntriesOpts = metrics.CounterOpts{
+		Namespace:    "transientstore",
+		Subsystem:    "",
+		Name:         "evicted_entries",
+		Help:         "Number of private write sets evicted from the transient store because of their age or the size of the store.",
+		LabelNames:   []string{"channel", "reason"},
+		StatsdFormat: "%{#fqname}.%{channel}.%{reason}",
+	}
+)
+
+type stats struct {
+	entries        metrics.Gauge
+	size           metrics.Gauge
+	evictedEntries metrics.Counter
+}
+
+func newStats(metricsProvider metrics.Provider) *stats {
+	return &stats{
+		entries:        metricsProvider.NewGauge(entriesOpts),
+		size:           metricsProvider.NewGauge(sizeOpts),
+		evictedEntries: metricsProvider.NewCounter(evictedEntriesOpts),
+	}
+}
+
+func (s *stats) updateStoreSize(ledgerID string, entries, size uint64) {
+	s.entries.With("channel", ledgerID).Set(float64(entries))
+	s.size.With("channel", ledgerID).Set(float64(size))
+}
+
+func (s *stats) addEvictedEntries(ledgerID string, reason string, count uint64) {
+	if count == 0 {
+		return
+	}
+	s.evictedEntries.With("channel", ledgerID, "reason", reason).Add(float64(count))
+}
@@ -8,10 +8,13 @@ package transientstore
 
 import (
 	"errors"
+	"sync"
+	"time"
 
 	"github.com/golang/protobuf/proto"
 	"github.com/hyperledger/fabric/common/flogging"
 	"github.com/hyperledger/fabric/common/ledger/util/leveldbhelper"
+	"github.com/hyperledger/fabric/common/metrics"
 	"github.com/hyperledger/fabric/common/util"
 	"github.com/hyperledger/fabric/core/ledger"
 	"github.com/hyperledger/fabric/protos/ledger/rwset"
@@ -78,6 +81,14 @@ type Store interface {
 	// after successful block commit, PurgeByHeight() is still required to remove orphan entries (as
 	// transaction that gets endorsed may not be submitted by the client for commit)
 	PurgeByHeight(maxBlockNumToRetain uint64) error
+	// PurgeByKeyHashes removes, from the private write sets received at block height lesser than or equal to the
+	// block of a purge marker, the collection write sets that contain a write of the key purged by the marker.
+	// This ensures that the cleartext of a purged key does not survive in the transient store. The whole
+	// collection write set is removed as a trimmed write set would not match the hash in the transaction anyway
+	PurgeByKeyHashes(purgeMarkers []*ledger.PvtDataPurgeMarker) error
+	// GetEntries returns the description of the private write sets in the transient store, ordered
+	// by txid. The private write sets themselves are not returned
+	GetEntries() ([]*EntryInfo, error)
 	// GetMinTransientBlkHt returns the lowest block height remaining in transient store
 	GetMinTransientBlkHt() (uint64, error)
 	Shutdown()
@@ -96,6 +107,24 @@ type EndorserPvtSimulationResultsWithConfig struct {
 	PvtSimulationResultsWithConfig *transientstore.TxPvtReadWriteSetWithConfigInfo
 }
 
+// EntryInfo describes a private write set persisted in the transient store
+type EntryInfo struct {
+	Txid                  string
+	UUID                  string
+	ReceivedAtBlockHeight uint64
+	PersistedAt           time.Time
+	Size                  uint64
+}
+
+// Config holds the limits beyond which private write sets are evicted from the transient store.
+// A private write set is evicted when it was persisted longer than MaxAge ago, and the oldest
+// private write sets are evicted when the total size of the private write sets of a channel
+// exceeds MaxSize bytes. A zero value disables the corresponding eviction
+type Config struct {
+	MaxAge  time.Duration
+	MaxSize uint64
+}
+
 //////////////////////////////////////////////
 // Implementation
 /////////////////////////////////////////////
@@ -105,12 +134,24 @@ type EndorserPvtSimulationResultsWithConfig struct {
 // interface.
 type storeProvider struct {
 	dbProvider *leveldbhelper.Provider
+	conf       *Config
+	stats      *stats
 }
 
 // store holds an instance of a levelDB.
 type store struct {
 	db       *leveldbhelper.DBHandle
 	ledgerID string
+	conf     *Config
+	stats    *stats
+	now      func() time.Time
+
+	// mutex serializes the updates to the store so that the number of entries and
+	// the size of the store, which drive the metrics and the size based eviction,
+	// remain accurate
+	mutex   sync.Mutex
+	entries uint64
+	size    uint64
 }
 
 type RwsetScanner struct {
@@ -120,15 +161,29 @@ type RwsetScanner struct {
 }
 
 // NewStoreProvider instantiates TransientStoreProvider
-func NewStoreProvider() StoreProvider {
+func NewStoreProvider(metricsProvider metrics.Provider) StoreProvider {
 	dbProvider := leveldbhelper.NewProvider(&leveldbhelper.Conf{DBPath: GetTransientStorePath()})
-	return &storeProvider{dbProvider: dbProvider}
+	return &storeProvider{
+		dbProvider: dbProvider,
+		conf:       GetTransientStoreConfig(),
+		stats:      newStats(metricsProvider),
+	}
 }
 
 // OpenStore returns a handle to a ledgerId in Store
 func (provider *storeProvider) OpenStore(ledgerID string) (Store, error) {
 	dbHandle := provider.dbProvider.GetDBHandle(ledgerID)
-	return &store{db: dbHandle, ledgerID: ledgerID}, nil
+	s := &store{
+		db:       dbHandle,
+		ledgerID: ledgerID,
+		conf:     provider.conf,
+		stats:    provider.stats,
+		now:      time.Now,
+	}
+	if err := s.loadSize(); err != nil {
+		return nil, err
+	}
+	return s, nil
 }
 
 // Close closes the TransientStoreProvider
@@ -151,13 +206,14 @@ func (s *store) Persist(txid string, blockHeight uint64,
 	// endorsers (via Gossip), we postfix an uuid with the txid to avoid collision.
 	uuid := util.GenerateUUID()
 	compositeKeyPvtRWSet := createCompositeKeyForPvtRWSet(txid, uuid, blockHeight)
-	privateSimulationResultsBytes, err := proto.Marshal(privateSimulationResults)
+	value, err := proto.Marshal(privateSimulationResults)
 	if err != nil {
 		return err
 	}
-	dbBatch.Put(compositeKeyPvtRWSet, privateSimulationResultsBytes)
+	dbBatch.Put(compositeKeyPvtRWSet, value)
 
-	// Create two index: (i) by txid, and (ii) by height
+	// Create three index: (i) by txid, (ii) by height, and (iii) by persist time. The
+	// last one is created along with the purge index by txid by writeNewEntry()
 
 	// Create compositeKey for purge index by height with appropriate prefix, blockHeight,
 	// txid, uuid and store the compositeKey (purge index) with a nil byte as value. Note that
@@ -168,9 +224,9 @@ func (s *store) Persist(txid string, blockHeight uint64,
 	dbBatch.Put(compositeKeyPurgeIndexByHeight, emptyValue)
 
 	// Create compositeKey for purge index by txid with appropriate prefix, txid, uuid,
-	// blockHeight and store the compositeKey (purge index) with a nil byte as value.
+	// blockHeight and store the compositeKey (purge index) with the persist time and size as value.
 	// Though compositeKeyPvtRWSet itself can be used to purge private write set by txid,
-	// we create a separate composite key with a nil byte as value. The reason is that
+	// we create a separate composite key with a small value. The reason is that
 	// if we use compositeKeyPvtRWSet, we unnecessarily read (potentially large) private write
 	// set associated with the key from db. Note that this purge index is used to remove non-orphan
 	// entries in the transient store and is used by PurgeTxids()
@@ -178,9 +234,8 @@ func (s *store) Persist(txid string, blockHeight uint64,
 	// with purgeIndexByTxidPrefix. For code readability and to be expressive, we use a
 	// createCompositeKeyForPurgeIndexByTxid() instead.
 	compositeKeyPurgeIndexByTxid := createCompositeKeyForPurgeIndexByTxid(txid, uuid, blockHeight)
-	dbBatch.Put(compositeKeyPurgeIndexByTxid, emptyValue)
 
-	return s.db.WriteBatch(dbBatch, true)
+	return s.writeNewEntry(dbBatch, compositeKeyPurgeIndexByTxid, txid, uuid, blockHeight, uint64(len(value)))
 }
 
 // PersistWithConfig stores the private write set of a transaction along with the collection config
@@ -212,7 +267,8 @@ func (s *store) PersistWithConfig(txid string, blockHeight uint64,
 	value := append([]byte{nilByte}, privateSimulationResultsWithConfigBytes...)
 	dbBatch.Put(compositeKeyPvtRWSet, value)
 
-	// Create two index: (i) by txid, and (ii) by height
+	// Create three index: (i) by txid, (ii) by height, and (iii) by persist time. The
+	// last one is created along with the purge index by txid by writeNewEntry()
 
 	// Create compositeKey for purge index by height with appropriate prefix, blockHeight,
 	// txid, uuid and store the compositeKey (purge index) with a nil byte as value. Note that
@@ -223,9 +279,9 @@ func (s *store) PersistWithConfig(txid string, blockHeight uint64,
 	dbBatch.Put(compositeKeyPurgeIndexByHeight, emptyValue)
 
 	// Create compositeKey for purge index by txid with appropriate prefix, txid, uuid,
-	// blockHeight and store the compositeKey (purge index) with a nil byte as value.
+	// blockHeight and store the compositeKey (purge index) with the persist time and size as value.
 	// Though compositeKeyPvtRWSet itself can be used to purge private write set by txid,
-	// we create a separate composite key with a nil byte as value. The reason is that
+	// we create a separate composite key with a small value. The reason is that
 	// if we use compositeKeyPvtRWSet, we unnecessarily read (potentially large) private write
 	// set associated with the key from db. Note that this purge index is used to remove non-orphan
 	// entries in the transient store and is used by PurgeTxids()
@@ -233,9 +289,8 @@ func (s *store) PersistWithConfig(txid string, blockHeight uint64,
 	// with purgeIndexByTxidPrefix. For code readability and to be expressive, we use a
 	// createCompositeKeyForPurgeIndexByTxid() instead.
 	compositeKeyPurgeIndexByTxid := createCompositeKeyForPurgeIndexByTxid(txid, uuid, blockHeight)
-	dbBatch.Put(compositeKeyPurgeIndexByTxid, emptyValue)
 
-	return s.db.WriteBatch(dbBatch, true)
+	return s.writeNewEntry(dbBatch, compositeKeyPurgeIndexByTxid, txid, uuid, blockHeight, uint64(len(value)))
 }
 
 // GetTxPvtRWSetByTxid returns an iterator due to the fact that the txid may have multiple private
@@ -259,7 +314,11 @@ func (s *store) PurgeByTxids(txids []string) error {
 
 	logger.Debug("Purging private data from transient store for committed txids")
 
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
 	dbBatch := leveldbhelper.NewUpdateBatch()
+	var purgedEntries, purgedSize uint64
 
 	for _, txid := range txids {
 		// Construct startKey and endKey to do an range query
@@ -272,27 +331,28 @@ func (s *store) PurgeByTxids(txids []string) error {
 		// write set and the corresponding indexes.
 		for iter.Next() {
 			// For each entry, remove the private read-write set and correponding indexes
-
-			// Remove private write set
-			compositeKeyPurgeIndexByTxid := iter.Key()
 			// Note: We can create compositeKeyPvtRWSet by just replacing the prefix of compositeKeyPurgeIndexByTxid
 			// with  prwsetPrefix. For code readability and to be expressive, we split and create again.
-			uuid, blockHeight := splitCompositeKeyOfPurgeIndexByTxid(compositeKeyPurgeIndexByTxid)
-			compositeKeyPvtRWSet := createCompositeKeyForPvtRWSet(txid, uuid, blockHeight)
-			dbBatch.Delete(compositeKeyPvtRWSet)
-
-			// Remove purge index -- purgeIndexByHeight
-			compositeKeyPurgeIndexByHeight := createCompositeKeyForPurgeIndexByHeight(blockHeight, txid, uuid)
-			dbBatch.Delete(compositeKeyPurgeIndexByHeight)
-
-			// Remove purge index -- purgeIndexByTxid
-			dbBatch.Delete(compositeKeyPurgeIndexByTxid)
+			uuid, blockHeight := splitCompositeKeyOfPurgeIndexByTxid(iter.Key())
+			size, removed, err := s.addEntryDeletesToBatch(dbBatch, txid, uuid, blockHeight)
+			if err != nil {
+				iter.Release()
+				return err
+			}
+			if removed {
+				purgedEntries++
+				purgedSize += size
+			}
 		}
 		iter.Release()
 	}
 	// If peer fails before/while writing the batch to golevelDB, these entries will be
 	// removed as per BTL policy later by PurgeByHeight()
-	return s.db.WriteBatch(dbBatch, true)
+	if err := s.db.WriteBatch(dbBatch, true); err != nil {
+		return err
+	}
+	s.removeEntries(purgedEntries, purgedSize)
+	return nil
 }
 
 // PurgeByHeight removes private write sets at block height lesser than
@@ -305,36 +365,356 @@ func (s *store) PurgeByHeight(maxBlockNumToRetain uint64) error {
 
 	logger.Debugf("Purging orphaned private data from transient store received prior to block [%d]", maxBlockNumToRetain)
 
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
 	// Do a range query with 0 as startKey and maxBlockNumToRetain-1 as endKey
 	startKey := createPurgeIndexByHeightRangeStartKey(0)
 	endKey := createPurgeIndexByHeightRangeEndKey(maxBlockNumToRetain - 1)
 	iter := s.db.GetIterator(startKey, endKey)
 
 	dbBatch := leveldbhelper.NewUpdateBatch()
+	var purgedEntries, purgedSize uint64
 
 	// Get all txid and uuid from above result and remove it from transient store (both
 	// write set and the corresponding index.
 	for iter.Next() {
 		// For each entry, remove the private read-write set and correponding indexes
-
-		// Remove private write set
-		compositeKeyPurgeIndexByHeight := iter.Key()
-		txid, uuid, blockHeight := splitCompositeKeyOfPurgeIndexByHeight(compositeKeyPurgeIndexByHeight)
+		txid, uuid, blockHeight := splitCompositeKeyOfPurgeIndexByHeight(iter.Key())
 		logger.Debugf("Purging from transient store private data simulated at block [%d]: txid [%s] uuid [%s]", blockHeight, txid, uuid)
 
+		size, removed, err := s.addEntryDeletesToBatch(dbBatch, txid, uuid, blockHeight)
+		if err != nil {
+			iter.Release()
+			return err
+		}
+		if removed {
+			purgedEntries++
+			purgedSize += size
+		}
+	}
+	iter.Release()
+
+	if err := s.db.WriteBatch(dbBatch, true); err != nil {
+		return err
+	}
+	s.removeEntries(purgedEntries, purgedSize)
+
+	// PurgeByHeight() is invoked periodically as blocks get committed, which makes it a
+	// convenient point to also evict the private write sets that are too old even when
+	// no new private write sets are persisted
+	return s.evict()
+}
+
+// PurgeByKeyHashes removes, from the private write sets received at block height lesser than or equal to the
+// block of a purge marker, the collection write sets that contain a write of the key purged by the marker.
+// PurgeByKeyHashes() is expected to be called by coordinator after committing a block that purges private data
+func (s *store) PurgeByKeyHashes(purgeMarkers []*ledger.PvtDataPurgeMarker) error {
+	if len(purgeMarkers) == 0 {
+		return nil
+	}
+
+	logger.Debugf("Purging private data from transient store for [%d] purged keys", len(purgeMarkers))
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	var maxBlockNum uint64
+	for _, purgeMarker := range purgeMarkers {
+		if purgeMarker.BlockNum > maxBlockNum {
+			maxBlockNum = purgeMarker.BlockNum
+		}
+	}
+
+	startKey := createPurgeIndexByHeightRangeStartKey(0)
+	endKey := createPurgeIndexByHeightRangeEndKey(maxBlockNum)
+	iter := s.db.GetIterator(startKey, endKey)
+	defer iter.Release()
+
+	dbBatch := leveldbhelper.NewUpdateBatch()
+	var purgedEntries, purgedSize, rewrittenSize uint64
+	for iter.Next() {
+		txid, uuid, blockHeight := splitCompositeKeyOfPurgeIndexByHeight(iter.Key())
 		compositeKeyPvtRWSet := createCompositeKeyForPvtRWSet(txid, uuid, blockHeight)
-		dbBatch.Delete(compositeKeyPvtRWSet)
+		dbVal, err := s.db.Get(compositeKeyPvtRWSet)
+		if err != nil {
+			return err
+		}
+		if dbVal == nil {
+			continue
+		}
+
+		var txPvtRWSet *rwset.TxPvtReadWriteSet
+		txPvtRWSetWithConfig := &transientstore.TxPvtReadWriteSetWithConfigInfo{}
+		isNewProto := len(dbVal) > 0 && dbVal[0] == nilByte
+		if isNewProto {
+			if err := proto.Unmarshal(dbVal[1:], txPvtRWSetWithConfig); err != nil {
+				return err
+			}
+			txPvtRWSet = txPvtRWSetWithConfig.GetPvtRwset()
+		} else {
+			txPvtRWSet = &rwset.TxPvtReadWriteSet{}
+			if err := proto.Unmarshal(dbVal, txPvtRWSet); err != nil {
+				return err
+			}
+		}
+
+		purgedTxPvtRWSet, purged := removePurgedCollections(txPvtRWSet, purgeMarkers, blockHeight)
+		if !purged {
+			continue
+		}
+		logger.Debugf("Purging from transient store private data of purged keys: txid [%s] uuid [%s]", txid, uuid)
+
+		if purgedTxPvtRWSet == nil {
+			size, removed, err := s.addEntryDeletesToBatch(dbBatch, txid, uuid, blockHeight)
+			if err != nil {
+				return err
+			}
+			if removed {
+				purgedEntries++
+				purgedSize += size
+			}
+			continue
+		}
 
-		// Remove purge index -- purgeIndexByTxid
+		var value []byte
+		if isNewProto {
+			txPvtRWSetWithConfig.PvtRwset = purgedTxPvtRWSet
+			valueBytes, err := proto.Marshal(txPvtRWSetWithConfig)
+			if err != nil {
+				return err
+			}
+			value = append([]byte{nilByte}, valueBytes...)
+		} else {
+			if value, err = proto.Marshal(purgedTxPvtRWSet); err != nil {
+				return err
+			}
+		}
+		dbBatch.Put(compositeKeyPvtRWSet, value)
+
+		// Record the new size of the private write set in the purge index by txid
 		compositeKeyPurgeIndexByTxid := createCompositeKeyForPurgeIndexByTxid(txid, uuid, blockHeight)
-		dbBatch.Delete(compositeKeyPurgeIndexByTxid)
+		indexValue, err := s.db.Get(compositeKeyPurgeIndexByTxid)
+		if err != nil {
+			return err
+		}
+		if persistTime, _, ok := splitValueOfPurgeIndexByTxid(indexValue); ok {
+			dbBatch.Put(compositeKeyPurgeIndexByTxid, createValueForPurgeIndexByTxid(persistTime, uint64(len(value))))
+		}
+		purgedSize += uint64(len(dbVal))
+		rewrittenSize += uint64(len(value))
+	}
 
-		// Remove purge index -- purgeIndexByHeight
-		dbBatch.Delete(compositeKeyPurgeIndexByHeight)
+	if err := s.db.WriteBatch(dbBatch, true); err != nil {
+		return err
 	}
-	iter.Release()
+	s.size += rewrittenSize
+	s.removeEntries(purgedEntries, purgedSize)
+	return nil
+}
+
+// GetEntries returns the description of the private write sets in the transient store, ordered by txid
+func (s *store) GetEntries() ([]*EntryInfo, error) {
+	iter := s.db.GetIterator(createPrefixRangeStartKey(purgeIndexByTxidPrefix), createPrefixRangeEndKey(purgeIndexByTxidPrefix))
+	defer iter.Release()
 
-	return s.db.WriteBatch(dbBatch, true)
+	var entries []*EntryInfo
+	for iter.Next() {
+		compositeKeyPurgeIndexByTxid := iter.Key()
+		txid := splitTxidOfCompositeKeyWithoutPrefix(compositeKeyPurgeIndexByTxid[2:])
+		uuid, blockHeight := splitCompositeKeyOfPurgeIndexByTxid(compositeKeyPurgeIndexByTxid)
+		persistTime, size, err := s.getEntryTimeAndSize(iter.Value(), txid, uuid, blockHeight)
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, &EntryInfo{
+			Txid:                  txid,
+			UUID:                  uuid,
+			ReceivedAtBlockHeight: blockHeight,
+			PersistedAt:           persistTime,
+			Size:                  size,
+		})
+	}
+	return entries, nil
+}
+
+// writeNewEntry writes the batch that persists a private write set along with the purge index by txid,
+// whose value records the persist time and the size of the private write set, and the purge index by time.
+// Once written, the private write sets beyond the limits of the store are evicted
+func (s *store) writeNewEntry(dbBatch *leveldbhelper.UpdateBatch, compositeKeyPurgeIndexByTxid []byte,
+	txid string, uuid string, blockHeight uint64, size uint64) error {
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	persistTime := s.now()
+	dbBatch.Put(compositeKeyPurgeIndexByTxid, createValueForPurgeIndexByTxid(persistTime, size))
+	dbBatch.Put(createCompositeKeyForPurgeIndexByTime(persistTime, txid, uuid, blockHeight), emptyValue)
+	if err := s.db.WriteBatch(dbBatch, true); err != nil {
+		return err
+	}
+	s.entries++
+	s.size += size
+	s.stats.updateStoreSize(s.ledgerID, s.entries, s.size)
+
+	return s.evict()
+}
+
+// addEntryDeletesToBatch adds to the batch the deletes of the private write set identified by
+// txid, uuid and blockHeight and of all its indexes. It returns the size of the private write set
+// and whether it is removed by the batch, i.e., whether it exists and was not yet deleted by the batch
+func (s *store) addEntryDeletesToBatch(dbBatch *leveldbhelper.UpdateBatch, txid string, uuid string,
+	blockHeight uint64) (uint64, bool, error) {
+
+	compositeKeyPvtRWSet := createCompositeKeyForPvtRWSet(txid, uuid, blockHeight)
+	if value, ok := dbBatch.KVs[string(compositeKeyPvtRWSet)]; ok && value == nil {
+		return 0, false, nil
+	}
+
+	compositeKeyPurgeIndexByTxid := createCompositeKeyForPurgeIndexByTxid(txid, uuid, blockHeight)
+	indexValue, err := s.db.Get(compositeKeyPurgeIndexByTxid)
+	if err != nil {
+		return 0, false, err
+	}
+	persistTime, size, hasPersistTime := splitValueOfPurgeIndexByTxid(indexValue)
+	removed := hasPersistTime
+	if !hasPersistTime {
+		value, err := s.db.Get(compositeKeyPvtRWSet)
+		if err != nil {
+			return 0, false, err
+		}
+		size, removed = uint64(len(value)), value != nil
+	}
+
+	dbBatch.Delete(compositeKeyPvtRWSet)
+	dbBatch.Delete(compositeKeyPurgeIndexByTxid)
+	dbBatch.Delete(createCompositeKeyForPurgeIndexByHeight(blockHeight, txid, uuid))
+	if hasPersistTime {
+		dbBatch.Delete(createCompositeKeyForPurgeIndexByTime(persistTime, txid, uuid, blockHeight))
+	}
+	return size, removed, nil
+}
+
+// getEntryTimeAndSize returns the persist time and the size of a private write set, given
+// the value of its purge index by txid
+func (s *store) getEntryTimeAndSize(indexValue []byte, txid string, uuid string, blockHeight uint64) (time.Time, uint64, error) {
+	if persistTime, size, ok := splitValueOfPurgeIndexByTxid(indexValue); ok {
+		return persistTime, size, nil
+	}
+	value, err := s.db.Get(createCompositeKeyForPvtRWSet(txid, uuid, blockHeight))
+	if err != nil {
+		return time.Time{}, 0, err
+	}
+	return time.Time{}, uint64(len(value)), nil
+}
+
+// evict removes, oldest first, the private write sets that were persisted longer than the
+// maximum age ago and then as many private write sets as needed to bring the size of the store
+// within the maximum size. It must be invoked with the mutex held
+func (s *store) evict() error {
+	if s.conf.MaxAge == 0 && s.conf.MaxSize == 0 {
+		return nil
+	}
+
+	cutoff := s.now().Add(-s.conf.MaxAge)
+	size := s.size
+
+	iter := s.db.GetIterator(createPrefixRangeStartKey(purgeIndexByTimePrefix), createPrefixRangeEndKey(purgeIndexByTimePrefix))
+	defer iter.Release()
+
+	dbBatch := leveldbhelper.NewUpdateBatch()
+	var evictedByAge, evictedBySize, evictedSize uint64
+	for iter.Next() {
+		persistTime, txid, uuid, blockHeight := splitCompositeKeyOfPurgeIndexByTime(iter.Key())
+		expired := s.conf.MaxAge > 0 && persistTime.Before(cutoff)
+		oversized := s.conf.MaxSize > 0 && size > s.conf.MaxSize
+		if !expired && !oversized {
+			break
+		}
+
+		entrySize, removed, err := s.addEntryDeletesToBatch(dbBatch, txid, uuid, blockHeight)
+		if err != nil {
+			return err
+		}
+		// the purge index by time may outlive the private write set if the peer failed
+		// while updating the store, so delete it explicitly
+		dbBatch.Delete(iter.Key())
+		if !removed {
+			continue
+		}
+		logger.Debugf("Evicting from transient store private data persisted at [%s]: txid [%s] uuid [%s]", persistTime, txid, uuid)
+		size -= entrySize
+		evictedSize += entrySize
+		if expired {
+			evictedByAge++
+		} else {
+			evictedBySize++
+		}
+	}
+
+	if err := s.db.WriteBatch(dbBatch, true); err != nil {
+		return err
+	}
+	if evictedByAge > 0 {
+		logger.Infof("Evicted [%d] private write sets persisted more than [%s] ago from the transient store of channel [%s]",
+			evictedByAge, s.conf.MaxAge, s.ledgerID)
+	}
+	if evictedBySize > 0 {
+		logger.Infof("Evicted [%d] private write sets to keep the transient store of channel [%s] within [%d] bytes",
+			evictedBySize, s.ledgerID, s.conf.MaxSize)
+	}
+	s.stats.addEvictedEntries(s.ledgerID, "age", evictedByAge)
+	s.stats.addEvictedEntries(s.ledgerID, "size", evictedBySize)
+	s.removeEntries(evictedByAge+evictedBySize, evictedSize)
+	return nil
+}
+
+// loadSize computes the number of private write sets in the store and their total size. The
+// private write sets persisted before the store recorded the persist time are indexed by time
+// as of now, so that they become subject to the eviction as well
+func (s *store) loadSize() error {
+	iter := s.db.GetIterator(createPrefixRangeStartKey(prwsetPrefix), createPrefixRangeEndKey(prwsetPrefix))
+	defer iter.Release()
+
+	now := s.now()
+	dbBatch := leveldbhelper.NewUpdateBatch()
+	for iter.Next() {
+		compositeKeyPvtRWSet := iter.Key()
+		size := uint64(len(iter.Value()))
+		s.entries++
+		s.size += size
+
+		txid := splitTxidOfCompositeKeyWithoutPrefix(compositeKeyPvtRWSet[2:])
+		uuid, blockHeight := splitCompositeKeyOfPvtRWSet(compositeKeyPvtRWSet)
+		compositeKeyPurgeIndexByTxid := createCompositeKeyForPurgeIndexByTxid(txid, uuid, blockHeight)
+		indexValue, err := s.db.Get(compositeKeyPurgeIndexByTxid)
+		if err != nil {
+			return err
+		}
+		if _, _, ok := splitValueOfPurgeIndexByTxid(indexValue); ok {
+			continue
+		}
+		dbBatch.Put(compositeKeyPurgeIndexByTxid, createValueForPurgeIndexByTxid(now, size))
+		dbBatch.Put(createCompositeKeyForPurgeIndexByTime(now, txid, uuid, blockHeight), emptyValue)
+	}
+	if err := s.db.WriteBatch(dbBatch, true); err != nil {
+		return err
+	}
+	s.stats.updateStoreSize(s.ledgerID, s.entries, s.size)
+	return nil
+}
+
+// removeEntries accounts for the removal of private write sets from the store
+func (s *store) removeEntries(entries, size uint64) {
+	if entries > s.entries {
+		entries = s.entries
+	}
+	if size > s.size {
+		size = s.size
+	}
+	s.entries -= entries
+	s.size -= size
+	s.stats.updateStoreSize(s.ledgerID, s.entries, s.size)
 }
 
 // GetMinTransientBlkHt returns the lowest block height remaining in transient store
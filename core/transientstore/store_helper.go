@@ -10,18 +10,24 @@ import (
 	"bytes"
 	"errors"
 	"path/filepath"
+	"time"
 
+	"github.com/golang/protobuf/proto"
 	"github.com/hyperledger/fabric/common/ledger/util"
 	"github.com/hyperledger/fabric/core/config"
 	"github.com/hyperledger/fabric/core/ledger"
+	lutil "github.com/hyperledger/fabric/core/ledger/util"
 	"github.com/hyperledger/fabric/protos/common"
 	"github.com/hyperledger/fabric/protos/ledger/rwset"
+	"github.com/hyperledger/fabric/protos/ledger/rwset/kvrwset"
+	"github.com/spf13/viper"
 )
 
 var (
 	prwsetPrefix             = []byte("P")[0] // key prefix for storing private write set in transient store.
 	purgeIndexByHeightPrefix = []byte("H")[0] // key prefix for storing index on private write set using received at block height.
 	purgeIndexByTxidPrefix   = []byte("T")[0] // key prefix for storing index on private write set using txid
+	purgeIndexByTimePrefix   = []byte("W")[0] // key prefix for storing index on private write set using persist time
 	compositeKeySep          = byte(0x00)
 )
 
@@ -76,6 +82,44 @@ func createCompositeKeyForPurgeIndexByHeight(blockHeight uint64, txid string, uu
 	return compositeKey
 }
 
+// createCompositeKeyForPurgeIndexByTime creates a key to index private write set based on
+// the wall-clock time it was persisted at such that eviction based on age can be achieved.
+// The structure of the key is <purgeIndexByTimePrefix>~persistTime~txid~uuid~blockHeight.
+func createCompositeKeyForPurgeIndexByTime(persistTime time.Time, txid string, uuid string, blockHeight uint64) []byte {
+	var compositeKey []byte
+	compositeKey = append(compositeKey, purgeIndexByTimePrefix)
+	compositeKey = append(compositeKey, compositeKeySep)
+	compositeKey = append(compositeKey, util.EncodeOrderPreservingVarUint64(uint64(persistTime.UnixNano()))...)
+	compositeKey = append(compositeKey, compositeKeySep)
+	compositeKey = append(compositeKey, createCompositeKeyWithoutPrefixForTxid(txid, uuid, blockHeight)...)
+
+	return compositeKey
+}
+
+// createValueForPurgeIndexByTxid creates the value of the purge index by txid, which holds
+// the wall-clock time the private write set was persisted at and the size of the private
+// write set. The value is used to locate the purge index by time of the private write set
+// and to keep track of the size of the transient store.
+func createValueForPurgeIndexByTxid(persistTime time.Time, size uint64) []byte {
+	value := proto.EncodeVarint(uint64(persistTime.UnixNano()))
+	return append(value, proto.EncodeVarint(size)...)
+}
+
+// splitValueOfPurgeIndexByTxid splits the value of the purge index by txid into the
+// persist time and the size of the private write set. The returned bool is false for the
+// empty value of the purge indexes created before the persist time was tracked.
+func splitValueOfPurgeIndexByTxid(value []byte) (persistTime time.Time, size uint64, ok bool) {
+	persistNanos, n := proto.DecodeVarint(value)
+	if n == 0 {
+		return time.Time{}, 0, false
+	}
+	size, m := proto.DecodeVarint(value[n:])
+	if m == 0 {
+		return time.Time{}, 0, false
+	}
+	return time.Unix(0, int64(persistNanos)), size, true
+}
+
 // splitCompositeKeyOfPvtRWSet splits the compositeKey (<prwsetPrefix>~txid~uuid~blockHeight)
 // into uuid and blockHeight.
 func splitCompositeKeyOfPvtRWSet(compositeKey []byte) (uuid string, blockHeight uint64) {
@@ -99,6 +143,21 @@ func splitCompositeKeyOfPurgeIndexByHeight(compositeKey []byte) (txid string, uu
 	return
 }
 
+// splitCompositeKeyOfPurgeIndexByTime splits the compositeKey
+// (<purgeIndexByTimePrefix>~persistTime~txid~uuid~blockHeight) into persistTime, txid, uuid and blockHeight.
+func splitCompositeKeyOfPurgeIndexByTime(compositeKey []byte) (persistTime time.Time, txid string, uuid string, blockHeight uint64) {
+	persistNanos, n := util.DecodeOrderPreservingVarUint64(compositeKey[2:])
+	persistTime = time.Unix(0, int64(persistNanos))
+	txid = splitTxidOfCompositeKeyWithoutPrefix(compositeKey[n+3:])
+	uuid, blockHeight = splitCompositeKeyWithoutPrefixForTxid(compositeKey[n+3:])
+	return
+}
+
+// splitTxidOfCompositeKeyWithoutPrefix returns the txid of the composite key txid~uuid~blockHeight
+func splitTxidOfCompositeKeyWithoutPrefix(compositeKey []byte) string {
+	return string(compositeKey[:bytes.IndexByte(compositeKey, compositeKeySep)])
+}
+
 // splitCompositeKeyWithoutPrefixForTxid splits the composite key txid~uuid~blockHeight into
 // uuid and blockHeight
 func splitCompositeKeyWithoutPrefixForTxid(compositeKey []byte) (uuid string, blockHeight uint64) {
@@ -178,12 +237,38 @@ func createPurgeIndexByTxidRangeEndKey(txid string) []byte {
 	return endKey
 }
 
+// createPrefixRangeStartKey returns a startKey to do a range query over all the keys with the given prefix
+func createPrefixRangeStartKey(prefix byte) []byte {
+	return []byte{prefix, compositeKeySep}
+}
+
+// createPrefixRangeEndKey returns a endKey to do a range query over all the keys with the given prefix
+func createPrefixRangeEndKey(prefix byte) []byte {
+	return []byte{prefix, compositeKeySep + 1}
+}
+
 // GetTransientStorePath returns the filesystem path for temporarily storing the private rwset
 func GetTransientStorePath() string {
 	sysPath := config.GetPath("peer.fileSystemPath")
 	return filepath.Join(sysPath, "transientStore")
 }
 
+// GetTransientStoreConfig returns the limits beyond which private write sets are evicted from the transient store
+func GetTransientStoreConfig() *Config {
+	maxSize := viper.GetInt("peer.gossip.pvtData.transientstoreMaxSize")
+	if maxSize < 0 {
+		maxSize = 0
+	}
+	maxAge := viper.GetDuration("peer.gossip.pvtData.transientstoreMaxAge")
+	if maxAge < 0 {
+		maxAge = 0
+	}
+	return &Config{
+		MaxAge:  maxAge,
+		MaxSize: uint64(maxSize),
+	}
+}
+
 // trimPvtWSet returns a `TxPvtReadWriteSet` that retains only list of 'ns/collections' supplied in the filter
 // A nil filter does not filter any results and returns the original `pvtWSet` as is
 func trimPvtWSet(pvtWSet *rwset.TxPvtReadWriteSet, filter ledger.PvtNsCollFilter) *rwset.TxPvtReadWriteSet {
@@ -240,3 +325,70 @@ func trimPvtCollectionConfigs(configs map[string]*common.CollectionConfigPackage
 	}
 	return result, nil
 }
+
+// removePurgedCollections returns a `TxPvtReadWriteSet` without the collection write sets that contain a write
+// (or a metadata write) of a key purged by one of the purge markers, if the write set was received at block
+// height lesser than or equal to the block of the purge marker. The returned bool indicates whether any collection
+// write set was removed. A nil `TxPvtReadWriteSet` is returned if all the collection write sets are removed
+func removePurgedCollections(pvtWSet *rwset.TxPvtReadWriteSet, purgeMarkers []*ledger.PvtDataPurgeMarker,
+	receivedAtBlockHeight uint64) (*rwset.TxPvtReadWriteSet, bool) {
+	purged := false
+	var retainedNsRwSet []*rwset.NsPvtReadWriteSet
+	for _, ns := range pvtWSet.GetNsPvtRwset() {
+		var retainedCollRwSet []*rwset.CollectionPvtReadWriteSet
+		for _, coll := range ns.CollectionPvtRwset {
+			if writesPurgedKey(ns.Namespace, coll, purgeMarkers, receivedAtBlockHeight) {
+				purged = true
+				continue
+			}
+			retainedCollRwSet = append(retainedCollRwSet, coll)
+		}
+		if retainedCollRwSet != nil {
+			retainedNsRwSet = append(retainedNsRwSet,
+				&rwset.NsPvtReadWriteSet{
+					Namespace:          ns.Namespace,
+					CollectionPvtRwset: retainedCollRwSet,
+				},
+			)
+		}
+	}
+	if !purged {
+		return pvtWSet, false
+	}
+	if retainedNsRwSet == nil {
+		return nil, true
+	}
+	return &rwset.TxPvtReadWriteSet{
+		DataModel:  pvtWSet.GetDataModel(),
+		NsPvtRwset: retainedNsRwSet,
+	}, true
+}
+
+func writesPurgedKey(ns string, coll *rwset.CollectionPvtReadWriteSet, purgeMarkers []*ledger.PvtDataPurgeMarker,
+	receivedAtBlockHeight uint64) bool {
+	var kvRWSet *kvrwset.KVRWSet
+	for _, purgeMarker := range purgeMarkers {
+		if purgeMarker.Namespace != ns || purgeMarker.Collection != coll.CollectionName ||
+			receivedAtBlockHeight > purgeMarker.BlockNum {
+			continue
+		}
+		if kvRWSet == nil {
+			kvRWSet = &kvrwset.KVRWSet{}
+			if err := proto.Unmarshal(coll.Rwset, kvRWSet); err != nil {
+				logger.Warningf("Could not unmarshal the private write set of [ns=%s, coll=%s]: %s", ns, coll.CollectionName, err)
+				return false
+			}
+		}
+		for _, kvWrite := range kvRWSet.Writes {
+			if bytes.Equal(lutil.ComputeStringHash(kvWrite.Key), purgeMarker.KeyHash) {
+				return true
+			}
+		}
+		for _, kvMetadataWrite := range kvRWSet.MetadataWrites {
+			if bytes.Equal(lutil.ComputeStringHash(kvMetadataWrite.Key), purgeMarker.KeyHash) {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -7,17 +7,22 @@ SPDX-License-Identifier: Apache-2.0
 package transientstore
 
 import (
+	"bytes"
 	"fmt"
 	"os"
 	"sort"
 	"testing"
+	"time"
 
 	"github.com/golang/protobuf/proto"
 	"github.com/hyperledger/fabric/common/cauthdsl"
+	"github.com/hyperledger/fabric/common/metrics"
+	"github.com/hyperledger/fabric/common/metrics/metricsfakes"
 	"github.com/hyperledger/fabric/core/ledger"
 	"github.com/hyperledger/fabric/core/ledger/util"
 	"github.com/hyperledger/fabric/protos/common"
 	"github.com/hyperledger/fabric/protos/ledger/rwset"
+	"github.com/hyperledger/fabric/protos/ledger/rwset/kvrwset"
 	"github.com/hyperledger/fabric/protos/transientstore"
 	"github.com/spf13/viper"
 	"github.com/stretchr/testify/assert"
@@ -50,6 +55,30 @@ func TestPurgeIndexKeyCodingEncoding(t *testing.T) {
 	}
 }
 
+func TestPurgeIndexByTimeKeyCodingEncoding(t *testing.T) {
+	assert := assert.New(t)
+	persistTime := time.Unix(1500000000, 123)
+	purgeIndexKey := createCompositeKeyForPurgeIndexByTime(persistTime, "txid", "uuid", 20000)
+	persistTime1, txid, uuid, blkHt := splitCompositeKeyOfPurgeIndexByTime(purgeIndexKey)
+	assert.True(persistTime.Equal(persistTime1))
+	assert.Equal("txid", txid)
+	assert.Equal("uuid", uuid)
+	assert.Equal(uint64(20000), blkHt)
+
+	// the keys are ordered by persist time
+	laterPurgeIndexKey := createCompositeKeyForPurgeIndexByTime(persistTime.Add(time.Nanosecond), "a", "a", 0)
+	assert.True(bytes.Compare(purgeIndexKey, laterPurgeIndexKey) < 0)
+
+	purgeIndexValue := createValueForPurgeIndexByTxid(persistTime, 1024)
+	persistTime2, size, ok := splitValueOfPurgeIndexByTxid(purgeIndexValue)
+	assert.True(ok)
+	assert.True(persistTime.Equal(persistTime2))
+	assert.Equal(uint64(1024), size)
+
+	_, _, ok = splitValueOfPurgeIndexByTxid(emptyValue)
+	assert.False(ok)
+}
+
 func TestRWSetKeyCodingEncoding(t *testing.T) {
 	assert := assert.New(t)
 	blkHts := []uint64{0, 10, 20000}
@@ -516,6 +545,312 @@ func TestTransientStoreRetrievalWithFilter(t *testing.T) {
 
 }
 
+func TestTransientStorePurgeByKeyHashes(t *testing.T) {
+	env := NewTestStoreEnv(t)
+	defer env.Cleanup()
+	assert := assert.New(t)
+	store := env.TestStore
+
+	ns1Coll1Key1 := sampleCollPvtRWSet(t, "coll-1", "key1")
+	ns1Coll1Key2 := sampleCollPvtRWSet(t, "coll-1", "key2")
+	ns1Coll2Key1 := sampleCollPvtRWSet(t, "coll-2", "key1")
+	ns2Coll1Key1 := sampleCollPvtRWSet(t, "coll-1", "key1")
+
+	// txid-1 writes the purged key in ns-1:coll-1 and the same key in ns-1:coll-2
+	assert.NoError(store.PersistWithConfig("txid-1", 5, &transientstore.TxPvtReadWriteSetWithConfigInfo{
+		PvtRwset: samplePvtRWSet(map[string][]*rwset.CollectionPvtReadWriteSet{"ns-1": {ns1Coll1Key1, ns1Coll2Key1}}),
+	}))
+	// txid-2 writes only the purged key
+	assert.NoError(store.PersistWithConfig("txid-2", 5, &transientstore.TxPvtReadWriteSetWithConfigInfo{
+		PvtRwset: samplePvtRWSet(map[string][]*rwset.CollectionPvtReadWriteSet{"ns-1": {ns1Coll1Key1}}),
+	}))
+	// txid-3 writes the purged key but is received after the block that purges the key
+	assert.NoError(store.PersistWithConfig("txid-3", 6, &transientstore.TxPvtReadWriteSetWithConfigInfo{
+		PvtRwset: samplePvtRWSet(map[string][]*rwset.CollectionPvtReadWriteSet{"ns-1": {ns1Coll1Key1}}),
+	}))
+	// txid-4 (old proto) writes the purged key in ns-1:coll-1 and the same key in ns-2:coll-1
+	assert.NoError(store.Persist("txid-4", 4,
+		samplePvtRWSet(map[string][]*rwset.CollectionPvtReadWriteSet{"ns-1": {ns1Coll1Key1}, "ns-2": {ns2Coll1Key1}})))
+	// txid-5 writes another key of ns-1:coll-1
+	assert.NoError(store.PersistWithConfig("txid-5", 5, &transientstore.TxPvtReadWriteSetWithConfigInfo{
+		PvtRwset: samplePvtRWSet(map[string][]*rwset.CollectionPvtReadWriteSet{"ns-1": {ns1Coll1Key2}}),
+	}))
+
+	assert.NoError(store.PurgeByKeyHashes(nil))
+	assert.NoError(store.PurgeByKeyHashes([]*ledger.PvtDataPurgeMarker{
+		{Namespace: "ns-1", Collection: "coll-1", KeyHash: util.ComputeStringHash("key1"), BlockNum: 5, TxNum: 0},
+	}))
+
+	expectedPvtRWSets := map[string]*rwset.TxPvtReadWriteSet{
+		"txid-1": samplePvtRWSet(map[string][]*rwset.CollectionPvtReadWriteSet{"ns-1": {ns1Coll2Key1}}),
+		"txid-2": nil,
+		"txid-3": samplePvtRWSet(map[string][]*rwset.CollectionPvtReadWriteSet{"ns-1": {ns1Coll1Key1}}),
+		"txid-4": samplePvtRWSet(map[string][]*rwset.CollectionPvtReadWriteSet{"ns-2": {ns2Coll1Key1}}),
+		"txid-5": samplePvtRWSet(map[string][]*rwset.CollectionPvtReadWriteSet{"ns-1": {ns1Coll1Key2}}),
+	}
+	for txid, expectedPvtRWSet := range expectedPvtRWSets {
+		itr, err := store.GetTxPvtRWSetByTxid(txid, nil)
+		assert.NoError(err)
+		res, err := itr.NextWithConfig()
+		assert.NoError(err)
+		itr.Close()
+		if expectedPvtRWSet == nil {
+			assert.Nil(res, txid)
+			continue
+		}
+		assert.True(proto.Equal(expectedPvtRWSet, res.PvtSimulationResultsWithConfig.PvtRwset), txid)
+	}
+
+	// the indexes of the removed entry are removed as well
+	assert.NoError(store.PurgeByTxids([]string{"txid-1", "txid-3", "txid-4", "txid-5"}))
+	_, err := store.GetMinTransientBlkHt()
+	assert.Equal(ErrStoreEmpty, err)
+}
+
+func TestTransientStoreGetEntries(t *testing.T) {
+	env := NewTestStoreEnv(t)
+	defer env.Cleanup()
+	assert := assert.New(t)
+	s := env.TestStore.(*store)
+	persistTime := time.Unix(1500000000, 0)
+	s.now = func() time.Time { return persistTime }
+
+	entries, err := s.GetEntries()
+	assert.NoError(err)
+	assert.Empty(entries)
+
+	samplePvtRWSetWithConfig := samplePvtDataWithConfigInfo(t)
+	assert.NoError(s.PersistWithConfig("txid-2", 10, samplePvtRWSetWithConfig))
+	assert.NoError(s.Persist("txid-1", 11, samplePvtData(t)))
+	assert.NoError(s.PersistWithConfig("txid-1", 12, samplePvtRWSetWithConfig))
+
+	entries, err = s.GetEntries()
+	assert.NoError(err)
+	assert.Len(entries, 3)
+	var totalSize uint64
+	for i, expected := range []struct {
+		txid        string
+		blockHeight uint64
+	}{{"txid-1", 11}, {"txid-1", 12}, {"txid-2", 10}} {
+		if entries[i].ReceivedAtBlockHeight != expected.blockHeight {
+			// the entries of a txid are ordered by uuid
+			entries[i], entries[i+1] = entries[i+1], entries[i]
+		}
+		assert.Equal(expected.txid, entries[i].Txid)
+		assert.Equal(expected.blockHeight, entries[i].ReceivedAtBlockHeight)
+		assert.NotEmpty(entries[i].UUID)
+		assert.True(persistTime.Equal(entries[i].PersistedAt))
+		totalSize += entries[i].Size
+	}
+	oldProtoBytes, err := proto.Marshal(samplePvtData(t))
+	assert.NoError(err)
+	assert.Equal(uint64(len(oldProtoBytes)), entries[0].Size)
+	assert.Equal(uint64(3), s.entries)
+	assert.Equal(totalSize, s.size)
+
+	// the private write sets persisted before the persist time was recorded are
+	// indexed by time when the store is opened
+	legacyEntry := entries[2]
+	assert.NoError(s.db.Put(createCompositeKeyForPurgeIndexByTxid(legacyEntry.Txid, legacyEntry.UUID, legacyEntry.ReceivedAtBlockHeight), emptyValue, true))
+	assert.NoError(s.db.Delete(createCompositeKeyForPurgeIndexByTime(persistTime, legacyEntry.Txid, legacyEntry.UUID, legacyEntry.ReceivedAtBlockHeight), true))
+	entries, err = s.GetEntries()
+	assert.NoError(err)
+	assert.True(entries[2].PersistedAt.IsZero())
+	assert.Equal(legacyEntry.Size, entries[2].Size)
+
+	reopenedStore, err := env.TestStoreProvider.OpenStore("TestStore")
+	assert.NoError(err)
+	reopened := reopenedStore.(*store)
+	assert.Equal(uint64(3), reopened.entries)
+	assert.Equal(totalSize, reopened.size)
+	entries, err = reopenedStore.GetEntries()
+	assert.NoError(err)
+	assert.False(entries[2].PersistedAt.IsZero())
+	legacyEntry.PersistedAt = entries[2].PersistedAt
+
+	assert.NoError(s.PurgeByTxids([]string{"txid-1", "txid-1"}))
+	entries, err = s.GetEntries()
+	assert.NoError(err)
+	assert.Equal([]*EntryInfo{legacyEntry}, entries)
+	assert.Equal(uint64(1), s.entries)
+	assert.Equal(legacyEntry.Size, s.size)
+
+	assert.NoError(s.PurgeByHeight(11))
+	entries, err = s.GetEntries()
+	assert.NoError(err)
+	assert.Empty(entries)
+	assert.Equal(uint64(0), s.entries)
+	assert.Equal(uint64(0), s.size)
+}
+
+func TestGetTransientStoreConfig(t *testing.T) {
+	defer func() {
+		viper.Set("peer.gossip.pvtData.transientstoreMaxAge", 0)
+		viper.Set("peer.gossip.pvtData.transientstoreMaxSize", 0)
+	}()
+	assert.Equal(t, &Config{}, GetTransientStoreConfig())
+
+	viper.Set("peer.gossip.pvtData.transientstoreMaxAge", "1h")
+	viper.Set("peer.gossip.pvtData.transientstoreMaxSize", 1048576)
+	assert.Equal(t, &Config{MaxAge: time.Hour, MaxSize: 1048576}, GetTransientStoreConfig())
+
+	viper.Set("peer.gossip.pvtData.transientstoreMaxAge", "-1h")
+	viper.Set("peer.gossip.pvtData.transientstoreMaxSize", -1)
+	assert.Equal(t, &Config{}, GetTransientStoreConfig())
+}
+
+func TestTransientStoreEvictionByAge(t *testing.T) {
+	env := NewTestStoreEnv(t)
+	defer env.Cleanup()
+	assert := assert.New(t)
+	store := env.TestStore.(*store)
+	store.conf = &Config{MaxAge: time.Minute}
+	now := time.Unix(1500000000, 0)
+	store.now = func() time.Time { return now }
+
+	samplePvtRWSetWithConfig := samplePvtDataWithConfigInfo(t)
+	assert.NoError(store.PersistWithConfig("txid-1", 10, samplePvtRWSetWithConfig))
+	now = now.Add(30 * time.Second)
+	assert.NoError(store.PersistWithConfig("txid-2", 10, samplePvtRWSetWithConfig))
+	now = now.Add(40 * time.Second)
+	assert.NoError(store.PersistWithConfig("txid-3", 10, samplePvtRWSetWithConfig))
+	assert.Equal([]string{"txid-2", "txid-3"}, entryTxids(t, store))
+	assertNoPvtRWSet(t, store, "txid-1")
+
+	// private write sets are evicted by age when purging by height, even if nothing is persisted
+	now = now.Add(30 * time.Second)
+	assert.NoError(store.PurgeByHeight(5))
+	assert.Equal([]string{"txid-3"}, entryTxids(t, store))
+	assertNoPvtRWSet(t, store, "txid-2")
+	assert.Equal(uint64(1), store.entries)
+
+	now = now.Add(time.Minute)
+	assert.NoError(store.PurgeByHeight(5))
+	_, err := store.GetMinTransientBlkHt()
+	assert.Equal(ErrStoreEmpty, err)
+	assert.Equal(uint64(0), store.entries)
+	assert.Equal(uint64(0), store.size)
+}
+
+func TestTransientStoreEvictionBySize(t *testing.T) {
+	env := NewTestStoreEnv(t)
+	defer env.Cleanup()
+	assert := assert.New(t)
+	store := env.TestStore.(*store)
+	now := time.Unix(1500000000, 0)
+	store.now = func() time.Time {
+		now = now.Add(time.Second)
+		return now
+	}
+
+	samplePvtRWSetWithConfig := samplePvtDataWithConfigInfo(t)
+	samplePvtRWSetWithConfigBytes, err := proto.Marshal(samplePvtRWSetWithConfig)
+	assert.NoError(err)
+	entrySize := uint64(len(samplePvtRWSetWithConfigBytes) + 1)
+	store.conf = &Config{MaxSize: 2*entrySize + 1}
+
+	assert.NoError(store.PersistWithConfig("txid-1", 10, samplePvtRWSetWithConfig))
+	assert.NoError(store.PersistWithConfig("txid-2", 10, samplePvtRWSetWithConfig))
+	assert.Equal([]string{"txid-1", "txid-2"}, entryTxids(t, store))
+
+	assert.NoError(store.PersistWithConfig("txid-3", 10, samplePvtRWSetWithConfig))
+	assert.Equal([]string{"txid-2", "txid-3"}, entryTxids(t, store))
+	assertNoPvtRWSet(t, store, "txid-1")
+	assert.Equal(2*entrySize, store.size)
+
+	// purging frees up space for new private write sets
+	assert.NoError(store.PurgeByTxids([]string{"txid-3"}))
+	assert.NoError(store.PersistWithConfig("txid-4", 10, samplePvtRWSetWithConfig))
+	assert.Equal([]string{"txid-2", "txid-4"}, entryTxids(t, store))
+}
+
+func TestTransientStoreMetrics(t *testing.T) {
+	removeStorePath(t)
+	defer removeStorePath(t)
+	assert := assert.New(t)
+
+	fakeEntriesGauge := &metricsfakes.Gauge{}
+	fakeEntriesGauge.WithReturns(fakeEntriesGauge)
+	fakeSizeGauge := &metricsfakes.Gauge{}
+	fakeSizeGauge.WithReturns(fakeSizeGauge)
+	fakeEvictedEntriesCounter := &metricsfakes.Counter{}
+	fakeEvictedEntriesCounter.WithReturns(fakeEvictedEntriesCounter)
+	fakeProvider := &metricsfakes.Provider{}
+	fakeProvider.NewGaugeStub = func(opts metrics.GaugeOpts) metrics.Gauge {
+		switch opts.Name {
+		case entriesOpts.Name:
+			return fakeEntriesGauge
+		case sizeOpts.Name:
+			return fakeSizeGauge
+		}
+		return nil
+	}
+	fakeProvider.NewCounterReturns(fakeEvictedEntriesCounter)
+
+	provider := NewStoreProvider(fakeProvider)
+	defer provider.Close()
+	s, err := provider.OpenStore("test-channel")
+	assert.NoError(err)
+	assert.Equal(1, fakeEntriesGauge.SetCallCount())
+	assert.Equal([]string{"channel", "test-channel"}, fakeEntriesGauge.WithArgsForCall(0))
+	assert.Equal(float64(0), fakeEntriesGauge.SetArgsForCall(0))
+
+	store := s.(*store)
+	store.conf = &Config{MaxAge: time.Minute}
+	now := time.Unix(1500000000, 0)
+	store.now = func() time.Time { return now }
+	assert.NoError(store.PersistWithConfig("txid-1", 10, samplePvtDataWithConfigInfo(t)))
+	assert.Equal(float64(1), fakeEntriesGauge.SetArgsForCall(1))
+	assert.Equal(float64(store.size), fakeSizeGauge.SetArgsForCall(1))
+
+	now = now.Add(2 * time.Minute)
+	assert.NoError(store.PersistWithConfig("txid-2", 10, samplePvtDataWithConfigInfo(t)))
+	assert.Equal(1, fakeEvictedEntriesCounter.AddCallCount())
+	assert.Equal([]string{"channel", "test-channel", "reason", "age"}, fakeEvictedEntriesCounter.WithArgsForCall(0))
+	assert.Equal(float64(1), fakeEvictedEntriesCounter.AddArgsForCall(0))
+	lastSet := fakeEntriesGauge.SetCallCount() - 1
+	assert.Equal(float64(1), fakeEntriesGauge.SetArgsForCall(lastSet))
+}
+
+func entryTxids(t *testing.T, s Store) []string {
+	entries, err := s.GetEntries()
+	assert.NoError(t, err)
+	var txids []string
+	for _, entry := range entries {
+		txids = append(txids, entry.Txid)
+	}
+	return txids
+}
+
+func assertNoPvtRWSet(t *testing.T, s Store, txid string) {
+	itr, err := s.GetTxPvtRWSetByTxid(txid, nil)
+	assert.NoError(t, err)
+	defer itr.Close()
+	res, err := itr.NextWithConfig()
+	assert.NoError(t, err)
+	assert.Nil(t, res)
+}
+
+func sampleCollPvtRWSet(t *testing.T, coll, key string) *rwset.CollectionPvtReadWriteSet {
+	kvRWSet := &kvrwset.KVRWSet{
+		Writes: []*kvrwset.KVWrite{{Key: key, Value: []byte("value-" + key)}},
+	}
+	rwsetBytes, err := proto.Marshal(kvRWSet)
+	assert.NoError(t, err)
+	return &rwset.CollectionPvtReadWriteSet{CollectionName: coll, Rwset: rwsetBytes}
+}
+
+func samplePvtRWSet(nsColls map[string][]*rwset.CollectionPvtReadWriteSet) *rwset.TxPvtReadWriteSet {
+	pvtWriteSet := &rwset.TxPvtReadWriteSet{DataModel: rwset.TxReadWriteSet_KV}
+	for _, ns := range []string{"ns-1", "ns-2"} {
+		if colls, ok := nsColls[ns]; ok {
+			pvtWriteSet.NsPvtRwset = append(pvtWriteSet.NsPvtRwset,
+				&rwset.NsPvtReadWriteSet{Namespace: ns, CollectionPvtRwset: colls})
+		}
+	}
+	return pvtWriteSet
+}
+
 func sortResults(res []*EndorserPvtSimulationResultsWithConfig) {
 	// Results are sorted by ascending order of received at block height. When the block
 	// heights are same, we sort by comparing the hash of private write set.
@@ -31,4 +31,7 @@ type Dig2CollectionConfig map[DigKey]*common.StaticCollectionConfig
 type FetchedPvtDataContainer struct {
 	AvailableElements []*gossip.PvtDataElement
 	PurgedElements    []*gossip.PvtDataDigest
+	// FailedPeers maps the endpoint of each peer that was asked for private data
+	// to the number of requested elements it didn't supply
+	FailedPeers map[string]int
 }
@@ -67,6 +67,10 @@ type TransientStore interface {
 	// after successful block commit, PurgeByHeight() is still required to remove orphan entries (as
 	// transaction that gets endorsed may not be submitted by the client for commit)
 	PurgeByHeight(maxBlockNumToRetain uint64) error
+
+	// PurgeByKeyHashes removes from the transient store the private write sets of the collections
+	// that contain a write of a key purged by a committed transaction
+	PurgeByKeyHashes(purgeMarkers []*ledger.PvtDataPurgeMarker) error
 }
 
 // Coordinator orchestrates the flow of the new
@@ -242,6 +246,13 @@ func (c *coordinator) StoreBlock(block *common.Block, privateDataSets util.PvtDa
 		}
 	}
 
+	// The validity of the transactions is known only after the commit (because of the MVCC checks)
+	if purgeMarkers := validPurgeMarkers(block, privateInfo.purgeMarkers); len(purgeMarkers) > 0 {
+		if err := c.PurgeByKeyHashes(purgeMarkers); err != nil {
+			logger.Error("Purging private data of purged keys from transient store failed:", err)
+		}
+	}
+
 	seq := block.Header.Number
 	if seq%c.transientBlockRetention == 0 && seq > c.transientBlockRetention {
 		err := c.PurgeByHeight(seq - c.transientBlockRetention)
@@ -629,6 +640,7 @@ type privateDataInfo struct {
 	missingKeys             rwsetKeys
 	txns                    txns
 	missingRWSButIneligible []rwSetKey
+	purgeMarkers            []*ledger.PvtDataPurgeMarker
 }
 
 // listMissingPrivateData identifies missing private write sets and attempts to retrieve them from local transient store
@@ -646,6 +658,7 @@ func (c *coordinator) listMissingPrivateData(block *common.Block, ownedRWsets ma
 	missing := make(rwSetKeysByTxIDs)
 	data := blockData(block.Data.Data)
 	bi := &transactionInspector{
+		blockNum:             block.Header.Number,
 		sources:              sources,
 		missingKeys:          missing,
 		ownedRWsets:          ownedRWsets,
@@ -662,6 +675,7 @@ func (c *coordinator) listMissingPrivateData(block *common.Block, ownedRWsets ma
 		missingKeysByTxIDs:      missing,
 		txns:                    txList,
 		missingRWSButIneligible: bi.missingRWSButIneligible,
+		purgeMarkers:            bi.purgeMarkers,
 	}
 
 	logger.Debug("Retrieving private write sets for", len(privateInfo.missingKeysByTxIDs), "transactions from transient store")
@@ -690,14 +704,17 @@ func (c *coordinator) listMissingPrivateData(block *common.Block, ownedRWsets ma
 
 type transactionInspector struct {
 	*coordinator
+	blockNum                uint64
 	privateRWsetsInBlock    map[rwSetKey]struct{}
 	missingKeys             rwSetKeysByTxIDs
 	sources                 map[rwSetKey][]*peer.Endorsement
 	ownedRWsets             map[rwSetKey][]byte
 	missingRWSButIneligible []rwSetKey
+	purgeMarkers            []*ledger.PvtDataPurgeMarker
 }
 
 func (bi *transactionInspector) inspectTransaction(seqInBlock uint64, chdr *common.ChannelHeader, txRWSet *rwsetutil.TxRwSet, endorsers []*peer.Endorsement) error {
+	bi.purgeMarkers = append(bi.purgeMarkers, txRWSet.GetPvtDataPurgeMarkers(bi.blockNum, seqInBlock)...)
 	for _, ns := range txRWSet.NsRwSets {
 		for _, hashedCollection := range ns.CollHashedRwSets {
 			if !containsWrites(chdr.TxId, ns.NameSpace, hashedCollection) {
@@ -749,6 +766,18 @@ func (bi *transactionInspector) inspectTransaction(seqInBlock uint64, chdr *comm
 	return nil
 }
 
+// validPurgeMarkers returns the purge markers of the transactions that are marked valid in the committed block
+func validPurgeMarkers(block *common.Block, purgeMarkers []*ledger.PvtDataPurgeMarker) []*ledger.PvtDataPurgeMarker {
+	txsFilter := txValidationFlags(block.Metadata.Metadata[common.BlockMetadataIndex_TRANSACTIONS_FILTER])
+	var res []*ledger.PvtDataPurgeMarker
+	for _, purgeMarker := range purgeMarkers {
+		if purgeMarker.TxNum < uint64(len(txsFilter)) && txsFilter[purgeMarker.TxNum] == uint8(peer.TxValidationCode_VALID) {
+			res = append(res, purgeMarker)
+		}
+	}
+	return res
+}
+
 // accessPolicyForCollection retrieves a CollectionAccessPolicy for a given namespace, collection name
 // that corresponds to a given ChannelHeader
 func (c *coordinator) accessPolicyForCollection(chdr *common.ChannelHeader, namespace string, col string) (privdata.CollectionAccessPolicy, error) {
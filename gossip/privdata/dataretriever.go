@@ -11,6 +11,7 @@ import (
 	"github.com/hyperledger/fabric/core/transientstore"
 	"github.com/hyperledger/fabric/gossip/privdata/common"
 	"github.com/hyperledger/fabric/gossip/util"
+	fcommon "github.com/hyperledger/fabric/protos/common"
 	gossip2 "github.com/hyperledger/fabric/protos/gossip"
 	"github.com/hyperledger/fabric/protos/ledger/rwset"
 	"github.com/pkg/errors"
@@ -133,26 +134,9 @@ func (dr *dataRetriever) fromLedger(digests []*gossip2.PvtDataDigest, blockNum u
 			pvtRWSetWithConfig.RWSet = append(pvtRWSetWithConfig.RWSet, pvtRWSet...)
 		}
 
-		confHistoryRetriever, err := dr.store.GetConfigHistoryRetriever()
+		configs, err := dr.collectionConfigFromLedger(dig)
 		if err != nil {
-			return nil, errors.Errorf("cannot obtain configuration history retriever, for collection <%s>"+
-				" txID <%s> block sequence number <%d> due to <%s>", dig.Collection, dig.TxId, dig.BlockSeq, err)
-		}
-
-		configInfo, err := confHistoryRetriever.MostRecentCollectionConfigBelow(dig.BlockSeq, dig.Namespace)
-		if err != nil {
-			return nil, errors.Errorf("cannot find recent collection config update below block sequence = %d,"+
-				" collection name = <%s> for chaincode <%s>", dig.BlockSeq, dig.Collection, dig.Namespace)
-		}
-
-		if configInfo == nil {
-			return nil, errors.Errorf("no collection config update below block sequence = <%d>"+
-				" collection name = <%s> for chaincode <%s> is available ", dig.BlockSeq, dig.Collection, dig.Namespace)
-		}
-		configs := extractCollectionConfig(configInfo.CollectionConfig, dig.Collection)
-		if configs == nil {
-			return nil, errors.Errorf("no collection config was found for collection <%s>"+
-				" namespace <%s> txID <%s>", dig.Collection, dig.Namespace, dig.TxId)
+			return nil, err
 		}
 		pvtRWSetWithConfig.CollectionConfig = configs
 		results[common.DigKey{
@@ -167,6 +151,35 @@ func (dr *dataRetriever) fromLedger(digests []*gossip2.PvtDataDigest, blockNum u
 	return results, nil
 }
 
+func (dr *dataRetriever) collectionConfigFromLedger(dig *gossip2.PvtDataDigest) (*fcommon.CollectionConfig, error) {
+	if implicitConfig := implicitCollectionConfig(dig.Collection); implicitConfig != nil {
+		return implicitConfig, nil
+	}
+
+	confHistoryRetriever, err := dr.store.GetConfigHistoryRetriever()
+	if err != nil {
+		return nil, errors.Errorf("cannot obtain configuration history retriever, for collection <%s>"+
+			" txID <%s> block sequence number <%d> due to <%s>", dig.Collection, dig.TxId, dig.BlockSeq, err)
+	}
+
+	configInfo, err := confHistoryRetriever.MostRecentCollectionConfigBelow(dig.BlockSeq, dig.Namespace)
+	if err != nil {
+		return nil, errors.Errorf("cannot find recent collection config update below block sequence = %d,"+
+			" collection name = <%s> for chaincode <%s>", dig.BlockSeq, dig.Collection, dig.Namespace)
+	}
+
+	if configInfo == nil {
+		return nil, errors.Errorf("no collection config update below block sequence = <%d>"+
+			" collection name = <%s> for chaincode <%s> is available ", dig.BlockSeq, dig.Collection, dig.Namespace)
+	}
+	configs := extractCollectionConfig(configInfo.CollectionConfig, dig.Collection)
+	if configs == nil {
+		return nil, errors.Errorf("no collection config was found for collection <%s>"+
+			" namespace <%s> txID <%s>", dig.Collection, dig.Namespace, dig.TxId)
+	}
+	return configs, nil
+}
+
 func (dr *dataRetriever) fromTransientStore(dig *gossip2.PvtDataDigest, filter map[string]ledger.PvtCollFilter) (*util.PrivateRWSetWithConfig, error) {
 	results := &util.PrivateRWSetWithConfig{}
 	it, err := dr.store.GetTxPvtRWSetByTxid(dig.TxId, filter)
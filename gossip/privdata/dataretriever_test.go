@@ -10,6 +10,8 @@ import (
 	"errors"
 	"testing"
 
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/core/common/privdata"
 	"github.com/hyperledger/fabric/core/ledger"
 	"github.com/hyperledger/fabric/core/transientstore"
 	privdatacommon "github.com/hyperledger/fabric/gossip/privdata/common"
@@ -167,6 +169,53 @@ func TestNewDataRetriever_GetDataFromLedger(t *testing.T) {
 	assertion.Equal([]byte{1, 2, 3, 4}, mergedRWSet)
 }
 
+func TestNewDataRetriever_GetImplicitCollectionDataFromLedger(t *testing.T) {
+	t.Parallel()
+	dataStore := &mocks.DataStore{}
+
+	namespace := "testChaincodeName1"
+	collectionName := privdata.ImplicitCollectionNameForOrg("Org1MSP")
+
+	result := []*ledger.TxPvtData{{
+		WriteSet: &rwset.TxPvtReadWriteSet{
+			DataModel: rwset.TxReadWriteSet_KV,
+			NsPvtRwset: []*rwset.NsPvtReadWriteSet{
+				pvtReadWriteSet(namespace, collectionName, []byte{1, 2}),
+			},
+		},
+		SeqInBlock: 1,
+	}}
+
+	dataStore.On("LedgerHeight").Return(uint64(10), nil)
+	dataStore.On("GetPvtDataByNum", uint64(5), mock.Anything).Return(result, nil)
+
+	retriever := NewDataRetriever(dataStore)
+
+	// The config of an implicit collection is not looked up in the collection config history
+	rwSets, _, err := retriever.CollectionRWSet([]*gossip2.PvtDataDigest{{
+		Namespace:  namespace,
+		Collection: collectionName,
+		BlockSeq:   uint64(5),
+		TxId:       "testTxID",
+		SeqInBlock: 1,
+	}}, uint64(5))
+
+	assertion := assert.New(t)
+	assertion.NoError(err)
+	pvtRWSet := rwSets[privdatacommon.DigKey{
+		Namespace:  namespace,
+		Collection: collectionName,
+		BlockSeq:   5,
+		TxId:       "testTxID",
+		SeqInBlock: 1,
+	}]
+	assertion.NotNil(pvtRWSet)
+	assertion.Len(pvtRWSet.RWSet, 1)
+	assertion.Equal([]byte{1, 2}, []byte(pvtRWSet.RWSet[0]))
+	assertion.True(proto.Equal(privdata.GenerateImplicitCollectionForOrg("Org1MSP"), pvtRWSet.CollectionConfig.GetStaticCollectionConfig()))
+	dataStore.AssertNotCalled(t, "GetConfigHistoryRetriever")
+}
+
 func TestNewDataRetriever_FailGetPvtDataFromLedger(t *testing.T) {
 	t.Parallel()
 	dataStore := &mocks.DataStore{}
@@ -0,0 +1,195 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package fakes
+
+import (
+	sync "sync"
+
+	ledger "github.com/hyperledger/fabric/core/ledger"
+	privdata "github.com/hyperledger/fabric/gossip/privdata"
+	httpadmin "github.com/hyperledger/fabric/gossip/privdata/httpadmin"
+)
+
+type ReconciliationSupport struct {
+	MissingPvtDataTrackerStub        func(string) (ledger.MissingPvtDataTracker, error)
+	missingPvtDataTrackerMutex       sync.RWMutex
+	missingPvtDataTrackerArgsForCall []struct {
+		arg1 string
+	}
+	missingPvtDataTrackerReturns struct {
+		result1 ledger.MissingPvtDataTracker
+		result2 error
+	}
+	missingPvtDataTrackerReturnsOnCall map[int]struct {
+		result1 ledger.MissingPvtDataTracker
+		result2 error
+	}
+	ReconcilerStub        func(string) (privdata.PvtDataReconciler, error)
+	reconcilerMutex       sync.RWMutex
+	reconcilerArgsForCall []struct {
+		arg1 string
+	}
+	reconcilerReturns struct {
+		result1 privdata.PvtDataReconciler
+		result2 error
+	}
+	reconcilerReturnsOnCall map[int]struct {
+		result1 privdata.PvtDataReconciler
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *ReconciliationSupport) MissingPvtDataTracker(arg1 string) (ledger.MissingPvtDataTracker, error) {
+	fake.missingPvtDataTrackerMutex.Lock()
+	ret, specificReturn := fake.missingPvtDataTrackerReturnsOnCall[len(fake.missingPvtDataTrackerArgsForCall)]
+	fake.missingPvtDataTrackerArgsForCall = append(fake.missingPvtDataTrackerArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	fake.recordInvocation("MissingPvtDataTracker", []interface{}{arg1})
+	fake.missingPvtDataTrackerMutex.Unlock()
+	if fake.MissingPvtDataTrackerStub != nil {
+		return fake.MissingPvtDataTrackerStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.missingPvtDataTrackerReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *ReconciliationSupport) MissingPvtDataTrackerCallCount() int {
+	fake.missingPvtDataTrackerMutex.RLock()
+	defer fake.missingPvtDataTrackerMutex.RUnlock()
+	return len(fake.missingPvtDataTrackerArgsForCall)
+}
+
+func (fake *ReconciliationSupport) MissingPvtDataTrackerCalls(stub func(string) (ledger.MissingPvtDataTracker, error)) {
+	fake.missingPvtDataTrackerMutex.Lock()
+	defer fake.missingPvtDataTrackerMutex.Unlock()
+	fake.MissingPvtDataTrackerStub = stub
+}
+
+func (fake *ReconciliationSupport) MissingPvtDataTrackerArgsForCall(i int) string {
+	fake.missingPvtDataTrackerMutex.RLock()
+	defer fake.missingPvtDataTrackerMutex.RUnlock()
+	argsForCall := fake.missingPvtDataTrackerArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *ReconciliationSupport) MissingPvtDataTrackerReturns(result1 ledger.MissingPvtDataTracker, result2 error) {
+	fake.missingPvtDataTrackerMutex.Lock()
+	defer fake.missingPvtDataTrackerMutex.Unlock()
+	fake.MissingPvtDataTrackerStub = nil
+	fake.missingPvtDataTrackerReturns = struct {
+		result1 ledger.MissingPvtDataTracker
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *ReconciliationSupport) MissingPvtDataTrackerReturnsOnCall(i int, result1 ledger.MissingPvtDataTracker, result2 error) {
+	fake.missingPvtDataTrackerMutex.Lock()
+	defer fake.missingPvtDataTrackerMutex.Unlock()
+	fake.MissingPvtDataTrackerStub = nil
+	if fake.missingPvtDataTrackerReturnsOnCall == nil {
+		fake.missingPvtDataTrackerReturnsOnCall = make(map[int]struct {
+			result1 ledger.MissingPvtDataTracker
+			result2 error
+		})
+	}
+	fake.missingPvtDataTrackerReturnsOnCall[i] = struct {
+		result1 ledger.MissingPvtDataTracker
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *ReconciliationSupport) Reconciler(arg1 string) (privdata.PvtDataReconciler, error) {
+	fake.reconcilerMutex.Lock()
+	ret, specificReturn := fake.reconcilerReturnsOnCall[len(fake.reconcilerArgsForCall)]
+	fake.reconcilerArgsForCall = append(fake.reconcilerArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	fake.recordInvocation("Reconciler", []interface{}{arg1})
+	fake.reconcilerMutex.Unlock()
+	if fake.ReconcilerStub != nil {
+		return fake.ReconcilerStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.reconcilerReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *ReconciliationSupport) ReconcilerCallCount() int {
+	fake.reconcilerMutex.RLock()
+	defer fake.reconcilerMutex.RUnlock()
+	return len(fake.reconcilerArgsForCall)
+}
+
+func (fake *ReconciliationSupport) ReconcilerCalls(stub func(string) (privdata.PvtDataReconciler, error)) {
+	fake.reconcilerMutex.Lock()
+	defer fake.reconcilerMutex.Unlock()
+	fake.ReconcilerStub = stub
+}
+
+func (fake *ReconciliationSupport) ReconcilerArgsForCall(i int) string {
+	fake.reconcilerMutex.RLock()
+	defer fake.reconcilerMutex.RUnlock()
+	argsForCall := fake.reconcilerArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *ReconciliationSupport) ReconcilerReturns(result1 privdata.PvtDataReconciler, result2 error) {
+	fake.reconcilerMutex.Lock()
+	defer fake.reconcilerMutex.Unlock()
+	fake.ReconcilerStub = nil
+	fake.reconcilerReturns = struct {
+		result1 privdata.PvtDataReconciler
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *ReconciliationSupport) ReconcilerReturnsOnCall(i int, result1 privdata.PvtDataReconciler, result2 error) {
+	fake.reconcilerMutex.Lock()
+	defer fake.reconcilerMutex.Unlock()
+	fake.ReconcilerStub = nil
+	if fake.reconcilerReturnsOnCall == nil {
+		fake.reconcilerReturnsOnCall = make(map[int]struct {
+			result1 privdata.PvtDataReconciler
+			result2 error
+		})
+	}
+	fake.reconcilerReturnsOnCall[i] = struct {
+		result1 privdata.PvtDataReconciler
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *ReconciliationSupport) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.missingPvtDataTrackerMutex.RLock()
+	defer fake.missingPvtDataTrackerMutex.RUnlock()
+	fake.reconcilerMutex.RLock()
+	defer fake.reconcilerMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *ReconciliationSupport) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ httpadmin.ReconciliationSupport = new(ReconciliationSupport)
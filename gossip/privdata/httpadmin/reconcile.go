@@ -0,0 +1,192 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package httpadmin
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+
+	"github.com/hyperledger/fabric/common/flogging"
+	"github.com/hyperledger/fabric/core/ledger"
+	"github.com/hyperledger/fabric/gossip/privdata"
+)
+
+//go:generate counterfeiter -o fakes/reconciliation_support.go -fake-name ReconciliationSupport . ReconciliationSupport
+//go:generate counterfeiter -o fakes/reconciler.go -fake-name Reconciler . Reconciler
+//go:generate counterfeiter -o fakes/missing_pvt_data_tracker.go -fake-name MissingPvtDataTracker . MissingPvtDataTracker
+
+type ReconciliationSupport interface {
+	privdata.ReconciliationSupport
+}
+
+type Reconciler interface {
+	privdata.PvtDataReconciler
+}
+
+type MissingPvtDataTracker interface {
+	ledger.MissingPvtDataTracker
+}
+
+type MissingPvtData struct {
+	Missing []*privdata.MissingPvtData `json:"missing"`
+}
+
+type ReconcileRequest struct {
+	Channel    string `json:"channel"`
+	StartBlock uint64 `json:"start_block"`
+	EndBlock   uint64 `json:"end_block"`
+}
+
+type ErrorResponse struct {
+	Error string `json:"error"`
+}
+
+func NewMissingHandler(support privdata.ReconciliationSupport) *MissingHandler {
+	return &MissingHandler{
+		Support: support,
+		Logger:  flogging.MustGetLogger("privdata.httpadmin"),
+	}
+}
+
+// MissingHandler lists the private data of eligible collections that is missing in a range of
+// blocks of a channel. The channel and the range are selected with the channel, start and end
+// query parameters; the range defaults to all the blocks of the channel.
+type MissingHandler struct {
+	Support privdata.ReconciliationSupport
+	Logger  *flogging.FabricLogger
+}
+
+func (h *MissingHandler) ServeHTTP(resp http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodGet {
+		err := fmt.Errorf("invalid request method: %s", req.Method)
+		sendResponse(h.Logger, resp, http.StatusBadRequest, err)
+		return
+	}
+
+	channel := req.URL.Query().Get("channel")
+	if channel == "" {
+		sendResponse(h.Logger, resp, http.StatusBadRequest, fmt.Errorf("channel must be provided"))
+		return
+	}
+	startBlock, err := blockParam(req, "start", 0)
+	if err != nil {
+		sendResponse(h.Logger, resp, http.StatusBadRequest, err)
+		return
+	}
+	endBlock, err := blockParam(req, "end", math.MaxUint64)
+	if err != nil {
+		sendResponse(h.Logger, resp, http.StatusBadRequest, err)
+		return
+	}
+
+	tracker, err := h.Support.MissingPvtDataTracker(channel)
+	if err != nil {
+		sendResponse(h.Logger, resp, http.StatusNotFound, err)
+		return
+	}
+	missing, err := privdata.ListMissingPvtData(tracker, startBlock, endBlock)
+	if err != nil {
+		sendResponse(h.Logger, resp, http.StatusInternalServerError, err)
+		return
+	}
+	sendResponse(h.Logger, resp, http.StatusOK, &MissingPvtData{Missing: missing})
+}
+
+func NewReconcileHandler(support privdata.ReconciliationSupport) *ReconcileHandler {
+	return &ReconcileHandler{
+		Support: support,
+		Logger:  flogging.MustGetLogger("privdata.httpadmin"),
+	}
+}
+
+// ReconcileHandler triggers a reconciliation pass over the missing private data in a range of
+// blocks of a channel on POST, and reports the progress of the most recent pass triggered on the
+// channel selected with the channel query parameter on GET.
+type ReconcileHandler struct {
+	Support privdata.ReconciliationSupport
+	Logger  *flogging.FabricLogger
+}
+
+func (h *ReconcileHandler) ServeHTTP(resp http.ResponseWriter, req *http.Request) {
+	switch req.Method {
+	case http.MethodPost:
+		var reconcileReq ReconcileRequest
+		decoder := json.NewDecoder(req.Body)
+		if err := decoder.Decode(&reconcileReq); err != nil {
+			sendResponse(h.Logger, resp, http.StatusBadRequest, err)
+			return
+		}
+		req.Body.Close()
+
+		if reconcileReq.Channel == "" {
+			sendResponse(h.Logger, resp, http.StatusBadRequest, fmt.Errorf("channel must be provided"))
+			return
+		}
+		reconciler, err := h.Support.Reconciler(reconcileReq.Channel)
+		if err != nil {
+			sendResponse(h.Logger, resp, http.StatusNotFound, err)
+			return
+		}
+		if err := reconciler.Reconcile(reconcileReq.StartBlock, reconcileReq.EndBlock); err != nil {
+			sendResponse(h.Logger, resp, http.StatusConflict, err)
+			return
+		}
+		sendResponse(h.Logger, resp, http.StatusAccepted, reconciler.Status())
+
+	case http.MethodGet:
+		channel := req.URL.Query().Get("channel")
+		if channel == "" {
+			sendResponse(h.Logger, resp, http.StatusBadRequest, fmt.Errorf("channel must be provided"))
+			return
+		}
+		reconciler, err := h.Support.Reconciler(channel)
+		if err != nil {
+			sendResponse(h.Logger, resp, http.StatusNotFound, err)
+			return
+		}
+		status := reconciler.Status()
+		if status == nil {
+			err := fmt.Errorf("no private data reconciliation was triggered on channel %s", channel)
+			sendResponse(h.Logger, resp, http.StatusNotFound, err)
+			return
+		}
+		sendResponse(h.Logger, resp, http.StatusOK, status)
+
+	default:
+		err := fmt.Errorf("invalid request method: %s", req.Method)
+		sendResponse(h.Logger, resp, http.StatusBadRequest, err)
+	}
+}
+
+func blockParam(req *http.Request, name string, defaultValue uint64) (uint64, error) {
+	value := req.URL.Query().Get(name)
+	if value == "" {
+		return defaultValue, nil
+	}
+	blockNum, err := strconv.ParseUint(value, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid %s block: %s", name, value)
+	}
+	return blockNum, nil
+}
+
+func sendResponse(logger *flogging.FabricLogger, resp http.ResponseWriter, code int, payload interface{}) {
+	encoder := json.NewEncoder(resp)
+	if err, ok := payload.(error); ok {
+		payload = &ErrorResponse{Error: err.Error()}
+	}
+
+	resp.Header().Set("Content-Type", "application/json")
+	resp.WriteHeader(code)
+
+	if err := encoder.Encode(payload); err != nil {
+		logger.Errorw("failed to encode payload", "error", err)
+	}
+}
@@ -0,0 +1,287 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package httpadmin_test
+
+import (
+	"errors"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"time"
+
+	"github.com/hyperledger/fabric/common/flogging"
+	"github.com/hyperledger/fabric/core/ledger"
+	"github.com/hyperledger/fabric/gossip/privdata"
+	"github.com/hyperledger/fabric/gossip/privdata/httpadmin"
+	"github.com/hyperledger/fabric/gossip/privdata/httpadmin/fakes"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("MissingHandler", func() {
+	var (
+		fakeSupport *fakes.ReconciliationSupport
+		fakeTracker *fakes.MissingPvtDataTracker
+		handler     *httpadmin.MissingHandler
+	)
+
+	BeforeEach(func() {
+		missingPvtDataInfo := ledger.MissingPvtDataInfo{}
+		missingPvtDataInfo.Add(5, 2, "ns-1", "coll-2")
+		missingPvtDataInfo.Add(5, 2, "ns-1", "coll-1")
+		missingPvtDataInfo.Add(3, 0, "ns-2", "coll-1")
+		fakeTracker = &fakes.MissingPvtDataTracker{}
+		fakeTracker.GetMissingPvtDataInfoForBlockRangeReturns(missingPvtDataInfo, nil)
+		fakeSupport = &fakes.ReconciliationSupport{}
+		fakeSupport.MissingPvtDataTrackerReturns(fakeTracker, nil)
+		handler = &httpadmin.MissingHandler{
+			Support: fakeSupport,
+			Logger:  flogging.NewFabricLogger(flogging.NewZapLogger(nil)),
+		}
+	})
+
+	It("responds with the missing private data of the channel", func() {
+		req := httptest.NewRequest("GET", "/ignored?channel=testchannel&start=3&end=10", nil)
+		resp := httptest.NewRecorder()
+		handler.ServeHTTP(resp, req)
+
+		Expect(fakeSupport.MissingPvtDataTrackerCallCount()).To(Equal(1))
+		Expect(fakeSupport.MissingPvtDataTrackerArgsForCall(0)).To(Equal("testchannel"))
+		Expect(fakeTracker.GetMissingPvtDataInfoForBlockRangeCallCount()).To(Equal(1))
+		startBlock, endBlock, _ := fakeTracker.GetMissingPvtDataInfoForBlockRangeArgsForCall(0)
+		Expect(startBlock).To(Equal(uint64(3)))
+		Expect(endBlock).To(Equal(uint64(10)))
+		Expect(resp.Code).To(Equal(http.StatusOK))
+		Expect(resp.Body).To(MatchJSON(`{"missing": [
+			{"block_num": 3, "tx_num": 0, "namespace": "ns-2", "collection": "coll-1"},
+			{"block_num": 5, "tx_num": 2, "namespace": "ns-1", "collection": "coll-1"},
+			{"block_num": 5, "tx_num": 2, "namespace": "ns-1", "collection": "coll-2"}
+		]}`))
+		Expect(resp.Header().Get("Content-Type")).To(Equal("application/json"))
+	})
+
+	It("defaults to all the blocks of the channel", func() {
+		req := httptest.NewRequest("GET", "/ignored?channel=testchannel", nil)
+		resp := httptest.NewRecorder()
+		handler.ServeHTTP(resp, req)
+
+		Expect(resp.Code).To(Equal(http.StatusOK))
+		startBlock, endBlock, _ := fakeTracker.GetMissingPvtDataInfoForBlockRangeArgsForCall(0)
+		Expect(startBlock).To(Equal(uint64(0)))
+		Expect(endBlock).To(Equal(uint64(math.MaxUint64)))
+	})
+
+	Context("when the channel is not provided", func() {
+		It("responds with an error payload", func() {
+			req := httptest.NewRequest("GET", "/ignored", nil)
+			resp := httptest.NewRecorder()
+			handler.ServeHTTP(resp, req)
+
+			Expect(resp.Code).To(Equal(http.StatusBadRequest))
+			Expect(resp.Body).To(MatchJSON(`{"error": "channel must be provided"}`))
+		})
+	})
+
+	Context("when a block is invalid", func() {
+		It("responds with an error payload", func() {
+			req := httptest.NewRequest("GET", "/ignored?channel=testchannel&start=foo", nil)
+			resp := httptest.NewRecorder()
+			handler.ServeHTTP(resp, req)
+
+			Expect(resp.Code).To(Equal(http.StatusBadRequest))
+			Expect(resp.Body).To(MatchJSON(`{"error": "invalid start block: foo"}`))
+		})
+	})
+
+	Context("when the channel does not exist", func() {
+		BeforeEach(func() {
+			fakeSupport.MissingPvtDataTrackerReturns(nil, errors.New("no such channel"))
+		})
+
+		It("responds with an error payload", func() {
+			req := httptest.NewRequest("GET", "/ignored?channel=testchannel", nil)
+			resp := httptest.NewRecorder()
+			handler.ServeHTTP(resp, req)
+
+			Expect(resp.Code).To(Equal(http.StatusNotFound))
+			Expect(resp.Body).To(MatchJSON(`{"error": "no such channel"}`))
+		})
+	})
+
+	Context("when retrieving the missing private data fails", func() {
+		BeforeEach(func() {
+			fakeTracker.GetMissingPvtDataInfoForBlockRangeReturns(nil, errors.New("boom"))
+		})
+
+		It("responds with an error payload", func() {
+			req := httptest.NewRequest("GET", "/ignored?channel=testchannel", nil)
+			resp := httptest.NewRecorder()
+			handler.ServeHTTP(resp, req)
+
+			Expect(resp.Code).To(Equal(http.StatusInternalServerError))
+			Expect(resp.Body).To(MatchJSON(`{"error": "boom"}`))
+		})
+	})
+
+	Context("when an unsupported method is used", func() {
+		It("responds with an error payload", func() {
+			req := httptest.NewRequest("DELETE", "/ignored?channel=testchannel", nil)
+			resp := httptest.NewRecorder()
+			handler.ServeHTTP(resp, req)
+
+			Expect(resp.Code).To(Equal(http.StatusBadRequest))
+			Expect(resp.Body).To(MatchJSON(`{"error": "invalid request method: DELETE"}`))
+		})
+	})
+})
+
+var _ = Describe("ReconcileHandler", func() {
+	var (
+		fakeSupport    *fakes.ReconciliationSupport
+		fakeReconciler *fakes.Reconciler
+		handler        *httpadmin.ReconcileHandler
+	)
+
+	BeforeEach(func() {
+		fakeReconciler = &fakes.Reconciler{}
+		fakeReconciler.StatusReturns(&privdata.ReconciliationStatus{
+			StartBlock:         1,
+			EndBlock:           10,
+			InProgress:         true,
+			ProcessedBlocks:    2,
+			LastProcessedBlock: 8,
+			ReconciledItems:    3,
+			UnavailableItems:   1,
+			FailedPeers:        map[string]int{"peer0:7051": 1},
+			StartedAt:          time.Unix(0, 0).UTC(),
+		})
+		fakeSupport = &fakes.ReconciliationSupport{}
+		fakeSupport.ReconcilerReturns(fakeReconciler, nil)
+		handler = &httpadmin.ReconcileHandler{
+			Support: fakeSupport,
+			Logger:  flogging.NewFabricLogger(flogging.NewZapLogger(nil)),
+		}
+	})
+
+	expectedStatus := `{
+		"start_block": 1,
+		"end_block": 10,
+		"in_progress": true,
+		"processed_blocks": 2,
+		"last_processed_block": 8,
+		"reconciled_items": 3,
+		"unavailable_items": 1,
+		"hash_mismatches": 0,
+		"failed_peers": {"peer0:7051": 1},
+		"started_at": "1970-01-01T00:00:00Z",
+		"finished_at": "0001-01-01T00:00:00Z"
+	}`
+
+	It("triggers a reconciliation pass", func() {
+		req := httptest.NewRequest("POST", "/ignored", strings.NewReader(`{"channel": "testchannel", "start_block": 1, "end_block": 10}`))
+		resp := httptest.NewRecorder()
+		handler.ServeHTTP(resp, req)
+
+		Expect(fakeSupport.ReconcilerCallCount()).To(Equal(1))
+		Expect(fakeSupport.ReconcilerArgsForCall(0)).To(Equal("testchannel"))
+		Expect(fakeReconciler.ReconcileCallCount()).To(Equal(1))
+		startBlock, endBlock := fakeReconciler.ReconcileArgsForCall(0)
+		Expect(startBlock).To(Equal(uint64(1)))
+		Expect(endBlock).To(Equal(uint64(10)))
+		Expect(resp.Code).To(Equal(http.StatusAccepted))
+		Expect(resp.Body).To(MatchJSON(expectedStatus))
+	})
+
+	It("responds with the status of the reconciliation pass", func() {
+		req := httptest.NewRequest("GET", "/ignored?channel=testchannel", nil)
+		resp := httptest.NewRecorder()
+		handler.ServeHTTP(resp, req)
+
+		Expect(fakeReconciler.ReconcileCallCount()).To(Equal(0))
+		Expect(resp.Code).To(Equal(http.StatusOK))
+		Expect(resp.Body).To(MatchJSON(expectedStatus))
+	})
+
+	Context("when no reconciliation pass was triggered", func() {
+		BeforeEach(func() {
+			fakeReconciler.StatusReturns(nil)
+		})
+
+		It("responds with an error payload", func() {
+			req := httptest.NewRequest("GET", "/ignored?channel=testchannel", nil)
+			resp := httptest.NewRecorder()
+			handler.ServeHTTP(resp, req)
+
+			Expect(resp.Code).To(Equal(http.StatusNotFound))
+			Expect(resp.Body).To(MatchJSON(`{"error": "no private data reconciliation was triggered on channel testchannel"}`))
+		})
+	})
+
+	Context("when the reconciliation pass cannot be triggered", func() {
+		BeforeEach(func() {
+			fakeReconciler.ReconcileReturns(errors.New("already in progress"))
+		})
+
+		It("responds with an error payload", func() {
+			req := httptest.NewRequest("POST", "/ignored", strings.NewReader(`{"channel": "testchannel", "start_block": 1, "end_block": 10}`))
+			resp := httptest.NewRecorder()
+			handler.ServeHTTP(resp, req)
+
+			Expect(resp.Code).To(Equal(http.StatusConflict))
+			Expect(resp.Body).To(MatchJSON(`{"error": "already in progress"}`))
+		})
+	})
+
+	Context("when the request payload cannot be decoded", func() {
+		It("responds with an error payload", func() {
+			req := httptest.NewRequest("POST", "/ignored", strings.NewReader(`goo`))
+			resp := httptest.NewRecorder()
+			handler.ServeHTTP(resp, req)
+
+			Expect(resp.Code).To(Equal(http.StatusBadRequest))
+			Expect(resp.Body).To(MatchJSON(`{"error": "invalid character 'g' looking for beginning of value"}`))
+		})
+	})
+
+	Context("when the channel is not provided", func() {
+		It("responds with an error payload", func() {
+			req := httptest.NewRequest("POST", "/ignored", strings.NewReader(`{"start_block": 1}`))
+			resp := httptest.NewRecorder()
+			handler.ServeHTTP(resp, req)
+
+			Expect(resp.Code).To(Equal(http.StatusBadRequest))
+			Expect(resp.Body).To(MatchJSON(`{"error": "channel must be provided"}`))
+		})
+	})
+
+	Context("when the channel does not exist", func() {
+		BeforeEach(func() {
+			fakeSupport.ReconcilerReturns(nil, errors.New("no such channel"))
+		})
+
+		It("responds with an error payload", func() {
+			req := httptest.NewRequest("GET", "/ignored?channel=testchannel", nil)
+			resp := httptest.NewRecorder()
+			handler.ServeHTTP(resp, req)
+
+			Expect(resp.Code).To(Equal(http.StatusNotFound))
+			Expect(resp.Body).To(MatchJSON(`{"error": "no such channel"}`))
+		})
+	})
+
+	Context("when an unsupported method is used", func() {
+		It("responds with an error payload", func() {
+			req := httptest.NewRequest("PUT", "/ignored", nil)
+			resp := httptest.NewRecorder()
+			handler.ServeHTTP(resp, req)
+
+			Expect(resp.Code).To(Equal(http.StatusBadRequest))
+			Expect(resp.Body).To(MatchJSON(`{"error": "invalid request method: PUT"}`))
+		})
+	})
+})
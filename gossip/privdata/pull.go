@@ -274,25 +274,51 @@ func (p *puller) fetchPrivateData(dig2Filter digestToFilterMapping) (*privdataco
 		logger.Debug("Matched", len(dig2Filter), "digests to", len(peer2digests), "peer(s)")
 		subscriptions := p.scatterRequests(peer2digests)
 		responses := p.gatherResponses(subscriptions)
+		received := make(map[privdatacommon.DigKey]struct{})
 		for _, resp := range responses {
 			if len(resp.Payload) == 0 {
 				logger.Debug("Got empty response for", resp.Digest)
 				continue
 			}
-			delete(dig2Filter, privdatacommon.DigKey{
+			dig := privdatacommon.DigKey{
 				TxId:       resp.Digest.TxId,
 				BlockSeq:   resp.Digest.BlockSeq,
 				SeqInBlock: resp.Digest.SeqInBlock,
 				Namespace:  resp.Digest.Namespace,
 				Collection: resp.Digest.Collection,
-			})
+			}
+			received[dig] = struct{}{}
+			delete(dig2Filter, dig)
 			itemsLeftToCollect--
 		}
 		res.AvailableElements = append(res.AvailableElements, responses...)
+		recordFailedPeers(res, peer2digests, received)
 	}
 	return res, nil
 }
 
+// recordFailedPeers counts, for each peer that was sent a request, the digests it didn't supply
+func recordFailedPeers(res *privdatacommon.FetchedPvtDataContainer, peer2digests peer2Digests, received map[privdatacommon.DigKey]struct{}) {
+	for peer, digests := range peer2digests {
+		for _, dig := range digests {
+			digKey := privdatacommon.DigKey{
+				TxId:       dig.TxId,
+				BlockSeq:   dig.BlockSeq,
+				SeqInBlock: dig.SeqInBlock,
+				Namespace:  dig.Namespace,
+				Collection: dig.Collection,
+			}
+			if _, exists := received[digKey]; exists {
+				continue
+			}
+			if res.FailedPeers == nil {
+				res.FailedPeers = make(map[string]int)
+			}
+			res.FailedPeers[peer.endpoint]++
+		}
+	}
+}
+
 func (p *puller) gatherResponses(subscriptions []util.Subscription) []*proto.PvtDataElement {
 	var res []*proto.PvtDataElement
 	privateElements := make(chan *proto.PvtDataElement, len(subscriptions))
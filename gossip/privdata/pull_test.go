@@ -358,6 +358,7 @@ func TestPullerFromOnly1Peer(t *testing.T) {
 	fetched := []util.PrivateRWSet{rws1, rws2}
 	assert.NoError(t, err)
 	assert.Equal(t, p2TransientStore.RWSet, fetched)
+	assert.Empty(t, fetchedMessages.FailedPeers)
 }
 
 func TestPullerDataNotAvailable(t *testing.T) {
@@ -399,6 +400,7 @@ func TestPullerDataNotAvailable(t *testing.T) {
 	dasf := &digestsAndSourceFactory{}
 	fetchedMessages, err := p1.fetch(dasf.mapDigest(toDigKey(dig)).toSources().create())
 	assert.Empty(t, fetchedMessages.AvailableElements)
+	assert.Equal(t, map[string]int{"p2": 1}, fetchedMessages.FailedPeers)
 	assert.NoError(t, err)
 }
 
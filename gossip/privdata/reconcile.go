@@ -10,6 +10,7 @@ import (
 	"encoding/hex"
 	"fmt"
 	"math"
+	"sort"
 	"sync"
 	"time"
 
@@ -48,6 +49,99 @@ type PvtDataReconciler interface {
 	Start()
 	// Stop function stops reconciler
 	Stop()
+	// Reconcile triggers an immediate reconciliation pass over the missing private data of
+	// the blocks in the range [startBlock, endBlock]. The pass runs in the background and its
+	// progress can be retrieved with Status
+	Reconcile(startBlock, endBlock uint64) error
+	// Status returns the progress of the most recent reconciliation pass triggered with Reconcile,
+	// or nil if no such pass was triggered
+	Status() *ReconciliationStatus
+}
+
+// ReconciliationSupport provides access to the missing private data information
+// and to the private data reconciler of the channels the peer has joined
+type ReconciliationSupport interface {
+	// MissingPvtDataTracker returns the missing private data tracker of the given channel
+	MissingPvtDataTracker(channel string) (ledger.MissingPvtDataTracker, error)
+	// Reconciler returns the private data reconciler of the given channel
+	Reconciler(channel string) (PvtDataReconciler, error)
+}
+
+// ReconciliationStatus describes the progress of a reconciliation pass triggered with Reconcile.
+// The blocks of the range are processed from the most recent one downwards
+type ReconciliationStatus struct {
+	StartBlock uint64 `json:"start_block"`
+	EndBlock   uint64 `json:"end_block"`
+	InProgress bool   `json:"in_progress"`
+	// ProcessedBlocks is the number of blocks with missing private data processed so far
+	ProcessedBlocks int `json:"processed_blocks"`
+	// LastProcessedBlock is the lowest block number processed so far
+	LastProcessedBlock uint64 `json:"last_processed_block"`
+	ReconciledItems    int    `json:"reconciled_items"`
+	// UnavailableItems is the number of missing private data items that no peer supplied
+	UnavailableItems int `json:"unavailable_items"`
+	HashMismatches   int `json:"hash_mismatches"`
+	// FailedPeers maps the endpoint of each peer that didn't supply requested private data
+	// to the number of items it failed to supply
+	FailedPeers map[string]int `json:"failed_peers,omitempty"`
+	StartedAt   time.Time      `json:"started_at"`
+	FinishedAt  time.Time      `json:"finished_at"`
+	Error       string         `json:"error,omitempty"`
+}
+
+func (s *ReconciliationStatus) clone() *ReconciliationStatus {
+	clone := *s
+	if s.FailedPeers != nil {
+		clone.FailedPeers = make(map[string]int, len(s.FailedPeers))
+		for endpoint, count := range s.FailedPeers {
+			clone.FailedPeers[endpoint] = count
+		}
+	}
+	return &clone
+}
+
+// MissingPvtData describes a private data item of an eligible collection that is missing on the peer
+type MissingPvtData struct {
+	BlockNum   uint64 `json:"block_num"`
+	TxNum      uint64 `json:"tx_num"`
+	Namespace  string `json:"namespace"`
+	Collection string `json:"collection"`
+}
+
+// ListMissingPvtData returns the private data of eligible collections that is missing in the blocks of
+// the range [startBlock, endBlock], ordered by block, transaction, namespace and collection
+func ListMissingPvtData(tracker ledger.MissingPvtDataTracker, startBlock, endBlock uint64) ([]*MissingPvtData, error) {
+	missingPvtDataInfo, err := tracker.GetMissingPvtDataInfoForBlockRange(startBlock, endBlock, math.MaxInt32)
+	if err != nil {
+		return nil, err
+	}
+	var missing []*MissingPvtData
+	for blockNum, blockPvtDataInfo := range missingPvtDataInfo {
+		for txNum, collectionPvtDataInfo := range blockPvtDataInfo {
+			for _, pvtDataInfo := range collectionPvtDataInfo {
+				missing = append(missing, &MissingPvtData{
+					BlockNum:   blockNum,
+					TxNum:      txNum,
+					Namespace:  pvtDataInfo.Namespace,
+					Collection: pvtDataInfo.Collection,
+				})
+			}
+		}
+	}
+	sort.Slice(missing, func(i, j int) bool {
+		a, b := missing[i], missing[j]
+		if a.BlockNum != b.BlockNum {
+			return a.BlockNum < b.BlockNum
+		}
+		if a.TxNum != b.TxNum {
+			return a.TxNum < b.TxNum
+		}
+		if a.Namespace != b.Namespace {
+			return a.Namespace < b.Namespace
+		}
+		return a.Collection < b.Collection
+	})
+	return missing, nil
 }
 
 type Reconciler struct {
@@ -57,6 +151,10 @@ type Reconciler struct {
 	stopChan  chan struct{}
 	startOnce sync.Once
 	stopOnce  sync.Once
+	// reconcileLock serializes the scheduled reconciliation cycles and the triggered passes
+	reconcileLock sync.Mutex
+	statusLock    sync.Mutex
+	status        *ReconciliationStatus
 }
 
 // NoOpReconciler non functional reconciler to be used
@@ -73,6 +171,14 @@ func (*NoOpReconciler) Stop() {
 	// do nothing
 }
 
+func (*NoOpReconciler) Reconcile(startBlock, endBlock uint64) error {
+	return errors.New("private data reconciliation is disabled")
+}
+
+func (*NoOpReconciler) Status() *ReconciliationStatus {
+	return nil
+}
+
 // ReconcilerConfig holds config flags that are read from core.yaml
 type ReconcilerConfig struct {
 	sleepInterval time.Duration
@@ -134,8 +240,154 @@ func (r *Reconciler) run() {
 	}
 }
 
+// Reconcile triggers an immediate reconciliation pass over the missing private data
+// of the blocks in the range [startBlock, endBlock]
+func (r *Reconciler) Reconcile(startBlock, endBlock uint64) error {
+	if startBlock > endBlock {
+		return errors.Errorf("invalid block range [%d - %d]", startBlock, endBlock)
+	}
+	r.statusLock.Lock()
+	defer r.statusLock.Unlock()
+	if r.status != nil && r.status.InProgress {
+		return errors.Errorf("reconciliation of blocks range [%d - %d] is already in progress", r.status.StartBlock, r.status.EndBlock)
+	}
+	r.status = &ReconciliationStatus{
+		StartBlock: startBlock,
+		EndBlock:   endBlock,
+		InProgress: true,
+		StartedAt:  time.Now(),
+	}
+	go r.reconcileRange(startBlock, endBlock)
+	return nil
+}
+
+// Status returns the progress of the most recent reconciliation pass triggered with Reconcile
+func (r *Reconciler) Status() *ReconciliationStatus {
+	r.statusLock.Lock()
+	defer r.statusLock.Unlock()
+	if r.status == nil {
+		return nil
+	}
+	return r.status.clone()
+}
+
+func (r *Reconciler) updateStatus(update func(status *ReconciliationStatus)) {
+	r.statusLock.Lock()
+	defer r.statusLock.Unlock()
+	update(r.status)
+}
+
+func (r *Reconciler) reconcileRange(startBlock, endBlock uint64) {
+	logger.Infof("Start reconciling missing private data of blocks range [%d - %d]", startBlock, endBlock)
+	err := r.reconcileBlocks(startBlock, endBlock)
+	r.updateStatus(func(status *ReconciliationStatus) {
+		status.InProgress = false
+		status.FinishedAt = time.Now()
+		if err != nil {
+			status.Error = err.Error()
+		}
+	})
+	if err != nil {
+		logger.Errorf("Failed to reconcile missing private data of blocks range [%d - %d]: %s", startBlock, endBlock, err)
+		return
+	}
+	logger.Infof("Finished reconciling missing private data of blocks range [%d - %d]", startBlock, endBlock)
+}
+
+func (r *Reconciler) reconcileBlocks(startBlock, endBlock uint64) error {
+	r.reconcileLock.Lock()
+	defer r.reconcileLock.Unlock()
+
+	missingPvtDataTracker, err := r.GetMissingPvtDataTracker()
+	if err != nil {
+		return errors.WithMessage(err, "failed to get missingPvtDataTracker")
+	}
+	if missingPvtDataTracker == nil {
+		return errors.New("got nil as MissingPvtDataTracker")
+	}
+
+	for {
+		select {
+		case <-r.stopChan:
+			return errors.New("reconciler stopped")
+		default:
+		}
+
+		missingPvtDataInfo, err := missingPvtDataTracker.GetMissingPvtDataInfoForBlockRange(startBlock, endBlock, r.config.batchSize)
+		if err != nil {
+			return errors.WithMessage(err, "failed to get missing pvt data info")
+		}
+		if len(missingPvtDataInfo) == 0 {
+			return nil
+		}
+
+		dig2collectionCfg, minB, _ := r.getDig2CollectionConfig(missingPvtDataInfo)
+		fetchedData, pvtdataHashMismatch, err := r.fetchAndCommit(dig2collectionCfg)
+		if err != nil {
+			return err
+		}
+
+		reconciled := 0
+		for _, element := range fetchedData.AvailableElements {
+			if len(element.Payload) > 0 {
+				reconciled++
+			}
+		}
+		unavailable := len(dig2collectionCfg) - reconciled - len(fetchedData.PurgedElements)
+		if unavailable < 0 {
+			unavailable = 0
+		}
+		r.updateStatus(func(status *ReconciliationStatus) {
+			status.ProcessedBlocks += len(missingPvtDataInfo)
+			status.LastProcessedBlock = minB
+			status.ReconciledItems += reconciled
+			status.UnavailableItems += unavailable
+			status.HashMismatches += len(pvtdataHashMismatch)
+			for endpoint, count := range fetchedData.FailedPeers {
+				if status.FailedPeers == nil {
+					status.FailedPeers = make(map[string]int)
+				}
+				status.FailedPeers[endpoint] += count
+			}
+		})
+
+		// the private data that is still missing would be returned again,
+		// hence the next batch is taken from below the processed blocks
+		if minB <= startBlock {
+			return nil
+		}
+		endBlock = minB - 1
+	}
+}
+
+// fetchAndCommit pulls the missing private data described by the given digests from other peers
+// and commits the private data that is available. It returns the fetched private data along with
+// the private data that was not committed due to a hash mismatch
+func (r *Reconciler) fetchAndCommit(dig2collectionCfg privdatacommon.Dig2CollectionConfig) (*privdatacommon.FetchedPvtDataContainer, []*ledger.PvtdataHashMismatch, error) {
+	fetchedData, err := r.FetchReconciledItems(dig2collectionCfg)
+	if err != nil {
+		logger.Error("reconciliation error when trying to fetch missing items from different peers:", err)
+		return nil, nil, err
+	}
+	if len(fetchedData.AvailableElements) == 0 {
+		return fetchedData, nil, nil
+	}
+
+	pvtDataToCommit := r.preparePvtDataToCommit(fetchedData.AvailableElements)
+	// commit missing private data that was reconciled and log mismatched
+	pvtdataHashMismatch, err := r.CommitPvtDataOfOldBlocks(pvtDataToCommit)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "failed to commit private data")
+	}
+	r.logMismatched(pvtdataHashMismatch)
+	return fetchedData, pvtdataHashMismatch, nil
+}
+
 // returns the number of items that were reconciled , minBlock, maxBlock (blocks range) and an error
 func (r *Reconciler) reconcile() error {
+	r.reconcileLock.Lock()
+	defer r.reconcileLock.Unlock()
+
 	missingPvtDataTracker, err := r.GetMissingPvtDataTracker()
 	if err != nil {
 		logger.Error("reconciliation error when trying to get missingPvtDataTracker:", err)
@@ -166,23 +418,14 @@ func (r *Reconciler) reconcile() error {
 		logger.Debug("got from ledger", len(missingPvtDataInfo), "blocks with missing private data, trying to reconcile...")
 
 		dig2collectionCfg, minB, maxB := r.getDig2CollectionConfig(missingPvtDataInfo)
-		fetchedData, err := r.FetchReconciledItems(dig2collectionCfg)
+		fetchedData, _, err := r.fetchAndCommit(dig2collectionCfg)
 		if err != nil {
-			logger.Error("reconciliation error when trying to fetch missing items from different peers:", err)
 			return err
 		}
 		if len(fetchedData.AvailableElements) == 0 {
 			logger.Warning("missing private data is not available on other peers")
 			return nil
 		}
-
-		pvtDataToCommit := r.preparePvtDataToCommit(fetchedData.AvailableElements)
-		// commit missing private data that was reconciled and log mismatched
-		pvtdataHashMismatch, err := r.CommitPvtDataOfOldBlocks(pvtDataToCommit)
-		if err != nil {
-			return errors.Wrap(err, "failed to commit private data")
-		}
-		r.logMismatched(pvtdataHashMismatch)
 		if minB < minBlock {
 			minBlock = minB
 		}
@@ -240,6 +483,10 @@ func (r *Reconciler) getDig2CollectionConfig(missingPvtDataInfo ledger.MissingPv
 }
 
 func (r *Reconciler) getMostRecentCollectionConfig(chaincodeName string, collectionName string, blockNum uint64) (*common.StaticCollectionConfig, error) {
+	if implicitConfig := implicitCollectionConfig(collectionName); implicitConfig != nil {
+		return implicitConfig.GetStaticCollectionConfig(), nil
+	}
+
 	configHistoryRetriever, err := r.GetConfigHistoryRetriever()
 	if err != nil {
 		return nil, errors.Wrap(err, "configHistoryRetriever is not available")
@@ -12,7 +12,9 @@ import (
 	"testing"
 	"time"
 
+	"github.com/golang/protobuf/proto"
 	util2 "github.com/hyperledger/fabric/common/util"
+	"github.com/hyperledger/fabric/core/common/privdata"
 	"github.com/hyperledger/fabric/core/ledger"
 	privdatacommon "github.com/hyperledger/fabric/gossip/privdata/common"
 	"github.com/hyperledger/fabric/gossip/privdata/mocks"
@@ -81,6 +83,45 @@ func TestNotReconcilingWhenCollectionConfigNotAvailable(t *testing.T) {
 	assert.True(t, fetchCalled)
 }
 
+func TestReconcilingImplicitCollectionWithoutCollectionConfig(t *testing.T) {
+	// Scenario: the missing private data belongs to an implicit collection, which has no entry in
+	// the collection config history. The reconciler should still pull the digest using the
+	// generated config of the implicit collection.
+	committer := &mocks.Committer{}
+	fetcher := &mocks.ReconciliationFetcher{}
+	configHistoryRetriever := &mocks.ConfigHistoryRetriever{}
+	missingPvtDataTracker := &mocks.MissingPvtDataTracker{}
+	implicitCollName := privdata.ImplicitCollectionNameForOrg("Org1MSP")
+
+	var missingInfo ledger.MissingPvtDataInfo = map[uint64]ledger.MissingBlockPvtdataInfo{
+		1: map[uint64][]*ledger.MissingCollectionPvtDataInfo{
+			1: {{Collection: implicitCollName, Namespace: "chain1"}},
+		},
+	}
+
+	missingPvtDataTracker.On("GetMissingPvtDataInfoForMostRecentBlocks", mock.Anything).Return(missingInfo, nil)
+	committer.On("GetMissingPvtDataTracker").Return(missingPvtDataTracker, nil)
+	committer.On("GetConfigHistoryRetriever").Return(configHistoryRetriever, nil)
+
+	var fetchCalled bool
+	fetcher.On("FetchReconciledItems", mock.Anything).Run(func(args mock.Arguments) {
+		var dig2CollectionConfig = args.Get(0).(privdatacommon.Dig2CollectionConfig)
+		assert.Equal(t, 1, len(dig2CollectionConfig))
+		for dig, collConfig := range dig2CollectionConfig {
+			assert.Equal(t, implicitCollName, dig.Collection)
+			assert.True(t, proto.Equal(privdata.GenerateImplicitCollectionForOrg("Org1MSP"), collConfig))
+		}
+		fetchCalled = true
+	}).Return(nil, errors.New("fetch failed"))
+
+	r := &Reconciler{config: &ReconcilerConfig{sleepInterval: time.Minute, batchSize: 1, IsEnabled: true}, ReconciliationFetcher: fetcher, Committer: committer}
+	err := r.reconcile()
+
+	assert.EqualError(t, err, "fetch failed")
+	assert.True(t, fetchCalled)
+	configHistoryRetriever.AssertNotCalled(t, "MostRecentCollectionConfigBelow", mock.Anything, mock.Anything)
+}
+
 func TestReconciliationHappyPathWithoutScheduler(t *testing.T) {
 	// Scenario: happy path when trying to reconcile missing private data.
 	committer := &mocks.Committer{}
@@ -452,3 +493,144 @@ func TestFailuresWhileReconcilingMissingPvtData(t *testing.T) {
 	assert.Error(t, err)
 	assert.Contains(t, "failed get missing pvt data for recent blocks", err.Error())
 }
+
+func TestReconcileBlockRange(t *testing.T) {
+	// Scenario: an operator triggers the reconciliation of the blocks range [2 - 10], where blocks 5 and 3
+	// miss private data. The private data of block 5 is pulled from a peer, while the peer that is asked for
+	// the private data of block 3 doesn't supply it. The range is processed from the most recent block
+	// downwards, one block per batch, and the status reports the progress along with the failing peer.
+	committer := &mocks.Committer{}
+	fetcher := &mocks.ReconciliationFetcher{}
+	configHistoryRetriever := &mocks.ConfigHistoryRetriever{}
+	missingPvtDataTracker := &mocks.MissingPvtDataTracker{}
+
+	missingInfo := func(blockNum uint64) ledger.MissingPvtDataInfo {
+		return map[uint64]ledger.MissingBlockPvtdataInfo{
+			blockNum: map[uint64][]*ledger.MissingCollectionPvtDataInfo{
+				1: {{Collection: "col1", Namespace: "ns1"}},
+			},
+		}
+	}
+	missingPvtDataTracker.On("GetMissingPvtDataInfoForBlockRange", uint64(2), uint64(10), 1).Return(missingInfo(5), nil)
+	missingPvtDataTracker.On("GetMissingPvtDataInfoForBlockRange", uint64(2), uint64(4), 1).Return(missingInfo(3), nil)
+	missingPvtDataTracker.On("GetMissingPvtDataInfoForBlockRange", uint64(2), uint64(2), 1).Return(nil, nil)
+
+	collectionConfigInfo := ledger.CollectionConfigInfo{
+		CollectionConfig: &common.CollectionConfigPackage{
+			Config: []*common.CollectionConfig{
+				{Payload: &common.CollectionConfig_StaticCollectionConfig{
+					StaticCollectionConfig: &common.StaticCollectionConfig{
+						Name: "col1",
+					},
+				}},
+			},
+		},
+		CommittingBlockNum: 1,
+	}
+	configHistoryRetriever.On("MostRecentCollectionConfigBelow", mock.Anything, mock.Anything).Return(&collectionConfigInfo, nil)
+	committer.On("GetMissingPvtDataTracker").Return(missingPvtDataTracker, nil)
+	committer.On("GetConfigHistoryRetriever").Return(configHistoryRetriever, nil)
+
+	fetchDone := make(chan struct{})
+	fetcher.On("FetchReconciledItems", mock.Anything).Return(func(dig2CollectionConfig privdatacommon.Dig2CollectionConfig) *privdatacommon.FetchedPvtDataContainer {
+		<-fetchDone
+		result := &privdatacommon.FetchedPvtDataContainer{}
+		for digest := range dig2CollectionConfig {
+			if digest.BlockSeq == 3 {
+				result.FailedPeers = map[string]int{"peer1:7051": 1}
+				continue
+			}
+			result.AvailableElements = append(result.AvailableElements, &gossip2.PvtDataElement{
+				Digest: &gossip2.PvtDataDigest{
+					TxId:       digest.TxId,
+					BlockSeq:   digest.BlockSeq,
+					Collection: digest.Collection,
+					Namespace:  digest.Namespace,
+					SeqInBlock: digest.SeqInBlock,
+				},
+				Payload: [][]byte{util2.ComputeSHA256([]byte("rws-pre-image"))},
+			})
+		}
+		return result
+	}, nil)
+
+	var committedBlocks []uint64
+	committer.On("CommitPvtDataOfOldBlocks", mock.Anything).Run(func(args mock.Arguments) {
+		for _, blockPvtData := range args.Get(0).([]*ledger.BlockPvtData) {
+			committedBlocks = append(committedBlocks, blockPvtData.BlockNum)
+		}
+	}).Return([]*ledger.PvtdataHashMismatch{}, nil)
+
+	r := NewReconciler(committer, fetcher, &ReconcilerConfig{sleepInterval: time.Minute, batchSize: 1, IsEnabled: true})
+	assert.Nil(t, r.Status())
+	assert.EqualError(t, r.Reconcile(10, 2), "invalid block range [10 - 2]")
+
+	assert.NoError(t, r.Reconcile(2, 10))
+	status := r.Status()
+	assert.True(t, status.InProgress)
+	assert.Equal(t, uint64(2), status.StartBlock)
+	assert.Equal(t, uint64(10), status.EndBlock)
+	assert.EqualError(t, r.Reconcile(0, 1), "reconciliation of blocks range [2 - 10] is already in progress")
+	close(fetchDone)
+
+	waitForReconciliation(t, r)
+	status = r.Status()
+	assert.Equal(t, 2, status.ProcessedBlocks)
+	assert.Equal(t, uint64(3), status.LastProcessedBlock)
+	assert.Equal(t, 1, status.ReconciledItems)
+	assert.Equal(t, 1, status.UnavailableItems)
+	assert.Equal(t, map[string]int{"peer1:7051": 1}, status.FailedPeers)
+	assert.Empty(t, status.Error)
+	assert.False(t, status.FinishedAt.IsZero())
+	assert.Equal(t, []uint64{5}, committedBlocks)
+
+	// the status returned is a copy
+	status.FailedPeers["peer2:7051"] = 1
+	assert.Equal(t, map[string]int{"peer1:7051": 1}, r.Status().FailedPeers)
+
+	// a failure is reported in the status
+	committer.Mock = mock.Mock{}
+	committer.On("GetMissingPvtDataTracker").Return(nil, errors.New("failed to obtain missing pvt data tracker"))
+	assert.NoError(t, r.Reconcile(0, 1))
+	waitForReconciliation(t, r)
+	assert.Equal(t, "failed to get missingPvtDataTracker: failed to obtain missing pvt data tracker", r.Status().Error)
+}
+
+func waitForReconciliation(t *testing.T, r *Reconciler) {
+	deadline := time.Now().Add(time.Second * 10)
+	for r.Status().InProgress {
+		if time.Now().After(deadline) {
+			t.Fatal("reconciliation pass didn't finish in time")
+		}
+		time.Sleep(time.Millisecond * 10)
+	}
+}
+
+func TestNoOpReconcilerReconcile(t *testing.T) {
+	r := &NoOpReconciler{}
+	assert.EqualError(t, r.Reconcile(0, 10), "private data reconciliation is disabled")
+	assert.Nil(t, r.Status())
+}
+
+func TestListMissingPvtData(t *testing.T) {
+	missingPvtDataTracker := &mocks.MissingPvtDataTracker{}
+	missingInfo := ledger.MissingPvtDataInfo{}
+	missingInfo.Add(5, 2, "ns1", "col2")
+	missingInfo.Add(5, 2, "ns1", "col1")
+	missingInfo.Add(5, 1, "ns2", "col1")
+	missingInfo.Add(3, 4, "ns1", "col1")
+	missingPvtDataTracker.On("GetMissingPvtDataInfoForBlockRange", uint64(1), uint64(7), mock.Anything).Return(missingInfo, nil)
+
+	missing, err := ListMissingPvtData(missingPvtDataTracker, 1, 7)
+	assert.NoError(t, err)
+	assert.Equal(t, []*MissingPvtData{
+		{BlockNum: 3, TxNum: 4, Namespace: "ns1", Collection: "col1"},
+		{BlockNum: 5, TxNum: 1, Namespace: "ns2", Collection: "col1"},
+		{BlockNum: 5, TxNum: 2, Namespace: "ns1", Collection: "col1"},
+		{BlockNum: 5, TxNum: 2, Namespace: "ns1", Collection: "col2"},
+	}, missing)
+
+	missingPvtDataTracker.On("GetMissingPvtDataInfoForBlockRange", uint64(0), uint64(1), mock.Anything).Return(nil, errors.New("boom"))
+	_, err = ListMissingPvtData(missingPvtDataTracker, 0, 1)
+	assert.EqualError(t, err, "boom")
+}
@@ -10,6 +10,7 @@ import (
 	"fmt"
 
 	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/core/common/privdata"
 	"github.com/hyperledger/fabric/core/ledger"
 	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/rwsetutil"
 	privdatacommon "github.com/hyperledger/fabric/gossip/privdata/common"
@@ -240,6 +241,20 @@ func extractCollectionConfig(configPackage *common.CollectionConfigPackage, coll
 	return nil
 }
 
+// implicitCollectionConfig returns the collection config of the given collection
+// if it is the implicit collection of an organization, and nil otherwise
+func implicitCollectionConfig(collectionName string) *common.CollectionConfig {
+	isImplicit, mspID := privdata.MSPIDIfImplicitCollection(collectionName)
+	if !isImplicit {
+		return nil
+	}
+	return &common.CollectionConfig{
+		Payload: &common.CollectionConfig_StaticCollectionConfig{
+			StaticCollectionConfig: privdata.GenerateImplicitCollectionForOrg(mspID),
+		},
+	}
+}
+
 type pvtDataFactory struct {
 	data []*ledger.TxPvtData
 }
@@ -14,6 +14,7 @@ import (
 	"github.com/hyperledger/fabric/core/common/privdata"
 	"github.com/hyperledger/fabric/core/deliverservice"
 	"github.com/hyperledger/fabric/core/deliverservice/blocksprovider"
+	"github.com/hyperledger/fabric/core/ledger"
 	"github.com/hyperledger/fabric/gossip/api"
 	gossipCommon "github.com/hyperledger/fabric/gossip/common"
 	"github.com/hyperledger/fabric/gossip/election"
@@ -40,6 +41,7 @@ type gossipSvc gossip.Gossip
 // GossipService encapsulates gossip and state capabilities into single interface
 type GossipService interface {
 	gossip.Gossip
+	privdata2.ReconciliationSupport
 
 	// DistributePrivateData distributes private data to the peers in the collections
 	// according to policies induced by the PolicyStore and PolicyParser
@@ -194,6 +196,28 @@ func (g *gossipServiceImpl) DistributePrivateData(chainID string, txID string, p
 	return nil
 }
 
+// MissingPvtDataTracker returns the missing private data tracker of the given channel
+func (g *gossipServiceImpl) MissingPvtDataTracker(chainID string) (ledger.MissingPvtDataTracker, error) {
+	g.lock.RLock()
+	handler, exists := g.privateHandlers[chainID]
+	g.lock.RUnlock()
+	if !exists {
+		return nil, errors.Errorf("No private data handler for %s", chainID)
+	}
+	return handler.support.Committer.GetMissingPvtDataTracker()
+}
+
+// Reconciler returns the private data reconciler of the given channel
+func (g *gossipServiceImpl) Reconciler(chainID string) (privdata2.PvtDataReconciler, error) {
+	g.lock.RLock()
+	handler, exists := g.privateHandlers[chainID]
+	g.lock.RUnlock()
+	if !exists {
+		return nil, errors.Errorf("No private data handler for %s", chainID)
+	}
+	return handler.reconciler, nil
+}
+
 // NewConfigEventer creates a ConfigProcessor which the channelconfig.BundleSource can ultimately route config updates to
 func (g *gossipServiceImpl) NewConfigEventer() ConfigProcessor {
 	return newConfigEventer(g)
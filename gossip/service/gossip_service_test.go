@@ -49,6 +49,10 @@ func (*mockTransientStore) PurgeByHeight(maxBlockNumToRetain uint64) error {
 	return nil
 }
 
+func (*mockTransientStore) PurgeByKeyHashes(purgeMarkers []*ledger.PvtDataPurgeMarker) error {
+	return nil
+}
+
 func (*mockTransientStore) Persist(txid string, blockHeight uint64, privateSimulationResults *rwset.TxPvtReadWriteSet) error {
 	panic("implement me")
 }
@@ -264,6 +268,14 @@ func TestWithStaticDeliverClientNotLeader(t *testing.T) {
 		assert.False(t, gossips[i].(*gossipServiceImpl).deliveryService[channelName].(*mockDeliverService).running[channelName], "Block deliverer should not be started for peer %d", i)
 	}
 
+	reconciler, err := gossips[0].Reconciler(channelName)
+	assert.NoError(t, err)
+	assert.NotNil(t, reconciler)
+	_, err = gossips[0].Reconciler("nonexistent")
+	assert.EqualError(t, err, "No private data handler for nonexistent")
+	_, err = gossips[0].MissingPvtDataTracker("nonexistent")
+	assert.EqualError(t, err, "No private data handler for nonexistent")
+
 	stopPeers(gossips)
 }
 
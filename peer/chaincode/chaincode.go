@@ -23,7 +23,7 @@ import (
 
 const (
 	chainFuncName = "chaincode"
-	chainCmdDes   = "Operate a chaincode: install|instantiate|invoke|package|query|signpackage|upgrade|list."
+	chainCmdDes   = "Operate a chaincode: install|instantiate|invoke|package|query|signpackage|upgrade|list|index."
 )
 
 var logger = flogging.MustGetLogger("chaincodeCmd")
@@ -57,6 +57,7 @@ func Cmd(cf *ChaincodeCmdFactory) *cobra.Command {
 	chaincodeCmd.AddCommand(signpackageCmd(cf))
 	chaincodeCmd.AddCommand(upgradeCmd(cf))
 	chaincodeCmd.AddCommand(listCmd(cf))
+	chaincodeCmd.AddCommand(indexCmd(cf))
 
 	return chaincodeCmd
 }
@@ -84,6 +85,10 @@ var (
 	connectionProfile     string
 	waitForEvent          bool
 	waitForEventTimeout   time.Duration
+	indexCollection       string
+	designDoc             string
+	indexName             string
+	indexFile             string
 )
 
 var chaincodeCmd = &cobra.Command{
@@ -142,6 +147,14 @@ func resetFlags() {
 		fmt.Sprint("Whether to wait for the event from each peer's deliver filtered service signifying that the 'invoke' transaction has been committed successfully"))
 	flags.DurationVar(&waitForEventTimeout, "waitForEventTimeout", 30*time.Second,
 		fmt.Sprint("Time to wait for the event from each peer's deliver filtered service signifying that the 'invoke' transaction has been committed successfully"))
+	flags.StringVar(&indexCollection, "collection", "",
+		fmt.Sprint("The collection of the chaincode the index belongs to (default the public state of the chaincode)"))
+	flags.StringVar(&designDoc, "designDoc", "",
+		fmt.Sprint("The design document of the index"))
+	flags.StringVar(&indexName, "indexName", "",
+		fmt.Sprint("The name of the index"))
+	flags.StringVar(&indexFile, "indexFile", "",
+		fmt.Sprint("The path to the file containing the CouchDB index definition in JSON format"))
 }
 
 func attachFlags(cmd *cobra.Command, names []string) {
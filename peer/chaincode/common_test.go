@@ -344,7 +344,7 @@ func TestDeliverGroupConnect(t *testing.T) {
 	g := NewGomegaWithT(t)
 
 	// success
-	mockDeliverClients := []*deliverClient{
+	mockDeliverClients := []*DeliverClient{
 		{
 			Client:  getMockDeliverClientResponseWithTxID("txid0"),
 			Address: "peer0",
@@ -354,7 +354,7 @@ func TestDeliverGroupConnect(t *testing.T) {
 			Address: "peer1",
 		},
 	}
-	dg := deliverGroup{
+	dg := DeliverGroup{
 		Clients:   mockDeliverClients,
 		ChannelID: "testchannel",
 		Certificate: tls.Certificate{
@@ -368,13 +368,13 @@ func TestDeliverGroupConnect(t *testing.T) {
 	// failure - DeliverFiltered returns error
 	mockDC := &cmock.PeerDeliverClient{}
 	mockDC.DeliverFilteredReturns(nil, errors.New("icecream"))
-	mockDeliverClients = []*deliverClient{
+	mockDeliverClients = []*DeliverClient{
 		{
 			Client:  mockDC,
 			Address: "peer0",
 		},
 	}
-	dg = deliverGroup{
+	dg = DeliverGroup{
 		Clients:   mockDeliverClients,
 		ChannelID: "testchannel",
 		Certificate: tls.Certificate{
@@ -390,13 +390,13 @@ func TestDeliverGroupConnect(t *testing.T) {
 	mockD := &mock.Deliver{}
 	mockD.SendReturns(errors.New("blah"))
 	mockDC.DeliverFilteredReturns(mockD, nil)
-	mockDeliverClients = []*deliverClient{
+	mockDeliverClients = []*DeliverClient{
 		{
 			Client:  mockDC,
 			Address: "peer0",
 		},
 	}
-	dg = deliverGroup{
+	dg = DeliverGroup{
 		Clients:   mockDeliverClients,
 		ChannelID: "testchannel",
 		Certificate: tls.Certificate{
@@ -411,7 +411,7 @@ func TestDeliverGroupConnect(t *testing.T) {
 	// failure - deliver registration timeout
 	delayChan := make(chan struct{})
 	mockDCDelay := getMockDeliverClientRegisterAfterDelay(delayChan)
-	mockDeliverClients = []*deliverClient{
+	mockDeliverClients = []*DeliverClient{
 		{
 			Client:  mockDCDelay,
 			Address: "peer0",
@@ -419,7 +419,7 @@ func TestDeliverGroupConnect(t *testing.T) {
 	}
 	ctx, cancelFunc := context.WithTimeout(context.Background(), 10*time.Millisecond)
 	defer cancelFunc()
-	dg = deliverGroup{
+	dg = DeliverGroup{
 		Clients:   mockDeliverClients,
 		ChannelID: "testchannel",
 		Certificate: tls.Certificate{
@@ -438,13 +438,13 @@ func TestDeliverGroupWait(t *testing.T) {
 
 	// success
 	mockConn := getMockDeliverConnectionResponseWithTxID("txid0")
-	mockDeliverClients := []*deliverClient{
+	mockDeliverClients := []*DeliverClient{
 		{
 			Connection: mockConn,
 			Address:    "peer0",
 		},
 	}
-	dg := deliverGroup{
+	dg := DeliverGroup{
 		Clients:   mockDeliverClients,
 		ChannelID: "testchannel",
 		Certificate: tls.Certificate{
@@ -458,13 +458,13 @@ func TestDeliverGroupWait(t *testing.T) {
 	// failure - Recv returns error
 	mockConn = &mock.Deliver{}
 	mockConn.RecvReturns(nil, errors.New("avocado"))
-	mockDeliverClients = []*deliverClient{
+	mockDeliverClients = []*DeliverClient{
 		{
 			Connection: mockConn,
 			Address:    "peer0",
 		},
 	}
-	dg = deliverGroup{
+	dg = DeliverGroup{
 		Clients:   mockDeliverClients,
 		ChannelID: "testchannel",
 		Certificate: tls.Certificate{
@@ -482,13 +482,13 @@ func TestDeliverGroupWait(t *testing.T) {
 		Type: &pb.DeliverResponse_Block{},
 	}
 	mockConn.RecvReturns(resp, nil)
-	mockDeliverClients = []*deliverClient{
+	mockDeliverClients = []*DeliverClient{
 		{
 			Connection: mockConn,
 			Address:    "peer0",
 		},
 	}
-	dg = deliverGroup{
+	dg = DeliverGroup{
 		Clients:   mockDeliverClients,
 		ChannelID: "testchannel",
 		Certificate: tls.Certificate{
@@ -504,7 +504,7 @@ func TestDeliverGroupWait(t *testing.T) {
 	mockConn.RecvReturns(nil, errors.New("barbeque"))
 	mockConn2 := &mock.Deliver{}
 	mockConn2.RecvReturns(nil, errors.New("tofu"))
-	mockDeliverClients = []*deliverClient{
+	mockDeliverClients = []*DeliverClient{
 		{
 			Connection: mockConn,
 			Address:    "peerBBQ",
@@ -514,7 +514,7 @@ func TestDeliverGroupWait(t *testing.T) {
 			Address:    "peerTOFU",
 		},
 	}
-	dg = deliverGroup{
+	dg = DeliverGroup{
 		Clients:   mockDeliverClients,
 		ChannelID: "testchannel",
 		Certificate: tls.Certificate{
@@ -0,0 +1,185 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package chaincode
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/common/crypto"
+	"github.com/hyperledger/fabric/peer/common"
+	cb "github.com/hyperledger/fabric/protos/common"
+	pb "github.com/hyperledger/fabric/protos/peer"
+	"github.com/hyperledger/fabric/protos/utils"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+const indexCmdDes = "Manage the indexes of a chaincode in the state database: list|drop|rebuild."
+
+// indexCmd returns the cobra command for managing the chaincode indexes
+func indexCmd(cf *ChaincodeCmdFactory) *cobra.Command {
+	chaincodeIndexCmd := &cobra.Command{
+		Use:   "index",
+		Short: indexCmdDes,
+		Long:  indexCmdDes,
+	}
+	chaincodeIndexCmd.AddCommand(indexListCmd(cf))
+	chaincodeIndexCmd.AddCommand(indexDropCmd(cf))
+	chaincodeIndexCmd.AddCommand(indexRebuildCmd(cf))
+
+	return chaincodeIndexCmd
+}
+
+func indexListCmd(cf *ChaincodeCmdFactory) *cobra.Command {
+	chaincodeIndexListCmd := &cobra.Command{
+		Use:   "list",
+		Short: "List the indexes of a chaincode.",
+		Long:  "List the indexes of a chaincode on a channel, for the public state of the chaincode and for each of its collections.",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return listIndexes(cmd, args, cf)
+		},
+	}
+	attachFlags(chaincodeIndexListCmd, []string{"channelID", "name"})
+
+	return chaincodeIndexListCmd
+}
+
+func indexDropCmd(cf *ChaincodeCmdFactory) *cobra.Command {
+	chaincodeIndexDropCmd := &cobra.Command{
+		Use:   "drop",
+		Short: "Drop an index of a chaincode.",
+		Long:  "Drop the index of a design document from the public state of a chaincode on a channel, or from one of its collections.",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return dropIndex(cmd, args, cf)
+		},
+	}
+	attachFlags(chaincodeIndexDropCmd, []string{"channelID", "name", "collection", "designDoc", "indexName"})
+
+	return chaincodeIndexDropCmd
+}
+
+func indexRebuildCmd(cf *ChaincodeCmdFactory) *cobra.Command {
+	chaincodeIndexRebuildCmd := &cobra.Command{
+		Use:   "rebuild",
+		Short: "Rebuild an index of a chaincode from its definition.",
+		Long: "Create an index on the public state of a chaincode on a channel, or on one of its collections, from the " +
+			"CouchDB index definition in a file. An existing index with the same design document and name is replaced.",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return rebuildIndex(cmd, args, cf)
+		},
+	}
+	attachFlags(chaincodeIndexRebuildCmd, []string{"channelID", "name", "collection", "indexFile"})
+
+	return chaincodeIndexRebuildCmd
+}
+
+func listIndexes(cmd *cobra.Command, args []string, cf *ChaincodeCmdFactory) error {
+	cf, env, err := prepareIndexRequest(cmd, args, cf, nil)
+	if err != nil {
+		return err
+	}
+	response, err := cf.AdminClient.ListChaincodeIndexes(context.Background(), env)
+	if err != nil {
+		return err
+	}
+	return printIndexResponse(cmd.OutOrStdout(), "Chaincode indexes", response)
+}
+
+func dropIndex(cmd *cobra.Command, args []string, cf *ChaincodeCmdFactory) error {
+	if designDoc == "" || indexName == "" {
+		return errors.New("must supply the design document and the name of the index")
+	}
+	cf, env, err := prepareIndexRequest(cmd, args, cf, nil)
+	if err != nil {
+		return err
+	}
+	if _, err := cf.AdminClient.DropChaincodeIndex(context.Background(), env); err != nil {
+		return err
+	}
+	fmt.Fprintf(cmd.OutOrStdout(), "Dropped index %s of design document %s\n", indexName, designDoc)
+	return nil
+}
+
+func rebuildIndex(cmd *cobra.Command, args []string, cf *ChaincodeCmdFactory) error {
+	if indexFile == "" {
+		return errors.New("must supply the index definition file")
+	}
+	definition, err := ioutil.ReadFile(indexFile)
+	if err != nil {
+		return errors.Wrapf(err, "failed reading the index definition file %s", indexFile)
+	}
+	cf, env, err := prepareIndexRequest(cmd, args, cf, definition)
+	if err != nil {
+		return err
+	}
+	response, err := cf.AdminClient.RebuildChaincodeIndex(context.Background(), env)
+	if err != nil {
+		return err
+	}
+	return printIndexResponse(cmd.OutOrStdout(), "Rebuilt chaincode index", response)
+}
+
+// prepareIndexRequest checks the parameters of the command and returns the signed admin operation
+// selecting the indexes of the chaincode given on the command line
+func prepareIndexRequest(cmd *cobra.Command, args []string, cf *ChaincodeCmdFactory, definition []byte) (*ChaincodeCmdFactory, *cb.Envelope, error) {
+	if len(args) > 0 {
+		return nil, nil, errors.Errorf("more parameters than necessary were provided. Expected 0, received %d", len(args))
+	}
+	if channelID == "" {
+		return nil, nil, errors.New("The required parameter 'channelID' is empty. Rerun the command with -C flag")
+	}
+	if chaincodeName == "" {
+		return nil, nil, errors.New("must supply the chaincode name")
+	}
+	// Parsing of the command line is done so silence cmd usage
+	cmd.SilenceUsage = true
+
+	var err error
+	if cf == nil {
+		cf, err = InitCmdFactory(cmd.Name(), false, false)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+	if cf.AdminClient == nil {
+		if cf.AdminClient, err = common.GetAdminClient(); err != nil {
+			return nil, nil, errors.WithMessage(err, "error getting admin client")
+		}
+	}
+
+	op := &pb.AdminOperation{
+		Content: &pb.AdminOperation_ChaincodeIndexReq{
+			ChaincodeIndexReq: &pb.ChaincodeIndexRequest{
+				ChannelId:       channelID,
+				Chaincode:       chaincodeName,
+				Collection:      indexCollection,
+				DesignDoc:       designDoc,
+				IndexName:       indexName,
+				IndexDefinition: definition,
+			},
+		},
+	}
+	env, err := utils.CreateSignedEnvelope(cb.HeaderType_PEER_ADMIN_OPERATION, "", crypto.NewSignatureHeaderCreator(cf.Signer), op, 0, 0)
+	if err != nil {
+		return nil, nil, errors.WithMessage(err, "error signing the admin operation")
+	}
+	return cf, env, nil
+}
+
+func printIndexResponse(out io.Writer, title string, response proto.Message) error {
+	jsonBytes, err := json.Marshal(response)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(out, "%s: %s\n", title, string(jsonBytes))
+	return nil
+}
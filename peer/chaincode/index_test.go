@@ -0,0 +1,132 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package chaincode
+
+import (
+	"bytes"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hyperledger/fabric/peer/common"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestChaincodeIndexCmds(t *testing.T) {
+	signer, err := common.GetDefaultSigner()
+	require.NoError(t, err)
+
+	dir, err := ioutil.TempDir("", "chaincode-index")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	indexFilePath := filepath.Join(dir, "indexOwner.json")
+	require.NoError(t, ioutil.WriteFile(indexFilePath, []byte(`{"index":{"fields":["owner"]}}`), 0644))
+
+	tests := []struct {
+		name        string
+		command     string
+		args        []string
+		clientErr   error
+		expectedErr string
+		expectedOut string
+	}{
+		{
+			name:        "list",
+			command:     "list",
+			args:        []string{"-C", "mychannel", "-n", "mycc"},
+			expectedOut: "Chaincode indexes: {\"indexes\":[{\"chaincode\":\"mycc\",\"design_doc\":\"indexOwnerDoc\",\"name\":\"indexOwner\"}]}\n",
+		},
+		{
+			name:        "drop",
+			command:     "drop",
+			args:        []string{"-C", "mychannel", "-n", "mycc", "--designDoc", "indexOwnerDoc", "--indexName", "indexOwner"},
+			expectedOut: "Dropped index indexOwner of design document indexOwnerDoc\n",
+		},
+		{
+			name:        "rebuild",
+			command:     "rebuild",
+			args:        []string{"-C", "mychannel", "-n", "mycc", "--collection", "coll1", "--indexFile", indexFilePath},
+			expectedOut: "Rebuilt chaincode index: {\"chaincode\":\"mycc\",\"collection\":\"coll1\",\"design_doc\":\"indexOwnerDoc\",\"name\":\"indexOwner\",\"definition\":\"{\\\"index\\\":{\\\"fields\\\":[\\\"owner\\\"]}}\"}\n",
+		},
+		{
+			name:        "no channel",
+			command:     "list",
+			args:        []string{"-n", "mycc"},
+			expectedErr: "The required parameter 'channelID' is empty. Rerun the command with -C flag",
+		},
+		{
+			name:        "no chaincode name",
+			command:     "list",
+			args:        []string{"-C", "mychannel"},
+			expectedErr: "must supply the chaincode name",
+		},
+		{
+			name:        "extra parameters",
+			command:     "list",
+			args:        []string{"-C", "mychannel", "-n", "mycc", "extra"},
+			expectedErr: "more parameters than necessary were provided. Expected 0, received 1",
+		},
+		{
+			name:        "drop without index name",
+			command:     "drop",
+			args:        []string{"-C", "mychannel", "-n", "mycc", "--designDoc", "indexOwnerDoc"},
+			expectedErr: "must supply the design document and the name of the index",
+		},
+		{
+			name:        "rebuild without index file",
+			command:     "rebuild",
+			args:        []string{"-C", "mychannel", "-n", "mycc"},
+			expectedErr: "must supply the index definition file",
+		},
+		{
+			name:        "rebuild with missing index file",
+			command:     "rebuild",
+			args:        []string{"-C", "mychannel", "-n", "mycc", "--indexFile", filepath.Join(dir, "missing.json")},
+			expectedErr: "failed reading the index definition file " + filepath.Join(dir, "missing.json"),
+		},
+		{
+			name:        "admin client error",
+			command:     "drop",
+			args:        []string{"-C", "mychannel", "-n", "mycc", "--designDoc", "indexOwnerDoc", "--indexName", "indexOwner"},
+			clientErr:   errors.New("index not found"),
+			expectedErr: "index not found",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			resetFlags()
+			mockCF := &ChaincodeCmdFactory{
+				Signer:      signer,
+				AdminClient: common.GetMockAdminClient(test.clientErr),
+			}
+			var cmd *cobra.Command
+			switch test.command {
+			case "list":
+				cmd = indexListCmd(mockCF)
+			case "drop":
+				cmd = indexDropCmd(mockCF)
+			case "rebuild":
+				cmd = indexRebuildCmd(mockCF)
+			}
+			out := &bytes.Buffer{}
+			cmd.SetOutput(out)
+			cmd.SetArgs(test.args)
+			err := cmd.Execute()
+			if test.expectedErr != "" {
+				assert.Contains(t, err.Error(), test.expectedErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, test.expectedOut, out.String())
+		})
+	}
+}
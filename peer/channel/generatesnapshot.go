@@ -0,0 +1,105 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package channel
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hyperledger/fabric/core/scc/cscc"
+	"github.com/hyperledger/fabric/peer/common"
+	cb "github.com/hyperledger/fabric/protos/common"
+	pb "github.com/hyperledger/fabric/protos/peer"
+	"github.com/hyperledger/fabric/protos/utils"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+const generateSnapshotCommandDescription = "Generates a snapshot of the ledger of a channel at the last committed block. Requires '-c'."
+
+func generateSnapshotCmd(cf *ChannelCmdFactory) *cobra.Command {
+	generateSnapshotCmd := &cobra.Command{
+		Use:   "generatesnapshot",
+		Short: "Generates a snapshot of the ledger of a specified channel.",
+		Long:  generateSnapshotCommandDescription,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return generateSnapshot(cmd, cf)
+		},
+	}
+	flagList := []string{
+		"channelID",
+	}
+	attachFlags(generateSnapshotCmd, flagList)
+
+	return generateSnapshotCmd
+}
+
+func (cc *endorserClient) generateSnapshot() (string, error) {
+	invocation := &pb.ChaincodeInvocationSpec{
+		ChaincodeSpec: &pb.ChaincodeSpec{
+			Type:        pb.ChaincodeSpec_Type(pb.ChaincodeSpec_Type_value["GOLANG"]),
+			ChaincodeId: &pb.ChaincodeID{Name: "cscc"},
+			Input:       &pb.ChaincodeInput{Args: [][]byte{[]byte(cscc.GenerateSnapshot), []byte(channelID)}},
+		},
+	}
+
+	c, err := cc.cf.Signer.Serialize()
+	if err != nil {
+		return "", errors.WithMessage(err, "cannot serialize the signer identity")
+	}
+	prop, _, err := utils.CreateProposalFromCIS(cb.HeaderType_ENDORSER_TRANSACTION, "", invocation, c)
+	if err != nil {
+		return "", errors.WithMessage(err, "cannot create proposal")
+	}
+
+	signedProp, err := utils.GetSignedProposal(prop, cc.cf.Signer)
+	if err != nil {
+		return "", errors.WithMessage(err, "cannot create signed proposal")
+	}
+
+	proposalResp, err := cc.cf.EndorserClient.ProcessProposal(context.Background(), signedProp)
+	if err != nil {
+		return "", errors.WithMessage(err, "failed sending proposal")
+	}
+
+	if proposalResp.Response == nil {
+		return "", errors.New("received nil response")
+	}
+	if proposalResp.Response.Status != 200 {
+		return "", errors.Errorf("received bad response, status %d: %s", proposalResp.Response.Status, proposalResp.Response.Message)
+	}
+
+	return string(proposalResp.Response.Payload), nil
+}
+
+func generateSnapshot(cmd *cobra.Command, cf *ChannelCmdFactory) error {
+	//the global chainID filled by the "-c" command
+	if channelID == common.UndefinedParamValue {
+		return errors.New("Must supply channel ID")
+	}
+	// Parsing of the command line is done so silence cmd usage
+	cmd.SilenceUsage = true
+
+	var err error
+	if cf == nil {
+		cf, err = InitCmdFactory(EndorserRequired, PeerDeliverNotRequired, OrdererNotRequired)
+		if err != nil {
+			return err
+		}
+	}
+
+	client := &endorserClient{cf}
+
+	snapshotDir, err := client.generateSnapshot()
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Snapshot generated in the directory: %s\n", snapshotDir)
+
+	return nil
+}
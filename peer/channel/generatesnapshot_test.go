@@ -0,0 +1,67 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package channel
+
+import (
+	"testing"
+
+	"github.com/hyperledger/fabric/peer/common"
+	pb "github.com/hyperledger/fabric/protos/peer"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGenerateSnapshotMissingChannelID(t *testing.T) {
+	defer resetFlags()
+
+	resetFlags()
+
+	cmd := generateSnapshotCmd(nil)
+	AddFlags(cmd)
+	cmd.SetArgs([]string{})
+
+	assert.EqualError(t, cmd.Execute(), "Must supply channel ID")
+}
+
+func TestGenerateSnapshot(t *testing.T) {
+	defer resetFlags()
+
+	InitMSP()
+	resetFlags()
+
+	signer, err := common.GetDefaultSigner()
+	assert.NoError(t, err, "Get default signer error: %v", err)
+
+	mockResponse := &pb.ProposalResponse{
+		Response: &pb.Response{
+			Status:  200,
+			Payload: []byte("/snapshots/completed/mychannel/100"),
+		},
+		Endorsement: &pb.Endorsement{},
+	}
+	mockCF := &ChannelCmdFactory{
+		EndorserClient:   common.GetMockEndorserClient(mockResponse, nil),
+		BroadcastFactory: mockBroadcastClientFactory,
+		Signer:           signer,
+	}
+
+	client := &endorserClient{mockCF}
+	channelID = mockChannel
+	snapshotDir, err := client.generateSnapshot()
+	assert.NoError(t, err)
+	assert.Equal(t, "/snapshots/completed/mychannel/100", snapshotDir)
+
+	cmd := generateSnapshotCmd(mockCF)
+	AddFlags(cmd)
+	cmd.SetArgs([]string{"-c", mockChannel})
+	assert.NoError(t, cmd.Execute(), "expected generatesnapshot command to succeed")
+
+	mockResponse.Response = &pb.Response{Status: 500, Message: "the state database is not in sync with the block store"}
+	cmd = generateSnapshotCmd(mockCF)
+	AddFlags(cmd)
+	cmd.SetArgs([]string{"-c", mockChannel})
+	assert.EqualError(t, cmd.Execute(), "received bad response, status 500: the state database is not in sync with the block store")
+}
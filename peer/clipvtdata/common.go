@@ -0,0 +1,105 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package clipvtdata
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/common/crypto"
+	"github.com/hyperledger/fabric/peer/common"
+	common2 "github.com/hyperledger/fabric/protos/common"
+	pb "github.com/hyperledger/fabric/protos/peer"
+	"github.com/hyperledger/fabric/protos/utils"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+type envelopeWrapper func(msg proto.Message) *common2.Envelope
+
+// PvtdataCmdFactory holds the clients used by PvtdataCmd
+type PvtdataCmdFactory struct {
+	AdminClient      pb.AdminClient
+	wrapWithEnvelope envelopeWrapper
+}
+
+// InitCmdFactory init the PvtdataCmdFactory with default admin client
+func InitCmdFactory() (*PvtdataCmdFactory, error) {
+	adminClient, err := common.GetAdminClient()
+	if err != nil {
+		return nil, err
+	}
+
+	signer, err := common.GetDefaultSignerFnc()
+	if err != nil {
+		return nil, errors.Errorf("failed obtaining default signer: %v", err)
+	}
+
+	localSigner := crypto.NewSignatureHeaderCreator(signer)
+	wrapEnv := func(msg proto.Message) *common2.Envelope {
+		env, err := utils.CreateSignedEnvelope(common2.HeaderType_PEER_ADMIN_OPERATION, "", localSigner, msg, 0, 0)
+		if err != nil {
+			logger.Panicf("Failed signing: %v", err)
+		}
+		return env
+	}
+
+	return &PvtdataCmdFactory{
+		AdminClient:      adminClient,
+		wrapWithEnvelope: wrapEnv,
+	}, nil
+}
+
+// prepare checks the parameters of the command and returns the admin operation selecting the
+// channel and the range of blocks given on the command line
+func prepare(cf *PvtdataCmdFactory, cmd *cobra.Command, args []string) (*PvtdataCmdFactory, *pb.AdminOperation, error) {
+	if len(args) > 0 {
+		return nil, nil, errors.Errorf("more parameters than necessary were provided. Expected 0, received %d", len(args))
+	}
+	if channelID == "" {
+		return nil, nil, errors.New("must supply channel ID")
+	}
+	lastBlock := endBlock
+	if !cmd.Flags().Changed("endBlock") {
+		lastBlock = math.MaxUint64
+	}
+	if startBlock > lastBlock {
+		return nil, nil, errors.Errorf("start block %d is above end block %d", startBlock, lastBlock)
+	}
+	// Parsing of the command line is done so silence cmd usage
+	cmd.SilenceUsage = true
+
+	if cf == nil {
+		var err error
+		cf, err = InitCmdFactory()
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+	op := &pb.AdminOperation{
+		Content: &pb.AdminOperation_PvtDataReconciliationReq{
+			PvtDataReconciliationReq: &pb.PvtDataReconciliationRequest{
+				ChannelId:  channelID,
+				StartBlock: startBlock,
+				EndBlock:   lastBlock,
+			},
+		},
+	}
+	return cf, op, nil
+}
+
+func printResponse(out io.Writer, title string, response proto.Message) error {
+	jsonBytes, err := json.Marshal(response)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(out, "%s: %s\n", title, string(jsonBytes))
+	return nil
+}
@@ -0,0 +1,40 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package clipvtdata
+
+import (
+	"context"
+
+	"github.com/spf13/cobra"
+)
+
+func missingCmd(cf *PvtdataCmdFactory) *cobra.Command {
+	var pvtdataMissingCmd = &cobra.Command{
+		Use:   "missing",
+		Short: "Lists the missing private data.",
+		Long:  `Lists the private data of the collections the peer is eligible to that is missing in a range of blocks of a channel, by block, transaction, namespace and collection.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return missing(cf, cmd, args)
+		},
+	}
+	attachFlags(pvtdataMissingCmd, []string{"channelID", "startBlock", "endBlock"})
+
+	return pvtdataMissingCmd
+}
+
+func missing(cf *PvtdataCmdFactory, cmd *cobra.Command, args []string) error {
+	cf, op, err := prepare(cf, cmd, args)
+	if err != nil {
+		return err
+	}
+	env := cf.wrapWithEnvelope(op)
+	response, err := cf.AdminClient.GetMissingPvtData(context.Background(), env)
+	if err != nil {
+		return err
+	}
+	return printResponse(cmd.OutOrStdout(), "Missing private data", response)
+}
@@ -0,0 +1,71 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package clipvtdata
+
+import (
+	"fmt"
+
+	"github.com/hyperledger/fabric/common/flogging"
+	"github.com/hyperledger/fabric/peer/common"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+const (
+	pvtdataFuncName = "pvtdata"
+	pvtdataCmdDes   = "Private data reconciliation: missing|reconcile|status."
+)
+
+var logger = flogging.MustGetLogger("cli.pvtdata")
+
+var (
+	channelID  string
+	startBlock uint64
+	endBlock   uint64
+)
+
+// Cmd returns the cobra command for Pvtdata
+func Cmd(cf *PvtdataCmdFactory) *cobra.Command {
+	pvtdataCmd.AddCommand(missingCmd(cf))
+	pvtdataCmd.AddCommand(reconcileCmd(cf))
+	pvtdataCmd.AddCommand(statusCmd(cf))
+
+	return pvtdataCmd
+}
+
+var pvtdataCmd = &cobra.Command{
+	Use:              pvtdataFuncName,
+	Short:            fmt.Sprint(pvtdataCmdDes),
+	Long:             fmt.Sprint(pvtdataCmdDes),
+	PersistentPreRun: common.InitCmd,
+}
+
+var flags *pflag.FlagSet
+
+func init() {
+	resetFlags()
+}
+
+// resetFlags resets the values of these flags to facilitate tests
+func resetFlags() {
+	flags = &pflag.FlagSet{}
+
+	flags.StringVarP(&channelID, "channelID", "c", "", "The channel on which this command should be executed")
+	flags.Uint64VarP(&startBlock, "startBlock", "s", 0, "The first block of the range of blocks")
+	flags.Uint64VarP(&endBlock, "endBlock", "e", 0, "The last block of the range of blocks (default the most recent block)")
+}
+
+func attachFlags(cmd *cobra.Command, names []string) {
+	cmdFlags := cmd.Flags()
+	for _, name := range names {
+		if flag := flags.Lookup(name); flag != nil {
+			cmdFlags.AddFlag(flag)
+		} else {
+			logger.Fatalf("Could not find flag '%s' to attach to command '%s'", name, cmd.Name())
+		}
+	}
+}
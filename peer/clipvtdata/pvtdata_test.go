@@ -0,0 +1,123 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package clipvtdata
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/peer/common"
+	common2 "github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/utils"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+)
+
+func initPvtdataTest(command string, err error) (*cobra.Command, *bytes.Buffer) {
+	resetFlags()
+	mockCF := &PvtdataCmdFactory{
+		AdminClient: common.GetMockAdminClient(err),
+		wrapWithEnvelope: func(msg proto.Message) *common2.Envelope {
+			pl := &common2.Payload{
+				Data: utils.MarshalOrPanic(msg),
+			}
+			env := &common2.Envelope{
+				Payload: utils.MarshalOrPanic(pl),
+			}
+			return env
+		},
+	}
+	var cmd *cobra.Command
+	switch command {
+	case "missing":
+		cmd = missingCmd(mockCF)
+	case "reconcile":
+		cmd = reconcileCmd(mockCF)
+	case "status":
+		cmd = statusCmd(mockCF)
+	}
+	out := &bytes.Buffer{}
+	cmd.SetOutput(out)
+	return cmd, out
+}
+
+func TestPvtdataCmds(t *testing.T) {
+	tests := []struct {
+		name        string
+		command     string
+		args        []string
+		clientErr   error
+		expectedErr string
+		expectedOut string
+	}{
+		{
+			name:        "missing",
+			command:     "missing",
+			args:        []string{"-c", "testchannel", "--startBlock", "2", "--endBlock", "5"},
+			expectedOut: "Missing private data: {}\n",
+		},
+		{
+			name:        "reconcile",
+			command:     "reconcile",
+			args:        []string{"-c", "testchannel", "--startBlock", "2", "--endBlock", "5"},
+			expectedOut: "Private data reconciliation triggered: {\"start_block\":2,\"end_block\":5,\"in_progress\":true}\n",
+		},
+		{
+			name:        "reconcile up to the most recent block",
+			command:     "reconcile",
+			args:        []string{"-c", "testchannel", "--startBlock", "2"},
+			expectedOut: "Private data reconciliation triggered: {\"start_block\":2,\"end_block\":18446744073709551615,\"in_progress\":true}\n",
+		},
+		{
+			name:        "status",
+			command:     "status",
+			args:        []string{"-c", "testchannel"},
+			expectedOut: "Private data reconciliation status: {}\n",
+		},
+		{
+			name:        "no channel",
+			command:     "missing",
+			args:        []string{},
+			expectedErr: "must supply channel ID",
+		},
+		{
+			name:        "invalid range",
+			command:     "reconcile",
+			args:        []string{"-c", "testchannel", "--startBlock", "6", "--endBlock", "5"},
+			expectedErr: "start block 6 is above end block 5",
+		},
+		{
+			name:        "extra parameters",
+			command:     "status",
+			args:        []string{"-c", "testchannel", "extra"},
+			expectedErr: "more parameters than necessary were provided. Expected 0, received 1",
+		},
+		{
+			name:        "client error",
+			command:     "reconcile",
+			args:        []string{"-c", "testchannel"},
+			clientErr:   errors.New("already in progress"),
+			expectedErr: "already in progress",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd, out := initPvtdataTest(tt.command, tt.clientErr)
+			cmd.SetArgs(tt.args)
+			err := cmd.Execute()
+			if tt.expectedErr != "" {
+				assert.EqualError(t, err, tt.expectedErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedOut, out.String())
+		})
+	}
+}
@@ -0,0 +1,40 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package clipvtdata
+
+import (
+	"context"
+
+	"github.com/spf13/cobra"
+)
+
+func reconcileCmd(cf *PvtdataCmdFactory) *cobra.Command {
+	var pvtdataReconcileCmd = &cobra.Command{
+		Use:   "reconcile",
+		Short: "Triggers a private data reconciliation pass.",
+		Long:  `Triggers an immediate reconciliation pass over the missing private data in a range of blocks of a channel. The pass runs in the background; use the status command to follow its progress.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return reconcile(cf, cmd, args)
+		},
+	}
+	attachFlags(pvtdataReconcileCmd, []string{"channelID", "startBlock", "endBlock"})
+
+	return pvtdataReconcileCmd
+}
+
+func reconcile(cf *PvtdataCmdFactory, cmd *cobra.Command, args []string) error {
+	cf, op, err := prepare(cf, cmd, args)
+	if err != nil {
+		return err
+	}
+	env := cf.wrapWithEnvelope(op)
+	status, err := cf.AdminClient.ReconcilePvtData(context.Background(), env)
+	if err != nil {
+		return err
+	}
+	return printResponse(cmd.OutOrStdout(), "Private data reconciliation triggered", status)
+}
@@ -0,0 +1,40 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package clipvtdata
+
+import (
+	"context"
+
+	"github.com/spf13/cobra"
+)
+
+func statusCmd(cf *PvtdataCmdFactory) *cobra.Command {
+	var pvtdataStatusCmd = &cobra.Command{
+		Use:   "status",
+		Short: "Returns the progress of the private data reconciliation pass.",
+		Long:  `Returns the progress of the most recent private data reconciliation pass triggered on a channel, including the number of items each peer failed to supply.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return status(cf, cmd, args)
+		},
+	}
+	attachFlags(pvtdataStatusCmd, []string{"channelID"})
+
+	return pvtdataStatusCmd
+}
+
+func status(cf *PvtdataCmdFactory, cmd *cobra.Command, args []string) error {
+	cf, op, err := prepare(cf, cmd, args)
+	if err != nil {
+		return err
+	}
+	env := cf.wrapWithEnvelope(op)
+	status, err := cf.AdminClient.GetPvtDataReconciliationStatus(context.Background(), env)
+	if err != nil {
+		return err
+	}
+	return printResponse(cmd.OutOrStdout(), "Private data reconciliation status", status)
+}
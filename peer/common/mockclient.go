@@ -113,3 +113,65 @@ func (m *mockAdminClient) SetLogSpec(ctx context.Context, in *cb.Envelope, opts
 	response := &pb.LogSpecResponse{LogSpec: "info"}
 	return response, m.err
 }
+
+func (m *mockAdminClient) GetTransientEntries(ctx context.Context, in *cb.Envelope, opts ...grpc.CallOption) (*pb.TransientEntriesResponse, error) {
+	return &pb.TransientEntriesResponse{}, m.err
+}
+
+func (m *mockAdminClient) PurgeTransientEntries(ctx context.Context, in *cb.Envelope, opts ...grpc.CallOption) (*pb.TransientEntriesResponse, error) {
+	return &pb.TransientEntriesResponse{}, m.err
+}
+
+func (m *mockAdminClient) GetMissingPvtData(ctx context.Context, in *cb.Envelope, opts ...grpc.CallOption) (*pb.MissingPvtDataResponse, error) {
+	return &pb.MissingPvtDataResponse{}, m.err
+}
+
+func (m *mockAdminClient) ReconcilePvtData(ctx context.Context, env *cb.Envelope, opts ...grpc.CallOption) (*pb.PvtDataReconciliationStatus, error) {
+	op := &pb.AdminOperation{}
+	pl := &cb.Payload{}
+	proto.Unmarshal(env.Payload, pl)
+	proto.Unmarshal(pl.Data, op)
+	response := &pb.PvtDataReconciliationStatus{
+		StartBlock: op.GetPvtDataReconciliationReq().GetStartBlock(),
+		EndBlock:   op.GetPvtDataReconciliationReq().GetEndBlock(),
+		InProgress: true,
+	}
+	return response, m.err
+}
+
+func (m *mockAdminClient) GetPvtDataReconciliationStatus(ctx context.Context, in *cb.Envelope, opts ...grpc.CallOption) (*pb.PvtDataReconciliationStatus, error) {
+	return &pb.PvtDataReconciliationStatus{}, m.err
+}
+
+func (m *mockAdminClient) ListChaincodeIndexes(ctx context.Context, env *cb.Envelope, opts ...grpc.CallOption) (*pb.ChaincodeIndexesResponse, error) {
+	op := &pb.AdminOperation{}
+	pl := &cb.Payload{}
+	proto.Unmarshal(env.Payload, pl)
+	proto.Unmarshal(pl.Data, op)
+	response := &pb.ChaincodeIndexesResponse{
+		Indexes: []*pb.ChaincodeIndex{
+			{Chaincode: op.GetChaincodeIndexReq().GetChaincode(), DesignDoc: "indexOwnerDoc", Name: "indexOwner"},
+		},
+	}
+	return response, m.err
+}
+
+func (m *mockAdminClient) DropChaincodeIndex(ctx context.Context, in *cb.Envelope, opts ...grpc.CallOption) (*empty.Empty, error) {
+	return &empty.Empty{}, m.err
+}
+
+func (m *mockAdminClient) RebuildChaincodeIndex(ctx context.Context, env *cb.Envelope, opts ...grpc.CallOption) (*pb.ChaincodeIndex, error) {
+	op := &pb.AdminOperation{}
+	pl := &cb.Payload{}
+	proto.Unmarshal(env.Payload, pl)
+	proto.Unmarshal(pl.Data, op)
+	request := op.GetChaincodeIndexReq()
+	response := &pb.ChaincodeIndex{
+		Chaincode:  request.GetChaincode(),
+		Collection: request.GetCollection(),
+		DesignDoc:  "indexOwnerDoc",
+		Name:       "indexOwner",
+		Definition: string(request.GetIndexDefinition()),
+	}
+	return response, m.err
+}
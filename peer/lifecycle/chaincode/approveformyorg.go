@@ -0,0 +1,78 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package chaincode
+
+import (
+	"fmt"
+
+	"github.com/hyperledger/fabric/core/chaincode/lifecycle"
+	lb "github.com/hyperledger/fabric/protos/peer/lifecycle"
+	"github.com/spf13/cobra"
+)
+
+const approveForMyOrgDesc = "Approve a chaincode definition for the org of the peers on a channel."
+
+// approveForMyOrgCmd returns the cobra command for approving a chaincode definition
+func approveForMyOrgCmd(cf *CmdFactory) *cobra.Command {
+	chaincodeApproveForMyOrgCmd := &cobra.Command{
+		Use:   "approveformyorg",
+		Short: approveForMyOrgDesc,
+		Long:  approveForMyOrgDesc,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return approveForMyOrg(cmd, args, cf)
+		},
+	}
+	attachFlags(chaincodeApproveForMyOrgCmd, append(definitionFlags, "package-id"))
+
+	return chaincodeApproveForMyOrgCmd
+}
+
+// definitionFlags are the flags of the commands which take a chaincode definition
+var definitionFlags = []string{
+	"channelID",
+	"name",
+	"version",
+	"sequence",
+	"escc",
+	"vscc",
+	"policy",
+	"collections-config",
+	"peerAddresses",
+	"tlsRootCertFiles",
+	"connectionProfile",
+	"waitForEvent",
+	"waitForEventTimeout",
+}
+
+// approveForMyOrg submits the approval of the chaincode definition by the org of the peers,
+// along with the package ID of the chaincode install package the org runs for it, if any
+func approveForMyOrg(cmd *cobra.Command, args []string, cf *CmdFactory) error {
+	if err := checkNoArgs(args); err != nil {
+		return err
+	}
+	cd, err := getChaincodeDefinition()
+	if err != nil {
+		return err
+	}
+
+	cf, err = initCmdFactory(cmd, cf, true, true)
+	if err != nil {
+		return err
+	}
+
+	err = submit(cf, channelID, lifecycle.ApproveChaincodeDefinitionForMyOrgFuncName, &lb.ApproveChaincodeDefinitionForMyOrgArgs{
+		Name:       chaincodeName,
+		Definition: cd,
+		PackageId:  packageID,
+	})
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(cmd.OutOrStdout(), "Approved definition of chaincode %s at sequence %d on channel %s\n", chaincodeName, sequence, channelID)
+
+	return nil
+}
@@ -0,0 +1,148 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package chaincode
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/hyperledger/fabric/common/flogging"
+	"github.com/hyperledger/fabric/core/chaincode/platforms"
+	"github.com/hyperledger/fabric/core/chaincode/platforms/car"
+	"github.com/hyperledger/fabric/core/chaincode/platforms/golang"
+	"github.com/hyperledger/fabric/core/chaincode/platforms/java"
+	"github.com/hyperledger/fabric/core/chaincode/platforms/node"
+	"github.com/hyperledger/fabric/peer/common"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+const (
+	lifecycleName = "+lifecycle"
+	chainFuncName = "chaincode"
+	chainCmdDes   = "Perform chaincode operations: package|install|queryinstalled|getinstalledpackage|approveformyorg|checkcommitreadiness|commit|querycommitted."
+)
+
+var logger = flogging.MustGetLogger("lifecycleChaincodeCmd")
+
+// XXX This is a terrible singleton hack, however
+// it simply making a latent dependency explicit.
+// It should be removed along with the other package
+// scoped variables
+var platformRegistry = platforms.NewRegistry(
+	&golang.Platform{},
+	&car.Platform{},
+	&java.Platform{},
+	&node.Platform{},
+)
+
+// Cmd returns the cobra command for the chaincode operations of the lifecycle SCC
+func Cmd(cf *CmdFactory) *cobra.Command {
+	common.AddOrdererFlags(chaincodeCmd)
+
+	chaincodeCmd.AddCommand(packageCmd(cf))
+	chaincodeCmd.AddCommand(installCmd(cf))
+	chaincodeCmd.AddCommand(queryInstalledCmd(cf))
+	chaincodeCmd.AddCommand(getInstalledPackageCmd(cf))
+	chaincodeCmd.AddCommand(approveForMyOrgCmd(cf))
+	chaincodeCmd.AddCommand(checkCommitReadinessCmd(cf))
+	chaincodeCmd.AddCommand(commitCmd(cf))
+	chaincodeCmd.AddCommand(queryCommittedCmd(cf))
+
+	return chaincodeCmd
+}
+
+// Chaincode-related variables.
+var (
+	chaincodeLang         string
+	chaincodePath         string
+	chaincodeName         string
+	chaincodeVersion      string
+	channelID             string
+	sequence              int64
+	signaturePolicy       string
+	endorsementPlugin     string
+	validationPlugin      string
+	collectionsConfigFile string
+	peerAddresses         []string
+	tlsRootCertFiles      []string
+	connectionProfile     string
+	waitForEvent          bool
+	waitForEventTimeout   time.Duration
+	outputDirectory       string
+	label                 string
+	packageID             string
+)
+
+var chaincodeCmd = &cobra.Command{
+	Use:   chainFuncName,
+	Short: fmt.Sprint(chainCmdDes),
+	Long:  fmt.Sprint(chainCmdDes),
+	PersistentPreRun: func(cmd *cobra.Command, args []string) {
+		common.InitCmd(cmd, args)
+		common.SetOrdererEnv(cmd, args)
+	},
+}
+
+var flags *pflag.FlagSet
+
+func init() {
+	resetFlags()
+}
+
+// Explicitly define a method to facilitate tests
+func resetFlags() {
+	flags = &pflag.FlagSet{}
+
+	flags.StringVarP(&chaincodeLang, "lang", "l", "golang",
+		fmt.Sprintf("Language the %s is written in", chainFuncName))
+	flags.StringVarP(&chaincodePath, "path", "p", common.UndefinedParamValue,
+		fmt.Sprintf("Path to %s", chainFuncName))
+	flags.StringVarP(&chaincodeName, "name", "n", common.UndefinedParamValue,
+		fmt.Sprint("Name of the chaincode"))
+	flags.StringVarP(&chaincodeVersion, "version", "v", common.UndefinedParamValue,
+		fmt.Sprint("Version of the chaincode"))
+	flags.StringVarP(&channelID, "channelID", "C", "",
+		fmt.Sprint("The channel on which this command should be executed"))
+	flags.Int64VarP(&sequence, "sequence", "", 0,
+		fmt.Sprint("The sequence number of the chaincode definition for the channel"))
+	flags.StringVarP(&signaturePolicy, "policy", "P", common.UndefinedParamValue,
+		fmt.Sprint("The endorsement policy associated to this chaincode"))
+	flags.StringVarP(&endorsementPlugin, "escc", "E", common.UndefinedParamValue,
+		fmt.Sprint("The name of the endorsement plugin to be used for this chaincode"))
+	flags.StringVarP(&validationPlugin, "vscc", "V", common.UndefinedParamValue,
+		fmt.Sprint("The name of the validation plugin to be used for this chaincode"))
+	flags.StringVar(&collectionsConfigFile, "collections-config", common.UndefinedParamValue,
+		fmt.Sprint("The fully qualified path to the collection JSON file including the file name"))
+	flags.StringArrayVarP(&peerAddresses, "peerAddresses", "", []string{common.UndefinedParamValue},
+		fmt.Sprint("The addresses of the peers to connect to"))
+	flags.StringArrayVarP(&tlsRootCertFiles, "tlsRootCertFiles", "", []string{common.UndefinedParamValue},
+		fmt.Sprint("If TLS is enabled, the paths to the TLS root cert files of the peers to connect to. The order and number of certs specified should match the --peerAddresses flag"))
+	flags.StringVarP(&connectionProfile, "connectionProfile", "", common.UndefinedParamValue,
+		fmt.Sprint("Connection profile that provides the necessary connection information for the network. Note: currently only supported for providing peer connection information"))
+	flags.BoolVar(&waitForEvent, "waitForEvent", true,
+		fmt.Sprint("Whether to wait for the event from each peer's deliver filtered service signifying that the transaction has been committed successfully"))
+	flags.DurationVar(&waitForEventTimeout, "waitForEventTimeout", 30*time.Second,
+		fmt.Sprint("Time to wait for the event from each peer's deliver filtered service signifying that the transaction has been committed successfully"))
+	flags.StringVarP(&outputDirectory, "output-directory", "", "",
+		fmt.Sprint("The output directory to use when writing a chaincode install package to disk. Default is the current working directory"))
+	flags.StringVarP(&label, "label", "", "",
+		fmt.Sprint("The package label contains a human-readable description of the package"))
+	flags.StringVarP(&packageID, "package-id", "", "",
+		fmt.Sprint("The identifier of the chaincode install package"))
+}
+
+func attachFlags(cmd *cobra.Command, names []string) {
+	cmdFlags := cmd.Flags()
+	for _, name := range names {
+		if flag := flags.Lookup(name); flag != nil {
+			cmdFlags.AddFlag(flag)
+		} else {
+			logger.Fatalf("Could not find flag '%s' to attach to command '%s'", name, cmd.Name())
+		}
+	}
+}
@@ -0,0 +1,78 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package chaincode
+
+import (
+	"fmt"
+
+	"github.com/hyperledger/fabric/core/chaincode/lifecycle"
+	lb "github.com/hyperledger/fabric/protos/peer/lifecycle"
+	"github.com/spf13/cobra"
+)
+
+const checkCommitReadinessDesc = "Check which orgs of a channel approved a chaincode definition."
+
+// checkCommitReadinessCmd returns the cobra command for checking the approvals of a chaincode definition
+func checkCommitReadinessCmd(cf *CmdFactory) *cobra.Command {
+	chaincodeCheckCommitReadinessCmd := &cobra.Command{
+		Use:   "checkcommitreadiness",
+		Short: checkCommitReadinessDesc,
+		Long:  checkCommitReadinessDesc,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return checkCommitReadiness(cmd, args, cf)
+		},
+	}
+	flagList := []string{
+		"channelID",
+		"name",
+		"version",
+		"sequence",
+		"escc",
+		"vscc",
+		"policy",
+		"collections-config",
+		"peerAddresses",
+		"tlsRootCertFiles",
+		"connectionProfile",
+	}
+	attachFlags(chaincodeCheckCommitReadinessCmd, flagList)
+
+	return chaincodeCheckCommitReadinessCmd
+}
+
+// checkCommitReadiness prints the approval of the chaincode definition by each org of the channel
+func checkCommitReadiness(cmd *cobra.Command, args []string, cf *CmdFactory) error {
+	if err := checkNoArgs(args); err != nil {
+		return err
+	}
+	cd, err := getChaincodeDefinition()
+	if err != nil {
+		return err
+	}
+
+	cf, err = initCmdFactory(cmd, cf, false, false)
+	if err != nil {
+		return err
+	}
+
+	result := &lb.CheckCommitReadinessResult{}
+	err = evaluate(cf, channelID, lifecycle.CheckCommitReadinessFuncName, &lb.CheckCommitReadinessArgs{
+		Name:       chaincodeName,
+		Definition: cd,
+	}, result)
+	if err != nil {
+		return err
+	}
+
+	out := cmd.OutOrStdout()
+	fmt.Fprintf(out, "Chaincode definition for chaincode '%s', version '%s', sequence '%d' on channel '%s' approval status by org:\n", chaincodeName, chaincodeVersion, sequence, channelID)
+	for _, approval := range result.Approvals {
+		fmt.Fprintf(out, "%s: %t\n", approval.MspId, approval.Approved)
+	}
+
+	return nil
+}
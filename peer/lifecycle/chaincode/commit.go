@@ -0,0 +1,59 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package chaincode
+
+import (
+	"fmt"
+
+	"github.com/hyperledger/fabric/core/chaincode/lifecycle"
+	lb "github.com/hyperledger/fabric/protos/peer/lifecycle"
+	"github.com/spf13/cobra"
+)
+
+const commitDesc = "Commit a chaincode definition approved by enough orgs to a channel."
+
+// commitCmd returns the cobra command for committing a chaincode definition
+func commitCmd(cf *CmdFactory) *cobra.Command {
+	chaincodeCommitCmd := &cobra.Command{
+		Use:   "commit",
+		Short: commitDesc,
+		Long:  commitDesc,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return commit(cmd, args, cf)
+		},
+	}
+	attachFlags(chaincodeCommitCmd, definitionFlags)
+
+	return chaincodeCommitCmd
+}
+
+// commit submits the commit of the chaincode definition, endorsed by the peers, to the channel
+func commit(cmd *cobra.Command, args []string, cf *CmdFactory) error {
+	if err := checkNoArgs(args); err != nil {
+		return err
+	}
+	cd, err := getChaincodeDefinition()
+	if err != nil {
+		return err
+	}
+
+	cf, err = initCmdFactory(cmd, cf, true, true)
+	if err != nil {
+		return err
+	}
+
+	err = submit(cf, channelID, lifecycle.CommitChaincodeDefinitionFuncName, &lb.CommitChaincodeDefinitionArgs{
+		Name:       chaincodeName,
+		Definition: cd,
+	})
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(cmd.OutOrStdout(), "Committed definition of chaincode %s at sequence %d on channel %s\n", chaincodeName, sequence, channelID)
+
+	return nil
+}
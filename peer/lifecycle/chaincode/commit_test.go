@@ -0,0 +1,157 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package chaincode
+
+import (
+	"errors"
+	"testing"
+
+	lb "github.com/hyperledger/fabric/protos/peer/lifecycle"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestApproveForMyOrgAndCommit(t *testing.T) {
+	tests := []struct {
+		name     string
+		cmd      func(cf *CmdFactory) *cobra.Command
+		funcName string
+		args     func() (*lb.ChaincodeDefinition, string)
+		out      string
+	}{
+		{
+			name:     "approveformyorg",
+			cmd:      approveForMyOrgCmd,
+			funcName: "ApproveChaincodeDefinitionForMyOrg",
+			out:      "Approved definition of chaincode mycc at sequence 1 on channel mychannel\n",
+		},
+		{
+			name:     "commit",
+			cmd:      commitCmd,
+			funcName: "CommitChaincodeDefinition",
+			out:      "Committed definition of chaincode mycc at sequence 1 on channel mychannel\n",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			resetFlags()
+			endorser0, endorser1 := &fakeEndorser{}, &fakeEndorser{}
+			cf, bc := newCmdFactory(t, endorser0, endorser1)
+			out, err := executeCmd(test.cmd(cf), "-C", "mychannel", "-n", "mycc", "-v", "1.0", "--sequence", "1",
+				"--peerAddresses", "peer0:7051", "--peerAddresses", "peer1:7051", "--waitForEvent=false")
+			require.NoError(t, err)
+			assert.Equal(t, test.out, out)
+
+			for _, endorser := range []*fakeEndorser{endorser0, endorser1} {
+				var name string
+				var cd *lb.ChaincodeDefinition
+				var channel, funcName string
+				if test.funcName == "CommitChaincodeDefinition" {
+					args := &lb.CommitChaincodeDefinitionArgs{}
+					channel, funcName = endorser.invocation(t, 0, args)
+					name, cd = args.Name, args.Definition
+				} else {
+					args := &lb.ApproveChaincodeDefinitionForMyOrgArgs{}
+					channel, funcName = endorser.invocation(t, 0, args)
+					name, cd = args.Name, args.Definition
+				}
+				assert.Equal(t, "mychannel", channel)
+				assert.Equal(t, test.funcName, funcName)
+				assert.Equal(t, "mycc", name)
+				assert.Equal(t, int64(1), cd.Sequence)
+				assert.Equal(t, "1.0", cd.Version)
+			}
+			assert.Len(t, bc.envelopes, 1)
+
+			// the transaction is not sent when an endorsement fails
+			resetFlags()
+			endorser1.status, endorser1.message = 500, "not approved"
+			cf, bc = newCmdFactory(t, &fakeEndorser{}, endorser1)
+			_, err = executeCmd(test.cmd(cf), "-C", "mychannel", "-n", "mycc", "-v", "1.0", "--sequence", "1", "--waitForEvent=false")
+			assert.EqualError(t, err, "proposal failed with status: 500 - not approved")
+			assert.Empty(t, bc.envelopes)
+
+			// the error of the orderer is returned
+			resetFlags()
+			cf, bc = newCmdFactory(t, &fakeEndorser{})
+			bc.err = errors.New("service unavailable")
+			_, err = executeCmd(test.cmd(cf), "-C", "mychannel", "-n", "mycc", "-v", "1.0", "--sequence", "1", "--waitForEvent=false")
+			assert.EqualError(t, err, "error sending transaction for "+test.funcName+": service unavailable")
+
+			resetFlags()
+			_, err = executeCmd(test.cmd(cf), "-C", "mychannel", "-n", "mycc", "-v", "1.0")
+			assert.EqualError(t, err, "must supply a positive value for the sequence parameter")
+		})
+	}
+}
+
+func TestCheckCommitReadiness(t *testing.T) {
+	resetFlags()
+	endorser := &fakeEndorser{result: &lb.CheckCommitReadinessResult{
+		Approvals: []*lb.OrgApproval{
+			{MspId: "Org1MSP", Approved: true},
+			{MspId: "Org2MSP", Approved: false},
+		},
+	}}
+	cf, _ := newCmdFactory(t, endorser)
+	out, err := executeCmd(checkCommitReadinessCmd(cf), "-C", "mychannel", "-n", "mycc", "-v", "1.0", "--sequence", "1")
+	require.NoError(t, err)
+	assert.Equal(t, "Chaincode definition for chaincode 'mycc', version '1.0', sequence '1' on channel 'mychannel' approval status by org:\nOrg1MSP: true\nOrg2MSP: false\n", out)
+
+	args := &lb.CheckCommitReadinessArgs{}
+	channel, funcName := endorser.invocation(t, 0, args)
+	assert.Equal(t, "mychannel", channel)
+	assert.Equal(t, "CheckCommitReadiness", funcName)
+	assert.Equal(t, "mycc", args.Name)
+	assert.Equal(t, int64(1), args.Definition.Sequence)
+}
+
+func TestApproveForMyOrgPackageID(t *testing.T) {
+	resetFlags()
+	endorser := &fakeEndorser{}
+	cf, _ := newCmdFactory(t, endorser)
+	_, err := executeCmd(approveForMyOrgCmd(cf), "-C", "mychannel", "-n", "mycc", "-v", "1.0", "--sequence", "1",
+		"--package-id", "mycc_1:hash", "--waitForEvent=false")
+	require.NoError(t, err)
+
+	args := &lb.ApproveChaincodeDefinitionForMyOrgArgs{}
+	endorser.invocation(t, 0, args)
+	assert.Equal(t, "mycc_1:hash", args.PackageId)
+}
+
+func TestQueryCommitted(t *testing.T) {
+	resetFlags()
+	endorser := &fakeEndorser{result: &lb.QueryChaincodeDefinitionResult{
+		Definition: &lb.ChaincodeDefinition{Sequence: 3, Version: "2.0", EndorsementPlugin: "escc", ValidationPlugin: "vscc"},
+	}}
+	cf, _ := newCmdFactory(t, endorser)
+	out, err := executeCmd(queryCommittedCmd(cf), "-C", "mychannel", "-n", "mycc")
+	require.NoError(t, err)
+	assert.Equal(t, "Committed chaincode definition for chaincode 'mycc' on channel 'mychannel':\nVersion: 2.0, Sequence: 3, Endorsement Plugin: escc, Validation Plugin: vscc\n", out)
+
+	args := &lb.QueryChaincodeDefinitionArgs{}
+	channel, funcName := endorser.invocation(t, 0, args)
+	assert.Equal(t, "mychannel", channel)
+	assert.Equal(t, "QueryChaincodeDefinition", funcName)
+	assert.Equal(t, "mycc", args.Name)
+
+	resetFlags()
+	endorser = &fakeEndorser{result: &lb.QueryChaincodeDefinitionResult{
+		Definition: &lb.ChaincodeDefinition{Sequence: 3, Version: "2.0", EndorsementPlugin: "escc", ValidationPlugin: "vscc"},
+		PackageId:  "mycc_2:hash",
+	}}
+	cf, _ = newCmdFactory(t, endorser)
+	out, err = executeCmd(queryCommittedCmd(cf), "-C", "mychannel", "-n", "mycc")
+	require.NoError(t, err)
+	assert.Equal(t, "Committed chaincode definition for chaincode 'mycc' on channel 'mychannel':\nVersion: 2.0, Sequence: 3, Endorsement Plugin: escc, Validation Plugin: vscc\nPackage ID approved by this org: mycc_2:hash\n", out)
+
+	resetFlags()
+	_, err = executeCmd(queryCommittedCmd(cf), "-n", "mycc")
+	assert.EqualError(t, err, "The required parameter 'channelID' is empty. Rerun the command with -C flag")
+}
@@ -0,0 +1,348 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package chaincode
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/common/cauthdsl"
+	"github.com/hyperledger/fabric/core/chaincode/shim"
+	"github.com/hyperledger/fabric/msp"
+	"github.com/hyperledger/fabric/peer/chaincode"
+	"github.com/hyperledger/fabric/peer/common"
+	"github.com/hyperledger/fabric/peer/common/api"
+	cb "github.com/hyperledger/fabric/protos/common"
+	pb "github.com/hyperledger/fabric/protos/peer"
+	lb "github.com/hyperledger/fabric/protos/peer/lifecycle"
+	"github.com/hyperledger/fabric/protos/utils"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// CmdFactory holds the clients used by the lifecycle chaincode commands
+type CmdFactory struct {
+	EndorserClients []pb.EndorserClient
+	DeliverClients  []api.PeerDeliverClient
+	Certificate     tls.Certificate
+	Signer          msp.SigningIdentity
+	BroadcastClient common.BroadcastClient
+}
+
+// InitCmdFactory init the CmdFactory with default clients. The endorser clients
+// connect to the peers given by --peerAddresses or by the connection profile, and
+// several peers are only supported by the commands which submit a transaction
+func InitCmdFactory(cmdName string, isEndorserRequired, isOrdererRequired, multiplePeers bool) (*CmdFactory, error) {
+	var endorserClients []pb.EndorserClient
+	var deliverClients []api.PeerDeliverClient
+	if isEndorserRequired {
+		if err := validatePeerConnectionParameters(cmdName, multiplePeers); err != nil {
+			return nil, errors.WithMessage(err, "error validating peer connection parameters")
+		}
+		for i, address := range peerAddresses {
+			var tlsRootCertFile string
+			if tlsRootCertFiles != nil {
+				tlsRootCertFile = tlsRootCertFiles[i]
+			}
+			endorserClient, err := common.GetEndorserClientFnc(address, tlsRootCertFile)
+			if err != nil {
+				return nil, errors.WithMessage(err, fmt.Sprintf("error getting endorser client for %s", cmdName))
+			}
+			endorserClients = append(endorserClients, endorserClient)
+			deliverClient, err := common.GetPeerDeliverClientFnc(address, tlsRootCertFile)
+			if err != nil {
+				return nil, errors.WithMessage(err, fmt.Sprintf("error getting deliver client for %s", cmdName))
+			}
+			deliverClients = append(deliverClients, deliverClient)
+		}
+		if len(endorserClients) == 0 {
+			return nil, errors.New("no endorser clients retrieved - this might indicate a bug")
+		}
+	}
+
+	certificate, err := common.GetCertificateFnc()
+	if err != nil {
+		return nil, errors.WithMessage(err, "error getting client certificate")
+	}
+
+	signer, err := common.GetDefaultSignerFnc()
+	if err != nil {
+		return nil, errors.WithMessage(err, "error getting default signer")
+	}
+
+	var broadcastClient common.BroadcastClient
+	if isOrdererRequired {
+		if len(common.OrderingEndpoint) == 0 {
+			if len(endorserClients) == 0 {
+				return nil, errors.New("orderer is required, but no ordering endpoint or endorser client supplied")
+			}
+			orderingEndpoints, err := common.GetOrdererEndpointOfChainFnc(channelID, signer, endorserClients[0])
+			if err != nil {
+				return nil, errors.WithMessage(err, fmt.Sprintf("error getting channel (%s) orderer endpoint", channelID))
+			}
+			if len(orderingEndpoints) == 0 {
+				return nil, errors.Errorf("no orderer endpoints retrieved for channel %s", channelID)
+			}
+			logger.Infof("Retrieved channel (%s) orderer endpoint: %s", channelID, orderingEndpoints[0])
+			// override viper env
+			viper.Set("orderer.address", orderingEndpoints[0])
+		}
+
+		broadcastClient, err = common.GetBroadcastClientFnc()
+		if err != nil {
+			return nil, errors.WithMessage(err, "error getting broadcast client")
+		}
+	}
+
+	return &CmdFactory{
+		EndorserClients: endorserClients,
+		DeliverClients:  deliverClients,
+		Certificate:     certificate,
+		Signer:          signer,
+		BroadcastClient: broadcastClient,
+	}, nil
+}
+
+func validatePeerConnectionParameters(cmdName string, multiplePeers bool) error {
+	if connectionProfile != common.UndefinedParamValue {
+		networkConfig, err := common.GetConfig(connectionProfile)
+		if err != nil {
+			return err
+		}
+		if len(networkConfig.Channels[channelID].Peers) != 0 {
+			peerAddresses = []string{}
+			tlsRootCertFiles = []string{}
+			for peer, peerChannelConfig := range networkConfig.Channels[channelID].Peers {
+				if peerChannelConfig.EndorsingPeer {
+					peerConfig, ok := networkConfig.Peers[peer]
+					if !ok {
+						return errors.Errorf("peer '%s' is defined in the channel config but doesn't have associated peer config", peer)
+					}
+					peerAddresses = append(peerAddresses, peerConfig.URL)
+					tlsRootCertFiles = append(tlsRootCertFiles, peerConfig.TLSCACerts.Path)
+				}
+			}
+		}
+	}
+
+	if !multiplePeers && len(peerAddresses) > 1 {
+		return errors.Errorf("'%s' command can only be executed against one peer. received %d", cmdName, len(peerAddresses))
+	}
+
+	if len(tlsRootCertFiles) > len(peerAddresses) {
+		logger.Warningf("received more TLS root cert files (%d) than peer addresses (%d)", len(tlsRootCertFiles), len(peerAddresses))
+	}
+
+	if viper.GetBool("peer.tls.enabled") {
+		if len(tlsRootCertFiles) != len(peerAddresses) {
+			return errors.Errorf("number of peer addresses (%d) does not match the number of TLS root cert files (%d)", len(peerAddresses), len(tlsRootCertFiles))
+		}
+	} else {
+		tlsRootCertFiles = nil
+	}
+
+	return nil
+}
+
+// initCmdFactory returns the given factory, which is only set by tests, or
+// parses the command line and creates a factory with the default clients
+func initCmdFactory(cmd *cobra.Command, cf *CmdFactory, isOrdererRequired, multiplePeers bool) (*CmdFactory, error) {
+	// Parsing of the command line is done so silence cmd usage
+	cmd.SilenceUsage = true
+
+	if cf != nil {
+		return cf, nil
+	}
+	return InitCmdFactory(cmd.Name(), true, isOrdererRequired, multiplePeers)
+}
+
+// createProposal creates a signed proposal invoking a function of the lifecycle
+// SCC with the marshaled args
+func createProposal(channelID, funcName string, args proto.Message, signer msp.SigningIdentity) (*pb.Proposal, *pb.SignedProposal, string, error) {
+	argsBytes, err := proto.Marshal(args)
+	if err != nil {
+		return nil, nil, "", errors.Wrap(err, "error marshaling args")
+	}
+
+	cis := &pb.ChaincodeInvocationSpec{
+		ChaincodeSpec: &pb.ChaincodeSpec{
+			ChaincodeId: &pb.ChaincodeID{Name: lifecycleName},
+			Input:       &pb.ChaincodeInput{Args: [][]byte{[]byte(funcName), argsBytes}},
+		},
+	}
+
+	creator, err := signer.Serialize()
+	if err != nil {
+		return nil, nil, "", errors.WithMessage(err, fmt.Sprintf("error serializing identity for %s", signer.GetIdentifier()))
+	}
+
+	prop, txID, err := utils.CreateChaincodeProposalWithTxIDAndTransient(cb.HeaderType_ENDORSER_TRANSACTION, channelID, cis, creator, "", nil)
+	if err != nil {
+		return nil, nil, "", errors.WithMessage(err, fmt.Sprintf("error creating proposal for %s", funcName))
+	}
+
+	signedProp, err := utils.GetSignedProposal(prop, signer)
+	if err != nil {
+		return nil, nil, "", errors.WithMessage(err, fmt.Sprintf("error creating signed proposal for %s", funcName))
+	}
+
+	return prop, signedProp, txID, nil
+}
+
+// evaluate sends a proposal invoking a function of the lifecycle SCC to the
+// first peer and unmarshals the result of the function
+func evaluate(cf *CmdFactory, channelID, funcName string, args, result proto.Message) error {
+	_, signedProp, _, err := createProposal(channelID, funcName, args, cf.Signer)
+	if err != nil {
+		return err
+	}
+
+	proposalResponse, err := cf.EndorserClients[0].ProcessProposal(context.Background(), signedProp)
+	if err != nil {
+		return errors.WithMessage(err, fmt.Sprintf("error endorsing %s", funcName))
+	}
+	if err := checkProposalResponse(proposalResponse); err != nil {
+		return err
+	}
+
+	err = proto.Unmarshal(proposalResponse.Response.Payload, result)
+	if err != nil {
+		return errors.Wrapf(err, "failed to unmarshal proposal response's response payload as %T", result)
+	}
+
+	return nil
+}
+
+// submit sends a proposal invoking a function of the lifecycle SCC to all the
+// peers, sends the transaction assembled from the endorsements to the orderer
+// and, with --waitForEvent, waits for the peers to commit the transaction
+func submit(cf *CmdFactory, channelID, funcName string, args proto.Message) error {
+	prop, signedProp, txID, err := createProposal(channelID, funcName, args, cf.Signer)
+	if err != nil {
+		return err
+	}
+
+	var responses []*pb.ProposalResponse
+	for _, endorser := range cf.EndorserClients {
+		proposalResponse, err := endorser.ProcessProposal(context.Background(), signedProp)
+		if err != nil {
+			return errors.WithMessage(err, fmt.Sprintf("error endorsing %s", funcName))
+		}
+		if err := checkProposalResponse(proposalResponse); err != nil {
+			return err
+		}
+		responses = append(responses, proposalResponse)
+	}
+
+	env, err := utils.CreateSignedTx(prop, cf.Signer, responses...)
+	if err != nil {
+		return errors.WithMessage(err, "could not assemble transaction")
+	}
+
+	var dg *chaincode.DeliverGroup
+	var ctx context.Context
+	if waitForEvent {
+		var cancelFunc context.CancelFunc
+		ctx, cancelFunc = context.WithTimeout(context.Background(), waitForEventTimeout)
+		defer cancelFunc()
+
+		dg = chaincode.NewDeliverGroup(cf.DeliverClients, peerAddresses, cf.Certificate, channelID, txID)
+		// connect to deliver service on all peers
+		err := dg.Connect(ctx)
+		if err != nil {
+			return err
+		}
+	}
+
+	if err = cf.BroadcastClient.Send(env); err != nil {
+		return errors.WithMessage(err, fmt.Sprintf("error sending transaction for %s", funcName))
+	}
+
+	if dg != nil && ctx != nil {
+		// wait for event that contains the txid from all peers
+		err = dg.Wait(ctx)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func checkProposalResponse(proposalResponse *pb.ProposalResponse) error {
+	if proposalResponse == nil {
+		return errors.New("received nil proposal response")
+	}
+	if proposalResponse.Response == nil {
+		return errors.New("received proposal response with nil response")
+	}
+	if proposalResponse.Response.Status >= shim.ERRORTHRESHOLD {
+		return errors.Errorf("proposal failed with status: %d - %s", proposalResponse.Response.Status, proposalResponse.Response.Message)
+	}
+	return nil
+}
+
+// getChaincodeDefinition builds the chaincode definition given on the command line
+func getChaincodeDefinition() (*lb.ChaincodeDefinition, error) {
+	if chaincodeName == common.UndefinedParamValue {
+		return nil, errors.Errorf("must supply value for %s name parameter", chainFuncName)
+	}
+	if chaincodeVersion == common.UndefinedParamValue {
+		return nil, errors.Errorf("must supply value for %s version parameter", chainFuncName)
+	}
+	if sequence <= 0 {
+		return nil, errors.New("must supply a positive value for the sequence parameter")
+	}
+	if channelID == "" {
+		return nil, errors.New("The required parameter 'channelID' is empty. Rerun the command with -C flag")
+	}
+
+	cd := &lb.ChaincodeDefinition{
+		Sequence:          sequence,
+		Version:           chaincodeVersion,
+		EndorsementPlugin: "escc",
+		ValidationPlugin:  "vscc",
+	}
+	if endorsementPlugin != common.UndefinedParamValue {
+		cd.EndorsementPlugin = endorsementPlugin
+	}
+	if validationPlugin != common.UndefinedParamValue {
+		cd.ValidationPlugin = validationPlugin
+	}
+
+	if signaturePolicy != common.UndefinedParamValue {
+		p, err := cauthdsl.FromString(signaturePolicy)
+		if err != nil {
+			return nil, errors.Errorf("invalid policy %s", signaturePolicy)
+		}
+		cd.ValidationParameter = utils.MarshalOrPanic(p)
+	}
+
+	if collectionsConfigFile != common.UndefinedParamValue {
+		collectionConfigBytes, err := chaincode.GetCollectionConfigFromFile(collectionsConfigFile)
+		if err != nil {
+			return nil, errors.WithMessage(err, fmt.Sprintf("invalid collection configuration in file %s", collectionsConfigFile))
+		}
+		cd.Collections = &cb.CollectionConfigPackage{}
+		if err := proto.Unmarshal(collectionConfigBytes, cd.Collections); err != nil {
+			return nil, errors.Wrap(err, "could not unmarshal collection configuration")
+		}
+	}
+
+	return cd, nil
+}
+
+// checkNoArgs returns an error when positional parameters are given
+func checkNoArgs(args []string) error {
+	if len(args) > 0 {
+		return errors.Errorf("more parameters than necessary were provided. Expected 0, received %d", len(args))
+	}
+	return nil
+}
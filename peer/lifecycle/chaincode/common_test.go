@@ -0,0 +1,179 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package chaincode
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	msptesttools "github.com/hyperledger/fabric/msp/mgmt/testtools"
+	"github.com/hyperledger/fabric/peer/common"
+	cb "github.com/hyperledger/fabric/protos/common"
+	pb "github.com/hyperledger/fabric/protos/peer"
+	lb "github.com/hyperledger/fabric/protos/peer/lifecycle"
+	"github.com/hyperledger/fabric/protos/utils"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+)
+
+func TestMain(m *testing.M) {
+	err := msptesttools.LoadMSPSetupForTesting()
+	if err != nil {
+		panic(fmt.Sprintf("Fatal error when reading MSP config: %s", err))
+	}
+
+	os.Exit(m.Run())
+}
+
+// fakeEndorser records the proposals it receives and returns the result of the
+// lifecycle SCC function
+type fakeEndorser struct {
+	result    proto.Message
+	status    int32
+	message   string
+	proposals []*pb.SignedProposal
+}
+
+func (e *fakeEndorser) ProcessProposal(ctx context.Context, signedProp *pb.SignedProposal, opts ...grpc.CallOption) (*pb.ProposalResponse, error) {
+	e.proposals = append(e.proposals, signedProp)
+	status := e.status
+	if status == 0 {
+		status = 200
+	}
+	var payload []byte
+	if e.result != nil {
+		payload = utils.MarshalOrPanic(e.result)
+	}
+	return &pb.ProposalResponse{
+		Response:    &pb.Response{Status: status, Message: e.message, Payload: payload},
+		Payload:     []byte("proposal-response-payload"),
+		Endorsement: &pb.Endorsement{},
+	}, nil
+}
+
+// invocation returns the channel, the function and the args of a proposal to the lifecycle SCC
+func (e *fakeEndorser) invocation(t *testing.T, i int, args proto.Message) (string, string) {
+	require.True(t, len(e.proposals) > i)
+	prop, err := utils.GetProposal(e.proposals[i].ProposalBytes)
+	require.NoError(t, err)
+	hdr, err := utils.GetHeader(prop.Header)
+	require.NoError(t, err)
+	chdr, err := utils.UnmarshalChannelHeader(hdr.ChannelHeader)
+	require.NoError(t, err)
+	cpp, err := utils.GetChaincodeProposalPayload(prop.Payload)
+	require.NoError(t, err)
+	cis := &pb.ChaincodeInvocationSpec{}
+	require.NoError(t, proto.Unmarshal(cpp.Input, cis))
+	assert.Equal(t, "+lifecycle", cis.ChaincodeSpec.ChaincodeId.Name)
+	require.Len(t, cis.ChaincodeSpec.Input.Args, 2)
+	require.NoError(t, proto.Unmarshal(cis.ChaincodeSpec.Input.Args[1], args))
+	return chdr.ChannelId, string(cis.ChaincodeSpec.Input.Args[0])
+}
+
+type fakeBroadcastClient struct {
+	envelopes []*cb.Envelope
+	err       error
+}
+
+func (b *fakeBroadcastClient) Send(env *cb.Envelope) error {
+	b.envelopes = append(b.envelopes, env)
+	return b.err
+}
+
+func (b *fakeBroadcastClient) Close() error {
+	return nil
+}
+
+func newCmdFactory(t *testing.T, endorsers ...*fakeEndorser) (*CmdFactory, *fakeBroadcastClient) {
+	signer, err := common.GetDefaultSigner()
+	require.NoError(t, err)
+	cf := &CmdFactory{Signer: signer}
+	for _, endorser := range endorsers {
+		cf.EndorserClients = append(cf.EndorserClients, endorser)
+	}
+	bc := &fakeBroadcastClient{}
+	cf.BroadcastClient = bc
+	return cf, bc
+}
+
+func executeCmd(cmd *cobra.Command, args ...string) (string, error) {
+	out := &bytes.Buffer{}
+	cmd.SetOutput(out)
+	cmd.SetArgs(args)
+	err := cmd.Execute()
+	return out.String(), err
+}
+
+func TestGetChaincodeDefinition(t *testing.T) {
+	dir, err := ioutil.TempDir("", "lifecycle-definition")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	collectionsFile := filepath.Join(dir, "collections.json")
+	require.NoError(t, ioutil.WriteFile(collectionsFile, []byte(`[{"name":"coll1","policy":"OR('Org1MSP.member')","requiredPeerCount":1,"maxPeerCount":2}]`), 0644))
+
+	tests := []struct {
+		name        string
+		setup       func()
+		expectedErr string
+	}{
+		{name: "missing name", setup: func() { chaincodeName = "" }, expectedErr: "must supply value for chaincode name parameter"},
+		{name: "missing version", setup: func() { chaincodeVersion = "" }, expectedErr: "must supply value for chaincode version parameter"},
+		{name: "missing sequence", setup: func() { sequence = 0 }, expectedErr: "must supply a positive value for the sequence parameter"},
+		{name: "missing channel", setup: func() { channelID = "" }, expectedErr: "The required parameter 'channelID' is empty. Rerun the command with -C flag"},
+		{name: "invalid policy", setup: func() { signaturePolicy = "bad-policy" }, expectedErr: "invalid policy bad-policy"},
+		{name: "missing collections file", setup: func() { collectionsConfigFile = filepath.Join(dir, "missing.json") }, expectedErr: "invalid collection configuration in file " + filepath.Join(dir, "missing.json") + ": could not read file '" + filepath.Join(dir, "missing.json") + "': open " + filepath.Join(dir, "missing.json") + ": no such file or directory"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			resetFlags()
+			chaincodeName, chaincodeVersion, sequence, channelID = "mycc", "1.0", 1, "mychannel"
+			test.setup()
+			_, err := getChaincodeDefinition()
+			assert.EqualError(t, err, test.expectedErr)
+		})
+	}
+
+	resetFlags()
+	chaincodeName, chaincodeVersion, sequence, channelID = "mycc", "1.0", 2, "mychannel"
+	signaturePolicy = "OR('Org1MSP.member')"
+	collectionsConfigFile = collectionsFile
+	validationPlugin = "custom-vscc"
+	cd, err := getChaincodeDefinition()
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), cd.Sequence)
+	assert.Equal(t, "1.0", cd.Version)
+	assert.Equal(t, "escc", cd.EndorsementPlugin)
+	assert.Equal(t, "custom-vscc", cd.ValidationPlugin)
+	assert.NotEmpty(t, cd.ValidationParameter)
+	require.Len(t, cd.Collections.Config, 1)
+	assert.Equal(t, "coll1", cd.Collections.Config[0].GetStaticCollectionConfig().Name)
+}
+
+func TestEvaluateFailure(t *testing.T) {
+	resetFlags()
+	endorser := &fakeEndorser{status: 500, message: "chaincode definition for 'mycc' not found"}
+	cf, _ := newCmdFactory(t, endorser)
+
+	err := evaluate(cf, "mychannel", "QueryChaincodeDefinition", &lb.QueryChaincodeDefinitionArgs{Name: "mycc"}, &lb.QueryChaincodeDefinitionResult{})
+	assert.EqualError(t, err, "proposal failed with status: 500 - chaincode definition for 'mycc' not found")
+}
+
+func TestValidatePeerConnectionParameters(t *testing.T) {
+	resetFlags()
+	peerAddresses = []string{"peer0:7051", "peer1:7051"}
+	assert.EqualError(t, validatePeerConnectionParameters("install", false), "'install' command can only be executed against one peer. received 2")
+	assert.NoError(t, validatePeerConnectionParameters("commit", true))
+}
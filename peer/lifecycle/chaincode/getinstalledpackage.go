@@ -0,0 +1,82 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package chaincode
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/hyperledger/fabric/core/chaincode/lifecycle"
+	lb "github.com/hyperledger/fabric/protos/peer/lifecycle"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+const getInstalledPackageDesc = "Get a chaincode install package installed on a peer and write it to disk."
+
+// getInstalledPackageCmd returns the cobra command for retrieving an installed chaincode install package
+func getInstalledPackageCmd(cf *CmdFactory) *cobra.Command {
+	chaincodeGetInstalledPackageCmd := &cobra.Command{
+		Use:   "getinstalledpackage",
+		Short: getInstalledPackageDesc,
+		Long:  getInstalledPackageDesc,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return getInstalledPackage(cmd, args, cf)
+		},
+	}
+	flagList := []string{
+		"package-id",
+		"output-directory",
+		"peerAddresses",
+		"tlsRootCertFiles",
+		"connectionProfile",
+	}
+	attachFlags(chaincodeGetInstalledPackageCmd, flagList)
+
+	return chaincodeGetInstalledPackageCmd
+}
+
+// getInstalledPackage writes the chaincode install package with the package ID
+// to <label>.<hash>.tar.gz in the output directory
+func getInstalledPackage(cmd *cobra.Command, args []string, cf *CmdFactory) error {
+	if err := checkNoArgs(args); err != nil {
+		return err
+	}
+	if packageID == "" {
+		return errors.New("must supply value for package ID parameter")
+	}
+
+	cf, err := initCmdFactory(cmd, cf, false, false)
+	if err != nil {
+		return err
+	}
+
+	result := &lb.GetInstalledChaincodePackageResult{}
+	err = evaluate(cf, "", lifecycle.GetInstalledChaincodePackageFuncName, &lb.GetInstalledChaincodePackageArgs{
+		PackageId: packageID,
+	}, result)
+	if err != nil {
+		return err
+	}
+
+	dir := outputDirectory
+	if dir == "" {
+		if dir, err = os.Getwd(); err != nil {
+			return errors.Wrap(err, "failed to get the current working directory")
+		}
+	}
+	outputFile := filepath.Join(dir, strings.Replace(packageID, ":", ".", 1)+".tar.gz")
+	if err := ioutil.WriteFile(outputFile, result.ChaincodeInstallPackage, 0600); err != nil {
+		return errors.Wrapf(err, "error writing chaincode install package to %s", outputFile)
+	}
+	fmt.Fprintf(cmd.OutOrStdout(), "Wrote chaincode install package to %s\n", outputFile)
+
+	return nil
+}
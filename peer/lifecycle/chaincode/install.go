@@ -0,0 +1,67 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package chaincode
+
+import (
+	"fmt"
+	"io/ioutil"
+
+	"github.com/hyperledger/fabric/core/chaincode/lifecycle"
+	lb "github.com/hyperledger/fabric/protos/peer/lifecycle"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+const installDesc = "Install a chaincode install package on a peer."
+
+// installCmd returns the cobra command for installing a chaincode install package
+func installCmd(cf *CmdFactory) *cobra.Command {
+	chaincodeInstallCmd := &cobra.Command{
+		Use:       "install [packagefile]",
+		Short:     installDesc,
+		Long:      installDesc,
+		ValidArgs: []string{"1"},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return install(cmd, args, cf)
+		},
+	}
+	flagList := []string{
+		"peerAddresses",
+		"tlsRootCertFiles",
+		"connectionProfile",
+	}
+	attachFlags(chaincodeInstallCmd, flagList)
+
+	return chaincodeInstallCmd
+}
+
+// install sends the chaincode install package to the peer
+func install(cmd *cobra.Command, args []string, cf *CmdFactory) error {
+	if len(args) != 1 {
+		return errors.New("chaincode install package not specified or invalid number of args (filename should be the only arg)")
+	}
+	pkgBytes, err := ioutil.ReadFile(args[0])
+	if err != nil {
+		return errors.Wrapf(err, "error reading chaincode install package at %s", args[0])
+	}
+
+	cf, err = initCmdFactory(cmd, cf, false, false)
+	if err != nil {
+		return err
+	}
+
+	result := &lb.InstallChaincodeResult{}
+	err = evaluate(cf, "", lifecycle.InstallChaincodeFuncName, &lb.InstallChaincodeArgs{
+		ChaincodeInstallPackage: pkgBytes,
+	}, result)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(cmd.OutOrStdout(), "Installed chaincode package with package ID: %s, label: %s\n", result.PackageId, result.Label)
+
+	return nil
+}
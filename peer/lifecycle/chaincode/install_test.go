@@ -0,0 +1,100 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package chaincode
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	lb "github.com/hyperledger/fabric/protos/peer/lifecycle"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInstall(t *testing.T) {
+	dir, err := ioutil.TempDir("", "lifecycle-install")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	pkgFile := filepath.Join(dir, "mycc.tar.gz")
+	require.NoError(t, ioutil.WriteFile(pkgFile, []byte("install-package"), 0644))
+
+	resetFlags()
+	endorser := &fakeEndorser{result: &lb.InstallChaincodeResult{PackageId: "mycc_1:hash", Label: "mycc_1"}}
+	cf, _ := newCmdFactory(t, endorser)
+	out, err := executeCmd(installCmd(cf), pkgFile)
+	require.NoError(t, err)
+	assert.Equal(t, "Installed chaincode package with package ID: mycc_1:hash, label: mycc_1\n", out)
+
+	args := &lb.InstallChaincodeArgs{}
+	channel, funcName := endorser.invocation(t, 0, args)
+	assert.Equal(t, "", channel)
+	assert.Equal(t, "InstallChaincode", funcName)
+	assert.Equal(t, []byte("install-package"), args.ChaincodeInstallPackage)
+
+	resetFlags()
+	_, err = executeCmd(installCmd(cf))
+	assert.EqualError(t, err, "chaincode install package not specified or invalid number of args (filename should be the only arg)")
+
+	resetFlags()
+	_, err = executeCmd(installCmd(cf), filepath.Join(dir, "missing.tar.gz"))
+	assert.Contains(t, err.Error(), "error reading chaincode install package at "+filepath.Join(dir, "missing.tar.gz"))
+}
+
+func TestQueryInstalled(t *testing.T) {
+	resetFlags()
+	endorser := &fakeEndorser{result: &lb.QueryInstalledChaincodesResult{
+		InstalledChaincodes: []*lb.QueryInstalledChaincodesResult_InstalledChaincode{
+			{PackageId: "mycc_1:hash1", Label: "mycc_1"},
+			{PackageId: "mycc_2:hash2", Label: "mycc_2"},
+		},
+	}}
+	cf, _ := newCmdFactory(t, endorser)
+	out, err := executeCmd(queryInstalledCmd(cf))
+	require.NoError(t, err)
+	assert.Equal(t, "Installed chaincodes on peer:\nPackage ID: mycc_1:hash1, Label: mycc_1\nPackage ID: mycc_2:hash2, Label: mycc_2\n", out)
+
+	_, funcName := endorser.invocation(t, 0, &lb.QueryInstalledChaincodesArgs{})
+	assert.Equal(t, "QueryInstalledChaincodes", funcName)
+
+	resetFlags()
+	_, err = executeCmd(queryInstalledCmd(cf), "extra")
+	assert.EqualError(t, err, "more parameters than necessary were provided. Expected 0, received 1")
+}
+
+func TestGetInstalledPackage(t *testing.T) {
+	dir, err := ioutil.TempDir("", "lifecycle-getinstalledpackage")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	resetFlags()
+	endorser := &fakeEndorser{result: &lb.GetInstalledChaincodePackageResult{ChaincodeInstallPackage: []byte("install-package")}}
+	cf, _ := newCmdFactory(t, endorser)
+	out, err := executeCmd(getInstalledPackageCmd(cf), "--package-id", "mycc_1:hash", "--output-directory", dir)
+	require.NoError(t, err)
+	outputFile := filepath.Join(dir, "mycc_1.hash.tar.gz")
+	assert.Equal(t, "Wrote chaincode install package to "+outputFile+"\n", out)
+	pkgBytes, err := ioutil.ReadFile(outputFile)
+	require.NoError(t, err)
+	assert.Equal(t, []byte("install-package"), pkgBytes)
+
+	args := &lb.GetInstalledChaincodePackageArgs{}
+	_, funcName := endorser.invocation(t, 0, args)
+	assert.Equal(t, "GetInstalledChaincodePackage", funcName)
+	assert.Equal(t, "mycc_1:hash", args.PackageId)
+
+	resetFlags()
+	_, err = executeCmd(getInstalledPackageCmd(cf), "--output-directory", dir)
+	assert.EqualError(t, err, "must supply value for package ID parameter")
+
+	resetFlags()
+	endorser = &fakeEndorser{status: 500, message: "chaincode install package not found"}
+	cf, _ = newCmdFactory(t, endorser)
+	_, err = executeCmd(getInstalledPackageCmd(cf), "--package-id", "mycc_3:hash", "--output-directory", dir)
+	assert.EqualError(t, err, "proposal failed with status: 500 - chaincode install package not found")
+}
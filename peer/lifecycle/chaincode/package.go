@@ -0,0 +1,138 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package chaincode
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"strings"
+
+	"github.com/hyperledger/fabric/core/chaincode/persistence"
+	"github.com/hyperledger/fabric/core/container"
+	"github.com/hyperledger/fabric/peer/common"
+	pb "github.com/hyperledger/fabric/protos/peer"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+const (
+	packageCmdName = "package"
+	packageDesc    = "Package the specified chaincode into a chaincode install package for the lifecycle SCC."
+
+	// codePackageFile is the name of the code package in the chaincode install package
+	codePackageFile = "Code-Package.tar.gz"
+)
+
+// packageCmd returns the cobra command for packaging a chaincode
+func packageCmd(cf *CmdFactory) *cobra.Command {
+	chaincodePackageCmd := &cobra.Command{
+		Use:       "package [outputfile]",
+		Short:     packageDesc,
+		Long:      packageDesc,
+		ValidArgs: []string{"1"},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return chaincodePackage(cmd, args)
+		},
+	}
+	attachFlags(chaincodePackageCmd, []string{"path", "lang", "label"})
+
+	return chaincodePackageCmd
+}
+
+// chaincodePackage writes the chaincode install package of the chaincode at the path to the output file
+func chaincodePackage(cmd *cobra.Command, args []string) error {
+	if len(args) != 1 {
+		return errors.New("output file not specified or invalid number of args (filename should be the only arg)")
+	}
+	if chaincodePath == common.UndefinedParamValue {
+		return errors.Errorf("must supply value for %s path parameter", chainFuncName)
+	}
+	if label == "" {
+		return errors.New("must supply value for label parameter")
+	}
+	if err := persistence.ValidateLabel(label); err != nil {
+		return err
+	}
+	// Parsing of the command line is done so silence cmd usage
+	cmd.SilenceUsage = true
+
+	ccType := strings.ToUpper(chaincodeLang)
+	spec := &pb.ChaincodeSpec{
+		Type:        pb.ChaincodeSpec_Type(pb.ChaincodeSpec_Type_value[ccType]),
+		ChaincodeId: &pb.ChaincodeID{Path: chaincodePath},
+	}
+	if err := platformRegistry.ValidateSpec(spec.CCType(), spec.Path()); err != nil {
+		return err
+	}
+
+	codePackage, err := container.GetChaincodePackageBytes(platformRegistry, spec)
+	if err != nil {
+		return errors.WithMessage(err, "error getting chaincode package bytes")
+	}
+
+	pkgBytes, err := chaincodeInstallPackage(ccType, chaincodePath, label, codePackage)
+	if err != nil {
+		return err
+	}
+
+	outputFile := args[0]
+	if err := ioutil.WriteFile(outputFile, pkgBytes, 0600); err != nil {
+		return errors.Wrapf(err, "error writing chaincode install package to %s", outputFile)
+	}
+	fmt.Fprintf(cmd.OutOrStdout(), "Wrote chaincode install package to %s\n", outputFile)
+
+	return nil
+}
+
+// chaincodeInstallPackage returns the .tar.gz of the metadata and of the code
+// package, in the format expected by the lifecycle SCC
+func chaincodeInstallPackage(ccType, path, label string, codePackage []byte) ([]byte, error) {
+	metadataBytes, err := json.Marshal(&persistence.ChaincodePackageMetadata{
+		Type:  ccType,
+		Path:  path,
+		Label: label,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "error marshaling chaincode package metadata")
+	}
+
+	buf := &bytes.Buffer{}
+	gw := gzip.NewWriter(buf)
+	tw := tar.NewWriter(gw)
+	for _, file := range []struct {
+		name    string
+		content []byte
+	}{
+		{persistence.ChaincodePackageMetadataFile, metadataBytes},
+		{codePackageFile, codePackage},
+	} {
+		err := tw.WriteHeader(&tar.Header{
+			Name:     file.name,
+			Typeflag: tar.TypeReg,
+			Mode:     0100644,
+			Size:     int64(len(file.content)),
+		})
+		if err != nil {
+			return nil, errors.Wrapf(err, "error writing header of %s", file.name)
+		}
+		if _, err := tw.Write(file.content); err != nil {
+			return nil, errors.Wrapf(err, "error writing %s", file.name)
+		}
+	}
+	if err := tw.Close(); err != nil {
+		return nil, errors.Wrap(err, "error closing tar writer")
+	}
+	if err := gw.Close(); err != nil {
+		return nil, errors.Wrap(err, "error closing gzip writer")
+	}
+
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,64 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package chaincode
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hyperledger/fabric/core/chaincode/persistence"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPackage(t *testing.T) {
+	dir, err := ioutil.TempDir("", "lifecycle-package")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	outputFile := filepath.Join(dir, "mycc.tar.gz")
+	ccPath := filepath.Join(dir, "mycc")
+	require.NoError(t, os.Mkdir(ccPath, 0755))
+	require.NoError(t, ioutil.WriteFile(filepath.Join(ccPath, "package.json"), []byte(`{"name":"mycc"}`), 0644))
+
+	resetFlags()
+	out, err := executeCmd(packageCmd(nil), outputFile, "--path", ccPath, "--lang", "node", "--label", "mycc_1")
+	require.NoError(t, err)
+	assert.Equal(t, "Wrote chaincode install package to "+outputFile+"\n", out)
+
+	pkgBytes, err := ioutil.ReadFile(outputFile)
+	require.NoError(t, err)
+	ccPackage, err := persistence.ChaincodePackageParser{}.Parse(pkgBytes)
+	require.NoError(t, err)
+	assert.Equal(t, "NODE", ccPackage.Metadata.Type)
+	assert.Equal(t, ccPath, ccPackage.Metadata.Path)
+	assert.Equal(t, "mycc_1", ccPackage.Metadata.Label)
+	assert.NotEmpty(t, ccPackage.CodePackage)
+}
+
+func TestPackageFailures(t *testing.T) {
+	resetFlags()
+	_, err := executeCmd(packageCmd(nil), "--path", "mycc")
+	assert.EqualError(t, err, "output file not specified or invalid number of args (filename should be the only arg)")
+
+	resetFlags()
+	_, err = executeCmd(packageCmd(nil), "mycc.tar.gz")
+	assert.EqualError(t, err, "must supply value for chaincode path parameter")
+
+	resetFlags()
+	_, err = executeCmd(packageCmd(nil), "mycc.tar.gz", "--path", "mycc")
+	assert.EqualError(t, err, "must supply value for label parameter")
+
+	resetFlags()
+	_, err = executeCmd(packageCmd(nil), "mycc.tar.gz", "--path", "mycc", "--label", "my/cc")
+	assert.EqualError(t, err, "invalid label 'my/cc'. Label must be non-empty, can only consist of alphanumerics, symbols from '.+-_', and can only begin with alphanumerics")
+
+	resetFlags()
+	_, err = executeCmd(packageCmd(nil), "mycc.tar.gz", "--path", "/missing/mycc", "--lang", "node", "--label", "mycc_1")
+	assert.EqualError(t, err, "path to chaincode does not exist: /missing/mycc")
+}
@@ -0,0 +1,76 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package chaincode
+
+import (
+	"fmt"
+
+	"github.com/hyperledger/fabric/core/chaincode/lifecycle"
+	"github.com/hyperledger/fabric/peer/common"
+	lb "github.com/hyperledger/fabric/protos/peer/lifecycle"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+const queryCommittedDesc = "Query the committed definition of a chaincode on a channel."
+
+// queryCommittedCmd returns the cobra command for querying a committed chaincode definition
+func queryCommittedCmd(cf *CmdFactory) *cobra.Command {
+	chaincodeQueryCommittedCmd := &cobra.Command{
+		Use:   "querycommitted",
+		Short: queryCommittedDesc,
+		Long:  queryCommittedDesc,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return queryCommitted(cmd, args, cf)
+		},
+	}
+	flagList := []string{
+		"channelID",
+		"name",
+		"peerAddresses",
+		"tlsRootCertFiles",
+		"connectionProfile",
+	}
+	attachFlags(chaincodeQueryCommittedCmd, flagList)
+
+	return chaincodeQueryCommittedCmd
+}
+
+// queryCommitted prints the committed definition of the chaincode
+func queryCommitted(cmd *cobra.Command, args []string, cf *CmdFactory) error {
+	if err := checkNoArgs(args); err != nil {
+		return err
+	}
+	if channelID == "" {
+		return errors.New("The required parameter 'channelID' is empty. Rerun the command with -C flag")
+	}
+	if chaincodeName == common.UndefinedParamValue {
+		return errors.Errorf("must supply value for %s name parameter", chainFuncName)
+	}
+
+	cf, err := initCmdFactory(cmd, cf, false, false)
+	if err != nil {
+		return err
+	}
+
+	result := &lb.QueryChaincodeDefinitionResult{}
+	err = evaluate(cf, channelID, lifecycle.QueryChaincodeDefinitionFuncName, &lb.QueryChaincodeDefinitionArgs{
+		Name: chaincodeName,
+	}, result)
+	if err != nil {
+		return err
+	}
+
+	cd := result.Definition
+	fmt.Fprintf(cmd.OutOrStdout(), "Committed chaincode definition for chaincode '%s' on channel '%s':\nVersion: %s, Sequence: %d, Endorsement Plugin: %s, Validation Plugin: %s\n",
+		chaincodeName, channelID, cd.GetVersion(), cd.GetSequence(), cd.GetEndorsementPlugin(), cd.GetValidationPlugin())
+	if result.PackageId != "" {
+		fmt.Fprintf(cmd.OutOrStdout(), "Package ID approved by this org: %s\n", result.PackageId)
+	}
+
+	return nil
+}
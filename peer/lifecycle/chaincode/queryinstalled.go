@@ -0,0 +1,63 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package chaincode
+
+import (
+	"fmt"
+
+	"github.com/hyperledger/fabric/core/chaincode/lifecycle"
+	lb "github.com/hyperledger/fabric/protos/peer/lifecycle"
+	"github.com/spf13/cobra"
+)
+
+const queryInstalledDesc = "Query the chaincodes installed on a peer."
+
+// queryInstalledCmd returns the cobra command for listing the chaincodes installed on a peer
+func queryInstalledCmd(cf *CmdFactory) *cobra.Command {
+	chaincodeQueryInstalledCmd := &cobra.Command{
+		Use:   "queryinstalled",
+		Short: queryInstalledDesc,
+		Long:  queryInstalledDesc,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return queryInstalled(cmd, args, cf)
+		},
+	}
+	flagList := []string{
+		"peerAddresses",
+		"tlsRootCertFiles",
+		"connectionProfile",
+	}
+	attachFlags(chaincodeQueryInstalledCmd, flagList)
+
+	return chaincodeQueryInstalledCmd
+}
+
+// queryInstalled prints the package IDs and labels of the installed chaincodes
+func queryInstalled(cmd *cobra.Command, args []string, cf *CmdFactory) error {
+	if err := checkNoArgs(args); err != nil {
+		return err
+	}
+
+	cf, err := initCmdFactory(cmd, cf, false, false)
+	if err != nil {
+		return err
+	}
+
+	result := &lb.QueryInstalledChaincodesResult{}
+	err = evaluate(cf, "", lifecycle.QueryInstalledChaincodesFuncName, &lb.QueryInstalledChaincodesArgs{}, result)
+	if err != nil {
+		return err
+	}
+
+	out := cmd.OutOrStdout()
+	fmt.Fprintln(out, "Installed chaincodes on peer:")
+	for _, installedChaincode := range result.InstalledChaincodes {
+		fmt.Fprintf(out, "Package ID: %s, Label: %s\n", installedChaincode.PackageId, installedChaincode.Label)
+	}
+
+	return nil
+}
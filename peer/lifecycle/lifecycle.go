@@ -0,0 +1,26 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package lifecycle
+
+import (
+	"github.com/hyperledger/fabric/peer/lifecycle/chaincode"
+	"github.com/spf13/cobra"
+)
+
+const lifecycleCmdDes = "Perform chaincode lifecycle operations"
+
+// Cmd returns the cobra command for the lifecycle SCC
+func Cmd() *cobra.Command {
+	lifecycleCmd := &cobra.Command{
+		Use:   "lifecycle",
+		Short: lifecycleCmdDes,
+		Long:  lifecycleCmdDes,
+	}
+	lifecycleCmd.AddCommand(chaincode.Cmd(nil))
+
+	return lifecycleCmd
+}
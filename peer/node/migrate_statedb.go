@@ -0,0 +1,45 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package node
+
+import (
+	"github.com/hyperledger/fabric/core/ledger/kvledger"
+	"github.com/hyperledger/fabric/peer/common"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+var targetStateDB string
+
+func migrateStateDBCmd() *cobra.Command {
+	nodeMigrateStateDBCmd.ResetFlags()
+	flags := nodeMigrateStateDBCmd.Flags()
+	flags.StringVarP(&targetStateDB, "to", "", common.UndefinedParamValue,
+		"State database to migrate to, one of goleveldb, CouchDB or JSONDB.")
+
+	return nodeMigrateStateDBCmd
+}
+
+var nodeMigrateStateDBCmd = &cobra.Command{
+	Use:   "migrate-statedb",
+	Short: "Migrates the state database.",
+	Long: `Copies the state database of all the channels from the state database in use to the target state database ` +
+		`and verifies the copied state. When the command is executed, the peer must be offline. The state database ` +
+		`in use is left intact, the peer starts using the target state database once ledger.state.stateDatabase ` +
+		`is changed to the target state database.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if len(args) != 0 {
+			return errors.Errorf("trailing args detected: %s", args)
+		}
+		if targetStateDB == common.UndefinedParamValue {
+			return errors.New("Must supply the target state database")
+		}
+		// Parsing of the command line is done so silence cmd usage
+		cmd.SilenceUsage = true
+		return kvledger.MigrateStateDB(targetStateDB)
+	},
+}
@@ -0,0 +1,36 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package node
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMigrateStateDBCmd(t *testing.T) {
+	cmd := migrateStateDBCmd()
+	cmd.SetArgs([]string{})
+	assert.EqualError(t, cmd.Execute(), "Must supply the target state database")
+
+	tempDir, err := ioutil.TempDir("", "migrateStateDBCmd")
+	assert.NoError(t, err)
+	defer os.RemoveAll(tempDir)
+	viper.Set("peer.fileSystemPath", tempDir)
+	defer viper.Reset()
+
+	cmd = migrateStateDBCmd()
+	cmd.SetArgs([]string{"--to", "unknown"})
+	assert.EqualError(t, cmd.Execute(), "invalid state database [unknown], must be one of goleveldb, CouchDB or JSONDB")
+
+	cmd = migrateStateDBCmd()
+	cmd.SetArgs([]string{"--to", "JSONDB"})
+	assert.NoError(t, cmd.Execute())
+}
@@ -0,0 +1,31 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package node
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResetCmd(t *testing.T) {
+	tempDir, err := ioutil.TempDir("", "resetCmd")
+	assert.NoError(t, err)
+	defer os.RemoveAll(tempDir)
+	viper.Set("peer.fileSystemPath", tempDir)
+	defer viper.Reset()
+
+	cmd := resetCmd()
+	cmd.SetArgs([]string{"arg1"})
+	assert.EqualError(t, cmd.Execute(), "trailing args detected: [arg1]")
+
+	cmd.SetArgs([]string{})
+	assert.NoError(t, cmd.Execute())
+}
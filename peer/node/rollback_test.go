@@ -0,0 +1,33 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package node
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRollbackCmd(t *testing.T) {
+	cmd := rollbackCmd()
+	cmd.SetArgs([]string{"-b", "10"})
+	assert.EqualError(t, cmd.Execute(), "Must supply channel ID")
+
+	tempDir, err := ioutil.TempDir("", "rollbackCmd")
+	assert.NoError(t, err)
+	defer os.RemoveAll(tempDir)
+	viper.Set("peer.fileSystemPath", tempDir)
+	defer viper.Reset()
+
+	cmd = rollbackCmd()
+	cmd.SetArgs([]string{"-c", "ch1", "-b", "10"})
+	err = cmd.Execute()
+	assert.EqualError(t, err, "ledgerID [ch1] does not exist")
+}
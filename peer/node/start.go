@@ -12,12 +12,14 @@ import (
 	"net/http"
 	"os"
 	"os/signal"
+	"path/filepath"
 	"syscall"
 	"time"
 
 	"github.com/golang/protobuf/proto"
 	"github.com/hyperledger/fabric/common/cauthdsl"
 	ccdef "github.com/hyperledger/fabric/common/chaincode"
+	"github.com/hyperledger/fabric/common/channelconfig"
 	"github.com/hyperledger/fabric/common/crypto/tlsgen"
 	"github.com/hyperledger/fabric/common/deliver"
 	"github.com/hyperledger/fabric/common/flogging"
@@ -47,6 +49,7 @@ import (
 	"github.com/hyperledger/fabric/core/common/privdata"
 	"github.com/hyperledger/fabric/core/container"
 	"github.com/hyperledger/fabric/core/container/dockercontroller"
+	"github.com/hyperledger/fabric/core/container/externalbuilders"
 	"github.com/hyperledger/fabric/core/container/inproccontroller"
 	"github.com/hyperledger/fabric/core/endorser"
 	authHandler "github.com/hyperledger/fabric/core/handlers/auth"
@@ -54,6 +57,7 @@ import (
 	endorsement3 "github.com/hyperledger/fabric/core/handlers/endorsement/api/identities"
 	"github.com/hyperledger/fabric/core/handlers/library"
 	"github.com/hyperledger/fabric/core/handlers/validation/api"
+	"github.com/hyperledger/fabric/core/ledger"
 	"github.com/hyperledger/fabric/core/ledger/cceventmgmt"
 	"github.com/hyperledger/fabric/core/ledger/ledgermgmt"
 	"github.com/hyperledger/fabric/core/operations"
@@ -70,6 +74,8 @@ import (
 	"github.com/hyperledger/fabric/discovery/support/config"
 	"github.com/hyperledger/fabric/discovery/support/gossip"
 	gossipcommon "github.com/hyperledger/fabric/gossip/common"
+	gossipprivdata "github.com/hyperledger/fabric/gossip/privdata"
+	privdatahttpadmin "github.com/hyperledger/fabric/gossip/privdata/httpadmin"
 	"github.com/hyperledger/fabric/gossip/service"
 	"github.com/hyperledger/fabric/msp"
 	"github.com/hyperledger/fabric/msp/mgmt"
@@ -152,7 +158,9 @@ func serve(args []string) error {
 		&car.Platform{},
 	)
 
-	deployedCCInfoProvider := &lscc.DeployedCCInfoProvider{}
+	deployedCCInfoProvider := &lifecycle.DeployedCCInfoProvider{
+		LegacyDeployedCCInfoProvider: &lscc.DeployedCCInfoProvider{},
+	}
 
 	identityDeserializerFactory := func(chainID string) msp.IdentityDeserializer {
 		return mgmt.GetManagerForChain(chainID)
@@ -310,6 +318,9 @@ func serve(args []string) error {
 	}
 	defer service.GetGossipService().Stop()
 
+	opsSystem.RegisterHandler("/pvtdata/missing", privdatahttpadmin.NewMissingHandler(service.GetGossipService()))
+	opsSystem.RegisterHandler("/pvtdata/reconcile", privdatahttpadmin.NewReconcileHandler(service.GetGossipService()))
+
 	// register prover grpc service
 	// FAB-12971 disable prover service before v1.4 cut. Will uncomment after v1.4 cut
 	// err = registerProverService(peerServer, aclProvider, signingIdentity)
@@ -620,6 +631,7 @@ func registerChaincodeSupport(
 	packageProvider *persistence.PackageProvider,
 	aclProvider aclmgmt.ACLProvider,
 	pr *platforms.Registry,
+	lifecycleImpl *lifecycle.Lifecycle,
 	lifecycleSCC *lifecycle.SCC,
 	ops *operations.System,
 ) (*chaincode.ChaincodeSupport, ccprovider.ChaincodeProvider, *scc.Provider) {
@@ -633,6 +645,10 @@ func registerChaincodeSupport(
 	sccp := scc.NewProvider(peer.Default, peer.DefaultSupport, ipRegistry)
 	lsccInst := lscc.New(sccp, aclProvider, pr)
 
+	// The chaincodes which are not defined through the new lifecycle
+	// are the ones instantiated through LSCC
+	lifecycleImpl.LegacyImpl = lsccInst
+
 	dockerProvider := dockercontroller.NewProvider(
 		viper.GetString("peer.id"),
 		viper.GetString("peer.networkId"),
@@ -644,23 +660,40 @@ func registerChaincodeSupport(
 		dockerProvider.BuildMetrics,
 	)
 
-	err := ops.RegisterChecker("docker", dockerVM)
-	if err != nil {
-		logger.Panicf("failed to register docker health check: %s", err)
+	// User chaincode runs in docker containers, unless one of the external
+	// builders takes care of it. Docker is only a fallback of the external
+	// builders, so its health is checked only when they are not configured.
+	chaincodeConfig := chaincode.GlobalConfig()
+	var userCCProvider container.VMProvider = dockerProvider
+	if len(chaincodeConfig.ExternalBuilders) == 0 {
+		err := ops.RegisterChecker("docker", dockerVM)
+		if err != nil {
+			logger.Panicf("failed to register docker health check: %s", err)
+		}
+	} else {
+		var builders []*externalbuilders.Builder
+		for _, b := range chaincodeConfig.ExternalBuilders {
+			builders = append(builders, &externalbuilders.Builder{
+				Name:                 b.Name,
+				Location:             b.Path,
+				EnvironmentWhitelist: b.EnvironmentWhitelist,
+			})
+		}
+		userCCProvider = externalbuilders.NewProvider(builders, ccEndpoint, dockerProvider)
 	}
 
 	chaincodeSupport := chaincode.NewChaincodeSupport(
-		chaincode.GlobalConfig(),
+		chaincodeConfig,
 		ccEndpoint,
 		userRunsCC,
 		ca.CertBytes(),
 		authenticator,
 		packageProvider,
-		lsccInst,
+		lifecycleImpl,
 		aclProvider,
 		container.NewVMController(
 			map[string]container.VMProvider{
-				dockercontroller.ContainerType: dockerProvider,
+				dockercontroller.ContainerType: userCCProvider,
 				inproccontroller.ContainerType: ipRegistry,
 			},
 		),
@@ -704,23 +737,48 @@ func startChaincodeServer(
 	chaincodeInstallPath := ccprovider.GetChaincodeInstallPathFromViper()
 	ccprovider.SetChaincodesPath(chaincodeInstallPath)
 
+	// Chaincode install packages of the new lifecycle are kept apart from
+	// the chaincode deployment specs of the legacy lifecycle
+	lifecycleInstallPath := filepath.Join(chaincodeInstallPath, "lifecycle")
+	if err := os.MkdirAll(lifecycleInstallPath, 0755); err != nil {
+		logger.Panicf("Failed to create chaincode install path %s: %s", lifecycleInstallPath, err)
+	}
+
+	// The chaincode install packages persisted under their hash by earlier
+	// versions of the peer have no label and cannot be launched
+	legacyPackages, err := persistence.ListLegacyPackages(chaincodeInstallPath, &persistence.FilesystemIO{})
+	if err != nil {
+		logger.Warningf("Failed to look for chaincode install packages without a label: %s", err)
+	}
+	for _, lp := range legacyPackages {
+		logger.Warningf("Chaincode install package %s of chaincode '%s' version '%s' has no label and is ignored, it must be packaged with a label and installed again", lp.Path, lp.Name, lp.Version)
+	}
+
 	ccPackageParser := &persistence.ChaincodePackageParser{}
 	ccStore := &persistence.Store{
-		Path:       chaincodeInstallPath,
+		Path:       lifecycleInstallPath,
 		ReadWriter: &persistence.FilesystemIO{},
 	}
 
 	packageProvider := &persistence.PackageProvider{
-		LegacyPP: &ccprovider.CCInfoFSImpl{},
-		Store:    ccStore,
+		Store:      ccStore,
+		Parser:     ccPackageParser,
+		LegacyPP:   &ccprovider.CCInfoFSImpl{},
+		LegacyPath: chaincodeInstallPath,
+	}
+
+	lifecycleImpl := &lifecycle.Lifecycle{
+		PackageParser:  ccPackageParser,
+		ChaincodeStore: ccStore,
+		Protobuf:       &lifecycle.ProtobufImpl{},
+		OrgMSPID:       viper.GetString("peer.localMspId"),
 	}
 
 	lifecycleSCC := &lifecycle.SCC{
-		Protobuf: &lifecycle.ProtobufImpl{},
-		Functions: &lifecycle.Lifecycle{
-			PackageParser:  ccPackageParser,
-			ChaincodeStore: ccStore,
-		},
+		OrgMSPID:            viper.GetString("peer.localMspId"),
+		ChannelConfigSource: &stableChannelConfigSource{},
+		Protobuf:            &lifecycle.ProtobufImpl{},
+		Functions:           lifecycleImpl,
 	}
 
 	// Create a self-signed CA for chaincode service
@@ -739,6 +797,7 @@ func startChaincodeServer(
 		packageProvider,
 		aclProvider,
 		pr,
+		lifecycleImpl,
 		lifecycleSCC,
 		ops,
 	)
@@ -799,7 +858,37 @@ func startAdminServer(peerListenAddr string, peerServer *grpc.Server, metricsPro
 		}()
 	}
 
-	pb.RegisterAdminServer(gRPCService, admin.NewAdminServer(adminPolicy))
+	pb.RegisterAdminServer(gRPCService, admin.NewAdminServer(adminPolicy, peer.TransientStoreFactory, &gossipReconciliationSupport{}, &ledgerIndexManagers{}))
+}
+
+// gossipReconciliationSupport resolves the private data reconciliation support of the gossip service
+// when it is used, as the gossip service is initialized after the admin server is registered
+type gossipReconciliationSupport struct{}
+
+func (*gossipReconciliationSupport) MissingPvtDataTracker(channel string) (ledger.MissingPvtDataTracker, error) {
+	return service.GetGossipService().MissingPvtDataTracker(channel)
+}
+
+func (*gossipReconciliationSupport) Reconciler(channel string) (gossipprivdata.PvtDataReconciler, error) {
+	return service.GetGossipService().Reconciler(channel)
+}
+
+// stableChannelConfigSource provides the config of the channels joined by the peer
+type stableChannelConfigSource struct{}
+
+func (*stableChannelConfigSource) GetStableChannelConfig(channelID string) channelconfig.Resources {
+	return peer.GetStableChannelConfig(channelID)
+}
+
+// ledgerIndexManagers retrieves the chaincode index managers from the ledgers of the channels joined by the peer
+type ledgerIndexManagers struct{}
+
+func (*ledgerIndexManagers) ChaincodeIndexManager(channel string) (ledger.ChaincodeIndexManager, error) {
+	l := peer.GetLedger(channel)
+	if l == nil {
+		return nil, errors.Errorf("channel %s not found", channel)
+	}
+	return l.GetChaincodeIndexManager()
 }
 
 // secureDialOpts is the callback function for secure dial options for gossip service
@@ -138,9 +138,101 @@ func (m *KeyModification) GetIsDelete() bool {
 	return false
 }
 
+// PrivateDataKeyModification -- QueryResult for private data history query. Holds the
+// block and transaction numbers, a transaction ID, timestamp, hash of the value and the
+// delete marker which resulted from a private data history query. The value is present
+// only if the private data is still available on the peer, i.e., it has neither been
+// purged nor been missing
+type PrivateDataKeyModification struct {
+	BlockNum             uint64               `protobuf:"varint,1,opt,name=block_num,json=blockNum,proto3" json:"block_num,omitempty"`
+	TxNum                uint64               `protobuf:"varint,2,opt,name=tx_num,json=txNum,proto3" json:"tx_num,omitempty"`
+	TxId                 string               `protobuf:"bytes,3,opt,name=tx_id,json=txId,proto3" json:"tx_id,omitempty"`
+	Value                []byte               `protobuf:"bytes,4,opt,name=value,proto3" json:"value,omitempty"`
+	ValueHash            []byte               `protobuf:"bytes,5,opt,name=value_hash,json=valueHash,proto3" json:"value_hash,omitempty"`
+	Timestamp            *timestamp.Timestamp `protobuf:"bytes,6,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	IsDelete             bool                 `protobuf:"varint,7,opt,name=is_delete,json=isDelete,proto3" json:"is_delete,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *PrivateDataKeyModification) Reset()         { *m = PrivateDataKeyModification{} }
+func (m *PrivateDataKeyModification) String() string { return proto.CompactTextString(m) }
+func (*PrivateDataKeyModification) ProtoMessage()    {}
+func (*PrivateDataKeyModification) Descriptor() ([]byte, []int) {
+	return fileDescriptor_kv_query_result_8f59f813f4fe5e5a, []int{2}
+}
+func (m *PrivateDataKeyModification) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PrivateDataKeyModification.Unmarshal(m, b)
+}
+func (m *PrivateDataKeyModification) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PrivateDataKeyModification.Marshal(b, m, deterministic)
+}
+func (dst *PrivateDataKeyModification) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PrivateDataKeyModification.Merge(dst, src)
+}
+func (m *PrivateDataKeyModification) XXX_Size() int {
+	return xxx_messageInfo_PrivateDataKeyModification.Size(m)
+}
+func (m *PrivateDataKeyModification) XXX_DiscardUnknown() {
+	xxx_messageInfo_PrivateDataKeyModification.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PrivateDataKeyModification proto.InternalMessageInfo
+
+func (m *PrivateDataKeyModification) GetBlockNum() uint64 {
+	if m != nil {
+		return m.BlockNum
+	}
+	return 0
+}
+
+func (m *PrivateDataKeyModification) GetTxNum() uint64 {
+	if m != nil {
+		return m.TxNum
+	}
+	return 0
+}
+
+func (m *PrivateDataKeyModification) GetTxId() string {
+	if m != nil {
+		return m.TxId
+	}
+	return ""
+}
+
+func (m *PrivateDataKeyModification) GetValue() []byte {
+	if m != nil {
+		return m.Value
+	}
+	return nil
+}
+
+func (m *PrivateDataKeyModification) GetValueHash() []byte {
+	if m != nil {
+		return m.ValueHash
+	}
+	return nil
+}
+
+func (m *PrivateDataKeyModification) GetTimestamp() *timestamp.Timestamp {
+	if m != nil {
+		return m.Timestamp
+	}
+	return nil
+}
+
+func (m *PrivateDataKeyModification) GetIsDelete() bool {
+	if m != nil {
+		return m.IsDelete
+	}
+	return false
+}
+
 func init() {
 	proto.RegisterType((*KV)(nil), "queryresult.KV")
 	proto.RegisterType((*KeyModification)(nil), "queryresult.KeyModification")
+	proto.RegisterType((*PrivateDataKeyModification)(nil), "queryresult.PrivateDataKeyModification")
 }
 
 func init() {
@@ -148,23 +240,29 @@ func init() {
 }
 
 var fileDescriptor_kv_query_result_8f59f813f4fe5e5a = []byte{
-	// 286 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x64, 0x51, 0x4f, 0x4b, 0xc3, 0x30,
-	0x1c, 0xa5, 0xdd, 0x26, 0x6b, 0x26, 0x28, 0xd1, 0x43, 0x99, 0x82, 0x65, 0xa7, 0x9e, 0x12, 0xd1,
-	0x83, 0x9e, 0xc5, 0x8b, 0x0e, 0x2f, 0x45, 0x3c, 0x78, 0x29, 0x69, 0xfb, 0x6b, 0x17, 0xda, 0x2e,
-	0x35, 0x7f, 0xc6, 0xfa, 0x39, 0xfc, 0xc2, 0x62, 0xb2, 0xd9, 0x82, 0xb7, 0xbc, 0xf7, 0x7b, 0xef,
-	0xf1, 0x78, 0x41, 0x71, 0x03, 0x45, 0x05, 0x92, 0x7e, 0x19, 0x90, 0xbd, 0x04, 0x65, 0x1a, 0x4d,
-	0xeb, 0x5d, 0x6a, 0x61, 0xea, 0x30, 0xe9, 0xa4, 0xd0, 0x02, 0x2f, 0x46, 0x92, 0xe5, 0x4d, 0x25,
-	0x44, 0xd5, 0x00, 0xb5, 0xa7, 0xcc, 0x94, 0x54, 0xf3, 0x16, 0x94, 0x66, 0x6d, 0xe7, 0xd4, 0xab,
-	0x57, 0xe4, 0xaf, 0x3f, 0xf0, 0x35, 0x0a, 0xb6, 0xac, 0x05, 0xd5, 0xb1, 0x1c, 0x42, 0x2f, 0xf2,
-	0xe2, 0x20, 0x19, 0x08, 0x7c, 0x8e, 0x26, 0x35, 0xf4, 0xa1, 0x6f, 0xf9, 0xdf, 0x27, 0xbe, 0x44,
-	0xb3, 0x1d, 0x6b, 0x0c, 0x84, 0x93, 0xc8, 0x8b, 0x4f, 0x13, 0x07, 0x56, 0xdf, 0x1e, 0x3a, 0x5b,
-	0x43, 0xff, 0x26, 0x0a, 0x5e, 0xf2, 0x9c, 0x69, 0x2e, 0xb6, 0xf8, 0x02, 0xcd, 0xf4, 0x3e, 0xe5,
-	0xc5, 0x21, 0x75, 0xaa, 0xf7, 0x2f, 0xc5, 0x60, 0xf7, 0x47, 0x76, 0xfc, 0x88, 0x82, 0xbf, 0x76,
-	0x36, 0x78, 0x71, 0xb7, 0x24, 0xae, 0x3f, 0x39, 0xf6, 0x27, 0xef, 0x47, 0x45, 0x32, 0x88, 0xf1,
-	0x15, 0x0a, 0xb8, 0x4a, 0x0b, 0x68, 0x40, 0x43, 0x38, 0x8d, 0xbc, 0x78, 0x9e, 0xcc, 0xb9, 0x7a,
-	0xb6, 0xf8, 0xa9, 0x46, 0xb7, 0x42, 0x56, 0x64, 0xd3, 0x77, 0x20, 0xdd, 0x88, 0xa4, 0x64, 0x99,
-	0xe4, 0xb9, 0x0b, 0x55, 0xe4, 0x40, 0x8e, 0x66, 0xfb, 0x7c, 0xa8, 0xb8, 0xde, 0x98, 0x8c, 0xe4,
-	0xa2, 0xa5, 0x23, 0x23, 0x75, 0x46, 0xb7, 0xa6, 0xa2, 0xff, 0xbf, 0x24, 0x3b, 0xb1, 0xa7, 0xfb,
-	0x9f, 0x00, 0x00, 0x00, 0xff, 0xff, 0xa2, 0xb7, 0x3e, 0x86, 0xaf, 0x01, 0x00, 0x00,
+	// 371 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x9c, 0x52, 0x4d, 0x6f, 0x9b, 0x40,
+	0x14, 0x14, 0x18, 0x5c, 0xb3, 0xae, 0xd4, 0x6a, 0xdb, 0x4a, 0xc8, 0x6e, 0x55, 0xcb, 0x27, 0x4e,
+	0x4b, 0xd5, 0x1e, 0xda, 0x73, 0xe5, 0x43, 0x5b, 0xab, 0x55, 0x85, 0xa2, 0x1c, 0x72, 0x41, 0x0b,
+	0x3c, 0xc3, 0x0a, 0xf0, 0x92, 0xdd, 0xc5, 0x82, 0xdf, 0x91, 0x5f, 0x9a, 0x7f, 0x10, 0x79, 0xd7,
+	0x1f, 0x38, 0xc9, 0x29, 0xb7, 0xf7, 0x66, 0xde, 0x3c, 0xcd, 0x48, 0x83, 0x82, 0x0a, 0xb2, 0x1c,
+	0x44, 0x78, 0xdb, 0x82, 0xe8, 0x05, 0xc8, 0xb6, 0x52, 0x61, 0xb9, 0x8b, 0xf5, 0x1a, 0x9b, 0x9d,
+	0x34, 0x82, 0x2b, 0x8e, 0xa7, 0x83, 0x93, 0xd9, 0xe7, 0x9c, 0xf3, 0xbc, 0x82, 0x50, 0x53, 0x49,
+	0xbb, 0x09, 0x15, 0xab, 0x41, 0x2a, 0x5a, 0x37, 0xe6, 0x7a, 0xf9, 0x07, 0xd9, 0xeb, 0x6b, 0xfc,
+	0x11, 0x79, 0x5b, 0x5a, 0x83, 0x6c, 0x68, 0x0a, 0xbe, 0xb5, 0xb0, 0x02, 0x2f, 0x3a, 0x03, 0xf8,
+	0x2d, 0x1a, 0x95, 0xd0, 0xfb, 0xb6, 0xc6, 0xf7, 0x23, 0x7e, 0x8f, 0xdc, 0x1d, 0xad, 0x5a, 0xf0,
+	0x47, 0x0b, 0x2b, 0x78, 0x1d, 0x99, 0x65, 0x79, 0x67, 0xa1, 0x37, 0x6b, 0xe8, 0xff, 0xf2, 0x8c,
+	0x6d, 0x58, 0x4a, 0x15, 0xe3, 0x5b, 0xfc, 0x0e, 0xb9, 0xaa, 0x8b, 0x59, 0x76, 0xf8, 0xea, 0xa8,
+	0xee, 0x77, 0x76, 0x96, 0xdb, 0x03, 0x39, 0xfe, 0x81, 0xbc, 0x93, 0x3b, 0xfd, 0x78, 0xfa, 0x75,
+	0x46, 0x8c, 0x7f, 0x72, 0xf4, 0x4f, 0xae, 0x8e, 0x17, 0xd1, 0xf9, 0x18, 0xcf, 0x91, 0xc7, 0x64,
+	0x9c, 0x41, 0x05, 0x0a, 0x7c, 0x67, 0x61, 0x05, 0x93, 0x68, 0xc2, 0xe4, 0x4a, 0xef, 0xcb, 0x7b,
+	0x0b, 0xcd, 0xfe, 0x0b, 0xb6, 0xa3, 0x0a, 0x56, 0x54, 0xd1, 0xc7, 0x06, 0xe7, 0xc8, 0x4b, 0x2a,
+	0x9e, 0x96, 0xf1, 0xb6, 0xad, 0xb5, 0x49, 0x27, 0x9a, 0x68, 0xe0, 0x5f, 0x5b, 0xe3, 0x0f, 0x68,
+	0xac, 0x3a, 0xcd, 0xd8, 0x9a, 0x71, 0x55, 0xb7, 0x87, 0x4f, 0xa1, 0x46, 0xcf, 0x85, 0x72, 0x86,
+	0xa1, 0x3e, 0x21, 0xa4, 0x87, 0xb8, 0xa0, 0xb2, 0xf0, 0x5d, 0x4d, 0x79, 0x1a, 0xf9, 0x45, 0x65,
+	0x71, 0x99, 0x79, 0xfc, 0xe2, 0xcc, 0xaf, 0x2e, 0x33, 0xff, 0x2c, 0xd1, 0x17, 0x2e, 0x72, 0x52,
+	0xf4, 0x0d, 0x08, 0x53, 0x1c, 0xb2, 0xa1, 0x89, 0x60, 0xa9, 0x79, 0x2a, 0xc9, 0x01, 0x1c, 0x54,
+	0xe5, 0xe6, 0x7b, 0xce, 0x54, 0xd1, 0x26, 0x24, 0xe5, 0x75, 0x38, 0x10, 0x86, 0x46, 0x68, 0x1a,
+	0x24, 0xc3, 0xa7, 0x35, 0x4c, 0xc6, 0x9a, 0xfa, 0xf6, 0x30, 0x00, 0x6a, 0xe5, 0x06, 0x06, 0xa3,
+	0x02, 0x00, 0x00,
 }
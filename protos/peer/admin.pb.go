@@ -7,6 +7,7 @@ import proto "github.com/golang/protobuf/proto"
 import fmt "fmt"
 import math "math"
 import empty "github.com/golang/protobuf/ptypes/empty"
+import timestamp "github.com/golang/protobuf/ptypes/timestamp"
 import common "github.com/hyperledger/fabric/protos/common"
 
 import (
@@ -274,10 +275,654 @@ func (m *LogSpecResponse) GetError() string {
 	return ""
 }
 
+// TransientEntriesRequest selects the private write sets in the transient store
+// of a channel. When txids is empty, all the private write sets are selected
+type TransientEntriesRequest struct {
+	ChannelId            string   `protobuf:"bytes,1,opt,name=channel_id,json=channelId,proto3" json:"channel_id,omitempty"`
+	Txids                []string `protobuf:"bytes,2,rep,name=txids,proto3" json:"txids,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *TransientEntriesRequest) Reset()         { *m = TransientEntriesRequest{} }
+func (m *TransientEntriesRequest) String() string { return proto.CompactTextString(m) }
+func (*TransientEntriesRequest) ProtoMessage()    {}
+func (*TransientEntriesRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_admin_b2904393863b6bc5, []int{5}
+}
+func (m *TransientEntriesRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TransientEntriesRequest.Unmarshal(m, b)
+}
+func (m *TransientEntriesRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TransientEntriesRequest.Marshal(b, m, deterministic)
+}
+func (dst *TransientEntriesRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TransientEntriesRequest.Merge(dst, src)
+}
+func (m *TransientEntriesRequest) XXX_Size() int {
+	return xxx_messageInfo_TransientEntriesRequest.Size(m)
+}
+func (m *TransientEntriesRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_TransientEntriesRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TransientEntriesRequest proto.InternalMessageInfo
+
+func (m *TransientEntriesRequest) GetChannelId() string {
+	if m != nil {
+		return m.ChannelId
+	}
+	return ""
+}
+
+func (m *TransientEntriesRequest) GetTxids() []string {
+	if m != nil {
+		return m.Txids
+	}
+	return nil
+}
+
+// TransientEntry describes a private write set in the transient store
+type TransientEntry struct {
+	Txid                  string               `protobuf:"bytes,1,opt,name=txid,proto3" json:"txid,omitempty"`
+	Uuid                  string               `protobuf:"bytes,2,opt,name=uuid,proto3" json:"uuid,omitempty"`
+	ReceivedAtBlockHeight uint64               `protobuf:"varint,3,opt,name=received_at_block_height,json=receivedAtBlockHeight,proto3" json:"received_at_block_height,omitempty"`
+	PersistedAt           *timestamp.Timestamp `protobuf:"bytes,4,opt,name=persisted_at,json=persistedAt,proto3" json:"persisted_at,omitempty"`
+	Size                  uint64               `protobuf:"varint,5,opt,name=size,proto3" json:"size,omitempty"`
+	XXX_NoUnkeyedLiteral  struct{}             `json:"-"`
+	XXX_unrecognized      []byte               `json:"-"`
+	XXX_sizecache         int32                `json:"-"`
+}
+
+func (m *TransientEntry) Reset()         { *m = TransientEntry{} }
+func (m *TransientEntry) String() string { return proto.CompactTextString(m) }
+func (*TransientEntry) ProtoMessage()    {}
+func (*TransientEntry) Descriptor() ([]byte, []int) {
+	return fileDescriptor_admin_b2904393863b6bc5, []int{6}
+}
+func (m *TransientEntry) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TransientEntry.Unmarshal(m, b)
+}
+func (m *TransientEntry) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TransientEntry.Marshal(b, m, deterministic)
+}
+func (dst *TransientEntry) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TransientEntry.Merge(dst, src)
+}
+func (m *TransientEntry) XXX_Size() int {
+	return xxx_messageInfo_TransientEntry.Size(m)
+}
+func (m *TransientEntry) XXX_DiscardUnknown() {
+	xxx_messageInfo_TransientEntry.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TransientEntry proto.InternalMessageInfo
+
+func (m *TransientEntry) GetTxid() string {
+	if m != nil {
+		return m.Txid
+	}
+	return ""
+}
+
+func (m *TransientEntry) GetUuid() string {
+	if m != nil {
+		return m.Uuid
+	}
+	return ""
+}
+
+func (m *TransientEntry) GetReceivedAtBlockHeight() uint64 {
+	if m != nil {
+		return m.ReceivedAtBlockHeight
+	}
+	return 0
+}
+
+func (m *TransientEntry) GetPersistedAt() *timestamp.Timestamp {
+	if m != nil {
+		return m.PersistedAt
+	}
+	return nil
+}
+
+func (m *TransientEntry) GetSize() uint64 {
+	if m != nil {
+		return m.Size
+	}
+	return 0
+}
+
+type TransientEntriesResponse struct {
+	Entries              []*TransientEntry `protobuf:"bytes,1,rep,name=entries,proto3" json:"entries,omitempty"`
+	TotalSize            uint64            `protobuf:"varint,2,opt,name=total_size,json=totalSize,proto3" json:"total_size,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *TransientEntriesResponse) Reset()         { *m = TransientEntriesResponse{} }
+func (m *TransientEntriesResponse) String() string { return proto.CompactTextString(m) }
+func (*TransientEntriesResponse) ProtoMessage()    {}
+func (*TransientEntriesResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_admin_b2904393863b6bc5, []int{7}
+}
+func (m *TransientEntriesResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TransientEntriesResponse.Unmarshal(m, b)
+}
+func (m *TransientEntriesResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TransientEntriesResponse.Marshal(b, m, deterministic)
+}
+func (dst *TransientEntriesResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TransientEntriesResponse.Merge(dst, src)
+}
+func (m *TransientEntriesResponse) XXX_Size() int {
+	return xxx_messageInfo_TransientEntriesResponse.Size(m)
+}
+func (m *TransientEntriesResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_TransientEntriesResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TransientEntriesResponse proto.InternalMessageInfo
+
+func (m *TransientEntriesResponse) GetEntries() []*TransientEntry {
+	if m != nil {
+		return m.Entries
+	}
+	return nil
+}
+
+func (m *TransientEntriesResponse) GetTotalSize() uint64 {
+	if m != nil {
+		return m.TotalSize
+	}
+	return 0
+}
+
+// PvtDataReconciliationRequest selects the blocks in the range [start_block, end_block]
+// of a channel. The range is ignored when retrieving the reconciliation status
+type PvtDataReconciliationRequest struct {
+	ChannelId            string   `protobuf:"bytes,1,opt,name=channel_id,json=channelId,proto3" json:"channel_id,omitempty"`
+	StartBlock           uint64   `protobuf:"varint,2,opt,name=start_block,json=startBlock,proto3" json:"start_block,omitempty"`
+	EndBlock             uint64   `protobuf:"varint,3,opt,name=end_block,json=endBlock,proto3" json:"end_block,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PvtDataReconciliationRequest) Reset()         { *m = PvtDataReconciliationRequest{} }
+func (m *PvtDataReconciliationRequest) String() string { return proto.CompactTextString(m) }
+func (*PvtDataReconciliationRequest) ProtoMessage()    {}
+func (*PvtDataReconciliationRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_admin_b2904393863b6bc5, []int{8}
+}
+func (m *PvtDataReconciliationRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PvtDataReconciliationRequest.Unmarshal(m, b)
+}
+func (m *PvtDataReconciliationRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PvtDataReconciliationRequest.Marshal(b, m, deterministic)
+}
+func (dst *PvtDataReconciliationRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PvtDataReconciliationRequest.Merge(dst, src)
+}
+func (m *PvtDataReconciliationRequest) XXX_Size() int {
+	return xxx_messageInfo_PvtDataReconciliationRequest.Size(m)
+}
+func (m *PvtDataReconciliationRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_PvtDataReconciliationRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PvtDataReconciliationRequest proto.InternalMessageInfo
+
+func (m *PvtDataReconciliationRequest) GetChannelId() string {
+	if m != nil {
+		return m.ChannelId
+	}
+	return ""
+}
+
+func (m *PvtDataReconciliationRequest) GetStartBlock() uint64 {
+	if m != nil {
+		return m.StartBlock
+	}
+	return 0
+}
+
+func (m *PvtDataReconciliationRequest) GetEndBlock() uint64 {
+	if m != nil {
+		return m.EndBlock
+	}
+	return 0
+}
+
+// MissingPvtData describes a private data item of an eligible collection that is missing on the peer
+type MissingPvtData struct {
+	BlockNum             uint64   `protobuf:"varint,1,opt,name=block_num,json=blockNum,proto3" json:"block_num,omitempty"`
+	TxNum                uint64   `protobuf:"varint,2,opt,name=tx_num,json=txNum,proto3" json:"tx_num,omitempty"`
+	Namespace            string   `protobuf:"bytes,3,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	Collection           string   `protobuf:"bytes,4,opt,name=collection,proto3" json:"collection,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *MissingPvtData) Reset()         { *m = MissingPvtData{} }
+func (m *MissingPvtData) String() string { return proto.CompactTextString(m) }
+func (*MissingPvtData) ProtoMessage()    {}
+func (*MissingPvtData) Descriptor() ([]byte, []int) {
+	return fileDescriptor_admin_b2904393863b6bc5, []int{9}
+}
+func (m *MissingPvtData) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_MissingPvtData.Unmarshal(m, b)
+}
+func (m *MissingPvtData) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_MissingPvtData.Marshal(b, m, deterministic)
+}
+func (dst *MissingPvtData) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_MissingPvtData.Merge(dst, src)
+}
+func (m *MissingPvtData) XXX_Size() int {
+	return xxx_messageInfo_MissingPvtData.Size(m)
+}
+func (m *MissingPvtData) XXX_DiscardUnknown() {
+	xxx_messageInfo_MissingPvtData.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_MissingPvtData proto.InternalMessageInfo
+
+func (m *MissingPvtData) GetBlockNum() uint64 {
+	if m != nil {
+		return m.BlockNum
+	}
+	return 0
+}
+
+func (m *MissingPvtData) GetTxNum() uint64 {
+	if m != nil {
+		return m.TxNum
+	}
+	return 0
+}
+
+func (m *MissingPvtData) GetNamespace() string {
+	if m != nil {
+		return m.Namespace
+	}
+	return ""
+}
+
+func (m *MissingPvtData) GetCollection() string {
+	if m != nil {
+		return m.Collection
+	}
+	return ""
+}
+
+type MissingPvtDataResponse struct {
+	Missing              []*MissingPvtData `protobuf:"bytes,1,rep,name=missing,proto3" json:"missing,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *MissingPvtDataResponse) Reset()         { *m = MissingPvtDataResponse{} }
+func (m *MissingPvtDataResponse) String() string { return proto.CompactTextString(m) }
+func (*MissingPvtDataResponse) ProtoMessage()    {}
+func (*MissingPvtDataResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_admin_b2904393863b6bc5, []int{10}
+}
+func (m *MissingPvtDataResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_MissingPvtDataResponse.Unmarshal(m, b)
+}
+func (m *MissingPvtDataResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_MissingPvtDataResponse.Marshal(b, m, deterministic)
+}
+func (dst *MissingPvtDataResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_MissingPvtDataResponse.Merge(dst, src)
+}
+func (m *MissingPvtDataResponse) XXX_Size() int {
+	return xxx_messageInfo_MissingPvtDataResponse.Size(m)
+}
+func (m *MissingPvtDataResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_MissingPvtDataResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_MissingPvtDataResponse proto.InternalMessageInfo
+
+func (m *MissingPvtDataResponse) GetMissing() []*MissingPvtData {
+	if m != nil {
+		return m.Missing
+	}
+	return nil
+}
+
+// PvtDataReconciliationStatus describes the progress of a reconciliation pass triggered by an
+// operator. failed_peers maps the endpoint of each peer that didn't supply requested private
+// data to the number of items it failed to supply
+type PvtDataReconciliationStatus struct {
+	StartBlock           uint64               `protobuf:"varint,1,opt,name=start_block,json=startBlock,proto3" json:"start_block,omitempty"`
+	EndBlock             uint64               `protobuf:"varint,2,opt,name=end_block,json=endBlock,proto3" json:"end_block,omitempty"`
+	InProgress           bool                 `protobuf:"varint,3,opt,name=in_progress,json=inProgress,proto3" json:"in_progress,omitempty"`
+	ProcessedBlocks      uint64               `protobuf:"varint,4,opt,name=processed_blocks,json=processedBlocks,proto3" json:"processed_blocks,omitempty"`
+	LastProcessedBlock   uint64               `protobuf:"varint,5,opt,name=last_processed_block,json=lastProcessedBlock,proto3" json:"last_processed_block,omitempty"`
+	ReconciledItems      uint64               `protobuf:"varint,6,opt,name=reconciled_items,json=reconciledItems,proto3" json:"reconciled_items,omitempty"`
+	UnavailableItems     uint64               `protobuf:"varint,7,opt,name=unavailable_items,json=unavailableItems,proto3" json:"unavailable_items,omitempty"`
+	HashMismatches       uint64               `protobuf:"varint,8,opt,name=hash_mismatches,json=hashMismatches,proto3" json:"hash_mismatches,omitempty"`
+	FailedPeers          map[string]uint64    `protobuf:"bytes,9,rep,name=failed_peers,json=failedPeers,proto3" json:"failed_peers,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3"`
+	StartedAt            *timestamp.Timestamp `protobuf:"bytes,10,opt,name=started_at,json=startedAt,proto3" json:"started_at,omitempty"`
+	FinishedAt           *timestamp.Timestamp `protobuf:"bytes,11,opt,name=finished_at,json=finishedAt,proto3" json:"finished_at,omitempty"`
+	Error                string               `protobuf:"bytes,12,opt,name=error,proto3" json:"error,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *PvtDataReconciliationStatus) Reset()         { *m = PvtDataReconciliationStatus{} }
+func (m *PvtDataReconciliationStatus) String() string { return proto.CompactTextString(m) }
+func (*PvtDataReconciliationStatus) ProtoMessage()    {}
+func (*PvtDataReconciliationStatus) Descriptor() ([]byte, []int) {
+	return fileDescriptor_admin_b2904393863b6bc5, []int{11}
+}
+func (m *PvtDataReconciliationStatus) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PvtDataReconciliationStatus.Unmarshal(m, b)
+}
+func (m *PvtDataReconciliationStatus) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PvtDataReconciliationStatus.Marshal(b, m, deterministic)
+}
+func (dst *PvtDataReconciliationStatus) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PvtDataReconciliationStatus.Merge(dst, src)
+}
+func (m *PvtDataReconciliationStatus) XXX_Size() int {
+	return xxx_messageInfo_PvtDataReconciliationStatus.Size(m)
+}
+func (m *PvtDataReconciliationStatus) XXX_DiscardUnknown() {
+	xxx_messageInfo_PvtDataReconciliationStatus.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PvtDataReconciliationStatus proto.InternalMessageInfo
+
+func (m *PvtDataReconciliationStatus) GetStartBlock() uint64 {
+	if m != nil {
+		return m.StartBlock
+	}
+	return 0
+}
+
+func (m *PvtDataReconciliationStatus) GetEndBlock() uint64 {
+	if m != nil {
+		return m.EndBlock
+	}
+	return 0
+}
+
+func (m *PvtDataReconciliationStatus) GetInProgress() bool {
+	if m != nil {
+		return m.InProgress
+	}
+	return false
+}
+
+func (m *PvtDataReconciliationStatus) GetProcessedBlocks() uint64 {
+	if m != nil {
+		return m.ProcessedBlocks
+	}
+	return 0
+}
+
+func (m *PvtDataReconciliationStatus) GetLastProcessedBlock() uint64 {
+	if m != nil {
+		return m.LastProcessedBlock
+	}
+	return 0
+}
+
+func (m *PvtDataReconciliationStatus) GetReconciledItems() uint64 {
+	if m != nil {
+		return m.ReconciledItems
+	}
+	return 0
+}
+
+func (m *PvtDataReconciliationStatus) GetUnavailableItems() uint64 {
+	if m != nil {
+		return m.UnavailableItems
+	}
+	return 0
+}
+
+func (m *PvtDataReconciliationStatus) GetHashMismatches() uint64 {
+	if m != nil {
+		return m.HashMismatches
+	}
+	return 0
+}
+
+func (m *PvtDataReconciliationStatus) GetFailedPeers() map[string]uint64 {
+	if m != nil {
+		return m.FailedPeers
+	}
+	return nil
+}
+
+func (m *PvtDataReconciliationStatus) GetStartedAt() *timestamp.Timestamp {
+	if m != nil {
+		return m.StartedAt
+	}
+	return nil
+}
+
+func (m *PvtDataReconciliationStatus) GetFinishedAt() *timestamp.Timestamp {
+	if m != nil {
+		return m.FinishedAt
+	}
+	return nil
+}
+
+func (m *PvtDataReconciliationStatus) GetError() string {
+	if m != nil {
+		return m.Error
+	}
+	return ""
+}
+
+// ChaincodeIndexRequest selects the indexes of the state database of a chaincode on a channel.
+// An empty collection designates the public state of the chaincode. The design document and the
+// index name identify the index to drop, and index_definition is the CouchDB index definition to rebuild
+type ChaincodeIndexRequest struct {
+	ChannelId            string   `protobuf:"bytes,1,opt,name=channel_id,json=channelId,proto3" json:"channel_id,omitempty"`
+	Chaincode            string   `protobuf:"bytes,2,opt,name=chaincode,proto3" json:"chaincode,omitempty"`
+	Collection           string   `protobuf:"bytes,3,opt,name=collection,proto3" json:"collection,omitempty"`
+	DesignDoc            string   `protobuf:"bytes,4,opt,name=design_doc,json=designDoc,proto3" json:"design_doc,omitempty"`
+	IndexName            string   `protobuf:"bytes,5,opt,name=index_name,json=indexName,proto3" json:"index_name,omitempty"`
+	IndexDefinition      []byte   `protobuf:"bytes,6,opt,name=index_definition,json=indexDefinition,proto3" json:"index_definition,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ChaincodeIndexRequest) Reset()         { *m = ChaincodeIndexRequest{} }
+func (m *ChaincodeIndexRequest) String() string { return proto.CompactTextString(m) }
+func (*ChaincodeIndexRequest) ProtoMessage()    {}
+func (*ChaincodeIndexRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_admin_b2904393863b6bc5, []int{12}
+}
+func (m *ChaincodeIndexRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ChaincodeIndexRequest.Unmarshal(m, b)
+}
+func (m *ChaincodeIndexRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ChaincodeIndexRequest.Marshal(b, m, deterministic)
+}
+func (dst *ChaincodeIndexRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ChaincodeIndexRequest.Merge(dst, src)
+}
+func (m *ChaincodeIndexRequest) XXX_Size() int {
+	return xxx_messageInfo_ChaincodeIndexRequest.Size(m)
+}
+func (m *ChaincodeIndexRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ChaincodeIndexRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ChaincodeIndexRequest proto.InternalMessageInfo
+
+func (m *ChaincodeIndexRequest) GetChannelId() string {
+	if m != nil {
+		return m.ChannelId
+	}
+	return ""
+}
+
+func (m *ChaincodeIndexRequest) GetChaincode() string {
+	if m != nil {
+		return m.Chaincode
+	}
+	return ""
+}
+
+func (m *ChaincodeIndexRequest) GetCollection() string {
+	if m != nil {
+		return m.Collection
+	}
+	return ""
+}
+
+func (m *ChaincodeIndexRequest) GetDesignDoc() string {
+	if m != nil {
+		return m.DesignDoc
+	}
+	return ""
+}
+
+func (m *ChaincodeIndexRequest) GetIndexName() string {
+	if m != nil {
+		return m.IndexName
+	}
+	return ""
+}
+
+func (m *ChaincodeIndexRequest) GetIndexDefinition() []byte {
+	if m != nil {
+		return m.IndexDefinition
+	}
+	return nil
+}
+
+// ChaincodeIndex describes an index of the state database of a chaincode
+type ChaincodeIndex struct {
+	Chaincode            string   `protobuf:"bytes,1,opt,name=chaincode,proto3" json:"chaincode,omitempty"`
+	Collection           string   `protobuf:"bytes,2,opt,name=collection,proto3" json:"collection,omitempty"`
+	DesignDoc            string   `protobuf:"bytes,3,opt,name=design_doc,json=designDoc,proto3" json:"design_doc,omitempty"`
+	Name                 string   `protobuf:"bytes,4,opt,name=name,proto3" json:"name,omitempty"`
+	Definition           string   `protobuf:"bytes,5,opt,name=definition,proto3" json:"definition,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ChaincodeIndex) Reset()         { *m = ChaincodeIndex{} }
+func (m *ChaincodeIndex) String() string { return proto.CompactTextString(m) }
+func (*ChaincodeIndex) ProtoMessage()    {}
+func (*ChaincodeIndex) Descriptor() ([]byte, []int) {
+	return fileDescriptor_admin_b2904393863b6bc5, []int{13}
+}
+func (m *ChaincodeIndex) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ChaincodeIndex.Unmarshal(m, b)
+}
+func (m *ChaincodeIndex) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ChaincodeIndex.Marshal(b, m, deterministic)
+}
+func (dst *ChaincodeIndex) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ChaincodeIndex.Merge(dst, src)
+}
+func (m *ChaincodeIndex) XXX_Size() int {
+	return xxx_messageInfo_ChaincodeIndex.Size(m)
+}
+func (m *ChaincodeIndex) XXX_DiscardUnknown() {
+	xxx_messageInfo_ChaincodeIndex.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ChaincodeIndex proto.InternalMessageInfo
+
+func (m *ChaincodeIndex) GetChaincode() string {
+	if m != nil {
+		return m.Chaincode
+	}
+	return ""
+}
+
+func (m *ChaincodeIndex) GetCollection() string {
+	if m != nil {
+		return m.Collection
+	}
+	return ""
+}
+
+func (m *ChaincodeIndex) GetDesignDoc() string {
+	if m != nil {
+		return m.DesignDoc
+	}
+	return ""
+}
+
+func (m *ChaincodeIndex) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *ChaincodeIndex) GetDefinition() string {
+	if m != nil {
+		return m.Definition
+	}
+	return ""
+}
+
+type ChaincodeIndexesResponse struct {
+	Indexes              []*ChaincodeIndex `protobuf:"bytes,1,rep,name=indexes,proto3" json:"indexes,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *ChaincodeIndexesResponse) Reset()         { *m = ChaincodeIndexesResponse{} }
+func (m *ChaincodeIndexesResponse) String() string { return proto.CompactTextString(m) }
+func (*ChaincodeIndexesResponse) ProtoMessage()    {}
+func (*ChaincodeIndexesResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_admin_b2904393863b6bc5, []int{14}
+}
+func (m *ChaincodeIndexesResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ChaincodeIndexesResponse.Unmarshal(m, b)
+}
+func (m *ChaincodeIndexesResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ChaincodeIndexesResponse.Marshal(b, m, deterministic)
+}
+func (dst *ChaincodeIndexesResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ChaincodeIndexesResponse.Merge(dst, src)
+}
+func (m *ChaincodeIndexesResponse) XXX_Size() int {
+	return xxx_messageInfo_ChaincodeIndexesResponse.Size(m)
+}
+func (m *ChaincodeIndexesResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ChaincodeIndexesResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ChaincodeIndexesResponse proto.InternalMessageInfo
+
+func (m *ChaincodeIndexesResponse) GetIndexes() []*ChaincodeIndex {
+	if m != nil {
+		return m.Indexes
+	}
+	return nil
+}
+
 type AdminOperation struct {
 	// Types that are valid to be assigned to Content:
 	//	*AdminOperation_LogReq
 	//	*AdminOperation_LogSpecReq
+	//	*AdminOperation_TransientEntriesReq
+	//	*AdminOperation_PvtDataReconciliationReq
+	//	*AdminOperation_ChaincodeIndexReq
 	Content              isAdminOperation_Content `protobuf_oneof:"content"`
 	XXX_NoUnkeyedLiteral struct{}                 `json:"-"`
 	XXX_unrecognized     []byte                   `json:"-"`
@@ -288,7 +933,7 @@ func (m *AdminOperation) Reset()         { *m = AdminOperation{} }
 func (m *AdminOperation) String() string { return proto.CompactTextString(m) }
 func (*AdminOperation) ProtoMessage()    {}
 func (*AdminOperation) Descriptor() ([]byte, []int) {
-	return fileDescriptor_admin_b2904393863b6bc5, []int{5}
+	return fileDescriptor_admin_b2904393863b6bc5, []int{15}
 }
 func (m *AdminOperation) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_AdminOperation.Unmarshal(m, b)
@@ -320,10 +965,28 @@ type AdminOperation_LogSpecReq struct {
 	LogSpecReq *LogSpecRequest `protobuf:"bytes,2,opt,name=logSpecReq,proto3,oneof"`
 }
 
+type AdminOperation_TransientEntriesReq struct {
+	TransientEntriesReq *TransientEntriesRequest `protobuf:"bytes,3,opt,name=transientEntriesReq,proto3,oneof"`
+}
+
+type AdminOperation_PvtDataReconciliationReq struct {
+	PvtDataReconciliationReq *PvtDataReconciliationRequest `protobuf:"bytes,4,opt,name=pvtDataReconciliationReq,proto3,oneof"`
+}
+
+type AdminOperation_ChaincodeIndexReq struct {
+	ChaincodeIndexReq *ChaincodeIndexRequest `protobuf:"bytes,5,opt,name=chaincodeIndexReq,proto3,oneof"`
+}
+
 func (*AdminOperation_LogReq) isAdminOperation_Content() {}
 
 func (*AdminOperation_LogSpecReq) isAdminOperation_Content() {}
 
+func (*AdminOperation_TransientEntriesReq) isAdminOperation_Content() {}
+
+func (*AdminOperation_PvtDataReconciliationReq) isAdminOperation_Content() {}
+
+func (*AdminOperation_ChaincodeIndexReq) isAdminOperation_Content() {}
+
 func (m *AdminOperation) GetContent() isAdminOperation_Content {
 	if m != nil {
 		return m.Content
@@ -345,11 +1008,35 @@ func (m *AdminOperation) GetLogSpecReq() *LogSpecRequest {
 	return nil
 }
 
+func (m *AdminOperation) GetTransientEntriesReq() *TransientEntriesRequest {
+	if x, ok := m.GetContent().(*AdminOperation_TransientEntriesReq); ok {
+		return x.TransientEntriesReq
+	}
+	return nil
+}
+
+func (m *AdminOperation) GetPvtDataReconciliationReq() *PvtDataReconciliationRequest {
+	if x, ok := m.GetContent().(*AdminOperation_PvtDataReconciliationReq); ok {
+		return x.PvtDataReconciliationReq
+	}
+	return nil
+}
+
+func (m *AdminOperation) GetChaincodeIndexReq() *ChaincodeIndexRequest {
+	if x, ok := m.GetContent().(*AdminOperation_ChaincodeIndexReq); ok {
+		return x.ChaincodeIndexReq
+	}
+	return nil
+}
+
 // XXX_OneofFuncs is for the internal use of the proto package.
 func (*AdminOperation) XXX_OneofFuncs() (func(msg proto.Message, b *proto.Buffer) error, func(msg proto.Message, tag, wire int, b *proto.Buffer) (bool, error), func(msg proto.Message) (n int), []interface{}) {
 	return _AdminOperation_OneofMarshaler, _AdminOperation_OneofUnmarshaler, _AdminOperation_OneofSizer, []interface{}{
 		(*AdminOperation_LogReq)(nil),
 		(*AdminOperation_LogSpecReq)(nil),
+		(*AdminOperation_TransientEntriesReq)(nil),
+		(*AdminOperation_PvtDataReconciliationReq)(nil),
+		(*AdminOperation_ChaincodeIndexReq)(nil),
 	}
 }
 
@@ -367,6 +1054,21 @@ func _AdminOperation_OneofMarshaler(msg proto.Message, b *proto.Buffer) error {
 		if err := b.EncodeMessage(x.LogSpecReq); err != nil {
 			return err
 		}
+	case *AdminOperation_TransientEntriesReq:
+		b.EncodeVarint(3<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.TransientEntriesReq); err != nil {
+			return err
+		}
+	case *AdminOperation_PvtDataReconciliationReq:
+		b.EncodeVarint(4<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.PvtDataReconciliationReq); err != nil {
+			return err
+		}
+	case *AdminOperation_ChaincodeIndexReq:
+		b.EncodeVarint(5<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.ChaincodeIndexReq); err != nil {
+			return err
+		}
 	case nil:
 	default:
 		return fmt.Errorf("AdminOperation.Content has unexpected type %T", x)
@@ -393,6 +1095,30 @@ func _AdminOperation_OneofUnmarshaler(msg proto.Message, tag, wire int, b *proto
 		err := b.DecodeMessage(msg)
 		m.Content = &AdminOperation_LogSpecReq{msg}
 		return true, err
+	case 3: // content.transientEntriesReq
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(TransientEntriesRequest)
+		err := b.DecodeMessage(msg)
+		m.Content = &AdminOperation_TransientEntriesReq{msg}
+		return true, err
+	case 4: // content.pvtDataReconciliationReq
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(PvtDataReconciliationRequest)
+		err := b.DecodeMessage(msg)
+		m.Content = &AdminOperation_PvtDataReconciliationReq{msg}
+		return true, err
+	case 5: // content.chaincodeIndexReq
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(ChaincodeIndexRequest)
+		err := b.DecodeMessage(msg)
+		m.Content = &AdminOperation_ChaincodeIndexReq{msg}
+		return true, err
 	default:
 		return false, nil
 	}
@@ -412,6 +1138,21 @@ func _AdminOperation_OneofSizer(msg proto.Message) (n int) {
 		n += 1 // tag and wire
 		n += proto.SizeVarint(uint64(s))
 		n += s
+	case *AdminOperation_TransientEntriesReq:
+		s := proto.Size(x.TransientEntriesReq)
+		n += 1 // tag and wire
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case *AdminOperation_PvtDataReconciliationReq:
+		s := proto.Size(x.PvtDataReconciliationReq)
+		n += 1 // tag and wire
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case *AdminOperation_ChaincodeIndexReq:
+		s := proto.Size(x.ChaincodeIndexReq)
+		n += 1 // tag and wire
+		n += proto.SizeVarint(uint64(s))
+		n += s
 	case nil:
 	default:
 		panic(fmt.Sprintf("proto: unexpected type %T in oneof", x))
@@ -425,6 +1166,17 @@ func init() {
 	proto.RegisterType((*LogLevelResponse)(nil), "protos.LogLevelResponse")
 	proto.RegisterType((*LogSpecRequest)(nil), "protos.LogSpecRequest")
 	proto.RegisterType((*LogSpecResponse)(nil), "protos.LogSpecResponse")
+	proto.RegisterType((*TransientEntriesRequest)(nil), "protos.TransientEntriesRequest")
+	proto.RegisterType((*TransientEntry)(nil), "protos.TransientEntry")
+	proto.RegisterType((*TransientEntriesResponse)(nil), "protos.TransientEntriesResponse")
+	proto.RegisterType((*PvtDataReconciliationRequest)(nil), "protos.PvtDataReconciliationRequest")
+	proto.RegisterType((*MissingPvtData)(nil), "protos.MissingPvtData")
+	proto.RegisterType((*MissingPvtDataResponse)(nil), "protos.MissingPvtDataResponse")
+	proto.RegisterType((*PvtDataReconciliationStatus)(nil), "protos.PvtDataReconciliationStatus")
+	proto.RegisterMapType((map[string]uint64)(nil), "protos.PvtDataReconciliationStatus.FailedPeersEntry")
+	proto.RegisterType((*ChaincodeIndexRequest)(nil), "protos.ChaincodeIndexRequest")
+	proto.RegisterType((*ChaincodeIndex)(nil), "protos.ChaincodeIndex")
+	proto.RegisterType((*ChaincodeIndexesResponse)(nil), "protos.ChaincodeIndexesResponse")
 	proto.RegisterType((*AdminOperation)(nil), "protos.AdminOperation")
 	proto.RegisterEnum("protos.ServerStatus_StatusCode", ServerStatus_StatusCode_name, ServerStatus_StatusCode_value)
 }
@@ -448,6 +1200,14 @@ type AdminClient interface {
 	RevertLogLevels(ctx context.Context, in *common.Envelope, opts ...grpc.CallOption) (*empty.Empty, error)
 	GetLogSpec(ctx context.Context, in *common.Envelope, opts ...grpc.CallOption) (*LogSpecResponse, error)
 	SetLogSpec(ctx context.Context, in *common.Envelope, opts ...grpc.CallOption) (*LogSpecResponse, error)
+	GetTransientEntries(ctx context.Context, in *common.Envelope, opts ...grpc.CallOption) (*TransientEntriesResponse, error)
+	PurgeTransientEntries(ctx context.Context, in *common.Envelope, opts ...grpc.CallOption) (*TransientEntriesResponse, error)
+	GetMissingPvtData(ctx context.Context, in *common.Envelope, opts ...grpc.CallOption) (*MissingPvtDataResponse, error)
+	ReconcilePvtData(ctx context.Context, in *common.Envelope, opts ...grpc.CallOption) (*PvtDataReconciliationStatus, error)
+	GetPvtDataReconciliationStatus(ctx context.Context, in *common.Envelope, opts ...grpc.CallOption) (*PvtDataReconciliationStatus, error)
+	ListChaincodeIndexes(ctx context.Context, in *common.Envelope, opts ...grpc.CallOption) (*ChaincodeIndexesResponse, error)
+	DropChaincodeIndex(ctx context.Context, in *common.Envelope, opts ...grpc.CallOption) (*empty.Empty, error)
+	RebuildChaincodeIndex(ctx context.Context, in *common.Envelope, opts ...grpc.CallOption) (*ChaincodeIndex, error)
 }
 
 type adminClient struct {
@@ -521,6 +1281,78 @@ func (c *adminClient) SetLogSpec(ctx context.Context, in *common.Envelope, opts
 	return out, nil
 }
 
+func (c *adminClient) GetTransientEntries(ctx context.Context, in *common.Envelope, opts ...grpc.CallOption) (*TransientEntriesResponse, error) {
+	out := new(TransientEntriesResponse)
+	err := c.cc.Invoke(ctx, "/protos.Admin/GetTransientEntries", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminClient) PurgeTransientEntries(ctx context.Context, in *common.Envelope, opts ...grpc.CallOption) (*TransientEntriesResponse, error) {
+	out := new(TransientEntriesResponse)
+	err := c.cc.Invoke(ctx, "/protos.Admin/PurgeTransientEntries", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminClient) GetMissingPvtData(ctx context.Context, in *common.Envelope, opts ...grpc.CallOption) (*MissingPvtDataResponse, error) {
+	out := new(MissingPvtDataResponse)
+	err := c.cc.Invoke(ctx, "/protos.Admin/GetMissingPvtData", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminClient) ReconcilePvtData(ctx context.Context, in *common.Envelope, opts ...grpc.CallOption) (*PvtDataReconciliationStatus, error) {
+	out := new(PvtDataReconciliationStatus)
+	err := c.cc.Invoke(ctx, "/protos.Admin/ReconcilePvtData", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminClient) GetPvtDataReconciliationStatus(ctx context.Context, in *common.Envelope, opts ...grpc.CallOption) (*PvtDataReconciliationStatus, error) {
+	out := new(PvtDataReconciliationStatus)
+	err := c.cc.Invoke(ctx, "/protos.Admin/GetPvtDataReconciliationStatus", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminClient) ListChaincodeIndexes(ctx context.Context, in *common.Envelope, opts ...grpc.CallOption) (*ChaincodeIndexesResponse, error) {
+	out := new(ChaincodeIndexesResponse)
+	err := c.cc.Invoke(ctx, "/protos.Admin/ListChaincodeIndexes", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminClient) DropChaincodeIndex(ctx context.Context, in *common.Envelope, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/protos.Admin/DropChaincodeIndex", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminClient) RebuildChaincodeIndex(ctx context.Context, in *common.Envelope, opts ...grpc.CallOption) (*ChaincodeIndex, error) {
+	out := new(ChaincodeIndex)
+	err := c.cc.Invoke(ctx, "/protos.Admin/RebuildChaincodeIndex", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // AdminServer is the server API for Admin service.
 type AdminServer interface {
 	GetStatus(context.Context, *common.Envelope) (*ServerStatus, error)
@@ -530,6 +1362,14 @@ type AdminServer interface {
 	RevertLogLevels(context.Context, *common.Envelope) (*empty.Empty, error)
 	GetLogSpec(context.Context, *common.Envelope) (*LogSpecResponse, error)
 	SetLogSpec(context.Context, *common.Envelope) (*LogSpecResponse, error)
+	GetTransientEntries(context.Context, *common.Envelope) (*TransientEntriesResponse, error)
+	PurgeTransientEntries(context.Context, *common.Envelope) (*TransientEntriesResponse, error)
+	GetMissingPvtData(context.Context, *common.Envelope) (*MissingPvtDataResponse, error)
+	ReconcilePvtData(context.Context, *common.Envelope) (*PvtDataReconciliationStatus, error)
+	GetPvtDataReconciliationStatus(context.Context, *common.Envelope) (*PvtDataReconciliationStatus, error)
+	ListChaincodeIndexes(context.Context, *common.Envelope) (*ChaincodeIndexesResponse, error)
+	DropChaincodeIndex(context.Context, *common.Envelope) (*empty.Empty, error)
+	RebuildChaincodeIndex(context.Context, *common.Envelope) (*ChaincodeIndex, error)
 }
 
 func RegisterAdminServer(s *grpc.Server, srv AdminServer) {
@@ -662,6 +1502,150 @@ func _Admin_SetLogSpec_Handler(srv interface{}, ctx context.Context, dec func(in
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Admin_GetTransientEntries_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(common.Envelope)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServer).GetTransientEntries(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/protos.Admin/GetTransientEntries",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServer).GetTransientEntries(ctx, req.(*common.Envelope))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Admin_PurgeTransientEntries_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(common.Envelope)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServer).PurgeTransientEntries(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/protos.Admin/PurgeTransientEntries",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServer).PurgeTransientEntries(ctx, req.(*common.Envelope))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Admin_GetMissingPvtData_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(common.Envelope)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServer).GetMissingPvtData(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/protos.Admin/GetMissingPvtData",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServer).GetMissingPvtData(ctx, req.(*common.Envelope))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Admin_ReconcilePvtData_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(common.Envelope)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServer).ReconcilePvtData(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/protos.Admin/ReconcilePvtData",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServer).ReconcilePvtData(ctx, req.(*common.Envelope))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Admin_GetPvtDataReconciliationStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(common.Envelope)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServer).GetPvtDataReconciliationStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/protos.Admin/GetPvtDataReconciliationStatus",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServer).GetPvtDataReconciliationStatus(ctx, req.(*common.Envelope))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Admin_ListChaincodeIndexes_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(common.Envelope)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServer).ListChaincodeIndexes(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/protos.Admin/ListChaincodeIndexes",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServer).ListChaincodeIndexes(ctx, req.(*common.Envelope))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Admin_DropChaincodeIndex_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(common.Envelope)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServer).DropChaincodeIndex(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/protos.Admin/DropChaincodeIndex",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServer).DropChaincodeIndex(ctx, req.(*common.Envelope))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Admin_RebuildChaincodeIndex_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(common.Envelope)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServer).RebuildChaincodeIndex(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/protos.Admin/RebuildChaincodeIndex",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServer).RebuildChaincodeIndex(ctx, req.(*common.Envelope))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _Admin_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "protos.Admin",
 	HandlerType: (*AdminServer)(nil),
@@ -694,6 +1678,38 @@ var _Admin_serviceDesc = grpc.ServiceDesc{
 			MethodName: "SetLogSpec",
 			Handler:    _Admin_SetLogSpec_Handler,
 		},
+		{
+			MethodName: "GetTransientEntries",
+			Handler:    _Admin_GetTransientEntries_Handler,
+		},
+		{
+			MethodName: "PurgeTransientEntries",
+			Handler:    _Admin_PurgeTransientEntries_Handler,
+		},
+		{
+			MethodName: "GetMissingPvtData",
+			Handler:    _Admin_GetMissingPvtData_Handler,
+		},
+		{
+			MethodName: "ReconcilePvtData",
+			Handler:    _Admin_ReconcilePvtData_Handler,
+		},
+		{
+			MethodName: "GetPvtDataReconciliationStatus",
+			Handler:    _Admin_GetPvtDataReconciliationStatus_Handler,
+		},
+		{
+			MethodName: "ListChaincodeIndexes",
+			Handler:    _Admin_ListChaincodeIndexes_Handler,
+		},
+		{
+			MethodName: "DropChaincodeIndex",
+			Handler:    _Admin_DropChaincodeIndex_Handler,
+		},
+		{
+			MethodName: "RebuildChaincodeIndex",
+			Handler:    _Admin_RebuildChaincodeIndex_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "peer/admin.proto",
@@ -702,40 +1718,94 @@ var _Admin_serviceDesc = grpc.ServiceDesc{
 func init() { proto.RegisterFile("peer/admin.proto", fileDescriptor_admin_b2904393863b6bc5) }
 
 var fileDescriptor_admin_b2904393863b6bc5 = []byte{
-	// 555 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0x54, 0xdd, 0x6e, 0xd3, 0x30,
-	0x14, 0x6e, 0x37, 0xda, 0x92, 0xd3, 0xd1, 0x05, 0x33, 0x6d, 0xa5, 0x13, 0x02, 0xe5, 0x0a, 0x84,
-	0x94, 0x88, 0x22, 0x34, 0x76, 0xc1, 0x45, 0x4b, 0x43, 0x87, 0xe8, 0xd2, 0x2a, 0x59, 0x85, 0x40,
-	0x42, 0x55, 0x9a, 0x9e, 0x79, 0x15, 0x6e, 0x9c, 0x39, 0x6e, 0xa5, 0xbd, 0x01, 0xcf, 0xc1, 0x23,
-	0xf0, 0x84, 0x28, 0x76, 0xc2, 0x2a, 0x56, 0x2e, 0xd8, 0xae, 0xec, 0x73, 0xfc, 0x7d, 0xdf, 0xf9,
-	0xb3, 0x0d, 0x66, 0x82, 0x28, 0x9c, 0x70, 0xb6, 0x98, 0xc7, 0x76, 0x22, 0xb8, 0xe4, 0xa4, 0xaa,
-	0x96, 0xb4, 0x75, 0x48, 0x39, 0xa7, 0x0c, 0x1d, 0x65, 0x4e, 0x97, 0xe7, 0x0e, 0x2e, 0x12, 0x79,
-	0xa5, 0x41, 0xad, 0x47, 0x11, 0x5f, 0x2c, 0x78, 0xec, 0xe8, 0x45, 0x3b, 0xad, 0x9f, 0x65, 0xd8,
-	0x09, 0x50, 0xac, 0x50, 0x04, 0x32, 0x94, 0xcb, 0x94, 0x1c, 0x41, 0x35, 0x55, 0xbb, 0x66, 0xf9,
-	0x59, 0xf9, 0x79, 0xa3, 0xfd, 0x54, 0x03, 0x53, 0x7b, 0x1d, 0x65, 0xeb, 0xe5, 0x3d, 0x9f, 0xa1,
-	0x9f, 0xc3, 0xad, 0x2f, 0x00, 0xd7, 0x5e, 0xf2, 0x00, 0x8c, 0xb1, 0xd7, 0x73, 0x3f, 0x7c, 0xf4,
-	0xdc, 0x9e, 0x59, 0x22, 0x75, 0xa8, 0x05, 0x67, 0x1d, 0xff, 0xcc, 0xed, 0x99, 0x65, 0x6d, 0x0c,
-	0x47, 0x23, 0xb7, 0x67, 0x6e, 0x11, 0x80, 0xea, 0xa8, 0x33, 0x0e, 0xdc, 0x9e, 0xb9, 0x4d, 0x0c,
-	0xa8, 0xb8, 0xbe, 0x3f, 0xf4, 0xcd, 0x7b, 0x19, 0x66, 0xec, 0x7d, 0xf2, 0x86, 0x9f, 0x3d, 0xb3,
-	0x62, 0x9d, 0xc2, 0xee, 0x80, 0xd3, 0x01, 0xae, 0x90, 0xf9, 0x78, 0xb9, 0xc4, 0x54, 0x92, 0x27,
-	0x00, 0x8c, 0xd3, 0xc9, 0x82, 0xcf, 0x96, 0x0c, 0x55, 0xaa, 0x86, 0x6f, 0x30, 0x4e, 0x4f, 0x95,
-	0x83, 0x1c, 0x42, 0x66, 0x4c, 0x58, 0x46, 0x69, 0x6e, 0xa9, 0xd3, 0xfb, 0x2c, 0x97, 0xb0, 0x3c,
-	0x30, 0xaf, 0xe5, 0xd2, 0x84, 0xc7, 0x29, 0xde, 0x49, 0xef, 0x25, 0x34, 0x06, 0x9c, 0x06, 0x09,
-	0x46, 0x45, 0x76, 0x8f, 0x21, 0x3b, 0x9d, 0xa4, 0x09, 0x46, 0xb9, 0x56, 0x8d, 0x69, 0x84, 0xd5,
-	0x55, 0xb5, 0x68, 0x70, 0x1e, 0xfb, 0xdf, 0x68, 0xb2, 0x07, 0x15, 0x14, 0x82, 0x8b, 0x3c, 0xa6,
-	0x36, 0xac, 0x1f, 0x65, 0x68, 0x74, 0xb2, 0xf1, 0x0f, 0x13, 0x14, 0xa1, 0x9c, 0xf3, 0x98, 0xbc,
-	0x82, 0x2a, 0xe3, 0xd4, 0xc7, 0x4b, 0xa5, 0x50, 0x6f, 0x1f, 0x14, 0x63, 0xfb, 0xab, 0x71, 0x27,
-	0x25, 0x3f, 0x07, 0x92, 0xb7, 0xaa, 0xe4, 0x3c, 0x6d, 0x15, 0xa0, 0xde, 0xde, 0x5f, 0xa3, 0xad,
-	0x15, 0x74, 0x52, 0xf2, 0xd7, 0xb0, 0x5d, 0x03, 0x6a, 0x11, 0x8f, 0x25, 0xc6, 0xb2, 0xfd, 0x6b,
-	0x1b, 0x2a, 0x2a, 0x15, 0xf2, 0x06, 0x8c, 0x3e, 0xca, 0xfc, 0x16, 0x99, 0x76, 0x7e, 0xcb, 0xdc,
-	0x78, 0x85, 0x8c, 0x27, 0xd8, 0xda, 0xdb, 0x74, 0x8f, 0xac, 0x12, 0x39, 0x82, 0x7a, 0x20, 0x43,
-	0x21, 0xb5, 0xfb, 0x3f, 0x88, 0x1d, 0x78, 0xd8, 0x47, 0xa9, 0xe7, 0x53, 0x14, 0xb9, 0x81, 0xde,
-	0xbc, 0xd9, 0x08, 0xdd, 0x76, 0x2d, 0x11, 0xdc, 0x51, 0xe2, 0x1d, 0xec, 0xfa, 0xb8, 0x42, 0x21,
-	0x8b, 0xb3, 0x4d, 0xb5, 0xef, 0xdb, 0xfa, 0x5d, 0xda, 0xc5, 0xbb, 0xb4, 0xdd, 0xec, 0x5d, 0x5a,
-	0x25, 0x72, 0x0c, 0xd0, 0x47, 0x99, 0x37, 0x7b, 0x03, 0xf3, 0xe0, 0xc6, 0x3c, 0xfe, 0x44, 0x3e,
-	0x06, 0x08, 0x6e, 0x47, 0xed, 0x7e, 0x03, 0x8b, 0x0b, 0x6a, 0x5f, 0x5c, 0x25, 0x28, 0x18, 0xce,
-	0x28, 0x0a, 0xfb, 0x3c, 0x9c, 0x8a, 0x79, 0x54, 0x50, 0xb2, 0x0f, 0xa6, 0xbb, 0xa3, 0xe6, 0x3a,
-	0x0a, 0xa3, 0xef, 0x21, 0xc5, 0xaf, 0x2f, 0xe8, 0x5c, 0x5e, 0x2c, 0xa7, 0x59, 0x18, 0x67, 0x8d,
-	0xe8, 0x68, 0xa2, 0xfe, 0x71, 0x52, 0x27, 0x23, 0x4e, 0xf5, 0x6f, 0xf4, 0xfa, 0x77, 0x00, 0x00,
-	0x00, 0xff, 0xff, 0xb0, 0x77, 0x16, 0x8f, 0xa8, 0x04, 0x00, 0x00,
+	// 1420 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x57, 0xeb, 0x6e, 0xdb, 0xc6,
+	0x12, 0x16, 0x25, 0xdf, 0x38, 0xf2, 0xb1, 0x95, 0x8d, 0x9d, 0xf0, 0x38, 0x17, 0x1b, 0x3c, 0x07,
+	0x38, 0x0e, 0x02, 0xc8, 0x39, 0x3e, 0xa7, 0x48, 0xd2, 0x22, 0x45, 0xe5, 0x48, 0xb1, 0xdd, 0xd8,
+	0xb2, 0x40, 0x39, 0x08, 0x52, 0xa0, 0x10, 0x28, 0x72, 0x4c, 0x2d, 0xcc, 0x5b, 0xb8, 0x2b, 0xc1,
+	0x6e, 0x7f, 0xb5, 0x4f, 0xd0, 0x1f, 0x7d, 0x81, 0xf6, 0x59, 0xfa, 0x1a, 0x7d, 0x97, 0x62, 0x77,
+	0x49, 0x5d, 0x68, 0xc9, 0x71, 0x92, 0xfe, 0x12, 0xf7, 0x9b, 0x99, 0x6f, 0x66, 0x67, 0x67, 0x66,
+	0x57, 0x50, 0x89, 0x11, 0x93, 0x1d, 0xdb, 0x0d, 0x68, 0x58, 0x8d, 0x93, 0x88, 0x47, 0x64, 0x41,
+	0xfe, 0xb0, 0x8d, 0x7b, 0x5e, 0x14, 0x79, 0x3e, 0xee, 0xc8, 0x65, 0xb7, 0x7f, 0xb6, 0x83, 0x41,
+	0xcc, 0x2f, 0x95, 0xd2, 0xc6, 0x66, 0x5e, 0xc8, 0x69, 0x80, 0x8c, 0xdb, 0x41, 0x9c, 0x2a, 0xdc,
+	0x76, 0xa2, 0x20, 0x88, 0xc2, 0x1d, 0xf5, 0xa3, 0x40, 0xf3, 0x77, 0x0d, 0x96, 0xdb, 0x98, 0x0c,
+	0x30, 0x69, 0x73, 0x9b, 0xf7, 0x19, 0x79, 0x0a, 0x0b, 0x4c, 0x7e, 0x19, 0xda, 0x96, 0xb6, 0xbd,
+	0xb2, 0xbb, 0xa9, 0x14, 0x59, 0x75, 0x5c, 0xab, 0xaa, 0x7e, 0x5e, 0x46, 0x2e, 0x5a, 0xa9, 0xba,
+	0xf9, 0x0e, 0x60, 0x84, 0x92, 0x7f, 0x80, 0xfe, 0xa6, 0x59, 0x6f, 0xbc, 0x3a, 0x6c, 0x36, 0xea,
+	0x95, 0x02, 0x29, 0xc3, 0x62, 0xfb, 0xb4, 0x66, 0x9d, 0x36, 0xea, 0x15, 0x4d, 0x2d, 0x4e, 0x5a,
+	0xad, 0x46, 0xbd, 0x52, 0x24, 0x00, 0x0b, 0xad, 0xda, 0x9b, 0x76, 0xa3, 0x5e, 0x29, 0x11, 0x1d,
+	0xe6, 0x1b, 0x96, 0x75, 0x62, 0x55, 0xe6, 0x84, 0xce, 0x9b, 0xe6, 0xeb, 0xe6, 0xc9, 0xdb, 0x66,
+	0x65, 0xde, 0x3c, 0x86, 0xd5, 0xa3, 0xc8, 0x3b, 0xc2, 0x01, 0xfa, 0x16, 0xbe, 0xef, 0x23, 0xe3,
+	0xe4, 0x01, 0x80, 0x1f, 0x79, 0x9d, 0x20, 0x72, 0xfb, 0x3e, 0xca, 0x50, 0x75, 0x4b, 0xf7, 0x23,
+	0xef, 0x58, 0x02, 0xe4, 0x1e, 0x88, 0x45, 0xc7, 0x17, 0x26, 0x46, 0x51, 0x4a, 0x97, 0xfc, 0x94,
+	0xc2, 0x6c, 0x42, 0x65, 0x44, 0xc7, 0xe2, 0x28, 0x64, 0xf8, 0x59, 0x7c, 0x8f, 0x61, 0xe5, 0x28,
+	0xf2, 0xda, 0x31, 0x3a, 0x59, 0x74, 0xff, 0x04, 0x21, 0xed, 0xb0, 0x18, 0x9d, 0x94, 0x6b, 0xd1,
+	0x57, 0x1a, 0xe6, 0x9e, 0xdc, 0x8b, 0x52, 0x4e, 0x7d, 0xcf, 0xd6, 0x26, 0x6b, 0x30, 0x8f, 0x49,
+	0x12, 0x25, 0xa9, 0x4f, 0xb5, 0x30, 0x9b, 0x70, 0xf7, 0x34, 0xb1, 0x43, 0x46, 0x31, 0xe4, 0x8d,
+	0x90, 0x27, 0x14, 0xd9, 0x58, 0x5e, 0x9c, 0x9e, 0x1d, 0x86, 0xe8, 0x77, 0xa8, 0x9b, 0xed, 0x23,
+	0x45, 0x0e, 0x5d, 0xc1, 0xc7, 0x2f, 0xa8, 0xcb, 0x8c, 0xe2, 0x56, 0x49, 0xf0, 0xc9, 0x85, 0xf9,
+	0x87, 0x06, 0x2b, 0x13, 0x84, 0x97, 0x84, 0xc0, 0x1c, 0xbf, 0x18, 0x32, 0xc8, 0x6f, 0x81, 0xf5,
+	0xfb, 0xd4, 0x4d, 0x63, 0x91, 0xdf, 0xe4, 0x29, 0x18, 0x09, 0x3a, 0x48, 0x07, 0xe8, 0x76, 0x6c,
+	0xde, 0xe9, 0xfa, 0x91, 0x73, 0xde, 0xe9, 0x21, 0xf5, 0x7a, 0xdc, 0x28, 0x6d, 0x69, 0xdb, 0x73,
+	0xd6, 0x7a, 0x26, 0xaf, 0xf1, 0x3d, 0x21, 0x3d, 0x90, 0x42, 0xf2, 0x02, 0x96, 0x63, 0x4c, 0x18,
+	0x65, 0x5c, 0x5a, 0x1a, 0x73, 0x5b, 0xda, 0x76, 0x79, 0x77, 0xa3, 0xaa, 0xaa, 0xb8, 0x9a, 0x55,
+	0x71, 0xf5, 0x34, 0xab, 0x62, 0xab, 0x3c, 0xd4, 0xaf, 0x71, 0x11, 0x0b, 0xa3, 0x3f, 0xa0, 0x31,
+	0x2f, 0x7d, 0xc8, 0x6f, 0xf3, 0x1c, 0x8c, 0xab, 0x69, 0x49, 0x73, 0xfc, 0x04, 0x16, 0x51, 0x41,
+	0x86, 0xb6, 0x55, 0xda, 0x2e, 0xef, 0xde, 0xc9, 0xea, 0x7a, 0x72, 0xe3, 0x56, 0xa6, 0x26, 0x32,
+	0xc9, 0x23, 0x6e, 0xfb, 0x1d, 0xe9, 0xa7, 0x28, 0xfd, 0xe8, 0x12, 0x69, 0x0b, 0x67, 0x3f, 0xc2,
+	0xfd, 0xd6, 0x80, 0xd7, 0x6d, 0x6e, 0x5b, 0xe8, 0x44, 0xa1, 0x43, 0x7d, 0x6a, 0x73, 0x1a, 0x85,
+	0x37, 0x3c, 0x88, 0x4d, 0x28, 0x33, 0x6e, 0x27, 0x69, 0xc6, 0x52, 0x7a, 0x90, 0x90, 0xcc, 0x92,
+	0xa8, 0x38, 0x0c, 0xdd, 0x54, 0xac, 0x32, 0xb9, 0x84, 0xa1, 0x2b, 0x85, 0xe6, 0xcf, 0x1a, 0xac,
+	0x1c, 0x53, 0xc6, 0x68, 0xe8, 0xa5, 0x41, 0x08, 0x7d, 0x95, 0xfc, 0xb0, 0x1f, 0x48, 0x77, 0x73,
+	0xd6, 0x92, 0x04, 0x9a, 0xfd, 0x80, 0xac, 0xc3, 0x02, 0xbf, 0x90, 0x12, 0xe5, 0x68, 0x9e, 0x5f,
+	0x08, 0xf8, 0x3e, 0xe8, 0xa1, 0x1d, 0x20, 0x8b, 0x6d, 0x07, 0xa5, 0x0f, 0xdd, 0x1a, 0x01, 0xe4,
+	0x21, 0x80, 0x13, 0xf9, 0x3e, 0x3a, 0x62, 0x5b, 0xf2, 0x7c, 0x74, 0x6b, 0x0c, 0x31, 0xbf, 0x85,
+	0x3b, 0x93, 0x31, 0x8c, 0x27, 0x3b, 0x50, 0x92, 0x7c, 0xb2, 0x73, 0x06, 0x99, 0x9a, 0xf9, 0xd3,
+	0x3c, 0xdc, 0x9b, 0x9a, 0xce, 0x74, 0x2a, 0xe5, 0xd2, 0xa5, 0x5d, 0x9f, 0xae, 0xe2, 0x64, 0xba,
+	0x84, 0x35, 0x0d, 0x3b, 0x71, 0x12, 0x79, 0x09, 0x32, 0x26, 0x77, 0xba, 0x64, 0x01, 0x0d, 0x5b,
+	0x29, 0x42, 0x1e, 0x41, 0x25, 0x4e, 0x22, 0x07, 0x19, 0xc3, 0x94, 0x83, 0xc9, 0x0d, 0xcf, 0x59,
+	0xab, 0x43, 0x5c, 0x52, 0x31, 0xf2, 0x04, 0xd6, 0x7c, 0x9b, 0xf1, 0x4e, 0x4e, 0x3f, 0x2d, 0x44,
+	0x22, 0x64, 0xad, 0x09, 0x13, 0x41, 0x9e, 0xa4, 0x7b, 0x42, 0xb7, 0x43, 0x39, 0x06, 0xcc, 0x58,
+	0x50, 0xe4, 0x23, 0xfc, 0x50, 0xc0, 0xe4, 0x31, 0xdc, 0xea, 0x87, 0xf6, 0xc0, 0xa6, 0xbe, 0xdd,
+	0xf5, 0x31, 0xd5, 0x5d, 0x94, 0xba, 0x95, 0x31, 0x81, 0x52, 0xfe, 0x0f, 0xac, 0xf6, 0x6c, 0xd6,
+	0xeb, 0x04, 0x94, 0x05, 0x36, 0x77, 0x7a, 0xc8, 0x8c, 0x25, 0xa9, 0xba, 0x22, 0xe0, 0xe3, 0x21,
+	0x4a, 0xde, 0xc2, 0xf2, 0x99, 0x2d, 0x9d, 0x8b, 0x9b, 0x85, 0x19, 0xba, 0x3c, 0x93, 0xff, 0x67,
+	0x67, 0x72, 0x4d, 0xde, 0xab, 0xaf, 0xa4, 0x5d, 0x4b, 0x98, 0xa9, 0xf6, 0x28, 0x9f, 0x8d, 0x10,
+	0xf2, 0x1c, 0xd4, 0x11, 0xa8, 0x0e, 0x86, 0x0f, 0x76, 0xb0, 0x9e, 0x6a, 0xd7, 0x38, 0xf9, 0x0a,
+	0xca, 0x67, 0x34, 0xa4, 0xac, 0xa7, 0x6c, 0xcb, 0x1f, 0xb4, 0x85, 0x4c, 0xbd, 0xc6, 0x47, 0x53,
+	0x71, 0x79, 0x6c, 0x2a, 0x6e, 0x7c, 0x0d, 0x95, 0x7c, 0xb8, 0xa4, 0x02, 0xa5, 0x73, 0xbc, 0x4c,
+	0xdb, 0x4f, 0x7c, 0x0a, 0xdb, 0x81, 0xed, 0xf7, 0xb3, 0x8e, 0x56, 0x8b, 0x2f, 0x8b, 0xcf, 0x34,
+	0xf3, 0x4f, 0x0d, 0xd6, 0x5f, 0xf6, 0x6c, 0x1a, 0x3a, 0x91, 0x8b, 0x87, 0xa1, 0x8b, 0x17, 0x37,
+	0xec, 0xe5, 0xfb, 0xa0, 0x3b, 0x99, 0x5d, 0x3a, 0x1c, 0x47, 0x40, 0xae, 0x8d, 0x4a, 0xf9, 0x36,
+	0x12, 0xe4, 0x2e, 0x32, 0xea, 0x85, 0x1d, 0x37, 0x72, 0xd2, 0x36, 0xd3, 0x15, 0x52, 0x8f, 0x1c,
+	0x21, 0xa6, 0x22, 0x96, 0x8e, 0x68, 0x4c, 0x59, 0x65, 0xba, 0xa5, 0x4b, 0xa4, 0x69, 0x07, 0x28,
+	0x8a, 0x4b, 0x89, 0x5d, 0x14, 0x09, 0x92, 0x3e, 0x44, 0x71, 0x2d, 0x5b, 0xab, 0x12, 0xaf, 0x0f,
+	0x61, 0xf3, 0x37, 0x0d, 0x56, 0x26, 0xf7, 0x37, 0x19, 0xb9, 0x76, 0x7d, 0xe4, 0xc5, 0x0f, 0x44,
+	0x5e, 0xca, 0x47, 0x4e, 0x60, 0x4e, 0xc6, 0xac, 0xb6, 0x24, 0xbf, 0x05, 0xe5, 0x58, 0xa0, 0x6a,
+	0x37, 0x63, 0x88, 0x79, 0x04, 0xc6, 0x64, 0x88, 0x93, 0x23, 0x9c, 0x2a, 0x28, 0x3f, 0x55, 0x72,
+	0xa7, 0x96, 0xa9, 0x99, 0xbf, 0x94, 0x60, 0xa5, 0x26, 0xde, 0x51, 0x27, 0x31, 0x26, 0xb2, 0xac,
+	0xc9, 0x7f, 0x61, 0xc1, 0x8f, 0x3c, 0x0b, 0xdf, 0xcb, 0xed, 0x96, 0x77, 0xef, 0x66, 0x1c, 0xb9,
+	0x07, 0xc6, 0x41, 0xc1, 0x4a, 0x15, 0xc9, 0x33, 0x80, 0xf4, 0x3a, 0x16, 0x66, 0xc5, 0x2d, 0x6d,
+	0xdc, 0xf5, 0xe4, 0xc5, 0x7f, 0x50, 0xb0, 0xc6, 0x74, 0x49, 0x1b, 0x6e, 0xf3, 0xab, 0xf7, 0xb4,
+	0xcc, 0x54, 0x79, 0x77, 0x73, 0xea, 0x05, 0x34, 0xba, 0xca, 0x0f, 0x0a, 0xd6, 0x34, 0x6b, 0xd2,
+	0x05, 0x23, 0x9e, 0x71, 0xf1, 0xa4, 0x97, 0xe8, 0xbf, 0xaf, 0xed, 0xec, 0x11, 0xfd, 0x4c, 0x1e,
+	0x72, 0x0c, 0xb7, 0x9c, 0x7c, 0x27, 0xc8, 0xd3, 0x2a, 0xef, 0x3e, 0x98, 0x91, 0xf4, 0x21, 0xeb,
+	0x55, 0xcb, 0x3d, 0x1d, 0x16, 0x9d, 0x28, 0xe4, 0x18, 0xf2, 0xdd, 0x5f, 0x97, 0x60, 0x5e, 0x1e,
+	0x09, 0xf9, 0x02, 0xf4, 0x7d, 0xe4, 0xe9, 0x7c, 0xaf, 0x54, 0xd3, 0x57, 0x69, 0x23, 0x1c, 0xa0,
+	0x1f, 0xc5, 0xb8, 0xb1, 0x36, 0xed, 0xdd, 0x69, 0x16, 0xc8, 0x53, 0x28, 0xb7, 0xc5, 0x14, 0x51,
+	0xf0, 0x47, 0x18, 0xd6, 0xe0, 0xd6, 0x3e, 0x72, 0xf5, 0x9e, 0xcb, 0x0e, 0x7b, 0x8a, 0xb9, 0x71,
+	0xb5, 0x20, 0x54, 0xfd, 0x29, 0x8a, 0xf6, 0x67, 0x52, 0xbc, 0x80, 0x55, 0x0b, 0x07, 0x98, 0xf0,
+	0x4c, 0x36, 0x6d, 0xef, 0x77, 0xae, 0xcc, 0xc1, 0x86, 0x78, 0xe8, 0x9b, 0x05, 0x31, 0x71, 0xf7,
+	0x91, 0xa7, 0x45, 0x37, 0xc5, 0xf2, 0xee, 0x95, 0xba, 0x1c, 0x7a, 0x7e, 0x0e, 0xd0, 0xfe, 0x44,
+	0xd3, 0xd7, 0x70, 0x7b, 0x1f, 0x79, 0xbe, 0x4e, 0xa7, 0x70, 0x6c, 0xcd, 0xae, 0xe9, 0x21, 0xd9,
+	0x31, 0xac, 0xb7, 0xfa, 0x89, 0x87, 0x7f, 0x13, 0xdd, 0xbe, 0x3a, 0xd6, 0xc9, 0xc7, 0xd0, 0x55,
+	0xaa, 0x87, 0x33, 0x5e, 0x20, 0xe3, 0x9b, 0xac, 0x64, 0x8d, 0x80, 0xb3, 0x79, 0xfe, 0x75, 0x83,
+	0x5b, 0xd3, 0x2c, 0x90, 0x77, 0xf0, 0x70, 0x1f, 0xf9, 0x35, 0x3a, 0x9f, 0x4e, 0x7d, 0x04, 0x6b,
+	0x47, 0x94, 0xf1, 0xfc, 0x98, 0xbc, 0x2e, 0x7d, 0xb3, 0x46, 0xaa, 0x59, 0x20, 0xdf, 0x00, 0xa9,
+	0x27, 0x51, 0x3c, 0xa9, 0xf1, 0x51, 0x25, 0xf9, 0x12, 0xd6, 0x2d, 0xec, 0xf6, 0xa9, 0xef, 0xde,
+	0x80, 0x64, 0x6a, 0x40, 0x66, 0x61, 0xef, 0x7b, 0x30, 0xa3, 0xc4, 0xab, 0xf6, 0x2e, 0x63, 0x4c,
+	0x7c, 0x74, 0x3d, 0x4c, 0xaa, 0x67, 0x76, 0x37, 0xa1, 0x4e, 0x66, 0x21, 0x5e, 0x2e, 0x7b, 0xcb,
+	0x72, 0x72, 0xb4, 0x6c, 0xe7, 0xdc, 0xf6, 0xf0, 0xbb, 0x47, 0x1e, 0xe5, 0xbd, 0x7e, 0x57, 0x78,
+	0xd9, 0x19, 0x33, 0xdc, 0x51, 0x86, 0xea, 0x7f, 0x30, 0xdb, 0x11, 0x86, 0x5d, 0xf5, 0x07, 0xfa,
+	0x7f, 0x7f, 0x0d, 0x00, 0xfb, 0xfe, 0xcb, 0x53, 0x5b, 0x0f, 0x00, 0x00,
 }
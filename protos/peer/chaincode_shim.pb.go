@@ -27,27 +27,31 @@ const _ = proto.ProtoPackageIsVersion2 // please upgrade the proto package
 type ChaincodeMessage_Type int32
 
 const (
-	ChaincodeMessage_UNDEFINED           ChaincodeMessage_Type = 0
-	ChaincodeMessage_REGISTER            ChaincodeMessage_Type = 1
-	ChaincodeMessage_REGISTERED          ChaincodeMessage_Type = 2
-	ChaincodeMessage_INIT                ChaincodeMessage_Type = 3
-	ChaincodeMessage_READY               ChaincodeMessage_Type = 4
-	ChaincodeMessage_TRANSACTION         ChaincodeMessage_Type = 5
-	ChaincodeMessage_COMPLETED           ChaincodeMessage_Type = 6
-	ChaincodeMessage_ERROR               ChaincodeMessage_Type = 7
-	ChaincodeMessage_GET_STATE           ChaincodeMessage_Type = 8
-	ChaincodeMessage_PUT_STATE           ChaincodeMessage_Type = 9
-	ChaincodeMessage_DEL_STATE           ChaincodeMessage_Type = 10
-	ChaincodeMessage_INVOKE_CHAINCODE    ChaincodeMessage_Type = 11
-	ChaincodeMessage_RESPONSE            ChaincodeMessage_Type = 13
-	ChaincodeMessage_GET_STATE_BY_RANGE  ChaincodeMessage_Type = 14
-	ChaincodeMessage_GET_QUERY_RESULT    ChaincodeMessage_Type = 15
-	ChaincodeMessage_QUERY_STATE_NEXT    ChaincodeMessage_Type = 16
-	ChaincodeMessage_QUERY_STATE_CLOSE   ChaincodeMessage_Type = 17
-	ChaincodeMessage_KEEPALIVE           ChaincodeMessage_Type = 18
-	ChaincodeMessage_GET_HISTORY_FOR_KEY ChaincodeMessage_Type = 19
-	ChaincodeMessage_GET_STATE_METADATA  ChaincodeMessage_Type = 20
-	ChaincodeMessage_PUT_STATE_METADATA  ChaincodeMessage_Type = 21
+	ChaincodeMessage_UNDEFINED                    ChaincodeMessage_Type = 0
+	ChaincodeMessage_REGISTER                     ChaincodeMessage_Type = 1
+	ChaincodeMessage_REGISTERED                   ChaincodeMessage_Type = 2
+	ChaincodeMessage_INIT                         ChaincodeMessage_Type = 3
+	ChaincodeMessage_READY                        ChaincodeMessage_Type = 4
+	ChaincodeMessage_TRANSACTION                  ChaincodeMessage_Type = 5
+	ChaincodeMessage_COMPLETED                    ChaincodeMessage_Type = 6
+	ChaincodeMessage_ERROR                        ChaincodeMessage_Type = 7
+	ChaincodeMessage_GET_STATE                    ChaincodeMessage_Type = 8
+	ChaincodeMessage_PUT_STATE                    ChaincodeMessage_Type = 9
+	ChaincodeMessage_DEL_STATE                    ChaincodeMessage_Type = 10
+	ChaincodeMessage_INVOKE_CHAINCODE             ChaincodeMessage_Type = 11
+	ChaincodeMessage_RESPONSE                     ChaincodeMessage_Type = 13
+	ChaincodeMessage_GET_STATE_BY_RANGE           ChaincodeMessage_Type = 14
+	ChaincodeMessage_GET_QUERY_RESULT             ChaincodeMessage_Type = 15
+	ChaincodeMessage_QUERY_STATE_NEXT             ChaincodeMessage_Type = 16
+	ChaincodeMessage_QUERY_STATE_CLOSE            ChaincodeMessage_Type = 17
+	ChaincodeMessage_KEEPALIVE                    ChaincodeMessage_Type = 18
+	ChaincodeMessage_GET_HISTORY_FOR_KEY          ChaincodeMessage_Type = 19
+	ChaincodeMessage_GET_STATE_METADATA           ChaincodeMessage_Type = 20
+	ChaincodeMessage_PUT_STATE_METADATA           ChaincodeMessage_Type = 21
+	ChaincodeMessage_GET_STATE_AT_HEIGHT          ChaincodeMessage_Type = 22
+	ChaincodeMessage_GET_STATE_BY_RANGE_AT_HEIGHT ChaincodeMessage_Type = 23
+	ChaincodeMessage_GET_PRIVATE_DATA_HASH        ChaincodeMessage_Type = 24
+	ChaincodeMessage_PURGE_PRIVATE_DATA           ChaincodeMessage_Type = 25
 )
 
 var ChaincodeMessage_Type_name = map[int32]string{
@@ -72,29 +76,37 @@ var ChaincodeMessage_Type_name = map[int32]string{
 	19: "GET_HISTORY_FOR_KEY",
 	20: "GET_STATE_METADATA",
 	21: "PUT_STATE_METADATA",
+	22: "GET_STATE_AT_HEIGHT",
+	23: "GET_STATE_BY_RANGE_AT_HEIGHT",
+	24: "GET_PRIVATE_DATA_HASH",
+	25: "PURGE_PRIVATE_DATA",
 }
 var ChaincodeMessage_Type_value = map[string]int32{
-	"UNDEFINED":           0,
-	"REGISTER":            1,
-	"REGISTERED":          2,
-	"INIT":                3,
-	"READY":               4,
-	"TRANSACTION":         5,
-	"COMPLETED":           6,
-	"ERROR":               7,
-	"GET_STATE":           8,
-	"PUT_STATE":           9,
-	"DEL_STATE":           10,
-	"INVOKE_CHAINCODE":    11,
-	"RESPONSE":            13,
-	"GET_STATE_BY_RANGE":  14,
-	"GET_QUERY_RESULT":    15,
-	"QUERY_STATE_NEXT":    16,
-	"QUERY_STATE_CLOSE":   17,
-	"KEEPALIVE":           18,
-	"GET_HISTORY_FOR_KEY": 19,
-	"GET_STATE_METADATA":  20,
-	"PUT_STATE_METADATA":  21,
+	"UNDEFINED":                    0,
+	"REGISTER":                     1,
+	"REGISTERED":                   2,
+	"INIT":                         3,
+	"READY":                        4,
+	"TRANSACTION":                  5,
+	"COMPLETED":                    6,
+	"ERROR":                        7,
+	"GET_STATE":                    8,
+	"PUT_STATE":                    9,
+	"DEL_STATE":                    10,
+	"INVOKE_CHAINCODE":             11,
+	"RESPONSE":                     13,
+	"GET_STATE_BY_RANGE":           14,
+	"GET_QUERY_RESULT":             15,
+	"QUERY_STATE_NEXT":             16,
+	"QUERY_STATE_CLOSE":            17,
+	"KEEPALIVE":                    18,
+	"GET_HISTORY_FOR_KEY":          19,
+	"GET_STATE_METADATA":           20,
+	"PUT_STATE_METADATA":           21,
+	"GET_STATE_AT_HEIGHT":          22,
+	"GET_STATE_BY_RANGE_AT_HEIGHT": 23,
+	"GET_PRIVATE_DATA_HASH":        24,
+	"PURGE_PRIVATE_DATA":           25,
 }
 
 func (x ChaincodeMessage_Type) String() string {
@@ -196,7 +208,9 @@ func (m *ChaincodeMessage) GetChannelId() string {
 
 // GetState is the payload of a ChaincodeMessage. It contains a key which
 // is to be fetched from the ledger. If the collection is specified, the key
-// would be fetched from the collection (i.e., private state)
+// would be fetched from the collection (i.e., private state). GetState is
+// also the payload of GET_PRIVATE_DATA_HASH, in which case the hash of the
+// value of the key in the collection is fetched.
 type GetState struct {
 	Key                  string   `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
 	Collection           string   `protobuf:"bytes,2,opt,name=collection,proto3" json:"collection,omitempty"`
@@ -404,7 +418,9 @@ func (m *PutStateMetadata) GetMetadata() *StateMetadata {
 // DelState is the payload of a ChaincodeMessage. It contains a key which
 // needs to be recorded in the transaction's write set as a delete operation.
 // If the collection is specified, the key needs to be recorded in the
-// transaction's private write set as a delete operation.
+// transaction's private write set as a delete operation. DelState is also
+// the payload of PURGE_PRIVATE_DATA, in which case the key is recorded as
+// a purge of the key in the collection.
 type DelState struct {
 	Key                  string   `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
 	Collection           string   `protobuf:"bytes,2,opt,name=collection,proto3" json:"collection,omitempty"`
@@ -575,7 +591,7 @@ func (m *GetQueryResult) GetMetadata() []byte {
 	return nil
 }
 
-// QueryMetadata is the metadata of a GetStateByRange and GetQueryResult.
+// QueryMetadata is the metadata of a GetStateByRange, GetQueryResult, and GetHistoryForKey.
 // It contains a pageSize which denotes the number of records to be fetched
 // and a bookmark.
 type QueryMetadata struct {
@@ -625,9 +641,19 @@ func (m *QueryMetadata) GetBookmark() string {
 }
 
 // GetHistoryForKey is the payload of a ChaincodeMessage. It contains a key
-// for which the historical values need to be retrieved.
+// for which the historical values need to be retrieved. The start_block and
+// the end_block (both inclusive) restrict the history to the modifications
+// committed in the given block range. A zero end_block implies no upper bound.
+// If descending is set, the newest modification is returned first. The metadata
+// hold the byte representation of QueryMetadata. If the collection is specified,
+// the history of the hashed private writes of the key is retrieved instead.
 type GetHistoryForKey struct {
 	Key                  string   `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	StartBlock           uint64   `protobuf:"varint,2,opt,name=start_block,json=startBlock,proto3" json:"start_block,omitempty"`
+	EndBlock             uint64   `protobuf:"varint,3,opt,name=end_block,json=endBlock,proto3" json:"end_block,omitempty"`
+	Descending           bool     `protobuf:"varint,4,opt,name=descending,proto3" json:"descending,omitempty"`
+	Metadata             []byte   `protobuf:"bytes,5,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	Collection           string   `protobuf:"bytes,6,opt,name=collection,proto3" json:"collection,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -664,6 +690,147 @@ func (m *GetHistoryForKey) GetKey() string {
 	return ""
 }
 
+func (m *GetHistoryForKey) GetStartBlock() uint64 {
+	if m != nil {
+		return m.StartBlock
+	}
+	return 0
+}
+
+func (m *GetHistoryForKey) GetEndBlock() uint64 {
+	if m != nil {
+		return m.EndBlock
+	}
+	return 0
+}
+
+func (m *GetHistoryForKey) GetDescending() bool {
+	if m != nil {
+		return m.Descending
+	}
+	return false
+}
+
+func (m *GetHistoryForKey) GetMetadata() []byte {
+	if m != nil {
+		return m.Metadata
+	}
+	return nil
+}
+
+func (m *GetHistoryForKey) GetCollection() string {
+	if m != nil {
+		return m.Collection
+	}
+	return ""
+}
+
+// GetStateAtHeight is the payload of a ChaincodeMessage. It contains a key
+// and a block number. The value of the key as of the given block number
+// (i.e., after the block is committed) needs to be retrieved.
+type GetStateAtHeight struct {
+	Key                  string   `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	BlockNum             uint64   `protobuf:"varint,2,opt,name=block_num,json=blockNum,proto3" json:"block_num,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetStateAtHeight) Reset()         { *m = GetStateAtHeight{} }
+func (m *GetStateAtHeight) String() string { return proto.CompactTextString(m) }
+func (*GetStateAtHeight) ProtoMessage()    {}
+func (*GetStateAtHeight) Descriptor() ([]byte, []int) {
+	return fileDescriptor_chaincode_shim_3c6cdf1ba834f673, []int{10}
+}
+func (m *GetStateAtHeight) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetStateAtHeight.Unmarshal(m, b)
+}
+func (m *GetStateAtHeight) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetStateAtHeight.Marshal(b, m, deterministic)
+}
+func (dst *GetStateAtHeight) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetStateAtHeight.Merge(dst, src)
+}
+func (m *GetStateAtHeight) XXX_Size() int {
+	return xxx_messageInfo_GetStateAtHeight.Size(m)
+}
+func (m *GetStateAtHeight) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetStateAtHeight.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetStateAtHeight proto.InternalMessageInfo
+
+func (m *GetStateAtHeight) GetKey() string {
+	if m != nil {
+		return m.Key
+	}
+	return ""
+}
+
+func (m *GetStateAtHeight) GetBlockNum() uint64 {
+	if m != nil {
+		return m.BlockNum
+	}
+	return 0
+}
+
+// GetStateByRangeAtHeight is the payload of a ChaincodeMessage. It contains
+// a start key, an end key, and a block number required to execute a range
+// query on the state as of the given block number.
+type GetStateByRangeAtHeight struct {
+	StartKey             string   `protobuf:"bytes,1,opt,name=start_key,json=startKey,proto3" json:"start_key,omitempty"`
+	EndKey               string   `protobuf:"bytes,2,opt,name=end_key,json=endKey,proto3" json:"end_key,omitempty"`
+	BlockNum             uint64   `protobuf:"varint,3,opt,name=block_num,json=blockNum,proto3" json:"block_num,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetStateByRangeAtHeight) Reset()         { *m = GetStateByRangeAtHeight{} }
+func (m *GetStateByRangeAtHeight) String() string { return proto.CompactTextString(m) }
+func (*GetStateByRangeAtHeight) ProtoMessage()    {}
+func (*GetStateByRangeAtHeight) Descriptor() ([]byte, []int) {
+	return fileDescriptor_chaincode_shim_3c6cdf1ba834f673, []int{11}
+}
+func (m *GetStateByRangeAtHeight) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetStateByRangeAtHeight.Unmarshal(m, b)
+}
+func (m *GetStateByRangeAtHeight) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetStateByRangeAtHeight.Marshal(b, m, deterministic)
+}
+func (dst *GetStateByRangeAtHeight) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetStateByRangeAtHeight.Merge(dst, src)
+}
+func (m *GetStateByRangeAtHeight) XXX_Size() int {
+	return xxx_messageInfo_GetStateByRangeAtHeight.Size(m)
+}
+func (m *GetStateByRangeAtHeight) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetStateByRangeAtHeight.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetStateByRangeAtHeight proto.InternalMessageInfo
+
+func (m *GetStateByRangeAtHeight) GetStartKey() string {
+	if m != nil {
+		return m.StartKey
+	}
+	return ""
+}
+
+func (m *GetStateByRangeAtHeight) GetEndKey() string {
+	if m != nil {
+		return m.EndKey
+	}
+	return ""
+}
+
+func (m *GetStateByRangeAtHeight) GetBlockNum() uint64 {
+	if m != nil {
+		return m.BlockNum
+	}
+	return 0
+}
+
 type QueryStateNext struct {
 	Id                   string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
@@ -675,7 +842,7 @@ func (m *QueryStateNext) Reset()         { *m = QueryStateNext{} }
 func (m *QueryStateNext) String() string { return proto.CompactTextString(m) }
 func (*QueryStateNext) ProtoMessage()    {}
 func (*QueryStateNext) Descriptor() ([]byte, []int) {
-	return fileDescriptor_chaincode_shim_3c6cdf1ba834f673, []int{10}
+	return fileDescriptor_chaincode_shim_3c6cdf1ba834f673, []int{12}
 }
 func (m *QueryStateNext) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_QueryStateNext.Unmarshal(m, b)
@@ -713,7 +880,7 @@ func (m *QueryStateClose) Reset()         { *m = QueryStateClose{} }
 func (m *QueryStateClose) String() string { return proto.CompactTextString(m) }
 func (*QueryStateClose) ProtoMessage()    {}
 func (*QueryStateClose) Descriptor() ([]byte, []int) {
-	return fileDescriptor_chaincode_shim_3c6cdf1ba834f673, []int{11}
+	return fileDescriptor_chaincode_shim_3c6cdf1ba834f673, []int{13}
 }
 func (m *QueryStateClose) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_QueryStateClose.Unmarshal(m, b)
@@ -752,7 +919,7 @@ func (m *QueryResultBytes) Reset()         { *m = QueryResultBytes{} }
 func (m *QueryResultBytes) String() string { return proto.CompactTextString(m) }
 func (*QueryResultBytes) ProtoMessage()    {}
 func (*QueryResultBytes) Descriptor() ([]byte, []int) {
-	return fileDescriptor_chaincode_shim_3c6cdf1ba834f673, []int{12}
+	return fileDescriptor_chaincode_shim_3c6cdf1ba834f673, []int{14}
 }
 func (m *QueryResultBytes) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_QueryResultBytes.Unmarshal(m, b)
@@ -780,7 +947,7 @@ func (m *QueryResultBytes) GetResultBytes() []byte {
 }
 
 // QueryResponse is returned by the peer as a result of a GetStateByRange,
-// GetQueryResult, and GetHistoryForKey. It holds a bunch of records in
+// GetQueryResult, GetHistoryForKey, and GetStateByRangeAtHeight. It holds a bunch of records in
 // results field, a flag to denote whether more results need to be fetched from
 // the peer in has_more field, transaction id in id field, and a QueryResponseMetadata
 // in metadata field.
@@ -798,7 +965,7 @@ func (m *QueryResponse) Reset()         { *m = QueryResponse{} }
 func (m *QueryResponse) String() string { return proto.CompactTextString(m) }
 func (*QueryResponse) ProtoMessage()    {}
 func (*QueryResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_chaincode_shim_3c6cdf1ba834f673, []int{13}
+	return fileDescriptor_chaincode_shim_3c6cdf1ba834f673, []int{15}
 }
 func (m *QueryResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_QueryResponse.Unmarshal(m, b)
@@ -860,7 +1027,7 @@ func (m *QueryResponseMetadata) Reset()         { *m = QueryResponseMetadata{} }
 func (m *QueryResponseMetadata) String() string { return proto.CompactTextString(m) }
 func (*QueryResponseMetadata) ProtoMessage()    {}
 func (*QueryResponseMetadata) Descriptor() ([]byte, []int) {
-	return fileDescriptor_chaincode_shim_3c6cdf1ba834f673, []int{14}
+	return fileDescriptor_chaincode_shim_3c6cdf1ba834f673, []int{16}
 }
 func (m *QueryResponseMetadata) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_QueryResponseMetadata.Unmarshal(m, b)
@@ -906,7 +1073,7 @@ func (m *StateMetadata) Reset()         { *m = StateMetadata{} }
 func (m *StateMetadata) String() string { return proto.CompactTextString(m) }
 func (*StateMetadata) ProtoMessage()    {}
 func (*StateMetadata) Descriptor() ([]byte, []int) {
-	return fileDescriptor_chaincode_shim_3c6cdf1ba834f673, []int{15}
+	return fileDescriptor_chaincode_shim_3c6cdf1ba834f673, []int{17}
 }
 func (m *StateMetadata) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_StateMetadata.Unmarshal(m, b)
@@ -951,7 +1118,7 @@ func (m *StateMetadataResult) Reset()         { *m = StateMetadataResult{} }
 func (m *StateMetadataResult) String() string { return proto.CompactTextString(m) }
 func (*StateMetadataResult) ProtoMessage()    {}
 func (*StateMetadataResult) Descriptor() ([]byte, []int) {
-	return fileDescriptor_chaincode_shim_3c6cdf1ba834f673, []int{16}
+	return fileDescriptor_chaincode_shim_3c6cdf1ba834f673, []int{18}
 }
 func (m *StateMetadataResult) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_StateMetadataResult.Unmarshal(m, b)
@@ -989,6 +1156,8 @@ func init() {
 	proto.RegisterType((*GetQueryResult)(nil), "protos.GetQueryResult")
 	proto.RegisterType((*QueryMetadata)(nil), "protos.QueryMetadata")
 	proto.RegisterType((*GetHistoryForKey)(nil), "protos.GetHistoryForKey")
+	proto.RegisterType((*GetStateAtHeight)(nil), "protos.GetStateAtHeight")
+	proto.RegisterType((*GetStateByRangeAtHeight)(nil), "protos.GetStateByRangeAtHeight")
 	proto.RegisterType((*QueryStateNext)(nil), "protos.QueryStateNext")
 	proto.RegisterType((*QueryStateClose)(nil), "protos.QueryStateClose")
 	proto.RegisterType((*QueryResultBytes)(nil), "protos.QueryResultBytes")
@@ -1103,74 +1272,181 @@ var _ChaincodeSupport_serviceDesc = grpc.ServiceDesc{
 	Metadata: "peer/chaincode_shim.proto",
 }
 
+// ChaincodeClient is the client API for Chaincode service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type ChaincodeClient interface {
+	Connect(ctx context.Context, opts ...grpc.CallOption) (Chaincode_ConnectClient, error)
+}
+
+type chaincodeClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewChaincodeClient(cc *grpc.ClientConn) ChaincodeClient {
+	return &chaincodeClient{cc}
+}
+
+func (c *chaincodeClient) Connect(ctx context.Context, opts ...grpc.CallOption) (Chaincode_ConnectClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Chaincode_serviceDesc.Streams[0], "/protos.Chaincode/Connect", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &chaincodeConnectClient{stream}
+	return x, nil
+}
+
+type Chaincode_ConnectClient interface {
+	Send(*ChaincodeMessage) error
+	Recv() (*ChaincodeMessage, error)
+	grpc.ClientStream
+}
+
+type chaincodeConnectClient struct {
+	grpc.ClientStream
+}
+
+func (x *chaincodeConnectClient) Send(m *ChaincodeMessage) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *chaincodeConnectClient) Recv() (*ChaincodeMessage, error) {
+	m := new(ChaincodeMessage)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// ChaincodeServer is the server API for Chaincode service.
+type ChaincodeServer interface {
+	Connect(Chaincode_ConnectServer) error
+}
+
+func RegisterChaincodeServer(s *grpc.Server, srv ChaincodeServer) {
+	s.RegisterService(&_Chaincode_serviceDesc, srv)
+}
+
+func _Chaincode_Connect_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(ChaincodeServer).Connect(&chaincodeConnectServer{stream})
+}
+
+type Chaincode_ConnectServer interface {
+	Send(*ChaincodeMessage) error
+	Recv() (*ChaincodeMessage, error)
+	grpc.ServerStream
+}
+
+type chaincodeConnectServer struct {
+	grpc.ServerStream
+}
+
+func (x *chaincodeConnectServer) Send(m *ChaincodeMessage) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *chaincodeConnectServer) Recv() (*ChaincodeMessage, error) {
+	m := new(ChaincodeMessage)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+var _Chaincode_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "protos.Chaincode",
+	HandlerType: (*ChaincodeServer)(nil),
+	Methods:     []grpc.MethodDesc{},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "Connect",
+			Handler:       _Chaincode_Connect_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
+	},
+	Metadata: "peer/chaincode_shim.proto",
+}
+
 func init() {
 	proto.RegisterFile("peer/chaincode_shim.proto", fileDescriptor_chaincode_shim_3c6cdf1ba834f673)
 }
 
 var fileDescriptor_chaincode_shim_3c6cdf1ba834f673 = []byte{
-	// 1010 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x9c, 0x56, 0xcf, 0x73, 0xda, 0x46,
-	0x14, 0x0e, 0x06, 0x8c, 0x78, 0xd8, 0x78, 0xb3, 0x8e, 0x5d, 0xc2, 0x4c, 0x5a, 0xca, 0xf4, 0x40,
-	0x2f, 0xd0, 0xd0, 0x1e, 0x7a, 0xe8, 0x4c, 0x06, 0xc3, 0x1a, 0x33, 0xb6, 0x05, 0x59, 0xc9, 0x99,
-	0xb8, 0x17, 0x8d, 0x90, 0xd6, 0x42, 0x63, 0xa1, 0x55, 0xa5, 0x25, 0x0d, 0xbd, 0xf5, 0xda, 0x7f,
-	0xa9, 0x7f, 0x58, 0xaf, 0x9d, 0xd5, 0x2f, 0x03, 0xae, 0x93, 0x4e, 0x4e, 0xe8, 0x7b, 0xef, 0xdb,
-	0xef, 0xfd, 0xda, 0x87, 0x04, 0x2f, 0x03, 0xc6, 0xc2, 0x9e, 0xb5, 0x30, 0x5d, 0xdf, 0xe2, 0x36,
-	0x33, 0xa2, 0x85, 0xbb, 0xec, 0x06, 0x21, 0x17, 0x1c, 0xef, 0xc7, 0x3f, 0x51, 0xb3, 0xb9, 0x43,
-	0x61, 0x1f, 0x98, 0x2f, 0x12, 0x4e, 0xf3, 0x38, 0xf6, 0x05, 0x21, 0x0f, 0x78, 0x64, 0x7a, 0xa9,
-	0xf1, 0x1b, 0x87, 0x73, 0xc7, 0x63, 0xbd, 0x18, 0xcd, 0x57, 0x77, 0x3d, 0xe1, 0x2e, 0x59, 0x24,
-	0xcc, 0x65, 0x90, 0x10, 0xda, 0x7f, 0x97, 0x01, 0x0d, 0x33, 0xbd, 0x6b, 0x16, 0x45, 0xa6, 0xc3,
-	0xf0, 0x6b, 0x28, 0x89, 0x75, 0xc0, 0x1a, 0x85, 0x56, 0xa1, 0x53, 0xef, 0xbf, 0x4a, 0xa8, 0x51,
-	0x77, 0x97, 0xd7, 0xd5, 0xd7, 0x01, 0xa3, 0x31, 0x15, 0xff, 0x0c, 0xd5, 0x5c, 0xba, 0xb1, 0xd7,
-	0x2a, 0x74, 0x6a, 0xfd, 0x66, 0x37, 0x09, 0xde, 0xcd, 0x82, 0x77, 0xf5, 0x8c, 0x41, 0x1f, 0xc8,
-	0xb8, 0x01, 0x95, 0xc0, 0x5c, 0x7b, 0xdc, 0xb4, 0x1b, 0xc5, 0x56, 0xa1, 0x73, 0x40, 0x33, 0x88,
-	0x31, 0x94, 0xc4, 0x47, 0xd7, 0x6e, 0x94, 0x5a, 0x85, 0x4e, 0x95, 0xc6, 0xcf, 0xb8, 0x0f, 0x4a,
-	0x56, 0x62, 0xa3, 0x1c, 0x87, 0x39, 0xcd, 0xd2, 0xd3, 0x5c, 0xc7, 0x67, 0xf6, 0x2c, 0xf5, 0xd2,
-	0x9c, 0x87, 0xdf, 0xc0, 0xd1, 0x4e, 0xcb, 0x1a, 0xfb, 0xdb, 0x47, 0xf3, 0xca, 0x88, 0xf4, 0xd2,
-	0xba, 0xb5, 0x85, 0xf1, 0x2b, 0x00, 0x6b, 0x61, 0xfa, 0x3e, 0xf3, 0x0c, 0xd7, 0x6e, 0x54, 0xe2,
-	0x74, 0xaa, 0xa9, 0x65, 0x62, 0xb7, 0xff, 0xd9, 0x83, 0x92, 0x6c, 0x05, 0x3e, 0x84, 0xea, 0x8d,
-	0x3a, 0x22, 0xe7, 0x13, 0x95, 0x8c, 0xd0, 0x33, 0x7c, 0x00, 0x0a, 0x25, 0xe3, 0x89, 0xa6, 0x13,
-	0x8a, 0x0a, 0xb8, 0x0e, 0x90, 0x21, 0x32, 0x42, 0x7b, 0x58, 0x81, 0xd2, 0x44, 0x9d, 0xe8, 0xa8,
-	0x88, 0xab, 0x50, 0xa6, 0x64, 0x30, 0xba, 0x45, 0x25, 0x7c, 0x04, 0x35, 0x9d, 0x0e, 0x54, 0x6d,
-	0x30, 0xd4, 0x27, 0x53, 0x15, 0x95, 0xa5, 0xe4, 0x70, 0x7a, 0x3d, 0xbb, 0x22, 0x3a, 0x19, 0xa1,
-	0x7d, 0x49, 0x25, 0x94, 0x4e, 0x29, 0xaa, 0x48, 0xcf, 0x98, 0xe8, 0x86, 0xa6, 0x0f, 0x74, 0x82,
-	0x14, 0x09, 0x67, 0x37, 0x19, 0xac, 0x4a, 0x38, 0x22, 0x57, 0x29, 0x04, 0xfc, 0x02, 0xd0, 0x44,
-	0x7d, 0x37, 0xbd, 0x24, 0xc6, 0xf0, 0x62, 0x30, 0x51, 0x87, 0xd3, 0x11, 0x41, 0xb5, 0x24, 0x41,
-	0x6d, 0x36, 0x55, 0x35, 0x82, 0x0e, 0xf1, 0x29, 0xe0, 0x5c, 0xd0, 0x38, 0xbb, 0x35, 0xe8, 0x40,
-	0x1d, 0x13, 0x54, 0x97, 0x67, 0xa5, 0xfd, 0xed, 0x0d, 0xa1, 0xb7, 0x06, 0x25, 0xda, 0xcd, 0x95,
-	0x8e, 0x8e, 0xa4, 0x35, 0xb1, 0x24, 0x7c, 0x95, 0xbc, 0xd7, 0x11, 0xc2, 0x27, 0xf0, 0x7c, 0xd3,
-	0x3a, 0xbc, 0x9a, 0x6a, 0x04, 0x3d, 0x97, 0xd9, 0x5c, 0x12, 0x32, 0x1b, 0x5c, 0x4d, 0xde, 0x11,
-	0x84, 0xf1, 0x57, 0x70, 0x2c, 0x15, 0x2f, 0x26, 0x9a, 0x3e, 0xa5, 0xb7, 0xc6, 0xf9, 0x94, 0x1a,
-	0x97, 0xe4, 0x16, 0x1d, 0x6f, 0xa7, 0x70, 0x4d, 0xf4, 0xc1, 0x68, 0xa0, 0x0f, 0xd0, 0x0b, 0x69,
-	0xcf, 0x8b, 0x7b, 0xb0, 0x9f, 0xb4, 0x7f, 0x01, 0x65, 0xcc, 0x84, 0x26, 0x4c, 0xc1, 0x30, 0x82,
-	0xe2, 0x3d, 0x5b, 0xc7, 0x77, 0xb6, 0x4a, 0xe5, 0x23, 0xfe, 0x1a, 0xc0, 0xe2, 0x9e, 0xc7, 0x2c,
-	0xe1, 0x72, 0x3f, 0xbe, 0x94, 0x55, 0xba, 0x61, 0x69, 0x8f, 0x00, 0x65, 0xa7, 0xaf, 0x99, 0x30,
-	0x6d, 0x53, 0x98, 0x5f, 0xa0, 0x42, 0x41, 0x99, 0xad, 0x9e, 0xcc, 0xe1, 0x05, 0x94, 0x3f, 0x98,
-	0xde, 0x8a, 0xc5, 0x07, 0x0f, 0x68, 0x02, 0x76, 0x34, 0x8b, 0x8f, 0x34, 0x7f, 0x07, 0x94, 0x69,
-	0xfe, 0xef, 0xcc, 0x1e, 0xa9, 0xe0, 0xd7, 0xa0, 0x2c, 0xd3, 0xd3, 0xf1, 0x0e, 0xd5, 0xfa, 0x27,
-	0xf9, 0xae, 0x6c, 0x4a, 0xd3, 0x9c, 0x26, 0x1b, 0x3a, 0x62, 0xde, 0x97, 0x36, 0xf4, 0xcf, 0x02,
-	0x1c, 0x65, 0x1d, 0x3d, 0x5b, 0x53, 0xd3, 0x77, 0x18, 0x6e, 0x82, 0x12, 0x09, 0x33, 0x14, 0x97,
-	0xb9, 0x54, 0x8e, 0xf1, 0x29, 0xec, 0x33, 0xdf, 0x96, 0x9e, 0x44, 0x2b, 0x45, 0x9f, 0x2d, 0xac,
-	0xb9, 0x53, 0xd8, 0xc1, 0x46, 0x05, 0x73, 0xa8, 0x8f, 0x99, 0x78, 0xbb, 0x62, 0xe1, 0x9a, 0xb2,
-	0x68, 0xe5, 0x09, 0x39, 0x82, 0xdf, 0x24, 0x4c, 0xc3, 0x27, 0xe0, 0x73, 0xb5, 0x6c, 0xc5, 0x28,
-	0xee, 0xc4, 0x18, 0xc3, 0x61, 0x1c, 0x20, 0x9f, 0x4d, 0x13, 0x94, 0xc0, 0x74, 0x98, 0xe6, 0xfe,
-	0x91, 0xfc, 0x69, 0x96, 0x69, 0x8e, 0xa5, 0x6f, 0xce, 0xf9, 0xfd, 0xd2, 0x0c, 0xef, 0xd3, 0x30,
-	0x39, 0x6e, 0x7f, 0x17, 0xdf, 0xc0, 0x0b, 0x37, 0x12, 0x3c, 0x5c, 0x9f, 0xf3, 0x50, 0x16, 0xff,
-	0xa8, 0xed, 0xed, 0x16, 0xd4, 0xe3, 0x70, 0x71, 0x5f, 0x55, 0xf6, 0x51, 0xe0, 0x3a, 0xec, 0xb9,
-	0x76, 0x4a, 0xd9, 0x73, 0xed, 0xf6, 0xb7, 0x70, 0xf4, 0xc0, 0x18, 0x7a, 0x3c, 0x62, 0x8f, 0x28,
-	0x3f, 0x01, 0xda, 0x68, 0xca, 0xd9, 0x5a, 0xb0, 0x08, 0xb7, 0xa0, 0x16, 0x3e, 0xc0, 0x98, 0x7c,
-	0x40, 0x37, 0x4d, 0xed, 0xbf, 0x0a, 0x69, 0xa9, 0x94, 0x45, 0x01, 0xf7, 0x23, 0x86, 0xfb, 0x50,
-	0x49, 0x08, 0x92, 0x5f, 0xec, 0xd4, 0xfa, 0x8d, 0xec, 0x4e, 0xed, 0xca, 0xd3, 0x8c, 0x88, 0x5f,
-	0x82, 0xb2, 0x30, 0x23, 0x63, 0xc9, 0xc3, 0x64, 0x0f, 0x14, 0x5a, 0x59, 0x98, 0xd1, 0x35, 0x0f,
-	0xb3, 0x34, 0x8b, 0x59, 0x9a, 0x9f, 0x1c, 0xad, 0x03, 0x27, 0x5b, 0xb9, 0xe4, 0xed, 0xef, 0xc3,
-	0xc9, 0x1d, 0x13, 0xd6, 0x82, 0xd9, 0x46, 0xc8, 0x2c, 0x1e, 0xda, 0x91, 0x61, 0xf1, 0x95, 0x2f,
-	0xd2, 0x59, 0x1c, 0xa7, 0x4e, 0x9a, 0xf8, 0x86, 0xd2, 0xf5, 0xc9, 0xb1, 0xbc, 0x81, 0xc3, 0xed,
-	0xdd, 0x6b, 0x40, 0x45, 0x66, 0xf1, 0x30, 0x97, 0x0c, 0xfe, 0xf7, 0x7e, 0xb7, 0xcf, 0xe1, 0x78,
-	0x7b, 0xc3, 0x92, 0x9b, 0xd8, 0x83, 0x0a, 0xf3, 0x45, 0xe8, 0xb2, 0xac, 0x77, 0x4f, 0xec, 0x63,
-	0xc6, 0xea, 0xbf, 0xdf, 0x78, 0x39, 0x6b, 0xab, 0x20, 0xe0, 0xa1, 0xc0, 0x23, 0x50, 0x28, 0x73,
-	0xdc, 0x48, 0xb0, 0x10, 0x37, 0x9e, 0x7a, 0x35, 0x37, 0x9f, 0xf4, 0xb4, 0x9f, 0x75, 0x0a, 0x3f,
-	0x14, 0xce, 0xa6, 0xd0, 0xe6, 0xa1, 0xd3, 0x5d, 0xac, 0x03, 0x16, 0x7a, 0xcc, 0x76, 0x58, 0xd8,
-	0xbd, 0x33, 0xe7, 0xa1, 0x6b, 0x65, 0xe7, 0xe4, 0xd7, 0xc4, 0xaf, 0xdf, 0x3b, 0xae, 0x58, 0xac,
-	0xe6, 0x5d, 0x8b, 0x2f, 0x7b, 0x1b, 0xd4, 0x5e, 0x42, 0x4d, 0xbe, 0x2a, 0xa2, 0x9e, 0xa4, 0xce,
-	0x93, 0x4f, 0x94, 0x1f, 0xff, 0x0d, 0x00, 0x00, 0xff, 0xff, 0x41, 0xbd, 0x1a, 0x01, 0xc6, 0x08,
+	// 1186 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x9c, 0x56, 0x5d, 0x73, 0xda, 0x46,
+	0x17, 0x0e, 0x06, 0x1b, 0x71, 0xb0, 0xf1, 0x66, 0x1d, 0xdb, 0x98, 0xbc, 0x49, 0x78, 0xb9, 0x72,
+	0x6f, 0xa0, 0xa1, 0xbd, 0xe8, 0x45, 0x67, 0x32, 0x32, 0xac, 0x81, 0xb1, 0x0d, 0x64, 0x91, 0x33,
+	0x71, 0x6f, 0x34, 0x42, 0xda, 0x08, 0x8d, 0x85, 0x56, 0x95, 0x96, 0x34, 0xf4, 0xae, 0xb7, 0xfd,
+	0x27, 0xfd, 0x13, 0xfd, 0x37, 0xfd, 0x1f, 0x9d, 0xd5, 0x97, 0xf9, 0x88, 0x93, 0x69, 0xae, 0xe0,
+	0x39, 0xe7, 0xd9, 0xe7, 0x7c, 0xec, 0x39, 0x3b, 0x82, 0x33, 0x9f, 0xb1, 0xa0, 0x65, 0xce, 0x0c,
+	0xc7, 0x33, 0xb9, 0xc5, 0xf4, 0x70, 0xe6, 0xcc, 0x9b, 0x7e, 0xc0, 0x05, 0xc7, 0x7b, 0xd1, 0x4f,
+	0x58, 0xab, 0x6d, 0x50, 0xd8, 0x47, 0xe6, 0x89, 0x98, 0x53, 0x3b, 0x8a, 0x7c, 0x7e, 0xc0, 0x7d,
+	0x1e, 0x1a, 0x6e, 0x62, 0x7c, 0x65, 0x73, 0x6e, 0xbb, 0xac, 0x15, 0xa1, 0xe9, 0xe2, 0x43, 0x4b,
+	0x38, 0x73, 0x16, 0x0a, 0x63, 0xee, 0xc7, 0x84, 0xc6, 0x5f, 0x7b, 0x80, 0x3a, 0xa9, 0xde, 0x0d,
+	0x0b, 0x43, 0xc3, 0x66, 0xf8, 0x35, 0x14, 0xc4, 0xd2, 0x67, 0xd5, 0x5c, 0x3d, 0x77, 0x5e, 0x69,
+	0xbf, 0x88, 0xa9, 0x61, 0x73, 0x93, 0xd7, 0xd4, 0x96, 0x3e, 0xa3, 0x11, 0x15, 0xff, 0x04, 0xa5,
+	0x4c, 0xba, 0xba, 0x53, 0xcf, 0x9d, 0x97, 0xdb, 0xb5, 0x66, 0x1c, 0xbc, 0x99, 0x06, 0x6f, 0x6a,
+	0x29, 0x83, 0x3e, 0x90, 0x71, 0x15, 0x8a, 0xbe, 0xb1, 0x74, 0xb9, 0x61, 0x55, 0xf3, 0xf5, 0xdc,
+	0xf9, 0x3e, 0x4d, 0x21, 0xc6, 0x50, 0x10, 0x9f, 0x1c, 0xab, 0x5a, 0xa8, 0xe7, 0xce, 0x4b, 0x34,
+	0xfa, 0x8f, 0xdb, 0xa0, 0xa4, 0x25, 0x56, 0x77, 0xa3, 0x30, 0x27, 0x69, 0x7a, 0x13, 0xc7, 0xf6,
+	0x98, 0x35, 0x4e, 0xbc, 0x34, 0xe3, 0xe1, 0x37, 0x70, 0xb8, 0xd1, 0xb2, 0xea, 0xde, 0xfa, 0xd1,
+	0xac, 0x32, 0x22, 0xbd, 0xb4, 0x62, 0xae, 0x61, 0xfc, 0x02, 0xc0, 0x9c, 0x19, 0x9e, 0xc7, 0x5c,
+	0xdd, 0xb1, 0xaa, 0xc5, 0x28, 0x9d, 0x52, 0x62, 0x19, 0x58, 0x8d, 0x7f, 0xf2, 0x50, 0x90, 0xad,
+	0xc0, 0x07, 0x50, 0xba, 0x1d, 0x76, 0xc9, 0xe5, 0x60, 0x48, 0xba, 0xe8, 0x09, 0xde, 0x07, 0x85,
+	0x92, 0xde, 0x60, 0xa2, 0x11, 0x8a, 0x72, 0xb8, 0x02, 0x90, 0x22, 0xd2, 0x45, 0x3b, 0x58, 0x81,
+	0xc2, 0x60, 0x38, 0xd0, 0x50, 0x1e, 0x97, 0x60, 0x97, 0x12, 0xb5, 0x7b, 0x87, 0x0a, 0xf8, 0x10,
+	0xca, 0x1a, 0x55, 0x87, 0x13, 0xb5, 0xa3, 0x0d, 0x46, 0x43, 0xb4, 0x2b, 0x25, 0x3b, 0xa3, 0x9b,
+	0xf1, 0x35, 0xd1, 0x48, 0x17, 0xed, 0x49, 0x2a, 0xa1, 0x74, 0x44, 0x51, 0x51, 0x7a, 0x7a, 0x44,
+	0xd3, 0x27, 0x9a, 0xaa, 0x11, 0xa4, 0x48, 0x38, 0xbe, 0x4d, 0x61, 0x49, 0xc2, 0x2e, 0xb9, 0x4e,
+	0x20, 0xe0, 0x67, 0x80, 0x06, 0xc3, 0x77, 0xa3, 0x2b, 0xa2, 0x77, 0xfa, 0xea, 0x60, 0xd8, 0x19,
+	0x75, 0x09, 0x2a, 0xc7, 0x09, 0x4e, 0xc6, 0xa3, 0xe1, 0x84, 0xa0, 0x03, 0x7c, 0x02, 0x38, 0x13,
+	0xd4, 0x2f, 0xee, 0x74, 0xaa, 0x0e, 0x7b, 0x04, 0x55, 0xe4, 0x59, 0x69, 0x7f, 0x7b, 0x4b, 0xe8,
+	0x9d, 0x4e, 0xc9, 0xe4, 0xf6, 0x5a, 0x43, 0x87, 0xd2, 0x1a, 0x5b, 0x62, 0xfe, 0x90, 0xbc, 0xd7,
+	0x10, 0xc2, 0xc7, 0xf0, 0x74, 0xd5, 0xda, 0xb9, 0x1e, 0x4d, 0x08, 0x7a, 0x2a, 0xb3, 0xb9, 0x22,
+	0x64, 0xac, 0x5e, 0x0f, 0xde, 0x11, 0x84, 0xf1, 0x29, 0x1c, 0x49, 0xc5, 0xfe, 0x60, 0xa2, 0x8d,
+	0xe8, 0x9d, 0x7e, 0x39, 0xa2, 0xfa, 0x15, 0xb9, 0x43, 0x47, 0xeb, 0x29, 0xdc, 0x10, 0x4d, 0xed,
+	0xaa, 0x9a, 0x8a, 0x9e, 0x49, 0xfb, 0xf8, 0x76, 0xcb, 0x7e, 0x9c, 0x0a, 0xc5, 0x76, 0x55, 0xd3,
+	0xfb, 0x64, 0xd0, 0xeb, 0x6b, 0xe8, 0x04, 0xd7, 0xe1, 0x7f, 0xdb, 0xb5, 0xac, 0x30, 0x4e, 0xf1,
+	0x19, 0x1c, 0x4b, 0xc6, 0x98, 0x0e, 0xde, 0x49, 0x8e, 0x14, 0xd4, 0xfb, 0xea, 0xa4, 0x8f, 0xaa,
+	0x71, 0x34, 0xda, 0x23, 0x6b, 0x4e, 0x74, 0xd6, 0xf8, 0x19, 0x94, 0x1e, 0x13, 0x13, 0x61, 0x08,
+	0x86, 0x11, 0xe4, 0xef, 0xd9, 0x32, 0xda, 0x90, 0x12, 0x95, 0x7f, 0xf1, 0x4b, 0x00, 0x93, 0xbb,
+	0x2e, 0x33, 0x85, 0xc3, 0xbd, 0x68, 0x05, 0x4a, 0x74, 0xc5, 0xd2, 0xe8, 0x02, 0x4a, 0x4f, 0xdf,
+	0x30, 0x61, 0x58, 0x86, 0x30, 0xbe, 0x41, 0x85, 0x82, 0x32, 0x5e, 0x3c, 0x9a, 0xc3, 0x33, 0xd8,
+	0xfd, 0x68, 0xb8, 0x0b, 0x16, 0x1d, 0xdc, 0xa7, 0x31, 0xd8, 0xd0, 0xcc, 0x6f, 0x69, 0xfe, 0x06,
+	0x68, 0xbc, 0xf8, 0x8f, 0x99, 0x6d, 0xa9, 0xe0, 0xd7, 0xa0, 0xcc, 0x93, 0xd3, 0xd1, 0xc6, 0x96,
+	0xdb, 0xc7, 0xd9, 0x66, 0xae, 0x4a, 0xd3, 0x8c, 0x26, 0x1b, 0xda, 0x65, 0xee, 0xb7, 0x36, 0xf4,
+	0x8f, 0x1c, 0x1c, 0xa6, 0x1d, 0xbd, 0x58, 0x52, 0xc3, 0xb3, 0x19, 0xae, 0x81, 0x12, 0x0a, 0x23,
+	0x10, 0x57, 0x99, 0x54, 0x86, 0xf1, 0x09, 0xec, 0x31, 0xcf, 0x92, 0x9e, 0x58, 0x2b, 0x41, 0x5f,
+	0x2d, 0xac, 0xb6, 0x51, 0xd8, 0xfe, 0x4a, 0x05, 0x53, 0xa8, 0xf4, 0x98, 0x78, 0xbb, 0x60, 0xc1,
+	0x92, 0xb2, 0x70, 0xe1, 0x0a, 0x79, 0x05, 0xbf, 0x4a, 0x98, 0x84, 0x8f, 0xc1, 0xd7, 0x6a, 0x59,
+	0x8b, 0x91, 0xdf, 0x88, 0xd1, 0x83, 0x83, 0x28, 0x40, 0x76, 0x37, 0x35, 0x50, 0x7c, 0xc3, 0x66,
+	0x13, 0xe7, 0xf7, 0xf8, 0x89, 0xde, 0xa5, 0x19, 0x96, 0xbe, 0x29, 0xe7, 0xf7, 0x73, 0x23, 0xb8,
+	0x4f, 0xc2, 0x64, 0xb8, 0xf1, 0x77, 0x2e, 0x1a, 0xc1, 0xbe, 0x13, 0x0a, 0x1e, 0x2c, 0x2f, 0x79,
+	0x20, 0xab, 0xdf, 0xee, 0xfb, 0x2b, 0x28, 0x47, 0x3d, 0xd3, 0xa7, 0x2e, 0x37, 0x63, 0x95, 0x02,
+	0x85, 0xc8, 0x74, 0x21, 0x2d, 0xf8, 0x39, 0x94, 0x98, 0x67, 0x25, 0xee, 0x7c, 0xe4, 0x56, 0x98,
+	0x67, 0xc5, 0xce, 0x97, 0x00, 0x16, 0x0b, 0x4d, 0xe6, 0x59, 0x8e, 0x67, 0x47, 0xfd, 0x52, 0xe8,
+	0x8a, 0x65, 0xad, 0xd2, 0xdd, 0xf5, 0x4a, 0x37, 0xba, 0xb4, 0xb7, 0x75, 0xe3, 0xea, 0xc3, 0x0a,
+	0xa9, 0xa2, 0xcf, 0x1c, 0x7b, 0x26, 0x3e, 0x93, 0xff, 0x73, 0x28, 0x45, 0xa9, 0xe9, 0xde, 0x62,
+	0x9e, 0x64, 0xaf, 0x44, 0x86, 0xe1, 0x62, 0xde, 0x70, 0xe1, 0x74, 0x63, 0x66, 0x32, 0xa5, 0xe7,
+	0x50, 0x8a, 0xeb, 0xbe, 0xff, 0xcc, 0xf0, 0x9c, 0x42, 0x51, 0xd6, 0x7c, 0xbf, 0x35, 0x3d, 0x6b,
+	0xd1, 0xf2, 0x1b, 0xd1, 0xea, 0x50, 0x89, 0xae, 0x2e, 0x8a, 0x37, 0x64, 0x9f, 0x04, 0xae, 0xc0,
+	0x8e, 0x63, 0x25, 0xea, 0x3b, 0x8e, 0xd5, 0xf8, 0x3f, 0x1c, 0x3e, 0x30, 0x3a, 0x2e, 0x0f, 0xd9,
+	0x16, 0xe5, 0x47, 0x40, 0x2b, 0x03, 0x76, 0xb1, 0x14, 0x2c, 0xc4, 0x75, 0x28, 0x07, 0x0f, 0x30,
+	0x22, 0xef, 0xd3, 0x55, 0x53, 0xe3, 0xcf, 0x5c, 0x32, 0x36, 0x94, 0x85, 0x3e, 0xf7, 0x42, 0x86,
+	0xdb, 0x50, 0x8c, 0x09, 0x92, 0x9f, 0x3f, 0x2f, 0xb7, 0xab, 0xe9, 0x7e, 0x6e, 0xca, 0xd3, 0x94,
+	0x88, 0xcf, 0x40, 0x99, 0x19, 0xa1, 0x3e, 0xe7, 0x41, 0xfc, 0xa6, 0x28, 0xb4, 0x38, 0x33, 0xc2,
+	0x1b, 0x1e, 0xa4, 0x69, 0xe6, 0xd3, 0x34, 0xbf, 0xb8, 0x26, 0x36, 0x1c, 0xaf, 0xe5, 0x92, 0x8d,
+	0x72, 0x1b, 0x8e, 0x3f, 0x30, 0x61, 0xce, 0x98, 0xa5, 0x07, 0xcc, 0xe4, 0x81, 0x15, 0xea, 0x26,
+	0x5f, 0x78, 0x22, 0x99, 0xeb, 0xa3, 0xc4, 0x49, 0x63, 0x5f, 0x47, 0xba, 0xbe, 0x38, 0xe2, 0x6f,
+	0xe0, 0x60, 0xfd, 0x1d, 0xab, 0x42, 0x51, 0x66, 0xf1, 0x70, 0xa5, 0x29, 0xfc, 0xfc, 0x5b, 0xd9,
+	0xb8, 0x84, 0xa3, 0xf5, 0xd7, 0x2a, 0xde, 0xea, 0x96, 0xbc, 0x7e, 0x11, 0x38, 0x2c, 0xed, 0xdd,
+	0x23, 0x6f, 0x5b, 0xca, 0x6a, 0xbf, 0x5f, 0xf9, 0xac, 0x9a, 0x2c, 0x7c, 0x9f, 0x07, 0x02, 0x77,
+	0x41, 0xa1, 0xcc, 0x76, 0x42, 0xc1, 0x02, 0x5c, 0x7d, 0xec, 0xa3, 0xaa, 0xf6, 0xa8, 0xa7, 0xf1,
+	0xe4, 0x3c, 0xf7, 0x7d, 0xae, 0x3d, 0x86, 0x52, 0xe6, 0xc1, 0x1d, 0x28, 0x76, 0xb8, 0xe7, 0x31,
+	0x53, 0x7c, 0xbb, 0xe2, 0xc5, 0x08, 0x1a, 0x3c, 0xb0, 0x9b, 0xb3, 0xa5, 0xcf, 0x02, 0x97, 0x59,
+	0x36, 0x0b, 0x9a, 0x1f, 0x8c, 0x69, 0xe0, 0x98, 0xe9, 0x39, 0xf9, 0x65, 0xf9, 0xcb, 0x77, 0xb6,
+	0x23, 0x66, 0x8b, 0x69, 0xd3, 0xe4, 0xf3, 0xd6, 0x0a, 0xb5, 0x15, 0x53, 0xe3, 0x2f, 0xcc, 0xb0,
+	0x25, 0xa9, 0xd3, 0xf8, 0x73, 0xf5, 0x87, 0x7f, 0x07, 0x00, 0x5e, 0xa3, 0x04, 0x99, 0xd2, 0x0a,
 	0x00, 0x00,
 }
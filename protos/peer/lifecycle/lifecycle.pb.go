@@ -6,6 +6,7 @@ package lifecycle // import "github.com/hyperledger/fabric/protos/peer/lifecycle
 import proto "github.com/golang/protobuf/proto"
 import fmt "fmt"
 import math "math"
+import common "github.com/hyperledger/fabric/protos/common"
 
 // Reference imports to suppress errors if they are not otherwise used.
 var _ = proto.Marshal
@@ -21,9 +22,7 @@ const _ = proto.ProtoPackageIsVersion2 // please upgrade the proto package
 // InstallChaincodeArgs is the message used as the argument to
 // '+lifecycle.InstallChaincode'
 type InstallChaincodeArgs struct {
-	Name                    string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
-	Version                 string   `protobuf:"bytes,2,opt,name=version,proto3" json:"version,omitempty"`
-	ChaincodeInstallPackage []byte   `protobuf:"bytes,3,opt,name=chaincode_install_package,json=chaincodeInstallPackage,proto3" json:"chaincode_install_package,omitempty"`
+	ChaincodeInstallPackage []byte   `protobuf:"bytes,1,opt,name=chaincode_install_package,json=chaincodeInstallPackage,proto3" json:"chaincode_install_package,omitempty"`
 	XXX_NoUnkeyedLiteral    struct{} `json:"-"`
 	XXX_unrecognized        []byte   `json:"-"`
 	XXX_sizecache           int32    `json:"-"`
@@ -53,20 +52,6 @@ func (m *InstallChaincodeArgs) XXX_DiscardUnknown() {
 
 var xxx_messageInfo_InstallChaincodeArgs proto.InternalMessageInfo
 
-func (m *InstallChaincodeArgs) GetName() string {
-	if m != nil {
-		return m.Name
-	}
-	return ""
-}
-
-func (m *InstallChaincodeArgs) GetVersion() string {
-	if m != nil {
-		return m.Version
-	}
-	return ""
-}
-
 func (m *InstallChaincodeArgs) GetChaincodeInstallPackage() []byte {
 	if m != nil {
 		return m.ChaincodeInstallPackage
@@ -77,7 +62,8 @@ func (m *InstallChaincodeArgs) GetChaincodeInstallPackage() []byte {
 // InstallChaincodeArgs is the message returned by
 // '+lifecycle.InstallChaincode'
 type InstallChaincodeResult struct {
-	Hash                 []byte   `protobuf:"bytes,1,opt,name=hash,proto3" json:"hash,omitempty"`
+	PackageId            string   `protobuf:"bytes,1,opt,name=package_id,json=packageId,proto3" json:"package_id,omitempty"`
+	Label                string   `protobuf:"bytes,2,opt,name=label,proto3" json:"label,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -107,18 +93,24 @@ func (m *InstallChaincodeResult) XXX_DiscardUnknown() {
 
 var xxx_messageInfo_InstallChaincodeResult proto.InternalMessageInfo
 
-func (m *InstallChaincodeResult) GetHash() []byte {
+func (m *InstallChaincodeResult) GetPackageId() string {
 	if m != nil {
-		return m.Hash
+		return m.PackageId
 	}
-	return nil
+	return ""
+}
+
+func (m *InstallChaincodeResult) GetLabel() string {
+	if m != nil {
+		return m.Label
+	}
+	return ""
 }
 
 // QueryInstalledChaincodeArgs is the message returned by
 // '+lifecycle.QueryInstalledChaincode'
 type QueryInstalledChaincodeArgs struct {
-	Name                 string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
-	Version              string   `protobuf:"bytes,2,opt,name=version,proto3" json:"version,omitempty"`
+	PackageId            string   `protobuf:"bytes,1,opt,name=package_id,json=packageId,proto3" json:"package_id,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -148,16 +140,9 @@ func (m *QueryInstalledChaincodeArgs) XXX_DiscardUnknown() {
 
 var xxx_messageInfo_QueryInstalledChaincodeArgs proto.InternalMessageInfo
 
-func (m *QueryInstalledChaincodeArgs) GetName() string {
+func (m *QueryInstalledChaincodeArgs) GetPackageId() string {
 	if m != nil {
-		return m.Name
-	}
-	return ""
-}
-
-func (m *QueryInstalledChaincodeArgs) GetVersion() string {
-	if m != nil {
-		return m.Version
+		return m.PackageId
 	}
 	return ""
 }
@@ -165,7 +150,8 @@ func (m *QueryInstalledChaincodeArgs) GetVersion() string {
 // QueryInstalledChaincodeResult is the message returned by
 // '+lifecycle.QueryInstalledChaincode'
 type QueryInstalledChaincodeResult struct {
-	Hash                 []byte   `protobuf:"bytes,1,opt,name=hash,proto3" json:"hash,omitempty"`
+	PackageId            string   `protobuf:"bytes,1,opt,name=package_id,json=packageId,proto3" json:"package_id,omitempty"`
+	Label                string   `protobuf:"bytes,2,opt,name=label,proto3" json:"label,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -195,18 +181,727 @@ func (m *QueryInstalledChaincodeResult) XXX_DiscardUnknown() {
 
 var xxx_messageInfo_QueryInstalledChaincodeResult proto.InternalMessageInfo
 
-func (m *QueryInstalledChaincodeResult) GetHash() []byte {
+func (m *QueryInstalledChaincodeResult) GetPackageId() string {
 	if m != nil {
-		return m.Hash
+		return m.PackageId
+	}
+	return ""
+}
+
+func (m *QueryInstalledChaincodeResult) GetLabel() string {
+	if m != nil {
+		return m.Label
+	}
+	return ""
+}
+
+// ChaincodeDefinition is the definition of a chaincode on a channel, it is
+// approved by the organizations and then committed to the channel
+type ChaincodeDefinition struct {
+	Sequence             int64                           `protobuf:"varint,1,opt,name=sequence,proto3" json:"sequence,omitempty"`
+	Version              string                          `protobuf:"bytes,2,opt,name=version,proto3" json:"version,omitempty"`
+	EndorsementPlugin    string                          `protobuf:"bytes,3,opt,name=endorsement_plugin,json=endorsementPlugin,proto3" json:"endorsement_plugin,omitempty"`
+	ValidationPlugin     string                          `protobuf:"bytes,4,opt,name=validation_plugin,json=validationPlugin,proto3" json:"validation_plugin,omitempty"`
+	ValidationParameter  []byte                          `protobuf:"bytes,5,opt,name=validation_parameter,json=validationParameter,proto3" json:"validation_parameter,omitempty"`
+	Collections          *common.CollectionConfigPackage `protobuf:"bytes,6,opt,name=collections,proto3" json:"collections,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                        `json:"-"`
+	XXX_unrecognized     []byte                          `json:"-"`
+	XXX_sizecache        int32                           `json:"-"`
+}
+
+func (m *ChaincodeDefinition) Reset()         { *m = ChaincodeDefinition{} }
+func (m *ChaincodeDefinition) String() string { return proto.CompactTextString(m) }
+func (*ChaincodeDefinition) ProtoMessage()    {}
+func (*ChaincodeDefinition) Descriptor() ([]byte, []int) {
+	return fileDescriptor_lifecycle_f98901bea638af10, []int{4}
+}
+func (m *ChaincodeDefinition) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ChaincodeDefinition.Unmarshal(m, b)
+}
+func (m *ChaincodeDefinition) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ChaincodeDefinition.Marshal(b, m, deterministic)
+}
+func (dst *ChaincodeDefinition) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ChaincodeDefinition.Merge(dst, src)
+}
+func (m *ChaincodeDefinition) XXX_Size() int {
+	return xxx_messageInfo_ChaincodeDefinition.Size(m)
+}
+func (m *ChaincodeDefinition) XXX_DiscardUnknown() {
+	xxx_messageInfo_ChaincodeDefinition.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ChaincodeDefinition proto.InternalMessageInfo
+
+func (m *ChaincodeDefinition) GetSequence() int64 {
+	if m != nil {
+		return m.Sequence
+	}
+	return 0
+}
+
+func (m *ChaincodeDefinition) GetVersion() string {
+	if m != nil {
+		return m.Version
+	}
+	return ""
+}
+
+func (m *ChaincodeDefinition) GetEndorsementPlugin() string {
+	if m != nil {
+		return m.EndorsementPlugin
+	}
+	return ""
+}
+
+func (m *ChaincodeDefinition) GetValidationPlugin() string {
+	if m != nil {
+		return m.ValidationPlugin
+	}
+	return ""
+}
+
+func (m *ChaincodeDefinition) GetValidationParameter() []byte {
+	if m != nil {
+		return m.ValidationParameter
 	}
 	return nil
 }
 
+func (m *ChaincodeDefinition) GetCollections() *common.CollectionConfigPackage {
+	if m != nil {
+		return m.Collections
+	}
+	return nil
+}
+
+// ApproveChaincodeDefinitionForMyOrgArgs is the message used as the argument to
+// '+lifecycle.ApproveChaincodeDefinitionForMyOrg'
+type ApproveChaincodeDefinitionForMyOrgArgs struct {
+	Name                 string               `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Definition           *ChaincodeDefinition `protobuf:"bytes,2,opt,name=definition,proto3" json:"definition,omitempty"`
+	PackageId            string               `protobuf:"bytes,3,opt,name=package_id,json=packageId,proto3" json:"package_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *ApproveChaincodeDefinitionForMyOrgArgs) Reset() {
+	*m = ApproveChaincodeDefinitionForMyOrgArgs{}
+}
+func (m *ApproveChaincodeDefinitionForMyOrgArgs) String() string { return proto.CompactTextString(m) }
+func (*ApproveChaincodeDefinitionForMyOrgArgs) ProtoMessage()    {}
+func (*ApproveChaincodeDefinitionForMyOrgArgs) Descriptor() ([]byte, []int) {
+	return fileDescriptor_lifecycle_f98901bea638af10, []int{5}
+}
+func (m *ApproveChaincodeDefinitionForMyOrgArgs) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ApproveChaincodeDefinitionForMyOrgArgs.Unmarshal(m, b)
+}
+func (m *ApproveChaincodeDefinitionForMyOrgArgs) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ApproveChaincodeDefinitionForMyOrgArgs.Marshal(b, m, deterministic)
+}
+func (dst *ApproveChaincodeDefinitionForMyOrgArgs) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ApproveChaincodeDefinitionForMyOrgArgs.Merge(dst, src)
+}
+func (m *ApproveChaincodeDefinitionForMyOrgArgs) XXX_Size() int {
+	return xxx_messageInfo_ApproveChaincodeDefinitionForMyOrgArgs.Size(m)
+}
+func (m *ApproveChaincodeDefinitionForMyOrgArgs) XXX_DiscardUnknown() {
+	xxx_messageInfo_ApproveChaincodeDefinitionForMyOrgArgs.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ApproveChaincodeDefinitionForMyOrgArgs proto.InternalMessageInfo
+
+func (m *ApproveChaincodeDefinitionForMyOrgArgs) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *ApproveChaincodeDefinitionForMyOrgArgs) GetDefinition() *ChaincodeDefinition {
+	if m != nil {
+		return m.Definition
+	}
+	return nil
+}
+
+func (m *ApproveChaincodeDefinitionForMyOrgArgs) GetPackageId() string {
+	if m != nil {
+		return m.PackageId
+	}
+	return ""
+}
+
+// ApproveChaincodeDefinitionForMyOrgResult is the message returned by
+// '+lifecycle.ApproveChaincodeDefinitionForMyOrg'
+type ApproveChaincodeDefinitionForMyOrgResult struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ApproveChaincodeDefinitionForMyOrgResult) Reset() {
+	*m = ApproveChaincodeDefinitionForMyOrgResult{}
+}
+func (m *ApproveChaincodeDefinitionForMyOrgResult) String() string { return proto.CompactTextString(m) }
+func (*ApproveChaincodeDefinitionForMyOrgResult) ProtoMessage()    {}
+func (*ApproveChaincodeDefinitionForMyOrgResult) Descriptor() ([]byte, []int) {
+	return fileDescriptor_lifecycle_f98901bea638af10, []int{6}
+}
+func (m *ApproveChaincodeDefinitionForMyOrgResult) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ApproveChaincodeDefinitionForMyOrgResult.Unmarshal(m, b)
+}
+func (m *ApproveChaincodeDefinitionForMyOrgResult) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ApproveChaincodeDefinitionForMyOrgResult.Marshal(b, m, deterministic)
+}
+func (dst *ApproveChaincodeDefinitionForMyOrgResult) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ApproveChaincodeDefinitionForMyOrgResult.Merge(dst, src)
+}
+func (m *ApproveChaincodeDefinitionForMyOrgResult) XXX_Size() int {
+	return xxx_messageInfo_ApproveChaincodeDefinitionForMyOrgResult.Size(m)
+}
+func (m *ApproveChaincodeDefinitionForMyOrgResult) XXX_DiscardUnknown() {
+	xxx_messageInfo_ApproveChaincodeDefinitionForMyOrgResult.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ApproveChaincodeDefinitionForMyOrgResult proto.InternalMessageInfo
+
+// CommitChaincodeDefinitionArgs is the message used as the argument to
+// '+lifecycle.CommitChaincodeDefinition'
+type CommitChaincodeDefinitionArgs struct {
+	Name                 string               `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Definition           *ChaincodeDefinition `protobuf:"bytes,2,opt,name=definition,proto3" json:"definition,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *CommitChaincodeDefinitionArgs) Reset()         { *m = CommitChaincodeDefinitionArgs{} }
+func (m *CommitChaincodeDefinitionArgs) String() string { return proto.CompactTextString(m) }
+func (*CommitChaincodeDefinitionArgs) ProtoMessage()    {}
+func (*CommitChaincodeDefinitionArgs) Descriptor() ([]byte, []int) {
+	return fileDescriptor_lifecycle_f98901bea638af10, []int{7}
+}
+func (m *CommitChaincodeDefinitionArgs) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CommitChaincodeDefinitionArgs.Unmarshal(m, b)
+}
+func (m *CommitChaincodeDefinitionArgs) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CommitChaincodeDefinitionArgs.Marshal(b, m, deterministic)
+}
+func (dst *CommitChaincodeDefinitionArgs) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CommitChaincodeDefinitionArgs.Merge(dst, src)
+}
+func (m *CommitChaincodeDefinitionArgs) XXX_Size() int {
+	return xxx_messageInfo_CommitChaincodeDefinitionArgs.Size(m)
+}
+func (m *CommitChaincodeDefinitionArgs) XXX_DiscardUnknown() {
+	xxx_messageInfo_CommitChaincodeDefinitionArgs.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CommitChaincodeDefinitionArgs proto.InternalMessageInfo
+
+func (m *CommitChaincodeDefinitionArgs) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *CommitChaincodeDefinitionArgs) GetDefinition() *ChaincodeDefinition {
+	if m != nil {
+		return m.Definition
+	}
+	return nil
+}
+
+// CommitChaincodeDefinitionResult is the message returned by
+// '+lifecycle.CommitChaincodeDefinition'
+type CommitChaincodeDefinitionResult struct {
+	Approvals            []*OrgApproval `protobuf:"bytes,1,rep,name=approvals,proto3" json:"approvals,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
+	XXX_unrecognized     []byte         `json:"-"`
+	XXX_sizecache        int32          `json:"-"`
+}
+
+func (m *CommitChaincodeDefinitionResult) Reset()         { *m = CommitChaincodeDefinitionResult{} }
+func (m *CommitChaincodeDefinitionResult) String() string { return proto.CompactTextString(m) }
+func (*CommitChaincodeDefinitionResult) ProtoMessage()    {}
+func (*CommitChaincodeDefinitionResult) Descriptor() ([]byte, []int) {
+	return fileDescriptor_lifecycle_f98901bea638af10, []int{8}
+}
+func (m *CommitChaincodeDefinitionResult) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CommitChaincodeDefinitionResult.Unmarshal(m, b)
+}
+func (m *CommitChaincodeDefinitionResult) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CommitChaincodeDefinitionResult.Marshal(b, m, deterministic)
+}
+func (dst *CommitChaincodeDefinitionResult) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CommitChaincodeDefinitionResult.Merge(dst, src)
+}
+func (m *CommitChaincodeDefinitionResult) XXX_Size() int {
+	return xxx_messageInfo_CommitChaincodeDefinitionResult.Size(m)
+}
+func (m *CommitChaincodeDefinitionResult) XXX_DiscardUnknown() {
+	xxx_messageInfo_CommitChaincodeDefinitionResult.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CommitChaincodeDefinitionResult proto.InternalMessageInfo
+
+func (m *CommitChaincodeDefinitionResult) GetApprovals() []*OrgApproval {
+	if m != nil {
+		return m.Approvals
+	}
+	return nil
+}
+
+// QueryChaincodeDefinitionArgs is the message used as the argument to
+// '+lifecycle.QueryChaincodeDefinition'
+type QueryChaincodeDefinitionArgs struct {
+	Name                 string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *QueryChaincodeDefinitionArgs) Reset()         { *m = QueryChaincodeDefinitionArgs{} }
+func (m *QueryChaincodeDefinitionArgs) String() string { return proto.CompactTextString(m) }
+func (*QueryChaincodeDefinitionArgs) ProtoMessage()    {}
+func (*QueryChaincodeDefinitionArgs) Descriptor() ([]byte, []int) {
+	return fileDescriptor_lifecycle_f98901bea638af10, []int{9}
+}
+func (m *QueryChaincodeDefinitionArgs) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_QueryChaincodeDefinitionArgs.Unmarshal(m, b)
+}
+func (m *QueryChaincodeDefinitionArgs) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_QueryChaincodeDefinitionArgs.Marshal(b, m, deterministic)
+}
+func (dst *QueryChaincodeDefinitionArgs) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_QueryChaincodeDefinitionArgs.Merge(dst, src)
+}
+func (m *QueryChaincodeDefinitionArgs) XXX_Size() int {
+	return xxx_messageInfo_QueryChaincodeDefinitionArgs.Size(m)
+}
+func (m *QueryChaincodeDefinitionArgs) XXX_DiscardUnknown() {
+	xxx_messageInfo_QueryChaincodeDefinitionArgs.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_QueryChaincodeDefinitionArgs proto.InternalMessageInfo
+
+func (m *QueryChaincodeDefinitionArgs) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+// QueryChaincodeDefinitionResult is the message returned by
+// '+lifecycle.QueryChaincodeDefinition'
+type QueryChaincodeDefinitionResult struct {
+	Definition           *ChaincodeDefinition `protobuf:"bytes,1,opt,name=definition,proto3" json:"definition,omitempty"`
+	PackageId            string               `protobuf:"bytes,2,opt,name=package_id,json=packageId,proto3" json:"package_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *QueryChaincodeDefinitionResult) Reset()         { *m = QueryChaincodeDefinitionResult{} }
+func (m *QueryChaincodeDefinitionResult) String() string { return proto.CompactTextString(m) }
+func (*QueryChaincodeDefinitionResult) ProtoMessage()    {}
+func (*QueryChaincodeDefinitionResult) Descriptor() ([]byte, []int) {
+	return fileDescriptor_lifecycle_f98901bea638af10, []int{10}
+}
+func (m *QueryChaincodeDefinitionResult) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_QueryChaincodeDefinitionResult.Unmarshal(m, b)
+}
+func (m *QueryChaincodeDefinitionResult) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_QueryChaincodeDefinitionResult.Marshal(b, m, deterministic)
+}
+func (dst *QueryChaincodeDefinitionResult) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_QueryChaincodeDefinitionResult.Merge(dst, src)
+}
+func (m *QueryChaincodeDefinitionResult) XXX_Size() int {
+	return xxx_messageInfo_QueryChaincodeDefinitionResult.Size(m)
+}
+func (m *QueryChaincodeDefinitionResult) XXX_DiscardUnknown() {
+	xxx_messageInfo_QueryChaincodeDefinitionResult.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_QueryChaincodeDefinitionResult proto.InternalMessageInfo
+
+func (m *QueryChaincodeDefinitionResult) GetDefinition() *ChaincodeDefinition {
+	if m != nil {
+		return m.Definition
+	}
+	return nil
+}
+
+func (m *QueryChaincodeDefinitionResult) GetPackageId() string {
+	if m != nil {
+		return m.PackageId
+	}
+	return ""
+}
+
+// QueryInstalledChaincodesArgs is the message used as the argument to
+// '+lifecycle.QueryInstalledChaincodes'
+type QueryInstalledChaincodesArgs struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *QueryInstalledChaincodesArgs) Reset()         { *m = QueryInstalledChaincodesArgs{} }
+func (m *QueryInstalledChaincodesArgs) String() string { return proto.CompactTextString(m) }
+func (*QueryInstalledChaincodesArgs) ProtoMessage()    {}
+func (*QueryInstalledChaincodesArgs) Descriptor() ([]byte, []int) {
+	return fileDescriptor_lifecycle_f98901bea638af10, []int{11}
+}
+func (m *QueryInstalledChaincodesArgs) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_QueryInstalledChaincodesArgs.Unmarshal(m, b)
+}
+func (m *QueryInstalledChaincodesArgs) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_QueryInstalledChaincodesArgs.Marshal(b, m, deterministic)
+}
+func (dst *QueryInstalledChaincodesArgs) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_QueryInstalledChaincodesArgs.Merge(dst, src)
+}
+func (m *QueryInstalledChaincodesArgs) XXX_Size() int {
+	return xxx_messageInfo_QueryInstalledChaincodesArgs.Size(m)
+}
+func (m *QueryInstalledChaincodesArgs) XXX_DiscardUnknown() {
+	xxx_messageInfo_QueryInstalledChaincodesArgs.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_QueryInstalledChaincodesArgs proto.InternalMessageInfo
+
+// QueryInstalledChaincodesResult is the message returned by
+// '+lifecycle.QueryInstalledChaincodes'
+type QueryInstalledChaincodesResult struct {
+	InstalledChaincodes  []*QueryInstalledChaincodesResult_InstalledChaincode `protobuf:"bytes,1,rep,name=installed_chaincodes,json=installedChaincodes,proto3" json:"installed_chaincodes,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                                             `json:"-"`
+	XXX_unrecognized     []byte                                               `json:"-"`
+	XXX_sizecache        int32                                                `json:"-"`
+}
+
+func (m *QueryInstalledChaincodesResult) Reset()         { *m = QueryInstalledChaincodesResult{} }
+func (m *QueryInstalledChaincodesResult) String() string { return proto.CompactTextString(m) }
+func (*QueryInstalledChaincodesResult) ProtoMessage()    {}
+func (*QueryInstalledChaincodesResult) Descriptor() ([]byte, []int) {
+	return fileDescriptor_lifecycle_f98901bea638af10, []int{12}
+}
+func (m *QueryInstalledChaincodesResult) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_QueryInstalledChaincodesResult.Unmarshal(m, b)
+}
+func (m *QueryInstalledChaincodesResult) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_QueryInstalledChaincodesResult.Marshal(b, m, deterministic)
+}
+func (dst *QueryInstalledChaincodesResult) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_QueryInstalledChaincodesResult.Merge(dst, src)
+}
+func (m *QueryInstalledChaincodesResult) XXX_Size() int {
+	return xxx_messageInfo_QueryInstalledChaincodesResult.Size(m)
+}
+func (m *QueryInstalledChaincodesResult) XXX_DiscardUnknown() {
+	xxx_messageInfo_QueryInstalledChaincodesResult.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_QueryInstalledChaincodesResult proto.InternalMessageInfo
+
+func (m *QueryInstalledChaincodesResult) GetInstalledChaincodes() []*QueryInstalledChaincodesResult_InstalledChaincode {
+	if m != nil {
+		return m.InstalledChaincodes
+	}
+	return nil
+}
+
+type QueryInstalledChaincodesResult_InstalledChaincode struct {
+	PackageId            string   `protobuf:"bytes,1,opt,name=package_id,json=packageId,proto3" json:"package_id,omitempty"`
+	Label                string   `protobuf:"bytes,2,opt,name=label,proto3" json:"label,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *QueryInstalledChaincodesResult_InstalledChaincode) Reset() {
+	*m = QueryInstalledChaincodesResult_InstalledChaincode{}
+}
+func (m *QueryInstalledChaincodesResult_InstalledChaincode) String() string {
+	return proto.CompactTextString(m)
+}
+func (*QueryInstalledChaincodesResult_InstalledChaincode) ProtoMessage() {}
+func (*QueryInstalledChaincodesResult_InstalledChaincode) Descriptor() ([]byte, []int) {
+	return fileDescriptor_lifecycle_f98901bea638af10, []int{12, 0}
+}
+func (m *QueryInstalledChaincodesResult_InstalledChaincode) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_QueryInstalledChaincodesResult_InstalledChaincode.Unmarshal(m, b)
+}
+func (m *QueryInstalledChaincodesResult_InstalledChaincode) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_QueryInstalledChaincodesResult_InstalledChaincode.Marshal(b, m, deterministic)
+}
+func (dst *QueryInstalledChaincodesResult_InstalledChaincode) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_QueryInstalledChaincodesResult_InstalledChaincode.Merge(dst, src)
+}
+func (m *QueryInstalledChaincodesResult_InstalledChaincode) XXX_Size() int {
+	return xxx_messageInfo_QueryInstalledChaincodesResult_InstalledChaincode.Size(m)
+}
+func (m *QueryInstalledChaincodesResult_InstalledChaincode) XXX_DiscardUnknown() {
+	xxx_messageInfo_QueryInstalledChaincodesResult_InstalledChaincode.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_QueryInstalledChaincodesResult_InstalledChaincode proto.InternalMessageInfo
+
+func (m *QueryInstalledChaincodesResult_InstalledChaincode) GetPackageId() string {
+	if m != nil {
+		return m.PackageId
+	}
+	return ""
+}
+
+func (m *QueryInstalledChaincodesResult_InstalledChaincode) GetLabel() string {
+	if m != nil {
+		return m.Label
+	}
+	return ""
+}
+
+// GetInstalledChaincodePackageArgs is the message used as the argument to
+// '+lifecycle.GetInstalledChaincodePackage'
+type GetInstalledChaincodePackageArgs struct {
+	PackageId            string   `protobuf:"bytes,1,opt,name=package_id,json=packageId,proto3" json:"package_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetInstalledChaincodePackageArgs) Reset()         { *m = GetInstalledChaincodePackageArgs{} }
+func (m *GetInstalledChaincodePackageArgs) String() string { return proto.CompactTextString(m) }
+func (*GetInstalledChaincodePackageArgs) ProtoMessage()    {}
+func (*GetInstalledChaincodePackageArgs) Descriptor() ([]byte, []int) {
+	return fileDescriptor_lifecycle_f98901bea638af10, []int{13}
+}
+func (m *GetInstalledChaincodePackageArgs) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetInstalledChaincodePackageArgs.Unmarshal(m, b)
+}
+func (m *GetInstalledChaincodePackageArgs) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetInstalledChaincodePackageArgs.Marshal(b, m, deterministic)
+}
+func (dst *GetInstalledChaincodePackageArgs) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetInstalledChaincodePackageArgs.Merge(dst, src)
+}
+func (m *GetInstalledChaincodePackageArgs) XXX_Size() int {
+	return xxx_messageInfo_GetInstalledChaincodePackageArgs.Size(m)
+}
+func (m *GetInstalledChaincodePackageArgs) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetInstalledChaincodePackageArgs.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetInstalledChaincodePackageArgs proto.InternalMessageInfo
+
+func (m *GetInstalledChaincodePackageArgs) GetPackageId() string {
+	if m != nil {
+		return m.PackageId
+	}
+	return ""
+}
+
+// GetInstalledChaincodePackageResult is the message returned by
+// '+lifecycle.GetInstalledChaincodePackage'
+type GetInstalledChaincodePackageResult struct {
+	ChaincodeInstallPackage []byte   `protobuf:"bytes,1,opt,name=chaincode_install_package,json=chaincodeInstallPackage,proto3" json:"chaincode_install_package,omitempty"`
+	XXX_NoUnkeyedLiteral    struct{} `json:"-"`
+	XXX_unrecognized        []byte   `json:"-"`
+	XXX_sizecache           int32    `json:"-"`
+}
+
+func (m *GetInstalledChaincodePackageResult) Reset()         { *m = GetInstalledChaincodePackageResult{} }
+func (m *GetInstalledChaincodePackageResult) String() string { return proto.CompactTextString(m) }
+func (*GetInstalledChaincodePackageResult) ProtoMessage()    {}
+func (*GetInstalledChaincodePackageResult) Descriptor() ([]byte, []int) {
+	return fileDescriptor_lifecycle_f98901bea638af10, []int{14}
+}
+func (m *GetInstalledChaincodePackageResult) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetInstalledChaincodePackageResult.Unmarshal(m, b)
+}
+func (m *GetInstalledChaincodePackageResult) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetInstalledChaincodePackageResult.Marshal(b, m, deterministic)
+}
+func (dst *GetInstalledChaincodePackageResult) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetInstalledChaincodePackageResult.Merge(dst, src)
+}
+func (m *GetInstalledChaincodePackageResult) XXX_Size() int {
+	return xxx_messageInfo_GetInstalledChaincodePackageResult.Size(m)
+}
+func (m *GetInstalledChaincodePackageResult) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetInstalledChaincodePackageResult.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetInstalledChaincodePackageResult proto.InternalMessageInfo
+
+func (m *GetInstalledChaincodePackageResult) GetChaincodeInstallPackage() []byte {
+	if m != nil {
+		return m.ChaincodeInstallPackage
+	}
+	return nil
+}
+
+// CheckCommitReadinessArgs is the message used as the argument to
+// '+lifecycle.CheckCommitReadiness'
+type CheckCommitReadinessArgs struct {
+	Name                 string               `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Definition           *ChaincodeDefinition `protobuf:"bytes,2,opt,name=definition,proto3" json:"definition,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *CheckCommitReadinessArgs) Reset()         { *m = CheckCommitReadinessArgs{} }
+func (m *CheckCommitReadinessArgs) String() string { return proto.CompactTextString(m) }
+func (*CheckCommitReadinessArgs) ProtoMessage()    {}
+func (*CheckCommitReadinessArgs) Descriptor() ([]byte, []int) {
+	return fileDescriptor_lifecycle_f98901bea638af10, []int{15}
+}
+func (m *CheckCommitReadinessArgs) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CheckCommitReadinessArgs.Unmarshal(m, b)
+}
+func (m *CheckCommitReadinessArgs) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CheckCommitReadinessArgs.Marshal(b, m, deterministic)
+}
+func (dst *CheckCommitReadinessArgs) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CheckCommitReadinessArgs.Merge(dst, src)
+}
+func (m *CheckCommitReadinessArgs) XXX_Size() int {
+	return xxx_messageInfo_CheckCommitReadinessArgs.Size(m)
+}
+func (m *CheckCommitReadinessArgs) XXX_DiscardUnknown() {
+	xxx_messageInfo_CheckCommitReadinessArgs.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CheckCommitReadinessArgs proto.InternalMessageInfo
+
+func (m *CheckCommitReadinessArgs) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *CheckCommitReadinessArgs) GetDefinition() *ChaincodeDefinition {
+	if m != nil {
+		return m.Definition
+	}
+	return nil
+}
+
+// CheckCommitReadinessResult is the message returned by
+// '+lifecycle.CheckCommitReadiness'
+type CheckCommitReadinessResult struct {
+	Approvals            []*OrgApproval `protobuf:"bytes,1,rep,name=approvals,proto3" json:"approvals,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
+	XXX_unrecognized     []byte         `json:"-"`
+	XXX_sizecache        int32          `json:"-"`
+}
+
+func (m *CheckCommitReadinessResult) Reset()         { *m = CheckCommitReadinessResult{} }
+func (m *CheckCommitReadinessResult) String() string { return proto.CompactTextString(m) }
+func (*CheckCommitReadinessResult) ProtoMessage()    {}
+func (*CheckCommitReadinessResult) Descriptor() ([]byte, []int) {
+	return fileDescriptor_lifecycle_f98901bea638af10, []int{16}
+}
+func (m *CheckCommitReadinessResult) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CheckCommitReadinessResult.Unmarshal(m, b)
+}
+func (m *CheckCommitReadinessResult) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CheckCommitReadinessResult.Marshal(b, m, deterministic)
+}
+func (dst *CheckCommitReadinessResult) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CheckCommitReadinessResult.Merge(dst, src)
+}
+func (m *CheckCommitReadinessResult) XXX_Size() int {
+	return xxx_messageInfo_CheckCommitReadinessResult.Size(m)
+}
+func (m *CheckCommitReadinessResult) XXX_DiscardUnknown() {
+	xxx_messageInfo_CheckCommitReadinessResult.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CheckCommitReadinessResult proto.InternalMessageInfo
+
+func (m *CheckCommitReadinessResult) GetApprovals() []*OrgApproval {
+	if m != nil {
+		return m.Approvals
+	}
+	return nil
+}
+
+// OrgApproval is the approval of a chaincode definition by an organization.
+// A repeated field sorted by MSP ID is used rather than a map, so that the
+// results are marshaled identically by all the endorsing peers.
+type OrgApproval struct {
+	MspId                string   `protobuf:"bytes,1,opt,name=msp_id,json=mspId,proto3" json:"msp_id,omitempty"`
+	Approved             bool     `protobuf:"varint,2,opt,name=approved,proto3" json:"approved,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *OrgApproval) Reset()         { *m = OrgApproval{} }
+func (m *OrgApproval) String() string { return proto.CompactTextString(m) }
+func (*OrgApproval) ProtoMessage()    {}
+func (*OrgApproval) Descriptor() ([]byte, []int) {
+	return fileDescriptor_lifecycle_f98901bea638af10, []int{17}
+}
+func (m *OrgApproval) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_OrgApproval.Unmarshal(m, b)
+}
+func (m *OrgApproval) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_OrgApproval.Marshal(b, m, deterministic)
+}
+func (dst *OrgApproval) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_OrgApproval.Merge(dst, src)
+}
+func (m *OrgApproval) XXX_Size() int {
+	return xxx_messageInfo_OrgApproval.Size(m)
+}
+func (m *OrgApproval) XXX_DiscardUnknown() {
+	xxx_messageInfo_OrgApproval.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_OrgApproval proto.InternalMessageInfo
+
+func (m *OrgApproval) GetMspId() string {
+	if m != nil {
+		return m.MspId
+	}
+	return ""
+}
+
+func (m *OrgApproval) GetApproved() bool {
+	if m != nil {
+		return m.Approved
+	}
+	return false
+}
+
 func init() {
 	proto.RegisterType((*InstallChaincodeArgs)(nil), "lifecycle.InstallChaincodeArgs")
 	proto.RegisterType((*InstallChaincodeResult)(nil), "lifecycle.InstallChaincodeResult")
 	proto.RegisterType((*QueryInstalledChaincodeArgs)(nil), "lifecycle.QueryInstalledChaincodeArgs")
 	proto.RegisterType((*QueryInstalledChaincodeResult)(nil), "lifecycle.QueryInstalledChaincodeResult")
+	proto.RegisterType((*ChaincodeDefinition)(nil), "lifecycle.ChaincodeDefinition")
+	proto.RegisterType((*ApproveChaincodeDefinitionForMyOrgArgs)(nil), "lifecycle.ApproveChaincodeDefinitionForMyOrgArgs")
+	proto.RegisterType((*ApproveChaincodeDefinitionForMyOrgResult)(nil), "lifecycle.ApproveChaincodeDefinitionForMyOrgResult")
+	proto.RegisterType((*CommitChaincodeDefinitionArgs)(nil), "lifecycle.CommitChaincodeDefinitionArgs")
+	proto.RegisterType((*CommitChaincodeDefinitionResult)(nil), "lifecycle.CommitChaincodeDefinitionResult")
+	proto.RegisterType((*QueryChaincodeDefinitionArgs)(nil), "lifecycle.QueryChaincodeDefinitionArgs")
+	proto.RegisterType((*QueryChaincodeDefinitionResult)(nil), "lifecycle.QueryChaincodeDefinitionResult")
+	proto.RegisterType((*QueryInstalledChaincodesArgs)(nil), "lifecycle.QueryInstalledChaincodesArgs")
+	proto.RegisterType((*QueryInstalledChaincodesResult)(nil), "lifecycle.QueryInstalledChaincodesResult")
+	proto.RegisterType((*QueryInstalledChaincodesResult_InstalledChaincode)(nil), "lifecycle.QueryInstalledChaincodesResult.InstalledChaincode")
+	proto.RegisterType((*GetInstalledChaincodePackageArgs)(nil), "lifecycle.GetInstalledChaincodePackageArgs")
+	proto.RegisterType((*GetInstalledChaincodePackageResult)(nil), "lifecycle.GetInstalledChaincodePackageResult")
+	proto.RegisterType((*CheckCommitReadinessArgs)(nil), "lifecycle.CheckCommitReadinessArgs")
+	proto.RegisterType((*CheckCommitReadinessResult)(nil), "lifecycle.CheckCommitReadinessResult")
+	proto.RegisterType((*OrgApproval)(nil), "lifecycle.OrgApproval")
 }
 
 func init() {
@@ -214,21 +909,46 @@ func init() {
 }
 
 var fileDescriptor_lifecycle_f98901bea638af10 = []byte{
-	// 255 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0x91, 0x3f, 0x4f, 0xc3, 0x30,
-	0x10, 0xc5, 0x15, 0x40, 0xa0, 0x9e, 0x3a, 0x59, 0x08, 0x82, 0x10, 0xa8, 0xca, 0xd4, 0xa1, 0xb2,
-	0x87, 0x6e, 0x6c, 0xc0, 0x84, 0x58, 0x20, 0x23, 0x4b, 0xe5, 0x38, 0x57, 0xdb, 0xc2, 0x8d, 0xa3,
-	0x73, 0x82, 0x94, 0x8d, 0x8f, 0x8e, 0x6a, 0xb7, 0xe1, 0x8f, 0xd4, 0x89, 0xed, 0xf9, 0xd9, 0xef,
-	0xfc, 0xd3, 0x3b, 0xb8, 0x6d, 0x11, 0x49, 0x38, 0xbb, 0x46, 0x35, 0x28, 0x87, 0xdf, 0x8a, 0xb7,
-	0xe4, 0x3b, 0xcf, 0x26, 0xa3, 0x51, 0x7c, 0x66, 0x70, 0xfe, 0xd4, 0x84, 0x4e, 0x3a, 0xf7, 0x68,
-	0xa4, 0x6d, 0x94, 0xaf, 0xf1, 0x9e, 0x74, 0x60, 0x0c, 0x4e, 0x1a, 0xb9, 0xc1, 0x3c, 0x9b, 0x65,
-	0xf3, 0x49, 0x19, 0x35, 0xcb, 0xe1, 0xec, 0x03, 0x29, 0x58, 0xdf, 0xe4, 0x47, 0xd1, 0xde, 0x1f,
-	0xd9, 0x1d, 0x5c, 0xa9, 0x7d, 0x7c, 0x65, 0xd3, 0xbc, 0x55, 0x2b, 0xd5, 0xbb, 0xd4, 0x98, 0x1f,
-	0xcf, 0xb2, 0xf9, 0xb4, 0xbc, 0x1c, 0x1f, 0xec, 0xfe, 0x7b, 0x49, 0xd7, 0xc5, 0x02, 0x2e, 0xfe,
-	0x12, 0x94, 0x18, 0x7a, 0xd7, 0x6d, 0x19, 0x8c, 0x0c, 0x26, 0x32, 0x4c, 0xcb, 0xa8, 0x8b, 0x67,
-	0xb8, 0x7e, 0xed, 0x91, 0x86, 0x5d, 0x04, 0xeb, 0x7f, 0x60, 0x17, 0x4b, 0xb8, 0x39, 0x30, 0xec,
-	0x30, 0xc1, 0x83, 0x82, 0x85, 0x27, 0xcd, 0xcd, 0xd0, 0x22, 0x39, 0xac, 0x35, 0x12, 0x5f, 0xcb,
-	0x8a, 0xac, 0x4a, 0xed, 0x06, 0xbe, 0x6d, 0x9f, 0x8f, 0x15, 0xbf, 0x2d, 0xb5, 0xed, 0x4c, 0x5f,
-	0x71, 0xe5, 0x37, 0xe2, 0x47, 0x48, 0xa4, 0x90, 0x48, 0x21, 0xf1, 0x7b, 0x65, 0xd5, 0x69, 0xb4,
-	0x97, 0x5f, 0x01, 0x00, 0x00, 0xff, 0xff, 0xd3, 0x6e, 0xec, 0xb6, 0xcb, 0x01, 0x00, 0x00,
+	// 653 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x55, 0x4b, 0x6f, 0xd3, 0x4a,
+	0x14, 0x96, 0x9b, 0xb6, 0xb7, 0x39, 0xb9, 0x8b, 0xdb, 0x69, 0x6e, 0x6b, 0x42, 0x9b, 0x46, 0x5e,
+	0xa0, 0x88, 0x87, 0x23, 0x52, 0x56, 0xa8, 0x42, 0x84, 0x20, 0x50, 0x16, 0x15, 0xc5, 0x42, 0x42,
+	0x62, 0x13, 0x26, 0xf6, 0x89, 0x33, 0xaa, 0x3d, 0x63, 0x66, 0x9c, 0x4a, 0x59, 0xf1, 0x43, 0x10,
+	0xff, 0x8c, 0x1f, 0x83, 0x6c, 0x4f, 0x6c, 0x37, 0x8f, 0xf2, 0x88, 0xba, 0x9b, 0x99, 0xef, 0x3b,
+	0xdf, 0x9c, 0x39, 0xaf, 0x81, 0x66, 0x84, 0x28, 0x3b, 0x01, 0x1b, 0xa3, 0x3b, 0x73, 0x03, 0x2c,
+	0x56, 0x76, 0x24, 0x45, 0x2c, 0x48, 0x35, 0x3f, 0x68, 0x1c, 0xb9, 0x22, 0x0c, 0x05, 0xef, 0xb8,
+	0x22, 0x08, 0xd0, 0x8d, 0x99, 0xe0, 0x19, 0xc7, 0x72, 0xa0, 0x3e, 0xe0, 0x2a, 0xa6, 0x41, 0xd0,
+	0x9f, 0x50, 0xc6, 0x5d, 0xe1, 0x61, 0x4f, 0xfa, 0x8a, 0x3c, 0x87, 0x7b, 0xee, 0xfc, 0x60, 0xc8,
+	0x32, 0xc6, 0x30, 0xa2, 0xee, 0x15, 0xf5, 0xd1, 0x34, 0x5a, 0x46, 0xfb, 0x5f, 0xe7, 0x28, 0x27,
+	0x68, 0x85, 0xcb, 0x0c, 0xb6, 0x2e, 0xe0, 0x70, 0x51, 0xd3, 0x41, 0x35, 0x0d, 0x62, 0x72, 0x02,
+	0xa0, 0x35, 0x86, 0xcc, 0x4b, 0x65, 0xaa, 0x4e, 0x55, 0x9f, 0x0c, 0x3c, 0x52, 0x87, 0x9d, 0x80,
+	0x8e, 0x30, 0x30, 0xb7, 0x52, 0x24, 0xdb, 0x58, 0xe7, 0x70, 0xff, 0xfd, 0x14, 0xe5, 0x4c, 0x6b,
+	0xa2, 0x77, 0xd3, 0xd3, 0xdb, 0x35, 0xad, 0x0f, 0x70, 0xb2, 0xc6, 0x7a, 0x13, 0x9f, 0xbe, 0x6f,
+	0xc1, 0x41, 0x2e, 0xf4, 0x1a, 0xc7, 0x8c, 0xb3, 0x24, 0xa8, 0xa4, 0x01, 0x7b, 0x0a, 0xbf, 0x4c,
+	0x91, 0xbb, 0x59, 0x94, 0x2a, 0x4e, 0xbe, 0x27, 0x26, 0xfc, 0x73, 0x8d, 0x52, 0x31, 0xc1, 0xb5,
+	0xd6, 0x7c, 0x4b, 0x9e, 0x00, 0x41, 0xee, 0x09, 0xa9, 0x30, 0x44, 0x1e, 0x0f, 0xa3, 0x60, 0xea,
+	0x33, 0x6e, 0x56, 0x52, 0xd2, 0x7e, 0x09, 0xb9, 0x4c, 0x01, 0xf2, 0x08, 0xf6, 0xaf, 0x69, 0xc0,
+	0x3c, 0x9a, 0x5c, 0x39, 0x67, 0x6f, 0xa7, 0xec, 0xff, 0x0a, 0x40, 0x93, 0x9f, 0x42, 0xbd, 0x4c,
+	0xa6, 0x92, 0x86, 0x18, 0xa3, 0x34, 0x77, 0xd2, 0x1c, 0x1e, 0x94, 0xf8, 0x73, 0x88, 0xf4, 0xa0,
+	0x56, 0xd4, 0x89, 0x32, 0x77, 0x5b, 0x46, 0xbb, 0xd6, 0x3d, 0xb5, 0xb3, 0x12, 0xb2, 0xfb, 0x39,
+	0xd4, 0x17, 0x7c, 0xcc, 0x7c, 0x9d, 0x75, 0xa7, 0x6c, 0x63, 0x7d, 0x33, 0xe0, 0x41, 0x2f, 0x8a,
+	0xa4, 0xb8, 0xc6, 0x15, 0x61, 0x7a, 0x23, 0xe4, 0xc5, 0xec, 0x9d, 0xf4, 0xd3, 0xfc, 0x11, 0xd8,
+	0xe6, 0x34, 0x44, 0x1d, 0xf9, 0x74, 0x4d, 0x5e, 0x00, 0x78, 0x39, 0x3b, 0x8d, 0x56, 0xad, 0xdb,
+	0xb4, 0x8b, 0xfa, 0x5e, 0xa1, 0xe9, 0x94, 0x2c, 0x16, 0x72, 0x5a, 0x59, 0xac, 0x89, 0x87, 0xd0,
+	0xfe, 0xb5, 0x73, 0x59, 0x79, 0x58, 0x0a, 0x4e, 0xfa, 0x22, 0x0c, 0x59, 0xbc, 0x82, 0x7a, 0x57,
+	0xfe, 0x5b, 0x1f, 0xe1, 0x74, 0xed, 0xa5, 0xba, 0x6c, 0x9f, 0x41, 0x95, 0xa6, 0x6f, 0xa0, 0x81,
+	0x32, 0x8d, 0x56, 0xa5, 0x5d, 0xeb, 0x1e, 0x96, 0x6e, 0x48, 0xa2, 0xab, 0x61, 0xa7, 0x20, 0x5a,
+	0x5d, 0x38, 0x4e, 0xbb, 0xe1, 0x0f, 0x1e, 0x63, 0x7d, 0x85, 0xe6, 0x3a, 0x1b, 0xed, 0xcb, 0xcd,
+	0xe7, 0x1a, 0x1b, 0xa6, 0x6b, 0x6b, 0x31, 0x5d, 0x4d, 0x38, 0x5e, 0xd3, 0xc2, 0x2a, 0x71, 0xda,
+	0xfa, 0x61, 0x40, 0x73, 0x1d, 0x41, 0x7b, 0x28, 0xa0, 0xce, 0xe6, 0xe0, 0x30, 0x9f, 0x5b, 0xf3,
+	0xc0, 0x9d, 0x97, 0x7c, 0xbd, 0x5d, 0xc8, 0x5e, 0x46, 0x9c, 0x03, 0xb6, 0xcc, 0x6e, 0x0c, 0x80,
+	0x2c, 0x53, 0xff, 0x6e, 0xd6, 0xf4, 0xa0, 0xf5, 0x16, 0xe3, 0x65, 0x35, 0xdd, 0x78, 0xbf, 0x33,
+	0x04, 0x3f, 0x83, 0x75, 0x9b, 0x84, 0x0e, 0xd2, 0x26, 0x33, 0x9f, 0x83, 0xd9, 0x9f, 0xa0, 0x7b,
+	0x95, 0x95, 0xad, 0x83, 0xd4, 0x63, 0x1c, 0x95, 0xba, 0xb3, 0x0e, 0x71, 0xa0, 0xb1, 0xea, 0xbe,
+	0x8d, 0x9a, 0xe3, 0x25, 0xd4, 0x4a, 0x08, 0xf9, 0x1f, 0x76, 0x43, 0x15, 0x15, 0xf1, 0xdc, 0x09,
+	0x55, 0x34, 0xf0, 0x92, 0x11, 0x9f, 0x99, 0x60, 0x56, 0xaa, 0x7b, 0x4e, 0xbe, 0x7f, 0xe5, 0xc2,
+	0x63, 0x21, 0x7d, 0x7b, 0x32, 0x8b, 0x50, 0x06, 0xe8, 0xf9, 0x28, 0xed, 0x31, 0x1d, 0x49, 0xe6,
+	0x66, 0xbf, 0xad, 0xb2, 0x93, 0x1f, 0xbb, 0x70, 0xe4, 0xd3, 0x99, 0xcf, 0xe2, 0xc9, 0x74, 0x94,
+	0x8c, 0xd6, 0x4e, 0xc9, 0xa8, 0x93, 0x19, 0x75, 0x32, 0xa3, 0xce, 0xcd, 0x6f, 0x7e, 0xb4, 0x9b,
+	0x1e, 0x9f, 0xfd, 0x1c, 0x00, 0x6f, 0xda, 0x20, 0x26, 0xff, 0x07, 0x00, 0x00,
 }
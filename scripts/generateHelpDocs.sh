@@ -21,7 +21,7 @@ cat docs/wrappers/license_postscript.md >> $DOC
 DOC=docs/source/commands/peerchaincode.md
 cat docs/wrappers/peer_chaincode_preamble.md > $DOC
 
-for x in "peer chaincode install" "peer chaincode instantiate" "peer chaincode invoke" "peer chaincode list" "peer chaincode package" "peer chaincode query" "peer chaincode signpackage" "peer chaincode upgrade"; do
+for x in "peer chaincode index" "peer chaincode index drop" "peer chaincode index list" "peer chaincode index rebuild" "peer chaincode install" "peer chaincode instantiate" "peer chaincode invoke" "peer chaincode list" "peer chaincode package" "peer chaincode query" "peer chaincode signpackage" "peer chaincode upgrade"; do
   echo "" >> $DOC
   echo "##" $x >> $DOC
   echo "\`\`\`" >> $DOC
@@ -34,7 +34,7 @@ cat docs/wrappers/peer_chaincode_postscript.md >> $DOC
 DOC=docs/source/commands/peerchannel.md
 cat docs/wrappers/peer_channel_preamble.md > $DOC
 
-for x in "peer channel" "peer channel create" "peer channel fetch" "peer channel getinfo" "peer channel join" "peer channel list" "peer channel signconfigtx" "peer channel update"; do
+for x in "peer channel" "peer channel create" "peer channel fetch" "peer channel generatesnapshot" "peer channel getinfo" "peer channel join" "peer channel joinbysnapshot" "peer channel list" "peer channel signconfigtx" "peer channel update"; do
   echo "" >> $DOC
   echo "##" $x >> $DOC
   echo "\`\`\`" >> $DOC
@@ -57,10 +57,36 @@ for x in "peer logging" "peer logging getlevel" "peer logging revertlevels" "pee
 done
 cat docs/wrappers/peer_logging_postscript.md >> $DOC
 
+DOC=docs/source/commands/peerpvtdata.md
+cat docs/wrappers/peer_pvtdata_preamble.md > $DOC
+
+for x in "peer pvtdata" "peer pvtdata missing" "peer pvtdata reconcile" "peer pvtdata status"; do
+  echo "" >> $DOC
+  echo "##" $x >> $DOC
+  echo "\`\`\`" >> $DOC
+  .build/bin/${x} --help 1>> $DOC 2>/dev/null
+  echo "\`\`\`" >> $DOC
+  echo "" >> $DOC
+done
+cat docs/wrappers/peer_pvtdata_postscript.md >> $DOC
+
+DOC=docs/source/commands/peerlifecycle.md
+cat docs/wrappers/peer_lifecycle_chaincode_preamble.md > $DOC
+
+for x in "peer lifecycle chaincode" "peer lifecycle chaincode package" "peer lifecycle chaincode install" "peer lifecycle chaincode queryinstalled" "peer lifecycle chaincode getinstalledpackage" "peer lifecycle chaincode approveformyorg" "peer lifecycle chaincode checkcommitreadiness" "peer lifecycle chaincode commit" "peer lifecycle chaincode querycommitted"; do
+  echo "" >> $DOC
+  echo "##" $x >> $DOC
+  echo "\`\`\`" >> $DOC
+  .build/bin/${x} --help 1>> $DOC 2>/dev/null
+  echo "\`\`\`" >> $DOC
+  echo "" >> $DOC
+done
+cat docs/wrappers/peer_lifecycle_chaincode_postscript.md >> $DOC
+
 DOC=docs/source/commands/peernode.md
 cat docs/wrappers/peer_node_preamble.md > $DOC
 
-for x in "peer node start" "peer node status"; do
+for x in "peer node start" "peer node status" "peer node reset" "peer node rebuild-dbs" "peer node rollback" "peer node migrate-statedb"; do
   echo "" >> $DOC
   echo "##" $x >> $DOC
   echo "\`\`\`" >> $DOC